@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/bench"
+	"github.com/Mgrdich/TermChess/internal/bot"
+)
+
+// benchPerftRow is a perft result in the -format json report.
+type benchPerftRow struct {
+	Position string  `json:"position"`
+	Depth    int     `json:"depth"`
+	Nodes    uint64  `json:"nodes"`
+	Expected uint64  `json:"expected,omitempty"`
+	OK       bool    `json:"ok"`
+	TimeMs   float64 `json:"time_ms"`
+	NPS      float64 `json:"nps"`
+}
+
+// benchSearchRow is a search iteration in the -format json report.
+type benchSearchRow struct {
+	Position string  `json:"position"`
+	Depth    int     `json:"depth"`
+	Nodes    uint64  `json:"nodes"`
+	TimeMs   float64 `json:"time_ms"`
+	NPS      float64 `json:"nps"`
+	Move     string  `json:"move"`
+}
+
+// benchReport is the -format json report of a benchmark run.
+type benchReport struct {
+	Bot       string           `json:"bot"`
+	Perft     []benchPerftRow  `json:"perft"`
+	PerftNPS  float64          `json:"perft_nps"`
+	Search    []benchSearchRow `json:"search"`
+	SearchNPS float64          `json:"search_nps"`
+}
+
+// runBench handles the "bench" subcommand, which times the move generator
+// (perft) and the bot search over the standard positions, so performance
+// regressions are measurable across commits. It returns 1 if a perft count
+// doesn't match the known one.
+func runBench(args []string) int {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	perftDepth := fs.Int("perft-depth", 4, "Perft depth for each position (0 skips perft)")
+	searchDepth := fs.Int("depth", 4, "Search depth for each position (0 skips the search)")
+	botName := fs.String("bot", "hard", "Built-in bot whose search is timed: medium or hard")
+	format := fs.String("format", "text", "Output format (text, json)")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: termchess bench [flags]")
+		fmt.Fprintln(os.Stderr, "\nTimes the move generator and the bot search over a standard suite of positions.")
+		fmt.Fprintln(os.Stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if *perftDepth < 0 || *perftDepth > 6 {
+		fmt.Fprintln(os.Stderr, "Error: -perft-depth must be 0-6")
+		return 2
+	}
+	if *searchDepth < 0 || *searchDepth > 20 {
+		fmt.Fprintln(os.Stderr, "Error: -depth must be 0-20")
+		return 2
+	}
+	difficulty, err := bot.ParseDifficulty(*botName)
+	if err != nil || difficulty == bot.Easy {
+		fmt.Fprintln(os.Stderr, "Error: -bot must be medium or hard")
+		return 2
+	}
+	jsonOutput := false
+	switch strings.ToLower(*format) {
+	case "text":
+	case "json":
+		jsonOutput = true
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q (use text or json)\n", *format)
+		return 2
+	}
+
+	// Ctrl+C stops the search; perft can only be stopped between positions
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	report := benchReport{Bot: difficulty.String()}
+	var perftNodes, searchNodes uint64
+	var perftTime, searchTime time.Duration
+	failed := false
+
+	for _, pos := range bench.Suite {
+		if *perftDepth == 0 || ctx.Err() != nil {
+			break
+		}
+		r, err := bench.Perft(pos, *perftDepth)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		failed = failed || !r.OK()
+		perftNodes += r.Nodes
+		perftTime += r.Time
+		report.Perft = append(report.Perft, benchPerftRow{
+			Position: r.Position, Depth: r.Depth, Nodes: r.Nodes, Expected: r.Expected, OK: r.OK(),
+			TimeMs: milliseconds(r.Time), NPS: bench.NodesPerSecond(r.Nodes, r.Time),
+		})
+	}
+
+	for _, pos := range bench.Suite {
+		if *searchDepth == 0 || ctx.Err() != nil {
+			break
+		}
+		results, err := bench.Search(ctx, pos, difficulty, *searchDepth)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		for _, r := range results {
+			searchNodes += r.Nodes
+			searchTime += r.Time
+			report.Search = append(report.Search, benchSearchRow{
+				Position: r.Position, Depth: r.Depth, Nodes: r.Nodes, TimeMs: milliseconds(r.Time),
+				NPS: bench.NodesPerSecond(r.Nodes, r.Time), Move: r.Move.String(),
+			})
+		}
+	}
+	report.PerftNPS = bench.NodesPerSecond(perftNodes, perftTime)
+	report.SearchNPS = bench.NodesPerSecond(searchNodes, searchTime)
+
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to write results: %v\n", err)
+			return 1
+		}
+	} else {
+		writeBenchText(os.Stdout, report, perftNodes, perftTime, searchNodes, searchTime)
+	}
+
+	if ctx.Err() != nil {
+		fmt.Fprintln(os.Stderr, "Interrupted: results include finished positions only")
+		return 1
+	}
+	if failed {
+		fmt.Fprintln(os.Stderr, "Error: perft counts don't match the known ones; the move generator is broken")
+		return 1
+	}
+	return 0
+}
+
+// writeBenchText writes the report as aligned tables with totals.
+func writeBenchText(w io.Writer, report benchReport, perftNodes uint64, perftTime time.Duration, searchNodes uint64, searchTime time.Duration) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if len(report.Perft) > 0 {
+		fmt.Fprintln(w, "Perft")
+		fmt.Fprintln(tw, "Position\tDepth\tNodes\tTime\tNodes/s\t\t")
+		for _, r := range report.Perft {
+			check := "ok"
+			if !r.OK {
+				check = fmt.Sprintf("MISMATCH (want %d)", r.Expected)
+			} else if r.Expected == 0 {
+				check = "unchecked"
+			}
+			fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%.0f\t%s\t\n", r.Position, r.Depth, r.Nodes, formatMs(r.TimeMs), r.NPS, check)
+		}
+		fmt.Fprintf(tw, "Total\t\t%d\t%s\t%.0f\t\t\n", perftNodes, perftTime.Round(time.Millisecond), report.PerftNPS)
+		tw.Flush()
+		fmt.Fprintln(w)
+	}
+	if len(report.Search) > 0 {
+		fmt.Fprintf(w, "Search (%s bot)\n", report.Bot)
+		fmt.Fprintln(tw, "Position\tDepth\tNodes\tTime\tNodes/s\tBest move\t")
+		for _, r := range report.Search {
+			fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%.0f\t%s\t\n", r.Position, r.Depth, r.Nodes, formatMs(r.TimeMs), r.NPS, r.Move)
+		}
+		fmt.Fprintf(tw, "Total\t\t%d\t%s\t%.0f\t\t\n", searchNodes, searchTime.Round(time.Millisecond), report.SearchNPS)
+		tw.Flush()
+	}
+}
+
+// milliseconds returns d in milliseconds.
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// formatMs formats a time in milliseconds for the text report.
+func formatMs(ms float64) string {
+	return time.Duration(ms * float64(time.Millisecond)).Round(100 * time.Microsecond).String()
+}
@@ -0,0 +1,358 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/bot"
+	"github.com/Mgrdich/TermChess/internal/bvb"
+	"github.com/Mgrdich/TermChess/internal/config"
+	"github.com/Mgrdich/TermChess/internal/engine"
+	"github.com/Mgrdich/TermChess/internal/spectate"
+	"github.com/Mgrdich/TermChess/internal/ui"
+)
+
+// runBvB handles the "bvb" subcommand, which plays Bot vs Bot games without the TUI.
+// Results are written to stdout (or the -o file) and progress to stderr.
+// It returns the exit code (0 for success, 1 for error, 2 for invalid usage).
+func runBvB(args []string) int {
+	fs := flag.NewFlagSet("bvb", flag.ContinueOnError)
+	white := fs.String("white", "medium", "White bot: easy, medium, hard or the name of a registered bot")
+	black := fs.String("black", "medium", "Black bot: easy, medium, hard or the name of a registered bot")
+	games := fs.Int("games", 10, "Number of games to play")
+	concurrency := fs.Int("concurrency", 0, "Games to run in parallel (0 = based on CPU count)")
+	cpus := fs.Int("cpus", 0, "Maximum CPU cores to use, by running at most that many games at once (0 = all)")
+	seed := fs.Int64("seed", 0, "Seed for the built-in bots' random choices (default: random)")
+	moveTime := fs.Duration("move-time", 0, "Time each built-in bot may think per move, e.g. 500ms (0 = the Bot Move Time setting, or each difficulty's budget)")
+	format := fs.String("format", "pgn", "Output format (pgn, json, csv)")
+	output := fs.String("o", "", "Write results to this file instead of stdout")
+	stream := fs.String("stream", "", "Append each game to this file while it is played (JSONL for .jsonl, PGN otherwise)")
+	openings := fs.String("openings", "", "Start the games in turn from the positions of this FEN/EPD file, one per line")
+	paired := fs.Bool("paired", false, "Play games in pairs, each opening once with each bot as White, and report the pair results")
+	spectateAddr := fs.String("spectate", "", "Serve a live view of the games over HTTP on this address (e.g. :8080)")
+	sprt := fs.Bool("sprt", false, "Stop early once a sequential probability ratio test decides (-games is the maximum)")
+	defaultSPRT := bvb.DefaultSPRTConfig()
+	elo0 := fs.Float64("elo0", defaultSPRT.Elo0, "SPRT: Elo difference of White over Black under H0")
+	elo1 := fs.Float64("elo1", defaultSPRT.Elo1, "SPRT: Elo difference of White over Black under H1")
+	alpha := fs.Float64("alpha", defaultSPRT.Alpha, "SPRT: probability of accepting H1 when H0 is true")
+	beta := fs.Float64("beta", defaultSPRT.Beta, "SPRT: probability of accepting H0 when H1 is true")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: termchess bvb [flags]")
+		fmt.Fprintln(os.Stderr, "\nPlays Bot vs Bot games headless and prints the results.")
+		fmt.Fprintln(os.Stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	// External bots from the config file can play too
+	cfg := config.LoadConfig()
+	registerExternalBots(cfg)
+
+	whiteBot, err := bot.ParseConfig(*white)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: -white: %v\n", err)
+		return 2
+	}
+	blackBot, err := bot.ParseConfig(*black)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: -black: %v\n", err)
+		return 2
+	}
+	if *games < 1 {
+		fmt.Fprintln(os.Stderr, "Error: -games must be at least 1")
+		return 2
+	}
+	if *concurrency < 0 {
+		fmt.Fprintln(os.Stderr, "Error: -concurrency cannot be negative")
+		return 2
+	}
+	if *cpus < 0 {
+		fmt.Fprintln(os.Stderr, "Error: -cpus cannot be negative")
+		return 2
+	}
+	if *moveTime < 0 {
+		fmt.Fprintln(os.Stderr, "Error: -move-time cannot be negative")
+		return 2
+	}
+	if *moveTime == 0 {
+		*moveTime = cfg.BotMoveTime
+	}
+	whiteBot.MoveTime = *moveTime
+	blackBot.MoveTime = *moveTime
+	whiteName, blackName := whiteBot.DisplayName(), blackBot.DisplayName()
+	sprtConfig := bvb.SPRTConfig{Elo0: *elo0, Elo1: *elo1, Alpha: *alpha, Beta: *beta}
+	if *sprt {
+		if err := sprtConfig.Validate(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: -sprt: %v\n", err)
+			return 2
+		}
+	}
+	var openingSuite []*engine.Board
+	if *openings != "" {
+		if openingSuite, err = bvb.LoadOpenings(*openings); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: -openings: %v\n", err)
+			return 2
+		}
+	}
+	writeResults, ok := bvbWriters[strings.ToLower(*format)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q (use pgn, json or csv)\n", *format)
+		return 2
+	}
+
+	manager := bvb.NewSessionManagerFor(whiteBot, blackBot, *games, *concurrency)
+	manager.SetCPULimit(*cpus)
+	seedSet := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "seed" {
+			seedSet = true
+		}
+	})
+	if seedSet {
+		manager.SetSeed(*seed)
+	}
+	if len(openingSuite) > 0 || *paired {
+		manager.SetOpenings(openingSuite, *paired)
+	}
+	if *stream != "" {
+		gameStream, err := ui.OpenGameStream(*stream)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: -stream: %v\n", err)
+			return 1
+		}
+		// Closed by the manager once the games are over
+		manager.SetObserver(gameStream)
+	}
+
+	fmt.Fprintf(os.Stderr, "%s vs %s: %d game(s), concurrency %d, seed %d\n",
+		whiteName, blackName, *games, manager.Concurrency(), manager.Seed())
+	if *spectateAddr != "" {
+		server, err := spectate.Listen(*spectateAddr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: -spectate: %v\n", err)
+			return 1
+		}
+		defer server.Close()
+		stopPublishing := publishBvB(server, manager)
+		defer stopPublishing()
+		fmt.Fprintf(os.Stderr, "Watch live at http://%s/\n", server.Addr())
+	}
+	if *sprt {
+		manager.EnableSPRT(sprtConfig)
+		fmt.Fprintf(os.Stderr, "SPRT: H0 elo %g, H1 elo %g, alpha %g, beta %g\n",
+			sprtConfig.Elo0, sprtConfig.Elo1, sprtConfig.Alpha, sprtConfig.Beta)
+	}
+
+	// Ctrl+C stops the run but still writes the games that finished
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	runErr := manager.RunHeadless(ctx, func(r bvb.GameResult, finished, total int) {
+		fmt.Fprintf(os.Stderr, "[%d/%d] Game %d: %s (%s, %d moves, %s)\n",
+			finished, total, r.GameNumber, r.Winner, r.EndReason, r.MoveCount,
+			r.Duration.Round(time.Millisecond))
+		if status, ok := manager.SPRT(); ok {
+			fmt.Fprintf(os.Stderr, "        %s\n", status)
+		}
+	})
+	if runErr != nil && ctx.Err() == nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", runErr)
+		return 1
+	}
+
+	stats := manager.Stats()
+	fmt.Fprintf(os.Stderr, "%s wins: %d | %s wins: %d | Draws: %d | Avg moves: %.1f\n",
+		whiteName, stats.WhiteWins, blackName, stats.BlackWins, stats.Draws, stats.AvgMoveCount)
+	if p := stats.Pairs; p != nil {
+		fmt.Fprintf(os.Stderr, "Pairs: %s won %d | %s won %d | Split %d | Games won with White: %d, with Black: %d\n",
+			whiteName, p.WhiteWins, blackName, p.BlackWins, p.Draws, p.WinsAsWhite, p.WinsAsBlack)
+	}
+	if status, ok := manager.SPRT(); ok {
+		if status.Decision == bvb.SPRTContinue {
+			fmt.Fprintf(os.Stderr, "SPRT: %s (inconclusive after %d games)\n", status, stats.TotalGames)
+		} else {
+			fmt.Fprintf(os.Stderr, "SPRT: %s\n", status)
+		}
+	}
+
+	out := io.Writer(os.Stdout)
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		defer f.Close()
+		out = f
+	}
+
+	export := manager.ExportStats(whiteName, blackName)
+	if err := writeResults(out, export); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to write results: %v\n", err)
+		return 1
+	}
+
+	if runErr != nil {
+		fmt.Fprintln(os.Stderr, "Interrupted: results include finished games only")
+		return 1
+	}
+	return 0
+}
+
+// publishBvB keeps server up to date with the games of manager until the
+// returned function is called, which publishes the final state.
+func publishBvB(server *spectate.Server, manager *bvb.SessionManager) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(500 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			server.Publish(ui.BvBSnapshot(manager))
+			select {
+			case <-ticker.C:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+		server.Publish(ui.BvBSnapshot(manager))
+	}
+}
+
+// bvbWriters maps each -format value to the function that writes the results.
+var bvbWriters = map[string]func(io.Writer, *bvb.SessionExport) error{
+	"pgn":  writeBvBPGN,
+	"json": writeBvBJSON,
+	"csv":  writeBvBCSV,
+}
+
+// writeBvBJSON writes the session in the same JSON format as the TUI stats export.
+func writeBvBJSON(w io.Writer, export *bvb.SessionExport) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(export)
+}
+
+// writeBvBCSV writes one row per game.
+func writeBvBCSV(w io.Writer, export *bvb.SessionExport) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"game", "white", "black", "result", "termination", "moves", "final_fen"}); err != nil {
+		return err
+	}
+	for _, g := range export.Games {
+		white, black := gamePlayers(export, g)
+		err := cw.Write([]string{
+			strconv.Itoa(g.GameNumber),
+			white,
+			black,
+			g.Result,
+			g.TerminationReason,
+			strconv.Itoa(g.MoveCount),
+			g.FinalFEN,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeBvBPGN writes every game as a PGN record with moves in SAN.
+func writeBvBPGN(w io.Writer, export *bvb.SessionExport) error {
+	date := export.Timestamp.Format("2006.01.02")
+	for _, g := range export.Games {
+		result := pgnResult(g)
+		white, black := gamePlayers(export, g)
+		var b strings.Builder
+		fmt.Fprintf(&b, "[Event \"TermChess Bot vs Bot\"]\n")
+		fmt.Fprintf(&b, "[Site \"TermChess\"]\n")
+		fmt.Fprintf(&b, "[Date \"%s\"]\n", date)
+		fmt.Fprintf(&b, "[Round \"%d\"]\n", g.GameNumber)
+		fmt.Fprintf(&b, "[White \"%s\"]\n", white)
+		fmt.Fprintf(&b, "[Black \"%s\"]\n", black)
+		fmt.Fprintf(&b, "[Result \"%s\"]\n", result)
+		if g.StartFEN != "" {
+			fmt.Fprintf(&b, "[SetUp \"1\"]\n")
+			fmt.Fprintf(&b, "[FEN \"%s\"]\n", g.StartFEN)
+		}
+		fmt.Fprintf(&b, "[Termination \"%s\"]\n\n", g.TerminationReason)
+
+		movetext, err := pgnMovetext(g.StartFEN, g.Moves)
+		if err != nil {
+			return fmt.Errorf("game %d: %w", g.GameNumber, err)
+		}
+		if movetext != "" {
+			b.WriteString(movetext)
+			b.WriteString(" ")
+		}
+		b.WriteString(result)
+		b.WriteString("\n\n")
+
+		if _, err := io.WriteString(w, b.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// gamePlayers returns the names of the bots that played White and Black in g,
+// which are swapped in the games where the bots swapped colors.
+func gamePlayers(export *bvb.SessionExport, g bvb.GameExport) (white, black string) {
+	if g.Reversed {
+		return export.BlackBot, export.WhiteBot
+	}
+	return export.WhiteBot, export.BlackBot
+}
+
+// pgnResult converts an exported game result, which names the winning bot
+// ("White", "Black" or "Draw"), to a PGN result.
+func pgnResult(g bvb.GameExport) string {
+	whiteWon := g.Result == "White"
+	switch {
+	case g.Result == "Draw":
+		return "1/2-1/2"
+	case whiteWon != g.Reversed:
+		return "1-0"
+	default:
+		return "0-1"
+	}
+}
+
+// pgnMovetext converts coordinate-notation moves played from startFEN, or the
+// standard starting position if it is empty, into numbered SAN movetext,
+// e.g. "1. e4 e5 2. Nf3".
+func pgnMovetext(startFEN string, moves []string) (string, error) {
+	start := engine.NewBoard()
+	if startFEN != "" {
+		var err error
+		if start, err = engine.FromFEN(startFEN); err != nil {
+			return "", err
+		}
+	}
+	parsed := make([]engine.Move, len(moves))
+	for i, s := range moves {
+		move, err := engine.ParseMove(s)
+		if err != nil {
+			return "", err
+		}
+		parsed[i] = move
+	}
+	return ui.FormatPGNMovetext(start, parsed)
+}
@@ -8,11 +8,17 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 	"runtime"
 	"strings"
+	"syscall"
 	"time"
 
+	"github.com/Mgrdich/TermChess/internal/bot"
 	"github.com/Mgrdich/TermChess/internal/config"
+	"github.com/Mgrdich/TermChess/internal/engine"
+	"github.com/Mgrdich/TermChess/internal/replay"
+	"github.com/Mgrdich/TermChess/internal/spectate"
 	"github.com/Mgrdich/TermChess/internal/ui"
 	"github.com/Mgrdich/TermChess/internal/updater"
 	"github.com/Mgrdich/TermChess/internal/version"
@@ -20,10 +26,42 @@ import (
 )
 
 func main() {
+	// Subcommands take their own flags and never start the TUI
+	if len(os.Args) > 1 && os.Args[1] == "bvb" {
+		os.Exit(runBvB(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "watch" {
+		os.Exit(runWatch(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "bench" {
+		os.Exit(runBench(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "suite" {
+		os.Exit(runSuite(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "sync" {
+		os.Exit(runSync(os.Args[2:]))
+	}
+
 	// Parse command-line flags first
 	showVersion := flag.Bool("version", false, "Show version information")
 	doUpgrade := flag.Bool("upgrade", false, "Upgrade to latest version (or specify version as argument)")
 	doUninstall := flag.Bool("uninstall", false, "Uninstall TermChess (remove binary and config)")
+	doRollback := flag.Bool("rollback", false, "Go back to the version installed before the last upgrade")
+	fen := flag.String("fen", "", "Start a game from a FEN position")
+	pgnFile := flag.String("pgn", "", "Continue the game in a PGN file")
+	vsBot := flag.String("vs-bot", "", "Play against a bot (easy, medium, hard)")
+	color := flag.String("color", "white", "Your color when playing against a bot (white, black)")
+	variant := flag.String("variant", "", "Chess variant to play (standard, atomic)")
+	spectateAddr := flag.String("spectate", "", "Serve a live view of the games over HTTP on this address (e.g. :8080)")
+	sharePath := flag.String("share", "", "Share the games on this unix socket for 'termchess watch'")
+	profile := flag.String("profile", "", "Use the named config profile, creating it on first save (e.g. streaming)")
+	dataDir := flag.String("data-dir", "", "Keep the config, saved games and game history in this directory")
+	offline := flag.Bool("offline", false, "Make no network requests, such as update checks")
+	debug := flag.Bool("debug", false, "Show the debug panel with the message log and UI state (toggle with ctrl+g)")
+	recordPath := flag.String("record", "", "Record the keys and mouse input of the session to this file")
+	replayPath := flag.String("replay", "", "Play back the session recorded in this file")
+	replaySpeed := flag.Float64("replay-speed", 1, "Speed to play back a recording at (e.g. 2 for twice as fast)")
 	flag.Parse()
 
 	// Handle --version flag (exit before TUI)
@@ -42,29 +80,280 @@ func main() {
 		os.Exit(handleUninstall())
 	}
 
-	// Load configuration from ~/.termchess/config.toml
+	// Handle --rollback flag
+	if *doRollback {
+		os.Exit(handleRollback())
+	}
+
+	// Keep all files in the given directory. Set after --uninstall, which only
+	// removes the default directories
+	if *dataDir != "" {
+		if err := config.SetDataDir(*dataDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: -data-dir: %v\n", err)
+			os.Exit(2)
+		}
+	}
+
+	// Use the profile's config file instead of config.toml
+	if *profile != "" {
+		if err := config.SetProfile(*profile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: -profile: %v\n", err)
+			os.Exit(2)
+		}
+	}
+
+	// Bring in the files changed on other machines, including the config itself
+	if !*offline {
+		autoSync(config.LoadConfig())
+	}
+
+	// Load configuration from config.toml in the config directory, or the profile's file
 	// If the file doesn't exist or cannot be parsed, default values are used
 	cfg := config.LoadConfig()
+	if err := config.CheckConfig(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	registerExternalBots(cfg)
 
 	// Initialize the Bubbletea model with the loaded configuration
 	model := ui.NewModel(cfg)
+	if *offline {
+		model = model.WithOffline()
+	}
+	if *debug {
+		model = model.WithDebug()
+	}
+
+	// Launch straight into a game if any game flags were given
+	opts, startGame, err := startOptionsFromFlags(*fen, *pgnFile, *vsBot, *color, *variant)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+	if startGame {
+		model, err = model.StartGame(opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	} else if recovery, err := config.LoadCrashRecovery(); err == nil && recovery != nil {
+		// Offer to restore the game interrupted by the last crash
+		model = model.WithCrashRecovery(*recovery)
+	} else if *profile == "" {
+		// Let the user pick one of the saved profiles before the main menu
+		if names, _ := config.ListProfiles(); len(names) > 0 {
+			model = model.WithProfilePicker()
+		}
+	}
+
+	// The encrypted saved game and history need the passphrase before anything reads or writes them
+	if cfg.EncryptSaves || config.EncryptedFilesExist() {
+		model = model.WithPassphrasePrompt()
+	}
+
+	// Let the games be watched from a browser while the TUI runs
+	if *spectateAddr != "" {
+		server, err := spectate.Listen(*spectateAddr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: -spectate: %v\n", err)
+			os.Exit(1)
+		}
+		defer server.Close()
+		model = model.WithSpectator(server)
+	}
+
+	// Let other terminals follow the games with "termchess watch"
+	if *sharePath != "" {
+		broadcaster, err := spectate.ListenUnix(*sharePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: -share: %v\n", err)
+			os.Exit(1)
+		}
+		defer broadcaster.Close()
+		model = model.WithSpectator(broadcaster)
+	}
+
+	// Load the session to play back before the TUI takes over the terminal
+	var recording *replay.Recording
+	if *replayPath != "" {
+		if *replaySpeed <= 0 {
+			fmt.Fprintln(os.Stderr, "Error: -replay-speed must be greater than 0")
+			os.Exit(2)
+		}
+		recording, err = replay.Load(*replayPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: -replay: %v\n", err)
+			os.Exit(1)
+		}
+	}
 
 	// Create the Bubbletea program with options:
 	// - WithAltScreen: Use alternate screen buffer for clean TUI experience
 	// - WithMouseCellMotion: Enable mouse support for future interactions
-	p := tea.NewProgram(
-		model,
+	// - WithOutput: Write frames and the UI's own escape sequences one at a time
+	out := ui.NewTerminalOutput(os.Stdout)
+	model = model.WithOutput(out)
+	options := []tea.ProgramOption{
 		tea.WithAltScreen(),       // Use alternate screen buffer
 		tea.WithMouseCellMotion(), // Future: mouse support
-	)
+		tea.WithOutput(out),
+	}
+
+	// Record the input the program receives, played back input included
+	if *recordPath != "" {
+		recorder, err := replay.Create(*recordPath, version.Version)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: -record: %v\n", err)
+			os.Exit(1)
+		}
+		defer func() {
+			if err := recorder.Close(); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: -record: %v\n", err)
+			}
+		}()
+		options = append(options, tea.WithFilter(func(m tea.Model, msg tea.Msg) tea.Msg {
+			// Leave the passphrase out of the recording
+			if model, ok := m.(ui.Model); ok && model.EnteringPassphrase() {
+				return msg
+			}
+			return recorder.Filter(m, msg)
+		}))
+	}
+	p := tea.NewProgram(model, options...)
+
+	// Play back the recorded session while the keyboard keeps working, e.g. to quit early
+	if recording != nil {
+		ctx, stop := context.WithCancel(context.Background())
+		defer stop()
+		go func() { _ = replay.Play(ctx, recording, *replaySpeed, p.Send) }()
+	}
 
-	// Run the program
-	if _, err := p.Run(); err != nil {
+	// Reload the config file on SIGHUP, e.g. after a dotfile manager updated it
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	defer signal.Stop(reload)
+	go func() {
+		for range reload {
+			p.Send(ui.ReloadConfigMsg{})
+		}
+	}()
+
+	// Run the program, putting back the window title it changes on exit
+	ui.SaveTerminalTitle(out)
+	final, err := p.Run()
+	ui.RestoreTerminal(out, final)
+	if !*offline {
+		autoSync(config.LoadConfig())
+	}
+	if errors.Is(err, tea.ErrProgramPanic) {
+		fmt.Fprintf(os.Stderr, "TermChess crashed. A crash report was written to %s\n", model.CrashReport())
+		fmt.Fprintln(os.Stderr, "Please attach it when reporting the crash at https://github.com/Mgrdich/TermChess/issues")
+		os.Exit(1)
+	}
+	if err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
+// registerExternalBots makes the external bots from the config file available in the bot menus.
+// A bot that cannot be registered is reported and skipped. The Syzygy tablebases,
+// if any are configured and found, are probed by the built-in bots and the
+// analysis, and given to UCI bots.
+func registerExternalBots(cfg config.Config) {
+	var uciOpts []bot.EngineOption
+	if cfg.SyzygyPath != "" {
+		tables, err := bot.FindSyzygyTables(cfg.SyzygyPath)
+		switch {
+		case err != nil:
+			fmt.Fprintf(os.Stderr, "Warning: not using Syzygy tablebases: %v\n", err)
+		case tables.WDL == 0:
+			fmt.Fprintf(os.Stderr, "Warning: not using Syzygy tablebases: no tables found in %s\n", cfg.SyzygyPath)
+		default:
+			tb, err := bot.OpenTablebases(cfg.SyzygyPath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: built-in bots not using Syzygy tablebases: %v\n", err)
+			}
+			bot.UseTablebases(tb)
+			uciOpts = append(uciOpts, bot.WithSyzygyPath(cfg.SyzygyPath))
+		}
+	}
+
+	for _, b := range cfg.ExternalBots {
+		var err error
+		switch strings.ToLower(b.Protocol) {
+		case "", "json":
+			err = bot.RegisterSubprocess(b.Name, b.Command, b.Args)
+		case "uci":
+			err = bot.RegisterUCI(b.Name, b.Command, b.Args, uciOpts...)
+		default:
+			err = fmt.Errorf("bot %q has unknown protocol %q", b.Name, b.Protocol)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping external bot: %v\n", err)
+		}
+	}
+}
+
+// startOptionsFromFlags builds the game to launch from the --fen, --pgn, --vs-bot,
+// --color and --variant flags. It reports false if none of them asks for a game.
+func startOptionsFromFlags(fen, pgnFile, vsBot, color, variant string) (ui.StartOptions, bool, error) {
+	colorSet := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "color" {
+			colorSet = true
+		}
+	})
+
+	var opts ui.StartOptions
+	if fen == "" && pgnFile == "" && vsBot == "" && variant == "" && !colorSet {
+		return opts, false, nil
+	}
+	if fen != "" && pgnFile != "" {
+		return opts, false, fmt.Errorf("--fen and --pgn cannot be used together")
+	}
+	if colorSet && vsBot == "" {
+		return opts, false, fmt.Errorf("--color requires --vs-bot")
+	}
+
+	if variant != "" {
+		v, err := engine.ParseVariant(variant)
+		if err != nil {
+			return opts, false, fmt.Errorf("--variant: %w", err)
+		}
+		opts.Variant = v
+	}
+
+	opts.FEN = fen
+	if pgnFile != "" {
+		data, err := os.ReadFile(pgnFile)
+		if err != nil {
+			return opts, false, fmt.Errorf("failed to read PGN file: %w", err)
+		}
+		opts.PGN = string(data)
+	}
+
+	if vsBot != "" {
+		difficulty, err := bot.ParseDifficulty(vsBot)
+		if err != nil {
+			return opts, false, fmt.Errorf("--vs-bot: %w", err)
+		}
+		opts.VsBot = true
+		opts.BotDifficulty = difficulty
+
+		switch strings.ToLower(color) {
+		case "white":
+			opts.Color = engine.White
+		case "black":
+			opts.Color = engine.Black
+		default:
+			return opts, false, fmt.Errorf("--color: unknown color %q (use white or black)", color)
+		}
+	}
+
+	return opts, true, nil
+}
+
 // printVersion prints the version information and exits.
 func printVersion() {
 	fmt.Printf("termchess %s\n", version.Version)
@@ -147,6 +436,10 @@ func handleUpgrade(args []string) int {
 			fmt.Println("Error: Checksum verification failed. The download may be corrupted.")
 			return 1
 		}
+		if errors.Is(err, updater.ErrSignatureInvalid) {
+			fmt.Printf("Error: %v. The release may have been tampered with; nothing was installed.\n", err)
+			return 1
+		}
 		if strings.Contains(err.Error(), "cancelled by user") {
 			fmt.Println("Upgrade cancelled.")
 			return 0
@@ -156,6 +449,9 @@ func handleUpgrade(args []string) int {
 	}
 
 	fmt.Print("Verifying checksum... \u2713\n")
+	if result.Signed {
+		fmt.Print("Verifying signature... \u2713\n")
+	}
 	fmt.Print("Installing... \u2713\n\n")
 
 	if result.IsDowngrade {
@@ -163,10 +459,32 @@ func handleUpgrade(args []string) int {
 	} else {
 		fmt.Printf("\u2713 TermChess upgraded from %s to %s\n", result.PreviousVersion, result.NewVersion)
 	}
+	fmt.Printf("The previous version is kept at %s. Run 'termchess --rollback' to go back to it.\n", result.BackupPath)
 
 	return 0
 }
 
+// handleRollback handles the --rollback flag.
+// It returns the exit code (0 for success, 1 for error).
+func handleRollback() int {
+	path, err := updater.Rollback()
+	if err != nil {
+		if errors.Is(err, updater.ErrNoBackup) {
+			fmt.Println("Nothing to roll back: no earlier version was kept by an upgrade.")
+			return 1
+		}
+		if errors.Is(err, updater.ErrPermissionDenied) {
+			fmt.Println("Error: Permission denied. Try running with sudo:")
+			fmt.Println("  sudo termchess --rollback")
+			return 1
+		}
+		fmt.Printf("Error: %v\n", err)
+		return 1
+	}
+	fmt.Printf("\u2713 Restored the previous version to %s\n", path)
+	return 0
+}
+
 // handleUninstall handles the --uninstall flag.
 // It returns the exit code (0 for success, 1 for error).
 func handleUninstall() int {
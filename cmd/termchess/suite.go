@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/bench"
+	"github.com/Mgrdich/TermChess/internal/bot"
+	"github.com/Mgrdich/TermChess/internal/config"
+)
+
+// suiteRow is a test position in the -format json report.
+type suiteRow struct {
+	ID     string   `json:"id"`
+	Move   string   `json:"move"`
+	Best   []string `json:"best,omitempty"`
+	Avoid  []string `json:"avoid,omitempty"`
+	Solved bool     `json:"solved"`
+	TimeMs float64  `json:"time_ms"`
+}
+
+// suiteReport is the -format json report of a test suite run.
+type suiteReport struct {
+	Bot       string     `json:"bot"`
+	Positions []suiteRow `json:"positions"`
+	Solved    int        `json:"solved"`
+	Unsolved  int        `json:"unsolved"`
+}
+
+// runSuite handles the "suite" subcommand, which scores a bot against an EPD
+// test suite like WAC: each position's bm (or am) operation says which moves
+// solve it. It returns 1 on errors or when interrupted, 2 for invalid usage.
+func runSuite(args []string) int {
+	fs := flag.NewFlagSet("suite", flag.ContinueOnError)
+	botName := fs.String("bot", "hard", "Bot to test: easy, medium, hard or the name of a registered bot")
+	moveTime := fs.Duration("move-time", time.Second, "Time a built-in bot may think per position (0 = its difficulty's budget)")
+	format := fs.String("format", "text", "Output format (text, json)")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: termchess suite [flags] FILE.epd")
+		fmt.Fprintln(os.Stderr, "\nScores a bot against a \"best move\" test suite in EPD, such as WAC.")
+		fmt.Fprintln(os.Stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	// External bots from the config file can be tested too
+	registerExternalBots(config.LoadConfig())
+
+	cfg, err := bot.ParseConfig(*botName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: -bot: %v\n", err)
+		return 2
+	}
+	if *moveTime < 0 {
+		fmt.Fprintln(os.Stderr, "Error: -move-time cannot be negative")
+		return 2
+	}
+	cfg.MoveTime = *moveTime
+	jsonOutput := false
+	switch strings.ToLower(*format) {
+	case "text":
+	case "json":
+		jsonOutput = true
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q (use text or json)\n", *format)
+		return 2
+	}
+	suite, err := bench.LoadTestSuite(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	report := suiteReport{Bot: cfg.DisplayName()}
+	for i, pos := range suite {
+		if ctx.Err() != nil {
+			break
+		}
+		r, err := bench.Solve(ctx, pos, cfg)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Fprintf(os.Stderr, "[%d/%d] %s: %s\n", i+1, len(suite), r.ID, r.Move)
+		if r.Solved {
+			report.Solved++
+		} else {
+			report.Unsolved++
+		}
+		report.Positions = append(report.Positions, suiteRow{
+			ID: r.ID, Move: r.Move, Best: r.Best, Avoid: r.Avoid, Solved: r.Solved, TimeMs: milliseconds(r.Time),
+		})
+	}
+
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to write results: %v\n", err)
+			return 1
+		}
+	} else {
+		writeSuiteText(os.Stdout, report)
+	}
+
+	if ctx.Err() != nil {
+		fmt.Fprintln(os.Stderr, "Interrupted: results include finished positions only")
+		return 1
+	}
+	return 0
+}
+
+// writeSuiteText writes the report as an aligned table with the solved count.
+func writeSuiteText(w io.Writer, report suiteReport) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Test suite (%s)\n", report.Bot)
+	fmt.Fprintln(tw, "Position\tMove\tExpected\tTime\t\t")
+	for _, r := range report.Positions {
+		expected := strings.Join(r.Best, " ")
+		if len(r.Avoid) > 0 {
+			expected = strings.TrimSpace(expected + " not " + strings.Join(r.Avoid, " "))
+		}
+		result := "solved"
+		if !r.Solved {
+			result = "UNSOLVED"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t\n", r.ID, r.Move, expected, formatMs(r.TimeMs), result)
+	}
+	tw.Flush()
+
+	total := report.Solved + report.Unsolved
+	percent := 0.0
+	if total > 0 {
+		percent = 100 * float64(report.Solved) / float64(total)
+	}
+	fmt.Fprintf(w, "\nSolved %d/%d (%.1f%%), unsolved %d\n", report.Solved, total, percent, report.Unsolved)
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/cloudsync"
+	"github.com/Mgrdich/TermChess/internal/config"
+)
+
+// runSync handles the "sync" subcommand, which syncs the config, saved game and
+// game history with the backend of the [sync] section of the config file.
+func runSync(args []string) int {
+	fs := flag.NewFlagSet("sync", flag.ContinueOnError)
+	dataDir := fs.String("data-dir", "", "Sync the files in this directory (see termchess -data-dir)")
+	profile := fs.String("profile", "", "Use the sync settings of the named config profile")
+	dryRun := fs.Bool("dry-run", false, "Show what would be synced without syncing")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: termchess sync [flags]")
+		fmt.Fprintln(os.Stderr, "\nSyncs the config, saved game and game history with the S3 bucket or WebDAV")
+		fmt.Fprintln(os.Stderr, "folder set in the [sync] section of the config file.")
+		fmt.Fprintln(os.Stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *dataDir != "" {
+		if err := config.SetDataDir(*dataDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: -data-dir: %v\n", err)
+			return 2
+		}
+	}
+	if *profile != "" {
+		if err := config.SetProfile(*profile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: -profile: %v\n", err)
+			return 2
+		}
+	}
+
+	syncer, err := newSyncer(config.LoadConfig())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if *dryRun {
+		changes, err := syncer.Pending(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		printSyncChanges(changes)
+		return 0
+	}
+
+	changes, err := syncer.Sync(ctx)
+	printSyncChanges(changes)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// newSyncer returns the syncer of TermChess's files with the backend configured in cfg.
+func newSyncer(cfg config.Config) (*cloudsync.Syncer, error) {
+	backend, err := cloudsync.NewBackend(cfg)
+	if err != nil {
+		return nil, err
+	}
+	roots, err := cloudsync.DefaultRoots()
+	if err != nil {
+		return nil, err
+	}
+	statePath, err := config.SyncStatePath()
+	if err != nil {
+		return nil, err
+	}
+	return cloudsync.NewSyncer(backend, roots, statePath), nil
+}
+
+// autoSyncTimeout bounds the automatic sync, so an unreachable backend delays
+// starting or exiting by seconds at most.
+const autoSyncTimeout = 10 * time.Second
+
+// autoSync syncs the files if the config asks for it on start and exit. Errors
+// are reported as warnings: a failed sync shouldn't keep anyone from playing.
+func autoSync(cfg config.Config) {
+	if !cfg.SyncAuto || cfg.SyncBackend == "" {
+		return
+	}
+	syncer, err := newSyncer(cfg)
+	if err == nil {
+		ctx, cancel := context.WithTimeout(context.Background(), autoSyncTimeout)
+		defer cancel()
+		_, err = syncer.Sync(ctx)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: sync failed: %v\n", err)
+	}
+}
+
+// printSyncChanges lists the synced files with what was done with them.
+func printSyncChanges(changes []cloudsync.Change) {
+	if len(changes) == 0 {
+		fmt.Println("Everything is in sync")
+		return
+	}
+	for _, change := range changes {
+		fmt.Printf("%-14s %s\n", change.Action, change.Name)
+	}
+}
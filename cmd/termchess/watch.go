@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Mgrdich/TermChess/internal/config"
+	"github.com/Mgrdich/TermChess/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// runWatch handles the "watch" subcommand, which follows the games of another
+// instance started with --share, read-only, on the board of this terminal.
+func runWatch(args []string) int {
+	if len(args) != 1 || args[0] == "-h" || args[0] == "--help" {
+		fmt.Fprintln(os.Stderr, "Usage: termchess watch <socket>")
+		fmt.Fprintln(os.Stderr, "\nFollow the games of an instance started with --share <socket>.")
+		return 2
+	}
+
+	model, err := ui.NewModel(config.LoadConfig()).Watch(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	p := tea.NewProgram(model, tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,121 @@
+// Package bench measures the speed of the move generator and of the built-in
+// bots' search over a fixed suite of positions, so performance changes to the
+// engine can be compared across commits.
+package bench
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/bot"
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// Position is a benchmark position with its known perft counts.
+type Position struct {
+	Name string
+	FEN  string
+	// Perft holds the known leaf node counts, Perft[i] being the count at depth i+1.
+	Perft []uint64
+}
+
+// Suite is the standard benchmark suite: the well-known perft test positions,
+// which between them cover castling, en passant, promotions and checks.
+var Suite = []Position{
+	{
+		Name:  "Starting position",
+		FEN:   "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+		Perft: []uint64{20, 400, 8902, 197281, 4865609},
+	},
+	{
+		Name:  "Kiwipete",
+		FEN:   "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1",
+		Perft: []uint64{48, 2039, 97862, 4085603},
+	},
+	{
+		Name:  "Position 3",
+		FEN:   "8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1",
+		Perft: []uint64{14, 191, 2812, 43238, 674624},
+	},
+	{
+		Name:  "Position 4",
+		FEN:   "r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1",
+		Perft: []uint64{6, 264, 9467, 422333},
+	},
+	{
+		Name:  "Position 5",
+		FEN:   "rnbq1k1r/pp1Pbppp/2p5/8/2B5/8/PPP1NnPP/RNBQK2R w KQ - 1 8",
+		Perft: []uint64{44, 1486, 62379, 2103487},
+	},
+	{
+		Name:  "Position 6",
+		FEN:   "r4rk1/1pp1qppp/p1np1n2/2b1p1B1/2B1P1b1/P1NP1N2/1PP1QPPP/R4RK1 w - - 0 10",
+		Perft: []uint64{46, 2079, 89890, 3894594},
+	},
+}
+
+// PerftResult is the result of counting the leaf nodes of a position to a depth.
+type PerftResult struct {
+	Position string
+	Depth    int
+	Nodes    uint64
+	// Expected is the known count, or 0 if the suite doesn't know it.
+	Expected uint64
+	Time     time.Duration
+}
+
+// OK reports whether the count matches the known count, if there is one.
+func (r PerftResult) OK() bool {
+	return r.Expected == 0 || r.Nodes == r.Expected
+}
+
+// SearchResult is the result of one iteration of the bot's search of a position.
+type SearchResult struct {
+	Position string
+	bot.DepthStats
+}
+
+// Perft counts the leaf nodes of pos to depth. The count is checked against
+// the known one by PerftResult.OK.
+func Perft(pos Position, depth int) (PerftResult, error) {
+	board, err := engine.FromFEN(pos.FEN)
+	if err != nil {
+		return PerftResult{}, fmt.Errorf("%s: %w", pos.Name, err)
+	}
+
+	start := time.Now()
+	nodes := board.Perft(depth)
+	result := PerftResult{Position: pos.Name, Depth: depth, Nodes: nodes, Time: time.Since(start)}
+	if depth <= len(pos.Perft) {
+		result.Expected = pos.Perft[depth-1]
+	}
+	return result, nil
+}
+
+// Search searches pos with the built-in bot of the given difficulty, Medium or
+// Hard, to each depth from 1 to maxDepth.
+func Search(ctx context.Context, pos Position, difficulty bot.Difficulty, maxDepth int) ([]SearchResult, error) {
+	board, err := engine.FromFEN(pos.FEN)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", pos.Name, err)
+	}
+
+	stats, err := bot.SearchDepths(ctx, board, difficulty, maxDepth)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", pos.Name, err)
+	}
+	results := make([]SearchResult, len(stats))
+	for i, s := range stats {
+		results[i] = SearchResult{Position: pos.Name, DepthStats: s}
+	}
+	return results, nil
+}
+
+// NodesPerSecond returns the rate of visiting nodes in d, or 0 if d is 0.
+func NodesPerSecond(nodes uint64, d time.Duration) float64 {
+	if d <= 0 {
+		return 0
+	}
+	return float64(nodes) / d.Seconds()
+}
@@ -0,0 +1,57 @@
+package bench
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/bot"
+)
+
+// TestSuitePerft checks the shallow known counts of every suite position
+func TestSuitePerft(t *testing.T) {
+	for _, pos := range Suite {
+		result, err := Perft(pos, 2)
+		if err != nil {
+			t.Fatalf("Perft(%s) error = %v", pos.Name, err)
+		}
+		if !result.OK() || result.Expected == 0 {
+			t.Errorf("%s: perft(2) = %d, want %d", pos.Name, result.Nodes, result.Expected)
+		}
+	}
+}
+
+func TestPerftBeyondKnownDepth(t *testing.T) {
+	pos := Position{Name: "Short", FEN: Suite[0].FEN, Perft: []uint64{20}}
+	result, err := Perft(pos, 2)
+	if err != nil {
+		t.Fatalf("Perft() error = %v", err)
+	}
+	if result.Expected != 0 || !result.OK() {
+		t.Errorf("Perft() = %+v, want an unchecked count", result)
+	}
+
+	pos.Perft = []uint64{20, 401}
+	if result, _ := Perft(pos, 2); result.OK() {
+		t.Error("OK() = true for a count that doesn't match the known one")
+	}
+}
+
+func TestSearch(t *testing.T) {
+	results, err := Search(context.Background(), Suite[2], bot.Medium, 2)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(results) != 2 || results[1].Depth != 2 || results[1].Position != Suite[2].Name {
+		t.Errorf("Search() = %+v, want depths 1 and 2 of %s", results, Suite[2].Name)
+	}
+}
+
+func TestNodesPerSecond(t *testing.T) {
+	if got := NodesPerSecond(500, 250*time.Millisecond); got != 2000 {
+		t.Errorf("NodesPerSecond() = %v, want 2000", got)
+	}
+	if got := NodesPerSecond(500, 0); got != 0 {
+		t.Errorf("NodesPerSecond() = %v, want 0 for no time", got)
+	}
+}
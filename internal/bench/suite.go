@@ -0,0 +1,147 @@
+package bench
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/bot"
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// TestPosition is a position of a test suite like WAC ("Win At Chess"), with
+// the moves that solve it or the moves that fail it.
+type TestPosition struct {
+	// ID is the record's id, or its line number if it has none.
+	ID    string
+	Board *engine.Board
+	// Best are the moves of the bm operation: playing any of them solves the
+	// position.
+	Best []engine.Move
+	// Avoid are the moves of the am operation: playing none of them solves the
+	// position.
+	Avoid []engine.Move
+}
+
+// Solved reports whether playing move solves p.
+func (p TestPosition) Solved(move engine.Move) bool {
+	for _, m := range p.Avoid {
+		if m == move {
+			return false
+		}
+	}
+	if len(p.Best) == 0 {
+		return true
+	}
+	for _, m := range p.Best {
+		if m == move {
+			return true
+		}
+	}
+	return false
+}
+
+// TestResult is the bot's answer to a test position.
+type TestResult struct {
+	ID string
+	// Move is the move the bot played, in SAN.
+	Move string
+	// Best and Avoid are the moves of the position in SAN.
+	Best, Avoid []string
+	Solved      bool
+	Time        time.Duration
+}
+
+// LoadTestSuite reads a test suite from path, see ParseTestSuite.
+func LoadTestSuite(path string) ([]TestPosition, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseTestSuite(f)
+}
+
+// ParseTestSuite reads a test suite in EPD, one record per line. Every record
+// needs a bm or am operation to be scored by; blank lines and lines starting
+// with '#' are skipped.
+func ParseTestSuite(r io.Reader) ([]TestPosition, error) {
+	var suite []TestPosition
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		record, err := engine.ParseEPD(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		pos := TestPosition{ID: record.ID(), Board: record.Board}
+		if pos.ID == "" {
+			pos.ID = fmt.Sprintf("line %d", line)
+		}
+		if pos.Best, err = record.Moves("bm"); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if pos.Avoid, err = record.Moves("am"); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if len(pos.Best) == 0 && len(pos.Avoid) == 0 {
+			return nil, fmt.Errorf("line %d: no bm or am operation to score the position by", line)
+		}
+		suite = append(suite, pos)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(suite) == 0 {
+		return nil, fmt.Errorf("no positions found")
+	}
+	return suite, nil
+}
+
+// Solve asks the bot of cfg for its move in pos and scores it. The bot gets its
+// usual time per move, or cfg.MoveTime if it is set.
+func Solve(ctx context.Context, pos TestPosition, cfg bot.Config) (TestResult, error) {
+	e, err := cfg.NewEngine(bot.WithDeterministic(true))
+	if err != nil {
+		return TestResult{}, err
+	}
+	defer e.Close()
+	if timeout := cfg.MoveTimeout(); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	board := pos.Board.Copy()
+	start := time.Now()
+	move, err := e.SelectMove(ctx, board)
+	if err != nil {
+		return TestResult{}, fmt.Errorf("%s: %w", pos.ID, err)
+	}
+	return TestResult{
+		ID:     pos.ID,
+		Move:   engine.FormatSAN(pos.Board, move),
+		Best:   formatMoves(pos.Board, pos.Best),
+		Avoid:  formatMoves(pos.Board, pos.Avoid),
+		Solved: pos.Solved(move),
+		Time:   time.Since(start),
+	}, nil
+}
+
+// formatMoves returns moves played in board in SAN.
+func formatMoves(board *engine.Board, moves []engine.Move) []string {
+	sans := make([]string, len(moves))
+	for i, m := range moves {
+		sans[i] = engine.FormatSAN(board, m)
+	}
+	return sans
+}
@@ -0,0 +1,72 @@
+package bench
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/bot"
+)
+
+func TestParseTestSuite(t *testing.T) {
+	suite, err := ParseTestSuite(strings.NewReader(`# Two test positions
+6k1/5ppp/8/8/8/8/8/R5K1 w - - bm Ra8#; id "back rank";
+
+4k3/8/8/8/8/8/8/R3K3 w - - am Kd2 Kf2;
+`))
+	if err != nil {
+		t.Fatalf("ParseTestSuite() error = %v", err)
+	}
+	if len(suite) != 2 {
+		t.Fatalf("Expected 2 positions, got %d", len(suite))
+	}
+	if suite[0].ID != "back rank" || len(suite[0].Best) != 1 {
+		t.Errorf("First position = %+v, want id \"back rank\" with one best move", suite[0])
+	}
+	if suite[1].ID != "line 4" || len(suite[1].Avoid) != 2 {
+		t.Errorf("Second position = %+v, want id \"line 4\" with two moves to avoid", suite[1])
+	}
+
+	if !suite[0].Solved(suite[0].Best[0]) {
+		t.Error("Expected the best move to solve the position")
+	}
+	if suite[1].Solved(suite[1].Avoid[0]) {
+		t.Error("Expected a move to avoid not to solve the position")
+	}
+}
+
+func TestParseTestSuiteErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		suite string
+		want  string
+	}{
+		{"empty", "# nothing\n", "no positions"},
+		{"no operation", "4k3/8/8/8/8/8/8/4K3 w - - id \"x\";\n", "line 1: no bm or am"},
+		{"illegal best move", "4k3/8/8/8/8/8/8/4K3 w - - bm Qh5;\n", "line 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseTestSuite(strings.NewReader(tt.suite))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseTestSuite() error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSolve(t *testing.T) {
+	suite, err := ParseTestSuite(strings.NewReader("6k1/5ppp/8/8/8/8/8/R5K1 w - - bm Ra8#; id \"back rank\";\n"))
+	if err != nil {
+		t.Fatalf("ParseTestSuite() error = %v", err)
+	}
+	cfg := bot.Config{Difficulty: bot.Medium, MoveTime: 200 * time.Millisecond}
+	result, err := Solve(context.Background(), suite[0], cfg)
+	if err != nil {
+		t.Fatalf("Solve() error = %v", err)
+	}
+	if !result.Solved || result.Move != "Ra8#" || result.ID != "back rank" {
+		t.Errorf("Solve() = %+v, want the mate found", result)
+	}
+}
@@ -0,0 +1,366 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// DefaultAnalysisDepth is the search depth used to evaluate each position when analyzing a game.
+const DefaultAnalysisDepth = 3
+
+// analysisTimeLimit caps the time spent evaluating a single position during analysis.
+const analysisTimeLimit = 2 * time.Second
+
+// maxAnalysisScore caps evaluations (in pawns) so that mate scores don't dominate
+// centipawn loss and accuracy figures.
+const maxAnalysisScore = 20.0
+
+// Centipawn loss thresholds for classifying moves.
+const (
+	inaccuracyThreshold = 50
+	mistakeThreshold    = 100
+	blunderThreshold    = 300
+)
+
+// MoveClassification grades a move by how much evaluation it gave away.
+type MoveClassification int
+
+const (
+	// MoveGood is a move within 50 centipawns of the engine's best move.
+	MoveGood MoveClassification = iota
+	// MoveInaccuracy loses between 50 and 100 centipawns.
+	MoveInaccuracy
+	// MoveMistake loses between 100 and 300 centipawns.
+	MoveMistake
+	// MoveBlunder loses 300 centipawns or more.
+	MoveBlunder
+)
+
+// String returns a string representation of the classification.
+func (c MoveClassification) String() string {
+	switch c {
+	case MoveGood:
+		return "Good"
+	case MoveInaccuracy:
+		return "Inaccuracy"
+	case MoveMistake:
+		return "Mistake"
+	case MoveBlunder:
+		return "Blunder"
+	default:
+		return "Unknown"
+	}
+}
+
+// Symbol returns the conventional annotation symbol ("?!", "?", "??") for the classification.
+func (c MoveClassification) Symbol() string {
+	switch c {
+	case MoveInaccuracy:
+		return "?!"
+	case MoveMistake:
+		return "?"
+	case MoveBlunder:
+		return "??"
+	default:
+		return ""
+	}
+}
+
+// MoveAnalysis holds the engine's verdict on a single move.
+// Scores are in pawns from the perspective of the player who made the move.
+type MoveAnalysis struct {
+	Ply            int          // 0-based index of the move in the game
+	Color          engine.Color // Player who made the move
+	Move           engine.Move  // Move that was played
+	BestMove       engine.Move  // Engine's preferred move in the same position
+	BestScore      float64      // Evaluation had the best move been played
+	PlayedScore    float64      // Evaluation after the move that was played
+	CentipawnLoss  int          // Evaluation given away by the move (never negative)
+	Classification MoveClassification
+	// Tablebase is the exact result after the move when the Syzygy tables
+	// cover the position, nil otherwise
+	Tablebase *TablebaseResult
+}
+
+// WhiteScore returns the evaluation after the move from White's perspective.
+func (a MoveAnalysis) WhiteScore() float64 {
+	if a.Color == engine.Black {
+		return -a.PlayedScore
+	}
+	return a.PlayedScore
+}
+
+// PlayerSummary aggregates move analyses for one player.
+type PlayerSummary struct {
+	Moves                int
+	AverageCentipawnLoss float64
+	Accuracy             float64 // 0-100, higher is better
+	Inaccuracies         int
+	Mistakes             int
+	Blunders             int
+}
+
+// GameAnalysis is the result of analyzing a complete game.
+type GameAnalysis struct {
+	Moves []MoveAnalysis
+	White PlayerSummary
+	Black PlayerSummary
+}
+
+// AnalyzeGame replays the moves from the start position and evaluates every move
+// with a minimax search of the given depth, flagging inaccuracies, mistakes and
+// blunders and computing an accuracy score for each player.
+// The context can be used to abort a long analysis.
+func AnalyzeGame(ctx context.Context, start *engine.Board, moves []engine.Move, depth int) (*GameAnalysis, error) {
+	me, err := newAnalysisEngine(depth)
+	if err != nil {
+		return nil, err
+	}
+	defer me.Close()
+
+	board := start.Copy()
+	bestMove, bestScore, err := me.evaluatePosition(ctx, board)
+	if err != nil {
+		return nil, err
+	}
+
+	analysis := &GameAnalysis{Moves: make([]MoveAnalysis, 0, len(moves))}
+	for ply, move := range moves {
+		mover := board.ActiveColor
+		if err := board.MakeMove(move); err != nil {
+			return nil, fmt.Errorf("move %d (%s): %w", ply+1, move.String(), err)
+		}
+
+		// The next position is evaluated from the opponent's perspective
+		nextBest, nextScore, err := me.evaluatePosition(ctx, board)
+		if err != nil {
+			return nil, err
+		}
+		playedScore := -nextScore
+
+		loss := 0
+		if move != bestMove {
+			loss = centipawnLoss(bestScore, playedScore)
+		}
+
+		analysis.Moves = append(analysis.Moves, MoveAnalysis{
+			Ply:            ply,
+			Color:          mover,
+			Move:           move,
+			BestMove:       bestMove,
+			BestScore:      bestScore,
+			PlayedScore:    playedScore,
+			CentipawnLoss:  loss,
+			Classification: classifyLoss(loss),
+			Tablebase:      probeAfterMove(me.tablebases, board),
+		})
+
+		bestMove, bestScore = nextBest, nextScore
+	}
+
+	analysis.White = summarize(analysis.Moves, engine.White)
+	analysis.Black = summarize(analysis.Moves, engine.Black)
+	return analysis, nil
+}
+
+// MoveComparison is the engine's verdict on a move compared to another move in
+// the same position. Scores are in pawns from the perspective of the player to move.
+type MoveComparison struct {
+	Score          float64 // Evaluation after the move
+	ReferenceScore float64 // Evaluation after the reference move
+	CentipawnLoss  int     // Evaluation given away compared to the reference move (never negative)
+}
+
+// CompareMoves evaluates move and reference in the position on board with a
+// minimax search of the given depth, e.g. to grade a guess against the move
+// played in a game. The board is not modified.
+func CompareMoves(ctx context.Context, board *engine.Board, move, reference engine.Move, depth int) (*MoveComparison, error) {
+	me, err := newAnalysisEngine(depth)
+	if err != nil {
+		return nil, err
+	}
+	defer me.Close()
+
+	score := func(move engine.Move) (float64, error) {
+		after := board.Copy()
+		if err := after.MakeMove(move); err != nil {
+			return 0, fmt.Errorf("move %s: %w", move.String(), err)
+		}
+		// The next position is evaluated from the opponent's perspective
+		_, score, err := me.evaluatePosition(ctx, after)
+		return -score, err
+	}
+
+	c := &MoveComparison{}
+	if c.ReferenceScore, err = score(reference); err != nil {
+		return nil, err
+	}
+	if move == reference {
+		c.Score = c.ReferenceScore
+		return c, nil
+	}
+	if c.Score, err = score(move); err != nil {
+		return nil, err
+	}
+	c.CentipawnLoss = centipawnLoss(c.ReferenceScore, c.Score)
+	return c, nil
+}
+
+// MoveReview is the engine's verdict on a move as it is played, e.g. for a coach
+// commenting on it. Scores are in pawns from the perspective of the player who
+// made the move.
+type MoveReview struct {
+	BestMove       engine.Move // Engine's preferred move in the same position
+	BestScore      float64     // Evaluation had the best move been played
+	PlayedScore    float64     // Evaluation after the move that was played
+	CentipawnLoss  int         // Evaluation given away by the move (never negative)
+	Classification MoveClassification
+	Reply          engine.Move // Opponent's best reply, the zero Move if the move ends the game
+	// Tablebase is the exact result after the move when the Syzygy tables
+	// cover the position, nil otherwise
+	Tablebase *TablebaseResult
+}
+
+// ReviewMove evaluates move in the position on board with a minimax search of the
+// given depth, comparing it with the engine's best move and finding the
+// opponent's best reply, which shows what the move allows. The board is not
+// modified.
+func ReviewMove(ctx context.Context, board *engine.Board, move engine.Move, depth int) (*MoveReview, error) {
+	me, err := newAnalysisEngine(depth)
+	if err != nil {
+		return nil, err
+	}
+	defer me.Close()
+
+	bestMove, bestScore, err := me.evaluatePosition(ctx, board)
+	if err != nil {
+		return nil, err
+	}
+
+	after := board.Copy()
+	if err := after.MakeMove(move); err != nil {
+		return nil, fmt.Errorf("move %s: %w", move.String(), err)
+	}
+	// The next position is evaluated from the opponent's perspective
+	reply, nextScore, err := me.evaluatePosition(ctx, after)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &MoveReview{
+		BestMove:    bestMove,
+		BestScore:   bestScore,
+		PlayedScore: -nextScore,
+		Reply:       reply,
+		Tablebase:   probeAfterMove(me.tablebases, after),
+	}
+	if move != bestMove {
+		r.CentipawnLoss = centipawnLoss(r.BestScore, r.PlayedScore)
+	}
+	r.Classification = classifyLoss(r.CentipawnLoss)
+	return r, nil
+}
+
+// probeAfterMove returns the tablebase result of the position on board from
+// the perspective of the player who just moved, nil if the tables don't cover it.
+func probeAfterMove(tb *Tablebases, board *engine.Board) *TablebaseResult {
+	result, ok := tb.Probe(board)
+	if !ok {
+		return nil
+	}
+	result = result.Negate()
+	return &result
+}
+
+// newAnalysisEngine returns the deterministic engine used to evaluate positions
+// during analysis, searching to the given depth.
+func newAnalysisEngine(depth int) (*minimaxEngine, error) {
+	eng, err := NewMinimaxEngine(Medium,
+		WithSearchDepth(depth),
+		WithTimeLimit(analysisTimeLimit),
+		WithDeterministic(true),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return eng.(*minimaxEngine), nil
+}
+
+// clampScore limits a score to ±maxAnalysisScore pawns.
+func clampScore(score float64) float64 {
+	return math.Max(-maxAnalysisScore, math.Min(maxAnalysisScore, score))
+}
+
+// centipawnLoss returns how many centipawns the played move gave away relative to the best move.
+func centipawnLoss(best, played float64) int {
+	loss := (clampScore(best) - clampScore(played)) * 100
+	if loss < 0 {
+		return 0
+	}
+	return int(math.Round(loss))
+}
+
+// classifyLoss grades a move by its centipawn loss.
+func classifyLoss(loss int) MoveClassification {
+	switch {
+	case loss >= blunderThreshold:
+		return MoveBlunder
+	case loss >= mistakeThreshold:
+		return MoveMistake
+	case loss >= inaccuracyThreshold:
+		return MoveInaccuracy
+	default:
+		return MoveGood
+	}
+}
+
+// winPercent converts a score in pawns into an expected winning chance (0-100).
+func winPercent(score float64) float64 {
+	cp := clampScore(score) * 100
+	return 50 + 50*(2/(1+math.Exp(-0.00368208*cp))-1)
+}
+
+// moveAccuracy converts the drop in winning chances caused by a move into an accuracy (0-100).
+func moveAccuracy(best, played float64) float64 {
+	drop := math.Max(0, winPercent(best)-winPercent(played))
+	accuracy := 103.1668*math.Exp(-0.04354*drop) - 3.1669
+	return math.Max(0, math.Min(100, accuracy))
+}
+
+// summarize computes the summary statistics for one player's moves.
+func summarize(moves []MoveAnalysis, color engine.Color) PlayerSummary {
+	var s PlayerSummary
+	var totalLoss, totalAccuracy float64
+
+	for _, m := range moves {
+		if m.Color != color {
+			continue
+		}
+		s.Moves++
+		totalLoss += float64(m.CentipawnLoss)
+		if m.CentipawnLoss == 0 {
+			totalAccuracy += 100
+		} else {
+			totalAccuracy += moveAccuracy(m.BestScore, m.PlayedScore)
+		}
+
+		switch m.Classification {
+		case MoveInaccuracy:
+			s.Inaccuracies++
+		case MoveMistake:
+			s.Mistakes++
+		case MoveBlunder:
+			s.Blunders++
+		}
+	}
+
+	if s.Moves > 0 {
+		s.AverageCentipawnLoss = totalLoss / float64(s.Moves)
+		s.Accuracy = totalAccuracy / float64(s.Moves)
+	}
+	return s
+}
@@ -0,0 +1,180 @@
+package bot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// parseMoves converts coordinate notation strings into moves.
+func parseMoves(t *testing.T, moves ...string) []engine.Move {
+	t.Helper()
+	result := make([]engine.Move, 0, len(moves))
+	for _, s := range moves {
+		m, err := engine.ParseMove(s)
+		if err != nil {
+			t.Fatalf("ParseMove(%q) error = %v", s, err)
+		}
+		result = append(result, m)
+	}
+	return result
+}
+
+func TestAnalyzeGame_FlagsBlunder(t *testing.T) {
+	// 1. e4 e5 2. Qh5 Nc6 3. Bc4 Nf6?? 4. Qxf7#
+	moves := parseMoves(t, "e2e4", "e7e5", "d1h5", "b8c6", "f1c4", "g8f6", "h5f7")
+
+	analysis, err := AnalyzeGame(context.Background(), engine.NewBoard(), moves, DefaultAnalysisDepth)
+	if err != nil {
+		t.Fatalf("AnalyzeGame() error = %v", err)
+	}
+
+	if len(analysis.Moves) != len(moves) {
+		t.Fatalf("got %d move analyses, want %d", len(analysis.Moves), len(moves))
+	}
+
+	nf6 := analysis.Moves[5]
+	if nf6.Color != engine.Black {
+		t.Errorf("Nf6 color = %v, want Black", nf6.Color)
+	}
+	if nf6.Classification != MoveBlunder {
+		t.Errorf("Nf6 classified as %s (loss %d), want Blunder", nf6.Classification, nf6.CentipawnLoss)
+	}
+
+	mate := analysis.Moves[6]
+	if mate.CentipawnLoss != 0 {
+		t.Errorf("Qxf7# centipawn loss = %d, want 0", mate.CentipawnLoss)
+	}
+	if mate.WhiteScore() < maxAnalysisScore {
+		t.Errorf("Qxf7# WhiteScore() = %v, want a winning score", mate.WhiteScore())
+	}
+
+	if analysis.Black.Blunders < 1 {
+		t.Errorf("Black blunders = %d, want at least 1", analysis.Black.Blunders)
+	}
+	if analysis.White.Accuracy <= analysis.Black.Accuracy {
+		t.Errorf("White accuracy %.1f should exceed Black accuracy %.1f",
+			analysis.White.Accuracy, analysis.Black.Accuracy)
+	}
+	if analysis.White.Moves != 4 || analysis.Black.Moves != 3 {
+		t.Errorf("move counts = %d/%d, want 4/3", analysis.White.Moves, analysis.Black.Moves)
+	}
+}
+
+func TestAnalyzeGame_IllegalMove(t *testing.T) {
+	moves := parseMoves(t, "e2e4", "e2e4")
+
+	if _, err := AnalyzeGame(context.Background(), engine.NewBoard(), moves, 1); err == nil {
+		t.Error("AnalyzeGame() should fail on an illegal move")
+	}
+}
+
+func TestAnalyzeGame_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	moves := parseMoves(t, "e2e4", "e7e5")
+	if _, err := AnalyzeGame(ctx, engine.NewBoard(), moves, DefaultAnalysisDepth); err == nil {
+		t.Error("AnalyzeGame() should return an error when the context is cancelled")
+	}
+}
+
+func TestCompareMoves(t *testing.T) {
+	// After 1. e4 e5 2. Qh5 Nc6 3. Bc4 Nf6, Qxf7# mates while Qh3 throws the attack away
+	board := engine.NewBoard()
+	for _, move := range parseMoves(t, "e2e4", "e7e5", "d1h5", "b8c6", "f1c4", "g8f6") {
+		if err := board.MakeMove(move); err != nil {
+			t.Fatalf("MakeMove() error = %v", err)
+		}
+	}
+	fen := board.ToFEN()
+	moves := parseMoves(t, "h5h3", "h5f7")
+
+	c, err := CompareMoves(context.Background(), board, moves[0], moves[1], DefaultAnalysisDepth)
+	if err != nil {
+		t.Fatalf("CompareMoves() error = %v", err)
+	}
+	if c.CentipawnLoss < blunderThreshold {
+		t.Errorf("Qh3 centipawn loss = %d, want at least %d", c.CentipawnLoss, blunderThreshold)
+	}
+	if c.ReferenceScore < maxAnalysisScore {
+		t.Errorf("Qxf7# score = %v, want a winning score", c.ReferenceScore)
+	}
+	if board.ToFEN() != fen {
+		t.Error("CompareMoves() modified the board")
+	}
+
+	c, err = CompareMoves(context.Background(), board, moves[1], moves[1], DefaultAnalysisDepth)
+	if err != nil {
+		t.Fatalf("CompareMoves() error = %v", err)
+	}
+	if c.CentipawnLoss != 0 || c.Score != c.ReferenceScore {
+		t.Errorf("comparing a move with itself = %+v, want no loss", c)
+	}
+}
+
+func TestReviewMove(t *testing.T) {
+	// After 1. e4 d5, Qg4 hangs the queen while Nc3 is a fine developing move
+	board := engine.NewBoard()
+	for _, move := range parseMoves(t, "e2e4", "d7d5") {
+		if err := board.MakeMove(move); err != nil {
+			t.Fatalf("MakeMove() error = %v", err)
+		}
+	}
+	fen := board.ToFEN()
+	moves := parseMoves(t, "d1g4", "b1c3")
+
+	r, err := ReviewMove(context.Background(), board, moves[0], DefaultAnalysisDepth)
+	if err != nil {
+		t.Fatalf("ReviewMove() error = %v", err)
+	}
+	if r.Classification != MoveBlunder {
+		t.Errorf("Qg4 classification = %v (loss %d), want Blunder", r.Classification, r.CentipawnLoss)
+	}
+	if r.Reply.To != moves[0].To {
+		t.Errorf("best reply to Qg4 = %s, want the queen captured", r.Reply.String())
+	}
+	if board.ToFEN() != fen {
+		t.Error("ReviewMove() modified the board")
+	}
+
+	r, err = ReviewMove(context.Background(), board, moves[1], DefaultAnalysisDepth)
+	if err != nil {
+		t.Fatalf("ReviewMove() error = %v", err)
+	}
+	if r.Classification != MoveGood {
+		t.Errorf("Nc3 classification = %v (loss %d), want Good", r.Classification, r.CentipawnLoss)
+	}
+}
+
+func TestClassifyLoss(t *testing.T) {
+	tests := []struct {
+		loss int
+		want MoveClassification
+	}{
+		{0, MoveGood},
+		{49, MoveGood},
+		{50, MoveInaccuracy},
+		{100, MoveMistake},
+		{299, MoveMistake},
+		{300, MoveBlunder},
+	}
+
+	for _, tc := range tests {
+		if got := classifyLoss(tc.loss); got != tc.want {
+			t.Errorf("classifyLoss(%d) = %s, want %s", tc.loss, got, tc.want)
+		}
+	}
+}
+
+func TestCentipawnLoss_ClampsMateScores(t *testing.T) {
+	// Missing a mate while staying a queen up costs at most the clamp range
+	if got := centipawnLoss(9995, 9); got != 1100 {
+		t.Errorf("centipawnLoss(mate, 9) = %d, want 1100", got)
+	}
+	// Improving on the best line never counts as a loss
+	if got := centipawnLoss(1, 2); got != 0 {
+		t.Errorf("centipawnLoss(1, 2) = %d, want 0", got)
+	}
+}
@@ -0,0 +1,54 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// DepthStats describes one iteration of iterative deepening: the depth searched,
+// the positions visited and time taken by that iteration, and its best move.
+type DepthStats struct {
+	Depth int
+	Nodes uint64
+	Time  time.Duration
+	Move  engine.Move
+	// Score is in pawns from the side to move's perspective.
+	Score float64
+}
+
+// SearchDepths searches board with a deterministic Medium or Hard bot, one
+// iteration for each depth from 1 to maxDepth without a time limit, and
+// reports every iteration. It is meant for benchmarking the search; cancelling
+// ctx stops it with an error.
+func SearchDepths(ctx context.Context, board *engine.Board, difficulty Difficulty, maxDepth int) ([]DepthStats, error) {
+	if len(board.LegalMoves()) == 0 {
+		return nil, fmt.Errorf("no legal moves available")
+	}
+	e, err := NewMinimaxEngine(difficulty, WithSearchDepth(maxDepth), WithDeterministic(true))
+	if err != nil {
+		return nil, err
+	}
+	defer e.Close()
+	m := e.(*minimaxEngine)
+
+	stats := make([]DepthStats, 0, maxDepth)
+	for depth := 1; depth <= maxDepth; depth++ {
+		nodes := m.nodes
+		start := time.Now()
+		move, score, err := m.searchDepth(ctx, board, depth)
+		if err != nil {
+			return stats, err
+		}
+		stats = append(stats, DepthStats{
+			Depth: depth,
+			Nodes: m.nodes - nodes + 1, // the root
+			Time:  time.Since(start),
+			Move:  move,
+			Score: score,
+		})
+	}
+	return stats, nil
+}
@@ -0,0 +1,51 @@
+package bot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+func TestSearchDepths(t *testing.T) {
+	board := engine.NewBoard()
+
+	stats, err := SearchDepths(context.Background(), board, Medium, 3)
+	if err != nil {
+		t.Fatalf("SearchDepths() error = %v", err)
+	}
+	if len(stats) != 3 {
+		t.Fatalf("SearchDepths() returned %d depths, want 3", len(stats))
+	}
+	for i, s := range stats {
+		if s.Depth != i+1 {
+			t.Errorf("stats[%d].Depth = %d, want %d", i, s.Depth, i+1)
+		}
+		// Every legal root move is searched at least once
+		if s.Nodes <= 20 {
+			t.Errorf("depth %d visited %d nodes, want more than the 20 root moves", s.Depth, s.Nodes)
+		}
+		if s.Move == (engine.Move{}) {
+			t.Errorf("depth %d found no move", s.Depth)
+		}
+	}
+	if stats[2].Nodes <= stats[0].Nodes {
+		t.Errorf("depth 3 visited %d nodes, want more than depth 1's %d", stats[2].Nodes, stats[0].Nodes)
+	}
+
+	// A deterministic search visits the same nodes every time
+	again, err := SearchDepths(context.Background(), board, Medium, 3)
+	if err != nil {
+		t.Fatalf("SearchDepths() error = %v", err)
+	}
+	if again[2].Nodes != stats[2].Nodes || again[2].Move != stats[2].Move {
+		t.Errorf("Repeated search visited %d nodes and chose %s, want %d and %s",
+			again[2].Nodes, again[2].Move, stats[2].Nodes, stats[2].Move)
+	}
+}
+
+func TestSearchDepthsRejectsEasy(t *testing.T) {
+	if _, err := SearchDepths(context.Background(), engine.NewBoard(), Easy, 2); err == nil {
+		t.Error("SearchDepths(Easy) error = nil, want an error since Easy doesn't search")
+	}
+}
@@ -0,0 +1,113 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Config describes a bot to play: a built-in bot of some difficulty, or a
+// registered bot. The game screens, Bot vs Bot sessions and the command line
+// flags all describe their bots with it.
+type Config struct {
+	// Difficulty is the strength of a built-in bot. A registered bot is judged
+	// at this difficulty when it decides whether to accept a draw.
+	Difficulty Difficulty
+	// Backend is the name of the registered bot to play instead of a built-in
+	// one, or "" for the built-in bot of Difficulty.
+	Backend string
+	// MoveTime overrides the time a built-in bot thinks per move; 0 keeps the
+	// budget of its difficulty.
+	MoveTime time.Duration
+	// Name is the name the bot is shown under; "" derives it from the backend
+	// or difficulty.
+	Name string
+}
+
+// ParseDifficulty converts a difficulty name ("easy", "medium", "hard", in any
+// case) to a Difficulty.
+func ParseDifficulty(name string) (Difficulty, error) {
+	switch strings.ToLower(name) {
+	case "easy":
+		return Easy, nil
+	case "medium":
+		return Medium, nil
+	case "hard":
+		return Hard, nil
+	default:
+		return Easy, fmt.Errorf("unknown difficulty %q (use easy, medium or hard)", name)
+	}
+}
+
+// ParseConfig resolves a bot given by name: a built-in difficulty, or a
+// registered bot (ignoring case), which is judged as Medium for draws.
+func ParseConfig(name string) (Config, error) {
+	if diff, err := ParseDifficulty(name); err == nil {
+		return Config{Difficulty: diff}, nil
+	}
+	for _, registered := range Registered() {
+		if strings.EqualFold(registered, name) {
+			return Config{Difficulty: Medium, Backend: registered}, nil
+		}
+	}
+	return Config{}, fmt.Errorf("unknown bot %q (use easy, medium, hard or a registered bot)", name)
+}
+
+// DisplayName returns the name the bot is shown under, e.g. "Medium Bot" or
+// the name of a registered bot.
+func (c Config) DisplayName() string {
+	switch {
+	case c.Name != "":
+		return c.Name
+	case c.Backend != "":
+		return c.Backend
+	default:
+		return c.Difficulty.String() + " Bot"
+	}
+}
+
+// Builtin reports whether c is one of the built-in bots.
+func (c Config) Builtin() bool {
+	return c.Backend == ""
+}
+
+// Factory returns a factory for the registered bot of c, or nil for a built-in bot.
+func (c Config) Factory() Factory {
+	if c.Builtin() {
+		return nil
+	}
+	backend := c.Backend
+	return func() (Engine, error) {
+		return NewRegistered(backend)
+	}
+}
+
+// NewEngine creates the bot described by c. A built-in bot thinks for MoveTime
+// if it is set and gets opts too; a registered bot manages its own time and
+// randomness, so opts don't apply to it.
+func (c Config) NewEngine(opts ...EngineOption) (Engine, error) {
+	if !c.Builtin() {
+		return NewRegistered(c.Backend)
+	}
+	if c.MoveTime > 0 {
+		opts = append([]EngineOption{WithTimeLimit(c.MoveTime)}, opts...)
+	}
+	switch c.Difficulty {
+	case Medium:
+		return NewMinimaxEngine(Medium, opts...)
+	case Hard:
+		return NewMinimaxEngine(Hard, opts...)
+	default:
+		return NewRandomEngine(opts...)
+	}
+}
+
+// MoveTimeout returns how long a move of the bot may take before it is given up
+// on: the budget of a built-in bot plus MoveTimeGrace, or 0 for the default
+// timeout of registered bots, whose budget isn't known.
+func (c Config) MoveTimeout() time.Duration {
+	if !c.Builtin() {
+		return 0
+	}
+	return MoveTime(c.Difficulty, c.MoveTime) + MoveTimeGrace
+}
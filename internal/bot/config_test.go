@@ -0,0 +1,85 @@
+package bot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDifficulty(t *testing.T) {
+	for name, want := range map[string]Difficulty{"easy": Easy, "Medium": Medium, "HARD": Hard} {
+		got, err := ParseDifficulty(name)
+		if err != nil || got != want {
+			t.Errorf("ParseDifficulty(%q) = %v, %v; want %v", name, got, err, want)
+		}
+	}
+	if _, err := ParseDifficulty("grandmaster"); err == nil {
+		t.Error("Expected an error for an unknown difficulty")
+	}
+}
+
+func TestParseConfig(t *testing.T) {
+	Register("Config Bot", func() (Engine, error) { return NewRandomEngine() })
+	defer Unregister("Config Bot")
+
+	cfg, err := ParseConfig("hard")
+	if err != nil || cfg != (Config{Difficulty: Hard}) {
+		t.Errorf("ParseConfig(hard) = %+v, %v", cfg, err)
+	}
+	cfg, err = ParseConfig("config bot")
+	if err != nil || cfg != (Config{Difficulty: Medium, Backend: "Config Bot"}) {
+		t.Errorf("ParseConfig(config bot) = %+v, %v", cfg, err)
+	}
+	if _, err := ParseConfig("nobody"); err == nil {
+		t.Error("Expected an error for an unknown bot")
+	}
+}
+
+func TestConfigDisplayName(t *testing.T) {
+	tests := []struct {
+		cfg  Config
+		want string
+	}{
+		{Config{Difficulty: Medium}, "Medium Bot"},
+		{Config{Difficulty: Medium, Backend: "Stockfish"}, "Stockfish"},
+		{Config{Difficulty: Hard, Name: "Hard"}, "Hard"},
+	}
+	for _, tt := range tests {
+		if got := tt.cfg.DisplayName(); got != tt.want {
+			t.Errorf("%+v.DisplayName() = %q, want %q", tt.cfg, got, tt.want)
+		}
+	}
+}
+
+func TestConfigEngine(t *testing.T) {
+	Register("Config Bot", func() (Engine, error) { return NewRandomEngine() })
+	defer Unregister("Config Bot")
+
+	builtin := Config{Difficulty: Hard, MoveTime: 100 * time.Millisecond}
+	e, err := builtin.NewEngine(WithSeed(1))
+	if err != nil {
+		t.Fatalf("NewEngine() error = %v", err)
+	}
+	defer e.Close()
+	if m, ok := e.(*minimaxEngine); !ok || m.difficulty != Hard {
+		t.Errorf("Expected a Hard minimax engine, got %T", e)
+	}
+	if builtin.Factory() != nil {
+		t.Error("Expected no factory for a built-in bot")
+	}
+	if got, want := builtin.MoveTimeout(), 100*time.Millisecond+MoveTimeGrace; got != want {
+		t.Errorf("MoveTimeout() = %v, want %v", got, want)
+	}
+
+	registered := Config{Difficulty: Medium, Backend: "Config Bot"}
+	if registered.Factory() == nil {
+		t.Fatal("Expected a factory for a registered bot")
+	}
+	e, err = registered.NewEngine()
+	if err != nil {
+		t.Fatalf("NewEngine() error = %v", err)
+	}
+	defer e.Close()
+	if registered.MoveTimeout() != 0 {
+		t.Errorf("Expected no timeout for a registered bot, got %v", registered.MoveTimeout())
+	}
+}
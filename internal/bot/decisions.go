@@ -0,0 +1,131 @@
+package bot
+
+import "github.com/Mgrdich/TermChess/internal/engine"
+
+// drawAcceptMargins is how far ahead (in pawns) a bot may be and still accept
+// a draw offer. Weaker bots are more willing to split the point.
+var drawAcceptMargins = map[Difficulty]float64{
+	Easy:   1.0,
+	Medium: 0.5,
+	Hard:   0.0,
+}
+
+// AcceptsDraw reports whether bot e, of the given difficulty and playing color,
+// accepts a draw offer in the current position. Bots decline when they are
+// clearly ahead and accept otherwise. The position is judged by EvaluatedBalance;
+// e may be nil to judge it by the built-in evaluation.
+func AcceptsDraw(e Engine, board *engine.Board, difficulty Difficulty, color engine.Color) bool {
+	return EvaluatedBalance(e, board, color) <= drawAcceptMargins[difficulty]
+}
+
+// ShouldResign reports whether bot e playing color is hopelessly lost, meaning
+// it is down at least threshold pawns as judged by EvaluatedBalance. e may be nil
+// to judge by the built-in evaluation. A threshold of 0 or less disables resignation.
+func ShouldResign(e Engine, board *engine.Board, color engine.Color, threshold float64) bool {
+	if threshold <= 0 {
+		return false
+	}
+	return EvaluatedBalance(e, board, color) <= -threshold
+}
+
+// HangsPiece reports whether playing move on board leaves one of the mover's pieces
+// (pawns excluded) to be won by the opponent's reply: captured by a cheaper piece,
+// or captured where the mover cannot recapture. Losses no bigger than the material
+// the move itself captures are ignored, so even trades are not flagged.
+// It returns the square of the piece that would be lost. This is a shallow check
+// meant for warning beginners, not a full tactical search.
+func HangsPiece(board *engine.Board, move engine.Move) (engine.Square, bool) {
+	color := board.ActiveColor
+	gain := 0.0
+	if captured := board.PieceAt(move.To); !captured.IsEmpty() {
+		gain = pieceValues[captured.Type()]
+	}
+
+	after := board.Copy()
+	if err := after.MakeMove(move); err != nil || after.IsGameOver() {
+		return 0, false
+	}
+
+	var hung engine.Square
+	worstLoss := gain
+	found := false
+	for _, reply := range after.LegalMoves() {
+		victim := after.PieceAt(reply.To)
+		if victim.IsEmpty() || victim.Color() != color || victim.Type() == engine.Pawn {
+			continue
+		}
+
+		loss := pieceValues[victim.Type()]
+		if canRecapture(after, reply) {
+			loss -= pieceValues[after.PieceAt(reply.From).Type()]
+		}
+		if loss > worstLoss {
+			worstLoss = loss
+			hung = reply.To
+			found = true
+		}
+	}
+	return hung, found
+}
+
+// canRecapture reports whether, after the capture is played, the side that
+// lost the piece can take back on the same square.
+func canRecapture(board *engine.Board, capture engine.Move) bool {
+	next := board.Copy()
+	if err := next.MakeMove(capture); err != nil {
+		return false
+	}
+	for _, move := range next.LegalMoves() {
+		if move.To == capture.To {
+			return true
+		}
+	}
+	return false
+}
+
+// Phase is the stage of a game, judged by the material left on the board.
+type Phase int
+
+const (
+	// PhaseOpening is the start of the game, before much material is traded
+	PhaseOpening Phase = iota
+	// PhaseMiddlegame is everything between the opening and the endgame
+	PhaseMiddlegame
+	// PhaseEndgame is reached once little more than pawns and a few pieces remain
+	PhaseEndgame
+)
+
+// openingMoves is the last full move of the opening, provided most of the
+// material is still on the board.
+const openingMoves = 10
+
+// openingPhase is the lowest computeGamePhase value the opening allows.
+const openingPhase = 0.8
+
+// String returns the name of the phase, e.g. "middlegame".
+func (p Phase) String() string {
+	switch p {
+	case PhaseOpening:
+		return "opening"
+	case PhaseEndgame:
+		return "endgame"
+	default:
+		return "middlegame"
+	}
+}
+
+// GamePhase returns the phase of the game on board. The endgame starts where
+// evaluation switches to endgame play, once the non-pawn material is down to
+// endgameThreshold; the opening lasts openingMoves moves unless pieces are
+// traded off early.
+func GamePhase(board *engine.Board) Phase {
+	phase := computeGamePhase(board)
+	switch {
+	case phase == 0:
+		return PhaseEndgame
+	case board.FullMoveNum <= openingMoves && phase >= openingPhase:
+		return PhaseOpening
+	default:
+		return PhaseMiddlegame
+	}
+}
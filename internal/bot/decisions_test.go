@@ -0,0 +1,136 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+func TestEvaluatedBalanceWithoutEvaluator(t *testing.T) {
+	// Mobility is left out, so an even position is even whoever is on move
+	for _, fen := range []string{
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR b KQkq - 0 1",
+	} {
+		if got := EvaluatedBalance(nil, loadFEN(t, fen), engine.White); got != 0 {
+			t.Errorf("EvaluatedBalance() of the starting position = %v, want 0", got)
+		}
+	}
+
+	// Material is even, but White's pawn is about to promote
+	board := loadFEN(t, "4k3/P7/7p/8/8/8/8/4K3 b - - 0 1")
+	if got := EvaluatedBalance(nil, board, engine.White); got <= 1.0 {
+		t.Errorf("EvaluatedBalance() with a pawn on the 7th = %v, want more than a pawn", got)
+	}
+
+	// Checkmate is decisive
+	board = loadFEN(t, "rnb1kbnr/pppp1ppp/8/4p3/6Pq/5P2/PPPPP2P/RNBQKBNR w KQkq - 1 3")
+	if got := EvaluatedBalance(nil, board, engine.White); got != -10000 {
+		t.Errorf("EvaluatedBalance() when mated = %v, want -10000", got)
+	}
+}
+
+func TestAcceptsDraw(t *testing.T) {
+	tests := []struct {
+		name       string
+		fen        string
+		difficulty Difficulty
+		color      engine.Color
+		want       bool
+	}{
+		{"equal position", "4k3/4p3/8/8/8/8/4P3/4K3 w - - 0 1", Hard, engine.Black, true},
+		{"bot is losing", "4k3/8/8/8/8/8/8/3QK3 w - - 0 1", Medium, engine.Black, true},
+		{"bot is winning", "4k3/8/8/8/8/8/8/3QK3 b - - 0 1", Medium, engine.White, false},
+		{"easy accepts when a rook pawn up", "4k3/8/8/8/8/8/P7/4K3 b - - 0 1", Easy, engine.White, true},
+		{"hard declines when a pawn up", "4k3/8/8/8/8/8/4P3/4K3 b - - 0 1", Hard, engine.White, false},
+		{"declines when about to promote", "4k3/P7/7p/8/8/8/8/4K3 b - - 0 1", Medium, engine.White, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			board := loadFEN(t, tc.fen)
+			e, err := Config{Difficulty: tc.difficulty}.NewEngine()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer e.Close()
+			if got := AcceptsDraw(e, board, tc.difficulty, tc.color); got != tc.want {
+				t.Errorf("AcceptsDraw() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestShouldResign(t *testing.T) {
+	// Black is down a queen and a rook
+	board := loadFEN(t, "4k3/8/8/8/8/8/8/R2QK3 b - - 0 1")
+
+	if !ShouldResign(nil, board, engine.Black, 10.0) {
+		t.Error("ShouldResign() should be true when down 14 pawns with threshold 10")
+	}
+	if ShouldResign(nil, board, engine.Black, 20.0) {
+		t.Error("ShouldResign() should be false below the threshold")
+	}
+	if ShouldResign(nil, board, engine.White, 10.0) {
+		t.Error("ShouldResign() should be false for the winning side")
+	}
+	if ShouldResign(nil, board, engine.Black, 0) {
+		t.Error("ShouldResign() should be false when resignation is disabled")
+	}
+}
+
+func TestHangsPiece(t *testing.T) {
+	tests := []struct {
+		name     string
+		fen      string
+		move     string
+		wantHung bool
+		wantSq   string
+	}{
+		// 1. e4 e5 2. Nf3 Nc6: Bc4 is safe, Nxe5 loses the knight to Nxe5
+		{"safe developing move", "r1bqkbnr/pppp1ppp/2n5/4p3/4P3/5N2/PPPP1PPP/RNBQKB1R w KQkq - 2 3", "f1c4", false, ""},
+		{"knight takes defended pawn", "r1bqkbnr/pppp1ppp/2n5/4p3/4P3/5N2/PPPP1PPP/RNBQKB1R w KQkq - 2 3", "f3e5", true, "e5"},
+		// Queen steps onto a square attacked by a pawn, even though it is defended
+		{"queen attacked by pawn", "4k3/8/4p3/8/8/8/8/3QK3 w - - 0 1", "d1d5", true, "d5"},
+		// Rook trade: rook takes a defended rook and is recaptured
+		{"even trade", "3rk3/3r4/8/8/8/8/8/3RK3 w - - 0 1", "d1d7", false, ""},
+		// Moving the knight leaves the undefended bishop en prise to the rook
+		{"discovered hanging piece", "4k3/4r3/8/8/8/4B3/3N4/6K1 w - - 0 1", "d2f3", true, "e3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board := loadFEN(t, tt.fen)
+			move, err := engine.ParseMove(tt.move)
+			if err != nil {
+				t.Fatalf("ParseMove(%q) error = %v", tt.move, err)
+			}
+			sq, hung := HangsPiece(board, move)
+			if hung != tt.wantHung {
+				t.Fatalf("HangsPiece(%s) = %v, want %v", tt.move, hung, tt.wantHung)
+			}
+			if hung && sq.String() != tt.wantSq {
+				t.Errorf("HangsPiece(%s) square = %s, want %s", tt.move, sq, tt.wantSq)
+			}
+		})
+	}
+}
+
+func TestGamePhase(t *testing.T) {
+	tests := []struct {
+		name string
+		fen  string
+		want Phase
+	}{
+		{"starting position", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", PhaseOpening},
+		{"move 20 with the pieces on", "r1bq1rk1/pp2bppp/2n1pn2/3p4/3P4/2NBPN2/PP3PPP/R2Q1RK1 w - - 0 20", PhaseMiddlegame},
+		{"early queen trade", "rnb1kbnr/ppp2ppp/8/4p3/4P3/8/PPP2PPP/RNB1KBNR w KQkq - 0 5", PhaseMiddlegame},
+		{"rook endgame", "8/5pk1/6p1/8/8/6P1/5PK1/3R1r2 w - - 0 40", PhaseEndgame},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GamePhase(loadFEN(t, tt.fen)); got != tt.want {
+				t.Errorf("GamePhase() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -13,6 +13,8 @@ import (
 type Engine interface {
 	// SelectMove returns the bot's chosen move for the given position.
 	// The context allows cancellation if the bot exceeds time limits.
+	// The board is left as it was, but engines may make and take back moves
+	// on it while searching, so it must not be used concurrently.
 	SelectMove(ctx context.Context, board *engine.Board) (engine.Move, error)
 
 	// Name returns a human-readable name for this engine.
@@ -68,6 +70,13 @@ type Inspectable interface {
 	Info() Info
 }
 
+// Predictor engines can guess the opponent's most likely reply.
+// A prediction is what allows an engine to be wrapped in a Ponderer.
+type Predictor interface {
+	Engine
+	PredictMove(board *engine.Board) (engine.Move, bool)
+}
+
 // EngineType categorizes engine implementations.
 type EngineType int
 
@@ -78,6 +87,8 @@ const (
 	TypeUCI
 	// TypeRL represents RL agents with ONNX models (Phase 6).
 	TypeRL
+	// TypeSubprocess represents external programs speaking the JSON line protocol.
+	TypeSubprocess
 )
 
 // String returns a string representation of the engine type.
@@ -89,6 +100,8 @@ func (t EngineType) String() string {
 		return "UCI"
 	case TypeRL:
 		return "RL"
+	case TypeSubprocess:
+		return "Subprocess"
 	default:
 		return "Unknown"
 	}
@@ -213,6 +213,7 @@ func TestEngineTypeConstants(t *testing.T) {
 		{"Internal", TypeInternal, "Internal"},
 		{"UCI", TypeUCI, "UCI"},
 		{"RL", TypeRL, "RL"},
+		{"Subprocess", TypeSubprocess, "Subprocess"},
 	}
 
 	for _, tt := range tests {
@@ -164,34 +164,47 @@ var passedPawnBonus = [8]float64{
 // evaluate returns a score for the position from White's perspective.
 // Positive = White advantage, Negative = Black advantage
 func evaluate(board *engine.Board, difficulty Difficulty) float64 {
+	score, over := evaluateStanding(board, difficulty)
+
+	// Mobility (Medium+), which only counts the side to move's moves
+	if !over && difficulty >= Medium {
+		score += evaluateMobility(board) * 0.1 // Weight mobility at 10%
+	}
+
+	return score
+}
+
+// evaluateStanding returns evaluate's score without mobility, which favors
+// whoever is on move, so it tells how the game stands for either side.
+// over reports a finished game, scored as won, lost or drawn.
+func evaluateStanding(board *engine.Board, difficulty Difficulty) (score float64, over bool) {
 	// 1. Check terminal states first
 	status := board.Status()
 
-	if status == engine.Checkmate {
+	if status == engine.Checkmate || status == engine.KingExploded {
 		winner, _ := board.Winner()
 		if winner == engine.White {
-			return 10000.0
+			return 10000.0, true
 		}
-		return -10000.0
+		return -10000.0, true
 	}
 
 	if status == engine.Stalemate || status == engine.DrawThreefoldRepetition ||
 		status == engine.DrawFiftyMoveRule || status == engine.DrawInsufficientMaterial ||
 		status == engine.DrawFivefoldRepetition || status == engine.DrawSeventyFiveMoveRule {
-		return 0.0
+		return 0.0, true
 	}
 
 	// 2. Material count (all difficulties)
 	material := countMaterial(board)
-	score := material
+	score = material
 
-	// 3. Piece-square tables, passed pawns, and mobility (Medium+)
+	// 3. Piece-square tables and passed pawns (Medium+)
 	var phase float64
 	if difficulty >= Medium {
 		phase = computeGamePhase(board)
 		score += evaluatePiecePositions(board, phase)
 		score += evaluatePassedPawns(board, phase)
-		score += evaluateMobility(board) * 0.1 // Weight mobility at 10%
 	}
 
 	// 4. King safety and mop-up evaluation (Hard only)
@@ -200,7 +213,7 @@ func evaluate(board *engine.Board, difficulty Difficulty) float64 {
 		score += evaluateMopUp(board, phase, material)
 	}
 
-	return score
+	return score, false
 }
 
 // countMaterial calculates the material balance from White's perspective.
@@ -15,7 +15,11 @@ type engineConfig struct {
 	timeLimit     time.Duration
 	searchDepth   int
 	deterministic bool
+	seed          int64
+	seeded        bool
 	options       map[string]any
+	syzygyPath    string
+	tablebases    *Tablebases
 }
 
 // WithTimeLimit sets a custom time limit for move selection.
@@ -57,11 +61,67 @@ func WithDeterministic(deterministic bool) EngineOption {
 	}
 }
 
+// WithSeed seeds the bot's random choices, so a bot created with the same seed
+// makes the same choices given the same positions. Searches cut short by the
+// time limit can still vary with machine load.
+func WithSeed(seed int64) EngineOption {
+	return func(c *engineConfig) error {
+		c.seed = seed
+		c.seeded = true
+		return nil
+	}
+}
+
+// newRand returns the random source for a bot: seeded from the configuration if
+// a seed was given, from the clock otherwise.
+func (c *engineConfig) newRand() *rand.Rand {
+	if c.seeded {
+		return rand.New(rand.NewSource(c.seed))
+	}
+	return rand.New(rand.NewSource(time.Now().UnixNano()))
+}
+
+// SearchLimits describes how deep and how long a bot may search per move.
+type SearchLimits struct {
+	Depth     int           // Maximum search depth in plies (0 for engines that don't search)
+	TimeLimit time.Duration // Maximum time spent selecting a move
+}
+
+// DefaultSearchLimits returns the default search depth and time limit for a difficulty.
+// Hard searches deeper, for longer, and additionally resolves captures at the leaves.
+// The time limits are the per-move budgets that keep games paced predictably:
+// a search that runs out of time plays the best move found so far. Each budget
+// leaves room to complete its depth in a busy middlegame; depth 6 would take
+// Hard several times longer again.
+func DefaultSearchLimits(difficulty Difficulty) SearchLimits {
+	switch difficulty {
+	case Medium:
+		return SearchLimits{Depth: 4, TimeLimit: 1 * time.Second}
+	case Hard:
+		return SearchLimits{Depth: 5, TimeLimit: 5 * time.Second}
+	default:
+		return SearchLimits{Depth: 0, TimeLimit: 50 * time.Millisecond}
+	}
+}
+
+// MoveTimeGrace is how long a bot may overrun its move-time budget before the
+// caller's deadline cuts it off, leaving time for a search to unwind.
+const MoveTimeGrace = 500 * time.Millisecond
+
+// MoveTime returns the per-move time budget of a difficulty, or override if it
+// is positive.
+func MoveTime(difficulty Difficulty, override time.Duration) time.Duration {
+	if override > 0 {
+		return override
+	}
+	return DefaultSearchLimits(difficulty).TimeLimit
+}
+
 // NewRandomEngine creates an Easy bot with random move selection.
 func NewRandomEngine(opts ...EngineOption) (Engine, error) {
 	cfg := &engineConfig{
 		difficulty: Easy,
-		timeLimit:  2 * time.Second,
+		timeLimit:  DefaultSearchLimits(Easy).TimeLimit,
 	}
 
 	for _, opt := range opts {
@@ -74,22 +134,20 @@ func NewRandomEngine(opts ...EngineOption) (Engine, error) {
 		name:      "Easy Bot",
 		timeLimit: cfg.timeLimit,
 		closed:    0, // atomic: 0 = open
-		rng:       rand.New(rand.NewSource(time.Now().UnixNano())),
+		rng:       cfg.newRand(),
 	}, nil
 }
 
 // NewMinimaxEngine creates a Medium or Hard bot using minimax with alpha-beta pruning.
 func NewMinimaxEngine(difficulty Difficulty, opts ...EngineOption) (Engine, error) {
-	cfg := &engineConfig{difficulty: difficulty}
+	cfg := &engineConfig{difficulty: difficulty, tablebases: defaultTablebases()}
 
 	// Set defaults based on difficulty
 	switch difficulty {
-	case Medium:
-		cfg.timeLimit = 4 * time.Second
-		cfg.searchDepth = 4
-	case Hard:
-		cfg.timeLimit = 8 * time.Second
-		cfg.searchDepth = 7
+	case Medium, Hard:
+		limits := DefaultSearchLimits(difficulty)
+		cfg.timeLimit = limits.TimeLimit
+		cfg.searchDepth = limits.Depth
 	default:
 		return nil, fmt.Errorf("invalid difficulty for minimax: %d (expected Medium or Hard)", difficulty)
 	}
@@ -111,6 +169,9 @@ func NewMinimaxEngine(difficulty Difficulty, opts ...EngineOption) (Engine, erro
 		timeLimit:     cfg.timeLimit,
 		evalWeights:   getDefaultWeights(cfg.difficulty),
 		deterministic: cfg.deterministic,
-		closed:        false,
+		rng:           cfg.newRand(),
+		useQuiescence: cfg.difficulty == Hard,
+		tt:            newTranspositionTable(),
+		tablebases:    cfg.tablebases,
 	}, nil
 }
@@ -1,9 +1,12 @@
 package bot
 
 import (
+	"context"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
 )
 
 // TestWithTimeLimit verifies the WithTimeLimit option.
@@ -302,8 +305,8 @@ func TestNewMinimaxEngine(t *testing.T) {
 		if minimaxEng.maxDepth != 4 {
 			t.Errorf("maxDepth = %d, want 4", minimaxEng.maxDepth)
 		}
-		if minimaxEng.timeLimit != 4*time.Second {
-			t.Errorf("timeLimit = %v, want 4s", minimaxEng.timeLimit)
+		if minimaxEng.timeLimit != 1*time.Second {
+			t.Errorf("timeLimit = %v, want 1s", minimaxEng.timeLimit)
 		}
 
 		// Clean up
@@ -335,11 +338,11 @@ func TestNewMinimaxEngine(t *testing.T) {
 		if minimaxEng.difficulty != Hard {
 			t.Errorf("difficulty = %v, want Hard", minimaxEng.difficulty)
 		}
-		if minimaxEng.maxDepth != 7 {
-			t.Errorf("maxDepth = %d, want 7", minimaxEng.maxDepth)
+		if minimaxEng.maxDepth != 5 {
+			t.Errorf("maxDepth = %d, want 5", minimaxEng.maxDepth)
 		}
-		if minimaxEng.timeLimit != 8*time.Second {
-			t.Errorf("timeLimit = %v, want 8s", minimaxEng.timeLimit)
+		if minimaxEng.timeLimit != 5*time.Second {
+			t.Errorf("timeLimit = %v, want 5s", minimaxEng.timeLimit)
 		}
 
 		// Clean up
@@ -534,6 +537,50 @@ func TestEngineConfigDefaults(t *testing.T) {
 	})
 }
 
+// TestDefaultSearchLimits verifies each difficulty's search depth and time budget.
+func TestDefaultSearchLimits(t *testing.T) {
+	tests := []struct {
+		difficulty Difficulty
+		depth      int
+		timeLimit  time.Duration
+	}{
+		{Easy, 0, 50 * time.Millisecond},
+		{Medium, 4, 1 * time.Second},
+		{Hard, 5, 5 * time.Second},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.difficulty.String(), func(t *testing.T) {
+			limits := DefaultSearchLimits(tc.difficulty)
+			if limits.Depth != tc.depth {
+				t.Errorf("Depth = %d, want %d", limits.Depth, tc.depth)
+			}
+			if limits.TimeLimit != tc.timeLimit {
+				t.Errorf("TimeLimit = %v, want %v", limits.TimeLimit, tc.timeLimit)
+			}
+		})
+	}
+
+	if DefaultSearchLimits(Hard).Depth <= DefaultSearchLimits(Medium).Depth {
+		t.Error("Hard should search deeper than Medium")
+	}
+}
+
+// TestMoveTime verifies the per-move budget falls back to the difficulty's time limit.
+func TestMoveTime(t *testing.T) {
+	for _, d := range []Difficulty{Easy, Medium, Hard} {
+		if got, want := MoveTime(d, 0), DefaultSearchLimits(d).TimeLimit; got != want {
+			t.Errorf("MoveTime(%v, 0) = %v, want %v", d, got, want)
+		}
+		if got := MoveTime(d, 300*time.Millisecond); got != 300*time.Millisecond {
+			t.Errorf("MoveTime(%v, 300ms) = %v, want the override", d, got)
+		}
+	}
+	if MoveTime(Easy, 0) >= MoveTime(Hard, 0) {
+		t.Error("Easy should move faster than Hard")
+	}
+}
+
 // TestEngineOptionChaining verifies options can be chained and applied in order.
 func TestEngineOptionChaining(t *testing.T) {
 	cfg := &engineConfig{}
@@ -580,3 +627,37 @@ func TestEngineOptionOverrides(t *testing.T) {
 		t.Errorf("timeLimit = %v, want 7s (should be overridden)", cfg.timeLimit)
 	}
 }
+
+func TestWithSeedReplaysMoves(t *testing.T) {
+	play := func(seed int64) []engine.Move {
+		e, err := NewRandomEngine(WithSeed(seed))
+		if err != nil {
+			t.Fatalf("NewRandomEngine() error = %v", err)
+		}
+		defer e.Close()
+
+		board := engine.NewBoard()
+		var moves []engine.Move
+		for i := 0; i < 20 && board.Status() == engine.Ongoing; i++ {
+			move, err := e.SelectMove(context.Background(), board)
+			if err != nil {
+				t.Fatalf("SelectMove() error = %v", err)
+			}
+			if err := board.MakeMove(move); err != nil {
+				t.Fatalf("MakeMove(%s) error = %v", move, err)
+			}
+			moves = append(moves, move)
+		}
+		return moves
+	}
+
+	first, second := play(42), play(42)
+	if len(first) != len(second) {
+		t.Fatalf("Expected the same game twice, got %d and %d moves", len(first), len(second))
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("Move %d differs with the same seed: %s vs %s", i+1, first[i], second[i])
+		}
+	}
+}
@@ -6,11 +6,19 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"sort"
+	"sync/atomic"
 	"time"
 
 	"github.com/Mgrdich/TermChess/internal/engine"
 )
 
+// maxKillerPly bounds the number of plies for which killer moves are tracked.
+const maxKillerPly = 64
+
+// maxQuiescenceDepth limits how many plies of captures quiescence search follows.
+const maxQuiescenceDepth = 6
+
 // minimaxEngine implements Medium and Hard bots using minimax with alpha-beta pruning.
 type minimaxEngine struct {
 	name          string
@@ -18,8 +26,14 @@ type minimaxEngine struct {
 	maxDepth      int
 	timeLimit     time.Duration
 	evalWeights   evalWeights
-	deterministic bool // If true, disables random tie-breaking
-	closed        bool
+	deterministic bool       // If true, disables random tie-breaking
+	rng           *rand.Rand // Source of the random tie-breaking
+	useQuiescence bool       // If true, leaf nodes are resolved with quiescence search
+	tt            *transpositionTable
+	killers       [maxKillerPly][2]engine.Move
+	tablebases    *Tablebases // Syzygy tables probed in endgames, nil if none
+	nodes         uint64      // Positions visited by the searches, for benchmarking
+	closed        int32       // atomic: 0 = open, 1 = closed, set while a search may still run
 }
 
 // evalWeights holds the weights for different evaluation components.
@@ -64,9 +78,11 @@ func (e *minimaxEngine) Name() string {
 	return e.name
 }
 
-// Close releases resources held by the engine.
+// Close releases resources held by the engine. A search still running when the
+// engine is closed, e.g. one whose context was just cancelled, may still read
+// the transposition table, so it is left to the garbage collector.
 func (e *minimaxEngine) Close() error {
-	e.closed = true
+	atomic.StoreInt32(&e.closed, 1)
 	return nil
 }
 
@@ -121,13 +137,45 @@ func (e *minimaxEngine) Info() Info {
 			"piece_square_tables": e.difficulty >= Medium,
 			"mobility":            e.difficulty >= Medium,
 			"king_safety":         e.difficulty >= Hard,
+			"transposition_table": true,
+			"killer_moves":        true,
+			"quiescence":          e.useQuiescence,
+			"tablebases":          e.tablebases != nil,
 		},
 	}
 }
 
+// Evaluate scores the position with the evaluation the engine searches with,
+// leaving out mobility so the score doesn't depend on whose move it is.
+func (e *minimaxEngine) Evaluate(board *engine.Board) float64 {
+	score, _ := evaluateStanding(board, e.difficulty)
+	return score
+}
+
+// PredictMove returns the move the engine expects to be played in the given position,
+// taken from the principal variation of its previous search.
+func (e *minimaxEngine) PredictMove(board *engine.Board) (engine.Move, bool) {
+	if atomic.LoadInt32(&e.closed) == 1 {
+		return engine.Move{}, false
+	}
+
+	entry, ok := e.tt.probe(board.Hash)
+	if !ok || entry.bestMove == (engine.Move{}) {
+		return engine.Move{}, false
+	}
+
+	// Guard against hash collisions by confirming the move is legal here
+	for _, move := range board.LegalMoves() {
+		if move == entry.bestMove {
+			return move, true
+		}
+	}
+	return engine.Move{}, false
+}
+
 // SelectMove returns the best move found by minimax search.
 func (e *minimaxEngine) SelectMove(ctx context.Context, board *engine.Board) (engine.Move, error) {
-	if e.closed {
+	if atomic.LoadInt32(&e.closed) == 1 {
 		return engine.Move{}, errors.New("engine is closed")
 	}
 
@@ -146,6 +194,18 @@ func (e *minimaxEngine) SelectMove(ctx context.Context, board *engine.Board) (en
 		return moves[0], nil
 	}
 
+	// In endgames the tablebases cover, only the moves that keep the best
+	// result are searched
+	if tbMoves, _, ok := e.tablebases.tablebaseMoves(board); ok {
+		if len(tbMoves) == 1 {
+			return tbMoves[0], nil
+		}
+		moves = tbMoves
+	}
+
+	// Killer moves are only meaningful within a single search
+	e.killers = [maxKillerPly][2]engine.Move{}
+
 	// Iterative deepening: start at depth 1, increment to maxDepth
 	var bestMove engine.Move
 
@@ -163,7 +223,7 @@ func (e *minimaxEngine) SelectMove(ctx context.Context, board *engine.Board) (en
 		}
 
 		// Search at current depth
-		move, _, err := e.searchDepth(ctx, board, depth)
+		move, _, err := e.searchMoves(ctx, board, moves, depth)
 		if err != nil {
 			// Timeout during search, return best move found so far
 			if bestMove == (engine.Move{}) {
@@ -180,15 +240,54 @@ func (e *minimaxEngine) SelectMove(ctx context.Context, board *engine.Board) (en
 	return bestMove, nil
 }
 
+// evaluatePosition searches the position with iterative deepening and returns the best
+// move and its score in pawns from the side to move's perspective.
+// Returns an error only if the caller's context is cancelled.
+func (e *minimaxEngine) evaluatePosition(ctx context.Context, board *engine.Board) (engine.Move, float64, error) {
+	if len(board.LegalMoves()) == 0 || board.IsGameOver() {
+		return engine.Move{}, e.leafScore(board, 0), nil
+	}
+	if moves, score, ok := e.tablebases.tablebaseMoves(board); ok {
+		return moves[0], score, nil
+	}
+
+	searchCtx, cancel := context.WithTimeout(ctx, e.timeLimit)
+	defer cancel()
+
+	var bestMove engine.Move
+	bestScore := e.leafScore(board, 0)
+
+	for depth := 1; depth <= e.maxDepth; depth++ {
+		move, score, err := e.searchDepth(searchCtx, board, depth)
+		if err != nil {
+			break
+		}
+		bestMove, bestScore = move, score
+	}
+
+	if err := ctx.Err(); err != nil {
+		return engine.Move{}, 0, err
+	}
+	return bestMove, bestScore, nil
+}
+
 // searchDepth performs a minimax search at a specific depth.
 func (e *minimaxEngine) searchDepth(ctx context.Context, board *engine.Board, depth int) (engine.Move, float64, error) {
-	moves := board.LegalMoves()
+	return e.searchMoves(ctx, board, board.LegalMoves(), depth)
+}
+
+// searchMoves performs a minimax search at a specific depth, choosing among the given legal moves.
+func (e *minimaxEngine) searchMoves(ctx context.Context, board *engine.Board, moves []engine.Move, depth int) (engine.Move, float64, error) {
 	if len(moves) == 0 {
 		return engine.Move{}, 0, errors.New("no legal moves available")
 	}
 
-	// Order moves to improve alpha-beta pruning
-	moves = e.orderMoves(board, moves)
+	// Search the previous iteration's best move first to improve alpha-beta pruning
+	var ttMove engine.Move
+	if entry, ok := e.tt.probe(board.Hash); ok {
+		ttMove = entry.bestMove
+	}
+	moves = e.orderMovesWithHints(board, moves, ttMove, 0)
 
 	// Initialize alpha-beta bounds
 	alpha := math.Inf(-1)
@@ -207,17 +306,11 @@ func (e *minimaxEngine) searchDepth(ctx context.Context, board *engine.Board, de
 		default:
 		}
 
-		// Make the move on a copy
-		boardCopy := board.Copy()
-		err := boardCopy.MakeMove(move)
-		if err != nil {
-			// This should not happen with legal moves, but handle it anyway
-			continue
-		}
-
 		// Search with negamax (negate the score since we switched sides)
 		// Pass ply=1 since we're one move from the root
-		score := -e.alphaBeta(ctx, boardCopy, depth-1, -beta, -alpha, 1)
+		board.PushMove(move)
+		score := -e.alphaBeta(ctx, board, depth-1, -beta, -alpha, 1)
+		board.PopMove()
 
 		// Update best move (random tie-breaking among equal scores)
 		if score > bestScore {
@@ -227,7 +320,7 @@ func (e *minimaxEngine) searchDepth(ctx context.Context, board *engine.Board, de
 		} else if score == bestScore && !e.deterministic {
 			// Random tie-breaking among equal scores (disabled in deterministic mode)
 			bestCount++
-			if rand.Intn(bestCount) == 0 {
+			if e.rng.Intn(bestCount) == 0 {
 				bestMove = move
 			}
 		}
@@ -243,6 +336,18 @@ func (e *minimaxEngine) searchDepth(ctx context.Context, board *engine.Board, de
 		}
 	}
 
+	// A search interrupted by the deadline returns neutral scores, so only
+	// complete iterations are allowed to update the table.
+	if ctx.Err() != nil {
+		return engine.Move{}, 0, ctx.Err()
+	}
+	e.tt.store(board.Hash, ttEntry{
+		depth:    depth,
+		score:    scoreToTT(bestScore, 0),
+		flag:     ttExact,
+		bestMove: bestMove,
+	})
+
 	return bestMove, bestScore, nil
 }
 
@@ -250,6 +355,8 @@ func (e *minimaxEngine) searchDepth(ctx context.Context, board *engine.Board, de
 // Returns the score from the perspective of the side to move.
 // ply is the distance from the root (0 at root, increments with each recursive call).
 func (e *minimaxEngine) alphaBeta(ctx context.Context, board *engine.Board, depth int, alpha, beta float64, ply int) float64 {
+	e.nodes++
+
 	// Check for timeout at the start of each node
 	select {
 	case <-ctx.Done():
@@ -259,26 +366,47 @@ func (e *minimaxEngine) alphaBeta(ctx context.Context, board *engine.Board, dept
 	default:
 	}
 
-	// Base case: reached depth 0 or game over
-	if depth == 0 || board.IsGameOver() {
-		// Evaluate from White's perspective, then adjust for current player
-		whiteScore := evaluate(board, e.difficulty)
+	// Game over positions are scored directly
+	if board.IsGameOver() {
+		return e.leafScore(board, ply)
+	}
 
-		// Adjust mate scores to prefer faster mates
-		// Mate in 1 ply scores higher than mate in 3 ply
-		if whiteScore >= 9999.0 {
-			// White wins - prefer faster mate
-			whiteScore = whiteScore - float64(ply)
-		} else if whiteScore <= -9999.0 {
-			// Black wins - prefer faster mate (more negative = worse for us)
-			whiteScore = whiteScore + float64(ply)
+	// Endgames the tablebases cover are scored exactly. They are only probed
+	// right after a capture or pawn move, so the fifty-move counter is reset
+	if board.HalfMoveClock == 0 && e.tablebases.covers(board) {
+		if wdl, err := e.tablebases.probeWDL(board); err == nil {
+			return tablebaseScore(wdl, ply)
 		}
+	}
 
-		// Negamax: flip score if Black is to move
-		if board.ActiveColor == engine.Black {
-			return -whiteScore
+	// Base case: resolve captures before evaluating so the search doesn't
+	// stop in the middle of an exchange (horizon effect)
+	if depth == 0 {
+		if e.useQuiescence {
+			return e.quiescence(ctx, board, alpha, beta, ply, 0)
+		}
+		return e.leafScore(board, ply)
+	}
+
+	// Probe the transposition table for a cached result or a move hint
+	originalAlpha := alpha
+	var ttMove engine.Move
+	if entry, ok := e.tt.probe(board.Hash); ok {
+		ttMove = entry.bestMove
+		if entry.depth >= depth {
+			score := scoreFromTT(entry.score, ply)
+			switch entry.flag {
+			case ttExact:
+				return score
+			case ttLowerBound:
+				alpha = math.Max(alpha, score)
+			case ttUpperBound:
+				beta = math.Min(beta, score)
+			}
+			if alpha >= beta {
+				return score
+			}
 		}
-		return whiteScore
 	}
 
 	// Get all legal moves
@@ -286,41 +414,27 @@ func (e *minimaxEngine) alphaBeta(ctx context.Context, board *engine.Board, dept
 	if len(moves) == 0 {
 		// No legal moves means checkmate or stalemate
 		// evaluate() already handles this, so just evaluate
-		whiteScore := evaluate(board, e.difficulty)
-
-		// Adjust mate scores to prefer faster mates
-		if whiteScore >= 9999.0 {
-			whiteScore = whiteScore - float64(ply)
-		} else if whiteScore <= -9999.0 {
-			whiteScore = whiteScore + float64(ply)
-		}
-
-		if board.ActiveColor == engine.Black {
-			return -whiteScore
-		}
-		return whiteScore
+		return e.leafScore(board, ply)
 	}
 
 	// Order moves for better pruning
-	moves = e.orderMoves(board, moves)
+	moves = e.orderMovesWithHints(board, moves, ttMove, ply)
 
 	// Negamax with alpha-beta pruning
 	maxScore := math.Inf(-1)
+	var bestMove engine.Move
 
 	for _, move := range moves {
-		// Make the move on a copy
-		boardCopy := board.Copy()
-		err := boardCopy.MakeMove(move)
-		if err != nil {
-			continue
-		}
-
-		// Recursive search with negated alpha-beta bounds
-		score := -e.alphaBeta(ctx, boardCopy, depth-1, -beta, -alpha, ply+1)
+		// Recursive search with negated alpha-beta bounds, taking the
+		// move back before the board is used again
+		board.PushMove(move)
+		score := -e.alphaBeta(ctx, board, depth-1, -beta, -alpha, ply+1)
+		board.PopMove()
 
 		// Update max score
 		if score > maxScore {
 			maxScore = score
+			bestMove = move
 		}
 
 		// Update alpha
@@ -330,15 +444,119 @@ func (e *minimaxEngine) alphaBeta(ctx context.Context, board *engine.Board, dept
 
 		// Beta cutoff (pruning)
 		if alpha >= beta {
+			// Quiet moves that cause a cutoff are likely to do so in sibling nodes too
+			if !isCapture(board, move) {
+				e.storeKiller(ply, move)
+			}
 			break
 		}
 	}
 
+	// Don't cache results from a search that was cut short by the deadline
+	if ctx.Err() == nil {
+		flag := ttExact
+		if maxScore <= originalAlpha {
+			flag = ttUpperBound
+		} else if maxScore >= beta {
+			flag = ttLowerBound
+		}
+		e.tt.store(board.Hash, ttEntry{
+			depth:    depth,
+			score:    scoreToTT(maxScore, ply),
+			flag:     flag,
+			bestMove: bestMove,
+		})
+	}
+
 	return maxScore
 }
 
+// quiescence extends the search along capture and promotion sequences until
+// the position is quiet, so that leaf evaluations aren't taken mid-exchange.
+// The side to move may "stand pat" and decline all captures.
+func (e *minimaxEngine) quiescence(ctx context.Context, board *engine.Board, alpha, beta float64, ply, qDepth int) float64 {
+	e.nodes++
+
+	select {
+	case <-ctx.Done():
+		return 0.0
+	default:
+	}
+
+	standPat := e.leafScore(board, ply)
+	if qDepth >= maxQuiescenceDepth || board.IsGameOver() {
+		return standPat
+	}
+
+	if standPat >= beta {
+		return standPat
+	}
+	if standPat > alpha {
+		alpha = standPat
+	}
+
+	moves := filterTacticalMoves(board, board.LegalMoves())
+	moves = e.orderMoves(board, moves)
+
+	for _, move := range moves {
+		board.PushMove(move)
+		score := -e.quiescence(ctx, board, -beta, -alpha, ply+1, qDepth+1)
+		board.PopMove()
+		if score >= beta {
+			return score
+		}
+		if score > alpha {
+			alpha = score
+		}
+	}
+
+	return alpha
+}
+
+// leafScore evaluates a position from the side to move's perspective,
+// adjusting mate scores so that faster mates are preferred.
+func (e *minimaxEngine) leafScore(board *engine.Board, ply int) float64 {
+	// Evaluate from White's perspective, then adjust for current player
+	whiteScore := evaluate(board, e.difficulty)
+
+	// Adjust mate scores to prefer faster mates
+	// Mate in 1 ply scores higher than mate in 3 ply
+	if whiteScore >= 9999.0 {
+		// White wins - prefer faster mate
+		whiteScore = whiteScore - float64(ply)
+	} else if whiteScore <= -9999.0 {
+		// Black wins - prefer faster mate (more negative = worse for us)
+		whiteScore = whiteScore + float64(ply)
+	}
+
+	// Negamax: flip score if Black is to move
+	if board.ActiveColor == engine.Black {
+		return -whiteScore
+	}
+	return whiteScore
+}
+
+// storeKiller records a quiet move that caused a beta cutoff at the given ply.
+// Two killers are kept per ply; the newest replaces the oldest.
+func (e *minimaxEngine) storeKiller(ply int, move engine.Move) {
+	if ply >= maxKillerPly || e.killers[ply][0] == move {
+		return
+	}
+	e.killers[ply][1] = e.killers[ply][0]
+	e.killers[ply][0] = move
+}
+
+// isKiller reports whether the move is a killer move at the given ply.
+func (e *minimaxEngine) isKiller(ply int, move engine.Move) bool {
+	if ply >= maxKillerPly {
+		return false
+	}
+	return e.killers[ply][0] == move || e.killers[ply][1] == move
+}
+
 // orderMoves implements simple move ordering (captures first) to improve alpha-beta pruning.
-// This is a basic MVV-LVA (Most Valuable Victim - Least Valuable Attacker) implementation.
+// Captures are sorted by MVV-LVA (Most Valuable Victim - Least Valuable Attacker),
+// so that winning captures such as PxQ are searched before losing ones such as QxP.
 func (e *minimaxEngine) orderMoves(board *engine.Board, moves []engine.Move) []engine.Move {
 	// Separate captures from non-captures
 	var captures []engine.Move
@@ -353,7 +571,65 @@ func (e *minimaxEngine) orderMoves(board *engine.Board, moves []engine.Move) []e
 		}
 	}
 
+	sort.SliceStable(captures, func(i, j int) bool {
+		return mvvLvaScore(board, captures[i]) > mvvLvaScore(board, captures[j])
+	})
+
 	// Return captures first, then non-captures
-	// Future enhancement: sort captures by MVV-LVA value
 	return append(captures, nonCaptures...)
 }
+
+// orderMovesWithHints orders moves for the main search: the transposition
+// table move first, then captures by MVV-LVA, then killer moves, then the rest.
+func (e *minimaxEngine) orderMovesWithHints(board *engine.Board, moves []engine.Move, ttMove engine.Move, ply int) []engine.Move {
+	ordered := e.orderMoves(board, moves)
+
+	var hashMove, captures, killers, quiet []engine.Move
+	for _, move := range ordered {
+		switch {
+		case ttMove != (engine.Move{}) && move == ttMove:
+			hashMove = append(hashMove, move)
+		case isCapture(board, move):
+			captures = append(captures, move)
+		case e.isKiller(ply, move):
+			killers = append(killers, move)
+		default:
+			quiet = append(quiet, move)
+		}
+	}
+
+	result := make([]engine.Move, 0, len(moves))
+	result = append(result, hashMove...)
+	result = append(result, captures...)
+	result = append(result, killers...)
+	result = append(result, quiet...)
+	return result
+}
+
+// mvvLvaScore scores a capture for move ordering.
+// Higher victim value dominates; among equal victims, cheaper attackers come first.
+func mvvLvaScore(board *engine.Board, move engine.Move) float64 {
+	victim := board.PieceAt(move.To)
+	attacker := board.PieceAt(move.From)
+	return pieceValues[victim.Type()]*10 - pieceValues[attacker.Type()]
+}
+
+// isCapture reports whether the move captures a piece, including en passant.
+func isCapture(board *engine.Board, move engine.Move) bool {
+	if !board.PieceAt(move.To).IsEmpty() {
+		return true
+	}
+	return board.PieceAt(move.From).Type() == engine.Pawn &&
+		board.EnPassantSq >= 0 && move.To == engine.Square(board.EnPassantSq)
+}
+
+// filterTacticalMoves returns the captures and queen promotions searched by quiescence.
+func filterTacticalMoves(board *engine.Board, moves []engine.Move) []engine.Move {
+	var tactical []engine.Move
+	for _, m := range moves {
+		if m.Promotion == engine.Queen || isCapture(board, m) {
+			tactical = append(tactical, m)
+		}
+	}
+	return tactical
+}
@@ -118,6 +118,12 @@ func TestMinimaxEngine_Info(t *testing.T) {
 	if infoMedium.Features["king_safety"] {
 		t.Error("Medium bot should NOT have king_safety feature")
 	}
+	if !infoMedium.Features["transposition_table"] {
+		t.Error("Medium bot should have transposition_table feature")
+	}
+	if infoMedium.Features["quiescence"] {
+		t.Error("Medium bot should NOT have quiescence feature")
+	}
 
 	// Hard bot
 	engHard, err := NewMinimaxEngine(Hard)
@@ -136,6 +142,9 @@ func TestMinimaxEngine_Info(t *testing.T) {
 	if !infoHard.Features["king_safety"] {
 		t.Error("Hard bot should have king_safety feature")
 	}
+	if !infoHard.Features["quiescence"] {
+		t.Error("Hard bot should have quiescence feature")
+	}
 }
 
 func TestMinimaxEngine_ForcedMove(t *testing.T) {
@@ -422,38 +431,25 @@ func TestMinimaxEngine_Depth2Search(t *testing.T) {
 }
 
 func TestMinimaxEngine_AlphaBetaPruning(t *testing.T) {
-	if testing.Short() {
-		t.Skip("skipping timing-sensitive test in short mode")
-	}
-
-	// This test verifies that the engine completes within reasonable time
-	// which indicates alpha-beta pruning is working (vs. plain minimax)
-	board := engine.NewBoard()
-
-	eng, err := NewMinimaxEngine(Medium)
-	if err != nil {
-		t.Fatalf("NewMinimaxEngine() error = %v", err)
-	}
-	defer eng.Close()
+	// Plain minimax visits every node of the game tree: from the starting
+	// position that is 20 + 400 + 8,902 + 197,281 positions at depth 4
+	// (the perft counts). Alpha-beta pruning should visit a small fraction.
+	const minimaxNodes = 20 + 400 + 8902 + 197281
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	start := time.Now()
-	move, err := eng.SelectMove(ctx, board)
-	elapsed := time.Since(start)
-
+	stats, err := SearchDepths(ctx, engine.NewBoard(), Medium, 4)
 	if err != nil {
-		t.Fatalf("SelectMove() error = %v", err)
+		t.Fatalf("SearchDepths() error = %v", err)
 	}
-	if move == (engine.Move{}) {
-		t.Error("SelectMove() returned empty move")
+	last := stats[len(stats)-1]
+	if last.Move == (engine.Move{}) {
+		t.Error("search returned empty move")
 	}
 
-	// Depth-2 search with alpha-beta should complete in well under 1 second
-	// even from the starting position
-	if elapsed >= 1*time.Second {
-		t.Errorf("search took %v, should complete quickly with pruning (< 1s)", elapsed)
+	if last.Nodes >= minimaxNodes/10 {
+		t.Errorf("depth-4 search visited %d nodes, want fewer than a tenth of plain minimax's %d", last.Nodes, minimaxNodes)
 	}
 }
 
@@ -542,6 +538,120 @@ func TestMinimaxEngine_MoveOrdering(t *testing.T) {
 	}
 }
 
+func TestMinimaxEngine_MoveOrdering_MVVLVA(t *testing.T) {
+	// White can capture the queen on d5 with the pawn (e4) or the rook (d1),
+	// or capture the pawn on a5 with the rook (a1)
+	fen := "6k1/8/8/p2q4/4P3/8/8/R2R2K1 w - - 0 1"
+
+	board, err := engine.ParseFEN(fen)
+	if err != nil {
+		t.Fatalf("ParseFEN() error = %v", err)
+	}
+
+	eng, err := NewMinimaxEngine(Medium)
+	if err != nil {
+		t.Fatalf("NewMinimaxEngine() error = %v", err)
+	}
+	defer eng.Close()
+
+	me := eng.(*minimaxEngine)
+	ordered := me.orderMoves(board, board.LegalMoves())
+
+	want := []string{"e4d5", "d1d5", "a1a5"}
+	for i, w := range want {
+		if ordered[i].String() != w {
+			t.Errorf("ordered[%d] = %s, want %s", i, ordered[i].String(), w)
+		}
+	}
+}
+
+func TestMinimaxEngine_KillerMoves(t *testing.T) {
+	eng, err := NewMinimaxEngine(Medium)
+	if err != nil {
+		t.Fatalf("NewMinimaxEngine() error = %v", err)
+	}
+	defer eng.Close()
+
+	me := eng.(*minimaxEngine)
+	board := engine.NewBoard()
+
+	killer, _ := engine.ParseMove("g1f3")
+	me.storeKiller(2, killer)
+
+	if !me.isKiller(2, killer) {
+		t.Fatal("stored move should be a killer at its ply")
+	}
+	if me.isKiller(3, killer) {
+		t.Error("killer should not leak to other plies")
+	}
+
+	// Killers are searched before other quiet moves
+	ordered := me.orderMovesWithHints(board, board.LegalMoves(), engine.Move{}, 2)
+	if ordered[0] != killer {
+		t.Errorf("first move = %s, want killer %s", ordered[0].String(), killer.String())
+	}
+
+	// The transposition table move is searched before everything else
+	ttMove, _ := engine.ParseMove("e2e4")
+	ordered = me.orderMovesWithHints(board, board.LegalMoves(), ttMove, 2)
+	if ordered[0] != ttMove || ordered[1] != killer {
+		t.Errorf("ordering = %s, %s; want %s, %s", ordered[0].String(), ordered[1].String(), ttMove.String(), killer.String())
+	}
+}
+
+func TestMinimaxEngine_Quiescence_SeesRecapture(t *testing.T) {
+	// White queen can take the pawn on d5, but it is defended by the e6 pawn.
+	// At depth 1 without quiescence this looks like winning a pawn.
+	fen := "6k1/8/4p3/3p4/8/8/3Q4/6K1 w - - 0 1"
+
+	board, err := engine.ParseFEN(fen)
+	if err != nil {
+		t.Fatalf("ParseFEN() error = %v", err)
+	}
+
+	eng, err := NewMinimaxEngine(Hard, WithSearchDepth(1), WithDeterministic(true))
+	if err != nil {
+		t.Fatalf("NewMinimaxEngine() error = %v", err)
+	}
+	defer eng.Close()
+
+	move, err := eng.SelectMove(context.Background(), board)
+	if err != nil {
+		t.Fatalf("SelectMove() error = %v", err)
+	}
+
+	if move.String() == "d2d5" {
+		t.Error("Hard bot with quiescence should not capture a defended pawn with the queen")
+	}
+}
+
+func TestMinimaxEngine_UsesTranspositionTable(t *testing.T) {
+	eng, err := NewMinimaxEngine(Medium, WithSearchDepth(3), WithDeterministic(true))
+	if err != nil {
+		t.Fatalf("NewMinimaxEngine() error = %v", err)
+	}
+	defer eng.Close()
+
+	me := eng.(*minimaxEngine)
+	board := engine.NewBoard()
+
+	move, err := eng.SelectMove(context.Background(), board)
+	if err != nil {
+		t.Fatalf("SelectMove() error = %v", err)
+	}
+
+	entry, ok := me.tt.probe(board.Hash)
+	if !ok {
+		t.Fatal("root position should be stored in the transposition table")
+	}
+	if entry.bestMove != move {
+		t.Errorf("stored best move = %s, want %s", entry.bestMove.String(), move.String())
+	}
+	if me.tt.size() <= 1 {
+		t.Errorf("expected interior nodes to be cached, table size = %d", me.tt.size())
+	}
+}
+
 func TestMinimaxEngine_IterativeDeepening_Timeout(t *testing.T) {
 	// Create engine with very short timeout
 	eng, err := NewMinimaxEngine(Medium, WithTimeLimit(100*time.Millisecond))
@@ -0,0 +1,155 @@
+package bot
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// Ponderer wraps an engine so it can think on the opponent's time.
+// After the bot moves, StartPondering predicts the opponent's reply and searches
+// the resulting position in a background goroutine. If the opponent plays the
+// predicted move, SelectMove returns the pondered result (instantly if the search
+// already finished); otherwise the background search is cancelled and a normal
+// search is run.
+//
+// The wrapped engine is never used by two goroutines at once: every call that
+// touches it first stops any background search.
+type Ponderer struct {
+	inner Engine
+
+	mu       sync.Mutex
+	cancel   context.CancelFunc
+	done     chan struct{}
+	expected uint64 // Hash of the position after the predicted move
+	move     engine.Move
+	err      error
+	hit      bool
+}
+
+// NewPonderer wraps an engine with pondering support.
+// Engines that don't implement Predictor are still usable, but never ponder.
+func NewPonderer(inner Engine) *Ponderer {
+	return &Ponderer{inner: inner}
+}
+
+// Name returns the name of the wrapped engine.
+func (p *Ponderer) Name() string {
+	return p.inner.Name()
+}
+
+// StartPondering begins searching the reply to the opponent's predicted move.
+// The board is the position with the opponent to move; it is copied, so the
+// caller may keep modifying it. Any previous background search is stopped first.
+// Returns false if the engine could not predict a move.
+func (p *Ponderer) StartPondering(board *engine.Board) bool {
+	p.StopPondering()
+
+	predictor, ok := p.inner.(Predictor)
+	if !ok {
+		return false
+	}
+
+	predicted, ok := predictor.PredictMove(board)
+	if !ok {
+		return false
+	}
+
+	next := board.Copy()
+	if err := next.MakeMove(predicted); err != nil || next.IsGameOver() {
+		return false
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	p.mu.Lock()
+	p.cancel = cancel
+	p.done = done
+	p.expected = next.Hash
+	p.mu.Unlock()
+
+	go func() {
+		defer close(done)
+		move, err := p.inner.SelectMove(ctx, next)
+		p.mu.Lock()
+		p.move, p.err = move, err
+		p.mu.Unlock()
+	}()
+
+	return true
+}
+
+// StopPondering cancels any background search and waits for it to finish.
+// Safe to call when not pondering.
+func (p *Ponderer) StopPondering() {
+	p.mu.Lock()
+	cancel, done := p.cancel, p.done
+	p.cancel, p.done = nil, nil
+	p.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+// IsPondering reports whether a background search is in progress or has a result waiting.
+func (p *Ponderer) IsPondering() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.done != nil
+}
+
+// PonderHit reports whether the last SelectMove call was answered from the pondered search.
+func (p *Ponderer) PonderHit() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.hit
+}
+
+// SelectMove returns the pondered move if the opponent played the predicted move,
+// otherwise it abandons the background search and searches the position normally.
+func (p *Ponderer) SelectMove(ctx context.Context, board *engine.Board) (engine.Move, error) {
+	p.mu.Lock()
+	done, cancel := p.done, p.cancel
+	matches := done != nil && board.Hash == p.expected
+	p.hit = false
+	p.mu.Unlock()
+
+	if matches {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			// Interrupted: stop the background search and use its best move so far
+			cancel()
+			<-done
+		}
+
+		p.mu.Lock()
+		p.cancel, p.done = nil, nil
+		move, err := p.move, p.err
+		p.mu.Unlock()
+		cancel()
+
+		if err == nil {
+			p.mu.Lock()
+			p.hit = true
+			p.mu.Unlock()
+			return move, nil
+		}
+		// The pondered search failed; fall through to a fresh search
+	} else {
+		p.StopPondering()
+	}
+
+	return p.inner.SelectMove(ctx, board)
+}
+
+// Close stops any background search and closes the wrapped engine.
+func (p *Ponderer) Close() error {
+	p.StopPondering()
+	return p.inner.Close()
+}
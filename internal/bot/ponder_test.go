@@ -0,0 +1,131 @@
+package bot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// newPonderTestPosition returns a pondering Medium bot that has just played
+// its move from the starting position, leaving the opponent to move.
+func newPonderTestPosition(t *testing.T) (*Ponderer, *engine.Board) {
+	t.Helper()
+
+	eng, err := NewMinimaxEngine(Medium, WithSearchDepth(3), WithDeterministic(true))
+	if err != nil {
+		t.Fatalf("NewMinimaxEngine() error = %v", err)
+	}
+	p := NewPonderer(eng)
+	t.Cleanup(func() { _ = p.Close() })
+
+	board := engine.NewBoard()
+	move, err := p.SelectMove(context.Background(), board)
+	if err != nil {
+		t.Fatalf("SelectMove() error = %v", err)
+	}
+	if err := board.MakeMove(move); err != nil {
+		t.Fatalf("MakeMove(%s) error = %v", move.String(), err)
+	}
+	return p, board
+}
+
+func TestPonderer_PredictedMoveIsHit(t *testing.T) {
+	p, board := newPonderTestPosition(t)
+
+	predicted, ok := p.inner.(Predictor).PredictMove(board)
+	if !ok {
+		t.Fatal("PredictMove() should predict a reply after a search")
+	}
+
+	if !p.StartPondering(board) {
+		t.Fatal("StartPondering() should start when a move can be predicted")
+	}
+	if !p.IsPondering() {
+		t.Error("IsPondering() should be true after StartPondering()")
+	}
+
+	if err := board.MakeMove(predicted); err != nil {
+		t.Fatalf("MakeMove(%s) error = %v", predicted.String(), err)
+	}
+
+	move, err := p.SelectMove(context.Background(), board)
+	if err != nil {
+		t.Fatalf("SelectMove() error = %v", err)
+	}
+	if !p.PonderHit() {
+		t.Error("PonderHit() should be true when the predicted move was played")
+	}
+	if p.IsPondering() {
+		t.Error("IsPondering() should be false once the pondered move is consumed")
+	}
+	if err := board.MakeMove(move); err != nil {
+		t.Errorf("pondered move %s is not legal: %v", move.String(), err)
+	}
+}
+
+func TestPonderer_UnexpectedMoveIsMiss(t *testing.T) {
+	p, board := newPonderTestPosition(t)
+
+	predicted, _ := p.inner.(Predictor).PredictMove(board)
+	if !p.StartPondering(board) {
+		t.Fatal("StartPondering() should start when a move can be predicted")
+	}
+
+	// Play any legal move other than the predicted one
+	var other engine.Move
+	for _, m := range board.LegalMoves() {
+		if m != predicted {
+			other = m
+			break
+		}
+	}
+	if err := board.MakeMove(other); err != nil {
+		t.Fatalf("MakeMove(%s) error = %v", other.String(), err)
+	}
+
+	move, err := p.SelectMove(context.Background(), board)
+	if err != nil {
+		t.Fatalf("SelectMove() error = %v", err)
+	}
+	if p.PonderHit() {
+		t.Error("PonderHit() should be false when a different move was played")
+	}
+	if err := board.MakeMove(move); err != nil {
+		t.Errorf("move %s is not legal after a ponder miss: %v", move.String(), err)
+	}
+}
+
+func TestPonderer_NonPredictorNeverPonders(t *testing.T) {
+	eng, err := NewRandomEngine()
+	if err != nil {
+		t.Fatalf("NewRandomEngine() error = %v", err)
+	}
+	p := NewPonderer(eng)
+	defer p.Close()
+
+	if p.StartPondering(engine.NewBoard()) {
+		t.Error("StartPondering() should return false for engines without predictions")
+	}
+	if p.IsPondering() {
+		t.Error("IsPondering() should be false")
+	}
+}
+
+func TestPonderer_CloseStopsPondering(t *testing.T) {
+	p, board := newPonderTestPosition(t)
+
+	if !p.StartPondering(board) {
+		t.Fatal("StartPondering() should start when a move can be predicted")
+	}
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if p.IsPondering() {
+		t.Error("IsPondering() should be false after Close()")
+	}
+
+	if _, err := p.SelectMove(context.Background(), board); err == nil {
+		t.Error("SelectMove() after Close() should return an error")
+	}
+}
@@ -0,0 +1,106 @@
+package bot
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"time"
+)
+
+// errProcessTimeout is returned by receive when the bot doesn't answer in time.
+var errProcessTimeout = errors.New("timed out")
+
+// botProcess runs an external bot program and exchanges lines of text with it
+// over its standard input and output. It is not safe for concurrent use.
+type botProcess struct {
+	name    string
+	command string
+	args    []string
+
+	proc  *exec.Cmd
+	stdin io.WriteCloser
+	lines chan string // lines read from the bot's stdout; closed when it exits
+}
+
+// start launches the program.
+func (p *botProcess) start() error {
+	proc := exec.Command(p.command, p.args...)
+	stdin, err := proc.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := proc.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := proc.Start(); err != nil {
+		return fmt.Errorf("failed to start %s: %w", p.name, err)
+	}
+
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+
+	p.proc = proc
+	p.stdin = stdin
+	p.lines = lines
+	return nil
+}
+
+// running reports whether the program has been started and not stopped since.
+func (p *botProcess) running() bool {
+	return p.proc != nil
+}
+
+// stop kills the program. It is a no-op if the program isn't running.
+func (p *botProcess) stop() {
+	if p.proc == nil {
+		return
+	}
+	_ = p.stdin.Close()
+	_ = p.proc.Process.Kill()
+	_ = p.proc.Wait()
+	// Let the reader goroutine finish
+	for range p.lines {
+	}
+	p.proc = nil
+}
+
+// send writes line to the program. The program is stopped if it can't be written to.
+func (p *botProcess) send(line string) error {
+	if _, err := io.WriteString(p.stdin, line+"\n"); err != nil {
+		p.stop()
+		return fmt.Errorf("%s stopped responding: %w", p.name, err)
+	}
+	return nil
+}
+
+// receive waits up to timeout for the next line from the program. It returns
+// errProcessTimeout if no line arrives in time, or ctx.Err() if ctx is done first.
+// The program keeps running in both cases; it is stopped if it exits.
+func (p *botProcess) receive(ctx context.Context, timeout time.Duration) (string, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case line, ok := <-p.lines:
+		if !ok {
+			p.stop()
+			return "", fmt.Errorf("%s exited", p.name)
+		}
+		return line, nil
+	case <-timer.C:
+		return "", errProcessTimeout
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
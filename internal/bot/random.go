@@ -89,14 +89,12 @@ func filterCaptures(board *engine.Board, moves []engine.Move) []engine.Move {
 func filterChecks(board *engine.Board, moves []engine.Move) []engine.Move {
 	var checks []engine.Move
 	for _, m := range moves {
-		// Make move on a copy and check if opponent is in check
-		boardCopy := board.Copy()
-		boardCopy.MakeMove(m)
-
 		// After making the move, check if the NEW active color (opponent) is in check
-		if boardCopy.InCheck() {
+		board.PushMove(m)
+		if board.InCheck() {
 			checks = append(checks, m)
 		}
+		board.PopMove()
 	}
 	return checks
 }
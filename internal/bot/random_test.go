@@ -261,9 +261,9 @@ func TestNewRandomEngine_DefaultConfig(t *testing.T) {
 		t.Fatal("Expected engine to be *randomEngine")
 	}
 
-	// Verify default time limit is 2 seconds
-	if randomEng.timeLimit != 2*time.Second {
-		t.Errorf("Expected default time limit of 2s, got %v", randomEng.timeLimit)
+	// Verify default time limit is 50 milliseconds
+	if randomEng.timeLimit != 50*time.Millisecond {
+		t.Errorf("Expected default time limit of 50ms, got %v", randomEng.timeLimit)
 	}
 }
 
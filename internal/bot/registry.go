@@ -0,0 +1,142 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// Factory creates a new bot instance. Every game gets its own instance, so
+// instances must not share mutable state.
+type Factory func() (Engine, error)
+
+// Evaluator is an optional hook for custom bots: engines that implement it score
+// positions with their own evaluation, in pawns from White's perspective. The score
+// replaces the built-in evaluation when the bot decides whether to resign or accept a draw.
+// Evaluate may be called while the bot is searching, so it must be safe to call
+// concurrently with SelectMove.
+type Evaluator interface {
+	Engine
+	Evaluate(board *engine.Board) float64
+}
+
+// Kind identifies the backend a registered bot runs on.
+type Kind string
+
+const (
+	// KindCustom is a bot compiled into TermChess with Register.
+	KindCustom Kind = "custom"
+	// KindSubprocess is an external program speaking the JSON line protocol.
+	KindSubprocess Kind = "subprocess"
+	// KindUCI is an external engine speaking the Universal Chess Interface.
+	KindUCI Kind = "UCI"
+)
+
+// registryEntry is a registered bot.
+type registryEntry struct {
+	kind    Kind
+	factory Factory
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]registryEntry)
+)
+
+// builtinNames are the difficulty names custom bots cannot use, so bot menus stay unambiguous.
+var builtinNames = map[string]bool{"Easy": true, "Medium": true, "Hard": true}
+
+// Register makes a custom bot available under name in the bot selection menus.
+// It is meant to be called from an init function of the file that defines the bot:
+//
+//	func init() {
+//		bot.Register("My Bot", func() (bot.Engine, error) { return &myBot{}, nil })
+//	}
+//
+// Register panics if name is empty, clashes with a built-in difficulty, is already
+// registered, or if factory is nil.
+func Register(name string, factory Factory) {
+	if err := register(name, KindCustom, factory); err != nil {
+		panic("bot: " + err.Error())
+	}
+}
+
+// register adds a bot of the given kind to the registry, or reports why it cannot.
+func register(name string, kind Kind, factory Factory) error {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if name == "" || builtinNames[name] {
+		return fmt.Errorf("invalid custom bot name %q", name)
+	}
+	if factory == nil {
+		return fmt.Errorf("factory is nil for %s", name)
+	}
+	if _, dup := registry[name]; dup {
+		return fmt.Errorf("bot %q is already registered", name)
+	}
+	registry[name] = registryEntry{kind: kind, factory: factory}
+	return nil
+}
+
+// Registered returns the names of all registered custom bots in alphabetical order.
+func Registered() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewRegistered creates an instance of the custom bot registered under name.
+func NewRegistered(name string) (Engine, error) {
+	registryMu.RLock()
+	entry, ok := registry[name]
+	registryMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown bot %q", name)
+	}
+	return entry.factory()
+}
+
+// KindOf returns the backend of the bot registered under name, and whether there is one.
+func KindOf(name string) (Kind, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	entry, ok := registry[name]
+	return entry.kind, ok
+}
+
+// Unregister removes the custom bot registered under name, if any.
+// It is mainly useful for tests that register bots temporarily.
+func Unregister(name string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	delete(registry, name)
+}
+
+// EvaluatedBalance returns how good the position is for color, in pawns, as judged
+// by e: its own evaluation if it is an Evaluator, the Hard bot's evaluation otherwise.
+// A Ponderer is judged by the engine it wraps.
+func EvaluatedBalance(e Engine, board *engine.Board, color engine.Color) float64 {
+	if p, ok := e.(*Ponderer); ok {
+		e = p.inner
+	}
+	var score float64
+	if ev, ok := e.(Evaluator); ok {
+		score = ev.Evaluate(board)
+	} else {
+		score, _ = evaluateStanding(board, Hard)
+	}
+	if color == engine.Black {
+		return -score
+	}
+	return score
+}
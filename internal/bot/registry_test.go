@@ -0,0 +1,87 @@
+package bot
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// evalBot is a custom bot with its own evaluation, which always thinks White is winning.
+type evalBot struct{ randomEngine }
+
+func (b *evalBot) Evaluate(board *engine.Board) float64 { return 20 }
+
+func TestRegisterCustomBot(t *testing.T) {
+	Register("Zeta Bot", func() (Engine, error) { return NewRandomEngine() })
+	Register("Alpha Bot", func() (Engine, error) { return NewRandomEngine() })
+	defer Unregister("Zeta Bot")
+	defer Unregister("Alpha Bot")
+
+	if got, want := Registered(), []string{"Alpha Bot", "Zeta Bot"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Registered() = %v, want %v", got, want)
+	}
+
+	e, err := NewRegistered("Alpha Bot")
+	if err != nil {
+		t.Fatalf("NewRegistered() error = %v", err)
+	}
+	defer e.Close()
+	if _, err := e.SelectMove(context.Background(), engine.NewBoard()); err != nil {
+		t.Errorf("SelectMove() error = %v", err)
+	}
+
+	if _, err := NewRegistered("Missing Bot"); err == nil {
+		t.Error("Expected an error for an unknown bot")
+	}
+}
+
+func TestRegisterRejectsInvalidBots(t *testing.T) {
+	factory := func() (Engine, error) { return NewRandomEngine() }
+	Register("Dup Bot", factory)
+	defer Unregister("Dup Bot")
+
+	tests := []struct {
+		name    string
+		factory Factory
+	}{
+		{"", factory},
+		{"Hard", factory},
+		{"Dup Bot", factory},
+		{"Nil Bot", nil},
+	}
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Register(%q) did not panic", tt.name)
+				}
+			}()
+			Register(tt.name, tt.factory)
+		}()
+	}
+}
+
+func TestEvaluatedBalance(t *testing.T) {
+	board := engine.NewBoard()
+	custom := &evalBot{}
+
+	if got := EvaluatedBalance(custom, board, engine.White); got != 20 {
+		t.Errorf("EvaluatedBalance() for White = %v, want 20", got)
+	}
+	if got := EvaluatedBalance(NewPonderer(custom), board, engine.Black); got != -20 {
+		t.Errorf("EvaluatedBalance() through a Ponderer for Black = %v, want -20", got)
+	}
+	if got := EvaluatedBalance(nil, board, engine.Black); got != 0 {
+		t.Errorf("EvaluatedBalance() without an engine = %v, want the even starting position's 0", got)
+	}
+
+	// The custom evaluation drives resignation
+	if !ShouldResign(custom, board, engine.Black, 10) {
+		t.Error("Expected Black to resign when its own evaluation is -20")
+	}
+	if ShouldResign(nil, board, engine.Black, 10) {
+		t.Error("Expected no resignation in the starting position")
+	}
+}
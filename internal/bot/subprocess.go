@@ -0,0 +1,194 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// DefaultSubprocessTimeLimit is the time an external bot is given per move.
+const DefaultSubprocessTimeLimit = 5 * time.Second
+
+// subprocessGrace is how long past its time limit an external bot may take to answer
+// before it is considered hung and restarted.
+const subprocessGrace = time.Second
+
+// SubprocessRequest is the JSON line sent to an external bot when it is its turn.
+type SubprocessRequest struct {
+	// FEN is the current position.
+	FEN string `json:"fen"`
+	// Variant is the chess variant being played, e.g. "standard" or "atomic".
+	Variant string `json:"variant"`
+	// LegalMoves lists every legal move in coordinate notation (e.g. "e2e4", "e7e8q").
+	LegalMoves []string `json:"legal_moves"`
+	// TimeLimitMs is how long the bot may think, in milliseconds.
+	TimeLimitMs int64 `json:"time_limit_ms"`
+}
+
+// SubprocessResponse is the JSON line an external bot answers with.
+type SubprocessResponse struct {
+	// Move is the chosen move in coordinate notation.
+	Move string `json:"move"`
+	// Error, if set, reports that the bot could not choose a move.
+	Error string `json:"error,omitempty"`
+}
+
+// subprocessEngine plays the moves of an external program that speaks a simple
+// line-based JSON protocol on its standard input and output: one SubprocessRequest
+// per move in, one SubprocessResponse out.
+type subprocessEngine struct {
+	timeLimit time.Duration
+
+	mu     sync.Mutex
+	proc   botProcess
+	closed bool
+}
+
+// NewSubprocessEngine starts command with args as an external bot called name.
+func NewSubprocessEngine(name, command string, args []string, opts ...EngineOption) (Engine, error) {
+	cfg := &engineConfig{timeLimit: DefaultSubprocessTimeLimit}
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	e := &subprocessEngine{
+		timeLimit: cfg.timeLimit,
+		proc:      botProcess{name: name, command: command, args: args},
+	}
+	if err := e.proc.start(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// RegisterSubprocess registers an external bot under name, so it is listed in the
+// bot menus like a compiled-in custom bot. Unlike Register it returns an error
+// instead of panicking, since external bots come from the user's configuration.
+func RegisterSubprocess(name, command string, args []string) error {
+	if command == "" {
+		return fmt.Errorf("bot %q has no command", name)
+	}
+	return register(name, KindSubprocess, func() (Engine, error) {
+		return NewSubprocessEngine(name, command, args)
+	})
+}
+
+// SelectMove sends the position to the bot and waits for its move. A bot that
+// doesn't answer in time, or answers with anything but a legal move, is an error;
+// a bot that hung or exited is restarted for the next move.
+func (e *subprocessEngine) SelectMove(ctx context.Context, board *engine.Board) (engine.Move, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.closed {
+		return engine.Move{}, errors.New("engine is closed")
+	}
+	if !e.proc.running() {
+		if err := e.proc.start(); err != nil {
+			return engine.Move{}, err
+		}
+	}
+
+	legal := board.LegalMoves()
+	if len(legal) == 0 {
+		return engine.Move{}, errors.New("no legal moves available")
+	}
+
+	request := SubprocessRequest{
+		FEN:         board.ToFEN(),
+		Variant:     variantName(board),
+		LegalMoves:  make([]string, len(legal)),
+		TimeLimitMs: e.timeLimit.Milliseconds(),
+	}
+	for i, move := range legal {
+		request.LegalMoves[i] = move.String()
+	}
+
+	data, err := json.Marshal(request)
+	if err != nil {
+		return engine.Move{}, err
+	}
+	if err := e.proc.send(string(data)); err != nil {
+		return engine.Move{}, err
+	}
+
+	line, err := e.proc.receive(ctx, e.timeLimit+subprocessGrace)
+	if err != nil {
+		// A late answer would arrive out of step with the next request, so restart the bot
+		e.proc.stop()
+		if err == errProcessTimeout {
+			return engine.Move{}, fmt.Errorf("%s did not move within %s", e.proc.name, e.timeLimit)
+		}
+		return engine.Move{}, err
+	}
+
+	var response SubprocessResponse
+	if err := json.Unmarshal([]byte(line), &response); err != nil {
+		return engine.Move{}, fmt.Errorf("%s sent invalid JSON: %w", e.proc.name, err)
+	}
+	if response.Error != "" {
+		return engine.Move{}, fmt.Errorf("%s: %s", e.proc.name, response.Error)
+	}
+	return matchLegalMove(response.Move, legal)
+}
+
+// variantName returns the lowercase name of the variant played on board, e.g. "standard".
+func variantName(board *engine.Board) string {
+	if board.Variant == nil {
+		return "standard"
+	}
+	return strings.ToLower(board.Variant.Name())
+}
+
+// matchLegalMove finds the legal move written in coordinate notation as s.
+// A promotion without a piece promotes to a queen.
+func matchLegalMove(s string, legal []engine.Move) (engine.Move, error) {
+	move, err := engine.ParseMove(strings.ToLower(strings.TrimSpace(s)))
+	if err != nil {
+		return engine.Move{}, fmt.Errorf("invalid move %q: %w", s, err)
+	}
+	if move.Promotion == engine.Empty {
+		for _, m := range legal {
+			if m.From == move.From && m.To == move.To && m.Promotion == engine.Queen {
+				return m, nil
+			}
+		}
+	}
+	for _, m := range legal {
+		if m == move {
+			return m, nil
+		}
+	}
+	return engine.Move{}, fmt.Errorf("illegal move %q", s)
+}
+
+// Name returns the bot's name.
+func (e *subprocessEngine) Name() string {
+	return e.proc.name
+}
+
+// Close stops the bot process. It is safe to call multiple times.
+func (e *subprocessEngine) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.closed = true
+	e.proc.stop()
+	return nil
+}
+
+// Info returns metadata about the bot.
+func (e *subprocessEngine) Info() Info {
+	return Info{
+		Name:     e.proc.name,
+		Type:     TypeSubprocess,
+		Features: map[string]bool{},
+	}
+}
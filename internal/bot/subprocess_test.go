@@ -0,0 +1,154 @@
+package bot
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// TestHelperSubprocessBot is not a real test: it is the external bot that the
+// subprocess tests start, by running the test binary with TERMCHESS_HELPER_BOT set
+// to the bot's behavior.
+func TestHelperSubprocessBot(t *testing.T) {
+	mode := os.Getenv("TERMCHESS_HELPER_BOT")
+	if mode == "" {
+		return
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var req SubprocessRequest
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			fmt.Println(`{"error": "bad request"}`)
+			continue
+		}
+		switch mode {
+		case "first":
+			fmt.Printf(`{"move": %q}`+"\n", req.LegalMoves[0])
+		case "illegal":
+			fmt.Println(`{"move": "e2e5"}`)
+		case "error":
+			fmt.Println(`{"error": "no idea"}`)
+		case "hang":
+			time.Sleep(time.Minute)
+		}
+	}
+	os.Exit(0)
+}
+
+// helperBot starts the test binary as an external bot with the given behavior.
+func helperBot(t *testing.T, mode string, opts ...EngineOption) Engine {
+	t.Helper()
+	t.Setenv("TERMCHESS_HELPER_BOT", mode)
+	e, err := NewSubprocessEngine("Helper Bot", os.Args[0], []string{"-test.run=^TestHelperSubprocessBot$"}, opts...)
+	if err != nil {
+		t.Fatalf("NewSubprocessEngine() error = %v", err)
+	}
+	t.Cleanup(func() { _ = e.Close() })
+	return e
+}
+
+func TestSubprocessEnginePlaysMoves(t *testing.T) {
+	e := helperBot(t, "first")
+	board := engine.NewBoard()
+
+	for i := 0; i < 4; i++ {
+		move, err := e.SelectMove(context.Background(), board)
+		if err != nil {
+			t.Fatalf("SelectMove() error = %v", err)
+		}
+		if err := board.MakeMove(move); err != nil {
+			t.Fatalf("MakeMove(%s) error = %v", move, err)
+		}
+	}
+	if e.Name() != "Helper Bot" {
+		t.Errorf("Name() = %q, want Helper Bot", e.Name())
+	}
+}
+
+func TestSubprocessEngineRejectsBadAnswers(t *testing.T) {
+	tests := map[string]string{
+		"illegal": "illegal move",
+		"error":   "no idea",
+	}
+	for mode, want := range tests {
+		e := helperBot(t, mode)
+		_, err := e.SelectMove(context.Background(), engine.NewBoard())
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: SelectMove() error = %v, want %q", mode, err, want)
+		}
+	}
+}
+
+func TestSubprocessEngineTimeout(t *testing.T) {
+	e := helperBot(t, "hang", WithTimeLimit(100*time.Millisecond))
+	_, err := e.SelectMove(context.Background(), engine.NewBoard())
+	if err == nil || !strings.Contains(err.Error(), "did not move") {
+		t.Fatalf("SelectMove() error = %v, want a timeout", err)
+	}
+
+	// A cancelled move restarts the bot as well
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := e.SelectMove(ctx, engine.NewBoard()); err != context.Canceled {
+		t.Errorf("SelectMove() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestSubprocessEngineClose(t *testing.T) {
+	e := helperBot(t, "first")
+	if err := e.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := e.Close(); err != nil {
+		t.Errorf("second Close() error = %v", err)
+	}
+	if _, err := e.SelectMove(context.Background(), engine.NewBoard()); err == nil {
+		t.Error("Expected SelectMove to fail after Close")
+	}
+}
+
+func TestNewSubprocessEngineMissingCommand(t *testing.T) {
+	if _, err := NewSubprocessEngine("Ghost", "/nonexistent/termchess-bot", nil); err == nil {
+		t.Error("Expected an error for a missing command")
+	}
+}
+
+func TestRegisterSubprocess(t *testing.T) {
+	if err := RegisterSubprocess("Script Bot", "python3", []string{"bot.py"}); err != nil {
+		t.Fatalf("RegisterSubprocess() error = %v", err)
+	}
+	defer Unregister("Script Bot")
+
+	if err := RegisterSubprocess("Script Bot", "python3", nil); err == nil {
+		t.Error("Expected an error for a duplicate name")
+	}
+	if err := RegisterSubprocess("Empty Bot", "", nil); err == nil {
+		t.Error("Expected an error without a command")
+	}
+	if err := RegisterSubprocess("Medium", "python3", nil); err == nil {
+		t.Error("Expected an error for a built-in difficulty name")
+	}
+}
+
+func TestMatchLegalMoveDefaultsToQueen(t *testing.T) {
+	board, err := engine.FromFEN("8/P7/8/8/8/8/8/k6K w - - 0 1")
+	if err != nil {
+		t.Fatalf("FromFEN() error = %v", err)
+	}
+	move, err := matchLegalMove("a7a8", board.LegalMoves())
+	if err != nil || move.Promotion != engine.Queen {
+		t.Errorf("matchLegalMove(a7a8) = %v, %v, want a queen promotion", move, err)
+	}
+	if move, err := matchLegalMove("a7a8n", board.LegalMoves()); err != nil || move.Promotion != engine.Knight {
+		t.Errorf("matchLegalMove(a7a8n) = %v, %v, want a knight promotion", move, err)
+	}
+}
@@ -0,0 +1,112 @@
+package bot
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Syzygy endgame tablebases hold the exact result of every position with few
+// pieces. The built-in bots and the analysis probe them through Tablebases,
+// see syzygy_table.go for the file format and syzygy_probe.go for probing, and
+// UCI engines are given the configured path through their SyzygyPath option.
+
+// Syzygy table file extensions: WDL tables give win/draw/loss, DTZ tables the
+// distance to zeroing the fifty-move counter.
+const (
+	syzygyWDLExt = ".rtbw"
+	syzygyDTZExt = ".rtbz"
+)
+
+// Magic numbers at the start of Syzygy table files.
+var (
+	syzygyWDLMagic = []byte{0x71, 0xe8, 0x23, 0x5d}
+	syzygyDTZMagic = []byte{0xd7, 0x66, 0x0c, 0xa5}
+)
+
+// syzygyTableName matches the material of a table file name, e.g. "KRPvKR".
+var syzygyTableName = regexp.MustCompile(`^K[QRBNP]*vK[QRBNP]*$`)
+
+// SyzygyTables summarises the tablebase files found in a Syzygy path.
+type SyzygyTables struct {
+	// WDL and DTZ are the numbers of valid WDL and DTZ tables.
+	WDL, DTZ int
+	// MaxPieces is the most pieces, kings included, covered by a WDL table.
+	MaxPieces int
+}
+
+// FindSyzygyTables scans the directories of path, separated like PATH, for
+// Syzygy tables. Files that aren't named after their material or don't start
+// with the magic number of their kind are skipped. It returns an error if a
+// directory cannot be read.
+func FindSyzygyTables(path string) (SyzygyTables, error) {
+	var tables SyzygyTables
+	err := walkSyzygyTables(path, func(_, material string, dtz bool) {
+		if dtz {
+			tables.DTZ++
+			return
+		}
+		tables.WDL++
+		tables.MaxPieces = max(tables.MaxPieces, len(material)-1) // minus the "v"
+	})
+	if err != nil {
+		return SyzygyTables{}, err
+	}
+	return tables, nil
+}
+
+// walkSyzygyTables calls visit with every valid table file in the directories
+// of path, the material it covers and whether it is a DTZ table.
+func walkSyzygyTables(path string, visit func(file, material string, dtz bool)) error {
+	for _, dir := range filepath.SplitList(path) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return fmt.Errorf("failed to read tablebase directory: %w", err)
+		}
+		for _, entry := range entries {
+			ext := filepath.Ext(entry.Name())
+			material := strings.TrimSuffix(entry.Name(), ext)
+			if entry.IsDir() || !syzygyTableName.MatchString(material) {
+				continue
+			}
+			file := filepath.Join(dir, entry.Name())
+			switch {
+			case ext == syzygyWDLExt && hasMagic(file, syzygyWDLMagic):
+				visit(file, material, false)
+			case ext == syzygyDTZExt && hasMagic(file, syzygyDTZMagic):
+				visit(file, material, true)
+			}
+		}
+	}
+	return nil
+}
+
+// hasMagic reports whether the file at path starts with magic.
+func hasMagic(path string, magic []byte) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	header := make([]byte, len(magic))
+	if _, err := io.ReadFull(f, header); err != nil {
+		return false
+	}
+	return string(header) == string(magic)
+}
+
+// WithSyzygyPath gives UCI engines the directories of Syzygy tablebases, as
+// their SyzygyPath option. Other engines ignore it.
+func WithSyzygyPath(path string) EngineOption {
+	return func(c *engineConfig) error {
+		c.syzygyPath = path
+		return nil
+	}
+}
@@ -0,0 +1,488 @@
+package bot
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// WDL is the result of a position under perfect play, from the perspective of
+// the side to move. Cursed wins and blessed losses are won or lost positions
+// that are drawn by the fifty-move rule.
+type WDL int
+
+const (
+	// WDLLoss is a loss.
+	WDLLoss WDL = -2
+	// WDLBlessedLoss is a loss that the fifty-move rule saves.
+	WDLBlessedLoss WDL = -1
+	// WDLDraw is a draw.
+	WDLDraw WDL = 0
+	// WDLCursedWin is a win that the fifty-move rule spoils.
+	WDLCursedWin WDL = 1
+	// WDLWin is a win.
+	WDLWin WDL = 2
+)
+
+// String returns a string representation of the result.
+func (w WDL) String() string {
+	switch w {
+	case WDLLoss:
+		return "Loss"
+	case WDLBlessedLoss:
+		return "Blessed loss"
+	case WDLDraw:
+		return "Draw"
+	case WDLCursedWin:
+		return "Cursed win"
+	case WDLWin:
+		return "Win"
+	default:
+		return "Unknown"
+	}
+}
+
+// TablebaseResult is the tablebase verdict on a position, from the
+// perspective of the side to move.
+type TablebaseResult struct {
+	WDL WDL
+	// DTZ is the distance in plies to the next capture or pawn move under
+	// perfect play, positive when winning and negative when losing. It is 0
+	// for draws, and for won or lost positions when no DTZ table covers them.
+	DTZ int
+}
+
+// Negate returns the result from the other side's perspective.
+func (r TablebaseResult) Negate() TablebaseResult {
+	return TablebaseResult{WDL: -r.WDL, DTZ: -r.DTZ}
+}
+
+// Tablebases are the Syzygy tables found in a Syzygy path, probed by the
+// built-in bots and the analysis. Tables are opened the first time a position
+// needs them, and a Tablebases may be probed from several goroutines at once.
+type Tablebases struct {
+	tables    map[string]*tbEntry // by material key, both ways round
+	maxPieces int
+}
+
+// tbEntry is the WDL table of some material and its DTZ table, nil if missing.
+type tbEntry struct {
+	wdl, dtz *tbTable
+}
+
+// OpenTablebases indexes the Syzygy tables in the directories of path,
+// separated like PATH, as FindSyzygyTables does. Tables are only read when
+// first probed. It returns an error if a directory cannot be read.
+func OpenTablebases(path string) (*Tablebases, error) {
+	tb := &Tablebases{tables: make(map[string]*tbEntry)}
+	dtz := make(map[string]*tbTable)
+	err := walkSyzygyTables(path, func(file, material string, isDTZ bool) {
+		table := newTBTable(file, material, isDTZ)
+		if table.pieceCount > tbPieces {
+			return
+		}
+		if isDTZ {
+			dtz[table.key], dtz[table.key2] = table, table
+			return
+		}
+		entry := &tbEntry{wdl: table}
+		tb.tables[table.key], tb.tables[table.key2] = entry, entry
+		tb.maxPieces = max(tb.maxPieces, table.pieceCount)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// DTZ tables are only of use next to the WDL table of the same material
+	for key, entry := range tb.tables {
+		entry.dtz = dtz[key]
+	}
+	return tb, nil
+}
+
+// MaxPieces returns the most pieces, kings included, the tables cover.
+func (tb *Tablebases) MaxPieces() int {
+	if tb == nil {
+		return 0
+	}
+	return tb.maxPieces
+}
+
+var (
+	tablebasesMu sync.RWMutex
+	tablebases   *Tablebases
+)
+
+// UseTablebases makes tb the tablebases that new built-in bots and analyses
+// probe, or stops them probing if tb is nil. It is meant to be called at
+// startup, before any bot is created.
+func UseTablebases(tb *Tablebases) {
+	tablebasesMu.Lock()
+	defer tablebasesMu.Unlock()
+	tablebases = tb
+}
+
+// defaultTablebases returns the tablebases set with UseTablebases.
+func defaultTablebases() *Tablebases {
+	tablebasesMu.RLock()
+	defer tablebasesMu.RUnlock()
+	return tablebases
+}
+
+// WithTablebases sets the tablebases a minimax engine probes instead of those
+// set with UseTablebases; nil stops it probing.
+func WithTablebases(tb *Tablebases) EngineOption {
+	return func(c *engineConfig) error {
+		c.tablebases = tb
+		return nil
+	}
+}
+
+// errTBMissing reports a position some of whose tables are missing.
+var errTBMissing = errors.New("tablebase not found")
+
+// covers reports whether the tables may hold the position on board: standard
+// chess without castling rights and few enough pieces. Capturing moves into
+// material without tables are only found while probing.
+func (tb *Tablebases) covers(board *engine.Board) bool {
+	if tb == nil || board.CastlingRights != 0 {
+		return false
+	}
+	if _, standard := board.Variant.(engine.Standard); board.Variant != nil && !standard {
+		return false
+	}
+	pieces := 0
+	for _, p := range board.Squares {
+		if !p.IsEmpty() {
+			pieces++
+		}
+	}
+	if pieces > tb.maxPieces {
+		return false
+	}
+	_, ok := tb.tables[materialKey(board)]
+	return ok || pieces == 2
+}
+
+// Probe returns the tablebase result of the position on board. It reports
+// false if the position isn't covered by the tables, or a table can't be read.
+func (tb *Tablebases) Probe(board *engine.Board) (TablebaseResult, bool) {
+	if !tb.covers(board) {
+		return TablebaseResult{}, false
+	}
+	board = board.Copy()
+	wdl, err := tb.probeWDL(board)
+	if err != nil {
+		return TablebaseResult{}, false
+	}
+	result := TablebaseResult{WDL: wdl}
+	if dtz, err := tb.probeDTZ(board); err == nil {
+		result.DTZ = dtz
+	}
+	return result, true
+}
+
+// probeTable probes the WDL or DTZ table of the position's material.
+func (tb *Tablebases) probeTable(board *engine.Board, dtz bool, wdl WDL) (value int, changeSTM bool, err error) {
+	key := materialKey(board)
+	if key == "KvK" {
+		return int(WDLDraw), false, nil
+	}
+	entry := tb.tables[key]
+	switch {
+	case entry == nil:
+		return 0, false, errTBMissing
+	case dtz && entry.dtz == nil:
+		return 0, false, errTBMissing
+	case dtz:
+		return entry.dtz.probe(board, wdl)
+	default:
+		return entry.wdl.probe(board, wdl)
+	}
+}
+
+// zeroing reports whether move resets the fifty-move counter.
+func zeroing(board *engine.Board, move engine.Move) bool {
+	return isCapture(board, move) || board.PieceAt(move.From).Type() == engine.Pawn
+}
+
+// search returns the WDL score of the position on board. Tables store
+// arbitrary values for positions where capturing is best, and know nothing of
+// en passant, so captures are searched first; with checkZeroing, pawn moves
+// too. It also reports whether the best move resets the fifty-move counter,
+// in which case a DTZ table can't be trusted either.
+func (tb *Tablebases) search(board *engine.Board, checkZeroing bool) (WDL, bool, error) {
+	moves := board.LegalMoves()
+	if len(moves) == 0 {
+		if board.InCheck() {
+			return WDLLoss, false, nil
+		}
+		return WDLDraw, false, nil
+	}
+
+	best, searched := WDLLoss, 0
+	for _, move := range moves {
+		if !isCapture(board, move) && (!checkZeroing || board.PieceAt(move.From).Type() != engine.Pawn) {
+			continue
+		}
+		searched++
+
+		board.PushMove(move)
+		value, _, err := tb.search(board, false)
+		board.PopMove()
+		if err != nil {
+			return WDLDraw, false, err
+		}
+		if -value > best {
+			best = -value
+			if best >= WDLWin {
+				return best, true, nil
+			}
+		}
+	}
+
+	// With every move searched the table isn't needed, and may be wrong
+	if searched == len(moves) {
+		return best, true, nil
+	}
+	value, _, err := tb.probeTable(board, false, WDLDraw)
+	if err != nil {
+		return WDLDraw, false, err
+	}
+	if best >= WDL(value) {
+		return best, best > WDLDraw, nil
+	}
+	return WDL(value), false, nil
+}
+
+// probeWDL returns the WDL score of the position on board.
+func (tb *Tablebases) probeWDL(board *engine.Board) (WDL, error) {
+	wdl, _, err := tb.search(board, false)
+	return wdl, err
+}
+
+// dtzBeforeZeroing returns the DTZ of a position whose best move resets the
+// fifty-move counter and leads to a position of score wdl.
+func dtzBeforeZeroing(wdl WDL) int {
+	switch wdl {
+	case WDLWin:
+		return 1
+	case WDLCursedWin:
+		return 101
+	case WDLBlessedLoss:
+		return -101
+	case WDLLoss:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// probeDTZ returns the DTZ of the position on board in plies: positive when
+// winning, negative when losing, 0 for a draw. Cursed wins and blessed losses
+// count 100 more than the fifty-move rule allows.
+func (tb *Tablebases) probeDTZ(board *engine.Board) (int, error) {
+	moves := board.LegalMoves()
+	if len(moves) == 0 {
+		if board.InCheck() {
+			return -1, nil
+		}
+		return 0, nil
+	}
+
+	wdl, zeroingBest, err := tb.search(board, true)
+	if err != nil || wdl == WDLDraw {
+		return 0, err
+	}
+	if zeroingBest {
+		return dtzBeforeZeroing(wdl), nil
+	}
+
+	dtz, changeSTM, err := tb.probeTable(board, true, wdl)
+	if err != nil {
+		return 0, err
+	}
+	if !changeSTM {
+		if wdl == WDLCursedWin || wdl == WDLBlessedLoss {
+			dtz += 100
+		}
+		if wdl < 0 {
+			dtz = -dtz
+		}
+		return dtz, nil
+	}
+
+	// The table holds the other side to move: take the best DTZ one ply on,
+	// only counting moves that keep the result
+	minDTZ := 0xffff
+	for _, move := range moves {
+		isZeroing := zeroing(board, move)
+		board.PushMove(move)
+		mates := board.InCheck() && len(board.LegalMoves()) == 0
+		if isZeroing {
+			var value WDL
+			if value, _, err = tb.search(board, false); err == nil {
+				dtz = -dtzBeforeZeroing(value)
+			}
+		} else if dtz, err = tb.probeDTZ(board); err == nil {
+			dtz = -dtz
+			if dtz != 0 {
+				dtz += sign(dtz)
+			}
+		}
+		board.PopMove()
+		if err != nil {
+			return 0, err
+		}
+
+		if mates {
+			minDTZ = 1
+		}
+		if dtz < minDTZ && sign(dtz) == sign(int(wdl)) {
+			minDTZ = dtz
+		}
+	}
+	if minDTZ == 0xffff {
+		return -1, nil
+	}
+	return minDTZ, nil
+}
+
+// sign returns -1, 0 or 1 as n is negative, zero or positive.
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// maxTBRank is the rank of a move that wins at once.
+const maxTBRank = 1 << 18
+
+// rankRootMoves ranks the legal moves of the position on board by their
+// tablebase results, higher is better: wins that the fifty-move rule can't
+// spoil, the quickest first, then cursed wins, draws and losses, the longest
+// first. Without DTZ tables moves are ranked by their WDL score only.
+func (tb *Tablebases) rankRootMoves(board *engine.Board) ([]engine.Move, []int, error) {
+	board = board.Copy()
+	moves := board.LegalMoves()
+	ranks := make([]int, len(moves))
+	if dtzRanks, err := tb.rankByDTZ(board, moves); err == nil {
+		return moves, dtzRanks, nil
+	}
+	for i, move := range moves {
+		board.PushMove(move)
+		wdl, err := tb.probeWDL(board)
+		draw := isDraw(board)
+		board.PopMove()
+		if err != nil {
+			return nil, nil, err
+		}
+		if !draw {
+			ranks[i] = -int(wdl)
+		}
+	}
+	return moves, ranks, nil
+}
+
+// rankByDTZ ranks moves by the DTZ of the positions they lead to.
+func (tb *Tablebases) rankByDTZ(board *engine.Board, moves []engine.Move) ([]int, error) {
+	cnt50 := int(board.HalfMoveClock)
+	ranks := make([]int, len(moves))
+	for i, move := range moves {
+		board.PushMove(move)
+		var dtz int
+		var err error
+		switch {
+		case board.HalfMoveClock == 0:
+			var wdl WDL
+			if wdl, err = tb.probeWDL(board); err == nil {
+				dtz = dtzBeforeZeroing(-wdl)
+			}
+		case isDraw(board):
+			dtz = 0
+		default:
+			if dtz, err = tb.probeDTZ(board); err == nil {
+				dtz = -dtz
+				if dtz != 0 {
+					dtz += sign(dtz)
+				}
+			}
+		}
+		if dtz == 2 && board.InCheck() && len(board.LegalMoves()) == 0 {
+			dtz = 1 // mate
+		}
+		board.PopMove()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case dtz > 0 && dtz+cnt50 <= 99:
+			ranks[i] = maxTBRank - dtz
+		case dtz > 0:
+			ranks[i] = maxTBRank - (dtz + cnt50)
+		case dtz < 0:
+			ranks[i] = -maxTBRank - dtz + cnt50
+		}
+	}
+	return ranks, nil
+}
+
+// isDraw reports whether the position on board is drawn by rule, or may be claimed as one.
+func isDraw(board *engine.Board) bool {
+	switch board.Status() {
+	case engine.Stalemate, engine.DrawInsufficientMaterial, engine.DrawFiftyMoveRule,
+		engine.DrawSeventyFiveMoveRule, engine.DrawThreefoldRepetition, engine.DrawFivefoldRepetition:
+		return true
+	default:
+		return false
+	}
+}
+
+// tablebaseMoves returns the best moves of the position on board by the
+// tablebases, and the score of the position for the side to move. It reports
+// false if the tables don't cover the position.
+func (tb *Tablebases) tablebaseMoves(board *engine.Board) ([]engine.Move, float64, bool) {
+	result, ok := tb.Probe(board)
+	if !ok {
+		return nil, 0, false
+	}
+	moves, ranks, err := tb.rankRootMoves(board)
+	if err != nil || len(moves) == 0 {
+		return nil, 0, false
+	}
+	best := ranks[0]
+	for _, r := range ranks {
+		best = max(best, r)
+	}
+	var bestMoves []engine.Move
+	for i, move := range moves {
+		if ranks[i] == best {
+			bestMoves = append(bestMoves, move)
+		}
+	}
+	return bestMoves, tablebaseScore(result.WDL, 0), true
+}
+
+// tbWinScore is the score in pawns of a position the tablebases show won:
+// above any evaluation, but below mate scores so a mate found is still preferred.
+const tbWinScore = 1000.0
+
+// tablebaseScore returns the score of a position of the given WDL score, ply
+// plies from the root, from the side to move's perspective. Cursed wins and
+// blessed losses score as draws, as the fifty-move rule decides them.
+func tablebaseScore(wdl WDL, ply int) float64 {
+	switch wdl {
+	case WDLWin:
+		return tbWinScore - float64(ply)
+	case WDLLoss:
+		return -tbWinScore + float64(ply)
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,411 @@
+package bot
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// kqk holds KQvK worked out by retrograde analysis, by side to move and index
+// of the table layout used by writeKQvK.
+type kqk struct {
+	boards [2]map[uint64]*engine.Board // a position of each index
+	wdl    [2][]int                    // table values: WDL score + 2
+	dtz    [2][]int                    // DTZ in plies, negative when losing
+}
+
+var (
+	kqkOnce  sync.Once
+	kqkTable kqk
+)
+
+// kqkLayouts return the layouts of the KQvK tables written by writeKQvK.
+func kqkLayouts() (wdl, dtz *tbTable) {
+	wdl = testLayout("KQvK", false, tbWK, tbWQ, tbBK)
+	dtz = testLayout("KQvK", true, tbWK, tbWQ, tbBK) // White to move only
+	return wdl, dtz
+}
+
+// solveKQvK works out the WDL score and DTZ of every KQvK position.
+func solveKQvK() kqk {
+	kqkOnce.Do(func() {
+		layout, _ := kqkLayouts()
+		k := &kqkTable
+		for side := range 2 {
+			k.boards[side] = make(map[uint64]*engine.Board)
+			k.wdl[side] = make([]int, 31332)
+			k.dtz[side] = make([]int, 31332)
+			for i := range k.wdl[side] {
+				k.wdl[side][i] = 2
+			}
+		}
+		index := func(board *engine.Board) (int, uint64) {
+			pos, _ := layout.index(board)
+			return pos.stm, pos.idx
+		}
+
+		// Every position has a symmetric one with the white king in the a1-d1-d4 triangle
+		for _, wk := range []int{0, 1, 2, 3, 9, 10, 11, 18, 19, 27} {
+			for q := 0; q < 64; q++ {
+				for bk := 0; bk < 64; bk++ {
+					if wk == q || q == bk || wk == bk {
+						continue
+					}
+					for _, stm := range []engine.Color{engine.White, engine.Black} {
+						if board, ok := testBoard(map[int]byte{wk: tbWK, q: tbWQ, bk: tbBK}, stm); ok {
+							side, idx := index(board)
+							if _, seen := k.boards[side][idx]; !seen {
+								k.boards[side][idx] = board
+							}
+						}
+					}
+				}
+			}
+		}
+
+		// White always wins; Black draws by stalemate or taking the queen
+		successors := [2]map[uint64][]uint64{{}, {}}
+		for side, boards := range k.boards {
+			for idx, board := range boards {
+				if side == 0 {
+					k.wdl[0][idx] = 4
+				} else {
+					k.wdl[1][idx] = 0
+					if !board.InCheck() && len(board.LegalMoves()) == 0 {
+						k.wdl[1][idx] = 2
+					}
+				}
+				for _, move := range board.LegalMoves() {
+					if isCapture(board, move) {
+						k.wdl[1][idx] = 2
+						continue
+					}
+					board.PushMove(move)
+					_, next := index(board)
+					board.PopMove()
+					successors[side][idx] = append(successors[side][idx], next)
+				}
+			}
+		}
+
+		// Mated positions are 0 plies from mate, and each ply back one more
+		known := [2]map[uint64]int{{}, {}}
+		for idx, board := range k.boards[1] {
+			if k.wdl[1][idx] == 0 && board.InCheck() && len(successors[1][idx]) == 0 {
+				known[1][idx] = 0
+			}
+		}
+		for plies := 1; ; plies++ {
+			side := plies % 2 // White wins in an odd number of plies
+			found := make(map[uint64]int)
+			for idx := range k.boards[1-side] {
+				if _, done := known[1-side][idx]; done || (side == 0 && k.wdl[1][idx] != 0) {
+					continue
+				}
+				all, any := true, false
+				for _, next := range successors[1-side][idx] {
+					_, ok := known[side][next]
+					all, any = all && ok, any || ok
+				}
+				if (side == 1 && any) || (side == 0 && all) {
+					found[idx] = plies
+				}
+			}
+			if len(found) == 0 {
+				break
+			}
+			for idx, v := range found {
+				known[1-side][idx] = v
+			}
+		}
+		for idx, plies := range known[0] {
+			k.dtz[0][idx] = plies
+		}
+		for idx, plies := range known[1] {
+			k.dtz[1][idx] = -plies
+		}
+	})
+	return kqkTable
+}
+
+// writeKQvK writes KQvK tables into dir: the WDL table, and the DTZ table of
+// White to move with the given flags unless dtzFlags is -1. Without
+// tbFlagWinPlies the wins are stored in moves, and with tbFlagMapped through a
+// map listing them longest first.
+func writeKQvK(t *testing.T, dir string, dtzFlags int) {
+	t.Helper()
+	k := solveKQvK()
+	wdl, dtz := kqkLayouts()
+	writeTBFile(t, dir, wdl, []tbPart{{values: k.wdl[0]}, {values: k.wdl[1]}})
+	if dtzFlags < 0 {
+		return
+	}
+
+	part := tbPart{flags: byte(dtzFlags), values: make([]int, len(k.dtz[0]))}
+	if part.flags&tbFlagMapped != 0 {
+		for v := 18; v >= 0; v-- {
+			part.dtzMap[0] = append(part.dtzMap[0], v)
+		}
+	}
+	for idx, plies := range k.dtz[0] {
+		v := max(plies-1, 0) // Stored one less
+		if part.flags&tbFlagWinPlies == 0 {
+			v /= 2 // wins take an odd number of plies
+		}
+		if part.flags&tbFlagMapped != 0 {
+			v = slices.Index(part.dtzMap[0], v)
+		}
+		part.values[idx] = v
+	}
+	dtz.items[0][0].flags = part.flags
+	writeTBFile(t, dir, dtz, []tbPart{part})
+}
+
+// openKQvK returns tablebases of KQvK only, with a DTZ table if withDTZ is set.
+func openKQvK(t *testing.T, withDTZ bool) *Tablebases {
+	t.Helper()
+	flags := -1
+	if withDTZ {
+		flags = tbFlagWinPlies | tbFlagLossPlies
+	}
+	return openKQvKFlags(t, flags)
+}
+
+// openKQvKFlags returns tablebases of KQvK only, written by writeKQvK.
+func openKQvKFlags(t *testing.T, dtzFlags int) *Tablebases {
+	t.Helper()
+	dir := t.TempDir()
+	writeKQvK(t, dir, dtzFlags)
+	tb, err := OpenTablebases(dir)
+	if err != nil {
+		t.Fatalf("OpenTablebases() error = %v", err)
+	}
+	if tb.MaxPieces() != 3 {
+		t.Fatalf("MaxPieces() = %d, want 3", tb.MaxPieces())
+	}
+	return tb
+}
+
+// colorFlipped returns board with colors swapped and ranks mirrored.
+func colorFlipped(board *engine.Board) *engine.Board {
+	flipped := &engine.Board{ActiveColor: 1 - board.ActiveColor, EnPassantSq: -1, FullMoveNum: 1}
+	for sq, p := range board.Squares {
+		if !p.IsEmpty() {
+			flipped.Squares[sq^56] = engine.NewPiece(1-p.Color(), p.Type())
+		}
+	}
+	flipped.Hash = flipped.ComputeHash()
+	return flipped
+}
+
+func TestTablebasesProbe(t *testing.T) {
+	k := solveKQvK()
+	// The longest KQvK win is mate in 10
+	if longest := slices.Max(k.dtz[0]); longest != 19 {
+		t.Fatalf("longest win is %d plies, want 19", longest)
+	}
+
+	tests := []struct {
+		name  string
+		flags int
+	}{
+		{"plies", tbFlagWinPlies | tbFlagLossPlies},
+		{"moves", 0},
+		{"mapped", tbFlagMapped},
+		{"wide map", tbFlagMapped | tbFlagWide},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkKQvK(t, k, openKQvKFlags(t, tt.flags))
+		})
+	}
+}
+
+// checkKQvK checks the results tb gives for a sample of KQvK positions, and
+// the same positions with colors swapped.
+func checkKQvK(t *testing.T, k kqk, tb *Tablebases) {
+	t.Helper()
+
+	probed := 0
+	for side, boards := range k.boards {
+		for idx, board := range boards {
+			if idx%7 != 0 {
+				continue // a sample keeps the test quick
+			}
+			want := TablebaseResult{WDL: WDL(k.wdl[side][idx] - 2), DTZ: k.dtz[side][idx]}
+			if want.WDL == WDLLoss && want.DTZ == 0 {
+				want.DTZ = -1 // mated
+			}
+			for _, b := range []*engine.Board{board, colorFlipped(board)} {
+				got, ok := tb.Probe(b)
+				if !ok {
+					t.Fatalf("Probe(%s) not covered", b.ToFEN())
+				}
+				if got != want {
+					t.Fatalf("Probe(%s) = %+v, want %+v", b.ToFEN(), got, want)
+				}
+			}
+			probed++
+		}
+	}
+	if probed < 1000 {
+		t.Errorf("probed %d positions, want at least 1000", probed)
+	}
+}
+
+func TestTablebasesProbeNotCovered(t *testing.T) {
+	tb := openKQvK(t, true)
+	for _, fen := range []string{
+		"8/8/8/4k3/8/8/8/KR6 w - - 0 1",   // no table
+		"4k3/8/8/8/8/8/8/KQ2R3 w - - 0 1", // too many pieces
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+	} {
+		board, err := engine.FromFEN(fen)
+		if err != nil {
+			t.Fatalf("FromFEN(%q) error = %v", fen, err)
+		}
+		if result, ok := tb.Probe(board); ok {
+			t.Errorf("Probe(%s) = %+v, want not covered", fen, result)
+		}
+	}
+
+	var none *Tablebases
+	if _, ok := none.Probe(engine.NewBoard()); ok {
+		t.Error("Probe() of nil tablebases covered a position")
+	}
+}
+
+func TestTablebasesWithoutDTZ(t *testing.T) {
+	tb := openKQvK(t, false)
+	board, err := engine.FromFEN("8/8/8/4k3/8/8/8/KQ6 w - - 0 1")
+	if err != nil {
+		t.Fatalf("FromFEN() error = %v", err)
+	}
+	got, ok := tb.Probe(board)
+	if want := (TablebaseResult{WDL: WDLWin}); !ok || got != want {
+		t.Errorf("Probe() = %+v, %v; want %+v with no DTZ", got, ok, want)
+	}
+
+	// Moves are still ranked by result: the queen isn't given away
+	moves, score, ok := tb.tablebaseMoves(board)
+	if !ok || score != tbWinScore {
+		t.Fatalf("tablebaseMoves() = %v, %v, %v; want winning moves", moves, score, ok)
+	}
+	for _, move := range moves {
+		board.PushMove(move)
+		if result, _ := tb.Probe(board); result.WDL != WDLLoss {
+			t.Errorf("move %s leaves Black %v, want a loss", move, result.WDL)
+		}
+		board.PopMove()
+	}
+}
+
+func TestMinimaxEngine_TablebaseMates(t *testing.T) {
+	tb := openKQvK(t, true)
+	rng := rand.New(rand.NewSource(1))
+
+	for _, fen := range []string{
+		"8/8/8/4k3/8/8/8/KQ6 w - - 0 1",
+		"8/8/3k4/8/8/8/1Q6/6K1 w - - 0 1",
+		"k7/8/8/8/8/8/8/1Q5K w - - 0 1",
+	} {
+		for _, difficulty := range []Difficulty{Medium, Hard} {
+			board, err := engine.FromFEN(fen)
+			if err != nil {
+				t.Fatalf("FromFEN(%q) error = %v", fen, err)
+			}
+			start, _ := tb.Probe(board)
+			eng, err := NewMinimaxEngine(difficulty, WithTablebases(tb), WithDeterministic(true), WithSearchDepth(2))
+			if err != nil {
+				t.Fatalf("NewMinimaxEngine() error = %v", err)
+			}
+			if !eng.(Inspectable).Info().Features["tablebases"] {
+				t.Error("Info() doesn't list tablebases")
+			}
+
+			// Against any defence, each move takes the shortest way to mate
+			plies := 0
+			for !board.IsGameOver() && plies <= start.DTZ {
+				var move engine.Move
+				if board.ActiveColor == engine.White {
+					move, err = eng.SelectMove(context.Background(), board)
+					if err != nil {
+						t.Fatalf("SelectMove(%s) error = %v", board.ToFEN(), err)
+					}
+				} else {
+					moves := board.LegalMoves()
+					move = moves[rng.Intn(len(moves))]
+				}
+				if err := board.MakeMove(move); err != nil {
+					t.Fatalf("MakeMove(%s) error = %v", move, err)
+				}
+				plies++
+			}
+			if board.Status() != engine.Checkmate || plies > start.DTZ {
+				t.Errorf("%v from %s: %v after %d plies, want mate within %d", difficulty, fen, board.Status(), plies, start.DTZ)
+			}
+			eng.Close()
+		}
+	}
+}
+
+func TestMinimaxEngine_TablebaseScoresExchanges(t *testing.T) {
+	tb := openKQvK(t, true)
+	eng, err := NewMinimaxEngine(Medium, WithTablebases(tb))
+	if err != nil {
+		t.Fatalf("NewMinimaxEngine() error = %v", err)
+	}
+	me := eng.(*minimaxEngine)
+
+	// Just after a capture the tables score the position exactly
+	board, err := engine.FromFEN("8/8/8/4k3/8/8/8/KQ6 b - - 0 1")
+	if err != nil {
+		t.Fatalf("FromFEN() error = %v", err)
+	}
+	if got, want := me.alphaBeta(context.Background(), board, 2, math.Inf(-1), math.Inf(1), 3), -tbWinScore+3; got != want {
+		t.Errorf("alphaBeta() = %v, want %v", got, want)
+	}
+
+	// The analysis takes the tables' word for the position
+	_, score, err := me.evaluatePosition(context.Background(), board)
+	if err != nil || score != -tbWinScore {
+		t.Errorf("evaluatePosition() = %v, %v; want %v", score, err, -tbWinScore)
+	}
+}
+
+func TestReviewMove_Tablebase(t *testing.T) {
+	UseTablebases(openKQvK(t, true))
+	defer UseTablebases(nil)
+
+	board, err := engine.FromFEN("8/8/8/4k3/8/8/8/KQ6 w - - 0 1")
+	if err != nil {
+		t.Fatalf("FromFEN() error = %v", err)
+	}
+	// Qe4+ hangs the queen to the king
+	blunder := engine.Move{From: engine.NewSquare(1, 0), To: engine.NewSquare(4, 3)}
+	review, err := ReviewMove(context.Background(), board, blunder, DefaultAnalysisDepth)
+	if err != nil {
+		t.Fatalf("ReviewMove() error = %v", err)
+	}
+	if review.Tablebase == nil || review.Tablebase.WDL != WDLDraw {
+		t.Errorf("ReviewMove().Tablebase = %+v, want a draw", review.Tablebase)
+	}
+	if review.Classification != MoveBlunder {
+		t.Errorf("ReviewMove().Classification = %v, want %v", review.Classification, MoveBlunder)
+	}
+
+	// Without tables the review has no tablebase result
+	UseTablebases(nil)
+	review, err = ReviewMove(context.Background(), board, blunder, 1)
+	if err != nil {
+		t.Fatalf("ReviewMove() error = %v", err)
+	}
+	if review.Tablebase != nil {
+		t.Errorf("ReviewMove().Tablebase = %+v without tables, want nil", review.Tablebase)
+	}
+}
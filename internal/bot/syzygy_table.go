@@ -0,0 +1,947 @@
+package bot
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// This file decodes Syzygy table files, following the layout of the reference
+// probing code by Ronald de Man as ported to Stockfish. A table splits its
+// positions by side to move and, with pawns, by the file of the leading pawn.
+// Each part maps a position to an index, and the index to a value compressed
+// with recursive pairing and a canonical Huffman code in blocks of bits.
+
+// tbPieces is the most pieces a Syzygy table can hold.
+const tbPieces = 7
+
+// Flags of the parts of a table.
+const (
+	tbFlagSTM         = 1   // DTZ: the part stores black to move
+	tbFlagMapped      = 2   // DTZ: values go through the map
+	tbFlagWinPlies    = 4   // DTZ: wins are stored in plies rather than moves
+	tbFlagLossPlies   = 8   // DTZ: losses are stored in plies rather than moves
+	tbFlagWide        = 16  // DTZ: the map holds 16-bit values
+	tbFlagSingleValue = 128 // every position of the part has the same value
+)
+
+// Flags of a table file's first byte.
+const (
+	tbFileSplit    = 1 // the file stores both sides to move
+	tbFileHasPawns = 2
+)
+
+// Index tables shared by all tables, filled in by init.
+var (
+	tbMapB1H1H7     [64]int       // squares below the a1-h8 diagonal to 0..27
+	tbMapA1D1D4     [64]int       // squares of the a1-d1-d4 triangle to 0..9
+	tbMapKK         [10][64]int   // the 462 placements of two kings
+	tbBinomial      [6][64]uint64 // tbBinomial[k][n]: ways to choose k of n
+	tbMapPawns      [64]int       // pawn squares to 0..47, the leading pawn highest
+	tbLeadPawnIdx   [6][64]uint64 // start index of a leading pawn square
+	tbLeadPawnsSize [6][4]uint64  // indexes per file of the leading pawn
+)
+
+func init() {
+	code := 0
+	for s := 0; s < 64; s++ {
+		if tbOffDiagonal(s) < 0 {
+			tbMapB1H1H7[s] = code
+			code++
+		}
+	}
+
+	// The triangle's squares off the diagonal come first, then the diagonal
+	var diagonal []int
+	code = 0
+	for s := 0; s <= 27; s++ {
+		switch {
+		case tbOffDiagonal(s) < 0 && s%8 <= 3:
+			tbMapA1D1D4[s] = code
+			code++
+		case tbOffDiagonal(s) == 0 && s%8 <= 3:
+			diagonal = append(diagonal, s)
+		}
+	}
+	for _, s := range diagonal {
+		tbMapA1D1D4[s] = code
+		code++
+	}
+
+	// The first king is in the triangle, and if it is on the diagonal the
+	// other isn't above it. Kings both on the diagonal come last.
+	type pair struct{ idx, s2 int }
+	var bothOnDiagonal []pair
+	code = 0
+	for idx := 0; idx < 10; idx++ {
+		for s1 := 0; s1 <= 27; s1++ {
+			if tbMapA1D1D4[s1] != idx || (idx == 0 && s1 != 1) { // b1 is 0
+				continue
+			}
+			for s2 := 0; s2 < 64; s2++ {
+				switch {
+				case tbSquareDistance(s1, s2) <= 1:
+				case tbOffDiagonal(s1) == 0 && tbOffDiagonal(s2) > 0:
+				case tbOffDiagonal(s1) == 0 && tbOffDiagonal(s2) == 0:
+					bothOnDiagonal = append(bothOnDiagonal, pair{idx, s2})
+				default:
+					tbMapKK[idx][s2] = code
+					code++
+				}
+			}
+		}
+	}
+	for _, p := range bothOnDiagonal {
+		tbMapKK[p.idx][p.s2] = code
+		code++
+	}
+
+	tbBinomial[0][0] = 1
+	for n := 1; n < 64; n++ {
+		for k := 0; k < 6 && k <= n; k++ {
+			if k > 0 {
+				tbBinomial[k][n] += tbBinomial[k-1][n-1]
+			}
+			if k < n {
+				tbBinomial[k][n] += tbBinomial[k][n-1]
+			}
+		}
+	}
+
+	// Pawns nearer the edge and lower down number higher, as a leading pawn
+	// leaves fewer squares for the others
+	available := 47
+	for count := 1; count <= 5; count++ {
+		for f := 0; f < 4; f++ {
+			var idx uint64
+			for r := 1; r <= 6; r++ {
+				sq := r*8 + f
+				if count == 1 {
+					tbMapPawns[sq] = available
+					available--
+					tbMapPawns[sq^7] = available
+					available--
+				}
+				tbLeadPawnIdx[count][sq] = idx
+				idx += tbBinomial[count-1][tbMapPawns[sq]]
+			}
+			tbLeadPawnsSize[count][f] = idx
+		}
+	}
+}
+
+// tbOffDiagonal returns how far s is above the a1-h8 diagonal, negative below it.
+func tbOffDiagonal(s int) int {
+	return s/8 - s%8
+}
+
+// tbSquareDistance returns the number of king moves between two squares.
+func tbSquareDistance(a, b int) int {
+	return max(abs(a/8-b/8), abs(a%8-b%8))
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// tbPairs is one part of a table: the positions of one side to move and, with
+// pawns, one file of the leading pawn. Offsets are from the start of the file.
+type tbPairs struct {
+	flags           byte
+	sizeofBlock     int64  // bytes per block of compressed data
+	span            uint64 // positions between entries of the sparse index
+	numBlocks       int64
+	minSymLen       int // with tbFlagSingleValue, the value
+	lowestSym       []uint16
+	base64          []uint64 // lowest code of each length, left-aligned
+	symLen          []uint8  // values a symbol expands to, minus one
+	btree           []byte   // the two symbols each symbol pairs, 12 bits each
+	sparseIndex     int64
+	sparseIndexSize uint64
+	blockLength     int64
+	blockLengthSize int64
+	data            int64
+	pieces          [tbPieces]byte       // the pieces in index order
+	groupIdx        [tbPieces + 1]uint64 // multiplier of each group's index
+	groupLen        [tbPieces + 1]int    // pieces per group, zero-terminated
+	mapIdx          [4]int               // DTZ map offsets: win, loss, cursed win, blessed loss
+}
+
+// left and right return the symbols sym pairs; a leaf's left is its value.
+func (d *tbPairs) left(sym int) int {
+	return int(d.btree[3*sym+1]&0xf)<<8 | int(d.btree[3*sym])
+}
+
+func (d *tbPairs) right(sym int) int {
+	return int(d.btree[3*sym+2])<<4 | int(d.btree[3*sym+1]>>4)
+}
+
+// tbTable is a WDL or DTZ table file. Its header is only read on first use.
+type tbTable struct {
+	path string
+	dtz  bool
+	// key is the material with the table's first side as White, e.g. "KRPvKR",
+	// and key2 the same with colors swapped; they are equal for symmetric material
+	key, key2       string
+	pieceCount      int
+	hasPawns        bool
+	hasUniquePieces bool
+	pawnCount       [2]int // of the leading color and the other
+
+	once   sync.Once
+	err    error
+	file   io.ReaderAt
+	dtzMap []byte
+	items  [2][4]tbPairs // [side to move][file of the leading pawn]
+}
+
+// newTBTable returns the table of the file at path, named after its material, e.g. "KRPvKR".
+func newTBTable(path, material string, dtz bool) *tbTable {
+	strong, weak, _ := strings.Cut(material, "v")
+	t := &tbTable{
+		path:       path,
+		dtz:        dtz,
+		key:        strong + "v" + weak,
+		key2:       weak + "v" + strong,
+		pieceCount: len(strong) + len(weak),
+		hasPawns:   strings.Contains(material, "P"),
+	}
+	for _, side := range []string{strong, weak} {
+		for _, p := range "QRBNP" {
+			if strings.Count(side, string(p)) == 1 {
+				t.hasUniquePieces = true
+			}
+		}
+	}
+	// The leading color has the fewest pawns, for better compression
+	white, black := strings.Count(strong, "P"), strings.Count(weak, "P")
+	if black == 0 || (white > 0 && black >= white) {
+		t.pawnCount = [2]int{white, black}
+	} else {
+		t.pawnCount = [2]int{black, white}
+	}
+	return t
+}
+
+// sides returns the number of sides to move the table stores parts for.
+func (t *tbTable) sides() int {
+	if t.dtz {
+		return 1
+	}
+	return 2
+}
+
+// get returns the part of the side to move stm, 0 for the first side, and the
+// file of the leading pawn.
+func (t *tbTable) get(stm, file int) *tbPairs {
+	if !t.hasPawns {
+		file = 0
+	}
+	return &t.items[stm%t.sides()][file]
+}
+
+// open reads the table's header the first time it is needed.
+func (t *tbTable) open() error {
+	t.once.Do(func() {
+		f, err := os.Open(t.path)
+		if err != nil {
+			t.err = err
+			return
+		}
+		if t.err = t.readHeader(f); t.err != nil {
+			f.Close()
+			t.err = fmt.Errorf("%s: %w", t.path, t.err)
+			return
+		}
+		// Kept open for the life of the program, like the tables' memory maps elsewhere
+		t.file = f
+	})
+	return t.err
+}
+
+// errTBCorrupt reports a table file that doesn't decode.
+var errTBCorrupt = errors.New("corrupt tablebase file")
+
+// tbReader reads a table file's header in order, remembering the first error.
+type tbReader struct {
+	r   io.ReaderAt
+	off int64
+	err error
+}
+
+func (c *tbReader) bytes(n int64) []byte {
+	if c.err != nil || n < 0 || n > 1<<24 {
+		c.err = errOr(c.err, errTBCorrupt)
+		return nil
+	}
+	buf := make([]byte, n)
+	if _, err := c.r.ReadAt(buf, c.off); err != nil {
+		c.err = err
+		return nil
+	}
+	c.off += n
+	return buf
+}
+
+func (c *tbReader) u8() int {
+	if b := c.bytes(1); b != nil {
+		return int(b[0])
+	}
+	return 0
+}
+
+func (c *tbReader) u16() int {
+	if b := c.bytes(2); b != nil {
+		return int(binary.LittleEndian.Uint16(b))
+	}
+	return 0
+}
+
+func (c *tbReader) u32() int64 {
+	if b := c.bytes(4); b != nil {
+		return int64(binary.LittleEndian.Uint32(b))
+	}
+	return 0
+}
+
+// align skips to the next multiple of n bytes from the start of the file.
+func (c *tbReader) align(n int64) {
+	c.off = (c.off + n - 1) / n * n
+}
+
+// errOr returns err if it is set, fallback otherwise.
+func errOr(err, fallback error) error {
+	if err != nil {
+		return err
+	}
+	return fallback
+}
+
+// readHeader reads the layout of the table from r: the pieces and groups of
+// every part, the Huffman codes, the DTZ map and where the data lies.
+func (t *tbTable) readHeader(r io.ReaderAt) error {
+	c := &tbReader{r: r}
+	magic := syzygyWDLMagic
+	if t.dtz {
+		magic = syzygyDTZMagic
+	}
+	if string(c.bytes(4)) != string(magic) {
+		return errOr(c.err, errTBCorrupt)
+	}
+
+	if flags := c.u8(); (flags&tbFileHasPawns != 0) != t.hasPawns {
+		return errOr(c.err, errTBCorrupt)
+	}
+	sides := 1
+	if !t.dtz && t.key != t.key2 {
+		sides = 2
+	}
+	maxFile := 0
+	if t.hasPawns {
+		maxFile = 3
+	}
+	pp := t.hasPawns && t.pawnCount[1] > 0 // pawns on both sides
+
+	for f := 0; f <= maxFile; f++ {
+		b0, b1 := c.u8(), 0xff
+		if pp {
+			b1 = c.u8()
+		}
+		order := [2][2]int{{b0 & 0xf, b1 & 0xf}, {b0 >> 4, b1 >> 4}}
+		for k := 0; k < t.pieceCount; k++ {
+			v := byte(c.u8())
+			for i := 0; i < sides; i++ {
+				if i == 0 {
+					t.get(i, f).pieces[k] = v & 0xf
+				} else {
+					t.get(i, f).pieces[k] = v >> 4
+				}
+			}
+		}
+		for i := 0; i < sides; i++ {
+			if !t.validPieces(t.get(i, f)) {
+				return errOr(c.err, errTBCorrupt)
+			}
+			t.setGroups(t.get(i, f), order[i], f)
+		}
+	}
+	c.align(2)
+
+	for f := 0; f <= maxFile; f++ {
+		for i := 0; i < sides; i++ {
+			t.readSizes(t.get(i, f), c)
+		}
+	}
+	if t.dtz {
+		t.readDTZMap(c, maxFile)
+	}
+	for f := 0; f <= maxFile; f++ {
+		for i := 0; i < sides; i++ {
+			d := t.get(i, f)
+			d.sparseIndex = c.off
+			c.off += int64(d.sparseIndexSize) * 6
+		}
+	}
+	for f := 0; f <= maxFile; f++ {
+		for i := 0; i < sides; i++ {
+			d := t.get(i, f)
+			d.blockLength = c.off
+			c.off += d.blockLengthSize * 2
+		}
+	}
+	for f := 0; f <= maxFile; f++ {
+		for i := 0; i < sides; i++ {
+			c.align(64)
+			d := t.get(i, f)
+			d.data = c.off
+			c.off += d.numBlocks * d.sizeofBlock
+		}
+	}
+	return c.err
+}
+
+// validPieces reports whether the pieces of d are the table's material.
+func (t *tbTable) validPieces(d *tbPairs) bool {
+	var want, got []byte
+	strong, weak, _ := strings.Cut(t.key, "v")
+	for _, r := range strong {
+		want = append(want, byte(strings.IndexRune(tbPieceLetters, r)))
+	}
+	for _, r := range weak {
+		want = append(want, byte(strings.IndexRune(tbPieceLetters, r))|8)
+	}
+	got = append(got, d.pieces[:t.pieceCount]...)
+	slices.Sort(want)
+	slices.Sort(got)
+	return slices.Equal(want, got)
+}
+
+// setGroups groups d's pieces the way they are encoded: the leading pawns, or
+// the kings and a unique piece or just the kings, then runs of the same piece.
+// order gives the order the groups' indexes are combined in.
+func (t *tbTable) setGroups(d *tbPairs, order [2]int, file int) {
+	firstLen := 2
+	switch {
+	case t.hasPawns:
+		firstLen = 0
+	case t.hasUniquePieces:
+		firstLen = 3
+	}
+	n := 0
+	d.groupLen[0] = 1
+	for i := 1; i < t.pieceCount; i++ {
+		firstLen--
+		if firstLen > 0 || d.pieces[i] == d.pieces[i-1] {
+			d.groupLen[n]++
+		} else {
+			n++
+			d.groupLen[n] = 1
+		}
+	}
+	n++
+	d.groupLen[n] = 0
+
+	pp := t.hasPawns && t.pawnCount[1] > 0
+	next := 1
+	freeSquares := 64 - d.groupLen[0]
+	if pp {
+		next = 2
+		freeSquares -= d.groupLen[1]
+	}
+	idx := uint64(1)
+	for k := 0; next < n || k == order[0] || k == order[1]; k++ {
+		switch {
+		case k == order[0]:
+			d.groupIdx[0] = idx
+			switch {
+			case t.hasPawns:
+				idx *= tbLeadPawnsSize[d.groupLen[0]][file]
+			case t.hasUniquePieces:
+				idx *= 31332
+			default:
+				idx *= 462
+			}
+		case k == order[1]:
+			d.groupIdx[1] = idx
+			idx *= tbBinomial[d.groupLen[1]][48-d.groupLen[0]]
+		default:
+			d.groupIdx[next] = idx
+			idx *= tbBinomial[d.groupLen[next]][freeSquares]
+			freeSquares -= d.groupLen[next]
+			next++
+		}
+		if k > 2*tbPieces {
+			break // a corrupt order
+		}
+	}
+	d.groupIdx[n] = idx
+}
+
+// readSizes reads the block sizes and Huffman code of d.
+func (t *tbTable) readSizes(d *tbPairs, c *tbReader) {
+	d.flags = byte(c.u8())
+	if d.flags&tbFlagSingleValue != 0 {
+		d.minSymLen = c.u8()
+		return
+	}
+
+	n := 0
+	for d.groupLen[n] != 0 {
+		n++
+	}
+	tbSize := d.groupIdx[n]
+
+	d.sizeofBlock = 1 << c.u8()
+	d.span = 1 << c.u8()
+	d.sparseIndexSize = (tbSize + d.span - 1) / d.span
+	padding := int64(c.u8())
+	d.numBlocks = c.u32()
+	d.blockLengthSize = d.numBlocks + padding
+	maxSymLen := c.u8()
+	d.minSymLen = c.u8()
+	if c.err != nil || maxSymLen < d.minSymLen || d.minSymLen == 0 || maxSymLen > 32 {
+		c.err = errOr(c.err, errTBCorrupt)
+		return
+	}
+
+	// Canonical Huffman: longer codes have lower values, so the lowest code of
+	// each length follows from the lowest symbols of the lengths
+	count := maxSymLen - d.minSymLen + 1
+	d.lowestSym = make([]uint16, count)
+	for i := range d.lowestSym {
+		d.lowestSym[i] = uint16(c.u16())
+	}
+	d.base64 = make([]uint64, count)
+	for i := count - 2; i >= 0; i-- {
+		d.base64[i] = (d.base64[i+1] + uint64(d.lowestSym[i]) - uint64(d.lowestSym[i+1])) / 2
+	}
+	for i := range d.base64 {
+		d.base64[i] <<= 64 - i - d.minSymLen
+	}
+
+	symbols := c.u16()
+	d.btree = c.bytes(int64(symbols) * 3)
+	c.off += int64(symbols & 1)
+	if c.err != nil {
+		return
+	}
+	d.symLen = make([]uint8, symbols)
+	visited := make([]bool, symbols)
+	for sym := 0; sym < symbols; sym++ {
+		if !visited[sym] && !d.setSymLen(sym, visited) {
+			c.err = errTBCorrupt
+			return
+		}
+	}
+}
+
+// setSymLen works out how many values sym expands to, pairs first. It reports
+// false for a symbol pairing symbols that don't exist.
+func (d *tbPairs) setSymLen(sym int, visited []bool) bool {
+	visited[sym] = true
+	right := d.right(sym)
+	if right == 0xfff {
+		d.symLen[sym] = 0
+		return true
+	}
+	left := d.left(sym)
+	if left >= len(d.symLen) || right >= len(d.symLen) {
+		return false
+	}
+	for _, s := range []int{left, right} {
+		if !visited[s] && !d.setSymLen(s, visited) {
+			return false
+		}
+	}
+	d.symLen[sym] = d.symLen[left] + d.symLen[right] + 1
+	return true
+}
+
+// readDTZMap reads the maps DTZ parts store their values through.
+func (t *tbTable) readDTZMap(c *tbReader, maxFile int) {
+	start := c.off
+	for f := 0; f <= maxFile; f++ {
+		d := t.get(0, f)
+		if d.flags&tbFlagMapped == 0 {
+			continue
+		}
+		if d.flags&tbFlagWide != 0 {
+			c.align(2)
+			for i := range d.mapIdx {
+				d.mapIdx[i] = int(c.off-start) + 2
+				n := c.u16()
+				c.off += 2 * int64(n)
+			}
+		} else {
+			for i := range d.mapIdx {
+				d.mapIdx[i] = int(c.off-start) + 1
+				n := c.u8()
+				c.off += int64(n)
+			}
+		}
+	}
+	c.align(2)
+	end := c.off
+	c.off = start
+	t.dtzMap = c.bytes(end - start)
+}
+
+// decompress returns the value stored at idx in d.
+func (t *tbTable) decompress(d *tbPairs, idx uint64) (int, error) {
+	if d.flags&tbFlagSingleValue != 0 {
+		return d.minSymLen, nil
+	}
+
+	// The sparse index points to the block and offset of every span-th value
+	var entry [6]byte
+	k := idx / d.span
+	if k >= d.sparseIndexSize {
+		return 0, errTBCorrupt
+	}
+	if _, err := t.file.ReadAt(entry[:], d.sparseIndex+int64(k)*6); err != nil {
+		return 0, err
+	}
+	block := int64(binary.LittleEndian.Uint32(entry[:4]))
+	offset := int(binary.LittleEndian.Uint16(entry[4:]))
+	offset += int(idx%d.span) - int(d.span/2)
+
+	// Walk to the block holding the value; a block holds its length plus one values
+	blockLength := func(block int64) (int, error) {
+		if block < 0 || block >= d.blockLengthSize {
+			return 0, errTBCorrupt
+		}
+		var b [2]byte
+		if _, err := t.file.ReadAt(b[:], d.blockLength+block*2); err != nil {
+			return 0, err
+		}
+		return int(binary.LittleEndian.Uint16(b[:])), nil
+	}
+	for offset < 0 {
+		block--
+		n, err := blockLength(block)
+		if err != nil {
+			return 0, err
+		}
+		offset += n + 1
+	}
+	for {
+		n, err := blockLength(block)
+		if err != nil {
+			return 0, err
+		}
+		if offset <= n {
+			break
+		}
+		offset -= n + 1
+		block++
+	}
+	if block >= d.numBlocks {
+		return 0, errTBCorrupt
+	}
+
+	// The decoder reads a few bytes ahead, so up to 8 past the block
+	buf := make([]byte, d.sizeofBlock+8)
+	if n, err := t.file.ReadAt(buf, d.data+block*d.sizeofBlock); err != nil && (err != io.EOF || int64(n) < d.sizeofBlock) {
+		return 0, err
+	}
+
+	// Find the symbol covering offset: the code length is found from the
+	// lowest codes of each length, and each symbol stands for symLen+1 values
+	bits := binary.BigEndian.Uint64(buf)
+	pos, available := 8, 64
+	var sym int
+	for {
+		l := 0
+		for bits < d.base64[l] {
+			l++
+			if l == len(d.base64) {
+				return 0, errTBCorrupt
+			}
+		}
+		sym = int((bits-d.base64[l])>>(64-l-d.minSymLen)) + int(d.lowestSym[l])
+		if sym >= len(d.symLen) {
+			return 0, errTBCorrupt
+		}
+		if offset < int(d.symLen[sym])+1 {
+			break
+		}
+		offset -= int(d.symLen[sym]) + 1
+		l += d.minSymLen
+		bits <<= l
+		available -= l
+		if available <= 32 {
+			if pos+4 > len(buf) {
+				return 0, errTBCorrupt
+			}
+			available += 32
+			bits |= uint64(binary.BigEndian.Uint32(buf[pos:])) << (64 - available)
+			pos += 4
+		}
+	}
+
+	// Expand the pairs down to the value at offset
+	for d.symLen[sym] != 0 {
+		left := d.left(sym)
+		if offset < int(d.symLen[left])+1 {
+			sym = left
+		} else {
+			offset -= int(d.symLen[left]) + 1
+			sym = d.right(sym)
+		}
+	}
+	return d.left(sym), nil
+}
+
+// tbPieceLetters are the letters of the piece types in table names, by type.
+const tbPieceLetters = " PNBRQK"
+
+// tbPiece returns the code of p in table files: the piece type, plus 8 for black.
+func tbPiece(p engine.Piece) byte {
+	return byte(p.Type()) | byte(p.Color())<<3
+}
+
+// tbPosition is a position laid out for a table: its squares flipped so the
+// table's first side is White, and the part of the table it is in.
+type tbPosition struct {
+	stm    int // side to move, 0 for the table's first side
+	file   int // file of the leading pawn, counted from the edge
+	idx    uint64
+	pieces int
+}
+
+// index computes where the position on board is stored in t. It reports false
+// for a DTZ table that only stores the other side to move.
+func (t *tbTable) index(board *engine.Board) (tbPosition, bool) {
+	var squares [tbPieces]int
+	var pieces [tbPieces]byte
+	var pos tbPosition
+
+	// The table stores its first side as White: flip colors and ranks when
+	// Black has that material, or when a symmetric table has Black to move
+	flip := materialKey(board) != t.key || (t.key == t.key2 && board.ActiveColor == engine.Black)
+	flipColor, flipSquares := byte(0), 0
+	pos.stm = int(board.ActiveColor)
+	if flip {
+		flipColor, flipSquares = 8, 56
+		pos.stm ^= 1
+	}
+
+	// The leading pawns come first, the one nearest the edge at their head
+	size, leadPawns := 0, 0
+	var isLead [64]bool
+	if t.hasPawns {
+		lead := t.get(0, 0).pieces[0] ^ flipColor
+		for sq := 0; sq < 64; sq++ {
+			if p := board.Squares[sq]; !p.IsEmpty() && tbPiece(p) == lead {
+				squares[size] = sq ^ flipSquares
+				isLead[sq] = true
+				size++
+			}
+		}
+		leadPawns = size
+		best := 0
+		for i := 1; i < leadPawns; i++ {
+			if tbMapPawns[squares[i]] > tbMapPawns[squares[best]] {
+				best = i
+			}
+		}
+		squares[0], squares[best] = squares[best], squares[0]
+		pos.file = min(squares[0]%8, 7-squares[0]%8)
+	}
+
+	if t.dtz && t.get(pos.stm, pos.file).flags&tbFlagSTM != byte(pos.stm) && (t.key != t.key2 || t.hasPawns) {
+		return pos, false
+	}
+
+	for sq := 0; sq < 64; sq++ {
+		if p := board.Squares[sq]; !p.IsEmpty() && !isLead[sq] && size < tbPieces {
+			squares[size] = sq ^ flipSquares
+			pieces[size] = tbPiece(p) ^ flipColor
+			size++
+		}
+	}
+	pos.pieces = size
+	d := t.get(pos.stm, pos.file)
+
+	// Put the pieces in the order of the table
+	for i := leadPawns; i < size-1; i++ {
+		for j := i + 1; j < size; j++ {
+			if d.pieces[i] == pieces[j] {
+				pieces[i], pieces[j] = pieces[j], pieces[i]
+				squares[i], squares[j] = squares[j], squares[i]
+				break
+			}
+		}
+	}
+
+	// The leading piece goes on files a-d
+	if squares[0]%8 > 3 {
+		for i := 0; i < size; i++ {
+			squares[i] ^= 7
+		}
+	}
+
+	var idx uint64
+	if t.hasPawns {
+		idx = tbLeadPawnIdx[leadPawns][squares[0]]
+		others := squares[1:leadPawns]
+		sort.SliceStable(others, func(i, j int) bool { return tbMapPawns[others[i]] < tbMapPawns[others[j]] })
+		for i := 1; i < leadPawns; i++ {
+			idx += tbBinomial[i][tbMapPawns[squares[i]]]
+		}
+	} else {
+		// Without pawns the leading piece also goes on ranks 1-4, and the
+		// first piece of the leading group off the diagonal below it
+		if squares[0]/8 > 3 {
+			for i := 0; i < size; i++ {
+				squares[i] ^= 56
+			}
+		}
+		for i := 0; i < d.groupLen[0]; i++ {
+			off := tbOffDiagonal(squares[i])
+			if off == 0 {
+				continue
+			}
+			if off > 0 {
+				for j := i; j < size; j++ {
+					squares[j] = ((squares[j] >> 3) | (squares[j] << 3)) & 63
+				}
+			}
+			break
+		}
+		idx = t.leadingIndex(squares)
+	}
+	idx *= d.groupIdx[0]
+
+	// The other groups, each sorted, skip the squares of the groups before them
+	remainingPawns := t.hasPawns && t.pawnCount[1] > 0
+	start := d.groupLen[0]
+	for next := 1; d.groupLen[next] != 0; next++ {
+		group := squares[start : start+d.groupLen[next]]
+		sort.Ints(group)
+		var n uint64
+		for i, s := range group {
+			adjust := 0
+			for _, before := range squares[:start] {
+				if s > before {
+					adjust++
+				}
+			}
+			if remainingPawns {
+				adjust += 8
+			}
+			n += tbBinomial[i+1][s-adjust]
+		}
+		remainingPawns = false
+		idx += n * d.groupIdx[next]
+		start += d.groupLen[next]
+	}
+	pos.idx = idx
+	return pos, true
+}
+
+// leadingIndex encodes the leading group of a table without pawns: three
+// unique pieces together, or else the two kings.
+func (t *tbTable) leadingIndex(sq [tbPieces]int) uint64 {
+	if !t.hasUniquePieces {
+		return uint64(tbMapKK[tbMapA1D1D4[sq[0]]][sq[1]])
+	}
+	b := func(v bool) int {
+		if v {
+			return 1
+		}
+		return 0
+	}
+	adjust1 := b(sq[1] > sq[0])
+	adjust2 := b(sq[2] > sq[0]) + b(sq[2] > sq[1])
+	switch {
+	case tbOffDiagonal(sq[0]) != 0:
+		return uint64((tbMapA1D1D4[sq[0]]*63+sq[1]-adjust1)*62 + sq[2] - adjust2)
+	case tbOffDiagonal(sq[1]) != 0:
+		return uint64((6*63+sq[0]/8*28+tbMapB1H1H7[sq[1]])*62 + sq[2] - adjust2)
+	case tbOffDiagonal(sq[2]) != 0:
+		return uint64(6*63*62 + 4*28*62 + sq[0]/8*7*28 + (sq[1]/8-adjust1)*28 + tbMapB1H1H7[sq[2]])
+	default:
+		return uint64(6*63*62 + 4*28*62 + 4*7*28 + sq[0]/8*7*6 + (sq[1]/8-adjust1)*6 + sq[2]/8 - adjust2)
+	}
+}
+
+// probe returns the value of the position on board in t: a WDL score, or the
+// DTZ in plies of a position whose WDL score is wdl. changeSTM reports a DTZ
+// table that only stores the other side to move.
+func (t *tbTable) probe(board *engine.Board, wdl WDL) (value int, changeSTM bool, err error) {
+	if err := t.open(); err != nil {
+		return 0, false, err
+	}
+	pos, ok := t.index(board)
+	if !ok {
+		return 0, true, nil
+	}
+	d := t.get(pos.stm, pos.file)
+	v, err := t.decompress(d, pos.idx)
+	if err != nil {
+		return 0, false, err
+	}
+	if !t.dtz {
+		return v - 2, false, nil
+	}
+
+	// DTZ values are stored by frequency for each result, and in moves
+	// rather than plies unless flagged
+	d = t.get(0, pos.file)
+	if d.flags&tbFlagMapped != 0 {
+		i := d.mapIdx[[5]int{1, 3, 0, 2, 0}[wdl+2]]
+		if d.flags&tbFlagWide != 0 {
+			i += 2 * v
+			if i+2 > len(t.dtzMap) {
+				return 0, false, errTBCorrupt
+			}
+			v = int(binary.LittleEndian.Uint16(t.dtzMap[i:]))
+		} else {
+			i += v
+			if i >= len(t.dtzMap) {
+				return 0, false, errTBCorrupt
+			}
+			v = int(t.dtzMap[i])
+		}
+	}
+	if (wdl == WDLWin && d.flags&tbFlagWinPlies == 0) || (wdl == WDLLoss && d.flags&tbFlagLossPlies == 0) ||
+		wdl == WDLCursedWin || wdl == WDLBlessedLoss {
+		v *= 2
+	}
+	return v + 1, false, nil
+}
+
+// materialKey returns the material on board as White's pieces, "v" and
+// Black's, strongest first, e.g. "KRPvKR".
+func materialKey(board *engine.Board) string {
+	var counts [2][7]int
+	for _, p := range board.Squares {
+		if !p.IsEmpty() {
+			counts[p.Color()][p.Type()]++
+		}
+	}
+	var b strings.Builder
+	for c := range counts {
+		if c == 1 {
+			b.WriteByte('v')
+		}
+		for _, pt := range []engine.PieceType{engine.King, engine.Queen, engine.Rook, engine.Bishop, engine.Knight, engine.Pawn} {
+			for range counts[c][pt] {
+				b.WriteByte(tbPieceLetters[pt])
+			}
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,428 @@
+package bot
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// testBoard returns a position with the given pieces, as piece codes of table
+// files by square, and side to move. It reports false if it isn't legal.
+func testBoard(pieces map[int]byte, stm engine.Color) (*engine.Board, bool) {
+	b := &engine.Board{ActiveColor: stm, EnPassantSq: -1, FullMoveNum: 1}
+	for sq, p := range pieces {
+		b.Squares[sq] = engine.NewPiece(engine.Color(p>>3), engine.PieceType(p&7))
+	}
+	b.Hash = b.ComputeHash()
+	b.History = []uint64{b.Hash}
+	white, black := b.KingSquare(engine.White), b.KingSquare(engine.Black)
+	if tbSquareDistance(int(white), int(black)) <= 1 {
+		return nil, false
+	}
+	for sq, p := range pieces {
+		if p&7 == byte(engine.Pawn) && (sq < 8 || sq >= 56) {
+			return nil, false
+		}
+	}
+	// The side that just moved can't be in check
+	return b, !b.IsSquareAttacked(b.KingSquare(1-stm), stm)
+}
+
+// testLayout returns a table of material whose parts list the pieces in the
+// given order, as read from a table file.
+func testLayout(material string, dtz bool, pieces ...byte) *tbTable {
+	t := newTBTable("", material, dtz)
+	for f := 0; f < 4; f++ {
+		for i := 0; i < t.sides(); i++ {
+			d := &t.items[i][f]
+			copy(d.pieces[:], pieces)
+			t.setGroups(d, [2]int{0, 0xf}, f)
+		}
+	}
+	return t
+}
+
+// Piece codes of table files.
+const (
+	tbWK, tbWQ, tbWR, tbWP = 6, 5, 4, 1
+	tbBK                   = 14
+)
+
+// transform applies one of the 8 symmetries of the board without pawns to sq.
+func transform(sq, n int) int {
+	if n&1 != 0 {
+		sq ^= 7 // mirror files
+	}
+	if n&2 != 0 {
+		sq ^= 56 // mirror ranks
+	}
+	if n&4 != 0 {
+		sq = (sq>>3 | sq<<3) & 63 // mirror the a1-h8 diagonal
+	}
+	return sq
+}
+
+func TestTBMapKK(t *testing.T) {
+	seen := make(map[int]bool)
+	for idx := 0; idx < 10; idx++ {
+		for s2 := 0; s2 < 64; s2++ {
+			seen[tbMapKK[idx][s2]] = true
+		}
+	}
+	for code := 0; code < 462; code++ {
+		if !seen[code] {
+			t.Errorf("no king placement encodes to %d", code)
+		}
+	}
+	if len(seen) != 462 {
+		t.Errorf("king placements encode to %d values, want 462", len(seen))
+	}
+}
+
+// checkIndex indexes every position from positions, checking that the
+// positions symmetries map onto each other share an index and the others don't.
+func checkIndex(t *testing.T, table *tbTable, positions func(yield func(map[int]byte, engine.Color)), symmetries []int) {
+	t.Helper()
+	type part struct {
+		stm, file int
+		idx       uint64
+	}
+	classes := make(map[part]string)
+	n := 0
+	positions(func(pieces map[int]byte, stm engine.Color) {
+		board, ok := testBoard(pieces, stm)
+		if !ok {
+			return
+		}
+		pos, _ := table.index(board)
+		d := table.get(pos.stm, pos.file)
+		g := 0
+		for d.groupLen[g] != 0 {
+			g++
+		}
+		size := d.groupIdx[g]
+		if pos.idx >= size {
+			t.Fatalf("%s: index %d, want below %d", board.ToFEN(), pos.idx, size)
+		}
+
+		// The class of a position is its least image under the symmetries
+		class := ""
+		for _, s := range symmetries {
+			image := make(map[int]byte)
+			for sq, p := range pieces {
+				image[transform(sq, s)] = p
+			}
+			b, _ := testBoard(image, stm)
+			if fen := b.ToFEN(); class == "" || fen < class {
+				class = fen
+			}
+		}
+		key := part{pos.stm, pos.file, pos.idx}
+		if other, seen := classes[key]; seen && other != class {
+			t.Fatalf("%s and %s have the same index %d", other, class, pos.idx)
+		}
+		classes[key] = class
+		n++
+	})
+	if n == 0 {
+		t.Fatal("no positions indexed")
+	}
+}
+
+func TestTBIndexUniquePieces(t *testing.T) {
+	table := testLayout("KQvK", false, tbWK, tbWQ, tbBK)
+	checkIndex(t, table, func(yield func(map[int]byte, engine.Color)) {
+		for k := 0; k < 64; k++ {
+			for q := 0; q < 64; q++ {
+				for bk := 0; bk < 64; bk++ {
+					if k != q && q != bk && k != bk {
+						yield(map[int]byte{k: tbWK, q: tbWQ, bk: tbBK}, engine.White)
+					}
+				}
+			}
+		}
+	}, []int{0, 1, 2, 3, 4, 5, 6, 7})
+}
+
+func TestTBIndexKings(t *testing.T) {
+	// No piece is unique, so the kings lead and the rooks are a group
+	table := testLayout("KRRvK", false, tbWK, tbBK, tbWR, tbWR)
+	rng := rand.New(rand.NewSource(1))
+	checkIndex(t, table, func(yield func(map[int]byte, engine.Color)) {
+		for range 50000 {
+			squares := rng.Perm(64)[:4]
+			yield(map[int]byte{squares[0]: tbWK, squares[1]: tbBK, squares[2]: tbWR, squares[3]: tbWR}, engine.Black)
+		}
+	}, []int{0, 1, 2, 3, 4, 5, 6, 7})
+}
+
+func TestTBIndexPawns(t *testing.T) {
+	table := testLayout("KPvK", false, tbWP, tbWK, tbBK)
+	checkIndex(t, table, func(yield func(map[int]byte, engine.Color)) {
+		for p := 8; p < 56; p++ {
+			for k := 0; k < 64; k++ {
+				for bk := 0; bk < 64; bk++ {
+					if k != p && p != bk && k != bk {
+						yield(map[int]byte{p: tbWP, k: tbWK, bk: tbBK}, engine.White)
+					}
+				}
+			}
+		}
+	}, []int{0, 1})
+}
+
+func TestTBIndexColorFlip(t *testing.T) {
+	table := testLayout("KQvK", false, tbWK, tbWQ, tbBK)
+	flipped := 0
+	for k := 0; k < 64; k += 3 {
+		for q := 0; q < 64; q += 5 {
+			for bk := 0; bk < 64; bk++ {
+				if k == q || q == bk || k == bk {
+					continue
+				}
+				for _, stm := range []engine.Color{engine.White, engine.Black} {
+					board, ok := testBoard(map[int]byte{k: tbWK, q: tbWQ, bk: tbBK}, stm)
+					if !ok {
+						continue
+					}
+					// The same position with colors swapped and ranks mirrored
+					mirror, _ := testBoard(map[int]byte{k ^ 56: tbBK, q ^ 56: tbWQ | 8, bk ^ 56: tbWK}, 1-stm)
+					want, _ := table.index(board)
+					got, _ := table.index(mirror)
+					if got != want {
+						t.Fatalf("%s indexes to %+v, want %+v as %s", mirror.ToFEN(), got, want, board.ToFEN())
+					}
+					flipped++
+				}
+			}
+		}
+	}
+	if flipped == 0 {
+		t.Fatal("no positions compared")
+	}
+}
+
+// tbPart is the content of one part of a table file written by writeTBFile.
+type tbPart struct {
+	flags  byte
+	values []int // by index
+	// dtzMap holds the values of a DTZ part flagged tbFlagMapped, for wins,
+	// losses, cursed wins and blessed losses; values index them
+	dtzMap [4][]int
+}
+
+// writeTBFile writes a table file of table's layout into dir with the given
+// parts, one per side for a WDL table and one for a DTZ table, without pawns.
+// WDL values are Huffman coded with runs of draws paired; DTZ values are coded
+// in 5 bits each.
+func writeTBFile(t *testing.T, dir string, table *tbTable, parts []tbPart) {
+	t.Helper()
+	const blockBits, spanBits = 5, 6
+
+	var buf bytes.Buffer
+	w := func(v any) { _ = binary.Write(&buf, binary.LittleEndian, v) }
+	align := func(n int) {
+		for buf.Len()%n != 0 {
+			buf.WriteByte(0)
+		}
+	}
+
+	ext, magic := syzygyWDLExt, syzygyWDLMagic
+	if table.dtz {
+		ext, magic = syzygyDTZExt, syzygyDTZMagic
+	}
+	buf.Write(magic)
+	buf.WriteByte(tbFileSplit)
+	buf.WriteByte(0) // the leading group is encoded first on both sides
+	for k := 0; k < table.pieceCount; k++ {
+		buf.WriteByte(table.items[0][0].pieces[k] | table.items[1][0].pieces[k]<<4)
+	}
+	align(2)
+
+	// codes holds each symbol's code and length; leaves hold a value, and the
+	// last WDL symbol pairs two draws
+	type code struct{ bits, len int }
+	var codes []code
+	var btree []byte
+	node := func(left, right int) {
+		btree = append(btree, byte(left), byte(left>>8&0xf|right<<4&0xf0), byte(right>>4))
+	}
+	var minLen, maxLen int
+	var lowestSym []uint16
+	if table.dtz {
+		minLen, maxLen, lowestSym = 5, 5, []uint16{0}
+		for v := 0; v < 32; v++ {
+			codes = append(codes, code{v, 5})
+			node(v, 0xfff)
+		}
+	} else {
+		minLen, maxLen, lowestSym = 2, 3, []uint16{4, 0}
+		for i, v := range []int{0, 1, 3, 4} {
+			codes = append(codes, code{i, 3})
+			node(v, 0xfff)
+		}
+		codes = append(codes, code{2, 2}, code{3, 2})
+		node(2, 0xfff)
+		node(4, 4)
+	}
+
+	type block struct {
+		data   []byte
+		values int
+	}
+	var blocks [][]block
+	for _, part := range parts {
+		var out []block
+		var cur block
+		bit := 0
+		put := func(sym, values int) {
+			c := codes[sym]
+			if bit+c.len > 8<<blockBits {
+				out = append(out, cur)
+				cur, bit = block{}, 0
+			}
+			if cur.data == nil {
+				cur.data = make([]byte, 1<<blockBits)
+			}
+			for i := c.len - 1; i >= 0; i-- {
+				if c.bits>>i&1 != 0 {
+					cur.data[bit/8] |= 0x80 >> (bit % 8)
+				}
+				bit++
+			}
+			cur.values += values
+		}
+		for i := 0; i < len(part.values); i++ {
+			v := part.values[i]
+			switch {
+			case table.dtz:
+				put(v, 1)
+			case v == 2 && i+1 < len(part.values) && part.values[i+1] == 2:
+				put(5, 2)
+				i++
+			default:
+				put(map[int]int{0: 0, 1: 1, 2: 4, 3: 2, 4: 3}[v], 1)
+			}
+		}
+		blocks = append(blocks, append(out, cur))
+	}
+
+	for i, part := range parts {
+		buf.WriteByte(part.flags)
+		buf.WriteByte(blockBits)
+		buf.WriteByte(spanBits)
+		buf.WriteByte(0) // padding
+		w(uint32(len(blocks[i])))
+		buf.WriteByte(byte(maxLen))
+		buf.WriteByte(byte(minLen))
+		w(lowestSym)
+		w(uint16(len(codes)))
+		buf.Write(btree)
+		if len(codes)%2 != 0 {
+			buf.WriteByte(0)
+		}
+	}
+	for _, part := range parts {
+		if part.flags&tbFlagMapped == 0 {
+			continue
+		}
+		if part.flags&tbFlagWide != 0 {
+			align(2)
+		}
+		for _, m := range part.dtzMap {
+			if part.flags&tbFlagWide != 0 {
+				w(uint16(len(m)))
+				for _, v := range m {
+					w(uint16(v))
+				}
+			} else {
+				buf.WriteByte(byte(len(m)))
+				for _, v := range m {
+					buf.WriteByte(byte(v))
+				}
+			}
+		}
+	}
+	align(2)
+
+	for i, part := range parts {
+		span := 1 << spanBits
+		for k := 0; k*span < len(part.values); k++ {
+			target, b := k*span+span/2, 0
+			for b < len(blocks[i])-1 && target >= blocks[i][b].values {
+				target -= blocks[i][b].values
+				b++
+			}
+			w(uint32(b))
+			w(uint16(target))
+		}
+	}
+	for i := range parts {
+		for _, b := range blocks[i] {
+			w(uint16(b.values - 1))
+		}
+	}
+	for i := range parts {
+		align(64)
+		for _, b := range blocks[i] {
+			buf.Write(b.data)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, table.key+ext), buf.Bytes(), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+}
+
+func TestTBDecompress(t *testing.T) {
+	dir := t.TempDir()
+	layout := testLayout("KQvK", false, tbWK, tbWQ, tbBK)
+	rng := rand.New(rand.NewSource(1))
+	var parts []tbPart
+	for range 2 {
+		values := make([]int, 31332)
+		for i := range values {
+			// Runs of draws, to be paired, among random values
+			if values[i] = rng.Intn(5); rng.Intn(3) == 0 {
+				values[i] = 2
+			}
+		}
+		parts = append(parts, tbPart{values: values})
+	}
+	writeTBFile(t, dir, layout, parts)
+
+	table := newTBTable(filepath.Join(dir, "KQvK.rtbw"), "KQvK", false)
+	if err := table.open(); err != nil {
+		t.Fatalf("open() error = %v", err)
+	}
+	for side, part := range parts {
+		d := table.get(side, 0)
+		if d.pieces != layout.items[side][0].pieces || d.groupIdx != layout.items[side][0].groupIdx {
+			t.Fatalf("side %d: layout %v %v, want %v %v", side, d.pieces, d.groupIdx, layout.items[side][0].pieces, layout.items[side][0].groupIdx)
+		}
+		for idx, want := range part.values {
+			got, err := table.decompress(d, uint64(idx))
+			if err != nil {
+				t.Fatalf("side %d: decompress(%d) error = %v", side, idx, err)
+			}
+			if got != want {
+				t.Fatalf("side %d: decompress(%d) = %d, want %d", side, idx, got, want)
+			}
+		}
+	}
+}
+
+func TestTBOpenCorrupt(t *testing.T) {
+	dir := t.TempDir()
+	// The right magic number, then a header claiming pawns a KQvK table can't have
+	writeTable(t, dir, "KQvK.rtbw", append(syzygyWDLMagic, tbFileHasPawns))
+	table := newTBTable(filepath.Join(dir, "KQvK.rtbw"), "KQvK", false)
+	if err := table.open(); err == nil {
+		t.Error("open() error = nil, want an error for a corrupt table")
+	}
+}
@@ -0,0 +1,46 @@
+package bot
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTable writes a tablebase file starting with header into dir.
+func writeTable(t *testing.T, dir, name string, header []byte) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), append(header, 0, 0, 0, 0), 0644); err != nil {
+		t.Fatalf("WriteFile(%s) error = %v", name, err)
+	}
+}
+
+func TestFindSyzygyTables(t *testing.T) {
+	three, five := t.TempDir(), t.TempDir()
+	writeTable(t, three, "KQvK.rtbw", syzygyWDLMagic)
+	writeTable(t, three, "KQvK.rtbz", syzygyDTZMagic)
+	writeTable(t, three, "KRvK.rtbw", syzygyWDLMagic)
+	writeTable(t, five, "KRPvKR.rtbw", syzygyWDLMagic)
+	// Skipped: a DTZ header in a WDL file, a name that isn't material, and an unrelated file
+	writeTable(t, five, "KBNvK.rtbw", syzygyDTZMagic)
+	writeTable(t, five, "notes.rtbw", syzygyWDLMagic)
+	writeTable(t, five, "README.txt", nil)
+
+	tables, err := FindSyzygyTables(strings.Join([]string{three, five}, string(filepath.ListSeparator)))
+	if err != nil {
+		t.Fatalf("FindSyzygyTables() error = %v", err)
+	}
+	want := SyzygyTables{WDL: 3, DTZ: 1, MaxPieces: 5}
+	if tables != want {
+		t.Errorf("FindSyzygyTables() = %+v, want %+v", tables, want)
+	}
+}
+
+func TestFindSyzygyTablesMissingDirectory(t *testing.T) {
+	if _, err := FindSyzygyTables(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("FindSyzygyTables() error = nil, want an error for a missing directory")
+	}
+	if tables, err := FindSyzygyTables(t.TempDir()); err != nil || tables.WDL != 0 {
+		t.Errorf("FindSyzygyTables() = %+v, %v; want no tables and no error", tables, err)
+	}
+}
@@ -0,0 +1,93 @@
+package bot
+
+import "github.com/Mgrdich/TermChess/internal/engine"
+
+// maxTTEntries caps the number of positions kept in the transposition table.
+// When the cap is reached the table is cleared rather than evicting entries
+// one by one, which keeps the implementation simple and bounded in memory.
+const maxTTEntries = 1 << 20
+
+// mateThreshold is the score above which a value is treated as a mate score.
+// Mate scores are stored relative to the node (not the root) in the table.
+const mateThreshold = 9000.0
+
+// ttFlag describes how a stored score relates to the true minimax value.
+type ttFlag uint8
+
+const (
+	// ttExact means the stored score is the exact value of the position.
+	ttExact ttFlag = iota
+	// ttLowerBound means the search failed high (score >= beta).
+	ttLowerBound
+	// ttUpperBound means the search failed low (score <= alpha).
+	ttUpperBound
+)
+
+// ttEntry is a single transposition table record.
+type ttEntry struct {
+	depth    int
+	score    float64
+	flag     ttFlag
+	bestMove engine.Move
+}
+
+// transpositionTable caches search results keyed by Zobrist hash.
+// It is not safe for concurrent use; each engine owns its own table.
+type transpositionTable struct {
+	entries map[uint64]ttEntry
+}
+
+// newTranspositionTable creates an empty transposition table.
+func newTranspositionTable() *transpositionTable {
+	return &transpositionTable{entries: make(map[uint64]ttEntry)}
+}
+
+// probe looks up the entry for the given hash.
+func (tt *transpositionTable) probe(hash uint64) (ttEntry, bool) {
+	entry, ok := tt.entries[hash]
+	return entry, ok
+}
+
+// store records a search result, preferring deeper searches for the same position.
+func (tt *transpositionTable) store(hash uint64, entry ttEntry) {
+	if existing, ok := tt.entries[hash]; ok && existing.depth > entry.depth {
+		return
+	}
+	if len(tt.entries) >= maxTTEntries {
+		tt.clear()
+	}
+	tt.entries[hash] = entry
+}
+
+// clear removes all entries from the table.
+func (tt *transpositionTable) clear() {
+	tt.entries = make(map[uint64]ttEntry)
+}
+
+// size returns the number of stored entries.
+func (tt *transpositionTable) size() int {
+	return len(tt.entries)
+}
+
+// scoreToTT converts a root-relative mate score into a node-relative one
+// so that it remains valid when the position is reached at a different ply.
+func scoreToTT(score float64, ply int) float64 {
+	if score >= mateThreshold {
+		return score + float64(ply)
+	}
+	if score <= -mateThreshold {
+		return score - float64(ply)
+	}
+	return score
+}
+
+// scoreFromTT converts a node-relative mate score back to a root-relative one.
+func scoreFromTT(score float64, ply int) float64 {
+	if score >= mateThreshold {
+		return score - float64(ply)
+	}
+	if score <= -mateThreshold {
+		return score + float64(ply)
+	}
+	return score
+}
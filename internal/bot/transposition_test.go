@@ -0,0 +1,85 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+func TestTranspositionTable_StoreAndProbe(t *testing.T) {
+	tt := newTranspositionTable()
+
+	if _, ok := tt.probe(42); ok {
+		t.Fatal("probe() on empty table should miss")
+	}
+
+	move := engine.Move{From: engine.NewSquare(4, 1), To: engine.NewSquare(4, 3)}
+	tt.store(42, ttEntry{depth: 3, score: 1.5, flag: ttExact, bestMove: move})
+
+	entry, ok := tt.probe(42)
+	if !ok {
+		t.Fatal("probe() should hit after store()")
+	}
+	if entry.depth != 3 || entry.score != 1.5 || entry.flag != ttExact || entry.bestMove != move {
+		t.Errorf("probe() = %+v, want depth 3, score 1.5, exact, move %v", entry, move)
+	}
+}
+
+func TestTranspositionTable_PrefersDeeperEntries(t *testing.T) {
+	tt := newTranspositionTable()
+
+	tt.store(7, ttEntry{depth: 5, score: 2.0, flag: ttExact})
+	tt.store(7, ttEntry{depth: 2, score: -1.0, flag: ttLowerBound})
+
+	entry, _ := tt.probe(7)
+	if entry.depth != 5 || entry.score != 2.0 {
+		t.Errorf("shallower entry replaced deeper one: got %+v", entry)
+	}
+
+	tt.store(7, ttEntry{depth: 6, score: 3.0, flag: ttUpperBound})
+	entry, _ = tt.probe(7)
+	if entry.depth != 6 || entry.score != 3.0 {
+		t.Errorf("deeper entry should replace existing one: got %+v", entry)
+	}
+}
+
+func TestTranspositionTable_Clear(t *testing.T) {
+	tt := newTranspositionTable()
+	tt.store(1, ttEntry{depth: 1})
+	tt.store(2, ttEntry{depth: 1})
+
+	if tt.size() != 2 {
+		t.Fatalf("size() = %d, want 2", tt.size())
+	}
+
+	tt.clear()
+	if tt.size() != 0 {
+		t.Errorf("size() after clear() = %d, want 0", tt.size())
+	}
+}
+
+func TestScoreTTRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		score float64
+		ply   int
+	}{
+		{"normal score unchanged", 3.25, 4},
+		{"winning mate", 10000 - 5, 3},
+		{"losing mate", -(10000 - 5), 3},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := scoreFromTT(scoreToTT(tc.score, tc.ply), tc.ply)
+			if got != tc.score {
+				t.Errorf("round trip = %v, want %v", got, tc.score)
+			}
+		})
+	}
+
+	// A mate found 5 plies from the root at ply 3 is a mate in 2 from that node.
+	if got := scoreToTT(10000-5, 3); got != 10000-2 {
+		t.Errorf("scoreToTT(mate, 3) = %v, want %v", got, 10000-2)
+	}
+}
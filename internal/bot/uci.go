@@ -0,0 +1,188 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// uciHandshakeTimeout is how long a UCI engine may take to answer uci and isready.
+const uciHandshakeTimeout = 10 * time.Second
+
+// uciEngine plays the moves of an external engine that speaks the Universal Chess
+// Interface, such as Stockfish. Only standard chess is supported, since UCI has no
+// common way to select the other variants.
+type uciEngine struct {
+	timeLimit  time.Duration
+	syzygyPath string
+
+	mu     sync.Mutex
+	proc   botProcess
+	closed bool
+}
+
+// NewUCIEngine starts command with args as a UCI engine called name and waits
+// until it is ready to play.
+func NewUCIEngine(name, command string, args []string, opts ...EngineOption) (Engine, error) {
+	cfg := &engineConfig{timeLimit: DefaultSubprocessTimeLimit}
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	e := &uciEngine{
+		timeLimit:  cfg.timeLimit,
+		syzygyPath: cfg.syzygyPath,
+		proc:       botProcess{name: name, command: command, args: args},
+	}
+	if err := e.start(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// RegisterUCI registers a UCI engine under name, so it is listed in the bot menus
+// like a compiled-in custom bot, created with opts. Like RegisterSubprocess it
+// returns an error instead of panicking.
+func RegisterUCI(name, command string, args []string, opts ...EngineOption) error {
+	if command == "" {
+		return fmt.Errorf("bot %q has no command", name)
+	}
+	return register(name, KindUCI, func() (Engine, error) {
+		return NewUCIEngine(name, command, args, opts...)
+	})
+}
+
+// start launches the engine and performs the uci/isready handshake, setting
+// the Syzygy path in between if there is one.
+func (e *uciEngine) start() error {
+	if err := e.proc.start(); err != nil {
+		return err
+	}
+	if err := e.handshake("uci", "uciok"); err != nil {
+		return err
+	}
+	if e.syzygyPath != "" {
+		// Engines without tablebase support ignore options they don't know
+		if err := e.proc.send("setoption name SyzygyPath value " + e.syzygyPath); err != nil {
+			return err
+		}
+	}
+	return e.handshake("isready", "readyok")
+}
+
+// handshake sends command and skips output until the engine answers with want.
+// The engine is stopped if it doesn't answer in time.
+func (e *uciEngine) handshake(command, want string) error {
+	if err := e.proc.send(command); err != nil {
+		return err
+	}
+	deadline := time.Now().Add(uciHandshakeTimeout)
+	for {
+		line, err := e.proc.receive(context.Background(), time.Until(deadline))
+		if err != nil {
+			e.proc.stop()
+			if err == errProcessTimeout {
+				return fmt.Errorf("%s did not answer %s", e.proc.name, command)
+			}
+			return err
+		}
+		if strings.TrimSpace(line) == want {
+			return nil
+		}
+	}
+}
+
+// SelectMove asks the engine for a move in the current position, giving it the
+// engine's time limit. If ctx is cancelled the engine is told to stop and its best
+// move so far is played, like the built-in bots do.
+func (e *uciEngine) SelectMove(ctx context.Context, board *engine.Board) (engine.Move, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.closed {
+		return engine.Move{}, errors.New("engine is closed")
+	}
+	if board.Variant != nil && board.Variant.Name() != (engine.Standard{}).Name() {
+		return engine.Move{}, fmt.Errorf("%s only plays standard chess", e.proc.name)
+	}
+	if !e.proc.running() {
+		if err := e.start(); err != nil {
+			return engine.Move{}, err
+		}
+	}
+
+	legal := board.LegalMoves()
+	if len(legal) == 0 {
+		return engine.Move{}, errors.New("no legal moves available")
+	}
+
+	if err := e.proc.send("position fen " + board.ToFEN()); err != nil {
+		return engine.Move{}, err
+	}
+	if err := e.proc.send(fmt.Sprintf("go movetime %d", e.timeLimit.Milliseconds())); err != nil {
+		return engine.Move{}, err
+	}
+
+	deadline := time.Now().Add(e.timeLimit + subprocessGrace)
+	stopped := false
+	for {
+		line, err := e.proc.receive(ctx, time.Until(deadline))
+		if err != nil && !stopped && err == ctx.Err() {
+			// Ask for the best move so far and give the engine a moment to send it
+			stopped = true
+			ctx = context.Background()
+			deadline = time.Now().Add(subprocessGrace)
+			if err := e.proc.send("stop"); err != nil {
+				return engine.Move{}, err
+			}
+			continue
+		}
+		if err != nil {
+			// A late bestmove would answer the next position, so restart the engine
+			e.proc.stop()
+			if err == errProcessTimeout {
+				return engine.Move{}, fmt.Errorf("%s did not move within %s", e.proc.name, e.timeLimit)
+			}
+			return engine.Move{}, err
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != "bestmove" {
+			continue
+		}
+		return matchLegalMove(fields[1], legal)
+	}
+}
+
+// Name returns the engine's name.
+func (e *uciEngine) Name() string {
+	return e.proc.name
+}
+
+// Close tells the engine to quit and stops its process. It is safe to call multiple times.
+func (e *uciEngine) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.proc.running() {
+		_ = e.proc.send("quit")
+	}
+	e.closed = true
+	e.proc.stop()
+	return nil
+}
+
+// Info returns metadata about the engine.
+func (e *uciEngine) Info() Info {
+	return Info{
+		Name:     e.proc.name,
+		Type:     TypeUCI,
+		Features: map[string]bool{},
+	}
+}
@@ -0,0 +1,142 @@
+package bot
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// TestHelperUCIBot is not a real test: it is the UCI engine that the UCI tests
+// start, by running the test binary with TERMCHESS_HELPER_UCI set to the engine's
+// behavior. "first" plays the first legal move at once; "wait" thinks until told to stop.
+// If TERMCHESS_HELPER_UCI_LOG names a file, the commands received are appended to it.
+func TestHelperUCIBot(t *testing.T) {
+	mode := os.Getenv("TERMCHESS_HELPER_UCI")
+	if mode == "" {
+		return
+	}
+	var log *os.File
+	if path := os.Getenv("TERMCHESS_HELPER_UCI_LOG"); path != "" {
+		log, _ = os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	}
+
+	board := engine.NewBoard()
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if log != nil {
+			fmt.Fprintln(log, line)
+		}
+		switch {
+		case line == "uci":
+			fmt.Println("id name Helper")
+			fmt.Println("uciok")
+		case line == "isready":
+			fmt.Println("readyok")
+		case strings.HasPrefix(line, "position fen "):
+			board, _ = engine.FromFEN(strings.TrimPrefix(line, "position fen "))
+		case strings.HasPrefix(line, "go") && mode == "first":
+			fmt.Println("info depth 1 score cp 20")
+			fmt.Println("bestmove " + board.LegalMoves()[0].String())
+		case line == "stop":
+			fmt.Println("bestmove " + board.LegalMoves()[0].String())
+		case line == "quit":
+			os.Exit(0)
+		}
+	}
+	os.Exit(0)
+}
+
+// helperUCIBot starts the test binary as a UCI engine with the given behavior.
+func helperUCIBot(t *testing.T, mode string, opts ...EngineOption) Engine {
+	t.Helper()
+	t.Setenv("TERMCHESS_HELPER_UCI", mode)
+	e, err := NewUCIEngine("Helper UCI", os.Args[0], []string{"-test.run=^TestHelperUCIBot$"}, opts...)
+	if err != nil {
+		t.Fatalf("NewUCIEngine() error = %v", err)
+	}
+	t.Cleanup(func() { _ = e.Close() })
+	return e
+}
+
+func TestUCIEnginePlaysMoves(t *testing.T) {
+	e := helperUCIBot(t, "first")
+	board := engine.NewBoard()
+
+	for i := 0; i < 4; i++ {
+		move, err := e.SelectMove(context.Background(), board)
+		if err != nil {
+			t.Fatalf("SelectMove() error = %v", err)
+		}
+		if err := board.MakeMove(move); err != nil {
+			t.Fatalf("MakeMove(%s) error = %v", move, err)
+		}
+	}
+	if info := e.(Inspectable).Info(); info.Type != TypeUCI {
+		t.Errorf("Info().Type = %v, want UCI", info.Type)
+	}
+}
+
+func TestUCIEngineSetsSyzygyPath(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "uci.log")
+	t.Setenv("TERMCHESS_HELPER_UCI_LOG", logPath)
+	helperUCIBot(t, "first", WithSyzygyPath("/tb/3-4-5"))
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	want := "uci\nsetoption name SyzygyPath value /tb/3-4-5\nisready\n"
+	if !strings.HasPrefix(string(data), want) {
+		t.Errorf("Engine received %q, want it to start with %q", data, want)
+	}
+}
+
+func TestUCIEngineStopsOnCancel(t *testing.T) {
+	e := helperUCIBot(t, "wait", WithTimeLimit(time.Minute))
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	move, err := e.SelectMove(ctx, engine.NewBoard())
+	if err != nil {
+		t.Fatalf("SelectMove() error = %v, want the best move so far", err)
+	}
+	if move == (engine.Move{}) {
+		t.Error("Expected a move after stop")
+	}
+}
+
+func TestUCIEngineRejectsVariants(t *testing.T) {
+	e := helperUCIBot(t, "first")
+	variant, err := engine.ParseVariant("atomic")
+	if err != nil {
+		t.Fatalf("ParseVariant() error = %v", err)
+	}
+	board := engine.NewBoard()
+	board.Variant = variant
+
+	if _, err := e.SelectMove(context.Background(), board); err == nil {
+		t.Error("Expected an error for a non-standard variant")
+	}
+}
+
+func TestRegisterUCIKind(t *testing.T) {
+	if err := RegisterUCI("Fish", "stockfish", nil); err != nil {
+		t.Fatalf("RegisterUCI() error = %v", err)
+	}
+	defer Unregister("Fish")
+
+	if kind, ok := KindOf("Fish"); !ok || kind != KindUCI {
+		t.Errorf("KindOf(Fish) = %q, %v, want UCI", kind, ok)
+	}
+	if _, ok := KindOf("Nobody"); ok {
+		t.Error("Expected no kind for an unregistered bot")
+	}
+}
@@ -0,0 +1,81 @@
+package bvb
+
+import (
+	"fmt"
+	"math"
+)
+
+// eloZ95 is the z-score of a two-sided 95% confidence interval.
+const eloZ95 = 1.959964
+
+// EloEstimate is the estimated Elo difference between two players from their
+// head-to-head record, with a 95% confidence interval.
+type EloEstimate struct {
+	// Diff is the estimated Elo of the first player relative to the second.
+	Diff float64
+	// Lower is the lower bound of the 95% confidence interval.
+	Lower float64
+	// Upper is the upper bound of the 95% confidence interval.
+	Upper float64
+	// Games is the number of games the estimate is based on.
+	Games int
+}
+
+// EstimateElo estimates the Elo difference of a player that won, drew and lost
+// the given number of games. The confidence interval is computed from the
+// standard error of the score, then converted to Elo.
+// It returns false if there are fewer than two games or the score is 0% or 100%,
+// since no finite difference can be estimated from such a record.
+func EstimateElo(wins, draws, losses int) (EloEstimate, bool) {
+	games := wins + draws + losses
+	if games < 2 {
+		return EloEstimate{}, false
+	}
+
+	n := float64(games)
+	score := (float64(wins) + float64(draws)/2) / n
+	if score <= 0 || score >= 1 {
+		return EloEstimate{}, false
+	}
+
+	// Per-game variance of the score around its mean
+	variance := (float64(wins)*math.Pow(1-score, 2) +
+		float64(draws)*math.Pow(0.5-score, 2) +
+		float64(losses)*math.Pow(score, 2)) / n
+	margin := eloZ95 * math.Sqrt(variance/n)
+
+	return EloEstimate{
+		Diff:  scoreToElo(score),
+		Lower: scoreToElo(score - margin),
+		Upper: scoreToElo(score + margin),
+		Games: games,
+	}, true
+}
+
+// scoreToElo converts an expected score (0-1) to an Elo difference.
+// Scores at or beyond the limits map to infinities.
+func scoreToElo(score float64) float64 {
+	if score <= 0 {
+		return math.Inf(-1)
+	}
+	if score >= 1 {
+		return math.Inf(1)
+	}
+	return -400 * math.Log10(1/score-1)
+}
+
+// String formats the estimate, e.g. "+35 (95% CI: -7 to +77)".
+func (e EloEstimate) String() string {
+	return fmt.Sprintf("%s (95%% CI: %s to %s)", formatElo(e.Diff), formatElo(e.Lower), formatElo(e.Upper))
+}
+
+// formatElo formats an Elo difference with an explicit sign.
+func formatElo(elo float64) string {
+	switch {
+	case math.IsInf(elo, 1):
+		return "+inf"
+	case math.IsInf(elo, -1):
+		return "-inf"
+	}
+	return fmt.Sprintf("%+.0f", elo)
+}
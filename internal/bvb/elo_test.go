@@ -0,0 +1,86 @@
+package bvb
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEstimateElo(t *testing.T) {
+	// An even score is a difference of zero
+	even, ok := EstimateElo(3, 4, 3)
+	if !ok {
+		t.Fatal("Expected an estimate for an even score")
+	}
+	if even.Diff != 0 || even.Games != 10 {
+		t.Errorf("Expected +0 over 10 games, got %+v", even)
+	}
+	if even.Lower >= 0 || even.Upper <= 0 || math.Abs(even.Lower+even.Upper) > 1e-9 {
+		t.Errorf("Expected a symmetric interval around 0, got %+v", even)
+	}
+
+	// 75% is about +191 Elo
+	e, ok := EstimateElo(6, 3, 1)
+	if !ok {
+		t.Fatal("Expected an estimate for a 75% score")
+	}
+	if math.Abs(e.Diff-190.85) > 0.1 {
+		t.Errorf("Diff = %.2f, want about 190.85", e.Diff)
+	}
+	if e.Lower >= e.Diff || e.Upper <= e.Diff {
+		t.Errorf("Expected the interval to contain the estimate, got %+v", e)
+	}
+
+	// More games give a narrower interval
+	more, _ := EstimateElo(60, 30, 10)
+	if more.Upper-more.Lower >= e.Upper-e.Lower {
+		t.Errorf("Expected a narrower interval with more games, got %+v vs %+v", more, e)
+	}
+
+	// The losing side gets the mirrored estimate
+	mirrored, _ := EstimateElo(1, 3, 6)
+	if math.Abs(mirrored.Diff+e.Diff) > 1e-9 {
+		t.Errorf("Expected %.2f for the losing side, got %.2f", -e.Diff, mirrored.Diff)
+	}
+}
+
+func TestEstimateEloUndefined(t *testing.T) {
+	tests := []struct {
+		name                string
+		wins, draws, losses int
+	}{
+		{"no games", 0, 0, 0},
+		{"single game", 0, 1, 0},
+		{"all wins", 5, 0, 0},
+		{"all losses", 0, 0, 4},
+	}
+	for _, tt := range tests {
+		if _, ok := EstimateElo(tt.wins, tt.draws, tt.losses); ok {
+			t.Errorf("%s: expected no estimate", tt.name)
+		}
+	}
+}
+
+func TestEloEstimateString(t *testing.T) {
+	e := EloEstimate{Diff: 35.4, Lower: -7.2, Upper: 77.9}
+	if got, want := e.String(), "+35 (95% CI: -7 to +78)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestComputeStatsElo(t *testing.T) {
+	results := []GameResult{
+		{GameNumber: 1, Winner: "White Bot", MoveCount: 30},
+		{GameNumber: 2, Winner: "Draw", MoveCount: 40},
+		{GameNumber: 3, Winner: "Black Bot", MoveCount: 50},
+		{GameNumber: 4, Winner: "White Bot", MoveCount: 60},
+	}
+	stats := ComputeStats(results, "White Bot", "Black Bot")
+	if stats.Elo == nil || stats.Elo.Diff <= 0 {
+		t.Fatalf("Expected a positive Elo estimate for the white bot, got %+v", stats.Elo)
+	}
+
+	stats = ComputeStats(results[:1], "White Bot", "Black Bot")
+	if stats.Elo != nil {
+		t.Errorf("Expected no Elo estimate from a single game, got %+v", stats.Elo)
+	}
+}
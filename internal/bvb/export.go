@@ -6,6 +6,8 @@ import (
 	"os"
 	"path/filepath"
 	"time"
+
+	"github.com/Mgrdich/TermChess/internal/config"
 )
 
 // SessionExport represents the complete export data for a Bot vs Bot session.
@@ -19,17 +21,25 @@ type SessionExport struct {
 	BlackWins    int          `json:"black_wins"`
 	Draws        int          `json:"draws"`
 	AverageMoves float64      `json:"average_moves"`
+	Seed         int64        `json:"seed"`            // Reproduces the session's built-in bots
+	Pairs        *PairStats   `json:"pairs,omitempty"` // Set when the bots swapped colors every other game
 	Games        []GameExport `json:"games"`
 }
 
 // GameExport represents the export data for a single game.
 type GameExport struct {
-	GameNumber        int      `json:"game_number"`
-	Result            string   `json:"result"`      // "White", "Black", "Draw"
-	TerminationReason string   `json:"termination"` // "Checkmate", "Stalemate", etc.
-	MoveCount         int      `json:"move_count"`
-	Moves             []string `json:"moves"`     // Coordinate notation (e.g., "e2e4")
-	FinalFEN          string   `json:"final_fen"` // Final position in FEN
+	GameNumber         int      `json:"game_number"`
+	Result             string   `json:"result"`      // "White", "Black", "Draw"
+	TerminationReason  string   `json:"termination"` // "Checkmate", "Stalemate", etc.
+	MoveCount          int      `json:"move_count"`
+	Moves              []string `json:"moves"`                   // Coordinate notation (e.g., "e2e4")
+	FinalFEN           string   `json:"final_fen"`               // Final position in FEN
+	MoveTimesMs        []int64  `json:"move_times_ms,omitempty"` // Think time per move in milliseconds
+	Flags              []string `json:"flags,omitempty"`         // Notable events, e.g. "underpromotion"
+	Bookmarked         bool     `json:"bookmarked,omitempty"`
+	StartFEN           string   `json:"start_fen,omitempty"`           // Starting position, if not the standard one
+	Reversed           bool     `json:"reversed,omitempty"`            // The black bot played White
+	RepetitionWarnings int      `json:"repetition_warnings,omitempty"` // Moves that repeated a position for the second time
 }
 
 // ExportStats generates a SessionExport from the SessionManager's completed games.
@@ -42,10 +52,12 @@ func (m *SessionManager) ExportStats(whiteBot, blackBot string) *SessionExport {
 		Timestamp: time.Now(),
 		WhiteBot:  whiteBot,
 		BlackBot:  blackBot,
+		Seed:      m.seed,
 		Games:     make([]GameExport, 0),
 	}
 
 	var totalMoves int
+	var results []GameResult
 	for _, s := range m.sessions {
 		if s == nil || !s.IsFinished() {
 			continue
@@ -57,13 +69,14 @@ func (m *SessionManager) ExportStats(whiteBot, blackBot string) *SessionExport {
 		}
 
 		export.TotalGames++
+		results = append(results, *result)
 
 		// Determine result string for export
 		var resultStr string
 		if result.Winner == "Draw" {
 			resultStr = "Draw"
 			export.Draws++
-		} else if result.Winner == m.whiteName {
+		} else if result.Winner == m.white.DisplayName() {
 			resultStr = "White"
 			export.WhiteWins++
 		} else {
@@ -77,19 +90,33 @@ func (m *SessionManager) ExportStats(whiteBot, blackBot string) *SessionExport {
 			moves[i] = move.String()
 		}
 
+		// Convert think times to milliseconds
+		var moveTimes []int64
+		for _, d := range result.MoveTimes {
+			moveTimes = append(moveTimes, d.Milliseconds())
+		}
+
 		totalMoves += result.MoveCount
 
 		gameExport := GameExport{
-			GameNumber:        result.GameNumber,
-			Result:            resultStr,
-			TerminationReason: result.EndReason,
-			MoveCount:         result.MoveCount,
-			Moves:             moves,
-			FinalFEN:          result.FinalFEN,
+			GameNumber:         result.GameNumber,
+			Result:             resultStr,
+			TerminationReason:  result.EndReason,
+			MoveCount:          result.MoveCount,
+			Moves:              moves,
+			FinalFEN:           result.FinalFEN,
+			MoveTimesMs:        moveTimes,
+			Flags:              result.Flags.Names(),
+			Bookmarked:         m.bookmarks[result.GameNumber],
+			StartFEN:           result.StartFEN,
+			Reversed:           result.Reversed,
+			RepetitionWarnings: result.RepetitionWarnings,
 		}
 		export.Games = append(export.Games, gameExport)
 	}
 
+	export.Pairs = ComputePairStats(results, m.white.DisplayName())
+
 	// Calculate average moves
 	if export.TotalGames > 0 {
 		export.AverageMoves = float64(totalMoves) / float64(export.TotalGames)
@@ -99,7 +126,7 @@ func (m *SessionManager) ExportStats(whiteBot, blackBot string) *SessionExport {
 }
 
 // SaveSessionExport saves a SessionExport to a JSON file.
-// If dir is empty, it uses the default directory ~/.termchess/stats/.
+// If dir is empty, it uses the stats directory in the data directory.
 // Returns the full path to the created file, or an error if the operation fails.
 func SaveSessionExport(export *SessionExport, dir string) (string, error) {
 	if export == nil {
@@ -108,11 +135,11 @@ func SaveSessionExport(export *SessionExport, dir string) (string, error) {
 
 	// Use default directory if not specified
 	if dir == "" {
-		homeDir, err := os.UserHomeDir()
+		dataDir, err := config.GetDataDir()
 		if err != nil {
-			return "", fmt.Errorf("failed to get home directory: %w", err)
+			return "", fmt.Errorf("failed to get data directory: %w", err)
 		}
-		dir = filepath.Join(homeDir, ".termchess", "stats")
+		dir = filepath.Join(dataDir, "stats")
 	}
 
 	// Create directory if it doesn't exist
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/Mgrdich/TermChess/internal/bot"
+	"github.com/Mgrdich/TermChess/internal/config"
 	"github.com/Mgrdich/TermChess/internal/engine"
 )
 
@@ -348,8 +349,8 @@ func TestSaveSessionExportDefaultDirectory(t *testing.T) {
 	}
 
 	// Verify path contains expected components.
-	if !strings.Contains(filepath, ".termchess") {
-		t.Errorf("Path %s does not contain .termchess", filepath)
+	if dataDir, _ := config.GetDataDir(); !strings.HasPrefix(filepath, dataDir) {
+		t.Errorf("Path %s is not in the data directory %s", filepath, dataDir)
 	}
 	if !strings.Contains(filepath, "stats") {
 		t.Errorf("Path %s does not contain stats", filepath)
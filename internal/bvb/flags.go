@@ -0,0 +1,162 @@
+package bvb
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// longGameMoves is the number of moves after which a game is flagged as long.
+const longGameMoves = 200
+
+// imbalanceThreshold is the material difference, in pawns, that flags a game
+// once it holds for a full move, so a queen trade in progress doesn't count.
+const imbalanceThreshold = 9
+
+// GameFlags marks the notable events of a game.
+type GameFlags uint8
+
+const (
+	// FlagPromotion marks a game in which a pawn promoted.
+	FlagPromotion GameFlags = 1 << iota
+	// FlagUnderpromotion marks a game in which a pawn promoted to something other than a queen.
+	FlagUnderpromotion
+	// FlagLongGame marks a game that lasted longGameMoves moves or more.
+	FlagLongGame
+	// FlagMaterialImbalance marks a game in which one side was imbalanceThreshold
+	// pawns of material ahead.
+	FlagMaterialImbalance
+)
+
+// flagNames lists the display name of each flag, in the order they are shown.
+var flagNames = []struct {
+	flag GameFlags
+	name string
+}{
+	{FlagPromotion, "promotion"},
+	{FlagUnderpromotion, "underpromotion"},
+	{FlagLongGame, "long game"},
+	{FlagMaterialImbalance, "material imbalance"},
+}
+
+// Has reports whether all of flag are set.
+func (f GameFlags) Has(flag GameFlags) bool {
+	return f&flag == flag
+}
+
+// Names returns the display names of the set flags.
+func (f GameFlags) Names() []string {
+	var names []string
+	for _, fn := range flagNames {
+		if f.Has(fn.flag) {
+			names = append(names, fn.name)
+		}
+	}
+	return names
+}
+
+// String returns the set flags as a comma-separated list, e.g. "promotion, long game".
+func (f GameFlags) String() string {
+	return strings.Join(f.Names(), ", ")
+}
+
+// pieceValues holds the material value of each piece type in pawns.
+var pieceValues = [...]int{engine.Pawn: 1, engine.Knight: 3, engine.Bishop: 3, engine.Rook: 5, engine.Queen: 9}
+
+// materialDifference returns White's material minus Black's, in pawns.
+func materialDifference(board *engine.Board) int {
+	diff := 0
+	for _, p := range board.Squares {
+		if p.IsEmpty() || p.Type() == engine.King {
+			continue
+		}
+		if p.Color() == engine.White {
+			diff += pieceValues[p.Type()]
+		} else {
+			diff -= pieceValues[p.Type()]
+		}
+	}
+	return diff
+}
+
+// flagMove records the notable events of move, just played on s.board and
+// recorded in s.material. Must be called with s.mu held.
+func (s *GameSession) flagMove(move engine.Move) {
+	if move.Promotion != engine.Empty {
+		s.flags |= FlagPromotion
+		if move.Promotion != engine.Queen {
+			s.flags |= FlagUnderpromotion
+		}
+	}
+	if len(s.moveHistory) >= longGameMoves {
+		s.flags |= FlagLongGame
+	}
+
+	diff := s.material[len(s.material)-1]
+	imbalanced := diff >= imbalanceThreshold || diff <= -imbalanceThreshold
+	if imbalanced && s.imbalanced {
+		s.flags |= FlagMaterialImbalance
+	}
+	s.imbalanced = imbalanced
+
+	if s.board.RepetitionCount() == 2 {
+		s.repetitions++
+	}
+}
+
+// Flags returns the notable events of the game so far.
+func (s *GameSession) Flags() GameFlags {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.flags
+}
+
+// ToggleBookmark bookmarks the game with the given number, or removes its
+// bookmark, and reports whether it is bookmarked now.
+func (m *SessionManager) ToggleBookmark(gameNumber int) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.bookmarks[gameNumber] {
+		delete(m.bookmarks, gameNumber)
+		return false
+	}
+	if m.bookmarks == nil {
+		m.bookmarks = make(map[int]bool)
+	}
+	m.bookmarks[gameNumber] = true
+	return true
+}
+
+// IsBookmarked reports whether the game with the given number is bookmarked.
+func (m *SessionManager) IsBookmarked(gameNumber int) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.bookmarks[gameNumber]
+}
+
+// Bookmarks returns the numbers of the bookmarked games in ascending order.
+func (m *SessionManager) Bookmarks() []int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	games := make([]int, 0, len(m.bookmarks))
+	for n := range m.bookmarks {
+		games = append(games, n)
+	}
+	sort.Ints(games)
+	return games
+}
+
+// NotableGames returns the numbers of the games that are bookmarked or have
+// flags, in ascending order.
+func (m *SessionManager) NotableGames() []int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var games []int
+	for _, s := range m.sessions {
+		if s != nil && (m.bookmarks[s.gameNumber] || s.Flags() != 0) {
+			games = append(games, s.gameNumber)
+		}
+	}
+	return games
+}
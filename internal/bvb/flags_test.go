@@ -0,0 +1,143 @@
+package bvb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+func TestGameFlagsString(t *testing.T) {
+	tests := []struct {
+		flags GameFlags
+		want  string
+	}{
+		{0, ""},
+		{FlagPromotion, "promotion"},
+		{FlagPromotion | FlagUnderpromotion, "promotion, underpromotion"},
+		{FlagMaterialImbalance | FlagLongGame, "long game, material imbalance"},
+	}
+	for _, tt := range tests {
+		if got := tt.flags.String(); got != tt.want {
+			t.Errorf("GameFlags(%d).String() = %q, want %q", tt.flags, got, tt.want)
+		}
+	}
+}
+
+// sessionAt returns a session whose board is set up from fen.
+func sessionAt(t *testing.T, fen string) *GameSession {
+	t.Helper()
+	board, err := engine.FromFEN(fen)
+	if err != nil {
+		t.Fatalf("FromFEN(%q) error: %v", fen, err)
+	}
+	s := NewGameSession(1, nil, nil, "White", "Black", new(PlaybackSpeed))
+	s.board = board
+	return s
+}
+
+// play applies move to the session's board and records its material and flags.
+func play(t *testing.T, s *GameSession, move string) {
+	t.Helper()
+	m, err := engine.ParseMove(move)
+	if err != nil {
+		t.Fatalf("ParseMove(%q) error: %v", move, err)
+	}
+	if err := s.board.MakeMove(m); err != nil {
+		t.Fatalf("MakeMove(%q) error: %v", move, err)
+	}
+	s.moveHistory = append(s.moveHistory, m)
+	s.material = append(s.material, materialDifference(s.board))
+	s.flagMove(m)
+}
+
+func TestFlagMovePromotion(t *testing.T) {
+	s := sessionAt(t, "8/P6k/8/8/8/8/8/K7 w - - 0 1")
+	play(t, s, "a7a8q")
+	if s.Flags() != FlagPromotion {
+		t.Errorf("Flags after a queen promotion = %q, want promotion", s.Flags())
+	}
+
+	s = sessionAt(t, "8/P6k/8/8/8/8/8/K7 w - - 0 1")
+	play(t, s, "a7a8n")
+	if !s.Flags().Has(FlagPromotion | FlagUnderpromotion) {
+		t.Errorf("Flags after a knight promotion = %q, want promotion and underpromotion", s.Flags())
+	}
+}
+
+func TestFlagMoveMaterialImbalance(t *testing.T) {
+	// White can take the black queen, and Black can recapture
+	s := sessionAt(t, "4k3/8/8/3q4/8/3Q4/8/4K3 w - - 0 1")
+	play(t, s, "d3d5")
+	if s.Flags() != 0 {
+		t.Errorf("Flags after taking a queen = %q, want none until Black replies", s.Flags())
+	}
+	play(t, s, "e8e7")
+	if !s.Flags().Has(FlagMaterialImbalance) {
+		t.Errorf("Flags after the imbalance held = %q, want material imbalance", s.Flags())
+	}
+
+	// A queen trade evens out again
+	s = sessionAt(t, "3rk3/8/8/3q4/8/3Q4/8/4K3 w - - 0 1")
+	play(t, s, "d3d5")
+	play(t, s, "d8d5")
+	if s.Flags() != 0 {
+		t.Errorf("Flags after a queen trade = %q, want none", s.Flags())
+	}
+}
+
+func TestFlagMoveLongGame(t *testing.T) {
+	s := sessionAt(t, "4k3/8/8/8/8/8/8/4K3 w - - 0 1")
+	s.moveHistory = make([]engine.Move, longGameMoves-1)
+	play(t, s, "e1d1")
+	if !s.Flags().Has(FlagLongGame) {
+		t.Errorf("Flags after %d moves = %q, want long game", longGameMoves, s.Flags())
+	}
+}
+
+func TestFlagMoveRepetitions(t *testing.T) {
+	s := sessionAt(t, "4k3/8/8/8/8/8/8/4K3 w - - 0 1")
+	for _, move := range []string{"e1d1", "e8d8", "d1e1"} {
+		play(t, s, move)
+	}
+	if s.repetitions != 0 {
+		t.Fatalf("repetitions before any position repeated = %d, want 0", s.repetitions)
+	}
+
+	// Back to the starting position, which has now occurred twice
+	play(t, s, "d8e8")
+	if s.repetitions != 1 {
+		t.Errorf("repetitions after the first repeat = %d, want 1", s.repetitions)
+	}
+	play(t, s, "e1d1")
+	if s.repetitions != 2 {
+		t.Errorf("repetitions after the second repeat = %d, want 2", s.repetitions)
+	}
+}
+
+func TestSessionManagerBookmarks(t *testing.T) {
+	m := NewSessionManager(0, 0, "White", "Black", 3, 1)
+	flagged := finishedSession(3, "White", engine.White)
+	flagged.flags = FlagUnderpromotion
+	m.sessions = []*GameSession{
+		finishedSession(1, "White", engine.White),
+		finishedSession(2, "Draw", engine.White),
+		flagged,
+	}
+
+	if !m.ToggleBookmark(2) {
+		t.Error("ToggleBookmark(2) = false, want bookmarked")
+	}
+	if !m.IsBookmarked(2) || m.IsBookmarked(1) {
+		t.Error("Expected only game 2 to be bookmarked")
+	}
+	if got := m.NotableGames(); !reflect.DeepEqual(got, []int{2, 3}) {
+		t.Errorf("NotableGames() = %v, want [2 3]", got)
+	}
+	if m.ToggleBookmark(2) {
+		t.Error("ToggleBookmark(2) again = true, want the bookmark removed")
+	}
+	if got := m.Bookmarks(); len(got) != 0 {
+		t.Errorf("Bookmarks() = %v, want none", got)
+	}
+}
@@ -0,0 +1,60 @@
+package bvb
+
+import (
+	"context"
+	"time"
+)
+
+// headlessPollInterval is how often RunHeadless checks for finished games.
+const headlessPollInterval = 50 * time.Millisecond
+
+// HeadlessProgress is called by RunHeadless each time a game finishes.
+// finished is the number of games completed so far, including this one.
+type HeadlessProgress func(result GameResult, finished, total int)
+
+// RunHeadless starts the manager's games at instant speed and blocks until all
+// of them have finished or ctx is cancelled, and the workers have exited. onFinish, if non-nil, is called once
+// per game in the order the games complete.
+//
+// If ctx is cancelled, the remaining games are aborted and ctx.Err() is returned.
+// Games that finished before the cancellation are still available through
+// ExportStats and Stats.
+func (m *SessionManager) RunHeadless(ctx context.Context, onFinish HeadlessProgress) error {
+	m.SetSpeed(SpeedInstant)
+	if err := m.Start(); err != nil {
+		return err
+	}
+
+	total := m.GameCount()
+	reported := make([]bool, total)
+	finished := 0
+
+	ticker := time.NewTicker(headlessPollInterval)
+	defer ticker.Stop()
+
+	for {
+		for i, s := range m.Sessions() {
+			if reported[i] || s == nil || !s.IsFinished() {
+				continue
+			}
+			reported[i] = true
+			finished++
+			if r := s.Result(); r != nil && onFinish != nil {
+				onFinish(*r, finished, total)
+			}
+		}
+		if finished == total {
+			m.Wait()
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			// Let the running games stop so their engines are closed before returning
+			m.Abort()
+			m.Wait()
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package bvb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/bot"
+)
+
+func TestRunHeadlessReportsEveryGame(t *testing.T) {
+	m := NewSessionManager(bot.Easy, bot.Easy, "White", "Black", 3, 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	var calls []int
+	seen := make(map[int]bool)
+	err := m.RunHeadless(ctx, func(r GameResult, finished, total int) {
+		if total != 3 {
+			t.Errorf("total = %d, want 3", total)
+		}
+		if seen[r.GameNumber] {
+			t.Errorf("game %d reported twice", r.GameNumber)
+		}
+		seen[r.GameNumber] = true
+		calls = append(calls, finished)
+	})
+	if err != nil {
+		t.Fatalf("RunHeadless() error: %v", err)
+	}
+
+	if len(calls) != 3 {
+		t.Fatalf("progress called %d times, want 3", len(calls))
+	}
+	for i, finished := range calls {
+		if finished != i+1 {
+			t.Errorf("call %d: finished = %d, want %d", i, finished, i+1)
+		}
+	}
+	if m.Speed() != SpeedInstant {
+		t.Errorf("speed = %v, want SpeedInstant", m.Speed())
+	}
+	if export := m.ExportStats("Easy", "Easy"); export.TotalGames != 3 {
+		t.Errorf("exported %d games, want 3", export.TotalGames)
+	}
+}
+
+func TestRunHeadlessCancelled(t *testing.T) {
+	m := NewSessionManager(bot.Easy, bot.Easy, "White", "Black", 2, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := m.RunHeadless(ctx, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("RunHeadless() error = %v, want context.Canceled", err)
+	}
+	if m.State() != StateFinished {
+		t.Errorf("state = %v, want StateFinished after cancellation", m.State())
+	}
+}
+
+func TestRunHeadlessSeedReplaysGames(t *testing.T) {
+	play := func(seed int64) *SessionExport {
+		m := NewSessionManager(bot.Easy, bot.Easy, "White", "Black", 2, 2)
+		m.SetSeed(seed)
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+		if err := m.RunHeadless(ctx, nil); err != nil {
+			t.Fatalf("RunHeadless() error: %v", err)
+		}
+		return m.ExportStats("White", "Black")
+	}
+
+	first, second := play(7), play(7)
+	if first.Seed != 7 {
+		t.Errorf("export seed = %d, want 7", first.Seed)
+	}
+	for i := range first.Games {
+		a, b := first.Games[i], second.Games[i]
+		if a.GameNumber != b.GameNumber || len(a.Moves) != len(b.Moves) || a.FinalFEN != b.FinalFEN {
+			t.Fatalf("game %d differs with the same seed: %d moves to %q vs %d moves to %q",
+				a.GameNumber, len(a.Moves), a.FinalFEN, len(b.Moves), b.FinalFEN)
+		}
+	}
+	if GameSeed(7, 1, 0) == GameSeed(7, 2, 0) || GameSeed(7, 1, 0) == GameSeed(7, 1, 1) {
+		t.Error("Expected every bot of the session to get its own seed")
+	}
+}
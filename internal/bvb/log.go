@@ -0,0 +1,106 @@
+package bvb
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// sessionLogSize is the number of entries a session log keeps before the
+// oldest ones are dropped.
+const sessionLogSize = 500
+
+// LogLevel is the severity of a session log entry.
+type LogLevel int
+
+const (
+	// LogInfo records a decision taken about a game, such as an adjudication.
+	LogInfo LogLevel = iota
+	// LogWarning records something that cut a game short, such as an abort.
+	LogWarning
+	// LogError records a bot failing, such as an engine error or an illegal move.
+	LogError
+)
+
+// String returns the name of the level.
+func (l LogLevel) String() string {
+	switch l {
+	case LogInfo:
+		return "info"
+	case LogWarning:
+		return "warning"
+	case LogError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// LogEntry is one event of a session log.
+type LogEntry struct {
+	// Time is when the event happened.
+	Time time.Time
+	// Game is the number of the game the event belongs to, or 0 for the session.
+	Game int
+	// Level is the severity of the event.
+	Level LogLevel
+	// Message describes the event.
+	Message string
+}
+
+// SessionLog keeps the warnings, bot errors and adjudication decisions of a Bot vs
+// Bot session, so they can still be read after the games that caused them are
+// gone. It is a ring buffer: once full, each new entry replaces the oldest one.
+// It is safe for concurrent use.
+type SessionLog struct {
+	mu      sync.Mutex
+	entries []LogEntry
+	next    int // index the next entry is written to once the buffer is full
+	dropped int // entries overwritten so far
+}
+
+// NewSessionLog creates a log that keeps the last size entries.
+func NewSessionLog(size int) *SessionLog {
+	if size < 1 {
+		size = 1
+	}
+	return &SessionLog{entries: make([]LogEntry, 0, size)}
+}
+
+// Addf adds an entry for game (0 for the session as a whole) at the given level.
+func (l *SessionLog) Addf(game int, level LogLevel, format string, args ...any) {
+	entry := LogEntry{Time: time.Now(), Game: game, Level: level, Message: fmt.Sprintf(format, args...)}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.entries) < cap(l.entries) {
+		l.entries = append(l.entries, entry)
+		return
+	}
+	l.entries[l.next] = entry
+	l.next = (l.next + 1) % len(l.entries)
+	l.dropped++
+}
+
+// Entries returns the entries kept, oldest first.
+func (l *SessionLog) Entries() []LogEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entries := make([]LogEntry, 0, len(l.entries))
+	entries = append(entries, l.entries[l.next:]...)
+	return append(entries, l.entries[:l.next]...)
+}
+
+// Dropped returns the number of entries that were dropped to make room for newer ones.
+func (l *SessionLog) Dropped() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.dropped
+}
+
+// Len returns the number of entries kept.
+func (l *SessionLog) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.entries)
+}
@@ -0,0 +1,78 @@
+package bvb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/bot"
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// failingEngine is a bot whose every move fails.
+type failingEngine struct{}
+
+func (failingEngine) SelectMove(ctx context.Context, board *engine.Board) (engine.Move, error) {
+	return engine.Move{}, errors.New("out of memory")
+}
+func (failingEngine) Name() string { return "Failing" }
+func (failingEngine) Close() error { return nil }
+
+func TestSessionLogRingBuffer(t *testing.T) {
+	log := NewSessionLog(3)
+	for i := 1; i <= 5; i++ {
+		log.Addf(i, LogWarning, "event %d", i)
+	}
+
+	entries := log.Entries()
+	if len(entries) != 3 || log.Dropped() != 2 {
+		t.Fatalf("Expected the last 3 entries with 2 dropped, got %d with %d dropped", len(entries), log.Dropped())
+	}
+	for i, e := range entries {
+		if want := i + 3; e.Game != want || e.Message != fmt.Sprintf("event %d", want) {
+			t.Errorf("entries[%d] = game %d %q, want game %d", i, e.Game, e.Message, want)
+		}
+	}
+}
+
+func TestSessionManagerLogsBotErrors(t *testing.T) {
+	m := NewSessionManager(bot.Easy, bot.Easy, "White", "Broken", 1, 1)
+	m.UseBots(nil, func() (bot.Engine, error) { return failingEngine{}, nil })
+	if err := m.RunHeadless(context.Background(), nil); err != nil {
+		t.Fatalf("RunHeadless() error: %v", err)
+	}
+
+	entries := m.Log().Entries()
+	if len(entries) != 1 {
+		t.Fatalf("Expected one log entry, got %+v", entries)
+	}
+	e := entries[0]
+	if e.Game != 1 || e.Level != LogError || !strings.Contains(e.Message, "Broken (Black) failed and loses: out of memory") {
+		t.Errorf("Unexpected log entry %+v", e)
+	}
+}
+
+func TestGameSessionLogsAbort(t *testing.T) {
+	whiteEngine, _ := bot.NewRandomEngine()
+	blackEngine, _ := bot.NewRandomEngine()
+	speed := SpeedNormal
+	session := NewGameSession(4, whiteEngine, blackEngine, "White Bot", "Black Bot", &speed)
+	session.log = NewSessionLog(10)
+
+	done := make(chan struct{})
+	go func() {
+		session.Run()
+		close(done)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	session.Abort()
+	<-done
+
+	entries := session.log.Entries()
+	if len(entries) != 1 || entries[0].Game != 4 || entries[0].Level != LogWarning || !strings.HasPrefix(entries[0].Message, "aborted after") {
+		t.Errorf("Expected the abort to be logged, got %+v", entries)
+	}
+}
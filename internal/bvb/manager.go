@@ -1,11 +1,15 @@
 package bvb
 
 import (
+	"context"
+	"io"
 	"runtime"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/Mgrdich/TermChess/internal/bot"
+	"github.com/Mgrdich/TermChess/internal/engine"
 )
 
 // maxConcurrentGames limits how many games run simultaneously.
@@ -58,15 +62,23 @@ type SessionManager struct {
 	sessions    []*GameSession
 	state       SessionState
 	speed       PlaybackSpeed
-	whiteDiff   bot.Difficulty
-	blackDiff   bot.Difficulty
-	whiteName   string
-	blackName   string
+	white       bot.Config
+	black       bot.Config
+	whiteBot    bot.Factory // creates the white engines instead of white, if set
+	blackBot    bot.Factory // creates the black engines instead of black, if set
 	gameCount   int
-	concurrency int           // effective concurrency (auto-detected or user-specified)
-	semaphore   chan struct{} // limits concurrent game execution
-	abortCh     chan struct{} // signals all waiting goroutines to abort
-	activeCount int32         // atomic counter for currently running games
+	concurrency int             // effective concurrency: the number of workers playing games
+	seed        int64           // seeds the built-in bots' random choices, see GameSeed
+	observer    Observer        // notified of every game's moves and ending, may be nil
+	pool        *workerPool     // plays the games, nil until Start
+	done        chan struct{}   // closed once the workers have exited and the session is cleaned up
+	activeCount int32           // atomic counter for currently running games
+	sprt        *SPRTConfig     // sequential test that can end the session early, nil if disabled
+	sprtResult  *SPRTStatus     // status at the moment the test reached a decision
+	bookmarks   map[int]bool    // numbers of the games the user bookmarked
+	openings    []*engine.Board // positions the games start from in turn, none for the standard one
+	paired      bool            // play each opening twice, the bots swapping colors
+	log         *SessionLog     // errors, warnings and adjudications of the games
 }
 
 // NewSessionManager creates a new manager configured for the given matchup.
@@ -74,6 +86,14 @@ type SessionManager struct {
 // If concurrency is 0, it auto-detects based on CPU count (capped at maxConcurrentGames).
 // If concurrency is explicitly provided by the user, it is NOT capped (user accepts responsibility).
 func NewSessionManager(whiteDiff, blackDiff bot.Difficulty, whiteName, blackName string, gameCount, concurrency int) *SessionManager {
+	white := bot.Config{Difficulty: whiteDiff, Name: whiteName}
+	black := bot.Config{Difficulty: blackDiff, Name: blackName}
+	return NewSessionManagerFor(white, black, gameCount, concurrency)
+}
+
+// NewSessionManagerFor creates a new manager for a matchup between the bots
+// white and black, concurrency working as for NewSessionManager.
+func NewSessionManagerFor(white, black bot.Config, gameCount, concurrency int) *SessionManager {
 	// Auto-detect if concurrency is 0
 	effectiveConcurrency := concurrency
 	if effectiveConcurrency == 0 {
@@ -88,104 +108,342 @@ func NewSessionManager(whiteDiff, blackDiff bot.Difficulty, whiteName, blackName
 	return &SessionManager{
 		state:       StateRunning,
 		speed:       SpeedNormal,
-		whiteDiff:   whiteDiff,
-		blackDiff:   blackDiff,
-		whiteName:   whiteName,
-		blackName:   blackName,
+		white:       white,
+		black:       black,
+		whiteBot:    white.Factory(),
+		blackBot:    black.Factory(),
 		gameCount:   gameCount,
 		concurrency: effectiveConcurrency,
+		seed:        time.Now().UnixNano(),
+		log:         NewSessionLog(sessionLogSize),
 	}
 }
 
-// Start creates engine instances for each game and launches them via a coordinator.
-// Games are started in order (1, 2, 3, ...) with up to concurrency running at once.
+// Start creates the game sessions and launches them via a coordinator. Games are
+// started in order (1, 2, 3, ...) with up to concurrency running at once; each
+// game's engines are created by the worker that plays it.
 func (m *SessionManager) Start() error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	m.sessions = make([]*GameSession, m.gameCount)
 
-	// Create semaphore to limit concurrent games
-	// Use the smaller of concurrency and gameCount
-	semaphoreSize := m.concurrency
-	if m.gameCount < semaphoreSize {
-		semaphoreSize = m.gameCount
-	}
-	m.semaphore = make(chan struct{}, semaphoreSize)
-	m.abortCh = make(chan struct{})
-
-	// Pre-create all sessions and their engines
+	// Pre-create all sessions, without engines so the manager never holds more
+	// engines, or external bot processes, than there are workers
 	for i := 0; i < m.gameCount; i++ {
-		whiteEngine, err := createEngine(m.whiteDiff)
-		if err != nil {
-			m.abortSessions()
-			return err
-		}
-		blackEngine, err := createEngine(m.blackDiff)
-		if err != nil {
-			whiteEngine.Close()
-			m.abortSessions()
-			return err
-		}
-
 		sessionSpeed := new(PlaybackSpeed)
 		*sessionSpeed = m.speed
-		session := NewGameSession(i+1, whiteEngine, blackEngine, m.whiteName, m.blackName, sessionSpeed)
+		start, reversed := m.opening(i)
+		var session *GameSession
+		if reversed {
+			session = NewGameSession(i+1, nil, nil, m.black.DisplayName(), m.white.DisplayName(), sessionSpeed)
+			session.whiteTimeout = m.moveTimeout(m.blackBot, m.black)
+			session.blackTimeout = m.moveTimeout(m.whiteBot, m.white)
+		} else {
+			session = NewGameSession(i+1, nil, nil, m.white.DisplayName(), m.black.DisplayName(), sessionSpeed)
+			session.whiteTimeout = m.moveTimeout(m.whiteBot, m.white)
+			session.blackTimeout = m.moveTimeout(m.blackBot, m.black)
+		}
+		session.observer = m.observer
+		session.log = m.log
+		session.reversed = reversed
+		if start != nil {
+			session.board = start.Copy()
+			session.startFEN = start.ToFEN()
+		}
 		m.sessions[i] = session
 	}
 
-	// Launch coordinator goroutine that starts games in order
-	go m.coordinateGames()
+	// One worker per concurrent game, never more than there are games, fed from a
+	// queue of the same size so the coordinator stays at most one round ahead
+	workers := m.concurrency
+	if m.gameCount < workers {
+		workers = m.gameCount
+	}
+	m.pool = newWorkerPool(workers, workers, m.runGame)
+
+	// Close the observer once the workers are done
+	pool, observer := m.pool, m.observer
+	m.done = make(chan struct{})
+	go func(done chan struct{}) {
+		defer close(done)
+		pool.wait()
+		if closer, ok := observer.(io.Closer); ok {
+			_ = closer.Close()
+		}
+	}(m.done)
+
+	// Launch coordinator goroutine that queues games in order
+	go m.coordinateGames(m.pool)
 
 	return nil
 }
 
-// coordinateGames starts games sequentially as semaphore slots become available.
-// This ensures games start in order: 1-25 first, then 26, 27, etc.
-func (m *SessionManager) coordinateGames() {
+// coordinateGames queues the games in order, blocking while the queue is full, so
+// games start in order: 1-25 first, then 26, 27, etc. It stops early if the pool
+// is aborted.
+func (m *SessionManager) coordinateGames(pool *workerPool) {
+	defer pool.close()
 	for i := 0; i < m.gameCount; i++ {
-		// Wait for a semaphore slot or abort signal
-		select {
-		case m.semaphore <- struct{}{}: // acquired slot
-			// Start game i
-			atomic.AddInt32(&m.activeCount, 1)
-			go func(idx int) {
-				defer func() {
-					atomic.AddInt32(&m.activeCount, -1)
-					<-m.semaphore // release slot when done
-				}()
-
-				// Safely get the session while holding the lock
-				// This prevents race with Stop() which sets m.sessions = nil
-				m.mu.Lock()
-				if m.sessions == nil || idx >= len(m.sessions) || m.sessions[idx] == nil {
-					m.mu.Unlock()
-					return
-				}
-				session := m.sessions[idx]
-				m.mu.Unlock()
-
-				session.Run()
-			}(i)
-		case <-m.abortCh:
-			// Aborted, stop starting new games
+		if !pool.submit(i) {
 			return
 		}
 	}
 }
 
-// createEngine creates a bot engine based on difficulty.
-func createEngine(diff bot.Difficulty) (bot.Engine, error) {
-	switch diff {
-	case bot.Easy:
-		return bot.NewRandomEngine()
-	case bot.Medium:
-		return bot.NewMinimaxEngine(bot.Medium)
-	case bot.Hard:
-		return bot.NewMinimaxEngine(bot.Hard)
-	default:
-		return bot.NewRandomEngine()
+// runGame plays game idx on a pool worker. Cancelling ctx aborts the game.
+func (m *SessionManager) runGame(ctx context.Context, idx int) {
+	atomic.AddInt32(&m.activeCount, 1)
+	defer atomic.AddInt32(&m.activeCount, -1)
+
+	// Safely get the session while holding the lock
+	// This prevents race with Stop() which sets m.sessions = nil
+	m.mu.Lock()
+	if m.sessions == nil || idx >= len(m.sessions) || m.sessions[idx] == nil {
+		m.mu.Unlock()
+		return
+	}
+	session := m.sessions[idx]
+	whiteBot, white, blackBot, black, seed := m.whiteBot, m.white, m.blackBot, m.black, m.seed
+	m.mu.Unlock()
+
+	// A game aborted while it was queued never gets its engines
+	if session.IsFinished() {
+		return
+	}
+
+	stop := context.AfterFunc(ctx, session.Abort)
+	defer stop()
+
+	// The engines are created on the worker and closed by the session when the
+	// game ends, so only the games being played have engines running
+	whiteColor, blackColor := engine.White, engine.Black
+	if session.reversed {
+		whiteColor, blackColor = blackColor, whiteColor
+	}
+	whiteEngine, err := createEngine(whiteBot, white, GameSeed(seed, idx+1, engine.White))
+	if err != nil {
+		session.failToStart(whiteColor, err)
+		m.checkSPRT()
+		return
+	}
+	blackEngine, err := createEngine(blackBot, black, GameSeed(seed, idx+1, engine.Black))
+	if err != nil {
+		_ = whiteEngine.Close()
+		session.failToStart(blackColor, err)
+		m.checkSPRT()
+		return
+	}
+	if session.reversed {
+		whiteEngine, blackEngine = blackEngine, whiteEngine
+	}
+	session.setEngines(whiteEngine, blackEngine)
+
+	session.Run()
+	m.checkSPRT()
+}
+
+// SetObserver makes the session report every move and game ending to o as they
+// happen. If o is an io.Closer, it is closed once the workers have finished or
+// dropped every game. It must be called before Start.
+func (m *SessionManager) SetObserver(o Observer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.observer = o
+}
+
+// SetCPULimit caps the games the session plays at once at n, so with every game
+// busy on one core they use at most n of them. It only sizes the worker pool; the
+// Go runtime's settings are left alone. Zero or less sets no cap. It must be
+// called before Start.
+func (m *SessionManager) SetCPULimit(n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if n > 0 && n < m.concurrency {
+		m.concurrency = n
+	}
+}
+
+// Wait blocks until the workers have finished every game, or dropped the rest
+// after an abort, and exited. Engines of the games that ran are closed by then,
+// and so is the observer. It returns at once if the session hasn't been started.
+func (m *SessionManager) Wait() {
+	m.mu.Lock()
+	done := m.done
+	m.mu.Unlock()
+	if done != nil {
+		<-done
+	}
+}
+
+// EnableSPRT runs the session as a sequential probability ratio test: the game
+// count becomes the maximum number of games, and the remaining games are aborted
+// as soon as the test accepts H0 or H1. It must be called before Start.
+func (m *SessionManager) EnableSPRT(cfg SPRTConfig) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sprt = &cfg
+}
+
+// SPRT returns the current status of the sequential test, or false if the session
+// is not an SPRT. Once the test has decided, the status no longer changes.
+func (m *SessionManager) SPRT() (SPRTStatus, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.sprt == nil {
+		return SPRTStatus{}, false
+	}
+	if m.sprtResult != nil {
+		return *m.sprtResult, true
+	}
+	return m.sprtStatus(), true
+}
+
+// sprtStatus evaluates the sequential test on the finished games.
+// Must be called with m.mu held and m.sprt set.
+func (m *SessionManager) sprtStatus() SPRTStatus {
+	var wins, draws, losses int
+	for _, s := range m.sessions {
+		if s == nil || !s.IsFinished() {
+			continue
+		}
+		r := s.Result()
+		switch {
+		case r == nil:
+			// Aborted games don't count
+		case r.Winner == "Draw":
+			draws++
+		case (r.WinnerColor == engine.White) != r.Reversed:
+			// The white bot won, whichever color it played
+			wins++
+		default:
+			losses++
+		}
+	}
+	return m.sprt.Status(wins, draws, losses)
+}
+
+// checkSPRT stops the session once the sequential test has reached a decision.
+func (m *SessionManager) checkSPRT() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.sprt == nil || m.sprtResult != nil || m.sessions == nil {
+		return
+	}
+	status := m.sprtStatus()
+	if status.Decision == SPRTContinue {
+		return
+	}
+	m.sprtResult = &status
+	m.log.Addf(0, LogInfo, "SPRT decided after %d games, stopping the session: %s", status.Wins+status.Draws+status.Losses, status)
+	m.state = StateFinished
+	m.abortPool()
+	m.abortSessions()
+}
+
+// UseBots makes the session play bots created by the given factories, such as
+// registered custom bots, instead of the bots it was created with. A nil factory
+// keeps the bot for that color. It must be called before Start.
+func (m *SessionManager) UseBots(white, black bot.Factory) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.whiteBot = white
+	m.blackBot = black
+}
+
+// SetStartPosition makes every game start from board, such as a position loaded
+// from FEN, instead of the standard starting position. It must be called before Start.
+func (m *SessionManager) SetStartPosition(board *engine.Board) {
+	m.SetOpenings([]*engine.Board{board}, false)
+}
+
+// SetOpenings makes the games start from the given positions in turn, e.g. from
+// an opening suite, instead of the standard starting position: game 1 from the
+// first, game 2 from the second, and so on, starting over once they run out.
+// If paired is set, each opening is played twice in a row with the bots
+// swapping colors, so neither bot gets the better side of every opening.
+// It must be called before Start.
+func (m *SessionManager) SetOpenings(openings []*engine.Board, paired bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.openings = make([]*engine.Board, len(openings))
+	for i, board := range openings {
+		m.openings[i] = board.Copy()
+	}
+	m.paired = paired
+}
+
+// opening returns the position game idx (0-based) starts from, nil for the
+// standard one, and whether the bots swap colors for it.
+func (m *SessionManager) opening(idx int) (*engine.Board, bool) {
+	if !m.paired {
+		if len(m.openings) == 0 {
+			return nil, false
+		}
+		return m.openings[idx%len(m.openings)], false
 	}
+	reversed := idx%2 == 1
+	if len(m.openings) == 0 {
+		return nil, reversed
+	}
+	return m.openings[idx/2%len(m.openings)], reversed
+}
+
+// SetMoveTime makes every built-in bot think for d per move instead of the
+// budget of its difficulty. 0 restores the difficulty budgets. It must be
+// called before Start.
+func (m *SessionManager) SetMoveTime(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.white.MoveTime = d
+	m.black.MoveTime = d
+}
+
+// moveTimeout returns the deadline for the moves of a bot created by factory,
+// or described by cfg if factory is nil: the built-in bot's budget plus some
+// grace, or 0 for the default timeout of bots whose budget isn't known.
+func (m *SessionManager) moveTimeout(factory bot.Factory, cfg bot.Config) time.Duration {
+	if factory != nil {
+		return 0
+	}
+	return cfg.MoveTimeout()
+}
+
+// createEngine creates a bot engine from factory, or as described by cfg if
+// factory is nil. Built-in bots are seeded with seed; bots from a factory
+// manage their own randomness and time.
+func createEngine(factory bot.Factory, cfg bot.Config, seed int64) (bot.Engine, error) {
+	if factory != nil {
+		return factory()
+	}
+	return cfg.NewEngine(bot.WithSeed(seed))
+}
+
+// SetSeed makes the session reproducible: the built-in bots of every game are
+// seeded from seed and the game number, so rerunning with the same seed replays
+// the same games. By default the seed is taken from the clock. It must be called
+// before Start.
+func (m *SessionManager) SetSeed(seed int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.seed = seed
+}
+
+// Seed returns the session's seed, which reproduces the session when passed to SetSeed.
+func (m *SessionManager) Seed() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.seed
+}
+
+// GameSeed derives the seed of the bot playing color in game number game (1-based)
+// from the session seed, so every bot gets its own stream of random choices.
+func GameSeed(seed int64, game int, color engine.Color) int64 {
+	// splitmix64 finalizer, so nearby seeds and games don't give related streams
+	x := uint64(seed) + uint64(2*game+int(color))*0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return int64(x ^ (x >> 31))
 }
 
 // Pause pauses all running sessions.
@@ -229,22 +487,22 @@ func (m *SessionManager) Abort() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.state = StateFinished
-	m.closeAbortChannel()
+	m.abortPool()
 	m.abortSessions()
 }
 
 // Stop stops the session manager and cleans up all sessions and their resources.
 // This is the preferred method for graceful shutdown as it ensures all engines
-// are properly closed and resources are freed. It signals all goroutines to exit
-// via the abort channel and then cleans up each session.
+// are properly closed and resources are freed. It aborts the worker pool so no
+// more games start and then cleans up each session.
 func (m *SessionManager) Stop() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	m.state = StateFinished
 
-	// Signal all waiting goroutines to stop
-	m.closeAbortChannel()
+	// Signal the coordinator and workers to stop
+	m.abortPool()
 
 	// Abort all running sessions
 	m.abortSessions()
@@ -256,16 +514,11 @@ func (m *SessionManager) Stop() {
 	m.sessions = nil
 }
 
-// closeAbortChannel safely closes the abort channel if not already closed.
-// Must be called with m.mu held.
-func (m *SessionManager) closeAbortChannel() {
-	if m.abortCh != nil {
-		select {
-		case <-m.abortCh:
-			// Already closed
-		default:
-			close(m.abortCh)
-		}
+// abortPool stops the coordinator from queueing more games and cancels the
+// workers' contexts. Must be called with m.mu held.
+func (m *SessionManager) abortPool() {
+	if m.pool != nil {
+		m.pool.abort()
 	}
 }
 
@@ -372,7 +625,13 @@ func (m *SessionManager) Stats() *AggregateStats {
 		}
 	}
 
-	return ComputeStats(results, m.whiteName, m.blackName)
+	return ComputeStats(results, m.white.DisplayName(), m.black.DisplayName())
+}
+
+// Log returns the log of the session's errors, warnings and adjudications. It
+// outlives Stop, so the log can still be read after the session is over.
+func (m *SessionManager) Log() *SessionLog {
+	return m.log
 }
 
 // GetSession returns the session at the given index (0-indexed).
@@ -1,10 +1,15 @@
 package bvb
 
 import (
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/Mgrdich/TermChess/internal/bot"
+	"github.com/Mgrdich/TermChess/internal/engine"
 )
 
 func TestNewSessionManager(t *testing.T) {
@@ -435,3 +440,202 @@ func TestSessionManagerGameCount(t *testing.T) {
 		})
 	}
 }
+
+func TestSessionManagerUseBots(t *testing.T) {
+	var created int32
+	factory := func() (bot.Engine, error) {
+		atomic.AddInt32(&created, 1)
+		return bot.NewRandomEngine()
+	}
+	m := NewSessionManager(bot.Hard, bot.Easy, "Custom", "Easy", 2, 1)
+	m.UseBots(factory, nil)
+	m.SetSpeed(SpeedInstant)
+	if err := m.Start(); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	defer m.Stop()
+	m.Wait()
+
+	if created != 2 {
+		t.Errorf("Expected the factory to create the white bot of both games, got %d", created)
+	}
+
+	// A bot that cannot be created loses its game
+	failing := NewSessionManager(bot.Easy, bot.Easy, "White", "Broken", 1, 1)
+	failing.UseBots(nil, func() (bot.Engine, error) { return nil, errors.New("no such bot") })
+	if err := failing.Start(); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	failing.Wait()
+	result := failing.Sessions()[0].Result()
+	if result == nil || result.Winner != "White" || !strings.Contains(result.EndReason, "no such bot") {
+		t.Errorf("Expected Broken to lose with its error, got %+v", result)
+	}
+}
+
+func TestSessionManagerCreatesEnginesPerGame(t *testing.T) {
+	counter := &engineCounter{}
+	factory := func() (bot.Engine, error) {
+		e, err := bot.NewRandomEngine()
+		if err != nil {
+			return nil, err
+		}
+		counter.add(1)
+		return &countedEngine{Engine: e, counter: counter}, nil
+	}
+	m := NewSessionManager(bot.Easy, bot.Easy, "White", "Black", 20, 2)
+	m.UseBots(factory, factory)
+	m.SetSpeed(SpeedInstant)
+	if err := m.Start(); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	defer m.Stop()
+	m.Wait()
+
+	// Two workers, each holding the two engines of the game it plays
+	live, peak := counter.counts()
+	if peak > 4 {
+		t.Errorf("Expected at most 4 engines at once, got %d", peak)
+	}
+	if live != 0 {
+		t.Errorf("Expected every engine to be closed after its game, %d still open", live)
+	}
+}
+
+// engineCounter counts the engines that are open, and the most that were open at once.
+type engineCounter struct {
+	mu         sync.Mutex
+	live, peak int
+}
+
+func (c *engineCounter) add(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.live += n
+	if c.live > c.peak {
+		c.peak = c.live
+	}
+}
+
+func (c *engineCounter) counts() (live, peak int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.live, c.peak
+}
+
+// countedEngine leaves its counter when it is first closed.
+type countedEngine struct {
+	bot.Engine
+	counter *engineCounter
+	closed  bool
+}
+
+func (e *countedEngine) Close() error {
+	if !e.closed {
+		e.closed = true
+		e.counter.add(-1)
+	}
+	return e.Engine.Close()
+}
+
+func TestSessionManagerSetStartPosition(t *testing.T) {
+	// King and queen against king: White to move and mate
+	start, err := engine.FromFEN("7k/8/6K1/8/8/8/8/Q7 w - - 0 1")
+	if err != nil {
+		t.Fatalf("FromFEN() error: %v", err)
+	}
+	m := NewSessionManager(bot.Easy, bot.Easy, "White", "Black", 2, 1)
+	m.SetStartPosition(start)
+	if err := m.Start(); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	defer m.Abort()
+
+	// Only the three pieces of the start position can be on the board, however
+	// far the games have got
+	for i, s := range m.Sessions() {
+		board := s.CurrentBoard()
+		pieces := 0
+		for sq := engine.Square(0); sq < 64; sq++ {
+			if !board.PieceAt(sq).IsEmpty() {
+				pieces++
+			}
+		}
+		if pieces > 3 {
+			t.Errorf("session[%d] has %d pieces, want the start position's 3 at most", i, pieces)
+		}
+	}
+}
+
+func TestSessionManagerPairedOpenings(t *testing.T) {
+	var openings []*engine.Board
+	for _, fen := range []string{
+		"rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1",
+		"rnbqkbnr/pppppppp/8/8/3P4/8/PPP1PPPP/RNBQKBNR b KQkq d3 0 1",
+	} {
+		board, err := engine.FromFEN(fen)
+		if err != nil {
+			t.Fatalf("FromFEN() error: %v", err)
+		}
+		openings = append(openings, board)
+	}
+
+	m := NewSessionManager(bot.Easy, bot.Easy, "Alpha", "Beta", 5, 1)
+	m.SetOpenings(openings, true)
+	if err := m.Start(); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	defer m.Abort()
+
+	// Each opening twice, the bots swapping colors, then the first again
+	want := []struct {
+		opening  int
+		reversed bool
+	}{{0, false}, {0, true}, {1, false}, {1, true}, {0, false}}
+	for i, s := range m.Sessions() {
+		white, black := s.Players()
+		if want[i].reversed != (white == "Beta") || want[i].reversed != (black == "Alpha") {
+			t.Errorf("session[%d] is %s vs %s, want reversed = %v", i, white, black, want[i].reversed)
+		}
+		if s.reversed != want[i].reversed || s.startFEN != openings[want[i].opening].ToFEN() {
+			t.Errorf("session[%d] starts from %q (reversed %v), want opening %d", i, s.startFEN, s.reversed, want[i].opening)
+		}
+	}
+}
+
+func TestSessionManagerSetMoveTime(t *testing.T) {
+	m := NewSessionManager(bot.Hard, bot.Easy, "Hard", "Custom", 1, 1)
+	m.UseBots(nil, func() (bot.Engine, error) { return bot.NewRandomEngine() })
+	m.SetMoveTime(100 * time.Millisecond)
+	m.SetSpeed(SpeedInstant)
+	if err := m.Start(); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	defer m.Stop()
+
+	session := m.GetSession(0)
+	if want := 100*time.Millisecond + bot.MoveTimeGrace; session.whiteTimeout != want {
+		t.Errorf("whiteTimeout = %v, want the override plus grace (%v)", session.whiteTimeout, want)
+	}
+	if session.blackTimeout != 0 {
+		t.Errorf("blackTimeout = %v, want 0 for a custom bot", session.blackTimeout)
+	}
+
+	// The built-in bot keeps to the override
+	deadline := time.Now().Add(5 * time.Second)
+	for len(session.CurrentMoveTimes()) < 3 && !session.IsFinished() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	times := session.CurrentMoveTimes()
+	if len(times) == 0 {
+		t.Fatal("Expected the game to have moves")
+	}
+	if times[0] > 100*time.Millisecond+bot.MoveTimeGrace {
+		t.Errorf("Hard bot took %v for its first move, want at most the override plus grace", times[0])
+	}
+
+	defaults := NewSessionManager(bot.Hard, bot.Easy, "Hard", "Easy", 1, 1)
+	if got, want := defaults.moveTimeout(nil, bot.Config{Difficulty: bot.Easy}), bot.MoveTime(bot.Easy, 0)+bot.MoveTimeGrace; got != want {
+		t.Errorf("moveTimeout(Easy) = %v, want the difficulty budget plus grace (%v)", got, want)
+	}
+}
@@ -0,0 +1,22 @@
+package bvb
+
+import (
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// Observer is notified of every move and game ending of a session as it happens,
+// e.g. to stream the games to disk. Its methods are called from the goroutines
+// playing the games, possibly concurrently, so it must be safe for concurrent use.
+// Slow observers slow the games down.
+type Observer interface {
+	// GameStarted is called before the first move of game number game, played
+	// by white and black from board. board belongs to the observer.
+	GameStarted(game int, white, black string, board *engine.Board)
+	// MovePlayed is called after move was played in game number game. board is
+	// the position after the move and belongs to the observer.
+	MovePlayed(game int, move engine.Move, board *engine.Board, thinkTime time.Duration)
+	// GameFinished is called once a game ends. result is nil if the game was aborted.
+	GameFinished(game int, result *GameResult)
+}
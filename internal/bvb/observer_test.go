@@ -0,0 +1,74 @@
+package bvb
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/bot"
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// recordingObserver counts the moves and endings it is told about.
+type recordingObserver struct {
+	mu       sync.Mutex
+	started  map[int]string // "white-black" of each game
+	moves    map[int]int
+	finished map[int]*GameResult
+	closed   bool
+}
+
+func (o *recordingObserver) GameStarted(game int, white, black string, board *engine.Board) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.started != nil {
+		o.started[game] = white + "-" + black
+	}
+}
+
+func (o *recordingObserver) MovePlayed(game int, move engine.Move, board *engine.Board, thinkTime time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.moves[game]++
+}
+
+func (o *recordingObserver) GameFinished(game int, result *GameResult) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.finished[game] = result
+}
+
+func (o *recordingObserver) Close() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.closed = true
+	return nil
+}
+
+func TestSessionManagerObserver(t *testing.T) {
+	o := &recordingObserver{moves: make(map[int]int), finished: make(map[int]*GameResult)}
+	m := NewSessionManager(bot.Easy, bot.Easy, "White", "Black", 3, 2)
+	m.SetObserver(o)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+	if err := m.RunHeadless(ctx, nil); err != nil {
+		t.Fatalf("RunHeadless() error: %v", err)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for game := 1; game <= 3; game++ {
+		result := o.finished[game]
+		if result == nil {
+			t.Fatalf("game %d: no ending reported", game)
+		}
+		if o.moves[game] != result.MoveCount {
+			t.Errorf("game %d: %d moves reported, want %d", game, o.moves[game], result.MoveCount)
+		}
+	}
+	if !o.closed {
+		t.Error("Expected the observer to be closed after the session")
+	}
+}
@@ -0,0 +1,54 @@
+package bvb
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// LoadOpenings reads an opening suite from path, see ParseOpenings.
+func LoadOpenings(path string) ([]*engine.Board, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseOpenings(f)
+}
+
+// ParseOpenings reads an opening suite: one position per line, in FEN or EPD.
+// EPD operations after the position (e.g. `bm e4; id "x";`) are ignored, as are
+// blank lines and lines starting with '#'. Positions where the game is already
+// over are rejected, since no game could be played from them.
+func ParseOpenings(r io.Reader) ([]*engine.Board, error) {
+	var openings []*engine.Board
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		record, err := engine.ParseEPD(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		board := record.Board
+		if board.IsGameOver() {
+			return nil, fmt.Errorf("line %d: the game is already over: %s", line, board.Status())
+		}
+		openings = append(openings, board)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(openings) == 0 {
+		return nil, fmt.Errorf("no positions found")
+	}
+	return openings, nil
+}
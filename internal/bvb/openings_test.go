@@ -0,0 +1,50 @@
+package bvb
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseOpenings(t *testing.T) {
+	suite := `# Two openings
+rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1
+
+rnbqkbnr/pppppppp/8/8/3P4/8/PPP1PPPP/RNBQKBNR b KQkq d3 bm d5; id "QP";
+`
+	openings, err := ParseOpenings(strings.NewReader(suite))
+	if err != nil {
+		t.Fatalf("ParseOpenings() error: %v", err)
+	}
+	want := []string{
+		"rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1",
+		"rnbqkbnr/pppppppp/8/8/3P4/8/PPP1PPPP/RNBQKBNR b KQkq d3 0 1",
+	}
+	if len(openings) != len(want) {
+		t.Fatalf("Expected %d openings, got %d", len(want), len(openings))
+	}
+	for i, board := range openings {
+		if got := board.ToFEN(); got != want[i] {
+			t.Errorf("opening %d = %q, want %q", i, got, want[i])
+		}
+	}
+}
+
+func TestParseOpeningsErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		suite string
+		want  string
+	}{
+		{"empty", "# nothing here\n", "no positions"},
+		{"invalid", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1\nnot a position\n", "line 2"},
+		{"game over", "7k/6Q1/6K1/8/8/8/8/8 b - - 0 1\n", "already over"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseOpenings(strings.NewReader(tt.suite))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseOpenings() error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
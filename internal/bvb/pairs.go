@@ -0,0 +1,74 @@
+package bvb
+
+import "github.com/Mgrdich/TermChess/internal/engine"
+
+// PairStats summarizes a session played in game pairs, see SessionManager.SetOpenings:
+// each opening is played once with each bot as White, so the pair results are
+// free of the first-move advantage that the results of single games carry.
+type PairStats struct {
+	// Complete is the number of pairs with both games finished. Only they are counted.
+	Complete int `json:"complete"`
+	// WhiteWins is the number of pairs the session's white bot scored more points in.
+	WhiteWins int `json:"white_wins"`
+	// BlackWins is the number of pairs the session's black bot scored more points in.
+	BlackWins int `json:"black_wins"`
+	// Draws is the number of pairs the bots split evenly.
+	Draws int `json:"draws"`
+	// WinsAsWhite and WinsAsBlack count the games won by the bot playing White
+	// and the bot playing Black, whichever bot that was, showing the first-move advantage.
+	WinsAsWhite int `json:"wins_as_white"`
+	WinsAsBlack int `json:"wins_as_black"`
+}
+
+// ComputePairStats pairs up the results of a session whose bots swap colors:
+// games 1 and 2 form the first pair, games 3 and 4 the second, and so on.
+// It returns nil if no game was played with the colors reversed.
+func ComputePairStats(results []GameResult, whiteName string) *PairStats {
+	paired := false
+	for _, r := range results {
+		if r.Reversed {
+			paired = true
+			break
+		}
+	}
+	if !paired {
+		return nil
+	}
+
+	stats := &PairStats{}
+	// Half points the white bot scored in each pair, and the games counted
+	halfPoints := make(map[int]int)
+	games := make(map[int]int)
+	for _, r := range results {
+		pair := (r.GameNumber + 1) / 2
+		games[pair]++
+		switch {
+		case r.Winner == "Draw":
+			halfPoints[pair]++
+		case r.Winner == whiteName:
+			halfPoints[pair] += 2
+		}
+		if r.Winner != "Draw" {
+			if r.WinnerColor == engine.White {
+				stats.WinsAsWhite++
+			} else {
+				stats.WinsAsBlack++
+			}
+		}
+	}
+	for pair, n := range games {
+		if n != 2 {
+			continue
+		}
+		stats.Complete++
+		switch {
+		case halfPoints[pair] > 2:
+			stats.WhiteWins++
+		case halfPoints[pair] < 2:
+			stats.BlackWins++
+		default:
+			stats.Draws++
+		}
+	}
+	return stats
+}
@@ -0,0 +1,33 @@
+package bvb
+
+import (
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+func TestComputePairStats(t *testing.T) {
+	// Without reversed games there are no pairs
+	if p := ComputePairStats([]GameResult{{GameNumber: 1, Winner: "A"}}, "A"); p != nil {
+		t.Errorf("Expected no pair stats without reversed games, got %+v", p)
+	}
+
+	results := []GameResult{
+		// Pair 1: A wins both
+		{GameNumber: 1, Winner: "A", WinnerColor: engine.White},
+		{GameNumber: 2, Winner: "A", WinnerColor: engine.Black, Reversed: true},
+		// Pair 2: each wins with White
+		{GameNumber: 3, Winner: "A", WinnerColor: engine.White},
+		{GameNumber: 4, Winner: "B", WinnerColor: engine.White, Reversed: true},
+		// Pair 3: a draw and a B win
+		{GameNumber: 5, Winner: "Draw"},
+		{GameNumber: 6, Winner: "B", WinnerColor: engine.White, Reversed: true},
+		// Pair 4 is unfinished
+		{GameNumber: 7, Winner: "A", WinnerColor: engine.White},
+	}
+	got := ComputePairStats(results, "A")
+	want := PairStats{Complete: 3, WhiteWins: 1, BlackWins: 1, Draws: 1, WinsAsWhite: 5, WinsAsBlack: 1}
+	if got == nil || *got != want {
+		t.Errorf("ComputePairStats() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,78 @@
+package bvb
+
+import (
+	"context"
+	"sync"
+)
+
+// workerPool runs jobs on a fixed number of worker goroutines fed from a bounded
+// queue. Submitting blocks while the queue is full, so the producer never gets more
+// than a queue's worth ahead of the workers.
+type workerPool struct {
+	jobs   chan int
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// newWorkerPool starts workers goroutines that call run for every submitted job,
+// in submission order. Each worker gets its own context, derived from the pool's,
+// which is cancelled when the pool is aborted. queueSize bounds how many jobs can
+// wait for a worker.
+func newWorkerPool(workers, queueSize int, run func(ctx context.Context, job int)) *workerPool {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &workerPool{
+		jobs:   make(chan int, queueSize),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		workerCtx, workerCancel := context.WithCancel(ctx)
+		go func() {
+			defer p.wg.Done()
+			defer workerCancel()
+			for job := range p.jobs {
+				// After an abort the queue is drained without running anything
+				if workerCtx.Err() != nil {
+					continue
+				}
+				run(workerCtx, job)
+			}
+		}()
+	}
+	return p
+}
+
+// submit queues job, blocking while the queue is full. It returns false without
+// queueing the job if the pool has been aborted.
+func (p *workerPool) submit(job int) bool {
+	if p.ctx.Err() != nil {
+		return false
+	}
+	select {
+	case p.jobs <- job:
+		return true
+	case <-p.ctx.Done():
+		return false
+	}
+}
+
+// close tells the workers that no more jobs will be submitted. They exit once the
+// queue is empty. It must be called exactly once, after the last submit.
+func (p *workerPool) close() {
+	close(p.jobs)
+}
+
+// abort cancels the workers' contexts: running jobs are asked to stop and queued
+// jobs are dropped. Use wait to block until the workers are done.
+func (p *workerPool) abort() {
+	p.cancel()
+}
+
+// wait blocks until every worker has exited, which happens after close once the
+// queue has drained.
+func (p *workerPool) wait() {
+	p.wg.Wait()
+}
@@ -0,0 +1,129 @@
+package bvb
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/bot"
+)
+
+func TestWorkerPoolRunsJobsInOrder(t *testing.T) {
+	var mu sync.Mutex
+	var order []int
+	p := newWorkerPool(1, 1, func(ctx context.Context, job int) {
+		mu.Lock()
+		order = append(order, job)
+		mu.Unlock()
+	})
+	for i := 0; i < 5; i++ {
+		if !p.submit(i) {
+			t.Fatalf("submit(%d) = false before abort", i)
+		}
+	}
+	p.close()
+	p.wait()
+
+	for i, job := range order {
+		if job != i {
+			t.Fatalf("Expected jobs in submission order, got %v", order)
+		}
+	}
+	if len(order) != 5 {
+		t.Errorf("Expected 5 jobs to run, got %d", len(order))
+	}
+}
+
+func TestWorkerPoolBackpressure(t *testing.T) {
+	release := make(chan struct{})
+	var running int32
+	p := newWorkerPool(2, 1, func(ctx context.Context, job int) {
+		atomic.AddInt32(&running, 1)
+		<-release
+	})
+
+	// Two jobs run and one waits in the queue; the fourth submit must block
+	submitted := make(chan int, 4)
+	go func() {
+		for i := 0; i < 4; i++ {
+			p.submit(i)
+			submitted <- i
+		}
+		p.close()
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	if n := len(submitted); n != 3 {
+		t.Errorf("Expected 3 submits to go through while workers are busy, got %d", n)
+	}
+	if n := atomic.LoadInt32(&running); n != 2 {
+		t.Errorf("Expected 2 jobs running, got %d", n)
+	}
+
+	close(release)
+	p.wait()
+	if n := atomic.LoadInt32(&running); n != 4 {
+		t.Errorf("Expected all 4 jobs to run after release, got %d", n)
+	}
+}
+
+func TestWorkerPoolAbortDrains(t *testing.T) {
+	var ran int32
+	p := newWorkerPool(1, 3, func(ctx context.Context, job int) {
+		atomic.AddInt32(&ran, 1)
+		<-ctx.Done()
+	})
+	for i := 0; i < 4; i++ {
+		p.submit(i)
+	}
+
+	p.abort()
+	if p.submit(4) {
+		t.Error("Expected submit to fail after abort")
+	}
+	p.close()
+
+	done := make(chan struct{})
+	go func() {
+		p.wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Workers did not exit after abort")
+	}
+	if n := atomic.LoadInt32(&ran); n != 1 {
+		t.Errorf("Expected queued jobs to be dropped, but %d ran", n)
+	}
+}
+
+func TestSessionManagerCPULimit(t *testing.T) {
+	previous := runtime.GOMAXPROCS(0)
+	m := NewSessionManager(bot.Easy, bot.Easy, "White", "Black", 6, 4)
+	m.SetCPULimit(2)
+	m.SetSpeed(SpeedInstant)
+	if got := m.Concurrency(); got != 2 {
+		t.Errorf("Concurrency() = %d, want the CPU limit of 2", got)
+	}
+	if err := m.Start(); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	peak := int32(0)
+	for !m.AllFinished() {
+		if n := atomic.LoadInt32(&m.activeCount); n > peak {
+			peak = n
+		}
+		if got := runtime.GOMAXPROCS(0); got != previous {
+			t.Fatalf("GOMAXPROCS while running = %d, want it left at %d", got, previous)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	m.Wait()
+	if peak > 2 {
+		t.Errorf("Expected at most 2 games at once, got %d", peak)
+	}
+}
@@ -14,6 +14,10 @@ import (
 // maxMoveCount is the maximum number of moves before a forced draw.
 const maxMoveCount = 500
 
+// defaultMoveTimeout caps the time a bot without a known move-time budget, such
+// as a custom or external bot, may take for a move.
+const defaultMoveTimeout = 30 * time.Second
+
 // GameSession manages a single Bot vs Bot chess game.
 // It runs the game loop in a goroutine and provides thread-safe
 // access to the current board state and move history.
@@ -26,6 +30,7 @@ type GameSession struct {
 	whiteName   string
 	blackName   string
 	moveHistory []engine.Move
+	moveTimes   []time.Duration
 	state       SessionState
 	paused      bool
 	result      *GameResult
@@ -34,6 +39,30 @@ type GameSession struct {
 	stopCh      chan struct{}
 	pauseCh     chan struct{}
 	resumeCh    chan struct{}
+	observer    Observer // notified of moves and the game's end, may be nil
+	flags       GameFlags
+	material    []int // material difference after each move, see MaterialHistory
+	imbalanced  bool  // whether the last position had a flagged material imbalance
+	repetitions int   // moves that repeated a position for the second time
+
+	// whiteTimeout and blackTimeout are the deadlines for each side's moves,
+	// 0 for defaultMoveTimeout.
+	whiteTimeout time.Duration
+	blackTimeout time.Duration
+
+	// thinkingSince is when the bot to move started thinking, zero between moves.
+	thinkingSince time.Time
+
+	// revision counts the moves and the end of the game, see Revision.
+	revision uint64
+
+	// startFEN is the position the game starts from, empty for the standard one,
+	// and reversed is set when the session's bots swapped colors for this game.
+	startFEN string
+	reversed bool
+
+	// log records the game's errors, warnings and adjudications, may be nil.
+	log *SessionLog
 }
 
 // NewGameSession creates a new game session ready to be run.
@@ -48,6 +77,7 @@ func NewGameSession(gameNumber int, whiteEngine bot.Engine, blackEngine bot.Engi
 		whiteName:   whiteName,
 		blackName:   blackName,
 		moveHistory: make([]engine.Move, 0, 80),
+		moveTimes:   make([]time.Duration, 0, 80),
 		state:       StateRunning,
 		speed:       speed,
 		stopCh:      make(chan struct{}),
@@ -62,17 +92,21 @@ func NewGameSession(gameNumber int, whiteEngine bot.Engine, blackEngine bot.Engi
 func (s *GameSession) Run() {
 	s.mu.Lock()
 	s.startTime = time.Now()
+	observer, white, black, start := s.observer, s.whiteName, s.blackName, s.board.Copy()
 	s.mu.Unlock()
 
 	defer s.cleanup() // Ensure cleanup runs even on panic
+	defer s.notifyFinished()
+
+	if observer != nil {
+		observer.GameStarted(s.gameNumber, white, black, start)
+	}
 
 	for {
 		// Check for abort signal.
 		select {
 		case <-s.stopCh:
-			s.mu.Lock()
-			s.state = StateFinished
-			s.mu.Unlock()
+			s.finishAborted()
 			return
 		default:
 		}
@@ -85,15 +119,11 @@ func (s *GameSession) Run() {
 			case <-s.resumeCh:
 				// Continue.
 			case <-s.stopCh:
-				s.mu.Lock()
-				s.state = StateFinished
-				s.mu.Unlock()
+				s.finishAborted()
 				return
 			}
 		case <-s.stopCh:
-			s.mu.Lock()
-			s.state = StateFinished
-			s.mu.Unlock()
+			s.finishAborted()
 			return
 		default:
 			// Not paused, continue.
@@ -104,20 +134,45 @@ func (s *GameSession) Run() {
 		activeColor := s.board.ActiveColor
 		var currentEngine bot.Engine
 		var currentName string
+		var timeout time.Duration
 		if activeColor == engine.White {
 			currentEngine = s.whiteEngine
 			currentName = s.whiteName
+			timeout = s.whiteTimeout
 		} else {
 			currentEngine = s.blackEngine
 			currentName = s.blackName
+			timeout = s.blackTimeout
+		}
+		if timeout <= 0 {
+			timeout = defaultMoveTimeout
 		}
 		boardCopy := s.board.Copy()
+		thinkStart := time.Now()
+		s.thinkingSince = thinkStart
 		s.mu.Unlock()
 
-		// Ask the engine to select a move with a timeout to prevent infinite computation.
-		moveCtx, moveCancel := context.WithTimeout(context.Background(), 30*time.Second)
+		// Ask the engine to select a move within its deadline to keep the pacing
+		// predictable. An abort cuts the search short so the session stops promptly.
+		moveCtx, moveCancel := context.WithTimeout(context.Background(), timeout)
+		go func() {
+			select {
+			case <-s.stopCh:
+				moveCancel()
+			case <-moveCtx.Done():
+			}
+		}()
 		move, err := currentEngine.SelectMove(moveCtx, boardCopy)
+		thinkTime := time.Since(thinkStart)
 		moveCancel()
+
+		// A move cut short by an abort doesn't count.
+		select {
+		case <-s.stopCh:
+			s.finishAborted()
+			return
+		default:
+		}
 		if err != nil {
 			s.finishWithError(currentName, activeColor, err)
 			return
@@ -127,11 +182,24 @@ func (s *GameSession) Run() {
 		s.mu.Lock()
 		if err := s.board.MakeMove(move); err != nil {
 			s.mu.Unlock()
-			s.finishWithError(currentName, activeColor, err)
+			s.finishWithError(currentName, activeColor, fmt.Errorf("illegal move %s: %w", move, err))
 			return
 		}
+		s.thinkingSince = time.Time{}
 		s.moveHistory = append(s.moveHistory, move)
+		s.moveTimes = append(s.moveTimes, thinkTime)
+		s.revision++
 		moveCount := len(s.moveHistory)
+		s.material = append(s.material, materialDifference(s.board))
+		s.flagMove(move)
+
+		// Notify the observer without holding the lock, so a slow one doesn't block readers.
+		if s.observer != nil {
+			after := s.board.Copy()
+			s.mu.Unlock()
+			s.observer.MovePlayed(s.gameNumber, move, after, thinkTime)
+			s.mu.Lock()
+		}
 
 		// Check for game over conditions.
 		status := s.board.Status()
@@ -144,16 +212,23 @@ func (s *GameSession) Run() {
 		// Check for forced draw due to excessive moves.
 		if moveCount >= maxMoveCount {
 			s.result = &GameResult{
-				GameNumber:  s.gameNumber,
-				Winner:      "Draw",
-				EndReason:   "move limit exceeded",
-				MoveCount:   moveCount,
-				Duration:    time.Since(s.startTime),
-				FinalFEN:    s.board.ToFEN(),
-				MoveHistory: s.copyMoveHistory(),
+				GameNumber:         s.gameNumber,
+				Winner:             "Draw",
+				EndReason:          "move limit exceeded",
+				MoveCount:          moveCount,
+				Duration:           time.Since(s.startTime),
+				FinalFEN:           s.board.ToFEN(),
+				MoveHistory:        s.copyMoveHistory(),
+				MoveTimes:          s.copyMoveTimes(),
+				Flags:              s.flags,
+				StartFEN:           s.startFEN,
+				Reversed:           s.reversed,
+				RepetitionWarnings: s.repetitions,
 			}
 			s.state = StateFinished
+			s.revision++
 			s.mu.Unlock()
+			s.logf(LogInfo, "adjudicated a draw at the %d-move limit", maxMoveCount)
 			return
 		}
 		s.mu.Unlock()
@@ -167,15 +242,41 @@ func (s *GameSession) Run() {
 			select {
 			case <-time.After(delay):
 			case <-s.stopCh:
-				s.mu.Lock()
-				s.state = StateFinished
-				s.mu.Unlock()
+				s.finishAborted()
 				return
 			}
 		}
 	}
 }
 
+// setEngines gives the session the engines playing white and black, created
+// just before the game is run.
+func (s *GameSession) setEngines(white, black bot.Engine) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.whiteEngine = white
+	s.blackEngine = black
+}
+
+// failToStart ends the game before its first move because the engine for color
+// could not be created; that side loses as if its engine had failed to move.
+func (s *GameSession) failToStart(color engine.Color, err error) {
+	s.mu.Lock()
+	s.startTime = time.Now()
+	observer, white, black, start := s.observer, s.whiteName, s.blackName, s.board.Copy()
+	name := white
+	if color == engine.Black {
+		name = black
+	}
+	s.mu.Unlock()
+
+	if observer != nil {
+		observer.GameStarted(s.gameNumber, white, black, start)
+	}
+	s.finishWithError(name, color, err)
+	s.notifyFinished()
+}
+
 // Pause signals the game session to pause. It is safe to call multiple times.
 // If the session is already paused or finished, this is a no-op.
 func (s *GameSession) Pause() {
@@ -223,7 +324,16 @@ func (s *GameSession) SetSpeed(speed PlaybackSpeed) {
 }
 
 // Abort signals the game session to stop immediately. It is safe to call multiple times.
+// A session that has not started yet is finished right away, without a result.
 func (s *GameSession) Abort() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.startTime.IsZero() {
+		s.state = StateFinished
+		s.revision++
+	}
+
+	// Closing under the lock keeps concurrent aborts from closing the channel twice
 	select {
 	case <-s.stopCh:
 		// Already closed.
@@ -246,6 +356,51 @@ func (s *GameSession) CurrentMoveHistory() []engine.Move {
 	return s.copyMoveHistory()
 }
 
+// CurrentMoveTimes returns a copy of the time spent on each move so far.
+func (s *GameSession) CurrentMoveTimes() []time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.copyMoveTimes()
+}
+
+// Revision returns a number that changes whenever the game does: with each
+// move and when it finishes. A view can redraw a game only when it changed.
+func (s *GameSession) Revision() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.revision
+}
+
+// Thinking reports which bot is choosing a move, how long it has been thinking
+// and its deadline for the move. ok is false between moves and once the game
+// is over.
+func (s *GameSession) Thinking() (color engine.Color, elapsed, limit time.Duration, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.thinkingSince.IsZero() || s.state == StateFinished {
+		return 0, 0, 0, false
+	}
+	color = s.board.ActiveColor
+	limit = s.whiteTimeout
+	if color == engine.Black {
+		limit = s.blackTimeout
+	}
+	if limit <= 0 {
+		limit = defaultMoveTimeout
+	}
+	return color, time.Since(s.thinkingSince), limit, true
+}
+
+// MaterialHistory returns White's material minus Black's, in pawns, after
+// each move so far.
+func (s *GameSession) MaterialHistory() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	material := make([]int, len(s.material))
+	copy(material, s.material)
+	return material
+}
+
 // IsFinished returns true if the game session has completed.
 func (s *GameSession) IsFinished() bool {
 	s.mu.Lock()
@@ -260,6 +415,11 @@ func (s *GameSession) Result() *GameResult {
 	return s.result
 }
 
+// Players returns the names of the bots playing White and Black in this game.
+func (s *GameSession) Players() (white, black string) {
+	return s.whiteName, s.blackName
+}
+
 // GameNumber returns the sequence number of this game.
 func (s *GameSession) GameNumber() int {
 	return s.gameNumber
@@ -306,7 +466,7 @@ func (s *GameSession) finishWithStatus(status engine.GameStatus, moveCount int)
 	winner := "Draw"
 	var winnerColor engine.Color
 
-	if status == engine.Checkmate {
+	if status == engine.Checkmate || status == engine.KingExploded {
 		// The active color is the one checkmated, so the opponent wins.
 		if s.board.ActiveColor == engine.White {
 			winner = s.blackName
@@ -318,20 +478,28 @@ func (s *GameSession) finishWithStatus(status engine.GameStatus, moveCount int)
 	}
 
 	s.result = &GameResult{
-		GameNumber:  s.gameNumber,
-		Winner:      winner,
-		WinnerColor: winnerColor,
-		EndReason:   status.String(),
-		MoveCount:   moveCount,
-		Duration:    time.Since(s.startTime),
-		FinalFEN:    s.board.ToFEN(),
-		MoveHistory: s.copyMoveHistory(),
+		GameNumber:         s.gameNumber,
+		Winner:             winner,
+		WinnerColor:        winnerColor,
+		EndReason:          status.String(),
+		MoveCount:          moveCount,
+		Duration:           time.Since(s.startTime),
+		FinalFEN:           s.board.ToFEN(),
+		MoveHistory:        s.copyMoveHistory(),
+		MoveTimes:          s.copyMoveTimes(),
+		Flags:              s.flags,
+		StartFEN:           s.startFEN,
+		Reversed:           s.reversed,
+		RepetitionWarnings: s.repetitions,
 	}
 	s.state = StateFinished
+	s.revision++
 }
 
 // finishWithError records the game result when an engine produces an error.
 func (s *GameSession) finishWithError(engineName string, engineColor engine.Color, err error) {
+	s.logf(LogError, "%s (%s) failed and loses: %v", engineName, colorName(engineColor), err)
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -347,16 +515,47 @@ func (s *GameSession) finishWithError(engineName string, engineColor engine.Colo
 	}
 
 	s.result = &GameResult{
-		GameNumber:  s.gameNumber,
-		Winner:      winner,
-		WinnerColor: winnerColor,
-		EndReason:   fmt.Sprintf("engine error: %v", err),
-		MoveCount:   len(s.moveHistory),
-		Duration:    time.Since(s.startTime),
-		FinalFEN:    s.board.ToFEN(),
-		MoveHistory: s.copyMoveHistory(),
+		GameNumber:         s.gameNumber,
+		Winner:             winner,
+		WinnerColor:        winnerColor,
+		EndReason:          fmt.Sprintf("engine error: %v", err),
+		MoveCount:          len(s.moveHistory),
+		Duration:           time.Since(s.startTime),
+		FinalFEN:           s.board.ToFEN(),
+		MoveHistory:        s.copyMoveHistory(),
+		MoveTimes:          s.copyMoveTimes(),
+		Flags:              s.flags,
+		StartFEN:           s.startFEN,
+		Reversed:           s.reversed,
+		RepetitionWarnings: s.repetitions,
 	}
 	s.state = StateFinished
+	s.revision++
+}
+
+// finishAborted marks a game stopped before it ended as finished, without a result.
+func (s *GameSession) finishAborted() {
+	s.mu.Lock()
+	s.state = StateFinished
+	s.revision++
+	moves := len(s.moveHistory)
+	s.mu.Unlock()
+	s.logf(LogWarning, "aborted after %d moves", moves)
+}
+
+// logf adds an entry about this game to the session log, if there is one.
+func (s *GameSession) logf(level LogLevel, format string, args ...any) {
+	if s.log != nil {
+		s.log.Addf(s.gameNumber, level, format, args...)
+	}
+}
+
+// colorName returns "White" or "Black".
+func colorName(c engine.Color) string {
+	if c == engine.White {
+		return "White"
+	}
+	return "Black"
 }
 
 // copyMoveHistory returns a copy of the move history slice.
@@ -367,6 +566,24 @@ func (s *GameSession) copyMoveHistory() []engine.Move {
 	return moves
 }
 
+// copyMoveTimes returns a copy of the move times slice.
+// Must be called with s.mu held.
+func (s *GameSession) copyMoveTimes() []time.Duration {
+	times := make([]time.Duration, len(s.moveTimes))
+	copy(times, s.moveTimes)
+	return times
+}
+
+// notifyFinished tells the observer, if any, how the game ended.
+func (s *GameSession) notifyFinished() {
+	s.mu.Lock()
+	observer, result := s.observer, s.result
+	s.mu.Unlock()
+	if observer != nil {
+		observer.GameFinished(s.gameNumber, result)
+	}
+}
+
 // cleanup releases resources held by the session.
 // It closes both engines (checking for io.Closer interface for additional cleanup)
 // and nils the engine references to allow garbage collection.
@@ -1,6 +1,7 @@
 package bvb
 
 import (
+	"context"
 	"sync"
 	"testing"
 	"time"
@@ -121,6 +122,13 @@ func TestGameSessionResultPopulated(t *testing.T) {
 		t.Errorf("MoveHistory length = %d, want %d", len(result.MoveHistory), result.MoveCount)
 	}
 
+	if len(result.MoveTimes) != result.MoveCount {
+		t.Errorf("MoveTimes length = %d, want %d", len(result.MoveTimes), result.MoveCount)
+	}
+	if got := session.CurrentMoveTimes(); len(got) != len(result.MoveTimes) {
+		t.Errorf("CurrentMoveTimes length = %d, want %d", len(got), len(result.MoveTimes))
+	}
+
 	// Verify winner is one of the expected values.
 	validWinners := map[string]bool{
 		"White Bot": true,
@@ -904,3 +912,93 @@ func TestGameSessionStartTime(t *testing.T) {
 	session.Abort()
 	<-done
 }
+
+func TestGameSessionMaterialHistory(t *testing.T) {
+	whiteEngine, err := bot.NewRandomEngine()
+	if err != nil {
+		t.Fatalf("failed to create white engine: %v", err)
+	}
+	blackEngine, err := bot.NewRandomEngine()
+	if err != nil {
+		t.Fatalf("failed to create black engine: %v", err)
+	}
+
+	speed := SpeedInstant
+	session := NewGameSession(1, whiteEngine, blackEngine, "White Bot", "Black Bot", &speed)
+	if got := session.MaterialHistory(); len(got) != 0 {
+		t.Fatalf("MaterialHistory() before the first move = %v, want empty", got)
+	}
+	session.Run()
+
+	history := session.MaterialHistory()
+	result := session.Result()
+	if result == nil {
+		t.Fatal("expected a result after the game finished")
+	}
+	if len(history) != result.MoveCount {
+		t.Fatalf("MaterialHistory() has %d entries, want one per move (%d)", len(history), result.MoveCount)
+	}
+	if got, want := history[len(history)-1], materialDifference(session.CurrentBoard()); got != want {
+		t.Errorf("last material balance = %d, want %d for the final position", got, want)
+	}
+}
+
+// blockingEngine is a bot that thinks until its move is cut off.
+type blockingEngine struct{}
+
+func (blockingEngine) SelectMove(ctx context.Context, board *engine.Board) (engine.Move, error) {
+	<-ctx.Done()
+	return engine.Move{}, ctx.Err()
+}
+func (blockingEngine) Name() string { return "Blocking" }
+func (blockingEngine) Close() error { return nil }
+
+func TestGameSessionThinking(t *testing.T) {
+	speed := SpeedInstant
+	session := NewGameSession(1, blockingEngine{}, blockingEngine{}, "White Bot", "Black Bot", &speed)
+	session.whiteTimeout = 5 * time.Second
+	if _, _, _, ok := session.Thinking(); ok {
+		t.Error("Expected no bot to be thinking before the game starts")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		session.Run()
+		close(done)
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if color, elapsed, limit, ok := session.Thinking(); ok {
+			if color != engine.White || elapsed < 0 || limit != 5*time.Second {
+				t.Errorf("Thinking() = %v, %v, %v; want White with a 5s deadline", color, elapsed, limit)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("White never started thinking")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	session.Abort()
+	<-done
+	if _, _, _, ok := session.Thinking(); ok {
+		t.Error("Expected no bot to be thinking once the game is over")
+	}
+}
+
+func TestGameSessionRevision(t *testing.T) {
+	whiteEngine, _ := bot.NewRandomEngine(bot.WithSeed(1))
+	blackEngine, _ := bot.NewRandomEngine(bot.WithSeed(2))
+	speed := SpeedInstant
+	session := NewGameSession(1, whiteEngine, blackEngine, "White Bot", "Black Bot", &speed)
+	if session.Revision() != 0 {
+		t.Fatalf("Expected revision 0 before the game starts, got %d", session.Revision())
+	}
+
+	session.Run()
+	// One change per move, and one for the end of the game
+	if got, want := session.Revision(), uint64(len(session.CurrentMoveHistory())+1); got != want {
+		t.Errorf("Revision() = %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,141 @@
+package bvb
+
+import (
+	"fmt"
+	"math"
+)
+
+// SPRTConfig holds the hypotheses and error rates of a sequential probability
+// ratio test. H0 is that the white bot is Elo0 stronger than the black bot,
+// H1 that it is Elo1 stronger.
+type SPRTConfig struct {
+	// Elo0 is the Elo difference of the null hypothesis.
+	Elo0 float64
+	// Elo1 is the Elo difference of the alternative hypothesis.
+	Elo1 float64
+	// Alpha is the probability of accepting H1 when H0 is true.
+	Alpha float64
+	// Beta is the probability of accepting H0 when H1 is true.
+	Beta float64
+}
+
+// DefaultSPRTConfig returns the test used when none is configured: is the white
+// bot at least 50 Elo stronger, with 5% error rates. The bounds are wide because
+// the built-in bots play slowly and differ a lot in strength.
+func DefaultSPRTConfig() SPRTConfig {
+	return SPRTConfig{Elo0: 0, Elo1: 50, Alpha: 0.05, Beta: 0.05}
+}
+
+// Validate reports whether the configuration describes a usable test.
+func (c SPRTConfig) Validate() error {
+	if c.Elo1 <= c.Elo0 {
+		return fmt.Errorf("elo1 (%g) must be greater than elo0 (%g)", c.Elo1, c.Elo0)
+	}
+	if c.Alpha <= 0 || c.Alpha >= 0.5 {
+		return fmt.Errorf("alpha must be between 0 and 0.5, got %g", c.Alpha)
+	}
+	if c.Beta <= 0 || c.Beta >= 0.5 {
+		return fmt.Errorf("beta must be between 0 and 0.5, got %g", c.Beta)
+	}
+	return nil
+}
+
+// Describe summarizes the test, e.g. "SPRT [0, 50] (alpha 0.05, beta 0.05)".
+func (c SPRTConfig) Describe() string {
+	return fmt.Sprintf("SPRT [%g, %g] (alpha %g, beta %g)", c.Elo0, c.Elo1, c.Alpha, c.Beta)
+}
+
+// Bounds returns the log-likelihood ratios at which H0 (lower) and H1 (upper) are accepted.
+func (c SPRTConfig) Bounds() (lower, upper float64) {
+	return math.Log(c.Beta / (1 - c.Alpha)), math.Log((1 - c.Beta) / c.Alpha)
+}
+
+// LLR returns the log-likelihood ratio of H1 over H0 for a record of wins, draws
+// and losses, using the normal approximation of the score distribution.
+// It returns 0 while the record has no variance (e.g. only draws).
+func (c SPRTConfig) LLR(wins, draws, losses int) float64 {
+	games := wins + draws + losses
+	if games == 0 {
+		return 0
+	}
+
+	n := float64(games)
+	score := (float64(wins) + float64(draws)/2) / n
+	variance := (float64(wins)+float64(draws)/4)/n - score*score
+	if variance <= 0 {
+		return 0
+	}
+
+	s0 := eloToScore(c.Elo0)
+	s1 := eloToScore(c.Elo1)
+	return (s1 - s0) * (2*score - s0 - s1) / (2 * variance / n)
+}
+
+// eloToScore converts an Elo difference to the expected score (0-1).
+func eloToScore(elo float64) float64 {
+	return 1 / (1 + math.Pow(10, -elo/400))
+}
+
+// SPRTDecision is the outcome of a sequential probability ratio test.
+type SPRTDecision int
+
+const (
+	// SPRTContinue means more games are needed.
+	SPRTContinue SPRTDecision = iota
+	// SPRTAcceptH0 means the test accepted the null hypothesis (Elo0).
+	SPRTAcceptH0
+	// SPRTAcceptH1 means the test accepted the alternative hypothesis (Elo1).
+	SPRTAcceptH1
+)
+
+// String returns a human-readable description of the decision.
+func (d SPRTDecision) String() string {
+	switch d {
+	case SPRTAcceptH0:
+		return "H0 accepted"
+	case SPRTAcceptH1:
+		return "H1 accepted"
+	default:
+		return "running"
+	}
+}
+
+// SPRTStatus is the state of a sequential probability ratio test after some games.
+type SPRTStatus struct {
+	Config SPRTConfig
+	// LLR is the current log-likelihood ratio.
+	LLR float64
+	// Lower and Upper are the bounds at which H0 and H1 are accepted.
+	Lower, Upper float64
+	// Wins, Draws and Losses are the white bot's record.
+	Wins, Draws, Losses int
+	// Decision is the outcome of the test so far.
+	Decision SPRTDecision
+}
+
+// Status evaluates the test for the white bot's record.
+func (c SPRTConfig) Status(wins, draws, losses int) SPRTStatus {
+	lower, upper := c.Bounds()
+	status := SPRTStatus{
+		Config: c,
+		LLR:    c.LLR(wins, draws, losses),
+		Lower:  lower,
+		Upper:  upper,
+		Wins:   wins,
+		Draws:  draws,
+		Losses: losses,
+	}
+	switch {
+	case status.LLR >= upper:
+		status.Decision = SPRTAcceptH1
+	case status.LLR <= lower:
+		status.Decision = SPRTAcceptH0
+	}
+	return status
+}
+
+// String formats the status, e.g. "LLR 1.23 [-2.94, 2.94] (0, 50) - running".
+func (s SPRTStatus) String() string {
+	return fmt.Sprintf("LLR %.2f [%.2f, %.2f] (%g, %g) - %s",
+		s.LLR, s.Lower, s.Upper, s.Config.Elo0, s.Config.Elo1, s.Decision)
+}
@@ -0,0 +1,115 @@
+package bvb
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+func TestSPRTConfigValidate(t *testing.T) {
+	if err := DefaultSPRTConfig().Validate(); err != nil {
+		t.Errorf("DefaultSPRTConfig().Validate() = %v", err)
+	}
+
+	invalid := []SPRTConfig{
+		{Elo0: 10, Elo1: 10, Alpha: 0.05, Beta: 0.05},
+		{Elo0: 0, Elo1: 50, Alpha: 0, Beta: 0.05},
+		{Elo0: 0, Elo1: 50, Alpha: 0.05, Beta: 0.6},
+	}
+	for _, cfg := range invalid {
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("Validate(%+v) = nil, want error", cfg)
+		}
+	}
+}
+
+func TestSPRTBounds(t *testing.T) {
+	lower, upper := DefaultSPRTConfig().Bounds()
+	if math.Abs(lower+2.944) > 0.001 || math.Abs(upper-2.944) > 0.001 {
+		t.Errorf("Bounds() = %.3f, %.3f, want -2.944, 2.944", lower, upper)
+	}
+}
+
+func TestSPRTLLR(t *testing.T) {
+	cfg := DefaultSPRTConfig()
+	tests := []struct {
+		wins, draws, losses int
+		want                float64
+	}{
+		{60, 20, 20, 7.337},
+		{30, 40, 30, -1.702},
+		{3, 1, 2, 0.101},
+		{0, 5, 0, 0}, // no variance yet
+		{0, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		got := cfg.LLR(tt.wins, tt.draws, tt.losses)
+		if math.Abs(got-tt.want) > 0.001 {
+			t.Errorf("LLR(%d, %d, %d) = %.3f, want %.3f", tt.wins, tt.draws, tt.losses, got, tt.want)
+		}
+	}
+}
+
+func TestSPRTStatusDecision(t *testing.T) {
+	cfg := DefaultSPRTConfig()
+	if got := cfg.Status(60, 20, 20).Decision; got != SPRTAcceptH1 {
+		t.Errorf("Decision for a clearly stronger bot = %v, want H1 accepted", got)
+	}
+	if got := cfg.Status(20, 20, 60).Decision; got != SPRTAcceptH0 {
+		t.Errorf("Decision for a clearly weaker bot = %v, want H0 accepted", got)
+	}
+	status := cfg.Status(3, 1, 2)
+	if status.Decision != SPRTContinue {
+		t.Errorf("Decision after 6 games = %v, want running", status.Decision)
+	}
+	if got, want := status.String(), "LLR 0.10 [-2.94, 2.94] (0, 50) - running"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+// finishedSession returns a session that has already finished with the given winner.
+func finishedSession(n int, winner string, color engine.Color) *GameSession {
+	s := NewGameSession(n, nil, nil, "White", "Black", new(PlaybackSpeed))
+	s.state = StateFinished
+	s.result = &GameResult{GameNumber: n, Winner: winner, WinnerColor: color}
+	return s
+}
+
+func TestSessionManagerSPRTStopsOnDecision(t *testing.T) {
+	m := NewSessionManager(0, 0, "White", "Black", 4, 1)
+	m.EnableSPRT(SPRTConfig{Elo0: 0, Elo1: 400, Alpha: 0.2, Beta: 0.2})
+	queued := NewGameSession(4, nil, nil, "White", "Black", new(PlaybackSpeed))
+	m.sessions = []*GameSession{
+		finishedSession(1, "White", engine.White),
+		finishedSession(2, "White", engine.White),
+		finishedSession(3, "Draw", engine.White),
+		queued,
+	}
+
+	m.checkSPRT()
+
+	status, ok := m.SPRT()
+	if !ok {
+		t.Fatal("Expected the session to be an SPRT")
+	}
+	if status.Decision != SPRTAcceptH1 || status.Wins != 2 || status.Draws != 1 {
+		t.Fatalf("Expected H1 accepted after 2 wins and a draw, got %+v", status)
+	}
+	if m.State() != StateFinished {
+		t.Errorf("State() = %v, want StateFinished", m.State())
+	}
+	if !queued.IsFinished() || queued.Result() != nil {
+		t.Error("Expected the queued game to be aborted without a result")
+	}
+	if !m.AllFinished() {
+		t.Error("Expected every game to be finished after the decision")
+	}
+}
+
+func TestSessionManagerWithoutSPRT(t *testing.T) {
+	m := NewSessionManager(0, 0, "White", "Black", 2, 1)
+	if _, ok := m.SPRT(); ok {
+		t.Error("Expected no SPRT status for a fixed number of games")
+	}
+}
@@ -1,6 +1,11 @@
 package bvb
 
-import "time"
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
 
 // AggregateStats holds computed statistics for a multi-game session.
 type AggregateStats struct {
@@ -20,10 +25,43 @@ type AggregateStats struct {
 	WhiteWinPct float64
 	// BlackWinPct is the black bot's win percentage (0-100).
 	BlackWinPct float64
+	// DrawPct is the percentage of drawn games (0-100).
+	DrawPct float64
+	// DecisivePct is the percentage of games that had a winner (0-100).
+	DecisivePct float64
+	// WhiteWinCI, BlackWinCI and DrawCI are 95% confidence intervals of the
+	// white bot's win rate, the black bot's win rate and the draw rate.
+	WhiteWinCI Interval
+	BlackWinCI Interval
+	DrawCI     Interval
+	// EndReasons counts the games by how they ended, most common first.
+	EndReasons []EndReasonCount
+	// RepetitionWarnings is the number of moves, over all games, that repeated a
+	// position for the second time. RepetitionWarningGames is the number of games
+	// with such a move, and RepetitionDraws how many of those were then drawn by
+	// repetition.
+	RepetitionWarnings     int
+	RepetitionWarningGames int
+	RepetitionDraws        int
 	// AvgMoveCount is the average number of moves per game.
 	AvgMoveCount float64
 	// AvgDuration is the average game duration.
 	AvgDuration time.Duration
+	// DurationP10, DurationP50 and DurationP90 are the 10th, 50th (median) and
+	// 90th percentiles of the game durations.
+	DurationP10 time.Duration
+	DurationP50 time.Duration
+	DurationP90 time.Duration
+	// WhiteAvgThinkTime is the average time the white bot spent choosing a move.
+	WhiteAvgThinkTime time.Duration
+	// BlackAvgThinkTime is the average time the black bot spent choosing a move.
+	BlackAvgThinkTime time.Duration
+	// Elo is the estimated Elo difference of the white bot over the black bot,
+	// or nil if it cannot be estimated from the results.
+	Elo *EloEstimate
+	// Pairs summarizes the game pairs if the bots swapped colors every other game,
+	// or is nil if they kept their colors.
+	Pairs *PairStats
 	// ShortestGame is the game with the fewest moves.
 	ShortestGame GameResult
 	// LongestGame is the game with the most moves.
@@ -32,6 +70,22 @@ type AggregateStats struct {
 	IndividualResults []GameResult
 }
 
+// Interval is a confidence interval of a percentage (0-100).
+type Interval struct {
+	Lower float64
+	Upper float64
+}
+
+// EndReasonCount is the number of games that ended in one way.
+type EndReasonCount struct {
+	// Reason is the kind of ending, see EndReasonCategory.
+	Reason string
+	// Games is the number of games that ended this way.
+	Games int
+	// Pct is the percentage of all games that ended this way (0-100).
+	Pct float64
+}
+
 // ComputeStats calculates aggregate statistics from a slice of game results.
 func ComputeStats(results []GameResult, whiteName, blackName string) *AggregateStats {
 	if len(results) == 0 {
@@ -53,6 +107,10 @@ func ComputeStats(results []GameResult, whiteName, blackName string) *AggregateS
 
 	var totalMoves int
 	var totalDuration time.Duration
+	var whiteThink, blackThink time.Duration
+	var whiteMoves, blackMoves int
+	durations := make([]time.Duration, 0, len(results))
+	endReasons := make(map[string]int)
 
 	for _, r := range results {
 		// Count wins.
@@ -67,6 +125,28 @@ func ComputeStats(results []GameResult, whiteName, blackName string) *AggregateS
 		// Accumulate for averages.
 		totalMoves += r.MoveCount
 		totalDuration += r.Duration
+		durations = append(durations, r.Duration)
+		endReasons[EndReasonCategory(r.EndReason)]++
+		if r.RepetitionWarnings > 0 {
+			stats.RepetitionWarnings += r.RepetitionWarnings
+			stats.RepetitionWarningGames++
+			if EndReasonCategory(r.EndReason) == "repetition" {
+				stats.RepetitionDraws++
+			}
+		}
+
+		// The white bot makes the even-numbered plies, unless the game starts
+		// with Black to move or the bots swapped colors for it.
+		whiteFirst := r.Reversed == blackToMove(r.StartFEN)
+		for i, d := range r.MoveTimes {
+			if (i%2 == 0) == whiteFirst {
+				whiteThink += d
+				whiteMoves++
+			} else {
+				blackThink += d
+				blackMoves++
+			}
+		}
 
 		// Track shortest/longest by move count.
 		if r.MoveCount < stats.ShortestGame.MoveCount {
@@ -80,10 +160,109 @@ func ComputeStats(results []GameResult, whiteName, blackName string) *AggregateS
 	// Calculate averages.
 	stats.AvgMoveCount = float64(totalMoves) / float64(stats.TotalGames)
 	stats.AvgDuration = totalDuration / time.Duration(stats.TotalGames)
+	if whiteMoves > 0 {
+		stats.WhiteAvgThinkTime = whiteThink / time.Duration(whiteMoves)
+	}
+	if blackMoves > 0 {
+		stats.BlackAvgThinkTime = blackThink / time.Duration(blackMoves)
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	stats.DurationP10 = percentile(durations, 10)
+	stats.DurationP50 = percentile(durations, 50)
+	stats.DurationP90 = percentile(durations, 90)
 
 	// Calculate win percentages.
 	stats.WhiteWinPct = float64(stats.WhiteWins) / float64(stats.TotalGames) * 100
 	stats.BlackWinPct = float64(stats.BlackWins) / float64(stats.TotalGames) * 100
+	stats.DrawPct = float64(stats.Draws) / float64(stats.TotalGames) * 100
+	stats.DecisivePct = 100 - stats.DrawPct
+	stats.WhiteWinCI = WilsonInterval(stats.WhiteWins, stats.TotalGames)
+	stats.BlackWinCI = WilsonInterval(stats.BlackWins, stats.TotalGames)
+	stats.DrawCI = WilsonInterval(stats.Draws, stats.TotalGames)
+
+	for reason, games := range endReasons {
+		stats.EndReasons = append(stats.EndReasons, EndReasonCount{
+			Reason: reason,
+			Games:  games,
+			Pct:    float64(games) / float64(stats.TotalGames) * 100,
+		})
+	}
+	sort.Slice(stats.EndReasons, func(i, j int) bool {
+		a, b := stats.EndReasons[i], stats.EndReasons[j]
+		if a.Games != b.Games {
+			return a.Games > b.Games
+		}
+		return a.Reason < b.Reason
+	})
+
+	stats.Pairs = ComputePairStats(results, whiteName)
+
+	// Estimate the Elo difference from the white bot's record.
+	if elo, ok := EstimateElo(stats.WhiteWins, stats.Draws, stats.BlackWins); ok {
+		stats.Elo = &elo
+	}
 
 	return stats
 }
+
+// blackToMove reports whether Black moves first in the position fen. An empty
+// fen is the standard starting position.
+func blackToMove(fen string) bool {
+	fields := strings.Fields(fen)
+	return len(fields) > 1 && fields[1] == "b"
+}
+
+// WilsonInterval returns the 95% Wilson score interval of the rate of an event
+// that happened in successes of n trials, as percentages. Unlike the normal
+// approximation it stays within 0-100% and is sensible for small samples and
+// rates near 0% or 100%.
+func WilsonInterval(successes, n int) Interval {
+	if n <= 0 {
+		return Interval{}
+	}
+	total := float64(n)
+	p := float64(successes) / total
+	z2 := eloZ95 * eloZ95
+	center := (p + z2/(2*total)) / (1 + z2/total)
+	margin := eloZ95 * math.Sqrt(p*(1-p)/total+z2/(4*total*total)) / (1 + z2/total)
+	return Interval{
+		Lower: math.Max(0, center-margin) * 100,
+		Upper: math.Min(1, center+margin) * 100,
+	}
+}
+
+// EndReasonCategory groups a game's end reason with the similar ones: both
+// repetition draws are "repetition", both move-count draws "fifty-move rule",
+// and games stopped at the move limit are "adjudication". Engine errors are
+// grouped regardless of the error.
+func EndReasonCategory(reason string) string {
+	switch {
+	case strings.Contains(reason, "repetition"):
+		return "repetition"
+	case strings.Contains(reason, "move rule"):
+		return "fifty-move rule"
+	case strings.Contains(reason, "insufficient material"):
+		return "insufficient material"
+	case reason == "move limit exceeded":
+		return "adjudication"
+	case strings.HasPrefix(reason, "engine error"):
+		return "engine error"
+	case reason == "":
+		return "unknown"
+	}
+	return reason
+}
+
+// percentile returns the p-th percentile (0-100) of sorted by the nearest-rank
+// method, or 0 if sorted is empty.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(float64(p) / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
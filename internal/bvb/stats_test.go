@@ -101,6 +101,95 @@ func TestComputeStatsAverages(t *testing.T) {
 	}
 }
 
+func TestComputeStatsAvgThinkTime(t *testing.T) {
+	results := []GameResult{
+		{GameNumber: 1, Winner: "Draw", MoveCount: 3, MoveTimes: []time.Duration{1 * time.Second, 100 * time.Millisecond, 3 * time.Second}},
+		{GameNumber: 2, Winner: "Draw", MoveCount: 2, MoveTimes: []time.Duration{2 * time.Second, 200 * time.Millisecond}},
+	}
+
+	stats := ComputeStats(results, "White Bot", "Black Bot")
+
+	if stats.WhiteAvgThinkTime != 2*time.Second {
+		t.Errorf("WhiteAvgThinkTime = %v, want 2s", stats.WhiteAvgThinkTime)
+	}
+	if stats.BlackAvgThinkTime != 150*time.Millisecond {
+		t.Errorf("BlackAvgThinkTime = %v, want 150ms", stats.BlackAvgThinkTime)
+	}
+
+	// A reversed game and one with Black to move each start with the black bot
+	results = []GameResult{
+		{GameNumber: 1, Winner: "Draw", MoveCount: 2, Reversed: true, MoveTimes: []time.Duration{100 * time.Millisecond, 2 * time.Second}},
+		{GameNumber: 2, Winner: "Draw", MoveCount: 2, StartFEN: "4k3/8/8/8/8/8/4P3/4K3 b - - 0 1", MoveTimes: []time.Duration{200 * time.Millisecond, 2 * time.Second}},
+	}
+	stats = ComputeStats(results, "White Bot", "Black Bot")
+	if stats.WhiteAvgThinkTime != 2*time.Second || stats.BlackAvgThinkTime != 150*time.Millisecond {
+		t.Errorf("Expected think times credited to the bot that moved, got %v and %v", stats.WhiteAvgThinkTime, stats.BlackAvgThinkTime)
+	}
+
+	// Results without timings leave the averages at zero
+	stats = ComputeStats([]GameResult{{GameNumber: 1, Winner: "Draw", MoveCount: 10}}, "White Bot", "Black Bot")
+	if stats.WhiteAvgThinkTime != 0 || stats.BlackAvgThinkTime != 0 {
+		t.Errorf("Expected zero think times without timings, got %v and %v", stats.WhiteAvgThinkTime, stats.BlackAvgThinkTime)
+	}
+}
+
+func TestComputeStatsBreakdown(t *testing.T) {
+	results := []GameResult{
+		{GameNumber: 1, Winner: "White Bot", WinnerColor: engine.White, Duration: 1 * time.Second, EndReason: "checkmate"},
+		{GameNumber: 2, Winner: "Black Bot", WinnerColor: engine.Black, Duration: 2 * time.Second, EndReason: "checkmate"},
+		{GameNumber: 3, Winner: "Draw", Duration: 3 * time.Second, EndReason: "draw (threefold repetition)", RepetitionWarnings: 1},
+		{GameNumber: 4, Winner: "Draw", Duration: 4 * time.Second, EndReason: "draw (fivefold repetition)", RepetitionWarnings: 3},
+		{GameNumber: 5, Winner: "Draw", Duration: 10 * time.Second, EndReason: "move limit exceeded", RepetitionWarnings: 2},
+	}
+
+	stats := ComputeStats(results, "White Bot", "Black Bot")
+
+	if stats.DrawPct != 60 || stats.DecisivePct != 40 {
+		t.Errorf("DrawPct = %v, DecisivePct = %v, want 60 and 40", stats.DrawPct, stats.DecisivePct)
+	}
+	if ci := stats.WhiteWinCI; ci.Lower > stats.WhiteWinPct || ci.Upper < stats.WhiteWinPct {
+		t.Errorf("WhiteWinCI = %+v does not contain the win rate %v", ci, stats.WhiteWinPct)
+	}
+	want := []EndReasonCount{
+		{Reason: "checkmate", Games: 2, Pct: 40},
+		{Reason: "repetition", Games: 2, Pct: 40},
+		{Reason: "adjudication", Games: 1, Pct: 20},
+	}
+	if len(stats.EndReasons) != len(want) {
+		t.Fatalf("EndReasons = %+v, want %+v", stats.EndReasons, want)
+	}
+	for i := range want {
+		if stats.EndReasons[i] != want[i] {
+			t.Errorf("EndReasons[%d] = %+v, want %+v", i, stats.EndReasons[i], want[i])
+		}
+	}
+	if stats.RepetitionWarnings != 6 || stats.RepetitionWarningGames != 3 || stats.RepetitionDraws != 2 {
+		t.Errorf("repetition warnings = %d in %d games, %d drawn; want 6 in 3 games, 2 drawn",
+			stats.RepetitionWarnings, stats.RepetitionWarningGames, stats.RepetitionDraws)
+	}
+	if stats.DurationP10 != 1*time.Second || stats.DurationP50 != 3*time.Second || stats.DurationP90 != 10*time.Second {
+		t.Errorf("duration percentiles = %v/%v/%v, want 1s/3s/10s", stats.DurationP10, stats.DurationP50, stats.DurationP90)
+	}
+}
+
+func TestWilsonInterval(t *testing.T) {
+	tests := []struct {
+		successes, n int
+		lower, upper float64
+	}{
+		{5, 10, 23.66, 76.34},
+		{0, 10, 0, 27.75},
+		{10, 10, 72.25, 100},
+		{0, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		ci := WilsonInterval(tt.successes, tt.n)
+		if math.Abs(ci.Lower-tt.lower) > 0.01 || math.Abs(ci.Upper-tt.upper) > 0.01 {
+			t.Errorf("WilsonInterval(%d, %d) = %+v, want %.2f-%.2f", tt.successes, tt.n, ci, tt.lower, tt.upper)
+		}
+	}
+}
+
 func TestComputeStatsShortestLongest(t *testing.T) {
 	results := []GameResult{
 		{GameNumber: 1, Winner: "White Bot", MoveCount: 40, Duration: 5 * time.Second, EndReason: "checkmate"},
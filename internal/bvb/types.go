@@ -59,4 +59,16 @@ type GameResult struct {
 	FinalFEN string
 	// MoveHistory contains all moves played in order.
 	MoveHistory []engine.Move
+	// MoveTimes holds how long the engine spent choosing each move, parallel to MoveHistory.
+	MoveTimes []time.Duration
+	// Flags marks the notable events of the game.
+	Flags GameFlags
+	// StartFEN is the position the game started from, or empty for the standard starting position.
+	StartFEN string
+	// Reversed is set when the bots swapped colors for the game, see SessionManager.SetOpenings:
+	// the session's black bot played White.
+	Reversed bool
+	// RepetitionWarnings counts the moves that brought about a position for the
+	// second time, each a warning that one more repetition is a draw.
+	RepetitionWarnings int
 }
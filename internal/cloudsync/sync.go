@@ -0,0 +1,578 @@
+// Package cloudsync keeps the config, saved game and game history of TermChess
+// in step between machines, through storage the user provides: a folder of an
+// S3-compatible bucket or a WebDAV folder.
+//
+// Every sync compares the local files and the stored ones with how both were
+// at the previous sync, recorded in the sync state file. A file changed on one
+// side only is copied to the other, and a file deleted on one side only is
+// deleted on the other. A file changed on both sides, or found on both sides
+// on the first sync, is a conflict: the copy modified last wins.
+package cloudsync
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/Mgrdich/TermChess/internal/config"
+)
+
+// RemoteFile is a file stored in a backend.
+type RemoteFile struct {
+	// Name is the path of the file in the synced folder, with slashes, e.g.
+	// "data/games/game_2024-03-01_12-00-00.pgn".
+	Name string
+	// ModTime is when the file was last stored.
+	ModTime time.Time
+}
+
+// Backend stores the synced files.
+type Backend interface {
+	// List returns every file in the synced folder.
+	List(ctx context.Context) ([]RemoteFile, error)
+	// Get returns the contents of the named file.
+	Get(ctx context.Context, name string) ([]byte, error)
+	// Put stores data as the named file, creating its folders if needed.
+	Put(ctx context.Context, name string, data []byte) error
+	// Delete removes the named file. Deleting a missing file is not an error.
+	Delete(ctx context.Context, name string) error
+}
+
+// NewBackend returns the backend configured in cfg, logging in with the
+// credentials of config.LoadSyncCredentials.
+func NewBackend(cfg config.Config) (Backend, error) {
+	if cfg.SyncURL == "" && cfg.SyncBackend != "" {
+		return nil, errors.New("no sync url configured")
+	}
+	switch cfg.SyncBackend {
+	case config.SyncBackendS3:
+		creds, err := config.LoadSyncCredentials()
+		if err != nil {
+			return nil, err
+		}
+		return NewS3(nil, cfg.SyncURL, cfg.SyncRegion, creds.AccessKeyID, creds.SecretAccessKey)
+	case config.SyncBackendWebDAV:
+		creds, err := config.LoadSyncCredentials()
+		if err != nil {
+			return nil, err
+		}
+		return NewWebDAV(nil, cfg.SyncURL, cfg.SyncUsername, creds.Password)
+	case "":
+		return nil, errors.New("sync is not configured: set backend and url in the [sync] section of the config file")
+	default:
+		return nil, fmt.Errorf("unknown sync backend %q (use s3 or webdav)", cfg.SyncBackend)
+	}
+}
+
+// Root is a local directory whose files matching Patterns, paths relative to
+// Dir as understood by filepath.Match, are synced under Name. If LocalKeys is
+// set, the files are TOML and the tables and values at those dotted keys stay
+// on each machine: they are left out of uploads and kept through downloads.
+type Root struct {
+	Name      string
+	Dir       string
+	Patterns  []string
+	LocalKeys []string
+}
+
+// DefaultRoots returns the files TermChess syncs: the config file and profiles
+// from the config directory, without the settings of config.LocalKeys, and the
+// saved game, the FEN history and the game history from the data directory.
+func DefaultRoots() ([]Root, error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	dataDir, err := config.GetDataDir()
+	if err != nil {
+		return nil, err
+	}
+	return []Root{
+		{Name: "config", Dir: configDir, Patterns: []string{"config.toml", "profiles/*.toml"}, LocalKeys: config.LocalKeys},
+		{Name: "data", Dir: dataDir, Patterns: []string{
+			"savegame.fen", "savegame.toml", "savegame.notes", "fen_history", "games/*.pgn",
+		}},
+	}, nil
+}
+
+// Action is what a sync does with a file.
+type Action int
+
+const (
+	// Upload stores the local file in the backend.
+	Upload Action = iota
+	// Download replaces the local file with the stored one.
+	Download
+	// DeleteRemote deletes the stored file, deleted locally.
+	DeleteRemote
+	// DeleteLocal deletes the local file, deleted from the backend.
+	DeleteLocal
+)
+
+// String returns a short description of the action.
+func (a Action) String() string {
+	switch a {
+	case Upload:
+		return "upload"
+	case Download:
+		return "download"
+	case DeleteRemote:
+		return "delete remote"
+	case DeleteLocal:
+		return "delete local"
+	default:
+		return "unknown"
+	}
+}
+
+// Change is a file a sync copies or deletes.
+type Change struct {
+	Name   string
+	Action Action
+}
+
+// fileState is a file as it was at the last sync: the modification times of
+// the local and the stored copy.
+type fileState struct {
+	Local  time.Time `toml:"local"`
+	Remote time.Time `toml:"remote"`
+}
+
+// stateFile is the sync state file: the synced files by name.
+type stateFile struct {
+	Files map[string]fileState `toml:"files"`
+}
+
+// manifestName is the file in the synced folder recording when every stored
+// file was last modified on the machine that uploaded it. The backends only know
+// when a file was uploaded, which can be long after it was changed.
+const manifestName = ".termchess-sync.toml"
+
+// manifestFile is the manifest: the stored files by name, with the modification
+// time of their local copy when they were uploaded.
+type manifestFile struct {
+	Files map[string]time.Time `toml:"files"`
+}
+
+// snapshot is the local and the stored files at the start of a sync.
+type snapshot struct {
+	local map[string]localFile
+	// remote holds the times the stored files were uploaded, by name
+	remote map[string]time.Time
+	// modified holds the times the stored files were modified before their upload, by name
+	modified map[string]time.Time
+	// state holds the files as they were at the last sync, by name
+	state map[string]fileState
+}
+
+// plan returns the changes that bring the local files and the stored ones in
+// step, sorted by name.
+func (snap snapshot) plan() []Change {
+	names := make(map[string]bool)
+	for name := range snap.local {
+		names[name] = true
+	}
+	for name := range snap.remote {
+		names[name] = true
+	}
+
+	var changes []Change
+	for name := range names {
+		l, inLocal := snap.local[name]
+		r, inRemote := snap.remote[name]
+		s, synced := snap.state[name]
+		localChanged := inLocal && (!synced || !l.modTime.Equal(s.Local))
+		remoteChanged := inRemote && (!synced || !r.Equal(s.Remote))
+
+		var action Action
+		switch {
+		case inLocal && inRemote:
+			switch {
+			case localChanged && remoteChanged:
+				// Changed on both sides: the last modification wins
+				action = Download
+				if l.modTime.After(snap.modifiedAt(name)) {
+					action = Upload
+				}
+			case localChanged:
+				action = Upload
+			case remoteChanged:
+				action = Download
+			default:
+				continue
+			}
+		case inLocal:
+			// Deleted remotely, unless it was never synced or was changed since
+			action = Upload
+			if synced && !localChanged {
+				action = DeleteLocal
+			}
+		default:
+			action = Download
+			if synced && !remoteChanged {
+				action = DeleteRemote
+			}
+		}
+		changes = append(changes, Change{Name: name, Action: action})
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+	return changes
+}
+
+// modifiedAt returns when the stored file name was last modified: the time in
+// the manifest, or else the time it was uploaded.
+func (snap snapshot) modifiedAt(name string) time.Time {
+	if t, ok := snap.modified[name]; ok {
+		return t
+	}
+	return snap.remote[name]
+}
+
+// Syncer syncs the files of its roots with a backend.
+type Syncer struct {
+	backend   Backend
+	roots     []Root
+	statePath string
+}
+
+// NewSyncer returns a Syncer of the files of roots with backend, recording the
+// state of the files in the file at statePath.
+func NewSyncer(backend Backend, roots []Root, statePath string) *Syncer {
+	return &Syncer{backend: backend, roots: roots, statePath: statePath}
+}
+
+// Pending returns the changes the next sync would make, without making them.
+func (s *Syncer) Pending(ctx context.Context) ([]Change, error) {
+	snap, err := s.scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return snap.plan(), nil
+}
+
+// Sync brings the local files and the stored ones in step and returns the
+// changes made. A file that fails to sync doesn't stop the others; the errors
+// are joined, and the file is synced again next time.
+func (s *Syncer) Sync(ctx context.Context) ([]Change, error) {
+	snap, err := s.scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Change
+	var errs []error
+	failed := make(map[string]bool)
+	uploaded := false
+	for _, change := range snap.plan() {
+		if err := s.apply(ctx, snap, change); err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", change.Action, change.Name, err))
+			failed[change.Name] = true
+			continue
+		}
+		switch change.Action {
+		case Upload:
+			snap.modified[change.Name] = snap.local[change.Name].modTime
+			uploaded = true
+		case DeleteRemote:
+			delete(snap.modified, change.Name)
+			uploaded = true
+		}
+		done = append(done, change)
+	}
+	if uploaded {
+		if err := s.saveManifest(ctx, snap.modified); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// Record the files as they are now, the stored ones with the times the backend gave them
+	after, err := s.scan(ctx)
+	if err != nil {
+		return done, errors.Join(append(errs, err)...)
+	}
+	next := make(map[string]fileState)
+	for name, file := range after.local {
+		r, ok := after.remote[name]
+		switch {
+		case failed[name]:
+			if old, ok := snap.state[name]; ok {
+				next[name] = old
+			}
+		case ok:
+			next[name] = fileState{Local: file.modTime, Remote: r}
+		}
+	}
+	if err := s.saveState(next); err != nil {
+		errs = append(errs, err)
+	}
+	return done, errors.Join(errs...)
+}
+
+// apply makes change to the local file or the stored one.
+func (s *Syncer) apply(ctx context.Context, snap snapshot, change Change) error {
+	switch change.Action {
+	case Upload:
+		data, err := os.ReadFile(snap.local[change.Name].path)
+		if err != nil {
+			return err
+		}
+		if keys := s.localKeys(change.Name); len(keys) > 0 {
+			if data, err = mergeKeys(data, nil, keys); err != nil {
+				return err
+			}
+		}
+		return s.backend.Put(ctx, change.Name, data)
+	case Download:
+		data, err := s.backend.Get(ctx, change.Name)
+		if err != nil {
+			return err
+		}
+		path, err := s.localPath(change.Name)
+		if err != nil {
+			return err
+		}
+		if keys := s.localKeys(change.Name); len(keys) > 0 {
+			local, err := os.ReadFile(path)
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			if data, err = mergeKeys(data, local, keys); err != nil {
+				return err
+			}
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		// The local copy is replaced atomically and backed up, so a failed download leaves it intact
+		if err := config.WriteFileAtomic(path, data, 0644); err != nil {
+			return err
+		}
+		// The local copy keeps the time the file was modified, so it isn't taken for a newer one
+		modified := snap.modifiedAt(change.Name)
+		return os.Chtimes(path, modified, modified)
+	case DeleteRemote:
+		return s.backend.Delete(ctx, change.Name)
+	case DeleteLocal:
+		if err := os.Remove(snap.local[change.Name].path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	return nil
+}
+
+// localKeys returns the local keys of the root of the file name.
+func (s *Syncer) localKeys(name string) []string {
+	for _, root := range s.roots {
+		if strings.HasPrefix(name, root.Name+"/") {
+			return root.LocalKeys
+		}
+	}
+	return nil
+}
+
+// mergeKeys returns the TOML file data with the values at the dotted keys
+// replaced by those of the TOML file local: removed where local doesn't have
+// them, and all of them if local is nil.
+func mergeKeys(data, local []byte, keys []string) ([]byte, error) {
+	var file, localFile map[string]any
+	if _, err := toml.Decode(string(data), &file); err != nil {
+		return nil, err
+	}
+	if _, err := toml.Decode(string(local), &localFile); err != nil {
+		return nil, err
+	}
+	if file == nil {
+		file = make(map[string]any)
+	}
+	for _, key := range keys {
+		path := strings.Split(key, ".")
+		last := len(path) - 1
+		if parent, ok := lookupKey(file, path[:last]); ok {
+			if table, ok := parent.(map[string]any); ok {
+				delete(table, path[last])
+			}
+		}
+		value, ok := lookupKey(localFile, path)
+		if !ok {
+			continue
+		}
+		// Create the tables the value is in if the file doesn't have them
+		table := file
+		for _, name := range path[:last] {
+			next, ok := table[name].(map[string]any)
+			if !ok {
+				next = make(map[string]any)
+				table[name] = next
+			}
+			table = next
+		}
+		table[path[last]] = value
+	}
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(file); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// lookupKey returns the value at the path of keys in the TOML tables of file.
+func lookupKey(file map[string]any, path []string) (any, bool) {
+	var value any = file
+	for _, name := range path {
+		table, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = table[name]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// localFile is a local file to sync.
+type localFile struct {
+	path    string
+	modTime time.Time
+}
+
+// scan lists the local files and the stored files, and reads the manifest and
+// the state of the last sync.
+func (s *Syncer) scan(ctx context.Context) (snapshot, error) {
+	local, err := s.localFiles()
+	if err != nil {
+		return snapshot{}, err
+	}
+	files, err := s.backend.List(ctx)
+	if err != nil {
+		return snapshot{}, err
+	}
+	remote := make(map[string]time.Time)
+	hasManifest := false
+	for _, f := range files {
+		if f.Name == manifestName {
+			hasManifest = true
+		} else if s.isSynced(f.Name) {
+			remote[f.Name] = f.ModTime
+		}
+	}
+
+	var manifest manifestFile
+	if hasManifest {
+		data, err := s.backend.Get(ctx, manifestName)
+		if err != nil {
+			return snapshot{}, err
+		}
+		if _, err := toml.Decode(string(data), &manifest); err != nil {
+			return snapshot{}, fmt.Errorf("failed to read %s: %w", manifestName, err)
+		}
+	}
+	if manifest.Files == nil {
+		manifest.Files = make(map[string]time.Time)
+	}
+
+	state, err := s.loadState()
+	if err != nil {
+		return snapshot{}, err
+	}
+	return snapshot{local: local, remote: remote, modified: manifest.Files, state: state}, nil
+}
+
+// saveManifest stores the manifest with the modification times of the stored files.
+func (s *Syncer) saveManifest(ctx context.Context, modified map[string]time.Time) error {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(manifestFile{Files: modified}); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", manifestName, err)
+	}
+	return s.backend.Put(ctx, manifestName, buf.Bytes())
+}
+
+// localFiles returns the local files to sync by name.
+func (s *Syncer) localFiles() (map[string]localFile, error) {
+	files := make(map[string]localFile)
+	for _, root := range s.roots {
+		for _, pattern := range root.Patterns {
+			paths, err := filepath.Glob(filepath.Join(root.Dir, filepath.FromSlash(pattern)))
+			if err != nil {
+				return nil, err
+			}
+			for _, path := range paths {
+				info, err := os.Stat(path)
+				if err != nil || !info.Mode().IsRegular() {
+					continue
+				}
+				rel, err := filepath.Rel(root.Dir, path)
+				if err != nil {
+					continue
+				}
+				files[root.Name+"/"+filepath.ToSlash(rel)] = localFile{path: path, modTime: info.ModTime()}
+			}
+		}
+	}
+	return files, nil
+}
+
+// isSynced reports whether the stored file name belongs to a root and matches
+// one of its patterns, so files stored by others are left alone.
+func (s *Syncer) isSynced(name string) bool {
+	for _, root := range s.roots {
+		rel, ok := strings.CutPrefix(name, root.Name+"/")
+		if !ok {
+			continue
+		}
+		for _, pattern := range root.Patterns {
+			if matched, _ := filepath.Match(pattern, rel); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// localPath returns the local path of the file name.
+func (s *Syncer) localPath(name string) (string, error) {
+	for _, root := range s.roots {
+		if rel, ok := strings.CutPrefix(name, root.Name+"/"); ok && filepath.IsLocal(filepath.FromSlash(rel)) {
+			return filepath.Join(root.Dir, filepath.FromSlash(rel)), nil
+		}
+	}
+	return "", fmt.Errorf("no local directory for %s", name)
+}
+
+// loadState reads the state of the files at the last sync. A missing state
+// file means nothing was synced yet.
+func (s *Syncer) loadState() (map[string]fileState, error) {
+	var sf stateFile
+	if _, err := toml.DecodeFile(s.statePath, &sf); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read sync state: %w", err)
+	}
+	if sf.Files == nil {
+		sf.Files = make(map[string]fileState)
+	}
+	return sf.Files, nil
+}
+
+// saveState records the state of the files after a sync.
+func (s *Syncer) saveState(files map[string]fileState) error {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(stateFile{Files: files}); err != nil {
+		return fmt.Errorf("failed to encode sync state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.statePath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(s.statePath, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write sync state: %w", err)
+	}
+	return nil
+}
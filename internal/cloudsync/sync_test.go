@@ -0,0 +1,303 @@
+package cloudsync
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/Mgrdich/TermChess/internal/config"
+)
+
+// memBackend is a Backend keeping the files in memory, stamped with a clock
+// that ticks on every Put.
+type memBackend struct {
+	files map[string][]byte
+	times map[string]time.Time
+	clock time.Time
+}
+
+func newMemBackend() *memBackend {
+	return &memBackend{
+		files: make(map[string][]byte),
+		times: make(map[string]time.Time),
+		clock: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func (b *memBackend) List(ctx context.Context) ([]RemoteFile, error) {
+	var files []RemoteFile
+	for name := range b.files {
+		files = append(files, RemoteFile{Name: name, ModTime: b.times[name]})
+	}
+	return files, nil
+}
+
+func (b *memBackend) Get(ctx context.Context, name string) ([]byte, error) {
+	data, ok := b.files[name]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return data, nil
+}
+
+func (b *memBackend) Put(ctx context.Context, name string, data []byte) error {
+	b.clock = b.clock.Add(time.Second)
+	b.files[name] = append([]byte(nil), data...)
+	b.times[name] = b.clock
+	return nil
+}
+
+func (b *memBackend) Delete(ctx context.Context, name string) error {
+	delete(b.files, name)
+	delete(b.times, name)
+	return nil
+}
+
+func TestPlan(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	t1, t2 := t0.Add(time.Hour), t0.Add(2*time.Hour)
+	synced := map[string]fileState{"f": {Local: t0, Remote: t0}}
+
+	tests := []struct {
+		name   string
+		local  map[string]time.Time
+		remote map[string]time.Time
+		state  map[string]fileState
+		want   []Change
+	}{
+		{"unchanged", map[string]time.Time{"f": t0}, map[string]time.Time{"f": t0}, synced, nil},
+		{"new local file", map[string]time.Time{"f": t0}, nil, nil, []Change{{"f", Upload}}},
+		{"new remote file", nil, map[string]time.Time{"f": t0}, nil, []Change{{"f", Download}}},
+		{"changed locally", map[string]time.Time{"f": t1}, map[string]time.Time{"f": t0}, synced, []Change{{"f", Upload}}},
+		{"changed remotely", map[string]time.Time{"f": t0}, map[string]time.Time{"f": t1}, synced, []Change{{"f", Download}}},
+		{"conflict, local newer", map[string]time.Time{"f": t2}, map[string]time.Time{"f": t1}, synced, []Change{{"f", Upload}}},
+		{"conflict, remote newer", map[string]time.Time{"f": t1}, map[string]time.Time{"f": t2}, synced, []Change{{"f", Download}}},
+		{"first sync, both sides", map[string]time.Time{"f": t1}, map[string]time.Time{"f": t2}, nil, []Change{{"f", Download}}},
+		{"deleted locally", nil, map[string]time.Time{"f": t0}, synced, []Change{{"f", DeleteRemote}}},
+		{"deleted remotely", map[string]time.Time{"f": t0}, nil, synced, []Change{{"f", DeleteLocal}}},
+		{"deleted locally, changed remotely", nil, map[string]time.Time{"f": t1}, synced, []Change{{"f", Download}}},
+		{"deleted remotely, changed locally", map[string]time.Time{"f": t1}, nil, synced, []Change{{"f", Upload}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snap := snapshot{local: make(map[string]localFile), remote: tt.remote, state: tt.state}
+			for name, modTime := range tt.local {
+				snap.local[name] = localFile{path: name, modTime: modTime}
+			}
+			if got := snap.plan(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("plan() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// A conflict is decided on when the stored copy was modified, not uploaded
+	snap := snapshot{
+		local:    map[string]localFile{"f": {path: "f", modTime: t1}},
+		remote:   map[string]time.Time{"f": t2},
+		modified: map[string]time.Time{"f": t0},
+		state:    synced,
+	}
+	if got, want := snap.plan(), []Change{{"f", Upload}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("plan() with a manifest = %v, want %v", got, want)
+	}
+}
+
+// syncMachine is a machine syncing its data directory with a backend.
+type syncMachine struct {
+	dir    string
+	syncer *Syncer
+}
+
+func newSyncMachine(t *testing.T, backend Backend) syncMachine {
+	t.Helper()
+	dir := t.TempDir()
+	roots := []Root{{Name: "data", Dir: dir, Patterns: []string{"savegame.fen", "games/*.pgn"}}}
+	return syncMachine{dir: dir, syncer: NewSyncer(backend, roots, filepath.Join(dir, "sync_state.toml"))}
+}
+
+func (m syncMachine) write(t *testing.T, name, data string, modTime time.Time) {
+	t.Helper()
+	path := filepath.Join(m.dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (m syncMachine) read(name string) string {
+	data, err := os.ReadFile(filepath.Join(m.dir, filepath.FromSlash(name)))
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+func (m syncMachine) sync(t *testing.T) []Change {
+	t.Helper()
+	changes, err := m.syncer.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("Sync() error: %v", err)
+	}
+	return changes
+}
+
+func TestSyncBetweenMachines(t *testing.T) {
+	backend := newMemBackend()
+	laptop, server := newSyncMachine(t, backend), newSyncMachine(t, backend)
+	now := time.Now().Truncate(time.Second)
+
+	laptop.write(t, "games/game_1.pgn", "1. e4 *", now.Add(-time.Hour))
+	laptop.write(t, "savegame.fen", "laptop position", now.Add(-time.Hour))
+	laptop.write(t, "notes.txt", "not synced", now)
+	if got := laptop.sync(t); len(got) != 2 {
+		t.Fatalf("Expected 2 uploads, got %v", got)
+	}
+	if _, ok := backend.files["data/notes.txt"]; ok {
+		t.Error("Expected files not matching the patterns to stay local")
+	}
+
+	if got := server.sync(t); len(got) != 2 || server.read("games/game_1.pgn") != "1. e4 *" {
+		t.Fatalf("Expected the server to download both files, got %v", got)
+	}
+	if got := server.sync(t); len(got) != 0 {
+		t.Errorf("Expected nothing to do right after a sync, got %v", got)
+	}
+	if got := laptop.sync(t); len(got) != 0 {
+		t.Errorf("Expected the laptop to be in step, got %v", got)
+	}
+
+	// The server changes the saved game, the laptop deletes the game
+	server.write(t, "savegame.fen", "server position", now)
+	if err := os.Remove(filepath.Join(laptop.dir, "games", "game_1.pgn")); err != nil {
+		t.Fatal(err)
+	}
+	server.sync(t)
+	laptop.sync(t)
+	server.sync(t)
+	if got := laptop.read("savegame.fen"); got != "server position" {
+		t.Errorf("Expected the server's saved game on the laptop, got %q", got)
+	}
+	if got := laptop.read("savegame.fen.bak"); got != "laptop position" {
+		t.Errorf("Expected the replaced saved game backed up, got %q", got)
+	}
+	if got := server.read("games/game_1.pgn"); got != "" {
+		t.Errorf("Expected the deleted game deleted on the server too, got %q", got)
+	}
+
+	// Both change the saved game: the later change wins
+	laptop.write(t, "savegame.fen", "older edit", now.Add(time.Minute))
+	server.write(t, "savegame.fen", "newer edit", now.Add(2*time.Minute))
+	server.sync(t)
+	laptop.sync(t)
+	if got := laptop.read("savegame.fen"); got != "newer edit" {
+		t.Errorf("Expected the later edit to win the conflict, got %q", got)
+	}
+}
+
+func TestSyncKeepsLocalKeys(t *testing.T) {
+	backend := newMemBackend()
+	machine := func() syncMachine {
+		dir := t.TempDir()
+		roots := []Root{{Name: "config", Dir: dir, Patterns: []string{"config.toml"}, LocalKeys: config.LocalKeys}}
+		return syncMachine{dir: dir, syncer: NewSyncer(backend, roots, filepath.Join(dir, "sync_state.toml"))}
+	}
+	laptop, server := machine(), machine()
+	now := time.Now().Truncate(time.Second)
+
+	laptop.write(t, "config.toml", `[display]
+theme = "modern"
+
+[game]
+syzygy_path = "/home/laptop/syzygy"
+
+[[external_bots]]
+name = "Stockfish"
+command = "/usr/local/bin/stockfish"
+`, now.Add(-time.Hour))
+	server.write(t, "config.toml", `[game]
+syzygy_path = "/srv/syzygy"
+
+[correspondence]
+mailbox_dir = "/srv/mail"
+`, now.Add(-2*time.Hour))
+	laptop.sync(t)
+
+	stored := string(backend.files["config/config.toml"])
+	for _, local := range []string{"laptop", "stockfish", "external_bots"} {
+		if strings.Contains(stored, local) {
+			t.Errorf("Expected %s left out of the stored config, got %q", local, stored)
+		}
+	}
+
+	server.sync(t)
+	var cf config.ConfigFile
+	if _, err := toml.Decode(server.read("config.toml"), &cf); err != nil {
+		t.Fatalf("Failed to parse the downloaded config: %v", err)
+	}
+	if cf.Display.Theme != "modern" {
+		t.Errorf("Expected the laptop's theme on the server, got %q", cf.Display.Theme)
+	}
+	if cf.Game.SyzygyPath != "/srv/syzygy" || cf.Correspondence.MailboxDir != "/srv/mail" || len(cf.ExternalBots) != 0 {
+		t.Errorf("Expected the server's own paths and bots kept, got %+v", cf)
+	}
+}
+
+func TestPendingMakesNoChanges(t *testing.T) {
+	backend := newMemBackend()
+	m := newSyncMachine(t, backend)
+	m.write(t, "savegame.fen", "position", time.Now())
+
+	changes, err := m.syncer.Pending(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []Change{{"data/savegame.fen", Upload}}; !reflect.DeepEqual(changes, want) {
+		t.Errorf("Pending() = %v, want %v", changes, want)
+	}
+	if len(backend.files) != 0 {
+		t.Error("Expected Pending not to upload anything")
+	}
+}
+
+func TestNewBackend(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "SECRET")
+
+	cfg := config.DefaultConfig()
+	if _, err := NewBackend(cfg); err == nil {
+		t.Error("Expected an error without a configured backend")
+	}
+
+	cfg.SyncBackend = config.SyncBackendS3
+	cfg.SyncURL = "https://s3.amazonaws.com/bucket/termchess"
+	if backend, err := NewBackend(cfg); err != nil {
+		t.Errorf("NewBackend() with credentials from the environment error: %v", err)
+	} else if s3, ok := backend.(*S3); !ok || s3.accessKey != "AKID" || s3.region != defaultS3Region {
+		t.Errorf("NewBackend() = %+v, want an S3 backend using AWS_ACCESS_KEY_ID", backend)
+	}
+
+	cfg.SyncBackend = config.SyncBackendWebDAV
+	cfg.SyncURL = "https://dav.example.com/termchess"
+	if backend, err := NewBackend(cfg); err != nil {
+		t.Errorf("NewBackend() error: %v", err)
+	} else if _, ok := backend.(*WebDAV); !ok {
+		t.Errorf("NewBackend() = %T, want *WebDAV", backend)
+	}
+
+	cfg.SyncBackend = "ftp"
+	if _, err := NewBackend(cfg); err == nil {
+		t.Error("Expected an error for an unknown backend")
+	}
+}
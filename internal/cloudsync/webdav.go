@@ -0,0 +1,211 @@
+package cloudsync
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+// WebDAV is a Backend storing the files in a WebDAV folder.
+type WebDAV struct {
+	client   *http.Client
+	base     *url.URL
+	username string
+	password string
+	// created holds the folders known to exist, so they aren't created for every file
+	created map[string]bool
+}
+
+// NewWebDAV returns a backend storing the files in the WebDAV folder at
+// rawURL, logging in with username and password if username isn't empty. A
+// nil client means a client with a 30 second timeout.
+func NewWebDAV(client *http.Client, rawURL, username, password string) (*WebDAV, error) {
+	base, err := url.Parse(strings.TrimSuffix(rawURL, "/"))
+	if err != nil || (base.Scheme != "http" && base.Scheme != "https") {
+		return nil, fmt.Errorf("invalid WebDAV url %q", rawURL)
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &WebDAV{client: client, base: base, username: username, password: password, created: make(map[string]bool)}, nil
+}
+
+// davMultistatus is the reply to PROPFIND.
+type davMultistatus struct {
+	Responses []struct {
+		Href         string    `xml:"href"`
+		LastModified string    `xml:"propstat>prop>getlastmodified"`
+		Collection   *struct{} `xml:"propstat>prop>resourcetype>collection"`
+	} `xml:"response"`
+}
+
+// davPropfind asks PROPFIND for what List needs.
+const davPropfind = `<?xml version="1.0" encoding="utf-8"?>
+<propfind xmlns="DAV:"><prop><getlastmodified/><resourcetype/></prop></propfind>`
+
+// List returns every file in the folder and its subfolders.
+func (w *WebDAV) List(ctx context.Context) ([]RemoteFile, error) {
+	var files []RemoteFile
+	folders := []string{""}
+	for len(folders) > 0 {
+		folder := folders[0]
+		folders = folders[1:]
+
+		resp, err := w.do(ctx, "PROPFIND", folder+"/", strings.NewReader(davPropfind), map[string]string{
+			"Depth":        "1",
+			"Content-Type": "application/xml",
+		})
+		if err != nil {
+			return nil, err
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusNotFound && folder == "" {
+			// Nothing was synced yet
+			return nil, nil
+		}
+		if resp.StatusCode != http.StatusMultiStatus {
+			return nil, fmt.Errorf("listing %s/: %s", folder, resp.Status)
+		}
+
+		var ms davMultistatus
+		if err := xml.Unmarshal(body, &ms); err != nil {
+			return nil, fmt.Errorf("listing %s/: %w", folder, err)
+		}
+		for _, r := range ms.Responses {
+			name, ok := w.name(r.Href)
+			if !ok || name == folder {
+				continue
+			}
+			if r.Collection != nil {
+				folders = append(folders, name)
+				continue
+			}
+			modTime, err := http.ParseTime(r.LastModified)
+			if err != nil {
+				return nil, fmt.Errorf("listing %s/: invalid modification time of %s", folder, name)
+			}
+			files = append(files, RemoteFile{Name: name, ModTime: modTime})
+		}
+	}
+	return files, nil
+}
+
+// name returns the name, relative to the synced folder, of the file at href, a
+// URL or an absolute path. It reports false if the file isn't in the folder.
+func (w *WebDAV) name(href string) (string, bool) {
+	u, err := url.Parse(href)
+	if err != nil {
+		return "", false
+	}
+	p := strings.TrimSuffix(u.Path, "/")
+	base := strings.TrimSuffix(w.base.Path, "/")
+	if p == base {
+		return "", true
+	}
+	rel, ok := strings.CutPrefix(p, base+"/")
+	return rel, ok
+}
+
+// Get returns the contents of the named file.
+func (w *WebDAV) Get(ctx context.Context, name string) ([]byte, error) {
+	resp, err := w.do(ctx, http.MethodGet, name, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading %s: %s", name, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// Put stores data as the named file, creating its folders first.
+func (w *WebDAV) Put(ctx context.Context, name string, data []byte) error {
+	if err := w.mkdirAll(ctx, path.Dir(name)); err != nil {
+		return err
+	}
+	resp, err := w.do(ctx, http.MethodPut, name, bytes.NewReader(data), nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("uploading %s: %s", name, resp.Status)
+	}
+	return nil
+}
+
+// Delete removes the named file.
+func (w *WebDAV) Delete(ctx context.Context, name string) error {
+	resp, err := w.do(ctx, http.MethodDelete, name, nil, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound && (resp.StatusCode < 200 || resp.StatusCode > 299) {
+		return fmt.Errorf("deleting %s: %s", name, resp.Status)
+	}
+	return nil
+}
+
+// mkdirAll creates the folder dir, relative to the synced folder, and the
+// folders above it, the synced folder included.
+func (w *WebDAV) mkdirAll(ctx context.Context, dir string) error {
+	folders := []string{""}
+	if dir != "." {
+		parts := strings.Split(dir, "/")
+		for i := range parts {
+			folders = append(folders, strings.Join(parts[:i+1], "/"))
+		}
+	}
+	for _, folder := range folders {
+		if w.created[folder] {
+			continue
+		}
+		resp, err := w.do(ctx, "MKCOL", folder+"/", nil, nil)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		// 405 Method Not Allowed means the folder exists already
+		if resp.StatusCode != http.StatusMethodNotAllowed && (resp.StatusCode < 200 || resp.StatusCode > 299) {
+			return fmt.Errorf("creating folder %s/: %s", folder, resp.Status)
+		}
+		w.created[folder] = true
+	}
+	return nil
+}
+
+// do sends a request for the file or folder name, relative to the synced folder.
+func (w *WebDAV) do(ctx context.Context, method, name string, body io.Reader, headers map[string]string) (*http.Response, error) {
+	u := *w.base
+	u.Path = w.base.Path + "/" + strings.TrimPrefix(name, "/")
+	u.RawPath = ""
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	if w.username != "" {
+		req.SetBasicAuth(w.username, w.password)
+	}
+	req.Header.Set("User-Agent", "TermChess-Sync")
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", method, u.Redacted(), err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,178 @@
+package cloudsync
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeDAV is a minimal WebDAV server keeping files and folders in memory.
+type fakeDAV struct {
+	mu      sync.Mutex
+	files   map[string][]byte
+	folders map[string]bool
+	mtime   time.Time
+}
+
+func newFakeDAV() *fakeDAV {
+	return &fakeDAV{
+		files:   make(map[string][]byte),
+		folders: map[string]bool{"/": true},
+		mtime:   time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func (d *fakeDAV) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if user, pass, ok := r.BasicAuth(); !ok || user != "alice" || pass != "secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	p := r.URL.Path
+	switch r.Method {
+	case "MKCOL":
+		if d.folders[p] {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		d.folders[p] = true
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodPut:
+		parent := p[:strings.LastIndex(p, "/")+1]
+		if !d.folders[parent] {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		d.files[p] = data
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodGet:
+		data, ok := d.files[p]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	case http.MethodDelete:
+		if _, ok := d.files[p]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(d.files, p)
+		w.WriteHeader(http.StatusNoContent)
+	case "PROPFIND":
+		if r.Header.Get("Depth") != "1" || !d.folders[p] {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var entries []string
+		entries = append(entries, fmt.Sprintf(`<D:response><D:href>%s</D:href><D:propstat><D:prop><D:resourcetype><D:collection/></D:resourcetype></D:prop></D:propstat></D:response>`, p))
+		var children []string
+		for name := range d.files {
+			children = append(children, name)
+		}
+		for name := range d.folders {
+			children = append(children, name)
+		}
+		sort.Strings(children)
+		for _, child := range children {
+			rest, ok := strings.CutPrefix(child, p)
+			if !ok || rest == "" || strings.Contains(strings.TrimSuffix(rest, "/"), "/") {
+				continue
+			}
+			if d.folders[child] {
+				entries = append(entries, fmt.Sprintf(`<D:response><D:href>%s</D:href><D:propstat><D:prop><D:resourcetype><D:collection/></D:resourcetype></D:prop></D:propstat></D:response>`, child))
+			} else {
+				entries = append(entries, fmt.Sprintf(`<D:response><D:href>%s</D:href><D:propstat><D:prop><D:getlastmodified>%s</D:getlastmodified><D:resourcetype/></D:prop></D:propstat></D:response>`,
+					child, d.mtime.Format(http.TimeFormat)))
+			}
+		}
+		w.WriteHeader(http.StatusMultiStatus)
+		fmt.Fprintf(w, `<?xml version="1.0"?><D:multistatus xmlns:D="DAV:">%s</D:multistatus>`, strings.Join(entries, ""))
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestWebDAV(t *testing.T) {
+	dav := newFakeDAV()
+	server := httptest.NewServer(dav)
+	defer server.Close()
+	ctx := context.Background()
+
+	backend, err := NewWebDAV(server.Client(), server.URL+"/remote.php/termchess/", "alice", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Nothing synced yet: the folder doesn't exist
+	if files, err := backend.List(ctx); err != nil || len(files) != 0 {
+		t.Fatalf("List() of a missing folder = %v, %v", files, err)
+	}
+
+	dav.folders["/remote.php/"] = true
+	if err := backend.Put(ctx, "data/games/game_1.pgn", []byte("1. e4 *")); err != nil {
+		t.Fatalf("Put() error: %v", err)
+	}
+	if err := backend.Put(ctx, "config/config.toml", []byte("version = 1")); err != nil {
+		t.Fatalf("Put() error: %v", err)
+	}
+
+	files, err := backend.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error: %v", err)
+	}
+	var names []string
+	for _, f := range files {
+		names = append(names, f.Name)
+		if !f.ModTime.Equal(dav.mtime) {
+			t.Errorf("ModTime of %s = %v, want %v", f.Name, f.ModTime, dav.mtime)
+		}
+	}
+	sort.Strings(names)
+	if got := strings.Join(names, ","); got != "config/config.toml,data/games/game_1.pgn" {
+		t.Errorf("List() = %s", got)
+	}
+
+	if data, err := backend.Get(ctx, "data/games/game_1.pgn"); err != nil || string(data) != "1. e4 *" {
+		t.Errorf("Get() = %q, %v", data, err)
+	}
+	if err := backend.Delete(ctx, "data/games/game_1.pgn"); err != nil {
+		t.Errorf("Delete() error: %v", err)
+	}
+	if err := backend.Delete(ctx, "data/games/game_1.pgn"); err != nil {
+		t.Errorf("Delete() of a missing file error: %v", err)
+	}
+	if _, err := backend.Get(ctx, "data/games/game_1.pgn"); err == nil {
+		t.Error("Expected an error getting a deleted file")
+	}
+}
+
+func TestWebDAVWrongPassword(t *testing.T) {
+	server := httptest.NewServer(newFakeDAV())
+	defer server.Close()
+
+	backend, err := NewWebDAV(server.Client(), server.URL+"/termchess", "alice", "wrong")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := backend.List(context.Background()); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("Expected an authorization error, got %v", err)
+	}
+}
+
+func TestNewWebDAVInvalidURL(t *testing.T) {
+	if _, err := NewWebDAV(nil, "ftp://example.com/dav", "", ""); err == nil {
+		t.Error("Expected an error for a non-HTTP url")
+	}
+}
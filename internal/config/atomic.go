@@ -0,0 +1,176 @@
+package config
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Config and save files are written so that a crash or a full disk in the
+// middle of a save can't leave them half written: the new contents go to a
+// temporary file that replaces the old one by renaming it, and the old
+// contents are kept next to it as path + ".bak" unless they are damaged. Files
+// only TermChess writes also end with a checksum line, so damage done later is
+// noticed on load, and isn't backed up over the last good contents. config.toml
+// is meant to be edited by hand and is checked by parsing instead.
+
+// backupSuffix is appended to a file's path to name its backup.
+const backupSuffix = ".bak"
+
+// checksumPrefix starts the line that ends a checksummed file. The hex SHA-256
+// of everything before the line follows it.
+const checksumPrefix = "# sha256:"
+
+// errChecksum is returned when a file's contents don't match its checksum.
+var errChecksum = errors.New("checksum mismatch, the file is damaged")
+
+// backupPath returns the path of the backup of the file at path.
+func backupPath(path string) string {
+	return path + backupSuffix
+}
+
+// writeFileAtomic replaces the file at path with data. The file is first
+// copied to its backup if verify accepts its contents, and data is written to
+// a temporary file in the same directory that is renamed over path once it is
+// complete, so path always holds either the old or the new contents. A file
+// verify rejects is damaged and is not backed up, so the backup keeps the last
+// good contents. A file that couldn't be decrypted isn't damaged and is.
+func writeFileAtomic(path string, data []byte, perm os.FileMode, verify func([]byte) error) error {
+	if old, err := os.ReadFile(path); err == nil {
+		if err := verify(old); err == nil || isPassphraseError(err) {
+			if err := replaceFile(backupPath(path), old, perm); err != nil {
+				return fmt.Errorf("failed to back up %s: %w", filepath.Base(path), err)
+			}
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	return replaceFile(path, data, perm)
+}
+
+// WriteFileAtomic replaces the file at path with data like writeFileAtomic,
+// for files written outside this package, such as those sync downloads. The
+// file is verified as far as it can be without knowing what it holds: it is
+// decoded with the active codec, its checksum is checked if it has one, and
+// TOML files are parsed.
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	parse := parseNothing
+	if filepath.Ext(path) == ".toml" {
+		parse = parseTOML
+	}
+	return writeFileAtomic(path, data, perm, decodeVerified(ActiveCodec(), true, parse))
+}
+
+// parseTOML returns an error if data isn't valid TOML. It verifies files that
+// are edited by hand and have no checksum.
+func parseTOML(data []byte) error {
+	var v map[string]any
+	_, err := toml.Decode(string(data), &v)
+	return err
+}
+
+// replaceFile writes data to a temporary file and renames it to path.
+func replaceFile(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	// Remove the temporary file unless it was renamed
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	// Make sure the contents are on disk before the rename makes them the file
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// withChecksum returns data followed by its checksum line.
+func withChecksum(data []byte) []byte {
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		data = append(data, '\n')
+	}
+	sum := sha256.Sum256(data)
+	return append(data, checksumPrefix+hex.EncodeToString(sum[:])+"\n"...)
+}
+
+// verifyChecksum returns data without its checksum line, or errChecksum if it
+// doesn't match. Files without a checksum line, written before they had one,
+// are returned as they are.
+func verifyChecksum(data []byte) ([]byte, error) {
+	trimmed := bytes.TrimRight(data, "\n")
+	i := bytes.LastIndexByte(trimmed, '\n') + 1
+	if !bytes.HasPrefix(trimmed[i:], []byte(checksumPrefix)) {
+		return data, nil
+	}
+	content := data[:i]
+	sum := sha256.Sum256(content)
+	if string(trimmed[i+len(checksumPrefix):]) != hex.EncodeToString(sum[:]) {
+		return nil, errChecksum
+	}
+	return content, nil
+}
+
+// readFileRecovering reads the file at path and passes its contents to parse.
+// If the file is damaged, i.e. its checksum doesn't match (when checked) or
+// parse fails, the backup is parsed instead and, if it is intact, restored. A
+// file that doesn't exist is not recovered: it was deleted, not damaged, and
+// neither is one that couldn't be decrypted. parse may be called twice, so it
+// should only keep what it parsed on success.
+func readFileRecovering(path string, checked bool, parse func([]byte) error) error {
+	load := func(path string) ([]byte, error) {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		data := raw
+		if checked {
+			if data, err = verifyChecksum(raw); err != nil {
+				return nil, err
+			}
+		}
+		return raw, parse(data)
+	}
+
+	_, err := load(path)
+	if err == nil || os.IsNotExist(err) || isPassphraseError(err) {
+		return err
+	}
+	backup, backupErr := load(backupPath(path))
+	if backupErr != nil {
+		return err
+	}
+	// Restore the backup, leaving the backup itself alone
+	if info, statErr := os.Stat(path); statErr == nil {
+		_ = replaceFile(path, backup, info.Mode().Perm())
+	}
+	return nil
+}
+
+// removeWithBackup deletes the file at path and its backup. Files that don't
+// exist are not an error.
+func removeWithBackup(path string) error {
+	for _, p := range []string{path, backupPath(path)} {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
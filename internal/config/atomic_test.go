@@ -0,0 +1,189 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+func TestWriteFileAtomicKeepsBackup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file.toml")
+	if err := writeFileAtomic(path, []byte("first"), 0644, parseNothing); err != nil {
+		t.Fatalf("writeFileAtomic() error = %v", err)
+	}
+	if _, err := os.Stat(backupPath(path)); !os.IsNotExist(err) {
+		t.Errorf("Expected no backup of a new file, stat error = %v", err)
+	}
+	if err := writeFileAtomic(path, []byte("second"), 0644, parseNothing); err != nil {
+		t.Fatalf("writeFileAtomic() error = %v", err)
+	}
+
+	if data, _ := os.ReadFile(path); string(data) != "second" {
+		t.Errorf("File = %q, want second", data)
+	}
+	if data, _ := os.ReadFile(backupPath(path)); string(data) != "first" {
+		t.Errorf("Backup = %q, want first", data)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0644 {
+		t.Errorf("File mode = %v, %v; want 0644", info.Mode().Perm(), err)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 2 {
+		t.Errorf("Expected only the file and its backup, got %d entries", len(entries))
+	}
+}
+
+func TestWriteFileAtomicKeepsGoodBackup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := writeFileAtomic(path, []byte("theme = \"modern\"\n"), 0644, parseTOML); err != nil {
+		t.Fatalf("writeFileAtomic() error = %v", err)
+	}
+	if err := writeFileAtomic(path, []byte("theme = \"classic\"\n"), 0644, parseTOML); err != nil {
+		t.Fatalf("writeFileAtomic() error = %v", err)
+	}
+
+	// A damaged file isn't backed up over the good backup
+	if err := os.WriteFile(path, []byte("[display\ntheme = "), 0644); err != nil {
+		t.Fatalf("Failed to damage the file: %v", err)
+	}
+	if err := writeFileAtomic(path, []byte("theme = \"minimalist\"\n"), 0644, parseTOML); err != nil {
+		t.Fatalf("writeFileAtomic() error = %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "theme = \"minimalist\"\n" {
+		t.Errorf("File = %q, want the new contents", data)
+	}
+	if data, _ := os.ReadFile(backupPath(path)); string(data) != "theme = \"modern\"\n" {
+		t.Errorf("Backup = %q, want the last good contents", data)
+	}
+}
+
+func TestSaveGameNotesKeepsGoodBackup(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	for _, note := range []string{"Castle early", "Develop knights"} {
+		if err := SaveGameNotes([]string{note}); err != nil {
+			t.Fatalf("SaveGameNotes failed: %v", err)
+		}
+	}
+	path, _ := SaveGameNotesPath()
+	if err := os.WriteFile(path, []byte("Castle never\n"+checksumPrefix+"0000\n"), 0644); err != nil {
+		t.Fatalf("Failed to damage the notes: %v", err)
+	}
+	if err := SaveGameNotes([]string{"Push the h-pawn"}); err != nil {
+		t.Fatalf("SaveGameNotes failed: %v", err)
+	}
+	if data, _ := os.ReadFile(backupPath(path)); !strings.HasPrefix(string(data), "Castle early\n") {
+		t.Errorf("Backup = %q, want the last good notes", data)
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	data := withChecksum([]byte("game_type = \"pvp\"\n"))
+	if !strings.Contains(string(data), "\n"+checksumPrefix) {
+		t.Fatalf("Expected a checksum line, got %q", data)
+	}
+	content, err := verifyChecksum(data)
+	if err != nil || string(content) != "game_type = \"pvp\"\n" {
+		t.Errorf("verifyChecksum() = %q, %v; want the contents", content, err)
+	}
+
+	damaged := strings.Replace(string(data), "pvp", "pvb", 1)
+	if _, err := verifyChecksum([]byte(damaged)); err != errChecksum {
+		t.Errorf("verifyChecksum() error = %v for damaged contents, want errChecksum", err)
+	}
+	// Files written before they had checksums are read as they are
+	if content, err := verifyChecksum([]byte("a\nb\n")); err != nil || string(content) != "a\nb\n" {
+		t.Errorf("verifyChecksum() = %q, %v; want the file unchanged", content, err)
+	}
+}
+
+func TestLoadGameRecoversFromBackup(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	first := engine.NewBoard()
+	if err := SaveGame(first); err != nil {
+		t.Fatalf("SaveGame failed: %v", err)
+	}
+	second := engine.NewBoard()
+	move, _ := engine.ParseMove("e2e4")
+	_ = second.MakeMove(move)
+	if err := SaveGame(second); err != nil {
+		t.Fatalf("SaveGame failed: %v", err)
+	}
+
+	// A damaged save falls back to the previous one and restores it
+	path, _ := SaveGamePath()
+	if err := os.WriteFile(path, []byte("rnbqkbnr/pppp"), 0644); err != nil {
+		t.Fatalf("Failed to damage the save: %v", err)
+	}
+	board, err := LoadGame()
+	if err != nil || board.ToFEN() != first.ToFEN() {
+		t.Fatalf("LoadGame() = %v, %v; want the backed up position", board, err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != first.ToFEN() {
+		t.Errorf("Expected the backup to be restored, file = %q", data)
+	}
+
+	if err := DeleteSaveGame(); err != nil {
+		t.Fatalf("DeleteSaveGame failed: %v", err)
+	}
+	if _, err := os.Stat(path + backupSuffix); !os.IsNotExist(err) {
+		t.Errorf("Expected DeleteSaveGame to delete the backup, stat error = %v", err)
+	}
+	if _, err := LoadGame(); err == nil {
+		t.Error("Expected a deleted game not to be recovered")
+	}
+}
+
+func TestLoadGameNotesDetectsDamage(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := SaveGameNotes([]string{"Castle early"}); err != nil {
+		t.Fatalf("SaveGameNotes failed: %v", err)
+	}
+	path, _ := SaveGameNotesPath()
+	data, _ := os.ReadFile(path)
+	if err := os.WriteFile(path, []byte(strings.Replace(string(data), "early", "never", 1)), 0644); err != nil {
+		t.Fatalf("Failed to damage the notes: %v", err)
+	}
+
+	// Without a backup the damage is reported
+	if _, err := LoadGameNotes(); err == nil || !strings.Contains(err.Error(), "checksum") {
+		t.Errorf("LoadGameNotes() error = %v, want a checksum mismatch", err)
+	}
+
+	// With one, the notes are recovered
+	if err := SaveGameNotes([]string{"Castle early"}); err != nil {
+		t.Fatalf("SaveGameNotes failed: %v", err)
+	}
+	if err := SaveGameNotes([]string{"Push the h-pawn"}); err != nil {
+		t.Fatalf("SaveGameNotes failed: %v", err)
+	}
+	if err := os.WriteFile(path, []byte("Push the h-pawn\n"+checksumPrefix+"0000\n"), 0644); err != nil {
+		t.Fatalf("Failed to damage the notes: %v", err)
+	}
+	notes, err := LoadGameNotes()
+	if err != nil || len(notes) != 1 || notes[0] != "Castle early" {
+		t.Errorf("LoadGameNotes() = %q, %v; want the backed up note", notes, err)
+	}
+}
+
+func TestLoadConfigRecoversFromBackup(t *testing.T) {
+	configPath := writeConfigFile(t, "")
+	cfg := DefaultConfig()
+	cfg.Theme = "modern"
+	if err := SaveConfig(cfg); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+	cfg.Theme = "minimalist"
+	if err := SaveConfig(cfg); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+
+	if err := os.WriteFile(configPath, []byte("[display\ntheme = "), 0644); err != nil {
+		t.Fatalf("Failed to damage the config: %v", err)
+	}
+	if got := LoadConfig().Theme; got != "modern" {
+		t.Errorf("Loaded theme %q, want modern from the backup", got)
+	}
+}
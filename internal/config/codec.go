@@ -0,0 +1,353 @@
+package config
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// The saved game, the FEN history and the game history go through a Codec on
+// their way to and from the disk, so they can be stored encrypted on shared
+// machines. The codec in use is set with SetCodec; by default files are
+// stored as they are. Settings and other files stay readable.
+
+// Codec encodes the contents of files as they are written and decodes them as
+// they are read.
+type Codec interface {
+	// Encode returns data as it is stored on disk.
+	Encode(data []byte) ([]byte, error)
+	// Decode returns the contents of a file written with Encode.
+	Decode(data []byte) ([]byte, error)
+}
+
+// ErrPassphraseRequired is returned when reading an encrypted file without
+// the codec of a passphrase.
+var ErrPassphraseRequired = errors.New("the file is encrypted, enter the passphrase to read it")
+
+// ErrWrongPassphrase is returned when an encrypted file can't be decrypted with
+// the passphrase given.
+var ErrWrongPassphrase = errors.New("wrong passphrase")
+
+// encryptedMagic starts every encrypted file. It is followed by the salt the
+// key was derived with, the nonce and the sealed contents.
+var encryptedMagic = []byte("TERMCHESS-ENCRYPTED-1\n")
+
+const (
+	// saltSize is the size of the salt keys are derived with.
+	saltSize = 16
+	// pbkdf2Iterations is how many rounds of PBKDF2-SHA256 turn a passphrase into a key.
+	pbkdf2Iterations = 600000
+)
+
+var (
+	// codecMu guards activeCodec, set from the UI while commands read and
+	// write files on other goroutines
+	codecMu sync.RWMutex
+	// activeCodec encodes the files written through the codec
+	activeCodec Codec = PlainCodec{}
+)
+
+// SetCodec makes c the codec files are encoded with. nil stores them as they are.
+func SetCodec(c Codec) {
+	if c == nil {
+		c = PlainCodec{}
+	}
+	codecMu.Lock()
+	defer codecMu.Unlock()
+	activeCodec = c
+}
+
+// ActiveCodec returns the codec files are encoded with.
+func ActiveCodec() Codec {
+	codecMu.RLock()
+	defer codecMu.RUnlock()
+	return activeCodec
+}
+
+// IsEncrypted reports whether data is the contents of an encrypted file.
+func IsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, encryptedMagic)
+}
+
+// PlainCodec stores files as they are. It can't read encrypted files.
+type PlainCodec struct{}
+
+// Encode returns data unchanged.
+func (PlainCodec) Encode(data []byte) ([]byte, error) {
+	return data, nil
+}
+
+// Decode returns data unchanged, or ErrPassphraseRequired if it is encrypted.
+func (PlainCodec) Decode(data []byte) ([]byte, error) {
+	if IsEncrypted(data) {
+		return nil, ErrPassphraseRequired
+	}
+	return data, nil
+}
+
+// passphraseCodec encrypts files with AES-256-GCM under a key derived from a
+// passphrase with PBKDF2. Every file records the salt of its key, so files
+// written with the same passphrase on other machines can be read too.
+type passphraseCodec struct {
+	passphrase string
+	// salt is the salt of the key new files are encrypted with
+	salt []byte
+
+	mu sync.Mutex
+	// keys caches the keys derived for each salt, deriving them being slow on purpose
+	keys map[string]cipher.AEAD
+}
+
+// NewPassphraseCodec returns a codec encrypting files with passphrase. Files
+// that aren't encrypted, written before encryption was turned on, are read as
+// they are.
+func NewPassphraseCodec(passphrase string) (Codec, error) {
+	if passphrase == "" {
+		return nil, errors.New("passphrase is empty")
+	}
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	c := &passphraseCodec{passphrase: passphrase, salt: salt, keys: make(map[string]cipher.AEAD)}
+	if _, err := c.aead(salt); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// aead returns the cipher of the key derived with salt.
+func (c *passphraseCodec) aead(salt []byte) (cipher.AEAD, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if aead, ok := c.keys[string(salt)]; ok {
+		return aead, nil
+	}
+	key, err := pbkdf2.Key(sha256.New, c.passphrase, salt, pbkdf2Iterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	c.keys[string(salt)] = aead
+	return aead, nil
+}
+
+// Encode encrypts data. The header is authenticated along with the contents.
+func (c *passphraseCodec) Encode(data []byte) ([]byte, error) {
+	aead, err := c.aead(c.salt)
+	if err != nil {
+		return nil, err
+	}
+	header := append(append([]byte(nil), encryptedMagic...), c.salt...)
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	out := append(header, nonce...)
+	return aead.Seal(out, nonce, data, header), nil
+}
+
+// Decode decrypts data, or returns it as it is if it isn't encrypted.
+func (c *passphraseCodec) Decode(data []byte) ([]byte, error) {
+	if !IsEncrypted(data) {
+		return data, nil
+	}
+	headerSize := len(encryptedMagic) + saltSize
+	if len(data) < headerSize {
+		return nil, errChecksum
+	}
+	header, rest := data[:headerSize], data[headerSize:]
+	aead, err := c.aead(header[len(encryptedMagic):])
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) < aead.NonceSize() {
+		return nil, errChecksum
+	}
+	plain, err := aead.Open(nil, rest[:aead.NonceSize()], rest[aead.NonceSize():], header)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return plain, nil
+}
+
+// isPassphraseError reports whether err is about the passphrase rather than
+// the file, which isn't damaged then.
+func isPassphraseError(err error) bool {
+	return errors.Is(err, ErrPassphraseRequired) || errors.Is(err, ErrWrongPassphrase)
+}
+
+// WriteEncodedFile replaces the file at path with data, encoded with the
+// active codec, atomically and keeping the old contents as a backup like the
+// files of this package.
+func WriteEncodedFile(path string, data []byte, perm os.FileMode) error {
+	return writeEncodedAtomic(path, data, perm, false, parseNothing)
+}
+
+// ReadEncodedFile returns the contents of the file at path, decoded with the active codec.
+func ReadEncodedFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ActiveCodec().Decode(data)
+}
+
+// writeEncodedAtomic is writeFileAtomic for files encoded with the active
+// codec, verifying the file before it is backed up like readEncodedRecovering
+// does. When an unencrypted file is first encrypted, the old contents are
+// encrypted too before they become the backup, so they don't stay readable.
+func writeEncodedAtomic(path string, data []byte, perm os.FileMode, checked bool, parse func([]byte) error) error {
+	codec := ActiveCodec()
+	encoded, err := codec.Encode(data)
+	if err != nil {
+		return err
+	}
+	if IsEncrypted(encoded) {
+		if old, err := os.ReadFile(path); err == nil && !IsEncrypted(old) {
+			if old, err = codec.Encode(old); err != nil {
+				return err
+			}
+			if err := replaceFile(path, old, perm); err != nil {
+				return err
+			}
+		}
+	}
+	return writeFileAtomic(path, encoded, perm, decodeVerified(codec, checked, parse))
+}
+
+// readEncodedRecovering is readFileRecovering for files encoded with the
+// active codec: the contents are decoded before their checksum, if checked, is
+// verified and they are parsed. A file that can't be decrypted isn't damaged,
+// so it isn't replaced by its backup.
+func readEncodedRecovering(path string, checked bool, parse func([]byte) error) error {
+	return readFileRecovering(path, false, decodeVerified(ActiveCodec(), checked, parse))
+}
+
+// decodeVerified returns a function that decodes a file's contents with codec,
+// verifies their checksum if checked, and parses them.
+func decodeVerified(codec Codec, checked bool, parse func([]byte) error) func([]byte) error {
+	return func(raw []byte) error {
+		data, err := codec.Decode(raw)
+		if err != nil {
+			return err
+		}
+		if checked {
+			if data, err = verifyChecksum(data); err != nil {
+				return err
+			}
+		}
+		return parse(data)
+	}
+}
+
+// encodedFilePaths returns the paths of the files written through the codec
+// that exist, with their backups: the saved game, the FEN history and the
+// games of the game history.
+func encodedFilePaths() ([]string, error) {
+	var paths []string
+	for _, get := range []func() (string, error){SaveGamePath, SaveGameInfoPath, SaveGameNotesPath, FENHistoryPath} {
+		path, err := get()
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, path, backupPath(path))
+	}
+	dataDir, err := GetDataDir()
+	if err != nil {
+		return nil, err
+	}
+	games, err := filepath.Glob(filepath.Join(dataDir, "games", "*.pgn"))
+	if err != nil {
+		return nil, err
+	}
+	for _, game := range games {
+		paths = append(paths, game, backupPath(game))
+	}
+
+	existing := paths[:0]
+	for _, path := range paths {
+		if _, err := os.Stat(path); err == nil {
+			existing = append(existing, path)
+		}
+	}
+	return existing, nil
+}
+
+// EncryptedFilesExist reports whether any of the saved game, the FEN history
+// and the game history is stored encrypted.
+func EncryptedFilesExist() bool {
+	paths, err := encodedFilePaths()
+	if err != nil {
+		return false
+	}
+	for _, path := range paths {
+		if data, err := os.ReadFile(path); err == nil && IsEncrypted(data) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckCodec returns ErrWrongPassphrase if c can't decrypt the encrypted
+// files, i.e. it was made with another passphrase than theirs.
+func CheckCodec(c Codec) error {
+	paths, err := encodedFilePaths()
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		if data, err := os.ReadFile(path); err == nil && IsEncrypted(data) {
+			_, err := c.Decode(data)
+			return err
+		}
+	}
+	return nil
+}
+
+// RecodeFiles rewrites the saved game, the FEN history and the game history,
+// decoding them with from and encoding them with to, e.g. to encrypt them when
+// encryption is turned on. Backups are rewritten too rather than kept.
+func RecodeFiles(from, to Codec) error {
+	paths, err := encodedFilePaths()
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err == nil {
+			data, err = from.Decode(data)
+		}
+		if err == nil {
+			data, err = to.Encode(data)
+		}
+		if err == nil {
+			err = replaceFile(path, data, info.Mode().Perm())
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", filepath.Base(path), err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,171 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// useCodec makes c the active codec for the rest of the test.
+func useCodec(t *testing.T, c Codec) {
+	t.Helper()
+	SetCodec(c)
+	t.Cleanup(func() { SetCodec(nil) })
+}
+
+func TestPassphraseCodec(t *testing.T) {
+	codec, err := NewPassphraseCodec("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain := []byte("rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1")
+
+	encoded, err := codec.Encode(plain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsEncrypted(encoded) || bytes.Contains(encoded, []byte("rnbqkbnr")) {
+		t.Fatalf("Expected the contents encrypted, got %q", encoded)
+	}
+	if decoded, err := codec.Decode(encoded); err != nil || !bytes.Equal(decoded, plain) {
+		t.Errorf("Decode() = %q, %v", decoded, err)
+	}
+
+	// Another codec of the same passphrase, e.g. on another machine, reads it
+	other, _ := NewPassphraseCodec("correct horse")
+	if decoded, err := other.Decode(encoded); err != nil || !bytes.Equal(decoded, plain) {
+		t.Errorf("Decode() with another codec of the passphrase = %q, %v", decoded, err)
+	}
+
+	wrong, _ := NewPassphraseCodec("wrong horse")
+	if _, err := wrong.Decode(encoded); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("Decode() with the wrong passphrase error = %v, want ErrWrongPassphrase", err)
+	}
+	if _, err := (PlainCodec{}).Decode(encoded); !errors.Is(err, ErrPassphraseRequired) {
+		t.Errorf("PlainCodec.Decode() of an encrypted file error = %v, want ErrPassphraseRequired", err)
+	}
+	if decoded, err := codec.Decode(plain); err != nil || !bytes.Equal(decoded, plain) {
+		t.Errorf("Decode() of an unencrypted file = %q, %v, want it unchanged", decoded, err)
+	}
+
+	if _, err := NewPassphraseCodec(""); err == nil {
+		t.Error("Expected an error for an empty passphrase")
+	}
+}
+
+func TestEncryptedSaveGame(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_DATA_HOME", "")
+
+	// A game saved before encryption was turned on
+	if err := SaveGame(engine.NewBoard()); err != nil {
+		t.Fatal(err)
+	}
+	codec, err := NewPassphraseCodec("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	useCodec(t, codec)
+	if _, err := LoadGame(); err != nil {
+		t.Fatalf("LoadGame() of an unencrypted save error: %v", err)
+	}
+	if EncryptedFilesExist() {
+		t.Error("Expected no encrypted files yet")
+	}
+
+	if err := SaveGame(engine.NewBoard()); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveGameNotes([]string{"Prepared line"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddFENHistory("8/8/8/8/8/8/8/K6k w - - 0 1"); err != nil {
+		t.Fatal(err)
+	}
+	savePath, _ := SaveGamePath()
+	for _, path := range []string{savePath, backupPath(savePath)} {
+		if data, _ := os.ReadFile(path); !IsEncrypted(data) {
+			t.Errorf("Expected %s encrypted, got %q", filepath.Base(path), data)
+		}
+	}
+	if !EncryptedFilesExist() {
+		t.Error("Expected encrypted files")
+	}
+	if notes, err := LoadGameNotes(); err != nil || len(notes) != 1 {
+		t.Errorf("LoadGameNotes() = %q, %v", notes, err)
+	}
+	if fens, err := LoadFENHistory(); err != nil || len(fens) != 1 {
+		t.Errorf("LoadFENHistory() = %q, %v", fens, err)
+	}
+
+	// Without the passphrase, the files can't be read and aren't replaced by their backups
+	SetCodec(nil)
+	if _, err := LoadGame(); !errors.Is(err, ErrPassphraseRequired) {
+		t.Errorf("LoadGame() without a passphrase error = %v, want ErrPassphraseRequired", err)
+	}
+	wrong, _ := NewPassphraseCodec("guess")
+	if err := CheckCodec(wrong); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("CheckCodec() with the wrong passphrase = %v", err)
+	}
+	if err := CheckCodec(codec); err != nil {
+		t.Errorf("CheckCodec() error: %v", err)
+	}
+
+	// Turning encryption off decrypts everything
+	if err := RecodeFiles(codec, PlainCodec{}); err != nil {
+		t.Fatalf("RecodeFiles() error: %v", err)
+	}
+	if EncryptedFilesExist() {
+		t.Error("Expected no encrypted files after decrypting them")
+	}
+	if data, _ := os.ReadFile(savePath); !strings.HasPrefix(string(data), "rnbqkbnr") {
+		t.Errorf("Expected the saved game decrypted, got %q", data)
+	}
+	if _, err := LoadGame(); err != nil {
+		t.Errorf("LoadGame() after decrypting error: %v", err)
+	}
+}
+
+func TestWriteEncodedFileKeepsBackup(t *testing.T) {
+	codec, err := NewPassphraseCodec("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	useCodec(t, codec)
+	path := filepath.Join(t.TempDir(), "game.pgn")
+	for _, data := range []string{"1. e4 e5", "1. d4 d5"} {
+		if err := WriteEncodedFile(path, []byte(data), 0644); err != nil {
+			t.Fatalf("WriteEncodedFile() error: %v", err)
+		}
+	}
+
+	if data, err := ReadEncodedFile(path); err != nil || string(data) != "1. d4 d5" {
+		t.Errorf("ReadEncodedFile() = %q, %v; want the new contents", data, err)
+	}
+	backup, _ := os.ReadFile(backupPath(path))
+	if data, err := codec.Decode(backup); !IsEncrypted(backup) || err != nil || string(data) != "1. e4 e5" {
+		t.Errorf("Backup = %q, %v; want the old contents encrypted", data, err)
+	}
+}
+
+func TestSetCodecConcurrently(t *testing.T) {
+	t.Cleanup(func() { SetCodec(nil) })
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			SetCodec(PlainCodec{})
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		if _, err := ActiveCodec().Encode([]byte("e4")); err != nil {
+			t.Fatalf("Encode() error: %v", err)
+		}
+	}
+	<-done
+}
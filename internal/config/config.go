@@ -1,7 +1,9 @@
 // Package config provides configuration and game state persistence for TermChess.
 //
-// Configuration files are stored in ~/.termchess/ and use TOML format.
-// Game saves are stored as FEN strings in ~/.termchess/savegame.fen.
+// Configuration files are stored in the config directory (see GetConfigDir) and
+// use TOML format. Game saves are stored in the data directory (see GetDataDir)
+// as FEN strings in savegame.fen, with the game type, the user's color and the
+// bot in savegame.toml.
 //
 // The package provides:
 //   - Config types and default values
@@ -15,8 +17,11 @@
 package config
 
 import (
+	"bytes"
 	"fmt"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/BurntSushi/toml"
 )
@@ -27,20 +32,159 @@ import (
 // Invalid theme values will be normalized to DefaultTheme by ui.ParseThemeName.
 const DefaultTheme = "classic"
 
+// DefaultCoordinateStyle is the default placement of the board coordinates.
+// Valid values are "outside" (around the board) and "inside" (on the edge
+// squares). These must match the ui.CoordsX constants.
+const DefaultCoordinateStyle = "outside"
+
+// DefaultNotation is the default notation of the move history and hints.
+// Valid values are "san", "long" and "coordinate". These must match the
+// ui.NotationX constants.
+const DefaultNotation = "san"
+
+// DefaultLanguage is the default language of the piece letters in moves.
+// Valid values are "en", "de", "fr" and "es". These must match the
+// ui.LanguageX constants.
+const DefaultLanguage = "en"
+
+// DefaultBotResignThreshold is how far behind, in pawns, a bot must evaluate itself to resign.
+// A threshold of 0 means bots never resign.
+const DefaultBotResignThreshold = 10.0
+
+// DefaultBotDifficulty is the difficulty preselected in the Player vs Bot menu.
+const DefaultBotDifficulty = "medium"
+
+// Update check frequencies: how often TermChess looks for a newer release when
+// it starts.
+const (
+	// UpdateCheckStartup checks every time TermChess starts.
+	UpdateCheckStartup = "startup"
+	// UpdateCheckDaily checks at most once a day.
+	UpdateCheckDaily = "daily"
+	// UpdateCheckWeekly checks at most once a week.
+	UpdateCheckWeekly = "weekly"
+	// UpdateCheckOff never checks; updates are only looked for when asked to.
+	UpdateCheckOff = "off"
+)
+
+// DefaultUpdateCheck is the default frequency of update checks.
+const DefaultUpdateCheck = UpdateCheckDaily
+
+// Coach verbosities: which of the user's moves the coach comments on in Player
+// vs Bot games.
+const (
+	// CoachOff turns the coach off.
+	CoachOff = "off"
+	// CoachMistakes comments on inaccuracies, mistakes and blunders only.
+	CoachMistakes = "mistakes"
+	// CoachAll comments on every move.
+	CoachAll = "all"
+)
+
+// DefaultCoach is the default coach verbosity.
+const DefaultCoach = CoachOff
+
+// Sync backends: where the config, saved game and game history are synced to.
+const (
+	// SyncBackendS3 syncs to a folder of an S3-compatible bucket.
+	SyncBackendS3 = "s3"
+	// SyncBackendWebDAV syncs to a WebDAV folder.
+	SyncBackendWebDAV = "webdav"
+)
+
 // Config holds display configuration options that control how the UI is rendered.
 type Config struct {
 	// UseUnicode determines whether to use Unicode chess pieces (♔♕) or ASCII (K, Q)
 	UseUnicode bool
 	// ShowCoords determines whether to show file/rank labels (a-h, 1-8)
 	ShowCoords bool
+	// CoordinateStyle places the coordinates around the board ("outside") or on its edge squares ("inside")
+	CoordinateStyle string
 	// UseColors determines whether to color piece symbols
 	UseColors bool
 	// ShowMoveHistory determines whether to display the move history panel
 	ShowMoveHistory bool
 	// ShowHelpText determines whether to display navigation help text at the bottom of screens
 	ShowHelpText bool
+	// Notation is how moves are written in the move history and hints: "san", "long" or "coordinate"
+	Notation string
+	// FigurineNotation writes pieces as Unicode symbols (♘f3) instead of letters (Nf3)
+	FigurineNotation bool
+	// Language picks the piece letters moves are typed and written with, e.g. "de" for Sf3 instead of Nf3
+	Language string
+	// TerminalProgress reports Bot vs Bot progress to the terminal with OSC 9;4, which
+	// terminals such as ConEmu, Windows Terminal and WezTerm show as a progress bar
+	TerminalProgress bool
 	// Theme is the name of the color theme to use (e.g., "classic")
 	Theme string
+	// BotResignThreshold is the evaluated deficit in pawns at which bots resign (0 disables)
+	BotResignThreshold float64
+	// BotDifficulty is the difficulty preselected in the Player vs Bot menu: "easy",
+	// "medium" or "hard". Empty preselects the first one.
+	BotDifficulty string
+	// BotMoveTime, if positive, is how long the built-in bots may think per move,
+	// overriding the budget of each difficulty.
+	BotMoveTime time.Duration
+	// TurnNotifications sends a desktop notification when the bot has moved and it's the user's turn
+	TurnNotifications bool
+	// HotSeatPrivacy hides the board between moves of a Player vs Player game until the next player is ready
+	HotSeatPrivacy bool
+	// EncryptSaves stores the saved game, the FEN history and the game history
+	// encrypted with a passphrase asked for at startup
+	EncryptSaves bool
+	// Coach is which of the user's moves in Player vs Bot games get a comment
+	// from a quick engine check: "off", "mistakes" or "all"
+	Coach string
+	// KeyBindings maps action names to the keys bound to them, overriding the defaults.
+	// Actions that are not listed keep their default keys.
+	KeyBindings map[string][]string
+	// PlayerName identifies the user in correspondence games. Empty means the OS user name.
+	PlayerName string
+	// MailboxDir is the directory correspondence games are stored in. Empty means
+	// correspondence in the data directory; point it at a shared folder to play with others.
+	MailboxDir string
+	// SPRTElo0, SPRTElo1, SPRTAlpha and SPRTBeta configure the Bot vs Bot SPRT test.
+	// A zero alpha or beta, or an elo1 not above elo0, means the built-in default.
+	SPRTElo0  float64
+	SPRTElo1  float64
+	SPRTAlpha float64
+	SPRTBeta  float64
+	// BvBStreamFile, if set, is the file every Bot vs Bot game is appended to as it is
+	// played: JSONL for a .jsonl or .ndjson file, PGN otherwise.
+	BvBStreamFile string
+	// ExternalBots are programs that play through the JSON line protocol or UCI and
+	// are listed in the bot menus next to the built-in difficulties.
+	ExternalBots []ExternalBot
+	// SyzygyPath lists the directories of Syzygy endgame tablebases, separated like
+	// PATH. It is passed to UCI bots, which then play those endgames perfectly.
+	SyzygyPath string
+	// UpdateCheck is how often TermChess looks for a newer release on startup:
+	// "startup", "daily", "weekly" or "off"
+	UpdateCheck string
+	// SyncBackend is where files are synced to: "s3", "webdav", or empty for no sync
+	SyncBackend string
+	// SyncURL is the folder files are synced to: a WebDAV folder, or an S3 endpoint
+	// followed by the bucket and an optional folder in it
+	SyncURL string
+	// SyncRegion is the region of the S3 bucket. Empty means us-east-1.
+	SyncRegion string
+	// SyncUsername is the WebDAV user. Passwords and S3 keys are never kept in
+	// the config file, see LoadSyncCredentials.
+	SyncUsername string
+	// SyncAuto syncs when TermChess starts and when it exits
+	SyncAuto bool
+}
+
+// ExternalBot describes an external bot program.
+type ExternalBot struct {
+	// Name is shown in the bot menus.
+	Name string `toml:"name"`
+	// Command is the executable to run, e.g. "python3".
+	Command string `toml:"command"`
+	// Args are passed to the command, e.g. ["/path/to/bot.py"].
+	Args []string `toml:"args,omitempty"`
+	// Protocol is how the program plays: "json" (the default) or "uci".
+	Protocol string `toml:"protocol,omitempty"`
 }
 
 // DefaultConfig returns a Config with default values for maximum compatibility
@@ -53,16 +197,46 @@ func DefaultConfig() Config {
 		ShowMoveHistory: false,     // Hidden by default
 		ShowHelpText:    true,      // Show help text by default
 		Theme:           DefaultTheme, // Classic theme by default
+
+		CoordinateStyle: DefaultCoordinateStyle,
+		Notation:        DefaultNotation,
+		Language:        DefaultLanguage,
+
+		BotResignThreshold: DefaultBotResignThreshold,
+		BotDifficulty:      DefaultBotDifficulty,
+		Coach:              DefaultCoach,
+
+		UpdateCheck: DefaultUpdateCheck,
 	}
 }
 
 // ConfigFile represents the structure of the TOML configuration file.
-// It uses separate sections for display and game settings.
+// It uses separate sections for display, game settings and key bindings.
 type ConfigFile struct {
+	// Version is the format version of the file, see ConfigVersion.
+	Version int           `toml:"version"`
 	Display DisplayConfig `toml:"display"`
 	Game    GameConfig    `toml:"game"`
+	// Correspondence holds the player name and mailbox used for correspondence games.
+	Correspondence CorrespondenceConfig `toml:"correspondence,omitempty"`
+	// SPRT holds the hypotheses and error rates of Bot vs Bot SPRT tests.
+	SPRT SPRTConfig `toml:"sprt,omitempty"`
+	// Updates holds how often TermChess checks for a newer release.
+	Updates UpdatesConfig `toml:"updates,omitempty"`
+	// Sync holds the backend the config, saved game and game history are synced to.
+	Sync SyncConfig `toml:"sync,omitempty"`
+	// ExternalBots lists the external bot programs, one [[external_bots]] table each.
+	ExternalBots []ExternalBot `toml:"external_bots,omitempty"`
+	// Keys maps action names (e.g. "up", "quit") to lists of keys (e.g. ["up", "k"]).
+	Keys map[string][]string `toml:"keys,omitempty"`
 }
 
+// LocalKeys are the dotted keys of the config file and profiles that only make
+// sense on the machine they are set on: the paths of files and directories, and
+// the external bots, which are commands run on the machine. Sync leaves them out
+// of the files it stores, and keeps the local values when it downloads them.
+var LocalKeys = []string{"external_bots", "game.bvb_stream_file", "game.syzygy_path", "correspondence.mailbox_dir"}
+
 // DisplayConfig holds display-related configuration options for the TOML file.
 type DisplayConfig struct {
 	UseUnicode      bool   `toml:"use_unicode"`
@@ -71,6 +245,12 @@ type DisplayConfig struct {
 	ShowMoveHistory bool   `toml:"show_move_history"`
 	ShowHelpText    bool   `toml:"show_help_text"`
 	Theme           string `toml:"theme"`
+
+	CoordinateStyle  string `toml:"coordinate_style,omitempty"`
+	Notation         string `toml:"notation,omitempty"`
+	FigurineNotation bool   `toml:"figurine_notation,omitempty"`
+	Language         string `toml:"language,omitempty"`
+	TerminalProgress bool   `toml:"terminal_progress,omitempty"`
 }
 
 // GameConfig holds game-related configuration options for the TOML file.
@@ -83,22 +263,75 @@ type GameConfig struct {
 	// BvBDefaultViewMode specifies the default view mode for Bot vs Bot sessions.
 	// Valid values: "grid", "single", "stats_only"
 	BvBDefaultViewMode string `toml:"bvb_default_view_mode"`
+	// BotResignThreshold is the evaluated deficit in pawns at which bots resign.
+	// 0 disables resignation.
+	BotResignThreshold float64 `toml:"bot_resign_threshold"`
+	// BotMoveTimeMs is how long the built-in bots may think per move, in
+	// milliseconds. 0 uses the budget of each difficulty.
+	BotMoveTimeMs int64 `toml:"bot_move_time_ms,omitempty"`
+	// TurnNotifications enables desktop notifications when it's the user's turn.
+	TurnNotifications bool `toml:"turn_notifications"`
+	// HotSeatPrivacy shows a pass-the-keyboard screen between moves in Player vs Player games.
+	HotSeatPrivacy bool `toml:"hot_seat_privacy"`
+	// EncryptSaves encrypts the saved game and the history with a passphrase.
+	EncryptSaves bool `toml:"encrypt_saves,omitempty"`
+	// Coach is "off", "mistakes" or "all". Empty means off.
+	Coach string `toml:"coach,omitempty"`
+	// BvBStreamFile is the file Bot vs Bot games are streamed to while they are played.
+	BvBStreamFile string `toml:"bvb_stream_file,omitempty"`
+	// SyzygyPath lists the directories of Syzygy tablebases given to UCI bots.
+	SyzygyPath string `toml:"syzygy_path,omitempty"`
+}
+
+// CorrespondenceConfig holds correspondence game options for the TOML file.
+type CorrespondenceConfig struct {
+	PlayerName string `toml:"player_name,omitempty"`
+	MailboxDir string `toml:"mailbox_dir,omitempty"`
+}
+
+// SPRTConfig holds the Bot vs Bot SPRT options for the TOML file.
+type SPRTConfig struct {
+	Elo0  float64 `toml:"elo0,omitempty"`
+	Elo1  float64 `toml:"elo1,omitempty"`
+	Alpha float64 `toml:"alpha,omitempty"`
+	Beta  float64 `toml:"beta,omitempty"`
+}
+
+// UpdatesConfig holds the update check options for the TOML file.
+type UpdatesConfig struct {
+	// Check is "startup", "daily", "weekly" or "off". Empty means daily.
+	Check string `toml:"check,omitempty"`
+}
+
+// SyncConfig holds the sync options for the TOML file.
+type SyncConfig struct {
+	// Backend is "s3" or "webdav". Empty turns sync off.
+	Backend  string `toml:"backend,omitempty"`
+	URL      string `toml:"url,omitempty"`
+	Region   string `toml:"region,omitempty"`
+	Username string `toml:"username,omitempty"`
+	Auto     bool   `toml:"auto,omitempty"`
 }
 
 // defaultConfigFile returns a ConfigFile with default values.
 func defaultConfigFile() ConfigFile {
 	return ConfigFile{
+		Version: ConfigVersion,
 		Display: DisplayConfig{
 			UseUnicode:      false,     // ASCII for maximum compatibility
 			ShowCoordinates: true,      // Show a-h, 1-8 labels
 			UseColors:       true,      // Use colors if terminal supports
 			ShowMoveHistory: false,     // Hidden by default
 			Theme:           DefaultTheme, // Classic theme by default
+
+			CoordinateStyle: DefaultCoordinateStyle,
+			Notation:        DefaultNotation,
 		},
 		Game: GameConfig{
 			DefaultGameType:      "pvp",    // Default to player vs player
-			DefaultBotDifficulty: "medium", // Default bot difficulty
+			DefaultBotDifficulty: DefaultBotDifficulty,
 			BvBDefaultViewMode:   "grid",   // Default to grid view for BvB
+			BotResignThreshold:   DefaultBotResignThreshold,
 		},
 	}
 }
@@ -109,6 +342,26 @@ func configFileToConfig(cf ConfigFile) Config {
 	if theme == "" {
 		theme = DefaultTheme
 	}
+	coordinateStyle := cf.Display.CoordinateStyle
+	if coordinateStyle == "" {
+		coordinateStyle = DefaultCoordinateStyle
+	}
+	notation := cf.Display.Notation
+	if notation == "" {
+		notation = DefaultNotation
+	}
+	language := cf.Display.Language
+	if language == "" {
+		language = DefaultLanguage
+	}
+	updateCheck := cf.Updates.Check
+	if updateCheck == "" {
+		updateCheck = DefaultUpdateCheck
+	}
+	coach := cf.Game.Coach
+	if coach == "" {
+		coach = DefaultCoach
+	}
 	return Config{
 		UseUnicode:      cf.Display.UseUnicode,
 		ShowCoords:      cf.Display.ShowCoordinates,
@@ -116,6 +369,36 @@ func configFileToConfig(cf ConfigFile) Config {
 		ShowMoveHistory: cf.Display.ShowMoveHistory,
 		ShowHelpText:    cf.Display.ShowHelpText,
 		Theme:           theme,
+
+		CoordinateStyle:  coordinateStyle,
+		Notation:         notation,
+		FigurineNotation: cf.Display.FigurineNotation,
+		Language:         language,
+		TerminalProgress: cf.Display.TerminalProgress,
+
+		BotResignThreshold: cf.Game.BotResignThreshold,
+		BotDifficulty:      cf.Game.DefaultBotDifficulty,
+		BotMoveTime:        time.Duration(max(cf.Game.BotMoveTimeMs, 0)) * time.Millisecond,
+		TurnNotifications:  cf.Game.TurnNotifications,
+		HotSeatPrivacy:     cf.Game.HotSeatPrivacy,
+		EncryptSaves:       cf.Game.EncryptSaves,
+		Coach:              coach,
+		BvBStreamFile:      cf.Game.BvBStreamFile,
+		SyzygyPath:         cf.Game.SyzygyPath,
+		KeyBindings:        cf.Keys,
+		PlayerName:         cf.Correspondence.PlayerName,
+		MailboxDir:         cf.Correspondence.MailboxDir,
+		SPRTElo0:           cf.SPRT.Elo0,
+		SPRTElo1:           cf.SPRT.Elo1,
+		SPRTAlpha:          cf.SPRT.Alpha,
+		SPRTBeta:           cf.SPRT.Beta,
+		ExternalBots:       cf.ExternalBots,
+		UpdateCheck:        updateCheck,
+		SyncBackend:        cf.Sync.Backend,
+		SyncURL:            cf.Sync.URL,
+		SyncRegion:         cf.Sync.Region,
+		SyncUsername:       cf.Sync.Username,
+		SyncAuto:           cf.Sync.Auto,
 	}
 }
 
@@ -125,7 +408,12 @@ func configToConfigFile(c Config) ConfigFile {
 	if theme == "" {
 		theme = DefaultTheme
 	}
+	botDifficulty := c.BotDifficulty
+	if botDifficulty == "" {
+		botDifficulty = DefaultBotDifficulty
+	}
 	return ConfigFile{
+		Version: ConfigVersion,
 		Display: DisplayConfig{
 			UseUnicode:      c.UseUnicode,
 			ShowCoordinates: c.ShowCoords,
@@ -133,20 +421,63 @@ func configToConfigFile(c Config) ConfigFile {
 			ShowMoveHistory: c.ShowMoveHistory,
 			ShowHelpText:    c.ShowHelpText,
 			Theme:           theme,
+
+			CoordinateStyle:  c.CoordinateStyle,
+			Notation:         c.Notation,
+			FigurineNotation: c.FigurineNotation,
+			Language:         c.Language,
+			TerminalProgress: c.TerminalProgress,
 		},
 		Game: GameConfig{
 			DefaultGameType:      "pvp",    // Preserve default
-			DefaultBotDifficulty: "medium", // Preserve default
+			DefaultBotDifficulty: botDifficulty,
 			BvBDefaultViewMode:   "grid",   // Preserve default
+			BotResignThreshold:   c.BotResignThreshold,
+			BotMoveTimeMs:        c.BotMoveTime.Milliseconds(),
+			TurnNotifications:    c.TurnNotifications,
+			HotSeatPrivacy:       c.HotSeatPrivacy,
+			EncryptSaves:         c.EncryptSaves,
+			Coach:                c.Coach,
+			BvBStreamFile:        c.BvBStreamFile,
+			SyzygyPath:           c.SyzygyPath,
+		},
+		Correspondence: CorrespondenceConfig{
+			PlayerName: c.PlayerName,
+			MailboxDir: c.MailboxDir,
+		},
+		SPRT: SPRTConfig{
+			Elo0:  c.SPRTElo0,
+			Elo1:  c.SPRTElo1,
+			Alpha: c.SPRTAlpha,
+			Beta:  c.SPRTBeta,
+		},
+		Updates: UpdatesConfig{
+			Check: c.UpdateCheck,
 		},
+		Sync: SyncConfig{
+			Backend:  c.SyncBackend,
+			URL:      c.SyncURL,
+			Region:   c.SyncRegion,
+			Username: c.SyncUsername,
+			Auto:     c.SyncAuto,
+		},
+		ExternalBots: c.ExternalBots,
+		Keys:         c.KeyBindings,
 	}
 }
 
-// LoadConfig reads the configuration file from ~/.termchess/config.toml.
+// LoadConfig reads the configuration file, config.toml in the config directory, and
+// applies the TERMCHESS_* environment variable overrides.
 // If the file doesn't exist or cannot be parsed, it returns the default configuration.
 // This function never returns an error - it always returns a valid configuration.
 func LoadConfig() Config {
-	configPath, err := getConfigFilePath()
+	return ApplyEnvOverrides(loadConfigFile())
+}
+
+// loadConfigFile reads the configuration file, falling back to the default
+// configuration if it doesn't exist or cannot be parsed.
+func loadConfigFile() Config {
+	configPath, err := getConfigReadPath()
 	if err != nil {
 		// Cannot determine config path, use defaults
 		return DefaultConfig()
@@ -158,22 +489,36 @@ func LoadConfig() Config {
 		return DefaultConfig()
 	}
 
-	// Read and parse the config file
+	// Read and parse the config file, or its backup if it is damaged
 	var cf ConfigFile
-	if _, err := toml.DecodeFile(configPath, &cf); err != nil {
+	var meta toml.MetaData
+	err = readFileRecovering(configPath, false, func(data []byte) error {
+		var decoded ConfigFile
+		m, err := toml.Decode(string(data), &decoded)
+		if err != nil {
+			return err
+		}
+		cf, meta = decoded, m
+		return nil
+	})
+	if err != nil {
 		// Failed to parse config file, use defaults
 		return DefaultConfig()
 	}
 
+	// Bring files of older versions up to date. A file of a newer version is
+	// used as far as it can be read; CheckConfig reports it
+	_ = migrate(filepath.Base(configPath), &cf, meta, cf.Version, ConfigVersion, configMigrations)
+
 	// Convert ConfigFile to Config and return
 	return configFileToConfig(cf)
 }
 
-// LoadGameConfig reads the game configuration from ~/.termchess/config.toml.
+// LoadGameConfig reads the game configuration from config.toml in the config directory.
 // If the file doesn't exist or cannot be parsed, it returns the default game configuration.
 // This function never returns an error - it always returns a valid configuration.
 func LoadGameConfig() GameConfig {
-	configPath, err := getConfigFilePath()
+	configPath, err := getConfigReadPath()
 	if err != nil {
 		// Cannot determine config path, use defaults
 		return defaultConfigFile().Game
@@ -195,42 +540,66 @@ func LoadGameConfig() GameConfig {
 	return cf.Game
 }
 
-// SaveConfig writes the configuration to ~/.termchess/config.toml.
-// It creates the ~/.termchess/ directory if it doesn't exist.
-// Returns an error if the file cannot be written.
-func SaveConfig(config Config) error {
-	// Get the config directory path
-	configDir, err := GetConfigDir()
+// CheckConfig reports whether the config file can be read by this version of
+// TermChess. It returns a *FormatError if the file was written by a newer one,
+// and nil if it can be read or doesn't exist.
+func CheckConfig() error {
+	configPath, err := getConfigReadPath()
 	if err != nil {
-		return fmt.Errorf("failed to get config directory: %w", err)
+		return nil
 	}
+	return checkConfigVersion(configPath)
+}
 
-	// Create the config directory if it doesn't exist
-	if err := os.MkdirAll(configDir, 0755); err != nil {
-		return fmt.Errorf("failed to create config directory: %w", err)
+// checkConfigVersion returns a *FormatError if the config file at path has a
+// newer format version than this TermChess writes.
+func checkConfigVersion(path string) error {
+	var cf struct {
+		Version int `toml:"version"`
 	}
+	if _, err := toml.DecodeFile(path, &cf); err != nil {
+		return nil
+	}
+	if cf.Version > ConfigVersion {
+		return &FormatError{File: filepath.Base(path), Version: cf.Version, Supported: ConfigVersion}
+	}
+	return nil
+}
 
+// SaveConfig writes the configuration to config.toml in the config directory.
+// It creates the config directory if it doesn't exist. Options still holding
+// the value of a TERMCHESS_* override keep the value they have in the file.
+// Returns an error if the file cannot be written, or a *FormatError instead of
+// overwriting a file written by a newer version of TermChess.
+func SaveConfig(config Config) error {
 	// Get the config file path
 	configPath, err := getConfigFilePath()
 	if err != nil {
 		return fmt.Errorf("failed to get config file path: %w", err)
 	}
+	if err := checkConfigVersion(configPath); err != nil {
+		return err
+	}
 
-	// Convert Config to ConfigFile
-	cf := configToConfigFile(config)
-
-	// Create the config file
-	file, err := os.Create(configPath)
-	if err != nil {
-		return fmt.Errorf("failed to create config file: %w", err)
+	// Create the config directory, or the profiles directory, if it doesn't exist
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
 	}
-	defer file.Close()
 
-	// Encode the config to TOML and write to file
-	encoder := toml.NewEncoder(file)
+	// Convert Config to ConfigFile, leaving out the environment overrides
+	cf := configToConfigFile(removeEnvOverrides(config, loadConfigFile()))
+
+	// Encode the config to TOML
+	var buf bytes.Buffer
+	encoder := toml.NewEncoder(&buf)
 	if err := encoder.Encode(cf); err != nil {
 		return fmt.Errorf("failed to encode config to TOML: %w", err)
 	}
 
+	// Replace the config file, keeping the previous one as a backup
+	if err := writeFileAtomic(configPath, buf.Bytes(), 0644, parseTOML); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
 	return nil
 }
@@ -2,7 +2,11 @@ package config
 
 import (
 	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 )
 
 // TestLoadConfig_WithMissingFile tests that LoadConfig returns default config when file doesn't exist
@@ -249,3 +253,322 @@ func TestDefaultConfig_HasTheme(t *testing.T) {
 		t.Errorf("Expected default theme to be %q, got %q", DefaultTheme, config.Theme)
 	}
 }
+
+// TestNotationSaveAndLoad tests that the coordinate, notation and language settings round-trip through the config file
+func TestNotationSaveAndLoad(t *testing.T) {
+	customConfig := DefaultConfig()
+	customConfig.CoordinateStyle = "inside"
+	customConfig.Notation = "long"
+	customConfig.FigurineNotation = true
+	customConfig.Language = "de"
+
+	if err := SaveConfig(customConfig); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+
+	loadedConfig := LoadConfig()
+	if loadedConfig.CoordinateStyle != "inside" || loadedConfig.Notation != "long" || !loadedConfig.FigurineNotation {
+		t.Errorf("Loaded coordinate style %q, notation %q, figurine %v; want inside, long, true",
+			loadedConfig.CoordinateStyle, loadedConfig.Notation, loadedConfig.FigurineNotation)
+	}
+	if loadedConfig.Language != "de" {
+		t.Errorf("Loaded language %q, want de", loadedConfig.Language)
+	}
+
+	// Restore defaults so other tests see the standard notation
+	if err := SaveConfig(DefaultConfig()); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+}
+
+// TestNotationDefaultOnEmpty tests that config files without the notation keys use the defaults
+func TestNotationDefaultOnEmpty(t *testing.T) {
+	config := configFileToConfig(ConfigFile{})
+
+	if config.CoordinateStyle != DefaultCoordinateStyle {
+		t.Errorf("Expected empty coordinate style to default to %q, got %q", DefaultCoordinateStyle, config.CoordinateStyle)
+	}
+	if config.Notation != DefaultNotation {
+		t.Errorf("Expected empty notation to default to %q, got %q", DefaultNotation, config.Notation)
+	}
+	if config.Language != DefaultLanguage {
+		t.Errorf("Expected empty language to default to %q, got %q", DefaultLanguage, config.Language)
+	}
+}
+
+// TestBotResignThresholdSaveAndLoad tests that the resign threshold round-trips through the config file
+func TestBotResignThresholdSaveAndLoad(t *testing.T) {
+	customConfig := DefaultConfig()
+	customConfig.BotResignThreshold = 6.5
+
+	if err := SaveConfig(customConfig); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+
+	loadedConfig := LoadConfig()
+	if loadedConfig.BotResignThreshold != 6.5 {
+		t.Errorf("BotResignThreshold mismatch: got %v, want 6.5", loadedConfig.BotResignThreshold)
+	}
+
+	// Restore defaults so other tests see the standard threshold
+	if err := SaveConfig(DefaultConfig()); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+}
+
+// TestBotResignThresholdDefaultWhenMissing tests that config files without the key use the default threshold
+func TestBotResignThresholdDefaultWhenMissing(t *testing.T) {
+	configPath, err := getConfigFilePath()
+	if err != nil {
+		t.Fatalf("getConfigFilePath failed: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		t.Fatalf("Failed to create config directory: %v", err)
+	}
+
+	content := "[display]\nuse_unicode = true\n\n[game]\ndefault_game_type = \"pvp\"\n"
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	defer func() { _ = SaveConfig(DefaultConfig()) }()
+
+	config := LoadConfig()
+	if config.BotResignThreshold != DefaultBotResignThreshold {
+		t.Errorf("Expected missing threshold to default to %v, got %v", DefaultBotResignThreshold, config.BotResignThreshold)
+	}
+}
+
+// TestKeyBindingsSaveAndLoad tests that key binding overrides round-trip through the config file
+func TestKeyBindingsSaveAndLoad(t *testing.T) {
+	customConfig := DefaultConfig()
+	customConfig.KeyBindings = map[string][]string{
+		"up":   {"up", "w"},
+		"quit": {"x"},
+	}
+
+	if err := SaveConfig(customConfig); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+	defer func() { _ = SaveConfig(DefaultConfig()) }()
+
+	loadedConfig := LoadConfig()
+	if got := loadedConfig.KeyBindings["up"]; len(got) != 2 || got[0] != "up" || got[1] != "w" {
+		t.Errorf("KeyBindings[up] mismatch: got %v, want [up w]", got)
+	}
+	if got := loadedConfig.KeyBindings["quit"]; len(got) != 1 || got[0] != "x" {
+		t.Errorf("KeyBindings[quit] mismatch: got %v, want [x]", got)
+	}
+
+	// Configs without overrides don't write an empty [keys] section
+	if err := SaveConfig(DefaultConfig()); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+	configPath, err := getConfigFilePath()
+	if err != nil {
+		t.Fatalf("getConfigFilePath failed: %v", err)
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read config file: %v", err)
+	}
+	if strings.Contains(string(data), "[keys]") {
+		t.Error("Expected no [keys] section without overrides")
+	}
+}
+
+func TestTurnNotificationsSaveAndLoad(t *testing.T) {
+	if DefaultConfig().TurnNotifications {
+		t.Error("Expected turn notifications to be off by default")
+	}
+
+	customConfig := DefaultConfig()
+	customConfig.TurnNotifications = true
+	if err := SaveConfig(customConfig); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+	defer func() { _ = SaveConfig(DefaultConfig()) }()
+
+	if !LoadConfig().TurnNotifications {
+		t.Error("Expected TurnNotifications to be saved and loaded")
+	}
+}
+
+func TestHotSeatPrivacySaveAndLoad(t *testing.T) {
+	if DefaultConfig().HotSeatPrivacy {
+		t.Error("Expected the hot-seat privacy screen to be off by default")
+	}
+
+	customConfig := DefaultConfig()
+	customConfig.HotSeatPrivacy = true
+	if err := SaveConfig(customConfig); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+	defer func() { _ = SaveConfig(DefaultConfig()) }()
+
+	if !LoadConfig().HotSeatPrivacy {
+		t.Error("Expected HotSeatPrivacy to be saved and loaded")
+	}
+}
+
+func TestCoachSaveAndLoad(t *testing.T) {
+	if DefaultConfig().Coach != CoachOff {
+		t.Errorf("Expected the coach to be off by default, got %q", DefaultConfig().Coach)
+	}
+
+	customConfig := DefaultConfig()
+	customConfig.Coach = CoachMistakes
+	if err := SaveConfig(customConfig); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+	defer func() { _ = SaveConfig(DefaultConfig()) }()
+
+	if got := LoadConfig().Coach; got != CoachMistakes {
+		t.Errorf("Expected Coach to be saved and loaded, got %q", got)
+	}
+}
+
+func TestSPRTSaveAndLoad(t *testing.T) {
+	customConfig := DefaultConfig()
+	customConfig.SPRTElo0 = -10
+	customConfig.SPRTElo1 = 20
+	customConfig.SPRTAlpha = 0.1
+	customConfig.SPRTBeta = 0.2
+	if err := SaveConfig(customConfig); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+	defer func() { _ = SaveConfig(DefaultConfig()) }()
+
+	loaded := LoadConfig()
+	if loaded.SPRTElo0 != -10 || loaded.SPRTElo1 != 20 || loaded.SPRTAlpha != 0.1 || loaded.SPRTBeta != 0.2 {
+		t.Errorf("Expected the SPRT settings to be saved and loaded, got %g/%g/%g/%g",
+			loaded.SPRTElo0, loaded.SPRTElo1, loaded.SPRTAlpha, loaded.SPRTBeta)
+	}
+}
+
+func TestSyncSaveAndLoad(t *testing.T) {
+	customConfig := DefaultConfig()
+	customConfig.SyncBackend = SyncBackendWebDAV
+	customConfig.SyncURL = "https://dav.example.com/termchess"
+	customConfig.SyncUsername = "alice"
+	customConfig.SyncAuto = true
+	if err := SaveConfig(customConfig); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+	defer func() { _ = SaveConfig(DefaultConfig()) }()
+
+	loaded := LoadConfig()
+	if loaded.SyncBackend != SyncBackendWebDAV || loaded.SyncURL != customConfig.SyncURL ||
+		loaded.SyncUsername != "alice" || !loaded.SyncAuto {
+		t.Errorf("Expected the sync settings to be saved and loaded, got %q %q %q %v",
+			loaded.SyncBackend, loaded.SyncURL, loaded.SyncUsername, loaded.SyncAuto)
+	}
+}
+
+func TestBvBStreamFileSaveAndLoad(t *testing.T) {
+	customConfig := DefaultConfig()
+	customConfig.BvBStreamFile = "/tmp/bvb/games.jsonl"
+	if err := SaveConfig(customConfig); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+	defer func() { _ = SaveConfig(DefaultConfig()) }()
+
+	if got := LoadConfig().BvBStreamFile; got != "/tmp/bvb/games.jsonl" {
+		t.Errorf("Expected BvBStreamFile to be saved and loaded, got %q", got)
+	}
+}
+
+func TestSyzygyPathSaveAndLoad(t *testing.T) {
+	customConfig := DefaultConfig()
+	customConfig.SyzygyPath = "/tb/3-4-5:/tb/6"
+	if err := SaveConfig(customConfig); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+	defer func() { _ = SaveConfig(DefaultConfig()) }()
+
+	if got := LoadConfig().SyzygyPath; got != "/tb/3-4-5:/tb/6" {
+		t.Errorf("Expected SyzygyPath to be saved and loaded, got %q", got)
+	}
+}
+
+func TestBotMoveTimeSaveAndLoad(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if got := LoadConfig().BotMoveTime; got != 0 {
+		t.Errorf("Expected no BotMoveTime override by default, got %v", got)
+	}
+
+	customConfig := DefaultConfig()
+	customConfig.BotMoveTime = 500 * time.Millisecond
+	if err := SaveConfig(customConfig); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+	if got := LoadConfig().BotMoveTime; got != 500*time.Millisecond {
+		t.Errorf("Expected BotMoveTime to be saved and loaded, got %v", got)
+	}
+}
+
+func TestExternalBotsSaveAndLoad(t *testing.T) {
+	customConfig := DefaultConfig()
+	customConfig.ExternalBots = []ExternalBot{
+		{Name: "Py Bot", Command: "python3", Args: []string{"/bots/bot.py", "--fast"}},
+		{Name: "Node Bot", Command: "/usr/local/bin/node-bot"},
+		{Name: "Stockfish", Command: "stockfish", Protocol: "uci"},
+	}
+	if err := SaveConfig(customConfig); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+	defer func() { _ = SaveConfig(DefaultConfig()) }()
+
+	loaded := LoadConfig()
+	if len(loaded.ExternalBots) != 3 {
+		t.Fatalf("Expected 3 external bots, got %+v", loaded.ExternalBots)
+	}
+	py := loaded.ExternalBots[0]
+	if py.Name != "Py Bot" || py.Command != "python3" || len(py.Args) != 2 || py.Args[1] != "--fast" {
+		t.Errorf("Expected the Python bot to round-trip, got %+v", py)
+	}
+	if fish := loaded.ExternalBots[2]; fish.Protocol != "uci" {
+		t.Errorf("Expected the UCI protocol to round-trip, got %+v", fish)
+	}
+}
+
+func TestLoadSyncCredentials(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv(EnvAWSAccessKeyID, "")
+	t.Setenv(EnvAWSSecretAccessKey, "")
+	t.Setenv(EnvSyncPassword, "")
+
+	if creds, err := LoadSyncCredentials(); err != nil || creds != (SyncCredentials{}) {
+		t.Fatalf("LoadSyncCredentials() without a file = %+v, %v", creds, err)
+	}
+
+	path, err := SyncCredentialsPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	data := "access_key_id = \"AKID\"\nsecret_access_key = \"SECRET\"\npassword = \"dav\"\n"
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(EnvSyncPassword, "from-env")
+	creds, err := LoadSyncCredentials()
+	if err != nil {
+		t.Fatalf("LoadSyncCredentials() error: %v", err)
+	}
+	if want := (SyncCredentials{AccessKeyID: "AKID", SecretAccessKey: "SECRET", Password: "from-env"}); creds != want {
+		t.Errorf("LoadSyncCredentials() = %+v, want %+v", creds, want)
+	}
+
+	if runtime.GOOS != "windows" {
+		if err := os.Chmod(path, 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadSyncCredentials(); err == nil {
+			t.Error("Expected a credentials file other users can read to be refused")
+		}
+	}
+}
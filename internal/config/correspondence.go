@@ -0,0 +1,219 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// CorrespondenceDays are the days-per-move time controls offered for correspondence games.
+// 0 means there is no time limit.
+var CorrespondenceDays = []int{0, 1, 3, 7, 14}
+
+// CorrespondenceGame is a long-running game stored as one JSON file in the mailbox
+// directory. Both players read and write the same file, so a mailbox on a shared
+// folder lets two people play from different machines.
+type CorrespondenceGame struct {
+	ID    string `json:"id"`
+	White string `json:"white"`
+	Black string `json:"black"`
+	// StartFEN is the position the game started from.
+	StartFEN string `json:"start_fen"`
+	// Moves holds the moves played so far in coordinate notation (e.g. "e2e4").
+	Moves []string `json:"moves"`
+	// DaysPerMove is the time each player has for a move. 0 means no limit.
+	DaysPerMove int       `json:"days_per_move"`
+	Created     time.Time `json:"created"`
+	LastMoveAt  time.Time `json:"last_move_at"`
+	// Result is "1-0", "0-1" or "1/2-1/2" once the game has ended, otherwise empty.
+	Result string `json:"result,omitempty"`
+}
+
+// NewCorrespondenceGame creates a game between white and black from the starting position.
+func NewCorrespondenceGame(white, black string, daysPerMove int, now time.Time) *CorrespondenceGame {
+	return &CorrespondenceGame{
+		ID:          fmt.Sprintf("%s-%x", now.Format("20060102-150405"), now.UnixNano()&0xffff),
+		White:       white,
+		Black:       black,
+		StartFEN:    engine.NewBoard().ToFEN(),
+		DaysPerMove: daysPerMove,
+		Created:     now,
+		LastMoveAt:  now,
+	}
+}
+
+// Game replays the moves from the start position and returns the game.
+func (g *CorrespondenceGame) Game() (*engine.Game, error) {
+	start, err := engine.FromFEN(g.StartFEN)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start position: %w", err)
+	}
+	game := engine.NewGameFrom(start)
+	for i, s := range g.Moves {
+		move, err := engine.ParseMove(s)
+		if err != nil {
+			return nil, fmt.Errorf("move %d: %w", i+1, err)
+		}
+		if err := game.MakeMove(move); err != nil {
+			return nil, fmt.Errorf("move %d: %w", i+1, err)
+		}
+	}
+	return game, nil
+}
+
+// Board replays the game and returns the current position.
+func (g *CorrespondenceGame) Board() (*engine.Board, error) {
+	game, err := g.Game()
+	if err != nil {
+		return nil, err
+	}
+	return game.Board(), nil
+}
+
+// PlayerToMove returns the name of the player whose turn it is, or "" if the game is over.
+func (g *CorrespondenceGame) PlayerToMove() string {
+	if g.Result != "" {
+		return ""
+	}
+	board, err := g.Board()
+	if err != nil {
+		return ""
+	}
+	if board.ActiveColor == engine.White {
+		return g.White
+	}
+	return g.Black
+}
+
+// Opponent returns the name of the player facing player.
+func (g *CorrespondenceGame) Opponent(player string) string {
+	if g.White == player {
+		return g.Black
+	}
+	return g.White
+}
+
+// Deadline returns when the player to move must have moved by.
+// It returns false if the game has no time limit or is over.
+func (g *CorrespondenceGame) Deadline() (time.Time, bool) {
+	if g.DaysPerMove <= 0 || g.Result != "" {
+		return time.Time{}, false
+	}
+	return g.LastMoveAt.Add(time.Duration(g.DaysPerMove) * 24 * time.Hour), true
+}
+
+// Play validates and appends move, records the time it was played and sets the
+// result if it ends the game.
+func (g *CorrespondenceGame) Play(move engine.Move, now time.Time) error {
+	if g.Result != "" {
+		return fmt.Errorf("game is over (%s)", g.Result)
+	}
+	board, err := g.Board()
+	if err != nil {
+		return err
+	}
+	if err := board.MakeMove(move); err != nil {
+		return err
+	}
+	g.Moves = append(g.Moves, move.String())
+	g.LastMoveAt = now
+
+	if board.IsGameOver() {
+		g.Result = "1/2-1/2"
+		if winner, ok := board.Winner(); ok {
+			g.Result = "1-0"
+			if winner == engine.Black {
+				g.Result = "0-1"
+			}
+		}
+	}
+	return nil
+}
+
+// DefaultPlayerName returns the OS user name, or "Player" if it cannot be determined.
+func DefaultPlayerName() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return "Player"
+}
+
+// MailboxDir returns the directory correspondence games are stored in: the configured
+// MailboxDir, or the correspondence directory in the data directory.
+func MailboxDir(cfg Config) (string, error) {
+	if cfg.MailboxDir != "" {
+		return cfg.MailboxDir, nil
+	}
+	dataDir, err := GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dataDir, "correspondence"), nil
+}
+
+// SaveCorrespondenceGame writes game to <dir>/<id>.json, creating dir if needed.
+func SaveCorrespondenceGame(dir string, game *CorrespondenceGame) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create mailbox directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(game, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode correspondence game: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, game.ID+".json"), data, 0644); err != nil {
+		return fmt.Errorf("failed to write correspondence game: %w", err)
+	}
+	return nil
+}
+
+// LoadCorrespondenceGames reads every game in dir, oldest first.
+// A missing directory is not an error; files that cannot be parsed are skipped.
+func LoadCorrespondenceGames(dir string) ([]*CorrespondenceGame, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mailbox directory: %w", err)
+	}
+
+	var games []*CorrespondenceGame
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		var game CorrespondenceGame
+		if err := json.Unmarshal(data, &game); err != nil || game.ID == "" {
+			continue
+		}
+		games = append(games, &game)
+	}
+
+	sort.SliceStable(games, func(i, j int) bool {
+		return games[i].Created.Before(games[j].Created)
+	})
+	return games, nil
+}
+
+// DeleteCorrespondenceGame removes the game with the given ID from dir.
+// Returns nil if the game doesn't exist.
+func DeleteCorrespondenceGame(dir, id string) error {
+	err := os.Remove(filepath.Join(dir, id+".json"))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete correspondence game: %w", err)
+	}
+	return nil
+}
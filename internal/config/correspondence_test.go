@@ -0,0 +1,161 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+func mustParseMove(t *testing.T, s string) engine.Move {
+	t.Helper()
+	move, err := engine.ParseMove(s)
+	if err != nil {
+		t.Fatalf("ParseMove(%q) error = %v", s, err)
+	}
+	return move
+}
+
+// TestCorrespondenceGamePlay tests playing moves, turn order and deadlines.
+func TestCorrespondenceGamePlay(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	game := NewCorrespondenceGame("alice", "bob", 3, start)
+
+	if game.PlayerToMove() != "alice" {
+		t.Errorf("PlayerToMove() = %q, want alice", game.PlayerToMove())
+	}
+	if game.Opponent("alice") != "bob" || game.Opponent("bob") != "alice" {
+		t.Error("Opponent() returned the wrong player")
+	}
+
+	later := start.Add(26 * time.Hour)
+	if err := game.Play(mustParseMove(t, "e2e4"), later); err != nil {
+		t.Fatalf("Play(e2e4) error = %v", err)
+	}
+	if game.PlayerToMove() != "bob" {
+		t.Errorf("PlayerToMove() = %q, want bob", game.PlayerToMove())
+	}
+	deadline, ok := game.Deadline()
+	if !ok || !deadline.Equal(later.Add(72*time.Hour)) {
+		t.Errorf("Deadline() = %v, %v; want three days after the last move", deadline, ok)
+	}
+
+	if err := game.Play(mustParseMove(t, "e2e4"), later); err == nil {
+		t.Error("Expected an illegal move to be rejected")
+	}
+	if len(game.Moves) != 1 {
+		t.Errorf("Expected 1 move, got %v", game.Moves)
+	}
+}
+
+// TestCorrespondenceGameResult tests that a finishing move records the result.
+func TestCorrespondenceGameResult(t *testing.T) {
+	now := time.Now()
+	game := NewCorrespondenceGame("alice", "bob", 0, now)
+	for _, s := range []string{"f2f3", "e7e5", "g2g4", "d8h4"} {
+		if err := game.Play(mustParseMove(t, s), now); err != nil {
+			t.Fatalf("Play(%s) error = %v", s, err)
+		}
+	}
+
+	if game.Result != "0-1" {
+		t.Errorf("Result = %q, want 0-1", game.Result)
+	}
+	if game.PlayerToMove() != "" {
+		t.Error("Expected nobody to move in a finished game")
+	}
+	if _, ok := game.Deadline(); ok {
+		t.Error("Expected no deadline for a finished game")
+	}
+	if err := game.Play(mustParseMove(t, "a2a3"), now); err == nil {
+		t.Error("Expected moves after the end of the game to be rejected")
+	}
+}
+
+// TestCorrespondenceMailbox tests saving, loading and deleting games.
+func TestCorrespondenceMailbox(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mailbox")
+
+	games, err := LoadCorrespondenceGames(dir)
+	if err != nil || len(games) != 0 {
+		t.Fatalf("LoadCorrespondenceGames(missing) = %v, %v; want no games", games, err)
+	}
+
+	first := NewCorrespondenceGame("alice", "bob", 7, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	second := NewCorrespondenceGame("carol", "alice", 0, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC))
+	if err := first.Play(mustParseMove(t, "d2d4"), time.Now()); err != nil {
+		t.Fatalf("Play error = %v", err)
+	}
+	for _, g := range []*CorrespondenceGame{second, first} {
+		if err := SaveCorrespondenceGame(dir, g); err != nil {
+			t.Fatalf("SaveCorrespondenceGame error = %v", err)
+		}
+	}
+	// Unrelated and broken files are ignored
+	_ = os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("hi"), 0644)
+	_ = os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0644)
+
+	games, err = LoadCorrespondenceGames(dir)
+	if err != nil {
+		t.Fatalf("LoadCorrespondenceGames error = %v", err)
+	}
+	if len(games) != 2 || games[0].ID != first.ID || games[1].ID != second.ID {
+		t.Fatalf("Expected both games oldest first, got %+v", games)
+	}
+	if strings.Join(games[0].Moves, " ") != "d2d4" || games[0].DaysPerMove != 7 {
+		t.Errorf("Loaded game = %+v, want the saved moves and time control", games[0])
+	}
+
+	if err := DeleteCorrespondenceGame(dir, first.ID); err != nil {
+		t.Fatalf("DeleteCorrespondenceGame error = %v", err)
+	}
+	if err := DeleteCorrespondenceGame(dir, first.ID); err != nil {
+		t.Errorf("Deleting a missing game should not fail, got %v", err)
+	}
+	games, _ = LoadCorrespondenceGames(dir)
+	if len(games) != 1 || games[0].ID != second.ID {
+		t.Errorf("Expected only the second game to remain, got %+v", games)
+	}
+}
+
+// TestMailboxDir tests the default and configured mailbox directories.
+func TestMailboxDir(t *testing.T) {
+	dir, err := MailboxDir(DefaultConfig())
+	if err != nil {
+		t.Fatalf("MailboxDir error = %v", err)
+	}
+	dataDir, _ := GetDataDir()
+	if dir != filepath.Join(dataDir, "correspondence") {
+		t.Errorf("MailboxDir() = %q, want correspondence in %q", dir, dataDir)
+	}
+
+	cfg := DefaultConfig()
+	cfg.MailboxDir = "/shared/chess"
+	if dir, _ := MailboxDir(cfg); dir != "/shared/chess" {
+		t.Errorf("MailboxDir() = %q, want the configured directory", dir)
+	}
+}
+
+// TestCorrespondenceConfigSaveAndLoad tests that the player name and mailbox are persisted.
+func TestCorrespondenceConfigSaveAndLoad(t *testing.T) {
+	cf := configToConfigFile(DefaultConfig())
+	if cf.Correspondence != (CorrespondenceConfig{}) {
+		t.Errorf("Expected an empty correspondence section by default, got %+v", cf.Correspondence)
+	}
+
+	customConfig := DefaultConfig()
+	customConfig.PlayerName = "alice"
+	customConfig.MailboxDir = "/shared/chess"
+	if err := SaveConfig(customConfig); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+	defer func() { _ = SaveConfig(DefaultConfig()) }()
+
+	loaded := LoadConfig()
+	if loaded.PlayerName != "alice" || loaded.MailboxDir != "/shared/chess" {
+		t.Errorf("Loaded PlayerName %q MailboxDir %q, want alice and /shared/chess", loaded.PlayerName, loaded.MailboxDir)
+	}
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// CrashRecovery is the game that was being played when TermChess crashed, kept
+// in crash_recovery.toml in the data directory so it can be restored on the next launch.
+type CrashRecovery struct {
+	// CrashedAt is when TermChess crashed.
+	CrashedAt time.Time `toml:"crashed_at"`
+	// Report is the path of the crash report.
+	Report string `toml:"report,omitempty"`
+	// FEN is the position of the game.
+	FEN string `toml:"fen"`
+	// Game is what the game was played between, as for a saved game.
+	Game SavedGameInfo `toml:"game"`
+}
+
+// CrashReportsDir returns the directory crash reports are written to, crashes
+// in the data directory.
+func CrashReportsDir() (string, error) {
+	dataDir, err := GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dataDir, "crashes"), nil
+}
+
+// CrashRecoveryPath returns the full path to the file keeping the game interrupted by a crash.
+func CrashRecoveryPath() (string, error) {
+	dataDir, err := GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dataDir, "crash_recovery.toml"), nil
+}
+
+// SaveCrashReport writes report to a new file in the crash reports directory,
+// named after the time of the crash, and returns its path.
+func SaveCrashReport(report string, at time.Time) (string, error) {
+	dir, err := CrashReportsDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get crash reports directory: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create crash reports directory: %w", err)
+	}
+	path := filepath.Join(dir, "crash-"+at.Format("20060102-150405")+".txt")
+	if err := os.WriteFile(path, []byte(report), 0644); err != nil {
+		return "", fmt.Errorf("failed to write crash report: %w", err)
+	}
+	return path, nil
+}
+
+// SaveCrashRecovery keeps the game interrupted by a crash for the next launch,
+// replacing any kept earlier.
+func SaveCrashRecovery(recovery CrashRecovery) error {
+	recovery.Game.Version = SaveVersion
+	recoveryPath, err := CrashRecoveryPath()
+	if err != nil {
+		return fmt.Errorf("failed to get crash recovery path: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(recoveryPath), 0755); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(recovery); err != nil {
+		return fmt.Errorf("failed to encode crash recovery: %w", err)
+	}
+	if err := replaceFile(recoveryPath, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write crash recovery: %w", err)
+	}
+	return nil
+}
+
+// LoadCrashRecovery returns the game interrupted by the last crash, or nil if
+// there is none to restore.
+func LoadCrashRecovery() (*CrashRecovery, error) {
+	recoveryPath, err := CrashRecoveryPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get crash recovery path: %w", err)
+	}
+	var recovery CrashRecovery
+	meta, err := toml.DecodeFile(recoveryPath, &recovery)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read crash recovery: %w", err)
+	}
+	if err := migrate(filepath.Base(recoveryPath), &recovery.Game, meta, recovery.Game.Version, SaveVersion, saveMigrations); err != nil {
+		return nil, err
+	}
+	return &recovery, nil
+}
+
+// DeleteCrashRecovery forgets the game interrupted by the last crash. It is not
+// an error if there is none.
+func DeleteCrashRecovery() error {
+	recoveryPath, err := CrashRecoveryPath()
+	if err != nil {
+		return fmt.Errorf("failed to get crash recovery path: %w", err)
+	}
+	if err := os.Remove(recoveryPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete crash recovery: %w", err)
+	}
+	return nil
+}
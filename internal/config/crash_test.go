@@ -0,0 +1,75 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCrashRecovery(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if recovery, err := LoadCrashRecovery(); err != nil || recovery != nil {
+		t.Fatalf("LoadCrashRecovery() = %+v, %v; want nothing before a crash", recovery, err)
+	}
+
+	at := time.Date(2026, 3, 14, 15, 9, 26, 0, time.UTC)
+	path, err := SaveCrashReport("report", at)
+	if err != nil {
+		t.Fatalf("SaveCrashReport failed: %v", err)
+	}
+	if filepath.Base(path) != "crash-20260314-150926.txt" {
+		t.Errorf("Crash report written to %s, want it named after the time of the crash", path)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "report" {
+		t.Errorf("Crash report contains %q, want %q", data, "report")
+	}
+
+	fen := "4k3/8/8/8/8/8/4P3/4K3 w - - 0 1"
+	err = SaveCrashRecovery(CrashRecovery{
+		CrashedAt: at,
+		Report:    path,
+		FEN:       fen,
+		Game:      SavedGameInfo{GameType: "pvbot", UserColor: "black", BotDifficulty: "hard"},
+	})
+	if err != nil {
+		t.Fatalf("SaveCrashRecovery failed: %v", err)
+	}
+	recovery, err := LoadCrashRecovery()
+	if err != nil || recovery == nil {
+		t.Fatalf("LoadCrashRecovery() = %+v, %v; want the interrupted game", recovery, err)
+	}
+	if !recovery.CrashedAt.Equal(at) || recovery.Report != path || recovery.FEN != fen ||
+		recovery.Game.UserColor != "black" || recovery.Game.Version != SaveVersion {
+		t.Errorf("LoadCrashRecovery() = %+v, want what was saved", recovery)
+	}
+
+	if err := DeleteCrashRecovery(); err != nil {
+		t.Fatalf("DeleteCrashRecovery failed: %v", err)
+	}
+	if recovery, err := LoadCrashRecovery(); err != nil || recovery != nil {
+		t.Errorf("LoadCrashRecovery() = %+v, %v; want nothing after deleting it", recovery, err)
+	}
+	if err := DeleteCrashRecovery(); err != nil {
+		t.Errorf("DeleteCrashRecovery() = %v, want nil without a game", err)
+	}
+}
+
+func TestNewerCrashRecovery(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	recoveryPath, err := CrashRecoveryPath()
+	if err != nil {
+		t.Fatalf("CrashRecoveryPath failed: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(recoveryPath), 0755); err != nil {
+		t.Fatalf("Failed to create data directory: %v", err)
+	}
+	if err := os.WriteFile(recoveryPath, []byte("fen = \"x\"\n[game]\nversion = 99\n"), 0644); err != nil {
+		t.Fatalf("Failed to write crash recovery: %v", err)
+	}
+	if _, err := LoadCrashRecovery(); err == nil || !strings.Contains(err.Error(), "newer version") {
+		t.Errorf("LoadCrashRecovery() = %v, want a FormatError", err)
+	}
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Environment variables holding the credentials sync logs in with. They take
+// precedence over the credentials file.
+const (
+	// EnvAWSAccessKeyID is the S3 access key ID
+	EnvAWSAccessKeyID = "AWS_ACCESS_KEY_ID"
+	// EnvAWSSecretAccessKey is the S3 secret access key
+	EnvAWSSecretAccessKey = "AWS_SECRET_ACCESS_KEY"
+	// EnvSyncPassword is the WebDAV password
+	EnvSyncPassword = "TERMCHESS_SYNC_PASSWORD"
+)
+
+// SyncCredentials are the secrets sync logs in with. They are kept out of
+// config.toml, which is synced itself and readable by other users, in
+// sync_credentials.toml in the config directory, which only its owner may
+// read and which is never synced.
+type SyncCredentials struct {
+	// AccessKeyID and SecretAccessKey log in to S3.
+	AccessKeyID     string `toml:"access_key_id,omitempty"`
+	SecretAccessKey string `toml:"secret_access_key,omitempty"`
+	// Password logs in to WebDAV.
+	Password string `toml:"password,omitempty"`
+}
+
+// LoadSyncCredentials returns the sync credentials from the environment
+// variables or, for those not set, from sync_credentials.toml. A missing file
+// is no error. A file other users can read is refused, except on Windows,
+// which has no such permissions.
+func LoadSyncCredentials() (SyncCredentials, error) {
+	var creds SyncCredentials
+	path, err := SyncCredentialsPath()
+	if err != nil {
+		return creds, fmt.Errorf("failed to get sync credentials path: %w", err)
+	}
+	info, err := os.Stat(path)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return creds, fmt.Errorf("failed to read sync credentials: %w", err)
+	case runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0:
+		return creds, fmt.Errorf("%s can be read by other users, restrict it with: chmod 600 %s", path, path)
+	default:
+		if _, err := toml.DecodeFile(path, &creds); err != nil {
+			return creds, fmt.Errorf("failed to read sync credentials: %w", err)
+		}
+	}
+
+	for _, env := range []struct {
+		name  string
+		value *string
+	}{
+		{EnvAWSAccessKeyID, &creds.AccessKeyID},
+		{EnvAWSSecretAccessKey, &creds.SecretAccessKey},
+		{EnvSyncPassword, &creds.Password},
+	} {
+		if v := os.Getenv(env.name); v != "" {
+			*env.value = v
+		}
+	}
+	return creds, nil
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"os"
+	"strconv"
+)
+
+// Environment variables overriding options of the config file, so dotfile
+// managers and scripts can set them without editing the file. Boolean options
+// take the values accepted by strconv.ParseBool, such as 1/0 or true/false.
+const (
+	// EnvTheme overrides the theme name, e.g. TERMCHESS_THEME=modern
+	EnvTheme = "TERMCHESS_THEME"
+	// EnvUnicode overrides the use of Unicode pieces, e.g. TERMCHESS_UNICODE=0
+	EnvUnicode = "TERMCHESS_UNICODE"
+	// EnvColors overrides the use of piece colors
+	EnvColors = "TERMCHESS_COLORS"
+	// EnvCoordinates overrides showing the board coordinates
+	EnvCoordinates = "TERMCHESS_COORDINATES"
+	// EnvMoveHistory overrides showing the move history
+	EnvMoveHistory = "TERMCHESS_MOVE_HISTORY"
+	// EnvHelpText overrides showing the help text
+	EnvHelpText = "TERMCHESS_HELP_TEXT"
+	// EnvNotation overrides the notation of the move history, e.g. TERMCHESS_NOTATION=long
+	EnvNotation = "TERMCHESS_NOTATION"
+	// EnvUpdateCheck overrides how often updates are checked for, e.g. TERMCHESS_UPDATE_CHECK=off
+	EnvUpdateCheck = "TERMCHESS_UPDATE_CHECK"
+)
+
+// envOption is an option that an environment variable overrides: a string
+// option, or a boolean one if b is set.
+type envOption struct {
+	name string
+	s    *string
+	b    *bool
+}
+
+// envOptions returns the options of cfg the TERMCHESS_* variables override.
+func envOptions(cfg *Config) []envOption {
+	return []envOption{
+		{name: EnvTheme, s: &cfg.Theme},
+		{name: EnvNotation, s: &cfg.Notation},
+		{name: EnvUpdateCheck, s: &cfg.UpdateCheck},
+		{name: EnvUnicode, b: &cfg.UseUnicode},
+		{name: EnvColors, b: &cfg.UseColors},
+		{name: EnvCoordinates, b: &cfg.ShowCoords},
+		{name: EnvMoveHistory, b: &cfg.ShowMoveHistory},
+		{name: EnvHelpText, b: &cfg.ShowHelpText},
+	}
+}
+
+// ApplyEnvOverrides returns cfg with the options set by the TERMCHESS_*
+// environment variables replaced. Empty variables and boolean variables that
+// cannot be parsed are ignored.
+func ApplyEnvOverrides(cfg Config) Config {
+	for _, opt := range envOptions(&cfg) {
+		value := os.Getenv(opt.name)
+		switch {
+		case opt.b != nil:
+			if v, err := strconv.ParseBool(value); err == nil {
+				*opt.b = v
+			}
+		case value != "":
+			*opt.s = value
+		}
+	}
+	return cfg
+}
+
+// removeEnvOverrides returns cfg with the options that still hold the value
+// of their environment override put back to their value in file, so saving
+// the config doesn't make the overrides permanent. Options changed since, e.g.
+// in Settings, keep their new value.
+func removeEnvOverrides(cfg, file Config) Config {
+	overridden := ApplyEnvOverrides(file)
+	current, original, env := envOptions(&cfg), envOptions(&file), envOptions(&overridden)
+	for i, opt := range current {
+		if os.Getenv(opt.name) == "" {
+			continue
+		}
+		if opt.b != nil && *opt.b == *env[i].b {
+			*opt.b = *original[i].b
+		}
+		if opt.s != nil && *opt.s == *env[i].s {
+			*opt.s = *original[i].s
+		}
+	}
+	return cfg
+}
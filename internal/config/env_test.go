@@ -0,0 +1,86 @@
+package config
+
+import "testing"
+
+// TestApplyEnvOverrides tests that TERMCHESS_* variables replace the configured options
+func TestApplyEnvOverrides(t *testing.T) {
+	t.Setenv(EnvTheme, "modern")
+	t.Setenv(EnvUnicode, "1")
+	t.Setenv(EnvColors, "false")
+	t.Setenv(EnvNotation, "long")
+
+	cfg := ApplyEnvOverrides(DefaultConfig())
+
+	if cfg.Theme != "modern" {
+		t.Errorf("Theme = %q, want modern", cfg.Theme)
+	}
+	if !cfg.UseUnicode {
+		t.Error("Expected TERMCHESS_UNICODE=1 to enable Unicode pieces")
+	}
+	if cfg.UseColors {
+		t.Error("Expected TERMCHESS_COLORS=false to disable colors")
+	}
+	if cfg.Notation != "long" {
+		t.Errorf("Notation = %q, want long", cfg.Notation)
+	}
+	if !cfg.ShowCoords {
+		t.Error("Expected options without a variable to keep their value")
+	}
+}
+
+// TestApplyEnvOverridesIgnoresInvalidValues tests that unparsable booleans leave the option unchanged
+func TestApplyEnvOverridesIgnoresInvalidValues(t *testing.T) {
+	t.Setenv(EnvCoordinates, "maybe")
+	t.Setenv(EnvTheme, "")
+
+	cfg := ApplyEnvOverrides(DefaultConfig())
+
+	if !cfg.ShowCoords {
+		t.Error("Expected an invalid TERMCHESS_COORDINATES to be ignored")
+	}
+	if cfg.Theme != DefaultTheme {
+		t.Errorf("Theme = %q, want the default for an empty TERMCHESS_THEME", cfg.Theme)
+	}
+}
+
+// TestLoadConfigAppliesEnvOverrides tests that overrides apply on top of the config file
+func TestLoadConfigAppliesEnvOverrides(t *testing.T) {
+	if err := SaveConfig(DefaultConfig()); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+	t.Setenv(EnvUnicode, "true")
+
+	if cfg := LoadConfig(); !cfg.UseUnicode {
+		t.Error("Expected TERMCHESS_UNICODE to override the config file")
+	}
+}
+
+// TestSaveConfigLeavesOutEnvOverrides tests that saving the loaded config
+// doesn't write the overrides to the file, but keeps options changed since
+func TestSaveConfigLeavesOutEnvOverrides(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", "")
+	if err := SaveConfig(DefaultConfig()); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+	t.Setenv(EnvUnicode, "true")
+	t.Setenv(EnvTheme, "modern")
+
+	cfg := LoadConfig()
+	cfg.ShowCoords = !cfg.ShowCoords
+	cfg.Theme = "minimalist"
+	if err := SaveConfig(cfg); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+
+	saved := loadConfigFile()
+	if saved.UseUnicode {
+		t.Error("Expected TERMCHESS_UNICODE not to be saved to the config file")
+	}
+	if saved.ShowCoords == DefaultConfig().ShowCoords {
+		t.Error("Expected the changed option to be saved")
+	}
+	if saved.Theme != "minimalist" {
+		t.Errorf("Theme = %q, want the theme chosen over the override", saved.Theme)
+	}
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// MaxFENHistory is the number of recent FENs kept in the FEN history.
+const MaxFENHistory = 8
+
+// LoadFENHistory reads the recently loaded and exported FENs from
+// fen_history in the data directory, most recent first. It returns no FENs, and no
+// error, if the history doesn't exist yet.
+func LoadFENHistory() ([]string, error) {
+	historyPath, err := FENHistoryPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get FEN history path: %w", err)
+	}
+
+	data, err := ReadEncodedFile(historyPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read FEN history: %w", err)
+	}
+
+	var fens []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if fen := strings.TrimSpace(line); fen != "" && len(fens) < MaxFENHistory {
+			fens = append(fens, fen)
+		}
+	}
+	return fens, nil
+}
+
+// AddFENHistory records fen as the most recent entry of the FEN history,
+// moving it to the front if it is already there and dropping the oldest
+// entries beyond MaxFENHistory.
+func AddFENHistory(fen string) error {
+	fen = strings.TrimSpace(fen)
+	if fen == "" {
+		return nil
+	}
+
+	history, err := LoadFENHistory()
+	if err != nil {
+		return err
+	}
+	fens := []string{fen}
+	for _, old := range history {
+		if old != fen && len(fens) < MaxFENHistory {
+			fens = append(fens, old)
+		}
+	}
+
+	historyPath, err := FENHistoryPath()
+	if err != nil {
+		return fmt.Errorf("failed to get FEN history path: %w", err)
+	}
+	dataDir, err := GetDataDir()
+	if err != nil {
+		return fmt.Errorf("failed to get data directory: %w", err)
+	}
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+	if err := WriteEncodedFile(historyPath, []byte(strings.Join(fens, "\n")+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write FEN history: %w", err)
+	}
+	return nil
+}
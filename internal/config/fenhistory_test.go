@@ -0,0 +1,39 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestFENHistory tests that the FEN history keeps the most recent FENs first, without duplicates
+func TestFENHistory(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if fens, err := LoadFENHistory(); err != nil || len(fens) != 0 {
+		t.Fatalf("LoadFENHistory() = %v, %v; want an empty history", fens, err)
+	}
+
+	for i := 1; i <= MaxFENHistory+2; i++ {
+		if err := AddFENHistory(fmt.Sprintf("4k3/8/8/8/8/8/8/4K3 w - - 0 %d", i)); err != nil {
+			t.Fatalf("AddFENHistory failed: %v", err)
+		}
+	}
+	// Adding a FEN again moves it to the front
+	if err := AddFENHistory("4k3/8/8/8/8/8/8/4K3 w - - 0 5"); err != nil {
+		t.Fatalf("AddFENHistory failed: %v", err)
+	}
+
+	fens, err := LoadFENHistory()
+	if err != nil {
+		t.Fatalf("LoadFENHistory failed: %v", err)
+	}
+	if len(fens) != MaxFENHistory {
+		t.Fatalf("Expected %d FENs, got %d: %v", MaxFENHistory, len(fens), fens)
+	}
+	want := []string{"0 5", "0 10", "0 9", "0 8", "0 7", "0 6", "0 4", "0 3"}
+	for i, suffix := range want {
+		if fens[i] != "4k3/8/8/8/8/8/8/4K3 w - - "+suffix {
+			t.Errorf("FEN %d = %q, want the one ending in %q", i, fens[i], suffix)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+)
+
+// ConfigVersion is the version of the config file format written by SaveConfig.
+// Config files without a version were written before versions were introduced
+// and are version 0.
+const ConfigVersion = 1
+
+// SaveVersion is the version of the saved game format written by SaveGameInfo.
+// Version 1 is a bare FEN in savegame.fen; version 2 adds savegame.toml with the
+// game type, the user's color and the bot.
+const SaveVersion = 2
+
+// FormatError reports a file written by a newer TermChess in a format this
+// version cannot read.
+type FormatError struct {
+	// File is the name of the file, e.g. "config.toml".
+	File string
+	// Version is the format version of the file.
+	Version int
+	// Supported is the newest format version this TermChess reads.
+	Supported int
+}
+
+func (e *FormatError) Error() string {
+	return fmt.Sprintf("%s was written by a newer version of TermChess (format %d, this version reads up to %d); upgrade TermChess to use it",
+		e.File, e.Version, e.Supported)
+}
+
+// migration upgrades a decoded file by one format version. meta tells which keys
+// the file defines, so a migration can fill in the ones older versions lacked.
+type migration[T any] func(v *T, meta toml.MetaData)
+
+// configMigrations upgrade config files: configMigrations[v] turns version v into v+1.
+var configMigrations = map[int]migration[ConfigFile]{
+	// Config files written before the setting existed keep the default threshold
+	0: func(cf *ConfigFile, meta toml.MetaData) {
+		if !meta.IsDefined("game", "bot_resign_threshold") {
+			cf.Game.BotResignThreshold = DefaultBotResignThreshold
+		}
+	},
+}
+
+// saveMigrations upgrade saved games: saveMigrations[v] turns version v into v+1.
+var saveMigrations = map[int]migration[SavedGameInfo]{
+	// Games saved before savegame.toml existed were all Player vs Player games
+	1: func(info *SavedGameInfo, _ toml.MetaData) {
+		info.GameType = "pvp"
+	},
+}
+
+// migrate upgrades v, a file of the given format version, to the current
+// version by running its migrations in order. It returns a *FormatError if the
+// file is newer than current.
+func migrate[T any](file string, v *T, meta toml.MetaData, version, current int, migrations map[int]migration[T]) error {
+	if version > current {
+		return &FormatError{File: file, Version: version, Supported: current}
+	}
+	for ; version < current; version++ {
+		if m := migrations[version]; m != nil {
+			m(v, meta)
+		}
+	}
+	return nil
+}
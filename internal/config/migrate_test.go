@@ -0,0 +1,110 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeConfigFile writes content as the config file of a temporary home directory.
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	configPath, err := getConfigFilePath()
+	if err != nil {
+		t.Fatalf("getConfigFilePath failed: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		t.Fatalf("Failed to create config directory: %v", err)
+	}
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	return configPath
+}
+
+func TestConfigVersionWritten(t *testing.T) {
+	configPath := writeConfigFile(t, "")
+	if err := SaveConfig(DefaultConfig()); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read config file: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "version = 1\n") {
+		t.Errorf("Expected the config file to start with its version, got:\n%s", data)
+	}
+}
+
+func TestMigrateLegacyConfig(t *testing.T) {
+	// A version 0 file, written before the resign threshold existed
+	writeConfigFile(t, "[display]\nuse_unicode = true\n")
+	cfg := LoadConfig()
+	if !cfg.UseUnicode || cfg.BotResignThreshold != DefaultBotResignThreshold {
+		t.Errorf("Loaded unicode %v, threshold %v; want true and the default threshold", cfg.UseUnicode, cfg.BotResignThreshold)
+	}
+	if err := CheckConfig(); err != nil {
+		t.Errorf("CheckConfig() = %v, want nil for an older file", err)
+	}
+
+	// Version 1 files always write the threshold, so 0 means disabled
+	writeConfigFile(t, "version = 1\n[game]\nbot_resign_threshold = 0.0\n")
+	if cfg := LoadConfig(); cfg.BotResignThreshold != 0 {
+		t.Errorf("Loaded threshold %v, want 0", cfg.BotResignThreshold)
+	}
+}
+
+func TestNewerConfig(t *testing.T) {
+	configPath := writeConfigFile(t, "version = 99\n[display]\nuse_unicode = true\n")
+
+	// What can be read is still used
+	if cfg := LoadConfig(); !cfg.UseUnicode {
+		t.Error("Expected the settings of a newer config file to be read")
+	}
+
+	var formatErr *FormatError
+	if err := CheckConfig(); !errors.As(err, &formatErr) || formatErr.Version != 99 || formatErr.Supported != ConfigVersion {
+		t.Fatalf("CheckConfig() = %v, want a FormatError for version 99", err)
+	}
+	if !strings.Contains(formatErr.Error(), "newer version of TermChess") {
+		t.Errorf("Error() = %q, want it to say a newer TermChess wrote the file", formatErr.Error())
+	}
+
+	if err := SaveConfig(DefaultConfig()); !errors.As(err, &formatErr) {
+		t.Errorf("SaveConfig() = %v, want a FormatError instead of overwriting the file", err)
+	}
+	if data, _ := os.ReadFile(configPath); !strings.HasPrefix(string(data), "version = 99") {
+		t.Errorf("Expected the newer config file to be kept, got:\n%s", data)
+	}
+}
+
+func TestMigrateSavedGame(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	infoPath, err := SaveGameInfoPath()
+	if err != nil {
+		t.Fatalf("SaveGameInfoPath failed: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(infoPath), 0755); err != nil {
+		t.Fatalf("Failed to create config directory: %v", err)
+	}
+
+	// An info file written before it had a version is format 2
+	if err := os.WriteFile(infoPath, []byte("game_type = \"pvbot\"\nuser_color = \"black\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write save game info: %v", err)
+	}
+	info, err := LoadGameInfo()
+	if err != nil || info.GameType != "pvbot" || info.UserColor != "black" || info.Version != SaveVersion {
+		t.Errorf("LoadGameInfo() = %+v, %v; want the Player vs Bot game in the current version", info, err)
+	}
+
+	if err := os.WriteFile(infoPath, []byte("version = 99\ngame_type = \"pvbot\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write save game info: %v", err)
+	}
+	var formatErr *FormatError
+	if _, err := LoadGameInfo(); !errors.As(err, &formatErr) || formatErr.File != "savegame.toml" {
+		t.Errorf("LoadGameInfo() = %v, want a FormatError for savegame.toml", err)
+	}
+}
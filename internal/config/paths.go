@@ -1,23 +1,132 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 )
 
-// GetConfigDir returns the path to the TermChess configuration directory.
-// It returns ~/.termchess/ or an error if the home directory cannot be determined.
-func GetConfigDir() (string, error) {
+// TermChess keeps its config file and profiles in the config directory, and
+// everything it records, such as saved games, exported games and statistics,
+// the FEN history and the correspondence mailbox, in the data directory. They
+// follow the platform's conventions:
+//
+//   - Linux and other Unixes: $XDG_CONFIG_HOME/termchess (~/.config/termchess)
+//     and $XDG_DATA_HOME/termchess (~/.local/share/termchess)
+//   - macOS: both in ~/Library/Application Support/TermChess
+//   - Windows: %AppData%\TermChess and %LocalAppData%\TermChess
+//
+// Installations that already have ~/.termchess, where all files used to be
+// kept, go on using it for both. SetDataDir (the --data-dir flag) puts all
+// files in one directory of the user's choice instead.
+
+// dataDirOverride is the directory set with SetDataDir, or "" for the defaults.
+var dataDirOverride string
+
+// SetDataDir makes TermChess keep all its files, config and data alike, in dir.
+// An empty dir goes back to the default directories.
+func SetDataDir(dir string) error {
+	if dir != "" {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return fmt.Errorf("invalid data directory %q: %w", dir, err)
+		}
+		dir = abs
+	}
+	dataDirOverride = dir
+	return nil
+}
+
+// legacyDir returns ~/.termchess if it exists, or "" if it doesn't.
+func legacyDir() (string, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return "", fmt.Errorf("failed to get home directory: %w", err)
 	}
-	return filepath.Join(homeDir, ".termchess"), nil
+	dir := filepath.Join(homeDir, ".termchess")
+	if info, err := os.Stat(dir); err == nil && info.IsDir() {
+		return dir, nil
+	}
+	return "", nil
+}
+
+// appDirName returns the name of TermChess's directories inside the platform's
+// base directories: capitalized on macOS and Windows, lowercase elsewhere.
+func appDirName() string {
+	switch runtime.GOOS {
+	case "darwin", "windows":
+		return "TermChess"
+	default:
+		return "termchess"
+	}
+}
+
+// GetConfigDir returns the path to the TermChess configuration directory: the
+// directory set with SetDataDir, ~/.termchess if it exists, or the platform's
+// config directory. It returns an error if none can be determined.
+func GetConfigDir() (string, error) {
+	if dataDirOverride != "" {
+		return dataDirOverride, nil
+	}
+	if dir, err := legacyDir(); err != nil || dir != "" {
+		return dir, err
+	}
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get config directory: %w", err)
+	}
+	return filepath.Join(base, appDirName()), nil
 }
 
-// getConfigFilePath returns the full path to the configuration file.
+// GetDataDir returns the path to the directory of saved games and the other
+// files TermChess records: the directory set with SetDataDir, ~/.termchess if
+// it exists, or the platform's data directory. It returns an error if none can
+// be determined.
+func GetDataDir() (string, error) {
+	if dataDirOverride != "" {
+		return dataDirOverride, nil
+	}
+	if dir, err := legacyDir(); err != nil || dir != "" {
+		return dir, err
+	}
+	base, err := userDataDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get data directory: %w", err)
+	}
+	return filepath.Join(base, appDirName()), nil
+}
+
+// userDataDir returns the platform's base directory for user data, the
+// counterpart of os.UserConfigDir.
+func userDataDir() (string, error) {
+	switch runtime.GOOS {
+	case "windows":
+		if dir := os.Getenv("LocalAppData"); dir != "" {
+			return dir, nil
+		}
+		return "", errors.New("%LocalAppData% is not defined")
+	case "darwin", "ios", "plan9":
+		// Data and config share a directory on these platforms
+		return os.UserConfigDir()
+	}
+	if dir := os.Getenv("XDG_DATA_HOME"); filepath.IsAbs(dir) {
+		return dir, nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".local", "share"), nil
+}
+
+// getConfigFilePath returns the full path to the configuration file: the file
+// of the active profile, if there is one, or config.toml.
 func getConfigFilePath() (string, error) {
+	if activeProfile != "" {
+		return getProfilePath(activeProfile)
+	}
 	configDir, err := GetConfigDir()
 	if err != nil {
 		return "", err
@@ -25,22 +134,87 @@ func getConfigFilePath() (string, error) {
 	return filepath.Join(configDir, "config.toml"), nil
 }
 
+// getConfigReadPath returns the full path to the configuration file to read.
+// It is the file of getConfigFilePath, except for a profile that has no file
+// yet, which starts from config.toml.
+func getConfigReadPath() (string, error) {
+	configPath, err := getConfigFilePath()
+	if err != nil || activeProfile == "" {
+		return configPath, err
+	}
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		configDir, err := GetConfigDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(configDir, "config.toml"), nil
+	}
+	return configPath, nil
+}
+
 // SaveGamePath returns the full path to the save game file.
 // Exported for testing purposes.
 func SaveGamePath() (string, error) {
-	configDir, err := GetConfigDir()
+	dataDir, err := GetDataDir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(configDir, "savegame.fen"), nil
+	return filepath.Join(dataDir, "savegame.fen"), nil
 }
 
-// GetConfigPath returns the absolute path to the configuration file.
-// The config file is stored at ~/.termchess/config.toml
-func GetConfigPath() (string, error) {
-	homeDir, err := os.UserHomeDir()
+// SaveGameNotesPath returns the full path to the file of notes kept with the
+// saved game.
+func SaveGameNotesPath() (string, error) {
+	dataDir, err := GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dataDir, "savegame.notes"), nil
+}
+
+// SaveGameInfoPath returns the full path to the file describing the saved game:
+// its game type, the user's color and the bot.
+func SaveGameInfoPath() (string, error) {
+	dataDir, err := GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dataDir, "savegame.toml"), nil
+}
+
+// FENHistoryPath returns the full path to the file of recently loaded and
+// exported FENs.
+func FENHistoryPath() (string, error) {
+	dataDir, err := GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dataDir, "fen_history"), nil
+}
+
+// SyncStatePath returns the full path to the file recording the files as they
+// were at the last sync.
+func SyncStatePath() (string, error) {
+	dataDir, err := GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dataDir, "sync_state.toml"), nil
+}
+
+// SyncCredentialsPath returns the full path to the file holding the passwords
+// and keys sync logs in with, sync_credentials.toml in the config directory.
+func SyncCredentialsPath() (string, error) {
+	configDir, err := GetConfigDir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(homeDir, ".termchess", "config.toml"), nil
+	return filepath.Join(configDir, "sync_credentials.toml"), nil
+}
+
+// GetConfigPath returns the absolute path to the configuration file.
+// The config file is stored as config.toml in the config directory, or as
+// profiles/<name>.toml while a profile is active.
+func GetConfigPath() (string, error) {
+	return getConfigFilePath()
 }
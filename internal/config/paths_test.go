@@ -0,0 +1,76 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestDefaultDirs(t *testing.T) {
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		t.Skip("XDG directories are used on Linux and other Unixes")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg-config"))
+	t.Setenv("XDG_DATA_HOME", filepath.Join(home, "xdg-data"))
+
+	if dir, err := GetConfigDir(); err != nil || dir != filepath.Join(home, "xdg-config", "termchess") {
+		t.Errorf("GetConfigDir() = %q, %v; want termchess in XDG_CONFIG_HOME", dir, err)
+	}
+	if dir, err := GetDataDir(); err != nil || dir != filepath.Join(home, "xdg-data", "termchess") {
+		t.Errorf("GetDataDir() = %q, %v; want termchess in XDG_DATA_HOME", dir, err)
+	}
+
+	// Without XDG variables the defaults are ~/.config and ~/.local/share
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("XDG_DATA_HOME", "")
+	if dir, _ := GetConfigDir(); dir != filepath.Join(home, ".config", "termchess") {
+		t.Errorf("GetConfigDir() = %q, want ~/.config/termchess", dir)
+	}
+	if dir, _ := GetDataDir(); dir != filepath.Join(home, ".local", "share", "termchess") {
+		t.Errorf("GetDataDir() = %q, want ~/.local/share/termchess", dir)
+	}
+}
+
+func TestLegacyDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	legacy := filepath.Join(home, ".termchess")
+	if err := os.Mkdir(legacy, 0755); err != nil {
+		t.Fatalf("Failed to create ~/.termchess: %v", err)
+	}
+
+	// Existing installations keep all their files where they are
+	if dir, _ := GetConfigDir(); dir != legacy {
+		t.Errorf("GetConfigDir() = %q, want %q", dir, legacy)
+	}
+	if dir, _ := GetDataDir(); dir != legacy {
+		t.Errorf("GetDataDir() = %q, want %q", dir, legacy)
+	}
+}
+
+func TestSetDataDir(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	if err := SetDataDir(dir); err != nil {
+		t.Fatalf("SetDataDir() error = %v", err)
+	}
+	defer SetDataDir("")
+
+	configPath, _ := GetConfigPath()
+	savePath, _ := SaveGamePath()
+	if configPath != filepath.Join(dir, "config.toml") || savePath != filepath.Join(dir, "savegame.fen") {
+		t.Errorf("Config file %q and save %q, want both in %q", configPath, savePath, dir)
+	}
+
+	cfg := DefaultConfig()
+	cfg.Theme = "modern"
+	if err := SaveConfig(cfg); err != nil {
+		t.Fatalf("SaveConfig() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "config.toml")); err != nil {
+		t.Errorf("Expected the config file in the data directory: %v", err)
+	}
+}
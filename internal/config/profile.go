@@ -0,0 +1,98 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Profiles are named configurations, such as "streaming" or "ssh", each with
+// its own theme, display and bot settings. A profile is stored as
+// profiles/<name>.toml in the config directory, in the same format as config.toml. While a
+// profile is active, LoadConfig and SaveConfig use its file instead of
+// config.toml; a profile that has no file yet starts from config.toml.
+
+// profileExt is the file extension of profile files.
+const profileExt = ".toml"
+
+// activeProfile is the name of the profile in use, or "" for config.toml.
+var activeProfile string
+
+// ValidateProfileName reports whether name can be used as a profile name.
+// Names become file names, so they cannot contain path separators or start
+// with a dot.
+func ValidateProfileName(name string) error {
+	switch {
+	case strings.TrimSpace(name) == "":
+		return fmt.Errorf("profile name is empty")
+	case strings.ContainsAny(name, `/\`):
+		return fmt.Errorf("profile name %q contains a path separator", name)
+	case strings.HasPrefix(name, "."):
+		return fmt.Errorf("profile name %q starts with a dot", name)
+	}
+	return nil
+}
+
+// SetProfile makes the named profile the one LoadConfig and SaveConfig use.
+// An empty name switches back to config.toml.
+func SetProfile(name string) error {
+	if name != "" {
+		if err := ValidateProfileName(name); err != nil {
+			return err
+		}
+	}
+	activeProfile = name
+	return nil
+}
+
+// ActiveProfile returns the name of the profile in use, or "" if config.toml is used.
+func ActiveProfile() string {
+	return activeProfile
+}
+
+// getProfilesDir returns the directory profile files are stored in.
+func getProfilesDir() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "profiles"), nil
+}
+
+// getProfilePath returns the full path to the file of the named profile.
+func getProfilePath(name string) (string, error) {
+	profilesDir, err := getProfilesDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(profilesDir, name+profileExt), nil
+}
+
+// ListProfiles returns the names of the saved profiles, sorted. It returns no
+// profiles, and no error, if none have been saved.
+func ListProfiles() ([]string, error) {
+	profilesDir, err := getProfilesDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(profilesDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profiles directory: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), profileExt)
+		if ok && !entry.IsDir() && ValidateProfileName(name) == nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+// useProfile activates the named profile for the test, in a temporary home directory
+func useProfile(t *testing.T, name string) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	if err := SetProfile(name); err != nil {
+		t.Fatalf("SetProfile(%q) failed: %v", name, err)
+	}
+	t.Cleanup(func() { _ = SetProfile("") })
+}
+
+// TestValidateProfileName tests which names can be used for profiles
+func TestValidateProfileName(t *testing.T) {
+	for _, name := range []string{"streaming", "work laptop", "ssh"} {
+		if err := ValidateProfileName(name); err != nil {
+			t.Errorf("ValidateProfileName(%q) = %v, want nil", name, err)
+		}
+	}
+	for _, name := range []string{"", "  ", "../config", `a\b`, ".hidden"} {
+		if err := ValidateProfileName(name); err == nil {
+			t.Errorf("ValidateProfileName(%q) = nil, want an error", name)
+		}
+	}
+	if err := SetProfile("../x"); err == nil || ActiveProfile() != "" {
+		t.Error("Expected SetProfile to reject an invalid name and keep the current profile")
+	}
+}
+
+// TestProfileHasItsOwnConfig tests that a profile saves to its own file, apart from config.toml
+func TestProfileHasItsOwnConfig(t *testing.T) {
+	useProfile(t, "")
+
+	base := DefaultConfig()
+	base.Theme = "modern"
+	if err := SaveConfig(base); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+
+	if err := SetProfile("work laptop"); err != nil {
+		t.Fatalf("SetProfile failed: %v", err)
+	}
+	// A new profile starts from config.toml
+	if cfg := LoadConfig(); cfg.Theme != "modern" {
+		t.Errorf("New profile theme = %q, want modern from config.toml", cfg.Theme)
+	}
+
+	profile := DefaultConfig()
+	profile.Theme = "minimalist"
+	profile.BotDifficulty = "hard"
+	if err := SaveConfig(profile); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+	path, _ := GetConfigPath()
+	if !strings.HasSuffix(path, "profiles/work laptop.toml") {
+		t.Errorf("GetConfigPath() = %q, want the profile file", path)
+	}
+	if cfg := LoadConfig(); cfg.Theme != "minimalist" || cfg.BotDifficulty != "hard" {
+		t.Errorf("Profile theme %q, bot difficulty %q; want minimalist, hard", cfg.Theme, cfg.BotDifficulty)
+	}
+
+	if err := SetProfile(""); err != nil {
+		t.Fatalf("SetProfile failed: %v", err)
+	}
+	if cfg := LoadConfig(); cfg.Theme != "modern" {
+		t.Errorf("config.toml theme = %q, want it unchanged by the profile", cfg.Theme)
+	}
+}
+
+// TestListProfiles tests listing the saved profiles
+func TestListProfiles(t *testing.T) {
+	useProfile(t, "")
+
+	if names, err := ListProfiles(); err != nil || len(names) != 0 {
+		t.Fatalf("ListProfiles() = %v, %v; want no profiles", names, err)
+	}
+
+	for _, name := range []string{"streaming", "ssh"} {
+		if err := SetProfile(name); err != nil {
+			t.Fatalf("SetProfile failed: %v", err)
+		}
+		if err := SaveConfig(DefaultConfig()); err != nil {
+			t.Fatalf("SaveConfig failed: %v", err)
+		}
+	}
+
+	names, err := ListProfiles()
+	if err != nil {
+		t.Fatalf("ListProfiles failed: %v", err)
+	}
+	if strings.Join(names, ",") != "ssh,streaming" {
+		t.Errorf("ListProfiles() = %v, want [ssh streaming]", names)
+	}
+}
@@ -1,13 +1,33 @@
 package config
 
 import (
+	"bytes"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/BurntSushi/toml"
 	"github.com/Mgrdich/TermChess/internal/engine"
 )
 
-// SaveGame saves the current game state to ~/.termchess/savegame.fen.
+// SavedGameInfo is what resuming a saved game needs besides its position: the
+// game type and, for a Player vs Bot game, the user's color and the bot. It is
+// kept in savegame.toml in the data directory; saves without it are Player vs Player games.
+type SavedGameInfo struct {
+	// Version is the format version of the save, see SaveVersion.
+	Version int `toml:"version"`
+	// GameType is "pvp" or "pvbot".
+	GameType string `toml:"game_type"`
+	// UserColor is the color the user plays against the bot, "white" or "black".
+	UserColor string `toml:"user_color,omitempty"`
+	// BotDifficulty is the difficulty of the bot: "easy", "medium" or "hard".
+	BotDifficulty string `toml:"bot_difficulty,omitempty"`
+	// BotName is the registered bot played instead of a built-in one, if any.
+	BotName string `toml:"bot_name,omitempty"`
+}
+
+// SaveGame saves the current game state to savegame.fen in the data directory.
 // It converts the board to FEN format and writes it to the file.
 // Returns an error if the file cannot be written.
 func SaveGame(board *engine.Board) error {
@@ -17,29 +37,29 @@ func SaveGame(board *engine.Board) error {
 		return fmt.Errorf("failed to get save game path: %w", err)
 	}
 
-	// Get the config directory path
-	configDir, err := GetConfigDir()
+	// Get the data directory path
+	dataDir, err := GetDataDir()
 	if err != nil {
-		return fmt.Errorf("failed to get config directory: %w", err)
+		return fmt.Errorf("failed to get data directory: %w", err)
 	}
 
-	// Create the config directory if it doesn't exist
-	if err := os.MkdirAll(configDir, 0755); err != nil {
-		return fmt.Errorf("failed to create config directory: %w", err)
+	// Create the data directory if it doesn't exist
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
 	}
 
 	// Convert board to FEN
 	fen := board.ToFEN()
 
 	// Write FEN to file
-	if err := os.WriteFile(savePath, []byte(fen), 0644); err != nil {
+	if err := writeEncodedAtomic(savePath, []byte(fen), 0644, false, parseFEN); err != nil {
 		return fmt.Errorf("failed to write save game file: %w", err)
 	}
 
 	return nil
 }
 
-// LoadGame loads a saved game from ~/.termchess/savegame.fen.
+// LoadGame loads a saved game from savegame.fen in the data directory.
 // It reads the FEN from the file and creates a Board from it.
 // Returns an error if the file cannot be read or the FEN is invalid.
 func LoadGame() (*engine.Board, error) {
@@ -49,46 +69,173 @@ func LoadGame() (*engine.Board, error) {
 		return nil, fmt.Errorf("failed to get save game path: %w", err)
 	}
 
-	// Read the FEN from file
-	data, err := os.ReadFile(savePath)
+	// Read the FEN from file, or from its backup if it is damaged
+	var board *engine.Board
+	err = readEncodedRecovering(savePath, false, func(data []byte) error {
+		b, err := engine.FromFEN(string(data))
+		if err != nil {
+			return fmt.Errorf("failed to parse saved game FEN: %w", err)
+		}
+		board = b
+		return nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to read save game file: %w", err)
 	}
 
-	// Parse FEN and create board
-	board, err := engine.FromFEN(string(data))
+	return board, nil
+}
+
+// SaveGameNotes saves the notes taken during the saved game to
+// savegame.notes in the data directory, one per line. With no notes the file is removed.
+func SaveGameNotes(notes []string) error {
+	notesPath, err := SaveGameNotesPath()
+	if err != nil {
+		return fmt.Errorf("failed to get save game notes path: %w", err)
+	}
+	if len(notes) == 0 {
+		if err := removeWithBackup(notesPath); err != nil {
+			return fmt.Errorf("failed to delete save game notes: %w", err)
+		}
+		return nil
+	}
+
+	dataDir, err := GetDataDir()
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse saved game FEN: %w", err)
+		return fmt.Errorf("failed to get data directory: %w", err)
+	}
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
 	}
 
-	return board, nil
+	// Notes are typed on a single line, but keep the file one note per line regardless
+	lines := make([]string, len(notes))
+	for i, note := range notes {
+		lines[i] = strings.ReplaceAll(note, "\n", " ")
+	}
+	if err := writeEncodedAtomic(notesPath, withChecksum([]byte(strings.Join(lines, "\n")+"\n")), 0644, true, parseNothing); err != nil {
+		return fmt.Errorf("failed to write save game notes: %w", err)
+	}
+	return nil
+}
+
+// LoadGameNotes loads the notes kept with the saved game from
+// savegame.notes in the data directory. Returns no notes if the file doesn't exist.
+func LoadGameNotes() ([]string, error) {
+	notesPath, err := SaveGameNotesPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get save game notes path: %w", err)
+	}
+	var notes []string
+	err = readEncodedRecovering(notesPath, true, func(data []byte) error {
+		notes = nil
+		for _, line := range strings.Split(string(data), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				notes = append(notes, line)
+			}
+		}
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read save game notes: %w", err)
+	}
+	return notes, nil
 }
 
-// DeleteSaveGame deletes the saved game file at ~/.termchess/savegame.fen.
+// SaveGameInfo saves what the saved game is played between to
+// savegame.toml in the data directory, in the current format version.
+func SaveGameInfo(info SavedGameInfo) error {
+	info.Version = SaveVersion
+	infoPath, err := SaveGameInfoPath()
+	if err != nil {
+		return fmt.Errorf("failed to get save game info path: %w", err)
+	}
+	dataDir, err := GetDataDir()
+	if err != nil {
+		return fmt.Errorf("failed to get data directory: %w", err)
+	}
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(info); err != nil {
+		return fmt.Errorf("failed to encode save game info: %w", err)
+	}
+	if err := writeEncodedAtomic(infoPath, withChecksum(buf.Bytes()), 0644, true, parseTOML); err != nil {
+		return fmt.Errorf("failed to write save game info: %w", err)
+	}
+	return nil
+}
+
+// LoadGameInfo loads what the saved game is played between from
+// savegame.toml in the data directory, migrated to the current format version. Saves made
+// before the file existed are format 1. Returns a *FormatError if the game was
+// saved by a newer version of TermChess.
+func LoadGameInfo() (SavedGameInfo, error) {
+	infoPath, err := SaveGameInfoPath()
+	if err != nil {
+		return SavedGameInfo{}, fmt.Errorf("failed to get save game info path: %w", err)
+	}
+	// Info files without a version are format 2, the first to have them
+	var info SavedGameInfo
+	var meta toml.MetaData
+	err = readEncodedRecovering(infoPath, true, func(data []byte) error {
+		decoded := SavedGameInfo{Version: 2}
+		m, err := toml.Decode(string(data), &decoded)
+		if err != nil {
+			return err
+		}
+		info, meta = decoded, m
+		return nil
+	})
+	switch {
+	case os.IsNotExist(err):
+		info = SavedGameInfo{Version: 1}
+	case err != nil:
+		return SavedGameInfo{}, fmt.Errorf("failed to read save game info: %w", err)
+	}
+	if err := migrate(filepath.Base(infoPath), &info, meta, info.Version, SaveVersion, saveMigrations); err != nil {
+		return SavedGameInfo{}, err
+	}
+	info.Version = SaveVersion
+	return info, nil
+}
+
+// DeleteSaveGame deletes the saved game file at savegame.fen in the data directory,
+// along with its notes, info and backups.
 // Returns nil if the file doesn't exist (not an error condition).
 // Returns an error only if deletion fails.
 func DeleteSaveGame() error {
+	if err := SaveGameNotes(nil); err != nil {
+		return err
+	}
+	infoPath, err := SaveGameInfoPath()
+	if err != nil {
+		return fmt.Errorf("failed to get save game info path: %w", err)
+	}
+	if err := removeWithBackup(infoPath); err != nil {
+		return fmt.Errorf("failed to delete save game info: %w", err)
+	}
+
 	// Get the save game file path
 	savePath, err := SaveGamePath()
 	if err != nil {
 		return fmt.Errorf("failed to get save game path: %w", err)
 	}
 
-	// Check if file exists
-	if _, err := os.Stat(savePath); os.IsNotExist(err) {
-		// File doesn't exist, nothing to delete
-		return nil
-	}
-
-	// Delete the file
-	if err := os.Remove(savePath); err != nil {
+	// Delete the file and its backup, which don't have to exist
+	if err := removeWithBackup(savePath); err != nil {
 		return fmt.Errorf("failed to delete save game file: %w", err)
 	}
 
 	return nil
 }
 
-// SaveGameExists checks if a saved game file exists at ~/.termchess/savegame.fen.
+// SaveGameExists checks if a saved game file exists at savegame.fen in the data directory.
 // Returns true if the file exists, false otherwise.
 func SaveGameExists() bool {
 	savePath, err := SaveGamePath()
@@ -99,3 +246,15 @@ func SaveGameExists() bool {
 	_, err = os.Stat(savePath)
 	return err == nil
 }
+
+// parseFEN returns an error if data isn't a valid FEN. It verifies the saved
+// game before it is backed up.
+func parseFEN(data []byte) error {
+	_, err := engine.FromFEN(string(data))
+	return err
+}
+
+// parseNothing accepts any contents, for files whose checksum is all there is to verify.
+func parseNothing([]byte) error {
+	return nil
+}
@@ -20,9 +20,10 @@ func TestSaveGamePath(t *testing.T) {
 		t.Fatal("SaveGamePath returned empty string")
 	}
 
-	// Check that path contains .termchess directory
-	if !strings.Contains(path, ".termchess") {
-		t.Errorf("SaveGamePath %q does not contain .termchess", path)
+	// Check that path is in the data directory
+	dataDir, _ := GetDataDir()
+	if filepath.Dir(path) != dataDir {
+		t.Errorf("SaveGamePath %q is not in the data directory %q", path, dataDir)
 	}
 
 	// Check that path ends with savegame.fen
@@ -69,9 +70,12 @@ func TestSaveGame(t *testing.T) {
 	os.Remove(path)
 }
 
-// TestSaveGameCreatesDirectory tests that SaveGame creates the .termchess directory
+// TestSaveGameCreatesDirectory tests that SaveGame creates the data directory
 func TestSaveGameCreatesDirectory(t *testing.T) {
-	// Get the .termchess directory path
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_DATA_HOME", "")
+
+	// Get the data directory path
 	path, _ := SaveGamePath()
 	saveDir := filepath.Dir(path)
 
@@ -87,7 +91,7 @@ func TestSaveGameCreatesDirectory(t *testing.T) {
 
 	// Verify directory was created
 	if _, err := os.Stat(saveDir); os.IsNotExist(err) {
-		t.Fatalf("SaveGame did not create .termchess directory at %s", saveDir)
+		t.Fatalf("SaveGame did not create the data directory at %s", saveDir)
 	}
 
 	// Clean up
@@ -143,6 +147,8 @@ func TestLoadGameInvalidFEN(t *testing.T) {
 	path, _ := SaveGamePath()
 	saveDir := filepath.Dir(path)
 	os.MkdirAll(saveDir, 0755)
+	// With no backup to recover from
+	os.Remove(path + ".bak")
 
 	err := os.WriteFile(path, []byte("invalid fen string"), 0644)
 	if err != nil {
@@ -321,3 +327,59 @@ func TestSaveGameFilePermissions(t *testing.T) {
 	// Clean up
 	os.Remove(path)
 }
+
+// TestSaveGameNotes tests that notes are saved with the game and deleted with it
+func TestSaveGameNotes(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	notes, err := LoadGameNotes()
+	if err != nil || notes != nil {
+		t.Fatalf("LoadGameNotes with no file = %v, %v, want no notes", notes, err)
+	}
+
+	if err := SaveGame(engine.NewBoard()); err != nil {
+		t.Fatalf("SaveGame failed: %v", err)
+	}
+	if err := SaveGameNotes([]string{"Opening prep", "Missed\nthe fork"}); err != nil {
+		t.Fatalf("SaveGameNotes failed: %v", err)
+	}
+	notes, err = LoadGameNotes()
+	if err != nil {
+		t.Fatalf("LoadGameNotes failed: %v", err)
+	}
+	if len(notes) != 2 || notes[0] != "Opening prep" || notes[1] != "Missed the fork" {
+		t.Errorf("LoadGameNotes = %q, want the two notes", notes)
+	}
+
+	if err := DeleteSaveGame(); err != nil {
+		t.Fatalf("DeleteSaveGame failed: %v", err)
+	}
+	if notes, _ := LoadGameNotes(); notes != nil {
+		t.Errorf("Expected DeleteSaveGame to delete the notes, got %q", notes)
+	}
+}
+
+// TestSaveGameInfo tests that the game type, color and bot are saved with the game and deleted with it
+func TestSaveGameInfo(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	info, err := LoadGameInfo()
+	if err != nil || info != (SavedGameInfo{Version: SaveVersion, GameType: "pvp"}) {
+		t.Fatalf("LoadGameInfo with no file = %+v, %v, want a Player vs Player game", info, err)
+	}
+
+	want := SavedGameInfo{Version: SaveVersion, GameType: "pvbot", UserColor: "black", BotDifficulty: "hard"}
+	if err := SaveGameInfo(want); err != nil {
+		t.Fatalf("SaveGameInfo failed: %v", err)
+	}
+	if info, err = LoadGameInfo(); err != nil || info != want {
+		t.Errorf("LoadGameInfo = %+v, %v, want %+v", info, err, want)
+	}
+
+	if err := DeleteSaveGame(); err != nil {
+		t.Fatalf("DeleteSaveGame failed: %v", err)
+	}
+	if info, _ := LoadGameInfo(); info.GameType != "pvp" {
+		t.Errorf("Expected DeleteSaveGame to delete the info, got %+v", info)
+	}
+}
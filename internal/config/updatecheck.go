@@ -0,0 +1,85 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// UpdateCheckRecord remembers the last update check, so checks can be spaced
+// out and an available update is still shown until the next one.
+type UpdateCheckRecord struct {
+	// CheckedAt is when the latest release was last looked up.
+	CheckedAt time.Time `toml:"checked_at"`
+	// LatestVersion is the latest release found then, e.g. "v0.2.0".
+	LatestVersion string `toml:"latest_version,omitempty"`
+}
+
+// UpdateCheckPath returns the full path to the file recording the last update check.
+func UpdateCheckPath() (string, error) {
+	dataDir, err := GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dataDir, "update_check.toml"), nil
+}
+
+// LoadUpdateCheck reads the record of the last update check. It returns a zero
+// record, and no error, if updates were never checked for.
+func LoadUpdateCheck() (UpdateCheckRecord, error) {
+	recordPath, err := UpdateCheckPath()
+	if err != nil {
+		return UpdateCheckRecord{}, fmt.Errorf("failed to get update check path: %w", err)
+	}
+	var record UpdateCheckRecord
+	_, err = toml.DecodeFile(recordPath, &record)
+	if os.IsNotExist(err) {
+		return UpdateCheckRecord{}, nil
+	}
+	if err != nil {
+		return UpdateCheckRecord{}, fmt.Errorf("failed to read update check: %w", err)
+	}
+	return record, nil
+}
+
+// SaveUpdateCheck records an update check in the data directory.
+func SaveUpdateCheck(record UpdateCheckRecord) error {
+	recordPath, err := UpdateCheckPath()
+	if err != nil {
+		return fmt.Errorf("failed to get update check path: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(recordPath), 0755); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(record); err != nil {
+		return fmt.Errorf("failed to encode update check: %w", err)
+	}
+	if err := os.WriteFile(recordPath, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write update check: %w", err)
+	}
+	return nil
+}
+
+// UpdateCheckDue reports whether an update check is due at now under the given
+// frequency, if the last one was at last. Unknown frequencies are treated as
+// DefaultUpdateCheck.
+func UpdateCheckDue(frequency string, last, now time.Time) bool {
+	var interval time.Duration
+	switch frequency {
+	case UpdateCheckOff:
+		return false
+	case UpdateCheckStartup:
+		return true
+	case UpdateCheckWeekly:
+		interval = 7 * 24 * time.Hour
+	default:
+		interval = 24 * time.Hour
+	}
+	// A clock set back since the last check doesn't postpone the next one
+	return now.Sub(last) >= interval || now.Before(last)
+}
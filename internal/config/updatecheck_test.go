@@ -0,0 +1,66 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUpdateCheckDue(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		frequency string
+		last      time.Time
+		want      bool
+	}{
+		{UpdateCheckOff, time.Time{}, false},
+		{UpdateCheckStartup, now, true},
+		{UpdateCheckDaily, time.Time{}, true},
+		{UpdateCheckDaily, now.Add(-time.Hour), false},
+		{UpdateCheckDaily, now.Add(-25 * time.Hour), true},
+		{UpdateCheckWeekly, now.Add(-48 * time.Hour), false},
+		{UpdateCheckWeekly, now.Add(-8 * 24 * time.Hour), true},
+		{"hourly", now.Add(-time.Hour), false},
+		{UpdateCheckDaily, now.Add(time.Hour), true},
+	}
+	for _, tt := range tests {
+		if got := UpdateCheckDue(tt.frequency, tt.last, now); got != tt.want {
+			t.Errorf("UpdateCheckDue(%q, %v) = %v, want %v", tt.frequency, now.Sub(tt.last), got, tt.want)
+		}
+	}
+}
+
+func TestUpdateCheckRecord(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	record, err := LoadUpdateCheck()
+	if err != nil || !record.CheckedAt.IsZero() {
+		t.Fatalf("LoadUpdateCheck() = %+v, %v; want a zero record before the first check", record, err)
+	}
+
+	checked := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	if err := SaveUpdateCheck(UpdateCheckRecord{CheckedAt: checked, LatestVersion: "v1.2.0"}); err != nil {
+		t.Fatalf("SaveUpdateCheck failed: %v", err)
+	}
+	record, err = LoadUpdateCheck()
+	if err != nil || !record.CheckedAt.Equal(checked) || record.LatestVersion != "v1.2.0" {
+		t.Errorf("LoadUpdateCheck() = %+v, %v; want the saved record", record, err)
+	}
+}
+
+func TestUpdateCheckConfig(t *testing.T) {
+	// Config files written before the setting existed check daily
+	writeConfigFile(t, "version = 1\n[display]\nuse_unicode = true\n")
+	if cfg := LoadConfig(); cfg.UpdateCheck != DefaultUpdateCheck {
+		t.Errorf("UpdateCheck = %q, want the default", cfg.UpdateCheck)
+	}
+
+	writeConfigFile(t, "version = 1\n[updates]\ncheck = \"off\"\n")
+	if cfg := LoadConfig(); cfg.UpdateCheck != UpdateCheckOff {
+		t.Errorf("UpdateCheck = %q, want off", cfg.UpdateCheck)
+	}
+
+	t.Setenv(EnvUpdateCheck, UpdateCheckWeekly)
+	if cfg := ApplyEnvOverrides(LoadConfig()); cfg.UpdateCheck != UpdateCheckWeekly {
+		t.Errorf("UpdateCheck = %q, want the environment's weekly", cfg.UpdateCheck)
+	}
+}
@@ -0,0 +1,126 @@
+package engine
+
+// Atomic implements Atomic chess: every capture causes an explosion that removes
+// the capturing piece, the captured piece and all pieces other than pawns on the
+// eight surrounding squares. A player wins by exploding the opponent's king.
+//
+// Differences in legality from standard chess:
+//   - a king can never capture, since it would explode itself
+//   - a move that explodes the mover's own king is illegal
+//   - a move that explodes the opponent's king is legal even if the mover is in check
+//   - kings standing next to each other cannot give check, as capturing one
+//     would explode the other
+//
+// Everything else follows standard chess.
+type Atomic struct {
+	Standard
+}
+
+// Name returns "Atomic".
+func (Atomic) Name() string { return "Atomic" }
+
+// AfterCapture explodes the capturing piece on m.To along with every non-pawn
+// piece on the surrounding squares.
+func (Atomic) AfterCapture(b *Board, m Move) {
+	for df := -1; df <= 1; df++ {
+		for dr := -1; dr <= 1; dr++ {
+			if df == 0 && dr == 0 {
+				continue
+			}
+			file, rank := m.To.File()+df, m.To.Rank()+dr
+			if file < 0 || file > 7 || rank < 0 || rank > 7 {
+				continue
+			}
+			sq := NewSquare(file, rank)
+			if b.Squares[sq].Type() != Pawn {
+				b.removePiece(sq)
+			}
+		}
+	}
+	b.removePiece(m.To)
+}
+
+// IsLegal applies the Atomic legality rules described on the type.
+func (a Atomic) IsLegal(b *Board, m Move, moved, captured Piece) bool {
+	mover := moved.Color()
+	isCapture := !captured.IsEmpty() ||
+		(moved.Type() == Pawn && m.From.File() != m.To.File())
+	if moved.Type() == King && isCapture {
+		return false
+	}
+
+	if b.KingSquare(mover) == NoSquare {
+		return false
+	}
+	if b.KingSquare(opponent(mover)) == NoSquare {
+		return true
+	}
+	return !a.InCheck(b, mover)
+}
+
+// InCheck reports whether color's king is attacked, except when the kings are
+// adjacent or either king has already exploded.
+func (Atomic) InCheck(b *Board, color Color) bool {
+	own := b.KingSquare(color)
+	enemy := b.KingSquare(opponent(color))
+	if own == NoSquare || enemy == NoSquare {
+		return false
+	}
+	if abs(own.File()-enemy.File()) <= 1 && abs(own.Rank()-enemy.Rank()) <= 1 {
+		return false
+	}
+	return b.kingAttacked(color)
+}
+
+// Result ends the game once the side to move has lost its king in an explosion.
+func (Atomic) Result(b *Board) (GameStatus, bool) {
+	if b.KingSquare(b.ActiveColor) == NoSquare {
+		return KingExploded, true
+	}
+	return Ongoing, false
+}
+
+// removePiece clears sq, keeping the hash and castling rights in step.
+// The castling rights hash is updated by applyMove afterwards.
+func (b *Board) removePiece(sq Square) {
+	piece := b.Squares[sq]
+	if piece.IsEmpty() {
+		return
+	}
+	b.Hash ^= hashPiece(piece, sq)
+	b.Squares[sq] = Piece(Empty)
+
+	switch sq {
+	case NewSquare(0, 0): // a1
+		b.CastlingRights &^= CastleWhiteQueen
+	case NewSquare(7, 0): // h1
+		b.CastlingRights &^= CastleWhiteKing
+	case NewSquare(0, 7): // a8
+		b.CastlingRights &^= CastleBlackQueen
+	case NewSquare(7, 7): // h8
+		b.CastlingRights &^= CastleBlackKing
+	}
+	if piece.Type() == King {
+		if piece.Color() == White {
+			b.CastlingRights &^= CastleWhiteKing | CastleWhiteQueen
+		} else {
+			b.CastlingRights &^= CastleBlackKing | CastleBlackQueen
+		}
+	}
+}
+
+// opponent returns the other color.
+func opponent(c Color) Color {
+	if c == White {
+		return Black
+	}
+	return White
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,117 @@
+package engine
+
+import "testing"
+
+// atomicBoard loads fen with the Atomic variant.
+func atomicBoard(t *testing.T, fen string) *Board {
+	t.Helper()
+	b, err := FromFEN(fen)
+	if err != nil {
+		t.Fatalf("FromFEN(%q) error = %v", fen, err)
+	}
+	b.Variant = Atomic{}
+	return b
+}
+
+// hasMove reports whether moves contains the move in coordinate notation.
+func hasMove(moves []Move, coord string) bool {
+	for _, m := range moves {
+		if m.String() == coord {
+			return true
+		}
+	}
+	return false
+}
+
+// TestAtomicExplosion tests that a capture removes the capturer and surrounding non-pawn pieces.
+func TestAtomicExplosion(t *testing.T) {
+	b := atomicBoard(t, "rnbqkbnr/pppppppp/8/6N1/8/8/PPPPPPPP/RNBQKB1R w KQkq - 0 1")
+
+	if err := b.MakeMove(Move{From: NewSquare(6, 4), To: NewSquare(5, 6)}); err != nil {
+		t.Fatalf("Nxf7 error = %v", err)
+	}
+
+	want := "rnbq3r/ppppp1pp/8/8/8/8/PPPPPPPP/RNBQKB1R b KQ - 0 1"
+	if got := b.ToFEN(); got != want {
+		t.Errorf("ToFEN() = %q, want %q", got, want)
+	}
+
+	loaded, err := FromFEN(want)
+	if err != nil {
+		t.Fatalf("FromFEN() error = %v", err)
+	}
+	if b.Hash != loaded.Hash {
+		t.Errorf("Hash = %x, want %x after the explosion", b.Hash, loaded.Hash)
+	}
+
+	if b.Status() != KingExploded || !b.IsGameOver() {
+		t.Errorf("Status() = %v, want %v", b.Status(), KingExploded)
+	}
+	if winner, ok := b.Winner(); !ok || winner != White {
+		t.Errorf("Winner() = %v, %v; want White", winner, ok)
+	}
+}
+
+// TestAtomicLegality tests the legality rules that differ from standard chess.
+func TestAtomicLegality(t *testing.T) {
+	tests := []struct {
+		name    string
+		fen     string
+		move    string
+		atomic  bool
+		regular bool
+	}{
+		{"king cannot capture", "8/8/8/8/8/8/3p4/4K2k w - - 0 1", "e1d2", false, true},
+		{"capture cannot explode own king", "7k/8/8/8/8/8/3r4/3QK3 w - - 0 1", "d1d2", false, true},
+		{"exploding the enemy king escapes check", "4k3/4p3/8/8/7Q/8/8/r3K3 w - - 0 1", "h4e7", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := atomicBoard(t, tt.fen)
+			if got := hasMove(b.LegalMoves(), tt.move); got != tt.atomic {
+				t.Errorf("Atomic: %s legal = %v, want %v", tt.move, got, tt.atomic)
+			}
+
+			b.Variant = nil
+			if got := hasMove(b.LegalMoves(), tt.move); got != tt.regular {
+				t.Errorf("Standard: %s legal = %v, want %v", tt.move, got, tt.regular)
+			}
+		})
+	}
+}
+
+// TestAtomicAdjacentKingsNoCheck tests that touching kings cannot be in check.
+func TestAtomicAdjacentKingsNoCheck(t *testing.T) {
+	b := atomicBoard(t, "8/8/8/8/8/3k4/3K3r/8 w - - 0 1")
+	if b.InCheck() {
+		t.Error("Expected no check while the kings are adjacent")
+	}
+
+	b.Variant = nil
+	if !b.InCheck() {
+		t.Error("Expected the rook to give check in standard chess")
+	}
+}
+
+// TestParseVariant tests looking up variants by name.
+func TestParseVariant(t *testing.T) {
+	for name, want := range map[string]string{"standard": "Standard", "ATOMIC": "Atomic"} {
+		v, err := ParseVariant(name)
+		if err != nil || v.Name() != want {
+			t.Errorf("ParseVariant(%q) = %v, %v; want %s", name, v, err, want)
+		}
+	}
+	if _, err := ParseVariant("crazyhouse"); err == nil {
+		t.Error("Expected an error for an unsupported variant")
+	}
+}
+
+// TestCopyKeepsVariant tests that copies play by the same rules.
+func TestCopyKeepsVariant(t *testing.T) {
+	b := NewBoard()
+	b.Variant = Atomic{}
+	if _, ok := b.Copy().Variant.(Atomic); !ok {
+		t.Error("Expected the copy to keep the Atomic variant")
+	}
+}
@@ -34,6 +34,27 @@ type Board struct {
 	// History stores Zobrist hashes of previous positions.
 	// Used for threefold repetition detection.
 	History []uint64
+
+	// Variant supplies the rules of the chess variant being played.
+	// nil means standard chess.
+	Variant Variant
+
+	// undo holds the state before each move made with PushMove, most recent last.
+	undo []undoState
+}
+
+// undoState is the state of a board before a move, saved by PushMove so that
+// PopMove can restore it. The squares are saved whole since a variant may
+// change several of them in one move, as Atomic explosions do.
+type undoState struct {
+	squares        [64]Piece
+	activeColor    Color
+	castlingRights uint8
+	enPassantSq    int8
+	halfMoveClock  uint8
+	fullMoveNum    uint16
+	hash           uint64
+	historyLen     int
 }
 
 // Castling rights bit masks.
@@ -99,7 +120,8 @@ func (b *Board) PieceAt(sq Square) Piece {
 	return b.Squares[sq]
 }
 
-// Copy returns a deep copy of the board.
+// Copy returns a deep copy of the board. Moves made with PushMove are not
+// carried over, so PopMove cannot take them back on the copy.
 func (b *Board) Copy() *Board {
 	newBoard := &Board{
 		Squares:        b.Squares, // Array is copied by value
@@ -110,6 +132,7 @@ func (b *Board) Copy() *Board {
 		FullMoveNum:    b.FullMoveNum,
 		Hash:           b.Hash,
 		History:        make([]uint64, len(b.History)),
+		Variant:        b.Variant,
 	}
 	copy(newBoard.History, b.History)
 	return newBoard
@@ -145,8 +168,46 @@ func (b *Board) MakeMove(m Move) error {
 	return nil
 }
 
+// PushMove applies a move without checking that it is legal and saves the
+// previous state, so that PopMove can take the move back. Searches use the pair
+// to walk the game tree on a single board instead of copying it for every move.
+// The move must be pseudo-legal for the side to move; external code should use
+// MakeMove unless the move is already known to be legal.
+func (b *Board) PushMove(m Move) {
+	b.undo = append(b.undo, undoState{
+		squares:        b.Squares,
+		activeColor:    b.ActiveColor,
+		castlingRights: b.CastlingRights,
+		enPassantSq:    b.EnPassantSq,
+		halfMoveClock:  b.HalfMoveClock,
+		fullMoveNum:    b.FullMoveNum,
+		hash:           b.Hash,
+		historyLen:     len(b.History),
+	})
+	b.applyMove(m)
+}
+
+// PopMove takes back the last move made with PushMove, restoring the board to
+// the state before it. It does nothing if there is no move to take back.
+func (b *Board) PopMove() {
+	if len(b.undo) == 0 {
+		return
+	}
+	s := b.undo[len(b.undo)-1]
+	b.undo = b.undo[:len(b.undo)-1]
+
+	b.Squares = s.squares
+	b.ActiveColor = s.activeColor
+	b.CastlingRights = s.castlingRights
+	b.EnPassantSq = s.enPassantSq
+	b.HalfMoveClock = s.halfMoveClock
+	b.FullMoveNum = s.fullMoveNum
+	b.Hash = s.hash
+	b.History = b.History[:s.historyLen]
+}
+
 // applyMove applies a move to the board without checking legality.
-// This is used internally by LegalMoves() to test moves on a copy of the board.
+// This is used internally by PushMove() to test moves without copying the board.
 // External code should use MakeMove() which validates legality first.
 func (b *Board) applyMove(m Move) {
 	piece := b.Squares[m.From]
@@ -219,6 +280,11 @@ func (b *Board) applyMove(m Move) {
 	// --- Zobrist: XOR in the final piece at the destination ---
 	b.Hash ^= hashPiece(finalPiece, m.To)
 
+	// Let the variant apply its capture rules (e.g. Atomic explosions)
+	if isCapture || enPassantCapturedPawnSq != NoSquare {
+		b.rules().AfterCapture(b, m)
+	}
+
 	// Handle castling: if king moves 2 squares horizontally, also move the rook
 	if piece.Type() == King {
 		fileDiff := m.To.File() - m.From.File()
@@ -332,24 +398,31 @@ func (b *Board) applyMove(m Move) {
 
 // InCheck returns true if the active color's king is under attack by the opponent.
 func (b *Board) InCheck() bool {
-	// Find the active color's king
-	kingSquare := NoSquare
+	return b.rules().InCheck(b, b.ActiveColor)
+}
+
+// KingSquare returns the square of color's king, or NoSquare if it has none.
+func (b *Board) KingSquare(color Color) Square {
 	for sq := Square(0); sq < 64; sq++ {
 		piece := b.Squares[sq]
-		if piece.Type() == King && piece.Color() == b.ActiveColor {
-			kingSquare = sq
-			break
+		if piece.Type() == King && piece.Color() == color {
+			return sq
 		}
 	}
+	return NoSquare
+}
 
-	// If no king found (shouldn't happen in a valid game), return false
+// kingAttacked returns true if color's king is attacked by the opponent.
+// Returns false if color has no king.
+func (b *Board) kingAttacked(color Color) bool {
+	kingSquare := b.KingSquare(color)
 	if kingSquare == NoSquare {
 		return false
 	}
 
 	// Determine opponent color
 	opponentColor := Black
-	if b.ActiveColor == Black {
+	if color == Black {
 		opponentColor = White
 	}
 
@@ -423,11 +496,11 @@ func (b *Board) Perft(depth int) uint64 {
 
 	nodes := uint64(0)
 	for _, move := range moves {
-		boardCopy := b.Copy()
-		// Use applyMove instead of MakeMove to avoid redundant legality checks
+		// Use PushMove instead of MakeMove to avoid redundant legality checks
 		// We already know these moves are legal from LegalMoves()
-		boardCopy.applyMove(move)
-		nodes += boardCopy.Perft(depth - 1)
+		b.PushMove(move)
+		nodes += b.Perft(depth - 1)
+		b.PopMove()
 	}
 	return nodes
 }
@@ -439,14 +512,13 @@ func (b *Board) Divide(depth int) map[string]uint64 {
 
 	moves := b.LegalMoves()
 	for _, move := range moves {
-		boardCopy := b.Copy()
-		boardCopy.applyMove(move)
-
 		var nodes uint64
 		if depth <= 1 {
 			nodes = 1
 		} else {
-			nodes = boardCopy.Perft(depth - 1)
+			b.PushMove(move)
+			nodes = b.Perft(depth - 1)
+			b.PopMove()
 		}
 
 		result[move.String()] = nodes
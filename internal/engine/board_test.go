@@ -2379,3 +2379,87 @@ func TestEnPassantExpiry(t *testing.T) {
 		}
 	})
 }
+
+// TestPushPopMove tests that PopMove restores the board exactly after PushMove,
+// for every legal move of positions with castling, en passant, promotions and
+// Atomic explosions.
+func TestPushPopMove(t *testing.T) {
+	tests := []struct {
+		name   string
+		fen    string
+		atomic bool
+	}{
+		{"castling", "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1", false},
+		{"en passant", "rnbqkbnr/ppp1p1pp/8/3pPp2/8/8/PPPP1PPP/RNBQKBNR w KQkq f6 0 3", false},
+		{"promotion", "n1n5/PPPk4/8/8/8/8/4Kppp/5N1N b - - 0 1", false},
+		{"atomic explosion", "rnbqkbnr/pppppppp/8/6N1/8/8/PPPPPPPP/RNBQKB1R w KQkq - 0 1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := FromFEN(tt.fen)
+			if err != nil {
+				t.Fatalf("FromFEN(%q) error = %v", tt.fen, err)
+			}
+			if tt.atomic {
+				b.Variant = Atomic{}
+			}
+			before := b.Copy()
+
+			for _, move := range b.PseudoLegalMoves() {
+				b.PushMove(move)
+				if b.ToFEN() == before.ToFEN() {
+					t.Errorf("PushMove(%s) did not change the board", move)
+				}
+				b.PopMove()
+
+				if got := b.ToFEN(); got != tt.fen {
+					t.Errorf("After PushMove/PopMove(%s), FEN = %q, want %q", move, got, tt.fen)
+				}
+				if b.Hash != before.Hash || len(b.History) != len(before.History) {
+					t.Errorf("After PushMove/PopMove(%s), hash or history changed", move)
+				}
+			}
+		})
+	}
+}
+
+// TestPopMoveWithoutPush tests that PopMove does nothing when no move was pushed.
+func TestPopMoveWithoutPush(t *testing.T) {
+	b := NewBoard()
+	b.PopMove()
+	if got, want := b.ToFEN(), NewBoard().ToFEN(); got != want {
+		t.Errorf("PopMove() changed the board: FEN = %q, want %q", got, want)
+	}
+}
+
+// TestLegalMovesLeavesBoardUnchanged tests that generating and checking legal
+// moves leaves the position and its history as they were.
+func TestLegalMovesLeavesBoardUnchanged(t *testing.T) {
+	b := NewBoard()
+	for _, coord := range []string{"e2e4", "e7e5", "g1f3"} {
+		move, err := ParseMove(coord)
+		if err != nil {
+			t.Fatalf("ParseMove(%q) error = %v", coord, err)
+		}
+		if err := b.MakeMove(move); err != nil {
+			t.Fatalf("MakeMove(%s) error = %v", coord, err)
+		}
+	}
+	before := b.Copy()
+
+	b.LegalMoves()
+	b.IsLegalMove(Move{From: NewSquare(1, 7), To: NewSquare(2, 5)})
+
+	if b.ToFEN() != before.ToFEN() || b.Hash != before.Hash {
+		t.Errorf("Board changed: FEN = %q, want %q", b.ToFEN(), before.ToFEN())
+	}
+	if len(b.History) != len(before.History) {
+		t.Fatalf("len(History) = %d, want %d", len(b.History), len(before.History))
+	}
+	for i := range b.History {
+		if b.History[i] != before.History[i] {
+			t.Errorf("History[%d] = %x, want %x", i, b.History[i], before.History[i])
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// EPD is a record in Extended Position Description: a position followed by
+// operations about it, e.g. `bm Qg6; id "WAC.001";` giving the best move and
+// the name of a test position.
+type EPD struct {
+	// Board is the position. Its move counters come from the hmvc and fmvn
+	// operations if the record has them.
+	Board *Board
+	// Ops maps each opcode to its operands, with quotes removed. An opcode
+	// without operands, like "noop", maps to an empty slice.
+	Ops map[string][]string
+	// opcodes lists the opcodes in the order they were given, for String
+	opcodes []string
+}
+
+// ParseEPD parses an EPD record: the first four fields of a FEN, then any
+// number of operations, each an opcode and its operands ending with ';'. A full
+// FEN with move counters is accepted too, so suites may mix both.
+func ParseEPD(line string) (*EPD, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 4 {
+		return nil, fmt.Errorf("EPD must start with 4 position fields, got %d", len(fields))
+	}
+	position := strings.Join(fields[:4], " ")
+	rest := skipFields(line, 4)
+
+	halfmove, fullmove := "0", "1"
+	if len(fields) >= 6 && isFENCounter(fields[4]) && isFENCounter(fields[5]) {
+		halfmove, fullmove = fields[4], fields[5]
+		rest = skipFields(rest, 2)
+	}
+
+	e := &EPD{Ops: make(map[string][]string)}
+	if err := e.parseOps(rest); err != nil {
+		return nil, err
+	}
+	if ops := e.Ops["hmvc"]; len(ops) == 1 && isFENCounter(ops[0]) {
+		halfmove = ops[0]
+	}
+	if ops := e.Ops["fmvn"]; len(ops) == 1 && isFENCounter(ops[0]) {
+		fullmove = ops[0]
+	}
+
+	board, err := FromFEN(position + " " + halfmove + " " + fullmove)
+	if err != nil {
+		return nil, err
+	}
+	e.Board = board
+	return e, nil
+}
+
+// parseOps reads the operations after the position into e.Ops.
+func (e *EPD) parseOps(s string) error {
+	var tokens []string
+	var token strings.Builder
+	inQuote, quoted := false, false
+	flush := func() {
+		if token.Len() > 0 || quoted {
+			tokens = append(tokens, token.String())
+		}
+		token.Reset()
+		quoted = false
+	}
+	for _, r := range s {
+		switch {
+		case inQuote && r == '"':
+			inQuote = false
+		case inQuote:
+			token.WriteRune(r)
+		case r == '"':
+			inQuote, quoted = true, true
+		case r == ';':
+			flush()
+			if len(tokens) > 0 {
+				e.addOp(tokens[0], tokens[1:])
+			}
+			tokens = nil
+		case r == ' ' || r == '\t':
+			flush()
+		default:
+			token.WriteRune(r)
+		}
+	}
+	if inQuote {
+		return fmt.Errorf("unterminated string in EPD operations")
+	}
+	flush()
+	if len(tokens) > 0 {
+		return fmt.Errorf("EPD operation %q must end with ';'", tokens[0])
+	}
+	return nil
+}
+
+// addOp records an operation, keeping the first one if an opcode repeats.
+func (e *EPD) addOp(opcode string, operands []string) {
+	if _, ok := e.Ops[opcode]; ok {
+		return
+	}
+	e.Ops[opcode] = append([]string{}, operands...)
+	e.opcodes = append(e.opcodes, opcode)
+}
+
+// ID returns the operand of the "id" operation, or "" if there is none.
+func (e *EPD) ID() string {
+	if ops := e.Ops["id"]; len(ops) > 0 {
+		return ops[0]
+	}
+	return ""
+}
+
+// Moves returns the moves given as operands of opcode, e.g. "bm" for the best
+// moves or "am" for the moves to avoid. The operands are in SAN, though the
+// coordinate form like "e2e4" is accepted too. It returns nil if there is no
+// such operation, and an error if an operand isn't a legal move.
+func (e *EPD) Moves(opcode string) ([]Move, error) {
+	var moves []Move
+	for _, operand := range e.Ops[opcode] {
+		move, err := ParseSAN(e.Board, operand)
+		if err != nil {
+			coord, cerr := ParseMove(operand)
+			if cerr != nil || !e.Board.IsLegalMove(coord) {
+				return nil, fmt.Errorf("%s %s: %w", opcode, operand, err)
+			}
+			move = coord
+		}
+		moves = append(moves, move)
+	}
+	return moves, nil
+}
+
+// String returns the record in EPD form, with the operations in the order they
+// were given.
+func (e *EPD) String() string {
+	fields := strings.Fields(e.Board.ToFEN())
+	var b strings.Builder
+	b.WriteString(strings.Join(fields[:4], " "))
+	for _, opcode := range e.opcodes {
+		b.WriteString(" ")
+		b.WriteString(opcode)
+		for _, operand := range e.Ops[opcode] {
+			b.WriteString(" ")
+			if operand == "" || strings.ContainsAny(operand, " ;\"") {
+				operand = strconv.Quote(operand)
+			}
+			b.WriteString(operand)
+		}
+		b.WriteString(";")
+	}
+	return b.String()
+}
+
+// skipFields returns s after its first n whitespace-separated fields.
+func skipFields(s string, n int) string {
+	for i := 0; i < n; i++ {
+		s = strings.TrimLeft(s, " \t")
+		if end := strings.IndexAny(s, " \t"); end >= 0 {
+			s = s[end:]
+		} else {
+			s = ""
+		}
+	}
+	return strings.TrimSpace(s)
+}
+
+// isFENCounter reports whether s is a FEN move counter.
+func isFENCounter(s string) bool {
+	n, err := strconv.Atoi(s)
+	return err == nil && n >= 0
+}
@@ -0,0 +1,90 @@
+package engine
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseEPD(t *testing.T) {
+	e, err := ParseEPD(`2rr3k/pp3pp1/1nnqbN1p/3pN3/2pP4/2P3Q1/PPB4P/R4RK1 w - - bm Qg6; id "WAC.001"; c0 "mate in 3"; noop;`)
+	if err != nil {
+		t.Fatalf("ParseEPD() error = %v", err)
+	}
+	if got, want := e.Board.ToFEN(), "2rr3k/pp3pp1/1nnqbN1p/3pN3/2pP4/2P3Q1/PPB4P/R4RK1 w - - 0 1"; got != want {
+		t.Errorf("Board = %q, want %q", got, want)
+	}
+	if e.ID() != "WAC.001" {
+		t.Errorf("ID() = %q, want WAC.001", e.ID())
+	}
+	if got := e.Ops["c0"]; !reflect.DeepEqual(got, []string{"mate in 3"}) {
+		t.Errorf("c0 = %q, want the quoted comment", got)
+	}
+	if got, ok := e.Ops["noop"]; !ok || len(got) != 0 {
+		t.Errorf("noop = %q, %v; want no operands", got, ok)
+	}
+
+	best, err := e.Moves("bm")
+	if err != nil || len(best) != 1 || best[0].String() != "g3g6" {
+		t.Errorf("Moves(bm) = %v, %v; want g3g6", best, err)
+	}
+	if avoid, err := e.Moves("am"); err != nil || avoid != nil {
+		t.Errorf("Moves(am) = %v, %v; want none", avoid, err)
+	}
+	want := `2rr3k/pp3pp1/1nnqbN1p/3pN3/2pP4/2P3Q1/PPB4P/R4RK1 w - - bm Qg6; id WAC.001; c0 "mate in 3"; noop;`
+	if got := e.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestParseEPDCounters(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want string
+	}{
+		{"full FEN", "4k3/8/8/8/8/8/8/4K3 w - - 12 40", "4k3/8/8/8/8/8/8/4K3 w - - 12 40"},
+		{"full FEN with operations", "4k3/8/8/8/8/8/8/4K3 w - - 3 9 id \"x\";", "4k3/8/8/8/8/8/8/4K3 w - - 3 9"},
+		{"hmvc and fmvn", "4k3/8/8/8/8/8/8/4K3 b - - hmvc 5; fmvn 20;", "4k3/8/8/8/8/8/8/4K3 b - - 5 20"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := ParseEPD(tt.line)
+			if err != nil {
+				t.Fatalf("ParseEPD() error = %v", err)
+			}
+			if got := e.Board.ToFEN(); got != tt.want {
+				t.Errorf("Board = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseEPDErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want string
+	}{
+		{"too short", "4k3/8/8/8/8/8/8/4K3 w", "4 position fields"},
+		{"bad position", "4k3/8/8/8 w - - bm e4;", "rank"},
+		{"missing semicolon", "4k3/8/8/8/8/8/8/4K3 w - - bm Kd2", "must end with ';'"},
+		{"unterminated string", `4k3/8/8/8/8/8/8/4K3 w - - id "x;`, "unterminated"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseEPD(tt.line)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseEPD() error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+
+	e, err := ParseEPD("4k3/8/8/8/8/8/8/4K3 w - - bm Qh5;")
+	if err != nil {
+		t.Fatalf("ParseEPD() error = %v", err)
+	}
+	if _, err := e.Moves("bm"); err == nil {
+		t.Error("Expected an error for a best move that isn't legal")
+	}
+}
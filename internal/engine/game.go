@@ -0,0 +1,345 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// pgnRoster is the Seven Tag Roster, written first and in this order by PGN.
+var pgnRoster = []string{"Event", "Site", "Date", "Round", "White", "Black", "Result"}
+
+// Game is a game of chess as it was played: the position it started from, the
+// moves with their SAN and the time taken for each, the pieces captured and
+// how it ended. The board is never changed behind the game's back, so its
+// history always matches the position.
+type Game struct {
+	start    *Board
+	board    *Board
+	moves    []Move
+	sans     []string
+	times    []time.Duration
+	captures [][]Piece
+	tags     map[string]string
+
+	// resignedBy is the color that resigned, valid if resigned is set
+	resignedBy Color
+	resigned   bool
+	drawn      bool
+}
+
+// NewGame returns a game starting from the standard position.
+func NewGame() *Game {
+	return NewGameFrom(NewBoard())
+}
+
+// NewGameFrom returns a game starting from a copy of start.
+func NewGameFrom(start *Board) *Game {
+	return &Game{
+		start: start.Copy(),
+		board: start.Copy(),
+		tags:  make(map[string]string),
+	}
+}
+
+// Board returns the current position. It must not be changed; play moves
+// through the game instead.
+func (g *Game) Board() *Board {
+	return g.board
+}
+
+// Start returns a copy of the position the game started from.
+func (g *Game) Start() *Board {
+	return g.start.Copy()
+}
+
+// Moves returns the moves played so far.
+func (g *Game) Moves() []Move {
+	return append([]Move(nil), g.moves...)
+}
+
+// MoveCount returns the number of moves played so far.
+func (g *Game) MoveCount() int {
+	return len(g.moves)
+}
+
+// LastMove returns the last move played, or false if there is none.
+func (g *Game) LastMove() (Move, bool) {
+	if len(g.moves) == 0 {
+		return Move{}, false
+	}
+	return g.moves[len(g.moves)-1], true
+}
+
+// Positions returns the position before the first move followed by the
+// position after each move, so Positions()[n] is the board after n moves.
+func (g *Game) Positions() []*Board {
+	positions := make([]*Board, 0, len(g.moves)+1)
+	board := g.start.Copy()
+	positions = append(positions, board.Copy())
+	for _, move := range g.moves {
+		_ = board.MakeMove(move)
+		positions = append(positions, board.Copy())
+	}
+	return positions
+}
+
+// SANs returns the moves played so far in Standard Algebraic Notation.
+func (g *Game) SANs() []string {
+	return append([]string(nil), g.sans...)
+}
+
+// MoveTimes returns the time taken for each move, 0 for moves that weren't timed.
+func (g *Game) MoveTimes() []time.Duration {
+	return append([]time.Duration(nil), g.times...)
+}
+
+// TimeUsed returns the total time color took for its moves.
+func (g *Game) TimeUsed(color Color) time.Duration {
+	var total time.Duration
+	mover := g.start.ActiveColor
+	for _, d := range g.times {
+		if mover == color {
+			total += d
+		}
+		mover = opponent(mover)
+	}
+	return total
+}
+
+// Captured returns the pieces captured so far, in the order they left the board.
+// In Atomic chess this includes the pieces lost in explosions.
+func (g *Game) Captured() []Piece {
+	var pieces []Piece
+	for _, c := range g.captures {
+		pieces = append(pieces, c...)
+	}
+	return pieces
+}
+
+// MakeMove plays move, if it is legal and the game isn't over.
+func (g *Game) MakeMove(move Move) error {
+	return g.MakeTimedMove(move, 0)
+}
+
+// MakeTimedMove plays move like MakeMove, recording that it took took.
+func (g *Game) MakeTimedMove(move Move, took time.Duration) error {
+	if g.Over() {
+		return fmt.Errorf("the game is over")
+	}
+	before := g.board.Copy()
+	if err := g.board.MakeMove(move); err != nil {
+		return err
+	}
+	g.moves = append(g.moves, move)
+	g.sans = append(g.sans, FormatSAN(before, move))
+	g.times = append(g.times, took)
+	g.captures = append(g.captures, capturedBy(before, g.board, move))
+	return nil
+}
+
+// MakeSANMove plays the move given in Standard Algebraic Notation, e.g. "Nf3",
+// and returns it.
+func (g *Game) MakeSANMove(san string) (Move, error) {
+	move, err := ParseSAN(g.board, san)
+	if err != nil {
+		return Move{}, err
+	}
+	return move, g.MakeMove(move)
+}
+
+// Undo takes back the last move, and any resignation or draw that ended the
+// game. It returns false if there is no move to take back.
+func (g *Game) Undo() bool {
+	g.resigned = false
+	g.drawn = false
+	if len(g.moves) == 0 {
+		return false
+	}
+	last := len(g.moves) - 1
+	g.moves = g.moves[:last]
+	g.sans = g.sans[:last]
+	g.times = g.times[:last]
+	g.captures = g.captures[:last]
+
+	// Replay from the start, so the repetition history is right too
+	g.board = g.start.Copy()
+	for _, move := range g.moves {
+		_ = g.board.MakeMove(move)
+	}
+	return true
+}
+
+// Resign ends the game with color resigning.
+func (g *Game) Resign(color Color) {
+	if g.Over() {
+		return
+	}
+	g.resigned = true
+	g.resignedBy = color
+}
+
+// AgreeDraw ends the game in a draw by agreement.
+func (g *Game) AgreeDraw() {
+	if !g.Over() {
+		g.drawn = true
+	}
+}
+
+// ClaimDraw ends the game in a draw by threefold repetition or the fifty-move
+// rule, or reports why it can't.
+func (g *Game) ClaimDraw() error {
+	if !g.board.CanClaimDraw() {
+		return fmt.Errorf("no draw can be claimed in this position")
+	}
+	g.AgreeDraw()
+	return nil
+}
+
+// ResignedBy returns the color that resigned, or false if no one did.
+func (g *Game) ResignedBy() (Color, bool) {
+	return g.resignedBy, g.resigned
+}
+
+// DrawnByAgreement reports whether the game was agreed or claimed drawn, as
+// opposed to drawn on the board.
+func (g *Game) DrawnByAgreement() bool {
+	return g.drawn
+}
+
+// Over reports whether the game has ended, on the board or by resignation or draw.
+func (g *Game) Over() bool {
+	return g.resigned || g.drawn || g.board.IsGameOver()
+}
+
+// Result returns the result in PGN form: "1-0", "0-1", "1/2-1/2", or "*" while
+// the game goes on.
+func (g *Game) Result() string {
+	switch {
+	case g.resigned && g.resignedBy == White:
+		return "0-1"
+	case g.resigned:
+		return "1-0"
+	case g.drawn:
+		return "1/2-1/2"
+	case !g.board.IsGameOver():
+		return "*"
+	}
+	if winner, ok := g.board.Winner(); ok {
+		if winner == White {
+			return "1-0"
+		}
+		return "0-1"
+	}
+	return "1/2-1/2"
+}
+
+// SetTag sets a PGN tag, e.g. SetTag("White", "Alice"), or removes it if value is "".
+func (g *Game) SetTag(name, value string) {
+	if value == "" {
+		delete(g.tags, name)
+		return
+	}
+	g.tags[name] = value
+}
+
+// PGN returns the game in Portable Game Notation: the Seven Tag Roster with "?"
+// for tags that weren't set, the other tags in alphabetical order, the variant
+// and starting position if they aren't the standard ones, and the movetext.
+func (g *Game) PGN() string {
+	var b strings.Builder
+	for _, name := range pgnRoster {
+		value := g.tags[name]
+		switch {
+		case name == "Result":
+			value = g.Result()
+		case value == "":
+			value = "?"
+		}
+		writePGNTag(&b, name, value)
+	}
+
+	var extra []string
+	for name := range g.tags {
+		if name != "SetUp" && name != "FEN" && name != "Variant" && !isRosterTag(name) {
+			extra = append(extra, name)
+		}
+	}
+	sort.Strings(extra)
+	for _, name := range extra {
+		writePGNTag(&b, name, g.tags[name])
+	}
+	if _, standard := g.start.Variant.(Standard); g.start.Variant != nil && !standard {
+		writePGNTag(&b, "Variant", g.start.Variant.Name())
+	}
+	if fen := g.start.ToFEN(); fen != NewBoard().ToFEN() {
+		writePGNTag(&b, "SetUp", "1")
+		writePGNTag(&b, "FEN", fen)
+	}
+
+	b.WriteString("\n")
+	color, number := g.start.ActiveColor, g.start.FullMoveNum
+	for i, san := range g.sans {
+		if color == White {
+			fmt.Fprintf(&b, "%d. ", number)
+		} else if i == 0 {
+			fmt.Fprintf(&b, "%d... ", number)
+		}
+		b.WriteString(san)
+		b.WriteString(" ")
+		if color == Black {
+			number++
+		}
+		color = opponent(color)
+	}
+	b.WriteString(g.Result())
+	b.WriteString("\n")
+	return b.String()
+}
+
+// writePGNTag writes a tag pair, escaping the value.
+func writePGNTag(b *strings.Builder, name, value string) {
+	value = strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+	fmt.Fprintf(b, "[%s \"%s\"]\n", name, value)
+}
+
+// isRosterTag reports whether name is one of the Seven Tag Roster.
+func isRosterTag(name string) bool {
+	for _, tag := range pgnRoster {
+		if tag == name {
+			return true
+		}
+	}
+	return false
+}
+
+// capturedBy returns the pieces move took off the board, going from before to
+// after: the piece captured, plus those lost in an Atomic explosion. A pawn
+// that promotes isn't counted.
+func capturedBy(before, after *Board, move Move) []Piece {
+	var counts [2][7]int
+	for sq := Square(0); sq < 64; sq++ {
+		if p := before.Squares[sq]; !p.IsEmpty() {
+			counts[p.Color()][p.Type()]++
+		}
+		if p := after.Squares[sq]; !p.IsEmpty() {
+			counts[p.Color()][p.Type()]--
+		}
+	}
+	if move.Promotion != Empty {
+		mover := before.ActiveColor
+		counts[mover][Pawn]--
+		counts[mover][move.Promotion]++
+	}
+
+	var pieces []Piece
+	for _, color := range []Color{opponent(before.ActiveColor), before.ActiveColor} {
+		for pt := Pawn; pt <= King; pt++ {
+			for n := counts[color][pt]; n > 0; n-- {
+				pieces = append(pieces, NewPiece(color, pt))
+			}
+		}
+	}
+	return pieces
+}
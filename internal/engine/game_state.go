@@ -34,6 +34,10 @@ const (
 	// DrawFivefoldRepetition indicates an automatic draw due to
 	// fivefold repetition of the position.
 	DrawFivefoldRepetition
+
+	// KingExploded indicates the player to move lost their king in an
+	// Atomic chess explosion. The opponent wins.
+	KingExploded
 )
 
 // String returns a human-readable string representation of the game status.
@@ -55,15 +59,18 @@ func (s GameStatus) String() string {
 		return "draw (threefold repetition)"
 	case DrawFivefoldRepetition:
 		return "draw (fivefold repetition)"
+	case KingExploded:
+		return "king exploded"
 	default:
 		return "unknown"
 	}
 }
 
 // Status returns the current game status by checking for checkmate, stalemate,
-// and draw conditions in order of priority.
+// and draw conditions in order of priority. Each step is decided by the board's
+// variant; the order and standard chess rules are:
 //
-// The algorithm checks:
+// 0. Game endings specific to the variant (e.g. an exploded king in Atomic)
 // 1. If no legal moves exist:
 //   - If in check -> Checkmate
 //   - If not in check -> Stalemate
@@ -81,50 +88,56 @@ func (s GameStatus) String() string {
 // Use CanClaimDraw() to check if a draw is available, and IsGameOver() to check
 // if the game has actually ended.
 func (b *Board) Status() GameStatus {
-	// Generate all legal moves for the active player
-	legalMoves := b.LegalMoves()
+	rules := b.rules()
+	if status, decided := rules.Result(b); decided {
+		return status
+	}
 
 	// If no legal moves exist, check for checkmate or stalemate
-	if len(legalMoves) == 0 {
-		if b.InCheck() {
-			return Checkmate
-		}
-		return Stalemate
+	if len(b.LegalMoves()) == 0 {
+		return rules.NoLegalMoves(b)
+	}
+
+	if status, drawn := rules.Draw(b); drawn {
+		return status
 	}
 
-	// Check for automatic draws first (these end the game immediately)
+	return Ongoing
+}
 
+// standardDraw checks the standard chess draw rules, automatic draws first.
+func (b *Board) standardDraw() (GameStatus, bool) {
 	// Check for fivefold repetition (automatic draw)
-	repCount := b.repetitionCount()
+	repCount := b.RepetitionCount()
 	if repCount >= 5 {
-		return DrawFivefoldRepetition
+		return DrawFivefoldRepetition, true
 	}
 
 	// Check for seventy-five-move rule (automatic draw)
 	// 75 full moves = 150 half-moves
 	if b.HalfMoveClock >= 150 {
-		return DrawSeventyFiveMoveRule
+		return DrawSeventyFiveMoveRule, true
 	}
 
 	// Check for insufficient material (automatic draw)
 	if b.hasInsufficientMaterial() {
-		return DrawInsufficientMaterial
+		return DrawInsufficientMaterial, true
 	}
 
 	// Check for claimable draws (these require a player to claim)
 
 	// Check for threefold repetition (claimable draw)
 	if repCount >= 3 {
-		return DrawThreefoldRepetition
+		return DrawThreefoldRepetition, true
 	}
 
 	// Check for fifty-move rule (claimable draw)
 	// 50 full moves = 100 half-moves
 	if b.HalfMoveClock >= 100 {
-		return DrawFiftyMoveRule
+		return DrawFiftyMoveRule, true
 	}
 
-	return Ongoing
+	return Ongoing, false
 }
 
 // IsGameOver returns true if the game has ended due to an automatic game-ending
@@ -137,7 +150,7 @@ func (b *Board) IsGameOver() bool {
 	status := b.Status()
 	// Game is over for automatic conditions only (not claimable draws)
 	switch status {
-	case Checkmate, Stalemate, DrawFivefoldRepetition, DrawSeventyFiveMoveRule, DrawInsufficientMaterial:
+	case Checkmate, Stalemate, DrawFivefoldRepetition, DrawSeventyFiveMoveRule, DrawInsufficientMaterial, KingExploded:
 		return true
 	default:
 		return false
@@ -158,9 +171,10 @@ func (b *Board) CanClaimDraw() bool {
 
 // Winner returns the color of the winning player and whether there is a winner.
 // Returns (Black, true) if White is checkmated, (White, true) if Black is checkmated,
-// or (0, false) for stalemate, draws, or ongoing games.
+// or (0, false) for stalemate, draws, or ongoing games. In Atomic chess, exploding
+// the opponent's king wins like a checkmate.
 func (b *Board) Winner() (Color, bool) {
-	if b.Status() == Checkmate {
+	if status := b.Status(); status == Checkmate || status == KingExploded {
 		// The player to move is checkmated, so the opponent wins
 		if b.ActiveColor == White {
 			return Black, true
@@ -170,10 +184,11 @@ func (b *Board) Winner() (Color, bool) {
 	return 0, false // No winner (draw, stalemate, or ongoing)
 }
 
-// repetitionCount returns the number of times the current position
+// RepetitionCount returns the number of times the current position
 // has occurred in the game history. The current position's hash
-// is included in the history (added after the last move was made).
-func (b *Board) repetitionCount() int {
+// is included in the history (added after the last move was made),
+// so a position on the board for the first time counts 1.
+func (b *Board) RepetitionCount() int {
 	count := 0
 	for _, hash := range b.History {
 		if hash == b.Hash {
@@ -881,7 +881,7 @@ func TestFivefoldRepetition(t *testing.T) {
 func TestRepetitionCount(t *testing.T) {
 	t.Run("Initial position has count 1", func(t *testing.T) {
 		board := NewBoard()
-		count := board.repetitionCount()
+		count := board.RepetitionCount()
 		if count != 1 {
 			t.Errorf("expected repetition count 1 for initial position, got %d", count)
 		}
@@ -892,7 +892,7 @@ func TestRepetitionCount(t *testing.T) {
 		move, _ := ParseMove("e2e4")
 		_ = board.MakeMove(move)
 
-		count := board.repetitionCount()
+		count := board.RepetitionCount()
 		if count != 1 {
 			t.Errorf("expected repetition count 1 after first move, got %d", count)
 		}
@@ -914,7 +914,7 @@ func TestRepetitionCount(t *testing.T) {
 			_ = board.MakeMove(move)
 		}
 
-		count := board.repetitionCount()
+		count := board.RepetitionCount()
 		if count != 2 {
 			t.Errorf("expected repetition count 2, got %d", count)
 		}
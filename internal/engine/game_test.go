@@ -0,0 +1,153 @@
+package engine
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGameMakeSANMove(t *testing.T) {
+	g := NewGame()
+	for _, san := range []string{"e4", "d5", "exd5", "Qxd5"} {
+		if _, err := g.MakeSANMove(san); err != nil {
+			t.Fatalf("MakeSANMove(%s) error = %v", san, err)
+		}
+	}
+	if got, want := g.SANs(), []string{"e4", "d5", "exd5", "Qxd5"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SANs() = %v, want %v", got, want)
+	}
+	if len(g.Moves()) != 4 {
+		t.Errorf("Moves() = %v, want 4 moves", g.Moves())
+	}
+	want := []Piece{NewPiece(Black, Pawn), NewPiece(White, Pawn)}
+	if got := g.Captured(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Captured() = %v, want %v", got, want)
+	}
+	if _, err := g.MakeSANMove("Ke3"); err == nil {
+		t.Error("Expected an error for an illegal move")
+	}
+	if g.Result() != "*" || g.Over() {
+		t.Errorf("Expected the game to go on, result %s", g.Result())
+	}
+}
+
+func TestGameUndo(t *testing.T) {
+	g := NewGame()
+	for _, san := range []string{"Nf3", "Nf6", "Ng1", "Ng8", "Nf3"} {
+		if _, err := g.MakeSANMove(san); err != nil {
+			t.Fatalf("MakeSANMove(%s) error = %v", san, err)
+		}
+	}
+	if !g.Undo() {
+		t.Fatal("Expected a move to take back")
+	}
+	if g.Board().Hash != NewBoard().Hash || g.Board().RepetitionCount() != 2 {
+		t.Errorf("Expected the starting position a second time, got %s", g.Board().ToFEN())
+	}
+	if len(g.SANs()) != 4 {
+		t.Errorf("SANs() = %v, want 4 moves", g.SANs())
+	}
+
+	g.Resign(White)
+	if !g.Undo() || g.Over() {
+		t.Error("Expected Undo to take back the resignation too")
+	}
+
+	empty := NewGame()
+	if empty.Undo() {
+		t.Error("Expected nothing to take back in a new game")
+	}
+}
+
+func TestGameResult(t *testing.T) {
+	g := NewGame()
+	for _, san := range []string{"f3", "e5", "g4", "Qh4#"} {
+		if _, err := g.MakeSANMove(san); err != nil {
+			t.Fatalf("MakeSANMove(%s) error = %v", san, err)
+		}
+	}
+	if g.Result() != "0-1" || !g.Over() {
+		t.Errorf("Result() = %s, want 0-1", g.Result())
+	}
+	if err := g.MakeMove(Move{}); err == nil {
+		t.Error("Expected no moves once the game is over")
+	}
+
+	g = NewGame()
+	g.Resign(Black)
+	if g.Result() != "1-0" {
+		t.Errorf("Result() = %s after Black resigned, want 1-0", g.Result())
+	}
+
+	g = NewGame()
+	if err := g.ClaimDraw(); err == nil {
+		t.Error("Expected no draw to claim in the starting position")
+	}
+	g.AgreeDraw()
+	if g.Result() != "1/2-1/2" {
+		t.Errorf("Result() = %s after a draw by agreement, want 1/2-1/2", g.Result())
+	}
+}
+
+func TestGameTimeUsed(t *testing.T) {
+	g := NewGame()
+	for i, san := range []string{"e4", "e5", "Nf3"} {
+		move, err := ParseSAN(g.Board(), san)
+		if err != nil {
+			t.Fatalf("ParseSAN(%s) error = %v", san, err)
+		}
+		if err := g.MakeTimedMove(move, time.Duration(i+1)*time.Second); err != nil {
+			t.Fatalf("MakeTimedMove(%s) error = %v", san, err)
+		}
+	}
+	if got := g.TimeUsed(White); got != 4*time.Second {
+		t.Errorf("TimeUsed(White) = %v, want 4s", got)
+	}
+	if got := g.TimeUsed(Black); got != 2*time.Second {
+		t.Errorf("TimeUsed(Black) = %v, want 2s", got)
+	}
+}
+
+func TestGamePGN(t *testing.T) {
+	g := NewGame()
+	g.SetTag("White", "Alice")
+	g.SetTag("Opening", "King's Pawn")
+	for _, san := range []string{"e4", "e5", "Nf3"} {
+		if _, err := g.MakeSANMove(san); err != nil {
+			t.Fatalf("MakeSANMove(%s) error = %v", san, err)
+		}
+	}
+	want := `[Event "?"]
+[Site "?"]
+[Date "?"]
+[Round "?"]
+[White "Alice"]
+[Black "?"]
+[Result "*"]
+[Opening "King's Pawn"]
+
+1. e4 e5 2. Nf3 *
+`
+	if got := g.PGN(); got != want {
+		t.Errorf("PGN() =\n%s\nwant\n%s", got, want)
+	}
+
+	start, err := FromFEN("4k3/8/8/8/8/8/4P3/4K3 b - - 0 7")
+	if err != nil {
+		t.Fatalf("FromFEN() error = %v", err)
+	}
+	g = NewGameFrom(start)
+	for _, san := range []string{"Kd7", "e4"} {
+		if _, err := g.MakeSANMove(san); err != nil {
+			t.Fatalf("MakeSANMove(%s) error = %v", san, err)
+		}
+	}
+	pgn := g.PGN()
+	if !strings.Contains(pgn, `[FEN "4k3/8/8/8/8/8/4P3/4K3 b - - 0 7"]`) {
+		t.Errorf("Expected the starting position in the tags, got:\n%s", pgn)
+	}
+	if !strings.Contains(pgn, "7... Kd7 8. e4 *") {
+		t.Errorf("Expected the movetext to start with Black's move, got:\n%s", pgn)
+	}
+}
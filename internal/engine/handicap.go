@@ -0,0 +1,66 @@
+package engine
+
+// Handicap is material one side gives up from the starting position in an odds game.
+type Handicap uint8
+
+const (
+	// NoHandicap is the standard starting position.
+	NoHandicap Handicap = iota
+	// PawnOdds removes the f-pawn (f2 for White, f7 for Black).
+	PawnOdds
+	// KnightOdds removes the queen's knight (b1 for White, b8 for Black).
+	KnightOdds
+	// RookOdds removes the queen's rook (a1 for White, a8 for Black) and with it queenside castling.
+	RookOdds
+)
+
+// Handicaps lists every handicap, from none to the largest.
+var Handicaps = []Handicap{NoHandicap, PawnOdds, KnightOdds, RookOdds}
+
+// String returns the display name of the handicap.
+func (h Handicap) String() string {
+	switch h {
+	case NoHandicap:
+		return "No Handicap"
+	case PawnOdds:
+		return "Pawn Odds (f-pawn)"
+	case KnightOdds:
+		return "Knight Odds"
+	case RookOdds:
+		return "Rook Odds"
+	default:
+		return "Unknown"
+	}
+}
+
+// NewHandicapBoard creates the starting position with the handicap material removed
+// from giver's side. White is to move, as in a normal game.
+func NewHandicapBoard(h Handicap, giver Color) *Board {
+	b := NewBoard()
+
+	backRank, pawnRank := 0, 1
+	queenside := CastleWhiteQueen
+	if giver == Black {
+		backRank, pawnRank = 7, 6
+		queenside = CastleBlackQueen
+	}
+
+	switch h {
+	case PawnOdds:
+		b.Squares[NewSquare(5, pawnRank)] = Piece(Empty)
+	case KnightOdds:
+		b.Squares[NewSquare(1, backRank)] = Piece(Empty)
+	case RookOdds:
+		b.Squares[NewSquare(0, backRank)] = Piece(Empty)
+		b.CastlingRights &^= queenside
+	}
+
+	b.Hash = b.ComputeHash()
+	b.History = []uint64{b.Hash}
+	return b
+}
+
+// HandicapFEN returns the FEN of the starting position for an odds game.
+func HandicapFEN(h Handicap, giver Color) string {
+	return NewHandicapBoard(h, giver).ToFEN()
+}
@@ -0,0 +1,44 @@
+package engine
+
+import "testing"
+
+// TestHandicapFEN tests the starting positions generated for odds games.
+func TestHandicapFEN(t *testing.T) {
+	tests := []struct {
+		name     string
+		handicap Handicap
+		giver    Color
+		want     string
+	}{
+		{"none", NoHandicap, White, "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1"},
+		{"white pawn odds", PawnOdds, White, "rnbqkbnr/pppppppp/8/8/8/8/PPPPP1PP/RNBQKBNR w KQkq - 0 1"},
+		{"black pawn odds", PawnOdds, Black, "rnbqkbnr/ppppp1pp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1"},
+		{"white knight odds", KnightOdds, White, "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/R1BQKBNR w KQkq - 0 1"},
+		{"black knight odds", KnightOdds, Black, "r1bqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1"},
+		{"white rook odds", RookOdds, White, "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/1NBQKBNR w Kkq - 0 1"},
+		{"black rook odds", RookOdds, Black, "1nbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQk - 0 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HandicapFEN(tt.handicap, tt.giver); got != tt.want {
+				t.Errorf("HandicapFEN() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestNewHandicapBoardHash tests that the handicap position hashes like the same position loaded from FEN.
+func TestNewHandicapBoardHash(t *testing.T) {
+	b := NewHandicapBoard(RookOdds, Black)
+	loaded, err := FromFEN(b.ToFEN())
+	if err != nil {
+		t.Fatalf("FromFEN() error = %v", err)
+	}
+	if b.Hash != loaded.Hash {
+		t.Errorf("Hash = %x, want %x", b.Hash, loaded.Hash)
+	}
+	if len(b.History) != 1 || b.History[0] != b.Hash {
+		t.Errorf("History = %v, want only the starting hash", b.History)
+	}
+}
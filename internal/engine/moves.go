@@ -99,6 +99,9 @@ func (b *Board) generatePawnMoves() []Move {
 		startRank = 6  // Black pawns start on rank 7 (index 6)
 	}
 
+	// Pieces a pawn may promote to
+	promotions := b.rules().PromotionPieces()
+
 	// Iterate through all squares looking for pawns of the active color
 	for sq := Square(0); sq < 64; sq++ {
 		piece := b.Squares[sq]
@@ -125,8 +128,8 @@ func (b *Board) generatePawnMoves() []Move {
 			if b.Squares[forwardSq].IsEmpty() {
 				// Check if this is a promotion move
 				if forwardRank == promotionRank {
-					// Generate 4 promotion moves
-					for _, promoType := range []PieceType{Queen, Rook, Bishop, Knight} {
+					// Generate a move for each promotion piece
+					for _, promoType := range promotions {
 						moves = append(moves, Move{From: sq, To: forwardSq, Promotion: promoType})
 					}
 				} else {
@@ -157,8 +160,8 @@ func (b *Board) generatePawnMoves() []Move {
 				if !targetPiece.IsEmpty() && targetPiece.Color() != b.ActiveColor {
 					// Check if this is a promotion capture
 					if captureRank == promotionRank {
-						// Generate 4 promotion moves for capture
-						for _, promoType := range []PieceType{Queen, Rook, Bishop, Knight} {
+						// Generate a promotion move for each piece
+						for _, promoType := range promotions {
 							moves = append(moves, Move{From: sq, To: captureSq, Promotion: promoType})
 						}
 					} else {
@@ -360,8 +363,8 @@ func (b *Board) generateKingMoves() []Move {
 			}
 		}
 
-		// Generate castling moves
-		moves = append(moves, b.generateCastlingMoves(sq)...)
+		// Generate castling moves under the variant's rules
+		moves = append(moves, b.rules().CastlingMoves(b, sq)...)
 	}
 
 	return moves
@@ -481,45 +484,18 @@ func (b *Board) PseudoLegalMoves() []Move {
 }
 
 // LegalMoves generates all legal moves for the active color.
-// A legal move is a pseudo-legal move that does not leave the king in check.
-// This is done by filtering pseudo-legal moves: for each move, we make it on
-// a copy of the board and verify the king is not in check afterwards.
+// A legal move is a pseudo-legal move the variant's rules allow; in standard
+// chess, one that does not leave the king in check. This is done by filtering
+// pseudo-legal moves: for each move, we make it with PushMove, let the variant
+// judge the resulting position and take it back with PopMove. The board is
+// left unchanged, but it is modified while the moves are checked, so it must
+// not be read concurrently.
 func (b *Board) LegalMoves() []Move {
 	pseudoLegalMoves := b.PseudoLegalMoves()
-	var legalMoves []Move
-
-	// Remember which color is moving (before applyMove switches it)
-	movingColor := b.ActiveColor
+	legalMoves := make([]Move, 0, len(pseudoLegalMoves))
 
 	for _, move := range pseudoLegalMoves {
-		// Create a copy of the board to test the move
-		boardCopy := b.Copy()
-
-		// Apply the move on the copy using the internal method (skips legality check)
-		// This avoids infinite recursion since MakeMove calls IsLegalMove which calls LegalMoves
-		boardCopy.applyMove(move)
-
-		// After applyMove, ActiveColor has switched to the opponent.
-		// We need to check if the king of the color that JUST moved is in check.
-		// The opponent (now active) would be attacking, so we check if the
-		// moving color's king is attacked by the new active color.
-		kingSquare := NoSquare
-		for sq := Square(0); sq < 64; sq++ {
-			piece := boardCopy.Squares[sq]
-			if piece.Type() == King && piece.Color() == movingColor {
-				kingSquare = sq
-				break
-			}
-		}
-
-		// If no king found (shouldn't happen), skip this move
-		if kingSquare == NoSquare {
-			continue
-		}
-
-		// Check if the king is attacked by the opponent (who is now the active color)
-		if !boardCopy.IsSquareAttacked(kingSquare, boardCopy.ActiveColor) {
-			// King is not in check after this move - it's legal
+		if b.isLegal(move) {
 			legalMoves = append(legalMoves, move)
 		}
 	}
@@ -528,15 +504,24 @@ func (b *Board) LegalMoves() []Move {
 }
 
 // IsLegalMove checks if a specific move is legal for the current position.
-// Returns true if the move is in the list of legal moves, false otherwise.
-// This is a convenience method for validating a single move without generating
-// all legal moves manually.
+// Returns true if the move is pseudo-legal and the variant's rules allow it,
+// false otherwise. Only the matching move is tried, so this is cheaper than
+// searching the list of legal moves.
 func (b *Board) IsLegalMove(m Move) bool {
-	legalMoves := b.LegalMoves()
-	for _, legalMove := range legalMoves {
-		if legalMove.From == m.From && legalMove.To == m.To && legalMove.Promotion == m.Promotion {
-			return true
+	for _, move := range b.PseudoLegalMoves() {
+		if move.From == m.From && move.To == m.To && move.Promotion == m.Promotion {
+			return b.isLegal(move)
 		}
 	}
 	return false
 }
+
+// isLegal reports whether the pseudo-legal move m is legal, by making it with
+// PushMove and taking it back once the variant has judged the result.
+func (b *Board) isLegal(m Move) bool {
+	moved, captured := b.Squares[m.From], b.Squares[m.To]
+	b.PushMove(m)
+	legal := b.rules().IsLegal(b, m, moved, captured)
+	b.PopMove()
+	return legal
+}
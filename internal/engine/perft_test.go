@@ -231,9 +231,38 @@ func BenchmarkPerft(b *testing.B) {
 
 	for _, bm := range benchmarks {
 		b.Run(fmt.Sprintf("depth_%d", bm.depth), func(b *testing.B) {
+			b.ReportAllocs()
 			for i := 0; i < b.N; i++ {
 				board.Perft(bm.depth)
 			}
 		})
 	}
 }
+
+// BenchmarkLegalMoves benchmarks generating the legal moves of a busy middlegame
+// position in the middle of a long game, whose history the board carries along.
+func BenchmarkLegalMoves(b *testing.B) {
+	board, err := FromFEN("r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1")
+	if err != nil {
+		b.Fatalf("Failed to parse FEN: %v", err)
+	}
+	for len(board.History) < 100 {
+		board.History = append(board.History, board.Hash)
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		board.LegalMoves()
+	}
+}
+
+// BenchmarkIsLegalMove benchmarks validating a single move, as MakeMove does.
+func BenchmarkIsLegalMove(b *testing.B) {
+	board := NewBoard()
+	move := Move{From: NewSquare(4, 1), To: NewSquare(4, 3)} // e2e4
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		board.IsLegalMove(move)
+	}
+}
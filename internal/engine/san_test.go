@@ -1,20 +1,18 @@
-package ui
+package engine
 
 import (
 	"testing"
-
-	"github.com/Mgrdich/TermChess/internal/engine"
 )
 
 // parseSquareHelper converts algebraic notation (e.g., "e4") to a Square.
 // This is a test helper function.
-func parseSquareHelper(s string) engine.Square {
+func parseSquareHelper(s string) Square {
 	if len(s) != 2 {
 		panic("invalid square notation")
 	}
 	file := int(s[0] - 'a')
 	rank := int(s[1] - '1')
-	return engine.NewSquare(file, rank)
+	return NewSquare(file, rank)
 }
 
 // TestParseSAN_SimplePawnMoves tests parsing of simple pawn moves like "e4", "d5".
@@ -100,7 +98,7 @@ func TestParseSAN_SimplePawnMoves(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			board, err := engine.FromFEN(tt.fen)
+			board, err := FromFEN(tt.fen)
 			if err != nil {
 				t.Fatalf("failed to parse FEN: %v", err)
 			}
@@ -188,7 +186,7 @@ func TestParseSAN_PawnCaptures(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			board, err := engine.FromFEN(tt.fen)
+			board, err := FromFEN(tt.fen)
 			if err != nil {
 				t.Fatalf("failed to parse FEN: %v", err)
 			}
@@ -290,7 +288,7 @@ func TestParseSAN_PawnPromotions(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			board, err := engine.FromFEN(tt.fen)
+			board, err := FromFEN(tt.fen)
 			if err != nil {
 				t.Fatalf("failed to parse FEN: %v", err)
 			}
@@ -364,7 +362,7 @@ func TestParseSAN_CombinedCapturePromotion(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			board, err := engine.FromFEN(tt.fen)
+			board, err := FromFEN(tt.fen)
 			if err != nil {
 				t.Fatalf("failed to parse FEN: %v", err)
 			}
@@ -431,7 +429,7 @@ func TestParseSAN_CheckSymbolsStripped(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			board, err := engine.FromFEN(tt.fen)
+			board, err := FromFEN(tt.fen)
 			if err != nil {
 				t.Fatalf("failed to parse FEN: %v", err)
 			}
@@ -498,7 +496,7 @@ func TestParseSAN_InvalidPawnMoves(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			board, err := engine.FromFEN(tt.fen)
+			board, err := FromFEN(tt.fen)
 			if err != nil {
 				t.Fatalf("failed to parse FEN: %v", err)
 			}
@@ -533,7 +531,7 @@ func TestParseSAN_WrongTurn(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			board, err := engine.FromFEN(tt.fen)
+			board, err := FromFEN(tt.fen)
 			if err != nil {
 				t.Fatalf("failed to parse FEN: %v", err)
 			}
@@ -616,7 +614,7 @@ func TestParseSAN_KnightMoves(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			board, err := engine.FromFEN(tt.fen)
+			board, err := FromFEN(tt.fen)
 			if err != nil {
 				t.Fatalf("failed to parse FEN: %v", err)
 			}
@@ -690,7 +688,7 @@ func TestParseSAN_BishopMoves(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			board, err := engine.FromFEN(tt.fen)
+			board, err := FromFEN(tt.fen)
 			if err != nil {
 				t.Fatalf("failed to parse FEN: %v", err)
 			}
@@ -757,7 +755,7 @@ func TestParseSAN_RookMoves(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			board, err := engine.FromFEN(tt.fen)
+			board, err := FromFEN(tt.fen)
 			if err != nil {
 				t.Fatalf("failed to parse FEN: %v", err)
 			}
@@ -824,7 +822,7 @@ func TestParseSAN_QueenMoves(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			board, err := engine.FromFEN(tt.fen)
+			board, err := FromFEN(tt.fen)
 			if err != nil {
 				t.Fatalf("failed to parse FEN: %v", err)
 			}
@@ -891,7 +889,7 @@ func TestParseSAN_KingMoves(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			board, err := engine.FromFEN(tt.fen)
+			board, err := FromFEN(tt.fen)
 			if err != nil {
 				t.Fatalf("failed to parse FEN: %v", err)
 			}
@@ -958,7 +956,7 @@ func TestParseSAN_PieceCaptures(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			board, err := engine.FromFEN(tt.fen)
+			board, err := FromFEN(tt.fen)
 			if err != nil {
 				t.Fatalf("failed to parse FEN: %v", err)
 			}
@@ -1060,7 +1058,7 @@ func TestParseSAN_Castling(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			board, err := engine.FromFEN(tt.fen)
+			board, err := FromFEN(tt.fen)
 			if err != nil {
 				t.Fatalf("failed to parse FEN: %v", err)
 			}
@@ -1088,7 +1086,7 @@ func TestParseSAN_Castling(t *testing.T) {
 
 // TestParseSAN_FullGameSequence tests a sequence of moves for a real game.
 func TestParseSAN_FullGameSequence(t *testing.T) {
-	board := engine.NewBoard()
+	board := NewBoard()
 
 	// Test: 1. e4 e5 2. Nf3 Nc6 3. Bc4
 	moves := []struct {
@@ -1197,7 +1195,7 @@ func TestParseSAN_FileDisambiguation(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			board, err := engine.FromFEN(tt.fen)
+			board, err := FromFEN(tt.fen)
 			if err != nil {
 				t.Fatalf("failed to parse FEN: %v", err)
 			}
@@ -1284,7 +1282,7 @@ func TestParseSAN_RankDisambiguation(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			board, err := engine.FromFEN(tt.fen)
+			board, err := FromFEN(tt.fen)
 			if err != nil {
 				t.Fatalf("failed to parse FEN: %v", err)
 			}
@@ -1371,7 +1369,7 @@ func TestParseSAN_FileAndRankDisambiguation(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			board, err := engine.FromFEN(tt.fen)
+			board, err := FromFEN(tt.fen)
 			if err != nil {
 				t.Fatalf("failed to parse FEN: %v", err)
 			}
@@ -1450,7 +1448,7 @@ func TestParseSAN_DisambiguationWithCaptures(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			board, err := engine.FromFEN(tt.fen)
+			board, err := FromFEN(tt.fen)
 			if err != nil {
 				t.Fatalf("failed to parse FEN: %v", err)
 			}
@@ -1478,7 +1476,7 @@ func TestParseSAN_DisambiguationWithCaptures(t *testing.T) {
 
 // TestParseSAN_GameWithDisambiguation tests a game sequence that requires disambiguation.
 func TestParseSAN_GameWithDisambiguation(t *testing.T) {
-	board := engine.NewBoard()
+	board := NewBoard()
 
 	// Play a game that creates ambiguous positions
 	moves := []struct {
@@ -1523,4 +1521,3 @@ func TestParseSAN_GameWithDisambiguation(t *testing.T) {
 }
 
 // TestFormatSAN tests the FormatSAN function for converting moves to coordinate notation.
-
@@ -0,0 +1,160 @@
+package engine
+
+import "fmt"
+
+// castlingSquares are the squares the king and rook of each castling right
+// start from.
+var castlingSquares = []struct {
+	right      uint8
+	letter     rune
+	color      Color
+	king, rook Square
+}{
+	{CastleWhiteKing, 'K', White, NewSquare(4, 0), NewSquare(7, 0)},
+	{CastleWhiteQueen, 'Q', White, NewSquare(4, 0), NewSquare(0, 0)},
+	{CastleBlackKing, 'k', Black, NewSquare(4, 7), NewSquare(7, 7)},
+	{CastleBlackQueen, 'q', Black, NewSquare(4, 7), NewSquare(0, 7)},
+}
+
+// LoadFEN creates a Board from a FEN string like FromFEN, then normalizes it
+// and checks that the position is legal. Use it for positions from users or
+// other programs, which FromFEN takes as they are.
+func LoadFEN(fen string) (*Board, error) {
+	b, err := FromFEN(fen)
+	if err != nil {
+		return nil, err
+	}
+	b.Normalize()
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// NormalizeFEN returns fen as LoadFEN reads it, e.g. without castling rights
+// the pieces can't have or an en passant square no pawn can capture on.
+func NormalizeFEN(fen string) (string, error) {
+	b, err := LoadFEN(fen)
+	if err != nil {
+		return "", err
+	}
+	return b.ToFEN(), nil
+}
+
+// Normalize drops the castling rights whose king or rook has left its starting
+// square, and the en passant square if no pawn can capture en passant. It is
+// meant for boards just loaded from FEN, where such states are harmless slips
+// that would still make otherwise equal positions differ.
+func (b *Board) Normalize() {
+	for _, c := range castlingSquares {
+		if b.CastlingRights&c.right != 0 && !b.canCastle(c.color, c.king, c.rook) {
+			b.CastlingRights &^= c.right
+		}
+	}
+	if b.EnPassantSq >= 0 && !b.enPassantPossible() {
+		b.EnPassantSq = -1
+	}
+
+	b.Hash = b.ComputeHash()
+	if n := len(b.History); n > 0 {
+		b.History[n-1] = b.Hash
+	} else {
+		b.History = append(b.History, b.Hash)
+	}
+}
+
+// Validate reports why the position can't arise in a game, or nil if it can:
+// each side needs exactly one king, at most 16 pieces and 8 pawns, no pawns on
+// the first or last rank, and the side that just moved can't be left in check.
+// Castling rights need their king and rook on their starting squares, and an en
+// passant square must lie behind a pawn that just made a double step.
+func (b *Board) Validate() error {
+	for _, color := range []Color{White, Black} {
+		var kings, pieces, pawns int
+		for sq := Square(0); sq < 64; sq++ {
+			p := b.Squares[sq]
+			if p.IsEmpty() || p.Color() != color {
+				continue
+			}
+			pieces++
+			switch p.Type() {
+			case King:
+				kings++
+			case Pawn:
+				pawns++
+				if sq.Rank() == 0 || sq.Rank() == 7 {
+					return fmt.Errorf("%s pawn on %s", colorName(color), sq)
+				}
+			}
+		}
+		switch {
+		case kings == 0:
+			return fmt.Errorf("%s has no king", colorName(color))
+		case kings > 1:
+			return fmt.Errorf("%s has %d kings", colorName(color), kings)
+		case pieces > 16:
+			return fmt.Errorf("%s has %d pieces, at most 16 are possible", colorName(color), pieces)
+		case pawns > 8:
+			return fmt.Errorf("%s has %d pawns, at most 8 are possible", colorName(color), pawns)
+		}
+	}
+
+	if waiting := opponent(b.ActiveColor); b.rules().InCheck(b, waiting) {
+		return fmt.Errorf("%s is in check but it is %s's move", colorName(waiting), colorName(b.ActiveColor))
+	}
+
+	for _, c := range castlingSquares {
+		if b.CastlingRights&c.right != 0 && !b.canCastle(c.color, c.king, c.rook) {
+			return fmt.Errorf("castling right %c needs a king on %s and a rook on %s", c.letter, c.king, c.rook)
+		}
+	}
+
+	if b.EnPassantSq >= 0 && !b.enPassantConsistent() {
+		return fmt.Errorf("en passant square %s doesn't follow a double pawn push", Square(b.EnPassantSq))
+	}
+	return nil
+}
+
+// canCastle reports whether color's king and rook stand on king and rook.
+func (b *Board) canCastle(color Color, king, rook Square) bool {
+	return b.Squares[king] == NewPiece(color, King) && b.Squares[rook] == NewPiece(color, Rook)
+}
+
+// enPassantConsistent reports whether the en passant square lies behind an
+// enemy pawn that could just have made a double step: it is on the right rank
+// for the side to move, and it and the square the pawn came from are empty.
+func (b *Board) enPassantConsistent() bool {
+	ep := Square(b.EnPassantSq)
+	rank, forward := 5, 1 // White captures on the sixth rank
+	if b.ActiveColor == Black {
+		rank, forward = 2, -1
+	}
+	if ep.Rank() != rank {
+		return false
+	}
+	pawn := NewSquare(ep.File(), rank-forward)
+	from := NewSquare(ep.File(), rank+forward)
+	return b.Squares[ep].IsEmpty() && b.Squares[from].IsEmpty() &&
+		b.Squares[pawn] == NewPiece(opponent(b.ActiveColor), Pawn)
+}
+
+// enPassantPossible reports whether the side to move has a legal en passant capture.
+func (b *Board) enPassantPossible() bool {
+	if !b.enPassantConsistent() {
+		return false
+	}
+	for _, m := range b.LegalMoves() {
+		if int8(m.To) == b.EnPassantSq && b.Squares[m.From].Type() == Pawn {
+			return true
+		}
+	}
+	return false
+}
+
+// colorName returns "white" or "black".
+func colorName(c Color) string {
+	if c == White {
+		return "white"
+	}
+	return "black"
+}
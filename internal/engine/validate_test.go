@@ -0,0 +1,110 @@
+package engine
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		fen     string
+		wantErr string // "" for a legal position
+	}{
+		{"starting position", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", ""},
+		{"en passant", "4k3/8/8/3pP3/8/8/8/4K3 w - d6 0 2", ""},
+		{"no white king", "4k3/8/8/8/8/8/8/8 w - - 0 1", "white has no king"},
+		{"two black kings", "k3k3/8/8/8/8/8/8/4K3 w - - 0 1", "black has 2 kings"},
+		{"pawn on last rank", "4k2P/8/8/8/8/8/8/4K3 w - - 0 1", "white pawn on h8"},
+		{"nine pawns", "4k3/pppppppp/p7/8/8/8/8/4K3 w - - 0 1", "black has 9 pawns"},
+		{"waiting side in check", "4k3/8/8/8/8/8/8/4R1K1 w - - 0 1", "black is in check but it is white's move"},
+		{"castling without rook", "4k3/8/8/8/8/8/8/4K3 w K - 0 1", "castling right K needs a king on e1 and a rook on h1"},
+		{"en passant without pawn", "4k3/8/8/8/8/8/8/4K3 w - d6 0 1", "en passant square d6"},
+		{"en passant on wrong rank", "4k3/8/8/3pP3/8/8/8/4K3 w - d3 0 1", "en passant square d3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := FromFEN(tt.fen)
+			if err != nil {
+				t.Fatalf("FromFEN() error = %v", err)
+			}
+			err = b.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNormalizeFEN(t *testing.T) {
+	tests := []struct {
+		name string
+		fen  string
+		want string
+	}{
+		{
+			"unchanged",
+			"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+			"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+		},
+		{
+			"castling rights of moved pieces",
+			"r3k3/8/8/8/8/8/8/R4K1R w KQkq - 0 1",
+			"r3k3/8/8/8/8/8/8/R4K1R w q - 0 1",
+		},
+		{
+			"en passant nobody can capture",
+			"rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1",
+			"rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq - 0 1",
+		},
+		{
+			"en passant capture kept",
+			"4k3/8/8/3pP3/8/8/8/4K3 w - d6 0 2",
+			"4k3/8/8/3pP3/8/8/8/4K3 w - d6 0 2",
+		},
+		{
+			"en passant capture that is pinned",
+			"4k3/8/8/K2pP2r/8/8/8/8 w - d6 0 2",
+			"4k3/8/8/K2pP2r/8/8/8/8 w - - 0 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeFEN(tt.fen)
+			if err != nil {
+				t.Fatalf("NormalizeFEN() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeFEN() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadFEN(t *testing.T) {
+	if _, err := LoadFEN("not a fen"); err == nil {
+		t.Error("Expected an error for a malformed FEN")
+	}
+	if _, err := LoadFEN("4k3/8/8/8/8/8/8/8 w - - 0 1"); err == nil {
+		t.Error("Expected an error for a position without a white king")
+	}
+
+	b, err := LoadFEN("rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1")
+	if err != nil {
+		t.Fatalf("LoadFEN() error = %v", err)
+	}
+	if b.EnPassantSq != -1 {
+		t.Errorf("Expected the en passant square to be cleared, got %d", b.EnPassantSq)
+	}
+	if b.Hash != b.ComputeHash() || b.History[len(b.History)-1] != b.Hash {
+		t.Error("Expected the hash and history to match the normalized position")
+	}
+}
@@ -0,0 +1,119 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Variant defines the rules that differ between chess variants. The board calls
+// into its variant for the special cases of move generation, capture side effects,
+// move legality, check detection, game endings and draw rules, so new variants
+// can be added without changing the board itself.
+//
+// Variants that only change some rules can embed Standard and override the rest.
+type Variant interface {
+	// Name returns the variant's display name.
+	Name() string
+
+	// PromotionPieces returns the piece types a pawn may promote to.
+	PromotionPieces() []PieceType
+
+	// CastlingMoves returns the castling moves available to the king on kingSq.
+	CastlingMoves(b *Board, kingSq Square) []Move
+
+	// AfterCapture is called while a capturing move m is applied to b, after the
+	// capturing piece has landed on m.To and before castling rights, the en passant
+	// square and the side to move are updated.
+	AfterCapture(b *Board, m Move)
+
+	// IsLegal reports whether the pseudo-legal move m, which resulted in b, is
+	// legal. moved is the piece that made the move and captured the piece that
+	// stood on m.To before it, Empty if none (including en passant captures).
+	IsLegal(b *Board, m Move, moved, captured Piece) bool
+
+	// InCheck reports whether color's king is in check on b.
+	InCheck(b *Board, color Color) bool
+
+	// Result reports whether the position is decided by a rule of the variant
+	// that applies before legal moves are considered, and with which status.
+	Result(b *Board) (GameStatus, bool)
+
+	// NoLegalMoves returns the status of a position where the side to move has no legal moves.
+	NoLegalMoves(b *Board) GameStatus
+
+	// Draw reports whether the position is drawn, automatically or by claim, and with which status.
+	Draw(b *Board) (GameStatus, bool)
+}
+
+// Standard implements the rules of standard chess. It is used by boards with no Variant set.
+type Standard struct{}
+
+// Name returns "Standard".
+func (Standard) Name() string { return "Standard" }
+
+// standardPromotions are the pieces a pawn may promote to in standard chess.
+var standardPromotions = []PieceType{Queen, Rook, Bishop, Knight}
+
+// PromotionPieces returns queen, rook, bishop and knight.
+func (Standard) PromotionPieces() []PieceType { return standardPromotions }
+
+// CastlingMoves returns the standard king- and queenside castling moves.
+func (Standard) CastlingMoves(b *Board, kingSq Square) []Move {
+	return b.generateCastlingMoves(kingSq)
+}
+
+// AfterCapture does nothing in standard chess.
+func (Standard) AfterCapture(b *Board, m Move) {}
+
+// IsLegal reports whether the move leaves the mover's king out of check.
+func (Standard) IsLegal(b *Board, m Move, moved, captured Piece) bool {
+	mover := moved.Color()
+	if b.KingSquare(mover) == NoSquare {
+		return false
+	}
+	return !b.kingAttacked(mover)
+}
+
+// InCheck reports whether color's king is attacked.
+func (Standard) InCheck(b *Board, color Color) bool {
+	return b.kingAttacked(color)
+}
+
+// Result never decides a standard game early.
+func (Standard) Result(b *Board) (GameStatus, bool) {
+	return Ongoing, false
+}
+
+// NoLegalMoves returns Checkmate if the side to move is in check, otherwise Stalemate.
+func (Standard) NoLegalMoves(b *Board) GameStatus {
+	if b.InCheck() {
+		return Checkmate
+	}
+	return Stalemate
+}
+
+// Draw applies the repetition, seventy-five/fifty-move and insufficient material rules.
+func (Standard) Draw(b *Board) (GameStatus, bool) {
+	return b.standardDraw()
+}
+
+// Variants lists the supported variants, standard chess first.
+var Variants = []Variant{Standard{}, Atomic{}}
+
+// ParseVariant returns the variant with the given name, ignoring case.
+func ParseVariant(name string) (Variant, error) {
+	for _, v := range Variants {
+		if strings.EqualFold(v.Name(), name) {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown variant %q", name)
+}
+
+// rules returns the board's variant, defaulting to standard chess.
+func (b *Board) rules() Variant {
+	if b.Variant == nil {
+		return Standard{}
+	}
+	return b.Variant
+}
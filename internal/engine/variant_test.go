@@ -0,0 +1,99 @@
+package engine
+
+import "testing"
+
+// TestStandardVariantMatchesDefault tests that setting the Standard variant explicitly
+// plays exactly like a board with no variant.
+func TestStandardVariantMatchesDefault(t *testing.T) {
+	tests := []struct {
+		name  string
+		fen   string
+		depth int
+		nodes uint64
+	}{
+		{"starting position", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", 3, 8902},
+		{"kiwipete position", "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1", 2, 2039},
+		{"position 4", "r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1", 3, 9467},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := FromFEN(tt.fen)
+			if err != nil {
+				t.Fatalf("FromFEN() error = %v", err)
+			}
+			b.Variant = Standard{}
+			if got := b.Perft(tt.depth); got != tt.nodes {
+				t.Errorf("Perft(%d) = %d, want %d", tt.depth, got, tt.nodes)
+			}
+		})
+	}
+}
+
+// TestStandardVariantStatus tests that game endings and draws are unchanged under the Standard variant.
+func TestStandardVariantStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		fen  string
+		want GameStatus
+	}{
+		{"checkmate", "rnb1kbnr/pppp1ppp/8/4p3/6Pq/5P2/PPPPP2P/RNBQKBNR w KQkq - 1 3", Checkmate},
+		{"stalemate", "7k/5Q2/6K1/8/8/8/8/8 b - - 0 1", Stalemate},
+		{"insufficient material", "8/8/4k3/8/8/4K3/8/8 w - - 0 1", DrawInsufficientMaterial},
+		{"fifty-move rule", "8/8/4k3/8/8/4K3/4R3/8 w - - 100 80", DrawFiftyMoveRule},
+		{"ongoing", "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1", Ongoing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := FromFEN(tt.fen)
+			if err != nil {
+				t.Fatalf("FromFEN() error = %v", err)
+			}
+			if got := b.Status(); got != tt.want {
+				t.Errorf("default Status() = %v, want %v", got, tt.want)
+			}
+			b.Variant = Standard{}
+			if got := b.Status(); got != tt.want {
+				t.Errorf("Standard Status() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// noCastlingVariant is a test variant without castling, with queen-only promotion,
+// in which a player without legal moves always loses.
+type noCastlingVariant struct {
+	Standard
+}
+
+func (noCastlingVariant) Name() string                                 { return "No Castling" }
+func (noCastlingVariant) PromotionPieces() []PieceType                 { return []PieceType{Queen} }
+func (noCastlingVariant) CastlingMoves(b *Board, kingSq Square) []Move { return nil }
+func (noCastlingVariant) NoLegalMoves(b *Board) GameStatus             { return Checkmate }
+
+// TestCustomVariant tests that a variant embedding Standard can replace individual rules.
+func TestCustomVariant(t *testing.T) {
+	b, err := FromFEN("r3k3/1P6/8/8/8/8/8/4K2R w Kq - 0 1")
+	if err != nil {
+		t.Fatalf("FromFEN() error = %v", err)
+	}
+	b.Variant = noCastlingVariant{}
+
+	moves := b.LegalMoves()
+	if hasMove(moves, "e1g1") {
+		t.Error("Expected castling to be unavailable")
+	}
+	if !hasMove(moves, "b7a8q") || hasMove(moves, "b7a8n") {
+		t.Error("Expected only queen promotions")
+	}
+
+	stalemate, err := FromFEN("7k/5Q2/6K1/8/8/8/8/8 b - - 0 1")
+	if err != nil {
+		t.Fatalf("FromFEN() error = %v", err)
+	}
+	stalemate.Variant = noCastlingVariant{}
+	if winner, ok := stalemate.Winner(); !ok || winner != White {
+		t.Errorf("Winner() = %v, %v; want White to win by stalemate", winner, ok)
+	}
+}
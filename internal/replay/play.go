@@ -0,0 +1,39 @@
+package replay
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Play sends the events of r to send at the pace they were recorded, sped up by
+// speed: 2 plays twice as fast, 0.5 at half speed. Window resizes are left out,
+// so the program keeps the size of the terminal it plays in. Play returns once
+// all events were sent, or early with the context's error when ctx is done.
+func Play(ctx context.Context, r *Recording, speed float64, send func(tea.Msg)) error {
+	start := time.Now()
+	for _, e := range r.Events {
+		if e.Width > 0 {
+			continue
+		}
+		msg, err := e.Msg()
+		if err != nil {
+			return err
+		}
+		due := start.Add(time.Duration(float64(e.At) * float64(time.Millisecond) / speed))
+		if wait := time.Until(due); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		} else if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		send(msg)
+	}
+	return nil
+}
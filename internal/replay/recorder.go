@@ -0,0 +1,94 @@
+package replay
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Recorder writes the input of a session to a recording file as it happens.
+type Recorder struct {
+	mu    sync.Mutex
+	file  *os.File
+	w     *bufio.Writer
+	start time.Time
+	err   error
+}
+
+// Create starts recording to a new file at path, replacing any file there.
+// version is the version of TermChess recording the session.
+func Create(path, version string) (*Recorder, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recording: %w", err)
+	}
+	r := &Recorder{file: file, w: bufio.NewWriter(file), start: time.Now()}
+	header := struct {
+		Version    int       `toml:"version"`
+		TermChess  string    `toml:"termchess"`
+		RecordedAt time.Time `toml:"recorded_at"`
+	}{Version, version, r.start.UTC().Truncate(time.Second)}
+	r.write(header, "")
+	if r.err != nil {
+		file.Close()
+		return nil, r.err
+	}
+	return r, nil
+}
+
+// Filter records msg if it is input and returns it unchanged. It has the
+// signature of tea.WithFilter, so a program can record everything it receives.
+func (r *Recorder) Filter(_ tea.Model, msg tea.Msg) tea.Msg {
+	r.Record(msg, time.Now())
+	return msg
+}
+
+// Record adds msg, received at at, to the recording if it is input. Errors
+// stop the recording and are reported by Close.
+func (r *Recorder) Record(msg tea.Msg, at time.Time) {
+	e, ok := eventFor(msg)
+	if !ok {
+		return
+	}
+	e.At = at.Sub(r.start).Milliseconds()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.write(e, "\n[[events]]\n")
+}
+
+// write appends v encoded as TOML after prefix and flushes it to the file.
+// Callers hold mu, or own the recorder.
+func (r *Recorder) write(v any, prefix string) {
+	if r.err != nil {
+		return
+	}
+	var buf bytes.Buffer
+	buf.WriteString(prefix)
+	if err := toml.NewEncoder(&buf).Encode(v); err != nil {
+		r.err = fmt.Errorf("failed to encode recording: %w", err)
+		return
+	}
+	if _, err := r.w.Write(buf.Bytes()); err != nil {
+		r.err = fmt.Errorf("failed to write recording: %w", err)
+		return
+	}
+	if err := r.w.Flush(); err != nil {
+		r.err = fmt.Errorf("failed to write recording: %w", err)
+	}
+}
+
+// Close ends the recording. It returns the first error the recording ran into.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.file.Close(); err != nil && r.err == nil {
+		r.err = fmt.Errorf("failed to write recording: %w", err)
+	}
+	return r.err
+}
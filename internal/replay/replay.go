@@ -0,0 +1,187 @@
+// Package replay records the input of an interactive session to a file and plays
+// it back, so sessions can be shown as demos, turned into animated docs or sent
+// along with a bug report to reproduce it.
+//
+// A recording is a TOML file with a header followed by one [[events]] table per
+// event, appended as the session goes so a crash loses nothing:
+//
+//	version = 1
+//	termchess = "v0.3.0"
+//	recorded_at = 2026-10-15T10:04:05Z
+//
+//	[[events]]
+//	at_ms = 1520
+//	text = "n"
+//
+//	[[events]]
+//	at_ms = 2310
+//	key = "enter"
+//
+// Only input is recorded: keys, pastes, mouse clicks and wheel turns, and window
+// resizes. What the program does with it, such as the moves bots choose, may
+// differ when it is played back.
+package replay
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Version is the version of the recording format written by Recorder.
+const Version = 1
+
+// Event is one input event of a recording: a key press (Key or Text), a mouse
+// event (Mouse) or a resize (Width and Height).
+type Event struct {
+	// At is when the event happened, in milliseconds since the recording started.
+	At int64 `toml:"at_ms"`
+	// Key is the name of a key that isn't text, e.g. "enter", "up" or "ctrl+p".
+	Key string `toml:"key,omitempty"`
+	// Text is the text typed with a key press, or pasted if Paste is set. Keys
+	// with a name, such as space, may come with text too.
+	Text string `toml:"text,omitempty"`
+	// Paste is set when Text was pasted.
+	Paste bool `toml:"paste,omitempty"`
+	// Mouse is the mouse button and action, e.g. "left press" or "wheel up".
+	Mouse string `toml:"mouse,omitempty"`
+	// X and Y are the cell the mouse was on.
+	X int `toml:"x,omitempty"`
+	Y int `toml:"y,omitempty"`
+	// Alt, Ctrl and Shift are the modifiers held with a key or mouse event.
+	// Ctrl and Shift are part of the name of keys.
+	Alt   bool `toml:"alt,omitempty"`
+	Ctrl  bool `toml:"ctrl,omitempty"`
+	Shift bool `toml:"shift,omitempty"`
+	// Width and Height are the new size of the window after a resize.
+	Width  int `toml:"width,omitempty"`
+	Height int `toml:"height,omitempty"`
+}
+
+// Recording is a recorded session.
+type Recording struct {
+	// Version is the format version of the file.
+	Version int `toml:"version"`
+	// TermChess is the version of TermChess that recorded the session.
+	TermChess string `toml:"termchess"`
+	// RecordedAt is when the recording started.
+	RecordedAt time.Time `toml:"recorded_at"`
+	// Events are the recorded events in order.
+	Events []Event `toml:"events"`
+}
+
+// Duration returns how long the recorded session lasted, up to its last event.
+func (r *Recording) Duration() time.Duration {
+	if len(r.Events) == 0 {
+		return 0
+	}
+	return time.Duration(r.Events[len(r.Events)-1].At) * time.Millisecond
+}
+
+// Load reads the recording in the file at path.
+func Load(path string) (*Recording, error) {
+	var r Recording
+	if _, err := toml.DecodeFile(path, &r); err != nil {
+		if os.IsNotExist(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+	if r.Version > Version {
+		return nil, fmt.Errorf("%s was recorded by a newer version of TermChess (format %d, this version plays up to %d)",
+			path, r.Version, Version)
+	}
+	for i, e := range r.Events {
+		if _, err := e.Msg(); err != nil {
+			return nil, fmt.Errorf("event %d: %w", i+1, err)
+		}
+	}
+	return &r, nil
+}
+
+// keyTypes maps the names of keys to their types, e.g. "enter" to tea.KeyEnter.
+var keyTypes = func() map[string]tea.KeyType {
+	types := make(map[string]tea.KeyType)
+	// Special keys are negative and control keys go up to DEL (127)
+	for k := tea.KeyF20; k <= tea.KeyCtrlQuestionMark; k++ {
+		if name := k.String(); name != "" && k != tea.KeyRunes {
+			types[name] = k
+		}
+	}
+	return types
+}()
+
+// mouseEvents maps the names of mouse events to their button and action, e.g.
+// "left press" to the left button being pressed.
+var mouseEvents = func() map[string]tea.MouseEvent {
+	events := make(map[string]tea.MouseEvent)
+	for b := tea.MouseButtonNone; b <= tea.MouseButton11; b++ {
+		for _, a := range []tea.MouseAction{tea.MouseActionPress, tea.MouseActionRelease} {
+			e := tea.MouseEvent{Button: b, Action: a}
+			if _, ok := events[e.String()]; !ok {
+				events[e.String()] = e
+			}
+		}
+	}
+	return events
+}()
+
+// eventFor returns the event recording msg, or false if msg isn't recorded.
+// Mouse motion isn't recorded, as nothing reacts to it and there is a lot of it.
+func eventFor(msg tea.Msg) (Event, bool) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyRunes && len(msg.Runes) == 0 {
+			return Event{}, false
+		}
+		e := Event{Text: string(msg.Runes), Alt: msg.Alt, Paste: msg.Paste}
+		if msg.Type != tea.KeyRunes {
+			e.Key = msg.Type.String()
+		}
+		return e, true
+	case tea.MouseMsg:
+		if msg.Action == tea.MouseActionMotion {
+			return Event{}, false
+		}
+		return Event{
+			Mouse: tea.MouseEvent{Button: msg.Button, Action: msg.Action}.String(),
+			X:     msg.X, Y: msg.Y,
+			Alt: msg.Alt, Ctrl: msg.Ctrl, Shift: msg.Shift,
+		}, true
+	case tea.WindowSizeMsg:
+		return Event{Width: msg.Width, Height: msg.Height}, true
+	}
+	return Event{}, false
+}
+
+// Msg returns the message the event was recorded from.
+func (e Event) Msg() (tea.Msg, error) {
+	switch {
+	case e.Key != "":
+		k, ok := keyTypes[e.Key]
+		if !ok {
+			return nil, fmt.Errorf("unknown key %q", e.Key)
+		}
+		msg := tea.KeyMsg{Type: k, Alt: e.Alt}
+		if e.Text != "" {
+			msg.Runes = []rune(e.Text)
+		}
+		return msg, nil
+	case e.Text != "":
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(e.Text), Alt: e.Alt, Paste: e.Paste}, nil
+	case e.Mouse != "":
+		m, ok := mouseEvents[e.Mouse]
+		if !ok {
+			return nil, fmt.Errorf("unknown mouse event %q", e.Mouse)
+		}
+		m.X, m.Y = e.X, e.Y
+		m.Alt, m.Ctrl, m.Shift = e.Alt, e.Ctrl, e.Shift
+		return tea.MouseMsg(m), nil
+	case e.Width > 0 && e.Height > 0:
+		return tea.WindowSizeMsg{Width: e.Width, Height: e.Height}, nil
+	}
+	return nil, fmt.Errorf("no key, text, mouse event or window size")
+}
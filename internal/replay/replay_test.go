@@ -0,0 +1,139 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRecordAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.toml")
+	r, err := Create(path, "v1.2.3")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	msgs := []tea.Msg{
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")},
+		tea.KeyMsg{Type: tea.KeyEnter},
+		tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")},
+		tea.KeyMsg{Type: tea.KeyCtrlP},
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e2e4"), Paste: true},
+		tea.KeyMsg{Type: tea.KeyLeft, Alt: true},
+		tea.MouseMsg{X: 12, Y: 7, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress},
+		tea.MouseMsg{X: 12, Y: 7, Button: tea.MouseButtonWheelUp, Action: tea.MouseActionPress, Ctrl: true},
+		tea.WindowSizeMsg{Width: 120, Height: 40},
+	}
+	for i, msg := range msgs {
+		r.Record(msg, r.start.Add(time.Duration(i)*100*time.Millisecond))
+	}
+	// Neither motion nor other messages are input worth recording
+	r.Record(tea.MouseMsg{X: 3, Y: 4, Action: tea.MouseActionMotion}, r.start)
+	r.Record(tea.KeyMsg{Type: tea.KeyRunes}, r.start)
+	r.Record("not input", r.start)
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	rec, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if rec.Version != Version || rec.TermChess != "v1.2.3" || rec.RecordedAt.IsZero() {
+		t.Errorf("Expected the header of version %d by v1.2.3, got %+v", Version, rec)
+	}
+	if len(rec.Events) != len(msgs) {
+		t.Fatalf("Expected %d events, got %d", len(msgs), len(rec.Events))
+	}
+	for i, e := range rec.Events {
+		if want := int64(i * 100); e.At != want {
+			t.Errorf("Event %d at %dms, want %dms", i+1, e.At, want)
+		}
+		got, err := e.Msg()
+		if err != nil {
+			t.Fatalf("Event %d: Msg() error = %v", i+1, err)
+		}
+		if !reflect.DeepEqual(got, msgs[i]) {
+			t.Errorf("Event %d = %#v, want %#v", i+1, got, msgs[i])
+		}
+	}
+	if want := 800 * time.Millisecond; rec.Duration() != want {
+		t.Errorf("Duration() = %v, want %v", rec.Duration(), want)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+	}{
+		{"newer version", "version = 2\n"},
+		{"unknown key", "version = 1\n\n[[events]]\nat_ms = 10\nkey = \"hyper+x\"\n"},
+		{"unknown mouse event", "version = 1\n\n[[events]]\nat_ms = 10\nmouse = \"left wiggle\"\n"},
+		{"empty event", "version = 1\n\n[[events]]\nat_ms = 10\n"},
+		{"not TOML", "version = \n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "session.toml")
+			if err := os.WriteFile(path, []byte(tt.contents), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := Load(path); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.toml")); !os.IsNotExist(err) {
+		t.Errorf("Expected a missing file to be reported as such, got %v", err)
+	}
+}
+
+func TestPlay(t *testing.T) {
+	rec := &Recording{Version: Version, Events: []Event{
+		{At: 0, Text: "e"},
+		{At: 100, Width: 80, Height: 24},
+		{At: 200, Key: "enter"},
+	}}
+
+	var got []tea.Msg
+	start := time.Now()
+	if err := Play(context.Background(), rec, 4, func(msg tea.Msg) { got = append(got, msg) }); err != nil {
+		t.Fatalf("Play() error = %v", err)
+	}
+	// 200ms at four times the speed
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("Expected playback to take at least 50ms, took %v", elapsed)
+	}
+	want := []tea.Msg{
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")},
+		tea.KeyMsg{Type: tea.KeyEnter},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected the keys without the resize, got %#v", got)
+	}
+}
+
+func TestPlayCancelled(t *testing.T) {
+	rec := &Recording{Version: Version, Events: []Event{{At: 0, Text: "e"}, {At: 60_000, Text: "4"}}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sent := 0
+	err := Play(ctx, rec, 1, func(tea.Msg) {
+		sent++
+		cancel()
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected playback to stop when cancelled, got %v", err)
+	}
+	if sent != 1 {
+		t.Errorf("Expected 1 event before cancelling, got %d", sent)
+	}
+}
@@ -0,0 +1,74 @@
+package spectate
+
+// page is the spectator web page. It polls /state.json and draws each game's
+// board from its FEN, so it needs nothing but the server.
+const page = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>TermChess Live</title>
+<style>
+body { font-family: sans-serif; background: #1e1e1e; color: #ddd; margin: 2em; }
+.games { display: flex; flex-wrap: wrap; gap: 2em; }
+.game h2 { font-size: 1em; margin: 0 0 .5em; }
+table { border-collapse: collapse; }
+td { width: 2em; height: 2em; text-align: center; font-size: 1.6em; color: #000; }
+.light { background: #eed8b0; }
+.dark { background: #b58863; }
+.status { margin-top: .5em; font-size: .9em; color: #aaa; }
+</style>
+</head>
+<body>
+<h1>TermChess Live</h1>
+<p id="mode">Connecting...</p>
+<div class="games" id="games"></div>
+<script>
+const glyphs = {K:"♔",Q:"♕",R:"♖",B:"♗",N:"♘",P:"♙",k:"♚",q:"♛",r:"♜",b:"♝",n:"♞",p:"♟"};
+
+function board(fen) {
+  const rows = fen.split(" ")[0].split("/");
+  let html = "<table>";
+  rows.forEach((row, r) => {
+    html += "<tr>";
+    let c = 0;
+    for (const ch of row) {
+      const empty = parseInt(ch, 10);
+      const cells = isNaN(empty) ? [glyphs[ch] || ""] : Array(empty).fill("");
+      for (const piece of cells) {
+        html += "<td class=\"" + ((r + c) % 2 ? "dark" : "light") + "\">" + piece + "</td>";
+        c++;
+      }
+    }
+    html += "</tr>";
+  });
+  return html + "</table>";
+}
+
+function text(s) {
+  const el = document.createElement("span");
+  el.textContent = s;
+  return el.innerHTML;
+}
+
+async function refresh() {
+  try {
+    const state = await (await fetch("state.json")).json();
+    document.getElementById("mode").textContent =
+      state.mode === "idle" ? "No game in progress." : "";
+    document.getElementById("games").innerHTML = state.games.map(g =>
+      "<div class=\"game\"><h2>" + (g.number ? "Game " + g.number + ": " : "") +
+      text(g.white) + " vs " + text(g.black) + "</h2>" + board(g.fen) +
+      "<div class=\"status\">" + g.moves.length + " moves, " +
+      text(g.result ? g.status + " (" + g.result + ")" : g.status) + "</div></div>"
+    ).join("");
+  } catch (e) {
+    document.getElementById("mode").textContent = "Disconnected.";
+  }
+}
+
+refresh();
+setInterval(refresh, 1000);
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,167 @@
+// Package spectate serves a read-only live view of the games being played, so
+// they can be watched from a browser or followed with curl while TermChess runs.
+package spectate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Game is the state of one game as seen by spectators.
+type Game struct {
+	// Number is the game's number in a Bot vs Bot session, or 0 for a single game.
+	Number int    `json:"number,omitempty"`
+	White  string `json:"white"`
+	Black  string `json:"black"`
+	// FEN is the current position.
+	FEN string `json:"fen"`
+	// Moves lists the moves played so far in coordinate notation (e.g. "e2e4").
+	Moves []string `json:"moves"`
+	// Status is "ongoing" or a description of how the game ended.
+	Status string `json:"status"`
+	// Result is "1-0", "0-1" or "1/2-1/2" once the game has ended, otherwise empty.
+	Result string `json:"result,omitempty"`
+}
+
+// Snapshot is everything spectators can see at one moment.
+type Snapshot struct {
+	// Mode is "idle" when no game is on screen, "game" for a single game or "bvb"
+	// for a Bot vs Bot session.
+	Mode  string `json:"mode"`
+	Games []Game `json:"games"`
+	// Updated is when the snapshot was published.
+	Updated time.Time `json:"updated"`
+}
+
+// Server is an HTTP server publishing the latest Snapshot. It serves:
+//   - /            a web page that follows the games live
+//   - /state.json  the snapshot as JSON
+//   - /fen         the FEN of the first game, or of game N with ?game=N
+type Server struct {
+	mu       sync.RWMutex
+	snapshot Snapshot
+
+	listener net.Listener
+	http     *http.Server
+}
+
+// NewServer returns a server with an idle snapshot that is not listening yet.
+// Use it as an http.Handler, or call Listen to serve it.
+func NewServer() *Server {
+	return &Server{snapshot: Snapshot{Mode: "idle", Games: []Game{}, Updated: time.Now()}}
+}
+
+// Listen starts serving on addr (e.g. ":8080" or "localhost:0") in the background.
+func Listen(addr string) (*Server, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	s := NewServer()
+	s.listener = listener
+	s.http = &http.Server{Handler: s, ReadHeaderTimeout: 5 * time.Second}
+	go func() { _ = s.http.Serve(listener) }()
+	return s, nil
+}
+
+// Addr returns the address the server listens on, or "" if it isn't listening.
+func (s *Server) Addr() string {
+	if s.listener == nil {
+		return ""
+	}
+	return s.listener.Addr().String()
+}
+
+// Publish replaces the snapshot served to spectators.
+func (s *Server) Publish(snapshot Snapshot) {
+	if snapshot.Games == nil {
+		snapshot.Games = []Game{}
+	}
+	if snapshot.Updated.IsZero() {
+		snapshot.Updated = time.Now()
+	}
+	s.mu.Lock()
+	s.snapshot = snapshot
+	s.mu.Unlock()
+}
+
+// Snapshot returns the snapshot currently served.
+func (s *Server) Snapshot() Snapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.snapshot
+}
+
+// Close stops the server. It is safe to call on a server that isn't listening.
+func (s *Server) Close() error {
+	if s.http == nil {
+		return nil
+	}
+	err := s.http.Close()
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// ServeHTTP implements http.Handler. The view is read-only, so only GET and HEAD
+// requests are accepted.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+
+	switch r.URL.Path {
+	case "/":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(page))
+	case "/state.json":
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(s.Snapshot())
+	case "/fen":
+		s.serveFEN(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// serveFEN writes the FEN of the requested game as plain text.
+func (s *Server) serveFEN(w http.ResponseWriter, r *http.Request) {
+	snapshot := s.Snapshot()
+	if len(snapshot.Games) == 0 {
+		http.Error(w, "no game in progress", http.StatusNotFound)
+		return
+	}
+
+	game := snapshot.Games[0]
+	if q := r.URL.Query().Get("game"); q != "" {
+		n, err := strconv.Atoi(q)
+		if err != nil {
+			http.Error(w, "invalid game number", http.StatusBadRequest)
+			return
+		}
+		found := false
+		for _, g := range snapshot.Games {
+			if g.Number == n {
+				game, found = g, true
+				break
+			}
+		}
+		if !found {
+			http.Error(w, fmt.Sprintf("no game %d", n), http.StatusNotFound)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintln(w, game.FEN)
+}
@@ -0,0 +1,94 @@
+package spectate
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const startFEN = "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1"
+
+func get(t *testing.T, s *Server, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	return rec
+}
+
+func TestServerStartsIdle(t *testing.T) {
+	s := NewServer()
+
+	rec := get(t, s, "/state.json")
+	var snapshot Snapshot
+	if err := json.Unmarshal(rec.Body.Bytes(), &snapshot); err != nil {
+		t.Fatalf("state.json is not JSON: %v", err)
+	}
+	if snapshot.Mode != "idle" || len(snapshot.Games) != 0 {
+		t.Errorf("snapshot = %+v, want idle with no games", snapshot)
+	}
+	if rec := get(t, s, "/fen"); rec.Code != http.StatusNotFound {
+		t.Errorf("/fen status = %d, want 404 with no game", rec.Code)
+	}
+}
+
+func TestServerServesPublishedGames(t *testing.T) {
+	s := NewServer()
+	s.Publish(Snapshot{Mode: "bvb", Games: []Game{
+		{Number: 1, White: "Easy Bot", Black: "Hard Bot", FEN: startFEN, Moves: []string{}, Status: "ongoing"},
+		{Number: 2, White: "Easy Bot", Black: "Hard Bot", FEN: "8/8/8/8/8/8/8/K6k w - - 0 1", Status: "stalemate", Result: "1/2-1/2"},
+	}})
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(get(t, s, "/state.json").Body.Bytes(), &snapshot); err != nil {
+		t.Fatalf("state.json is not JSON: %v", err)
+	}
+	if snapshot.Mode != "bvb" || len(snapshot.Games) != 2 || snapshot.Games[1].Result != "1/2-1/2" {
+		t.Errorf("snapshot = %+v, want both published games", snapshot)
+	}
+
+	if got := strings.TrimSpace(get(t, s, "/fen").Body.String()); got != startFEN {
+		t.Errorf("/fen = %q, want the first game", got)
+	}
+	if got := strings.TrimSpace(get(t, s, "/fen?game=2").Body.String()); !strings.HasPrefix(got, "8/8/8") {
+		t.Errorf("/fen?game=2 = %q, want the second game", got)
+	}
+	if rec := get(t, s, "/fen?game=9"); rec.Code != http.StatusNotFound {
+		t.Errorf("/fen?game=9 status = %d, want 404", rec.Code)
+	}
+	if rec := get(t, s, "/fen?game=x"); rec.Code != http.StatusBadRequest {
+		t.Errorf("/fen?game=x status = %d, want 400", rec.Code)
+	}
+}
+
+func TestServerIsReadOnly(t *testing.T) {
+	s := NewServer()
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/state.json", strings.NewReader("{}")))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST status = %d, want 405", rec.Code)
+	}
+	if rec := get(t, s, "/nothing"); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown path status = %d, want 404", rec.Code)
+	}
+}
+
+func TestListenServesPage(t *testing.T) {
+	s, err := Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	defer s.Close()
+
+	resp, err := http.Get("http://" + s.Addr() + "/")
+	if err != nil {
+		t.Fatalf("GET / error = %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "state.json") {
+		t.Errorf("GET / = %d, want the live page polling state.json", resp.StatusCode)
+	}
+}
@@ -0,0 +1,200 @@
+package spectate
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// maxSnapshotSize bounds a snapshot line read by a Watcher. A Bot vs Bot session
+// with many long games is still far below it.
+const maxSnapshotSize = 16 << 20
+
+// watcherWriteTimeout is how long a watcher may take to accept a snapshot before
+// it is disconnected, so a stuck watcher can't hold anything up.
+const watcherWriteTimeout = 5 * time.Second
+
+// Broadcaster shares the games with other TermChess instances over a unix socket.
+// Every published Snapshot is sent as a JSON line to each connected watcher, and
+// a watcher that connects mid-game gets the latest snapshot straight away.
+// Watchers only ever receive: anything they send is ignored.
+type Broadcaster struct {
+	listener net.Listener
+
+	mu       sync.Mutex
+	latest   []byte
+	watchers map[*watcherConn]struct{}
+	closed   bool
+}
+
+// watcherConn is one connected watcher. updates holds at most the newest snapshot
+// not yet written, so a slow watcher skips snapshots instead of falling behind.
+type watcherConn struct {
+	conn    net.Conn
+	updates chan []byte
+}
+
+// ListenUnix creates the unix socket at path and starts accepting watchers. A
+// socket file left behind by an instance that is no longer running is replaced.
+func ListenUnix(path string) (*Broadcaster, error) {
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("%s is already being shared by another instance", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	b := &Broadcaster{
+		listener: listener,
+		watchers: make(map[*watcherConn]struct{}),
+	}
+	go b.accept()
+	return b, nil
+}
+
+// accept adds watchers until the listener is closed.
+func (b *Broadcaster) accept() {
+	for {
+		conn, err := b.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		w := &watcherConn{conn: conn, updates: make(chan []byte, 1)}
+		b.mu.Lock()
+		if b.closed {
+			b.mu.Unlock()
+			conn.Close()
+			return
+		}
+		b.watchers[w] = struct{}{}
+		if b.latest != nil {
+			w.updates <- b.latest
+		}
+		b.mu.Unlock()
+
+		go b.serve(w)
+	}
+}
+
+// serve writes snapshots to w until it disconnects or the broadcaster closes.
+func (b *Broadcaster) serve(w *watcherConn) {
+	defer func() {
+		b.mu.Lock()
+		delete(b.watchers, w)
+		b.mu.Unlock()
+		w.conn.Close()
+	}()
+	for data := range w.updates {
+		_ = w.conn.SetWriteDeadline(time.Now().Add(watcherWriteTimeout))
+		if _, err := w.conn.Write(data); err != nil {
+			return
+		}
+	}
+}
+
+// Publish sends snapshot to every connected watcher.
+func (b *Broadcaster) Publish(snapshot Snapshot) {
+	if snapshot.Games == nil {
+		snapshot.Games = []Game{}
+	}
+	if snapshot.Updated.IsZero() {
+		snapshot.Updated = time.Now()
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return
+	}
+	data = append(data, '\n')
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.latest = data
+	for w := range b.watchers {
+		// Replace a snapshot the watcher hasn't taken yet
+		select {
+		case <-w.updates:
+		default:
+		}
+		w.updates <- data
+	}
+}
+
+// Close stops accepting watchers, disconnects the connected ones and removes the
+// socket file. It is safe to call multiple times.
+func (b *Broadcaster) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	for w := range b.watchers {
+		close(w.updates)
+	}
+	err := b.listener.Close()
+	if errors.Is(err, net.ErrClosed) {
+		return nil
+	}
+	return err
+}
+
+// Watcher follows the games shared by another instance through its Broadcaster.
+type Watcher struct {
+	path    string
+	conn    net.Conn
+	scanner *bufio.Scanner
+}
+
+// Watch connects to the instance sharing its games on the unix socket at path.
+func Watch(path string) (*Watcher, error) {
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("no game is being shared at %s: %w", path, err)
+	}
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxSnapshotSize)
+	return &Watcher{path: path, conn: conn, scanner: scanner}, nil
+}
+
+// Path returns the socket the watcher is connected to.
+func (w *Watcher) Path() string {
+	return w.path
+}
+
+// Next blocks until the next snapshot arrives. It returns io.EOF once the
+// sharing instance has exited.
+func (w *Watcher) Next() (Snapshot, error) {
+	if !w.scanner.Scan() {
+		if err := w.scanner.Err(); err != nil {
+			return Snapshot{}, err
+		}
+		return Snapshot{}, io.EOF
+	}
+	var snapshot Snapshot
+	if err := json.Unmarshal(w.scanner.Bytes(), &snapshot); err != nil {
+		return Snapshot{}, fmt.Errorf("invalid snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
+// Close disconnects the watcher. A blocked Next returns an error.
+func (w *Watcher) Close() error {
+	return w.conn.Close()
+}
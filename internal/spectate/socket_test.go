@@ -0,0 +1,90 @@
+package spectate
+
+import (
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// socketPath returns a socket path in a fresh directory. Unix socket paths are
+// limited to about 100 bytes, so it avoids the long t.TempDir paths.
+func socketPath(t *testing.T) string {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "tc")
+	if err != nil {
+		t.Fatalf("MkdirTemp() error = %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return filepath.Join(dir, "game.sock")
+}
+
+func TestBroadcasterSendsSnapshots(t *testing.T) {
+	path := socketPath(t)
+	b, err := ListenUnix(path)
+	if err != nil {
+		t.Fatalf("ListenUnix() error = %v", err)
+	}
+	defer b.Close()
+
+	// A watcher joining mid-game gets the latest snapshot at once
+	b.Publish(Snapshot{Mode: "game", Games: []Game{{White: "White", Black: "Black", FEN: startFEN}}})
+	w, err := Watch(path)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	defer w.Close()
+
+	snapshot, err := w.Next()
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if snapshot.Mode != "game" || len(snapshot.Games) != 1 || snapshot.Games[0].FEN != startFEN {
+		t.Errorf("first snapshot = %+v, want the published game", snapshot)
+	}
+
+	b.Publish(Snapshot{Mode: "idle"})
+	if snapshot, err = w.Next(); err != nil || snapshot.Mode != "idle" {
+		t.Errorf("Next() = %+v, %v, want the idle snapshot", snapshot, err)
+	}
+
+	// Closing the broadcaster ends the watch and removes the socket
+	if err := b.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, err := w.Next(); !errors.Is(err, io.EOF) {
+		t.Errorf("Next() after Close error = %v, want io.EOF", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("socket file still exists after Close: %v", err)
+	}
+}
+
+func TestListenUnixReplacesStaleSocket(t *testing.T) {
+	path := socketPath(t)
+	// Leave a socket file behind with nothing listening on it
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	l.(*net.UnixListener).SetUnlinkOnClose(false)
+	l.Close()
+
+	b, err := ListenUnix(path)
+	if err != nil {
+		t.Fatalf("ListenUnix() over a stale socket error = %v", err)
+	}
+	defer b.Close()
+
+	if _, err := ListenUnix(path); err == nil {
+		t.Error("Expected an error sharing a socket that is in use")
+	}
+}
+
+func TestWatchWithoutSharer(t *testing.T) {
+	if _, err := Watch(socketPath(t)); err == nil {
+		t.Error("Expected an error watching a socket nobody shares")
+	}
+}
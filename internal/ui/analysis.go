@@ -0,0 +1,292 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/bot"
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// AnalysisDoneMsg is sent when the post-game analysis has finished.
+type AnalysisDoneMsg struct {
+	analysis *bot.GameAnalysis
+}
+
+// AnalysisErrorMsg is sent when the post-game analysis fails or is cancelled.
+type AnalysisErrorMsg struct {
+	err error
+}
+
+// beginGameRecord starts timing the first move of a new game and discards the
+// notes, analysis, pre-move, coach comment and training mode of the previous game.
+func (m *Model) beginGameRecord() {
+	m.notes = nil
+	m.turnStartedAt = time.Now()
+	m.premove = nil
+	m.stopCoach()
+	m.trainingMode = false
+	m.trainingWarned = nil
+	if m.analysisCancel != nil {
+		m.analysisCancel()
+		m.analysisCancel = nil
+	}
+	m.analysis = nil
+	m.evals = nil
+	m.analysisPositions = nil
+	m.analysisPly = 0
+	m.analyzing = false
+}
+
+// startAnalysis opens the analysis screen for the finished game and, unless the
+// game was already analyzed, starts evaluating every move in the background.
+func (m Model) startAnalysis() (tea.Model, tea.Cmd) {
+	if m.game.MoveCount() == 0 {
+		m.notify(SeverityError, "No moves to analyze")
+		return m, nil
+	}
+
+	positions := m.game.Positions()
+	m.analysisPositions = positions
+	m.analysisPly = 0
+	m.dismissToasts()
+	m.pushScreen(ScreenAnalysis)
+
+	// Reuse the previous result when returning to the analysis of the same game
+	if m.analysis != nil {
+		return m, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.analyzing = true
+	m.analysisCancel = cancel
+
+	start := positions[0]
+	moves := m.game.Moves()
+	analyzeCmd := func() tea.Msg {
+		analysis, err := bot.AnalyzeGame(ctx, start, moves, bot.DefaultAnalysisDepth)
+		if err != nil {
+			return AnalysisErrorMsg{err: err}
+		}
+		return AnalysisDoneMsg{analysis: analysis}
+	}
+
+	return m, tea.Batch(analyzeCmd, m.botSpinner.Tick)
+}
+
+// handleAnalysisDone stores the finished analysis.
+func (m Model) handleAnalysisDone(msg AnalysisDoneMsg) (tea.Model, tea.Cmd) {
+	m.stopAnalysis()
+	m.analysis = msg.analysis
+	m.evals = make([]float64, len(msg.analysis.Moves))
+	for i, a := range msg.analysis.Moves {
+		m.evals[i] = a.WhiteScore()
+	}
+	return m, nil
+}
+
+// handleAnalysisError reports a failed analysis. Cancellation is not an error.
+func (m Model) handleAnalysisError(msg AnalysisErrorMsg) (tea.Model, tea.Cmd) {
+	wasRunning := m.analyzing
+	m.stopAnalysis()
+	if wasRunning && m.screen == ScreenAnalysis {
+		m.notify(SeverityError, fmt.Sprintf("Analysis failed: %v", msg.err))
+	}
+	return m, nil
+}
+
+// stopAnalysis cancels a running analysis and clears the progress indicator.
+func (m *Model) stopAnalysis() {
+	if m.analysisCancel != nil {
+		m.analysisCancel()
+		m.analysisCancel = nil
+	}
+	m.analyzing = false
+}
+
+// handleAnalysisKeys handles keyboard input for the Analysis screen.
+// Arrow keys step through the game, Home/End jump to the start or end,
+// '[' and ']' jump between flagged moves, 'i' and 'c' export the game as a GIF
+// or an asciinema cast, and ESC returns to the game over screen.
+func (m Model) handleAnalysisKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.dismissToasts(SeverityError)
+	last := len(m.analysisPositions) - 1
+
+	switch {
+	case m.keys.Matches(msg, ActionLeft, ActionUp):
+		if m.analysisPly > 0 {
+			m.analysisPly--
+		}
+	case m.keys.Matches(msg, ActionRight, ActionDown):
+		if m.analysisPly < last {
+			m.analysisPly++
+		}
+	case m.keys.Matches(msg, ActionFirstMove):
+		m.analysisPly = 0
+	case m.keys.Matches(msg, ActionLastMove):
+		m.analysisPly = last
+	case m.keys.Matches(msg, ActionPrevFlagged):
+		m.analysisPly = m.findFlaggedPly(-1)
+	case m.keys.Matches(msg, ActionNextFlagged):
+		m.analysisPly = m.findFlaggedPly(1)
+	case m.keys.Matches(msg, ActionExportGIF):
+		return m.exportGameGIF()
+	case m.keys.Matches(msg, ActionExportCast):
+		return m.exportGameCast()
+	case m.keys.Matches(msg, ActionBack):
+		// Leaving cancels a running analysis; a finished one is kept for next time
+		m.stopAnalysis()
+		m.popScreen()
+	}
+
+	return m, nil
+}
+
+// findFlaggedPly returns the nearest position in the given direction reached by
+// an inaccuracy, mistake or blunder, or the current position if there is none.
+func (m Model) findFlaggedPly(direction int) int {
+	if m.analysis == nil {
+		return m.analysisPly
+	}
+	for ply := m.analysisPly + direction; ply >= 1 && ply <= len(m.analysis.Moves); ply += direction {
+		if m.analysis.Moves[ply-1].Classification != bot.MoveGood {
+			return ply
+		}
+	}
+	return m.analysisPly
+}
+
+// renderAnalysis renders the Analysis screen: per-player accuracy summaries,
+// the board at the selected position and the engine's verdict on the move that led there.
+func (m Model) renderAnalysis() string {
+	var b strings.Builder
+
+	title := m.titleStyle().Render("TermChess")
+	b.WriteString(title)
+	b.WriteString("\n\n")
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(m.theme.TitleText).
+		Padding(0, 0, 1, 0)
+	b.WriteString(headerStyle.Render("Game Analysis"))
+	b.WriteString("\n")
+
+	if m.analyzing {
+		progress := fmt.Sprintf("%s Analyzing %d moves...", m.botSpinner.View(), m.game.MoveCount())
+		b.WriteString(m.statusStyle().Render(progress))
+		if helpText := m.renderHelpText("ESC: cancel"); helpText != "" {
+			b.WriteString("\n\n")
+			b.WriteString(helpText)
+		}
+		return b.String()
+	}
+
+	if m.analysis != nil {
+		b.WriteString(m.renderAnalysisSummary("White", m.analysis.White))
+		b.WriteString("\n")
+		b.WriteString(m.renderAnalysisSummary("Black", m.analysis.Black))
+		b.WriteString("\n\n")
+	}
+
+	if graph := m.renderEvalGraph(m.analysisPly - 1); graph != "" {
+		b.WriteString(graph)
+		b.WriteString("\n\n")
+	}
+
+	if m.analysisPly < len(m.analysisPositions) {
+		renderer := NewBoardRendererWithTheme(m.config, m.theme)
+		renderer.UseCache(m.boardCache)
+		b.WriteString(renderer.Render(m.analysisPositions[m.analysisPly]))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(m.renderAnalysisMove())
+
+	helpText := m.renderHelpText("←/→: step | home/end: start/end | [ ]: prev/next flagged move | i/c: export GIF/cast | ESC: back")
+	if helpText != "" {
+		b.WriteString("\n\n")
+		b.WriteString(helpText)
+	}
+
+	return b.String()
+}
+
+// renderAnalysisSummary renders one player's accuracy line.
+func (m Model) renderAnalysisSummary(player string, s bot.PlayerSummary) string {
+	text := fmt.Sprintf("%-6s Accuracy %5.1f%% | Avg loss %3.0f cp | %d inaccuracies, %d mistakes, %d blunders",
+		player, s.Accuracy, s.AverageCentipawnLoss, s.Inaccuracies, s.Mistakes, s.Blunders)
+	return lipgloss.NewStyle().Foreground(m.theme.MenuNormal).Render(text)
+}
+
+// renderAnalysisMove describes the move that led to the selected position.
+func (m Model) renderAnalysisMove() string {
+	style := lipgloss.NewStyle().Foreground(m.theme.MenuSelected)
+
+	if m.analysisPly == 0 {
+		return style.Render(fmt.Sprintf("Start position (%d of %d)", 0, len(m.analysisPositions)-1))
+	}
+
+	before := m.analysisPositions[m.analysisPly-1]
+	move := m.game.Moves()[m.analysisPly-1]
+	moveText := fmt.Sprintf("%s %s", moveNumberPrefix(before), engine.FormatSAN(before, move))
+
+	if m.analysis == nil || m.analysisPly > len(m.analysis.Moves) {
+		return style.Render(moveText)
+	}
+
+	a := m.analysis.Moves[m.analysisPly-1]
+	eval := "Eval: " + formatEval(a.WhiteScore())
+	if a.Tablebase != nil {
+		eval = "Tablebase: " + formatTablebase(*a.Tablebase, a.Color)
+	}
+	line := fmt.Sprintf("%s%s  %s", moveText, a.Classification.Symbol(), eval)
+	if a.Classification != bot.MoveGood {
+		line += fmt.Sprintf("  %s (-%d cp), best was %s", a.Classification, a.CentipawnLoss, engine.FormatSAN(before, a.BestMove))
+	}
+	return style.Render(line)
+}
+
+// moveNumberPrefix returns "12." for White moves and "12..." for Black moves.
+func moveNumberPrefix(board *engine.Board) string {
+	if board.ActiveColor == engine.Black {
+		return fmt.Sprintf("%d...", board.FullMoveNum)
+	}
+	return fmt.Sprintf("%d.", board.FullMoveNum)
+}
+
+// formatTablebase formats a tablebase result from the perspective of mover,
+// e.g. "White wins, DTZ 12" or "draw".
+func formatTablebase(r bot.TablebaseResult, mover engine.Color) string {
+	winner := "White"
+	if (mover == engine.Black) == (r.WDL > 0) {
+		winner = "Black"
+	}
+	switch r.WDL {
+	case bot.WDLWin, bot.WDLLoss:
+		if r.DTZ == 0 {
+			return winner + " wins"
+		}
+		return fmt.Sprintf("%s wins, DTZ %d", winner, max(r.DTZ, -r.DTZ))
+	case bot.WDLCursedWin, bot.WDLBlessedLoss:
+		return "draw by the fifty-move rule"
+	default:
+		return "draw"
+	}
+}
+
+// formatEval formats an evaluation in pawns from White's perspective, e.g. "+1.25" or "-M".
+func formatEval(score float64) string {
+	if math.Abs(score) >= 9000 {
+		if score > 0 {
+			return "+M"
+		}
+		return "-M"
+	}
+	return fmt.Sprintf("%+.2f", score)
+}
@@ -0,0 +1,242 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/bot"
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// runAnalysisCmd executes the command returned by startAnalysis and returns the
+// analysis result message, skipping the spinner's tick.
+func runAnalysisCmd(t *testing.T, cmd tea.Cmd) tea.Msg {
+	t.Helper()
+	if cmd == nil {
+		t.Fatal("Expected an analysis command")
+	}
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok {
+		t.Fatal("Expected a batch of analysis and spinner commands")
+	}
+	for _, c := range batch {
+		if c == nil {
+			continue
+		}
+		switch res := c().(type) {
+		case AnalysisDoneMsg, AnalysisErrorMsg:
+			return res
+		}
+	}
+	t.Fatal("Analysis command returned no result")
+	return nil
+}
+
+// newFinishedGame plays the given moves from the start position and leaves the model on the game over screen.
+func newFinishedGame(t *testing.T, moves ...string) Model {
+	t.Helper()
+	m := NewModel(DefaultConfig())
+	m.game = engine.NewGame()
+	m.beginGameRecord()
+	for _, s := range moves {
+		move, err := engine.ParseMove(s)
+		if err != nil {
+			t.Fatalf("ParseMove(%q) error = %v", s, err)
+		}
+		if err := m.game.MakeMove(move); err != nil {
+			t.Fatalf("MakeMove(%q) error = %v", s, err)
+		}
+	}
+	m.screen = ScreenGameOver
+	return m
+}
+
+func TestAnalyzeFromGameOver(t *testing.T) {
+	// Fool's mate: White's f3 and g4 are both bad moves
+	m := newFinishedGame(t, "f2f3", "e7e5", "g2g4", "d8h4")
+
+	result, cmd := m.handleGameOverKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	m = result.(Model)
+
+	if m.screen != ScreenAnalysis {
+		t.Fatalf("Expected ScreenAnalysis, got %v", m.screen)
+	}
+	if !m.analyzing {
+		t.Error("Expected analysis to be running")
+	}
+	if len(m.analysisPositions) != 5 {
+		t.Errorf("Expected 5 positions, got %d", len(m.analysisPositions))
+	}
+	if !strings.Contains(m.View(), "Analyzing 4 moves") {
+		t.Error("Expected progress indicator while analyzing")
+	}
+
+	msg := runAnalysisCmd(t, cmd)
+	done, ok := msg.(AnalysisDoneMsg)
+	if !ok {
+		t.Fatalf("Expected AnalysisDoneMsg, got %T", msg)
+	}
+	result, _ = m.Update(done)
+	m = result.(Model)
+
+	if m.analyzing {
+		t.Error("Expected analysis to be finished")
+	}
+	if m.analysis == nil || len(m.analysis.Moves) != 4 {
+		t.Fatal("Expected analysis of 4 moves")
+	}
+	if m.analysis.White.Blunders == 0 {
+		t.Error("Expected g4 to be flagged as a blunder")
+	}
+
+	view := m.View()
+	for _, want := range []string{"Game Analysis", "Accuracy", "Start position"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected analysis view to contain %q", want)
+		}
+	}
+
+	// Leaving and re-entering keeps the finished analysis
+	result, _ = m.handleAnalysisKeys(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	if m.screen != ScreenGameOver {
+		t.Fatalf("Expected ESC to return to ScreenGameOver, got %v", m.screen)
+	}
+	result, cmd = m.startAnalysis()
+	m = result.(Model)
+	if cmd != nil || m.analyzing {
+		t.Error("Expected the previous analysis to be reused")
+	}
+}
+
+func TestAnalysisNavigation(t *testing.T) {
+	m := newFinishedGame(t, "f2f3", "e7e5", "g2g4", "d8h4")
+	result, cmd := m.startAnalysis()
+	m = result.(Model)
+	result, _ = m.Update(runAnalysisCmd(t, cmd))
+	m = result.(Model)
+
+	press := func(key tea.KeyMsg) {
+		result, _ := m.handleAnalysisKeys(key)
+		m = result.(Model)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyLeft})
+	if m.analysisPly != 0 {
+		t.Errorf("Expected ply to stay at 0, got %d", m.analysisPly)
+	}
+	press(tea.KeyMsg{Type: tea.KeyRight})
+	if m.analysisPly != 1 {
+		t.Errorf("Expected ply 1, got %d", m.analysisPly)
+	}
+	if !strings.Contains(m.View(), "1. f3") {
+		t.Error("Expected the first move in SAN")
+	}
+	press(tea.KeyMsg{Type: tea.KeyEnd})
+	if m.analysisPly != 4 {
+		t.Errorf("Expected ply 4, got %d", m.analysisPly)
+	}
+	press(tea.KeyMsg{Type: tea.KeyRight})
+	if m.analysisPly != 4 {
+		t.Errorf("Expected ply to stay at 4, got %d", m.analysisPly)
+	}
+	press(tea.KeyMsg{Type: tea.KeyHome})
+	if m.analysisPly != 0 {
+		t.Errorf("Expected ply 0, got %d", m.analysisPly)
+	}
+
+	// ']' jumps to a flagged move
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{']'}})
+	if m.analysisPly == 0 {
+		t.Fatal("Expected ']' to jump to a flagged move")
+	}
+	if !strings.Contains(m.View(), "best was") {
+		t.Error("Expected the flagged move to show the best alternative")
+	}
+
+	// The keys follow the key bindings
+	keys, err := m.keys.WithBinding(ActionLastMove, []string{"e"})
+	if err != nil {
+		t.Fatalf("WithBinding() error: %v", err)
+	}
+	m.keys = keys
+	press(tea.KeyMsg{Type: tea.KeyEnd})
+	if m.analysisPly == 4 {
+		t.Error("Expected End to be unbound")
+	}
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	if m.analysisPly != 4 {
+		t.Errorf("Expected 'e' to jump to the end, got ply %d", m.analysisPly)
+	}
+}
+
+func TestAnalyzeWithoutMoves(t *testing.T) {
+	m := newFinishedGame(t)
+	result, cmd := m.handleGameOverKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	m = result.(Model)
+
+	if cmd != nil {
+		t.Error("Expected no command without moves")
+	}
+	if m.screen != ScreenGameOver {
+		t.Errorf("Expected to stay on ScreenGameOver, got %v", m.screen)
+	}
+	if toastError(m) != "No moves to analyze" {
+		t.Errorf("Expected 'No moves to analyze', got %q", toastError(m))
+	}
+}
+
+func TestAnalysisCancelledOnExit(t *testing.T) {
+	m := newFinishedGame(t, "e2e4", "e7e5")
+	result, cmd := m.startAnalysis()
+	m = result.(Model)
+
+	result, _ = m.handleAnalysisKeys(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	if m.analyzing {
+		t.Error("Expected ESC to stop the analysis")
+	}
+
+	// The cancelled search reports an error, which is not shown after leaving
+	msg := runAnalysisCmd(t, cmd)
+	result, _ = m.Update(msg)
+	m = result.(Model)
+	if toastError(m) != "" {
+		t.Errorf("Expected no error after cancelling, got %q", toastError(m))
+	}
+}
+
+func TestNewGameClearsAnalysis(t *testing.T) {
+	m := newFinishedGame(t, "e2e4")
+	result, cmd := m.startAnalysis()
+	m = result.(Model)
+	result, _ = m.Update(runAnalysisCmd(t, cmd))
+	m = result.(Model)
+
+	m.game = engine.NewGame()
+	m.beginGameRecord()
+	if m.analysis != nil || m.analysisPositions != nil {
+		t.Error("Expected a new game to discard the previous analysis")
+	}
+}
+
+func TestFormatTablebase(t *testing.T) {
+	tests := []struct {
+		result bot.TablebaseResult
+		mover  engine.Color
+		want   string
+	}{
+		{bot.TablebaseResult{WDL: bot.WDLWin, DTZ: 12}, engine.White, "White wins, DTZ 12"},
+		{bot.TablebaseResult{WDL: bot.WDLWin, DTZ: 12}, engine.Black, "Black wins, DTZ 12"},
+		{bot.TablebaseResult{WDL: bot.WDLLoss, DTZ: -7}, engine.White, "Black wins, DTZ 7"},
+		{bot.TablebaseResult{WDL: bot.WDLLoss}, engine.Black, "White wins"},
+		{bot.TablebaseResult{WDL: bot.WDLCursedWin, DTZ: 110}, engine.White, "draw by the fifty-move rule"},
+		{bot.TablebaseResult{}, engine.Black, "draw"},
+	}
+	for _, tt := range tests {
+		if got := formatTablebase(tt.result, tt.mover); got != tt.want {
+			t.Errorf("formatTablebase(%+v, %v) = %q, want %q", tt.result, tt.mover, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,310 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"strings"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// An animated export shows every position of the game for animationMoveDelay,
+// and the final one for animationFinalDelay before it starts over.
+const (
+	animationMoveDelay  = time.Second
+	animationFinalDelay = 3 * time.Second
+)
+
+// Layout of the GIF export, in pixels: the board's squares, the scale the font
+// is drawn at for pieces, and the margins around the board for the title,
+// coordinates and caption.
+const (
+	gifSquareSize = 32
+	gifPieceScale = 2
+	gifMargin     = 24
+	gifTitleSpace = 28
+	gifTextSpace  = 44
+)
+
+// gifBackground is the background of the GIF export, a dark terminal's.
+var gifBackground = color.RGBA{0x1e, 0x1e, 0x1e, 0xff}
+
+// animationFrame is one position of an animated export.
+type animationFrame struct {
+	board *engine.Board
+	// move is the move that led to the position, or nil for the start
+	move *engine.Move
+	// caption describes the move, and the result on the final frame
+	caption string
+}
+
+// animationFrames returns a frame for the starting position and for the
+// position after every move of the game.
+func (m Model) animationFrames() []animationFrame {
+	positions := m.game.Positions()
+	frames := make([]animationFrame, 0, len(positions))
+	frames = append(frames, animationFrame{board: positions[0], caption: "Start position"})
+	for i, move := range m.game.Moves() {
+		before := positions[i]
+		frames = append(frames, animationFrame{
+			board:   positions[i+1],
+			move:    &move,
+			caption: fmt.Sprintf("%s %s", moveNumberPrefix(before), engine.FormatSAN(before, move)),
+		})
+	}
+	if status := m.spectatorGame().Status; status != engine.Ongoing.String() {
+		last := &frames[len(frames)-1]
+		last.caption += "  " + status
+	}
+	return frames
+}
+
+// animationTitle returns the title shown above the board, naming the players.
+func (m Model) animationTitle() string {
+	game := m.spectatorGame()
+	return fmt.Sprintf("%s vs %s", game.White, game.Black)
+}
+
+// exportGameCast writes the game as an asciinema cast to the games directory
+// of the data directory.
+func (m Model) exportGameCast() (tea.Model, tea.Cmd) {
+	return m.exportAnimation("cast", func(frames []animationFrame) ([]byte, error) {
+		return m.gameCast(frames, time.Now())
+	})
+}
+
+// exportGameGIF writes the game as an animated GIF to the games directory of
+// the data directory.
+func (m Model) exportGameGIF() (tea.Model, tea.Cmd) {
+	return m.exportAnimation("gif", m.gameGIF)
+}
+
+// exportAnimation writes the game to a file with extension ext, encoded from
+// its frames by encode, and reports where it went.
+func (m Model) exportAnimation(ext string, encode func([]animationFrame) ([]byte, error)) (tea.Model, tea.Cmd) {
+	name := strings.ToUpper(ext)
+	if m.game.MoveCount() == 0 {
+		m.notify(SeverityError, fmt.Sprintf("Failed to export %s: no moves to animate", name))
+		return m, nil
+	}
+	data, err := encode(m.animationFrames())
+	if err != nil {
+		m.notify(SeverityError, fmt.Sprintf("Failed to export %s: %v", name, err))
+		return m, nil
+	}
+	path, err := saveGameFile(data, ext, "", time.Now())
+	if err != nil {
+		m.notify(SeverityError, fmt.Sprintf("Failed to export %s: %v", name, err))
+		return m, nil
+	}
+	m.notify(SeverityInfo, fmt.Sprintf("Game exported to %s", path))
+	return m, nil
+}
+
+// castHeader is the first line of an asciinema cast (format version 2).
+type castHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Title     string            `json:"title"`
+	Env       map[string]string `json:"env"`
+}
+
+// gameCast encodes frames as an asciinema cast recorded at now: each frame
+// redraws the screen with the board as the board renderer draws it, in the
+// current theme, settings and color profile.
+func (m Model) gameCast(frames []animationFrame, now time.Time) ([]byte, error) {
+	renderer := NewBoardRendererWithTheme(m.config, m.theme)
+	renderer.ShowCheck()
+	title := lipgloss.NewStyle().Bold(true).Foreground(m.theme.TitleText).Render(m.animationTitle())
+	captionStyle := lipgloss.NewStyle().Foreground(m.theme.MenuSelected)
+
+	screens := make([]string, len(frames))
+	width, height := 0, 0
+	for i, frame := range frames {
+		renderer.FlashMove(frame.move)
+		screen := title + "\n\n" + renderer.Render(frame.board) + "\n\n" + captionStyle.Render(frame.caption)
+		for _, line := range strings.Split(screen, "\n") {
+			width = max(width, ansi.StringWidth(line))
+		}
+		height = max(height, strings.Count(screen, "\n")+1)
+		screens[i] = screen
+	}
+
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	header := castHeader{
+		Version:   2,
+		Width:     width,
+		Height:    height,
+		Timestamp: now.Unix(),
+		Title:     m.animationTitle(),
+		Env:       map[string]string{"TERM": "xterm-256color"},
+	}
+	if err := enc.Encode(header); err != nil {
+		return nil, err
+	}
+	var at time.Duration
+	for i, screen := range screens {
+		// Clear the screen and draw the frame from its top left corner
+		output := "\x1b[H\x1b[2J" + strings.ReplaceAll(screen, "\n", "\r\n")
+		if err := enc.Encode([]any{at.Seconds(), "o", output}); err != nil {
+			return nil, err
+		}
+		at += animationMoveDelay
+		if i == len(screens)-1 {
+			at += animationFinalDelay - animationMoveDelay
+		}
+	}
+	// The final frame stays up until the end of the cast
+	if err := enc.Encode([]any{at.Seconds(), "o", ""}); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// gameGIF encodes frames as an animated GIF of the board in the current theme's
+// colors. Pieces are drawn as letters, as the font has no chess symbols: white
+// ones in the theme's color outlined in black, and black ones in black outlined
+// in white so they stand out on dark squares.
+func (m Model) gameGIF(frames []animationFrame) ([]byte, error) {
+	var (
+		light      = themeColor(m.theme.LightSquare)
+		dark       = themeColor(m.theme.DarkSquare)
+		whitePiece = themeColor(m.theme.WhitePiece)
+		flash      = themeColor(m.theme.MoveFlashHighlight)
+		check      = themeColor(m.theme.CheckHighlight)
+		titleColor = themeColor(m.theme.TitleText)
+		caption    = themeColor(m.theme.MenuSelected)
+		coords     = themeColor(m.theme.HelpText)
+	)
+	palette := color.Palette{gifBackground}
+	for _, c := range []color.Color{color.Black, color.White, light, dark, whitePiece, flash, check, titleColor, caption, coords} {
+		if palette.Convert(c) != c {
+			palette = append(palette, c)
+		}
+	}
+
+	boardSize := 8 * gifSquareSize
+	bounds := image.Rect(0, 0, gifMargin*2+boardSize, gifTitleSpace+boardSize+gifTextSpace)
+	title := m.animationTitle()
+	anim := &gif.GIF{}
+	for i, frame := range frames {
+		img := image.NewPaletted(bounds, palette)
+		fill(img, bounds, gifBackground)
+		drawText(img, gifMargin, gifTitleSpace-10, title, titleColor)
+
+		checkedKing := engine.NoSquare
+		if frame.board.InCheck() {
+			checkedKing = frame.board.KingSquare(frame.board.ActiveColor)
+		}
+		for rank := 7; rank >= 0; rank-- {
+			for file := 0; file < 8; file++ {
+				sq := engine.NewSquare(file, rank)
+				x, y := gifMargin+file*gifSquareSize, gifTitleSpace+(7-rank)*gifSquareSize
+				squareColor := dark
+				if (file+rank)%2 == 1 {
+					squareColor = light
+				}
+				switch {
+				case sq == checkedKing:
+					squareColor = check
+				case frame.move != nil && (sq == frame.move.From || sq == frame.move.To):
+					squareColor = flash
+				}
+				fill(img, image.Rect(x, y, x+gifSquareSize, y+gifSquareSize), squareColor)
+
+				piece := frame.board.PieceAt(sq)
+				if piece.IsEmpty() {
+					continue
+				}
+				letter := rune("PNBRQK"[piece.Type()-engine.Pawn])
+				fg, outline := whitePiece, color.Black
+				if piece.Color() == engine.Black {
+					fg, outline = color.Black, color.White
+				}
+				drawPiece(img, x, y, letter, fg, outline)
+			}
+		}
+
+		if m.config.ShowCoords {
+			for i := 0; i < 8; i++ {
+				drawText(img, gifMargin+i*gifSquareSize+gifSquareSize/2-3, gifTitleSpace+boardSize+14, string(rune('a'+i)), coords)
+				drawText(img, gifMargin-12, gifTitleSpace+(7-i)*gifSquareSize+gifSquareSize/2+5, string(rune('1'+i)), coords)
+			}
+		}
+		drawText(img, gifMargin, gifTitleSpace+boardSize+34, frame.caption, caption)
+
+		delay := animationMoveDelay
+		if i == len(frames)-1 {
+			delay = animationFinalDelay
+		}
+		anim.Image = append(anim.Image, img)
+		anim.Delay = append(anim.Delay, int(delay/(10*time.Millisecond)))
+	}
+
+	var b bytes.Buffer
+	if err := gif.EncodeAll(&b, anim); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// themeColor returns the color c of a theme, a hex color or an ANSI color
+// number, as an image color.
+func themeColor(c lipgloss.Color) color.Color {
+	return termenv.ConvertToRGB(termenv.TrueColor.Color(string(c)))
+}
+
+// fill fills r of img with c.
+func fill(img draw.Image, r image.Rectangle, c color.Color) {
+	draw.Draw(img, r, image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+// drawText draws s on img in c, with its baseline starting at x, y.
+func drawText(img draw.Image, x, y int, s string, c color.Color) {
+	d := font.Drawer{Dst: img, Src: image.NewUniform(c), Face: basicfont.Face7x13, Dot: fixed.P(x, y)}
+	d.DrawString(s)
+}
+
+// drawPiece draws letter enlarged and centered on the square whose top left
+// corner is at x, y, in fg with a one pixel outline.
+func drawPiece(img *image.Paletted, x, y int, letter rune, fg, outline color.Color) {
+	face := basicfont.Face7x13
+	dr, mask, maskp, _, ok := face.Glyph(fixed.P(0, face.Ascent), letter)
+	if !ok {
+		return
+	}
+	left := x + (gifSquareSize-face.Width*gifPieceScale)/2
+	top := y + (gifSquareSize-face.Height*gifPieceScale)/2
+	plot := func(dx, dy int, c color.Color) {
+		for py := dr.Min.Y; py < dr.Max.Y; py++ {
+			for px := dr.Min.X; px < dr.Max.X; px++ {
+				_, _, _, a := mask.At(maskp.X+px-dr.Min.X, maskp.Y+py-dr.Min.Y).RGBA()
+				if a == 0 {
+					continue
+				}
+				cx, cy := left+px*gifPieceScale+dx, top+py*gifPieceScale+dy
+				fill(img, image.Rect(cx, cy, cx+gifPieceScale, cy+gifPieceScale), c)
+			}
+		}
+	}
+	for _, d := range [][2]int{{-1, -1}, {0, -1}, {1, -1}, {-1, 0}, {1, 0}, {-1, 1}, {0, 1}, {1, 1}} {
+		plot(d[0], d[1], outline)
+	}
+	plot(0, 0, fg)
+}
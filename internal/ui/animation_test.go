@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"image/gif"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/config"
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestAnimationFrames(t *testing.T) {
+	m := foolsMateModel(t)
+
+	frames := m.animationFrames()
+	want := []string{"Start position", "1. f3", "1... e5", "2. g4", "2... Qh4#  Checkmate! Black wins"}
+	if len(frames) != len(want) {
+		t.Fatalf("Expected %d frames, got %d", len(want), len(frames))
+	}
+	for i, frame := range frames {
+		if frame.caption != want[i] {
+			t.Errorf("Frame %d caption = %q, want %q", i, frame.caption, want[i])
+		}
+		if (frame.move == nil) != (i == 0) {
+			t.Errorf("Frame %d: expected a move on every frame but the first", i)
+		}
+	}
+	if frames[4].board.ToFEN() != m.game.Board().ToFEN() {
+		t.Errorf("Expected the last frame to show the final position, got %s", frames[4].board.ToFEN())
+	}
+}
+
+func TestGameCast(t *testing.T) {
+	m := foolsMateModel(t)
+	frames := m.animationFrames()
+
+	data, err := m.gameCast(frames, time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("gameCast() error: %v", err)
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, 1<<20)
+	if !scanner.Scan() {
+		t.Fatal("Expected a header line")
+	}
+	var header castHeader
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		t.Fatalf("Invalid header: %v", err)
+	}
+	if header.Version != 2 || header.Width < 15 || header.Height < 10 || !strings.HasSuffix(header.Title, " vs Medium Bot") {
+		t.Errorf("Unexpected header %+v", header)
+	}
+
+	var times []float64
+	var outputs []string
+	for scanner.Scan() {
+		var event []any
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil || len(event) != 3 || event[1] != "o" {
+			t.Fatalf("Invalid event %s (%v)", scanner.Text(), err)
+		}
+		times = append(times, event[0].(float64))
+		outputs = append(outputs, event[2].(string))
+	}
+	// A frame per position, then the end of the final frame
+	if len(times) != len(frames)+1 {
+		t.Fatalf("Expected %d events, got %d", len(frames)+1, len(times))
+	}
+	if times[1] != 1 || times[len(times)-1] != 7 {
+		t.Errorf("Expected a second per move and 3 on the final position, got times %v", times)
+	}
+	if !strings.Contains(outputs[0], "\x1b[2J") || !strings.Contains(outputs[4], "Checkmate! Black wins") {
+		t.Errorf("Expected every frame to redraw the screen, got %q", outputs[4])
+	}
+	if strings.Contains(strings.ReplaceAll(outputs[1], "\r\n", ""), "\n") {
+		t.Error("Expected the lines of a frame to end with \\r\\n")
+	}
+}
+
+func TestGameGIF(t *testing.T) {
+	m := foolsMateModel(t)
+	frames := m.animationFrames()
+
+	data, err := m.gameGIF(frames)
+	if err != nil {
+		t.Fatalf("gameGIF() error: %v", err)
+	}
+	anim, err := gif.DecodeAll(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Invalid GIF: %v", err)
+	}
+	if len(anim.Image) != len(frames) {
+		t.Fatalf("Expected %d frames, got %d", len(frames), len(anim.Image))
+	}
+	if anim.Delay[0] != 100 || anim.Delay[len(anim.Delay)-1] != 300 {
+		t.Errorf("Expected a second per move and 3 on the final position, got delays %v", anim.Delay)
+	}
+
+	// After 1. f3 its squares are highlighted, f2 (dark) in the middle of the board
+	f2 := engine.NewSquare(5, 1)
+	x := gifMargin + f2.File()*gifSquareSize + 2
+	y := gifTitleSpace + (7-f2.Rank())*gifSquareSize + 2
+	want := themeColor(m.theme.MoveFlashHighlight)
+	if got := anim.Image[1].At(x, y); !sameColor(got, want) {
+		t.Errorf("Expected f2 highlighted after f3, got %v", got)
+	}
+	if got := anim.Image[0].At(x, y); sameColor(got, want) {
+		t.Error("Expected no highlight on the start position")
+	}
+	// The mated king is highlighted on the final position
+	e1 := engine.NewSquare(4, 0)
+	x = gifMargin + e1.File()*gifSquareSize + 2
+	y = gifTitleSpace + (7-e1.Rank())*gifSquareSize + 2
+	if got := anim.Image[4].At(x, y); !sameColor(got, themeColor(m.theme.CheckHighlight)) {
+		t.Errorf("Expected the king in check highlighted, got %v", got)
+	}
+}
+
+// sameColor reports whether a and b are the same color.
+func sameColor(a, b interface{ RGBA() (r, g, b, a uint32) }) bool {
+	ar, ag, ab, aa := a.RGBA()
+	br, bg, bb, ba := b.RGBA()
+	return ar>>8 == br>>8 && ag>>8 == bg>>8 && ab>>8 == bb>>8 && aa>>8 == ba>>8
+}
+
+func TestExportAnimation(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dataDir, err := config.GetDataDir()
+	if err != nil {
+		t.Fatalf("GetDataDir() error: %v", err)
+	}
+
+	for _, tt := range []struct {
+		key, ext string
+	}{{"i", ".gif"}, {"c", ".cast"}} {
+		m := foolsMateModel(t)
+		result, _ := m.startAnalysis()
+		m = result.(Model)
+		m.stopAnalysis()
+
+		result, _ = m.handleAnalysisKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(tt.key)})
+		m = result.(Model)
+		if !strings.Contains(toastStatus(m), "Game exported to") {
+			t.Fatalf("Expected %q to export the game, got %q", tt.key, toastStatus(m))
+		}
+		files, _ := filepath.Glob(filepath.Join(dataDir, "games", "*"+tt.ext))
+		if len(files) != 1 {
+			t.Fatalf("Expected a %s file in the games directory, got %v", tt.ext, files)
+		}
+		if info, err := os.Stat(files[0]); err != nil || info.Size() == 0 {
+			t.Errorf("Expected %s to hold the export (%v)", files[0], err)
+		}
+	}
+
+	m := NewModel(DefaultConfig())
+	m.game = engine.NewGame()
+	result, _ := m.exportGameGIF()
+	if m = result.(Model); !strings.Contains(toastError(m), "no moves") {
+		t.Errorf("Expected an error exporting a game without moves, got %q", toastError(m))
+	}
+}
@@ -1,18 +1,63 @@
 package ui
 
 import (
-	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/Mgrdich/TermChess/internal/engine"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
+// boardSize is roughly the length of a rendered board, to size its builder.
+const boardSize = 2048
+
+// styledKey identifies a string rendered by styled.
+type styledKey struct {
+	text    string
+	fg, bg  lipgloss.Color
+	bold    bool
+	profile termenv.Profile
+}
+
+// styledCache holds the strings rendered by styled. A board styles the same few
+// pieces, labels and highlights every frame, so each is rendered only once.
+var styledCache sync.Map
+
+// styled returns text in the foreground and background colors, "" for none,
+// and bold if asked, as a lipgloss style renders it for the terminal's color
+// profile.
+func styled(text string, fg, bg lipgloss.Color, bold bool) string {
+	key := styledKey{text: text, fg: fg, bg: bg, bold: bold, profile: lipgloss.ColorProfile()}
+	if s, ok := styledCache.Load(key); ok {
+		return s.(string)
+	}
+	style := lipgloss.NewStyle()
+	if fg != "" {
+		style = style.Foreground(fg)
+	}
+	if bg != "" {
+		style = style.Background(bg)
+	}
+	if bold {
+		style = style.Bold(true)
+	}
+	rendered := style.Render(text)
+	styledCache.Store(key, rendered)
+	return rendered
+}
+
 // BoardRenderer is responsible for rendering the chess board to the terminal.
 // It uses the Config to determine how to display pieces and coordinates.
 type BoardRenderer struct {
 	config Config
 	theme  Theme
+	// flash is a move whose squares are highlighted, or nil
+	flash *engine.Move
+	// showCheck highlights the king of the side to move when it is in check
+	showCheck bool
+	// cache reuses boards rendered before, or is nil
+	cache *boardCache
 }
 
 // NewBoardRenderer creates a new BoardRenderer with the given configuration.
@@ -38,6 +83,24 @@ func (r *BoardRenderer) Render(b *engine.Board) string {
 	return r.RenderWithSelection(b, nil, nil, false)
 }
 
+// FlashMove highlights the origin and destination squares of move in the
+// boards r renders, or nothing if move is nil.
+func (r *BoardRenderer) FlashMove(move *engine.Move) {
+	r.flash = move
+}
+
+// ShowCheck highlights the king of the side to move in the boards r renders
+// whenever it is in check.
+func (r *BoardRenderer) ShowCheck() {
+	r.showCheck = true
+}
+
+// UseCache makes r reuse the boards in c that were rendered the same way before,
+// and add the boards it renders to c.
+func (r *BoardRenderer) UseCache(c *boardCache) {
+	r.cache = c
+}
+
 // RenderWithSelection renders the chess board with optional selection highlighting.
 // selectedSquare is the currently selected piece's square (or nil if none).
 // validMoves are the valid destination squares for the selected piece.
@@ -46,14 +109,37 @@ func (r *BoardRenderer) RenderWithSelection(b *engine.Board, selectedSquare *eng
 	if b == nil {
 		return "No board available"
 	}
+	// A board without a hash, e.g. one set up square by square, isn't cached
+	if r.cache == nil || b.Hash == 0 {
+		return r.render(b, selectedSquare, validMoves, blinkOn)
+	}
+	key := r.cache.key(r, b, selectedSquare, validMoves, blinkOn)
+	if rendered, ok := r.cache.get(key); ok {
+		return rendered
+	}
+	rendered := r.render(b, selectedSquare, validMoves, blinkOn)
+	r.cache.put(key, rendered)
+	return rendered
+}
+
+// render renders the board for RenderWithSelection, without the cache.
+func (r *BoardRenderer) render(b *engine.Board, selectedSquare *engine.Square, validMoves []engine.Square, blinkOn bool) string {
 
 	var result strings.Builder
+	result.Grow(boardSize)
+	outside := r.config.ShowCoords && r.config.CoordinateStyle != CoordsInside
+	inside := r.config.ShowCoords && r.config.CoordinateStyle == CoordsInside
+	checkedKing := engine.NoSquare
+	if r.showCheck && b.InCheck() {
+		checkedKing = b.KingSquare(b.ActiveColor)
+	}
 
 	// Render each rank from 8 down to 1 (from White's perspective)
 	for rank := 7; rank >= 0; rank-- {
-		// Show rank number if coordinates are enabled
-		if r.config.ShowCoords {
-			result.WriteString(fmt.Sprintf("%d ", rank+1))
+		// Show rank number if coordinates are shown around the board
+		if outside {
+			result.WriteByte(byte('1' + rank))
+			result.WriteByte(' ')
 		}
 
 		// Render pieces for this rank (files a-h, which are 0-7)
@@ -61,16 +147,26 @@ func (r *BoardRenderer) RenderWithSelection(b *engine.Board, selectedSquare *eng
 			sq := engine.NewSquare(file, rank)
 			piece := b.PieceAt(sq)
 			symbol := r.pieceSymbol(piece)
+			if inside && piece.IsEmpty() {
+				symbol = r.insideCoordinate(sq, symbol)
+			}
+			if glyph := r.specialMoveGlyph(b, selectedSquare, sq, validMoves); glyph != "" {
+				symbol = glyph
+			}
 
 			// Apply highlight if blinking is on and square matches selection state
-			if blinkOn {
-				if selectedSquare != nil && sq == *selectedSquare {
-					// Highlight the selected square
-					symbol = r.applyHighlight(symbol, r.theme.SelectedHighlight)
-				} else if r.isValidMove(sq, validMoves) {
-					// Highlight valid move destinations
-					symbol = r.applyHighlight(symbol, r.theme.ValidMoveHighlight)
-				}
+			if blinkOn && selectedSquare != nil && sq == *selectedSquare {
+				// Highlight the selected square
+				symbol = r.applyHighlight(symbol, r.theme.SelectedHighlight)
+			} else if blinkOn && r.isValidMove(sq, validMoves) {
+				// Highlight valid move destinations
+				symbol = r.applyHighlight(symbol, r.theme.ValidMoveHighlight)
+			} else if sq == checkedKing {
+				// Highlight the king in check
+				symbol = r.applyHighlight(symbol, r.theme.CheckHighlight)
+			} else if r.flash != nil && (sq == r.flash.From || sq == r.flash.To) {
+				// Highlight the squares of a move being flashed
+				symbol = r.applyHighlight(symbol, r.theme.MoveFlashHighlight)
 			}
 
 			// Add spacing between pieces for readability
@@ -84,8 +180,8 @@ func (r *BoardRenderer) RenderWithSelection(b *engine.Board, selectedSquare *eng
 		result.WriteString("\n")
 	}
 
-	// Show file labels at the bottom if coordinates are enabled
-	if r.config.ShowCoords {
+	// Show file labels at the bottom if coordinates are shown around the board
+	if outside {
 		result.WriteString("  ") // Indent to align with rank numbers
 		result.WriteString("a b c d e f g h")
 	}
@@ -93,6 +189,25 @@ func (r *BoardRenderer) RenderWithSelection(b *engine.Board, selectedSquare *eng
 	return result.String()
 }
 
+// insideCoordinate returns the coordinate label shown on the empty square sq
+// when coordinates are drawn inside the board: the file letter on the first
+// rank and the rank number on the a-file. Other squares keep symbol.
+func (r *BoardRenderer) insideCoordinate(sq engine.Square, symbol string) string {
+	var label string
+	switch {
+	case sq.Rank() == 0:
+		label = "abcdefgh"[sq.File() : sq.File()+1]
+	case sq.File() == 0:
+		label = "12345678"[sq.Rank() : sq.Rank()+1]
+	default:
+		return symbol
+	}
+	if !r.config.UseColors {
+		return label
+	}
+	return styled(label, r.theme.HelpText, "", false)
+}
+
 // isValidMove checks if a square is in the list of valid moves.
 func (r *BoardRenderer) isValidMove(sq engine.Square, validMoves []engine.Square) bool {
 	for _, vm := range validMoves {
@@ -103,10 +218,32 @@ func (r *BoardRenderer) isValidMove(sq engine.Square, validMoves []engine.Square
 	return false
 }
 
+// specialMoveGlyph returns the glyph marking sq when the piece on selected
+// can move there with a special move: en passant for a pawn, castling for a
+// king. Returns "" for any other square.
+func (r *BoardRenderer) specialMoveGlyph(b *engine.Board, selected *engine.Square, sq engine.Square, validMoves []engine.Square) string {
+	if selected == nil || !r.isValidMove(sq, validMoves) {
+		return ""
+	}
+	piece := b.PieceAt(*selected)
+	switch {
+	case piece.Type() == engine.Pawn && int8(sq) == b.EnPassantSq:
+		if r.config.UseUnicode {
+			return "×"
+		}
+		return "x"
+	case piece.Type() == engine.King && (sq.File()-selected.File() == 2 || selected.File()-sq.File() == 2):
+		if r.config.UseUnicode {
+			return "↔"
+		}
+		return "o"
+	}
+	return ""
+}
+
 // applyHighlight applies a background highlight color to a symbol.
 func (r *BoardRenderer) applyHighlight(symbol string, color lipgloss.Color) string {
-	style := lipgloss.NewStyle().Background(color)
-	return style.Render(symbol)
+	return styled(symbol, "", color, false)
 }
 
 // pieceSymbol returns the symbol to use for the given piece.
@@ -136,32 +273,15 @@ func (r *BoardRenderer) pieceSymbol(p engine.Piece) string {
 // Black pieces are lowercase (p, n, b, r, q, k).
 func (r *BoardRenderer) asciiSymbol(p engine.Piece) string {
 	pieceType := p.Type()
-	color := p.Color()
-
-	var ch byte
-	switch pieceType {
-	case engine.Pawn:
-		ch = 'P'
-	case engine.Knight:
-		ch = 'N'
-	case engine.Bishop:
-		ch = 'B'
-	case engine.Rook:
-		ch = 'R'
-	case engine.Queen:
-		ch = 'Q'
-	case engine.King:
-		ch = 'K'
-	default:
+	if pieceType < engine.Pawn || pieceType > engine.King {
 		return "."
 	}
-
-	// Convert to lowercase for black pieces
-	if color == engine.Black {
-		ch = ch - 'A' + 'a'
+	letters := "PNBRQK"
+	if p.Color() == engine.Black {
+		letters = "pnbrqk"
 	}
-
-	return string(ch)
+	i := int(pieceType - engine.Pawn)
+	return letters[i : i+1]
 }
 
 // unicodeSymbol returns the Unicode chess symbol for the given piece.
@@ -221,11 +341,8 @@ func (r *BoardRenderer) unicodeSymbol(p engine.Piece) string {
 func (r *BoardRenderer) colorSymbol(symbol string, p engine.Piece) string {
 	if p.Color() == engine.White {
 		// White pieces: bright white (terminal color 15) with bold
-		style := lipgloss.NewStyle().Foreground(lipgloss.Color("15")).Bold(true)
-		return style.Render(symbol)
-	} else {
-		// Black pieces: gray (terminal color 8)
-		style := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
-		return style.Render(symbol)
+		return styled(symbol, lipgloss.Color("15"), "", true)
 	}
+	// Black pieces: gray (terminal color 8)
+	return styled(symbol, lipgloss.Color("8"), "", false)
 }
@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// boardCacheSize is how many rendered boards a boardCache keeps. A screen shows
+// one board per frame, so a few are enough for blinking selections.
+const boardCacheSize = 8
+
+// boardCache keeps the boards a Model rendered lately, so frames where the board
+// looks the same, e.g. a toast expiring or a key that changed nothing, reuse the
+// string instead of styling 64 squares again.
+type boardCache struct {
+	boards map[boardCacheKey]string
+}
+
+// boardCacheKey is everything a rendered board depends on: the position (by its
+// Zobrist hash), the display settings and the highlights.
+type boardCacheKey struct {
+	hash       uint64
+	theme      Theme
+	unicode    bool
+	colors     bool
+	coords     bool
+	coordStyle string
+	selected   engine.Square
+	validMoves string
+	blinkOn    bool
+	showCheck  bool
+	flash      engine.Move
+	flashing   bool
+}
+
+// newBoardCache returns an empty cache.
+func newBoardCache() *boardCache {
+	return &boardCache{boards: make(map[boardCacheKey]string)}
+}
+
+// key returns the cache key of rendering b with r.
+func (c *boardCache) key(r *BoardRenderer, b *engine.Board, selected *engine.Square, validMoves []engine.Square, blinkOn bool) boardCacheKey {
+	key := boardCacheKey{
+		hash:       b.Hash,
+		theme:      r.theme,
+		unicode:    r.config.UseUnicode,
+		colors:     r.config.UseColors,
+		coords:     r.config.ShowCoords,
+		coordStyle: r.config.CoordinateStyle,
+		selected:   engine.NoSquare,
+		blinkOn:    blinkOn,
+		showCheck:  r.showCheck,
+	}
+	if selected != nil {
+		key.selected = *selected
+	}
+	if len(validMoves) > 0 {
+		var moves strings.Builder
+		for _, sq := range validMoves {
+			moves.WriteByte(byte(sq))
+		}
+		key.validMoves = moves.String()
+	}
+	if r.flash != nil {
+		key.flash, key.flashing = *r.flash, true
+	}
+	return key
+}
+
+// get returns the board rendered for key, if it is cached.
+func (c *boardCache) get(key boardCacheKey) (string, bool) {
+	s, ok := c.boards[key]
+	return s, ok
+}
+
+// put caches a rendered board, forgetting the others once the cache is full.
+func (c *boardCache) put(key boardCacheKey, rendered string) {
+	if len(c.boards) >= boardCacheSize {
+		clear(c.boards)
+	}
+	c.boards[key] = rendered
+}
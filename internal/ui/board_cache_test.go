@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+func TestBoardCache(t *testing.T) {
+	cache := newBoardCache()
+	config := DefaultConfig()
+	render := func(b *engine.Board, selected *engine.Square, valid []engine.Square) string {
+		r := NewBoardRenderer(config)
+		r.UseCache(cache)
+		return r.RenderWithSelection(b, selected, valid, true)
+	}
+
+	board := engine.NewBoard()
+	first := render(board, nil, nil)
+	if first != NewBoardRenderer(config).Render(board) {
+		t.Fatal("Expected a cached render to match an uncached one")
+	}
+	if len(cache.boards) != 1 {
+		t.Fatalf("Expected the board to be cached, got %d entries", len(cache.boards))
+	}
+	for key := range cache.boards {
+		cache.boards[key] = "cached"
+	}
+	if got := render(board.Copy(), nil, nil); got != "cached" {
+		t.Error("Expected the same position rendered the same way to come from the cache")
+	}
+
+	// A selection, another position or other settings render again
+	e2 := engine.NewSquare(4, 1)
+	if got := render(board, &e2, []engine.Square{engine.NewSquare(4, 2), engine.NewSquare(4, 3)}); got == "cached" {
+		t.Error("Expected a selection to render the board again")
+	}
+	moved := board.Copy()
+	_ = moved.MakeMove(engine.Move{From: e2, To: engine.NewSquare(4, 3)})
+	if got := render(moved, nil, nil); got == "cached" {
+		t.Error("Expected another position to render the board again")
+	}
+	config.UseUnicode = !config.UseUnicode
+	if got := render(board, nil, nil); got == "cached" {
+		t.Error("Expected another piece style to render the board again")
+	}
+}
+
+func TestBoardCacheBounded(t *testing.T) {
+	cache := newBoardCache()
+	r := NewBoardRenderer(DefaultConfig())
+	r.UseCache(cache)
+	board := engine.NewBoard()
+	for _, m := range board.LegalMoves() {
+		next := board.Copy()
+		_ = next.MakeMove(m)
+		r.Render(next)
+	}
+	if len(cache.boards) > boardCacheSize {
+		t.Errorf("Expected at most %d cached boards, got %d", boardCacheSize, len(cache.boards))
+	}
+}
+
+func BenchmarkRenderGamePlay(b *testing.B) {
+	for _, cached := range []bool{false, true} {
+		name := "uncached"
+		if cached {
+			name = "cached"
+		}
+		b.Run(name, func(b *testing.B) {
+			m := NewModel(DefaultConfig())
+			m.game = engine.NewGame()
+			m.screen = ScreenGamePlay
+			if !cached {
+				m.boardCache = nil
+			}
+			for i := 0; i < b.N; i++ {
+				_ = m.renderGamePlay()
+			}
+		})
+	}
+}
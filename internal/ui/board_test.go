@@ -713,3 +713,111 @@ func TestBoardRenderer_NoCoordinates_NoExtraSpaces(t *testing.T) {
 		}
 	}
 }
+
+func TestSpecialMoveGlyphs(t *testing.T) {
+	renderer := NewBoardRenderer(Config{})
+	// White can capture en passant on d6 and castle either way
+	board, err := engine.FromFEN("r3k2r/8/8/3pP3/8/8/8/R3K2R w KQkq d6 0 1")
+	if err != nil {
+		t.Fatalf("FromFEN() error = %v", err)
+	}
+	pawn, _ := engine.ParseSquare("e5")
+	king, _ := engine.ParseSquare("e1")
+	d6, _ := engine.ParseSquare("d6")
+	e6, _ := engine.ParseSquare("e6")
+	c1, _ := engine.ParseSquare("c1")
+	g1, _ := engine.ParseSquare("g1")
+	f1, _ := engine.ParseSquare("f1")
+
+	tests := []struct {
+		selected engine.Square
+		sq       engine.Square
+		want     string
+	}{
+		{pawn, d6, "x"},
+		{pawn, e6, ""},
+		{king, c1, "o"},
+		{king, g1, "o"},
+		{king, f1, ""},
+	}
+	valid := []engine.Square{d6, e6, c1, g1, f1}
+	for _, tt := range tests {
+		if got := renderer.specialMoveGlyph(board, &tt.selected, tt.sq, valid); got != tt.want {
+			t.Errorf("specialMoveGlyph(%s, %s) = %q, want %q", tt.selected, tt.sq, got, tt.want)
+		}
+	}
+
+	if got := renderer.specialMoveGlyph(board, nil, d6, valid); got != "" {
+		t.Errorf("Expected no glyph without a selection, got %q", got)
+	}
+	unicode := NewBoardRenderer(Config{UseUnicode: true})
+	if got := unicode.specialMoveGlyph(board, &pawn, d6, valid); got != "×" {
+		t.Errorf("Expected the Unicode en passant glyph, got %q", got)
+	}
+}
+
+// benchmarkRender renders a middlegame position with a selected piece, as the
+// game screen does each frame.
+func benchmarkRender(b *testing.B, config Config) {
+	board, err := engine.FromFEN("r1bqkb1r/pppp1ppp/2n2n2/4p3/2B1P3/5N2/PPPP1PPP/RNBQK2R w KQkq - 4 4")
+	if err != nil {
+		b.Fatal(err)
+	}
+	selected := engine.NewSquare(4, 0)
+	valid := []engine.Square{engine.NewSquare(5, 0), engine.NewSquare(6, 0), engine.NewSquare(4, 1)}
+	r := NewBoardRenderer(config)
+	r.ShowCheck()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = r.RenderWithSelection(board, &selected, valid, true)
+	}
+}
+
+func BenchmarkBoardRenderer(b *testing.B) {
+	b.Run("ascii", func(b *testing.B) {
+		benchmarkRender(b, Config{ShowCoords: true})
+	})
+	b.Run("unicode colors", func(b *testing.B) {
+		benchmarkRender(b, Config{UseUnicode: true, UseColors: true, ShowCoords: true, Theme: "classic"})
+	})
+	b.Run("inside coordinates", func(b *testing.B) {
+		benchmarkRender(b, Config{UseUnicode: true, UseColors: true, ShowCoords: true, CoordinateStyle: CoordsInside})
+	})
+}
+
+func TestStyledMatchesLipgloss(t *testing.T) {
+	defer lipgloss.SetColorProfile(lipgloss.ColorProfile())
+	lipgloss.SetColorProfile(termenv.TrueColor)
+
+	tests := []struct {
+		got  string
+		want lipgloss.Style
+	}{
+		{styled("♔", lipgloss.Color("15"), "", true), lipgloss.NewStyle().Foreground(lipgloss.Color("15")).Bold(true)},
+		{styled("♔", "", lipgloss.Color("#FF5555"), false), lipgloss.NewStyle().Background(lipgloss.Color("#FF5555"))},
+		{styled("♔", lipgloss.Color("8"), "", false), lipgloss.NewStyle().Foreground(lipgloss.Color("8"))},
+	}
+	for _, tt := range tests {
+		if want := tt.want.Render("♔"); tt.got != want {
+			t.Errorf("styled() = %q, want %q", tt.got, want)
+		}
+	}
+}
+
+// TestRenderAllocations keeps the renderer from going back to building styles
+// per square: a frame should only allocate the board string.
+func TestRenderAllocations(t *testing.T) {
+	board := engine.NewBoard()
+	selected := engine.NewSquare(4, 1)
+	valid := []engine.Square{engine.NewSquare(4, 2), engine.NewSquare(4, 3)}
+	r := NewBoardRenderer(Config{UseUnicode: true, UseColors: true, ShowCoords: true})
+	r.RenderWithSelection(board, &selected, valid, true) // fill the style cache
+
+	allocs := testing.AllocsPerRun(100, func() {
+		r.RenderWithSelection(board, &selected, valid, true)
+	})
+	if allocs > 2 {
+		t.Errorf("Rendering a board took %.0f allocations, want at most 2", allocs)
+	}
+}
@@ -2,21 +2,42 @@ package ui
 
 import (
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/Mgrdich/TermChess/internal/bot"
 	"github.com/Mgrdich/TermChess/internal/engine"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// runBotMoveCmd executes a command returned by makeBotMove and returns the
+// bot's result message, skipping the thinking spinner's tick.
+func runBotMoveCmd(cmd tea.Cmd) tea.Msg {
+	msg := cmd()
+	batch, ok := msg.(tea.BatchMsg)
+	if !ok {
+		return msg
+	}
+	for _, c := range batch {
+		if c == nil {
+			continue
+		}
+		switch res := c().(type) {
+		case BotMoveMsg, BotMoveErrorMsg:
+			return res
+		}
+	}
+	return nil
+}
+
 // TestBotMoveExecution tests the bot move execution flow
 func TestBotMoveExecution(t *testing.T) {
 	m := NewModel(DefaultConfig())
 	m.screen = ScreenGamePlay
 	m.gameType = GameTypePvBot
 	m.botDifficulty = BotEasy
-	m.board = engine.NewBoard()
-	m.moveHistory = []engine.Move{}
+	m.game = engine.NewGame()
 
 	// Player makes a move (e2e4)
 	m.input = "e4"
@@ -29,7 +50,7 @@ func TestBotMoveExecution(t *testing.T) {
 	}
 
 	// Should have a thinking message
-	if m.statusMsg == "" {
+	if m.thinkingMsg == "" {
 		t.Errorf("Expected status message with thinking text, got empty string")
 	}
 
@@ -45,7 +66,7 @@ func TestBotMoveExecution(t *testing.T) {
 
 	// Execute the bot move command to get the message
 	if cmd != nil {
-		msg := cmd()
+		msg := runBotMoveCmd(cmd)
 
 		// Should return either BotMoveMsg or BotMoveErrorMsg
 		switch msg.(type) {
@@ -83,12 +104,12 @@ func TestBotDifficultySelection(t *testing.T) {
 			m.menuOptions = []string{"Easy", "Medium", "Hard"}
 			m.menuSelection = tt.selection
 
-			result, _ := m.handleBotDifficultySelection()
+			result, _ := m.selectMenuItem()
 			m = result.(Model)
 
-			// Should transition to ColorSelect screen
-			if m.screen != ScreenColorSelect {
-				t.Errorf("Expected screen to be ScreenColorSelect, got: %v", m.screen)
+			// Should transition to HandicapSelect screen
+			if m.screen != ScreenHandicapSelect {
+				t.Errorf("Expected screen to be ScreenHandicapSelect, got: %v", m.screen)
 			}
 
 			// Should set bot difficulty
@@ -96,23 +117,20 @@ func TestBotDifficultySelection(t *testing.T) {
 				t.Errorf("Expected difficulty to be %v, got: %v", tt.difficulty, m.botDifficulty)
 			}
 
-			// Menu options should be color choices
-			if len(m.menuOptions) != 2 {
-				t.Errorf("Expected 2 menu options, got: %d", len(m.menuOptions))
-			}
-			if m.menuOptions[0] != "Play as White" {
-				t.Errorf("Expected first option to be 'Play as White', got: %s", m.menuOptions[0])
+			// Menu options should be handicap choices
+			if len(m.menuOptions) != 4 {
+				t.Errorf("Expected 4 menu options, got: %d", len(m.menuOptions))
 			}
-			if m.menuOptions[1] != "Play as Black" {
-				t.Errorf("Expected second option to be 'Play as Black', got: %s", m.menuOptions[1])
+			if m.menuOptions[0] != "No Handicap" {
+				t.Errorf("Expected first option to be 'No Handicap', got: %s", m.menuOptions[0])
 			}
 
 			// Should have cleared status messages
-			if m.statusMsg != "" {
-				t.Errorf("Expected status message to be cleared, got: %s", m.statusMsg)
+			if toastStatus(m) != "" {
+				t.Errorf("Expected status message to be cleared, got: %s", toastStatus(m))
 			}
-			if m.errorMsg != "" {
-				t.Errorf("Expected error message to be cleared, got: %s", m.errorMsg)
+			if toastError(m) != "" {
+				t.Errorf("Expected error message to be cleared, got: %s", toastError(m))
 			}
 		})
 	}
@@ -124,36 +142,35 @@ func TestBotMoveHandling(t *testing.T) {
 	m.screen = ScreenGamePlay
 	m.gameType = GameTypePvBot
 	m.botDifficulty = BotEasy
-	m.board = engine.NewBoard()
-	m.moveHistory = []engine.Move{}
-	m.statusMsg = "Thinking..."
+	m.game = engine.NewGame()
+	m.botThinking = true
+	m.thinkingMsg = "Thinking..."
 
 	// Create a valid bot move (e7e5)
 	move, _ := engine.ParseMove("e7e5")
 
 	// Make player move first (e2e4)
 	playerMove, _ := engine.ParseMove("e2e4")
-	_ = m.board.MakeMove(playerMove)
-	m.moveHistory = append(m.moveHistory, playerMove)
+	_ = m.game.MakeMove(playerMove)
 
 	// Handle bot move message
 	msg := BotMoveMsg{move: move}
 	result, _ := m.handleBotMove(msg)
 	m = result.(Model)
 
-	// Should clear status message
-	if m.statusMsg != "" {
-		t.Errorf("Expected status message to be cleared, got: %s", m.statusMsg)
+	// Should stop the thinking indicator
+	if m.botThinking {
+		t.Errorf("Expected the thinking indicator to be cleared")
 	}
 
 	// Should add move to history
-	if len(m.moveHistory) != 2 {
-		t.Errorf("Expected 2 moves in history, got: %d", len(m.moveHistory))
+	if m.game.MoveCount() != 2 {
+		t.Errorf("Expected 2 moves in history, got: %d", m.game.MoveCount())
 	}
 
 	// Should not show error
-	if m.errorMsg != "" {
-		t.Errorf("Expected no error message, got: %s", m.errorMsg)
+	if toastError(m) != "" {
+		t.Errorf("Expected no error message, got: %s", toastError(m))
 	}
 }
 
@@ -162,20 +179,21 @@ func TestBotMoveErrorHandling(t *testing.T) {
 	m := NewModel(DefaultConfig())
 	m.screen = ScreenGamePlay
 	m.gameType = GameTypePvBot
-	m.statusMsg = "Thinking..."
+	m.botThinking = true
+	m.thinkingMsg = "Thinking..."
 
 	// Create an error message
 	msg := BotMoveErrorMsg{err: errors.New("test error")}
 	result, _ := m.handleBotMoveError(msg)
 	m = result.(Model)
 
-	// Should clear status message
-	if m.statusMsg != "" {
-		t.Errorf("Expected status message to be cleared, got: %s", m.statusMsg)
+	// Should stop the thinking indicator
+	if m.botThinking {
+		t.Errorf("Expected the thinking indicator to be cleared")
 	}
 
 	// Should show error
-	if m.errorMsg == "" {
+	if toastError(m) == "" {
 		t.Errorf("Expected error message to be set")
 	}
 }
@@ -249,7 +267,7 @@ func TestColorSelection(t *testing.T) {
 			m.menuOptions = []string{"Play as White", "Play as Black"}
 			m.menuSelection = tt.selection
 
-			result, cmd := m.handleColorSelection()
+			result, cmd := m.selectMenuItem()
 			m = result.(Model)
 
 			// Should transition to GamePlay screen
@@ -263,13 +281,13 @@ func TestColorSelection(t *testing.T) {
 			}
 
 			// Should create a new board
-			if m.board == nil {
+			if m.game == nil {
 				t.Errorf("Expected board to be created")
 			}
 
 			// Should have cleared status messages initially
-			if m.errorMsg != "" {
-				t.Errorf("Expected error message to be cleared, got: %s", m.errorMsg)
+			if toastError(m) != "" {
+				t.Errorf("Expected error message to be cleared, got: %s", toastError(m))
 			}
 
 			// If user plays Black, should trigger bot move
@@ -280,7 +298,7 @@ func TestColorSelection(t *testing.T) {
 				if m.botEngine == nil {
 					t.Errorf("Expected bot engine to be created")
 				}
-				if m.statusMsg == "" {
+				if m.thinkingMsg == "" {
 					t.Errorf("Expected status message with thinking text when bot moves first")
 				}
 				// Clean up bot engine
@@ -289,8 +307,8 @@ func TestColorSelection(t *testing.T) {
 				}
 			} else {
 				// If user plays White, should not trigger bot move
-				if m.statusMsg != "" {
-					t.Errorf("Expected no status message when user plays White, got: %s", m.statusMsg)
+				if toastStatus(m) != "" {
+					t.Errorf("Expected no status message when user plays White, got: %s", toastStatus(m))
 				}
 			}
 		})
@@ -353,8 +371,10 @@ func TestBotPlaysWhite_FullFlow(t *testing.T) {
 	m.menuOptions = []string{"Easy", "Medium", "Hard"}
 	m.menuSelection = 0 // Select Easy
 
-	// Select bot difficulty
-	result, _ := m.handleBotDifficultySelection()
+	// Select bot difficulty, then play without a handicap
+	result, _ := m.selectMenuItem()
+	m = result.(Model)
+	result, _ = m.selectMenuItem()
 	m = result.(Model)
 
 	// Should be at color selection screen
@@ -364,7 +384,7 @@ func TestBotPlaysWhite_FullFlow(t *testing.T) {
 
 	// Select "Play as Black" (index 1)
 	m.menuSelection = 1
-	result, cmd := m.handleColorSelection()
+	result, cmd := m.selectMenuItem()
 	m = result.(Model)
 
 	// Should be at GamePlay screen
@@ -378,13 +398,13 @@ func TestBotPlaysWhite_FullFlow(t *testing.T) {
 	}
 
 	// Board should be created
-	if m.board == nil {
+	if m.game == nil {
 		t.Fatalf("Expected board to be created")
 	}
 
 	// Board should still be at initial position (White to move)
-	if m.board.ActiveColor != engine.White {
-		t.Errorf("Expected ActiveColor to be White, got: %v", m.board.ActiveColor)
+	if m.game.Board().ActiveColor != engine.White {
+		t.Errorf("Expected ActiveColor to be White, got: %v", m.game.Board().ActiveColor)
 	}
 
 	// Bot move command should be returned
@@ -398,12 +418,12 @@ func TestBotPlaysWhite_FullFlow(t *testing.T) {
 	}
 
 	// Status message should indicate bot is thinking
-	if m.statusMsg == "" {
+	if m.thinkingMsg == "" {
 		t.Errorf("Expected thinking status message")
 	}
 
 	// Execute the bot move command
-	msg := cmd()
+	msg := runBotMoveCmd(cmd)
 
 	// Should return BotMoveMsg (bot should make a valid move)
 	botMoveMsg, ok := msg.(BotMoveMsg)
@@ -416,18 +436,18 @@ func TestBotPlaysWhite_FullFlow(t *testing.T) {
 	m = result.(Model)
 
 	// After bot's move, it should be Black's turn
-	if m.board.ActiveColor != engine.Black {
-		t.Errorf("Expected ActiveColor to be Black after bot move, got: %v", m.board.ActiveColor)
+	if m.game.Board().ActiveColor != engine.Black {
+		t.Errorf("Expected ActiveColor to be Black after bot move, got: %v", m.game.Board().ActiveColor)
 	}
 
 	// Move history should have one move (bot's opening move)
-	if len(m.moveHistory) != 1 {
-		t.Errorf("Expected 1 move in history, got: %d", len(m.moveHistory))
+	if m.game.MoveCount() != 1 {
+		t.Errorf("Expected 1 move in history, got: %d", m.game.MoveCount())
 	}
 
 	// Status message should be cleared after bot move
-	if m.statusMsg != "" {
-		t.Errorf("Expected status message to be cleared after bot move, got: %s", m.statusMsg)
+	if toastStatus(m) != "" {
+		t.Errorf("Expected status message to be cleared after bot move, got: %s", toastStatus(m))
 	}
 
 	// Clean up
@@ -445,8 +465,10 @@ func TestBotPlaysBlack_FullFlow(t *testing.T) {
 	m.menuOptions = []string{"Easy", "Medium", "Hard"}
 	m.menuSelection = 1 // Select Medium
 
-	// Select bot difficulty
-	result, _ := m.handleBotDifficultySelection()
+	// Select bot difficulty, then play without a handicap
+	result, _ := m.selectMenuItem()
+	m = result.(Model)
+	result, _ = m.selectMenuItem()
 	m = result.(Model)
 
 	// Should be at color selection screen
@@ -456,7 +478,7 @@ func TestBotPlaysBlack_FullFlow(t *testing.T) {
 
 	// Select "Play as White" (index 0)
 	m.menuSelection = 0
-	result, cmd := m.handleColorSelection()
+	result, cmd := m.selectMenuItem()
 	m = result.(Model)
 
 	// Should be at GamePlay screen
@@ -470,13 +492,13 @@ func TestBotPlaysBlack_FullFlow(t *testing.T) {
 	}
 
 	// Board should be created
-	if m.board == nil {
+	if m.game == nil {
 		t.Fatalf("Expected board to be created")
 	}
 
 	// Board should be at initial position (White to move)
-	if m.board.ActiveColor != engine.White {
-		t.Errorf("Expected ActiveColor to be White, got: %v", m.board.ActiveColor)
+	if m.game.Board().ActiveColor != engine.White {
+		t.Errorf("Expected ActiveColor to be White, got: %v", m.game.Board().ActiveColor)
 	}
 
 	// No bot move command should be returned (user moves first)
@@ -485,13 +507,13 @@ func TestBotPlaysBlack_FullFlow(t *testing.T) {
 	}
 
 	// Status message should be empty (no thinking message)
-	if m.statusMsg != "" {
-		t.Errorf("Expected no status message when user plays White, got: %s", m.statusMsg)
+	if toastStatus(m) != "" {
+		t.Errorf("Expected no status message when user plays White, got: %s", toastStatus(m))
 	}
 
 	// Move history should be empty
-	if len(m.moveHistory) != 0 {
-		t.Errorf("Expected empty move history, got: %d moves", len(m.moveHistory))
+	if m.game.MoveCount() != 0 {
+		t.Errorf("Expected empty move history, got: %d moves", m.game.MoveCount())
 	}
 }
 
@@ -501,7 +523,7 @@ func TestBotEngineCleanup(t *testing.T) {
 	m.screen = ScreenGamePlay
 	m.gameType = GameTypePvBot
 	m.botDifficulty = BotEasy
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 
 	// Create a bot engine by making a bot move
 	m, _ = m.makeBotMove()
@@ -539,16 +561,14 @@ func TestBotMoveDelay(t *testing.T) {
 			m.screen = ScreenGamePlay
 			m.gameType = GameTypePvBot
 			m.botDifficulty = tt.difficulty
-			m.board = engine.NewBoard()
-			m.moveHistory = []engine.Move{}
+			m.game = engine.NewGame()
 
 			// Player makes a move (e2e4)
 			move, _ := engine.ParseMove("e2e4")
-			err := m.board.MakeMove(move)
+			err := m.game.MakeMove(move)
 			if err != nil {
 				t.Fatalf("Failed to make player move: %v", err)
 			}
-			m.moveHistory = append(m.moveHistory, move)
 
 			// Bot should respond
 			m, cmd := m.makeBotMove()
@@ -559,7 +579,7 @@ func TestBotMoveDelay(t *testing.T) {
 
 			// Measure time for bot move
 			start := time.Now()
-			msg := cmd()
+			msg := runBotMoveCmd(cmd)
 			elapsed := time.Since(start)
 
 			// Verify it's a successful move
@@ -621,3 +641,206 @@ func TestGetMinimumBotDelay(t *testing.T) {
 		})
 	}
 }
+
+// TestBotPondersOnUserTime verifies the bot keeps its engine across moves
+// and starts pondering after it replies.
+func TestBotPondersOnUserTime(t *testing.T) {
+	eng, err := bot.NewMinimaxEngine(bot.Medium, bot.WithSearchDepth(3), bot.WithDeterministic(true))
+	if err != nil {
+		t.Fatalf("NewMinimaxEngine() error = %v", err)
+	}
+	ponderer := bot.NewPonderer(eng)
+	defer ponderer.Close()
+
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenGamePlay
+	m.gameType = GameTypePvBot
+	m.botDifficulty = BotMedium
+	m.userColor = engine.White
+	m.game = engine.NewGame()
+	m.botEngine = ponderer
+
+	m.input = "e4"
+	result, cmd := m.handleMoveInput()
+	m = result.(Model)
+
+	if m.botEngine != bot.Engine(ponderer) {
+		t.Fatal("Expected the existing bot engine to be reused")
+	}
+	if cmd == nil {
+		t.Fatal("Expected a command to be returned for bot move execution")
+	}
+
+	raw := runBotMoveCmd(cmd)
+	msg, ok := raw.(BotMoveMsg)
+	if !ok {
+		t.Fatalf("Expected BotMoveMsg, got: %T", raw)
+	}
+
+	result, _ = m.handleBotMove(msg)
+	m = result.(Model)
+
+	if !ponderer.IsPondering() {
+		t.Error("Expected the bot to ponder after making its move")
+	}
+}
+
+// TestBotThinkingInterrupt verifies pressing Enter on an empty prompt makes a
+// thinking bot play its best move found so far.
+func TestBotThinkingInterrupt(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenGamePlay
+	m.gameType = GameTypePvBot
+	m.botDifficulty = BotHard
+	m.userColor = engine.Black
+	m.game = engine.NewGame()
+
+	m, cmd := m.makeBotMove()
+	defer func() {
+		if m.botEngine != nil {
+			_ = m.botEngine.Close()
+		}
+	}()
+
+	if !m.botThinking {
+		t.Fatal("Expected botThinking to be true while the bot searches")
+	}
+	if !strings.Contains(m.View(), "move now") {
+		t.Error("Expected the view to show how to interrupt the bot")
+	}
+
+	// Press Enter with an empty prompt shortly after the search starts
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		_, _ = m.handleGamePlayKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	}()
+
+	start := time.Now()
+	msg, ok := runBotMoveCmd(cmd).(BotMoveMsg)
+	elapsed := time.Since(start)
+	if !ok {
+		t.Fatal("Expected BotMoveMsg after interrupt")
+	}
+	if elapsed > 3*time.Second {
+		t.Errorf("Interrupted bot took %v, expected it to move promptly", elapsed)
+	}
+
+	result, _ := m.handleBotMove(msg)
+	m = result.(Model)
+	if m.botThinking {
+		t.Error("Expected botThinking to be false after the bot moved")
+	}
+	if m.game.MoveCount() != 1 {
+		t.Errorf("Expected bot move to be recorded, got %d moves", m.game.MoveCount())
+	}
+}
+
+// TestSpinnerTickIgnoredWhenIdle verifies the spinner stops once the bot is done.
+func TestSpinnerTickIgnoredWhenIdle(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.botThinking = false
+
+	_, cmd := m.Update(m.botSpinner.Tick())
+	if cmd != nil {
+		t.Error("Expected no further spinner ticks when the bot is idle")
+	}
+}
+
+// newBotGameFromFEN creates a PvBot model in gameplay at the given position.
+func newBotGameFromFEN(t *testing.T, fen string, userColor engine.Color) Model {
+	t.Helper()
+	board, err := engine.FromFEN(fen)
+	if err != nil {
+		t.Fatalf("FromFEN() error = %v", err)
+	}
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenGamePlay
+	m.gameType = GameTypePvBot
+	m.botDifficulty = BotMedium
+	m.userColor = userColor
+	m.game = engine.NewGameFrom(board)
+	return m
+}
+
+// TestBotDrawOffer tests that the bot accepts or declines draws based on the position
+func TestBotDrawOffer(t *testing.T) {
+	// Bot (Black) is down a queen, so it accepts
+	m := newBotGameFromFEN(t, "4k3/8/8/8/8/8/8/3QK3 w - - 0 1", engine.White)
+	m.input = "offerdraw"
+	result, _ := m.handleGamePlayInput()
+	m = result.(Model)
+	if !m.game.DrawnByAgreement() || m.screen != ScreenGameOver {
+		t.Error("Expected losing bot to accept the draw offer")
+	}
+
+	// Bot (White) is up a queen, so it declines
+	m = newBotGameFromFEN(t, "4k3/8/8/8/8/8/8/3QK3 b - - 0 1", engine.Black)
+	m.input = "offerdraw"
+	result, _ = m.handleGamePlayInput()
+	m = result.(Model)
+	if m.game.DrawnByAgreement() || m.screen != ScreenGamePlay {
+		t.Error("Expected winning bot to decline the draw offer")
+	}
+	if toastStatus(m) != "Bot declined the draw offer" {
+		t.Errorf("Expected decline status message, got: %q", toastStatus(m))
+	}
+	if !m.drawOfferedByBlack {
+		t.Error("Expected the user's draw offer to be recorded")
+	}
+}
+
+// TestBotResignsWhenHopeless tests that the bot resigns after two hopeless turns
+func TestBotResignsWhenHopeless(t *testing.T) {
+	// Bot (Black) is down a queen and a rook
+	m := newBotGameFromFEN(t, "4k3/8/8/8/8/8/8/R2QK3 b - - 0 1", engine.White)
+
+	m, cmd := m.makeBotMove()
+	if m.screen != ScreenGamePlay || cmd == nil {
+		t.Fatal("Expected the bot to keep playing on its first hopeless turn")
+	}
+	if m.botEngine != nil {
+		_ = m.botEngine.Close()
+		m.botEngine = nil
+	}
+	m.stopBotThinking()
+
+	m, _ = m.makeBotMove()
+	if m.screen != ScreenGameOver {
+		t.Fatal("Expected the bot to resign on its second hopeless turn")
+	}
+	if by, ok := m.game.ResignedBy(); !ok || by != engine.Black {
+		t.Errorf("Expected Black (bot) to have resigned, got %d", by)
+	}
+
+	// A threshold of 0 disables resignation
+	m = newBotGameFromFEN(t, "4k3/8/8/8/8/8/8/R2QK3 b - - 0 1", engine.White)
+	m.config.BotResignThreshold = 0
+	m.botHopelessTurns = 5
+	m, _ = m.makeBotMove()
+	if m.screen != ScreenGamePlay {
+		t.Error("Expected the bot never to resign when the threshold is 0")
+	}
+	if m.botEngine != nil {
+		_ = m.botEngine.Close()
+	}
+}
+
+// TestResignAgainstBotUsesUserColor tests that resigning while the bot thinks resigns for the user
+func TestResignAgainstBotUsesUserColor(t *testing.T) {
+	m := newBotGameFromFEN(t, "4k3/8/8/8/8/8/8/3QK3 b - - 0 1", engine.White)
+	m.input = "resign"
+	result, _ := m.handleGamePlayInput()
+	m = result.(Model)
+
+	if by, ok := m.game.ResignedBy(); !ok || by != engine.White {
+		t.Errorf("Expected the user (White) to have resigned, got %d", by)
+	}
+
+	// A bot move arriving after the resignation is ignored
+	move, _ := engine.ParseMove("e8e7")
+	result, _ = m.handleBotMove(BotMoveMsg{move: move})
+	m = result.(Model)
+	if m.game.MoveCount() != 0 {
+		t.Error("Expected bot move after game end to be ignored")
+	}
+}
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"github.com/Mgrdich/TermChess/internal/bvb"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// bvbCellCache keeps the rendered grid cells of Bot vs Bot games, so a frame
+// only renders the cells of the games that changed since the last one.
+type bvbCellCache struct {
+	cells map[*bvb.GameSession]bvbCell
+}
+
+// bvbCell is a rendered grid cell and what it was rendered from.
+type bvbCell struct {
+	key      bvbCellKey
+	rendered string
+}
+
+// bvbCellKey is everything a grid cell shows that can change: the game, its
+// bookmark and the display settings.
+type bvbCellKey struct {
+	revision   uint64
+	bookmarked bool
+	unicode    bool
+	dimmed     lipgloss.Color
+}
+
+// newBvBCellCache returns an empty cache.
+func newBvBCellCache() *bvbCellCache {
+	return &bvbCellCache{cells: make(map[*bvb.GameSession]bvbCell)}
+}
+
+// cachedCompactBoardCell returns the grid cell of session like
+// renderCompactBoardCell, rendering it again only if it is dirty: the game made
+// a move or finished, or its bookmark or the display settings changed.
+func (m Model) cachedCompactBoardCell(session *bvb.GameSession) string {
+	if m.bvbCells == nil {
+		return m.renderCompactBoardCell(session)
+	}
+	// The revision is read before rendering, so a move made meanwhile leaves
+	// the cell dirty for the next frame
+	key := bvbCellKey{
+		revision:   session.Revision(),
+		bookmarked: m.bvbManager != nil && m.bvbManager.IsBookmarked(session.GameNumber()),
+		unicode:    m.config.UseUnicode,
+		dimmed:     m.theme.HelpText,
+	}
+	if cell, ok := m.bvbCells.cells[session]; ok && cell.key == key {
+		return cell.rendered
+	}
+	rendered := m.renderCompactBoardCell(session)
+	m.bvbCells.cells[session] = bvbCell{key: key, rendered: rendered}
+	return rendered
+}
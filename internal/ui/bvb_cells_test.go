@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/bvb"
+)
+
+func TestCachedCompactBoardCell(t *testing.T) {
+	manager := bvb.NewSessionManager(0, 0, "Easy Bot", "Easy Bot", 1, 1)
+	manager.SetSeed(7)
+	if err := manager.RunHeadless(context.Background(), nil); err != nil {
+		t.Fatalf("RunHeadless() error: %v", err)
+	}
+	session := manager.Sessions()[0]
+
+	m := NewModel(DefaultConfig())
+	m.bvbManager = manager
+	m.bvbCells = newBvBCellCache()
+	first := m.cachedCompactBoardCell(session)
+	if first != m.renderCompactBoardCell(session) {
+		t.Fatal("Expected the cached cell to match a fresh render")
+	}
+
+	// An unchanged game is served from the cache
+	cell := m.bvbCells.cells[session]
+	cell.rendered = "cached"
+	m.bvbCells.cells[session] = cell
+	if got := m.cachedCompactBoardCell(session); got != "cached" {
+		t.Errorf("Expected the cell of an unchanged game to be reused, got:\n%s", got)
+	}
+
+	// A bookmark or a display setting makes the cell dirty
+	manager.ToggleBookmark(session.GameNumber())
+	if got := m.cachedCompactBoardCell(session); got == "cached" || got == first {
+		t.Error("Expected the cell to be rendered again once bookmarked")
+	}
+	m.config.UseUnicode = !m.config.UseUnicode
+	bookmarked := m.bvbCells.cells[session].rendered
+	if got := m.cachedCompactBoardCell(session); got == bookmarked {
+		t.Error("Expected the cell to be rendered again for a new piece style")
+	}
+}
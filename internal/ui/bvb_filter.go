@@ -0,0 +1,202 @@
+package ui
+
+import (
+	"sort"
+
+	"github.com/Mgrdich/TermChess/internal/bvb"
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// BvBGridFilter selects which games the Bot vs Bot grid shows.
+type BvBGridFilter int
+
+const (
+	// BvBFilterAll shows every game of the session
+	BvBFilterAll BvBGridFilter = iota
+	// BvBFilterRunning shows the games being played right now
+	BvBFilterRunning
+	// BvBFilterDecisive shows the finished games one of the bots won
+	BvBFilterDecisive
+	// BvBFilterDraws shows the finished games that were drawn
+	BvBFilterDraws
+)
+
+// String returns the filter's display name.
+func (f BvBGridFilter) String() string {
+	switch f {
+	case BvBFilterRunning:
+		return "Running"
+	case BvBFilterDecisive:
+		return "Decisive"
+	case BvBFilterDraws:
+		return "Draws"
+	default:
+		return "All"
+	}
+}
+
+// matches reports whether the filter shows the game of session.
+func (f BvBGridFilter) matches(session *bvb.GameSession) bool {
+	switch f {
+	case BvBFilterRunning:
+		return !session.StartTime().IsZero() && !session.IsFinished()
+	case BvBFilterDecisive:
+		r := session.Result()
+		return r != nil && r.Winner != "Draw"
+	case BvBFilterDraws:
+		r := session.Result()
+		return r != nil && r.Winner == "Draw"
+	default:
+		return true
+	}
+}
+
+// bvbGridSessions returns the sessions the grid shows under the current filter,
+// in game order.
+func (m Model) bvbGridSessions() []*bvb.GameSession {
+	sessions := m.bvbManager.Sessions()
+	if m.bvbGridFilter == BvBFilterAll {
+		return sessions
+	}
+	filtered := make([]*bvb.GameSession, 0, len(sessions))
+	for _, s := range sessions {
+		if m.bvbGridFilter.matches(s) {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered
+}
+
+// cycleBvBGridFilter switches the grid to the next filter: All -> Running ->
+// Decisive -> Draws -> All, starting again from the first page.
+func (m *Model) cycleBvBGridFilter() {
+	m.bvbGridFilter = (m.bvbGridFilter + 1) % (BvBFilterDraws + 1)
+	m.bvbPageIndex = 0
+}
+
+// toggleBvBFollow turns follow mode on or off. Turning it on shows the running
+// games right away.
+func (m *Model) toggleBvBFollow() {
+	m.bvbFollow = !m.bvbFollow
+	if m.bvbFollow {
+		m.followBvBRunningGames()
+		m.notify(SeverityInfo, "Following running games")
+	} else {
+		m.notify(SeverityInfo, "Stopped following running games")
+	}
+}
+
+// followBvBRunningGames keeps a running game in view in follow mode: in grid view,
+// once no game on the current page is running, it turns to the page of the first
+// running one; in single view, once the shown game has finished, it shows the
+// first running game instead.
+func (m *Model) followBvBRunningGames() {
+	if !m.bvbFollow || m.bvbManager == nil {
+		return
+	}
+
+	switch m.bvbViewMode {
+	case BvBGridView:
+		sessions := m.bvbGridSessions()
+		perPage := m.bvbGridRows * m.bvbGridCols
+		if perPage <= 0 {
+			return
+		}
+		start := min(m.bvbPageIndex*perPage, len(sessions))
+		for _, s := range sessions[start:min(start+perPage, len(sessions))] {
+			if BvBFilterRunning.matches(s) {
+				return
+			}
+		}
+		for i, s := range sessions {
+			if BvBFilterRunning.matches(s) {
+				m.bvbPageIndex = i / perPage
+				return
+			}
+		}
+	case BvBSingleView:
+		sessions := m.bvbManager.Sessions()
+		if m.bvbSelectedGame < len(sessions) && BvBFilterRunning.matches(sessions[m.bvbSelectedGame]) {
+			return
+		}
+		for i, s := range sessions {
+			if BvBFilterRunning.matches(s) {
+				m.bvbSelectedGame = i
+				return
+			}
+		}
+	}
+}
+
+// BvBResultSort selects the order of the individual results on the Bot vs Bot
+// statistics screen.
+type BvBResultSort int
+
+const (
+	// BvBSortGameNumber lists the results in game order
+	BvBSortGameNumber BvBResultSort = iota
+	// BvBSortMoves lists the shortest games first
+	BvBSortMoves
+	// BvBSortDuration lists the quickest games first
+	BvBSortDuration
+	// BvBSortResult groups the wins of each bot, then the draws
+	BvBSortResult
+)
+
+// String returns the sort order's display name.
+func (s BvBResultSort) String() string {
+	switch s {
+	case BvBSortMoves:
+		return "moves"
+	case BvBSortDuration:
+		return "duration"
+	case BvBSortResult:
+		return "result"
+	default:
+		return "game"
+	}
+}
+
+// sortBvBResults returns a copy of results in the given order. Ties keep game order.
+func sortBvBResults(results []bvb.GameResult, by BvBResultSort) []bvb.GameResult {
+	sorted := append([]bvb.GameResult(nil), results...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		switch by {
+		case BvBSortMoves:
+			if a.MoveCount != b.MoveCount {
+				return a.MoveCount < b.MoveCount
+			}
+		case BvBSortDuration:
+			if a.Duration != b.Duration {
+				return a.Duration < b.Duration
+			}
+		case BvBSortResult:
+			if ra, rb := resultRank(a), resultRank(b); ra != rb {
+				return ra < rb
+			}
+		}
+		return a.GameNumber < b.GameNumber
+	})
+	return sorted
+}
+
+// resultRank orders results for BvBSortResult: White's wins, Black's wins, then draws.
+func resultRank(r bvb.GameResult) int {
+	switch {
+	case r.Winner == "Draw":
+		return 2
+	case r.WinnerColor == engine.White:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// cycleBvBResultSort switches the statistics screen to the next result order:
+// game -> moves -> duration -> result -> game, starting again from the first page.
+func (m *Model) cycleBvBResultSort() {
+	m.bvbResultSort = (m.bvbResultSort + 1) % (BvBSortResult + 1)
+	m.bvbStatsResultsPage = 0
+	m.scrollOffset = 0
+}
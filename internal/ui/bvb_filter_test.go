@@ -0,0 +1,202 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/bvb"
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestSortBvBResults(t *testing.T) {
+	results := []bvb.GameResult{
+		{GameNumber: 1, Winner: "Draw", MoveCount: 40, Duration: 3 * time.Second},
+		{GameNumber: 2, Winner: "Easy Bot", WinnerColor: engine.Black, MoveCount: 20, Duration: 5 * time.Second},
+		{GameNumber: 3, Winner: "Easy Bot", WinnerColor: engine.White, MoveCount: 20, Duration: 1 * time.Second},
+		{GameNumber: 4, Winner: "Easy Bot", WinnerColor: engine.White, MoveCount: 60, Duration: 2 * time.Second},
+	}
+
+	tests := []struct {
+		by   BvBResultSort
+		want []int
+	}{
+		{BvBSortGameNumber, []int{1, 2, 3, 4}},
+		{BvBSortMoves, []int{2, 3, 1, 4}},
+		{BvBSortDuration, []int{3, 4, 1, 2}},
+		{BvBSortResult, []int{3, 4, 2, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.by.String(), func(t *testing.T) {
+			sorted := sortBvBResults(results, tt.by)
+			for i, r := range sorted {
+				if r.GameNumber != tt.want[i] {
+					t.Fatalf("sortBvBResults(%s) order = %v, want %v", tt.by, gameNumbers(sorted), tt.want)
+				}
+			}
+		})
+	}
+
+	if results[0].GameNumber != 1 || results[1].GameNumber != 2 {
+		t.Error("sortBvBResults should not reorder its input")
+	}
+}
+
+func gameNumbers(results []bvb.GameResult) []int {
+	numbers := make([]int, len(results))
+	for i, r := range results {
+		numbers[i] = r.GameNumber
+	}
+	return numbers
+}
+
+func TestCycleBvBGridFilter(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.bvbPageIndex = 2
+
+	want := []BvBGridFilter{BvBFilterRunning, BvBFilterDecisive, BvBFilterDraws, BvBFilterAll}
+	for _, f := range want {
+		m.cycleBvBGridFilter()
+		if m.bvbGridFilter != f {
+			t.Fatalf("Expected filter %s, got %s", f, m.bvbGridFilter)
+		}
+		if m.bvbPageIndex != 0 {
+			t.Errorf("Expected the filter change to reset the page, got page %d", m.bvbPageIndex)
+		}
+	}
+}
+
+func TestBvBGridSessionsFilter(t *testing.T) {
+	manager := bvb.NewSessionManager(0, 0, "Easy Bot", "Easy Bot", 4, 2)
+	manager.SetSeed(7)
+	if err := manager.RunHeadless(context.Background(), nil); err != nil {
+		t.Fatalf("RunHeadless() error: %v", err)
+	}
+
+	m := NewModel(DefaultConfig())
+	m.bvbManager = manager
+
+	if got := len(m.bvbGridSessions()); got != 4 {
+		t.Fatalf("Expected all 4 games unfiltered, got %d", got)
+	}
+
+	m.bvbGridFilter = BvBFilterRunning
+	if got := len(m.bvbGridSessions()); got != 0 {
+		t.Errorf("Expected no running games after the session finished, got %d", got)
+	}
+
+	m.bvbGridFilter = BvBFilterDecisive
+	decisive := m.bvbGridSessions()
+	m.bvbGridFilter = BvBFilterDraws
+	draws := m.bvbGridSessions()
+	if len(decisive)+len(draws) != 4 {
+		t.Errorf("Expected decisive (%d) and drawn (%d) games to add up to 4", len(decisive), len(draws))
+	}
+	for _, s := range draws {
+		if s.Result().Winner != "Draw" {
+			t.Errorf("Draws filter showed game won by %q", s.Result().Winner)
+		}
+	}
+}
+
+func TestBvBStatsSortKey(t *testing.T) {
+	manager := bvb.NewSessionManager(0, 0, "Easy Bot", "Easy Bot", 2, 1)
+	manager.SetSeed(3)
+	if err := manager.RunHeadless(context.Background(), nil); err != nil {
+		t.Fatalf("RunHeadless() error: %v", err)
+	}
+
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenBvBStats
+	m.bvbManager = manager
+	m.bvbStatsResultsPage = 1
+
+	result, _ := m.handleBvBStatsKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	m = result.(Model)
+	if m.bvbResultSort != BvBSortMoves {
+		t.Fatalf("Expected 'o' to sort by moves, got %s", m.bvbResultSort)
+	}
+	if m.bvbStatsResultsPage != 0 {
+		t.Errorf("Expected the sort change to reset the results page, got %d", m.bvbStatsResultsPage)
+	}
+	if view := ansi.Strip(m.renderBvBStats()); !strings.Contains(view, "by moves") {
+		t.Error("Expected the stats screen to show the sort order")
+	}
+}
+
+// runningBvBModel returns a model in grid view of a session of 6 games, 2 of
+// them running at normal speed and the rest queued.
+func runningBvBModel(t *testing.T) Model {
+	t.Helper()
+	manager := bvb.NewSessionManager(0, 0, "Easy Bot", "Easy Bot", 6, 2)
+	if err := manager.Start(); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	t.Cleanup(manager.Stop)
+
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenBvBGamePlay
+	m.bvbManager = manager
+	m.bvbGameCount = 6
+	m.bvbViewMode = BvBGridView
+	m.bvbGridRows, m.bvbGridCols = 1, 2
+
+	deadline := time.Now().Add(5 * time.Second)
+	for manager.RunningCount() < 2 || manager.Sessions()[1].StartTime().IsZero() {
+		if time.Now().After(deadline) {
+			t.Fatal("the first games did not start")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return m
+}
+
+func TestFollowBvBRunningGamesGrid(t *testing.T) {
+	m := runningBvBModel(t)
+	m.bvbPageIndex = 2 // games 5 and 6, still queued
+
+	m.followBvBRunningGames()
+	if m.bvbPageIndex != 2 {
+		t.Fatal("Expected no page change with follow mode off")
+	}
+
+	result, _ := m.handleBvBGamePlayKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'L'}})
+	m = result.(Model)
+	if !m.bvbFollow {
+		t.Fatal("Expected 'L' to turn follow mode on")
+	}
+	if m.bvbPageIndex != 0 {
+		t.Errorf("Expected follow mode to show the page of the running games, got page %d", m.bvbPageIndex)
+	}
+	if view := ansi.Strip(m.renderBvBGridView()); !strings.Contains(view, "FOLLOWING") {
+		t.Error("Expected the grid to show that it follows the running games")
+	}
+
+	result, _ = m.handleBvBGamePlayKeys(tea.KeyMsg{Type: tea.KeyRight})
+	m = result.(Model)
+	if m.bvbFollow {
+		t.Error("Expected paging by hand to end follow mode")
+	}
+}
+
+func TestFollowBvBRunningGamesSingleView(t *testing.T) {
+	m := runningBvBModel(t)
+	m.bvbViewMode = BvBSingleView
+	m.bvbSelectedGame = 4 // queued
+	m.bvbFollow = true
+
+	m.followBvBRunningGames()
+	if m.bvbSelectedGame != 0 {
+		t.Errorf("Expected follow mode to show the first running game, got game %d", m.bvbSelectedGame+1)
+	}
+
+	m.bvbSelectedGame = 1 // running, so it stays
+	m.followBvBRunningGames()
+	if m.bvbSelectedGame != 1 {
+		t.Errorf("Expected follow mode to keep a running game, got game %d", m.bvbSelectedGame+1)
+	}
+}
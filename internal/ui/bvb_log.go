@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Mgrdich/TermChess/internal/bvb"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// openBvBLog shows the Bot vs Bot session log over the current screen.
+func (m *Model) openBvBLog() {
+	if m.bvbManager == nil {
+		return
+	}
+	m.showBvBLog = true
+	m.scrollOffset = 0
+}
+
+// renderBvBLog renders the content of the session log overlay.
+func (m Model) renderBvBLog() string {
+	body, footer := m.bvbLogParts()
+	return m.renderScrolled(body, m.shortcutsScrollHeight(footer)) + footer
+}
+
+// bvbLogParts renders the session log overlay in two parts: the entries, oldest
+// first, in body and the closing hint in footer.
+func (m Model) bvbLogParts() (body, footer string) {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(m.theme.TitleText)
+	b.WriteString(titleStyle.Render("Session Log"))
+	b.WriteString("\n\n")
+
+	dimStyle := lipgloss.NewStyle().Foreground(m.theme.HelpText)
+	var log *bvb.SessionLog
+	if m.bvbManager != nil {
+		log = m.bvbManager.Log()
+	}
+	if log == nil || log.Len() == 0 {
+		b.WriteString(dimStyle.Render("No errors, warnings or adjudications so far."))
+		b.WriteString("\n")
+	} else {
+		if dropped := log.Dropped(); dropped > 0 {
+			b.WriteString(dimStyle.Render(fmt.Sprintf("(%d older entries dropped)", dropped)))
+			b.WriteString("\n")
+		}
+		for _, e := range log.Entries() {
+			b.WriteString(m.bvbLogLine(e))
+			b.WriteString("\n")
+		}
+	}
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(m.theme.HelpText).
+		Italic(true).
+		Padding(1, 0, 0, 0)
+	footer = hintStyle.Render("Press any key to close")
+	return b.String(), footer
+}
+
+// bvbLogLine renders a log entry, e.g. "12:04:05 Game 3 error: ...", colored by level.
+func (m Model) bvbLogLine(e bvb.LogEntry) string {
+	color := m.theme.StatusText
+	switch e.Level {
+	case bvb.LogWarning:
+		color = m.theme.MenuSelected
+	case bvb.LogError:
+		color = m.theme.ErrorText
+	}
+	game := "Session"
+	if e.Game > 0 {
+		game = fmt.Sprintf("Game %d", e.Game)
+	}
+	line := fmt.Sprintf("%s %s %s: %s", e.Time.Format("15:04:05"), game, e.Level, e.Message)
+	return lipgloss.NewStyle().Foreground(color).Render(line)
+}
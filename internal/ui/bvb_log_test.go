@@ -0,0 +1,35 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/bvb"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestBvBLogOverlay(t *testing.T) {
+	m := finishedBvBModel(t, 1)
+
+	view := func(m Model) string { return ansi.Strip(m.View()) }
+	result, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	m = result.(Model)
+	if !m.showBvBLog {
+		t.Fatal("Expected 'e' to open the session log")
+	}
+	if !strings.Contains(view(m), "No errors, warnings or adjudications so far.") {
+		t.Errorf("Expected an empty log, got:\n%s", view(m))
+	}
+
+	m.bvbManager.Log().Addf(1, bvb.LogError, "Easy Bot (White) failed and loses: boom")
+	if !strings.Contains(view(m), "Game 1 error: Easy Bot (White) failed and loses: boom") {
+		t.Errorf("Expected the entry in the log, got:\n%s", view(m))
+	}
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	m = result.(Model)
+	if m.showBvBLog || m.screen != ScreenBvBStats {
+		t.Error("Expected any other key to close the log and stay on the statistics")
+	}
+}
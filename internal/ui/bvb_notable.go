@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// bvbNotableLabel describes why the game with the given number is notable, e.g.
+// "bookmarked, underpromotion", or returns "" if it isn't.
+func (m Model) bvbNotableLabel(gameNumber int) string {
+	if m.bvbManager == nil {
+		return ""
+	}
+	var reasons []string
+	if m.bvbManager.IsBookmarked(gameNumber) {
+		reasons = append(reasons, "bookmarked")
+	}
+	if s := m.bvbManager.GetSession(gameNumber - 1); s != nil {
+		reasons = append(reasons, s.Flags().Names()...)
+	}
+	return strings.Join(reasons, ", ")
+}
+
+// toggleBvBBookmark bookmarks the focused Bot vs Bot game, or removes its bookmark.
+func (m *Model) toggleBvBBookmark() {
+	s := m.focusedBvBSession()
+	if s == nil {
+		return
+	}
+	if m.bvbManager.ToggleBookmark(s.GameNumber()) {
+		m.notify(SeverityInfo, fmt.Sprintf("Bookmarked game %d", s.GameNumber()))
+	} else {
+		m.notify(SeverityInfo, fmt.Sprintf("Removed bookmark from game %d", s.GameNumber()))
+	}
+}
+
+// openNextNotableBvBGame shows the first bookmarked or flagged game after the
+// game with the given number, wrapping around to the first one, in single view.
+func (m *Model) openNextNotableBvBGame(after int) {
+	if m.bvbManager == nil {
+		return
+	}
+	notable := m.bvbManager.NotableGames()
+	if len(notable) == 0 {
+		m.notify(SeverityInfo, "No bookmarked or flagged games yet")
+		return
+	}
+
+	next := notable[0]
+	for _, n := range notable {
+		if n > after {
+			next = n
+			break
+		}
+	}
+
+	m.bvbSelectedGame = next - 1
+	m.bvbFollow = false
+	m.bvbViewMode = BvBSingleView
+	m.bvbLastNotable = next
+	if m.screen == ScreenBvBStats {
+		m.screen = ScreenBvBGamePlay
+		m.scrollOffset = 0
+	}
+	m.notify(SeverityInfo, fmt.Sprintf("Game %d: %s", next, m.bvbNotableLabel(next)))
+}
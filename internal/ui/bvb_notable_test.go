@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/bvb"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// finishedBvBModel returns a model on the statistics screen of a finished
+// session of n games.
+func finishedBvBModel(t *testing.T, n int) Model {
+	t.Helper()
+	manager := bvb.NewSessionManager(0, 0, "Easy Bot", "Easy Bot", n, 1)
+	manager.SetSeed(5)
+	if err := manager.RunHeadless(context.Background(), nil); err != nil {
+		t.Fatalf("RunHeadless() error: %v", err)
+	}
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenBvBStats
+	m.bvbManager = manager
+	m.bvbGameCount = n
+	m.menuOptions = menuLabels(bvbStatsMenu())
+	return m
+}
+
+func TestBvBBookmarkKey(t *testing.T) {
+	m := finishedBvBModel(t, 3)
+	m.screen = ScreenBvBGamePlay
+	m.bvbViewMode = BvBSingleView
+	m.bvbSelectedGame = 1
+
+	result, _ := m.handleBvBGamePlayKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'*'}})
+	m = result.(Model)
+	if !m.bvbManager.IsBookmarked(2) {
+		t.Fatal("Expected '*' to bookmark the selected game")
+	}
+	if !strings.Contains(toastStatus(m), "Bookmarked game 2") {
+		t.Errorf("Expected a bookmark message, got %q", toastStatus(m))
+	}
+	if view := ansi.Strip(m.renderBvBSingleView()); !strings.Contains(view, "Notable: bookmarked") {
+		t.Error("Expected the single view to show the bookmark")
+	}
+
+	result, _ = m.handleBvBGamePlayKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'*'}})
+	m = result.(Model)
+	if m.bvbManager.IsBookmarked(2) {
+		t.Error("Expected a second '*' to remove the bookmark")
+	}
+}
+
+func TestBvBStatsOpenNextNotable(t *testing.T) {
+	m := finishedBvBModel(t, 3)
+	m.bvbManager.ToggleBookmark(2)
+
+	if view := ansi.Strip(m.renderBvBStats()); !strings.Contains(view, "Notable Games") || !strings.Contains(view, "Game 2: bookmarked") {
+		t.Error("Expected the stats screen to list the bookmarked game")
+	}
+
+	// Games between easy bots may be flagged too, so expect the first notable one
+	first := m.bvbManager.NotableGames()[0]
+	result, _ := m.handleBvBStatsKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'N'}})
+	m = result.(Model)
+	if m.screen != ScreenBvBGamePlay || m.bvbViewMode != BvBSingleView {
+		t.Fatalf("Expected 'N' to open the game in single view, got screen %v", m.screen)
+	}
+	if m.bvbSelectedGame != first-1 {
+		t.Errorf("Expected game %d to be selected, got game %d", first, m.bvbSelectedGame+1)
+	}
+
+	// The session is over, so going back returns to the stats screen
+	result, _ = m.handleBvBGamePlayKeys(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	if m.screen != ScreenBvBStats {
+		t.Errorf("Expected ESC to return to the stats screen, got %v", m.screen)
+	}
+}
+
+func TestOpenNextNotableWithoutNotableGames(t *testing.T) {
+	m := finishedBvBModel(t, 1)
+	// A single short game between easy bots may still be flagged
+	if len(m.bvbManager.NotableGames()) > 0 {
+		t.Skip("the game was flagged")
+	}
+
+	m.openNextNotableBvBGame(0)
+	if m.screen != ScreenBvBStats {
+		t.Error("Expected to stay on the stats screen without notable games")
+	}
+	if !strings.Contains(toastStatus(m), "No bookmarked or flagged games") {
+		t.Errorf("Expected a message about no notable games, got %q", toastStatus(m))
+	}
+}
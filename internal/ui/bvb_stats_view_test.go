@@ -1,10 +1,12 @@
 package ui
 
 import (
+	"context"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/Mgrdich/TermChess/internal/bvb"
 	"github.com/Mgrdich/TermChess/internal/engine"
 )
 
@@ -234,3 +236,73 @@ func TestFormatLastMovesEdgeCases(t *testing.T) {
 		t.Errorf("formatLastMoves with large n should show all moves, got %q", got)
 	}
 }
+
+func TestBvBEloLine(t *testing.T) {
+	stats := &bvb.AggregateStats{
+		WhiteBotName: "Hard Bot",
+		BlackBotName: "Easy Bot",
+		WhiteWins:    2,
+		Elo:          &bvb.EloEstimate{Diff: 191, Lower: 40, Upper: 420},
+	}
+	if got, want := bvbEloLine(stats), "Elo difference: Hard Bot +191 (95% CI: +40 to +420)"; got != want {
+		t.Errorf("bvbEloLine() = %q, want %q", got, want)
+	}
+
+	stats.Elo = nil
+	if got := bvbEloLine(stats); !strings.Contains(got, "not enough information (2-0-0)") {
+		t.Errorf("Expected no estimate from a perfect score, got %q", got)
+	}
+}
+
+func TestBvBEndingsLine(t *testing.T) {
+	stats := &bvb.AggregateStats{EndReasons: []bvb.EndReasonCount{
+		{Reason: "checkmate", Games: 3, Pct: 75},
+		{Reason: "adjudication", Games: 1, Pct: 25},
+	}}
+	if got, want := bvbEndingsLine(stats), "Endings: checkmate 3 (75%) | adjudication 1 (25%)"; got != want {
+		t.Errorf("bvbEndingsLine() = %q, want %q", got, want)
+	}
+	if got, want := formatBvBInterval(bvb.Interval{Lower: 23.66, Upper: 76.34}), "95% CI 23.7–76.3%"; got != want {
+		t.Errorf("formatBvBInterval() = %q, want %q", got, want)
+	}
+}
+
+func TestBvBRepetitionLine(t *testing.T) {
+	if got := bvbRepetitionLine(&bvb.AggregateStats{}); got != "" {
+		t.Errorf("bvbRepetitionLine() without warnings = %q, want empty", got)
+	}
+	stats := &bvb.AggregateStats{RepetitionWarnings: 5, RepetitionWarningGames: 3, RepetitionDraws: 2}
+	if got, want := bvbRepetitionLine(stats), "Repetition warnings: 5 in 3 games, 2 then drawn by repetition"; got != want {
+		t.Errorf("bvbRepetitionLine() = %q, want %q", got, want)
+	}
+}
+
+func TestBvBPairLines(t *testing.T) {
+	stats := &bvb.AggregateStats{
+		WhiteBotName: "Hard Bot",
+		BlackBotName: "Easy Bot",
+		Pairs:        &bvb.PairStats{Complete: 3, WhiteWins: 2, Draws: 1, WinsAsWhite: 4, WinsAsBlack: 1},
+	}
+	got := bvbPairLines(stats)
+	want := []string{
+		"Pairs: Hard Bot won 2 | Easy Bot won 0 | Split 1 (3 complete)",
+		"Games won with White: 4 | with Black: 1",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("bvbPairLines() = %q, want %q", got, want)
+	}
+}
+
+func TestRenderBvBStatsShowsSeed(t *testing.T) {
+	manager := bvb.NewSessionManager(0, 0, "Easy Bot", "Easy Bot", 1, 1)
+	manager.SetSeed(1234)
+	if err := manager.RunHeadless(context.Background(), nil); err != nil {
+		t.Fatalf("RunHeadless() error: %v", err)
+	}
+
+	m := NewModel(DefaultConfig())
+	m.bvbManager = manager
+	if view := m.renderBvBStats(); !strings.Contains(view, "Seed: 1234") {
+		t.Error("Expected the stats screen to show the seed")
+	}
+}
@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// mateRevealDuration is how long the mating position stays on the game screen
+// before the game over screen takes its place.
+const mateRevealDuration = 2 * time.Second
+
+// mateRevealDoneMsg ends the reveal of a checkmate.
+type mateRevealDoneMsg struct{}
+
+// revealMate holds a game that just ended in checkmate on the game screen, so
+// the mating position can be seen before the game over screen. It reports
+// whether it did; showGameOver switches screens once the reveal is over.
+func (m *Model) revealMate() bool {
+	if m.mateReveal || m.screen != ScreenGamePlay || m.inSimul() || m.game == nil ||
+		m.game.MoveCount() == 0 || m.game.Board().Status() != engine.Checkmate {
+		return false
+	}
+	m.mateReveal = true
+	return true
+}
+
+// scheduleMateReveal returns the command ending a reveal that was just started,
+// or nil.
+func (m *Model) scheduleMateReveal() tea.Cmd {
+	if !m.mateReveal || m.mateRevealScheduled {
+		return nil
+	}
+	m.mateRevealScheduled = true
+	return tea.Tick(mateRevealDuration, func(time.Time) tea.Msg {
+		return mateRevealDoneMsg{}
+	})
+}
+
+// finishMateReveal moves on to the game over screen, if a checkmate is being
+// revealed.
+func (m *Model) finishMateReveal() {
+	if !m.mateReveal {
+		return
+	}
+	m.showGameOver()
+	m.mateReveal = false
+	m.mateRevealScheduled = false
+}
+
+// checkBanner returns the banner shown under the board when the side to move
+// is in check, or "" if it isn't.
+func (m Model) checkBanner() string {
+	if !m.game.Board().InCheck() {
+		return ""
+	}
+	text := "CHECK!"
+	if m.mateReveal {
+		text = "CHECKMATE!"
+	}
+	return lipgloss.NewStyle().
+		Bold(true).
+		Foreground(m.theme.CheckHighlight).
+		Render(text)
+}
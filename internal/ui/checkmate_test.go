@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestCheckmateIsShownBeforeGameOver(t *testing.T) {
+	m := foolsMateOpening(t)
+	m.screen = ScreenGamePlay
+	m.gameType = GameTypePvP
+	m.input = "Qh4"
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if m.screen != ScreenGamePlay || !m.mateReveal {
+		t.Fatalf("Expected the mate to be shown on the game screen, got screen %v", m.screen)
+	}
+	if !m.mateRevealScheduled {
+		t.Error("Expected the end of the reveal to be scheduled")
+	}
+	if view := m.View(); !strings.Contains(view, "CHECKMATE!") {
+		t.Errorf("Expected a checkmate banner, got:\n%s", view)
+	}
+
+	result, _ = m.Update(mateRevealDoneMsg{})
+	m = result.(Model)
+	if m.screen != ScreenGameOver || m.mateReveal {
+		t.Errorf("Expected the game over screen after the reveal, got %v", m.screen)
+	}
+}
+
+func TestKeySkipsCheckmateReveal(t *testing.T) {
+	m := foolsMateOpening(t)
+	m.screen = ScreenGamePlay
+	m.gameType = GameTypePvP
+	m.input = "Qh4"
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeySpace})
+	m = result.(Model)
+	if m.screen != ScreenGameOver {
+		t.Errorf("Expected a key to skip to the game over screen, got %v", m.screen)
+	}
+	if m.menuSelection != 0 {
+		t.Errorf("Expected the key not to reach the game over menu, selection = %d", m.menuSelection)
+	}
+}
+
+func TestCheckBanner(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.game = engine.NewGame()
+	if banner := m.checkBanner(); banner != "" {
+		t.Errorf("Expected no banner out of check, got %q", banner)
+	}
+
+	board, err := engine.FromFEN("4k3/8/8/8/8/8/8/4R1K1 b - - 0 1")
+	if err != nil {
+		t.Fatalf("FromFEN() error = %v", err)
+	}
+	m.game = engine.NewGameFrom(board)
+	if banner := m.checkBanner(); !strings.Contains(banner, "CHECK!") || strings.Contains(banner, "MATE") {
+		t.Errorf("Expected a check banner, got %q", banner)
+	}
+}
+
+func TestBoardRendererShowCheck(t *testing.T) {
+	lipgloss.SetColorProfile(termenv.ANSI256)
+
+	board, err := engine.FromFEN("4k3/8/8/8/8/8/8/4R1K1 b - - 0 1")
+	if err != nil {
+		t.Fatalf("FromFEN() error = %v", err)
+	}
+	renderer := NewBoardRenderer(Config{})
+	plain := renderer.Render(board)
+	renderer.ShowCheck()
+	if got := renderer.Render(board); got == plain || !strings.Contains(got, "\x1b[") {
+		t.Errorf("Expected the king in check to be highlighted, got:\n%s", got)
+	}
+}
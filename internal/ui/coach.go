@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Mgrdich/TermChess/internal/bot"
+	"github.com/Mgrdich/TermChess/internal/config"
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// CoachCommentMsg is sent when the coach has checked a move of the user.
+type CoachCommentMsg struct {
+	// game is the game the move was played in
+	game *engine.Game
+	// ply is the number of moves of the game, the checked move included
+	ply     int
+	comment string
+}
+
+// coachMove starts the coach checking move, just played by the user from
+// position, in the background, and clears the comment on the previous move.
+// It returns nil if the coach is off or the game isn't against a bot.
+func (m *Model) coachMove(position *engine.Board, move engine.Move) tea.Cmd {
+	m.stopCoach()
+	verbosity := m.config.Coach
+	if m.gameType != GameTypePvBot || verbosity == "" || verbosity == config.CoachOff {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.coachCancel = cancel
+
+	game, ply := m.game, m.game.MoveCount()
+	format := m.formatMove
+	return func() tea.Msg {
+		review, err := bot.ReviewMove(ctx, position, move, bot.DefaultAnalysisDepth)
+		if err != nil {
+			// The coach stays quiet about a move it couldn't check
+			return nil
+		}
+		return CoachCommentMsg{game: game, ply: ply, comment: coachComment(position, move, review, verbosity, format)}
+	}
+}
+
+// handleCoachComment shows the coach's comment, unless the game has moved on
+// past the bot's reply to the move since.
+func (m Model) handleCoachComment(msg CoachCommentMsg) (tea.Model, tea.Cmd) {
+	if msg.game != m.game || (msg.ply != m.game.MoveCount() && msg.ply != m.game.MoveCount()-1) {
+		return m, nil
+	}
+	m.coachCancel = nil
+	m.coachComment = msg.comment
+	return m, nil
+}
+
+// stopCoach cancels a running check of the user's move and clears the coach's comment.
+func (m *Model) stopCoach() {
+	if m.coachCancel != nil {
+		m.coachCancel()
+		m.coachCancel = nil
+	}
+	m.coachComment = ""
+}
+
+// coachComment returns the coach's one-line comment on move, played from
+// position, or "" if the verbosity leaves out a good move. A move that gives a
+// piece away or allows mate says so; any other slip names the better move,
+// with the result it leaves when the tablebases cover the position.
+func coachComment(position *engine.Board, move engine.Move, review *bot.MoveReview, verbosity string,
+	format func(*engine.Board, engine.Move) string) string {
+	if review.Classification == bot.MoveGood {
+		switch {
+		case verbosity != config.CoachAll:
+			return ""
+		case move == review.BestMove:
+			return "Good move - the engine's choice too."
+		default:
+			return "Good move."
+		}
+	}
+
+	after := position.Copy()
+	_ = after.MakeMove(move)
+	if review.Reply != (engine.Move{}) {
+		mated := after.Copy()
+		if mated.MakeMove(review.Reply) == nil && mated.Status() == engine.Checkmate {
+			return fmt.Sprintf("%s: this allows mate with %s.", review.Classification, format(after, review.Reply))
+		}
+	}
+	if review.Classification >= bot.MoveMistake {
+		if sq, hangs := bot.HangsPiece(position, move); hangs {
+			return fmt.Sprintf("%s: you hung %s on %s.", review.Classification, hungPieceName(after.PieceAt(sq).Type()), sq)
+		}
+	}
+	if tb := review.Tablebase; tb != nil {
+		return fmt.Sprintf("%s: the tablebases show %s now; %s was better.",
+			review.Classification, tablebaseOutcome(tb.WDL), format(position, review.BestMove))
+	}
+	return fmt.Sprintf("%s: %s was better.", review.Classification, format(position, review.BestMove))
+}
+
+// tablebaseOutcome names a tablebase result for the player it belongs to: "a win", "a draw".
+func tablebaseOutcome(wdl bot.WDL) string {
+	switch wdl {
+	case bot.WDLWin:
+		return "a win"
+	case bot.WDLLoss:
+		return "a loss"
+	default:
+		return "a draw"
+	}
+}
+
+// hungPieceName names a hung piece of the user: "your queen", "a knight".
+func hungPieceName(t engine.PieceType) string {
+	if t == engine.Queen {
+		return "your queen"
+	}
+	return "a " + pieceNames[t]
+}
+
+// coachVerbosities are the values the Coach setting cycles through.
+var coachVerbosities = []string{
+	config.CoachOff,
+	config.CoachMistakes,
+	config.CoachAll,
+}
+
+// cycleCoach returns the coach verbosity after current. A value set in the
+// config file that isn't one of coachVerbosities counts as off.
+func cycleCoach(current string) string {
+	i := max(slices.Index(coachVerbosities, current), 0)
+	return coachVerbosities[(i+1)%len(coachVerbosities)]
+}
+
+// getCoachDisplayName returns a display-friendly name for a coach verbosity.
+func getCoachDisplayName(verbosity string) string {
+	switch verbosity {
+	case config.CoachMistakes:
+		return "Mistakes Only"
+	case config.CoachAll:
+		return "Every Move"
+	default:
+		return "Off"
+	}
+}
@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/bot"
+	"github.com/Mgrdich/TermChess/internal/config"
+	"github.com/Mgrdich/TermChess/internal/engine"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// coachModel returns the game of foolsMateOpening with the coach at verbosity
+// and the user playing Black, to move with mate in one.
+func coachModel(t *testing.T, verbosity string) Model {
+	t.Helper()
+	m := foolsMateOpening(t)
+	m.config.Coach = verbosity
+	m.userColor = engine.Black
+	m.screen = ScreenGamePlay
+	return m
+}
+
+// coachPlay plays san for the user as playUserMove does and returns the model
+// with the coach's comment on it.
+func coachPlay(t *testing.T, m Model, san string) Model {
+	t.Helper()
+	move, err := engine.ParseSAN(m.game.Board(), san)
+	if err != nil {
+		t.Fatalf("ParseSAN(%s) error = %v", san, err)
+	}
+	position := m.game.Board().Copy()
+	_ = m.game.MakeMove(move)
+	cmd := m.coachMove(position, move)
+	if cmd == nil {
+		return m
+	}
+	msg, ok := cmd().(CoachCommentMsg)
+	if !ok {
+		t.Fatal("Expected the coach to comment")
+	}
+	result, _ := m.handleCoachComment(msg)
+	return result.(Model)
+}
+
+func TestCoachCommentsOnMoves(t *testing.T) {
+	m := coachPlay(t, coachModel(t, config.CoachMistakes), "Ba3")
+	if !strings.HasPrefix(m.coachComment, "Blunder: you hung a bishop on a3") {
+		t.Errorf("Expected the hung bishop called out, got %q", m.coachComment)
+	}
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "Coach: Blunder") {
+		t.Errorf("Expected the comment shown under the board, got\n%s", view)
+	}
+
+	m = coachPlay(t, coachModel(t, config.CoachMistakes), "Qh4")
+	if m.coachComment != "" {
+		t.Errorf("Expected no comment on a good move with mistakes only, got %q", m.coachComment)
+	}
+	m = coachPlay(t, coachModel(t, config.CoachAll), "Qh4")
+	if !strings.HasPrefix(m.coachComment, "Good move") {
+		t.Errorf("Expected a good move praised, got %q", m.coachComment)
+	}
+
+	m = coachModel(t, config.CoachOff)
+	m.coachComment = "Good move."
+	if cmd := m.coachMove(m.game.Board().Copy(), m.game.Moves()[0]); cmd != nil || m.coachComment != "" {
+		t.Errorf("Expected no check with the coach off, got comment %q", m.coachComment)
+	}
+}
+
+func TestCoachCommentMentionsMateAndBetterMove(t *testing.T) {
+	// After 1. f3 e5, g4 allows Qh4#
+	board := engine.NewBoard()
+	for _, san := range []string{"f3", "e5"} {
+		move, _ := engine.ParseSAN(board, san)
+		_ = board.MakeMove(move)
+	}
+	move, _ := engine.ParseSAN(board, "g4")
+	review, err := bot.ReviewMove(context.Background(), board, move, bot.DefaultAnalysisDepth)
+	if err != nil {
+		t.Fatalf("ReviewMove() error = %v", err)
+	}
+	m := NewModel(DefaultConfig())
+	if got := coachComment(board, move, review, config.CoachMistakes, m.formatMove); got != "Blunder: this allows mate with Qh4#." {
+		t.Errorf("coachComment() = %q, want mate pointed out", got)
+	}
+
+	review = &bot.MoveReview{BestMove: move, CentipawnLoss: 60, Classification: bot.MoveInaccuracy}
+	other, _ := engine.ParseSAN(board, "a3")
+	if got := coachComment(board, other, review, config.CoachMistakes, m.formatMove); got != "Inaccuracy: g4 was better." {
+		t.Errorf("coachComment() = %q, want the better move named", got)
+	}
+
+	review.Tablebase = &bot.TablebaseResult{WDL: bot.WDLDraw}
+	if got := coachComment(board, other, review, config.CoachMistakes, m.formatMove); got != "Inaccuracy: the tablebases show a draw now; g4 was better." {
+		t.Errorf("coachComment() = %q, want the tablebase result", got)
+	}
+}
+
+func TestCoachIgnoresStaleComments(t *testing.T) {
+	m := coachModel(t, config.CoachAll)
+	msg := CoachCommentMsg{game: m.game, ply: m.game.MoveCount() - 2, comment: "Good move."}
+	result, _ := m.handleCoachComment(msg)
+	if got := result.(Model).coachComment; got != "" {
+		t.Errorf("Expected a comment on an earlier move ignored, got %q", got)
+	}
+	msg = CoachCommentMsg{game: engine.NewGame(), ply: m.game.MoveCount(), comment: "Good move."}
+	result, _ = m.handleCoachComment(msg)
+	if got := result.(Model).coachComment; got != "" {
+		t.Errorf("Expected a comment on another game ignored, got %q", got)
+	}
+}
+
+func TestSettingsCoach(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenSettings
+	m.settingsSelection = 13
+	if !strings.Contains(m.renderSettings(), "Coach: Off") {
+		t.Error("Expected the coach to start off")
+	}
+	for _, want := range []string{config.CoachMistakes, config.CoachAll, config.CoachOff} {
+		model, _ := m.toggleSelectedSetting()
+		m = model.(Model)
+		if m.config.Coach != want {
+			t.Fatalf("Coach = %q, want %q", m.config.Coach, want)
+		}
+	}
+	if got := cycleCoach("chatty"); got != config.CoachMistakes {
+		t.Errorf("cycleCoach(unknown) = %q, want mistakes after off", got)
+	}
+}
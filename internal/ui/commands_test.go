@@ -11,7 +11,7 @@ import (
 func TestHandleGamePlayKeys_ResignCommand(t *testing.T) {
 	// Create a model with a new board in gameplay
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenGamePlay
 
 	// White resigns
@@ -26,8 +26,8 @@ func TestHandleGamePlayKeys_ResignCommand(t *testing.T) {
 	}
 
 	// Verify White resigned
-	if m.resignedBy != int8(engine.White) {
-		t.Errorf("Expected resignedBy to be White (0), got %d", m.resignedBy)
+	if by, ok := m.game.ResignedBy(); !ok || by != engine.White {
+		t.Errorf("Expected White to have resigned, got %d", by)
 	}
 
 	// Verify input was cleared
@@ -36,12 +36,12 @@ func TestHandleGamePlayKeys_ResignCommand(t *testing.T) {
 	}
 
 	// Verify messages were cleared
-	if m.errorMsg != "" {
-		t.Errorf("Expected errorMsg to be cleared, got '%s'", m.errorMsg)
+	if toastError(m) != "" {
+		t.Errorf("Expected errorMsg to be cleared, got '%s'", toastError(m))
 	}
 
-	if m.statusMsg != "" {
-		t.Errorf("Expected statusMsg to be cleared, got '%s'", m.statusMsg)
+	if toastStatus(m) != "" {
+		t.Errorf("Expected statusMsg to be cleared, got '%s'", toastStatus(m))
 	}
 }
 
@@ -52,7 +52,7 @@ func TestHandleGamePlayKeys_ResignCommandCaseInsensitive(t *testing.T) {
 	for _, resignInput := range testCases {
 		// Create a fresh model for each test
 		m := NewModel(DefaultConfig())
-		m.board = engine.NewBoard()
+		m.game = engine.NewGame()
 		m.screen = ScreenGamePlay
 
 		// Test resignation with different casings
@@ -67,8 +67,8 @@ func TestHandleGamePlayKeys_ResignCommandCaseInsensitive(t *testing.T) {
 		}
 
 		// Verify resignation was recorded
-		if m.resignedBy != int8(engine.White) {
-			t.Errorf("Expected resignedBy to be White for input '%s', got %d", resignInput, m.resignedBy)
+		if by, ok := m.game.ResignedBy(); !ok || by != engine.White {
+			t.Errorf("Expected White to have resigned for input '%s', got %d", resignInput, by)
 		}
 	}
 }
@@ -77,7 +77,7 @@ func TestHandleGamePlayKeys_ResignCommandCaseInsensitive(t *testing.T) {
 func TestHandleGamePlayKeys_ResignBlackPlayer(t *testing.T) {
 	// Create a model and make it Black's turn
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenGamePlay
 
 	// Make a move to switch to Black's turn
@@ -93,8 +93,8 @@ func TestHandleGamePlayKeys_ResignBlackPlayer(t *testing.T) {
 	m = result.(Model)
 
 	// Verify Black resigned
-	if m.resignedBy != int8(engine.Black) {
-		t.Errorf("Expected resignedBy to be Black (1), got %d", m.resignedBy)
+	if by, ok := m.game.ResignedBy(); !ok || by != engine.Black {
+		t.Errorf("Expected Black to have resigned, got %d", by)
 	}
 
 	// Verify screen is game over
@@ -110,7 +110,7 @@ func TestHandleGamePlayKeys_ResignWithWhitespace(t *testing.T) {
 	for _, resignInput := range testCases {
 		// Create a fresh model for each test
 		m := NewModel(DefaultConfig())
-		m.board = engine.NewBoard()
+		m.game = engine.NewGame()
 		m.screen = ScreenGamePlay
 
 		// Test resignation with whitespace
@@ -130,7 +130,7 @@ func TestHandleGamePlayKeys_ResignWithWhitespace(t *testing.T) {
 func TestHandleGamePlayKeys_ShowFenCommand(t *testing.T) {
 	// Create a model with a new board
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenGamePlay
 
 	// Execute showfen command
@@ -145,18 +145,18 @@ func TestHandleGamePlayKeys_ShowFenCommand(t *testing.T) {
 	}
 
 	// Verify status message contains FEN
-	if m.statusMsg == "" {
+	if toastStatus(m) == "" {
 		t.Error("Expected statusMsg to contain FEN string")
 	}
 
 	// Verify the FEN is the starting position
 	expectedFen := "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1"
-	if m.statusMsg != "FEN: "+expectedFen+" (Copied to clipboard)" &&
-		m.statusMsg != "FEN: "+expectedFen+" (Failed to copy to clipboard: failed to initialize clipboard: system clipboard not accessible)" {
+	if toastStatus(m) != "FEN: "+expectedFen+" (Copied to clipboard)" &&
+		toastStatus(m) != "FEN: "+expectedFen+" (Failed to copy to clipboard: failed to initialize clipboard: system clipboard not accessible)" {
 		// Either success or expected clipboard failure is acceptable
 		// Some CI environments don't have clipboard access
-		if m.statusMsg[:5] != "FEN: " {
-			t.Errorf("Expected statusMsg to start with 'FEN: ', got '%s'", m.statusMsg)
+		if toastStatus(m)[:5] != "FEN: " {
+			t.Errorf("Expected statusMsg to start with 'FEN: ', got '%s'", toastStatus(m))
 		}
 	}
 
@@ -166,8 +166,8 @@ func TestHandleGamePlayKeys_ShowFenCommand(t *testing.T) {
 	}
 
 	// Verify no error message
-	if m.errorMsg != "" {
-		t.Errorf("Expected no error message, got '%s'", m.errorMsg)
+	if toastError(m) != "" {
+		t.Errorf("Expected no error message, got '%s'", toastError(m))
 	}
 }
 
@@ -178,7 +178,7 @@ func TestHandleGamePlayKeys_ShowFenCommandCaseInsensitive(t *testing.T) {
 	for _, showfenInput := range testCases {
 		// Create a fresh model for each test
 		m := NewModel(DefaultConfig())
-		m.board = engine.NewBoard()
+		m.game = engine.NewGame()
 		m.screen = ScreenGamePlay
 
 		// Execute showfen command
@@ -188,7 +188,7 @@ func TestHandleGamePlayKeys_ShowFenCommandCaseInsensitive(t *testing.T) {
 		m = result.(Model)
 
 		// Verify status message contains FEN
-		if m.statusMsg == "" {
+		if toastStatus(m) == "" {
 			t.Errorf("Expected statusMsg to contain FEN string for input '%s'", showfenInput)
 		}
 
@@ -203,7 +203,7 @@ func TestHandleGamePlayKeys_ShowFenCommandCaseInsensitive(t *testing.T) {
 func TestHandleGamePlayKeys_ShowFenAfterMoves(t *testing.T) {
 	// Create a model with a new board
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenGamePlay
 
 	// Make a move: e2e4
@@ -219,15 +219,15 @@ func TestHandleGamePlayKeys_ShowFenAfterMoves(t *testing.T) {
 	m = result.(Model)
 
 	// Verify status message contains FEN and it's different from starting position
-	if m.statusMsg == "" {
+	if toastStatus(m) == "" {
 		t.Error("Expected statusMsg to contain FEN string")
 	}
 
 	// The FEN should reflect the move e2e4
 	// Should contain "4P3" or similar pattern in the position
 	// And should show "b" (Black to move)
-	if m.statusMsg[:5] != "FEN: " {
-		t.Errorf("Expected statusMsg to start with 'FEN: ', got '%s'", m.statusMsg)
+	if toastStatus(m)[:5] != "FEN: " {
+		t.Errorf("Expected statusMsg to start with 'FEN: ', got '%s'", toastStatus(m))
 	}
 }
 
@@ -235,7 +235,7 @@ func TestHandleGamePlayKeys_ShowFenAfterMoves(t *testing.T) {
 func TestHandleGamePlayKeys_MenuCommand(t *testing.T) {
 	// Create a model with a new board
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenGamePlay
 
 	// Execute menu command
@@ -265,12 +265,12 @@ func TestHandleGamePlayKeys_MenuCommand(t *testing.T) {
 	}
 
 	// Verify messages were cleared
-	if m.errorMsg != "" {
-		t.Errorf("Expected errorMsg to be cleared, got '%s'", m.errorMsg)
+	if toastError(m) != "" {
+		t.Errorf("Expected errorMsg to be cleared, got '%s'", toastError(m))
 	}
 
-	if m.statusMsg != "" {
-		t.Errorf("Expected statusMsg to be cleared, got '%s'", m.statusMsg)
+	if toastStatus(m) != "" {
+		t.Errorf("Expected statusMsg to be cleared, got '%s'", toastStatus(m))
 	}
 }
 
@@ -281,7 +281,7 @@ func TestHandleGamePlayKeys_MenuCommandCaseInsensitive(t *testing.T) {
 	for _, menuInput := range testCases {
 		// Create a fresh model for each test
 		m := NewModel(DefaultConfig())
-		m.board = engine.NewBoard()
+		m.game = engine.NewGame()
 		m.screen = ScreenGamePlay
 
 		// Execute menu command
@@ -306,7 +306,7 @@ func TestHandleGamePlayKeys_MenuCommandCaseInsensitive(t *testing.T) {
 func TestHandleGamePlayKeys_CommandsDoNotInterfereWithMoves(t *testing.T) {
 	// Create a model with a new board
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenGamePlay
 
 	// First make a normal move
@@ -316,8 +316,8 @@ func TestHandleGamePlayKeys_CommandsDoNotInterfereWithMoves(t *testing.T) {
 	m = result.(Model)
 
 	// Verify move was executed
-	if m.errorMsg != "" {
-		t.Errorf("Expected no error after normal move, got '%s'", m.errorMsg)
+	if toastError(m) != "" {
+		t.Errorf("Expected no error after normal move, got '%s'", toastError(m))
 	}
 
 	if m.screen != ScreenGamePlay {
@@ -325,7 +325,7 @@ func TestHandleGamePlayKeys_CommandsDoNotInterfereWithMoves(t *testing.T) {
 	}
 
 	// Verify turn changed to Black
-	if m.board.ActiveColor != engine.Black {
+	if m.game.Board().ActiveColor != engine.Black {
 		t.Error("Expected active color to be Black after White's move")
 	}
 
@@ -336,18 +336,18 @@ func TestHandleGamePlayKeys_CommandsDoNotInterfereWithMoves(t *testing.T) {
 	m = result.(Model)
 
 	// Verify move was executed
-	if m.errorMsg != "" {
-		t.Errorf("Expected no error after second move, got '%s'", m.errorMsg)
+	if toastError(m) != "" {
+		t.Errorf("Expected no error after second move, got '%s'", toastError(m))
 	}
 
 	// Verify turn changed back to White
-	if m.board.ActiveColor != engine.White {
+	if m.game.Board().ActiveColor != engine.White {
 		t.Error("Expected active color to be White after Black's move")
 	}
 
 	// Verify move history has 2 moves
-	if len(m.moveHistory) != 2 {
-		t.Errorf("Expected 2 moves in history, got %d", len(m.moveHistory))
+	if m.game.MoveCount() != 2 {
+		t.Errorf("Expected 2 moves in history, got %d", m.game.MoveCount())
 	}
 }
 
@@ -355,7 +355,7 @@ func TestHandleGamePlayKeys_CommandsDoNotInterfereWithMoves(t *testing.T) {
 func TestHandleGamePlayKeys_InvalidCommandTreatedAsMove(t *testing.T) {
 	// Create a model with a new board
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenGamePlay
 
 	// Try an input that looks like a command but isn't
@@ -365,7 +365,7 @@ func TestHandleGamePlayKeys_InvalidCommandTreatedAsMove(t *testing.T) {
 	m = result.(Model)
 
 	// Verify it was treated as an invalid move, not a command
-	if m.errorMsg == "" {
+	if toastError(m) == "" {
 		t.Error("Expected error message for invalid move 'resigns'")
 	}
 
@@ -375,8 +375,8 @@ func TestHandleGamePlayKeys_InvalidCommandTreatedAsMove(t *testing.T) {
 	}
 
 	// Verify no resignation occurred
-	if m.resignedBy != -1 {
-		t.Errorf("Expected resignedBy to be -1, got %d", m.resignedBy)
+	if _, ok := m.game.ResignedBy(); ok {
+		t.Error("Expected no resignation")
 	}
 }
 
@@ -387,7 +387,7 @@ func TestHandleGamePlayKeys_PartialCommandsNotRecognized(t *testing.T) {
 	for _, input := range testCases {
 		// Create a fresh model for each test
 		m := NewModel(DefaultConfig())
-		m.board = engine.NewBoard()
+		m.game = engine.NewGame()
 		m.screen = ScreenGamePlay
 
 		// Try the partial command
@@ -397,7 +397,7 @@ func TestHandleGamePlayKeys_PartialCommandsNotRecognized(t *testing.T) {
 		m = result.(Model)
 
 		// Verify it was treated as an invalid move (should have error)
-		if m.errorMsg == "" {
+		if toastError(m) == "" {
 			t.Errorf("Expected error message for invalid input '%s'", input)
 		}
 
@@ -407,8 +407,8 @@ func TestHandleGamePlayKeys_PartialCommandsNotRecognized(t *testing.T) {
 		}
 
 		// Verify no resignation occurred
-		if m.resignedBy != -1 {
-			t.Errorf("Expected resignedBy to be -1 for input '%s', got %d", input, m.resignedBy)
+		if _, ok := m.game.ResignedBy(); ok {
+			t.Errorf("Expected no resignation for input '%s'", input)
 		}
 	}
 }
@@ -417,7 +417,7 @@ func TestHandleGamePlayKeys_PartialCommandsNotRecognized(t *testing.T) {
 func TestHandleGamePlayKeys_ResignationResetsOnNewGame(t *testing.T) {
 	// Create a model with a new board
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenGamePlay
 
 	// Resign
@@ -427,20 +427,20 @@ func TestHandleGamePlayKeys_ResignationResetsOnNewGame(t *testing.T) {
 	m = result.(Model)
 
 	// Verify resignation occurred
-	if m.resignedBy != int8(engine.White) {
-		t.Errorf("Expected resignedBy to be White, got %d", m.resignedBy)
+	if by, ok := m.game.ResignedBy(); !ok || by != engine.White {
+		t.Errorf("Expected White to have resigned, got %d", by)
 	}
 
 	// Now start a new game by selecting "New Game" from game over screen
 	m.screen = ScreenGameTypeSelect
 	m.menuOptions = []string{"Player vs Player", "Player vs Bot"}
 	m.menuSelection = 0
-	result, _ = m.handleGameTypeSelection()
+	result, _ = m.selectMenuItem()
 	m = result.(Model)
 
 	// Verify resignation was reset
-	if m.resignedBy != -1 {
-		t.Errorf("Expected resignedBy to be reset to -1, got %d", m.resignedBy)
+	if _, ok := m.game.ResignedBy(); ok {
+		t.Error("Expected resignation to be reset")
 	}
 
 	// Verify we're in gameplay
@@ -451,25 +451,26 @@ func TestHandleGamePlayKeys_ResignationResetsOnNewGame(t *testing.T) {
 
 // TestGetGameResultMessage_Resignation tests the game result message for resignation
 func TestGetGameResultMessage_Resignation(t *testing.T) {
-	// Create a board (state doesn't matter for resignation)
-	board := engine.NewBoard()
-
-	// Test White resignation
-	resultMsg := getGameResultMessage(board, int8(engine.White), false)
+	// Test White resignation (the position doesn't matter for resignation)
+	game := engine.NewGame()
+	game.Resign(engine.White)
+	resultMsg := getGameResultMessage(game)
 	expectedMsg := "White resigned - Black wins"
 	if resultMsg != expectedMsg {
 		t.Errorf("Expected '%s', got '%s'", expectedMsg, resultMsg)
 	}
 
 	// Test Black resignation
-	resultMsg = getGameResultMessage(board, int8(engine.Black), false)
+	game = engine.NewGame()
+	game.Resign(engine.Black)
+	resultMsg = getGameResultMessage(game)
 	expectedMsg = "Black resigned - White wins"
 	if resultMsg != expectedMsg {
 		t.Errorf("Expected '%s', got '%s'", expectedMsg, resultMsg)
 	}
 
 	// Test no resignation (should fall through to normal game status)
-	resultMsg = getGameResultMessage(board, -1, false)
+	resultMsg = getGameResultMessage(engine.NewGame())
 	// Starting position is not game over, so should return "Game Over" as default
 	expectedMsg = "Game Over"
 	if resultMsg != expectedMsg {
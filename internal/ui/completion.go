@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxShownCompletions is how many move completions are listed under the prompt.
+const maxShownCompletions = 8
+
+// moveCompletions returns the legal moves, in SAN with the piece letters of
+// the chosen language, that start with the text typed at the move prompt.
+// There are none while the prompt is empty, while a note is being typed, or
+// while the bot is to move in a Player vs Bot game.
+func (m Model) moveCompletions() []string {
+	if m.input == "" || m.game == nil || m.typingNote() {
+		return nil
+	}
+	if m.gameType == GameTypePvBot && m.game.Board().ActiveColor != m.userColor {
+		return nil
+	}
+
+	var completions []string
+	for _, move := range m.game.Board().LegalMoves() {
+		san := LocalizePieces(engine.FormatSAN(m.game.Board(), move), m.config.Language)
+		if strings.HasPrefix(san, m.input) && san != m.input {
+			completions = append(completions, san)
+		}
+	}
+	return completions
+}
+
+// moveCompletion returns the highlighted move completion, if there is one.
+func (m Model) moveCompletion() (string, bool) {
+	completions := m.moveCompletions()
+	if len(completions) == 0 {
+		return "", false
+	}
+	return completions[m.completionIndex%len(completions)], true
+}
+
+// cycleCompletion moves the highlight through the move completions by delta,
+// wrapping around at either end.
+func (m Model) cycleCompletion(delta int) Model {
+	n := len(m.moveCompletions())
+	if n == 0 {
+		return m
+	}
+	m.completionIndex = ((m.completionIndex+delta)%n + n) % n
+	return m
+}
+
+// acceptCompletion replaces the typed prefix with the highlighted completion.
+func (m Model) acceptCompletion() Model {
+	if san, ok := m.moveCompletion(); ok {
+		m.input = san
+		m.completionIndex = 0
+	}
+	return m
+}
+
+// renderMoveCompletions renders the completions of the typed move on one
+// line, with the highlighted one picked out, or "" when there are none.
+func (m Model) renderMoveCompletions() string {
+	completions := m.moveCompletions()
+	if len(completions) == 0 {
+		return ""
+	}
+	selected := m.completionIndex % len(completions)
+
+	// Keep the highlighted completion in view when there are too many to list
+	start := 0
+	if selected >= maxShownCompletions {
+		start = selected - maxShownCompletions + 1
+	}
+	end := min(start+maxShownCompletions, len(completions))
+
+	normal := lipgloss.NewStyle().Foreground(m.theme.MenuNormal)
+	highlight := lipgloss.NewStyle().Foreground(m.theme.MenuSelected).Bold(true)
+	parts := make([]string, 0, end-start+1)
+	for i := start; i < end; i++ {
+		if i == selected {
+			parts = append(parts, highlight.Render("["+completions[i]+"]"))
+		} else {
+			parts = append(parts, normal.Render(completions[i]))
+		}
+	}
+	if more := len(completions) - (end - start); more > 0 {
+		parts = append(parts, normal.Render(fmt.Sprintf("+%d more", more)))
+	}
+	return strings.Join(parts, " ")
+}
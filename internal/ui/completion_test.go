@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestMoveCompletions(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.game = engine.NewGame()
+	m.screen = ScreenGamePlay
+
+	if got := m.moveCompletions(); got != nil {
+		t.Errorf("expected no completions for an empty prompt, got %v", got)
+	}
+
+	m.input = "N"
+	got := m.moveCompletions()
+	slices.Sort(got)
+	if want := []string{"Na3", "Nc3", "Nf3", "Nh3"}; !slices.Equal(got, want) {
+		t.Errorf("completions of N = %v, want %v", got, want)
+	}
+
+	// A complete move needs no completing
+	m.input = "e4"
+	if got := m.moveCompletions(); len(got) != 0 {
+		t.Errorf("expected no completions of a complete move, got %v", got)
+	}
+
+	// Nothing is offered while the bot is to move
+	m.input = "N"
+	m.gameType = GameTypePvBot
+	m.userColor = engine.Black
+	if got := m.moveCompletions(); got != nil {
+		t.Errorf("expected no completions on the bot's turn, got %v", got)
+	}
+}
+
+func TestMoveCompletions_TabAndArrows(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.game = engine.NewGame()
+	m.screen = ScreenGamePlay
+
+	press := func(msg tea.KeyMsg) {
+		result, _ := m.Update(msg)
+		m = result.(Model)
+	}
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'N'}})
+
+	completions := m.moveCompletions()
+	if len(completions) != 4 {
+		t.Fatalf("expected 4 completions, got %v", completions)
+	}
+
+	// Left wraps around to the last completion
+	press(tea.KeyMsg{Type: tea.KeyLeft})
+	press(tea.KeyMsg{Type: tea.KeyRight})
+	press(tea.KeyMsg{Type: tea.KeyRight})
+	if !strings.Contains(m.View(), "["+completions[1]+"]") {
+		t.Errorf("expected %s to be highlighted in the view", completions[1])
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyTab})
+	if m.input != completions[1] {
+		t.Errorf("expected Tab to complete the input to %s, got %q", completions[1], m.input)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.game.Board().ActiveColor != engine.Black {
+		t.Errorf("expected the completed move to be played, error %q", toastError(m))
+	}
+}
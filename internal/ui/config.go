@@ -25,16 +25,16 @@ type GameConfig = config.GameConfig
 var DefaultConfig = config.DefaultConfig
 
 // GetConfigPath returns the absolute path to the configuration file.
-// The config file is stored at ~/.termchess/config.toml
+// The config file is stored as config.toml in the config directory
 var GetConfigPath = config.GetConfigPath
 
-// LoadConfig reads the configuration file from ~/.termchess/config.toml.
+// LoadConfig reads the configuration file, config.toml in the config directory.
 // If the file doesn't exist or cannot be parsed, it returns the default configuration.
 var LoadConfig = config.LoadConfig
 
-// LoadGameConfig reads the game configuration from ~/.termchess/config.toml.
+// LoadGameConfig reads the game configuration from config.toml in the config directory.
 // If the file doesn't exist or cannot be parsed, it returns the default game configuration.
 var LoadGameConfig = config.LoadGameConfig
 
-// SaveConfig writes the configuration to ~/.termchess/config.toml.
+// SaveConfig writes the configuration to config.toml in the config directory.
 var SaveConfig = config.SaveConfig
@@ -0,0 +1,496 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/config"
+	"github.com/Mgrdich/TermChess/internal/engine"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Fields of the new correspondence game form, in display order.
+const (
+	correspondenceFieldOpponent = iota
+	correspondenceFieldColor
+	correspondenceFieldDays
+	correspondenceFieldCount
+)
+
+// playerName returns the name identifying the user in correspondence games.
+func (m Model) playerName() string {
+	if m.config.PlayerName != "" {
+		return m.config.PlayerName
+	}
+	return config.DefaultPlayerName()
+}
+
+// openCorrespondenceList navigates to the My Games screen.
+func (m *Model) openCorrespondenceList() {
+	m.pushScreen(ScreenCorrespondenceList)
+	m.correspondenceSelection = 0
+	m.dismissToasts()
+	m.loadCorrespondenceGames()
+}
+
+// loadCorrespondenceGames reads the user's games from the mailbox: games awaiting
+// the user's move first, then games awaiting the opponent, then finished games.
+func (m *Model) loadCorrespondenceGames() {
+	m.correspondenceGames = nil
+	dir, err := config.MailboxDir(m.config)
+	if err != nil {
+		m.notify(SeverityError, fmt.Sprintf("Failed to find the mailbox: %v", err))
+		return
+	}
+	games, err := config.LoadCorrespondenceGames(dir)
+	if err != nil {
+		m.notify(SeverityError, err.Error())
+		return
+	}
+
+	me := m.playerName()
+	rank := func(g *config.CorrespondenceGame) int {
+		switch {
+		case g.PlayerToMove() == me:
+			return 0
+		case g.Result == "":
+			return 1
+		default:
+			return 2
+		}
+	}
+	for _, g := range games {
+		if g.White == me || g.Black == me {
+			m.correspondenceGames = append(m.correspondenceGames, g)
+		}
+	}
+	sort.SliceStable(m.correspondenceGames, func(i, j int) bool {
+		return rank(m.correspondenceGames[i]) < rank(m.correspondenceGames[j])
+	})
+
+	if m.correspondenceSelection >= len(m.correspondenceGames) {
+		m.correspondenceSelection = 0
+	}
+}
+
+// handleCorrespondenceListKeys handles keyboard input on the My Games screen.
+func (m Model) handleCorrespondenceListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.dismissToasts()
+
+	switch {
+	case m.keys.Matches(msg, ActionUp):
+		if m.correspondenceSelection > 0 {
+			m.correspondenceSelection--
+		} else if len(m.correspondenceGames) > 0 {
+			m.correspondenceSelection = len(m.correspondenceGames) - 1
+		}
+
+	case m.keys.Matches(msg, ActionDown):
+		if m.correspondenceSelection < len(m.correspondenceGames)-1 {
+			m.correspondenceSelection++
+		} else {
+			m.correspondenceSelection = 0
+		}
+
+	case m.keys.Matches(msg, ActionSelect):
+		if len(m.correspondenceGames) == 0 {
+			m.notify(SeverityError, "No correspondence games yet - press n to start one")
+			return m, nil
+		}
+		return m.openCorrespondenceGame(m.correspondenceGames[m.correspondenceSelection])
+
+	case m.keys.Matches(msg, ActionNewGame):
+		// Starts a correspondence game rather than going to game type selection
+		m.openCorrespondenceNew()
+
+	case m.keys.Matches(msg, ActionRefresh):
+		// Pick up moves the opponents made since the list was loaded
+		m.loadCorrespondenceGames()
+		m.notify(SeverityInfo, "Mailbox refreshed")
+
+	case m.keys.Matches(msg, ActionBack):
+		m.popScreen()
+	}
+
+	return m, nil
+}
+
+// openCorrespondenceGame starts playing game from its current position.
+func (m Model) openCorrespondenceGame(game *config.CorrespondenceGame) (tea.Model, tea.Cmd) {
+	played, err := game.Game()
+	if err != nil {
+		m.notify(SeverityError, fmt.Sprintf("Failed to load game: %v", err))
+		return m, nil
+	}
+
+	m.game = played
+	m.beginGameRecord()
+
+	m.gameType = GameTypeCorrespondence
+	m.correspondence = game
+	m.userColor = m.correspondenceColorOf(m.playerName())
+	m.clearNavStack()
+	m.screen = ScreenGamePlay
+	m.input = ""
+	m.dismissToasts()
+	m.drawOfferedBy = -1
+	m.drawOfferedByWhite = false
+	m.drawOfferedByBlack = false
+	if game.Result != "" {
+		m.notify(SeverityInfo, fmt.Sprintf("This game is over (%s)", game.Result))
+	}
+	return m, nil
+}
+
+// correspondenceColorOf returns the color player has in the current correspondence
+// game. A player on both sides is treated as playing the side to move.
+func (m Model) correspondenceColorOf(player string) engine.Color {
+	game := m.correspondence
+	switch {
+	case game.White == player && game.Black != player:
+		return engine.White
+	case game.Black == player && game.White != player:
+		return engine.Black
+	default:
+		return m.game.Board().ActiveColor
+	}
+}
+
+// leaveCorrespondenceGame returns from a correspondence game to the My Games screen.
+// There is nothing to save: every move is written to the mailbox as it is played.
+func (m Model) leaveCorrespondenceGame() (tea.Model, tea.Cmd) {
+	m.correspondence = nil
+	m.game = nil
+	m.gameType = GameTypePvP
+	m.input = ""
+	m.navStack = []Screen{ScreenMainMenu}
+	m.screen = ScreenCorrespondenceList
+	m.dismissToasts()
+	m.loadCorrespondenceGames()
+	return m, nil
+}
+
+// saveCorrespondenceGame writes game to the mailbox.
+func (m Model) saveCorrespondenceGame(game *config.CorrespondenceGame) error {
+	dir, err := config.MailboxDir(m.config)
+	if err != nil {
+		return err
+	}
+	return config.SaveCorrespondenceGame(dir, game)
+}
+
+// playCorrespondenceMove plays the user's move in the current correspondence game
+// and sends it to the opponent by writing the game to the mailbox.
+func (m Model) playCorrespondenceMove(move engine.Move) (tea.Model, tea.Cmd) {
+	game := m.correspondence
+	if game.Result != "" {
+		m.notify(SeverityError, fmt.Sprintf("This game is over (%s)", game.Result))
+		return m, nil
+	}
+	if toMove := game.PlayerToMove(); toMove != m.playerName() {
+		m.notify(SeverityError, fmt.Sprintf("Waiting for %s to move", toMove))
+		return m, nil
+	}
+
+	// Play on a copy so the game is unchanged if the mailbox cannot be written
+	updated := *game
+	updated.Moves = append([]string(nil), game.Moves...)
+	if err := updated.Play(move, time.Now()); err != nil {
+		m.notify(SeverityError, err.Error())
+		return m, nil
+	}
+	if err := m.saveCorrespondenceGame(&updated); err != nil {
+		m.notify(SeverityError, fmt.Sprintf("Failed to send move: %v", err))
+		return m, nil
+	}
+	m.correspondence = &updated
+
+	// The move was validated by Play, so it applies cleanly to the displayed game
+	_ = m.game.MakeMove(move)
+	m.input = ""
+	m.dismissToasts(SeverityError)
+	m.selectedSquare = nil
+	m.validMoves = nil
+	m.blinkOn = false
+
+	if m.game.Board().IsGameOver() {
+		m.showGameOver()
+		return m, nil
+	}
+	m.notify(SeverityInfo, fmt.Sprintf("Move sent - waiting for %s", updated.PlayerToMove()))
+	return m, nil
+}
+
+// resignCorrespondenceGame records the user's resignation in the mailbox.
+func (m Model) resignCorrespondenceGame() (tea.Model, tea.Cmd) {
+	m.input = ""
+	game := m.correspondence
+	if game.Result != "" {
+		m.notify(SeverityError, fmt.Sprintf("This game is over (%s)", game.Result))
+		return m, nil
+	}
+
+	color := m.correspondenceColorOf(m.playerName())
+	updated := *game
+	updated.Result = "0-1"
+	if color == engine.Black {
+		updated.Result = "1-0"
+	}
+	if err := m.saveCorrespondenceGame(&updated); err != nil {
+		m.notify(SeverityError, fmt.Sprintf("Failed to send resignation: %v", err))
+		return m, nil
+	}
+	m.correspondence = &updated
+
+	m.game.Resign(color)
+	m.showGameOver()
+	m.dismissToasts()
+	return m, nil
+}
+
+// openCorrespondenceNew navigates to the new correspondence game form.
+func (m *Model) openCorrespondenceNew() {
+	ti := textinput.New()
+	ti.Placeholder = "Opponent name"
+	ti.CharLimit = 40
+	ti.Width = 40
+	ti.Focus()
+
+	m.pushScreen(ScreenCorrespondenceNew)
+	m.correspondenceOpponent = ti
+	m.correspondenceField = correspondenceFieldOpponent
+	m.correspondenceColor = engine.White
+	m.correspondenceDays = 0
+	m.dismissToasts()
+}
+
+// handleCorrespondenceNewKeys handles keyboard input on the new correspondence game form.
+// Up/down and tab move between fields, left/right or space change the color and time
+// control, and enter creates the game.
+func (m Model) handleCorrespondenceNewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.popScreen()
+		return m, nil
+
+	case tea.KeyEnter:
+		return m.createCorrespondenceGame()
+
+	case tea.KeyUp, tea.KeyShiftTab:
+		m.correspondenceField = (m.correspondenceField + correspondenceFieldCount - 1) % correspondenceFieldCount
+
+	case tea.KeyDown, tea.KeyTab:
+		m.correspondenceField = (m.correspondenceField + 1) % correspondenceFieldCount
+
+	default:
+		switch m.correspondenceField {
+		case correspondenceFieldOpponent:
+			m.correspondenceOpponent, cmd = m.correspondenceOpponent.Update(msg)
+		case correspondenceFieldColor:
+			if msg.Type == tea.KeyLeft || msg.Type == tea.KeyRight || msg.Type == tea.KeySpace {
+				m.correspondenceColor = opponentColor(m.correspondenceColor)
+			}
+		case correspondenceFieldDays:
+			n := len(config.CorrespondenceDays)
+			if msg.Type == tea.KeyLeft {
+				m.correspondenceDays = (m.correspondenceDays + n - 1) % n
+			} else if msg.Type == tea.KeyRight || msg.Type == tea.KeySpace {
+				m.correspondenceDays = (m.correspondenceDays + 1) % n
+			}
+		}
+	}
+
+	if m.correspondenceField == correspondenceFieldOpponent {
+		m.correspondenceOpponent.Focus()
+	} else {
+		m.correspondenceOpponent.Blur()
+	}
+	m.dismissToasts(SeverityError)
+	return m, cmd
+}
+
+// createCorrespondenceGame writes a new game from the form to the mailbox and opens it.
+func (m Model) createCorrespondenceGame() (tea.Model, tea.Cmd) {
+	opponent := strings.TrimSpace(m.correspondenceOpponent.Value())
+	me := m.playerName()
+	if opponent == "" {
+		m.notify(SeverityError, "Enter your opponent's name")
+		return m, nil
+	}
+
+	white, black := me, opponent
+	if m.correspondenceColor == engine.Black {
+		white, black = opponent, me
+	}
+	game := config.NewCorrespondenceGame(white, black, config.CorrespondenceDays[m.correspondenceDays], time.Now())
+	if err := m.saveCorrespondenceGame(game); err != nil {
+		m.notify(SeverityError, fmt.Sprintf("Failed to create game: %v", err))
+		return m, nil
+	}
+	return m.openCorrespondenceGame(game)
+}
+
+// opponentColor returns the other color.
+func opponentColor(c engine.Color) engine.Color {
+	if c == engine.White {
+		return engine.Black
+	}
+	return engine.White
+}
+
+// correspondenceDaysLabel describes a days-per-move time control.
+func correspondenceDaysLabel(days int) string {
+	switch days {
+	case 0:
+		return "No limit"
+	case 1:
+		return "1 day per move"
+	default:
+		return fmt.Sprintf("%d days per move", days)
+	}
+}
+
+// formatTimeLeft describes the time remaining until a deadline, e.g. "2d 4h left".
+func formatTimeLeft(left time.Duration) string {
+	if left <= 0 {
+		return "overdue"
+	}
+	days := int(left / (24 * time.Hour))
+	hours := int(left/time.Hour) % 24
+	if days > 0 {
+		return fmt.Sprintf("%dd %dh left", days, hours)
+	}
+	return fmt.Sprintf("%dh %dm left", hours, int(left/time.Minute)%60)
+}
+
+// describeCorrespondenceGame summarizes game from the user's point of view,
+// e.g. "vs bob (White) - move 12 - your move - 2d 4h left".
+func (m Model) describeCorrespondenceGame(game *config.CorrespondenceGame) string {
+	me := m.playerName()
+	side := "White"
+	if game.Black == me && game.White != me {
+		side = "Black"
+	}
+	parts := []string{
+		fmt.Sprintf("vs %s (%s)", game.Opponent(me), side),
+		fmt.Sprintf("move %d", len(game.Moves)/2+1),
+	}
+
+	switch toMove := game.PlayerToMove(); {
+	case game.Result != "":
+		parts = append(parts, "finished "+game.Result)
+	case toMove == me:
+		parts = append(parts, "your move")
+	default:
+		parts = append(parts, "waiting for "+toMove)
+	}
+	if deadline, ok := game.Deadline(); ok {
+		parts = append(parts, formatTimeLeft(time.Until(deadline)))
+	}
+	return strings.Join(parts, " - ")
+}
+
+// renderCorrespondenceList renders the My Games screen.
+func (m Model) renderCorrespondenceList() string {
+	var b strings.Builder
+
+	// Render the application title
+	title := m.titleStyle().Render("TermChess")
+	b.WriteString(title)
+	b.WriteString("\n")
+
+	// Render breadcrumb navigation
+	b.WriteString(m.renderBreadcrumb())
+
+	// Render screen header
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(m.theme.TitleText).
+		Padding(0, 0, 1, 0)
+	b.WriteString(headerStyle.Render(fmt.Sprintf("My Games (%s)", m.playerName())))
+	b.WriteString("\n")
+
+	if len(m.correspondenceGames) == 0 {
+		b.WriteString(m.menuPrimaryStyle().Render("No correspondence games yet"))
+		b.WriteString("\n")
+	}
+	for i, game := range m.correspondenceGames {
+		cursor := "  "
+		text := m.menuPrimaryStyle().Render(m.describeCorrespondenceGame(game))
+		if i == m.correspondenceSelection {
+			cursor = m.cursorStyle().Render(">> ")
+			text = m.selectedPrimaryStyle().Render(m.describeCorrespondenceGame(game))
+		}
+		b.WriteString(fmt.Sprintf("%s%s\n", cursor, text))
+	}
+
+	// Render help text
+	helpText := m.renderHelpText("ESC: back | arrows/jk: navigate | enter: open game | n: new game | r: refresh mailbox")
+	if helpText != "" {
+		b.WriteString("\n")
+		b.WriteString(helpText)
+	}
+
+	return b.String()
+}
+
+// renderCorrespondenceNew renders the new correspondence game form.
+func (m Model) renderCorrespondenceNew() string {
+	var b strings.Builder
+
+	// Render the application title
+	title := m.titleStyle().Render("TermChess")
+	b.WriteString(title)
+	b.WriteString("\n")
+
+	// Render breadcrumb navigation
+	b.WriteString(m.renderBreadcrumb())
+
+	// Render screen header
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(m.theme.TitleText).
+		Padding(0, 0, 1, 0)
+	b.WriteString(headerStyle.Render("New Correspondence Game"))
+	b.WriteString("\n")
+
+	color := "White"
+	if m.correspondenceColor == engine.Black {
+		color = "Black"
+	}
+	fields := []string{
+		"Opponent: " + m.correspondenceOpponent.View(),
+		"Play as: < " + color + " >",
+		"Time control: < " + correspondenceDaysLabel(config.CorrespondenceDays[m.correspondenceDays]) + " >",
+	}
+	for i, field := range fields {
+		cursor := "  "
+		text := m.menuPrimaryStyle().Render(field)
+		if i == m.correspondenceField {
+			cursor = m.cursorStyle().Render(">> ")
+			text = m.selectedPrimaryStyle().Render(field)
+		}
+		b.WriteString(fmt.Sprintf("%s%s\n", cursor, text))
+	}
+
+	// Render help text
+	helpText := m.renderHelpText("ESC: back | up/down: change field | left/right: change option | enter: create game")
+	if helpText != "" {
+		b.WriteString("\n")
+		b.WriteString(helpText)
+	}
+
+	return b.String()
+}
+
+// renderCorrespondenceInfo renders the opponent, turn and deadline of the current
+// correspondence game for the gameplay screen.
+func (m Model) renderCorrespondenceInfo() string {
+	return m.statusStyle().UnsetPadding().Render("Correspondence " + m.describeCorrespondenceGame(m.correspondence))
+}
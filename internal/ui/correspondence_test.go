@@ -0,0 +1,192 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/config"
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// correspondenceModel returns a model playing as alice with a temporary mailbox.
+func correspondenceModel(t *testing.T) Model {
+	t.Helper()
+	cfg := DefaultConfig()
+	cfg.PlayerName = "alice"
+	cfg.MailboxDir = t.TempDir()
+	return NewModel(cfg)
+}
+
+// loadMailbox reads every game in the model's mailbox.
+func loadMailbox(t *testing.T, m Model) []*config.CorrespondenceGame {
+	t.Helper()
+	games, err := config.LoadCorrespondenceGames(m.config.MailboxDir)
+	if err != nil {
+		t.Fatalf("LoadCorrespondenceGames error = %v", err)
+	}
+	return games
+}
+
+func TestCorrespondenceNewGameFlow(t *testing.T) {
+	m := correspondenceModel(t)
+	m.screen = ScreenGameTypeSelect
+	m.menuOptions = gameTypeOptions()
+	m.menuSelection = 3
+
+	result, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if m.screen != ScreenCorrespondenceList {
+		t.Fatalf("Expected ScreenCorrespondenceList, got %v", m.screen)
+	}
+	if !strings.Contains(m.View(), "No correspondence games yet") {
+		t.Error("Expected an empty My Games screen")
+	}
+
+	// Open the form and create a game against bob with three days per move
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = result.(Model)
+	if m.screen != ScreenCorrespondenceNew {
+		t.Fatalf("Expected ScreenCorrespondenceNew, got %v", m.screen)
+	}
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if toastError(m) == "" {
+		t.Error("Expected an error without an opponent name")
+	}
+	m = pressKeys(m, "bob")
+	for _, key := range []tea.KeyType{tea.KeyDown, tea.KeyDown, tea.KeyRight, tea.KeyRight} {
+		result, _ = m.handleKeyPress(tea.KeyMsg{Type: key})
+		m = result.(Model)
+	}
+	if !strings.Contains(m.View(), "3 days per move") {
+		t.Error("Expected the form to show the chosen time control")
+	}
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+
+	if m.screen != ScreenGamePlay || m.gameType != GameTypeCorrespondence {
+		t.Fatalf("Expected a correspondence game, got screen %v type %v", m.screen, m.gameType)
+	}
+	games := loadMailbox(t, m)
+	if len(games) != 1 || games[0].White != "alice" || games[0].Black != "bob" || games[0].DaysPerMove != 3 {
+		t.Fatalf("Expected alice vs bob with 3 days per move in the mailbox, got %+v", games)
+	}
+	if !strings.Contains(m.View(), "Correspondence vs bob (White)") {
+		t.Error("Expected the gameplay screen to show the opponent")
+	}
+}
+
+func TestCorrespondenceMoveIsSent(t *testing.T) {
+	m := correspondenceModel(t)
+	game := config.NewCorrespondenceGame("alice", "bob", 0, time.Now())
+	if err := config.SaveCorrespondenceGame(m.config.MailboxDir, game); err != nil {
+		t.Fatalf("SaveCorrespondenceGame error = %v", err)
+	}
+	result, _ := m.openCorrespondenceGame(game)
+	m = result.(Model)
+
+	m.input = "e4"
+	result, _ = m.handleGamePlayInput()
+	m = result.(Model)
+	if toastError(m) != "" || toastStatus(m) != "Move sent - waiting for bob" {
+		t.Fatalf("Expected the move to be sent, got error %q status %q", toastError(m), toastStatus(m))
+	}
+	if got := loadMailbox(t, m)[0].Moves; len(got) != 1 || got[0] != "e2e4" {
+		t.Errorf("Expected e2e4 in the mailbox, got %v", got)
+	}
+
+	// It is bob's turn now
+	m.input = "e5"
+	result, _ = m.handleGamePlayInput()
+	m = result.(Model)
+	if toastError(m) != "Waiting for bob to move" {
+		t.Errorf("Expected to wait for bob, got %q", toastError(m))
+	}
+	if m.game.Board().ActiveColor != engine.Black || m.game.MoveCount() != 1 {
+		t.Error("Expected the board to be unchanged")
+	}
+}
+
+func TestCorrespondenceMyGamesOrder(t *testing.T) {
+	m := correspondenceModel(t)
+	dir := m.config.MailboxDir
+	start := time.Now().Add(-time.Hour)
+
+	waiting := config.NewCorrespondenceGame("alice", "bob", 0, start)
+	_ = waiting.Play(engine.Move{From: engine.NewSquare(4, 1), To: engine.NewSquare(4, 3)}, start)
+	mine := config.NewCorrespondenceGame("carol", "alice", 7, start.Add(time.Minute))
+	_ = mine.Play(engine.Move{From: engine.NewSquare(3, 1), To: engine.NewSquare(3, 3)}, time.Now())
+	others := config.NewCorrespondenceGame("carol", "dave", 0, start.Add(2*time.Minute))
+	for _, g := range []*config.CorrespondenceGame{waiting, mine, others} {
+		if err := config.SaveCorrespondenceGame(dir, g); err != nil {
+			t.Fatalf("SaveCorrespondenceGame error = %v", err)
+		}
+	}
+
+	m.screen = ScreenGameTypeSelect
+	m.openCorrespondenceList()
+	if len(m.correspondenceGames) != 2 {
+		t.Fatalf("Expected only alice's 2 games, got %d", len(m.correspondenceGames))
+	}
+	if m.correspondenceGames[0].ID != mine.ID {
+		t.Error("Expected the game awaiting alice's move to be listed first")
+	}
+	view := m.View()
+	if !strings.Contains(view, "vs carol (Black) - move 1 - your move - 6d 23h left") {
+		t.Errorf("Expected the game awaiting alice's move with its deadline, got:\n%s", view)
+	}
+	if !strings.Contains(view, "vs bob (White) - move 1 - waiting for bob") {
+		t.Errorf("Expected the game awaiting bob, got:\n%s", view)
+	}
+
+	// Open the game, leave it and come back to the list
+	result, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if m.correspondence == nil || m.correspondence.ID != mine.ID || m.userColor != engine.Black {
+		t.Fatal("Expected to play Black in the game against carol")
+	}
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	if m.screen != ScreenCorrespondenceList || m.correspondence != nil {
+		t.Errorf("Expected ESC to return to My Games, got %v", m.screen)
+	}
+}
+
+func TestCorrespondenceResign(t *testing.T) {
+	m := correspondenceModel(t)
+	game := config.NewCorrespondenceGame("alice", "bob", 1, time.Now())
+	result, _ := m.openCorrespondenceGame(game)
+	m = result.(Model)
+
+	m.input = "offerdraw"
+	result, _ = m.handleGamePlayInput()
+	m = result.(Model)
+	if !strings.Contains(toastError(m), "not available in correspondence games") {
+		t.Errorf("Expected draw offers to be unavailable, got %q", toastError(m))
+	}
+
+	m.input = "resign"
+	result, _ = m.handleGamePlayInput()
+	m = result.(Model)
+	if by, ok := m.game.ResignedBy(); m.screen != ScreenGameOver || !ok || by != engine.White {
+		t.Fatalf("Expected alice to resign as White, got screen %v", m.screen)
+	}
+	if games := loadMailbox(t, m); len(games) != 1 || games[0].Result != "0-1" {
+		t.Errorf("Expected the resignation in the mailbox, got %+v", games)
+	}
+}
+
+func TestFormatTimeLeft(t *testing.T) {
+	tests := map[time.Duration]string{
+		-time.Minute:                 "overdue",
+		50*time.Hour + time.Hour:     "2d 3h left",
+		5*time.Hour + time.Minute*12: "5h 12m left",
+	}
+	for left, want := range tests {
+		if got := formatTimeLeft(left); got != want {
+			t.Errorf("formatTimeLeft(%v) = %q, want %q", left, got, want)
+		}
+	}
+}
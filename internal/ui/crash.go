@@ -0,0 +1,241 @@
+package ui
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/config"
+	"github.com/Mgrdich/TermChess/internal/engine"
+	"github.com/Mgrdich/TermChess/internal/version"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// crashLogSize is the number of recent messages kept for crash reports.
+const crashLogSize = 30
+
+// crashLog keeps what a crash report needs to know about the time before a
+// crash. It is shared by all copies of the model and, as commands run in their
+// own goroutines, locked.
+type crashLog struct {
+	mu sync.Mutex
+	// messages are the descriptions of the most recent messages, oldest first
+	messages []string
+	// report is the path of the crash report, once one was written
+	report string
+}
+
+// record adds a message to the log, dropping the oldest beyond crashLogSize.
+// Spinner frames are left out, as they would crowd out everything else.
+func (l *crashLog) record(msg tea.Msg) {
+	if l == nil {
+		return
+	}
+	if _, ok := msg.(spinner.TickMsg); ok {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.messages = append(l.messages, describeMsg(msg))
+	if len(l.messages) > crashLogSize {
+		l.messages = l.messages[len(l.messages)-crashLogSize:]
+	}
+}
+
+// describeMsg describes a message for a crash report: its type and, for input,
+// what was pressed or clicked.
+func describeMsg(msg tea.Msg) string {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return fmt.Sprintf("key %q", msg.String())
+	case tea.MouseMsg:
+		return fmt.Sprintf("mouse %s at %d,%d", msg.String(), msg.X, msg.Y)
+	case tea.WindowSizeMsg:
+		return fmt.Sprintf("window size %dx%d", msg.Width, msg.Height)
+	default:
+		return fmt.Sprintf("%T", msg)
+	}
+}
+
+// CrashReport returns the path of the crash report written when the program
+// running the model panicked, or "" if it didn't. The report is shared by all
+// copies of the model, so the model the program was started with tells it.
+func (m Model) CrashReport() string {
+	if m.crash == nil {
+		return ""
+	}
+	m.crash.mu.Lock()
+	defer m.crash.mu.Unlock()
+	return m.crash.report
+}
+
+// recoverCrash, deferred, catches a panic, writes a crash report and keeps the
+// game being played for the next launch, then panics again so Bubbletea
+// restores the terminal and ends the program.
+func (m Model) recoverCrash() {
+	r := recover()
+	if r == nil {
+		return
+	}
+	m.reportCrash(r, debug.Stack(), time.Now())
+	panic(r)
+}
+
+// guardCmd returns cmd wrapped to report a panic in it like recoverCrash does.
+// The commands of a batch are wrapped as well.
+func (m Model) guardCmd(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		defer m.recoverCrash()
+		msg := cmd()
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			for i := range batch {
+				batch[i] = m.guardCmd(batch[i])
+			}
+		}
+		return msg
+	}
+}
+
+// reportCrash writes the crash report of panic r with stack trace stack and,
+// if a game was being played, keeps it to be restored on the next launch. Only
+// the first crash is reported.
+func (m Model) reportCrash(r any, stack []byte, at time.Time) {
+	if m.crash == nil {
+		return
+	}
+	m.crash.mu.Lock()
+	defer m.crash.mu.Unlock()
+	if m.crash.report != "" {
+		return
+	}
+
+	fen := m.crashedGameFEN()
+	var b strings.Builder
+	fmt.Fprintf(&b, "TermChess crash report\n\n")
+	fmt.Fprintf(&b, "Version: %s (commit %s, built %s)\n", version.Version, version.GitCommit, version.BuildDate)
+	fmt.Fprintf(&b, "Platform: %s/%s, %s\n", runtime.GOOS, runtime.GOARCH, runtime.Version())
+	fmt.Fprintf(&b, "Time: %s\n", at.Format(time.RFC3339))
+	fmt.Fprintf(&b, "Panic: %v\n", r)
+	fmt.Fprintf(&b, "Screen: %s\n", screenName(m.screen))
+	if fen != "" {
+		fmt.Fprintf(&b, "FEN: %s\n", fen)
+	}
+	fmt.Fprintf(&b, "\nLast messages, oldest first:\n")
+	for _, msg := range m.crash.messages {
+		fmt.Fprintf(&b, "  %s\n", msg)
+	}
+	fmt.Fprintf(&b, "\nStack trace:\n%s", stack)
+
+	path, err := config.SaveCrashReport(b.String(), at)
+	if err != nil {
+		// Nowhere to write it, so it goes with the panic Bubbletea prints
+		path = "(not written: " + err.Error() + ")"
+	}
+	m.crash.report = path
+
+	if fen != "" {
+		_ = config.SaveCrashRecovery(config.CrashRecovery{
+			CrashedAt: at,
+			Report:    path,
+			FEN:       fen,
+			Game:      m.savedGameInfo(),
+		})
+	}
+}
+
+// crashedGameFEN returns the position of the game interrupted by a crash, or ""
+// if no game that could be restored was being played.
+func (m Model) crashedGameFEN() string {
+	if m.game == nil || m.inSimul() || m.game.Board().Status() != engine.Ongoing {
+		return ""
+	}
+	switch m.screen {
+	case ScreenGamePlay, ScreenSavePrompt, ScreenDrawPrompt, ScreenHandoff:
+		if m.gameType == GameTypePvP || m.gameType == GameTypePvBot {
+			return m.game.Board().ToFEN()
+		}
+	}
+	return ""
+}
+
+// WithCrashRecovery returns the model opening on a prompt offering to restore
+// the game interrupted by the crash described by recovery.
+func (m Model) WithCrashRecovery(recovery config.CrashRecovery) Model {
+	m.crashRecovery = &recovery
+	m.crashRecoverySelection = 0
+	m.screen = ScreenCrashRecovery
+	m.navStack = nil
+	return m
+}
+
+// crashRecoveryPrompt is the dialog offering to restore the game interrupted by a crash.
+func (m Model) crashRecoveryPrompt() modal {
+	body := fmt.Sprintf("TermChess crashed during a game on %s.\nRestore the game?",
+		m.crashRecovery.CrashedAt.Local().Format("Jan 2 at 15:04"))
+	if m.crashRecovery.Report != "" {
+		body += fmt.Sprintf("\n\nA crash report was written to\n%s", m.crashRecovery.Report)
+	}
+	return modal{
+		title: "Restore Game",
+		body:  body,
+		options: []modalOption{
+			{label: "Restore Game", key: "y"},
+			{label: "Discard", key: "n"},
+		},
+		help: "y: restore | n: discard | ESC: decide next time",
+	}
+}
+
+// handleCrashRecoveryKeys handles keyboard input for the crash recovery prompt.
+// Restoring or discarding the game forgets it; ESC goes to the main menu and
+// asks again on the next launch.
+func (m Model) handleCrashRecoveryKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.crashRecoveryPrompt().update(m.keys, msg, &m.crashRecoverySelection, nil) {
+	case modalChosen:
+		recovery := m.crashRecovery
+		m.crashRecovery = nil
+		if err := config.DeleteCrashRecovery(); err != nil {
+			m.notify(SeverityError, fmt.Sprintf("Failed to forget the crashed game: %v", err))
+		}
+		if m.crashRecoverySelection == 0 {
+			return m.restoreCrashedGame(*recovery)
+		}
+		m.screen = ScreenMainMenu
+		m.menuOptions = buildMainMenuOptions()
+		m.menuSelection = 0
+
+	case modalCancelled:
+		m.crashRecovery = nil
+		m.screen = ScreenMainMenu
+		m.menuOptions = buildMainMenuOptions()
+		m.menuSelection = 0
+	}
+	return m, nil
+}
+
+// restoreCrashedGame starts gameplay from the game interrupted by a crash.
+func (m Model) restoreCrashedGame(recovery config.CrashRecovery) (tea.Model, tea.Cmd) {
+	board, err := engine.FromFEN(recovery.FEN)
+	if err != nil {
+		m.notify(SeverityError, fmt.Sprintf("Failed to restore the game: %v", err))
+		m.screen = ScreenMainMenu
+		m.menuOptions = buildMainMenuOptions()
+		m.menuSelection = 0
+		return m, nil
+	}
+	m.restoreGameInfo(recovery.Game)
+	return m.resumeGame(board, "Game restored")
+}
+
+// renderCrashRecovery renders the prompt offering to restore the game interrupted by a crash.
+func (m Model) renderCrashRecovery() string {
+	background := func(m Model) string { return m.titleStyle().Render("TermChess") }
+	return m.renderModalOver(background, m.crashRecoveryPrompt(), m.crashRecoverySelection, "")
+}
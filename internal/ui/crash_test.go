@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/config"
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCrashReport(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	fen := "4k3/8/8/8/8/8/4P3/4K3 w - - 0 1"
+	m, err := NewModel(DefaultConfig()).StartGame(StartOptions{FEN: fen})
+	if err != nil {
+		t.Fatalf("StartGame() error = %v", err)
+	}
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	m = model.(Model)
+
+	// A command panicking in its goroutine is reported like a panic in Update
+	cmd := m.guardCmd(func() tea.Msg { panic("boom") })
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("Expected the panic to go on to Bubbletea, got %v", r)
+			}
+		}()
+		cmd()
+	}()
+
+	path := m.CrashReport()
+	report, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read the crash report %q: %v", path, err)
+	}
+	for _, want := range []string{"Panic: boom", "FEN: " + fen, `key "e"`, "Stack trace:"} {
+		if !strings.Contains(string(report), want) {
+			t.Errorf("Expected the crash report to contain %q, got:\n%s", want, report)
+		}
+	}
+
+	recovery, err := config.LoadCrashRecovery()
+	if err != nil || recovery == nil || recovery.FEN != fen || recovery.Report != path || recovery.Game.GameType != "pvp" {
+		t.Errorf("LoadCrashRecovery() = %+v, %v; want the interrupted game", recovery, err)
+	}
+}
+
+func TestCrashReportWithoutGame(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(DefaultConfig())
+	func() {
+		defer func() { _ = recover() }()
+		defer m.recoverCrash()
+		panic("boom")
+	}()
+
+	if m.CrashReport() == "" {
+		t.Error("Expected a crash report")
+	}
+	if recovery, err := config.LoadCrashRecovery(); err != nil || recovery != nil {
+		t.Errorf("LoadCrashRecovery() = %+v, %v; want nothing to restore", recovery, err)
+	}
+}
+
+// crashedModel returns a model offering to restore a Player vs Bot game
+// interrupted by a crash, also kept on disk.
+func crashedModel(t *testing.T) (Model, string) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	fen := "4k3/8/8/8/8/8/4P3/4K3 b - - 0 1"
+	recovery := config.CrashRecovery{
+		CrashedAt: time.Now(),
+		Report:    "/tmp/crash.txt",
+		FEN:       fen,
+		Game:      config.SavedGameInfo{GameType: "pvbot", UserColor: "black", BotDifficulty: "easy"},
+	}
+	if err := config.SaveCrashRecovery(recovery); err != nil {
+		t.Fatalf("SaveCrashRecovery failed: %v", err)
+	}
+	return NewModel(DefaultConfig()).WithCrashRecovery(recovery), fen
+}
+
+func TestCrashRecoveryRestore(t *testing.T) {
+	m, fen := crashedModel(t)
+	if view := m.View(); !strings.Contains(view, "TermChess crashed during a game") || !strings.Contains(view, "/tmp/crash.txt") {
+		t.Errorf("Expected the restore prompt, got:\n%s", view)
+	}
+
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m = model.(Model)
+	if m.screen != ScreenGamePlay || m.gameType != GameTypePvBot || m.userColor != engine.Black {
+		t.Errorf("Expected the Player vs Bot game as black, got screen %v type %v color %v", m.screen, m.gameType, m.userColor)
+	}
+	if got := m.game.Board().ToFEN(); got != fen {
+		t.Errorf("Restored FEN = %q, want %q", got, fen)
+	}
+	if recovery, _ := config.LoadCrashRecovery(); recovery != nil {
+		t.Error("Expected the restored game to be forgotten")
+	}
+}
+
+func TestCrashRecoveryDiscard(t *testing.T) {
+	m, _ := crashedModel(t)
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	m = model.(Model)
+	if m.screen != ScreenMainMenu || m.game != nil {
+		t.Errorf("Expected the main menu without a game, got screen %v", m.screen)
+	}
+	if recovery, _ := config.LoadCrashRecovery(); recovery != nil {
+		t.Error("Expected the discarded game to be forgotten")
+	}
+
+	// ESC decides next time
+	m, _ = crashedModel(t)
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = model.(Model)
+	if m.screen != ScreenMainMenu {
+		t.Errorf("Expected the main menu, got screen %v", m.screen)
+	}
+	if recovery, _ := config.LoadCrashRecovery(); recovery == nil {
+		t.Error("Expected the game to be kept for the next launch")
+	}
+}
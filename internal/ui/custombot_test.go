@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/bot"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// registerTestBot registers a custom bot for the duration of the test.
+func registerTestBot(t *testing.T, name string) {
+	t.Helper()
+	bot.Register(name, func() (bot.Engine, error) { return bot.NewRandomEngine() })
+	t.Cleanup(func() { bot.Unregister(name) })
+}
+
+func TestBotSelectListsCustomBots(t *testing.T) {
+	registerTestBot(t, "Test Bot")
+
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenGameTypeSelect
+	m.menuOptions = gameTypeOptions()
+	m.menuSelection = 1 // Player vs Bot
+	result, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+
+	if got := m.menuOptions; len(got) != 4 || got[3] != "Test Bot" {
+		t.Fatalf("Expected the custom bot after the built-in difficulties, got %v", got)
+	}
+
+	m.menuSelection = 3
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if m.botName != "Test Bot" {
+		t.Errorf("Expected to play the custom bot, got %q", m.botName)
+	}
+
+	// Picking a built-in difficulty afterwards forgets the custom bot
+	m.popScreen()
+	m.menuSelection = 2
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if m.botName != "" || m.botDifficulty != BotHard {
+		t.Errorf("Expected the built-in Hard bot, got %q / %v", m.botName, m.botDifficulty)
+	}
+}
+
+func TestBvBCustomBotMatchup(t *testing.T) {
+	registerTestBot(t, "Test Bot")
+
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenBvBBotSelect
+	m.menuOptions = botOptions()
+	m.bvbSelectingWhite = true
+
+	m.menuSelection = 3 // Test Bot as White
+	result, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if !strings.Contains(m.View(), "White: Test Bot") {
+		t.Error("Expected the custom bot to be shown as White")
+	}
+	m.menuSelection = 1 // Medium as Black
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+
+	if m.bvbWhiteName() != "Test Bot" || m.bvbBlackName() != "Medium Bot" {
+		t.Errorf("Expected Test Bot vs Medium Bot, got %s vs %s", m.bvbWhiteName(), m.bvbBlackName())
+	}
+	if !strings.Contains(m.View(), "Test Bot (White) vs Medium Bot (Black)") {
+		t.Errorf("Expected the matchup on the game mode screen, got:\n%s", m.View())
+	}
+}
+
+func TestBvBEnginePickerShowsBackends(t *testing.T) {
+	registerTestBot(t, "Test Bot")
+	if err := bot.RegisterUCI("Test Fish", "stockfish", nil); err != nil {
+		t.Fatalf("RegisterUCI() error = %v", err)
+	}
+	t.Cleanup(func() { bot.Unregister("Test Fish") })
+
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenBvBBotSelect
+	m.menuOptions = botOptions()
+	m.bvbSelectingWhite = true
+
+	view := m.View()
+	for _, want := range []string{"Select White Engine:", "Hard (built-in)", "Test Bot (custom)", "Test Fish (UCI)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected the engine picker to contain %q", want)
+		}
+	}
+}
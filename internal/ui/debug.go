@@ -0,0 +1,187 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// debugKey toggles the debug panel. It isn't a rebindable action, so it works
+// the same on every screen, text inputs included.
+const debugKey = "ctrl+g"
+
+// debugLogSize is the number of messages kept in the debug log.
+const debugLogSize = 200
+
+// debugTextLimit is the length debug log entries are cut to.
+const debugTextLimit = 200
+
+// debugPanelWidth is the width of the debug panel, border included.
+const debugPanelWidth = 48
+
+// debugEntry is a run of messages of the same type in the debug log.
+type debugEntry struct {
+	// at is when the last message of the run arrived.
+	at time.Time
+	// text describes the last message of the run.
+	text string
+	// kind is the type of the messages.
+	kind string
+	// count is the number of messages in the run.
+	count int
+}
+
+// debugLog is what the debug panel shows beyond the model itself: the messages
+// received since debug mode was turned on and how long frames take to render.
+// Update and View run on the same goroutine, so it needs no locking.
+type debugLog struct {
+	entries []debugEntry
+	// frame is how long the last frame took to render
+	frame time.Duration
+	// slowest is the longest a frame took to render
+	slowest time.Duration
+	// frames is the number of frames rendered
+	frames int
+}
+
+// record adds a message to the log. Messages of the same type in a row, such as
+// spinner frames, are counted on one entry.
+func (l *debugLog) record(msg tea.Msg, at time.Time) {
+	kind := fmt.Sprintf("%T", msg)
+	text := kind
+	if s := fmt.Sprintf("%+v", msg); s != "{}" && s != "" {
+		text += " " + strings.Join(strings.Fields(s), " ")
+	}
+	text = ansi.Truncate(text, debugTextLimit, "…")
+	if n := len(l.entries); n > 0 && l.entries[n-1].kind == kind {
+		last := &l.entries[n-1]
+		last.at, last.text = at, text
+		last.count++
+		return
+	}
+	l.entries = append(l.entries, debugEntry{at: at, text: text, kind: kind, count: 1})
+	if len(l.entries) > debugLogSize {
+		l.entries = l.entries[len(l.entries)-debugLogSize:]
+	}
+}
+
+// timeFrame records the render time of a frame that started at start. Meant to
+// be deferred.
+func (l *debugLog) timeFrame(start time.Time) {
+	l.frame = time.Since(start)
+	l.slowest = max(l.slowest, l.frame)
+	l.frames++
+}
+
+// WithDebug returns the model in debug mode, showing the debug panel.
+func (m Model) WithDebug() Model {
+	m.debug = &debugLog{}
+	m.showDebug = true
+	return m
+}
+
+// toggleDebug shows or hides the debug panel, turning debug mode on the first time.
+func (m *Model) toggleDebug() {
+	if m.debug == nil {
+		m.debug = &debugLog{}
+	}
+	m.showDebug = !m.showDebug
+}
+
+// withDebug renders the screen with render and, if the debug panel is shown,
+// docks the panel on its right. The screen is rendered narrower to make room.
+func (m Model) withDebug(render func(Model) string) string {
+	if !m.showDebug || m.debug == nil {
+		return render(m)
+	}
+	narrow, width := m, 0
+	if m.termWidth > 0 {
+		width = max(m.termWidth-debugPanelWidth, 1)
+		narrow.termWidth = width
+	}
+	screen := lipgloss.PlaceHorizontal(width, lipgloss.Left, render(narrow))
+	return lipgloss.JoinHorizontal(lipgloss.Top, screen, m.renderDebugPanel(max(lipgloss.Height(screen), m.termHeight)))
+}
+
+// renderDebugPanel renders the debug panel: the state of the model, the render
+// time of frames and as many of the latest messages as fit in height lines.
+func (m Model) renderDebugPanel(height int) string {
+	inner := debugPanelWidth - 4
+	dimStyle := lipgloss.NewStyle().Foreground(m.theme.HelpText)
+	line := func(label, value string) string {
+		return ansi.Truncate(dimStyle.Render(label+": ")+value, inner, "…")
+	}
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Foreground(m.theme.TitleText).Render("Debug"))
+	b.WriteString("\n")
+
+	stack := make([]string, 0, len(m.navStack)+1)
+	for _, s := range m.navStack {
+		stack = append(stack, screenName(s))
+	}
+	stack = append(stack, screenName(m.screen))
+	var overlays []string
+	for _, o := range []struct {
+		shown bool
+		name  string
+	}{
+		{m.showCommandPalette, "palette"},
+		{m.showShortcutsOverlay, "shortcuts"},
+		{m.showBvBLog, "session log"},
+		{m.mateReveal, "mate reveal"},
+	} {
+		if o.shown {
+			overlays = append(overlays, o.name)
+		}
+	}
+	if len(overlays) == 0 {
+		overlays = append(overlays, "none")
+	}
+
+	lines := []string{
+		line("Screen", fmt.Sprintf("%s (%d)", screenName(m.screen), m.screen)),
+		line("Nav stack", strings.Join(stack, " > ")),
+		line("Overlays", strings.Join(overlays, ", ")),
+		line("Text input", fmt.Sprintf("%t", m.isInTextInputMode())),
+		line("Terminal", fmt.Sprintf("%dx%d", m.termWidth, m.termHeight)),
+		line("Frame", fmt.Sprintf("%s (slowest %s, %d frames)",
+			m.debug.frame.Round(time.Microsecond), m.debug.slowest.Round(time.Microsecond), m.debug.frames)),
+	}
+	if m.game != nil {
+		lines = append(lines, line("FEN", m.game.Board().ToFEN()))
+	}
+	lines = append(lines, line("Toasts", fmt.Sprintf("%d", len(m.toasts))), "", dimStyle.Render("Messages, newest last:"))
+	for _, l := range lines {
+		b.WriteString(l)
+		b.WriteString("\n")
+	}
+
+	// The panel is as tall as the screen, with room for at least a few messages
+	shown := max(height-len(lines)-4, 5)
+	entries := m.debug.entries[max(len(m.debug.entries)-shown, 0):]
+	if len(entries) == 0 {
+		b.WriteString(dimStyle.Render("(none yet)"))
+	}
+	for i, e := range entries {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		text := e.text
+		if e.count > 1 {
+			text = fmt.Sprintf("×%d %s", e.count, text)
+		}
+		b.WriteString(ansi.Truncate(dimStyle.Render(e.at.Format("15:04:05.000"))+" "+text, inner, "…"))
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.theme.HelpText).
+		Padding(0, 1).
+		Width(debugPanelWidth - 2).
+		Render(b.String())
+}
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestDebugPanelToggle(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenFENInput
+	if view := m.View(); strings.Contains(view, "Nav stack:") {
+		t.Fatal("Expected no debug panel outside debug mode")
+	}
+
+	// The key works in text inputs too, and doesn't end up in them
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlG})
+	m = model.(Model)
+	if !m.showDebug || m.debug == nil {
+		t.Fatal("Expected ctrl+g to show the debug panel")
+	}
+	if m.fenInput.Value() != "" {
+		t.Errorf("Expected the FEN input to stay empty, got %q", m.fenInput.Value())
+	}
+
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlG})
+	m = model.(Model)
+	if m.showDebug {
+		t.Error("Expected ctrl+g to hide the debug panel again")
+	}
+}
+
+func TestDebugPanel(t *testing.T) {
+	m := NewModel(DefaultConfig()).WithDebug()
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	m = model.(Model)
+	m.pushScreen(ScreenSettings)
+	for range 3 {
+		model, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+		m = model.(Model)
+	}
+	m.View()
+
+	view := ansi.Strip(m.View())
+	for _, want := range []string{"Nav stack: Main Menu > Settings", "Terminal: 140x40", "1 frames", "×3 tea.KeyMsg down", "tea.WindowSizeMsg"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected the debug panel to show %q, got:\n%s", want, view)
+		}
+	}
+	for _, line := range strings.Split(view, "\n") {
+		if w := ansi.StringWidth(line); w > 140 {
+			t.Fatalf("Expected the view to fit the terminal, got a line %d wide", w)
+		}
+	}
+}
+
+func TestDebugLogSize(t *testing.T) {
+	var log debugLog
+	at := time.Now()
+	for i := range debugLogSize + 10 {
+		// Alternate types, so no two messages in a row are counted together
+		if i%2 == 0 {
+			log.record(tea.KeyMsg{Type: tea.KeyUp}, at)
+		} else {
+			log.record(tea.FocusMsg{}, at)
+		}
+	}
+	if len(log.entries) != debugLogSize {
+		t.Errorf("Expected %d entries, got %d", debugLogSize, len(log.entries))
+	}
+	if got := log.entries[len(log.entries)-1].text; got != "tea.FocusMsg" {
+		t.Errorf("Expected the latest message last, got %q", got)
+	}
+}
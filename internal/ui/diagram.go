@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+	"github.com/Mgrdich/TermChess/internal/util"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// diagramCommand copies a text diagram of the position. "diagram plain" leaves
+// out the coordinate border.
+const diagramCommand = "diagram"
+
+// parseDiagramCommand reports whether input is the diagram command and, if so,
+// whether the diagram gets a coordinate border.
+func parseDiagramCommand(input string) (coords, ok bool) {
+	switch input {
+	case diagramCommand:
+		return true, true
+	case diagramCommand + " plain":
+		return false, true
+	}
+	return false, false
+}
+
+// boardDiagram returns a monospace diagram of b from White's side, followed by
+// the side to move and the FEN, to paste into forums, issues or chat. Pieces
+// are letters (uppercase for White) or, with unicode, chess symbols. With
+// coords, the board is framed and labeled with its ranks and files.
+func boardDiagram(b *engine.Board, unicode, coords bool) string {
+	renderer := NewBoardRenderer(Config{UseUnicode: unicode})
+	border := struct{ top, bottom, side string }{
+		top:    "  +-----------------+",
+		bottom: "  +-----------------+",
+		side:   "|",
+	}
+	if unicode {
+		border.top = "  ┌─────────────────┐"
+		border.bottom = "  └─────────────────┘"
+		border.side = "│"
+	}
+
+	var s strings.Builder
+	if coords {
+		s.WriteString(border.top)
+		s.WriteString("\n")
+	}
+	for rank := 7; rank >= 0; rank-- {
+		squares := make([]string, 8)
+		for file := 0; file < 8; file++ {
+			squares[file] = renderer.pieceSymbol(b.PieceAt(engine.NewSquare(file, rank)))
+		}
+		row := strings.Join(squares, " ")
+		if coords {
+			row = fmt.Sprintf("%d %s %s %s", rank+1, border.side, row, border.side)
+		}
+		s.WriteString(row)
+		s.WriteString("\n")
+	}
+	if coords {
+		s.WriteString(border.bottom)
+		s.WriteString("\n    a b c d e f g h\n")
+	}
+
+	toMove := "White to move"
+	if b.ActiveColor == engine.Black {
+		toMove = "Black to move"
+	}
+	fmt.Fprintf(&s, "\n%s\nFEN: %s\n", toMove, b.ToFEN())
+	return s.String()
+}
+
+// handleDiagramCommand copies a text diagram of the current position to the
+// clipboard, in the piece style of the settings and, with coords, framed with
+// coordinates.
+func (m Model) handleDiagramCommand(coords bool) (tea.Model, tea.Cmd) {
+	m.input = ""
+	m.dismissToasts(SeverityError)
+	if m.game == nil {
+		return m, nil
+	}
+	diagram := boardDiagram(m.game.Board(), m.config.UseUnicode, coords)
+	if err := util.CopyToClipboard(diagram); err != nil {
+		m.notify(SeverityError, fmt.Sprintf("Failed to copy diagram to clipboard: %v", err))
+		return m, nil
+	}
+	m.notify(SeverityInfo, "Diagram copied to clipboard")
+	return m, nil
+}
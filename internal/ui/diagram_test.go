@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+func TestBoardDiagram(t *testing.T) {
+	board := engine.NewBoard()
+	move, _ := engine.ParseMove("e2e4")
+	if err := board.MakeMove(move); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `  +-----------------+
+8 | r n b q k b n r |
+7 | p p p p p p p p |
+6 | . . . . . . . . |
+5 | . . . . . . . . |
+4 | . . . . P . . . |
+3 | . . . . . . . . |
+2 | P P P P . P P P |
+1 | R N B Q K B N R |
+  +-----------------+
+    a b c d e f g h
+
+Black to move
+FEN: rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1
+`
+	if got := boardDiagram(board, false, true); got != want {
+		t.Errorf("boardDiagram() =\n%s\nwant\n%s", got, want)
+	}
+
+	plain := boardDiagram(board, true, false)
+	lines := strings.Split(plain, "\n")
+	if lines[0] != "♜ ♞ ♝ ♛ ♚ ♝ ♞ ♜" || lines[4] != "· · · · ♙ · · ·" {
+		t.Errorf("Expected a Unicode diagram without coordinates, got\n%s", plain)
+	}
+	if strings.ContainsAny(plain, "│┌+") {
+		t.Errorf("Expected no border, got\n%s", plain)
+	}
+
+	framed := boardDiagram(board, true, true)
+	if !strings.HasPrefix(framed, "  ┌─────────────────┐\n8 │ ♜ ♞ ♝ ♛ ♚ ♝ ♞ ♜ │\n") {
+		t.Errorf("Expected a box drawn border for Unicode pieces, got\n%s", framed)
+	}
+}
+
+func TestParseDiagramCommand(t *testing.T) {
+	tests := []struct {
+		input      string
+		coords, ok bool
+	}{
+		{"diagram", true, true},
+		{"diagram plain", false, true},
+		{"diagrams", false, false},
+		{"diagram fancy", false, false},
+	}
+	for _, tt := range tests {
+		coords, ok := parseDiagramCommand(tt.input)
+		if coords != tt.coords || ok != tt.ok {
+			t.Errorf("parseDiagramCommand(%q) = %v, %v, want %v, %v", tt.input, coords, ok, tt.coords, tt.ok)
+		}
+	}
+}
+
+func TestDiagramCommand(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := pressKeys(NewModel(DefaultConfig()), "n", "enter", "e4", "enter", "Diagram", "enter")
+
+	if m.input != "" || m.game.MoveCount() != 1 {
+		t.Errorf("Expected the command to be taken, not a move, got input %q and %d moves", m.input, m.game.MoveCount())
+	}
+	// Headless test runs have no clipboard, so either outcome is reported
+	if !strings.Contains(toastStatus(m), "Diagram copied") && !strings.Contains(toastError(m), "Failed to copy diagram") {
+		t.Errorf("Expected the diagram to be copied or the failure reported, got %q / %q", toastStatus(m), toastError(m))
+	}
+}
@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// fiftyMoveFEN is a position where the fifty-move rule can be claimed
+const fiftyMoveFEN = "4k3/8/8/8/8/8/8/R3K3 w - - 100 80"
+
+// TestClaimDrawFiftyMoveRule tests claiming a draw under the fifty-move rule
+func TestClaimDrawFiftyMoveRule(t *testing.T) {
+	board, err := engine.FromFEN(fiftyMoveFEN)
+	if err != nil {
+		t.Fatalf("FromFEN() error = %v", err)
+	}
+
+	m := NewModel(DefaultConfig())
+	m.game = engine.NewGameFrom(board)
+	m.screen = ScreenGamePlay
+
+	if !strings.Contains(m.View(), "claimdraw") {
+		t.Error("Expected gameplay view to announce the claimable draw")
+	}
+
+	m.input = "claimdraw"
+	newModel, _ := m.handleGamePlayInput()
+	m = newModel.(Model)
+
+	if m.screen != ScreenGameOver {
+		t.Fatalf("Expected screen to be ScreenGameOver, got %v", m.screen)
+	}
+	msg := getGameResultMessage(m.game)
+	if msg != "Draw by fifty-move rule" {
+		t.Errorf("Expected fifty-move result message, got %q", msg)
+	}
+}
+
+// TestClaimDrawThreefoldRepetition tests claiming a draw after a position repeats three times
+func TestClaimDrawThreefoldRepetition(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.game = engine.NewGame()
+	m.screen = ScreenGamePlay
+
+	// Shuffle the knights back and forth twice to repeat the starting position
+	for _, mv := range []string{"g1f3", "g8f6", "f3g1", "f6g8", "g1f3", "g8f6", "f3g1", "f6g8"} {
+		move, _ := engine.ParseMove(mv)
+		if err := m.game.MakeMove(move); err != nil {
+			t.Fatalf("MakeMove(%s) error = %v", mv, err)
+		}
+	}
+
+	if reason := claimableDrawReason(m.game.Board()); reason != "threefold repetition" {
+		t.Errorf("Expected threefold repetition to be claimable, got %q", reason)
+	}
+
+	m.input = "claimdraw"
+	newModel, _ := m.handleGamePlayInput()
+	m = newModel.(Model)
+
+	if m.screen != ScreenGameOver {
+		t.Errorf("Expected screen to be ScreenGameOver, got %v", m.screen)
+	}
+}
+
+// TestClaimDrawNotAvailable tests that claimdraw is rejected when no draw can be claimed
+func TestClaimDrawNotAvailable(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.game = engine.NewGame()
+	m.screen = ScreenGamePlay
+
+	if strings.Contains(m.View(), "claimdraw") {
+		t.Error("Expected no claimable draw notice in the starting position")
+	}
+
+	m.input = "claimdraw"
+	newModel, _ := m.handleGamePlayInput()
+	m = newModel.(Model)
+
+	if m.screen != ScreenGamePlay {
+		t.Errorf("Expected to stay on ScreenGamePlay, got %v", m.screen)
+	}
+	if toastError(m) != "No draw is available to claim" {
+		t.Errorf("Expected error message, got %q", toastError(m))
+	}
+}
+
+// TestBotClaimsDrawWhenNotAhead tests that a bot claims an available draw when it is behind
+func TestBotClaimsDrawWhenNotAhead(t *testing.T) {
+	// Black (bot) is down a rook with the fifty-move rule available
+	board, err := engine.FromFEN("4k3/8/8/8/8/8/8/R3K3 b - - 100 80")
+	if err != nil {
+		t.Fatalf("FromFEN() error = %v", err)
+	}
+
+	m := NewModel(DefaultConfig())
+	m.game = engine.NewGameFrom(board)
+	m.screen = ScreenGamePlay
+	m.gameType = GameTypePvBot
+	m.botDifficulty = BotMedium
+	m.userColor = engine.White
+
+	m, cmd := m.makeBotMove()
+	if m.screen != ScreenGameOver {
+		t.Errorf("Expected the bot to claim the draw, got screen %v", m.screen)
+	}
+	if cmd != nil {
+		t.Error("Expected no bot move command after claiming a draw")
+	}
+}
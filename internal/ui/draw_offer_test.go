@@ -10,7 +10,7 @@ import (
 // TestOfferDraw tests that a player can offer a draw
 func TestOfferDraw(t *testing.T) {
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenGamePlay
 
 	// White offers a draw
@@ -49,7 +49,7 @@ func TestOfferDrawCaseInsensitive(t *testing.T) {
 
 	for _, input := range testCases {
 		m := NewModel(DefaultConfig())
-		m.board = engine.NewBoard()
+		m.game = engine.NewGame()
 		m.screen = ScreenGamePlay
 
 		m.input = input
@@ -65,7 +65,7 @@ func TestOfferDrawCaseInsensitive(t *testing.T) {
 // TestAcceptDrawOffer tests that accepting a draw offer ends the game
 func TestAcceptDrawOffer(t *testing.T) {
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenGamePlay
 
 	// White offers a draw
@@ -90,15 +90,15 @@ func TestAcceptDrawOffer(t *testing.T) {
 	}
 
 	// Check that draw by agreement is set
-	if !m.drawByAgreement {
-		t.Error("Expected drawByAgreement to be true")
+	if !m.game.DrawnByAgreement() {
+		t.Error("Expected the game to be drawn by agreement")
 	}
 }
 
 // TestDeclineDrawOffer tests that declining a draw offer continues the game
 func TestDeclineDrawOffer(t *testing.T) {
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenGamePlay
 
 	// White offers a draw
@@ -123,13 +123,13 @@ func TestDeclineDrawOffer(t *testing.T) {
 	}
 
 	// Check that draw by agreement is not set
-	if m.drawByAgreement {
-		t.Error("Expected drawByAgreement to be false")
+	if m.game.DrawnByAgreement() {
+		t.Error("Expected the game not to be drawn by agreement")
 	}
 
 	// Check status message
-	if m.statusMsg != "Draw offer declined" {
-		t.Errorf("Expected status message 'Draw offer declined', got %s", m.statusMsg)
+	if toastStatus(m) != "Draw offer declined" {
+		t.Errorf("Expected status message 'Draw offer declined', got %s", toastStatus(m))
 	}
 
 	// Check that draw offered by is reset
@@ -141,7 +141,7 @@ func TestDeclineDrawOffer(t *testing.T) {
 // TestDrawOfferSpamPrevention tests that a player can't offer draw twice
 func TestDrawOfferSpamPrevention(t *testing.T) {
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenGamePlay
 
 	// White offers a draw
@@ -166,20 +166,20 @@ func TestDrawOfferSpamPrevention(t *testing.T) {
 	}
 
 	// Check that an error message was set
-	if m.errorMsg != "You have already offered a draw this game" {
-		t.Errorf("Expected error message about already offering, got %s", m.errorMsg)
+	if toastError(m) != "You have already offered a draw this game" {
+		t.Errorf("Expected error message about already offering, got %s", toastError(m))
 	}
 }
 
 // TestDrawOfferBlackCanOffer tests that Black can also offer a draw
 func TestDrawOfferBlackCanOffer(t *testing.T) {
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenGamePlay
 
 	// Make a move to switch to Black's turn
 	move, _ := engine.ParseMove("e2e4")
-	m.board.MakeMove(move)
+	m.game.MakeMove(move)
 
 	// Black offers a draw
 	m.input = "offerdraw"
@@ -204,7 +204,7 @@ func TestDrawOfferBlackCanOffer(t *testing.T) {
 // TestDrawOfferBothPlayersCanOfferOnce tests that both players can each offer once
 func TestDrawOfferBothPlayersCanOfferOnce(t *testing.T) {
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenGamePlay
 
 	// White offers a draw
@@ -220,7 +220,7 @@ func TestDrawOfferBothPlayersCanOfferOnce(t *testing.T) {
 
 	// Make a move to switch to Black's turn
 	move, _ := engine.ParseMove("e2e4")
-	m.board.MakeMove(move)
+	m.game.MakeMove(move)
 
 	// Black offers a draw (should work since Black hasn't offered yet)
 	m.input = "offerdraw"
@@ -241,7 +241,7 @@ func TestDrawOfferBothPlayersCanOfferOnce(t *testing.T) {
 // TestDrawOfferEscapeCancel tests that pressing ESC cancels the draw offer
 func TestDrawOfferEscapeCancel(t *testing.T) {
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenGamePlay
 
 	// White offers a draw
@@ -265,8 +265,8 @@ func TestDrawOfferEscapeCancel(t *testing.T) {
 	}
 
 	// Check status message
-	if m.statusMsg != "Draw offer cancelled" {
-		t.Errorf("Expected status message 'Draw offer cancelled', got %s", m.statusMsg)
+	if toastStatus(m) != "Draw offer cancelled" {
+		t.Errorf("Expected status message 'Draw offer cancelled', got %s", toastStatus(m))
 	}
 
 	// Check that draw offer state is fully reset
@@ -282,7 +282,7 @@ func TestDrawOfferEscapeCancel(t *testing.T) {
 // TestDrawPromptNavigationUpDown tests that arrow keys navigate the draw prompt
 func TestDrawPromptNavigationUpDown(t *testing.T) {
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenDrawPrompt
 	m.drawPromptSelection = 0
 
@@ -326,10 +326,10 @@ func TestDrawPromptNavigationUpDown(t *testing.T) {
 // TestDrawByAgreementMessage tests that the game over message shows "Draw by agreement"
 func TestDrawByAgreementMessage(t *testing.T) {
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
-	m.drawByAgreement = true
+	m.game = engine.NewGame()
+	m.game.AgreeDraw()
 
-	msg := getGameResultMessage(m.board, -1, true)
+	msg := getGameResultMessage(m.game)
 	expected := "Draw by agreement"
 
 	if msg != expected {
@@ -340,20 +340,20 @@ func TestDrawByAgreementMessage(t *testing.T) {
 // TestNewGameResetsDrawState tests that starting a new game resets draw offer state
 func TestNewGameResetsDrawState(t *testing.T) {
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenGamePlay
 
 	// Set draw offer state
 	m.drawOfferedBy = int8(engine.White)
 	m.drawOfferedByWhite = true
-	m.drawByAgreement = true
+	m.game.AgreeDraw()
 
 	// Start a new game via game type selection
 	m.screen = ScreenGameTypeSelect
 	m.menuOptions = []string{"Player vs Player", "Player vs Bot"}
 	m.menuSelection = 0
 
-	newModel, _ := m.handleGameTypeSelection()
+	newModel, _ := m.selectMenuItem()
 	m = newModel.(Model)
 
 	// Check that draw state is reset
@@ -369,7 +369,7 @@ func TestNewGameResetsDrawState(t *testing.T) {
 		t.Error("Expected drawOfferedByBlack to be false")
 	}
 
-	if m.drawByAgreement {
-		t.Error("Expected drawByAgreement to be false")
+	if m.game.DrawnByAgreement() {
+		t.Error("Expected the game not to be drawn by agreement")
 	}
 }
@@ -2,6 +2,8 @@ package ui
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -62,7 +64,7 @@ func TestView_AllScreens(t *testing.T) {
 		t.Run(string(rune(screen)), func(t *testing.T) {
 			m := NewModel(DefaultConfig())
 			m.screen = screen
-			m.board = engine.NewBoard()
+			m.game = engine.NewGame()
 
 			// Set up necessary state for each screen
 			switch screen {
@@ -155,7 +157,7 @@ func TestRenderGameOver(t *testing.T) {
 	}
 
 	m := NewModel(DefaultConfig())
-	m.board = board
+	m.game = engine.NewGameFrom(board)
 	m.screen = ScreenGameOver
 	m.menuOptions = []string{"New Game", "Main Menu", "Exit"}
 	m.menuSelection = 0
@@ -319,7 +321,7 @@ func TestHandleGameTypeSelectKeys(t *testing.T) {
 // TestHandleGameOverKeys tests game over screen key handling
 func TestHandleGameOverKeys(t *testing.T) {
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenGameOver
 
 	// Test 'n' key for new game
@@ -333,7 +335,7 @@ func TestHandleGameOverKeys(t *testing.T) {
 
 	// Test 'm' key for main menu
 	m.screen = ScreenGameOver
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}}
 	result, _ = m.handleGameOverKeys(msg)
 	m = result.(Model)
@@ -344,7 +346,7 @@ func TestHandleGameOverKeys(t *testing.T) {
 
 	// Test 'q' key for quit
 	m.screen = ScreenGameOver
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}}
 	_, cmd := m.handleGameOverKeys(msg)
 
@@ -359,7 +361,7 @@ func TestHandleSavePromptKeys(t *testing.T) {
 	m.screen = ScreenSavePrompt
 	m.savePromptSelection = 0
 	m.savePromptAction = "menu"
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 
 	// Move to "No" option
 	msg := tea.KeyMsg{Type: tea.KeyDown}
@@ -392,7 +394,7 @@ func TestHandleSavePromptKeys(t *testing.T) {
 
 	// Test direct 'n' key - should go to main menu without saving
 	m.screen = ScreenSavePrompt
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.savePromptAction = "menu"
 	msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}}
 	result, _ = m.handleSavePromptKeys(msg)
@@ -404,7 +406,7 @@ func TestHandleSavePromptKeys(t *testing.T) {
 
 	// Test direct 'y' key - should save and go to main menu
 	m.screen = ScreenSavePrompt
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.savePromptAction = "menu"
 	msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}}
 	result, _ = m.handleSavePromptKeys(msg)
@@ -426,7 +428,7 @@ func TestHandleSavePromptKeys(t *testing.T) {
 // TestFullGameFlow tests a complete game from start to finish
 func TestFullGameFlow(t *testing.T) {
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 
 	// Start at main menu
 	if m.screen != ScreenMainMenu {
@@ -435,7 +437,7 @@ func TestFullGameFlow(t *testing.T) {
 
 	// Select "New Game"
 	m.menuSelection = 0
-	result, _ := m.handleMainMenuSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	if m.screen != ScreenGameTypeSelect {
@@ -444,7 +446,7 @@ func TestFullGameFlow(t *testing.T) {
 
 	// Select "Player vs Player"
 	m.menuSelection = 0
-	result, _ = m.handleGameTypeSelection()
+	result, _ = m.selectMenuItem()
 	m = result.(Model)
 
 	if m.screen != ScreenGamePlay {
@@ -460,29 +462,34 @@ func TestFullGameFlow(t *testing.T) {
 		result, _ = m.handleGamePlayKeys(msg)
 		m = result.(Model)
 
-		if m.errorMsg != "" && i < len(moves)-1 {
-			t.Errorf("Move %d (%s) failed: %s", i+1, moveStr, m.errorMsg)
+		if toastError(m) != "" && i < len(moves)-1 {
+			t.Errorf("Move %d (%s) failed: %s", i+1, moveStr, toastError(m))
 		}
 	}
 
-	// Should detect checkmate and transition to game over screen
+	// Should detect checkmate, show the mate and then transition to game over screen
+	if !m.mateReveal || m.screen != ScreenGamePlay {
+		t.Errorf("Expected the mate to be shown on the game screen, got %v", m.screen)
+	}
+	result, _ = m.Update(mateRevealDoneMsg{})
+	m = result.(Model)
 	if m.screen != ScreenGameOver {
 		t.Errorf("Expected game over screen after checkmate, got %v", m.screen)
 	}
 
 	// Verify game ended in checkmate
-	if !m.board.IsGameOver() {
+	if !m.game.Board().IsGameOver() {
 		t.Error("Expected game to be over after Scholar's Mate")
 	}
 
-	status := m.board.Status()
+	status := m.game.Board().Status()
 	if status != engine.Checkmate {
 		t.Errorf("Expected checkmate status, got %v", status)
 	}
 
 	// Verify move history was recorded
-	if len(m.moveHistory) != len(moves) {
-		t.Errorf("Expected %d moves in history, got %d", len(moves), len(m.moveHistory))
+	if m.game.MoveCount() != len(moves) {
+		t.Errorf("Expected %d moves in history, got %d", len(moves), m.game.MoveCount())
 	}
 }
 
@@ -506,7 +513,7 @@ func TestScreenTransitions(t *testing.T) {
 				m.menuSelection = 0
 			},
 			transitionFunc: func(m Model) (tea.Model, tea.Cmd) {
-				return m.handleMainMenuSelection()
+				return m.selectMenuItem()
 			},
 		},
 		{
@@ -519,7 +526,7 @@ func TestScreenTransitions(t *testing.T) {
 				m.menuSelection = 1
 			},
 			transitionFunc: func(m Model) (tea.Model, tea.Cmd) {
-				return m.handleMainMenuSelection()
+				return m.selectMenuItem()
 			},
 		},
 		{
@@ -532,7 +539,7 @@ func TestScreenTransitions(t *testing.T) {
 				m.menuSelection = 2
 			},
 			transitionFunc: func(m Model) (tea.Model, tea.Cmd) {
-				return m.handleMainMenuSelection()
+				return m.selectMenuItem()
 			},
 		},
 		{
@@ -543,10 +550,10 @@ func TestScreenTransitions(t *testing.T) {
 			setupFunc: func(m *Model) {
 				m.menuOptions = []string{"Player vs Player", "Player vs Bot", "Back"}
 				m.menuSelection = 0
-				m.board = engine.NewBoard()
+				m.game = engine.NewGame()
 			},
 			transitionFunc: func(m Model) (tea.Model, tea.Cmd) {
-				return m.handleGameTypeSelection()
+				return m.selectMenuItem()
 			},
 		},
 		{
@@ -641,8 +648,11 @@ func TestGetGameResultMessage(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			board := tt.setupBoard()
-			msg := getGameResultMessage(board, tt.resignedBy, false)
+			game := engine.NewGameFrom(tt.setupBoard())
+			if tt.resignedBy >= 0 {
+				game.Resign(engine.Color(tt.resignedBy))
+			}
+			msg := getGameResultMessage(game)
 
 			for _, check := range tt.containsCheck {
 				if !strings.Contains(strings.ToLower(msg), strings.ToLower(check)) {
@@ -693,15 +703,15 @@ func TestFENInputValidation(t *testing.T) {
 			newModel := result.(Model)
 
 			if tt.shouldErr {
-				if newModel.errorMsg == "" {
+				if toastError(newModel) == "" {
 					t.Errorf("Expected error for input '%s', got none", tt.input)
 				}
 				if newModel.screen != ScreenFENInput {
 					t.Errorf("Should stay on FEN input screen on error")
 				}
 			} else {
-				if newModel.errorMsg != "" {
-					t.Errorf("Expected no error for input '%s', got: %s", tt.input, newModel.errorMsg)
+				if toastError(newModel) != "" {
+					t.Errorf("Expected no error for input '%s', got: %s", tt.input, toastError(newModel))
 				}
 				if newModel.screen != ScreenGamePlay {
 					t.Errorf("Should transition to gameplay on valid FEN")
@@ -731,7 +741,7 @@ func TestCommandCaseInsensitivity(t *testing.T) {
 	for _, cmd := range commands {
 		t.Run(cmd.input, func(t *testing.T) {
 			m := NewModel(DefaultConfig())
-			m.board = engine.NewBoard()
+			m.game = engine.NewGame()
 			m.screen = ScreenGamePlay
 			m.input = cmd.input
 
@@ -746,7 +756,7 @@ func TestCommandCaseInsensitivity(t *testing.T) {
 					t.Errorf("Resign command '%s' should lead to game over screen", cmd.input)
 				}
 			case "showfen":
-				if newModel.statusMsg == "" {
+				if toastStatus(newModel) == "" {
 					t.Errorf("ShowFen command '%s' should set status message", cmd.input)
 				}
 			case "menu":
@@ -761,29 +771,29 @@ func TestCommandCaseInsensitivity(t *testing.T) {
 // TestErrorMessageClearing tests that error messages are cleared appropriately
 func TestErrorMessageClearing(t *testing.T) {
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenGamePlay
-	m.errorMsg = "Previous error"
+	m.notify(SeverityError, "Previous error")
 
 	// Error should clear when typing
 	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}}
 	result, _ := m.handleGamePlayKeys(msg)
 	m = result.(Model)
 
-	if m.errorMsg != "" {
-		t.Errorf("Error message should clear when typing, got: %s", m.errorMsg)
+	if toastError(m) != "" {
+		t.Errorf("Error message should clear when typing, got: %s", toastError(m))
 	}
 
 	// Set error again
-	m.errorMsg = "Another error"
+	m.notify(SeverityError, "Another error")
 
 	// Error should clear on backspace
 	msg = tea.KeyMsg{Type: tea.KeyBackspace}
 	result, _ = m.handleGamePlayKeys(msg)
 	m = result.(Model)
 
-	if m.errorMsg != "" {
-		t.Errorf("Error message should clear on backspace, got: %s", m.errorMsg)
+	if toastError(m) != "" {
+		t.Errorf("Error message should clear on backspace, got: %s", toastError(m))
 	}
 }
 
@@ -794,7 +804,7 @@ func TestGameTypeSelection_BotTransitionsToBotSelect(t *testing.T) {
 	m.menuOptions = []string{"Player vs Player", "Player vs Bot", "Back"}
 	m.menuSelection = 1 // Select "Player vs Bot"
 
-	result, _ := m.handleGameTypeSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	// Should transition to ScreenBotSelect
@@ -822,7 +832,7 @@ func TestGameTypeSelection_BotTransitionsToBotSelect(t *testing.T) {
 // TestMoveHistoryPersistence tests that move history persists through game
 func TestMoveHistoryPersistence(t *testing.T) {
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenGamePlay
 	m.config.ShowMoveHistory = true
 
@@ -836,8 +846,8 @@ func TestMoveHistoryPersistence(t *testing.T) {
 	}
 
 	// Verify all moves are in history
-	if len(m.moveHistory) != len(moves) {
-		t.Errorf("Expected %d moves in history, got %d", len(moves), len(m.moveHistory))
+	if m.game.MoveCount() != len(moves) {
+		t.Errorf("Expected %d moves in history, got %d", len(moves), m.game.MoveCount())
 	}
 
 	// Verify move history is formatted correctly
@@ -859,7 +869,7 @@ func TestGameTypeSelection_BvBTransitionsToBvBBotSelect(t *testing.T) {
 	m.menuOptions = []string{"Player vs Player", "Player vs Bot", "Bot vs Bot"}
 	m.menuSelection = 2 // Select "Bot vs Bot"
 
-	result, _ := m.handleGameTypeSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	if m.screen != ScreenBvBBotSelect {
@@ -892,7 +902,7 @@ func TestBvBBotSelect_WhiteThenBlack(t *testing.T) {
 	m.menuSelection = 2 // Select "Hard" for White
 
 	// Select White difficulty
-	result, _ := m.handleBvBBotDifficultySelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	if m.bvbSelectingWhite {
@@ -907,7 +917,7 @@ func TestBvBBotSelect_WhiteThenBlack(t *testing.T) {
 
 	// Select Black difficulty
 	m.menuSelection = 0 // Select "Easy" for Black
-	result, _ = m.handleBvBBotDifficultySelection()
+	result, _ = m.selectMenuItem()
 	m = result.(Model)
 
 	if m.bvbBlackDiff != BotEasy {
@@ -1007,8 +1017,8 @@ func TestRenderBvBBotSelect_WhiteSelection(t *testing.T) {
 
 	view := m.renderBvBBotSelect()
 
-	if !strings.Contains(view, "Select White Bot Difficulty:") {
-		t.Error("Expected view to contain 'Select White Bot Difficulty:'")
+	if !strings.Contains(view, "Select White Engine:") {
+		t.Error("Expected view to contain 'Select White Engine:'")
 	}
 	if !strings.Contains(view, "Easy") {
 		t.Error("Expected view to contain 'Easy'")
@@ -1026,8 +1036,8 @@ func TestRenderBvBBotSelect_BlackSelection(t *testing.T) {
 
 	view := m.renderBvBBotSelect()
 
-	if !strings.Contains(view, "Select Black Bot Difficulty:") {
-		t.Error("Expected view to contain 'Select Black Bot Difficulty:'")
+	if !strings.Contains(view, "Select Black Engine:") {
+		t.Error("Expected view to contain 'Select Black Engine:'")
 	}
 	if !strings.Contains(view, "White: Hard Bot") {
 		t.Error("Expected view to show previously selected White difficulty")
@@ -1062,7 +1072,7 @@ func TestBvBGameMode_SingleGameStartsDirectly(t *testing.T) {
 	m.bvbWhiteDiff = BotEasy
 	m.bvbBlackDiff = BotEasy
 
-	result, _ := m.handleBvBGameModeSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	if m.bvbGameCount != 1 {
@@ -1091,7 +1101,7 @@ func TestBvBGameMode_MultiGameShowsInput(t *testing.T) {
 	m.menuOptions = []string{"Single Game", "Multi-Game"}
 	m.menuSelection = 1 // Multi-Game
 
-	result, _ := m.handleBvBGameModeSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	if !m.bvbInputtingCount {
@@ -1168,7 +1178,7 @@ func TestBvBGameMode_CountInputRejectsZero(t *testing.T) {
 	result, _ := m.handleBvBCountInput(msg)
 	m = result.(Model)
 
-	if m.errorMsg == "" {
+	if toastError(m) == "" {
 		t.Error("Expected an error message for zero input")
 	}
 	if !m.bvbInputtingCount {
@@ -1187,7 +1197,7 @@ func TestBvBGameMode_CountInputRejectsEmpty(t *testing.T) {
 	result, _ := m.handleBvBCountInput(msg)
 	m = result.(Model)
 
-	if m.errorMsg == "" {
+	if toastError(m) == "" {
 		t.Error("Expected an error message for empty input")
 	}
 }
@@ -1319,7 +1329,7 @@ func TestBvBGridConfig_PresetSelection(t *testing.T) {
 			m.bvbWhiteDiff = BotEasy
 			m.bvbBlackDiff = BotHard
 
-			result, _ := m.handleBvBGridSelection()
+			result, _ := m.selectMenuItem()
 			m = result.(Model)
 
 			if m.bvbGridRows != tt.wantRows {
@@ -1348,7 +1358,7 @@ func TestBvBGridConfig_CustomSelection(t *testing.T) {
 	m.menuOptions = []string{"1x1", "2x2", "2x3", "2x4", "Custom"}
 	m.menuSelection = 4 // Custom
 
-	result, _ := m.handleBvBGridSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	if !m.bvbInputtingGrid {
@@ -1398,7 +1408,7 @@ func TestBvBGridConfig_CustomInputExceeds8(t *testing.T) {
 	result, _ := m.handleBvBGridInput(msg)
 	m = result.(Model)
 
-	if m.errorMsg == "" {
+	if toastError(m) == "" {
 		t.Error("Expected error for grid exceeding 8 boards")
 	}
 	if !m.bvbInputtingGrid {
@@ -1417,7 +1427,7 @@ func TestBvBGridConfig_CustomInputInvalid(t *testing.T) {
 	result, _ := m.handleBvBGridInput(msg)
 	m = result.(Model)
 
-	if m.errorMsg == "" {
+	if toastError(m) == "" {
 		t.Error("Expected error for invalid grid format")
 	}
 }
@@ -1537,7 +1547,7 @@ func TestBvBGamePlay_EscAbortsSession(t *testing.T) {
 	m.bvbGridCols = 1
 
 	// Go to concurrency selection
-	result, _ := m.handleBvBGridSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	if m.screen != ScreenBvBConcurrencySelect {
@@ -1602,7 +1612,7 @@ func TestBvBGamePlay_SpeedChange(t *testing.T) {
 	m.bvbGridCols = 1
 
 	// Go to concurrency selection
-	result, _ := m.handleBvBGridSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	// Select recommended concurrency and go to view mode selection
@@ -1657,7 +1667,7 @@ func TestBvBGamePlay_PauseResume(t *testing.T) {
 	m.bvbGridCols = 1
 
 	// Go to concurrency selection
-	result, _ := m.handleBvBGridSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	// Select recommended concurrency
@@ -1710,7 +1720,7 @@ func TestBvBGamePlay_GameNavigation(t *testing.T) {
 	m.bvbGridCols = 1
 
 	// Go to concurrency selection
-	result, _ := m.handleBvBGridSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	// Select recommended concurrency
@@ -1806,7 +1816,7 @@ func TestBvBGamePlay_TickSchedulesNext(t *testing.T) {
 	m.bvbGridCols = 1
 
 	// Go to concurrency selection
-	result, _ := m.handleBvBGridSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	// Select recommended concurrency
@@ -1849,7 +1859,7 @@ func TestBvBGamePlay_RenderSingleView(t *testing.T) {
 	m.bvbGridCols = 1
 
 	// Go to concurrency selection
-	result, _ := m.handleBvBGridSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	// Select recommended concurrency
@@ -1896,7 +1906,7 @@ func TestBvBGamePlay_RenderGridView(t *testing.T) {
 	m.bvbGridCols = 2
 
 	// Go to concurrency selection
-	result, _ := m.handleBvBGridSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	// Select recommended concurrency
@@ -1949,7 +1959,7 @@ func TestBvBGamePlay_GridPageNavigation(t *testing.T) {
 	m.bvbGridCols = 1
 
 	// Go to concurrency selection
-	result, _ := m.handleBvBGridSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	// Select recommended concurrency
@@ -2031,7 +2041,7 @@ func TestBvBGamePlay_GridViewPageIndicator(t *testing.T) {
 	m.bvbGridCols = 1
 
 	// Go to concurrency selection
-	result, _ := m.handleBvBGridSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	// Select recommended concurrency
@@ -2075,7 +2085,7 @@ func TestBvBGamePlay_GridViewNoPageIndicatorSinglePage(t *testing.T) {
 	m.bvbGridCols = 2
 
 	// Go to concurrency selection
-	result, _ := m.handleBvBGridSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	// Select recommended concurrency
@@ -2156,7 +2166,7 @@ func TestBvBGamePlay_GridViewPausedIndicator(t *testing.T) {
 	m.bvbGridCols = 1
 
 	// Go through concurrency and view mode selection to start the session
-	result, _ := m.handleBvBGridSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	// Select recommended concurrency
@@ -2195,7 +2205,7 @@ func TestBvBGridCell_FixedDimensions(t *testing.T) {
 	m.bvbGridCols = 2
 
 	// Go to concurrency selection and start session
-	result, _ := m.handleBvBGridSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 	m.bvbConcurrencySelection = 0
 	result, _ = m.handleBvBConcurrencySelectKeys(tea.KeyMsg{Type: tea.KeyEnter})
@@ -2243,7 +2253,7 @@ func TestBvBGridCell_ConsistentHeightFinishedVsInProgress(t *testing.T) {
 	m.bvbGridCols = 2
 
 	// Go to concurrency selection and start session
-	result, _ := m.handleBvBGridSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 	m.bvbConcurrencySelection = 0
 	result, _ = m.handleBvBConcurrencySelectKeys(tea.KeyMsg{Type: tea.KeyEnter})
@@ -2297,7 +2307,7 @@ func TestBvBGridCell_RenderBvBGridCell(t *testing.T) {
 	m.bvbGridCols = 2
 
 	// Go to concurrency selection and start session
-	result, _ := m.handleBvBGridSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 	m.bvbConcurrencySelection = 0
 	result, _ = m.handleBvBConcurrencySelectKeys(tea.KeyMsg{Type: tea.KeyEnter})
@@ -2359,7 +2369,7 @@ func TestBvBGrid_LayoutStability(t *testing.T) {
 	m.termHeight = 50
 
 	// Go to concurrency selection and start session
-	result, _ := m.handleBvBGridSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 	m.bvbConcurrencySelection = 0
 	result, _ = m.handleBvBConcurrencySelectKeys(tea.KeyMsg{Type: tea.KeyEnter})
@@ -2436,7 +2446,7 @@ func TestBvBGrid_LayoutStabilityConfigurations(t *testing.T) {
 			m.termHeight = 100
 
 			// Go to concurrency selection and start session
-			result, _ := m.handleBvBGridSelection()
+			result, _ := m.selectMenuItem()
 			m = result.(Model)
 			m.bvbConcurrencySelection = 0
 			result, _ = m.handleBvBConcurrencySelectKeys(tea.KeyMsg{Type: tea.KeyEnter})
@@ -2507,7 +2517,7 @@ func TestBvBStats_TransitionOnAllFinished(t *testing.T) {
 	m.bvbGridCols = 1
 
 	// Go to concurrency selection
-	result, _ := m.handleBvBGridSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	// Select recommended concurrency
@@ -2565,7 +2575,7 @@ func TestBvBLiveStats_RenderDuringGameplay(t *testing.T) {
 	m.bvbGridCols = 2
 
 	// Go to concurrency selection
-	result, _ := m.handleBvBGridSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	// Select recommended concurrency
@@ -2634,7 +2644,7 @@ func TestBvBLiveStats_UpdatesAsGamesComplete(t *testing.T) {
 	m.bvbGridCols = 2
 
 	// Go to concurrency selection
-	result, _ := m.handleBvBGridSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	// Select recommended concurrency
@@ -2714,7 +2724,7 @@ func TestBvBStats_RenderSingleGame(t *testing.T) {
 	m.bvbGridCols = 1
 
 	// Go to concurrency selection
-	result, _ := m.handleBvBGridSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	// Select recommended concurrency
@@ -2782,7 +2792,7 @@ func TestBvBStats_RenderMultiGame(t *testing.T) {
 	m.bvbGridCols = 2
 
 	// Go to concurrency selection
-	result, _ := m.handleBvBGridSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	// Select recommended concurrency
@@ -2937,7 +2947,7 @@ func TestBvBGamePlay_FENExportSingleView(t *testing.T) {
 	m.bvbGridCols = 1
 
 	// Go to concurrency selection
-	result, _ := m.handleBvBGridSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	// Select recommended concurrency
@@ -2957,11 +2967,11 @@ func TestBvBGamePlay_FENExportSingleView(t *testing.T) {
 	m = result.(Model)
 
 	// Status message should contain FEN-related text
-	if m.statusMsg == "" {
+	if toastStatus(m) == "" {
 		t.Error("Expected status message after FEN export")
 	}
-	if !strings.Contains(m.statusMsg, "FEN") {
-		t.Errorf("Expected status to mention FEN, got: %s", m.statusMsg)
+	if !strings.Contains(toastStatus(m), "FEN") {
+		t.Errorf("Expected status to mention FEN, got: %s", toastStatus(m))
 	}
 
 	// Clean up
@@ -2983,7 +2993,7 @@ func TestBvBGamePlay_FENExportGridView(t *testing.T) {
 	m.bvbGridCols = 2
 
 	// Go to concurrency selection
-	result, _ := m.handleBvBGridSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	// Select recommended concurrency
@@ -3003,11 +3013,11 @@ func TestBvBGamePlay_FENExportGridView(t *testing.T) {
 	m = result.(Model)
 
 	// Status message should contain FEN-related text
-	if m.statusMsg == "" {
+	if toastStatus(m) == "" {
 		t.Error("Expected status message after FEN export in grid view")
 	}
-	if !strings.Contains(m.statusMsg, "FEN") {
-		t.Errorf("Expected status to mention FEN, got: %s", m.statusMsg)
+	if !strings.Contains(toastStatus(m), "FEN") {
+		t.Errorf("Expected status to mention FEN, got: %s", toastStatus(m))
 	}
 
 	// Clean up
@@ -3028,8 +3038,8 @@ func TestBvBGamePlay_FENExportNoManager(t *testing.T) {
 	m = result.(Model)
 
 	// No status message since no manager
-	if m.statusMsg != "" {
-		t.Errorf("Expected no status message without manager, got: %s", m.statusMsg)
+	if toastStatus(m) != "" {
+		t.Errorf("Expected no status message without manager, got: %s", toastStatus(m))
 	}
 }
 
@@ -3046,7 +3056,7 @@ func TestBvB_CtrlCCleansBvBManager(t *testing.T) {
 	m.bvbGridCols = 1
 
 	// Go to concurrency selection
-	result, _ := m.handleBvBGridSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	// Select recommended concurrency
@@ -3093,7 +3103,7 @@ func TestBvB_QuitCleansBvBManager(t *testing.T) {
 	m.bvbGridCols = 1
 
 	// Go to concurrency selection
-	result, _ := m.handleBvBGridSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	// Select recommended concurrency
@@ -3139,7 +3149,7 @@ func TestBvB_GridViewTerminalTooSmall(t *testing.T) {
 	m.bvbGridCols = 2
 
 	// Go to concurrency selection
-	result, _ := m.handleBvBGridSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	// Select recommended concurrency
@@ -3183,7 +3193,7 @@ func TestBvB_GridViewTerminalLargeEnough(t *testing.T) {
 	m.bvbGridCols = 2
 
 	// Go to concurrency selection
-	result, _ := m.handleBvBGridSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	// Select recommended concurrency
@@ -3248,8 +3258,8 @@ func TestBvB_CompleteFlow(t *testing.T) {
 		t.Fatalf("Step 1: Expected ScreenGameTypeSelect, got %d", m.screen)
 	}
 
-	// Step 2: Game Type → Bot vs Bot (last option)
-	m.menuSelection = len(m.menuOptions) - 1 // "Bot vs Bot" is last
+	// Step 2: Game Type → Bot vs Bot
+	m.menuSelection = 2 // "Bot vs Bot"
 	result, _ = m.handleKeyPress(msg)
 	m = result.(Model)
 	if m.screen != ScreenBvBBotSelect {
@@ -3341,7 +3351,7 @@ func TestBvB_HelpTextConfig(t *testing.T) {
 	m.bvbWhiteDiff = BotEasy
 	m.bvbBlackDiff = BotEasy
 
-	result, _ := m.handleBvBGridSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 	m.bvbViewMode = BvBSingleView
 
@@ -3613,7 +3623,7 @@ func TestBvBConcurrencySelect_CustomInputInvalid(t *testing.T) {
 	m = result.(Model)
 
 	// Should show error and stay in input mode
-	if m.errorMsg == "" {
+	if toastError(m) == "" {
 		t.Error("Expected error message for empty input")
 	}
 	if !m.bvbInputtingConcurrency {
@@ -3646,6 +3656,49 @@ func TestBvBConcurrencySelect_Navigation(t *testing.T) {
 	}
 }
 
+// TestBvBConcurrencySelect_CPULimit tests changing the CPU limit with left/right.
+func TestBvBConcurrencySelect_CPULimit(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenBvBConcurrencySelect
+	m.bvbConcurrencySelection = 2
+
+	msg := tea.KeyMsg{Type: tea.KeyLeft}
+	result, _ := m.handleBvBConcurrencySelectKeys(msg)
+	m = result.(Model)
+
+	want := stepCPULimit(0, -1, getCPUCount())
+	if m.bvbCPULimit != want {
+		t.Errorf("Expected CPU limit %d after left, got %d", want, m.bvbCPULimit)
+	}
+	if !strings.Contains(m.View(), "CPU Limit: "+cpuLimitLabel(want, getCPUCount())) {
+		t.Error("Expected the view to show the CPU limit")
+	}
+}
+
+func TestStepCPULimit(t *testing.T) {
+	tests := []struct {
+		limit, direction, numCPU, want int
+	}{
+		{0, -1, 4, 3},
+		{3, -1, 4, 2},
+		{1, -1, 4, 0},
+		{0, 1, 4, 1},
+		{3, 1, 4, 0},
+		{0, -1, 1, 0},
+	}
+	for _, tt := range tests {
+		if got := stepCPULimit(tt.limit, tt.direction, tt.numCPU); got != tt.want {
+			t.Errorf("stepCPULimit(%d, %d, %d) = %d, want %d", tt.limit, tt.direction, tt.numCPU, got, tt.want)
+		}
+	}
+	if got := cpuLimitLabel(2, 8); got != "2 of 8 cores" {
+		t.Errorf("cpuLimitLabel(2, 8) = %q", got)
+	}
+	if got := cpuLimitLabel(0, 8); got != "All 8 cores" {
+		t.Errorf("cpuLimitLabel(0, 8) = %q", got)
+	}
+}
+
 // TestBvBConcurrencySelect_EscGoesBack tests ESC returns to grid config.
 func TestBvBConcurrencySelect_EscGoesBack(t *testing.T) {
 	m := NewModel(DefaultConfig())
@@ -3921,7 +3974,7 @@ func TestSaveQuitDialog_YesSavesAndExitsToMenu(t *testing.T) {
 	_ = config.DeleteSaveGame()
 
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenSavePrompt
 	m.savePromptSelection = 0 // Yes (Save & Exit)
 	m.savePromptAction = "menu"
@@ -3941,8 +3994,8 @@ func TestSaveQuitDialog_YesSavesAndExitsToMenu(t *testing.T) {
 	}
 
 	// Status message should indicate save
-	if m.statusMsg != "Game saved!" {
-		t.Errorf("Expected status 'Game saved!', got '%s'", m.statusMsg)
+	if toastStatus(m) != "Game saved!" {
+		t.Errorf("Expected status 'Game saved!', got '%s'", toastStatus(m))
 	}
 
 	// Clean up
@@ -3960,7 +4013,7 @@ func TestSaveQuitDialog_NoExitsWithoutSaving(t *testing.T) {
 	_ = config.DeleteSaveGame()
 
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenSavePrompt
 	m.savePromptSelection = 1 // No (Exit without saving)
 	m.savePromptAction = "menu"
@@ -3981,7 +4034,7 @@ func TestSaveQuitDialog_NoExitsWithoutSaving(t *testing.T) {
 	}
 
 	// Board should be cleaned up
-	if m.board != nil {
+	if m.game != nil {
 		t.Error("Expected board to be nil after exiting")
 	}
 }
@@ -3989,7 +4042,7 @@ func TestSaveQuitDialog_NoExitsWithoutSaving(t *testing.T) {
 // TestSaveQuitDialog_ESCCancels tests that ESC cancels and returns to gameplay.
 func TestSaveQuitDialog_ESCCancels(t *testing.T) {
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenSavePrompt
 	m.savePromptAction = "menu"
 
@@ -4003,7 +4056,7 @@ func TestSaveQuitDialog_ESCCancels(t *testing.T) {
 	}
 
 	// Board should still exist
-	if m.board == nil {
+	if m.game == nil {
 		t.Error("Expected board to still exist after canceling")
 	}
 }
@@ -4150,10 +4203,10 @@ func TestMenuStateRestorationOnPopScreen(t *testing.T) {
 		screen          Screen
 		expectedOptions []string
 	}{
-		{"GameTypeSelect", ScreenGameTypeSelect, []string{"Player vs Player", "Player vs Bot", "Bot vs Bot"}},
+		{"GameTypeSelect", ScreenGameTypeSelect, []string{"Player vs Player", "Player vs Bot", "Bot vs Bot", "Correspondence", "Simul", "Guess the Move", "Tutorial"}},
 		{"BvBBotSelect", ScreenBvBBotSelect, []string{"Easy", "Medium", "Hard"}},
-		{"BvBGameMode", ScreenBvBGameMode, []string{"Single Game", "Multi-Game"}},
-		{"BvBGridConfig", ScreenBvBGridConfig, []string{"1x1", "2x2", "2x3", "2x4", "Custom"}},
+		{"BvBGameMode", ScreenBvBGameMode, []string{"Single Game", "Multi-Game", "SPRT Test", "Set Seed", "Set Openings", "Paired Games"}},
+		{"BvBGridConfig", ScreenBvBGridConfig, []string{"Auto", "1x1", "2x2", "2x3", "2x4", "Custom"}},
 		{"BotSelect", ScreenBotSelect, []string{"Easy", "Medium", "Hard"}},
 		{"ColorSelect", ScreenColorSelect, []string{"Play as White", "Play as Black"}},
 	}
@@ -4185,8 +4238,8 @@ func TestMenuStateRestorationOnPopScreen(t *testing.T) {
 				t.Errorf("Expected menuSelection to be reset to 0, got %d", m.menuSelection)
 			}
 
-			if m.errorMsg != "" {
-				t.Errorf("Expected errorMsg to be cleared, got '%s'", m.errorMsg)
+			if toastError(m) != "" {
+				t.Errorf("Expected errorMsg to be cleared, got '%s'", toastError(m))
 			}
 		})
 	}
@@ -4195,22 +4248,20 @@ func TestMenuStateRestorationOnPopScreen(t *testing.T) {
 // TestCleanupGameClearsState tests that cleanupGame properly clears all game state.
 func TestCleanupGameClearsState(t *testing.T) {
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
-	m.moveHistory = []engine.Move{{}}
+	m.game = engine.NewGame()
+	move, _ := engine.ParseMove("e2e4")
+	_ = m.game.MakeMove(move)
 	sq := engine.Square(0)
 	m.selectedSquare = &sq
 	m.validMoves = []engine.Square{0}
 	m.input = "e2e4"
-	m.errorMsg = "some error"
+	m.notify(SeverityError, "some error")
 	m.blinkOn = true
 
 	m.cleanupGame()
 
-	if m.board != nil {
-		t.Error("Expected board to be nil")
-	}
-	if m.moveHistory != nil {
-		t.Error("Expected moveHistory to be nil")
+	if m.game != nil {
+		t.Error("Expected game to be nil")
 	}
 	if m.selectedSquare != nil {
 		t.Error("Expected selectedSquare to be nil")
@@ -4221,10 +4272,107 @@ func TestCleanupGameClearsState(t *testing.T) {
 	if m.input != "" {
 		t.Errorf("Expected input to be empty, got '%s'", m.input)
 	}
-	if m.errorMsg != "" {
-		t.Errorf("Expected errorMsg to be empty, got '%s'", m.errorMsg)
+	if toastError(m) != "" {
+		t.Errorf("Expected errorMsg to be empty, got '%s'", toastError(m))
 	}
 	if m.blinkOn {
 		t.Error("Expected blinkOn to be false")
 	}
 }
+
+// TestBvBGameMode_SetSeed tests entering and clearing the BvB seed.
+func TestBvBGameMode_SetSeed(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenBvBGameMode
+	m.menuOptions = bvbGameModeOptions()
+	m.menuSelection = 3 // Set Seed
+
+	result, _ := m.handleBvBGameModeKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if !m.bvbInputtingSeed {
+		t.Fatal("Expected seed input mode")
+	}
+
+	for _, r := range "-42x" {
+		result, _ = m.handleBvBGameModeKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = result.(Model)
+	}
+	result, _ = m.handleBvBGameModeKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if !m.bvbSeedSet || m.bvbSeed != -42 {
+		t.Fatalf("Expected seed -42, got %d (set %v)", m.bvbSeed, m.bvbSeedSet)
+	}
+	if !strings.Contains(m.View(), "Seed: -42") {
+		t.Error("Expected the game mode screen to show the seed")
+	}
+
+	// Entering an empty seed makes the games random again
+	result, _ = m.handleBvBGameModeKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	for m.bvbSeedInput != "" {
+		result, _ = m.handleBvBGameModeKeys(tea.KeyMsg{Type: tea.KeyBackspace})
+		m = result.(Model)
+	}
+	result, _ = m.handleBvBGameModeKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if m.bvbSeedSet {
+		t.Error("Expected the seed to be cleared")
+	}
+	if !strings.Contains(m.View(), "Seed: random") {
+		t.Error("Expected the game mode screen to show a random seed")
+	}
+}
+
+// TestBvBGameMode_SetOpenings tests loading an opening suite and pairing its openings.
+func TestBvBGameMode_SetOpenings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "suite.epd")
+	suite := "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq - bm e5;\n" +
+		"rnbqkbnr/pppppppp/8/8/3P4/8/PPP1PPPP/RNBQKBNR b KQkq - bm d5;\n"
+	if err := os.WriteFile(path, []byte(suite), 0644); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenBvBGameMode
+	m.menuOptions = bvbGameModeOptions()
+	m.menuSelection = 4 // Set Openings
+
+	result, _ := m.handleBvBGameModeKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if !m.bvbInputtingOpenings || !m.isInTextInputMode() {
+		t.Fatal("Expected openings text input mode")
+	}
+
+	result, _ = m.handleBvBGameModeKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(path)})
+	m = result.(Model)
+	result, _ = m.handleBvBGameModeKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if m.bvbInputtingOpenings || len(m.bvbOpenings) != 2 {
+		t.Fatalf("Expected the suite's 2 openings, got %d (error %q)", len(m.bvbOpenings), toastError(m))
+	}
+
+	m.menuSelection = 5 // Paired Games
+	result, _ = m.handleBvBGameModeKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if !m.bvbPairedOpenings {
+		t.Error("Expected paired games to be switched on")
+	}
+	if !strings.Contains(m.View(), "Openings: suite.epd (2 positions), paired") {
+		t.Error("Expected the game mode screen to show the openings")
+	}
+
+	// Neither a FEN nor a file
+	m.menuSelection = 4
+	result, _ = m.handleBvBGameModeKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	result, _ = m.handleBvBGameModeKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("missing.epd")})
+	m = result.(Model)
+	result, _ = m.handleBvBGameModeKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if !m.bvbInputtingOpenings || !strings.Contains(toastError(m), "neither a FEN nor an opening suite") {
+		t.Errorf("Expected the input to be rejected, got %q", toastError(m))
+	}
+	if len(m.bvbOpenings) != 2 {
+		t.Error("Expected the previous openings to be kept")
+	}
+}
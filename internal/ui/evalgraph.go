@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// evalGraphHeight is the number of rows of the evaluation graph.
+const evalGraphHeight = 4
+
+// evalGraphScale is the evaluation, in pawns, drawn as a full or an empty bar.
+const evalGraphScale = 5.0
+
+// evalGraphMaxWidth is the most columns the evaluation graph takes.
+const evalGraphMaxWidth = 80
+
+// evalGraph draws evals, the evaluation after each move in pawns from White's
+// perspective, as a bar chart height rows high in at most width columns: the
+// higher a bar, the better White stands, and a bar half as high as the graph
+// means equality. With more moves than columns, each column shows the last
+// move it covers. The bars are rendered with bar, except for the column of the
+// move at index selected, rendered with highlight and marked with ┊ above its
+// bar; a selected of -1 highlights none. It returns "" without evals.
+func evalGraph(evals []float64, width, height, selected int, bar, highlight func(...string) string) string {
+	if len(evals) == 0 || width <= 0 || height <= 0 {
+		return ""
+	}
+
+	columns := min(len(evals), width)
+	levels := make([]int, columns)
+	for c := range levels {
+		score := evals[(c+1)*len(evals)/columns-1]
+		score = max(-evalGraphScale, min(evalGraphScale, score))
+		eighths := float64(height * len(sparkBlocks))
+		levels[c] = int((score + evalGraphScale) / (2 * evalGraphScale) * eighths)
+	}
+	selectedColumn := columns
+	if selected >= 0 && selected < len(evals) {
+		selectedColumn = selected * columns / len(evals)
+	}
+
+	rows := make([]string, height)
+	for r := range rows {
+		// Rows are drawn from the top, each holding len(sparkBlocks) levels of the bars
+		base := (height - 1 - r) * len(sparkBlocks)
+		cells := make([]rune, columns)
+		for c, level := range levels {
+			switch fill := min(level-base, len(sparkBlocks)); {
+			case fill > 0:
+				cells[c] = sparkBlocks[fill-1]
+			case c == selectedColumn:
+				// Mark the selected column above its bar too
+				cells[c] = '┊'
+			default:
+				cells[c] = ' '
+			}
+		}
+		row := bar(string(cells[:selectedColumn]))
+		if selectedColumn < columns {
+			row += highlight(string(cells[selectedColumn])) + bar(string(cells[selectedColumn+1:]))
+		}
+		rows[r] = row
+	}
+	return strings.Join(rows, "\n")
+}
+
+// renderEvalGraph renders the evaluation graph of the finished game, framed by
+// the White and Black labels, with the move at index selected highlighted. It
+// returns "" if the game hasn't been evaluated.
+func (m Model) renderEvalGraph(selected int) string {
+	width := evalGraphMaxWidth
+	if m.termWidth > 0 {
+		width = min(width, m.termWidth-8)
+	}
+	bar := lipgloss.NewStyle().Foreground(m.theme.MenuNormal).Render
+	highlight := lipgloss.NewStyle().Foreground(m.theme.MenuSelected).Render
+	graph := evalGraph(m.evals, width, evalGraphHeight, selected, bar, highlight)
+	if graph == "" {
+		return ""
+	}
+
+	labelStyle := lipgloss.NewStyle().Foreground(m.theme.MenuSecondary)
+	rows := strings.Split(graph, "\n")
+	for i, row := range rows {
+		label := "      "
+		switch i {
+		case 0:
+			label = "White "
+		case len(rows) - 1:
+			label = "Black "
+		}
+		rows[i] = labelStyle.Render(label) + row
+	}
+	return strings.Join(rows, "\n")
+}
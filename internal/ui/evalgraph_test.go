@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestEvalGraph(t *testing.T) {
+	plain := func(s ...string) string { return strings.Join(s, "") }
+	mark := func(s ...string) string { return "[" + strings.Join(s, "") + "]" }
+
+	tests := []struct {
+		name     string
+		evals    []float64
+		width    int
+		selected int
+		want     string
+	}{
+		{"no moves", nil, 10, -1, ""},
+		{"equal is half high", []float64{0, 0}, 10, -1, "  \n██"},
+		{"white ahead", []float64{2.5, 5, 9}, 10, -1, "▄██\n███"},
+		{"black ahead", []float64{-2.5, -10000}, 10, -1, "  \n▄ "},
+		{"selected column", []float64{0, 5, 0}, 10, 1, " [█] \n█[█]█"},
+		{"selected column above its bar", []float64{0, 0}, 10, 0, "[┊] \n[█]█"},
+		{"last move of each column", []float64{5, -5, 5, 0}, 2, -1, "  \n █"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := evalGraph(tt.evals, tt.width, 2, tt.selected, plain, mark); got != tt.want {
+				t.Errorf("evalGraph(%v) = %q, want %q", tt.evals, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderEvalGraphInAnalysis(t *testing.T) {
+	m := foolsMateModel(t)
+	if got := m.renderEvalGraph(-1); got != "" {
+		t.Errorf("Expected no graph before the game is evaluated, got %q", got)
+	}
+	m.evals = []float64{-0.4, -0.3, -9.9, -10000}
+	if got := ansi.Strip(m.renderEvalGraph(-1)); !strings.HasPrefix(got, "White") || strings.Count(got, "\n") != evalGraphHeight-1 {
+		t.Errorf("Expected a %d row graph, got %q", evalGraphHeight, got)
+	}
+}
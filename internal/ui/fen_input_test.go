@@ -1,10 +1,13 @@
 package ui
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/Mgrdich/TermChess/internal/config"
 	"github.com/Mgrdich/TermChess/internal/engine"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
 )
 
 // TestFENInputNavigation tests that "Load Game" from main menu transitions to FEN input screen.
@@ -20,7 +23,7 @@ func TestFENInputNavigation(t *testing.T) {
 	m.menuSelection = 1
 
 	// Simulate Enter key press
-	updatedModelInterface, _ := m.handleMainMenuSelection()
+	updatedModelInterface, _ := m.selectMenuItem()
 	updatedModel := updatedModelInterface.(Model)
 
 	// Verify we're now on FEN input screen
@@ -58,14 +61,14 @@ func TestFENInputValidFEN(t *testing.T) {
 	}
 
 	// Verify the board was loaded
-	if updatedModel.board == nil {
+	if updatedModel.game == nil {
 		t.Fatal("Expected board to be loaded, got nil")
 	}
 
 	// Verify the board has the correct starting position
 	expectedBoard := engine.NewBoard()
-	if updatedModel.board.ToFEN() != expectedBoard.ToFEN() {
-		t.Errorf("Expected starting position, got %s", updatedModel.board.ToFEN())
+	if updatedModel.game.Board().ToFEN() != expectedBoard.ToFEN() {
+		t.Errorf("Expected starting position, got %s", updatedModel.game.Board().ToFEN())
 	}
 
 	// Verify game type is set to PvP
@@ -74,8 +77,8 @@ func TestFENInputValidFEN(t *testing.T) {
 	}
 
 	// Verify no error message
-	if updatedModel.errorMsg != "" {
-		t.Errorf("Expected no error message, got %q", updatedModel.errorMsg)
+	if toastError(updatedModel) != "" {
+		t.Errorf("Expected no error message, got %q", toastError(updatedModel))
 	}
 }
 
@@ -103,12 +106,12 @@ func TestFENInputInvalidFEN(t *testing.T) {
 	}
 
 	// Verify an error message is displayed
-	if updatedModel.errorMsg == "" {
+	if toastError(updatedModel) == "" {
 		t.Error("Expected error message for invalid FEN")
 	}
 
 	// Verify the board was not loaded
-	if updatedModel.board != nil {
+	if updatedModel.game != nil {
 		t.Error("Expected board to remain nil for invalid FEN")
 	}
 }
@@ -134,7 +137,7 @@ func TestFENInputEmptyString(t *testing.T) {
 	}
 
 	// Verify an error message is displayed
-	if updatedModel.errorMsg == "" {
+	if toastError(updatedModel) == "" {
 		t.Error("Expected error message for empty FEN string")
 	}
 }
@@ -160,7 +163,7 @@ func TestFENInputEscapeToMenu(t *testing.T) {
 	}
 
 	// Verify menu options are restored
-	expectedOptions := []string{"New Game", "Load Game", "Settings", "Exit"}
+	expectedOptions := []string{"New Game", "Load Game", "Game History", "Settings", "Learn", "Exit"}
 	if len(updatedModel.menuOptions) != len(expectedOptions) {
 		t.Errorf("Expected %d menu options, got %d", len(expectedOptions), len(updatedModel.menuOptions))
 	}
@@ -195,22 +198,210 @@ func TestFENInputMidGamePosition(t *testing.T) {
 	}
 
 	// Verify the board was loaded
-	if updatedModel.board == nil {
+	if updatedModel.game == nil {
 		t.Fatal("Expected board to be loaded, got nil")
 	}
 
 	// Verify the board matches the mid-game position
-	if updatedModel.board.ToFEN() != midGameFEN {
-		t.Errorf("Expected FEN %s, got %s", midGameFEN, updatedModel.board.ToFEN())
+	if updatedModel.game.Board().ToFEN() != midGameFEN {
+		t.Errorf("Expected FEN %s, got %s", midGameFEN, updatedModel.game.Board().ToFEN())
 	}
 
 	// Verify it's White's turn
-	if updatedModel.board.ActiveColor != engine.White {
-		t.Errorf("Expected White to move, got %v", updatedModel.board.ActiveColor)
+	if updatedModel.game.Board().ActiveColor != engine.White {
+		t.Errorf("Expected White to move, got %v", updatedModel.game.Board().ActiveColor)
 	}
 
 	// Verify castling rights are correct
-	if updatedModel.board.CastlingRights != (engine.CastleWhiteKing | engine.CastleWhiteQueen | engine.CastleBlackKing | engine.CastleBlackQueen) {
-		t.Errorf("Expected all castling rights, got %v", updatedModel.board.CastlingRights)
+	if updatedModel.game.Board().CastlingRights != (engine.CastleWhiteKing | engine.CastleWhiteQueen | engine.CastleBlackKing | engine.CastleBlackQueen) {
+		t.Errorf("Expected all castling rights, got %v", updatedModel.game.Board().CastlingRights)
+	}
+}
+
+// TestCheckFEN tests the live validation of FEN strings while they are typed.
+func TestCheckFEN(t *testing.T) {
+	tests := []struct {
+		name       string
+		fen        string
+		wantField  int // -1 for too many fields, -2 for a valid FEN
+		start, end int
+		missing    int
+	}{
+		{"complete", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", -2, 0, 0, 0},
+		{"placement only", "4k3/8/8/8/8/8/8/4K3", -2, 0, 0, 5},
+		{"bad rank", "4k3/8/8/4x3/8/8/8/4K3 w - - 0 1", 0, 8, 11, 0},
+		{"too few ranks", "4k3/8/8", 0, 0, 7, 0},
+		{"bad active color", "4k3/8/8/8/8/8/8/4K3 x - - 0 1", 1, 20, 21, 0},
+		{"bad castling", "4k3/8/8/8/8/8/8/4K3 w KX", 2, 22, 24, 0},
+		{"bad en passant", "4k3/8/8/8/8/8/8/4K3 b - z9", 3, 24, 26, 0},
+		{"too many fields", "4k3/8/8/8/8/8/8/4K3 w - - 0 1 extra", -1, 30, 35, 0},
+		{"illegal position", "4k3/8/8/8/8/8/8/8 w - - 0 1", -1, 0, 27, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := checkFEN(tt.fen)
+			if tt.wantField == -2 {
+				if c.err != nil || c.board == nil {
+					t.Fatalf("Expected a valid position, got error %v", c.err)
+				}
+				if c.missing != tt.missing {
+					t.Errorf("Expected %d missing fields, got %d", tt.missing, c.missing)
+				}
+				return
+			}
+			if c.err == nil {
+				t.Fatal("Expected an error")
+			}
+			if c.field != tt.wantField || c.start != tt.start || c.end != tt.end {
+				t.Errorf("Expected field %d at %d-%d, got field %d at %d-%d (%v)", tt.wantField, tt.start, tt.end, c.field, c.start, c.end, c.err)
+			}
+		})
+	}
+
+	if c := checkFEN("   "); c.err != nil || c.board != nil {
+		t.Errorf("Expected no check for an empty input, got %+v", c)
+	}
+}
+
+// TestFENInputLivePreview tests that the FEN screen previews the position as it is typed.
+func TestFENInputLivePreview(t *testing.T) {
+	config := DefaultConfig()
+	config.UseColors = false
+	m := NewModel(config)
+	m.screen = ScreenFENInput
+	m.fenInput.Focus()
+
+	m.fenInput.SetValue("4k3/8/8/8/8/8/8/4K3 b")
+	view := ansi.Strip(m.renderFENInput())
+	if !strings.Contains(view, "Black to move") || !strings.Contains(view, "castling rights, en passant square, half-move clock, full move number not typed yet") {
+		t.Errorf("Expected a summary of the partial FEN, got:\n%s", view)
+	}
+	if !strings.Contains(view, "k") || !strings.Contains(view, "K") {
+		t.Errorf("Expected a preview board with both kings, got:\n%s", view)
+	}
+
+	m.fenInput.SetValue("4k3/8/8/4x3/8/8/8/4K3 w - - 0 1")
+	view = ansi.Strip(m.renderFENInput())
+	if !strings.Contains(view, "Invalid piece placement (field 1, column 9)") {
+		t.Errorf("Expected the error location, got:\n%s", view)
+	}
+	if !strings.Contains(view, strings.Repeat(" ", 2+8)+"^^^\n") {
+		t.Errorf("Expected a marker under the invalid rank, got:\n%s", view)
+	}
+}
+
+// TestFENInputRecentPositions tests choosing and recording recent FENs on the FEN input screen.
+func TestFENInputRecentPositions(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	older := "4k3/8/8/8/8/8/8/4K3 w - - 0 1"
+	newer := "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1"
+	for _, fen := range []string{older, newer} {
+		if err := config.AddFENHistory(fen); err != nil {
+			t.Fatalf("AddFENHistory failed: %v", err)
+		}
+	}
+
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenMainMenu
+	m.menuOptions = buildMainMenuOptions()
+	for i, option := range m.menuOptions {
+		if option == "Load Game" {
+			m.menuSelection = i
+		}
+	}
+	model, _ := m.selectMenuItem()
+	m = model.(Model)
+	if len(m.fenHistory) != 2 || m.fenHistory[0] != newer {
+		t.Fatalf("Expected the recent FENs, most recent first, got %v", m.fenHistory)
+	}
+	if view := ansi.Strip(m.renderFENInput()); !strings.Contains(view, "Recent positions:") || !strings.Contains(view, older) {
+		t.Errorf("Expected the recent positions to be listed, got:\n%s", view)
+	}
+
+	// Down chooses the entries in order and fills the input
+	for _, want := range []string{newer, older, older} {
+		model, _ = m.handleFENInputKeys(tea.KeyMsg{Type: tea.KeyDown})
+		m = model.(Model)
+		if m.fenInput.Value() != want {
+			t.Errorf("Expected input %q, got %q", want, m.fenInput.Value())
+		}
+	}
+	model, _ = m.handleFENInputKeys(tea.KeyMsg{Type: tea.KeyUp})
+	m = model.(Model)
+	if m.fenHistorySelection != 0 || m.fenInput.Value() != newer {
+		t.Errorf("Expected up to choose the most recent FEN, got selection %d, input %q", m.fenHistorySelection, m.fenInput.Value())
+	}
+
+	// Loading the older position makes it the most recent one
+	m.fenInput.SetValue(older)
+	model, _ = m.handleFENInputKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	if model.(Model).screen != ScreenGamePlay {
+		t.Fatalf("Expected the position to load, got screen %v", model.(Model).screen)
+	}
+	if fens, _ := config.LoadFENHistory(); len(fens) != 2 || fens[0] != older {
+		t.Errorf("Expected the loaded FEN first in the history, got %v", fens)
+	}
+}
+
+func TestFENInputHandToBots(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(DefaultConfig())
+	m.pushScreen(ScreenFENInput)
+	fen := "7k/8/6K1/8/8/8/8/1Q6 w - - 0 1"
+	m.fenInput.SetValue(fen)
+
+	result, _ := m.handleFENInputKeys(tea.KeyMsg{Type: tea.KeyTab})
+	m = result.(Model)
+	if m.screen != ScreenBvBBotSelect || !m.bvbSelectingWhite {
+		t.Fatalf("Expected the White bot to be chosen next, got %v", m.screen)
+	}
+	if m.bvbStartFEN != fen {
+		t.Errorf("Expected the position to be kept for the bots, got %q", m.bvbStartFEN)
+	}
+	if !strings.Contains(ansi.Strip(m.renderBvBBotSelect()), "From position: "+fen) {
+		t.Error("Expected the bot selection screen to show the position")
+	}
+
+	// Choosing both bots starts a single game from the position straight away
+	result, _ = m.chooseBvBBot(BotEasy, "")
+	result, _ = result.(Model).chooseBvBBot(BotEasy, "")
+	m = result.(Model)
+	if m.screen != ScreenBvBGamePlay {
+		t.Fatalf("Expected the game to start, got %v", m.screen)
+	}
+	defer m.bvbManager.Abort()
+	if m.bvbGameCount != 1 {
+		t.Errorf("Expected a single game, got %d", m.bvbGameCount)
+	}
+	if board := m.bvbManager.GetSession(0).CurrentBoard(); board.PieceAt(engine.NewSquare(4, 1)).Type() == engine.Pawn {
+		t.Error("Expected the game to start from the handed over position")
+	}
+
+	// Bot vs Bot from the game type menu starts from the standard position again
+	m.openNewGame()
+	for i, label := range m.menuOptions {
+		if label == "Bot vs Bot" {
+			result, _ = m.runMenuItem(i)
+		}
+	}
+	if result.(Model).bvbStartFEN != "" {
+		t.Error("Expected a new Bot vs Bot setup to forget the position")
+	}
+}
+
+func TestFENInputHandFinishedGameToBots(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.pushScreen(ScreenFENInput)
+	// Black is checkmated
+	m.fenInput.SetValue("Q6k/8/6K1/8/8/8/8/8 b - - 0 1")
+
+	result, _ := m.handleFENInputKeys(tea.KeyMsg{Type: tea.KeyTab})
+	m = result.(Model)
+	if m.screen != ScreenFENInput {
+		t.Errorf("Expected to stay on the FEN input screen, got %v", m.screen)
+	}
+	if !strings.Contains(toastError(m), "already over") {
+		t.Errorf("Expected an error about the finished game, got %q", toastError(m))
 	}
 }
@@ -0,0 +1,173 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// fenFieldNames names the six space-separated fields of a FEN string, in order.
+var fenFieldNames = []string{
+	"piece placement",
+	"active color",
+	"castling rights",
+	"en passant square",
+	"half-move clock",
+	"full move number",
+}
+
+// fenFieldDefaults completes a FEN string that stops after some of its fields,
+// so the position typed so far can be previewed.
+var fenFieldDefaults = []string{"8/8/8/8/8/8/8/8", "w", "-", "-", "0", "1"}
+
+// fenCheck is the result of validating a FEN string while it is typed.
+type fenCheck struct {
+	// board is the parsed position, with missing trailing fields defaulted. It
+	// is nil if the FEN is empty or invalid.
+	board *engine.Board
+	// missing is the number of trailing fields not typed yet.
+	missing int
+	// err explains why the FEN is invalid.
+	err error
+	// field is the index of the invalid field in fenFieldNames, or -1 if the
+	// FEN has too many fields or describes an illegal position.
+	field int
+	// start and end are the byte offsets of the invalid part of the FEN.
+	start, end int
+}
+
+// checkFEN validates fen field by field, so an error points at the first field
+// that fails rather than at the whole string. Fields not typed yet are not
+// errors; the preview board fills them in with defaults.
+func checkFEN(fen string) fenCheck {
+	fields, offsets := splitFENFields(fen)
+	if len(fields) == 0 {
+		return fenCheck{}
+	}
+	if len(fields) > len(fenFieldNames) {
+		start := offsets[len(fenFieldNames)]
+		return fenCheck{
+			err:   fmt.Errorf("FEN must have %d fields, got %d", len(fenFieldNames), len(fields)),
+			field: -1,
+			start: start,
+			end:   len(strings.TrimRight(fen, " ")),
+		}
+	}
+
+	// Parse with one more typed field each time; the first failure is the culprit
+	var board *engine.Board
+	for i := range fields {
+		candidate := append(append([]string{}, fields[:i+1]...), fenFieldDefaults[i+1:]...)
+		b, err := engine.FromFEN(strings.Join(candidate, " "))
+		if err != nil {
+			start, end := offsets[i], offsets[i]+len(fields[i])
+			if i == 0 {
+				start, end = locatePlacementError(fields[0], start)
+			}
+			return fenCheck{err: err, field: i, start: start, end: end}
+		}
+		board = b
+	}
+
+	// A complete FEN must also be a position the game can be played from
+	if len(fields) == len(fenFieldNames) {
+		legal := board.Copy()
+		legal.Normalize()
+		if err := legal.Validate(); err != nil {
+			return fenCheck{err: err, field: -1, start: 0, end: len(strings.TrimRight(fen, " "))}
+		}
+	}
+
+	return fenCheck{board: board, missing: len(fenFieldNames) - len(fields)}
+}
+
+// splitFENFields splits fen into its space-separated fields, returning the
+// byte offset each field starts at.
+func splitFENFields(fen string) ([]string, []int) {
+	var fields []string
+	var offsets []int
+	start := -1
+	for i := 0; i <= len(fen); i++ {
+		if i < len(fen) && fen[i] != ' ' {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			fields = append(fields, fen[start:i])
+			offsets = append(offsets, start)
+			start = -1
+		}
+	}
+	return fields, offsets
+}
+
+// locatePlacementError narrows an invalid piece placement, starting at byte
+// offset start of the FEN, down to the first rank that fails on its own. It
+// returns the whole placement if the ranks are fine but their count is wrong.
+func locatePlacementError(placement string, start int) (int, int) {
+	ranks := strings.Split(placement, "/")
+	if len(ranks) == 8 {
+		offset := start
+		for i, rank := range ranks {
+			// Check the rank alone, with every other rank empty
+			others := []string{"8", "8", "8", "8", "8", "8", "8", "8"}
+			others[i] = rank
+			if _, err := engine.FromFEN(strings.Join(others, "/") + " w - - 0 1"); err != nil {
+				return offset, offset + len(rank)
+			}
+			offset += len(rank) + 1
+		}
+	}
+	return start, start + len(placement)
+}
+
+// describeFENCheck summarises a valid FEN check for the preview, e.g. "White to
+// move" or "White to move (castling rights, en passant square, ... not typed yet)".
+func describeFENCheck(c fenCheck) string {
+	side := "White"
+	if c.board.ActiveColor == engine.Black {
+		side = "Black"
+	}
+	text := side + " to move"
+	if c.missing > 0 {
+		missing := fenFieldNames[len(fenFieldNames)-c.missing:]
+		text += fmt.Sprintf(" (%s not typed yet)", strings.Join(missing, ", "))
+	}
+	return text
+}
+
+// renderFENCheck renders the live validation below the FEN input: a preview
+// board of the parsed position, or a marker under the invalid part of the FEN
+// and what is wrong with it.
+func (m Model) renderFENCheck() string {
+	fen := m.fenInput.Value()
+	c := checkFEN(fen)
+	if c.board == nil && c.err == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	if c.err != nil {
+		// Point at the invalid characters, unless the input has scrolled them out of line
+		if len(fen) <= m.fenInput.Width {
+			indent := strings.Repeat(" ", lipgloss.Width(m.fenInput.Prompt)+c.start)
+			b.WriteString(m.errorStyle().UnsetPadding().Render(indent + strings.Repeat("^", max(c.end-c.start, 1))))
+			b.WriteString("\n")
+		}
+		msg := fmt.Sprintf("Invalid FEN at column %d: %v", c.start+1, c.err)
+		if c.field >= 0 {
+			msg = fmt.Sprintf("Invalid %s (field %d, column %d): %v", fenFieldNames[c.field], c.field+1, c.start+1, c.err)
+		}
+		b.WriteString(m.errorStyle().UnsetPadding().Render(msg))
+		return b.String()
+	}
+
+	b.WriteString(m.statusStyle().UnsetPadding().Render(describeFENCheck(c)))
+	b.WriteString("\n\n")
+	b.WriteString(NewBoardRendererWithTheme(m.config, m.theme).Render(c.board))
+	return b.String()
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// moveFlashTicks is how many times the squares of a bot's move toggle between
+// highlighted and plain, starting highlighted, before the highlight goes away.
+const moveFlashTicks = 6
+
+// moveFlashInterval is the time between two toggles of a move flash.
+const moveFlashInterval = 150 * time.Millisecond
+
+// moveFlash flashes the origin and destination squares of a move just played
+// by a bot, so it doesn't go unnoticed.
+type moveFlash struct {
+	// move is the move being flashed, or nil
+	move *engine.Move
+	// ticks is how many toggles are left; the squares are highlighted while it is even
+	ticks int
+	// id tells the ticks of this flash from those of an earlier one
+	id int
+}
+
+// MoveFlashTickMsg toggles the highlight of a move flash.
+type MoveFlashTickMsg struct {
+	id int
+}
+
+// moveFlashTickCmd returns a command that sends the next tick of flash id.
+func moveFlashTickCmd(id int) tea.Cmd {
+	return tea.Tick(moveFlashInterval, func(time.Time) tea.Msg {
+		return MoveFlashTickMsg{id: id}
+	})
+}
+
+// startMoveFlash starts flashing move, replacing any flash in progress.
+func (m *Model) startMoveFlash(move engine.Move) tea.Cmd {
+	m.flashCount++
+	m.flash = moveFlash{move: &move, ticks: moveFlashTicks, id: m.flashCount}
+	return moveFlashTickCmd(m.flash.id)
+}
+
+// handleMoveFlashTick toggles the flash msg belongs to, unless it was replaced.
+func (m Model) handleMoveFlashTick(msg MoveFlashTickMsg) (tea.Model, tea.Cmd) {
+	if msg.id != m.flash.id || m.flash.move == nil {
+		return m, nil
+	}
+	m.flash.ticks--
+	if m.flash.ticks <= 0 {
+		m.flash.move = nil
+		return m, nil
+	}
+	return m, moveFlashTickCmd(m.flash.id)
+}
+
+// flashedMove returns the move whose squares are highlighted right now, or nil.
+func (m Model) flashedMove() *engine.Move {
+	if m.flash.move == nil || m.flash.ticks%2 != 0 {
+		return nil
+	}
+	return m.flash.move
+}
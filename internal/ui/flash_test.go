@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestBotMoveStartsFlash(t *testing.T) {
+	reply, _ := engine.ParseMove("e7e5")
+
+	m := botToMove(t, false)
+	result, cmd := m.handleBotMove(BotMoveMsg{move: reply})
+	m = result.(Model)
+	if cmd == nil {
+		t.Fatal("Expected a tick command for the move flash")
+	}
+	if got := m.flashedMove(); got == nil || *got != reply {
+		t.Fatalf("flashedMove() = %v, want %v", got, reply)
+	}
+
+	// The squares toggle on every tick and stay plain once the flash is over
+	for i := 1; i <= moveFlashTicks; i++ {
+		result, cmd = m.handleMoveFlashTick(MoveFlashTickMsg{id: m.flash.id})
+		m = result.(Model)
+		highlighted := m.flashedMove() != nil
+		if i < moveFlashTicks && highlighted != (i%2 == 0) {
+			t.Errorf("tick %d: highlighted = %v", i, highlighted)
+		}
+		if i == moveFlashTicks && (highlighted || cmd != nil) {
+			t.Errorf("Expected the flash to end after %d ticks", moveFlashTicks)
+		}
+	}
+}
+
+func TestMoveFlashIgnoresStaleTicks(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	first, _ := engine.ParseMove("e2e4")
+	second, _ := engine.ParseMove("e7e5")
+	m.startMoveFlash(first)
+	staleID := m.flash.id
+	m.startMoveFlash(second)
+
+	result, cmd := m.handleMoveFlashTick(MoveFlashTickMsg{id: staleID})
+	m = result.(Model)
+	if cmd != nil {
+		t.Error("Expected no command for a tick of a replaced flash")
+	}
+	if m.flash.ticks != moveFlashTicks || *m.flash.move != second {
+		t.Errorf("Expected the stale tick to leave the current flash alone, got %+v", m.flash)
+	}
+}
+
+func TestBoardRendererFlashMove(t *testing.T) {
+	lipgloss.SetColorProfile(termenv.ANSI256)
+
+	renderer := NewBoardRenderer(Config{})
+	board := engine.NewBoard()
+	plain := renderer.Render(board)
+
+	move, _ := engine.ParseMove("e2e4")
+	renderer.FlashMove(&move)
+	flashed := renderer.Render(board)
+	if flashed == plain || !strings.Contains(flashed, "\x1b[") {
+		t.Errorf("Expected the flashed squares to be highlighted, got:\n%s", flashed)
+	}
+
+	renderer.FlashMove(nil)
+	if got := renderer.Render(board); got != plain {
+		t.Errorf("Expected no highlight without a flash, got:\n%s", got)
+	}
+}
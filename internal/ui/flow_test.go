@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/config"
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/exp/teatest"
+)
+
+// The tests in this file script whole flows against a real Bubbletea program,
+// with its event loop, commands, bots and renderer, in a virtual terminal.
+
+// flowTimeout bounds how long a flow waits for the screen to show something,
+// long enough for a bot to move on a slow machine.
+const flowTimeout = 10 * time.Second
+
+// startFlow runs m, offline so nothing reaches the network, in a test program
+// with a virtual terminal of the given size. Window titles are off, since the
+// test program's output isn't safe for them to share with the renderer.
+func startFlow(t *testing.T, m Model, width, height int) *teatest.TestModel {
+	t.Helper()
+	m = m.WithOffline().WithoutWindowTitle()
+	tm := teatest.NewTestModel(t, m, teatest.WithInitialTermSize(width, height))
+	t.Cleanup(func() { _ = tm.Quit() })
+	return tm
+}
+
+// waitForText waits until the program has drawn all of texts since the last
+// wait. Output is only read once, so texts drawn together are waited for together.
+func waitForText(t *testing.T, tm *teatest.TestModel, texts ...string) {
+	t.Helper()
+	teatest.WaitFor(t, tm.Output(), func(out []byte) bool {
+		for _, text := range texts {
+			if !bytes.Contains(out, []byte(text)) {
+				return false
+			}
+		}
+		return true
+	}, teatest.WithDuration(flowTimeout), teatest.WithCheckInterval(10*time.Millisecond))
+}
+
+// sendKeys presses the given keys in order. Strings are the names of the keys
+// in snapshotKeys or else text typed one rune at a time.
+func sendKeys(tm *teatest.TestModel, keys ...string) {
+	for _, key := range keys {
+		if typ, ok := snapshotKeys[key]; ok {
+			tm.Send(tea.KeyMsg{Type: typ})
+			continue
+		}
+		tm.Type(key)
+	}
+}
+
+// finalModel waits for the program to exit and returns its last model.
+func finalModel(t *testing.T, tm *teatest.TestModel) Model {
+	t.Helper()
+	m, ok := tm.FinalModel(t, teatest.WithFinalTimeout(flowTimeout)).(Model)
+	if !ok {
+		t.Fatal("Expected the program to end with a Model")
+	}
+	return m
+}
+
+func TestFlowBotGameSaveAndResume(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	tm := startFlow(t, NewModel(DefaultConfig()), 100, 40)
+	waitForText(t, tm, "New Game")
+
+	// New Game > Player vs Bot > Easy > no handicap > White
+	sendKeys(tm, "n", "down", "enter")
+	waitForText(t, tm, "Select Bot Difficulty")
+	sendKeys(tm, "up", "enter")
+	waitForText(t, tm, "Handicap")
+	sendKeys(tm, "enter")
+	waitForText(t, tm, "Choose Color")
+	sendKeys(tm, "enter")
+	waitForText(t, tm, "vs Easy Bot")
+
+	// The bot answers the move by itself
+	sendKeys(tm, "e4", "enter")
+	waitForText(t, tm, "Move 2", "White to move")
+
+	// Quitting offers to save the game, and saving leaves it for the main menu
+	sendKeys(tm, "q")
+	waitForText(t, tm, "Save current game before exiting?")
+	sendKeys(tm, "y")
+	waitForText(t, tm, "Game saved!", "Resume Game")
+	sendKeys(tm, "q")
+	finalModel(t, tm)
+
+	saved, err := config.LoadGame()
+	if err != nil {
+		t.Fatalf("LoadGame() error = %v", err)
+	}
+	if saved.FullMoveNum != 2 || saved.ActiveColor != engine.White {
+		t.Fatalf("Expected the game to be saved after the bot's reply, got %s", saved.ToFEN())
+	}
+
+	// The next start resumes it from the main menu
+	tm = startFlow(t, NewModel(DefaultConfig()), 100, 40)
+	waitForText(t, tm, "Resume Game")
+	sendKeys(tm, "enter")
+	waitForText(t, tm, "Game resumed")
+	sendKeys(tm, "d4", "enter")
+	waitForText(t, tm, "Move 3", "White to move")
+	tm.Send(tea.KeyMsg{Type: tea.KeyCtrlC})
+
+	resumed := finalModel(t, tm)
+	if resumed.gameType != GameTypePvBot || resumed.botDifficulty != BotEasy || resumed.userColor != engine.White {
+		t.Errorf("Expected the game against the Easy bot as White, got type %v difficulty %v color %v",
+			resumed.gameType, resumed.botDifficulty, resumed.userColor)
+	}
+	if resumed.game.MoveCount() != 2 {
+		t.Errorf("Expected the move and the bot's reply after resuming, got %d moves", resumed.game.MoveCount())
+	}
+}
+
+func TestFlowCheckmate(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	tm := startFlow(t, NewModel(DefaultConfig()), 100, 40)
+	waitForText(t, tm, "New Game")
+	sendKeys(tm, "n", "enter")
+	waitForText(t, tm, "Player vs Player")
+
+	for _, move := range []string{"f3", "e5", "g4", "d8h4"} {
+		sendKeys(tm, move, "enter")
+	}
+	// The mate stays on the board for a moment before the game over screen
+	waitForText(t, tm, "Checkmate! Black wins", "Rematch")
+
+	sendKeys(tm, "q")
+	m := finalModel(t, tm)
+	if m.screen != ScreenGameOver || m.game.Board().Status() != engine.Checkmate {
+		t.Errorf("Expected to quit from the game over screen after the mate, got screen %v", m.screen)
+	}
+}
+
+func TestFlowTerminalResize(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	tm := startFlow(t, NewModel(DefaultConfig()), 100, 40)
+	waitForText(t, tm, "New Game")
+	sendKeys(tm, "n", "enter")
+	waitForText(t, tm, "Player vs Player")
+
+	tm.Send(tea.WindowSizeMsg{Width: 30, Height: 10})
+	waitForText(t, tm, "Terminal too small")
+
+	// The game goes on where it was once the terminal is big enough again
+	tm.Send(tea.WindowSizeMsg{Width: 80, Height: 30})
+	waitForText(t, tm, "White to move")
+	sendKeys(tm, "e4", "enter")
+	waitForText(t, tm, "Black to move")
+
+	tm.Send(tea.KeyMsg{Type: tea.KeyCtrlC})
+	m := finalModel(t, tm)
+	if m.termWidth != 80 || m.termHeight != 30 || m.game.MoveCount() != 1 {
+		t.Errorf("Expected one move at 80x30, got %d moves at %dx%d", m.game.MoveCount(), m.termWidth, m.termHeight)
+	}
+}
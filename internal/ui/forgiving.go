@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// ParseMoveForgiving parses a move typed by a player. It tries SAN, then
+// coordinate notation, and then forgives common slips by comparing the input
+// with every legal move after normalizing both: piece letters in lowercase
+// ("nf3"), a missing capture 'x' ("Nd5" for "Nxd5"), dashes ("e2-e4",
+// "Ng1-f3") and zeros for castling ("0-0"). Pieces are written with the
+// letters of language, and errors name moves with them too. When nothing
+// matches, the error suggests the closest legal move, if one is close enough.
+func ParseMoveForgiving(b *engine.Board, input, language string) (engine.Move, error) {
+	english := EnglishPieces(input, language)
+	move, sanErr := engine.ParseSAN(b, english)
+	if sanErr == nil {
+		return move, nil
+	}
+	if move, err := engine.ParseMove(input); err == nil {
+		return move, nil
+	}
+	if english != input {
+		// Name the typed move in the player's letters rather than the English ones
+		sanErr = errors.New(LocalizePieces(sanErr.Error(), language))
+	}
+
+	typed := normalizeMoveText(input)
+	if typed == "" {
+		return engine.Move{}, sanErr
+	}
+
+	var matches []engine.Move
+	var matchSANs []string
+	closest, closestDist := "", -1
+	for _, move := range b.LegalMoves() {
+		san := LocalizePieces(engine.FormatSAN(b, move), language)
+		dist := -1
+		for _, form := range moveForms(b, move, san, language) {
+			d := editDistance(typed, form)
+			if dist < 0 || d < dist {
+				dist = d
+			}
+		}
+		if dist == 0 {
+			matches = append(matches, move)
+			matchSANs = append(matchSANs, san)
+		} else if closestDist < 0 || dist < closestDist ||
+			(dist == closestDist && san[0] == input[0] && closest[0] != input[0]) {
+			// On a tie, prefer a move that starts like the input, so "Nf4" suggests
+			// a knight move rather than a pawn push
+			closest, closestDist = san, dist
+		}
+	}
+
+	switch {
+	case len(matches) == 1:
+		return matches[0], nil
+	case len(matches) > 1:
+		return engine.Move{}, fmt.Errorf("ambiguous move %s: did you mean %s?", input, strings.Join(matchSANs, " or "))
+	case closestDist > 0 && closestDist <= maxSuggestionDistance(typed):
+		return engine.Move{}, fmt.Errorf("%w (did you mean %s?)", sanErr, closest)
+	}
+	return engine.Move{}, sanErr
+}
+
+// moveForms returns the normalized spellings a player might use for move:
+// its SAN, coordinate and long algebraic notation, with the piece letters of
+// language.
+func moveForms(b *engine.Board, move engine.Move, san, language string) []string {
+	coordinate := move.String()
+	forms := []string{normalizeMoveText(san), normalizeMoveText(coordinate)}
+	if piece := b.PieceAt(move.From); piece.Type() != engine.Pawn {
+		long := LocalizePieces(string(engine.PieceLetter(piece.Type())), language) + coordinate
+		forms = append(forms, normalizeMoveText(long))
+	}
+	return forms
+}
+
+// normalizeMoveText reduces a move to the characters that identify it, in
+// upper case: check, capture, promotion and annotation marks are dropped and
+// castling zeros become the letter O.
+func normalizeMoveText(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(strings.TrimSpace(s)) {
+		switch r {
+		case '+', '#', '!', '?', 'X', ':', '-', '=', ' ':
+			continue
+		case '0':
+			r = 'O'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// maxSuggestionDistance is how many edits a typed move may be away from a
+// legal move for it to be suggested. Short inputs get less slack, so a
+// stray letter does not suggest an arbitrary pawn push.
+func maxSuggestionDistance(typed string) int {
+	if len(typed) <= 3 {
+		return 1
+	}
+	return 2
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
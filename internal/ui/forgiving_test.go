@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+func TestParseMoveForgiving(t *testing.T) {
+	// Position after 1.e4 d5 2.Nf3 Nc6 3.Bc4 with castling available to White
+	board, err := engine.FromFEN("r1bqkbnr/ppp1pppp/2n5/3p4/2B1P3/5N2/PPPP1PPP/RNBQK2R b KQkq - 3 3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	board.ActiveColor = engine.White
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Bb5", "c4b5"},
+		{"bb5", "c4b5"},
+		{"nc3", "b1c3"},
+		{"exd5", "e4d5"},
+		{"ed5", "e4d5"},
+		{"Bd5", "c4d5"},
+		{"bxd5", "c4d5"},
+		{"e4-e5", "e4e5"},
+		{"Nf3-g5", "f3g5"},
+		{"0-0", "e1g1"},
+		{"o-o", "e1g1"},
+		{"Rf1", "h1f1"},
+	}
+	for _, tt := range tests {
+		move, err := ParseMoveForgiving(board, tt.input, LanguageEnglish)
+		if err != nil {
+			t.Errorf("ParseMoveForgiving(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if move.String() != tt.want {
+			t.Errorf("ParseMoveForgiving(%q) = %s, want %s", tt.input, move, tt.want)
+		}
+	}
+}
+
+func TestParseMoveForgiving_Errors(t *testing.T) {
+	// A bishop capture and a pawn capture can both be spelled "bc3"
+	board, err := engine.FromFEN("4k3/8/8/8/8/2p5/1P1B4/4K3 w - - 0 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = ParseMoveForgiving(board, "bc3", LanguageEnglish)
+	if err == nil || !strings.Contains(err.Error(), "bxc3 or Bxc3") {
+		t.Errorf("expected an ambiguity error naming both moves, got %v", err)
+	}
+
+	board = engine.NewBoard()
+	_, err = ParseMoveForgiving(board, "Nf4", LanguageEnglish)
+	if err == nil || !strings.Contains(err.Error(), "did you mean Nf3?") {
+		t.Errorf("expected Nf3 to be suggested for Nf4, got %v", err)
+	}
+
+	_, err = ParseMoveForgiving(board, "zz", LanguageEnglish)
+	if err == nil || strings.Contains(err.Error(), "did you mean") {
+		t.Errorf("expected no suggestion for nonsense input, got %v", err)
+	}
+}
+
+func TestParseMoveForgiving_Language(t *testing.T) {
+	board := engine.NewBoard()
+
+	tests := []struct {
+		language, input, want string
+	}{
+		{LanguageGerman, "Sf3", "g1f3"},
+		{LanguageGerman, "sf3", "g1f3"},
+		{LanguageGerman, "Nf3", "g1f3"},
+		{LanguageFrench, "Cc3", "b1c3"},
+		{LanguageSpanish, "cc3", "b1c3"},
+		{LanguageFrench, "e2-e4", "e2e4"},
+	}
+	for _, tt := range tests {
+		move, err := ParseMoveForgiving(board, tt.input, tt.language)
+		if err != nil {
+			t.Errorf("ParseMoveForgiving(%q, %q) returned error: %v", tt.input, tt.language, err)
+			continue
+		}
+		if move.String() != tt.want {
+			t.Errorf("ParseMoveForgiving(%q, %q) = %s, want %s", tt.input, tt.language, move, tt.want)
+		}
+	}
+
+	// Errors and suggestions use the player's letters
+	_, err := ParseMoveForgiving(board, "Sf4", LanguageGerman)
+	if err == nil || !strings.Contains(err.Error(), "Sf4") || !strings.Contains(err.Error(), "did you mean Sf3?") {
+		t.Errorf("expected a German error suggesting Sf3, got %v", err)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"NF3", "NF3", 0},
+		{"NF4", "NF3", 1},
+		{"KE2", "E3", 2},
+		{"", "E4", 2},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
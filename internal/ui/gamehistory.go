@@ -0,0 +1,443 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/config"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// pgnTagsTag is the PGN tag pair holding the tags a game was given in the
+// game history, e.g. [Tags "instructive, endgame"].
+const pgnTagsTag = "Tags"
+
+// historyGame is a game of the game history: a PGN file in the games directory.
+type historyGame struct {
+	path string
+	game *PGNGame
+}
+
+// tags returns the tags the game was given.
+func (g historyGame) tags() []string {
+	return parseHistoryTags(g.game.Tags[pgnTagsTag])
+}
+
+// historyDir returns the directory of the game history, the games directory of
+// the data directory that Export PGN writes to.
+func historyDir() (string, error) {
+	dataDir, err := config.GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dataDir, "games"), nil
+}
+
+// loadGameHistory reads the PGN games in dir, newest first. Files that aren't
+// valid PGN are skipped; a missing dir is an empty history.
+func loadGameHistory(dir string) ([]historyGame, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pgn"))
+	if err != nil {
+		return nil, err
+	}
+	// Files are named after the time they were saved
+	sort.Sort(sort.Reverse(sort.StringSlice(paths)))
+
+	var games []historyGame
+	for _, path := range paths {
+		data, err := config.ReadEncodedFile(path)
+		if err != nil {
+			continue
+		}
+		game, err := ParsePGN(strings.ReplaceAll(string(data), "\r\n", "\n"))
+		if err != nil {
+			continue
+		}
+		games = append(games, historyGame{path: path, game: game})
+	}
+	return games, nil
+}
+
+// historyGameKey identifies a game regardless of its comments and tags: the
+// same players, date, starting position and moves make the same game.
+func historyGameKey(game *PGNGame) string {
+	moves := make([]string, len(game.Moves))
+	for i, move := range game.Moves {
+		moves[i] = move.String()
+	}
+	return strings.Join([]string{
+		game.Tags["White"], game.Tags["Black"], game.Tags["Date"], game.StartFEN, strings.Join(moves, " "),
+	}, "|")
+}
+
+// findHistoryDuplicate returns the game of games that is the same game as game.
+func findHistoryDuplicate(games []historyGame, game *PGNGame) (historyGame, bool) {
+	key := historyGameKey(game)
+	for _, g := range games {
+		if historyGameKey(g.game) == key {
+			return g, true
+		}
+	}
+	return historyGame{}, false
+}
+
+// addToHistory saves the PGN game pgn to the game history in dir, or in the
+// default history directory if dir is empty. A game already in the history
+// isn't saved twice: the path of the copy there is returned with duplicate set.
+func addToHistory(pgn, dir string, now time.Time) (path string, duplicate bool, err error) {
+	game, err := ParsePGN(strings.ReplaceAll(pgn, "\r\n", "\n"))
+	if err != nil {
+		return "", false, fmt.Errorf("invalid PGN: %w", err)
+	}
+	if dir == "" {
+		if dir, err = historyDir(); err != nil {
+			return "", false, err
+		}
+	}
+	games, err := loadGameHistory(dir)
+	if err != nil {
+		return "", false, err
+	}
+	if existing, ok := findHistoryDuplicate(games, game); ok {
+		return existing.path, true, nil
+	}
+	path, err = saveGamePGN(pgn, dir, now)
+	return path, false, err
+}
+
+// parseHistoryTags parses a comma-separated list of tags. Tags are lowercased
+// and trimmed, and empty and repeated tags are dropped.
+func parseHistoryTags(s string) []string {
+	var tags []string
+	for _, tag := range strings.Split(s, ",") {
+		tag = strings.ToLower(strings.TrimSpace(strings.ReplaceAll(tag, `"`, "")))
+		if tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// setHistoryTags gives the game in the PGN file at path the tags, replacing
+// its Tags tag pair or adding one after the other tag pairs. No tags removes it.
+func setHistoryTags(path string, tags []string) error {
+	data, err := config.ReadEncodedFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read game: %w", err)
+	}
+
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	tagLine := fmt.Sprintf("[%s \"%s\"]", pgnTagsTag, strings.Join(tags, ", "))
+	headerEnd := 0
+	found := false
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(line, "[") {
+			if line != "" || headerEnd > 0 {
+				break
+			}
+			continue
+		}
+		if match := pgnTagPattern.FindStringSubmatch(line); match != nil && match[1] == pgnTagsTag {
+			found = true
+			if len(tags) == 0 {
+				lines = slices.Delete(lines, i, i+1)
+				i--
+				continue
+			}
+			lines[i] = tagLine
+		}
+		headerEnd = i + 1
+	}
+	if !found && len(tags) > 0 {
+		lines = slices.Insert(lines, headerEnd, tagLine)
+	}
+
+	if err := config.WriteEncodedFile(path, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+		return fmt.Errorf("failed to write game: %w", err)
+	}
+	return nil
+}
+
+// matchesHistoryQuery reports whether game matches the free-text query: every
+// word of it must be found, ignoring case, in the game's tags, players, opening,
+// event or file name.
+func matchesHistoryQuery(g historyGame, query string) bool {
+	fields := []string{filepath.Base(g.path)}
+	fields = append(fields, g.tags()...)
+	for _, tag := range []string{"White", "Black", "Opening", "Variation", "ECO", "Event"} {
+		fields = append(fields, g.game.Tags[tag])
+	}
+	text := strings.ToLower(strings.Join(fields, "\n"))
+	for _, word := range strings.Fields(strings.ToLower(query)) {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// openGameHistory navigates to the Game History screen.
+func (m *Model) openGameHistory() {
+	search := textinput.New()
+	search.Placeholder = "Search tags, players, openings"
+	search.CharLimit = 60
+	search.Width = 40
+	search.Focus()
+
+	m.pushScreen(ScreenGameHistory)
+	m.historySearch = search
+	m.historySelection = 0
+	m.historyEditingTags = false
+	m.dismissToasts()
+	m.loadGameHistory()
+}
+
+// loadGameHistory reads the game history into the model.
+func (m *Model) loadGameHistory() {
+	m.historyGames = nil
+	dir, err := historyDir()
+	if err != nil {
+		m.notify(SeverityError, fmt.Sprintf("Failed to find the game history: %v", err))
+		return
+	}
+	games, err := loadGameHistory(dir)
+	if err != nil {
+		m.notify(SeverityError, fmt.Sprintf("Failed to load the game history: %v", err))
+		return
+	}
+	m.historyGames = games
+	if m.historySelection >= len(m.historyMatches()) {
+		m.historySelection = 0
+	}
+}
+
+// historyMatches returns the games of the history matching the search.
+func (m Model) historyMatches() []historyGame {
+	var matches []historyGame
+	for _, g := range m.historyGames {
+		if matchesHistoryQuery(g, m.historySearch.Value()) {
+			matches = append(matches, g)
+		}
+	}
+	return matches
+}
+
+// handleGameHistoryKeys handles keyboard input on the Game History screen.
+// Typing searches, up/down select a game, enter opens it and tab edits its
+// tags. A PGN game pasted in is imported.
+func (m Model) handleGameHistoryKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.historyEditingTags {
+		return m.handleHistoryTagsKeys(msg)
+	}
+	if msg.Paste && strings.Contains(string(msg.Runes), "[") {
+		return m.importHistoryGame(string(msg.Runes)), nil
+	}
+
+	matches := m.historyMatches()
+	var cmd tea.Cmd
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.popScreen()
+		return m, nil
+
+	case tea.KeyUp:
+		if m.historySelection > 0 {
+			m.historySelection--
+		} else if len(matches) > 0 {
+			m.historySelection = len(matches) - 1
+		}
+
+	case tea.KeyDown:
+		if m.historySelection < len(matches)-1 {
+			m.historySelection++
+		} else {
+			m.historySelection = 0
+		}
+
+	case tea.KeyEnter:
+		if len(matches) == 0 {
+			m.notify(SeverityError, "No game selected")
+			return m, nil
+		}
+		return m.openHistoryGame(matches[m.historySelection])
+
+	case tea.KeyTab:
+		if len(matches) == 0 {
+			m.notify(SeverityError, "No game selected")
+			return m, nil
+		}
+		tags := textinput.New()
+		tags.Placeholder = "instructive, blunderfest"
+		tags.CharLimit = 100
+		tags.Width = 40
+		tags.SetValue(strings.Join(matches[m.historySelection].tags(), ", "))
+		tags.Focus()
+		m.historyTags = tags
+		m.historyEditingTags = true
+		m.historySearch.Blur()
+
+	default:
+		m.historySearch, cmd = m.historySearch.Update(msg)
+		m.historySelection = 0
+	}
+
+	m.dismissToasts(SeverityError)
+	return m, cmd
+}
+
+// handleHistoryTagsKeys handles keyboard input while the tags of the selected
+// game are edited: enter saves them and ESC cancels.
+func (m Model) handleHistoryTagsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.historyEditingTags = false
+		m.historySearch.Focus()
+
+	case tea.KeyEnter:
+		m.historyEditingTags = false
+		m.historySearch.Focus()
+		matches := m.historyMatches()
+		if m.historySelection >= len(matches) {
+			return m, nil
+		}
+		path := matches[m.historySelection].path
+		if err := setHistoryTags(path, parseHistoryTags(m.historyTags.Value())); err != nil {
+			m.notify(SeverityError, fmt.Sprintf("Failed to save tags: %v", err))
+			return m, nil
+		}
+		m.loadGameHistory()
+		// Keep the game selected, unless its new tags no longer match the search
+		if i := slices.IndexFunc(m.historyMatches(), func(g historyGame) bool { return g.path == path }); i >= 0 {
+			m.historySelection = i
+		}
+		m.notify(SeverityInfo, "Tags saved")
+
+	default:
+		m.historyTags, cmd = m.historyTags.Update(msg)
+	}
+	return m, cmd
+}
+
+// importHistoryGame adds the pasted PGN game to the history, unless it's already there.
+func (m Model) importHistoryGame(pgn string) Model {
+	path, duplicate, err := addToHistory(pgn, "", time.Now())
+	switch {
+	case err != nil:
+		m.notify(SeverityError, fmt.Sprintf("Failed to import game: %v", err))
+		return m
+	case duplicate:
+		m.notify(SeverityWarning, fmt.Sprintf("Game already in the history as %s", filepath.Base(path)))
+	default:
+		m.notify(SeverityInfo, fmt.Sprintf("Game imported to %s", filepath.Base(path)))
+	}
+
+	m.historySearch.SetValue("")
+	m.loadGameHistory()
+	m.historySelection = max(slices.IndexFunc(m.historyGames, func(g historyGame) bool { return g.path == path }), 0)
+	return m
+}
+
+// openHistoryGame loads game, continuing it from its last position or showing
+// how it ended.
+func (m Model) openHistoryGame(game historyGame) (tea.Model, tea.Cmd) {
+	data, err := config.ReadEncodedFile(game.path)
+	if err != nil {
+		m.notify(SeverityError, fmt.Sprintf("Failed to load game: %v", err))
+		return m, nil
+	}
+	next, err := m.StartGame(StartOptions{PGN: strings.ReplaceAll(string(data), "\r\n", "\n")})
+	if err != nil {
+		m.notify(SeverityError, fmt.Sprintf("Failed to load game: %v", err))
+		return m, nil
+	}
+	return next, nil
+}
+
+// describeHistoryGame returns the line describing game in the history list:
+// the players, the result, the opening if known and the tags.
+func describeHistoryGame(game historyGame) string {
+	tags := game.game.Tags
+	parts := []string{fmt.Sprintf("%s vs %s", tagOr(tags["White"], "?"), tagOr(tags["Black"], "?"))}
+	if result := tags["Result"]; result != "" && result != "*" {
+		parts = append(parts, result)
+	}
+	if date := tags["Date"]; date != "" && !strings.Contains(date, "?") {
+		parts = append(parts, date)
+	}
+	if opening := tags["Opening"]; opening != "" {
+		parts = append(parts, opening)
+	}
+	line := strings.Join(parts, " - ")
+	if gameTags := game.tags(); len(gameTags) > 0 {
+		line += " [" + strings.Join(gameTags, ", ") + "]"
+	}
+	return line
+}
+
+// tagOr returns value, or fallback if value is empty.
+func tagOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// renderGameHistory renders the Game History screen.
+func (m Model) renderGameHistory() string {
+	var b strings.Builder
+
+	b.WriteString(m.titleStyle().Render("TermChess"))
+	b.WriteString("\n")
+	b.WriteString(m.renderBreadcrumb())
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(m.theme.TitleText).
+		Padding(0, 0, 1, 0)
+	b.WriteString(headerStyle.Render("Game History"))
+	b.WriteString("\n")
+
+	b.WriteString("Search: " + m.historySearch.View())
+	b.WriteString("\n\n")
+
+	matches := m.historyMatches()
+	switch {
+	case len(m.historyGames) == 0:
+		b.WriteString(m.menuPrimaryStyle().Render("No games yet - export a game or paste a PGN here"))
+		b.WriteString("\n")
+	case len(matches) == 0:
+		b.WriteString(m.menuPrimaryStyle().Render("No games match the search"))
+		b.WriteString("\n")
+	}
+	for i, game := range matches {
+		cursor := "  "
+		text := m.menuPrimaryStyle().Render(describeHistoryGame(game))
+		if i == m.historySelection {
+			cursor = m.cursorStyle().Render(">> ")
+			text = m.selectedPrimaryStyle().Render(describeHistoryGame(game))
+		}
+		b.WriteString(fmt.Sprintf("%s%s\n", cursor, text))
+	}
+
+	help := "type to search | up/down: navigate | enter: open game | tab: edit tags | paste PGN: import | ESC: back"
+	if m.historyEditingTags {
+		b.WriteString("\n")
+		b.WriteString("Tags: " + m.historyTags.View())
+		b.WriteString("\n")
+		help = "comma-separated tags | enter: save | ESC: cancel"
+	}
+	if helpText := m.renderHelpText(help); helpText != "" {
+		b.WriteString("\n")
+		b.WriteString(helpText)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,203 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+const historyTestPGN = `[Event "Casual"]
+[White "Alice"]
+[Black "Bob"]
+[Date "2024.03.01"]
+[Result "1-0"]
+[Opening "Italian Game"]
+
+1. e4 e5 2. Nf3 Nc6 3. Bc4 Bc5 1-0
+`
+
+func TestAddToHistoryDetectsDuplicates(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	path, duplicate, err := addToHistory(historyTestPGN, dir, now)
+	if err != nil || duplicate {
+		t.Fatalf("addToHistory() = %q, %v, %v; want a new game", path, duplicate, err)
+	}
+
+	// Comments and other tags don't make it another game
+	again := strings.Replace(historyTestPGN, "1. e4 e5", "1. e4 {[%eval 0.30]} e5", 1)
+	again = strings.Replace(again, `[Event "Casual"]`, `[Event "Casual"]`+"\n"+`[Tags "instructive"]`, 1)
+	got, duplicate, err := addToHistory(again, dir, now.Add(time.Minute))
+	if err != nil || !duplicate || got != path {
+		t.Fatalf("addToHistory() of the same game = %q, %v, %v; want duplicate of %q", got, duplicate, err, path)
+	}
+
+	other := strings.Replace(historyTestPGN, "3. Bc4 Bc5", "3. Bb5 a6", 1)
+	if _, duplicate, err := addToHistory(other, dir, now.Add(2*time.Minute)); err != nil || duplicate {
+		t.Fatalf("addToHistory() of another game = %v, %v; want a new game", duplicate, err)
+	}
+
+	games, err := loadGameHistory(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(games) != 2 {
+		t.Fatalf("history has %d games, want 2", len(games))
+	}
+	if games[0].game.Moves[4].String() != "f1b5" {
+		t.Errorf("Expected the newest game first")
+	}
+
+	if _, _, err := addToHistory("not a game", dir, now); err == nil {
+		t.Error("Expected an error importing invalid PGN")
+	}
+}
+
+func TestSetHistoryTags(t *testing.T) {
+	dir := t.TempDir()
+	path, _, err := addToHistory(historyTestPGN, dir, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	readTags := func() []string {
+		t.Helper()
+		games, err := loadGameHistory(dir)
+		if err != nil || len(games) != 1 {
+			t.Fatalf("loadGameHistory() = %v, %v", games, err)
+		}
+		if len(games[0].game.Moves) != 6 {
+			t.Fatalf("Expected the moves to survive tagging, got %d", len(games[0].game.Moves))
+		}
+		return games[0].tags()
+	}
+
+	if err := setHistoryTags(path, parseHistoryTags("Instructive, blunderfest, , instructive")); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(readTags(), ","); got != "instructive,blunderfest" {
+		t.Errorf("tags = %q, want instructive,blunderfest", got)
+	}
+
+	if err := setHistoryTags(path, []string{"endgame"}); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	if n := strings.Count(string(data), "[Tags "); n != 1 {
+		t.Errorf("Expected the Tags tag pair to be replaced, found %d", n)
+	}
+	if got := strings.Join(readTags(), ","); got != "endgame" {
+		t.Errorf("tags = %q, want endgame", got)
+	}
+
+	if err := setHistoryTags(path, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := readTags(); len(got) != 0 {
+		t.Errorf("Expected no tags, got %v", got)
+	}
+}
+
+func TestMatchesHistoryQuery(t *testing.T) {
+	game, err := ParsePGN(strings.Replace(historyTestPGN, `[Result`, `[Tags "blunderfest"]`+"\n[Result", 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := historyGame{path: "game_2024-03-01_12-00-00.pgn", game: game}
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"", true},
+		{"alice", true},
+		{"BOB italian", true},
+		{"blunder", true},
+		{"2024-03-01", true},
+		{"alice instructive", false},
+		{"sicilian", false},
+	}
+	for _, tt := range tests {
+		if got := matchesHistoryQuery(g, tt.query); got != tt.want {
+			t.Errorf("matchesHistoryQuery(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestGameHistoryScreen(t *testing.T) {
+	m := snapshotModel(t)
+	dir, err := historyDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	if _, _, err := addToHistory(historyTestPGN, dir, now); err != nil {
+		t.Fatal(err)
+	}
+	other := strings.NewReplacer("Alice", "Carol", "Italian Game", "Sicilian Defense").Replace(historyTestPGN)
+	if _, _, err := addToHistory(other, dir, now.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+
+	m = pressKeys(m, "down", "down", "enter")
+	if m.screen != ScreenGameHistory {
+		t.Fatalf("Expected Game History from the main menu, got screen %v", m.screen)
+	}
+	if n := len(m.historyMatches()); n != 2 {
+		t.Fatalf("Expected 2 games, got %d", n)
+	}
+
+	// Typed letters search rather than act as shortcuts
+	m = pressKeys(m, "italian")
+	if matches := m.historyMatches(); len(matches) != 1 || matches[0].game.Tags["White"] != "Alice" {
+		t.Fatalf("Expected the search to find Alice's game, got %v", matches)
+	}
+
+	m = pressKeys(m, "tab", "instructive", "enter")
+	if m.historyEditingTags {
+		t.Fatal("Expected enter to save the tags")
+	}
+	if got := m.historyMatches()[0].tags(); len(got) != 1 || got[0] != "instructive" {
+		t.Errorf("Expected the game tagged instructive, got %v", got)
+	}
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "Alice vs Bob - 1-0 - 2024.03.01 - Italian Game [instructive]") {
+		t.Errorf("Expected the tagged game listed, got\n%s", view)
+	}
+
+	m = pressKeys(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(other), Paste: true})
+	if len(m.historyGames) != 2 {
+		t.Errorf("Expected the duplicate import to be skipped, got %d games", len(m.historyGames))
+	}
+	if toast := lastToast(m, SeverityWarning); !strings.Contains(toast, "already in the history") {
+		t.Errorf("Expected a duplicate warning, got %q", toast)
+	}
+
+	m = pressKeys(m, "enter")
+	if m.screen != ScreenGamePlay || m.game.MoveCount() != 6 {
+		t.Errorf("Expected the game opened with its moves, got screen %v and %d moves", m.screen, m.game.MoveCount())
+	}
+}
+
+func TestExportGamePGNSkipsDuplicates(t *testing.T) {
+	m := pressKeys(snapshotModel(t), "n", "enter", "f3", "enter", "e5", "enter", "g4", "enter", "d8h4", "enter")
+
+	next, _ := m.exportGamePGN()
+	m = next.(Model)
+	next, _ = m.exportGamePGN()
+	m = next.(Model)
+
+	dir, _ := historyDir()
+	files, _ := filepath.Glob(filepath.Join(dir, "*.pgn"))
+	if len(files) != 1 {
+		t.Errorf("Expected the game exported once, got %d files", len(files))
+	}
+	if toast := lastToast(m, SeverityWarning); !strings.Contains(toast, "already exported") {
+		t.Errorf("Expected a duplicate warning, got %q", toast)
+	}
+}
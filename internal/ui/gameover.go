@@ -0,0 +1,226 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/config"
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// showGameOver switches to the game over screen with its menu, and adds the
+// result of a PvBot game to the match score. In a simul it waits for the last
+// board to finish, and a checkmate is shown on the board first.
+func (m *Model) showGameOver() {
+	if m.revealMate() {
+		return
+	}
+	if m.inSimul() {
+		if !m.finishSimulGame() {
+			return
+		}
+	} else if m.screen != ScreenGameOver && m.gameType == GameTypePvBot {
+		m.recordMatchResult()
+	}
+	m.screen = ScreenGameOver
+	m.menuOptions = menuLabels(m.gameOverMenu())
+	m.menuSelection = 0
+}
+
+// canRematch reports whether the finished game can be played again from the
+// game over screen. Correspondence games are started from My Games instead, and
+// a finished match leads to its summary.
+func (m Model) canRematch() bool {
+	return (m.gameType == GameTypePvBot || m.gameType == GameTypePvP) && !m.matchOver() && !m.inSimul()
+}
+
+// gameOverMenu declares the menu of the game over screen.
+func (m Model) gameOverMenu() []MenuItem {
+	var items []MenuItem
+	switch {
+	case m.inSimul():
+		boards := len(m.simulGames)
+		items = append(items, MenuItem{Label: "New Simul", Hint: fmt.Sprintf("%d boards", boards), Action: func(m Model) (tea.Model, tea.Cmd) {
+			return m.startSimul(boards)
+		}})
+	case m.matchOver():
+		items = append(items, MenuItem{Label: "Match Summary", Action: Model.openMatchSummary})
+	case m.canRematch() && m.gameType == GameTypePvBot && m.match.length > 0:
+		items = append(items, MenuItem{Label: "Next Game", Hint: fmt.Sprintf("game %d of up to %d", len(m.match.games)+1, m.match.length),
+			Action: Model.rematch})
+	case m.canRematch() && m.gameType == GameTypePvBot:
+		items = append(items, MenuItem{Label: "Rematch", Hint: "colors swapped", Action: Model.rematch})
+	case m.canRematch():
+		items = append(items, MenuItem{Label: "Rematch", Action: Model.rematch})
+	}
+	return append(items,
+		MenuItem{Label: "Analyze", Action: Model.startAnalysis},
+		MenuItem{Label: "Export PGN", Action: Model.exportGamePGN},
+		MenuItem{Label: "Export GIF", Hint: "animated", Action: Model.exportGameGIF},
+		MenuItem{Label: "Export Cast", Hint: "asciinema", Action: Model.exportGameCast},
+		MenuItem{Label: "Save to History", Action: Model.saveFinalPosition},
+		MenuItem{Label: "Main Menu", Kind: MenuItemSecondary, Separated: true, Action: Model.leaveGameOver},
+		MenuItem{Label: "Quit", Kind: MenuItemSecondary, Action: func(m Model) (tea.Model, tea.Cmd) {
+			if m.botEngine != nil {
+				_ = m.botEngine.Close()
+			}
+			return m, tea.Quit
+		}},
+	)
+}
+
+// rematch starts a new game of the same kind and variant: against the same bot,
+// at the same difficulty and handicap, with the user playing the other color.
+func (m Model) rematch() (tea.Model, tea.Cmd) {
+	var variant engine.Variant
+	if m.game != nil {
+		variant = m.game.Board().Variant
+	}
+
+	if m.gameType == GameTypePvBot {
+		color := engine.White
+		if m.userColor == engine.White {
+			color = engine.Black
+		}
+		return m.startBotGameVariant(color, variant)
+	}
+
+	return m.startPvPGameVariant(variant)
+}
+
+// leaveGameOver returns from the game over or match summary screen to the main menu.
+func (m Model) leaveGameOver() (tea.Model, tea.Cmd) {
+	// Clean up bot engine if it exists
+	if m.botEngine != nil {
+		_ = m.botEngine.Close()
+		m.botEngine = nil
+	}
+	m.endSimul()
+	m.clearNavStack()
+	m.screen = ScreenMainMenu
+	m.game = nil
+	m.input = ""
+	m.dismissToasts()
+	m.menuOptions = buildMainMenuOptions()
+	m.menuSelection = 0
+	return m, nil
+}
+
+// saveFinalPosition adds the final position to the FEN history listed on the
+// Load Game screen.
+func (m Model) saveFinalPosition() (tea.Model, tea.Cmd) {
+	if err := config.AddFENHistory(m.game.Board().ToFEN()); err != nil {
+		m.notify(SeverityError, fmt.Sprintf("Failed to save position: %v", err))
+		return m, nil
+	}
+	m.notify(SeverityInfo, "Final position saved to history")
+	return m, nil
+}
+
+// exportGamePGN writes the game to a PGN file in the games directory of the
+// data directory, the game history, unless it was exported already.
+func (m Model) exportGamePGN() (tea.Model, tea.Cmd) {
+	pgn, err := m.gamePGN(time.Now())
+	if err != nil {
+		m.notify(SeverityError, fmt.Sprintf("Failed to export PGN: %v", err))
+		return m, nil
+	}
+	path, duplicate, err := addToHistory(pgn, "", time.Now())
+	if err != nil {
+		m.notify(SeverityError, fmt.Sprintf("Failed to export PGN: %v", err))
+		return m, nil
+	}
+	if duplicate {
+		m.notify(SeverityWarning, fmt.Sprintf("Game already exported to %s", path))
+		return m, nil
+	}
+	m.notify(SeverityInfo, fmt.Sprintf("Game exported to %s", path))
+	return m, nil
+}
+
+// gamePGN returns the finished game as PGN, played on date.
+func (m Model) gamePGN(date time.Time) (string, error) {
+	movetext, err := formatPGNMovetextComments(m.game.Start(), m.game.Moves(), m.pgnComments())
+	if err != nil {
+		return "", err
+	}
+
+	game := m.spectatorGame()
+	if game.Result == "" {
+		game.Result = "*"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "[Event \"TermChess Game\"]\n")
+	fmt.Fprintf(&b, "[Site \"TermChess\"]\n")
+	fmt.Fprintf(&b, "[Date \"%s\"]\n", date.Format("2006.01.02"))
+	fmt.Fprintf(&b, "[White \"%s\"]\n", game.White)
+	fmt.Fprintf(&b, "[Black \"%s\"]\n", game.Black)
+	fmt.Fprintf(&b, "[Result \"%s\"]\n", game.Result)
+	if m.game.Board().Variant != nil && m.game.Board().Variant.Name() != (engine.Standard{}).Name() {
+		fmt.Fprintf(&b, "[Variant \"%s\"]\n", m.game.Board().Variant.Name())
+	}
+	if startFEN := m.game.Start().ToFEN(); startFEN != engine.NewBoard().ToFEN() {
+		fmt.Fprintf(&b, "[SetUp \"1\"]\n")
+		fmt.Fprintf(&b, "[FEN \"%s\"]\n", startFEN)
+	}
+	fmt.Fprintf(&b, "[Termination \"%s\"]\n\n", game.Status)
+	if movetext != "" {
+		b.WriteString(movetext)
+		b.WriteString(" ")
+	}
+	b.WriteString(game.Result)
+	b.WriteString("\n")
+	return b.String(), nil
+}
+
+// pgnComments returns the comments of the game's PGN: the evaluation of every
+// move once the game has been evaluated, followed by the notes taken.
+func (m Model) pgnComments() map[int][]string {
+	comments := m.noteComments()
+	if len(m.evals) != m.game.MoveCount() {
+		return comments
+	}
+	if comments == nil {
+		comments = make(map[int][]string)
+	}
+	for i, score := range m.evals {
+		comments[i+1] = append([]string{pgnEvalComment(score)}, comments[i+1]...)
+	}
+	return comments
+}
+
+// saveGamePGN writes pgn to a file named after the time it was saved, in dir or,
+// if dir is empty, in the games directory of the data directory. It returns the
+// path of the file. The game is encrypted if saves are.
+func saveGamePGN(pgn, dir string, now time.Time) (string, error) {
+	data, err := config.ActiveCodec().Encode([]byte(pgn))
+	if err != nil {
+		return "", err
+	}
+	return saveGameFile(data, "pgn", dir, now)
+}
+
+// saveGameFile writes data to a file with extension ext named after the time it
+// was saved, in dir or, if dir is empty, in the games directory of the data
+// directory. It returns the path of the file.
+func saveGameFile(data []byte, ext, dir string, now time.Time) (string, error) {
+	if dir == "" {
+		dataDir, err := config.GetDataDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(dataDir, "games")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory: %w", err)
+	}
+	path := filepath.Join(dir, fmt.Sprintf("game_%s.%s", now.Format("2006-01-02_15-04-05"), ext))
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,207 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/config"
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// foolsMateModel returns a model on the game over screen after Fool's mate,
+// played as White against a bot.
+func foolsMateModel(t *testing.T) Model {
+	t.Helper()
+	m := foolsMateOpening(t)
+	playMoves(t, &m, "d8h4")
+	m.showGameOver()
+	return m
+}
+
+// foolsMateOpening returns the game of foolsMateModel before Black mates with Qh4.
+func foolsMateOpening(t *testing.T) Model {
+	t.Helper()
+	m := NewModel(DefaultConfig())
+	m.gameType = GameTypePvBot
+	m.botDifficulty = BotMedium
+	m.userColor = engine.White
+	m.game = engine.NewGame()
+	playMoves(t, &m, "f2f3", "e7e5", "g2g4")
+	return m
+}
+
+// playMoves plays the moves, in UCI notation, on m's board and records them.
+func playMoves(t *testing.T, m *Model, moves ...string) {
+	t.Helper()
+	for _, s := range moves {
+		move, err := engine.ParseMove(s)
+		if err != nil {
+			t.Fatalf("ParseMove(%q) error: %v", s, err)
+		}
+		if err := m.game.MakeMove(move); err != nil {
+			t.Fatalf("MakeMove(%q) error: %v", s, err)
+		}
+	}
+}
+
+func TestGameOverMenu(t *testing.T) {
+	m := foolsMateModel(t)
+
+	want := []string{"Rematch", "Analyze", "Export PGN", "Export GIF", "Export Cast", "Save to History", "Main Menu", "Quit"}
+	if strings.Join(m.menuOptions, ",") != strings.Join(want, ",") {
+		t.Fatalf("Expected menu %v, got %v", want, m.menuOptions)
+	}
+
+	view := ansi.Strip(m.renderGameOver())
+	for _, label := range want {
+		if !strings.Contains(view, label) {
+			t.Errorf("Expected the game over screen to show %q", label)
+		}
+	}
+
+	result, _ := m.handleGameOverKeys(tea.KeyMsg{Type: tea.KeyDown})
+	m = result.(Model)
+	if m.menuSelection != 1 {
+		t.Errorf("Expected down to select the second item, got %d", m.menuSelection)
+	}
+}
+
+func TestGameOverRematchSwapsColors(t *testing.T) {
+	m := foolsMateModel(t)
+	m.game.Board().Variant = engine.Atomic{}
+
+	result, _ := m.handleGameOverKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if m.screen != ScreenGamePlay {
+		t.Fatalf("Expected rematch to start a game, got screen %v", m.screen)
+	}
+	if m.userColor != engine.Black {
+		t.Error("Expected the rematch to swap colors")
+	}
+	if m.botDifficulty != BotMedium || m.gameType != GameTypePvBot {
+		t.Error("Expected the rematch to keep the bot and its difficulty")
+	}
+	if m.game.Board().Variant.Name() != (engine.Atomic{}).Name() {
+		t.Errorf("Expected the rematch to keep the variant, got %s", m.game.Board().Variant.Name())
+	}
+}
+
+func TestGamePGN(t *testing.T) {
+	m := foolsMateModel(t)
+
+	pgn, err := m.gamePGN(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("gamePGN() error: %v", err)
+	}
+	for _, want := range []string{`[Date "2024.03.01"]`, `[Black "Medium Bot"]`, `[Result "0-1"]`, "1. f3 e5 2. g4 Qh4# 0-1"} {
+		if !strings.Contains(pgn, want) {
+			t.Errorf("Expected PGN to contain %q, got:\n%s", want, pgn)
+		}
+	}
+	if strings.Contains(pgn, "[FEN") {
+		t.Error("Expected no FEN tag for a game from the standard position")
+	}
+
+	dir := t.TempDir()
+	path, err := saveGamePGN(pgn, dir, time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("saveGamePGN() error: %v", err)
+	}
+	if path != filepath.Join(dir, "game_2024-03-01_12-30-00.pgn") {
+		t.Errorf("Unexpected export path %s", path)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != pgn {
+		t.Errorf("Expected the file to hold the PGN, got %q (%v)", data, err)
+	}
+}
+
+func TestGamePGNStoresEvals(t *testing.T) {
+	m := foolsMateModel(t)
+	m.evals = []float64{-0.4, -0.3, -9.9, -10000}
+	m.notes = []gameNote{{ply: 3, text: "oops"}}
+
+	pgn, err := m.gamePGN(time.Now())
+	if err != nil {
+		t.Fatalf("gamePGN() error: %v", err)
+	}
+	if want := "2. g4 {[%eval -9.90]} {oops} 2... Qh4# {[%eval -10000.00]} 0-1"; !strings.Contains(pgn, want) {
+		t.Errorf("Expected PGN to contain %q, got:\n%s", want, pgn)
+	}
+	game, err := ParsePGN(pgn)
+	if err != nil {
+		t.Fatalf("ParsePGN() error: %v", err)
+	}
+	if len(game.Evals) != 4 || game.Evals[2] != -9.9 {
+		t.Errorf("Expected the evaluations read back, got %v", game.Evals)
+	}
+}
+
+func TestGameOverSaveToHistory(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := foolsMateModel(t)
+
+	result, _ := m.saveFinalPosition()
+	m = result.(Model)
+	history, err := config.LoadFENHistory()
+	if err != nil {
+		t.Fatalf("LoadFENHistory() error: %v", err)
+	}
+	if len(history) == 0 || history[0] != m.game.Board().ToFEN() {
+		t.Errorf("Expected the final position in the history, got %v", history)
+	}
+	if !strings.Contains(toastStatus(m), "saved to history") {
+		t.Errorf("Expected a confirmation, got %q", toastStatus(m))
+	}
+}
+
+func TestMatchScore(t *testing.T) {
+	var s matchScore
+	s.record(engine.White, "1-0")
+	s.record(engine.Black, "1/2-1/2")
+	s.record(engine.Black, "1-0")
+	if got := s.String(); got != "1.5–1.5" {
+		t.Errorf("Expected score 1.5–1.5, got %q", got)
+	}
+	if len(s.games) != 3 {
+		t.Errorf("Expected 3 games, got %d", len(s.games))
+	}
+	if s.decided() {
+		t.Error("Expected an open-ended match never to be decided")
+	}
+}
+
+func TestRematchKeyKeepsMatchScore(t *testing.T) {
+	m := foolsMateModel(t)
+	if got := m.match.String(); got != "0–1" {
+		t.Fatalf("Expected the loss to be scored 0–1, got %q", got)
+	}
+	if !strings.Contains(ansi.Strip(m.renderGameOver()), "0–1 Medium Bot") {
+		t.Error("Expected the game over screen to show the match score")
+	}
+
+	result, _ := m.handleGameOverKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	m = result.(Model)
+	if m.screen != ScreenGamePlay || m.userColor != engine.Black {
+		t.Fatalf("Expected 'r' to start a rematch as Black, got screen %v", m.screen)
+	}
+	if !strings.Contains(ansi.Strip(m.renderGamePlay()), "Match: ") {
+		t.Error("Expected the rematch to show the match score")
+	}
+
+	m.game.AgreeDraw()
+	m.showGameOver()
+	m.showGameOver()
+	if got := m.match.String(); got != "0.5–1.5" {
+		t.Errorf("Expected the draw to be scored once, got %q", got)
+	}
+
+	result, _ = m.startBotGame(engine.White)
+	if m = result.(Model); len(m.match.games) != 0 {
+		t.Error("Expected a game started from the menus to begin a new match")
+	}
+}
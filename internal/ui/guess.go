@@ -0,0 +1,357 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Mgrdich/TermChess/internal/bot"
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// guessMaxPoints is the score of a guess that finds the move played in the game.
+// A different move scores by how much evaluation it gives away compared to it.
+const guessMaxPoints = 3
+
+// GuessScoredMsg is sent when the engine has compared a guess with the move played.
+type GuessScoredMsg struct {
+	// ply is the index of the guessed move in the game
+	ply        int
+	guess      engine.Move
+	comparison *bot.MoveComparison
+}
+
+// GuessScoreErrorMsg is sent when a guess could not be compared with the move played.
+type GuessScoreErrorMsg struct {
+	ply int
+	err error
+}
+
+// guessSession is a guess-the-move training session: the user guesses one side's
+// moves of a master game, and the other side's moves are played for them.
+type guessSession struct {
+	game  *PGNGame
+	title string
+	// side is the color whose moves are guessed, the winner's (White after a draw)
+	side engine.Color
+	// ply is the number of moves of the game played on board
+	ply   int
+	board *engine.Board
+	// score is the running score over guessed moves, out of guessMaxPoints each
+	score   int
+	guessed int
+	exact   int
+	// verdict describes the last guess
+	verdict    string
+	evaluating bool
+	cancel     context.CancelFunc
+}
+
+// finished reports whether every move of the game has been played.
+func (s guessSession) finished() bool {
+	return s.ply >= len(s.game.Moves)
+}
+
+// maxScore returns the score of a session in which every guess found the move played.
+func (s guessSession) maxScore() int {
+	return s.guessed * guessMaxPoints
+}
+
+// masterGameTitle names a game by its players, e.g. "Paul Morphy vs Duke Karl / Count Isouard".
+func masterGameTitle(game *PGNGame) string {
+	return fmt.Sprintf("%s vs %s", game.Tags["White"], game.Tags["Black"])
+}
+
+// masterGamePlace returns where and when a game was played, e.g. "Paris 1858".
+func masterGamePlace(game *PGNGame) string {
+	year, _, _ := strings.Cut(game.Tags["Date"], ".")
+	return strings.TrimSpace(game.Tags["Site"] + " " + strings.Trim(year, "?"))
+}
+
+// guessGameMenu declares the menu of bundled master games to train on.
+func guessGameMenu() []MenuItem {
+	var items []MenuItem
+	for _, pgn := range masterGames {
+		game, err := ParsePGN(pgn)
+		if err != nil {
+			continue
+		}
+		items = append(items, MenuItem{Label: masterGameTitle(game), Hint: masterGamePlace(game), Action: func(m Model) (tea.Model, tea.Cmd) {
+			return m.startGuessGame(game)
+		}})
+	}
+	return items
+}
+
+// startGuessGame starts guessing the moves of the winner of game, from its
+// starting position.
+func (m Model) startGuessGame(game *PGNGame) (tea.Model, tea.Cmd) {
+	board, err := engine.FromFEN(game.StartFEN)
+	if err != nil {
+		m.notify(SeverityError, fmt.Sprintf("Cannot start game: %v", err))
+		return m, nil
+	}
+	side := engine.White
+	if game.Tags["Result"] == "0-1" {
+		side = engine.Black
+	}
+
+	m.guess = guessSession{game: game, title: masterGameTitle(game), side: side, board: board}
+	m.playGuessOpponentMoves()
+	m.input = ""
+	m.dismissToasts()
+	m.pushScreen(ScreenGuessMove)
+	return m, nil
+}
+
+// playGuessOpponentMoves plays the moves of the side that isn't guessed, up to
+// the next move to guess.
+func (m *Model) playGuessOpponentMoves() {
+	for !m.guess.finished() && m.guess.board.ActiveColor != m.guess.side {
+		m.playGuessMove()
+	}
+}
+
+// playGuessMove plays the next move of the game.
+func (m *Model) playGuessMove() {
+	_ = m.guess.board.MakeMove(m.guess.game.Moves[m.guess.ply])
+	m.guess.ply++
+}
+
+// handleGuessSelectKeys handles keyboard input for the list of master games.
+func (m Model) handleGuessSelectKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.dismissToasts()
+	if m.keys.Matches(msg, ActionBack) {
+		m.popScreen()
+		return m, nil
+	}
+	return m.updateMenu(msg)
+}
+
+// handleGuessMoveKeys handles keyboard input for the guess-the-move screen.
+// Typed moves are guesses, Enter submits them and ESC returns to the list of games.
+func (m Model) handleGuessMoveKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "esc" {
+		m.stopGuessEvaluation()
+		m.input = ""
+		m.dismissToasts()
+		m.popScreen()
+		return m, nil
+	}
+
+	switch msg.Type {
+	case tea.KeyBackspace:
+		if len(m.input) > 0 {
+			m.input = m.input[:len(m.input)-1]
+		}
+		m.dismissToasts(SeverityError)
+	case tea.KeyEnter:
+		if m.guess.finished() {
+			m.popScreen()
+			return m, nil
+		}
+		if m.input != "" && !m.guess.evaluating {
+			return m.submitGuess()
+		}
+	case tea.KeyRunes:
+		m.dismissToasts(SeverityError)
+		m.input += string(msg.Runes)
+	}
+	return m, nil
+}
+
+// submitGuess checks the typed guess against the move played in the game. A
+// guess that finds it scores in full straight away; any other legal move is
+// compared with it by the engine in the background.
+func (m Model) submitGuess() (tea.Model, tea.Cmd) {
+	board := m.guess.board
+	guess, err := ParseMoveForgiving(board, m.input, m.config.Language)
+	if err != nil || !board.IsLegalMove(guess) {
+		m.notify(SeverityError, fmt.Sprintf("Illegal move: %s", m.input))
+		return m, nil
+	}
+	m.input = ""
+
+	actual := m.guess.game.Moves[m.guess.ply]
+	if guess == actual {
+		return m.scoreGuess(guess, &bot.MoveComparison{}), nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.guess.evaluating = true
+	m.guess.cancel = cancel
+
+	ply := m.guess.ply
+	position := board.Copy()
+	compareCmd := func() tea.Msg {
+		comparison, err := bot.CompareMoves(ctx, position, guess, actual, bot.DefaultAnalysisDepth)
+		if err != nil {
+			return GuessScoreErrorMsg{ply: ply, err: err}
+		}
+		return GuessScoredMsg{ply: ply, guess: guess, comparison: comparison}
+	}
+	return m, tea.Batch(compareCmd, m.botSpinner.Tick)
+}
+
+// handleGuessScored scores a guess the engine has compared with the move played.
+func (m Model) handleGuessScored(msg GuessScoredMsg) (tea.Model, tea.Cmd) {
+	if !m.guess.evaluating || msg.ply != m.guess.ply {
+		return m, nil
+	}
+	m.stopGuessEvaluation()
+	return m.scoreGuess(msg.guess, msg.comparison), nil
+}
+
+// handleGuessScoreError reports a guess that could not be compared. The guess
+// isn't scored, so the user can try again.
+func (m Model) handleGuessScoreError(msg GuessScoreErrorMsg) (tea.Model, tea.Cmd) {
+	if !m.guess.evaluating || msg.ply != m.guess.ply {
+		return m, nil
+	}
+	m.stopGuessEvaluation()
+	m.notify(SeverityError, fmt.Sprintf("Cannot evaluate guess: %v", msg.err))
+	return m, nil
+}
+
+// stopGuessEvaluation cancels a running comparison and clears the progress indicator.
+func (m *Model) stopGuessEvaluation() {
+	if m.guess.cancel != nil {
+		m.guess.cancel()
+		m.guess.cancel = nil
+	}
+	m.guess.evaluating = false
+}
+
+// guessPoints grades a guess by the evaluation it gives away compared to the
+// move played: as good as it scores 2 points and close to it 1.
+func guessPoints(guess, actual engine.Move, c *bot.MoveComparison) int {
+	switch {
+	case guess == actual:
+		return guessMaxPoints
+	case c.CentipawnLoss < 50:
+		return 2
+	case c.CentipawnLoss < 100:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// scoreGuess adds a guess to the score, then plays the move of the game and the
+// reply, up to the next move to guess.
+func (m Model) scoreGuess(guess engine.Move, c *bot.MoveComparison) Model {
+	board := m.guess.board
+	actual := m.guess.game.Moves[m.guess.ply]
+	points := guessPoints(guess, actual, c)
+
+	m.guess.score += points
+	m.guess.guessed++
+	played := fmt.Sprintf("%s %s", moveNumberPrefix(board), engine.FormatSAN(board, actual))
+	if guess == actual {
+		m.guess.exact++
+		m.guess.verdict = fmt.Sprintf("%s - found the move played! +%d", played, points)
+	} else {
+		m.guess.verdict = fmt.Sprintf("%s: +%d - the game went %s (%s vs %s)", engine.FormatSAN(board, guess), points,
+			played, formatEval(c.Score), formatEval(c.ReferenceScore))
+	}
+
+	m.playGuessMove()
+	m.playGuessOpponentMoves()
+	return m
+}
+
+// renderGuessSelect renders the list of master games to train on.
+func (m Model) renderGuessSelect() string {
+	var b strings.Builder
+
+	b.WriteString(m.titleStyle().Render("TermChess"))
+	b.WriteString("\n")
+	b.WriteString(m.renderBreadcrumb())
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(m.theme.TitleText).
+		Padding(0, 0, 1, 0)
+	b.WriteString(headerStyle.Render("Guess the Move - choose a master game:"))
+	b.WriteString("\n")
+
+	b.WriteString(m.renderMenu(m.menuSelection))
+
+	helpText := m.renderHelpText("ESC: back | arrows/jk: navigate | enter: start | you guess the winner's moves")
+	if helpText != "" {
+		b.WriteString("\n\n")
+		b.WriteString(helpText)
+	}
+
+	return b.String()
+}
+
+// renderGuessMove renders the guess-the-move screen: the game so far, the
+// verdict on the last guess, the running score and the prompt for the next guess.
+func (m Model) renderGuessMove() string {
+	var b strings.Builder
+	s := m.guess
+
+	b.WriteString(m.titleStyle().Render("TermChess"))
+	b.WriteString("\n")
+	b.WriteString(m.renderBreadcrumb())
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(m.theme.TitleText).
+		Padding(0, 0, 1, 0)
+	b.WriteString(headerStyle.Render(fmt.Sprintf("%s, %s", s.title, masterGamePlace(s.game))))
+	b.WriteString("\n")
+
+	renderer := NewBoardRendererWithTheme(m.config, m.theme)
+	b.WriteString(renderer.Render(s.board))
+	b.WriteString("\n\n")
+
+	if s.ply > 0 {
+		before, _ := engine.FromFEN(s.game.StartFEN)
+		for _, move := range s.game.Moves[:s.ply-1] {
+			_ = before.MakeMove(move)
+		}
+		last := s.game.Moves[s.ply-1]
+		b.WriteString(lipgloss.NewStyle().Foreground(m.theme.MenuNormal).Render(
+			fmt.Sprintf("Last move: %s %s", moveNumberPrefix(before), engine.FormatSAN(before, last))))
+		b.WriteString("\n")
+	}
+	if s.verdict != "" {
+		b.WriteString(lipgloss.NewStyle().Foreground(m.theme.MenuSelected).Render(s.verdict))
+		b.WriteString("\n")
+	}
+	score := fmt.Sprintf("Score: %d/%d", s.score, s.maxScore())
+	if s.guessed > 0 {
+		score += fmt.Sprintf(" (%d%%, %d of %d moves found)", s.score*100/s.maxScore(), s.exact, s.guessed)
+	}
+	b.WriteString(m.statusStyle().UnsetPadding().Render(score))
+	b.WriteString("\n\n")
+
+	switch {
+	case s.finished():
+		b.WriteString(m.statusStyle().UnsetPadding().Render(fmt.Sprintf("Game over: %s", s.game.Tags["Result"])))
+	case s.evaluating:
+		b.WriteString(m.statusStyle().UnsetPadding().Render(fmt.Sprintf("%s Evaluating your guess...", m.botSpinner.View())))
+	default:
+		side := "White"
+		if s.side == engine.Black {
+			side = "Black"
+		}
+		prompt := lipgloss.NewStyle().Foreground(m.theme.MenuNormal).Render(fmt.Sprintf("Guess %s's move: ", side))
+		b.WriteString(prompt + m.input)
+	}
+
+	help := "type a move + Enter: guess | ESC: back to games"
+	if s.finished() {
+		help = "Enter/ESC: back to games"
+	}
+	if helpText := m.renderHelpText(help); helpText != "" {
+		b.WriteString("\n\n")
+		b.WriteString(helpText)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/bot"
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// typeGuess types s at the guess prompt and presses Enter.
+func typeGuess(t *testing.T, m Model, s string) (Model, tea.Cmd) {
+	t.Helper()
+	result, _ := m.handleGuessMoveKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)})
+	result, cmd := result.(Model).handleGuessMoveKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	return result.(Model), cmd
+}
+
+// guessModel returns a model guessing the moves of the bundled game at index i.
+func guessModel(t *testing.T, i int) Model {
+	t.Helper()
+	m := NewModel(DefaultConfig())
+	m.openMenu(ScreenGuessSelect)
+	m.menuSelection = i
+	result, _ := m.runMenuItem(i)
+	m = result.(Model)
+	if m.screen != ScreenGuessMove {
+		t.Fatalf("Expected ScreenGuessMove, got %v", m.screen)
+	}
+	return m
+}
+
+func TestMasterGamesParse(t *testing.T) {
+	for i, pgn := range masterGames {
+		game, err := ParsePGN(pgn)
+		if err != nil {
+			t.Errorf("master game %d: %v", i, err)
+			continue
+		}
+		board, err := game.Board()
+		if err != nil {
+			t.Errorf("master game %d: %v", i, err)
+			continue
+		}
+		if board.Status() != engine.Checkmate {
+			t.Errorf("master game %d (%s) ends in %s, want the mate it is famous for", i, masterGameTitle(game), board.Status())
+		}
+	}
+	if got := len(guessGameMenu()); got != len(masterGames) {
+		t.Errorf("Expected every master game listed, got %d of %d", got, len(masterGames))
+	}
+}
+
+func TestGuessTheMoveFromGameTypeMenu(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.openNewGame()
+	m.menuSelection = len(m.menuOptions) - 2
+	if m.menuOptions[m.menuSelection] != "Guess the Move" {
+		t.Fatalf("Expected Guess the Move before Tutorial, got %v", m.menuOptions)
+	}
+	result, _ := m.handleGameTypeSelectKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if m.screen != ScreenGuessSelect {
+		t.Fatalf("Expected ScreenGuessSelect, got %v", m.screen)
+	}
+	if m.menuOptions[0] != "Paul Morphy vs Duke Karl / Count Isouard" {
+		t.Errorf("Expected the Opera Game first, got %q", m.menuOptions[0])
+	}
+}
+
+func TestGuessFindsMovePlayed(t *testing.T) {
+	m := guessModel(t, 0) // Morphy's Opera Game, guessing White
+
+	m, cmd := typeGuess(t, m, "e4")
+	if cmd != nil || m.guess.evaluating {
+		t.Fatalf("Expected the move played to score without the engine")
+	}
+	if m.guess.score != guessMaxPoints || m.guess.exact != 1 {
+		t.Errorf("Expected %d points for the move played, got %d", guessMaxPoints, m.guess.score)
+	}
+	// Black's reply is played, and White is to move again
+	if m.guess.ply != 2 || m.guess.board.ActiveColor != engine.White {
+		t.Errorf("Expected 1...e5 to be played, got ply %d", m.guess.ply)
+	}
+
+	view := ansi.Strip(m.renderGuessMove())
+	for _, want := range []string{"Last move: 1... e5", "found the move played", "Score: 3/3", "Guess White's move:"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected the view to show %q", want)
+		}
+	}
+}
+
+func TestGuessComparedByEngine(t *testing.T) {
+	m := guessModel(t, 0)
+
+	m, cmd := typeGuess(t, m, "d4")
+	if cmd == nil || !m.guess.evaluating {
+		t.Fatalf("Expected a different move to be evaluated")
+	}
+
+	d4, _ := engine.ParseMove("d2d4")
+	result, _ := m.handleGuessScored(GuessScoredMsg{ply: 0, guess: d4, comparison: &bot.MoveComparison{CentipawnLoss: 20}})
+	m = result.(Model)
+	if m.guess.evaluating {
+		t.Error("Expected the evaluation to be over")
+	}
+	if m.guess.score != 2 || m.guess.exact != 0 || m.guess.guessed != 1 {
+		t.Errorf("Expected 2 points for a move about as good, got %d", m.guess.score)
+	}
+	if !strings.Contains(m.guess.verdict, "the game went 1. e4") {
+		t.Errorf("Expected the verdict to give the move played, got %q", m.guess.verdict)
+	}
+
+	// A late result for a move already scored is ignored
+	result, _ = m.handleGuessScored(GuessScoredMsg{ply: 0, guess: d4, comparison: &bot.MoveComparison{}})
+	if result.(Model).guess.guessed != 1 {
+		t.Error("Expected a stale result to be ignored")
+	}
+}
+
+func TestGuessRejectsIllegalMove(t *testing.T) {
+	m := guessModel(t, 0)
+
+	m, cmd := typeGuess(t, m, "e5")
+	if cmd != nil || m.guess.guessed != 0 {
+		t.Errorf("Expected an illegal guess not to count")
+	}
+	if !strings.Contains(toastError(m), "Illegal move") {
+		t.Errorf("Expected an illegal move error, got %q", toastError(m))
+	}
+}
+
+func TestGuessBlackMovesOfBlackWin(t *testing.T) {
+	i := len(masterGames) - 1 // Byrne vs Fischer, won by Black
+	m := guessModel(t, i)
+
+	if m.guess.side != engine.Black || m.guess.ply != 1 {
+		t.Fatalf("Expected to guess Black's moves after 1. Nf3, got side %v at ply %d", m.guess.side, m.guess.ply)
+	}
+	if !strings.Contains(ansi.Strip(m.renderGuessMove()), "Guess Black's move:") {
+		t.Error("Expected the prompt to ask for Black's move")
+	}
+}
+
+func TestGuessGameFinished(t *testing.T) {
+	m := guessModel(t, 3) // Legall's mate: 7 moves by White
+	for _, san := range []string{"e4", "Bc4", "Nf3", "Nc3", "Nxe5", "Bxf7+", "Nd5#"} {
+		m, _ = typeGuess(t, m, san)
+	}
+
+	if !m.guess.finished() {
+		t.Fatalf("Expected the game to be finished at ply %d", m.guess.ply)
+	}
+	view := ansi.Strip(m.renderGuessMove())
+	if !strings.Contains(view, "Score: 21/21 (100%, 7 of 7 moves found)") || !strings.Contains(view, "Game over: 1-0") {
+		t.Errorf("Expected the final score, got %q", view)
+	}
+
+	result, _ := m.handleGuessMoveKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	if result.(Model).screen != ScreenGuessSelect {
+		t.Errorf("Expected Enter to return to the list of games")
+	}
+}
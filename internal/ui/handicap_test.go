@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestHandicapGameFlow(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenBotSelect
+	m.gameType = GameTypePvBot
+	m.menuOptions = []string{"Easy", "Medium", "Hard"}
+
+	result, _ := m.selectMenuItem()
+	m = result.(Model)
+	if m.screen != ScreenHandicapSelect {
+		t.Fatalf("Expected ScreenHandicapSelect, got: %v", m.screen)
+	}
+	if !strings.Contains(m.View(), "Knight Odds") {
+		t.Error("Expected the handicap options to be rendered")
+	}
+
+	// Choose knight odds
+	m.menuSelection = 2
+	result, _ = m.handleHandicapSelectKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if m.screen != ScreenColorSelect || m.handicap != engine.KnightOdds {
+		t.Fatalf("Expected color selection with knight odds, got screen %v handicap %v", m.screen, m.handicap)
+	}
+
+	// Going back restores the handicap menu
+	result, _ = m.handleColorSelectKeys(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	if m.screen != ScreenHandicapSelect || m.menuOptions[2] != "Knight Odds" {
+		t.Fatalf("Expected to return to the handicap menu, got screen %v options %v", m.screen, m.menuOptions)
+	}
+	m.menuSelection = 2
+	result, _ = m.selectMenuItem()
+	m = result.(Model)
+
+	// Playing White, the bot gives up Black's queen's knight
+	result, _ = m.selectMenuItem()
+	m = result.(Model)
+	want := engine.HandicapFEN(engine.KnightOdds, engine.Black)
+	if m.game.Board().ToFEN() != want || m.game.Start().ToFEN() != want {
+		t.Errorf("Expected the game to start from %q, got %q", want, m.game.Board().ToFEN())
+	}
+}
+
+func TestHandicapGivenByBotPlayingWhite(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.gameType = GameTypePvBot
+	m.screen = ScreenColorSelect
+	m.handicap = engine.RookOdds
+	m.menuOptions = []string{"Play as White", "Play as Black"}
+	m.menuSelection = 1
+
+	result, _ := m.selectMenuItem()
+	m = result.(Model)
+	defer func() {
+		m.stopBotThinking()
+		if m.botEngine != nil {
+			_ = m.botEngine.Close()
+		}
+	}()
+	if !m.game.Board().PieceAt(engine.NewSquare(0, 0)).IsEmpty() {
+		t.Error("Expected the bot to play White without the a1 rook")
+	}
+	if m.game.Board().PieceAt(engine.NewSquare(0, 7)).IsEmpty() {
+		t.Error("Expected the user to keep the a8 rook")
+	}
+}
+
+func TestHandicapSelectBack(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenHandicapSelect
+	m.navStack = []Screen{ScreenMainMenu, ScreenGameTypeSelect, ScreenBotSelect}
+	m.menuOptions = handicapOptions()
+
+	result, _ := m.handleHandicapSelectKeys(tea.KeyMsg{Type: tea.KeyUp})
+	m = result.(Model)
+	if m.menuSelection != len(engine.Handicaps)-1 {
+		t.Errorf("Expected selection to wrap to the last option, got %d", m.menuSelection)
+	}
+
+	result, _ = m.handleHandicapSelectKeys(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	if m.screen != ScreenBotSelect {
+		t.Errorf("Expected ESC to return to ScreenBotSelect, got %v", m.screen)
+	}
+}
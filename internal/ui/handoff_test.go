@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// hotSeatGame starts a PvP game with the privacy screen enabled or disabled.
+func hotSeatGame(privacy bool) Model {
+	cfg := DefaultConfig()
+	cfg.HotSeatPrivacy = privacy
+	m := NewModel(cfg)
+	m.gameType = GameTypePvP
+	m.game = engine.NewGame()
+	m.screen = ScreenGamePlay
+	return m
+}
+
+func TestHandoffAfterHotSeatMove(t *testing.T) {
+	m := hotSeatGame(true)
+	m.input = "e4"
+	result, _ := m.handleGamePlayInput()
+	m = result.(Model)
+
+	if m.screen != ScreenHandoff {
+		t.Fatalf("Expected ScreenHandoff after the move, got %v", m.screen)
+	}
+	view := m.View()
+	if !strings.Contains(view, "White has moved") || !strings.Contains(view, "Black: press Enter") {
+		t.Errorf("Expected the handoff screen to address Black, got:\n%s", view)
+	}
+	if strings.Contains(view, m.game.Board().String()) || strings.Contains(view, "Enter move:") {
+		t.Error("Expected the board to be hidden")
+	}
+
+	// Stray keys, including global shortcuts, don't reveal the board
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune{'n'}},
+		{Type: tea.KeyRunes, Runes: []rune{'q'}},
+		{Type: tea.KeyEsc},
+	} {
+		result, cmd := m.handleKeyPress(key)
+		m = result.(Model)
+		if m.screen != ScreenHandoff || cmd != nil {
+			t.Fatalf("Expected %q to be ignored, got screen %v", key.String(), m.screen)
+		}
+	}
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if m.screen != ScreenGamePlay {
+		t.Errorf("Expected Enter to show the board, got %v", m.screen)
+	}
+}
+
+func TestNoHandoffWhenDisabled(t *testing.T) {
+	m := hotSeatGame(false)
+	m.input = "e4"
+	result, _ := m.handleGamePlayInput()
+	m = result.(Model)
+	if m.screen != ScreenGamePlay {
+		t.Errorf("Expected to stay on ScreenGamePlay, got %v", m.screen)
+	}
+}
+
+func TestNoHandoffAfterFinalMove(t *testing.T) {
+	m := hotSeatGame(true)
+	board, err := engine.FromFEN("rnbqkbnr/pppp1ppp/8/4p3/6P1/5P2/PPPPP2P/RNBQKBNR b KQkq - 0 2")
+	if err != nil {
+		t.Fatalf("FromFEN error = %v", err)
+	}
+	m.game = engine.NewGameFrom(board)
+	m.input = "Qh4"
+	result, _ := m.handleGamePlayInput()
+	m = result.(Model)
+	m.finishMateReveal()
+	if m.screen != ScreenGameOver {
+		t.Errorf("Expected the game over screen after checkmate, got %v", m.screen)
+	}
+}
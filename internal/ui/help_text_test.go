@@ -95,7 +95,7 @@ func TestHelpTextVisibilityGamePlay(t *testing.T) {
 	m := NewModel(DefaultConfig())
 	m.screen = ScreenGamePlay
 	// Start a new game so we have a board
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 
 	// Test with help text enabled
 	m.config.ShowHelpText = true
@@ -116,11 +116,11 @@ func TestHelpTextVisibilityGamePlay(t *testing.T) {
 // TestHelpTextVisibilityGameOver tests that help text is shown/hidden on game over screen
 func TestHelpTextVisibilityGameOver(t *testing.T) {
 	m := NewModel(DefaultConfig())
-	m.screen = ScreenGameOver
 	// Create a board in checkmate state for testing
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	// Force a checkmate state (this is a simplified test)
 	// In a real scenario, we'd set up an actual checkmate position
+	m.showGameOver()
 
 	// Test with help text enabled
 	m.config.ShowHelpText = true
@@ -133,8 +133,8 @@ func TestHelpTextVisibilityGameOver(t *testing.T) {
 	// Test with help text disabled
 	m.config.ShowHelpText = false
 	output = m.renderGameOver()
-	// Should still show options but not additional help text at bottom
-	if !strings.Contains(output, "Press 'n'") {
+	// Should still show the menu but not additional help text at bottom
+	if !strings.Contains(output, "Main Menu") {
 		t.Error("Expected options to still be visible even with ShowHelpText disabled")
 	}
 }
@@ -242,7 +242,7 @@ func TestHelpTextToggleAffectsAllScreens(t *testing.T) {
 			ScreenGamePlay,
 			func(m *Model) {
 				m.screen = ScreenGamePlay
-				m.board = engine.NewBoard()
+				m.game = engine.NewGame()
 			},
 			func(m Model) string { return m.renderGamePlay() },
 		},
@@ -298,7 +298,7 @@ func TestHelpTextVisibilityFENInput(t *testing.T) {
 func TestHelpTextVisibilitySavePrompt(t *testing.T) {
 	m := NewModel(DefaultConfig())
 	m.screen = ScreenSavePrompt
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 
 	// Test with help text enabled
 	m.config.ShowHelpText = true
@@ -357,7 +357,7 @@ func TestHelpTextContentMatchesSpec(t *testing.T) {
 			ScreenGamePlay,
 			func(m *Model) {
 				m.screen = ScreenGamePlay
-				m.board = engine.NewBoard()
+				m.game = engine.NewGame()
 			},
 			func(m Model) string { return m.renderGamePlay() },
 			"ESC: menu (with save)",
@@ -367,7 +367,7 @@ func TestHelpTextContentMatchesSpec(t *testing.T) {
 			ScreenGameOver,
 			func(m *Model) {
 				m.screen = ScreenGameOver
-				m.board = engine.NewBoard()
+				m.game = engine.NewGame()
 			},
 			func(m Model) string { return m.renderGameOver() },
 			"ESC/m: menu",
@@ -0,0 +1,46 @@
+package ui
+
+// maxInputHistory is how many entries of the move prompt are remembered.
+const maxInputHistory = 100
+
+// rememberInput adds an entry submitted at the move prompt to the input
+// history, unless it repeats the previous entry, and stops browsing it.
+func (m *Model) rememberInput(entry string) {
+	if n := len(m.inputHistory); entry != "" && (n == 0 || m.inputHistory[n-1] != entry) {
+		m.inputHistory = append(m.inputHistory, entry)
+		if len(m.inputHistory) > maxInputHistory {
+			m.inputHistory = m.inputHistory[len(m.inputHistory)-maxInputHistory:]
+		}
+	}
+	m.stopBrowsingInput()
+}
+
+// stopBrowsingInput leaves the input history, keeping whatever is in the prompt.
+func (m *Model) stopBrowsingInput() {
+	m.inputHistoryIndex = len(m.inputHistory)
+	m.inputDraft = ""
+}
+
+// recallInput moves through the input history by delta, -1 for the previous
+// entry and 1 for the next, like a shell. Moving past the newest entry brings
+// back what was being typed before browsing started.
+func (m *Model) recallInput(delta int) {
+	// The index is stale if the history was never browsed or was trimmed
+	if m.inputHistoryIndex > len(m.inputHistory) || m.inputHistoryIndex < 0 {
+		m.stopBrowsingInput()
+	}
+	next := m.inputHistoryIndex + delta
+	if next < 0 || next > len(m.inputHistory) {
+		return
+	}
+	if m.inputHistoryIndex == len(m.inputHistory) {
+		m.inputDraft = m.input
+	}
+	m.inputHistoryIndex = next
+	if next == len(m.inputHistory) {
+		m.input = m.inputDraft
+	} else {
+		m.input = m.inputHistory[next]
+	}
+	m.completionIndex = 0
+}
@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestInputHistoryRecall(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.game = engine.NewGame()
+	m.screen = ScreenGamePlay
+
+	press := func(msg tea.KeyMsg) {
+		t.Helper()
+		result, _ := m.handleGamePlayKeys(msg)
+		m = result.(Model)
+	}
+	submit := func(text string) {
+		t.Helper()
+		press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text)})
+		press(tea.KeyMsg{Type: tea.KeyEnter})
+	}
+
+	// A mistyped move, which stays in the prompt, submitted twice, then a played move
+	submit("Nf4")
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	for range "Nf4" {
+		press(tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	submit("e4")
+	if len(m.inputHistory) != 2 {
+		t.Fatalf("inputHistory = %q, want a repeated entry kept once", m.inputHistory)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("sh")})
+	press(tea.KeyMsg{Type: tea.KeyUp})
+	if m.input != "e4" {
+		t.Errorf("input after up = %q, want e4", m.input)
+	}
+	press(tea.KeyMsg{Type: tea.KeyUp})
+	press(tea.KeyMsg{Type: tea.KeyUp})
+	if m.input != "Nf4" {
+		t.Errorf("input after going past the oldest entry = %q, want Nf4", m.input)
+	}
+
+	// Down goes forward again, back to the text being typed
+	press(tea.KeyMsg{Type: tea.KeyDown})
+	press(tea.KeyMsg{Type: tea.KeyDown})
+	if m.input != "sh" {
+		t.Errorf("input after coming back down = %q, want the draft sh", m.input)
+	}
+
+	// Editing a recalled entry ends browsing
+	press(tea.KeyMsg{Type: tea.KeyUp})
+	press(tea.KeyMsg{Type: tea.KeyBackspace})
+	press(tea.KeyMsg{Type: tea.KeyDown})
+	if m.input != "e" {
+		t.Errorf("input after editing a recalled entry = %q, want e", m.input)
+	}
+}
+
+func TestInputHistoryLimit(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	for i := 0; i < maxInputHistory+5; i++ {
+		m.rememberInput(string(rune('a'+i%26)) + string(rune('0'+i%10)))
+	}
+	if len(m.inputHistory) != maxInputHistory {
+		t.Errorf("len(inputHistory) = %d, want %d", len(m.inputHistory), maxInputHistory)
+	}
+	if m.inputHistoryIndex != maxInputHistory {
+		t.Errorf("inputHistoryIndex = %d, want %d", m.inputHistoryIndex, maxInputHistory)
+	}
+}
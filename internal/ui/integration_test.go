@@ -18,7 +18,7 @@ func TestMoveHistoryIntegration(t *testing.T) {
 	}
 
 	m := NewModel(config)
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 
 	// Play a short game (Scholar's mate setup)
 	moves := []string{"e2e4", "e7e5", "f1c4", "b8c6", "d1h5", "g8f6"}
@@ -29,12 +29,11 @@ func TestMoveHistoryIntegration(t *testing.T) {
 			t.Fatalf("Failed to parse move %s: %v", moveStr, err)
 		}
 
-		err = m.board.MakeMove(move)
+		err = m.game.MakeMove(move)
 		if err != nil {
 			t.Fatalf("Failed to make move %s: %v", moveStr, err)
 		}
 
-		m.moveHistory = append(m.moveHistory, move)
 	}
 
 	// Format the move history
@@ -75,7 +74,7 @@ func TestMoveHistoryDisabled(t *testing.T) {
 	}
 
 	m := NewModel(config)
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 
 	// Play some moves
 	moves := []string{"e2e4", "e7e5", "g1f3"}
@@ -86,8 +85,7 @@ func TestMoveHistoryDisabled(t *testing.T) {
 			t.Fatalf("Failed to parse move %s: %v", moveStr, err)
 		}
 
-		m.board.MakeMove(move)
-		m.moveHistory = append(m.moveHistory, move)
+		m.game.MakeMove(move)
 	}
 
 	// Test that rendering does NOT include the move history when config is disabled
@@ -107,29 +105,27 @@ func TestMoveHistoryResetsOnNewGame(t *testing.T) {
 	}
 
 	m := NewModel(config)
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 
 	// Play some moves
 	moves := []string{"e2e4", "e7e5", "g1f3"}
 
 	for _, moveStr := range moves {
 		move, _ := engine.ParseMove(moveStr)
-		m.board.MakeMove(move)
-		m.moveHistory = append(m.moveHistory, move)
+		m.game.MakeMove(move)
 	}
 
 	// Verify move history has moves
-	if len(m.moveHistory) != 3 {
-		t.Errorf("Expected 3 moves in history, got %d", len(m.moveHistory))
+	if m.game.MoveCount() != 3 {
+		t.Errorf("Expected 3 moves in history, got %d", m.game.MoveCount())
 	}
 
 	// Start a new game (simulating what happens in update.go)
-	m.board = engine.NewBoard()
-	m.moveHistory = []engine.Move{}
+	m.game = engine.NewGame()
 
 	// Verify move history is cleared
-	if len(m.moveHistory) != 0 {
-		t.Errorf("Move history should be cleared on new game, got %d moves", len(m.moveHistory))
+	if m.game.MoveCount() != 0 {
+		t.Errorf("Move history should be cleared on new game, got %d moves", m.game.MoveCount())
 	}
 
 	history := m.formatMoveHistory()
@@ -148,7 +144,7 @@ func TestCastlingInMoveHistory(t *testing.T) {
 	}
 
 	m := NewModel(config)
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 
 	// Set up for castling: 1. e4 e5 2. Nf3 Nc6 3. Bc4 Nf6 4. O-O
 	moves := []string{"e2e4", "e7e5", "g1f3", "b8c6", "f1c4", "g8f6", "e1g1"}
@@ -159,8 +155,7 @@ func TestCastlingInMoveHistory(t *testing.T) {
 			t.Fatalf("Failed to parse move %s: %v", moveStr, err)
 		}
 
-		m.board.MakeMove(move)
-		m.moveHistory = append(m.moveHistory, move)
+		m.game.MakeMove(move)
 	}
 
 	history := m.formatMoveHistory()
@@ -193,7 +188,7 @@ func TestPromotionInMoveHistory(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to parse FEN: %v", err)
 	}
-	m.board = board
+	m.game = engine.NewGameFrom(board)
 
 	// Promote to queen
 	move, err := engine.ParseMove("a7a8q")
@@ -201,8 +196,7 @@ func TestPromotionInMoveHistory(t *testing.T) {
 		t.Fatalf("Failed to parse move: %v", err)
 	}
 
-	m.board.MakeMove(move)
-	m.moveHistory = append(m.moveHistory, move)
+	m.game.MakeMove(move)
 
 	history := m.formatMoveHistory()
 
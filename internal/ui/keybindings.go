@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Mgrdich/TermChess/internal/config"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// handleKeyBindingsKeys handles keyboard input for the Key Bindings screen.
+// Enter starts capturing a new key for the selected action, 'r' restores the
+// selected action's default keys, 'R' restores all defaults, and ESC goes back.
+// While capturing, the next key press becomes the action's only key (ESC cancels).
+func (m Model) handleKeyBindingsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.dismissToasts()
+	action := keyActions[m.keyBindingSelection]
+
+	if m.keyBindingCapture {
+		m.keyBindingCapture = false
+		if msg.String() == "esc" {
+			m.notify(SeverityInfo, "Key binding unchanged")
+			return m, nil
+		}
+		keys, err := m.keys.WithBinding(action, []string{msg.String()})
+		if err != nil {
+			m.notify(SeverityError, err.Error())
+			return m, nil
+		}
+		return m.applyKeyMap(keys, fmt.Sprintf("Bound %s to %s", action, keys.Label(action)))
+	}
+
+	switch {
+	case m.keys.Matches(msg, ActionUp):
+		if m.keyBindingSelection > 0 {
+			m.keyBindingSelection--
+		} else {
+			m.keyBindingSelection = len(keyActions) - 1
+		}
+
+	case m.keys.Matches(msg, ActionDown):
+		if m.keyBindingSelection < len(keyActions)-1 {
+			m.keyBindingSelection++
+		} else {
+			m.keyBindingSelection = 0
+		}
+
+	case m.keys.Matches(msg, ActionSelect):
+		m.keyBindingCapture = true
+
+	case m.keys.Matches(msg, ActionBack):
+		m.popScreen()
+
+	case msg.String() == "r":
+		keys, err := m.keys.WithBinding(action, defaultKeyBindings[action])
+		if err != nil {
+			m.notify(SeverityError, err.Error())
+			return m, nil
+		}
+		return m.applyKeyMap(keys, fmt.Sprintf("Restored default keys for %s", action))
+
+	case msg.String() == "R":
+		return m.applyKeyMap(DefaultKeyMap(), "Restored all default key bindings")
+	}
+
+	return m, nil
+}
+
+// applyKeyMap makes the key map active and saves its overrides to the config file.
+func (m Model) applyKeyMap(keys KeyMap, status string) (tea.Model, tea.Cmd) {
+	m.keys = keys
+	m.config.KeyBindings = keys.Overrides()
+
+	if err := config.SaveConfig(m.config); err != nil {
+		m.notify(SeverityError, fmt.Sprintf("Failed to save key bindings: %v", err))
+		return m, nil
+	}
+	m.notify(SeverityInfo, status)
+	return m, nil
+}
+
+// renderKeyBindings renders the Key Bindings screen, listing every action with
+// its bound keys and marking actions that differ from the defaults.
+func (m Model) renderKeyBindings() string {
+	var b strings.Builder
+
+	title := m.titleStyle().Render("TermChess")
+	b.WriteString(title)
+	b.WriteString("\n")
+
+	b.WriteString(m.renderBreadcrumb())
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(m.theme.TitleText).
+		Padding(0, 0, 1, 0)
+	b.WriteString(headerStyle.Render("Key Bindings"))
+	b.WriteString("\n")
+
+	overrides := m.keys.Overrides()
+	for i, action := range keyActions {
+		keys := m.keys.Label(action)
+		if i == m.keyBindingSelection && m.keyBindingCapture {
+			keys = "<press a key>"
+		}
+		if _, changed := overrides[string(action)]; changed {
+			keys += " *"
+		}
+
+		cursor := "  "
+		optionText := fmt.Sprintf("%-28s %s", keyActionDescriptions[action], keys)
+		if i == m.keyBindingSelection {
+			cursor = m.cursorStyle().Render(">> ")
+			optionText = m.selectedItemStyle().Render(optionText)
+		} else {
+			optionText = m.menuItemStyle().Render(optionText)
+		}
+		b.WriteString(fmt.Sprintf("%s%s\n", cursor, optionText))
+	}
+
+	helpText := m.renderHelpText("ESC: back | arrows/jk: navigate | enter: rebind | r: reset key | R: reset all | *: customized")
+	if helpText != "" {
+		b.WriteString("\n")
+		b.WriteString(helpText)
+	}
+
+	if m.keyBindingCapture {
+		b.WriteString("\n\n")
+		b.WriteString(m.statusStyle().Render(fmt.Sprintf("Press a key for %s (ESC to cancel)", keyActions[m.keyBindingSelection])))
+	}
+
+	return b.String()
+}
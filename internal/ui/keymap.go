@@ -0,0 +1,413 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// KeyAction identifies something the user can do with a key press.
+// The string value is the action's name in the [keys] section of the config file.
+type KeyAction string
+
+const (
+	// ActionUp moves the selection up (or steps back in analysis)
+	ActionUp KeyAction = "up"
+	// ActionDown moves the selection down (or steps forward in analysis)
+	ActionDown KeyAction = "down"
+	// ActionLeft goes to the previous page, game or move
+	ActionLeft KeyAction = "left"
+	// ActionRight goes to the next page, game or move
+	ActionRight KeyAction = "right"
+	// ActionPageUp scrolls a long screen up by a page
+	ActionPageUp KeyAction = "page_up"
+	// ActionPageDown scrolls a long screen down by a page
+	ActionPageDown KeyAction = "page_down"
+	// ActionSelect confirms the current selection
+	ActionSelect KeyAction = "select"
+	// ActionToggle toggles a setting or pauses/resumes Bot vs Bot games
+	ActionToggle KeyAction = "toggle"
+	// ActionBack returns to the previous screen or cancels a dialog
+	ActionBack KeyAction = "back"
+	// ActionQuit quits the application (shows the save prompt during a game)
+	ActionQuit KeyAction = "quit"
+	// ActionHelp shows the keyboard shortcuts overlay
+	ActionHelp KeyAction = "help"
+	// ActionNewGame starts a new game
+	ActionNewGame KeyAction = "new_game"
+	// ActionSettings opens the settings screen
+	ActionSettings KeyAction = "settings"
+	// ActionMainMenu returns to the main menu from the game over screen
+	ActionMainMenu KeyAction = "main_menu"
+	// ActionAnalyze opens the analysis of a finished game
+	ActionAnalyze KeyAction = "analyze"
+	// ActionRematch starts a rematch with colors swapped from the game over screen
+	ActionRematch KeyAction = "rematch"
+	// ActionFirstMove jumps to the start of the game in analysis
+	ActionFirstMove KeyAction = "first_move"
+	// ActionLastMove jumps to the end of the game in analysis
+	ActionLastMove KeyAction = "last_move"
+	// ActionPrevFlagged jumps to the previous flagged move in analysis
+	ActionPrevFlagged KeyAction = "prev_flagged"
+	// ActionNextFlagged jumps to the next flagged move in analysis
+	ActionNextFlagged KeyAction = "next_flagged"
+	// ActionExportGIF exports the analyzed game as an animated GIF
+	ActionExportGIF KeyAction = "export_gif"
+	// ActionExportCast exports the analyzed game as an asciinema cast
+	ActionExportCast KeyAction = "export_cast"
+	// ActionRefresh refreshes the correspondence mailbox, or reloads the config file from the settings screen
+	ActionRefresh KeyAction = "refresh"
+	// ActionLeaveGame leaves a correspondence game for the correspondence list
+	ActionLeaveGame KeyAction = "leave_game"
+	// ActionToggleView cycles the Bot vs Bot view mode
+	ActionToggleView KeyAction = "toggle_view"
+	// ActionToggleSpeed switches Bot vs Bot playback speed
+	ActionToggleSpeed KeyAction = "toggle_speed"
+	// ActionJumpToGame opens the Bot vs Bot jump-to-game prompt
+	ActionJumpToGame KeyAction = "jump_to_game"
+	// ActionCopyFEN copies the FEN of the focused Bot vs Bot game
+	ActionCopyFEN KeyAction = "copy_fen"
+	// ActionExportStats exports Bot vs Bot statistics
+	ActionExportStats KeyAction = "export_stats"
+	// ActionFilterGames cycles which games the Bot vs Bot grid shows
+	ActionFilterGames KeyAction = "filter_games"
+	// ActionSortResults cycles the order of the Bot vs Bot individual results
+	ActionSortResults KeyAction = "sort_results"
+	// ActionBookmark bookmarks the focused Bot vs Bot game or removes its bookmark
+	ActionBookmark KeyAction = "bookmark"
+	// ActionNextNotable opens the next bookmarked or flagged Bot vs Bot game
+	ActionNextNotable KeyAction = "next_notable"
+	// ActionShowLog shows the Bot vs Bot session log of errors, warnings and adjudications
+	ActionShowLog KeyAction = "show_log"
+	// ActionFollowGames keeps the running Bot vs Bot games in view
+	ActionFollowGames KeyAction = "follow_games"
+	// ActionCommandPalette opens the command palette
+	ActionCommandPalette KeyAction = "command_palette"
+)
+
+// keyActions lists every bindable action in the order shown on the Key Bindings screen.
+var keyActions = []KeyAction{
+	ActionUp, ActionDown, ActionLeft, ActionRight, ActionPageUp, ActionPageDown,
+	ActionSelect, ActionToggle, ActionBack,
+	ActionQuit, ActionHelp, ActionNewGame, ActionSettings, ActionCommandPalette,
+	ActionMainMenu, ActionAnalyze, ActionRematch,
+	ActionFirstMove, ActionLastMove, ActionPrevFlagged, ActionNextFlagged, ActionExportGIF, ActionExportCast,
+	ActionRefresh, ActionLeaveGame,
+	ActionToggleView, ActionToggleSpeed, ActionJumpToGame, ActionCopyFEN, ActionFilterGames,
+	ActionFollowGames, ActionBookmark, ActionNextNotable, ActionShowLog, ActionExportStats, ActionSortResults,
+}
+
+// keyActionDescriptions holds the human-readable description of each action.
+var keyActionDescriptions = map[KeyAction]string{
+	ActionUp:             "Move up / previous move",
+	ActionDown:           "Move down / next move",
+	ActionLeft:           "Previous page, game or move",
+	ActionRight:          "Next page, game or move",
+	ActionPageUp:         "Scroll up a page",
+	ActionPageDown:       "Scroll down a page",
+	ActionSelect:         "Select / Confirm",
+	ActionToggle:         "Toggle setting / Pause BvB",
+	ActionBack:           "Go back / Cancel",
+	ActionQuit:           "Quit",
+	ActionHelp:           "Show shortcuts overlay",
+	ActionNewGame:        "Start new game",
+	ActionSettings:       "Open settings",
+	ActionCommandPalette: "Open command palette",
+	ActionMainMenu:       "Main menu (game over)",
+	ActionAnalyze:        "Analyze game (game over)",
+	ActionRematch:        "Rematch (game over)",
+	ActionFirstMove:      "First move (analysis)",
+	ActionLastMove:       "Last move (analysis)",
+	ActionPrevFlagged:    "Previous flagged move (analysis)",
+	ActionNextFlagged:    "Next flagged move (analysis)",
+	ActionExportGIF:      "Export game as GIF (analysis)",
+	ActionExportCast:     "Export game as cast (analysis)",
+	ActionRefresh:        "Refresh mailbox / Reload config",
+	ActionLeaveGame:      "Leave correspondence game",
+	ActionToggleView:     "Change BvB view",
+	ActionToggleSpeed:    "Toggle BvB speed",
+	ActionJumpToGame:     "Jump to BvB game",
+	ActionCopyFEN:        "Copy BvB game FEN",
+	ActionExportStats:    "Export BvB statistics",
+	ActionFilterGames:    "Filter BvB grid games",
+	ActionSortResults:    "Sort BvB results",
+	ActionBookmark:       "Bookmark BvB game",
+	ActionNextNotable:    "Next notable BvB game",
+	ActionShowLog:        "Show BvB session log",
+	ActionFollowGames:    "Follow running BvB games",
+}
+
+// defaultKeyBindings holds the keys bound to each action out of the box.
+// Key names follow Bubble Tea's KeyMsg.String() (e.g. "up", "enter", "ctrl+n", " " for space).
+var defaultKeyBindings = map[KeyAction][]string{
+	ActionUp:             {"up", "k"},
+	ActionDown:           {"down", "j"},
+	ActionLeft:           {"left", "h"},
+	ActionRight:          {"right", "l"},
+	ActionPageUp:         {"pgup"},
+	ActionPageDown:       {"pgdown"},
+	ActionSelect:         {"enter"},
+	ActionToggle:         {" "},
+	ActionBack:           {"esc", "b", "backspace"},
+	ActionQuit:           {"q"},
+	ActionHelp:           {"?"},
+	ActionNewGame:        {"n"},
+	ActionSettings:       {"s"},
+	ActionCommandPalette: {"ctrl+p"},
+	ActionMainMenu:       {"m", "M"},
+	ActionAnalyze:        {"a", "A"},
+	ActionRematch:        {"r", "R"},
+	ActionFirstMove:      {"home", "g"},
+	ActionLastMove:       {"end", "G"},
+	ActionPrevFlagged:    {"["},
+	ActionNextFlagged:    {"]"},
+	ActionExportGIF:      {"i"},
+	ActionExportCast:     {"c"},
+	ActionRefresh:        {"r"},
+	ActionLeaveGame:      {"esc"},
+	ActionToggleView:     {"tab", "v", "V"},
+	ActionToggleSpeed:    {"t", "T"},
+	ActionJumpToGame:     {"g", "G"},
+	ActionCopyFEN:        {"f"},
+	ActionExportStats:    {"s", "S"},
+	ActionFilterGames:    {"F"},
+	ActionSortResults:    {"o", "O"},
+	ActionBookmark:       {"*"},
+	ActionNextNotable:    {"N"},
+	ActionShowLog:        {"e", "E"},
+	ActionFollowGames:    {"L"},
+}
+
+// globalKeyActions are handled before any screen-specific keys.
+var globalKeyActions = []KeyAction{ActionQuit, ActionHelp, ActionNewGame, ActionSettings, ActionCommandPalette}
+
+// keyContexts groups the actions that are active on the same screen at the same time.
+// Two actions in the same context must not share a key. Global actions are added to
+// every context except where noted.
+var keyContexts = []struct {
+	name    string
+	actions []KeyAction
+	global  bool
+}{
+	{"menus", []KeyAction{ActionUp, ActionDown, ActionLeft, ActionRight, ActionSelect, ActionToggle, ActionRefresh, ActionBack}, true},
+	{"game over", []KeyAction{ActionUp, ActionDown, ActionSelect, ActionMainMenu, ActionAnalyze, ActionRematch, ActionBack}, true},
+	{"analysis", []KeyAction{ActionUp, ActionDown, ActionLeft, ActionRight, ActionFirstMove, ActionLastMove,
+		ActionPrevFlagged, ActionNextFlagged, ActionExportGIF, ActionExportCast, ActionBack}, true},
+	{"correspondence", []KeyAction{ActionUp, ActionDown, ActionSelect, ActionRefresh, ActionBack}, true},
+	// Other keys are typed into the move input
+	{"correspondence game", []KeyAction{ActionQuit, ActionLeaveGame}, false},
+	{"bot vs bot", []KeyAction{ActionToggle, ActionLeft, ActionRight, ActionToggleView, ActionToggleSpeed,
+		ActionJumpToGame, ActionCopyFEN, ActionFilterGames, ActionFollowGames, ActionBookmark, ActionNextNotable, ActionShowLog, ActionBack}, true},
+	// The stats screen uses its own export key instead of the settings shortcut
+	{"bot vs bot stats", []KeyAction{ActionUp, ActionDown, ActionLeft, ActionRight, ActionPageUp, ActionPageDown,
+		ActionSelect, ActionExportStats, ActionSortResults, ActionNextNotable, ActionShowLog, ActionBack, ActionQuit, ActionHelp, ActionCommandPalette}, false},
+	// Any other key closes the shortcuts overlay
+	{"shortcuts overlay", []KeyAction{ActionUp, ActionDown, ActionPageUp, ActionPageDown}, false},
+}
+
+// KeyMap holds the keys bound to each action.
+// The zero value has no bindings; use DefaultKeyMap or NewKeyMap.
+type KeyMap struct {
+	bindings map[KeyAction][]string
+}
+
+// DefaultKeyMap returns the built-in key bindings.
+func DefaultKeyMap() KeyMap {
+	bindings := make(map[KeyAction][]string, len(defaultKeyBindings))
+	for action, keys := range defaultKeyBindings {
+		bindings[action] = append([]string(nil), keys...)
+	}
+	return KeyMap{bindings: bindings}
+}
+
+// NewKeyMap returns the default key bindings with the given overrides applied.
+// Overrides map action names to keys, as found in the [keys] section of the config file.
+// Returns an error for unknown actions, empty or invalid keys, or keys that conflict
+// with another action on the same screen.
+func NewKeyMap(overrides map[string][]string) (KeyMap, error) {
+	km := DefaultKeyMap()
+
+	// Apply overrides in a stable order so errors are deterministic
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		action := KeyAction(name)
+		if _, ok := defaultKeyBindings[action]; !ok {
+			return DefaultKeyMap(), fmt.Errorf("unknown key action %q", name)
+		}
+		keys, err := normalizeKeys(overrides[name])
+		if err != nil {
+			return DefaultKeyMap(), fmt.Errorf("action %q: %w", name, err)
+		}
+		km.bindings[action] = keys
+	}
+
+	if err := km.Validate(); err != nil {
+		return DefaultKeyMap(), err
+	}
+	return km, nil
+}
+
+// normalizeKeys validates a list of key names, translating "space" into " "
+// and dropping duplicates.
+func normalizeKeys(keys []string) ([]string, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no keys given")
+	}
+	seen := make(map[string]bool, len(keys))
+	result := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key == "space" {
+			key = " "
+		}
+		if key == "" {
+			return nil, fmt.Errorf("empty key name")
+		}
+		if key == "ctrl+c" {
+			return nil, fmt.Errorf("ctrl+c is reserved for quitting")
+		}
+		if key == debugKey {
+			return nil, fmt.Errorf("%s is reserved for the debug panel", debugKey)
+		}
+		if !seen[key] {
+			seen[key] = true
+			result = append(result, key)
+		}
+	}
+	return result, nil
+}
+
+// Matches reports whether the key press is bound to any of the given actions.
+func (k KeyMap) Matches(msg tea.KeyMsg, actions ...KeyAction) bool {
+	pressed := msg.String()
+	for _, action := range actions {
+		for _, key := range k.bindings[action] {
+			if key == pressed {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Keys returns the keys bound to an action.
+func (k KeyMap) Keys(action KeyAction) []string {
+	return append([]string(nil), k.bindings[action]...)
+}
+
+// Label returns the keys bound to an action formatted for display, e.g. "up / k".
+func (k KeyMap) Label(action KeyAction) string {
+	keys := k.bindings[action]
+	labels := make([]string, len(keys))
+	for i, key := range keys {
+		labels[i] = keyDisplayName(key)
+	}
+	return strings.Join(labels, " / ")
+}
+
+// keyDisplayName returns the name shown to the user for a key,
+// e.g. "Space" for " " and "Esc" for "esc". Single characters are shown as is.
+func keyDisplayName(key string) string {
+	if key == " " {
+		return "Space"
+	}
+	if len(key) > 1 {
+		return strings.ToUpper(key[:1]) + key[1:]
+	}
+	return key
+}
+
+// keyConfigName returns the name used for a key in the config file.
+func keyConfigName(key string) string {
+	if key == " " {
+		return "space"
+	}
+	return key
+}
+
+// WithBinding returns a copy of the key map with the action bound to the given keys.
+// The receiver is left unchanged. Returns an error if the keys are invalid or
+// conflict with another action on the same screen.
+func (k KeyMap) WithBinding(action KeyAction, keys []string) (KeyMap, error) {
+	if _, ok := defaultKeyBindings[action]; !ok {
+		return k, fmt.Errorf("unknown key action %q", action)
+	}
+	normalized, err := normalizeKeys(keys)
+	if err != nil {
+		return k, err
+	}
+
+	bindings := make(map[KeyAction][]string, len(k.bindings))
+	for a, existing := range k.bindings {
+		bindings[a] = existing
+	}
+	bindings[action] = normalized
+
+	updated := KeyMap{bindings: bindings}
+	if err := updated.Validate(); err != nil {
+		return k, err
+	}
+	return updated, nil
+}
+
+// Validate checks that no two actions active on the same screen share a key.
+func (k KeyMap) Validate() error {
+	for _, ctx := range keyContexts {
+		actions := ctx.actions
+		if ctx.global {
+			actions = append(append([]KeyAction(nil), globalKeyActions...), actions...)
+		}
+
+		owner := make(map[string]KeyAction)
+		for _, action := range actions {
+			for _, key := range k.bindings[action] {
+				if other, ok := owner[key]; ok && other != action {
+					return fmt.Errorf("key %q is bound to both %q and %q (%s)",
+						keyDisplayName(key), other, action, ctx.name)
+				}
+				owner[key] = action
+			}
+		}
+	}
+	return nil
+}
+
+// Overrides returns the bindings that differ from the defaults, keyed by action name,
+// in the form stored in the config file.
+func (k KeyMap) Overrides() map[string][]string {
+	var overrides map[string][]string
+	for _, action := range keyActions {
+		keys := k.bindings[action]
+		if equalKeys(keys, defaultKeyBindings[action]) {
+			continue
+		}
+		if overrides == nil {
+			overrides = make(map[string][]string)
+		}
+		stored := make([]string, len(keys))
+		for i, key := range keys {
+			stored[i] = keyConfigName(key)
+		}
+		overrides[string(action)] = stored
+	}
+	return overrides
+}
+
+// equalKeys reports whether two key lists are identical.
+func equalKeys(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,242 @@
+package ui
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/config"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDefaultKeyMapIsValid(t *testing.T) {
+	km := DefaultKeyMap()
+	if err := km.Validate(); err != nil {
+		t.Fatalf("Default key map has conflicts: %v", err)
+	}
+	for _, action := range keyActions {
+		if len(km.Keys(action)) == 0 {
+			t.Errorf("Action %q has no default keys", action)
+		}
+		if keyActionDescriptions[action] == "" {
+			t.Errorf("Action %q has no description", action)
+		}
+	}
+	if km.Overrides() != nil {
+		t.Errorf("Expected no overrides for the default key map, got %v", km.Overrides())
+	}
+}
+
+func TestKeyMapMatches(t *testing.T) {
+	km := DefaultKeyMap()
+
+	tests := []struct {
+		msg    tea.KeyMsg
+		action KeyAction
+		want   bool
+	}{
+		{tea.KeyMsg{Type: tea.KeyUp}, ActionUp, true},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}}, ActionUp, true},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}}, ActionUp, false},
+		{tea.KeyMsg{Type: tea.KeyEnter}, ActionSelect, true},
+		{tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}, ActionToggle, true},
+		{tea.KeyMsg{Type: tea.KeyEsc}, ActionBack, true},
+	}
+
+	for _, tt := range tests {
+		if got := km.Matches(tt.msg, tt.action); got != tt.want {
+			t.Errorf("Matches(%q, %s) = %v, want %v", tt.msg.String(), tt.action, got, tt.want)
+		}
+	}
+
+	// Any of several actions
+	if !km.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}}, ActionUp, ActionDown) {
+		t.Error("Expected 'j' to match one of up/down")
+	}
+}
+
+func TestNewKeyMapOverrides(t *testing.T) {
+	km, err := NewKeyMap(map[string][]string{
+		"up":     {"w", "up"},
+		"toggle": {"space", "p"},
+	})
+	if err != nil {
+		t.Fatalf("NewKeyMap() error = %v", err)
+	}
+
+	if !km.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}}, ActionUp) {
+		t.Error("Expected 'w' to move up")
+	}
+	if km.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}}, ActionUp) {
+		t.Error("Expected override to replace the default 'k'")
+	}
+	if !km.Matches(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}, ActionToggle) {
+		t.Error("Expected \"space\" to bind the space bar")
+	}
+	if got := km.Label(ActionToggle); got != "Space / p" {
+		t.Errorf("Label(toggle) = %q, want %q", got, "Space / p")
+	}
+
+	// Overrides round-trip in config form
+	overrides := km.Overrides()
+	if len(overrides) != 2 {
+		t.Fatalf("Expected 2 overrides, got %v", overrides)
+	}
+	if got := overrides["toggle"]; len(got) != 2 || got[0] != "space" {
+		t.Errorf("Overrides()[toggle] = %v, want [space p]", got)
+	}
+	again, err := NewKeyMap(overrides)
+	if err != nil {
+		t.Fatalf("NewKeyMap(Overrides()) error = %v", err)
+	}
+	if again.Label(ActionUp) != km.Label(ActionUp) {
+		t.Errorf("Round-trip changed up keys: %q vs %q", again.Label(ActionUp), km.Label(ActionUp))
+	}
+}
+
+func TestNewKeyMapErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string][]string
+		wantErr   string
+	}{
+		{"unknown action", map[string][]string{"fly": {"x"}}, "unknown key action"},
+		{"no keys", map[string][]string{"up": {}}, "no keys"},
+		{"reserved key", map[string][]string{"quit": {"ctrl+c"}}, "reserved"},
+		{"debug key", map[string][]string{"help": {"ctrl+g"}}, "reserved"},
+		{"conflict with global", map[string][]string{"up": {"q"}}, "bound to both"},
+		{"conflict on same screen", map[string][]string{"toggle_speed": {"f"}}, "bound to both"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			km, err := NewKeyMap(tt.overrides)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("NewKeyMap() error = %v, want containing %q", err, tt.wantErr)
+			}
+			// The defaults are returned on error
+			if km.Label(ActionUp) != DefaultKeyMap().Label(ActionUp) {
+				t.Error("Expected default key map on error")
+			}
+		})
+	}
+
+	// Keys may be shared by actions that are never active on the same screen
+	if _, err := NewKeyMap(map[string][]string{"analyze": {"f"}}); err != nil {
+		t.Errorf("Expected analyze and copy_fen to share a key, got %v", err)
+	}
+}
+
+func TestNewModelWithInvalidKeyBindings(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.KeyBindings = map[string][]string{"up": {"q"}}
+
+	m := NewModel(cfg)
+	if !strings.Contains(toastError(m), "Invalid key bindings") {
+		t.Errorf("Expected invalid key bindings error, got %q", toastError(m))
+	}
+	if !m.keys.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}}, ActionUp) {
+		t.Error("Expected default bindings after invalid config")
+	}
+}
+
+func TestReboundKeysDriveHandlers(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.KeyBindings = map[string][]string{"down": {"down", "x"}, "new_game": {"ctrl+n"}}
+	m := NewModel(cfg)
+
+	// Rebound navigation key moves the menu selection
+	result, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	m = result.(Model)
+	if m.menuSelection != 1 {
+		t.Errorf("Expected 'x' to move down, menuSelection = %d", m.menuSelection)
+	}
+
+	// The old key no longer works
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m = result.(Model)
+	if m.menuSelection != 1 {
+		t.Errorf("Expected 'j' to be unbound, menuSelection = %d", m.menuSelection)
+	}
+
+	// Rebound global key
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = result.(Model)
+	if m.screen != ScreenMainMenu {
+		t.Errorf("Expected 'n' to be unbound, got screen %v", m.screen)
+	}
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlN})
+	m = result.(Model)
+	if m.screen != ScreenGameTypeSelect {
+		t.Errorf("Expected ctrl+n to start a new game, got screen %v", m.screen)
+	}
+}
+
+func TestKeyBindingsScreen(t *testing.T) {
+	defer func() {
+		configPath, _ := config.GetConfigPath()
+		os.Remove(configPath)
+	}()
+
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenSettings
+	m.settingsSelection = 14
+
+	// Enter on the last settings row opens the Key Bindings screen
+	result, _ := m.handleSettingsKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if m.screen != ScreenKeyBindings {
+		t.Fatalf("Expected ScreenKeyBindings, got %v", m.screen)
+	}
+	if !strings.Contains(m.View(), "Key Bindings") {
+		t.Error("Expected Key Bindings header")
+	}
+
+	// Rebind "up" (first action) to 'w'
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if !m.keyBindingCapture {
+		t.Fatal("Expected Enter to start capturing a key")
+	}
+	// Captured keys bypass global shortcuts such as quit
+	result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+	m = result.(Model)
+	if cmd != nil || m.keyBindingCapture {
+		t.Fatal("Expected the key to be captured")
+	}
+	if got := m.keys.Label(ActionUp); got != "w" {
+		t.Errorf("Label(up) = %q, want %q", got, "w")
+	}
+	if got := LoadConfig().KeyBindings["up"]; len(got) != 1 || got[0] != "w" {
+		t.Errorf("Expected saved binding [w], got %v", got)
+	}
+
+	// A conflicting key is rejected
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m = result.(Model)
+	if toastError(m) == "" || !strings.Contains(toastError(m), "bound to both") {
+		t.Errorf("Expected conflict error, got %q", toastError(m))
+	}
+	if got := m.keys.Label(ActionUp); got != "w" {
+		t.Errorf("Expected binding unchanged after conflict, got %q", got)
+	}
+
+	// 'r' restores the default keys
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	m = result.(Model)
+	if got := m.keys.Label(ActionUp); got != "Up / k" {
+		t.Errorf("Expected default up keys after reset, got %q", got)
+	}
+	if LoadConfig().KeyBindings != nil {
+		t.Error("Expected no saved overrides after reset")
+	}
+
+	// ESC returns to settings
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	if m.screen != ScreenSettings {
+		t.Errorf("Expected ScreenSettings after ESC, got %v", m.screen)
+	}
+}
@@ -0,0 +1,136 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// The game screens place their panels, such as the move history and the live
+// statistics, beside the board when the terminal is wide enough to fit them,
+// and stack them below the board otherwise.
+
+const (
+	// panelGap is the number of columns between the board and the side panels
+	panelGap = 4
+	// minSidePanelWidth is the narrowest side panel worth placing beside the board
+	minSidePanelWidth = 24
+)
+
+// sidePanelWidth returns the width left for panels beside board, or 0 if the
+// terminal is too narrow, or its size not yet known, to place them there.
+func (m Model) sidePanelWidth(board string) int {
+	if m.termWidth <= 0 {
+		return 0
+	}
+	width := m.termWidth - lipgloss.Width(board) - panelGap
+	if width < minSidePanelWidth {
+		return 0
+	}
+	return width
+}
+
+// besideBoard places panels in a column to the right of board, separated by a
+// blank line and wrapped to width. Empty panels are skipped.
+func besideBoard(board string, width int, panels ...string) string {
+	var column []string
+	for _, panel := range panels {
+		if panel == "" {
+			continue
+		}
+		if lipgloss.Width(panel) > width {
+			panel = lipgloss.NewStyle().Width(width).Render(panel)
+		}
+		column = append(column, panel)
+	}
+	if len(column) == 0 {
+		return board
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, board, strings.Repeat(" ", panelGap), strings.Join(column, "\n\n"))
+}
+
+// moveHistoryRows pairs moves, given one string per move, into numbered rows
+// such as "1. e4 e5".
+func moveHistoryRows(moves []string) []string {
+	rows := make([]string, 0, (len(moves)+1)/2)
+	for i := 0; i < len(moves); i += 2 {
+		row := fmt.Sprintf("%d. %s", i/2+1, moves[i])
+		if i+1 < len(moves) {
+			row += " " + moves[i+1]
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// renderMoveHistoryPanel renders the move history as a side panel of at most
+// height lines, one move pair per row. Older moves that don't fit are elided so
+// the latest ones stay visible.
+func (m Model) renderMoveHistoryPanel(moves []string, height int) string {
+	rows := moveHistoryRows(moves)
+	if maxRows := max(height-1, 2); len(rows) > maxRows {
+		rows = append([]string{"..."}, rows[len(rows)-maxRows+1:]...)
+	}
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(m.theme.TitleText)
+	historyStyle := lipgloss.NewStyle().
+		Foreground(m.theme.MenuSelected)
+	return headerStyle.Render("Move History:") + "\n" + historyStyle.Render(strings.Join(rows, "\n"))
+}
+
+// coordinateMoves returns moves in coordinate notation, one string per move.
+func coordinateMoves(moves []engine.Move) []string {
+	out := make([]string, len(moves))
+	for i, move := range moves {
+		out[i] = move.String()
+	}
+	return out
+}
+
+// bvbGridChromeLines is the number of lines of the Bot vs Bot grid view outside
+// the grid and the live statistics: the title, the matchup, the page indicator,
+// the speed and the help text, with the blank lines between them.
+const bvbGridChromeLines = 12
+
+// autoGridSize returns the grid that shows as many of games boards as fit in a
+// width x height area, using as few rows as possible. The grid is at least 1x1.
+func autoGridSize(width, height, games int) (rows, cols int) {
+	maxCols := max(width/(bvbCellWidth+2), 1) // cells are separated by a margin
+	maxRows := max(height/bvbCellHeight, 1)
+	games = max(games, 1)
+
+	cols = min(maxCols, games)
+	rows = min(maxRows, (games+cols-1)/cols)
+	// Spread the boards evenly over the rows, e.g. 2x3 rather than 2x4 for 6 games
+	cols = min(cols, (games+rows-1)/rows)
+	return rows, cols
+}
+
+// layoutBvBGridAuto sizes the Bot vs Bot grid to the terminal and the number of
+// games that run at once, keeping the first game on the page in view. It does
+// nothing unless the Auto layout was chosen or before the terminal size is known.
+func (m *Model) layoutBvBGridAuto() {
+	if !m.bvbGridAuto || m.bvbManager == nil || m.termWidth <= 0 || m.termHeight <= 0 {
+		return
+	}
+
+	height := m.termHeight - bvbGridChromeLines - lipgloss.Height(m.renderBvBLiveStats())
+	if m.bvbGridFilter != BvBFilterAll {
+		height-- // the "Showing:" line
+	}
+	games := min(m.bvbManager.Concurrency(), m.bvbManager.GameCount())
+	rows, cols := autoGridSize(m.termWidth, height, games)
+	if rows == m.bvbGridRows && cols == m.bvbGridCols {
+		return
+	}
+
+	if perPage := m.bvbGridRows * m.bvbGridCols; perPage > 0 {
+		first := m.bvbPageIndex * perPage
+		m.bvbPageIndex = first / (rows * cols)
+	}
+	m.bvbGridRows, m.bvbGridCols = rows, cols
+}
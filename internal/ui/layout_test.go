@@ -0,0 +1,224 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/bvb"
+	"github.com/Mgrdich/TermChess/internal/config"
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestSidePanelWidth(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	board := strings.Repeat("x", 20)
+
+	tests := []struct {
+		termWidth int
+		want      int
+	}{
+		{0, 0},
+		{40, 0},
+		{20 + panelGap + minSidePanelWidth, minSidePanelWidth},
+		{120, 120 - 20 - panelGap},
+	}
+	for _, tt := range tests {
+		m.termWidth = tt.termWidth
+		if got := m.sidePanelWidth(board); got != tt.want {
+			t.Errorf("sidePanelWidth() at width %d = %d, want %d", tt.termWidth, got, tt.want)
+		}
+	}
+}
+
+func TestBesideBoard(t *testing.T) {
+	out := besideBoard("AA\nAA\nAA", 10, "one", "", "a long panel line")
+	lines := strings.Split(out, "\n")
+	if !strings.HasPrefix(lines[0], "AA") || !strings.HasSuffix(strings.TrimRight(lines[0], " "), "one") {
+		t.Errorf("first line = %q, want the board then the first panel", lines[0])
+	}
+	for _, line := range lines {
+		if ansi.StringWidth(line) > 2+panelGap+10 {
+			t.Errorf("line %q is wider than the board, gap and panel width", line)
+		}
+	}
+	if got := besideBoard("AA", 10, ""); got != "AA" {
+		t.Errorf("besideBoard() without panels = %q, want the board alone", got)
+	}
+}
+
+func TestMoveHistoryRows(t *testing.T) {
+	got := moveHistoryRows([]string{"e4", "e5", "Nf3"})
+	want := []string{"1. e4 e5", "2. Nf3"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("moveHistoryRows() = %q, want %q", got, want)
+	}
+}
+
+func TestMoveHistoryPanelKeepsLatestMoves(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	moves := make([]string, 40)
+	for i := range moves {
+		moves[i] = "m"
+	}
+	panel := ansi.Strip(m.renderMoveHistoryPanel(moves, 6))
+	lines := strings.Split(panel, "\n")
+	if len(lines) != 6 || strings.TrimSpace(lines[1]) != "..." || lines[5] != "20. m m" {
+		t.Errorf("panel = %q, want the header, an ellipsis and the last four rows", lines)
+	}
+}
+
+func TestGamePlayLayoutFollowsTerminalWidth(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ShowMoveHistory = true
+	m := NewModel(cfg)
+	m.screen = ScreenGamePlay
+	m.game = engine.NewGame()
+	move, _ := engine.ParseMove("e2e4")
+	_ = m.game.MakeMove(move)
+
+	m.termWidth = 120
+	wide := ansi.Strip(m.renderGamePlay())
+	if strings.Count(wide, "Move History:") != 1 {
+		t.Fatalf("wide layout shows the history %d times, want once", strings.Count(wide, "Move History:"))
+	}
+	for _, line := range strings.Split(wide, "\n") {
+		if strings.Contains(line, "Move History:") && !strings.Contains(line, "8") {
+			t.Errorf("history header on %q, want it beside the top rank", line)
+		}
+	}
+
+	m.termWidth = 40
+	narrow := ansi.Strip(m.renderGamePlay())
+	if !strings.Contains(narrow, "Move History: 1. e4") {
+		t.Errorf("narrow layout = %q, want the history stacked below the board", narrow)
+	}
+}
+
+func TestAutoGridSize(t *testing.T) {
+	tests := []struct {
+		name               string
+		width, height      int
+		games              int
+		wantRows, wantCols int
+	}{
+		{"one game", 200, 100, 1, 1, 1},
+		{"wide terminal fits a row", 200, 30, 4, 1, 4},
+		{"rows when a row is full", 100, 40, 8, 2, 4},
+		{"spread over the rows", 120, 40, 6, 2, 3},
+		{"limited by height", 100, 15, 8, 1, 4},
+		{"tiny terminal still shows a board", 10, 5, 8, 1, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, cols := autoGridSize(tt.width, tt.height, tt.games)
+			if rows != tt.wantRows || cols != tt.wantCols {
+				t.Errorf("autoGridSize(%d, %d, %d) = %dx%d, want %dx%d",
+					tt.width, tt.height, tt.games, rows, cols, tt.wantRows, tt.wantCols)
+			}
+		})
+	}
+}
+
+func TestBvBGridAutoReflowsOnResize(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenBvBGamePlay
+	m.bvbViewMode = BvBGridView
+	m.bvbGridAuto = true
+	m.bvbManager = bvb.NewSessionManager(0, 0, "Easy Bot", "Easy Bot", 8, 8)
+
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	m = result.(Model)
+	if m.bvbGridRows != 2 || m.bvbGridCols != 4 {
+		t.Fatalf("Expected a 2x4 grid in 100x40, got %dx%d", m.bvbGridRows, m.bvbGridCols)
+	}
+
+	m.bvbPageIndex = 1 // games 9-16 of a larger session
+	result, _ = m.Update(tea.WindowSizeMsg{Width: 60, Height: 30})
+	m = result.(Model)
+	if m.bvbGridRows != 1 || m.bvbGridCols != 2 {
+		t.Fatalf("Expected a 1x2 grid in 60x30, got %dx%d", m.bvbGridRows, m.bvbGridCols)
+	}
+	if m.bvbPageIndex != 4 {
+		t.Errorf("Expected the page showing game 9 (4), got %d", m.bvbPageIndex)
+	}
+	if view := m.renderBvBGridView(); strings.Contains(view, "Terminal too small") {
+		t.Error("The Auto layout should not report the terminal as too small")
+	}
+}
+
+// TestPositionInfo tests the info line under the board.
+func TestPositionInfo(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.game = engine.NewGame()
+	m.screen = ScreenGamePlay
+
+	want := "Legal moves: 20 | Check: no | Fifty-move rule: 0/100 | Phase: opening"
+	if got := positionInfo(m.game.Board()); got != want {
+		t.Errorf("positionInfo() = %q, want %q", got, want)
+	}
+	if !strings.Contains(m.renderGamePlay(), want) {
+		t.Error("Expected the game screen to show the position info")
+	}
+
+	board, err := engine.FromFEN("4k3/8/8/8/8/8/4r3/4K3 w - - 37 60")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = "Legal moves: 3 | Check: yes | Fifty-move rule: 37/100 | Phase: endgame"
+	if got := positionInfo(board); got != want {
+		t.Errorf("positionInfo() = %q, want %q", got, want)
+	}
+}
+
+// TestGameHeader tests the line describing the game above the board.
+func TestGameHeader(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.game = engine.NewGame()
+	m.screen = ScreenGamePlay
+	m.gameType = GameTypePvBot
+	m.botDifficulty = BotHard
+	m.userColor = engine.Black
+
+	want := "vs Hard Bot | You: Black | Untimed | Move 1"
+	if got := m.gameHeader(); got != want {
+		t.Errorf("gameHeader() = %q, want %q", got, want)
+	}
+	if !strings.Contains(m.renderGamePlay(), want) {
+		t.Error("Expected the game screen to show the game header")
+	}
+
+	m.gameType = GameTypePvP
+	m.game.Board().FullMoveNum = 23
+	if got, want := m.gameHeader(), "Player vs Player | Untimed | Move 23"; got != want {
+		t.Errorf("gameHeader() = %q, want %q", got, want)
+	}
+
+	m.gameType = GameTypeCorrespondence
+	m.correspondence = &config.CorrespondenceGame{White: "someone", Black: m.playerName(), DaysPerMove: 3}
+	if got, want := m.gameHeader(), "Correspondence | You: Black | 3 days per move | Move 23"; got != want {
+		t.Errorf("gameHeader() = %q, want %q", got, want)
+	}
+}
+
+// TestRepetitionWarning tests the warning shown once the position has occurred twice.
+func TestRepetitionWarning(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.game = engine.NewGame()
+	m.screen = ScreenGamePlay
+
+	const warning = "one more repetition is a draw"
+	for i, move := range []string{"g1f3", "g8f6", "f3g1", "f6g8"} {
+		if strings.Contains(m.renderGamePlay(), warning) {
+			t.Fatalf("Expected no repetition warning after %d moves", i)
+		}
+		mv, _ := engine.ParseMove(move)
+		if err := m.game.MakeMove(mv); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !strings.Contains(m.renderGamePlay(), warning) {
+		t.Error("Expected a repetition warning once the starting position occurred twice")
+	}
+}
@@ -0,0 +1,271 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// learnTextWidth is the widest the text of the Learn pages is wrapped at.
+const learnTextWidth = 76
+
+// learnPage is a page of the Learn section, made of sections of paragraphs and
+// examples.
+type learnPage struct {
+	title    string
+	sections []learnSection
+}
+
+// learnSection is a heading followed by paragraphs and a list of examples.
+type learnSection struct {
+	heading    string
+	paragraphs []string
+	// examples pair what is typed with what it means
+	examples [][2]string
+}
+
+// learnPages returns the pages of the Learn section: notation, special moves and
+// the app's commands, with the keys as currently bound.
+func (m Model) learnPages() []learnPage {
+	return []learnPage{
+		{title: "Notation", sections: []learnSection{
+			{
+				heading: "Squares",
+				paragraphs: []string{
+					"Moves are typed in Standard Algebraic Notation (SAN), the notation of chess books and sites. " +
+						"Every square is named by its file, a to h from White's left to right, and its rank, 1 to 8 from White's side: " +
+						"White's king starts on e1 and Black's on e8.",
+				},
+			},
+			{
+				heading: "Pieces",
+				paragraphs: []string{
+					"A move is the letter of the piece followed by the square it goes to: K king, Q queen, R rook, B bishop and N knight. " +
+						"Pawns have no letter, so a pawn move is just its square.",
+				},
+				examples: [][2]string{
+					{"e4", "a pawn moves to e4"},
+					{"Nf3", "a knight moves to f3"},
+					{"Qh5", "the queen moves to h5"},
+				},
+			},
+			{
+				heading: "Captures, check and mate",
+				paragraphs: []string{
+					"An x marks a capture. A pawn capture starts with the file the pawn comes from. " +
+						"A + after a move marks check and a # checkmate; both are optional when typing.",
+				},
+				examples: [][2]string{
+					{"Bxc6", "the bishop captures on c6"},
+					{"exd5", "the pawn on the e-file captures on d5"},
+					{"Qxf7#", "the queen captures on f7 with checkmate"},
+				},
+			},
+			{
+				heading: "Two pieces that can go to the same square",
+				paragraphs: []string{
+					"Add the file, or if that's not enough the rank, the moving piece starts from.",
+				},
+				examples: [][2]string{
+					{"Nbd2", "the knight on the b-file moves to d2"},
+					{"R1e2", "the rook on the first rank moves to e2"},
+				},
+			},
+			{
+				heading: "Other ways to type moves",
+				paragraphs: []string{
+					"Coordinate notation works too: the square a piece leaves followed by the square it goes to. " +
+						"Common slips are forgiven: lowercase piece letters (nf3), a missing x (Nd5 for Nxd5), dashes (e2-e4) and zeros for castling (0-0). " +
+						"Tab completes a move you started typing.",
+					"Piece letters follow the Language setting, e.g. S for the knight (Springer) in German.",
+				},
+				examples: [][2]string{
+					{"e2e4", "the piece on e2 moves to e4"},
+					{"g1f3", "the piece on g1 moves to f3"},
+				},
+			},
+		}},
+		{title: "Special Moves", sections: []learnSection{
+			{
+				heading: "Castling",
+				paragraphs: []string{
+					"The king moves two squares towards a rook and the rook jumps to the square the king crossed. " +
+						"It is only allowed if neither piece has moved yet, the squares between them are empty, and the king is not in check " +
+						"and doesn't cross or land on an attacked square.",
+				},
+				examples: [][2]string{
+					{"O-O", "castle kingside, with the rook on the h-file"},
+					{"O-O-O", "castle queenside, with the rook on the a-file"},
+				},
+			},
+			{
+				heading: "En passant",
+				paragraphs: []string{
+					"A pawn that moves two squares from its starting rank can be captured by an enemy pawn standing next to it, " +
+						"as if it had moved only one square. The capture is only allowed on the very next move, and is typed like any pawn capture.",
+				},
+				examples: [][2]string{
+					{"exd6", "after ...d5, the pawn on e5 captures the d-pawn, landing on d6"},
+				},
+			},
+			{
+				heading: "Promotion",
+				paragraphs: []string{
+					"A pawn reaching the last rank becomes a queen, rook, bishop or knight of the same color. " +
+						"Write the new piece after an = sign, or at the end of a move in coordinate notation.",
+				},
+				examples: [][2]string{
+					{"e8=Q", "the pawn promotes to a queen on e8"},
+					{"exd8=N", "the pawn captures on d8 and becomes a knight"},
+					{"e7e8q", "the same as e8=Q in coordinate notation"},
+				},
+			},
+			{
+				heading: "How games end",
+				paragraphs: []string{
+					"Checkmate wins the game: the king is in check and no move gets it out. " +
+						"A player with no legal move who is not in check is stalemated, which is a draw, " +
+						"as is a position where neither side has enough pieces left to mate.",
+					"A draw can also be claimed when the same position occurs for the third time, " +
+						"or after fifty moves by each side without a capture or a pawn move.",
+				},
+			},
+		}},
+		{title: "Commands", sections: []learnSection{
+			{
+				heading: "During a game",
+				paragraphs: []string{
+					"Type a command instead of a move and press Enter.",
+				},
+				examples: [][2]string{
+					{"resign", "give up the game"},
+					{"offerdraw", "offer a draw"},
+					{"claimdraw", "claim a draw by repetition or the fifty-move rule"},
+					{"takeback", "take back your last move (training mode)"},
+					{"showfen", "show the position as FEN and copy it"},
+					{diagramCommand + " [plain]", "copy a text diagram of the position"},
+					{"note <text>", "note a thought, exported as a PGN comment"},
+					{"menu", "return to the menu, offering to save"},
+				},
+			},
+			{
+				heading: "Keys",
+				examples: [][2]string{
+					{"Enter", "submit the move or command typed"},
+					{"Tab", "complete the move typed"},
+					{"↑/↓", "recall earlier moves and commands"},
+					{"ESC", "return to the menu, offering to save"},
+					{m.keys.Label(ActionHelp), "show every keyboard shortcut"},
+					{m.keys.Label(ActionCommandPalette), "open the command palette"},
+				},
+			},
+		}},
+	}
+}
+
+// openLearn opens the Learn section on its first page.
+func (m *Model) openLearn() {
+	m.learnPage = 0
+	m.scrollOffset = 0
+	m.pushScreen(ScreenLearn)
+}
+
+// handleLearnKeys handles keyboard input for the Learn section: left and right
+// (or the page numbers) switch pages, the scroll keys scroll the page and ESC
+// goes back.
+func (m Model) handleLearnKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	pages := len(m.learnPages())
+	header, body, footer := m.learnParts()
+	switch {
+	case m.scrollContent(msg, body, m.learnScrollHeight(header, footer), true):
+	case m.keys.Matches(msg, ActionLeft):
+		m.learnPage = (m.learnPage + pages - 1) % pages
+		m.scrollOffset = 0
+	case m.keys.Matches(msg, ActionRight), msg.String() == "tab":
+		m.learnPage = (m.learnPage + 1) % pages
+		m.scrollOffset = 0
+	case len(msg.Runes) == 1 && msg.Runes[0] >= '1' && int(msg.Runes[0]-'1') < pages:
+		m.learnPage = int(msg.Runes[0] - '1')
+		m.scrollOffset = 0
+	case m.keys.Matches(msg, ActionBack):
+		m.scrollOffset = 0
+		m.popScreen()
+	}
+	return m, nil
+}
+
+// learnScrollHeight returns the number of lines the page may take between
+// header and footer.
+func (m Model) learnScrollHeight(header, footer string) int {
+	return m.termHeight - lipgloss.Height(header) - lipgloss.Height(footer)
+}
+
+// renderLearn renders the Learn section. The page scrolls when it doesn't fit
+// the terminal, while the page tabs and help stay in view.
+func (m Model) renderLearn() string {
+	header, body, footer := m.learnParts()
+	return header + m.renderScrolled(body, m.learnScrollHeight(header, footer)) + footer
+}
+
+// learnParts renders the Learn section in three parts: the title and the tabs
+// of the pages in header, the current page in body and the help in footer.
+func (m Model) learnParts() (header, body, footer string) {
+	pages := m.learnPages()
+	page := pages[min(m.learnPage, len(pages)-1)]
+
+	var h strings.Builder
+	h.WriteString(m.titleStyle().Render("TermChess"))
+	h.WriteString("\n")
+	h.WriteString(m.renderBreadcrumb())
+	selectedTab := lipgloss.NewStyle().Bold(true).Foreground(m.theme.MenuSelected)
+	tab := lipgloss.NewStyle().Foreground(m.theme.MenuSecondary)
+	tabs := make([]string, len(pages))
+	for i, p := range pages {
+		label := fmt.Sprintf("%d %s", i+1, p.title)
+		if p.title == page.title {
+			tabs[i] = selectedTab.Render(label)
+		} else {
+			tabs[i] = tab.Render(label)
+		}
+	}
+	h.WriteString(strings.Join(tabs, m.menuSeparatorStyle().Render(" | ")))
+	h.WriteString("\n\n")
+
+	width := learnTextWidth
+	if m.termWidth > 0 {
+		width = min(width, m.termWidth-2)
+	}
+	headingStyle := lipgloss.NewStyle().Bold(true).Foreground(m.theme.TitleText)
+	textStyle := lipgloss.NewStyle().Foreground(m.theme.MenuNormal).Width(width)
+	exampleStyle := lipgloss.NewStyle().Foreground(m.theme.StatusText)
+	meaningStyle := lipgloss.NewStyle().Foreground(m.theme.MenuNormal)
+
+	var b strings.Builder
+	for i, section := range page.sections {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(headingStyle.Render(section.heading))
+		b.WriteString("\n")
+		for _, p := range section.paragraphs {
+			b.WriteString(textStyle.Render(p))
+			b.WriteString("\n")
+		}
+		column := 0
+		for _, e := range section.examples {
+			column = max(column, lipgloss.Width(e[0]))
+		}
+		for _, e := range section.examples {
+			example := e[0] + strings.Repeat(" ", column-lipgloss.Width(e[0]))
+			b.WriteString("  " + exampleStyle.Render(example) + "  " + meaningStyle.Render(e[1]))
+			b.WriteString("\n")
+		}
+	}
+
+	if helpText := m.renderHelpText("←/→ or 1-3: page | ↑/↓: scroll | ESC: back"); helpText != "" {
+		footer = "\n" + helpText
+	}
+	return h.String(), b.String(), footer
+}
@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestLearnFromMainMenu(t *testing.T) {
+	m := snapshotModel(t)
+	m = pressKeys(m, "down", "down", "down", "down", "enter")
+	if m.screen != ScreenLearn {
+		t.Fatalf("Expected Learn to open the Learn section, got screen %v", m.screen)
+	}
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "Standard Algebraic Notation") {
+		t.Errorf("Expected the notation page first, got\n%s", view)
+	}
+
+	m = pressKeys(m, "right")
+	if view := ansi.Strip(m.View()); m.learnPage != 1 || !strings.Contains(view, "En passant") {
+		t.Errorf("Expected right to show the special moves, got page %d", m.learnPage)
+	}
+	m = pressKeys(m, "3")
+	if view := ansi.Strip(m.View()); m.learnPage != 2 || !strings.Contains(view, "offerdraw") {
+		t.Errorf("Expected 3 to show the commands, got page %d", m.learnPage)
+	}
+	m = pressKeys(m, "right")
+	if m.learnPage != 0 {
+		t.Errorf("Expected the pages to wrap around, got page %d", m.learnPage)
+	}
+
+	m = pressKeys(m, "esc")
+	if m.screen != ScreenMainMenu {
+		t.Errorf("Expected ESC to return to the main menu, got screen %v", m.screen)
+	}
+}
+
+func TestLearnScrolls(t *testing.T) {
+	m := pressKeys(snapshotModel(t), tea.WindowSizeMsg{Width: 60, Height: 20})
+	m.openLearn()
+
+	view := ansi.Strip(m.View())
+	if !strings.Contains(view, "Lines 1-") {
+		t.Fatalf("Expected the page to scroll in a short terminal, got\n%s", view)
+	}
+	if lines := strings.Count(strings.TrimSuffix(m.View(), "\n"), "\n") + 1; lines > 20 {
+		t.Errorf("Expected the page to fit the terminal, got %d lines", lines)
+	}
+
+	m = pressKeys(m, "down", "down")
+	if m.scrollOffset != 2 {
+		t.Errorf("Expected down to scroll a line at a time, got offset %d", m.scrollOffset)
+	}
+	m = pressKeys(m, "right")
+	if m.scrollOffset != 0 {
+		t.Errorf("Expected a new page to start at the top, got offset %d", m.scrollOffset)
+	}
+}
@@ -0,0 +1,218 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// matchLengths lists the match lengths offered on the color selection screen,
+// in games. 0 plays single games, each rematch adding to an open-ended score.
+var matchLengths = []int{0, 3, 5, 7}
+
+// matchLengthName describes a match length, e.g. "Best of 5".
+func matchLengthName(length int) string {
+	if length == 0 {
+		return "Single game"
+	}
+	return fmt.Sprintf("Best of %d", length)
+}
+
+// matchGame is the result of one game of a match.
+type matchGame struct {
+	userColor engine.Color
+	// result is the PGN result, e.g. "1-0"
+	result string
+	// points is what the user scored: 1 for a win, 0.5 for a draw and 0 for a loss
+	points float64
+}
+
+// matchScore is the running score of a match of PvBot games, in points.
+type matchScore struct {
+	user, bot float64
+	games     []matchGame
+	// length is the number of games of a best-of-N match, or 0 for an open-ended one
+	length int
+}
+
+// record adds the result of a game the user played as userColor.
+func (s *matchScore) record(userColor engine.Color, result string) {
+	game := matchGame{userColor: userColor, result: result}
+	switch {
+	case result == "1/2-1/2":
+		game.points = 0.5
+	case (result == "1-0") == (userColor == engine.White):
+		game.points = 1
+	}
+	s.user += game.points
+	s.bot += 1 - game.points
+	s.games = append(s.games, game)
+}
+
+// decided reports whether a best-of-N match is over: one side has more than
+// half the points, or all its games have been played.
+func (s matchScore) decided() bool {
+	if s.length == 0 {
+		return false
+	}
+	half := float64(s.length) / 2
+	return s.user > half || s.bot > half || len(s.games) >= s.length
+}
+
+// String returns the score as "user-bot", e.g. "2.5–1.5".
+func (s matchScore) String() string {
+	return strconv.FormatFloat(s.user, 'f', -1, 64) + "–" + strconv.FormatFloat(s.bot, 'f', -1, 64)
+}
+
+// recordMatchResult adds the result of the finished PvBot game to the match score.
+func (m *Model) recordMatchResult() {
+	m.match.record(m.userColor, m.game.Result())
+}
+
+// matchOver reports whether the last PvBot game decided a best-of-N match.
+func (m Model) matchOver() bool {
+	return m.gameType == GameTypePvBot && m.match.decided()
+}
+
+// matchBotName returns the name of the bot the match is played against.
+func (m Model) matchBotName() string {
+	return m.botConfig().DisplayName()
+}
+
+// matchStatus describes the running score of a PvBot match, e.g.
+// "Best of 5, game 3: You 1.5–0.5 Medium Bot", or returns "" while an
+// open-ended match has no finished game yet.
+func (m Model) matchStatus() string {
+	if m.gameType != GameTypePvBot {
+		return ""
+	}
+	score := fmt.Sprintf("%s %s %s", m.playerName(), m.match, m.matchBotName())
+	switch {
+	case m.match.length > 0:
+		game := len(m.match.games) + 1
+		if m.screen == ScreenGameOver || m.screen == ScreenMatchSummary {
+			game--
+		}
+		return fmt.Sprintf("%s, game %d: %s", matchLengthName(m.match.length), game, score)
+	case len(m.match.games) > 0:
+		return "Match: " + score
+	}
+	return ""
+}
+
+// matchWinner announces the outcome of a decided match.
+func (m Model) matchWinner() string {
+	switch {
+	case m.match.user > m.match.bot:
+		return fmt.Sprintf("%s wins the match %s", m.playerName(), m.match)
+	case m.match.bot > m.match.user:
+		return fmt.Sprintf("%s wins the match %s", m.matchBotName(), m.match)
+	}
+	return fmt.Sprintf("The match is drawn %s", m.match)
+}
+
+// cycleMatchLength selects the next (delta 1) or previous (delta -1) match
+// length on the color selection screen.
+func (m *Model) cycleMatchLength(delta int) {
+	i := 0
+	for j, length := range matchLengths {
+		if length == m.matchLengthOption {
+			i = j
+		}
+	}
+	i = (i + delta + len(matchLengths)) % len(matchLengths)
+	m.matchLengthOption = matchLengths[i]
+}
+
+// openMatchSummary shows the summary of the decided match.
+func (m Model) openMatchSummary() (tea.Model, tea.Cmd) {
+	m.dismissToasts()
+	m.openMenu(ScreenMatchSummary)
+	return m, nil
+}
+
+// matchSummaryMenu declares the menu of the match summary screen.
+func matchSummaryMenu() []MenuItem {
+	return []MenuItem{
+		{Label: "New Match", Hint: "same bot and length", Action: Model.startNewMatch},
+		{Label: "Main Menu", Kind: MenuItemSecondary, Separated: true, Action: Model.leaveGameOver},
+	}
+}
+
+// startNewMatch starts another match of the same length and variant against the
+// same bot, with the user playing the color of the first game of the last one.
+func (m Model) startNewMatch() (tea.Model, tea.Cmd) {
+	color := m.userColor
+	if len(m.match.games) > 0 {
+		color = m.match.games[0].userColor
+	}
+	var variant engine.Variant
+	if m.game != nil {
+		variant = m.game.Board().Variant
+	}
+	m.match = matchScore{length: m.match.length}
+	return m.startBotGameVariant(color, variant)
+}
+
+// handleMatchSummaryKeys handles keyboard input for the match summary screen.
+// ESC returns to the game over screen of the last game.
+func (m Model) handleMatchSummaryKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.dismissToasts()
+	if m.keys.Matches(msg, ActionBack) {
+		m.popScreen()
+		return m, nil
+	}
+	return m.updateMenu(msg)
+}
+
+// renderMatchSummary renders the match summary screen: the result of each game
+// and the winner of the match.
+func (m Model) renderMatchSummary() string {
+	var b strings.Builder
+
+	b.WriteString(m.titleStyle().Render("TermChess"))
+	b.WriteString("\n")
+	b.WriteString(m.renderBreadcrumb())
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(m.theme.TitleText).
+		Padding(0, 0, 1, 0)
+	b.WriteString(headerStyle.Render(fmt.Sprintf("%s vs %s", matchLengthName(m.match.length), m.matchBotName())))
+	b.WriteString("\n")
+
+	lineStyle := lipgloss.NewStyle().Foreground(m.theme.MenuNormal)
+	for i, g := range m.match.games {
+		outcome := "Draw"
+		switch g.points {
+		case 1:
+			outcome = "Win"
+		case 0:
+			outcome = "Loss"
+		}
+		side := "White"
+		if g.userColor == engine.Black {
+			side = "Black"
+		}
+		b.WriteString(lineStyle.Render(fmt.Sprintf("Game %d  as %-5s  %-7s  %s", i+1, side, g.result, outcome)))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(lipgloss.NewStyle().Bold(true).Foreground(m.theme.TitleText).Render(m.matchWinner()))
+	b.WriteString("\n\n")
+
+	b.WriteString(m.renderMenu(m.menuSelection))
+
+	helpText := m.renderHelpText("ESC: back to game | arrows/jk: navigate | enter: select")
+	if helpText != "" {
+		b.WriteString("\n\n")
+		b.WriteString(helpText)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestMatchScoreDecided(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []string
+		want    bool
+	}{
+		{"one win", []string{"1-0"}, false},
+		{"two wins", []string{"1-0", "1-0"}, true},
+		{"win and draw", []string{"1-0", "1/2-1/2"}, false},
+		{"all games drawn", []string{"1/2-1/2", "1/2-1/2", "1/2-1/2"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := matchScore{length: 3}
+			for _, r := range tt.results {
+				s.record(engine.White, r)
+			}
+			if got := s.decided(); got != tt.want {
+				t.Errorf("decided() after %v = %v, want %v", tt.results, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColorSelectMatchLength(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.openMenu(ScreenColorSelect)
+
+	result, _ := m.handleColorSelectKeys(tea.KeyMsg{Type: tea.KeyRight})
+	m = result.(Model)
+	if m.matchLengthOption != 3 {
+		t.Fatalf("Expected right to choose a best of 3 match, got %d", m.matchLengthOption)
+	}
+	if !strings.Contains(ansi.Strip(m.renderColorSelect()), "Best of 3") {
+		t.Error("Expected the color selection screen to show the match length")
+	}
+
+	result, _ = m.handleColorSelectKeys(tea.KeyMsg{Type: tea.KeyLeft})
+	m = result.(Model)
+	result, _ = m.handleColorSelectKeys(tea.KeyMsg{Type: tea.KeyLeft})
+	if m = result.(Model); m.matchLengthOption != 7 {
+		t.Errorf("Expected left to wrap around to best of 7, got %d", m.matchLengthOption)
+	}
+}
+
+// loseGame ends the current game with the user resigning.
+func loseGame(m Model) Model {
+	m.game.Resign(m.userColor)
+	m.showGameOver()
+	return m
+}
+
+func TestBestOfThreeMatch(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.gameType = GameTypePvBot
+	m.botDifficulty = BotMedium
+	m.matchLengthOption = 3
+
+	result, _ := m.startBotGame(engine.White)
+	m = loseGame(result.(Model))
+	if m.menuOptions[0] != "Next Game" {
+		t.Fatalf("Expected the match to go on, got menu %v", m.menuOptions)
+	}
+	if view := ansi.Strip(m.renderGameOver()); !strings.Contains(view, "Best of 3, game 1:") {
+		t.Error("Expected the game over screen to show the match score")
+	}
+
+	result, _ = m.handleGameOverKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if m.userColor != engine.Black {
+		t.Fatal("Expected the colors to alternate in the next game")
+	}
+	m = loseGame(m)
+	if !m.matchOver() || m.menuOptions[0] != "Match Summary" {
+		t.Fatalf("Expected two losses to decide the match, got menu %v", m.menuOptions)
+	}
+
+	result, _ = m.handleGameOverKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	m = result.(Model)
+	if m.screen != ScreenMatchSummary {
+		t.Fatalf("Expected 'r' to open the match summary once it is decided, got screen %v", m.screen)
+	}
+	view := ansi.Strip(m.renderMatchSummary())
+	for _, want := range []string{"Best of 3 vs Medium Bot", "as Black", "Medium Bot wins the match 0–2"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected the summary to contain %q, got:\n%s", want, view)
+		}
+	}
+
+	result, _ = m.handleMatchSummaryKeys(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	if m.screen != ScreenGameOver || m.menuOptions[0] != "Match Summary" {
+		t.Fatalf("Expected ESC to return to the game over menu, got screen %v, menu %v", m.screen, m.menuOptions)
+	}
+
+	m.openMenu(ScreenMatchSummary)
+	result, _ = m.runMenuItem(0)
+	m = result.(Model)
+	if m.screen != ScreenGamePlay || m.userColor != engine.White || len(m.match.games) != 0 || m.match.length != 3 {
+		t.Errorf("Expected New Match to start another best of 3 as White, got color %d, match %+v", m.userColor, m.match)
+	}
+}
@@ -0,0 +1,416 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Mgrdich/TermChess/internal/bot"
+	"github.com/Mgrdich/TermChess/internal/config"
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// MenuItemKind sets how a menu item is styled.
+type MenuItemKind int
+
+const (
+	// MenuItemPrimary is a main action of its screen, such as starting a game
+	MenuItemPrimary MenuItemKind = iota
+	// MenuItemSecondary is a less prominent action, such as Settings or Exit
+	MenuItemSecondary
+	// MenuItemResume is the highlighted action picking up where the user left off
+	MenuItemResume
+)
+
+// MenuItem is one entry of a menu screen. Menus are declared as lists of items
+// and share their key handling and rendering, so adding an entry only means
+// adding it to its list.
+type MenuItem struct {
+	// Label is the text shown for the item
+	Label string
+	// Hint, if set, is shown in parentheses after the label
+	Hint string
+	// Kind sets how the item is styled
+	Kind MenuItemKind
+	// Separated draws a separator line above the item
+	Separated bool
+	// Action runs when the item is selected
+	Action func(Model) (tea.Model, tea.Cmd)
+}
+
+// menuLabels returns the labels of items, in order.
+func menuLabels(items []MenuItem) []string {
+	labels := make([]string, len(items))
+	for i, item := range items {
+		labels[i] = item.Label
+	}
+	return labels
+}
+
+// menuItems returns every item the menu of the current screen can offer.
+func (m Model) menuItems() []MenuItem {
+	switch m.screen {
+	case ScreenMainMenu:
+		return mainMenu(true)
+	case ScreenGameTypeSelect:
+		return gameTypeMenu()
+	case ScreenBotSelect:
+		return botMenu(Model.chooseBot)
+	case ScreenHandicapSelect:
+		return handicapMenu()
+	case ScreenColorSelect:
+		return colorMenu()
+	case ScreenBvBBotSelect:
+		items := botMenu(Model.chooseBvBBot)
+		for i := range items {
+			items[i].Hint = engineBackend(items[i].Label)
+		}
+		return items
+	case ScreenBvBGameMode:
+		return bvbGameModeMenu()
+	case ScreenBvBGridConfig:
+		return bvbGridMenu()
+	case ScreenBvBStats:
+		return bvbStatsMenu()
+	case ScreenProfileSelect:
+		return profileMenu()
+	case ScreenGameOver:
+		return m.gameOverMenu()
+	case ScreenMatchSummary:
+		return matchSummaryMenu()
+	case ScreenSimulSetup:
+		return simulBoardMenu()
+	case ScreenGuessSelect:
+		return guessGameMenu()
+	}
+	return nil
+}
+
+// currentMenu returns the items shown on the current screen, in the order of
+// menuOptions. A label the screen doesn't declare is shown but does nothing.
+func (m Model) currentMenu() []MenuItem {
+	declared := m.menuItems()
+	items := make([]MenuItem, len(m.menuOptions))
+	for i, label := range m.menuOptions {
+		items[i] = MenuItem{Label: label}
+		for _, item := range declared {
+			if item.Label == label {
+				items[i] = item
+				break
+			}
+		}
+	}
+	return items
+}
+
+// updateMenu applies a key press to the menu of the current screen: up and down
+// move the selection, wrapping around at either end, and Enter runs the selected
+// item. Other keys are left to the screen.
+func (m Model) updateMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	count := len(m.menuOptions)
+	switch {
+	case count == 0:
+	case m.keys.Matches(msg, ActionUp):
+		m.menuSelection = (m.menuSelection - 1 + count) % count
+	case m.keys.Matches(msg, ActionDown):
+		m.menuSelection = (m.menuSelection + 1) % count
+	case m.keys.Matches(msg, ActionSelect):
+		return m.runMenuItem(m.menuSelection)
+	}
+	return m, nil
+}
+
+// selectMenuItem runs the action of the selected item of the current screen's menu.
+func (m Model) selectMenuItem() (tea.Model, tea.Cmd) {
+	return m.runMenuItem(m.menuSelection)
+}
+
+// runMenuItem runs the action of item i of the current screen's menu.
+func (m Model) runMenuItem(i int) (tea.Model, tea.Cmd) {
+	items := m.currentMenu()
+	if i < 0 || i >= len(items) || items[i].Action == nil {
+		return m, nil
+	}
+	return items[i].Action(m)
+}
+
+// renderMenu renders the menu of the current screen with item selection
+// highlighted. Primary items get a prominent ">>" cursor, secondary ones a
+// lighter ">".
+func (m Model) renderMenu(selection int) string {
+	var b strings.Builder
+	for i, item := range m.currentMenu() {
+		if item.Separated {
+			b.WriteString(m.renderMenuSeparator())
+			b.WriteString("\n")
+		}
+
+		text := item.Label
+		if item.Hint != "" {
+			text = fmt.Sprintf("%s (%s)", item.Label, item.Hint)
+		}
+
+		cursor := "  " // Two spaces for non-selected items
+		selected := i == selection
+		if selected {
+			cursor = m.cursorStyle().Render(">> ")
+		}
+
+		switch item.Kind {
+		case MenuItemResume:
+			resumeStyle := lipgloss.NewStyle().
+				Foreground(m.theme.StatusText).
+				Bold(selected).
+				Padding(0, 2)
+			text = resumeStyle.Render(text)
+		case MenuItemSecondary:
+			if selected {
+				cursor = m.cursorStyle().Render(" > ")
+				text = m.selectedSecondaryStyle().Render(text)
+			} else {
+				text = m.menuSecondaryStyle().Render(text)
+			}
+		default:
+			if selected {
+				text = m.selectedPrimaryStyle().Render(text)
+			} else {
+				text = m.menuPrimaryStyle().Render(text)
+			}
+		}
+
+		b.WriteString(fmt.Sprintf("%s%s\n", cursor, text))
+	}
+	return b.String()
+}
+
+// mainMenu declares the main menu. "Resume Game" is offered only if resume is
+// set, i.e. when a saved game exists.
+func mainMenu(resume bool) []MenuItem {
+	var items []MenuItem
+	if resume {
+		items = append(items, MenuItem{Label: "Resume Game", Kind: MenuItemResume, Action: Model.resumeSavedGame})
+	}
+	return append(items,
+		MenuItem{Label: "New Game", Action: func(m Model) (tea.Model, tea.Cmd) {
+			m.openNewGame()
+			m.input = ""
+			return m, nil
+		}},
+		MenuItem{Label: "Load Game", Kind: MenuItemSecondary, Action: func(m Model) (tea.Model, tea.Cmd) {
+			m.pushScreen(ScreenFENInput)
+			m.fenInput.SetValue("")
+			m.fenInput.Focus()
+			m.fenHistory, _ = config.LoadFENHistory()
+			m.fenHistorySelection = -1
+			m.dismissToasts()
+			return m, nil
+		}},
+		MenuItem{Label: "Game History", Kind: MenuItemSecondary, Hint: "exported games", Action: func(m Model) (tea.Model, tea.Cmd) {
+			m.openGameHistory()
+			return m, nil
+		}},
+		// Settings, Learn and Exit are app actions, set apart from the game actions
+		MenuItem{Label: "Settings", Kind: MenuItemSecondary, Separated: true, Action: func(m Model) (tea.Model, tea.Cmd) {
+			m.openSettings()
+			return m, nil
+		}},
+		MenuItem{Label: "Learn", Kind: MenuItemSecondary, Hint: "notation and rules", Action: func(m Model) (tea.Model, tea.Cmd) {
+			m.openLearn()
+			return m, nil
+		}},
+		MenuItem{Label: "Exit", Kind: MenuItemSecondary, Action: func(m Model) (tea.Model, tea.Cmd) {
+			return m, tea.Quit
+		}},
+	)
+}
+
+// buildMainMenuOptions constructs the main menu options array.
+// If a saved game exists, it includes "Resume Game" at the top of the menu.
+func buildMainMenuOptions() []string {
+	return menuLabels(mainMenu(config.SaveGameExists()))
+}
+
+// gameTypeMenu declares the game type selection menu.
+func gameTypeMenu() []MenuItem {
+	return []MenuItem{
+		{Label: "Player vs Player", Action: Model.startPvPGame},
+		{Label: "Player vs Bot", Action: func(m Model) (tea.Model, tea.Cmd) {
+			return m.openBotSelect(false)
+		}},
+		{Label: "Bot vs Bot", Action: func(m Model) (tea.Model, tea.Cmd) {
+			m.gameType = GameTypeBvB
+			m.bvbStartFEN = ""
+			// Start with selecting White bot difficulty
+			m.bvbSelectingWhite = true
+			m.openMenu(ScreenBvBBotSelect)
+			return m, nil
+		}},
+		{Label: "Correspondence", Action: func(m Model) (tea.Model, tea.Cmd) {
+			// Correspondence games start from the list of ongoing games
+			m.openCorrespondenceList()
+			return m, nil
+		}},
+		{Label: "Simul", Hint: "several bot games at once", Action: func(m Model) (tea.Model, tea.Cmd) {
+			return m.openBotSelect(true)
+		}},
+		{Label: "Guess the Move", Hint: "train on master games", Action: func(m Model) (tea.Model, tea.Cmd) {
+			m.openMenu(ScreenGuessSelect)
+			return m, nil
+		}},
+		{Label: "Tutorial", Hint: "learn to play step by step", Action: Model.startTutorial},
+	}
+}
+
+// openBotSelect opens the bot selection screen for a single game against the
+// bot, or for a simul.
+func (m Model) openBotSelect(simul bool) (tea.Model, tea.Cmd) {
+	m.gameType = GameTypePvBot
+	m.simulSetup = simul
+	m.openMenu(ScreenBotSelect)
+	// Start on the difficulty the config chooses, listed in BotDifficulty order
+	if diff, err := bot.ParseDifficulty(m.config.BotDifficulty); err == nil {
+		m.menuSelection = int(diff)
+	}
+	return m, nil
+}
+
+// gameTypeOptions returns the menu options of the game type selection screen.
+func gameTypeOptions() []string {
+	return menuLabels(gameTypeMenu())
+}
+
+// botMenu declares a bot selection menu: the built-in difficulties followed by
+// the registered custom bots. choose receives the picked difficulty, or the name
+// of the custom bot.
+func botMenu(choose func(Model, BotDifficulty, string) (tea.Model, tea.Cmd)) []MenuItem {
+	pick := func(diff BotDifficulty, name string) func(Model) (tea.Model, tea.Cmd) {
+		return func(m Model) (tea.Model, tea.Cmd) {
+			return choose(m, diff, name)
+		}
+	}
+	items := []MenuItem{
+		{Label: "Easy", Action: pick(BotEasy, "")},
+		{Label: "Medium", Action: pick(BotMedium, "")},
+		{Label: "Hard", Action: pick(BotHard, "")},
+	}
+	for _, name := range bot.Registered() {
+		items = append(items, MenuItem{Label: name, Action: pick(BotEasy, name)})
+	}
+	return items
+}
+
+// botOptions returns the menu options of the bot selection screens: the built-in
+// difficulties followed by the registered custom bots.
+func botOptions() []string {
+	return menuLabels(botMenu(nil))
+}
+
+// handicapMenu declares the handicap selection menu, in the order of engine.Handicaps.
+func handicapMenu() []MenuItem {
+	items := make([]MenuItem, len(engine.Handicaps))
+	for i, h := range engine.Handicaps {
+		items[i] = MenuItem{Label: h.String(), Action: func(m Model) (tea.Model, tea.Cmd) {
+			return m.chooseHandicap(h)
+		}}
+	}
+	return items
+}
+
+// handicapOptions returns the menu options of the handicap screen, in the order of engine.Handicaps.
+func handicapOptions() []string {
+	return menuLabels(handicapMenu())
+}
+
+// colorMenu declares the color selection menu.
+func colorMenu() []MenuItem {
+	return []MenuItem{
+		{Label: "Play as White", Action: func(m Model) (tea.Model, tea.Cmd) {
+			return m.startBotGame(engine.White)
+		}},
+		{Label: "Play as Black", Action: func(m Model) (tea.Model, tea.Cmd) {
+			return m.startBotGame(engine.Black)
+		}},
+	}
+}
+
+// bvbGameModeMenu declares the Bot vs Bot game mode menu.
+func bvbGameModeMenu() []MenuItem {
+	return []MenuItem{
+		{Label: "Single Game", Action: Model.startSingleBvBGame},
+		{Label: "Multi-Game", Action: func(m Model) (tea.Model, tea.Cmd) {
+			return m.askBvBGameCount(false)
+		}},
+		{Label: "SPRT Test", Action: func(m Model) (tea.Model, tea.Cmd) {
+			return m.askBvBGameCount(true)
+		}},
+		{Label: "Set Seed", Action: Model.askBvBSeed},
+		{Label: "Set Openings", Hint: "FEN or EPD suite", Action: Model.askBvBOpenings},
+		{Label: "Paired Games", Hint: "each opening with both colors", Action: Model.togglePairedGames},
+	}
+}
+
+// bvbGameModeOptions returns the menu options of the Bot vs Bot game mode screen.
+func bvbGameModeOptions() []string {
+	return menuLabels(bvbGameModeMenu())
+}
+
+// bvbGridMenu declares the Bot vs Bot grid layout menu: the preset layouts and
+// a custom one typed in by the user.
+func bvbGridMenu() []MenuItem {
+	items := []MenuItem{{Label: "Auto", Hint: "fit the terminal", Action: func(m Model) (tea.Model, tea.Cmd) {
+		// The size is worked out once the session runs, see layoutBvBGridAuto
+		m.bvbGridAuto = true
+		m.bvbGridRows, m.bvbGridCols = 1, 1
+		return m.navigateToConcurrencySelect()
+	}}}
+	for _, size := range [][2]int{{1, 1}, {2, 2}, {2, 3}, {2, 4}} {
+		items = append(items, MenuItem{
+			Label: fmt.Sprintf("%dx%d", size[0], size[1]),
+			Action: func(m Model) (tea.Model, tea.Cmd) {
+				m.bvbGridAuto = false
+				m.bvbGridRows, m.bvbGridCols = size[0], size[1]
+				return m.navigateToConcurrencySelect()
+			},
+		})
+	}
+	return append(items, MenuItem{Label: "Custom", Action: func(m Model) (tea.Model, tea.Cmd) {
+		m.bvbInputtingGrid = true
+		m.bvbCustomGridInput = ""
+		return m, nil
+	}})
+}
+
+// bvbGridOptions returns the menu options of the Bot vs Bot grid layout screen.
+func bvbGridOptions() []string {
+	return menuLabels(bvbGridMenu())
+}
+
+// bvbStatsMenu declares the menu of the Bot vs Bot statistics screen.
+func bvbStatsMenu() []MenuItem {
+	return []MenuItem{
+		{Label: "New Session", Action: func(m Model) (tea.Model, tea.Cmd) {
+			m.screen = ScreenBvBBotSelect
+			m.menuOptions = botOptions()
+			m.menuSelection = 0
+			m.bvbSelectingWhite = true
+			m.bvbManager = nil
+			return m, nil
+		}},
+		{Label: "Return to Menu", Kind: MenuItemSecondary, Action: func(m Model) (tea.Model, tea.Cmd) {
+			m.screen = ScreenMainMenu
+			m.menuOptions = buildMainMenuOptions()
+			m.menuSelection = 0
+			m.bvbManager = nil
+			return m, nil
+		}},
+	}
+}
+
+// openMenu navigates to screen and shows its menu with the first item selected.
+func (m *Model) openMenu(screen Screen) {
+	m.pushScreen(screen)
+	m.menuOptions = menuLabels(m.menuItems())
+	m.menuSelection = 0
+	m.dismissToasts()
+}
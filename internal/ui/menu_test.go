@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestMainMenuDeclaration(t *testing.T) {
+	items := mainMenu(true)
+	want := []string{"Resume Game", "New Game", "Load Game", "Game History", "Settings", "Learn", "Exit"}
+	if got := menuLabels(items); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("labels = %v, want %v", got, want)
+	}
+	kinds := []MenuItemKind{MenuItemResume, MenuItemPrimary, MenuItemSecondary, MenuItemSecondary, MenuItemSecondary, MenuItemSecondary, MenuItemSecondary}
+	for i, item := range items {
+		if item.Kind != kinds[i] {
+			t.Errorf("%s kind = %v, want %v", item.Label, item.Kind, kinds[i])
+		}
+		if item.Action == nil {
+			t.Errorf("%s has no action", item.Label)
+		}
+		if item.Separated != (item.Label == "Settings") {
+			t.Errorf("%s separated = %v", item.Label, item.Separated)
+		}
+	}
+	if got := menuLabels(mainMenu(false)); got[0] != "New Game" {
+		t.Errorf("labels without a save = %v, want New Game first", got)
+	}
+}
+
+func TestUpdateMenuWrapsAndSelects(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenGameTypeSelect
+	m.menuOptions = gameTypeOptions()
+	m.menuSelection = 0
+
+	result, _ := m.updateMenu(tea.KeyMsg{Type: tea.KeyUp})
+	m = result.(Model)
+	if m.menuSelection != len(m.menuOptions)-1 {
+		t.Errorf("selection after up = %d, want a wrap to the last item", m.menuSelection)
+	}
+	result, _ = m.updateMenu(tea.KeyMsg{Type: tea.KeyDown})
+	m = result.(Model)
+	if m.menuSelection != 0 {
+		t.Errorf("selection after down = %d, want a wrap to the first item", m.menuSelection)
+	}
+
+	result, _ = m.updateMenu(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if m.screen != ScreenGamePlay || m.gameType != GameTypePvP {
+		t.Errorf("screen = %v, game type = %v, want a PvP game started", m.screen, m.gameType)
+	}
+}
+
+func TestUndeclaredMenuItemDoesNothing(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenGameTypeSelect
+	m.menuOptions = []string{"Player vs Player", "Back"}
+	m.menuSelection = 1
+
+	result, cmd := m.selectMenuItem()
+	m = result.(Model)
+	if m.screen != ScreenGameTypeSelect || cmd != nil {
+		t.Errorf("screen = %v, want the undeclared item ignored", m.screen)
+	}
+}
+
+func TestRenderMenuStylesByKind(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenMainMenu
+	m.menuOptions = menuLabels(mainMenu(false))
+
+	m.menuSelection = 0
+	if out := m.renderMenu(m.menuSelection); !strings.Contains(out, ">> ") || !strings.Contains(out, "────") {
+		t.Errorf("menu = %q, want a primary cursor and the separator", out)
+	}
+	m.menuSelection = 3
+	if out := m.renderMenu(m.menuSelection); !strings.Contains(out, " > ") || strings.Contains(out, ">> ") {
+		t.Errorf("menu = %q, want a secondary cursor on Exit", out)
+	}
+}
+
+func TestBvBBotMenuShowsBackend(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenBvBBotSelect
+	m.menuOptions = botOptions()
+	if out := m.renderMenu(0); !strings.Contains(out, "Easy (built-in)") {
+		t.Errorf("menu = %q, want engines labelled with their backend", out)
+	}
+}
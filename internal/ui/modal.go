@@ -0,0 +1,205 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// modalOption is one choice of a modal dialog.
+type modalOption struct {
+	// label is the text shown for the option
+	label string
+	// key, if set, picks the option directly, e.g. "y" for Yes
+	key string
+}
+
+// modal is a dialog with a title, a body and either a list of options or a
+// single-line text input. It holds no state: the caller keeps the selected option
+// or the typed text in its own model field and passes it to update and renderModal.
+type modal struct {
+	title string
+	body  string
+	// options are the choices offered, unless the dialog asks for text
+	options []modalOption
+	// inputLabel, if set, makes the dialog ask for text instead of offering options
+	inputLabel string
+	// inputFilter accepts the runes that may be typed into the input, or is nil to accept any
+	inputFilter func(rune) bool
+	// help describes the dialog's keys, shown when help text is enabled
+	help string
+}
+
+// modalEvent is what a key press did to a modal dialog.
+type modalEvent int
+
+const (
+	// modalIgnored means the key did nothing
+	modalIgnored modalEvent = iota
+	// modalChanged means the selected option or the typed text changed
+	modalChanged
+	// modalChosen means an option was picked or the text submitted
+	modalChosen
+	// modalCancelled means the dialog was dismissed
+	modalCancelled
+)
+
+// update applies a key press to the dialog. selection is the caller's selected
+// option for option dialogs, input the caller's text for input dialogs; the other
+// may be nil.
+func (d modal) update(keys KeyMap, msg tea.KeyMsg, selection *int, input *string) modalEvent {
+	if d.inputLabel != "" {
+		switch msg.Type {
+		case tea.KeyEsc:
+			return modalCancelled
+		case tea.KeyEnter:
+			return modalChosen
+		case tea.KeyBackspace:
+			if len(*input) > 0 {
+				*input = (*input)[:len(*input)-1]
+			}
+			return modalChanged
+		case tea.KeyRunes:
+			for _, r := range msg.Runes {
+				if d.inputFilter == nil || d.inputFilter(r) {
+					*input += string(r)
+				}
+			}
+			return modalChanged
+		}
+		return modalIgnored
+	}
+
+	for i, option := range d.options {
+		if option.key != "" && strings.EqualFold(msg.String(), option.key) {
+			*selection = i
+			return modalChosen
+		}
+	}
+
+	count := len(d.options)
+	switch {
+	case keys.Matches(msg, ActionUp):
+		*selection = (*selection - 1 + count) % count
+		return modalChanged
+	case keys.Matches(msg, ActionDown):
+		*selection = (*selection + 1) % count
+		return modalChanged
+	case keys.Matches(msg, ActionSelect):
+		return modalChosen
+	case keys.Matches(msg, ActionBack):
+		return modalCancelled
+	}
+	return modalIgnored
+}
+
+// renderModal renders the content of the dialog, for use with renderOverlay.
+func (m Model) renderModal(d modal, selection int, input string) string {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(m.theme.TitleText)
+	b.WriteString(titleStyle.Render(d.title))
+	b.WriteString("\n\n")
+
+	if d.body != "" {
+		b.WriteString(m.menuPrimaryStyle().Render(d.body))
+		b.WriteString("\n\n")
+	}
+
+	if d.inputLabel != "" {
+		if input == "" {
+			input = "_"
+		}
+		inputStyle := lipgloss.NewStyle().
+			Bold(true).
+			Foreground(m.theme.MenuSelected)
+		b.WriteString(inputStyle.Render(fmt.Sprintf("%s: %s", d.inputLabel, input)))
+		b.WriteString("\n")
+	}
+
+	for i, option := range d.options {
+		cursor := "  "
+		text := m.menuPrimaryStyle().Render(option.label)
+		if i == selection {
+			cursor = m.cursorStyle().Render(">> ")
+			text = m.selectedPrimaryStyle().Render(option.label)
+		}
+		b.WriteString(fmt.Sprintf("%s%s\n", cursor, text))
+	}
+
+	if helpText := m.renderHelpText(d.help); helpText != "" {
+		b.WriteString("\n")
+		b.WriteString(helpText)
+	}
+
+	return b.String()
+}
+
+// renderModalOver renders the dialog over the screen drawn by background.
+func (m Model) renderModalOver(background func(Model) string, d modal, selection int, input string) string {
+	return m.renderOverlay(background(m), m.renderModal(d, selection, input))
+}
+
+// savePrompt is the dialog asking whether to save the game before leaving it.
+func (m Model) savePrompt() modal {
+	// The games of a simul aren't saved, so leaving one only needs confirming
+	if m.inSimul() {
+		return modal{
+			title: "Leave Simul",
+			body:  "Leave the simul? Its games in progress will be lost.",
+			options: []modalOption{
+				{label: "Leave Simul", key: "y"},
+				{label: "Keep Playing", key: "n"},
+			},
+			help: "y: leave | n: keep playing | ESC: cancel",
+		}
+	}
+	return modal{
+		title: "Save Game",
+		body:  "Save current game before exiting?",
+		options: []modalOption{
+			{label: "Save & Exit", key: "y"},
+			{label: "Exit without saving", key: "n"},
+		},
+		help: "y: save & exit | n: exit without saving | ESC: cancel",
+	}
+}
+
+// drawPrompt is the dialog asking the opponent to accept or decline a draw offer.
+func (m Model) drawPrompt() modal {
+	offeredBy := "White"
+	if m.drawOfferedBy == int8(engine.Black) {
+		offeredBy = "Black"
+	}
+	return modal{
+		title:   "Draw Offer",
+		body:    fmt.Sprintf("%s offers a draw. Accept?", offeredBy),
+		options: []modalOption{{label: "Accept"}, {label: "Decline"}},
+		help:    "arrows: select | enter: confirm | ESC: cancel",
+	}
+}
+
+// bvbAbortPrompt is the dialog confirming that a running Bot vs Bot session should be abandoned.
+func (m Model) bvbAbortPrompt() modal {
+	return modal{
+		title:   "Abort Session?",
+		body:    "Games in progress will be lost.",
+		options: []modalOption{{label: "Cancel"}, {label: "Abort Session"}},
+		help:    "esc: cancel | enter: select",
+	}
+}
+
+// bvbJumpPrompt is the dialog asking for the number of the Bot vs Bot game to show.
+func (m Model) bvbJumpPrompt() modal {
+	return modal{
+		title:       "Jump to Game",
+		inputLabel:  fmt.Sprintf("Game number (1-%d)", m.bvbGameCount),
+		inputFilter: func(r rune) bool { return r >= '0' && r <= '9' },
+		help:        "enter: jump | esc: cancel",
+	}
+}
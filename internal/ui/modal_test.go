@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestModalOptionKeys(t *testing.T) {
+	keys := DefaultKeyMap()
+	d := modal{title: "Pick", options: []modalOption{{label: "Yes", key: "y"}, {label: "No", key: "n"}, {label: "Maybe"}}}
+	selection := 0
+
+	if got := d.update(keys, tea.KeyMsg{Type: tea.KeyUp}, &selection, nil); got != modalChanged || selection != 2 {
+		t.Errorf("up = %v, selection %d, want a wrap to the last option", got, selection)
+	}
+	if got := d.update(keys, tea.KeyMsg{Type: tea.KeyDown}, &selection, nil); got != modalChanged || selection != 0 {
+		t.Errorf("down = %v, selection %d, want a wrap to the first option", got, selection)
+	}
+	if got := d.update(keys, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("N")}, &selection, nil); got != modalChosen || selection != 1 {
+		t.Errorf("N = %v, selection %d, want No chosen", got, selection)
+	}
+	if got := d.update(keys, tea.KeyMsg{Type: tea.KeyEnter}, &selection, nil); got != modalChosen {
+		t.Errorf("enter = %v, want chosen", got)
+	}
+	if got := d.update(keys, tea.KeyMsg{Type: tea.KeyEsc}, &selection, nil); got != modalCancelled {
+		t.Errorf("esc = %v, want cancelled", got)
+	}
+	if got := d.update(keys, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}, &selection, nil); got != modalIgnored {
+		t.Errorf("x = %v, want ignored", got)
+	}
+}
+
+func TestModalInputKeys(t *testing.T) {
+	keys := DefaultKeyMap()
+	d := modal{title: "Number", inputLabel: "N", inputFilter: func(r rune) bool { return r >= '0' && r <= '9' }}
+	input := ""
+
+	d.update(keys, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1a2")}, nil, &input)
+	if input != "12" {
+		t.Errorf("input = %q, want only the digits", input)
+	}
+	if got := d.update(keys, tea.KeyMsg{Type: tea.KeyBackspace}, nil, &input); got != modalChanged || input != "1" {
+		t.Errorf("backspace = %v, input %q, want one digit removed", got, input)
+	}
+	if got := d.update(keys, tea.KeyMsg{Type: tea.KeyEnter}, nil, &input); got != modalChosen {
+		t.Errorf("enter = %v, want chosen", got)
+	}
+	if got := d.update(keys, tea.KeyMsg{Type: tea.KeyEsc}, nil, &input); got != modalCancelled {
+		t.Errorf("esc = %v, want cancelled", got)
+	}
+}
+
+func TestRenderModal(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	d := modal{title: "Pick", body: "Choose one", options: []modalOption{{label: "Yes"}, {label: "No"}}, help: "enter: pick"}
+
+	view := m.renderModal(d, 1, "")
+	for _, want := range []string{"Pick", "Choose one", "Yes", ">> ", "No", "enter: pick"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in the dialog:\n%s", want, view)
+		}
+	}
+}
+
+func TestSavePromptOverlaysGame(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenSavePrompt
+	m.game = engine.NewGame()
+	m.notify(SeverityError, "disk full")
+
+	view := m.View()
+	if !strings.Contains(view, "Save current game before exiting?") {
+		t.Error("Expected the save prompt")
+	}
+	if !strings.Contains(view, "White to move") {
+		t.Errorf("Expected the game to stay visible behind the prompt, got:\n%s", view)
+	}
+	if strings.Count(view, "disk full") != 1 {
+		t.Errorf("Expected the error once, below the dialog, got:\n%s", view)
+	}
+}
+
+func TestBvBJumpPromptDialog(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenBvBGamePlay
+	m.bvbGameCount = 5
+	m.bvbGridRows, m.bvbGridCols = 1, 1
+	m.bvbShowJumpPrompt = true
+
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("4")},
+		{Type: tea.KeyEnter},
+	} {
+		result, _ := m.handleBvBJumpInput(key)
+		m = result.(Model)
+	}
+	if m.bvbShowJumpPrompt || m.bvbSelectedGame != 3 {
+		t.Errorf("Expected to jump to game 4, got prompt %v, game %d", m.bvbShowJumpPrompt, m.bvbSelectedGame+1)
+	}
+}
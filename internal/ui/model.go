@@ -1,10 +1,16 @@
 package ui
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	"github.com/Mgrdich/TermChess/internal/bot"
 	"github.com/Mgrdich/TermChess/internal/bvb"
 	"github.com/Mgrdich/TermChess/internal/config"
 	"github.com/Mgrdich/TermChess/internal/engine"
+	"github.com/Mgrdich/TermChess/internal/spectate"
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 )
 
@@ -47,6 +53,42 @@ const (
 	ScreenBvBViewModeSelect
 	// ScreenBvBConcurrencySelect allows the user to choose concurrency for multi-game BvB sessions
 	ScreenBvBConcurrencySelect
+	// ScreenAnalysis walks through a finished game with engine evaluations
+	ScreenAnalysis
+	// ScreenKeyBindings lets the user view and change key bindings
+	ScreenKeyBindings
+	// ScreenHandicapSelect allows the user to choose material odds for the bot in bot games
+	ScreenHandicapSelect
+	// ScreenCorrespondenceList lists the user's correspondence games ("My Games")
+	ScreenCorrespondenceList
+	// ScreenCorrespondenceNew is the form for starting a new correspondence game
+	ScreenCorrespondenceNew
+	// ScreenHandoff hides the board between moves of a hot-seat game until the next player is ready
+	ScreenHandoff
+	// ScreenWatch follows, read-only, the games shared by another instance
+	ScreenWatch
+	// ScreenProfileSelect lets the user choose a config profile at startup
+	ScreenProfileSelect
+	// ScreenMatchSummary shows the result of each game of a best-of-N match against a bot and its winner
+	ScreenMatchSummary
+	// ScreenSimulSetup allows the user to choose the number of boards of a simul against a bot
+	ScreenSimulSetup
+	// ScreenGuessSelect lists the bundled master games for guess-the-move training
+	ScreenGuessSelect
+	// ScreenGuessMove is where the user guesses the moves of a master game
+	ScreenGuessMove
+	// ScreenUpgrade shows the progress of upgrading TermChess to the available update
+	ScreenUpgrade
+	// ScreenCrashRecovery offers to restore the game interrupted by a crash
+	ScreenCrashRecovery
+	// ScreenLearn explains notation, special moves and the app's commands
+	ScreenLearn
+	// ScreenTutorial walks a new player through a scripted game
+	ScreenTutorial
+	// ScreenGameHistory lists the exported games for searching, tagging and importing
+	ScreenGameHistory
+	// ScreenPassphrase asks for the passphrase the saved game and history are encrypted with
+	ScreenPassphrase
 )
 
 // GameType represents the type of chess game being played.
@@ -59,29 +101,30 @@ const (
 	GameTypePvBot
 	// GameTypeBvB is a bot vs bot game
 	GameTypeBvB
+	// GameTypeCorrespondence is a correspondence game stored in the mailbox, played one move at a time
+	GameTypeCorrespondence
 )
 
-// BotDifficulty represents the difficulty level of the chess bot.
-// This is defined for future bot implementation.
-type BotDifficulty int
+// BotDifficulty represents the difficulty level of the chess bot, as defined
+// by the bot package.
+type BotDifficulty = bot.Difficulty
 
 const (
 	// BotEasy is the easiest bot difficulty level
-	BotEasy BotDifficulty = iota
+	BotEasy = bot.Easy
 	// BotMedium is the medium bot difficulty level
-	BotMedium
+	BotMedium = bot.Medium
 	// BotHard is the hardest bot difficulty level
-	BotHard
+	BotHard = bot.Hard
 )
 
 // Model is the Bubbletea application model that holds all application state.
 // It implements the tea.Model interface (Init, Update, View methods).
 type Model struct {
 	// Game state
-	// board holds the current chess board state from the engine
-	board *engine.Board
-	// moveHistory stores all moves made in the current game
-	moveHistory []engine.Move
+	// game holds the current game: its position, moves, move times and result
+	// (nil when no game is being played)
+	game *engine.Game
 
 	// UI state
 	// screen tracks which screen is currently being displayed
@@ -92,6 +135,8 @@ type Model struct {
 	config Config
 	// theme holds the current color theme for UI rendering
 	theme Theme
+	// keys holds the key bindings used by the screen handlers
+	keys KeyMap
 	// termWidth holds the current terminal width in characters
 	termWidth int
 	// termHeight holds the current terminal height in lines
@@ -100,12 +145,28 @@ type Model struct {
 	// Input state
 	// input holds the current user input text
 	input string
+	// completionIndex is the highlighted move completion of the typed input
+	completionIndex int
+	// inputHistory holds the entries submitted at the move prompt, oldest first
+	inputHistory []string
+	// inputHistoryIndex is the entry of inputHistory shown while browsing it with
+	// the up and down arrows, len(inputHistory) when not browsing
+	inputHistoryIndex int
+	// inputDraft holds what was typed at the move prompt before browsing began
+	inputDraft string
 	// fenInput holds the text input component for FEN string entry
 	fenInput textinput.Model
-	// errorMsg holds any error message to display to the user
-	errorMsg string
-	// statusMsg holds status information to display to the user
-	statusMsg string
+	// fenHistory holds the recently loaded and exported FENs, most recent first
+	fenHistory []string
+	// fenHistorySelection is the index of the recent FEN chosen with the arrow
+	// keys on the FEN input screen, or -1 if none is
+	fenHistorySelection int
+	// toasts holds the messages shown at the bottom of the screen, oldest first
+	toasts []toast
+	// nextToastID is the ID given to the next toast
+	nextToastID int
+	// scheduledToastID is the highest toast ID whose expiry has been scheduled
+	scheduledToastID int
 
 	// Menu state
 	// menuSelection tracks the currently selected menu item index
@@ -114,6 +175,10 @@ type Model struct {
 	menuOptions []string
 	// settingsSelection tracks the currently selected setting in the settings screen
 	settingsSelection int
+	// keyBindingSelection tracks the selected action on the Key Bindings screen
+	keyBindingSelection int
+	// keyBindingCapture indicates the Key Bindings screen is waiting for a new key
+	keyBindingCapture bool
 	// savePromptSelection tracks the currently selected option in the save prompt (0=Yes, 1=No)
 	savePromptSelection int
 	// savePromptAction indicates what action to take after save decision ("exit" or "menu")
@@ -128,44 +193,166 @@ type Model struct {
 	gameType GameType
 	// botDifficulty stores the selected bot difficulty (for future use)
 	botDifficulty BotDifficulty
+	// botName is the registered custom bot being played, or empty for the built-in bot of botDifficulty
+	botName string
+	// handicap is the material the bot gives up as odds in PvBot games
+	handicap engine.Handicap
 	// botEngine holds the chess bot engine instance for PvBot games
 	botEngine bot.Engine
+	// botThinking indicates the bot is currently searching for a move
+	botThinking bool
+	// thinkingMsg is shown beside the spinner while the bot is thinking
+	thinkingMsg string
+	// premove is the user's move queued while the bot is thinking, played
+	// automatically after the bot replies if it is still legal (nil if none)
+	premove *engine.Move
+	// coachComment is the coach's comment on the user's last move in a PvBot game
+	coachComment string
+	// coachCancel cancels the coach's check of the user's last move while it runs
+	coachCancel context.CancelFunc
+	// match is the running score of a PvBot game and its rematches
+	match matchScore
+	// matchLengthOption is the match length chosen on the color selection screen, 0 for single games
+	matchLengthOption int
+	// simulSetup is set while the bot of a simul is being chosen, instead of a single game's
+	simulSetup bool
+	// simulGames holds the games of a simul, one per board, or is nil outside simul mode.
+	// The shown board's entry is stale; its game lives in the fields above and below
+	simulGames []simulGame
+	// simulActive is the index in simulGames of the board shown
+	simulActive int
+	// guess is the guess-the-move training session played on ScreenGuessMove
+	guess guessSession
+	// tutorial is the progress through the tutorial game played on ScreenTutorial
+	tutorial tutorialSession
+	// historyGames holds the games of the game history shown on ScreenGameHistory
+	historyGames []historyGame
+	// historySearch is the free-text search filtering the game history
+	historySearch textinput.Model
+	// historySelection is the index of the selected game among those matching the search
+	historySelection int
+	// historyEditingTags is set while the tags of the selected game are edited in historyTags
+	historyEditingTags bool
+	historyTags        textinput.Model
+	// passphraseInput is the passphrase typed on ScreenPassphrase
+	passphraseInput textinput.Model
+	// passphraseNew is set when no files are encrypted yet, so the passphrase is chosen and typed twice
+	passphraseNew bool
+	// passphraseFirst is the new passphrase typed once, waiting to be repeated
+	passphraseFirst string
+	// passphraseEnabling is set when the passphrase turns Encrypt Saves on from Settings
+	passphraseEnabling bool
+	// trainingOption is the training mode checkbox on the color selection screen
+	trainingOption bool
+	// trainingMode enables move hints, hanging-piece warnings and takebacks for the current PvBot game
+	trainingMode bool
+	// trainingWarned is the move the user was last warned about; submitting it again plays it
+	trainingWarned *engine.Move
+	// botCancel interrupts the bot's search so it plays its best move found so far
+	botCancel context.CancelFunc
+	// botSpinner animates the thinking indicator while the bot searches or a game is analyzed
+	botSpinner spinner.Model
+	// botHopelessTurns counts consecutive bot turns spent below the resign threshold
+	botHopelessTurns int
 	// userColor stores the color the user is playing (White or Black) in bot games
 	userColor engine.Color
-	// resignedBy indicates which player resigned (White, Black, or -1 for no resignation)
-	resignedBy int8
 	// drawOfferedBy indicates which color offered a draw (-1 if none)
 	drawOfferedBy int8
 	// drawOfferedByWhite tracks if White has already offered a draw this game
 	drawOfferedByWhite bool
 	// drawOfferedByBlack tracks if Black has already offered a draw this game
 	drawOfferedByBlack bool
-	// drawByAgreement indicates if the game ended by draw agreement
-	drawByAgreement bool
+
+	// Move timing
+	// turnStartedAt is when the side to move started thinking about its move
+	turnStartedAt time.Time
+
+	// Analysis state
+	// notes holds the notes taken during the current game with the "note" command
+	notes []gameNote
+	// analysis holds the engine's evaluation of the finished game (nil until analyzed)
+	analysis *bot.GameAnalysis
+	// evals holds the evaluation after each move of the game in pawns from White's
+	// perspective, from its analysis or the PGN it was loaded from (nil if unknown)
+	evals []float64
+	// analysisPositions holds the board after each move (index 0 is the starting position)
+	analysisPositions []*engine.Board
+	// analysisPly is the position currently shown on the analysis screen (0 = start)
+	analysisPly int
+	// analyzing indicates the game analysis is still running
+	analyzing bool
+	// analysisCancel aborts a running analysis
+	analysisCancel context.CancelFunc
+
+	// Correspondence state
+	// correspondence is the correspondence game being played (nil outside correspondence games)
+	correspondence *config.CorrespondenceGame
+	// correspondenceGames holds the games listed on the My Games screen, awaiting the user's move first
+	correspondenceGames []*config.CorrespondenceGame
+	// correspondenceSelection is the highlighted game on the My Games screen
+	correspondenceSelection int
+	// correspondenceOpponent holds the opponent name typed into the new game form
+	correspondenceOpponent textinput.Model
+	// correspondenceField is the focused field of the new game form (see correspondenceField* constants)
+	correspondenceField int
+	// correspondenceColor is the color the user chose on the new game form
+	correspondenceColor engine.Color
+	// correspondenceDays indexes config.CorrespondenceDays for the new game's time control
+	correspondenceDays int
 
 	// Bot vs Bot fields
 	// bvbWhiteDiff stores the selected bot difficulty for White in BvB mode
 	bvbWhiteDiff BotDifficulty
 	// bvbBlackDiff stores the selected bot difficulty for Black in BvB mode
 	bvbBlackDiff BotDifficulty
+	// bvbWhiteBot and bvbBlackBot name the registered custom bots playing in BvB mode,
+	// or are empty for the built-in bots of bvbWhiteDiff and bvbBlackDiff
+	bvbWhiteBot string
+	bvbBlackBot string
 	// bvbSelectingWhite indicates whether we're selecting the White bot (true) or Black bot (false)
 	bvbSelectingWhite bool
+	// bvbStartFEN is the position handed to the bots from the FEN input screen, or
+	// empty for BvB games from the standard starting position
+	bvbStartFEN string
 	// bvbGameCount stores the number of games to play in multi-game mode
 	bvbGameCount int
+	// bvbSPRT indicates whether the session is an SPRT test that stops once it reaches a decision
+	bvbSPRT bool
 	// bvbCountInput holds the text input for game count entry
 	bvbCountInput string
 	// bvbInputtingCount indicates whether we're in text input mode for game count
 	bvbInputtingCount bool
+	// bvbSeedInput holds the text input for the BvB seed
+	bvbSeedInput string
+	// bvbInputtingSeed indicates whether we're in text input mode for the seed
+	bvbInputtingSeed bool
+	// bvbOpeningsInput holds the text input for the BvB openings: a FEN or the path of a suite
+	bvbOpeningsInput string
+	// bvbInputtingOpenings indicates whether we're in text input mode for the openings
+	bvbInputtingOpenings bool
+	// bvbOpenings are the positions the BvB games start from in turn, none for the
+	// standard one, and bvbOpeningsName describes where they came from
+	bvbOpenings     []*engine.Board
+	bvbOpeningsName string
+	// bvbPairedOpenings plays each opening twice, the bots swapping colors
+	bvbPairedOpenings bool
+	// bvbSeed seeds the next BvB session when bvbSeedSet is true; otherwise it is random
+	bvbSeed    int64
+	bvbSeedSet bool
 	// bvbGridRows stores the number of rows in the grid layout
 	bvbGridRows int
 	// bvbGridCols stores the number of columns in the grid layout
 	bvbGridCols int
+	// bvbGridAuto sizes the grid to the terminal instead of a fixed RxC, see layoutBvBGridAuto
+	bvbGridAuto bool
 	// bvbCustomGridInput holds the text input for custom grid dimensions
 	bvbCustomGridInput string
 	// bvbInputtingGrid indicates whether we're in text input mode for custom grid
 	bvbInputtingGrid bool
 	// bvbManager holds the session manager for the current BvB session
 	bvbManager *bvb.SessionManager
+	// bvbCells caches the rendered grid cells of the session's games
+	bvbCells *bvbCellCache
 	// bvbSpeed stores the current playback speed
 	bvbSpeed bvb.PlaybackSpeed
 	// bvbSelectedGame tracks which game is focused in single view (0-indexed)
@@ -176,10 +363,19 @@ type Model struct {
 	bvbPaused bool
 	// bvbPageIndex tracks the current page in grid view
 	bvbPageIndex int
+	// bvbGridFilter selects which games the grid view shows
+	bvbGridFilter BvBGridFilter
+	// bvbFollow keeps the running games in view as earlier ones finish, see followBvBRunningGames
+	bvbFollow bool
 	// bvbStatsSelection tracks the selected option on the stats screen (0=New Session, 1=Return to Menu)
 	bvbStatsSelection int
 	// bvbStatsResultsPage tracks the current page of individual results on the stats screen
 	bvbStatsResultsPage int
+	// bvbResultSort is the order of the individual results on the stats screen
+	bvbResultSort BvBResultSort
+	// bvbLastNotable is the number of the game the stats screen last opened with
+	// the next-notable key, 0 if none
+	bvbLastNotable int
 	// bvbJumpInput holds the text input for game number entry during jump navigation
 	bvbJumpInput string
 	// bvbShowJumpPrompt indicates whether the jump prompt is visible
@@ -196,6 +392,8 @@ type Model struct {
 	bvbInputtingConcurrency bool
 	// bvbConcurrency stores the selected concurrency value for the session
 	bvbConcurrency int
+	// bvbCPULimit caps the BvB games played at once, one per core (0 = all cores)
+	bvbCPULimit int
 	// bvbShowAbortConfirm indicates whether the abort confirmation dialog is displayed
 	bvbShowAbortConfirm bool
 	// bvbAbortSelection tracks the selected option in abort dialog (0 = Cancel, 1 = Abort)
@@ -204,6 +402,16 @@ type Model struct {
 	// Overlay state
 	// showShortcutsOverlay indicates whether the keyboard shortcuts help overlay is displayed
 	showShortcutsOverlay bool
+	// showBvBLog indicates whether the Bot vs Bot session log overlay is displayed
+	showBvBLog bool
+	// scrollOffset is the first line shown of a screen or overlay too long for the terminal
+	scrollOffset int
+	// showCommandPalette indicates the command palette is open over the current screen
+	showCommandPalette bool
+	// paletteQuery holds the text typed into the command palette
+	paletteQuery string
+	// paletteSelection is the index of the highlighted command among the matches
+	paletteSelection int
 
 	// Mouse interaction state
 	// selectedSquare holds the currently selected piece's square for mouse interaction
@@ -215,11 +423,68 @@ type Model struct {
 	// blinkOn controls the blinking highlight state for selected squares
 	// Toggles every 500ms when a piece is selected to create a blinking effect
 	blinkOn bool
+	// flash highlights the squares of the bot's last move for a moment
+	flash moveFlash
+	// flashCount numbers the move flashes, so each tick finds its own flash
+	flashCount int
+
+	// boardCache reuses rendered boards across frames where they look the same
+	boardCache *boardCache
+	// mateReveal keeps a game that just ended in checkmate on the game screen for a moment
+	mateReveal bool
+	// mateRevealScheduled is set once the end of the checkmate reveal is scheduled
+	mateRevealScheduled bool
+
+	// terminalTitle is the window title last set, so it is only set again when it changes
+	terminalTitle string
+	// noWindowTitle leaves the window title alone, see WithoutWindowTitle
+	noWindowTitle bool
+	// terminalProgress is the OSC 9;4 progress sequence last written, "" if none is shown
+	terminalProgress string
 
 	// Update notification state
 	// updateAvailable holds the latest version string when an update is available
 	// Empty string means no update is available or check hasn't completed
 	updateAvailable string
+	// offline turns off everything that uses the network, such as update checks
+	offline bool
+	// upgrade is the upgrade shown on ScreenUpgrade
+	upgrade *upgradeState
+
+	// Crash handling state
+	// crash records recent messages for crash reports, shared by all copies of the model
+	crash *crashLog
+	// crashRecovery is the game interrupted by a crash, offered on ScreenCrashRecovery
+	crashRecovery *config.CrashRecovery
+	// crashRecoverySelection is the highlighted option of the crash recovery prompt
+	crashRecoverySelection int
+
+	// learnPage is the page shown on ScreenLearn
+	learnPage int
+
+	// Debug mode state
+	// debug logs messages and frame times for the debug panel; nil until debug mode is turned on
+	debug *debugLog
+	// showDebug indicates whether the debug panel is displayed
+	showDebug bool
+
+	// spectators are told what is on screen after every update so the games can be
+	// watched elsewhere
+	spectators []Spectator
+
+	// output is the program's output, which escape sequences written outside of
+	// frames go through; nil writes them to os.Stdout
+	output *TerminalOutput
+
+	// Watch mode state
+	// watcher receives the games shared by another instance on the Watch screen
+	watcher *spectate.Watcher
+	// watchSnapshot is the latest state received from the watched instance
+	watchSnapshot spectate.Snapshot
+	// watchGame is the index of the game shown when watching a Bot vs Bot session
+	watchGame int
+	// watchErr is why the connection to the watched instance ended, or nil while connected
+	watchErr error
 }
 
 // BvBViewMode represents the display mode for BvB gameplay.
@@ -250,10 +515,12 @@ func NewModel(config Config) Model {
 	// Load theme based on config
 	theme := GetTheme(ParseThemeName(config.Theme))
 
-	return Model{
-		// Initialize with nil board (created when starting a new game)
-		board:       nil,
-		moveHistory: []engine.Move{},
+	// Load key bindings, falling back to the defaults if the config is invalid
+	keys, keysErr := NewKeyMap(config.KeyBindings)
+
+	m := Model{
+		// Initialize with no game (created when starting a new game)
+		game: nil,
 
 		// Always start at main menu
 		screen: ScreenMainMenu,
@@ -262,13 +529,19 @@ func NewModel(config Config) Model {
 		config: config,
 
 		// Use the loaded theme
-		theme: theme,
+		theme:      theme,
+		boardCache: newBoardCache(),
+		crash:      &crashLog{},
+		// Init sets this title, so updates only set the title once it changes
+		terminalTitle: appTitle,
+
+		// Use the loaded key bindings
+		keys: keys,
 
 		// Initialize input state
-		input:     "",
-		fenInput:  ti,
-		errorMsg:  "",
-		statusMsg: "",
+		input:               "",
+		fenInput:            ti,
+		fenHistorySelection: -1,
 
 		// Initialize main menu with dynamic options
 		menuSelection: 0,
@@ -280,23 +553,17 @@ func NewModel(config Config) Model {
 		// Default game metadata
 		gameType:      GameTypePvP,
 		botDifficulty: BotEasy,
-		resignedBy:    -1, // No resignation
+		botSpinner:    spinner.New(spinner.WithSpinner(spinner.Dot)),
 
 		// Initialize draw offer state
 		drawOfferedBy:      -1, // No draw offer
 		drawOfferedByWhite: false,
 		drawOfferedByBlack: false,
-		drawByAgreement:    false,
 	}
-}
-
-// buildMainMenuOptions constructs the main menu options array.
-// If a saved game exists, it includes "Resume Game" at the top of the menu.
-func buildMainMenuOptions() []string {
-	if config.SaveGameExists() {
-		return []string{"Resume Game", "New Game", "Load Game", "Settings", "Exit"}
+	if keysErr != nil {
+		m.notify(SeverityError, fmt.Sprintf("Invalid key bindings in config, using defaults: %v", keysErr))
 	}
-	return []string{"New Game", "Load Game", "Settings", "Exit"}
+	return m
 }
 
 // View renders the current state of the UI as a string.
@@ -16,11 +16,11 @@ const (
 	// Calculated from: title padding (1) + title text (1) + title padding (1) + 2 newlines = 4
 	boardStartY = 4
 
-	// boardStartXWithCoords is the column where the first piece starts when ShowCoords is true.
+	// boardStartXWithCoords is the column where the first piece starts when coordinates are shown outside the board.
 	// The rank label "8 " takes 2 characters.
 	boardStartXWithCoords = 2
 
-	// boardStartXNoCoords is the column where the first piece starts when ShowCoords is false.
+	// boardStartXNoCoords is the column where the first piece starts when no rank labels are shown.
 	boardStartXNoCoords = 0
 
 	// squareWidth is the width of each square in characters.
@@ -33,13 +33,13 @@ const (
 //
 // The calculation accounts for:
 // - Board Y offset from title and spacing
-// - Board X offset from rank labels (if ShowCoords is enabled)
+// - Board X offset from rank labels (if coordinates are shown outside the board)
 // - Each square being 2 characters wide
 // - Rank 8 at the top (y=0 relative to board), rank 1 at the bottom
 func squareFromMouse(x, y int, config Config) *engine.Square {
 	// Calculate board start X based on whether coordinates are shown
 	boardStartX := boardStartXNoCoords
-	if config.ShowCoords {
+	if config.ShowCoords && config.CoordinateStyle != CoordsInside {
 		boardStartX = boardStartXWithCoords
 	}
 
@@ -83,12 +83,17 @@ func (m Model) handleMouseEvent(msg tea.MouseMsg) (Model, tea.Cmd) {
 	}
 
 	// For PvBot games, only allow interaction when it's the human's turn
-	if m.gameType == GameTypePvBot && m.board.ActiveColor != m.userColor {
+	if m.gameType == GameTypePvBot && m.game.Board().ActiveColor != m.userColor {
+		return m, nil
+	}
+
+	// A finished simul board takes no more moves
+	if m.inSimul() && m.shownSimulGame().finished() {
 		return m, nil
 	}
 
 	// Get the piece at the clicked square
-	piece := m.board.PieceAt(*sq)
+	piece := m.game.Board().PieceAt(*sq)
 
 	// If we have a selected piece and clicked on a valid move destination, execute the move
 	if m.selectedSquare != nil && m.isValidMoveDestination(*sq) {
@@ -96,7 +101,7 @@ func (m Model) handleMouseEvent(msg tea.MouseMsg) (Model, tea.Cmd) {
 	}
 
 	// Check if the clicked square contains a piece belonging to the current player
-	if !piece.IsEmpty() && piece.Color() == m.board.ActiveColor {
+	if !piece.IsEmpty() && piece.Color() == m.game.Board().ActiveColor {
 		// Select this piece (or change selection to a different own piece)
 		m.selectedSquare = sq
 		m.computeValidMoves()
@@ -112,13 +117,13 @@ func (m Model) handleMouseEvent(msg tea.MouseMsg) (Model, tea.Cmd) {
 // computeValidMoves populates the validMoves field with all legal destination squares
 // for the currently selected piece.
 func (m *Model) computeValidMoves() {
-	if m.selectedSquare == nil || m.board == nil {
+	if m.selectedSquare == nil || m.game == nil {
 		m.validMoves = nil
 		return
 	}
 
 	var moves []engine.Square
-	for _, move := range m.board.LegalMoves() {
+	for _, move := range m.game.Board().LegalMoves() {
 		if move.From == *m.selectedSquare {
 			moves = append(moves, move.To)
 		}
@@ -148,7 +153,7 @@ func (m Model) executeMouseMove(destination engine.Square) (Model, tea.Cmd) {
 	// Find the matching move from legal moves
 	// For pawn promotions, we default to Queen promotion
 	var matchingMove *engine.Move
-	for _, move := range m.board.LegalMoves() {
+	for _, move := range m.game.Board().LegalMoves() {
 		if move.From == *m.selectedSquare && move.To == destination {
 			// For promotion moves, prefer Queen (or take the first one if no Queen promotion exists)
 			if matchingMove == nil {
@@ -166,14 +171,25 @@ func (m Model) executeMouseMove(destination engine.Square) (Model, tea.Cmd) {
 
 	if matchingMove == nil {
 		// Should not happen if isValidMoveDestination was true, but handle gracefully
-		m.errorMsg = "Invalid move"
+		m.notify(SeverityError, "Invalid move")
 		return m, nil
 	}
 
+	// In training mode a move that hangs a piece needs a second click to confirm
+	if m.holdHangingMove(*matchingMove) {
+		return m, nil
+	}
+
+	// Correspondence moves are sent to the mailbox
+	if m.gameType == GameTypeCorrespondence {
+		result, cmd := m.playCorrespondenceMove(*matchingMove)
+		return result.(Model), cmd
+	}
+
 	// Execute the move
-	err := m.board.MakeMove(*matchingMove)
+	err := m.playMove(*matchingMove)
 	if err != nil {
-		m.errorMsg = err.Error()
+		m.notify(SeverityError, err.Error())
 		return m, nil
 	}
 
@@ -181,16 +197,12 @@ func (m Model) executeMouseMove(destination engine.Square) (Model, tea.Cmd) {
 	m.selectedSquare = nil
 	m.validMoves = nil
 	m.blinkOn = false
-	m.errorMsg = ""
-	m.statusMsg = ""
+	m.dismissToasts()
 	m.input = "" // Clear any keyboard input as well
 
-	// Add move to history
-	m.moveHistory = append(m.moveHistory, *matchingMove)
-
 	// Check if the game is over after this move
-	if m.board.IsGameOver() {
-		m.screen = ScreenGameOver
+	if m.game.Board().IsGameOver() {
+		m.showGameOver()
 		// Delete the save game file since the game is over
 		_ = config.DeleteSaveGame()
 		// Clean up bot engine if it exists
@@ -206,5 +218,6 @@ func (m Model) executeMouseMove(destination engine.Square) (Model, tea.Cmd) {
 		return m.makeBotMove()
 	}
 
+	m.passKeyboard()
 	return m, nil
 }
@@ -208,7 +208,7 @@ func TestHandleMouseEvent_SelectOwnPiece(t *testing.T) {
 	}
 
 	m := Model{
-		board:    engine.NewBoard(),
+		game:     engine.NewGame(),
 		gameType: GameTypePvP,
 		screen:   ScreenGamePlay,
 		config:   config,
@@ -248,7 +248,7 @@ func TestHandleMouseEvent_ChangeSelection(t *testing.T) {
 	// Start with a piece already selected
 	initialSquare := engine.NewSquare(4, 1) // e2
 	m := Model{
-		board:          engine.NewBoard(),
+		game:           engine.NewGame(),
 		gameType:       GameTypePvP,
 		screen:         ScreenGamePlay,
 		config:         config,
@@ -289,7 +289,7 @@ func TestHandleMouseEvent_IgnoreOpponentPiece(t *testing.T) {
 	// Start with a piece already selected
 	initialSquare := engine.NewSquare(4, 1) // e2
 	m := Model{
-		board:          engine.NewBoard(),
+		game:           engine.NewGame(),
 		gameType:       GameTypePvP,
 		screen:         ScreenGamePlay,
 		config:         config,
@@ -329,7 +329,7 @@ func TestHandleMouseEvent_IgnoreEmptySquare(t *testing.T) {
 	// Start with a piece already selected
 	initialSquare := engine.NewSquare(4, 1) // e2
 	m := Model{
-		board:          engine.NewBoard(),
+		game:           engine.NewGame(),
 		gameType:       GameTypePvP,
 		screen:         ScreenGamePlay,
 		config:         config,
@@ -366,7 +366,7 @@ func TestHandleMouseEvent_IgnoreRightClick(t *testing.T) {
 	}
 
 	m := Model{
-		board:    engine.NewBoard(),
+		game:     engine.NewGame(),
 		gameType: GameTypePvP,
 		screen:   ScreenGamePlay,
 		config:   config,
@@ -394,7 +394,7 @@ func TestHandleMouseEvent_IgnoreMouseRelease(t *testing.T) {
 	}
 
 	m := Model{
-		board:    engine.NewBoard(),
+		game:     engine.NewGame(),
 		gameType: GameTypePvP,
 		screen:   ScreenGamePlay,
 		config:   config,
@@ -423,7 +423,7 @@ func TestHandleMouseEvent_IgnoreOutOfBounds(t *testing.T) {
 
 	initialSquare := engine.NewSquare(4, 1) // e2
 	m := Model{
-		board:          engine.NewBoard(),
+		game:           engine.NewGame(),
 		gameType:       GameTypePvP,
 		screen:         ScreenGamePlay,
 		config:         config,
@@ -458,7 +458,7 @@ func TestHandleMouseEvent_PvBot_HumanTurn(t *testing.T) {
 	}
 
 	m := Model{
-		board:     engine.NewBoard(),
+		game:      engine.NewGame(),
 		gameType:  GameTypePvBot,
 		screen:    ScreenGamePlay,
 		config:    config,
@@ -493,7 +493,7 @@ func TestHandleMouseEvent_PvBot_BotTurn(t *testing.T) {
 	}
 
 	m := Model{
-		board:     engine.NewBoard(),
+		game:      engine.NewGame(),
 		gameType:  GameTypePvBot,
 		screen:    ScreenGamePlay,
 		config:    config,
@@ -523,7 +523,7 @@ func TestUpdate_BvBIgnoresMouse(t *testing.T) {
 	}
 
 	m := Model{
-		board:    engine.NewBoard(),
+		game:     engine.NewGame(),
 		gameType: GameTypeBvB,
 		screen:   ScreenGamePlay,
 		config:   config,
@@ -554,7 +554,7 @@ func TestUpdate_NonGamePlayIgnoresMouse(t *testing.T) {
 	}
 
 	m := Model{
-		board:    engine.NewBoard(),
+		game:     engine.NewGame(),
 		gameType: GameTypePvP,
 		screen:   ScreenMainMenu, // Not GamePlay screen
 		config:   config,
@@ -583,7 +583,7 @@ func TestComputeValidMoves(t *testing.T) {
 	}
 
 	m := Model{
-		board:    engine.NewBoard(),
+		game:     engine.NewGame(),
 		gameType: GameTypePvP,
 		screen:   ScreenGamePlay,
 		config:   config,
@@ -667,7 +667,7 @@ func TestExecuteMouseMove_ValidMove(t *testing.T) {
 	}
 
 	m := Model{
-		board:    engine.NewBoard(),
+		game:     engine.NewGame(),
 		gameType: GameTypePvP,
 		screen:   ScreenGamePlay,
 		config:   config,
@@ -702,25 +702,25 @@ func TestExecuteMouseMove_ValidMove(t *testing.T) {
 
 	// Check that the pawn is now on e4
 	e4 := engine.NewSquare(4, 3)
-	piece := newModel.board.PieceAt(e4)
+	piece := newModel.game.Board().PieceAt(e4)
 	if piece.Type() != engine.Pawn || piece.Color() != engine.White {
 		t.Errorf("Expected white pawn on e4 after move")
 	}
 
 	// Check that e2 is now empty
-	piece = newModel.board.PieceAt(e2)
+	piece = newModel.game.Board().PieceAt(e2)
 	if !piece.IsEmpty() {
 		t.Errorf("Expected e2 to be empty after move")
 	}
 
 	// Check that it's now Black's turn
-	if newModel.board.ActiveColor != engine.Black {
+	if newModel.game.Board().ActiveColor != engine.Black {
 		t.Errorf("Expected Black to move after White's move")
 	}
 
 	// Check that move was added to history
-	if len(newModel.moveHistory) != 1 {
-		t.Errorf("Expected 1 move in history, got %d", len(newModel.moveHistory))
+	if newModel.game.MoveCount() != 1 {
+		t.Errorf("Expected 1 move in history, got %d", newModel.game.MoveCount())
 	}
 }
 
@@ -732,7 +732,7 @@ func TestExecuteMouseMove_InvalidDestination(t *testing.T) {
 	}
 
 	m := Model{
-		board:    engine.NewBoard(),
+		game:     engine.NewGame(),
 		gameType: GameTypePvP,
 		screen:   ScreenGamePlay,
 		config:   config,
@@ -766,7 +766,7 @@ func TestExecuteMouseMove_InvalidDestination(t *testing.T) {
 	}
 
 	// Move should NOT be executed
-	if newModel.board.ActiveColor != engine.White {
+	if newModel.game.Board().ActiveColor != engine.White {
 		t.Errorf("Expected White to still be the active color (no move made)")
 	}
 }
@@ -778,7 +778,7 @@ func TestMouseMoveClears_Selection(t *testing.T) {
 	}
 
 	m := Model{
-		board:    engine.NewBoard(),
+		game:     engine.NewGame(),
 		gameType: GameTypePvP,
 		screen:   ScreenGamePlay,
 		config:   config,
@@ -808,11 +808,10 @@ func TestMouseMove_PvP_CompleteTurn(t *testing.T) {
 	}
 
 	m := Model{
-		board:       engine.NewBoard(),
-		gameType:    GameTypePvP,
-		screen:      ScreenGamePlay,
-		config:      config,
-		moveHistory: []engine.Move{},
+		game:     engine.NewGame(),
+		gameType: GameTypePvP,
+		screen:   ScreenGamePlay,
+		config:   config,
 	}
 
 	// White's turn: select e2, move to e4
@@ -829,7 +828,7 @@ func TestMouseMove_PvP_CompleteTurn(t *testing.T) {
 	m, _ = m.handleMouseEvent(msgMoveE4)
 
 	// Verify move executed
-	if m.board.ActiveColor != engine.Black {
+	if m.game.Board().ActiveColor != engine.Black {
 		t.Errorf("Expected Black's turn after White's move")
 	}
 
@@ -847,13 +846,13 @@ func TestMouseMove_PvP_CompleteTurn(t *testing.T) {
 	m, _ = m.handleMouseEvent(msgMoveE5)
 
 	// Verify move executed
-	if m.board.ActiveColor != engine.White {
+	if m.game.Board().ActiveColor != engine.White {
 		t.Errorf("Expected White's turn after Black's move")
 	}
 
 	// Verify move history has 2 moves
-	if len(m.moveHistory) != 2 {
-		t.Errorf("Expected 2 moves in history, got %d", len(m.moveHistory))
+	if m.game.MoveCount() != 2 {
+		t.Errorf("Expected 2 moves in history, got %d", m.game.MoveCount())
 	}
 }
 
@@ -864,12 +863,11 @@ func TestMouseMove_PvBot_HumanMove(t *testing.T) {
 	}
 
 	m := Model{
-		board:       engine.NewBoard(),
-		gameType:    GameTypePvBot,
-		screen:      ScreenGamePlay,
-		config:      config,
-		userColor:   engine.White, // Human plays White
-		moveHistory: []engine.Move{},
+		game:      engine.NewGame(),
+		gameType:  GameTypePvBot,
+		screen:    ScreenGamePlay,
+		config:    config,
+		userColor: engine.White, // Human plays White
 	}
 
 	// Select e2 pawn
@@ -881,7 +879,7 @@ func TestMouseMove_PvBot_HumanMove(t *testing.T) {
 	newModel, cmd := m.executeMouseMove(engine.NewSquare(4, 3))
 
 	// Move should be executed
-	if newModel.board.ActiveColor != engine.Black {
+	if newModel.game.Board().ActiveColor != engine.Black {
 		t.Errorf("Expected Black's turn after White's move")
 	}
 
@@ -902,11 +900,10 @@ func TestMouseMove_Capture(t *testing.T) {
 	}
 
 	m := Model{
-		board:       board,
-		gameType:    GameTypePvP,
-		screen:      ScreenGamePlay,
-		config:      config,
-		moveHistory: []engine.Move{},
+		game:     engine.NewGameFrom(board),
+		gameType: GameTypePvP,
+		screen:   ScreenGamePlay,
+		config:   config,
 	}
 
 	// Select e4 pawn
@@ -924,13 +921,13 @@ func TestMouseMove_Capture(t *testing.T) {
 	newModel, _ := m.executeMouseMove(d5)
 
 	// Verify capture was executed
-	piece := newModel.board.PieceAt(d5)
+	piece := newModel.game.Board().PieceAt(d5)
 	if piece.Type() != engine.Pawn || piece.Color() != engine.White {
 		t.Errorf("Expected white pawn on d5 after capture")
 	}
 
 	// e4 should be empty
-	piece = newModel.board.PieceAt(e4)
+	piece = newModel.game.Board().PieceAt(e4)
 	if !piece.IsEmpty() {
 		t.Errorf("Expected e4 to be empty after capture")
 	}
@@ -944,7 +941,7 @@ func TestMouseMove_ChangeSelectionToAnotherPiece(t *testing.T) {
 	}
 
 	m := Model{
-		board:    engine.NewBoard(),
+		game:     engine.NewGame(),
 		gameType: GameTypePvP,
 		screen:   ScreenGamePlay,
 		config:   config,
@@ -1013,22 +1010,21 @@ func TestMouseMove_PromotionAutoQueen(t *testing.T) {
 	}
 
 	m := Model{
-		board:       board,
-		gameType:    GameTypePvP,
-		screen:      ScreenGamePlay,
-		config:      config,
-		moveHistory: []engine.Move{},
+		game:     engine.NewGameFrom(board),
+		gameType: GameTypePvP,
+		screen:   ScreenGamePlay,
+		config:   config,
 	}
 
 	// Select a7 pawn (file 0, rank 6)
 	a7 := engine.NewSquare(0, 6)
-	piece := m.board.PieceAt(a7)
+	piece := m.game.Board().PieceAt(a7)
 	if piece.Type() != engine.Pawn || piece.Color() != engine.White {
 		// Debug: print board state
 		for rank := 7; rank >= 0; rank-- {
 			for file := 0; file < 8; file++ {
 				sq := engine.NewSquare(file, rank)
-				p := m.board.PieceAt(sq)
+				p := m.game.Board().PieceAt(sq)
 				if p.IsEmpty() {
 					t.Logf("  ")
 				} else {
@@ -1047,7 +1043,7 @@ func TestMouseMove_PromotionAutoQueen(t *testing.T) {
 	a8 := engine.NewSquare(0, 7)
 	if !m.isValidMoveDestination(a8) {
 		// Debug: show all legal moves
-		for _, move := range m.board.LegalMoves() {
+		for _, move := range m.game.Board().LegalMoves() {
 			t.Logf("Legal move: %v -> %v (promo: %v)", move.From, move.To, move.Promotion)
 		}
 		t.Fatalf("Expected a8 to be a valid promotion destination, validMoves: %v", m.validMoves)
@@ -1057,7 +1053,7 @@ func TestMouseMove_PromotionAutoQueen(t *testing.T) {
 	newModel, _ := m.executeMouseMove(a8)
 
 	// Verify promotion to Queen
-	pieceAtA8 := newModel.board.PieceAt(a8)
+	pieceAtA8 := newModel.game.Board().PieceAt(a8)
 	if pieceAtA8.Type() != engine.Queen {
 		t.Errorf("Expected Queen on a8 after promotion, got %v", pieceAtA8.Type())
 	}
@@ -1077,20 +1073,19 @@ func TestMouseMove_GameOver(t *testing.T) {
 	}
 
 	m := Model{
-		board:       board,
-		gameType:    GameTypePvP,
-		screen:      ScreenGamePlay,
-		config:      config,
-		moveHistory: []engine.Move{},
+		game:     engine.NewGameFrom(board),
+		gameType: GameTypePvP,
+		screen:   ScreenGamePlay,
+		config:   config,
 	}
 
 	// This position is already checkmate for white
-	if !m.board.IsGameOver() {
+	if !m.game.Board().IsGameOver() {
 		t.Skip("Position should be game over (checkmate)")
 	}
 
 	// Verify game is over
-	if m.board.IsGameOver() {
+	if m.game.Board().IsGameOver() {
 		m.screen = ScreenGameOver
 	}
 
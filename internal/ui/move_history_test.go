@@ -27,9 +27,9 @@ func TestFormatSAN_SimplePawnMoves(t *testing.T) {
 				t.Fatalf("Failed to parse move %s: %v", tt.moveStr, err)
 			}
 
-			san := FormatSAN(board, move)
+			san := engine.FormatSAN(board, move)
 			if san != tt.expected {
-				t.Errorf("FormatSAN(%s) = %s, want %s", tt.moveStr, san, tt.expected)
+				t.Errorf("engine.FormatSAN(%s) = %s, want %s", tt.moveStr, san, tt.expected)
 			}
 		})
 	}
@@ -53,9 +53,9 @@ func TestFormatSAN_PawnCaptures(t *testing.T) {
 		t.Fatalf("Failed to parse move: %v", err)
 	}
 
-	san := FormatSAN(testBoard, move)
+	san := engine.FormatSAN(testBoard, move)
 	if san != "exd4" {
-		t.Errorf("FormatSAN(exd4) = %s, want exd4", san)
+		t.Errorf("engine.FormatSAN(exd4) = %s, want exd4", san)
 	}
 }
 
@@ -100,9 +100,9 @@ func TestFormatSAN_PieceMove(t *testing.T) {
 				t.Fatalf("Failed to parse move %s: %v", tt.moveStr, err)
 			}
 
-			san := FormatSAN(testBoard, move)
+			san := engine.FormatSAN(testBoard, move)
 			if san != tt.expected {
-				t.Errorf("FormatSAN(%s) = %s, want %s", tt.moveStr, san, tt.expected)
+				t.Errorf("engine.FormatSAN(%s) = %s, want %s", tt.moveStr, san, tt.expected)
 			}
 		})
 	}
@@ -125,9 +125,9 @@ func TestFormatSAN_PieceCaptures(t *testing.T) {
 		t.Fatalf("Failed to parse move: %v", err)
 	}
 
-	san := FormatSAN(testBoard, move)
+	san := engine.FormatSAN(testBoard, move)
 	if san != "Nxd5" {
-		t.Errorf("FormatSAN(Nxd5) = %s, want Nxd5", san)
+		t.Errorf("engine.FormatSAN(Nxd5) = %s, want Nxd5", san)
 	}
 }
 
@@ -166,9 +166,9 @@ func TestFormatSAN_Castling(t *testing.T) {
 				t.Fatalf("Failed to parse move %s: %v", tt.moveStr, err)
 			}
 
-			san := FormatSAN(testBoard, move)
+			san := engine.FormatSAN(testBoard, move)
 			if san != tt.expected {
-				t.Errorf("FormatSAN(%s) = %s, want %s", tt.moveStr, san, tt.expected)
+				t.Errorf("engine.FormatSAN(%s) = %s, want %s", tt.moveStr, san, tt.expected)
 			}
 		})
 	}
@@ -204,10 +204,10 @@ func TestFormatSAN_Promotion(t *testing.T) {
 				t.Fatalf("Failed to parse move %s: %v", tt.moveStr, err)
 			}
 
-			san := FormatSAN(testBoard, move)
+			san := engine.FormatSAN(testBoard, move)
 			// The move should end with checkmate symbol
 			if !strings.HasPrefix(san, tt.expected) {
-				t.Errorf("FormatSAN(%s) = %s, want prefix %s", tt.moveStr, san, tt.expected)
+				t.Errorf("engine.FormatSAN(%s) = %s, want prefix %s", tt.moveStr, san, tt.expected)
 			}
 		})
 	}
@@ -227,9 +227,9 @@ func TestFormatSAN_PromotionWithCapture(t *testing.T) {
 		t.Fatalf("Failed to parse move: %v", err)
 	}
 
-	san := FormatSAN(board, move)
+	san := engine.FormatSAN(board, move)
 	if !strings.HasPrefix(san, "axb8=Q") {
-		t.Errorf("FormatSAN(axb8=Q) = %s, want prefix axb8=Q", san)
+		t.Errorf("engine.FormatSAN(axb8=Q) = %s, want prefix axb8=Q", san)
 	}
 }
 
@@ -249,9 +249,9 @@ func TestFormatSAN_Check(t *testing.T) {
 		t.Fatalf("Failed to parse move: %v", err)
 	}
 
-	san := FormatSAN(board, move)
+	san := engine.FormatSAN(board, move)
 	if san != "Bb4+" {
-		t.Errorf("FormatSAN(Bb4+) = %s, want Bb4+", san)
+		t.Errorf("engine.FormatSAN(Bb4+) = %s, want Bb4+", san)
 	}
 }
 
@@ -270,9 +270,9 @@ func TestFormatSAN_Checkmate(t *testing.T) {
 		t.Fatalf("Failed to parse move: %v", err)
 	}
 
-	san := FormatSAN(board, move)
+	san := engine.FormatSAN(board, move)
 	if san != "Re8#" {
-		t.Errorf("FormatSAN(Re8#) = %s, want Re8#", san)
+		t.Errorf("engine.FormatSAN(Re8#) = %s, want Re8#", san)
 	}
 }
 
@@ -316,9 +316,9 @@ func TestFormatSAN_Disambiguation(t *testing.T) {
 				t.Fatalf("Failed to parse move %s: %v", tt.moveStr, err)
 			}
 
-			san := FormatSAN(board, move)
+			san := engine.FormatSAN(board, move)
 			if san != tt.expected {
-				t.Errorf("FormatSAN(%s) = %s, want %s", tt.moveStr, san, tt.expected)
+				t.Errorf("engine.FormatSAN(%s) = %s, want %s", tt.moveStr, san, tt.expected)
 			}
 		})
 	}
@@ -334,7 +334,7 @@ func TestFormatMoveHistory(t *testing.T) {
 	}
 
 	m := NewModel(config)
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 
 	// Play some moves
 	movesStr := []string{"e2e4", "e7e5", "g1f3", "b8c6", "f1c4"}
@@ -345,8 +345,7 @@ func TestFormatMoveHistory(t *testing.T) {
 			t.Fatalf("Failed to parse move %s: %v", moveStr, err)
 		}
 
-		m.board.MakeMove(move)
-		m.moveHistory = append(m.moveHistory, move)
+		m.game.MakeMove(move)
 	}
 
 	// Format the move history
@@ -376,7 +375,7 @@ func TestMoveHistoryEmpty(t *testing.T) {
 	}
 
 	m := NewModel(config)
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 
 	history := m.formatMoveHistory()
 	if history != "" {
@@ -394,7 +393,7 @@ func TestMoveHistoryReplayAccuracy(t *testing.T) {
 	}
 
 	m := NewModel(config)
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 
 	// Play Italian Game opening
 	expectedSAN := []string{"e4", "e5", "Nf3", "Nc6", "Bc4", "Nf6", "d3", "Bc5"}
@@ -407,13 +406,12 @@ func TestMoveHistoryReplayAccuracy(t *testing.T) {
 		}
 
 		// Format before making the move
-		san := FormatSAN(m.board, move)
+		san := engine.FormatSAN(m.game.Board(), move)
 		if san != expectedSAN[i] {
 			t.Errorf("Move %d: expected %s, got %s", i+1, expectedSAN[i], san)
 		}
 
-		m.board.MakeMove(move)
-		m.moveHistory = append(m.moveHistory, move)
+		m.game.MakeMove(move)
 	}
 
 	// Verify the full history
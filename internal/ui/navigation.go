@@ -14,6 +14,16 @@ func screenName(s Screen) string {
 		return "Bot Difficulty"
 	case ScreenColorSelect:
 		return "Choose Color"
+	case ScreenHandicapSelect:
+		return "Handicap"
+	case ScreenCorrespondenceList:
+		return "My Games"
+	case ScreenCorrespondenceNew:
+		return "New Correspondence Game"
+	case ScreenHandoff:
+		return "Pass the Keyboard"
+	case ScreenWatch:
+		return "Watch"
 	case ScreenFENInput:
 		return "Load Game"
 	case ScreenGamePlay:
@@ -40,6 +50,32 @@ func screenName(s Screen) string {
 		return "View Mode"
 	case ScreenBvBConcurrencySelect:
 		return "Concurrency Select"
+	case ScreenAnalysis:
+		return "Analysis"
+	case ScreenKeyBindings:
+		return "Key Bindings"
+	case ScreenProfileSelect:
+		return "Profiles"
+	case ScreenMatchSummary:
+		return "Match Summary"
+	case ScreenSimulSetup:
+		return "Simul Boards"
+	case ScreenGuessSelect:
+		return "Master Games"
+	case ScreenGuessMove:
+		return "Guess the Move"
+	case ScreenUpgrade:
+		return "Upgrade"
+	case ScreenCrashRecovery:
+		return "Restore Game"
+	case ScreenLearn:
+		return "Learn"
+	case ScreenTutorial:
+		return "Tutorial"
+	case ScreenGameHistory:
+		return "Game History"
+	case ScreenPassphrase:
+		return "Passphrase"
 	default:
 		return "Unknown"
 	}
@@ -82,13 +118,13 @@ func (m *Model) popScreen() Screen {
 func (m *Model) restoreMenuState() {
 	switch m.screen {
 	case ScreenGameTypeSelect:
-		m.menuOptions = []string{"Player vs Player", "Player vs Bot", "Bot vs Bot"}
+		m.menuOptions = gameTypeOptions()
 	case ScreenBvBBotSelect:
-		m.menuOptions = []string{"Easy", "Medium", "Hard"}
+		m.menuOptions = botOptions()
 	case ScreenBvBGameMode:
-		m.menuOptions = []string{"Single Game", "Multi-Game"}
+		m.menuOptions = bvbGameModeOptions()
 	case ScreenBvBGridConfig:
-		m.menuOptions = []string{"1x1", "2x2", "2x3", "2x4", "Custom"}
+		m.menuOptions = bvbGridOptions()
 	case ScreenBvBConcurrencySelect:
 		// Concurrency menu doesn't use menuOptions (uses bvbConcurrencySelection)
 	case ScreenBvBViewModeSelect:
@@ -96,14 +132,25 @@ func (m *Model) restoreMenuState() {
 	case ScreenMainMenu:
 		m.menuOptions = buildMainMenuOptions()
 	case ScreenBotSelect:
-		m.menuOptions = []string{"Easy", "Medium", "Hard"}
+		m.menuOptions = botOptions()
+	case ScreenHandicapSelect:
+		m.menuOptions = handicapOptions()
+	case ScreenCorrespondenceList:
+		// Opponents may have moved while the user was away
+		m.loadCorrespondenceGames()
 	case ScreenColorSelect:
-		m.menuOptions = []string{"Play as White", "Play as Black"}
+		m.menuOptions = menuLabels(colorMenu())
+	case ScreenGameOver:
+		m.menuOptions = menuLabels(m.gameOverMenu())
+	case ScreenSimulSetup:
+		m.menuOptions = menuLabels(simulBoardMenu())
+	case ScreenGuessSelect:
+		m.menuOptions = menuLabels(guessGameMenu())
 	case ScreenSettings:
 		m.menuOptions = []string{"Theme: " + string(m.theme.Name)}
 	}
 	m.menuSelection = 0
-	m.errorMsg = ""
+	m.dismissToasts(SeverityError)
 }
 
 // clearNavStack clears the navigation stack.
@@ -26,7 +26,7 @@ func TestESCKeyGameTypeSelectToMainMenu(t *testing.T) {
 	}
 
 	// Verify menu was reset to main menu options
-	expectedOptions := []string{"New Game", "Load Game", "Settings", "Exit"}
+	expectedOptions := []string{"New Game", "Load Game", "Game History", "Settings", "Learn", "Exit"}
 	if len(m.menuOptions) != len(expectedOptions) {
 		t.Errorf("Expected %d menu options, got %d", len(expectedOptions), len(m.menuOptions))
 	}
@@ -37,11 +37,11 @@ func TestESCKeyGameTypeSelectToMainMenu(t *testing.T) {
 	}
 
 	// Verify messages are cleared
-	if m.errorMsg != "" {
-		t.Errorf("Expected errorMsg to be cleared, got '%s'", m.errorMsg)
+	if toastError(m) != "" {
+		t.Errorf("Expected errorMsg to be cleared, got '%s'", toastError(m))
 	}
-	if m.statusMsg != "" {
-		t.Errorf("Expected statusMsg to be cleared, got '%s'", m.statusMsg)
+	if toastStatus(m) != "" {
+		t.Errorf("Expected statusMsg to be cleared, got '%s'", toastStatus(m))
 	}
 }
 
@@ -62,7 +62,7 @@ func TestESCKeyFENInputToMainMenu(t *testing.T) {
 	}
 
 	// Verify menu was reset to main menu options
-	expectedOptions := []string{"New Game", "Load Game", "Settings", "Exit"}
+	expectedOptions := []string{"New Game", "Load Game", "Game History", "Settings", "Learn", "Exit"}
 	if len(m.menuOptions) != len(expectedOptions) {
 		t.Errorf("Expected %d menu options, got %d", len(expectedOptions), len(m.menuOptions))
 	}
@@ -73,11 +73,11 @@ func TestESCKeyFENInputToMainMenu(t *testing.T) {
 	}
 
 	// Verify messages are cleared
-	if m.errorMsg != "" {
-		t.Errorf("Expected errorMsg to be cleared, got '%s'", m.errorMsg)
+	if toastError(m) != "" {
+		t.Errorf("Expected errorMsg to be cleared, got '%s'", toastError(m))
 	}
-	if m.statusMsg != "" {
-		t.Errorf("Expected statusMsg to be cleared, got '%s'", m.statusMsg)
+	if toastStatus(m) != "" {
+		t.Errorf("Expected statusMsg to be cleared, got '%s'", toastStatus(m))
 	}
 }
 
@@ -102,7 +102,7 @@ func TestESCKeySettingsToMainMenu(t *testing.T) {
 		t.Errorf("Expected menuSelection to be reset to 0, got %d", m.menuSelection)
 	}
 
-	expectedOptions := []string{"New Game", "Load Game", "Settings", "Exit"}
+	expectedOptions := []string{"New Game", "Load Game", "Game History", "Settings", "Learn", "Exit"}
 	if len(m.menuOptions) != len(expectedOptions) {
 		t.Errorf("Expected %d menu options, got %d", len(expectedOptions), len(m.menuOptions))
 	}
@@ -111,7 +111,7 @@ func TestESCKeySettingsToMainMenu(t *testing.T) {
 // TestESCKeyGamePlayShowsSavePrompt tests ESC key shows save prompt during active gameplay
 func TestESCKeyGamePlayShowsSavePrompt(t *testing.T) {
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenGamePlay
 	m.input = "e4"
 
@@ -136,18 +136,18 @@ func TestESCKeyGamePlayShowsSavePrompt(t *testing.T) {
 	}
 
 	// Verify messages are cleared
-	if m.errorMsg != "" {
-		t.Errorf("Expected errorMsg to be cleared, got '%s'", m.errorMsg)
+	if toastError(m) != "" {
+		t.Errorf("Expected errorMsg to be cleared, got '%s'", toastError(m))
 	}
-	if m.statusMsg != "" {
-		t.Errorf("Expected statusMsg to be cleared, got '%s'", m.statusMsg)
+	if toastStatus(m) != "" {
+		t.Errorf("Expected statusMsg to be cleared, got '%s'", toastStatus(m))
 	}
 }
 
 // TestESCKeySavePromptReturnsToGamePlay tests ESC key returns to gameplay from save prompt
 func TestESCKeySavePromptReturnsToGamePlay(t *testing.T) {
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenSavePrompt
 	m.savePromptSelection = 1
 	m.savePromptAction = "menu"
@@ -163,20 +163,20 @@ func TestESCKeySavePromptReturnsToGamePlay(t *testing.T) {
 	}
 
 	// Verify messages are cleared
-	if m.errorMsg != "" {
-		t.Errorf("Expected errorMsg to be cleared, got '%s'", m.errorMsg)
+	if toastError(m) != "" {
+		t.Errorf("Expected errorMsg to be cleared, got '%s'", toastError(m))
 	}
-	if m.statusMsg != "" {
-		t.Errorf("Expected statusMsg to be cleared, got '%s'", m.statusMsg)
+	if toastStatus(m) != "" {
+		t.Errorf("Expected statusMsg to be cleared, got '%s'", toastStatus(m))
 	}
 }
 
 // TestESCKeyGameOverToMainMenu tests ESC key navigation from GameOver to MainMenu
 func TestESCKeyGameOverToMainMenu(t *testing.T) {
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenGameOver
-	m.resignedBy = int8(engine.White)
+	m.game.Resign(engine.White)
 
 	// Press ESC key
 	msg := tea.KeyMsg{Type: tea.KeyEsc}
@@ -188,18 +188,13 @@ func TestESCKeyGameOverToMainMenu(t *testing.T) {
 		t.Errorf("Expected screen to be ScreenMainMenu, got %v", m.screen)
 	}
 
-	// Verify board was cleared
-	if m.board != nil {
-		t.Error("Expected board to be nil after returning to main menu")
-	}
-
-	// Verify move history was cleared
-	if len(m.moveHistory) != 0 {
-		t.Errorf("Expected moveHistory to be empty, got %d moves", len(m.moveHistory))
+	// Verify the game was cleared
+	if m.game != nil {
+		t.Error("Expected game to be nil after returning to main menu")
 	}
 
 	// Verify menu was reset
-	expectedOptions := []string{"New Game", "Load Game", "Settings", "Exit"}
+	expectedOptions := []string{"New Game", "Load Game", "Game History", "Settings", "Learn", "Exit"}
 	if len(m.menuOptions) != len(expectedOptions) {
 		t.Errorf("Expected %d menu options, got %d", len(expectedOptions), len(m.menuOptions))
 	}
@@ -212,7 +207,7 @@ func TestESCKeyGameOverToMainMenu(t *testing.T) {
 // TestESCKeyDrawPromptReturnsToGamePlay tests ESC key returns to gameplay from draw prompt
 func TestESCKeyDrawPromptReturnsToGamePlay(t *testing.T) {
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenDrawPrompt
 	m.drawOfferedBy = int8(engine.White)
 	m.drawOfferedByWhite = true
@@ -238,8 +233,8 @@ func TestESCKeyDrawPromptReturnsToGamePlay(t *testing.T) {
 	}
 
 	// Verify status message was set
-	if m.statusMsg != "Draw offer cancelled" {
-		t.Errorf("Expected statusMsg to be 'Draw offer cancelled', got '%s'", m.statusMsg)
+	if toastStatus(m) != "Draw offer cancelled" {
+		t.Errorf("Expected statusMsg to be 'Draw offer cancelled', got '%s'", toastStatus(m))
 	}
 }
 
@@ -251,7 +246,7 @@ func TestNavigationFlowNewGameToMenu(t *testing.T) {
 	m.menuSelection = 0
 
 	// Select "New Game"
-	result, _ := m.handleMainMenuSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	// Should be at GameTypeSelect
@@ -278,7 +273,7 @@ func TestNavigationFlowLoadGameToMenu(t *testing.T) {
 	m.menuSelection = 1
 
 	// Select "Load Game"
-	result, _ := m.handleMainMenuSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	// Should be at FENInput
@@ -305,7 +300,7 @@ func TestNavigationFlowSettingsToMenu(t *testing.T) {
 	m.menuSelection = 2
 
 	// Select "Settings"
-	result, _ := m.handleMainMenuSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	// Should be at Settings
@@ -338,7 +333,7 @@ func TestCtrlCExitsFromAnyScreen(t *testing.T) {
 		m := NewModel(DefaultConfig())
 		m.screen = screen
 		if screen == ScreenGameOver || screen == ScreenGamePlay {
-			m.board = engine.NewBoard()
+			m.game = engine.NewGame()
 		}
 		if screen == ScreenGameTypeSelect {
 			m.menuOptions = []string{"Player vs Player", "Player vs Bot"}
@@ -375,7 +370,7 @@ func TestQKeyBehaviorDiffersByScreen(t *testing.T) {
 
 	// 'q' should show save prompt from GamePlay
 	m = NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenGamePlay
 
 	msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}}
@@ -563,7 +558,7 @@ func TestNavStackClearedOnGameStart(t *testing.T) {
 	m.menuSelection = 0
 
 	// Select Player vs Player
-	result, _ := m.handleGameTypeSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	// Nav stack should be cleared
@@ -646,7 +641,7 @@ func TestShortcutsOverlayNotShownInTextInputMode(t *testing.T) {
 
 	// Test GamePlay screen (text input mode)
 	m = NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenGamePlay
 
 	result, _ = m.handleKeyPress(msg)
@@ -699,7 +694,7 @@ func TestShortcutsOverlayShownOnNonTextInputScreens(t *testing.T) {
 
 		// Set up any required state for certain screens
 		if screen == ScreenGameOver || screen == ScreenGamePlay {
-			m.board = engine.NewBoard()
+			m.game = engine.NewGame()
 		}
 		if screen == ScreenGameTypeSelect || screen == ScreenBotSelect || screen == ScreenColorSelect || screen == ScreenBvBBotSelect {
 			m.menuOptions = []string{"Option 1", "Option 2"}
@@ -787,7 +782,7 @@ func TestIsInTextInputMode(t *testing.T) {
 			name: "GamePlay is text input",
 			setup: func(m *Model) {
 				m.screen = ScreenGamePlay
-				m.board = engine.NewBoard()
+				m.game = engine.NewGame()
 			},
 			expectedResult: true,
 		},
@@ -867,7 +862,7 @@ func TestNKeyNavigatesToGameTypeSelect(t *testing.T) {
 	}
 
 	// Verify menu options are set for game type selection
-	expectedOptions := []string{"Player vs Player", "Player vs Bot", "Bot vs Bot"}
+	expectedOptions := []string{"Player vs Player", "Player vs Bot", "Bot vs Bot", "Correspondence", "Simul", "Guess the Move", "Tutorial"}
 	if len(m.menuOptions) != len(expectedOptions) {
 		t.Errorf("Expected %d menu options, got %d", len(expectedOptions), len(m.menuOptions))
 	}
@@ -904,7 +899,7 @@ func TestNKeyDoesNotWorkInTextInputMode(t *testing.T) {
 			name: "GamePlay screen",
 			setup: func(m *Model) {
 				m.screen = ScreenGamePlay
-				m.board = engine.NewBoard()
+				m.game = engine.NewGame()
 			},
 		},
 		{
@@ -965,7 +960,7 @@ func TestNKeyDoesNotWorkDuringActiveGameplay(t *testing.T) {
 
 			// Set up required state for certain screens
 			if screen == ScreenGamePlay || screen == ScreenGameOver {
-				m.board = engine.NewBoard()
+				m.game = engine.NewGame()
 			}
 			if screen == ScreenGameTypeSelect {
 				m.menuOptions = []string{"Player vs Player", "Player vs Bot", "Bot vs Bot"}
@@ -1030,7 +1025,7 @@ func TestSKeyDoesNotWorkInTextInputMode(t *testing.T) {
 			name: "GamePlay screen",
 			setup: func(m *Model) {
 				m.screen = ScreenGamePlay
-				m.board = engine.NewBoard()
+				m.game = engine.NewGame()
 			},
 		},
 		{
@@ -1128,7 +1123,7 @@ func TestGlobalShortcutsWorkFromVariousScreens(t *testing.T) {
 				m.bvbInputtingGrid = false
 			}
 			if screen == ScreenSavePrompt || screen == ScreenDrawPrompt {
-				m.board = engine.NewBoard()
+				m.game = engine.NewGame()
 			}
 
 			// Press 'n' key
@@ -1161,7 +1156,7 @@ func TestGlobalShortcutsWorkFromVariousScreens(t *testing.T) {
 
 			// Set up required state for certain screens
 			if screen == ScreenGameOver {
-				m.board = engine.NewBoard()
+				m.game = engine.NewGame()
 			}
 			if screen == ScreenGameTypeSelect || screen == ScreenBotSelect ||
 				screen == ScreenColorSelect || screen == ScreenBvBBotSelect {
@@ -0,0 +1,217 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/Mgrdich/TermChess/internal/config"
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// Coordinate style string constants, the valid values of Config.CoordinateStyle.
+const (
+	// CoordsOutside shows the rank numbers left of the board and the file letters below it
+	CoordsOutside = config.DefaultCoordinateStyle
+	// CoordsInside shows the coordinates on the empty squares of the a-file and first rank
+	CoordsInside = "inside"
+)
+
+// Notation string constants, the valid values of Config.Notation.
+const (
+	// NotationSAN is Standard Algebraic Notation, e.g. "Nf3"
+	NotationSAN = config.DefaultNotation
+	// NotationLong is long algebraic notation, naming both squares, e.g. "Ng1-f3"
+	NotationLong = "long"
+	// NotationCoordinate is the notation bots use, e.g. "g1f3"
+	NotationCoordinate = "coordinate"
+)
+
+// Language string constants, the valid values of Config.Language.
+const (
+	// LanguageEnglish writes pieces as K, Q, R, B and N, e.g. "Nf3"
+	LanguageEnglish = config.DefaultLanguage
+	// LanguageGerman writes pieces as K, D, T, L and S, e.g. "Sf3"
+	LanguageGerman = "de"
+	// LanguageFrench writes pieces as R, D, T, F and C, e.g. "Cf3"
+	LanguageFrench = "fr"
+	// LanguageSpanish writes pieces as R, D, T, A and C, e.g. "Cf3"
+	LanguageSpanish = "es"
+)
+
+// pieceLetters holds the letters each language writes the king, queen, rook,
+// bishop and knight with, in that order.
+var pieceLetters = map[string]string{
+	LanguageEnglish: "KQRBN",
+	LanguageGerman:  "KDTLS",
+	LanguageFrench:  "RDTFC",
+	LanguageSpanish: "RDTAC",
+}
+
+// figurines maps piece letters to the Unicode symbols of figurine notation.
+var figurines = strings.NewReplacer("K", "♔", "Q", "♕", "R", "♖", "B", "♗", "N", "♘")
+
+// cycleCoordinateStyle cycles through coordinate styles: outside -> inside -> outside.
+func cycleCoordinateStyle(current string) string {
+	if current == CoordsInside {
+		return CoordsOutside
+	}
+	return CoordsInside
+}
+
+// getCoordinateStyleDisplayName returns a display-friendly name for a coordinate style.
+func getCoordinateStyleDisplayName(style string) string {
+	if style == CoordsInside {
+		return "Inside"
+	}
+	return "Outside"
+}
+
+// cycleNotation cycles through notations: SAN -> long algebraic -> coordinate -> SAN.
+func cycleNotation(current string) string {
+	switch current {
+	case NotationLong:
+		return NotationCoordinate
+	case NotationCoordinate:
+		return NotationSAN
+	default:
+		return NotationLong
+	}
+}
+
+// cycleLanguage cycles through languages: English -> German -> French -> Spanish -> English.
+func cycleLanguage(current string) string {
+	switch current {
+	case LanguageGerman:
+		return LanguageFrench
+	case LanguageFrench:
+		return LanguageSpanish
+	case LanguageSpanish:
+		return LanguageEnglish
+	default:
+		return LanguageGerman
+	}
+}
+
+// getLanguageDisplayName returns a display-friendly name for a language.
+func getLanguageDisplayName(language string) string {
+	switch language {
+	case LanguageGerman:
+		return "Deutsch"
+	case LanguageFrench:
+		return "Français"
+	case LanguageSpanish:
+		return "Español"
+	default:
+		return "English"
+	}
+}
+
+// getNotationDisplayName returns a display-friendly name for a notation.
+func getNotationDisplayName(notation string) string {
+	switch notation {
+	case NotationLong:
+		return "Long Algebraic"
+	case NotationCoordinate:
+		return "Coordinate"
+	default:
+		return "SAN"
+	}
+}
+
+// FormatLongAlgebraic converts a Move to long algebraic notation, which names
+// both the origin and destination squares.
+// Takes the board state BEFORE the move and the move to format.
+// Returns the notation string (e.g., "e2-e4", "Ng1-f3", "Bc4xf7+", "O-O", "e7-e8=Q").
+func FormatLongAlgebraic(board *engine.Board, move engine.Move) string {
+	piece := board.PieceAt(move.From)
+	if piece.IsEmpty() {
+		return move.String() // Fallback to coordinate notation
+	}
+
+	// Castling is written the same as in SAN
+	if piece.Type() == engine.King {
+		fileDiff := move.To.File() - move.From.File()
+		if fileDiff == 2 || fileDiff == -2 {
+			return engine.FormatSAN(board, move)
+		}
+	}
+
+	var result strings.Builder
+	if piece.Type() != engine.Pawn {
+		result.WriteRune(engine.PieceLetter(piece.Type()))
+	}
+	result.WriteString(move.From.String())
+
+	isCapture := !board.PieceAt(move.To).IsEmpty() ||
+		(piece.Type() == engine.Pawn && board.EnPassantSq >= 0 && move.To == engine.Square(board.EnPassantSq))
+	if isCapture {
+		result.WriteRune('x')
+	} else {
+		result.WriteRune('-')
+	}
+	result.WriteString(move.To.String())
+
+	if move.Promotion != engine.Empty {
+		result.WriteRune('=')
+		result.WriteRune(engine.PieceLetter(move.Promotion))
+	}
+	result.WriteString(engine.CheckSuffix(board, move))
+
+	return result.String()
+}
+
+// FormatFigurine replaces the piece letters of a move written in SAN or long
+// algebraic notation with Unicode piece symbols (e.g., "Nf3" becomes "♘f3").
+func FormatFigurine(notation string) string {
+	return figurines.Replace(notation)
+}
+
+// LocalizePieces replaces the English piece letters of a move written in SAN
+// or long algebraic notation with those of language (e.g., "Nf3" becomes
+// "Sf3" in German). Unknown languages leave the text unchanged.
+func LocalizePieces(text, language string) string {
+	letters, ok := pieceLetters[language]
+	if !ok || language == LanguageEnglish {
+		return text
+	}
+	return letterReplacer(pieceLetters[LanguageEnglish], letters).Replace(text)
+}
+
+// EnglishPieces replaces the piece letters of language in a typed move with
+// the English ones SAN parsing expects (e.g., "Sf3" becomes "Nf3" in German).
+// Letters the language does not use are kept, so English moves still parse
+// unless a letter means another piece in language.
+func EnglishPieces(text, language string) string {
+	letters, ok := pieceLetters[language]
+	if !ok || language == LanguageEnglish {
+		return text
+	}
+	return letterReplacer(letters, pieceLetters[LanguageEnglish]).Replace(text)
+}
+
+// letterReplacer returns a replacer swapping each letter of from for the
+// letter at the same position in to, all at once.
+func letterReplacer(from, to string) *strings.Replacer {
+	pairs := make([]string, 0, 2*len(from))
+	for i := range from {
+		pairs = append(pairs, from[i:i+1], to[i:i+1])
+	}
+	return strings.NewReplacer(pairs...)
+}
+
+// formatMove writes move, played on board, in the notation chosen in the
+// settings. Takes the board state BEFORE the move.
+func (m Model) formatMove(board *engine.Board, move engine.Move) string {
+	var text string
+	switch m.config.Notation {
+	case NotationCoordinate:
+		return move.String()
+	case NotationLong:
+		text = FormatLongAlgebraic(board, move)
+	default:
+		text = engine.FormatSAN(board, move)
+	}
+	if m.config.FigurineNotation {
+		return FormatFigurine(text)
+	}
+	return LocalizePieces(text, m.config.Language)
+}
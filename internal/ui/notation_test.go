@@ -0,0 +1,194 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// TestFormatLongAlgebraic tests formatting moves with both squares named.
+func TestFormatLongAlgebraic(t *testing.T) {
+	tests := []struct {
+		name string
+		fen  string
+		move string
+		want string
+	}{
+		{"pawn push", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "e2e4", "e2-e4"},
+		{"knight move", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "g1f3", "Ng1-f3"},
+		{"capture with check", "rnbqkbnr/pppp1ppp/8/4p3/2B1P3/8/PPPP1PPP/RNBQK1NR w KQkq - 0 3", "c4f7", "Bc4xf7+"},
+		{"en passant", "4k3/8/8/3pP3/8/8/8/4K3 w - d6 0 1", "e5d6", "e5xd6"},
+		{"promotion", "8/4P3/8/8/8/8/k7/4K3 w - - 0 1", "e7e8q", "e7-e8=Q"},
+		{"castling", "4k3/8/8/8/8/8/8/4K2R w K - 0 1", "e1g1", "O-O"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board, err := engine.FromFEN(tt.fen)
+			if err != nil {
+				t.Fatalf("FromFEN(%q) failed: %v", tt.fen, err)
+			}
+			move, err := engine.ParseMove(tt.move)
+			if err != nil {
+				t.Fatalf("ParseMove(%q) failed: %v", tt.move, err)
+			}
+			if got := FormatLongAlgebraic(board, move); got != tt.want {
+				t.Errorf("FormatLongAlgebraic() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestFormatFigurine tests replacing piece letters with Unicode symbols.
+func TestFormatFigurine(t *testing.T) {
+	tests := map[string]string{
+		"Nf3":     "♘f3",
+		"Qxd8+":   "♕xd8+",
+		"e8=Q#":   "e8=♕#",
+		"Rg1-g8":  "♖g1-g8",
+		"O-O-O":   "O-O-O",
+		"exd5":    "exd5",
+		"Kb1xa2+": "♔b1xa2+",
+	}
+	for in, want := range tests {
+		if got := FormatFigurine(in); got != want {
+			t.Errorf("FormatFigurine(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+// TestFormatMoveFollowsSettings tests that moves are written in the notation chosen in the settings.
+func TestFormatMoveFollowsSettings(t *testing.T) {
+	board := engine.NewBoard()
+	move, _ := engine.ParseMove("g1f3")
+
+	tests := []struct {
+		notation string
+		figurine bool
+		language string
+		want     string
+	}{
+		{NotationSAN, false, LanguageEnglish, "Nf3"},
+		{NotationSAN, true, LanguageEnglish, "♘f3"},
+		{NotationLong, false, LanguageEnglish, "Ng1-f3"},
+		{NotationLong, true, LanguageEnglish, "♘g1-f3"},
+		{NotationCoordinate, true, LanguageEnglish, "g1f3"},
+		{"", false, "", "Nf3"},
+		{NotationSAN, false, LanguageGerman, "Sf3"},
+		{NotationLong, false, LanguageFrench, "Cg1-f3"},
+		{NotationSAN, true, LanguageGerman, "♘f3"},
+		{NotationCoordinate, false, LanguageGerman, "g1f3"},
+	}
+	for _, tt := range tests {
+		m := NewModel(DefaultConfig())
+		m.config.Notation = tt.notation
+		m.config.FigurineNotation = tt.figurine
+		m.config.Language = tt.language
+		if got := m.formatMove(board, move); got != tt.want {
+			t.Errorf("formatMove() with notation %q, figurine %v, language %q = %q, want %q",
+				tt.notation, tt.figurine, tt.language, got, tt.want)
+		}
+	}
+}
+
+// TestLocalizePieces tests translating piece letters between English and other languages.
+func TestLocalizePieces(t *testing.T) {
+	tests := []struct {
+		language, english, local string
+	}{
+		{LanguageGerman, "Nf3", "Sf3"},
+		{LanguageGerman, "Qxd8+", "Dxd8+"},
+		{LanguageGerman, "e8=Q#", "e8=D#"},
+		{LanguageFrench, "Kb1xa2+", "Rb1xa2+"},
+		{LanguageFrench, "Rg1-g8", "Tg1-g8"},
+		{LanguageSpanish, "Bb5", "Ab5"},
+		{LanguageSpanish, "O-O-O", "O-O-O"},
+		{LanguageEnglish, "Nf3", "Nf3"},
+		{"xx", "Nf3", "Nf3"},
+	}
+	for _, tt := range tests {
+		if got := LocalizePieces(tt.english, tt.language); got != tt.local {
+			t.Errorf("LocalizePieces(%q, %q) = %q, want %q", tt.english, tt.language, got, tt.local)
+		}
+		if got := EnglishPieces(tt.local, tt.language); got != tt.english {
+			t.Errorf("EnglishPieces(%q, %q) = %q, want %q", tt.local, tt.language, got, tt.english)
+		}
+	}
+
+	// Letters German does not use keep their English meaning
+	if got := EnglishPieces("Nf3", LanguageGerman); got != "Nf3" {
+		t.Errorf("EnglishPieces(Nf3, de) = %q, want Nf3", got)
+	}
+}
+
+// TestMoveHistoryUsesNotation tests that the move history is written in the chosen notation.
+func TestMoveHistoryUsesNotation(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.config.Notation = NotationLong
+	m.game = engine.NewGame()
+	for _, s := range []string{"e2e4", "e7e5", "g1f3"} {
+		move, _ := engine.ParseMove(s)
+		_ = m.game.MakeMove(move)
+	}
+
+	if got := m.formatMoveHistory(); got != "Move History: 1. e2-e4 e7-e5 2. Ng1-f3" {
+		t.Errorf("formatMoveHistory() = %q", got)
+	}
+}
+
+// TestCycleNotationSettings tests cycling through the coordinate styles, notations and languages.
+func TestCycleNotationSettings(t *testing.T) {
+	if got := cycleCoordinateStyle(CoordsOutside); got != CoordsInside {
+		t.Errorf("cycleCoordinateStyle(outside) = %q, want inside", got)
+	}
+	if got := cycleCoordinateStyle(CoordsInside); got != CoordsOutside {
+		t.Errorf("cycleCoordinateStyle(inside) = %q, want outside", got)
+	}
+
+	notation := NotationSAN
+	var seen []string
+	for i := 0; i < 3; i++ {
+		notation = cycleNotation(notation)
+		seen = append(seen, getNotationDisplayName(notation))
+	}
+	if got := strings.Join(seen, ","); got != "Long Algebraic,Coordinate,SAN" {
+		t.Errorf("cycled notations = %s", got)
+	}
+
+	language := LanguageEnglish
+	seen = nil
+	for i := 0; i < 4; i++ {
+		language = cycleLanguage(language)
+		seen = append(seen, getLanguageDisplayName(language))
+	}
+	if got := strings.Join(seen, ","); got != "Deutsch,Français,Español,English" {
+		t.Errorf("cycled languages = %s", got)
+	}
+}
+
+// TestBoardRenderer_InsideCoordinates tests drawing the coordinates on the edge squares.
+func TestBoardRenderer_InsideCoordinates(t *testing.T) {
+	board, err := engine.FromFEN("4k3/8/8/8/8/8/8/4K3 w - - 0 1")
+	if err != nil {
+		t.Fatalf("FromFEN failed: %v", err)
+	}
+	config := Config{ShowCoords: true, CoordinateStyle: CoordsInside}
+	lines := strings.Split(NewBoardRenderer(config).Render(board), "\n")
+
+	if len(lines) != 9 || lines[8] != "" {
+		t.Fatalf("Expected 8 ranks without a file label row, got:\n%s", strings.Join(lines, "\n"))
+	}
+	if lines[0] != "8 . . . k . . ." {
+		t.Errorf("Expected the rank number on a8, got %q", lines[0])
+	}
+	if lines[7] != "a b c d K f g h" {
+		t.Errorf("Expected file letters on the empty first-rank squares, got %q", lines[7])
+	}
+
+	// Clicks map to squares without the outside rank labels' offset
+	sq := squareFromMouse(0, boardStartY, config)
+	if sq == nil || sq.String() != "a8" {
+		t.Errorf("squareFromMouse() = %v, want a8", sq)
+	}
+}
@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Mgrdich/TermChess/internal/config"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// gameNote is a note taken during a game.
+type gameNote struct {
+	// ply is the number of moves played when the note was taken
+	ply  int
+	text string
+}
+
+// noteCommand is the command that adds a note, followed by its text.
+const noteCommand = "note"
+
+// parseNoteCommand returns the text of a "note <text>" command typed at the
+// move prompt, keeping its case, and whether input is such a command.
+func parseNoteCommand(input string) (string, bool) {
+	input = strings.TrimSpace(input)
+	fields := strings.Fields(input)
+	if len(fields) == 0 || !strings.EqualFold(fields[0], noteCommand) {
+		return "", false
+	}
+	return strings.TrimSpace(input[len(fields[0]):]), true
+}
+
+// typingNote reports whether the move prompt holds a note being typed, so
+// spaces and 'q' are part of its text.
+func (m Model) typingNote() bool {
+	_, ok := parseNoteCommand(m.input)
+	return ok
+}
+
+// handleNoteCommand adds text to the notes of the game, at the current move.
+func (m Model) handleNoteCommand(text string) (tea.Model, tea.Cmd) {
+	m.input = ""
+	if text == "" {
+		m.notify(SeverityError, "Type the note after 'note', e.g. note missed Nf5")
+		return m, nil
+	}
+	m.notes = append(m.notes, gameNote{ply: m.game.MoveCount(), text: text})
+	m.dismissToasts(SeverityError)
+	m.notify(SeverityInfo, fmt.Sprintf("Note added (%d)", len(m.notes)))
+	return m, nil
+}
+
+// noteTexts returns the text of each note, in the order they were taken.
+func (m Model) noteTexts() []string {
+	texts := make([]string, len(m.notes))
+	for i, n := range m.notes {
+		texts[i] = n.text
+	}
+	return texts
+}
+
+// noteComments groups the notes by the move they follow, as PGN comments.
+func (m Model) noteComments() map[int][]string {
+	if len(m.notes) == 0 {
+		return nil
+	}
+	comments := make(map[int][]string)
+	for _, n := range m.notes {
+		// Notes taken before a takeback stay with the last move still played
+		ply := min(n.ply, m.game.MoveCount())
+		comments[ply] = append(comments[ply], n.text)
+	}
+	return comments
+}
+
+// loadSavedNotes restores the notes kept with the saved game. They were taken
+// before the saved position, so they all come before the first move played
+// after resuming.
+func (m *Model) loadSavedNotes() {
+	texts, err := config.LoadGameNotes()
+	if err != nil {
+		m.notify(SeverityWarning, fmt.Sprintf("Failed to load notes: %v", err))
+		return
+	}
+	for _, text := range texts {
+		m.notes = append(m.notes, gameNote{text: text})
+	}
+}
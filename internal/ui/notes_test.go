@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/config"
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestParseNoteCommand(t *testing.T) {
+	tests := []struct {
+		input string
+		text  string
+		ok    bool
+	}{
+		{"note Queen trade?", "Queen trade?", true},
+		{"  NOTE   keep  spacing ", "keep  spacing", true},
+		{"note", "", true},
+		{"notebook", "", false},
+		{"Nf3", "", false},
+	}
+	for _, tt := range tests {
+		text, ok := parseNoteCommand(tt.input)
+		if text != tt.text || ok != tt.ok {
+			t.Errorf("parseNoteCommand(%q) = %q, %v, want %q, %v", tt.input, text, ok, tt.text, tt.ok)
+		}
+	}
+}
+
+func TestNoteCommand(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.game = engine.NewGame()
+	m.screen = ScreenGamePlay
+	m.gameType = GameTypePvP
+	m.beginGameRecord()
+
+	m = pressKeys(m, "e4", "enter")
+	m = pressKeys(m, "note quiet opening", "enter")
+	if m.screen != ScreenGamePlay {
+		t.Fatalf("Expected 'q' in a note not to open the save prompt, got screen %v", m.screen)
+	}
+	if len(m.notes) != 1 || m.notes[0] != (gameNote{ply: 1, text: "quiet opening"}) {
+		t.Fatalf("Expected the note after the first move, got %+v", m.notes)
+	}
+	if m.input != "" {
+		t.Errorf("Expected the prompt to be cleared, got %q", m.input)
+	}
+	if view := ansi.Strip(m.renderGamePlay()); !strings.Contains(view, "Notes (1): quiet opening") {
+		t.Error("Expected the gameplay screen to show the latest note")
+	}
+
+	m = pressKeys(m, "e5", "enter")
+	pgn, err := m.gamePGN(time.Now())
+	if err != nil {
+		t.Fatalf("gamePGN() error: %v", err)
+	}
+	if !strings.Contains(pgn, "1. e4 {quiet opening} 1... e5") {
+		t.Errorf("Expected the note as a PGN comment, got:\n%s", pgn)
+	}
+}
+
+func TestNotesSavedWithGame(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(DefaultConfig())
+	m.game = engine.NewGame()
+	m.screen = ScreenGamePlay
+	m.beginGameRecord()
+	m = pressKeys(m, "note Plan: castle long", "enter")
+
+	m.screen = ScreenSavePrompt
+	m.savePromptSelection = 0
+	result, _ := m.handleSavePromptKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if m.screen != ScreenMainMenu {
+		t.Fatalf("Expected the game to be saved, got screen %v", m.screen)
+	}
+
+	result, _ = m.resumeSavedGame()
+	m = result.(Model)
+	if len(m.notes) != 1 || m.notes[0].text != "Plan: castle long" {
+		t.Errorf("Expected the note to be restored with the game, got %+v", m.notes)
+	}
+
+	if err := config.DeleteSaveGame(); err != nil {
+		t.Fatalf("DeleteSaveGame() error: %v", err)
+	}
+}
@@ -0,0 +1,24 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/Mgrdich/TermChess/internal/util"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// turnNotificationCmd returns a command that sends a desktop notification saying the
+// bot played san and it's the user's turn, or nil if turn notifications are disabled.
+// Notification failures are ignored; the game continues either way.
+func (m Model) turnNotificationCmd(san string) tea.Cmd {
+	if !m.config.TurnNotifications {
+		return nil
+	}
+	message := fmt.Sprintf("Bot played %s - your move", san)
+	out := m.terminal()
+	return func() tea.Msg {
+		// Written through the program's output so it can't split a frame
+		_ = util.Notify(out, "TermChess", message)
+		return nil
+	}
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// botToMove returns a Player vs Bot game after 1. e4, waiting for the bot's reply.
+func botToMove(t *testing.T, notifications bool) Model {
+	t.Helper()
+	cfg := DefaultConfig()
+	cfg.TurnNotifications = notifications
+	m := NewModel(cfg)
+	m.screen = ScreenGamePlay
+	m.gameType = GameTypePvBot
+	m.userColor = engine.White
+	m.game = engine.NewGame()
+	playMoves(t, &m, "e2e4")
+	return m
+}
+
+// notifyModel returns the Player vs Bot game of foolsMateOpening on the game
+// screen, waiting for the bot's reply, with turn notifications on or off.
+func notifyModel(t *testing.T, notifications bool) Model {
+	t.Helper()
+	m := foolsMateOpening(t)
+	m.config.TurnNotifications = notifications
+	m.screen = ScreenGamePlay
+	return m
+}
+
+func TestTurnNotificationAfterBotMove(t *testing.T) {
+	reply, _ := engine.ParseMove("b8c6")
+
+	m := notifyModel(t, true)
+	result, cmd := m.handleBotMove(BotMoveMsg{move: reply})
+	m = result.(Model)
+	if m.game.MoveCount() != 4 {
+		t.Fatalf("Expected the bot move to be played, history = %v", m.game.Moves())
+	}
+	if cmd == nil {
+		t.Error("Expected a notification command when turn notifications are on")
+	}
+
+	// The bot move also starts flashing its squares, so check the notification on its own
+	m = notifyModel(t, false)
+	if cmd := m.turnNotificationCmd("Nc6"); cmd != nil {
+		t.Error("Expected no notification when turn notifications are off")
+	}
+}
+
+func TestNoTurnNotificationWhenGameEnds(t *testing.T) {
+	m := notifyModel(t, true)
+
+	mate, _ := engine.ParseMove("d8h4")
+	result, cmd := m.handleBotMove(BotMoveMsg{move: mate})
+	m = result.(Model)
+	m.finishMateReveal()
+	if m.screen != ScreenGameOver {
+		t.Fatalf("Expected game over after mate, got %v", m.screen)
+	}
+	if cmd != nil {
+		t.Error("Expected no turn notification once the game is over")
+	}
+}
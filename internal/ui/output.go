@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"io"
+	"os"
+	"sync"
+)
+
+// TerminalOutput is the program's output to the terminal. Bubble Tea writes its
+// frames to it from the renderer's goroutine while commands write escape
+// sequences of their own, such as desktop notifications, so every write holds a
+// lock and a sequence can never land in the middle of a frame.
+//
+// It is a term.File, so Bubble Tea still recognizes the terminal behind it.
+type TerminalOutput struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewTerminalOutput returns the output for writing to the terminal file, normally os.Stdout.
+func NewTerminalOutput(file *os.File) *TerminalOutput {
+	return &TerminalOutput{file: file}
+}
+
+// Write writes p in one piece, after any write in progress.
+func (o *TerminalOutput) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.file.Write(p)
+}
+
+// WriteString is like Write, for the renderer's io.WriteString calls.
+func (o *TerminalOutput) WriteString(s string) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.file.WriteString(s)
+}
+
+// Read reads from the terminal file.
+func (o *TerminalOutput) Read(p []byte) (int, error) {
+	return o.file.Read(p)
+}
+
+// Close closes the terminal file.
+func (o *TerminalOutput) Close() error {
+	return o.file.Close()
+}
+
+// Fd returns the terminal file's descriptor.
+func (o *TerminalOutput) Fd() uintptr {
+	return o.file.Fd()
+}
+
+// WithOutput makes the model write the escape sequences it sends outside of
+// frames to out, which must also be the program's output (tea.WithOutput).
+func (m Model) WithOutput(out *TerminalOutput) Model {
+	m.output = out
+	return m
+}
+
+// terminal returns where the model writes escape sequences outside of frames:
+// the output set by WithOutput, or os.Stdout without one.
+func (m Model) terminal() io.Writer {
+	if m.output == nil {
+		return os.Stdout
+	}
+	return m.output
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestTerminalOutputKeepsWritesWhole(t *testing.T) {
+	file, err := os.Create(filepath.Join(t.TempDir(), "terminal"))
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	out := NewTerminalOutput(file)
+	defer out.Close()
+
+	// A frame and an escape sequence written at the same time come out one after
+	// the other, never mixed
+	frame := strings.Repeat("frame ", 10000)
+	sequence := "\x1b]777;notify;TermChess;Bot played e5 - your move\x07"
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, _ = out.Write([]byte(frame))
+		}()
+		go func() {
+			defer wg.Done()
+			_, _ = out.WriteString(sequence)
+		}()
+	}
+	wg.Wait()
+
+	data, err := os.ReadFile(file.Name())
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	rest := string(data)
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, frame):
+			rest = rest[len(frame):]
+		case strings.HasPrefix(rest, sequence):
+			rest = rest[len(sequence):]
+		default:
+			t.Fatalf("Expected whole frames and sequences, got a mix at %q", rest[:min(len(rest), 40)])
+		}
+	}
+	if out.Fd() != file.Fd() {
+		t.Error("Expected the output to report the terminal's file descriptor")
+	}
+}
+
+func TestModelWritesToItsOutput(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	if m.terminal() != os.Stdout {
+		t.Error("Expected escape sequences to go to stdout without an output")
+	}
+	out := NewTerminalOutput(os.Stdout)
+	if m = m.WithOutput(out); m.terminal() != out {
+		t.Error("Expected escape sequences to go through the program's output")
+	}
+}
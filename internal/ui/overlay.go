@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// overlayBoxStyle returns the style of the box drawn around modal overlays.
+func (m Model) overlayBoxStyle() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.theme.MenuSelected).
+		Padding(1, 2)
+}
+
+// renderOverlay draws content in a modal box centered over background, which is
+// dimmed so the box stands out while the screen behind it stays visible.
+func (m Model) renderOverlay(background, content string) string {
+	return placeOverlay(background, m.overlayBoxStyle().Render(content), m.termWidth, m.termHeight, m.dimStyle())
+}
+
+// dimStyle returns the style of the screen behind a modal overlay.
+func (m Model) dimStyle() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Foreground(m.theme.HelpText).
+		Faint(true)
+}
+
+// placeOverlay composites foreground centered over background. The canvas is at
+// least width x height (the terminal size, 0 if unknown) and grows to fit both
+// layers. The background loses its own colors and is drawn with dim, so only
+// the foreground keeps its styling.
+func placeOverlay(background, foreground string, width, height int, dim lipgloss.Style) string {
+	bgLines := strings.Split(ansi.Strip(background), "\n")
+	fgLines := strings.Split(foreground, "\n")
+	fgWidth := lipgloss.Width(foreground)
+
+	width = max(width, fgWidth, lipgloss.Width(strings.Join(bgLines, "\n")))
+	height = max(height, len(fgLines), len(bgLines))
+	x := (width - fgWidth) / 2
+	y := (height - len(fgLines)) / 2
+
+	var b strings.Builder
+	for row := 0; row < height; row++ {
+		if row > 0 {
+			b.WriteString("\n")
+		}
+		line := ""
+		if row < len(bgLines) {
+			line = bgLines[row]
+		}
+		line += strings.Repeat(" ", max(0, width-ansi.StringWidth(line)))
+
+		if row < y || row >= y+len(fgLines) {
+			b.WriteString(dim.Render(line))
+			continue
+		}
+
+		// Cut the background around the foreground line
+		fgLine := fgLines[row-y]
+		fgLine += strings.Repeat(" ", max(0, fgWidth-ansi.StringWidth(fgLine)))
+		left := ansi.Truncate(line, x, "")
+		left += strings.Repeat(" ", max(0, x-ansi.StringWidth(left)))
+		right := ansi.TruncateLeft(line, x+fgWidth, "")
+
+		b.WriteString(dim.Render(left))
+		b.WriteString(fgLine)
+		b.WriteString(dim.Render(right))
+	}
+	return b.String()
+}
@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/bvb"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestPlaceOverlayCentersOverBackground(t *testing.T) {
+	background := strings.Join([]string{
+		"aaaaaaaaaa",
+		"bbbbbbbbbb",
+		"cccccccccc",
+	}, "\n")
+	got := placeOverlay(background, "XY", 0, 0, lipgloss.NewStyle())
+
+	want := strings.Join([]string{
+		"aaaaaaaaaa",
+		"bbbbXYbbbb",
+		"cccccccccc",
+	}, "\n")
+	if got != want {
+		t.Errorf("placeOverlay() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestPlaceOverlayGrowsToTerminal(t *testing.T) {
+	got := placeOverlay("ab", "X", 5, 3, lipgloss.NewStyle())
+	lines := strings.Split(got, "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines, got %d:\n%s", len(lines), got)
+	}
+	if lines[0] != "ab   " || lines[1] != "  X  " {
+		t.Errorf("placeOverlay() =\n%q", lines)
+	}
+}
+
+func TestPlaceOverlayStripsBackgroundStyles(t *testing.T) {
+	background := "\x1b[31mred text\x1b[0m"
+	got := placeOverlay(background, "X", 0, 0, lipgloss.NewStyle())
+	if strings.Contains(got, "\x1b[31m") {
+		t.Errorf("Expected the background colors to be dropped, got %q", got)
+	}
+}
+
+func TestShortcutsOverlayKeepsScreenVisible(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.termWidth = 120
+	m.termHeight = 40
+	m.showShortcutsOverlay = true
+
+	view := m.View()
+	if !strings.Contains(view, "Keyboard Shortcuts") {
+		t.Error("Expected the shortcuts overlay")
+	}
+	if !strings.Contains(view, "TermChess") || !strings.Contains(view, "New Game") {
+		t.Errorf("Expected the main menu to stay visible behind the overlay, got:\n%s", view)
+	}
+}
+
+func TestBvBAbortConfirmOverlaysGames(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.termWidth = 120
+	m.termHeight = 40
+	m.screen = ScreenBvBGamePlay
+	m.bvbViewMode = BvBSingleView
+	m.bvbManager = bvb.NewSessionManager(0, 0, "Easy Bot", "Easy Bot", 1, 1)
+	if err := m.bvbManager.RunHeadless(context.Background(), nil); err != nil {
+		t.Fatalf("RunHeadless() error = %v", err)
+	}
+	m.bvbShowAbortConfirm = true
+
+	view := m.View()
+	if !strings.Contains(view, "Abort Session?") {
+		t.Error("Expected the abort dialog")
+	}
+	if !strings.Contains(view, "Easy Bot") {
+		t.Errorf("Expected the game to stay visible behind the dialog, got:\n%s", view)
+	}
+}
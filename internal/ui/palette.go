@@ -0,0 +1,387 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/Mgrdich/TermChess/internal/config"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxPaletteResults is the number of matching commands shown at once.
+const maxPaletteResults = 10
+
+// paletteCommand is an action that can be run from the command palette.
+type paletteCommand struct {
+	name string
+	// hint is the shortcut or a short explanation shown next to the name
+	hint string
+	run  func(m Model) (tea.Model, tea.Cmd)
+}
+
+// paletteCommands returns the commands available on the current screen.
+func (m Model) paletteCommands() []paletteCommand {
+	var cmds []paletteCommand
+	add := func(name, hint string, run func(m Model) (tea.Model, tea.Cmd)) {
+		cmds = append(cmds, paletteCommand{name: name, hint: hint, run: run})
+	}
+
+	switch m.screen {
+	case ScreenGamePlay:
+		if m.game != nil {
+			add("Show FEN", "showfen", Model.handleShowFenCommand)
+			add("Copy Diagram", diagramCommand, func(m Model) (tea.Model, tea.Cmd) {
+				return m.handleDiagramCommand(true)
+			})
+			add("Offer Draw", "offerdraw", Model.handleOfferDrawCommand)
+			if m.game.Board().CanClaimDraw() {
+				add("Claim Draw", "claimdraw", Model.handleClaimDrawCommand)
+			}
+			if m.trainingMode {
+				add("Take Back Move", "takeback", Model.handleTakebackCommand)
+			}
+			add("Resign", "resign", Model.handleResignCommand)
+			add("Return to Menu", "menu", Model.handleMenuCommand)
+		}
+
+	case ScreenGameOver:
+		if m.canRematch() {
+			add("Rematch", m.keys.Label(ActionRematch), Model.rematch)
+		}
+		add("Analyze Game", m.keys.Label(ActionAnalyze), Model.startAnalysis)
+		add("Copy Diagram", "", func(m Model) (tea.Model, tea.Cmd) {
+			return m.handleDiagramCommand(true)
+		})
+
+	case ScreenBvBGamePlay:
+		if m.bvbManager != nil {
+			pause := "Pause Games"
+			if m.bvbPaused {
+				pause = "Resume Games"
+			}
+			add(pause, m.keys.Label(ActionToggle), func(m Model) (tea.Model, tea.Cmd) {
+				m.toggleBvBPause()
+				return m, nil
+			})
+			add("Toggle Speed", m.keys.Label(ActionToggleSpeed), func(m Model) (tea.Model, tea.Cmd) {
+				m.toggleBvBSpeed()
+				return m, nil
+			})
+			add("Change View", m.keys.Label(ActionToggleView), func(m Model) (tea.Model, tea.Cmd) {
+				m.cycleBvBViewMode()
+				return m, nil
+			})
+			add("Copy Game FEN", m.keys.Label(ActionCopyFEN), func(m Model) (tea.Model, tea.Cmd) {
+				m.copyBvBFEN()
+				return m, nil
+			})
+			follow := "Follow Running Games"
+			if m.bvbFollow {
+				follow = "Stop Following Games"
+			}
+			add(follow, m.keys.Label(ActionFollowGames), func(m Model) (tea.Model, tea.Cmd) {
+				m.toggleBvBFollow()
+				return m, nil
+			})
+			add("Bookmark Game", m.keys.Label(ActionBookmark), func(m Model) (tea.Model, tea.Cmd) {
+				m.toggleBvBBookmark()
+				return m, nil
+			})
+			add("Next Notable Game", m.keys.Label(ActionNextNotable), func(m Model) (tea.Model, tea.Cmd) {
+				if s := m.focusedBvBSession(); s != nil {
+					m.openNextNotableBvBGame(s.GameNumber())
+				}
+				return m, nil
+			})
+			add("Show Session Log", m.keys.Label(ActionShowLog), func(m Model) (tea.Model, tea.Cmd) {
+				m.openBvBLog()
+				return m, nil
+			})
+			if m.bvbViewMode == BvBGridView {
+				add("Filter Games", m.keys.Label(ActionFilterGames), func(m Model) (tea.Model, tea.Cmd) {
+					m.cycleBvBGridFilter()
+					return m, nil
+				})
+			}
+			if m.bvbGameCount > 1 {
+				for n := 1; n <= m.bvbGameCount; n++ {
+					gameNum := n
+					add(fmt.Sprintf("Jump to Game %d", gameNum), "", func(m Model) (tea.Model, tea.Cmd) {
+						m.bvbJumpInput = fmt.Sprintf("%d", gameNum)
+						m.handleBvBJumpSubmit()
+						return m, nil
+					})
+				}
+			}
+		}
+	}
+
+	if m.canStartNewGame() {
+		add("New Game", m.keys.Label(ActionNewGame), func(m Model) (tea.Model, tea.Cmd) {
+			m.openNewGame()
+			return m, nil
+		})
+	}
+	if m.screen != ScreenSettings && m.screen != ScreenKeyBindings {
+		add("Settings", m.keys.Label(ActionSettings), func(m Model) (tea.Model, tea.Cmd) {
+			m.openSettings()
+			return m, nil
+		})
+	}
+	if m.screen != ScreenKeyBindings {
+		add("Key Bindings", "Settings", func(m Model) (tea.Model, tea.Cmd) {
+			m.pushScreen(ScreenKeyBindings)
+			m.keyBindingSelection = 0
+			m.keyBindingCapture = false
+			return m, nil
+		})
+	}
+
+	add("Cycle Theme", "Settings", func(m Model) (tea.Model, tea.Cmd) {
+		m.config.Theme = cycleTheme(m.config.Theme)
+		m.theme = GetTheme(ParseThemeName(m.config.Theme))
+		return m.saveConfigFromPalette(fmt.Sprintf("Theme: %s", getThemeDisplayName(m.config.Theme)))
+	})
+	add("Toggle Unicode Pieces", "Settings", func(m Model) (tea.Model, tea.Cmd) {
+		m.config.UseUnicode = !m.config.UseUnicode
+		return m.saveConfigFromPalette("Unicode pieces " + onOff(m.config.UseUnicode))
+	})
+	add("Toggle Coordinates", "Settings", func(m Model) (tea.Model, tea.Cmd) {
+		m.config.ShowCoords = !m.config.ShowCoords
+		return m.saveConfigFromPalette("Coordinates " + onOff(m.config.ShowCoords))
+	})
+	add("Toggle Move History", "Settings", func(m Model) (tea.Model, tea.Cmd) {
+		m.config.ShowMoveHistory = !m.config.ShowMoveHistory
+		return m.saveConfigFromPalette("Move history " + onOff(m.config.ShowMoveHistory))
+	})
+	add("Toggle Turn Notifications", "Settings", func(m Model) (tea.Model, tea.Cmd) {
+		m.config.TurnNotifications = !m.config.TurnNotifications
+		return m.saveConfigFromPalette("Turn notifications " + onOff(m.config.TurnNotifications))
+	})
+	add("Keyboard Shortcuts", m.keys.Label(ActionHelp), func(m Model) (tea.Model, tea.Cmd) {
+		m.showShortcutsOverlay = true
+		m.scrollOffset = 0
+		return m, nil
+	})
+
+	if m.screen == ScreenGamePlay {
+		// Quitting a game goes through the save prompt, like the quit key
+		add("Quit", "save prompt", func(m Model) (tea.Model, tea.Cmd) {
+			m.screen = ScreenSavePrompt
+			m.savePromptSelection = 0
+			m.savePromptAction = "exit"
+			m.dismissToasts()
+			return m, nil
+		})
+	} else {
+		add("Quit", m.keys.Label(ActionQuit), Model.quit)
+	}
+
+	return cmds
+}
+
+// onOff formats a boolean setting for status messages.
+func onOff(enabled bool) string {
+	if enabled {
+		return "on"
+	}
+	return "off"
+}
+
+// saveConfigFromPalette persists a setting changed from the command palette.
+func (m Model) saveConfigFromPalette(status string) (tea.Model, tea.Cmd) {
+	if err := config.SaveConfig(m.config); err != nil {
+		m.notify(SeverityError, fmt.Sprintf("Failed to save settings: %v", err))
+		return m, nil
+	}
+	m.notify(SeverityInfo, status)
+	return m, nil
+}
+
+// filterPaletteCommands returns the commands matching the query, best matches first.
+// An empty query matches every command in its original order.
+func filterPaletteCommands(cmds []paletteCommand, query string) []paletteCommand {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return cmds
+	}
+
+	type scored struct {
+		cmd   paletteCommand
+		score int
+	}
+	var matches []scored
+	for _, cmd := range cmds {
+		if score, ok := fuzzyScore(query, cmd.name); ok {
+			matches = append(matches, scored{cmd, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	result := make([]paletteCommand, len(matches))
+	for i, s := range matches {
+		result[i] = s.cmd
+	}
+	return result
+}
+
+// fuzzyScore reports whether every character of query appears in text in order
+// (case-insensitively) and scores the match. Consecutive characters and characters
+// at the start of a word score higher, so "tm" prefers "Toggle Move History"
+// over "Cycle Theme".
+func fuzzyScore(query, text string) (int, bool) {
+	q := []rune(strings.ToLower(query))
+	t := []rune(strings.ToLower(text))
+
+	score := 0
+	qi := 0
+	prevMatch := -2
+	for ti := 0; ti < len(t) && qi < len(q); ti++ {
+		if t[ti] != q[qi] {
+			continue
+		}
+		score++
+		if ti == prevMatch+1 {
+			score += 3
+		}
+		if ti == 0 || unicode.IsSpace(t[ti-1]) {
+			score += 5
+		}
+		prevMatch = ti
+		qi++
+	}
+	if qi < len(q) {
+		return 0, false
+	}
+	// Prefer shorter names among equally good matches
+	return score*100 - len(t), true
+}
+
+// openCommandPalette opens the command palette with an empty query.
+func (m Model) openCommandPalette() Model {
+	m.showCommandPalette = true
+	m.paletteQuery = ""
+	m.paletteSelection = 0
+	return m
+}
+
+// handleCommandPaletteKeys handles keyboard input while the command palette is open.
+// Typing filters the commands, Up/Down move the selection, Enter runs the selected
+// command and ESC (or the palette key) closes the palette.
+func (m Model) handleCommandPaletteKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	matches := filterPaletteCommands(m.paletteCommands(), m.paletteQuery)
+
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.showCommandPalette = false
+		return m, nil
+
+	case tea.KeyEnter:
+		m.showCommandPalette = false
+		if m.paletteSelection >= len(matches) {
+			return m, nil
+		}
+		m.dismissToasts()
+		return matches[m.paletteSelection].run(m)
+
+	case tea.KeyUp:
+		if m.paletteSelection > 0 {
+			m.paletteSelection--
+		}
+		return m, nil
+
+	case tea.KeyDown:
+		if m.paletteSelection < len(matches)-1 && m.paletteSelection < maxPaletteResults-1 {
+			m.paletteSelection++
+		}
+		return m, nil
+
+	case tea.KeyBackspace:
+		if len(m.paletteQuery) > 0 {
+			runes := []rune(m.paletteQuery)
+			m.paletteQuery = string(runes[:len(runes)-1])
+			m.paletteSelection = 0
+		}
+		return m, nil
+
+	case tea.KeySpace:
+		m.paletteQuery += " "
+		m.paletteSelection = 0
+		return m, nil
+
+	case tea.KeyRunes:
+		m.paletteQuery += string(msg.Runes)
+		m.paletteSelection = 0
+		return m, nil
+	}
+
+	if m.keys.Matches(msg, ActionCommandPalette) {
+		m.showCommandPalette = false
+	}
+	return m, nil
+}
+
+// renderCommandPalette renders the command palette: the query being typed and
+// the best matching commands for the current screen.
+func (m Model) renderCommandPalette() string {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(m.theme.TitleText).
+		Padding(1, 0)
+	b.WriteString(titleStyle.Render("Command Palette"))
+	b.WriteString("\n")
+
+	queryStyle := lipgloss.NewStyle().
+		Foreground(m.theme.MenuSelected).
+		Bold(true)
+	b.WriteString(queryStyle.Render(fmt.Sprintf("> %s_", m.paletteQuery)))
+	b.WriteString("\n\n")
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(m.theme.HelpText).
+		Italic(true)
+
+	matches := filterPaletteCommands(m.paletteCommands(), m.paletteQuery)
+	if len(matches) == 0 {
+		b.WriteString(hintStyle.Render("No matching commands"))
+		b.WriteString("\n")
+	}
+	for i, cmd := range matches {
+		if i >= maxPaletteResults {
+			b.WriteString(hintStyle.Render(fmt.Sprintf("... %d more", len(matches)-maxPaletteResults)))
+			b.WriteString("\n")
+			break
+		}
+
+		cursor := "  "
+		name := fmt.Sprintf("%-24s", cmd.name)
+		if i == m.paletteSelection {
+			cursor = m.cursorStyle().Render(">> ")
+			name = m.selectedItemStyle().Render(name)
+		} else {
+			name = m.menuItemStyle().Render(name)
+		}
+		b.WriteString(cursor)
+		b.WriteString(name)
+		if cmd.hint != "" {
+			b.WriteString(" ")
+			b.WriteString(hintStyle.Render(cmd.hint))
+		}
+		b.WriteString("\n")
+	}
+
+	helpText := m.renderHelpText("type to filter | ↑/↓: select | enter: run | esc: close")
+	if helpText != "" {
+		b.WriteString("\n")
+		b.WriteString(helpText)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,173 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFuzzyScore(t *testing.T) {
+	tests := []struct {
+		query string
+		text  string
+		match bool
+	}{
+		{"set", "Settings", true},
+		{"stg", "Settings", true},
+		{"SETT", "Settings", true},
+		{"sx", "Settings", false},
+		{"jump 5", "Jump to Game 5", true},
+		{"", "Anything", true},
+	}
+	for _, tt := range tests {
+		if _, ok := fuzzyScore(tt.query, tt.text); ok != tt.match {
+			t.Errorf("fuzzyScore(%q, %q) match = %v, want %v", tt.query, tt.text, ok, tt.match)
+		}
+	}
+
+	// Word starts and consecutive characters rank higher
+	cmds := []paletteCommand{{name: "Cycle Theme"}, {name: "Toggle Move History"}}
+	got := filterPaletteCommands(cmds, "tm")
+	if len(got) != 2 || got[0].name != "Toggle Move History" {
+		t.Errorf("Expected 'Toggle Move History' first for \"tm\", got %v", got)
+	}
+
+	cmds = []paletteCommand{{name: "Jump to Game 15"}, {name: "Jump to Game 5"}}
+	got = filterPaletteCommands(cmds, "jump 5")
+	if len(got) != 2 || got[0].name != "Jump to Game 5" {
+		t.Errorf("Expected 'Jump to Game 5' first, got %v", got)
+	}
+}
+
+func TestCommandPaletteOpenFilterRun(t *testing.T) {
+	m := NewModel(DefaultConfig())
+
+	result, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlP})
+	m = result.(Model)
+	if !m.showCommandPalette {
+		t.Fatal("Expected ctrl+p to open the command palette")
+	}
+	view := m.View()
+	for _, want := range []string{"Command Palette", "New Game", "Settings", "Quit"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected palette to list %q", want)
+		}
+	}
+
+	// Typed keys filter instead of triggering shortcuts
+	m = pressKeys(m, "sett")
+	if m.screen != ScreenMainMenu {
+		t.Fatalf("Expected typing not to trigger shortcuts, got screen %v", m.screen)
+	}
+	if got := filterPaletteCommands(m.paletteCommands(), m.paletteQuery); len(got) == 0 || got[0].name != "Settings" {
+		t.Fatalf("Expected Settings as the best match, got %v", got)
+	}
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if m.showCommandPalette {
+		t.Error("Expected the palette to close after running a command")
+	}
+	if m.screen != ScreenSettings {
+		t.Errorf("Expected Settings screen, got %v", m.screen)
+	}
+}
+
+func TestCommandPaletteClose(t *testing.T) {
+	m := NewModel(DefaultConfig()).openCommandPalette()
+	m = pressKeys(m, "zzzz")
+	if !strings.Contains(m.View(), "No matching commands") {
+		t.Error("Expected empty result message")
+	}
+
+	// Enter with no match just closes
+	result, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if m.showCommandPalette || m.screen != ScreenMainMenu {
+		t.Error("Expected Enter without matches to close the palette")
+	}
+
+	m = m.openCommandPalette()
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	if m.showCommandPalette {
+		t.Error("Expected ESC to close the palette")
+	}
+}
+
+func TestCommandPaletteNavigation(t *testing.T) {
+	m := NewModel(DefaultConfig()).openCommandPalette()
+	first := filterPaletteCommands(m.paletteCommands(), "")[1].name
+
+	result, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyUp})
+	m = result.(Model)
+	if m.paletteSelection != 0 {
+		t.Errorf("Expected selection to stay at 0, got %d", m.paletteSelection)
+	}
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyDown})
+	m = result.(Model)
+	if m.paletteSelection != 1 {
+		t.Fatalf("Expected selection 1, got %d", m.paletteSelection)
+	}
+	if !strings.Contains(m.View(), first) {
+		t.Errorf("Expected %q in the palette", first)
+	}
+
+	// Typing resets the selection to the best match
+	m = pressKeys(m, "q")
+	if m.paletteSelection != 0 {
+		t.Errorf("Expected typing to reset selection, got %d", m.paletteSelection)
+	}
+}
+
+func TestCommandPaletteInGame(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenGamePlay
+	m.game = engine.NewGame()
+
+	// The palette key works while typing moves
+	result, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlP})
+	m = result.(Model)
+	if !m.showCommandPalette {
+		t.Fatal("Expected ctrl+p to open the palette during a game")
+	}
+
+	names := make(map[string]bool)
+	for _, cmd := range m.paletteCommands() {
+		names[cmd.name] = true
+	}
+	for _, want := range []string{"Resign", "Offer Draw", "Show FEN", "Quit"} {
+		if !names[want] {
+			t.Errorf("Expected %q during a game", want)
+		}
+	}
+	if names["New Game"] || names["Claim Draw"] {
+		t.Error("Expected New Game and Claim Draw to be unavailable")
+	}
+
+	// Quit during a game goes through the save prompt
+	m = pressKeys(m, "quit")
+	result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if cmd != nil || m.screen != ScreenSavePrompt {
+		t.Errorf("Expected save prompt, got screen %v", m.screen)
+	}
+}
+
+func TestCommandPaletteAnalyzeOnGameOver(t *testing.T) {
+	m := newFinishedGame(t, "e2e4")
+	m = m.openCommandPalette()
+	m = pressKeys(m, "analyze")
+
+	result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if m.screen != ScreenAnalysis {
+		t.Fatalf("Expected Analysis screen, got %v", m.screen)
+	}
+	if cmd == nil {
+		t.Error("Expected the analysis to start")
+	}
+	m.stopAnalysis()
+}
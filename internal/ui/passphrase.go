@@ -0,0 +1,178 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Mgrdich/TermChess/internal/config"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// WithPassphrasePrompt returns the model opening on the passphrase prompt, so
+// the encrypted saved game and history can be read and new ones encrypted.
+// The screen it would have opened on follows.
+func (m Model) WithPassphrasePrompt() Model {
+	m.openPassphrasePrompt(false)
+	return m
+}
+
+// EnteringPassphrase reports whether the passphrase is being typed, so input
+// recorders can leave it out.
+func (m Model) EnteringPassphrase() bool {
+	return m.screen == ScreenPassphrase
+}
+
+// openPassphrasePrompt navigates to the passphrase prompt. A new passphrase is
+// asked for twice; one for existing encrypted files is checked against them.
+// enabling turns Encrypt Saves on once the passphrase is entered.
+func (m *Model) openPassphrasePrompt(enabling bool) {
+	input := textinput.New()
+	input.Placeholder = "Passphrase"
+	input.EchoMode = textinput.EchoPassword
+	input.EchoCharacter = '*'
+	input.CharLimit = 128
+	input.Width = 40
+	input.Focus()
+
+	m.pushScreen(ScreenPassphrase)
+	m.passphraseInput = input
+	m.passphraseFirst = ""
+	m.passphraseNew = !config.EncryptedFilesExist()
+	m.passphraseEnabling = enabling
+	m.dismissToasts()
+}
+
+// handlePassphraseKeys handles keyboard input for the passphrase prompt. ESC
+// cancels turning encryption on, or quits at startup, since nothing could be
+// saved without the passphrase.
+func (m Model) handlePassphraseKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		if !m.passphraseEnabling {
+			return m.quit()
+		}
+		m.popScreen()
+		m.dismissToasts(SeverityInfo, SeverityWarning)
+		return m, nil
+
+	case "enter":
+		return m.submitPassphrase()
+	}
+
+	var cmd tea.Cmd
+	m.passphraseInput, cmd = m.passphraseInput.Update(msg)
+	return m, cmd
+}
+
+// submitPassphrase checks the passphrase typed and, if it is right, encrypts
+// the files with it from then on.
+func (m Model) submitPassphrase() (tea.Model, tea.Cmd) {
+	m.dismissToasts()
+	passphrase := m.passphraseInput.Value()
+	m.passphraseInput.SetValue("")
+	if passphrase == "" {
+		m.notify(SeverityError, "Enter a passphrase")
+		return m, nil
+	}
+
+	// A new passphrase is typed twice, as a typo would lock the files for good
+	if m.passphraseNew {
+		if m.passphraseFirst == "" {
+			m.passphraseFirst = passphrase
+			return m, nil
+		}
+		if passphrase != m.passphraseFirst {
+			m.passphraseFirst = ""
+			m.notify(SeverityError, "The passphrases don't match, try again")
+			return m, nil
+		}
+	}
+
+	codec, err := config.NewPassphraseCodec(passphrase)
+	if err == nil {
+		err = config.CheckCodec(codec)
+	}
+	if err != nil {
+		m.notify(SeverityError, err.Error())
+		return m, nil
+	}
+
+	if m.passphraseEnabling {
+		// The codec reads the files whether they are encrypted yet or not
+		if err := config.RecodeFiles(codec, codec); err != nil {
+			m.notify(SeverityError, fmt.Sprintf("Failed to encrypt saves: %v", err))
+			return m, nil
+		}
+		config.SetCodec(codec)
+		m.config.EncryptSaves = true
+		m.popScreen()
+		if err := config.SaveConfig(m.config); err != nil {
+			m.notify(SeverityError, fmt.Sprintf("Failed to save settings: %v", err))
+		} else {
+			m.notify(SeverityInfo, "Saves encrypted")
+		}
+		return m, nil
+	}
+
+	config.SetCodec(codec)
+	m.popScreen()
+	return m, nil
+}
+
+// turnOffEncryption decrypts the saved game and the history and stores them
+// as they are from then on.
+func (m Model) turnOffEncryption() (Model, error) {
+	if err := config.RecodeFiles(config.ActiveCodec(), config.PlainCodec{}); err != nil {
+		return m, err
+	}
+	config.SetCodec(nil)
+	m.config.EncryptSaves = false
+	return m, nil
+}
+
+// renderPassphrase renders the passphrase prompt.
+func (m Model) renderPassphrase() string {
+	var b strings.Builder
+
+	// Render the application title
+	title := m.titleStyle().Render("TermChess")
+	b.WriteString(title)
+	b.WriteString("\n")
+
+	// Render breadcrumb navigation
+	b.WriteString(m.renderBreadcrumb())
+
+	// Render screen header
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(m.theme.TitleText).
+		Padding(0, 0, 1, 0)
+	b.WriteString(headerStyle.Render("Encrypted Saves"))
+	b.WriteString("\n")
+
+	var instructions string
+	switch {
+	case !m.passphraseNew:
+		instructions = "Enter the passphrase of your saved game and game history:"
+	case m.passphraseFirst == "":
+		instructions = "Choose a passphrase to encrypt your saved game and game history.\nIt can't be recovered if you forget it:"
+	default:
+		instructions = "Enter the passphrase again:"
+	}
+	b.WriteString(instructions)
+	b.WriteString("\n\n")
+	b.WriteString(m.passphraseInput.View())
+	b.WriteString("\n\n")
+
+	help := "enter: confirm | ESC: quit"
+	if m.passphraseEnabling {
+		help = "enter: confirm | ESC: cancel"
+	}
+	if helpText := m.renderHelpText(help); helpText != "" {
+		b.WriteString(helpText)
+	}
+
+	return b.String()
+}
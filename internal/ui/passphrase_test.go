@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/config"
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// passphraseModel returns a model on the Settings screen with an empty home
+// directory, restoring the unencrypted codec after the test.
+func passphraseModel(t *testing.T) Model {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Cleanup(func() { config.SetCodec(nil) })
+
+	m := NewModel(DefaultConfig())
+	m.openSettings()
+	m.settingsSelection = 17
+	return m
+}
+
+func TestEncryptSavesFromSettings(t *testing.T) {
+	m := passphraseModel(t)
+	if err := config.SaveGame(engine.NewBoard()); err != nil {
+		t.Fatal(err)
+	}
+
+	m = pressKeys(m, "enter")
+	if m.screen != ScreenPassphrase || !m.passphraseNew {
+		t.Fatalf("Expected the prompt for a new passphrase, got screen %v", m.screen)
+	}
+	if view := ansi.Strip(m.View()); strings.Contains(view, "hunter2") {
+		t.Error("Expected the passphrase hidden")
+	}
+
+	// A typo in the repeated passphrase starts over
+	m = pressKeys(m, "hunter2", "enter", "hunter3", "enter")
+	if m.screen != ScreenPassphrase || m.passphraseFirst != "" {
+		t.Fatal("Expected mismatched passphrases to be asked for again")
+	}
+	if got := lastToast(m, SeverityError); !strings.Contains(got, "don't match") {
+		t.Errorf("Expected a mismatch error, got %q", got)
+	}
+
+	m = pressKeys(m, "hunter2", "enter", "hunter2", "enter")
+	if m.screen != ScreenSettings || !m.config.EncryptSaves {
+		t.Fatalf("Expected Encrypt Saves on and back on Settings, got screen %v", m.screen)
+	}
+	if !config.LoadConfig().EncryptSaves {
+		t.Error("Expected the setting saved")
+	}
+	savePath, _ := config.SaveGamePath()
+	if data, _ := os.ReadFile(savePath); !config.IsEncrypted(data) {
+		t.Error("Expected the existing saved game encrypted")
+	}
+	if _, err := config.LoadGame(); err != nil {
+		t.Errorf("LoadGame() with the passphrase error: %v", err)
+	}
+
+	// Turning it off decrypts the files
+	m = pressKeys(m, "enter")
+	if m.config.EncryptSaves || config.EncryptedFilesExist() {
+		t.Error("Expected Encrypt Saves off and the files decrypted")
+	}
+}
+
+func TestPassphrasePromptAtStartup(t *testing.T) {
+	m := passphraseModel(t)
+	codec, err := config.NewPassphraseCodec("open sesame")
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.SetCodec(codec)
+	if err := config.SaveGame(engine.NewBoard()); err != nil {
+		t.Fatal(err)
+	}
+	config.SetCodec(nil)
+
+	m = NewModel(DefaultConfig()).WithPassphrasePrompt()
+	if m.screen != ScreenPassphrase || m.passphraseNew {
+		t.Fatalf("Expected the prompt for the existing passphrase, got screen %v", m.screen)
+	}
+
+	m = pressKeys(m, "open sesame?", "enter")
+	if m.screen != ScreenPassphrase || !strings.Contains(lastToast(m, SeverityError), "wrong passphrase") {
+		t.Fatal("Expected the wrong passphrase rejected")
+	}
+	m = pressKeys(m, "open sesame", "enter")
+	if m.screen != ScreenMainMenu {
+		t.Fatalf("Expected the main menu once unlocked, got screen %v", m.screen)
+	}
+	if _, err := config.LoadGame(); err != nil {
+		t.Errorf("LoadGame() once unlocked error: %v", err)
+	}
+
+	// ESC at startup quits, as nothing could be saved
+	m = NewModel(DefaultConfig()).WithPassphrasePrompt()
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc}); cmd == nil {
+		t.Error("Expected ESC to quit")
+	}
+}
+
+func TestPassphraseKeptOutOfLogs(t *testing.T) {
+	m := passphraseModel(t).WithDebug()
+	m = m.WithPassphrasePrompt()
+	m = pressKeys(m, "swordfish")
+	for _, entry := range m.debug.entries {
+		if entry.kind == "tea.KeyMsg" && entry.text != "tea.KeyMsg *" {
+			t.Errorf("Expected the passphrase masked in the debug log, got %q", entry.text)
+		}
+	}
+	if len(m.crash.messages) == 0 {
+		t.Fatal("Expected the keys in the crash log")
+	}
+	for _, msg := range m.crash.messages {
+		if strings.HasPrefix(msg, "key") && msg != `key "*"` {
+			t.Errorf("Expected the passphrase masked in the crash log, got %q", msg)
+		}
+	}
+	if !m.EnteringPassphrase() {
+		t.Error("Expected the passphrase being entered")
+	}
+}
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// cleanPaste prepares text pasted with bracketed paste for the current text
+// input. On the FEN input screen a pasted PGN game becomes the FEN of its final
+// position, on Game History a pasted PGN game is kept whole to be imported,
+// and a pasted passphrase keeps its spaces.
+// Otherwise line breaks and runs of whitespace become single spaces, since
+// every text input holds a single line, and surrounding whitespace is dropped.
+func (m Model) cleanPaste(text string) string {
+	if m.screen == ScreenFENInput && !m.showCommandPalette {
+		if fen, ok := pastedPGNPosition(text); ok {
+			return fen
+		}
+	}
+	if m.screen == ScreenPassphrase {
+		// Spaces are part of a passphrase
+		return strings.TrimRight(text, "\r\n")
+	}
+	if m.screen == ScreenGameHistory && !m.historyEditingTags && !m.showCommandPalette && strings.Contains(text, "[") {
+		return strings.TrimSpace(text)
+	}
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// pastedPGNPosition returns the FEN of the position a pasted PGN game ends in.
+// A FEN has neither tag pairs nor move numbers, so text without '[' or '.' is
+// never taken for PGN.
+func pastedPGNPosition(text string) (string, bool) {
+	if !strings.ContainsAny(text, "[.") {
+		return "", false
+	}
+	game, err := ParsePGN(strings.ReplaceAll(text, "\r\n", "\n"))
+	if err != nil {
+		return "", false
+	}
+	board, err := game.Board()
+	if err != nil {
+		return "", false
+	}
+	return board.ToFEN(), true
+}
+
+// handlePaste filters a bracketed paste before it reaches the key handlers.
+// Text pasted outside a text input is dropped rather than read as shortcut
+// keys, and text pasted into one is cleaned up by cleanPaste. It reports
+// whether the paste should still be handled as typed text.
+func (m Model) handlePaste(msg tea.KeyMsg) (tea.KeyMsg, bool) {
+	if !m.showCommandPalette && !m.isInTextInputMode() {
+		return msg, false
+	}
+	msg.Runes = []rune(m.cleanPaste(string(msg.Runes)))
+	return msg, len(msg.Runes) > 0
+}
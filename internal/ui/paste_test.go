@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// paste sends text to m as a bracketed paste.
+func paste(t *testing.T, m Model, text string) Model {
+	t.Helper()
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text), Paste: true})
+	return result.(Model)
+}
+
+func TestPasteFEN(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenFENInput
+	m.fenInput.Focus()
+
+	// Line breaks and stray whitespace from the clipboard are dropped
+	fen := "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1"
+	m = paste(t, m, "  rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR\r\nb  KQkq e3 0 1\n")
+	if got := m.fenInput.Value(); got != fen {
+		t.Errorf("FEN input after paste = %q, want %q", got, fen)
+	}
+	if m.screen != ScreenFENInput {
+		t.Errorf("Expected to stay on the FEN input screen, got %v", m.screen)
+	}
+}
+
+func TestPastePGN(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenFENInput
+	m.fenInput.Focus()
+
+	pgn := "[Event \"Casual\"]\r\n[White \"A\"]\r\n[Black \"B\"]\r\n\r\n1. e4 e5 2. Nf3 *\r\n"
+	m = paste(t, m, pgn)
+
+	board := engine.NewBoard()
+	for _, s := range []string{"e2e4", "e7e5", "g1f3"} {
+		move, _ := engine.ParseMove(s)
+		if err := board.MakeMove(move); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, want := m.fenInput.Value(), board.ToFEN(); got != want {
+		t.Errorf("FEN input after pasting a PGN = %q, want %q", got, want)
+	}
+}
+
+func TestPasteMove(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.game = engine.NewGame()
+	m.screen = ScreenGamePlay
+
+	// A pasted 'q' is part of the move text, not the quit shortcut
+	m = paste(t, m, "Nf3\n")
+	if m.input != "Nf3" || m.screen != ScreenGamePlay {
+		t.Errorf("input = %q on screen %v, want Nf3 on the game screen", m.input, m.screen)
+	}
+	m.input = ""
+	m = paste(t, m, "q")
+	if m.input != "q" || m.screen != ScreenGamePlay {
+		t.Errorf("input = %q on screen %v, want q on the game screen", m.input, m.screen)
+	}
+}
+
+func TestPasteOutsideTextInput(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenMainMenu
+
+	result, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q"), Paste: true})
+	m = result.(Model)
+	if cmd != nil || m.screen != ScreenMainMenu {
+		t.Errorf("Expected a paste on the main menu to be ignored, got screen %v", m.screen)
+	}
+}
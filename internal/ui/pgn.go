@@ -0,0 +1,226 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// PGNGame is a game read from PGN: its tag pairs, starting position and moves.
+type PGNGame struct {
+	// Tags holds the tag pairs, e.g. Tags["White"].
+	Tags map[string]string
+	// StartFEN is the starting position (the FEN tag, or the standard position).
+	StartFEN string
+	// Moves contains the moves of the main line in order.
+	Moves []engine.Move
+	// Evals holds the evaluation after each move, in pawns from White's
+	// perspective, read from [%eval] comments. It is nil unless every move has one.
+	Evals []float64
+}
+
+// Board returns the position reached after playing all of the game's moves.
+func (g *PGNGame) Board() (*engine.Board, error) {
+	board, err := engine.FromFEN(g.StartFEN)
+	if err != nil {
+		return nil, err
+	}
+	for _, move := range g.Moves {
+		if err := board.MakeMove(move); err != nil {
+			return nil, err
+		}
+	}
+	return board, nil
+}
+
+// pgnTagPattern matches a tag pair such as [White "Magnus"].
+var pgnTagPattern = regexp.MustCompile(`^\[(\w+)\s+"(.*)"\]$`)
+
+// pgnEvalPattern matches an evaluation in a comment, in pawns ([%eval 0.25]) or
+// as a mate in so many moves ([%eval #-3]).
+var pgnEvalPattern = regexp.MustCompile(`\[%eval\s+(#?[-+]?[0-9.]+)`)
+
+// pgnEvalToken marks an evaluation among the tokens returned by pgnTokens.
+const pgnEvalToken = "%eval="
+
+// pgnMateScore is the evaluation, in pawns, a mate read from PGN stands for.
+const pgnMateScore = 10000.0
+
+// pgnEvalComment returns the comment storing an evaluation in pawns from White's
+// perspective, e.g. "[%eval 0.25]".
+func pgnEvalComment(score float64) string {
+	return fmt.Sprintf("[%%eval %.2f]", score)
+}
+
+// parsePGNEval parses the evaluation of an [%eval] comment. A mate counts as
+// pgnMateScore for the side that mates.
+func parsePGNEval(s string) (float64, error) {
+	if mate, ok := strings.CutPrefix(s, "#"); ok {
+		if strings.HasPrefix(mate, "-") {
+			return -pgnMateScore, nil
+		}
+		return pgnMateScore, nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// ParsePGN reads the first game of a PGN text. Comments, variations, move numbers,
+// annotations and the result are skipped; moves must be in SAN.
+func ParsePGN(text string) (*PGNGame, error) {
+	game := &PGNGame{Tags: make(map[string]string)}
+
+	var movetext strings.Builder
+	inMovetext := false
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") {
+			if inMovetext {
+				break // start of the next game
+			}
+			if match := pgnTagPattern.FindStringSubmatch(line); match != nil {
+				game.Tags[match[1]] = match[2]
+			}
+			continue
+		}
+		if line != "" {
+			inMovetext = true
+		}
+		movetext.WriteString(line)
+		movetext.WriteString("\n")
+	}
+
+	game.StartFEN = engine.NewBoard().ToFEN()
+	if fen, ok := game.Tags["FEN"]; ok {
+		game.StartFEN = fen
+	}
+	board, err := engine.FromFEN(game.StartFEN)
+	if err != nil {
+		return nil, fmt.Errorf("invalid FEN tag: %w", err)
+	}
+
+	// evals maps the number of moves played to the evaluation after them
+	evals := make(map[int]float64)
+	for _, token := range pgnTokens(movetext.String()) {
+		if eval, ok := strings.CutPrefix(token, pgnEvalToken); ok {
+			if score, err := parsePGNEval(eval); err == nil && len(game.Moves) > 0 {
+				evals[len(game.Moves)] = score
+			}
+			continue
+		}
+		san := strings.TrimRight(token, "!?")
+		move, err := engine.ParseSAN(board, san)
+		if err != nil {
+			return nil, fmt.Errorf("move %d (%s): %w", len(game.Moves)+1, token, err)
+		}
+		if err := board.MakeMove(move); err != nil {
+			return nil, fmt.Errorf("move %d (%s): %w", len(game.Moves)+1, token, err)
+		}
+		game.Moves = append(game.Moves, move)
+	}
+
+	if len(game.Moves) == 0 && len(game.Tags) == 0 {
+		return nil, fmt.Errorf("no PGN game found")
+	}
+	if len(game.Moves) > 0 && len(evals) == len(game.Moves) {
+		game.Evals = make([]float64, len(game.Moves))
+		for ply, score := range evals {
+			game.Evals[ply-1] = score
+		}
+	}
+	return game, nil
+}
+
+// pgnTokens splits PGN movetext into SAN moves, dropping comments ({...} and ;),
+// variations ((...)), move numbers, NAGs ($1) and game results. The evaluation
+// of a main line comment is kept as a pgnEvalToken token, e.g. "%eval=0.25".
+func pgnTokens(movetext string) []string {
+	var b, comment strings.Builder
+	depth := 0
+	inBrace, inLineComment := false, false
+	for _, r := range movetext {
+		switch {
+		case inLineComment:
+			if r == '\n' {
+				inLineComment = false
+				b.WriteRune(' ')
+			}
+		case inBrace:
+			if r != '}' {
+				comment.WriteRune(r)
+				break
+			}
+			inBrace = false
+			if match := pgnEvalPattern.FindStringSubmatch(comment.String()); match != nil && depth == 0 {
+				b.WriteString(" " + pgnEvalToken + match[1] + " ")
+			}
+		case r == '{':
+			inBrace = true
+			comment.Reset()
+		case r == ';':
+			inLineComment = true
+		case r == '(':
+			depth++
+		case r == ')':
+			if depth > 0 {
+				depth--
+			}
+		case depth > 0:
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	var tokens []string
+	for _, field := range strings.Fields(b.String()) {
+		// Drop move numbers, including ones attached to the move ("1.e4", "3...Nf6")
+		if i := strings.LastIndex(field, "."); i >= 0 && strings.Trim(field[:i+1], "0123456789.") == "" {
+			field = field[i+1:]
+		}
+		switch {
+		case field == "":
+		case strings.HasPrefix(field, "$"):
+		case field == "1-0", field == "0-1", field == "1/2-1/2", field == "*":
+		default:
+			tokens = append(tokens, field)
+		}
+	}
+	return tokens
+}
+
+// FormatPGNMovetext replays moves from the starting position and returns them
+// as numbered SAN movetext, e.g. "1. e4 e5 2. Nf3". A game starting with Black
+// to move begins with "1...".
+func FormatPGNMovetext(start *engine.Board, moves []engine.Move) (string, error) {
+	return formatPGNMovetextComments(start, moves, nil)
+}
+
+// formatPGNMovetextComments is FormatPGNMovetext with comments: comments[n] are
+// written as {...} after the nth move, comments[0] before the first one. A move
+// by Black after a comment gets its own "N..." number.
+func formatPGNMovetextComments(start *engine.Board, moves []engine.Move, comments map[int][]string) (string, error) {
+	board := start.Copy()
+	parts := make([]string, 0, len(moves)+len(moves)/2+1)
+	addComments := func(ply int) bool {
+		for _, c := range comments[ply] {
+			parts = append(parts, "{"+strings.NewReplacer("{", "(", "}", ")").Replace(c)+"}")
+		}
+		return len(comments[ply]) > 0
+	}
+	commented := addComments(0)
+	for i, move := range moves {
+		if board.ActiveColor == engine.White {
+			parts = append(parts, fmt.Sprintf("%d.", board.FullMoveNum))
+		} else if i == 0 || commented {
+			parts = append(parts, fmt.Sprintf("%d...", board.FullMoveNum))
+		}
+		parts = append(parts, engine.FormatSAN(board, move))
+		if err := board.MakeMove(move); err != nil {
+			return "", err
+		}
+		commented = addComments(i + 1)
+	}
+	return strings.Join(parts, " "), nil
+}
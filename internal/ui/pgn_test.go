@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+func TestParsePGN(t *testing.T) {
+	pgn := `[Event "Casual"]
+[White "Alice"]
+[Black "Bob"]
+[Result "*"]
+
+1. e4 {best by test} e5 2.Nf3 Nc6 (2... d6 3. d4) 3. Bb5 $1 a6!? ; Morphy
+4. Ba4 Nf6 5. O-O *
+
+[Event "Second game"]
+
+1. d4 d5 *
+`
+	game, err := ParsePGN(pgn)
+	if err != nil {
+		t.Fatalf("ParsePGN() error = %v", err)
+	}
+	if game.Tags["White"] != "Alice" || game.Tags["Black"] != "Bob" {
+		t.Errorf("Unexpected tags %v", game.Tags)
+	}
+	if len(game.Moves) != 9 {
+		t.Fatalf("Expected 9 moves from the first game, got %d", len(game.Moves))
+	}
+	if got := game.Moves[8].String(); got != "e1g1" {
+		t.Errorf("Expected last move e1g1 (O-O), got %s", got)
+	}
+
+	board, err := game.Board()
+	if err != nil {
+		t.Fatalf("Board() error = %v", err)
+	}
+	if board.ActiveColor != engine.Black {
+		t.Error("Expected Black to move after 5. O-O")
+	}
+}
+
+func TestParsePGNEvals(t *testing.T) {
+	game, err := ParsePGN("1. f3 { [%eval -0.45] } 1... e5 {[%eval -0.3]} 2. g4 {[%eval -9.9] a blunder} ( 2. e4 {[%eval 0.1]} ) 2... Qh4# {[%eval #-1]} 0-1")
+	if err != nil {
+		t.Fatalf("ParsePGN() error = %v", err)
+	}
+	want := []float64{-0.45, -0.3, -9.9, -pgnMateScore}
+	if len(game.Evals) != len(want) {
+		t.Fatalf("Evals = %v, want %v", game.Evals, want)
+	}
+	for i, score := range want {
+		if game.Evals[i] != score {
+			t.Errorf("Evals[%d] = %v, want %v", i, game.Evals[i], score)
+		}
+	}
+
+	// Evaluations of only some moves are left out
+	game, err = ParsePGN("1. e4 {[%eval 0.3]} e5 2. Nf3 *")
+	if err != nil {
+		t.Fatalf("ParsePGN() error = %v", err)
+	}
+	if game.Evals != nil {
+		t.Errorf("Evals = %v, want none for a partly evaluated game", game.Evals)
+	}
+}
+
+func TestParsePGNWithFENTag(t *testing.T) {
+	pgn := `[FEN "4k3/8/8/8/8/8/4P3/4K3 b - - 0 1"]
+
+1... Kd7 2. e4 *`
+	game, err := ParsePGN(pgn)
+	if err != nil {
+		t.Fatalf("ParsePGN() error = %v", err)
+	}
+	if len(game.Moves) != 2 {
+		t.Fatalf("Expected 2 moves, got %d", len(game.Moves))
+	}
+
+	start, _ := engine.FromFEN(game.StartFEN)
+	movetext, err := FormatPGNMovetext(start, game.Moves)
+	if err != nil {
+		t.Fatalf("FormatPGNMovetext() error = %v", err)
+	}
+	if movetext != "1... Kd7 2. e4" {
+		t.Errorf("FormatPGNMovetext() = %q, want %q", movetext, "1... Kd7 2. e4")
+	}
+}
+
+func TestParsePGNErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		pgn     string
+		wantErr string
+	}{
+		{"empty", "", "no PGN game"},
+		{"illegal move", "1. e4 e5 2. Ke3", "move 3"},
+		{"bad FEN tag", "[FEN \"not a fen\"]\n\n1. e4", "invalid FEN"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePGN(tt.pgn)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParsePGN() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFormatPGNMovetextRoundTrip(t *testing.T) {
+	game, err := ParsePGN("1. e4 e5 2. Nf3 Nc6 3. Bc4 Nf6 4. Ng5 d5 5. exd5 Nxd5 6. Nxf7")
+	if err != nil {
+		t.Fatalf("ParsePGN() error = %v", err)
+	}
+	movetext, err := FormatPGNMovetext(engine.NewBoard(), game.Moves)
+	if err != nil {
+		t.Fatalf("FormatPGNMovetext() error = %v", err)
+	}
+	want := "1. e4 e5 2. Nf3 Nc6 3. Bc4 Nf6 4. Ng5 d5 5. exd5 Nxd5 6. Nxf7"
+	if movetext != want {
+		t.Errorf("FormatPGNMovetext() = %q, want %q", movetext, want)
+	}
+}
@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// queuePremove validates the typed move as a pre-move and stores it until the bot
+// has replied. A new pre-move replaces the previous one.
+func (m Model) queuePremove() (tea.Model, tea.Cmd) {
+	move, err := parsePremove(m.game.Board(), EnglishPieces(m.input, m.config.Language), m.userColor)
+	if err != nil {
+		m.notify(SeverityError, fmt.Sprintf("Invalid pre-move: %v", err))
+		return m, nil
+	}
+
+	m.premove = &move
+	m.input = ""
+	m.dismissToasts(SeverityError)
+	return m, nil
+}
+
+// parsePremove parses a move for color while it is the opponent's turn.
+// SAN must name a legal move in the current position as if color were to move.
+// Coordinate notation (e.g. "e4d5") only needs one of color's pieces on the
+// starting square, so moves that depend on the opponent's reply, such as
+// recaptures, can be queued too. Full legality is checked when the move is played.
+func parsePremove(board *engine.Board, input string, color engine.Color) (engine.Move, error) {
+	turned := board.Copy()
+	turned.ActiveColor = color
+	turned.EnPassantSq = -1
+
+	move, sanErr := engine.ParseSAN(turned, input)
+	if sanErr == nil {
+		return move, nil
+	}
+
+	move, err := engine.ParseMove(input)
+	if err != nil {
+		return engine.Move{}, sanErr
+	}
+	piece := board.PieceAt(move.From)
+	if piece.IsEmpty() || piece.Color() != color {
+		return engine.Move{}, fmt.Errorf("no piece of yours on %s", move.From)
+	}
+	return move, nil
+}
+
+// playPremove plays the queued pre-move once the bot has replied with san.
+// If the reply made the pre-move illegal, it is dropped and the user is told
+// (and notified, like after any other bot move) that it's their turn.
+func (m Model) playPremove(san string) (tea.Model, tea.Cmd) {
+	move := *m.premove
+	m.premove = nil
+
+	if err := m.game.Board().Copy().MakeMove(move); err != nil {
+		m.notify(SeverityWarning, fmt.Sprintf("Pre-move %s cancelled: no longer legal after %s", move, san))
+		return m, m.turnNotificationCmd(san)
+	}
+	return m.playUserMove(move)
+}
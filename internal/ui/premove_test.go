@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// submitMove types text at the move prompt and presses Enter.
+func submitMove(m Model, text string) (Model, tea.Cmd) {
+	m.input = text
+	result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	return result.(Model), cmd
+}
+
+// botReplies delivers the bot's move in coordinate notation.
+func botReplies(t *testing.T, m Model, coord string) (Model, tea.Cmd) {
+	t.Helper()
+	move, err := engine.ParseMove(coord)
+	if err != nil {
+		t.Fatalf("ParseMove(%q) error = %v", coord, err)
+	}
+	result, cmd := m.handleBotMove(BotMoveMsg{move: move})
+	return result.(Model), cmd
+}
+
+func TestPremoveQueuedWhileBotThinks(t *testing.T) {
+	m := botToMove(t, false)
+	fenBefore := m.game.Board().ToFEN()
+
+	m, _ = submitMove(m, "Nf3")
+	if m.premove == nil || m.premove.String() != "g1f3" {
+		t.Fatalf("Expected g1f3 queued as pre-move, got %v", m.premove)
+	}
+	if m.game.Board().ToFEN() != fenBefore {
+		t.Error("Expected the board to be unchanged while the bot is to move")
+	}
+	if m.input != "" {
+		t.Errorf("Expected input to be cleared, got %q", m.input)
+	}
+	if !strings.Contains(m.View(), "Pre-move: g1f3") {
+		t.Error("Expected the pre-move to be shown")
+	}
+
+	// The pre-move is played right after the bot's reply and the bot is asked to move again
+	m, cmd := botReplies(t, m, "e7e5")
+	defer func() {
+		m.stopBotThinking()
+		if m.botEngine != nil {
+			_ = m.botEngine.Close()
+		}
+	}()
+	if m.premove != nil {
+		t.Error("Expected the pre-move to be consumed")
+	}
+	if m.game.MoveCount() != 3 || m.game.Moves()[2].String() != "g1f3" {
+		t.Fatalf("Expected e4 e5 Nf3 in history, got %v", m.game.Moves())
+	}
+	if !m.botThinking || cmd == nil {
+		t.Error("Expected the bot to start thinking after the pre-move")
+	}
+}
+
+func TestPremoveCancelledWhenIllegal(t *testing.T) {
+	// A recapture queued in coordinate notation only works if the bot captures first
+	m := botToMove(t, false)
+	m, _ = submitMove(m, "e4d5")
+	if m.premove == nil {
+		t.Fatalf("Expected e4d5 to be queued, error %q", toastError(m))
+	}
+
+	m, _ = botReplies(t, m, "e7e5")
+	if m.premove != nil {
+		t.Error("Expected the pre-move to be dropped")
+	}
+	if m.game.MoveCount() != 2 {
+		t.Errorf("Expected only e4 e5 in history, got %v", m.game.Moves())
+	}
+	if !strings.Contains(toastStatus(m), "cancelled") {
+		t.Errorf("Expected a cancelled message, got %q", toastStatus(m))
+	}
+	if m.game.Board().ActiveColor != engine.White {
+		t.Error("Expected the user to be on move")
+	}
+}
+
+func TestPremoveRecapture(t *testing.T) {
+	m := botToMove(t, false)
+	m, _ = submitMove(m, "e4d5")
+	m, _ = botReplies(t, m, "d7d5")
+	defer func() {
+		m.stopBotThinking()
+		if m.botEngine != nil {
+			_ = m.botEngine.Close()
+		}
+	}()
+	if m.game.MoveCount() != 3 || m.game.Moves()[2].String() != "e4d5" {
+		t.Errorf("Expected the recapture to be played, got %v", m.game.Moves())
+	}
+}
+
+func TestPremoveValidation(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"opponent's piece", "e7e5"},
+		{"empty square", "a3a4"},
+		{"impossible SAN", "Qd3"},
+		{"garbage", "xyz"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := botToMove(t, false)
+			m, _ = submitMove(m, tt.input)
+			if m.premove != nil {
+				t.Errorf("Expected %q to be rejected, queued %v", tt.input, m.premove)
+			}
+			if !strings.Contains(toastError(m), "Invalid pre-move") {
+				t.Errorf("Expected an invalid pre-move error, got %q", toastError(m))
+			}
+		})
+	}
+}
+
+func TestPremoveCancelWithBackspace(t *testing.T) {
+	m := botToMove(t, false)
+	m, _ = submitMove(m, "d4")
+	if m.premove == nil {
+		t.Fatal("Expected d4 to be queued")
+	}
+
+	result, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyBackspace})
+	m = result.(Model)
+	if m.premove != nil {
+		t.Error("Expected Backspace on an empty prompt to cancel the pre-move")
+	}
+
+	// A new game never inherits a pre-move
+	m, _ = submitMove(m, "d4")
+	m.beginGameRecord()
+	if m.premove != nil {
+		t.Error("Expected a new game to clear the pre-move")
+	}
+}
@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Mgrdich/TermChess/internal/config"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// defaultProfileLabel is the profile picker entry for config.toml.
+const defaultProfileLabel = "Default"
+
+// WithProfilePicker returns the model opening on the profile picker, so the
+// user chooses which of the saved profiles to use before the main menu.
+func (m Model) WithProfilePicker() Model {
+	m.screen = ScreenProfileSelect
+	m.navStack = nil
+	m.menuOptions = menuLabels(profileMenu())
+	m.menuSelection = 0
+	for i, label := range m.menuOptions {
+		if label == config.ActiveProfile() {
+			m.menuSelection = i
+		}
+	}
+	return m
+}
+
+// profileMenu declares the profile picker: the default configuration followed
+// by the saved profiles.
+func profileMenu() []MenuItem {
+	items := []MenuItem{{Label: defaultProfileLabel, Action: func(m Model) (tea.Model, tea.Cmd) {
+		return m.useProfile("")
+	}}}
+	names, _ := config.ListProfiles()
+	for _, name := range names {
+		items = append(items, MenuItem{Label: name, Action: func(m Model) (tea.Model, tea.Cmd) {
+			return m.useProfile(name)
+		}})
+	}
+	return items
+}
+
+// useProfile switches to the named profile, or to the default configuration
+// if name is empty, and continues to the main menu.
+func (m Model) useProfile(name string) (tea.Model, tea.Cmd) {
+	if err := config.SetProfile(name); err != nil {
+		m.notify(SeverityError, err.Error())
+		return m, nil
+	}
+	if err := m.applyConfig(LoadConfig()); err != nil {
+		m.notify(SeverityError, fmt.Sprintf("Invalid key bindings in config, using defaults: %v", err))
+	}
+
+	m.screen = ScreenMainMenu
+	m.navStack = nil
+	m.menuOptions = buildMainMenuOptions()
+	m.menuSelection = 0
+	if name != "" {
+		m.notify(SeverityInfo, fmt.Sprintf("Using profile %s", name))
+	}
+	return m, nil
+}
+
+// handleProfileSelectKeys handles keyboard input for the profile picker. ESC
+// keeps the current configuration and goes to the main menu.
+func (m Model) handleProfileSelectKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.dismissToasts()
+
+	if m.keys.Matches(msg, ActionBack) {
+		m.popScreen()
+		return m, nil
+	}
+
+	return m.updateMenu(msg)
+}
+
+// renderProfileSelect renders the profile picker.
+func (m Model) renderProfileSelect() string {
+	var b strings.Builder
+
+	// Render the application title
+	title := m.titleStyle().Render("TermChess")
+	b.WriteString(title)
+	b.WriteString("\n")
+
+	// Render breadcrumb navigation
+	b.WriteString(m.renderBreadcrumb())
+
+	// Render screen header
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(m.theme.TitleText).
+		Padding(0, 0, 1, 0)
+	header := headerStyle.Render("Choose a Profile:")
+	b.WriteString(header)
+	b.WriteString("\n")
+
+	b.WriteString(m.renderMenu(m.menuSelection))
+
+	// Render help text
+	helpText := m.renderHelpText("ESC: main menu | arrows/jk: navigate | enter: select | start with --profile <name> to create a profile")
+	if helpText != "" {
+		b.WriteString("\n")
+		b.WriteString(helpText)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/config"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// saveProfile saves cfg as the named profile in a temporary home directory,
+// leaving config.toml as the active configuration
+func saveProfile(t *testing.T, name string, cfg Config) {
+	t.Helper()
+	if err := config.SetProfile(name); err != nil {
+		t.Fatalf("SetProfile(%q) failed: %v", name, err)
+	}
+	t.Cleanup(func() { _ = config.SetProfile("") })
+	if err := config.SaveConfig(cfg); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+	if err := config.SetProfile(""); err != nil {
+		t.Fatalf("SetProfile failed: %v", err)
+	}
+}
+
+// TestProfilePickerListsProfiles tests that the picker offers the default configuration and the saved profiles
+func TestProfilePickerListsProfiles(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	saveProfile(t, "streaming", DefaultConfig())
+	saveProfile(t, "ssh", DefaultConfig())
+
+	m := NewModel(DefaultConfig()).WithProfilePicker()
+
+	if m.screen != ScreenProfileSelect {
+		t.Fatalf("Expected the profile picker, got screen %v", m.screen)
+	}
+	want := []string{defaultProfileLabel, "ssh", "streaming"}
+	if len(m.menuOptions) != len(want) {
+		t.Fatalf("Expected options %v, got %v", want, m.menuOptions)
+	}
+	for i, label := range want {
+		if m.menuOptions[i] != label {
+			t.Errorf("Option %d = %q, want %q", i, m.menuOptions[i], label)
+		}
+	}
+}
+
+// TestProfilePickerAppliesProfile tests that choosing a profile loads its settings and opens the main menu
+func TestProfilePickerAppliesProfile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cfg := DefaultConfig()
+	cfg.Theme = ThemeNameModern
+	saveProfile(t, "streaming", cfg)
+
+	m := NewModel(DefaultConfig()).WithProfilePicker()
+	m.menuSelection = 1
+	model, _ := m.handleProfileSelectKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = model.(Model)
+
+	if config.ActiveProfile() != "streaming" {
+		t.Errorf("Expected the streaming profile to be active, got %q", config.ActiveProfile())
+	}
+	if m.screen != ScreenMainMenu {
+		t.Errorf("Expected the main menu, got screen %v", m.screen)
+	}
+	if m.config.Theme != ThemeNameModern || m.theme.Name != ThemeNameModern {
+		t.Errorf("Expected the profile's modern theme, got config %q, theme %q", m.config.Theme, m.theme.Name)
+	}
+	if toastStatus(m) != "Using profile streaming" {
+		t.Errorf("Expected a status message naming the profile, got %q", toastStatus(m))
+	}
+}
+
+// TestProfilePickerEscape tests that ESC keeps the default configuration and opens the main menu
+func TestProfilePickerEscape(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	saveProfile(t, "streaming", DefaultConfig())
+
+	m := NewModel(DefaultConfig()).WithProfilePicker()
+	model, _ := m.handleProfileSelectKeys(tea.KeyMsg{Type: tea.KeyEsc})
+	m = model.(Model)
+
+	if m.screen != ScreenMainMenu {
+		t.Errorf("Expected the main menu, got screen %v", m.screen)
+	}
+	if config.ActiveProfile() != "" {
+		t.Errorf("Expected no active profile, got %q", config.ActiveProfile())
+	}
+}
+
+// TestPvBotPreselectsConfiguredDifficulty tests that the bot menu starts on the difficulty from the config
+func TestPvBotPreselectsConfiguredDifficulty(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.BotDifficulty = "hard"
+	m := NewModel(cfg)
+	m.openMenu(ScreenGameTypeSelect)
+	m.menuSelection = 1
+
+	model, _ := m.handleGameTypeSelectKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = model.(Model)
+
+	if m.screen != ScreenBotSelect {
+		t.Fatalf("Expected the bot selection screen, got %v", m.screen)
+	}
+	if m.menuSelection != int(BotHard) {
+		t.Errorf("Expected Hard to be selected, got option %d (%q)", m.menuSelection, m.menuOptions[m.menuSelection])
+	}
+}
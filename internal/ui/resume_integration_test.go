@@ -36,8 +36,8 @@ func TestResumeGameIntegrationFlow(t *testing.T) {
 	}
 
 	// Verify Resume Game is the first option
-	if len(model.menuOptions) != 5 {
-		t.Errorf("Expected 5 menu options with saved game, got %d", len(model.menuOptions))
+	if len(model.menuOptions) != 7 {
+		t.Errorf("Expected 7 menu options with saved game, got %d", len(model.menuOptions))
 	}
 
 	if model.menuOptions[0] != "Resume Game" {
@@ -75,22 +75,22 @@ func TestResumeGameIntegrationFlow(t *testing.T) {
 		t.Errorf("Expected screen to be ScreenGamePlay after selecting Resume Game, got %v", model.screen)
 	}
 
-	if model.board == nil {
+	if model.game == nil {
 		t.Fatal("Expected board to be loaded, got nil")
 	}
 
-	if model.board.ToFEN() != savedFEN {
+	if model.game.Board().ToFEN() != savedFEN {
 		t.Errorf("Loaded board FEN doesn't match saved FEN\nExpected: %s\nGot: %s",
-			savedFEN, model.board.ToFEN())
+			savedFEN, model.game.Board().ToFEN())
 	}
 
 	// Step 6: Simulate completing the game (game ends)
 	// Set up a checkmate position
 	checkmateBoard, _ := engine.FromFEN("r1bqkb1r/pppp1Qpp/2n2n2/4p3/2B1P3/8/PPPP1PPP/RNB1K1NR b KQkq - 0 4")
-	model.board = checkmateBoard
+	model.game = engine.NewGameFrom(checkmateBoard)
 
 	// Verify game is over
-	if !model.board.IsGameOver() {
+	if !model.game.Board().IsGameOver() {
 		t.Error("Expected game to be over")
 	}
 
@@ -109,8 +109,8 @@ func TestResumeGameIntegrationFlow(t *testing.T) {
 	}
 
 	// Verify no Resume Game option
-	if len(model2.menuOptions) != 4 {
-		t.Errorf("Expected 4 menu options without saved game, got %d", len(model2.menuOptions))
+	if len(model2.menuOptions) != 6 {
+		t.Errorf("Expected 6 menu options without saved game, got %d", len(model2.menuOptions))
 	}
 
 	for _, opt := range model2.menuOptions {
@@ -163,7 +163,7 @@ func TestResumeGameSelectNo(t *testing.T) {
 	}
 
 	// Board should still be nil
-	if model.board != nil {
+	if model.game != nil {
 		t.Error("Expected board to be nil after selecting New Game")
 	}
 
@@ -210,12 +210,12 @@ func TestResumeGameLoadError(t *testing.T) {
 	}
 
 	// Should have an error message
-	if model.errorMsg == "" {
+	if toastError(model) == "" {
 		t.Error("Expected error message after load failure")
 	}
 
 	// Board should still be nil
-	if model.board != nil {
+	if model.game != nil {
 		t.Error("Expected board to be nil after failed load")
 	}
 }
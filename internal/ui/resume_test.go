@@ -3,10 +3,12 @@ package ui
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/Mgrdich/TermChess/internal/config"
 	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 func TestResumeGameFunctionality(t *testing.T) {
@@ -46,8 +48,8 @@ func TestResumeGameFunctionality(t *testing.T) {
 	}
 
 	// Verify Resume Game is the first menu option
-	if len(model.menuOptions) != 5 {
-		t.Errorf("Expected 5 menu options with saved game, got %d", len(model.menuOptions))
+	if len(model.menuOptions) != 7 {
+		t.Errorf("Expected 7 menu options with saved game, got %d", len(model.menuOptions))
 	}
 
 	if model.menuOptions[0] != "Resume Game" {
@@ -134,3 +136,54 @@ func TestCorruptedFENHandling(t *testing.T) {
 	// Cleanup
 	_ = config.DeleteSaveGame()
 }
+
+func TestResumeBotGame(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(DefaultConfig())
+	m.gameType = GameTypePvBot
+	m.userColor = engine.White
+	m.botDifficulty = BotHard
+	m.game = engine.NewGame()
+	m.screen = ScreenGamePlay
+	move, _ := engine.ParseMove("e2e4")
+	_ = m.game.MakeMove(move)
+
+	m.screen = ScreenSavePrompt
+	m.savePromptSelection = 0
+	result, _ := m.handleSavePromptKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	if result.(Model).screen != ScreenMainMenu {
+		t.Fatalf("Expected the game to be saved, got screen %v", result.(Model).screen)
+	}
+
+	// A fresh start resumes against the same bot, which replies right away
+	result, cmd := NewModel(DefaultConfig()).resumeSavedGame()
+	m = result.(Model)
+	defer m.cleanupGame()
+	if m.gameType != GameTypePvBot || m.userColor != engine.White || m.botDifficulty != BotHard {
+		t.Errorf("Resumed game type %v, user color %v, difficulty %v; want PvBot, White, Hard",
+			m.gameType, m.userColor, m.botDifficulty)
+	}
+	if !m.botThinking || m.botEngine == nil || cmd == nil {
+		t.Error("Expected the bot to be created and start thinking on its turn")
+	}
+}
+
+func TestResumeBotGameWithUnavailableBot(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := config.SaveGame(engine.NewBoard()); err != nil {
+		t.Fatalf("SaveGame() error: %v", err)
+	}
+	info := config.SavedGameInfo{GameType: "pvbot", UserColor: "white", BotDifficulty: "medium", BotName: "gone"}
+	if err := config.SaveGameInfo(info); err != nil {
+		t.Fatalf("SaveGameInfo() error: %v", err)
+	}
+
+	result, _ := NewModel(DefaultConfig()).resumeSavedGame()
+	m := result.(Model)
+	if m.screen != ScreenGamePlay || m.botName != "" || m.botDifficulty != BotMedium {
+		t.Errorf("Expected the Medium Bot to replace the missing bot, got %q (%v)", m.botName, m.botDifficulty)
+	}
+	if !strings.Contains(lastToast(m, SeverityWarning), "not available") {
+		t.Error("Expected a warning that the bot is not available")
+	}
+}
@@ -22,9 +22,10 @@ func TestSaveGamePath(t *testing.T) {
 		t.Fatal("SaveGamePath returned empty string")
 	}
 
-	// Check that path contains .termchess directory
-	if !strings.Contains(path, ".termchess") {
-		t.Errorf("SaveGamePath %q does not contain .termchess", path)
+	// Check that path is in the data directory
+	dataDir, _ := config.GetDataDir()
+	if filepath.Dir(path) != dataDir {
+		t.Errorf("SaveGamePath %q is not in the data directory %q", path, dataDir)
 	}
 
 	// Check that path ends with savegame.fen
@@ -145,6 +146,8 @@ func TestLoadGameInvalidFEN(t *testing.T) {
 	path, _ := config.SaveGamePath()
 	saveDir := filepath.Dir(path)
 	os.MkdirAll(saveDir, 0755)
+	// With no backup to recover from
+	os.Remove(path + ".bak")
 
 	err := os.WriteFile(path, []byte("invalid fen string"), 0644)
 	if err != nil {
@@ -344,8 +347,8 @@ func TestNewModel_WithSavedGame(t *testing.T) {
 	}
 
 	// Verify "Resume Game" option is present in menu
-	if len(m.menuOptions) != 5 {
-		t.Errorf("Expected 5 menu options with saved game, got %d", len(m.menuOptions))
+	if len(m.menuOptions) != 7 {
+		t.Errorf("Expected 7 menu options with saved game, got %d", len(m.menuOptions))
 	}
 	if m.menuOptions[0] != "Resume Game" {
 		t.Errorf("Expected first option to be 'Resume Game', got '%s'", m.menuOptions[0])
@@ -390,7 +393,7 @@ func TestGameEnd_DeletesSavegame(t *testing.T) {
 
 	// Create model with the board
 	m := Model{
-		board:  board,
+		game:   engine.NewGameFrom(board),
 		screen: ScreenGamePlay,
 		config: DefaultConfig(),
 	}
@@ -405,11 +408,10 @@ func TestGameEnd_DeletesSavegame(t *testing.T) {
 
 		// Simulate entering the move
 		m.input = moveStr
-		m.board.MakeMove(move)
-		m.moveHistory = append(m.moveHistory, move)
+		m.game.MakeMove(move)
 
 		// Check if game is over
-		if m.board.IsGameOver() {
+		if m.game.Board().IsGameOver() {
 			// Delete savegame (simulating what happens in handleGamePlayKeys)
 			config.DeleteSaveGame()
 			m.screen = ScreenGameOver
@@ -469,7 +471,7 @@ func TestResumeGame_Integration(t *testing.T) {
 
 	// Phase 5: Select Resume Game from menu
 	m.menuSelection = 0
-	updatedModel, _ := m.handleMainMenuSelection()
+	updatedModel, _ := m.selectMenuItem()
 	m = updatedModel.(Model)
 
 	// Phase 6: Verify game state is restored
@@ -477,18 +479,18 @@ func TestResumeGame_Integration(t *testing.T) {
 		t.Errorf("After resuming, screen should be ScreenGamePlay, got %v", m.screen)
 	}
 
-	if m.board == nil {
+	if m.game == nil {
 		t.Fatal("Board should be loaded after resuming")
 	}
 
-	resumedFEN := m.board.ToFEN()
+	resumedFEN := m.game.Board().ToFEN()
 	if resumedFEN != originalFEN {
 		t.Errorf("Resumed game FEN mismatch.\nExpected: %s\nGot: %s", originalFEN, resumedFEN)
 	}
 
 	// Phase 7: Continue playing (make another move)
 	move, _ := engine.ParseMove("b8c6")
-	err = m.board.MakeMove(move)
+	err = m.game.MakeMove(move)
 	if err != nil {
 		t.Errorf("Failed to make move after resume: %v", err)
 	}
@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// minScrollHeight is the fewest lines worth scrolling content in: one line of
+// content would hardly be readable.
+const minScrollHeight = 3
+
+// scrolls reports whether content is too long for height lines, so it has to be
+// scrolled. Nothing scrolls before the terminal size is known.
+func (m Model) scrolls(content string, height int) bool {
+	return m.termHeight > 0 && height >= minScrollHeight && lipgloss.Height(content) > height
+}
+
+// scrollView returns a viewport showing content in height lines, scrolled to
+// the model's scroll offset.
+func (m Model) scrollView(content string, height int) viewport.Model {
+	vp := viewport.New(lipgloss.Width(content), height)
+	vp.SetContent(content)
+	vp.SetYOffset(m.scrollOffset)
+	return vp
+}
+
+// renderScrolled renders content in at most height lines. Content that doesn't
+// fit is shown in a viewport with a scroll indicator on its last line.
+func (m Model) renderScrolled(content string, height int) string {
+	trimmed := strings.TrimSuffix(content, "\n")
+	if !m.scrolls(trimmed, height) {
+		return content
+	}
+
+	// Leave the last line for the indicator
+	vp := m.scrollView(trimmed, height-1)
+	first := vp.YOffset + 1
+	last := vp.YOffset + vp.VisibleLineCount()
+	hint := fmt.Sprintf("%s/%s: scroll", m.keys.Label(ActionPageUp), m.keys.Label(ActionPageDown))
+	if m.showShortcutsOverlay || m.showBvBLog || m.screen == ScreenLearn {
+		hint = fmt.Sprintf("%s/%s, %s", m.keys.Label(ActionUp), m.keys.Label(ActionDown), hint)
+	}
+	indicatorStyle := lipgloss.NewStyle().
+		Foreground(m.theme.HelpText).
+		Italic(true)
+	indicator := fmt.Sprintf("Lines %d-%d of %d | %s", first, last, vp.TotalLineCount(), hint)
+	return vp.View() + "\n" + indicatorStyle.Render(indicator) + "\n"
+}
+
+// scrollContent applies a scroll key to content shown in height lines by
+// renderScrolled. Page up and down always scroll; with lines set, up and down
+// scroll a line at a time. It reports whether the key was used.
+func (m *Model) scrollContent(msg tea.KeyMsg, content string, height int, lines bool) bool {
+	content = strings.TrimSuffix(content, "\n")
+	if !m.scrolls(content, height) {
+		return false
+	}
+
+	vp := m.scrollView(content, height-1)
+	switch {
+	case m.keys.Matches(msg, ActionPageUp):
+		vp.PageUp()
+	case m.keys.Matches(msg, ActionPageDown):
+		vp.PageDown()
+	case lines && m.keys.Matches(msg, ActionUp):
+		vp.ScrollUp(1)
+	case lines && m.keys.Matches(msg, ActionDown):
+		vp.ScrollDown(1)
+	default:
+		return false
+	}
+	m.scrollOffset = vp.YOffset
+	return true
+}
@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func numberedLines(n int) string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = "line " + string(rune('a'+i))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func TestRenderScrolledFitsHeight(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	content := numberedLines(10)
+
+	if got := m.renderScrolled(content, 5); got != content {
+		t.Errorf("renderScrolled() before the terminal size is known = %q, want the content unchanged", got)
+	}
+
+	m.termHeight = 24
+	if got := m.renderScrolled(content, 10); got != content {
+		t.Errorf("renderScrolled() of fitting content = %q, want it unchanged", got)
+	}
+
+	out := ansi.Strip(m.renderScrolled(content, 5))
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("renderScrolled() = %q, want 5 lines", lines)
+	}
+	if !strings.HasPrefix(lines[0], "line a") || !strings.Contains(lines[4], "Lines 1-4 of 10") {
+		t.Errorf("renderScrolled() = %q, want the first lines and an indicator", lines)
+	}
+}
+
+func TestScrollContentKeys(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.termHeight = 24
+	content := numberedLines(10)
+
+	if !m.scrollContent(tea.KeyMsg{Type: tea.KeyPgDown}, content, 5, false) || m.scrollOffset != 4 {
+		t.Errorf("scrollOffset after pgdown = %d, want a page further", m.scrollOffset)
+	}
+	if m.scrollContent(tea.KeyMsg{Type: tea.KeyDown}, content, 5, false) {
+		t.Error("down scrolled content without line scrolling")
+	}
+	if !m.scrollContent(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")}, content, 5, true) || m.scrollOffset != 3 {
+		t.Errorf("scrollOffset after k = %d, want a line back", m.scrollOffset)
+	}
+	for i := 0; i < 5; i++ {
+		m.scrollContent(tea.KeyMsg{Type: tea.KeyPgDown}, content, 5, false)
+	}
+	if m.scrollOffset != 6 {
+		t.Errorf("scrollOffset after paging past the end = %d, want the last page", m.scrollOffset)
+	}
+	if out := ansi.Strip(m.renderScrolled(content, 5)); !strings.Contains(out, "Lines 7-10 of 10") {
+		t.Errorf("renderScrolled() = %q, want the last lines shown", out)
+	}
+}
+
+func TestShortcutsOverlayScrollsOnShortTerminal(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.termWidth = 100
+	m.termHeight = 30
+	result, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	m = result.(Model)
+
+	view := ansi.Strip(m.View())
+	if lines := strings.Count(view, "\n") + 1; lines > m.termHeight {
+		t.Errorf("view has %d lines, want at most %d", lines, m.termHeight)
+	}
+	if !strings.Contains(view, "Lines 1-") {
+		t.Errorf("view = %q, want a scroll indicator", view)
+	}
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m = result.(Model)
+	if !m.showShortcutsOverlay || m.scrollOffset != 1 {
+		t.Errorf("after j: overlay shown = %v, scrollOffset = %d, want scrolled by a line", m.showShortcutsOverlay, m.scrollOffset)
+	}
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	m = result.(Model)
+	if m.showShortcutsOverlay {
+		t.Error("overlay still shown after a key other than a scroll key")
+	}
+}
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"os"
+	"reflect"
 	"testing"
 
 	"github.com/Mgrdich/TermChess/internal/config"
@@ -118,7 +119,7 @@ func TestSettingsToggleAllOptions(t *testing.T) {
 
 	// Verify persistence
 	m2 := NewModel(LoadConfig())
-	if m2.config != m.config {
+	if !reflect.DeepEqual(m2.config, m.config) {
 		t.Errorf("Config after restart = %+v, want %+v", m2.config, m.config)
 	}
 }
@@ -141,18 +142,18 @@ func TestSettingsStatusMessages(t *testing.T) {
 	m = model.(Model)
 
 	// Verify success message is set
-	if m.statusMsg == "" {
+	if toastStatus(m) == "" {
 		t.Error("Expected status message to be set after toggling setting")
 	}
 
 	expectedMsg := "Setting saved successfully"
-	if m.statusMsg != expectedMsg {
-		t.Errorf("Status message = %q, want %q", m.statusMsg, expectedMsg)
+	if toastStatus(m) != expectedMsg {
+		t.Errorf("Status message = %q, want %q", toastStatus(m), expectedMsg)
 	}
 
 	// Verify error message is cleared
-	if m.errorMsg != "" {
-		t.Errorf("Expected error message to be empty, got %q", m.errorMsg)
+	if toastError(m) != "" {
+		t.Errorf("Expected error message to be empty, got %q", toastError(m))
 	}
 }
 
@@ -162,9 +163,9 @@ func TestSettingsNavigationAndReturn(t *testing.T) {
 
 	// Navigate from main menu to settings
 	m.screen = ScreenMainMenu
-	m.menuSelection = 2 // Settings option
+	m.menuSelection = 3 // Settings option
 
-	model, _ := m.handleMainMenuSelection()
+	model, _ := m.selectMenuItem()
 	m = model.(Model)
 
 	if m.screen != ScreenSettings {
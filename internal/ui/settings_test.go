@@ -1,11 +1,14 @@
 package ui
 
 import (
+	"errors"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Mgrdich/TermChess/internal/config"
+	"github.com/Mgrdich/TermChess/internal/version"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -36,21 +39,21 @@ func TestSettingsNavigation(t *testing.T) {
 		t.Errorf("Expected settingsSelection to be 1, got %d", m.settingsSelection)
 	}
 
-	// Test wrapping at bottom (should go from 5 to 0)
-	// Note: 6 settings total (5 toggles + 1 theme)
-	m.settingsSelection = 5
+	// Test wrapping at bottom (should go from 17 to 0)
+	// Note: 18 settings total (7 toggles + 7 value options + key bindings + 2 update options + encryption)
+	m.settingsSelection = 17
 	model, _ = m.handleSettingsKeys(tea.KeyMsg{Type: tea.KeyDown})
 	m = model.(Model)
 	if m.settingsSelection != 0 {
 		t.Errorf("Expected settingsSelection to wrap to 0, got %d", m.settingsSelection)
 	}
 
-	// Test wrapping at top (should go from 0 to 5)
+	// Test wrapping at top (should go from 0 to 17)
 	m.settingsSelection = 0
 	model, _ = m.handleSettingsKeys(tea.KeyMsg{Type: tea.KeyUp})
 	m = model.(Model)
-	if m.settingsSelection != 5 {
-		t.Errorf("Expected settingsSelection to wrap to 5, got %d", m.settingsSelection)
+	if m.settingsSelection != 17 {
+		t.Errorf("Expected settingsSelection to wrap to 17, got %d", m.settingsSelection)
 	}
 }
 
@@ -110,8 +113,26 @@ func TestSettingsToggle(t *testing.T) {
 		t.Errorf("Expected ShowHelpText to toggle from %v to %v", initialValue, !initialValue)
 	}
 
-	// Test cycling Theme (option 5)
+	// Test toggling TurnNotifications (option 5)
 	m.settingsSelection = 5
+	initialValue = m.config.TurnNotifications
+	model, _ = m.handleSettingsKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = model.(Model)
+	if m.config.TurnNotifications == initialValue {
+		t.Errorf("Expected TurnNotifications to toggle from %v to %v", initialValue, !initialValue)
+	}
+
+	// Test toggling HotSeatPrivacy (option 6)
+	m.settingsSelection = 6
+	initialValue = m.config.HotSeatPrivacy
+	model, _ = m.handleSettingsKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = model.(Model)
+	if m.config.HotSeatPrivacy == initialValue {
+		t.Errorf("Expected HotSeatPrivacy to toggle from %v to %v", initialValue, !initialValue)
+	}
+
+	// Test cycling Theme (option 7)
+	m.settingsSelection = 7
 	m.config.Theme = ThemeNameClassic
 	m.theme = GetTheme(ThemeClassic)
 	model, _ = m.handleSettingsKeys(tea.KeyMsg{Type: tea.KeyEnter})
@@ -122,6 +143,38 @@ func TestSettingsToggle(t *testing.T) {
 	if m.theme.Name != ThemeNameModern {
 		t.Errorf("Expected theme object to be updated to modern, got %s", m.theme.Name)
 	}
+
+	// Test cycling CoordinateStyle (option 8)
+	m.settingsSelection = 8
+	model, _ = m.handleSettingsKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = model.(Model)
+	if m.config.CoordinateStyle != CoordsInside {
+		t.Errorf("Expected CoordinateStyle to cycle from outside to inside, got %s", m.config.CoordinateStyle)
+	}
+
+	// Test cycling Notation (option 9)
+	m.settingsSelection = 9
+	model, _ = m.handleSettingsKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = model.(Model)
+	if m.config.Notation != NotationLong {
+		t.Errorf("Expected Notation to cycle from SAN to long algebraic, got %s", m.config.Notation)
+	}
+
+	// Test toggling FigurineNotation (option 10)
+	m.settingsSelection = 10
+	model, _ = m.handleSettingsKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = model.(Model)
+	if !m.config.FigurineNotation {
+		t.Error("Expected FigurineNotation to toggle on")
+	}
+
+	// Test cycling Language (option 11)
+	m.settingsSelection = 11
+	model, _ = m.handleSettingsKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = model.(Model)
+	if m.config.Language != LanguageGerman {
+		t.Errorf("Expected Language to cycle from English to German, got %s", m.config.Language)
+	}
 }
 
 // TestSettingsReturnToMenu tests that ESC/q/b/backspace return to main menu
@@ -153,9 +206,9 @@ func TestSettingsReturnToMenu(t *testing.T) {
 func TestMainMenuToSettings(t *testing.T) {
 	m := NewModel(DefaultConfig())
 	m.screen = ScreenMainMenu
-	m.menuSelection = 2 // "Settings" is the 3rd option (index 2)
+	m.menuSelection = 3 // "Settings" is the 4th option (index 3)
 
-	model, _ := m.handleMainMenuSelection()
+	model, _ := m.selectMenuItem()
 	m = model.(Model)
 
 	if m.screen != ScreenSettings {
@@ -185,10 +238,169 @@ func TestSettingsRender(t *testing.T) {
 	}
 
 	// Check that output contains expected strings
-	expectedStrings := []string{"Settings", "Use Unicode Pieces", "Show Coordinates", "Use Colors", "Show Move History", "Show Help Text", "Theme:"}
+	expectedStrings := []string{"Settings", "Use Unicode Pieces", "Show Coordinates", "Use Colors", "Show Move History", "Show Help Text", "Turn Notifications", "Hot-Seat Privacy Screen", "Theme:"}
 	for _, expected := range expectedStrings {
 		if !strings.Contains(output, expected) {
 			t.Errorf("Expected output to contain '%s'", expected)
 		}
 	}
 }
+
+// TestSettingsReloadConfig tests that 'r' on the settings screen, and a
+// ReloadConfigMsg anywhere, reload the config with its environment overrides
+func TestSettingsReloadConfig(t *testing.T) {
+	t.Setenv(config.EnvTheme, ThemeNameModern)
+
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenSettings
+	model, _ := m.handleSettingsKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	m = model.(Model)
+	if m.config.Theme != ThemeNameModern || m.theme.Name != ThemeNameModern {
+		t.Errorf("Expected the modern theme after reloading, got config %q, theme %q", m.config.Theme, m.theme.Name)
+	}
+	if toastStatus(m) != "Config reloaded" {
+		t.Errorf("Expected a reload status message, got %q", toastStatus(m))
+	}
+
+	// The reload key follows the key bindings
+	t.Setenv(config.EnvTheme, ThemeNameClassic)
+	keys, err := m.keys.WithBinding(ActionRefresh, []string{"ctrl+r"})
+	if err != nil {
+		t.Fatalf("WithBinding() error: %v", err)
+	}
+	m.keys = keys
+	model, _ = m.handleSettingsKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	if model.(Model).theme.Name != ThemeNameModern {
+		t.Error("Expected 'r' not to reload once unbound")
+	}
+	model, _ = m.handleSettingsKeys(tea.KeyMsg{Type: tea.KeyCtrlR})
+	if model.(Model).theme.Name != ThemeNameClassic {
+		t.Error("Expected ctrl+r to reload the config")
+	}
+
+	t.Setenv(config.EnvTheme, ThemeNameMinimalist)
+	m.screen = ScreenGamePlay
+	model, _ = m.Update(ReloadConfigMsg{})
+	m = model.(Model)
+	if m.theme.Name != ThemeNameMinimalist || m.screen != ScreenGamePlay {
+		t.Errorf("Expected the minimalist theme without leaving the game, got theme %q, screen %v", m.theme.Name, m.screen)
+	}
+}
+
+// TestSettingsBotMoveTime tests cycling the Bot Move Time setting, which is
+// saved and applied to the built-in bots
+func TestSettingsBotMoveTime(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenSettings
+	m.settingsSelection = 12
+	if !strings.Contains(m.renderSettings(), "Bot Move Time: Per Difficulty") {
+		t.Error("Expected the Bot Move Time setting to start at Per Difficulty")
+	}
+	if got := m.botConfig().MoveTime; got != 0 {
+		t.Errorf("Expected the bot to keep its budget without an override, got %v", got)
+	}
+
+	model, _ := m.handleSettingsKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = model.(Model)
+	if m.config.BotMoveTime != 100*time.Millisecond {
+		t.Errorf("BotMoveTime = %v, want 100ms", m.config.BotMoveTime)
+	}
+	if !strings.Contains(m.renderSettings(), "Bot Move Time: 100ms") {
+		t.Error("Expected the settings to show the new Bot Move Time")
+	}
+	if got := config.LoadConfig().BotMoveTime; got != 100*time.Millisecond {
+		t.Errorf("Saved BotMoveTime = %v, want 100ms", got)
+	}
+	if got := m.botConfig().MoveTime; got != 100*time.Millisecond {
+		t.Errorf("Expected the bot to think for the override, got %v", got)
+	}
+
+	// The values wrap around, and one set in the config file by hand starts over
+	for range botMoveTimes[1:] {
+		model, _ = m.handleSettingsKeys(tea.KeyMsg{Type: tea.KeyEnter})
+		m = model.(Model)
+	}
+	if m.config.BotMoveTime != 0 {
+		t.Errorf("BotMoveTime = %v after a full cycle, want Per Difficulty", m.config.BotMoveTime)
+	}
+	if got := cycleBotMoveTime(750 * time.Millisecond); got != 0 {
+		t.Errorf("cycleBotMoveTime(750ms) = %v, want Per Difficulty", got)
+	}
+}
+
+// TestSettingsUpdateChecks tests cycling the Update Checks setting and that no
+// update check is started when it is off or in offline mode
+func TestSettingsUpdateChecks(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	defer func(v string) { version.Version = v }(version.Version)
+	version.Version = "v1.0.0"
+
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenSettings
+	m.settingsSelection = 15
+	if !strings.Contains(m.renderSettings(), "Update Checks: Daily") {
+		t.Error("Expected the Update Checks setting to start at Daily")
+	}
+	if m.checkForUpdateCmd() == nil {
+		t.Error("Expected an update check on startup by default")
+	}
+
+	// Daily -> Weekly -> Off
+	for _, want := range []string{config.UpdateCheckWeekly, config.UpdateCheckOff} {
+		model, _ := m.handleSettingsKeys(tea.KeyMsg{Type: tea.KeyEnter})
+		m = model.(Model)
+		if m.config.UpdateCheck != want {
+			t.Fatalf("UpdateCheck = %q, want %q", m.config.UpdateCheck, want)
+		}
+	}
+	if got := config.LoadConfig().UpdateCheck; got != config.UpdateCheckOff {
+		t.Errorf("Saved UpdateCheck = %q, want off", got)
+	}
+	if m.checkForUpdateCmd() != nil {
+		t.Error("Expected no update check when update checks are off")
+	}
+
+	m.config.UpdateCheck = config.UpdateCheckStartup
+	m = m.WithOffline()
+	if m.checkForUpdateCmd() != nil {
+		t.Error("Expected no update check in offline mode")
+	}
+
+	// Asking to check while offline explains why nothing happens
+	m.settingsSelection = 16
+	model, cmd := m.handleSettingsKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = model.(Model)
+	if cmd != nil {
+		t.Error("Expected no update check command in offline mode")
+	}
+	if text := lastToast(m, SeverityWarning); !strings.Contains(text, "Offline") {
+		t.Errorf("Expected an offline warning, got %q", text)
+	}
+}
+
+// TestCheckForUpdatesResult tests how the result of the Check for Updates action is reported
+func TestCheckForUpdatesResult(t *testing.T) {
+	defer func(v string) { version.Version = v }(version.Version)
+	version.Version = "v1.0.0"
+	m := NewModel(DefaultConfig())
+
+	model, _ := m.Update(updateCheckResultMsg{latest: "v1.1.0"})
+	m = model.(Model)
+	if m.updateAvailable != "v1.1.0" || !strings.Contains(lastToast(m, SeverityInfo), "Update available: v1.1.0") {
+		t.Errorf("Expected the newer version to be reported, got %q", m.updateAvailable)
+	}
+
+	model, _ = m.Update(updateCheckResultMsg{latest: "v1.0.0"})
+	m = model.(Model)
+	if m.updateAvailable != "" || !strings.Contains(lastToast(m, SeverityInfo), "up to date") {
+		t.Errorf("Expected TermChess to be reported up to date, got %q", lastToast(m, SeverityInfo))
+	}
+
+	model, _ = m.Update(updateCheckResultMsg{err: errors.New("no network")})
+	m = model.(Model)
+	if !strings.Contains(lastToast(m, SeverityError), "no network") {
+		t.Errorf("Expected the error to be reported, got %q", lastToast(m, SeverityError))
+	}
+}
@@ -0,0 +1,321 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/bot"
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// simulBoardCounts lists the numbers of boards a simul can be played on. Boards
+// are switched with the number keys, so there are at most 9.
+var simulBoardCounts = []int{2, 3, 4, 6, 9}
+
+// simulGame holds the state of a simul game while another board is shown. The
+// board being played lives in the Model's own game fields, like any other game.
+type simulGame struct {
+	game             *engine.Game
+	notes            []gameNote
+	botEngine        bot.Engine
+	botThinking      bool
+	botCancel        context.CancelFunc
+	thinkingMsg      string
+	botHopelessTurns int
+	premove          *engine.Move
+	drawOfferedBy    int8
+	drawOfferedWhite bool
+	drawOfferedBlack bool
+	// turnElapsed is the time spent on the current turn before the board was left,
+	// so each board keeps its own clock
+	turnElapsed time.Duration
+}
+
+// inSimul reports whether a simul is being played.
+func (m Model) inSimul() bool {
+	return len(m.simulGames) > 0
+}
+
+// shownSimulGame returns the state of the shown game.
+func (m Model) shownSimulGame() simulGame {
+	return simulGame{
+		game:             m.game,
+		notes:            m.notes,
+		botEngine:        m.botEngine,
+		botThinking:      m.botThinking,
+		botCancel:        m.botCancel,
+		thinkingMsg:      m.thinkingMsg,
+		botHopelessTurns: m.botHopelessTurns,
+		premove:          m.premove,
+		drawOfferedBy:    m.drawOfferedBy,
+		drawOfferedWhite: m.drawOfferedByWhite,
+		drawOfferedBlack: m.drawOfferedByBlack,
+		turnElapsed:      time.Since(m.turnStartedAt),
+	}
+}
+
+// parkSimulGame stores the shown game in its slot.
+func (m *Model) parkSimulGame() {
+	m.simulGames[m.simulActive] = m.shownSimulGame()
+}
+
+// loadSimulGame shows game i, which must have been parked.
+func (m *Model) loadSimulGame(i int) {
+	g := m.simulGames[i]
+	m.simulActive = i
+	m.game = g.game
+	m.notes = g.notes
+	m.botEngine = g.botEngine
+	m.botThinking = g.botThinking
+	m.botCancel = g.botCancel
+	m.thinkingMsg = g.thinkingMsg
+	m.botHopelessTurns = g.botHopelessTurns
+	m.premove = g.premove
+	m.drawOfferedBy = g.drawOfferedBy
+	m.drawOfferedByWhite = g.drawOfferedWhite
+	m.drawOfferedByBlack = g.drawOfferedBlack
+	m.turnStartedAt = time.Now().Add(-g.turnElapsed)
+}
+
+// simulGameState returns the state of game i, whether it is shown or parked.
+func (m Model) simulGameState(i int) simulGame {
+	if i == m.simulActive {
+		return m.shownSimulGame()
+	}
+	return m.simulGames[i]
+}
+
+// finished reports whether the game has ended.
+func (g simulGame) finished() bool {
+	return g.game.Over()
+}
+
+// simulFinished reports whether every game of the simul has ended.
+func (m Model) simulFinished() bool {
+	for i := range m.simulGames {
+		if !m.simulGameState(i).finished() {
+			return false
+		}
+	}
+	return true
+}
+
+// simulBoardMenu declares the menu of the simul setup screen: the number of boards.
+func simulBoardMenu() []MenuItem {
+	items := make([]MenuItem, len(simulBoardCounts))
+	for i, n := range simulBoardCounts {
+		items[i] = MenuItem{Label: fmt.Sprintf("%d Boards", n), Action: func(m Model) (tea.Model, tea.Cmd) {
+			return m.startSimul(n)
+		}}
+	}
+	return items
+}
+
+// handleSimulSetupKeys handles keyboard input for the simul setup screen.
+func (m Model) handleSimulSetupKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.dismissToasts()
+	if m.keys.Matches(msg, ActionBack) {
+		m.popScreen()
+		return m, nil
+	}
+	return m.updateMenu(msg)
+}
+
+// startSimul starts n games of standard chess against the chosen bot, with the
+// user playing White on every board, and shows the first one.
+func (m Model) startSimul(n int) (tea.Model, tea.Cmd) {
+	m.endSimul()
+	if m.botEngine != nil {
+		_ = m.botEngine.Close()
+		m.botEngine = nil
+	}
+	m.gameType = GameTypePvBot
+	m.userColor = engine.White
+	m.match = matchScore{}
+
+	m.simulGames = make([]simulGame, n)
+	for i := range m.simulGames {
+		m.simulActive = i
+		m.game = engine.NewGameFrom(engine.NewBoard())
+		m.beginGameRecord()
+		m.botThinking = false
+		m.botCancel = nil
+		m.botHopelessTurns = 0
+		m.drawOfferedBy = -1
+		m.drawOfferedByWhite = false
+		m.drawOfferedByBlack = false
+		m.parkSimulGame()
+	}
+	m.loadSimulGame(0)
+
+	m.clearNavStack()
+	m.screen = ScreenGamePlay
+	m.input = ""
+	m.dismissToasts()
+	m.notify(SeverityInfo, fmt.Sprintf("Simul started on %d boards - press 1-%d to switch", n, n))
+	return m, nil
+}
+
+// switchSimulGame shows board i, leaving the current one as it is.
+func (m *Model) switchSimulGame(i int) {
+	if i < 0 || i >= len(m.simulGames) || i == m.simulActive {
+		return
+	}
+	m.parkSimulGame()
+	m.loadSimulGame(i)
+	m.input = ""
+	m.selectedSquare = nil
+	m.validMoves = nil
+	m.dismissToasts(SeverityError)
+}
+
+// simulBoardKey returns the board selected by a number key, and whether msg is one.
+// A pasted digit is text for the move prompt, not a number key.
+func (m Model) simulBoardKey(msg tea.KeyMsg) (int, bool) {
+	if !m.inSimul() || msg.Type != tea.KeyRunes || msg.Paste || len(msg.Runes) != 1 {
+		return 0, false
+	}
+	r := msg.Runes[0]
+	if r < '1' || r > '9' || int(r-'1') >= len(m.simulGames) {
+		return 0, false
+	}
+	return int(r - '1'), true
+}
+
+// endSimul stops the bots of the boards not shown and leaves simul mode. The
+// shown game is cleaned up like any other.
+func (m *Model) endSimul() {
+	for i, g := range m.simulGames {
+		if i == m.simulActive {
+			continue
+		}
+		if g.botCancel != nil {
+			g.botCancel()
+		}
+		if g.botEngine != nil {
+			_ = g.botEngine.Close()
+		}
+	}
+	m.simulGames = nil
+	m.simulActive = 0
+}
+
+// handleSimulBotMove applies a bot move, or bot error, that arrived for a board
+// that isn't shown, by showing it while handle runs.
+func (m Model) handleSimulBotMove(game int, handle func(Model) (tea.Model, tea.Cmd)) (tea.Model, tea.Cmd) {
+	shown := m.simulActive
+	screen := m.screen
+	flash := m.flash
+	m.parkSimulGame()
+	m.loadSimulGame(game)
+
+	result, cmd := handle(m)
+	m = result.(Model)
+	if !m.inSimul() {
+		return m, cmd
+	}
+	m.parkSimulGame()
+	m.loadSimulGame(shown)
+	// Moves on a board that isn't shown are not flashed on the shown one
+	m.flash = flash
+	// A finished background game doesn't interrupt the shown one
+	if m.screen != ScreenGameOver {
+		m.screen = screen
+	}
+	return m, cmd
+}
+
+// finishSimulGame announces the end of the shown simul game. It reports false
+// while other boards are still being played, so the game over screen waits.
+func (m *Model) finishSimulGame() bool {
+	if m.simulFinished() {
+		return true
+	}
+	m.notify(SeverityInfo, fmt.Sprintf("Board %d: %s", m.simulActive+1,
+		getGameResultMessage(m.game)))
+	return false
+}
+
+// simulScore returns the user's score over the finished games of the simul.
+func (m Model) simulScore() matchScore {
+	var score matchScore
+	for i := range m.simulGames {
+		g := m.simulGameState(i)
+		if g.finished() {
+			score.record(m.userColor, g.game.Result())
+		}
+	}
+	return score
+}
+
+// simulClock returns the time the user spent thinking on game g.
+func (m Model) simulClock(g simulGame) time.Duration {
+	total := g.game.TimeUsed(m.userColor)
+	if !g.finished() && g.game.Board().ActiveColor == m.userColor {
+		total += g.turnElapsed
+	}
+	return total
+}
+
+// simulBoardStatus describes game g for the simul board strip: whose move it
+// is, or its result, and the user's clock.
+func (m Model) simulBoardStatus(g simulGame) string {
+	state := "bot"
+	switch {
+	case g.finished():
+		state = g.game.Result()
+	case g.game.Board().ActiveColor == m.userColor:
+		state = "you"
+	}
+	clock := m.simulClock(g).Truncate(time.Second)
+	return fmt.Sprintf("%s %d:%02d", state, int(clock.Minutes()), int(clock.Seconds())%60)
+}
+
+// renderSimulBoards renders the strip of simul boards, e.g.
+// "[1 you 0:42]  2 bot 0:10  3 1-0 1:05", with the shown board in brackets.
+func (m Model) renderSimulBoards() string {
+	parts := make([]string, len(m.simulGames))
+	for i := range m.simulGames {
+		part := fmt.Sprintf("%d %s", i+1, m.simulBoardStatus(m.simulGameState(i)))
+		if i == m.simulActive {
+			part = lipgloss.NewStyle().Bold(true).Foreground(m.theme.MenuSelected).Render("[" + part + "]")
+		}
+		parts[i] = part
+	}
+	return "Simul: " + strings.Join(parts, "  ")
+}
+
+// simulStatus describes the score of a simul, e.g. "Simul score: You 2–1 Medium Bot".
+func (m Model) simulStatus() string {
+	return fmt.Sprintf("Simul score: %s %s %s", m.playerName(), m.simulScore(), m.matchBotName())
+}
+
+// renderSimulSetup renders the simul setup screen, where the number of boards is chosen.
+func (m Model) renderSimulSetup() string {
+	var b strings.Builder
+
+	b.WriteString(m.titleStyle().Render("TermChess"))
+	b.WriteString("\n")
+	b.WriteString(m.renderBreadcrumb())
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(m.theme.TitleText).
+		Padding(0, 0, 1, 0)
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Simul against %s - number of boards:", m.matchBotName())))
+	b.WriteString("\n")
+
+	b.WriteString(m.renderMenu(m.menuSelection))
+
+	helpText := m.renderHelpText("ESC: back to bot selection | arrows/jk: navigate | enter: start | you play White on every board")
+	if helpText != "" {
+		b.WriteString("\n\n")
+		b.WriteString(helpText)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// simulModel returns a model playing a simul on n boards against the easy bot.
+func simulModel(t *testing.T, n int) Model {
+	t.Helper()
+	m := NewModel(DefaultConfig())
+	m.botDifficulty = BotEasy
+	result, _ := m.startSimul(n)
+	return result.(Model)
+}
+
+// playSimulMove makes move s on the shown simul board as the bot would, without
+// starting a search.
+func playSimulMove(t *testing.T, m *Model, s string) {
+	t.Helper()
+	move, err := engine.ParseMove(s)
+	if err != nil {
+		t.Fatalf("ParseMove(%q) error: %v", s, err)
+	}
+	if err := m.game.MakeMove(move); err != nil {
+		t.Fatalf("MakeMove(%q) error: %v", s, err)
+	}
+}
+
+func TestStartSimul(t *testing.T) {
+	m := simulModel(t, 3)
+
+	if m.screen != ScreenGamePlay {
+		t.Fatalf("Expected ScreenGamePlay, got %v", m.screen)
+	}
+	if !m.inSimul() || len(m.simulGames) != 3 {
+		t.Fatalf("Expected a simul on 3 boards, got %d", len(m.simulGames))
+	}
+	if m.gameType != GameTypePvBot || m.userColor != engine.White {
+		t.Errorf("Expected the user to play White against the bot")
+	}
+	view := ansi.Strip(m.renderSimulBoards())
+	if !strings.Contains(view, "[1 you 0:00]") || !strings.Contains(view, "3 you") {
+		t.Errorf("Expected the board strip to show every board, got %q", view)
+	}
+}
+
+func TestChooseBotOpensSimulSetup(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	result, _ := m.openBotSelect(true)
+	m = result.(Model)
+
+	result, _ = m.chooseBot(BotMedium, "")
+	m = result.(Model)
+	if m.screen != ScreenSimulSetup {
+		t.Fatalf("Expected ScreenSimulSetup, got %v", m.screen)
+	}
+	if m.menuOptions[0] != "2 Boards" {
+		t.Errorf("Expected the board counts to be offered, got %v", m.menuOptions)
+	}
+}
+
+func TestSwitchSimulBoardKeepsState(t *testing.T) {
+	m := simulModel(t, 2)
+	playSimulMove(t, &m, "e2e4")
+
+	result, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}})
+	m = result.(Model)
+	if m.simulActive != 1 {
+		t.Fatalf("Expected '2' to show board 2, got board %d", m.simulActive+1)
+	}
+	if m.game.MoveCount() != 0 {
+		t.Errorf("Expected board 2 to have no moves, got %d", m.game.MoveCount())
+	}
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}})
+	m = result.(Model)
+	if m.simulActive != 0 || m.game.MoveCount() != 1 {
+		t.Errorf("Expected board 1 to keep its move, got board %d with %d moves", m.simulActive+1, m.game.MoveCount())
+	}
+}
+
+func TestSimulBackgroundBotMove(t *testing.T) {
+	m := simulModel(t, 2)
+	m.switchSimulGame(1)
+	playSimulMove(t, &m, "e2e4")
+	m.switchSimulGame(0)
+
+	reply, _ := engine.ParseMove("e7e5")
+	result, _ := m.handleBotMove(BotMoveMsg{move: reply, game: 1})
+	m = result.(Model)
+
+	if m.simulActive != 0 || m.game.MoveCount() != 0 {
+		t.Errorf("Expected board 1 to stay shown and untouched")
+	}
+	if got := m.simulGames[1].game.MoveCount(); got != 2 {
+		t.Errorf("Expected the reply on board 2, got %d moves", got)
+	}
+}
+
+func TestSimulFinishedBoardRejectsMoves(t *testing.T) {
+	m := simulModel(t, 2)
+	m.game.Resign(engine.White)
+
+	m.input = "e4"
+	result, _ := m.handleGamePlayInput()
+	m = result.(Model)
+	if m.game.MoveCount() != 0 {
+		t.Errorf("Expected no move on a finished board")
+	}
+	if !strings.Contains(toastError(m), "Board 1 is over") {
+		t.Errorf("Expected a notice that the board is over, got %q", toastError(m))
+	}
+}
+
+func TestSimulGameOverWaitsForLastBoard(t *testing.T) {
+	m := simulModel(t, 2)
+	m.game.Resign(engine.White)
+	m.showGameOver()
+	if m.screen != ScreenGamePlay {
+		t.Fatalf("Expected play to go on while board 2 is unfinished, got %v", m.screen)
+	}
+
+	m.switchSimulGame(1)
+	m.game.Resign(engine.White)
+	m.showGameOver()
+	if m.screen != ScreenGameOver {
+		t.Fatalf("Expected ScreenGameOver once every board is finished, got %v", m.screen)
+	}
+	if m.menuOptions[0] != "New Simul" {
+		t.Errorf("Expected New Simul first, got %v", m.menuOptions)
+	}
+	if got := m.simulScore().String(); got != "0–2" {
+		t.Errorf("Expected a simul score of 0–2, got %s", got)
+	}
+}
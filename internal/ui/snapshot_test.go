@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/exp/golden"
+	"github.com/muesli/termenv"
+)
+
+// Snapshots are rendered at a fixed terminal size.
+const (
+	snapshotWidth  = 100
+	snapshotHeight = 40
+)
+
+// snapshotModel returns the model snapshots start from: the default config, so
+// the Classic theme, at the snapshot size, with an empty home directory so no
+// saved game, profile or history shows up.
+func snapshotModel(t *testing.T) Model {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(DefaultConfig())
+	return pressKeys(m, tea.WindowSizeMsg{Width: snapshotWidth, Height: snapshotHeight})
+}
+
+// snapshotKeys maps the names of special keys pressKeys accepts to their types.
+var snapshotKeys = map[string]tea.KeyType{
+	"enter":  tea.KeyEnter,
+	"esc":    tea.KeyEsc,
+	"up":     tea.KeyUp,
+	"down":   tea.KeyDown,
+	"left":   tea.KeyLeft,
+	"right":  tea.KeyRight,
+	"tab":    tea.KeyTab,
+	"ctrl+p": tea.KeyCtrlP,
+}
+
+// pressKeys sends the messages in keys to m in order. Strings are names of
+// special keys, e.g. "enter", or else text typed one rune at a time. Commands
+// are not run, so bots never move and timers never fire.
+func pressKeys(m Model, keys ...any) Model {
+	for _, key := range keys {
+		var msgs []tea.Msg
+		switch key := key.(type) {
+		case string:
+			if typ, ok := snapshotKeys[key]; ok {
+				msgs = append(msgs, tea.KeyMsg{Type: typ})
+				break
+			}
+			for _, r := range key {
+				// A terminal reports the space bar as its own key
+				if r == ' ' {
+					msgs = append(msgs, tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{r}})
+					continue
+				}
+				msgs = append(msgs, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+			}
+		case tea.Msg:
+			msgs = append(msgs, key)
+		}
+		for _, msg := range msgs {
+			next, _ := m.Update(msg)
+			m = next.(Model)
+		}
+	}
+	return m
+}
+
+// TestSnapshots renders each screen in a fixed state and compares it with its
+// golden file in testdata/TestSnapshots, so changes to the views don't go
+// unnoticed. Regenerate the files after an intended change with:
+//
+//	go test ./internal/ui -run TestSnapshots -update
+func TestSnapshots(t *testing.T) {
+	// A fixed color profile, so the colors are part of the snapshots whatever the terminal
+	defer lipgloss.SetColorProfile(lipgloss.ColorProfile())
+	lipgloss.SetColorProfile(termenv.ANSI256)
+
+	tests := []struct {
+		name  string
+		setup func(t *testing.T) Model
+	}{
+		{"main_menu", func(t *testing.T) Model {
+			return snapshotModel(t)
+		}},
+		{"game_type_select", func(t *testing.T) Model {
+			return pressKeys(snapshotModel(t), "n")
+		}},
+		{"bot_select", func(t *testing.T) Model {
+			return pressKeys(snapshotModel(t), "n", "down", "enter")
+		}},
+		{"fen_input", func(t *testing.T) Model {
+			m := snapshotModel(t)
+			m.openMenu(ScreenFENInput)
+			return m
+		}},
+		{"gameplay_start", func(t *testing.T) Model {
+			return pressKeys(snapshotModel(t), "n", "enter")
+		}},
+		{"gameplay_moves", func(t *testing.T) Model {
+			return pressKeys(snapshotModel(t), "n", "enter", "e4", "enter", "e5", "enter", "Nf3", "enter", "Nc")
+		}},
+		{"gameplay_invalid_move", func(t *testing.T) Model {
+			return pressKeys(snapshotModel(t), "n", "enter", "e5", "enter")
+		}},
+		{"game_over", func(t *testing.T) Model {
+			m := pressKeys(snapshotModel(t), "n", "enter", "f3", "enter", "e5", "enter", "g4", "enter", "d8h4", "enter")
+			m.finishMateReveal()
+			// Real think times would change from run to run, so replay the
+			// moves with fixed ones
+			game := engine.NewGame()
+			times := []time.Duration{2 * time.Second, 3 * time.Second, 1500 * time.Millisecond, 4 * time.Second}
+			for i, move := range m.game.Moves() {
+				_ = game.MakeTimedMove(move, times[i])
+			}
+			m.game = game
+			return m
+		}},
+		{"save_prompt", func(t *testing.T) Model {
+			return pressKeys(snapshotModel(t), "n", "enter", "e4", "enter", "q")
+		}},
+		{"settings", func(t *testing.T) Model {
+			return pressKeys(snapshotModel(t), "s", "down", "down")
+		}},
+		{"shortcuts_overlay", func(t *testing.T) Model {
+			return pressKeys(snapshotModel(t), "?")
+		}},
+		{"command_palette", func(t *testing.T) Model {
+			return pressKeys(snapshotModel(t), "ctrl+p", "set")
+		}},
+		{"handoff", func(t *testing.T) Model {
+			m := snapshotModel(t)
+			m.config.HotSeatPrivacy = true
+			return pressKeys(m, "n", "enter", "d4", "enter")
+		}},
+		{"pvbot_as_black", func(t *testing.T) Model {
+			m := snapshotModel(t)
+			m.gameType = GameTypePvBot
+			m.userColor = engine.Black
+			m.game = engine.NewGame()
+			m.screen = ScreenGamePlay
+			return m
+		}},
+		{"learn", func(t *testing.T) Model {
+			return pressKeys(snapshotModel(t), "down", "down", "down", "down", "enter")
+		}},
+		{"game_history", func(t *testing.T) Model {
+			return pressKeys(snapshotModel(t), "down", "down", "enter")
+		}},
+		{"tutorial", func(t *testing.T) Model {
+			return pressKeys(snapshotModel(t), "n", "up", "enter", "e4", "enter")
+		}},
+		{"terminal_too_small", func(t *testing.T) Model {
+			return pressKeys(snapshotModel(t), tea.WindowSizeMsg{Width: 30, Height: 10})
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			golden.RequireEqualEscape(t, []byte(tt.setup(t).View()), true)
+		})
+	}
+}
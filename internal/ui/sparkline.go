@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// sparkBlocks are the bars of a sparkline, from lowest to highest.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkScale is the material difference, in pawns, drawn as a full or an empty bar.
+const sparkScale = 9
+
+// materialSparkline draws the last moves of a game's material balance (White's
+// material minus Black's, see bvb.GameSession.MaterialHistory) as a sparkline
+// followed by the current balance, e.g. "▄▄▄▅▅▆ +3", in at most width columns.
+// Bars above the middle mean White is ahead. It returns "" without moves.
+func materialSparkline(history []int, width int) string {
+	if len(history) == 0 {
+		return ""
+	}
+
+	current := history[len(history)-1]
+	balance := "="
+	if current != 0 {
+		balance = fmt.Sprintf("%+d", current)
+	}
+
+	bars := width - len(balance) - 1
+	if bars <= 0 {
+		return balance
+	}
+	if len(history) > bars {
+		history = history[len(history)-bars:]
+	}
+
+	var b strings.Builder
+	for _, diff := range history {
+		diff = max(-sparkScale, min(sparkScale, diff))
+		level := (diff + sparkScale) * (len(sparkBlocks) - 1) / (2 * sparkScale)
+		b.WriteRune(sparkBlocks[level])
+	}
+	b.WriteString(" ")
+	b.WriteString(balance)
+	return b.String()
+}
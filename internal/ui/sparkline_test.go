@@ -0,0 +1,45 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestMaterialSparkline(t *testing.T) {
+	tests := []struct {
+		name    string
+		history []int
+		width   int
+		want    string
+	}{
+		{"no moves", nil, 22, ""},
+		{"equal", []int{0, 0, 0}, 22, "▄▄▄ ="},
+		{"white ahead", []int{0, 3, 9, 20}, 22, "▄▅██ +20"},
+		{"black ahead", []int{-2, -5, -9}, 22, "▃▂▁ -9"},
+		{"only the last moves fit", []int{9, 9, 9, 0, 0, 0}, 5, "▄▄▄ ="},
+		{"too narrow for bars", []int{1}, 2, "+1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := materialSparkline(tt.history, tt.width); got != tt.want {
+				t.Errorf("materialSparkline(%v, %d) = %q, want %q", tt.history, tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaterialSparklineFitsWidth(t *testing.T) {
+	history := make([]int, 300)
+	for i := range history {
+		history[i] = i%19 - 9
+	}
+	got := materialSparkline(history, bvbCellWidth)
+	if w := lipgloss.Width(got); w > bvbCellWidth {
+		t.Errorf("sparkline is %d columns wide, want at most %d", w, bvbCellWidth)
+	}
+	if !strings.HasSuffix(got, " +5") {
+		t.Errorf("sparkline %q should end with the current balance", got)
+	}
+}
@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"github.com/Mgrdich/TermChess/internal/bvb"
+	"github.com/Mgrdich/TermChess/internal/engine"
+	"github.com/Mgrdich/TermChess/internal/spectate"
+)
+
+// Spectator receives what is on screen after every update, e.g. a spectate.Server
+// that lets the games be watched from a browser.
+type Spectator interface {
+	Publish(snapshot spectate.Snapshot)
+}
+
+// WithSpectator returns the model with s added to the spectators told about the
+// games played.
+func (m Model) WithSpectator(s Spectator) Model {
+	m.spectators = append(append([]Spectator(nil), m.spectators...), s)
+	s.Publish(m.spectatorSnapshot())
+	return m
+}
+
+// publishSpectator sends the current snapshot to the spectators, if there are any.
+func (m Model) publishSpectator() {
+	if len(m.spectators) == 0 {
+		return
+	}
+	snapshot := m.spectatorSnapshot()
+	for _, s := range m.spectators {
+		s.Publish(snapshot)
+	}
+}
+
+// spectatorSnapshot describes the game or Bot vs Bot session on screen.
+func (m Model) spectatorSnapshot() spectate.Snapshot {
+	switch {
+	case m.bvbManager != nil && (m.screen == ScreenBvBGamePlay || m.screen == ScreenBvBStats):
+		return BvBSnapshot(m.bvbManager)
+	case m.game != nil && (m.screen == ScreenGamePlay || m.screen == ScreenGameOver ||
+		m.screen == ScreenSavePrompt || m.screen == ScreenDrawPrompt):
+		return spectate.Snapshot{Mode: "game", Games: []spectate.Game{m.spectatorGame()}}
+	default:
+		return spectate.Snapshot{Mode: "idle"}
+	}
+}
+
+// spectatorGame describes the current single game.
+func (m Model) spectatorGame() spectate.Game {
+	white, black := "White", "Black"
+	if m.gameType == GameTypePvBot {
+		botName := m.botName
+		if botName == "" {
+			botName = botDifficultyName(m.botDifficulty) + " Bot"
+		}
+		white, black = m.playerName(), botName
+		if m.userColor == engine.Black {
+			white, black = black, white
+		}
+	}
+
+	game := spectate.Game{
+		White:  white,
+		Black:  black,
+		FEN:    m.game.Board().ToFEN(),
+		Moves:  spectatorMoves(m.game.Moves()),
+		Status: engine.Ongoing.String(),
+	}
+	if m.game.Over() {
+		game.Status = getGameResultMessage(m.game)
+		game.Result = m.game.Result()
+	}
+	return game
+}
+
+// BvBSnapshot describes every game of a Bot vs Bot session for spectators.
+func BvBSnapshot(manager *bvb.SessionManager) spectate.Snapshot {
+	sessions := manager.Sessions()
+	games := make([]spectate.Game, 0, len(sessions))
+	for _, session := range sessions {
+		if session == nil {
+			continue
+		}
+		whiteName, blackName := session.Players()
+		game := spectate.Game{
+			Number: session.GameNumber(),
+			White:  whiteName,
+			Black:  blackName,
+			FEN:    session.CurrentBoard().ToFEN(),
+			Moves:  spectatorMoves(session.CurrentMoveHistory()),
+			Status: engine.Ongoing.String(),
+		}
+		switch {
+		case session.State() == bvb.StateFinished && session.Result() != nil:
+			game.Status = session.Result().EndReason
+			game.Result = streamResult(session.Result())
+		case session.StartTime().IsZero():
+			game.Status = "queued"
+		}
+		games = append(games, game)
+	}
+	return spectate.Snapshot{Mode: "bvb", Games: games}
+}
+
+// spectatorMoves converts moves to coordinate notation.
+func spectatorMoves(moves []engine.Move) []string {
+	result := make([]string, len(moves))
+	for i, move := range moves {
+		result[i] = move.String()
+	}
+	return result
+}
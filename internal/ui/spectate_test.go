@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/bvb"
+	"github.com/Mgrdich/TermChess/internal/engine"
+	"github.com/Mgrdich/TermChess/internal/spectate"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSpectatorFollowsGame(t *testing.T) {
+	server := spectate.NewServer()
+	m := NewModel(DefaultConfig()).WithSpectator(server)
+	if got := server.Snapshot().Mode; got != "idle" {
+		t.Errorf("Mode on the main menu = %q, want idle", got)
+	}
+
+	m, err := m.StartGame(StartOptions{})
+	if err != nil {
+		t.Fatalf("StartGame() error = %v", err)
+	}
+	m.input = "e2e4"
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+
+	snapshot := server.Snapshot()
+	if snapshot.Mode != "game" || len(snapshot.Games) != 1 {
+		t.Fatalf("snapshot = %+v, want the game in progress", snapshot)
+	}
+	game := snapshot.Games[0]
+	if game.FEN != m.game.Board().ToFEN() || len(game.Moves) != 1 || game.Moves[0] != "e2e4" {
+		t.Errorf("game = %+v, want the position after e2e4", game)
+	}
+	if game.White != "White" || game.Black != "Black" || game.Status != "ongoing" || game.Result != "" {
+		t.Errorf("game = %+v, want an ongoing PvP game", game)
+	}
+}
+
+func TestSpectatorGameResult(t *testing.T) {
+	m, err := NewModel(DefaultConfig()).StartGame(StartOptions{PGN: "1. f3 e5 2. g4 Qh4# 0-1"})
+	if err != nil {
+		t.Fatalf("StartGame() error = %v", err)
+	}
+	game := m.spectatorGame()
+	if game.Result != "0-1" || game.Status != "Checkmate! Black wins" {
+		t.Errorf("game = %+v, want a win for Black", game)
+	}
+
+	m.game = engine.NewGame()
+	m.game.Resign(engine.Black)
+	if got := m.spectatorGame().Result; got != "1-0" {
+		t.Errorf("Result after Black resigns = %q, want 1-0", got)
+	}
+}
+
+func TestBvBSnapshot(t *testing.T) {
+	manager := bvb.NewSessionManager(0, 0, "Easy Bot", "Easy Bot", 2, 1)
+	if err := manager.RunHeadless(context.Background(), nil); err != nil {
+		t.Fatalf("RunHeadless() error = %v", err)
+	}
+
+	snapshot := BvBSnapshot(manager)
+	if snapshot.Mode != "bvb" || len(snapshot.Games) != 2 {
+		t.Fatalf("snapshot = %+v, want both games", snapshot)
+	}
+	for i, game := range snapshot.Games {
+		if game.Number != i+1 || game.Result == "" || game.Status == "ongoing" || len(game.Moves) == 0 {
+			t.Errorf("game %d = %+v, want a finished game", i+1, game)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/Mgrdich/TermChess/internal/bvb"
+)
+
+// sprtConfig returns the SPRT test configured in the config file. Missing or
+// invalid values fall back to bvb.DefaultSPRTConfig.
+func (m Model) sprtConfig() bvb.SPRTConfig {
+	cfg := bvb.DefaultSPRTConfig()
+	if m.config.SPRTElo1 > m.config.SPRTElo0 {
+		cfg.Elo0 = m.config.SPRTElo0
+		cfg.Elo1 = m.config.SPRTElo1
+	}
+	if m.config.SPRTAlpha > 0 && m.config.SPRTAlpha < 0.5 {
+		cfg.Alpha = m.config.SPRTAlpha
+	}
+	if m.config.SPRTBeta > 0 && m.config.SPRTBeta < 0.5 {
+		cfg.Beta = m.config.SPRTBeta
+	}
+	return cfg
+}
+
+// renderSPRTLine renders the progress of the session's SPRT test, or an empty
+// string if the session is not an SPRT test.
+func (m Model) renderSPRTLine() string {
+	if m.bvbManager == nil {
+		return ""
+	}
+	status, ok := m.bvbManager.SPRT()
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("SPRT: %s", status)
+}
@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/bvb"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSPRTConfigFromSettings(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	if got := m.sprtConfig(); got != bvb.DefaultSPRTConfig() {
+		t.Errorf("Expected the default test without settings, got %+v", got)
+	}
+
+	m.config.SPRTElo0 = -5
+	m.config.SPRTElo1 = 10
+	m.config.SPRTAlpha = 0.1
+	m.config.SPRTBeta = 0.9 // out of range, keeps the default
+	want := bvb.SPRTConfig{Elo0: -5, Elo1: 10, Alpha: 0.1, Beta: bvb.DefaultSPRTConfig().Beta}
+	if got := m.sprtConfig(); got != want {
+		t.Errorf("sprtConfig() = %+v, want %+v", got, want)
+	}
+}
+
+func TestBvBSPRTTestOption(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenBvBGameMode
+	m.menuOptions = bvbGameModeOptions()
+	m.menuSelection = 2
+
+	result, _ := m.handleBvBGameModeKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if !m.bvbSPRT || !m.bvbInputtingCount {
+		t.Fatal("Expected SPRT Test to ask for the maximum number of games")
+	}
+	if view := m.View(); !strings.Contains(view, "Maximum number of games for SPRT [0, 50] (alpha 0.05, beta 0.05):") {
+		t.Errorf("Expected the SPRT prompt, got:\n%s", view)
+	}
+
+	// Going back and picking Multi-Game turns the test off
+	result, _ = m.handleBvBGameModeKeys(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	m.menuSelection = 1
+	result, _ = m.handleBvBGameModeKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if m.bvbSPRT {
+		t.Error("Expected Multi-Game to play a fixed number of games")
+	}
+}
+
+func TestRenderSPRTLine(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	if got := m.renderSPRTLine(); got != "" {
+		t.Errorf("Expected no SPRT line without a session, got %q", got)
+	}
+
+	m.bvbManager = bvb.NewSessionManager(0, 0, "White", "Black", 10, 1)
+	if got := m.renderSPRTLine(); got != "" {
+		t.Errorf("Expected no SPRT line for a fixed number of games, got %q", got)
+	}
+
+	m.bvbManager.EnableSPRT(bvb.DefaultSPRTConfig())
+	if got, want := m.renderSPRTLine(), "SPRT: LLR 0.00 [-2.94, 2.94] (0, 50) - running"; got != want {
+		t.Errorf("renderSPRTLine() = %q, want %q", got, want)
+	}
+}
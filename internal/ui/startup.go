@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// StartOptions describes a game to launch directly from the command line,
+// skipping the menus.
+type StartOptions struct {
+	// FEN is the starting position. Empty means the standard starting position.
+	FEN string
+	// PGN is the text of a game to continue from. Cannot be combined with FEN.
+	PGN string
+	// VsBot starts a Player vs Bot game instead of Player vs Player.
+	VsBot bool
+	// BotDifficulty is the bot's difficulty when VsBot is set.
+	BotDifficulty BotDifficulty
+	// Color is the user's color when VsBot is set.
+	Color engine.Color
+	// Variant is the chess variant to play. nil means standard chess, or the
+	// variant named by a PGN's Variant tag.
+	Variant engine.Variant
+}
+
+// botTurnMsg asks the model to let the bot move, used when a game launched
+// from the command line starts on the bot's turn.
+type botTurnMsg struct{}
+
+// StartGame returns the model with the game described by opts already in progress.
+// A game loaded from PGN keeps its moves in the move history; if it has already
+// ended, the model opens on the game over screen.
+func (m Model) StartGame(opts StartOptions) (Model, error) {
+	if opts.FEN != "" && opts.PGN != "" {
+		return m, fmt.Errorf("cannot start from both a FEN and a PGN")
+	}
+
+	board := engine.NewBoard()
+	variant := opts.Variant
+	var moves []engine.Move
+	var evals []float64
+	switch {
+	case opts.FEN != "":
+		b, err := engine.LoadFEN(opts.FEN)
+		if err != nil {
+			return m, fmt.Errorf("invalid FEN: %w", err)
+		}
+		board = b
+	case opts.PGN != "":
+		game, err := ParsePGN(opts.PGN)
+		if err != nil {
+			return m, fmt.Errorf("invalid PGN: %w", err)
+		}
+		b, err := engine.FromFEN(game.StartFEN)
+		if err != nil {
+			return m, fmt.Errorf("invalid PGN: %w", err)
+		}
+		board = b
+		moves = game.Moves
+		evals = game.Evals
+		if tag, ok := game.Tags["Variant"]; ok && variant == nil {
+			if variant, err = engine.ParseVariant(tag); err != nil {
+				return m, fmt.Errorf("invalid PGN: %w", err)
+			}
+		}
+	}
+	board.Variant = variant
+
+	m.gameType = GameTypePvP
+	if opts.VsBot {
+		m.gameType = GameTypePvBot
+		m.botDifficulty = opts.BotDifficulty
+		m.userColor = opts.Color
+	}
+
+	// Record the starting position before replaying moves so analysis covers the whole game
+	m.game = engine.NewGameFrom(board)
+	m.beginGameRecord()
+	for _, move := range moves {
+		if err := m.game.MakeMove(move); err != nil {
+			return m, fmt.Errorf("invalid PGN: %w", err)
+		}
+	}
+	// The evaluations stored with the game spare analyzing it again for its graph
+	m.evals = evals
+
+	m.clearNavStack()
+	m.screen = ScreenGamePlay
+	if m.game.Board().IsGameOver() {
+		// A finished game opens straight on the game over screen
+		m.showGameOver()
+		m.finishMateReveal()
+	}
+	m.input = ""
+	m.dismissToasts()
+	m.drawOfferedBy = -1
+	m.drawOfferedByWhite = false
+	m.drawOfferedByBlack = false
+	return m, nil
+}
+
+// isBotTurn reports whether a Player vs Bot game is waiting for the bot to move.
+func (m Model) isBotTurn() bool {
+	return m.screen == ScreenGamePlay && m.gameType == GameTypePvBot &&
+		m.game != nil && m.game.Board().ActiveColor != m.userColor && !m.botThinking
+}
@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestStartGameFromFEN(t *testing.T) {
+	fen := "4k3/8/8/8/8/8/4P3/4K3 w - - 0 1"
+	m, err := NewModel(DefaultConfig()).StartGame(StartOptions{FEN: fen})
+	if err != nil {
+		t.Fatalf("StartGame() error = %v", err)
+	}
+	if m.screen != ScreenGamePlay || m.gameType != GameTypePvP {
+		t.Errorf("Expected PvP gameplay, got screen %v type %v", m.screen, m.gameType)
+	}
+	if m.game.Board().ToFEN() != fen || m.game.Start().ToFEN() != fen {
+		t.Errorf("Expected board at %q, got %q", fen, m.game.Board().ToFEN())
+	}
+	if m.Init() == nil {
+		t.Error("Expected Init to still check for updates")
+	}
+}
+
+func TestStartGameFromPGN(t *testing.T) {
+	m, err := NewModel(DefaultConfig()).StartGame(StartOptions{PGN: "1. e4 e5 2. Nf3 *"})
+	if err != nil {
+		t.Fatalf("StartGame() error = %v", err)
+	}
+	if m.game.MoveCount() != 3 {
+		t.Fatalf("Expected 3 moves in history, got %d", m.game.MoveCount())
+	}
+	if m.game.Board().ActiveColor != engine.Black {
+		t.Error("Expected Black to move")
+	}
+	if start := m.game.Start().ToFEN(); start != engine.NewBoard().ToFEN() {
+		t.Errorf("Expected the game record to start from the initial position, got %q", start)
+	}
+
+	// A finished game opens on the game over screen
+	m, err = NewModel(DefaultConfig()).StartGame(StartOptions{PGN: "1. f3 e5 2. g4 Qh4# 0-1"})
+	if err != nil {
+		t.Fatalf("StartGame() error = %v", err)
+	}
+	if m.screen != ScreenGameOver {
+		t.Errorf("Expected ScreenGameOver for a finished game, got %v", m.screen)
+	}
+}
+
+func TestStartGameFromPGNKeepsEvals(t *testing.T) {
+	m, err := NewModel(DefaultConfig()).StartGame(StartOptions{
+		PGN: "1. f3 {[%eval -0.4]} e5 {[%eval -0.3]} 2. g4 {[%eval -9.9]} Qh4# {[%eval #-1]} 0-1",
+	})
+	if err != nil {
+		t.Fatalf("StartGame() error = %v", err)
+	}
+	if len(m.evals) != 4 {
+		t.Fatalf("Expected the 4 evaluations kept, got %v", m.evals)
+	}
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "White ") || !strings.Contains(view, "Black █") {
+		t.Errorf("Expected the evaluation graph on the game over screen, got\n%s", view)
+	}
+}
+
+func TestStartGameVsBot(t *testing.T) {
+	opts := StartOptions{VsBot: true, BotDifficulty: BotMedium, Color: engine.Black}
+	m, err := NewModel(DefaultConfig()).StartGame(opts)
+	if err != nil {
+		t.Fatalf("StartGame() error = %v", err)
+	}
+	if m.gameType != GameTypePvBot || m.botDifficulty != BotMedium || m.userColor != engine.Black {
+		t.Errorf("Unexpected game setup: type %v difficulty %v color %v", m.gameType, m.botDifficulty, m.userColor)
+	}
+	if !m.isBotTurn() {
+		t.Fatal("Expected the bot to be on move as White")
+	}
+
+	// The bot turn message starts the bot thinking
+	result, cmd := m.Update(botTurnMsg{})
+	m = result.(Model)
+	if !m.botThinking || cmd == nil {
+		t.Error("Expected botTurnMsg to start the bot's move")
+	}
+	m.stopBotThinking()
+
+	// Playing White, the user moves first
+	opts.Color = engine.White
+	m, _ = NewModel(DefaultConfig()).StartGame(opts)
+	if m.isBotTurn() {
+		t.Error("Expected the user to move first as White")
+	}
+}
+
+func TestStartGameErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    StartOptions
+		wantErr string
+	}{
+		{"both FEN and PGN", StartOptions{FEN: "8/8/8/8/8/8/8/8 w - - 0 1", PGN: "1. e4"}, "both"},
+		{"bad FEN", StartOptions{FEN: "nonsense"}, "invalid FEN"},
+		{"bad PGN", StartOptions{PGN: "1. e5"}, "invalid PGN"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewModel(DefaultConfig()).StartGame(tt.opts)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("StartGame() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStartGameVariant(t *testing.T) {
+	m, err := NewModel(DefaultConfig()).StartGame(StartOptions{Variant: engine.Atomic{}})
+	if err != nil {
+		t.Fatalf("StartGame() error = %v", err)
+	}
+	if _, ok := m.game.Board().Variant.(engine.Atomic); !ok {
+		t.Fatalf("Expected an Atomic board, got %v", m.game.Board().Variant)
+	}
+	if !strings.Contains(m.View(), "White to move (Atomic chess)") {
+		t.Error("Expected the variant to be shown next to the turn indicator")
+	}
+
+	// The PGN Variant tag selects the rules the moves are replayed with
+	pgn := "[Variant \"Atomic\"]\n\n1. Nf3 f6 2. Ng5 e6 3. Nxh7 *"
+	m, err = NewModel(DefaultConfig()).StartGame(StartOptions{PGN: pgn})
+	if err != nil {
+		t.Fatalf("StartGame() error = %v", err)
+	}
+	if !m.game.Board().PieceAt(engine.NewSquare(7, 7)).IsEmpty() {
+		t.Error("Expected Nxh7 to explode the rook on h8")
+	}
+
+	if _, err := NewModel(DefaultConfig()).StartGame(StartOptions{PGN: "[Variant \"Bughouse\"]\n\n1. e4 *"}); err == nil {
+		t.Error("Expected an unknown PGN variant to be rejected")
+	}
+}
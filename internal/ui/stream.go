@@ -0,0 +1,214 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/bvb"
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// GameStream appends Bot vs Bot games to a file while they are played, so the
+// games survive a crash or abort and other tools can tail the file. It streams in
+// one of two formats, chosen by the file extension:
+//   - .jsonl or .ndjson: one JSON object per move and per game ending, written as
+//     the moves are played.
+//   - anything else: PGN, one game appended as soon as it ends. Aborted games are
+//     written with the result "*".
+//
+// GameStream implements bvb.Observer.
+type GameStream struct {
+	mu    sync.Mutex
+	file  *os.File
+	jsonl bool
+	games map[int]*streamGame // games being played
+	err   error               // first write error, reported by Close
+}
+
+// streamGame is a game being streamed.
+type streamGame struct {
+	white, black string
+	start        *engine.Board // position the game started from
+	board        *engine.Board // position before the next move
+	moves        []engine.Move // moves played so far
+}
+
+// streamEvent is a line of a JSONL game stream.
+type streamEvent struct {
+	Event       string `json:"event"` // "move" or "end"
+	Game        int    `json:"game"`
+	Ply         int    `json:"ply,omitempty"`
+	Move        string `json:"move,omitempty"`
+	SAN         string `json:"san,omitempty"`
+	FEN         string `json:"fen,omitempty"`
+	ThinkMs     int64  `json:"think_ms,omitempty"`
+	Result      string `json:"result,omitempty"`
+	Termination string `json:"termination,omitempty"`
+}
+
+// OpenGameStream opens path for appending, creating it and its directory if needed.
+func OpenGameStream(path string) (*GameStream, error) {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, err
+		}
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	ext := strings.ToLower(filepath.Ext(path))
+	return &GameStream{
+		file:  file,
+		jsonl: ext == ".jsonl" || ext == ".ndjson",
+		games: make(map[int]*streamGame),
+	}, nil
+}
+
+// GameStarted records who plays the game and the position it starts from.
+func (s *GameStream) GameStarted(game int, white, black string, board *engine.Board) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.games[game] = &streamGame{white: white, black: black, start: board, board: board}
+}
+
+// MovePlayed records a move and, in JSONL format, writes it out.
+func (s *GameStream) MovePlayed(game int, move engine.Move, board *engine.Board, thinkTime time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g := s.game(game)
+	san := engine.FormatSAN(g.board, move)
+	g.board = board
+	g.moves = append(g.moves, move)
+
+	if s.jsonl {
+		s.writeEvent(streamEvent{
+			Event:   "move",
+			Game:    game,
+			Ply:     len(g.moves),
+			Move:    move.String(),
+			SAN:     san,
+			FEN:     board.ToFEN(),
+			ThinkMs: thinkTime.Milliseconds(),
+		})
+	}
+}
+
+// GameFinished writes the end of a game: a JSONL "end" event, or the whole game in PGN.
+func (s *GameStream) GameFinished(game int, result *bvb.GameResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g := s.game(game)
+	delete(s.games, game)
+
+	pgnResult, termination := "*", "aborted"
+	if result != nil {
+		pgnResult, termination = streamResult(result), result.EndReason
+	}
+
+	if s.jsonl {
+		s.writeEvent(streamEvent{Event: "end", Game: game, Result: pgnResult, Termination: termination})
+		return
+	}
+
+	movetext, err := FormatPGNMovetext(g.start, g.moves)
+	if err != nil {
+		s.fail(err)
+		return
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "[Event \"TermChess Bot vs Bot\"]\n")
+	fmt.Fprintf(&b, "[Site \"TermChess\"]\n")
+	fmt.Fprintf(&b, "[Date \"%s\"]\n", time.Now().Format("2006.01.02"))
+	fmt.Fprintf(&b, "[Round \"%d\"]\n", game)
+	fmt.Fprintf(&b, "[White \"%s\"]\n", g.white)
+	fmt.Fprintf(&b, "[Black \"%s\"]\n", g.black)
+	fmt.Fprintf(&b, "[Result \"%s\"]\n", pgnResult)
+	if fen := g.start.ToFEN(); fen != engine.NewBoard().ToFEN() {
+		fmt.Fprintf(&b, "[SetUp \"1\"]\n")
+		fmt.Fprintf(&b, "[FEN \"%s\"]\n", fen)
+	}
+	fmt.Fprintf(&b, "[Termination \"%s\"]\n\n", termination)
+	if movetext != "" {
+		b.WriteString(movetext)
+		b.WriteString(" ")
+	}
+	b.WriteString(pgnResult)
+	b.WriteString("\n\n")
+	s.write(b.String())
+}
+
+// game returns the game with the given number, starting it from the standard
+// position if GameStarted was not called for it. Must be called with s.mu held.
+func (s *GameStream) game(game int) *streamGame {
+	g, ok := s.games[game]
+	if !ok {
+		g = &streamGame{white: "White", black: "Black", start: engine.NewBoard()}
+		g.board = g.start
+		s.games[game] = g
+	}
+	return g
+}
+
+// streamResult converts a game result to a PGN result.
+func streamResult(result *bvb.GameResult) string {
+	switch {
+	case result.Winner == "Draw":
+		return "1/2-1/2"
+	case result.WinnerColor == engine.White:
+		return "1-0"
+	default:
+		return "0-1"
+	}
+}
+
+// writeEvent writes one JSONL line. Must be called with s.mu held.
+func (s *GameStream) writeEvent(e streamEvent) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		s.fail(err)
+		return
+	}
+	s.write(string(data) + "\n")
+}
+
+// write appends text to the file in a single write, so readers never see half a
+// line from one game mixed with another. Must be called with s.mu held.
+func (s *GameStream) write(text string) {
+	if s.err != nil || s.file == nil {
+		return
+	}
+	if _, err := s.file.WriteString(text); err != nil {
+		s.fail(err)
+	}
+}
+
+// fail records the first error. Must be called with s.mu held.
+func (s *GameStream) fail(err error) {
+	if s.err == nil {
+		s.err = err
+	}
+}
+
+// Close closes the file and returns the first error the stream ran into, if any.
+// It is safe to call multiple times.
+func (s *GameStream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return s.err
+	}
+	if err := s.file.Close(); err != nil {
+		s.fail(err)
+	}
+	s.file = nil
+	return s.err
+}
@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/bvb"
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// streamGames plays count Easy vs Easy games headless, streaming them to path.
+func streamGames(t *testing.T, path string, count int) *bvb.SessionManager {
+	t.Helper()
+	stream, err := OpenGameStream(path)
+	if err != nil {
+		t.Fatalf("OpenGameStream() error = %v", err)
+	}
+	manager := bvb.NewSessionManager(0, 0, "Easy Bot", "Easy Bot", count, 1)
+	manager.SetObserver(stream)
+	if err := manager.RunHeadless(context.Background(), nil); err != nil {
+		t.Fatalf("RunHeadless() error = %v", err)
+	}
+	return manager
+}
+
+func TestGameStreamJSONL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "games.jsonl")
+	manager := streamGames(t, path, 2)
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer file.Close()
+
+	moves := make(map[int]int)
+	ends := make(map[int]streamEvent)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var e streamEvent
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("invalid JSONL line %q: %v", scanner.Text(), err)
+		}
+		switch e.Event {
+		case "move":
+			moves[e.Game]++
+			if e.Ply != moves[e.Game] || e.SAN == "" || e.FEN == "" {
+				t.Errorf("unexpected move event %+v", e)
+			}
+		case "end":
+			ends[e.Game] = e
+		}
+	}
+
+	for _, s := range manager.Sessions() {
+		r := s.Result()
+		end, ok := ends[r.GameNumber]
+		if !ok {
+			t.Fatalf("game %d: no end event", r.GameNumber)
+		}
+		if moves[r.GameNumber] != r.MoveCount || end.Termination != r.EndReason {
+			t.Errorf("game %d: streamed %d moves ending in %q, want %d ending in %q",
+				r.GameNumber, moves[r.GameNumber], end.Termination, r.MoveCount, r.EndReason)
+		}
+	}
+}
+
+func TestGameStreamPGNAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "games.pgn")
+	streamGames(t, path, 1)
+	streamGames(t, path, 1)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if n := strings.Count(string(data), "[Event "); n != 2 {
+		t.Errorf("Expected both runs' games in the file, got %d", n)
+	}
+	if !strings.Contains(string(data), "1. ") {
+		t.Error("Expected SAN movetext in the stream")
+	}
+}
+
+func TestGameStreamAbortedGame(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "games.pgn")
+	stream, err := OpenGameStream(path)
+	if err != nil {
+		t.Fatalf("OpenGameStream() error = %v", err)
+	}
+	board := engine.NewBoard()
+	move, _ := engine.ParseMove("e2e4")
+	_ = board.MakeMove(move)
+	stream.MovePlayed(1, move, board, 0)
+	stream.GameFinished(1, nil)
+	if err := stream.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), `[Result "*"]`) || !strings.Contains(string(data), "1. e4 *") {
+		t.Errorf("Expected the aborted game with result *, got:\n%s", data)
+	}
+}
+
+func TestGameStreamStartPosition(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "games.pgn")
+	stream, err := OpenGameStream(path)
+	if err != nil {
+		t.Fatalf("OpenGameStream() error = %v", err)
+	}
+	fen := "7k/8/6K1/8/8/8/8/Q7 w - - 0 1"
+	board, _ := engine.FromFEN(fen)
+	stream.GameStarted(1, "Hard Bot", "Easy Bot", board.Copy())
+
+	move, _ := engine.ParseMove("a1a8")
+	_ = board.MakeMove(move)
+	stream.MovePlayed(1, move, board, 0)
+	stream.GameFinished(1, nil)
+	if err := stream.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	for _, want := range []string{`[White "Hard Bot"]`, `[Black "Easy Bot"]`, `[SetUp "1"]`, `[FEN "` + fen + `"]`, "1. Qa8# *"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Expected %q in the stream, got:\n%s", want, data)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Mgrdich/TermChess/internal/bvb"
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Escape sequences that save and restore the window title (XTWINOPS 22 and 23)
+// and clear an OSC 9;4 progress bar. Terminals without support ignore them.
+const (
+	saveTitleSeq     = "\x1b[22;0t"
+	restoreTitleSeq  = "\x1b[23;0t"
+	clearProgressSeq = "\x1b]9;4;0;0\x07"
+)
+
+// appTitle is the window title outside of games, and the start of the others.
+const appTitle = "TermChess"
+
+// OSC 9;4 progress states.
+const (
+	progressNormal = 1
+	progressPaused = 4
+)
+
+// SaveTerminalTitle asks the terminal to remember its window title, so
+// RestoreTerminal can put it back when the program exits.
+func SaveTerminalTitle(w io.Writer) {
+	fmt.Fprint(w, saveTitleSeq)
+}
+
+// RestoreTerminal undoes what the program changed in the terminal itself: it
+// clears the progress bar final left behind, if any, and restores the window
+// title saved by SaveTerminalTitle.
+func RestoreTerminal(w io.Writer, final tea.Model) {
+	if m, ok := final.(Model); ok && m.terminalProgress != "" {
+		fmt.Fprint(w, clearProgressSeq)
+	}
+	fmt.Fprint(w, restoreTitleSeq)
+}
+
+// windowTitle returns the terminal window title for the current screen, e.g.
+// "TermChess – Your move" or "TermChess – BvB 37/100 complete".
+func (m Model) windowTitle() string {
+	state := ""
+	switch m.screen {
+	case ScreenGamePlay:
+		switch {
+		case m.game == nil:
+		case m.gameType == GameTypePvBot && m.game.Board().ActiveColor == m.userColor:
+			state = "Your move"
+		case m.gameType == GameTypePvBot:
+			state = "Bot thinking"
+		case m.game.Board().ActiveColor == engine.White:
+			state = "White to move"
+		default:
+			state = "Black to move"
+		}
+	case ScreenGameOver:
+		state = "Game over"
+	case ScreenAnalysis:
+		state = "Analysis"
+	case ScreenBvBGamePlay, ScreenBvBStats:
+		if m.bvbManager != nil {
+			finished, total := bvbProgress(m.bvbManager)
+			state = fmt.Sprintf("BvB %d/%d complete", finished, total)
+			if m.bvbManager.State() == bvb.StatePaused && finished < total {
+				state += " (paused)"
+			}
+		}
+	}
+	if state == "" {
+		return appTitle
+	}
+	return appTitle + " – " + state
+}
+
+// bvbProgress returns how many games of a Bot vs Bot session are finished, and
+// how many it plays.
+func bvbProgress(manager *bvb.SessionManager) (finished, total int) {
+	for _, s := range manager.Sessions() {
+		if s != nil && s.IsFinished() {
+			finished++
+		}
+	}
+	return finished, manager.GameCount()
+}
+
+// progressSequence returns the OSC 9;4 sequence showing the progress of the
+// Bot vs Bot games being played, or "" if there is none to show or terminal
+// progress is turned off.
+func (m Model) progressSequence() string {
+	if !m.config.TerminalProgress || m.screen != ScreenBvBGamePlay || m.bvbManager == nil {
+		return ""
+	}
+	finished, total := bvbProgress(m.bvbManager)
+	if total <= 0 || finished >= total {
+		return ""
+	}
+	state := progressNormal
+	if m.bvbManager.State() == bvb.StatePaused {
+		state = progressPaused
+	}
+	return fmt.Sprintf("\x1b]9;4;%d;%d\x07", state, finished*100/total)
+}
+
+// WithoutWindowTitle returns the model with window titles turned off, for programs
+// whose output isn't a terminal. Bubble Tea writes titles outside of its
+// renderer's lock, so they could otherwise race with a frame on an unsynchronized
+// output such as a test's.
+func (m Model) WithoutWindowTitle() Model {
+	m.noWindowTitle = true
+	return m
+}
+
+// syncTerminal returns a command that updates the window title and progress bar
+// if they changed since the last update, or nil if they didn't.
+func (m *Model) syncTerminal() tea.Cmd {
+	var cmds []tea.Cmd
+	if title := m.windowTitle(); !m.noWindowTitle && title != m.terminalTitle {
+		m.terminalTitle = title
+		cmds = append(cmds, tea.SetWindowTitle(title))
+	}
+	if progress := m.progressSequence(); progress != m.terminalProgress {
+		seq := progress
+		if seq == "" {
+			seq = clearProgressSeq
+		}
+		m.terminalProgress = progress
+		// Written through the program's output, like the title, so it can't split a frame
+		out := m.terminal()
+		cmds = append(cmds, func() tea.Msg {
+			_, _ = fmt.Fprint(out, seq)
+			return nil
+		})
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/bvb"
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestWindowTitle(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	if got := m.windowTitle(); got != "TermChess" {
+		t.Errorf("Main menu title = %q, want TermChess", got)
+	}
+
+	m.screen = ScreenGamePlay
+	m.game = engine.NewGame()
+	if got := m.windowTitle(); got != "TermChess – White to move" {
+		t.Errorf("Player vs Player title = %q", got)
+	}
+	m.gameType = GameTypePvBot
+	m.userColor = engine.White
+	if got := m.windowTitle(); got != "TermChess – Your move" {
+		t.Errorf("Title on the user's move = %q", got)
+	}
+	m.userColor = engine.Black
+	if got := m.windowTitle(); got != "TermChess – Bot thinking" {
+		t.Errorf("Title on the bot's move = %q", got)
+	}
+
+	m.screen = ScreenBvBGamePlay
+	m.bvbManager = bvb.NewSessionManager(0, 0, "Easy Bot", "Easy Bot", 100, 1)
+	if got := m.windowTitle(); got != "TermChess – BvB 0/100 complete" {
+		t.Errorf("Bot vs Bot title = %q", got)
+	}
+	m.bvbManager.Pause()
+	if got := m.windowTitle(); got != "TermChess – BvB 0/100 complete (paused)" {
+		t.Errorf("Paused Bot vs Bot title = %q", got)
+	}
+}
+
+func TestSyncTerminal(t *testing.T) {
+	// Init sets the main menu's title, so it isn't set again
+	m := NewModel(DefaultConfig())
+	if cmd := m.syncTerminal(); cmd != nil {
+		t.Error("Expected no command when nothing changed")
+	}
+	m.screen = ScreenGameOver
+	if cmd := m.syncTerminal(); cmd == nil || m.terminalTitle != "TermChess – Game over" {
+		t.Errorf("Expected the title to be set, got %q", m.terminalTitle)
+	}
+
+	// Progress is only reported when turned on
+	m.screen = ScreenBvBGamePlay
+	m.bvbManager = bvb.NewSessionManager(0, 0, "Easy Bot", "Easy Bot", 4, 1)
+	m.syncTerminal()
+	if m.terminalProgress != "" {
+		t.Errorf("Progress = %q with terminal progress off, want none", m.terminalProgress)
+	}
+	m.config.TerminalProgress = true
+	m.syncTerminal()
+	if m.terminalProgress != "\x1b]9;4;1;0\x07" {
+		t.Errorf("Progress = %q, want 0%% in the normal state", m.terminalProgress)
+	}
+	m.bvbManager.Pause()
+	m.syncTerminal()
+	if m.terminalProgress != "\x1b]9;4;4;0\x07" {
+		t.Errorf("Progress = %q, want the paused state", m.terminalProgress)
+	}
+
+	// Leaving the session clears the progress bar
+	m.screen = ScreenMainMenu
+	if cmd := m.syncTerminal(); cmd == nil || m.terminalProgress != "" {
+		t.Errorf("Expected the progress to be cleared, got %q", m.terminalProgress)
+	}
+}
+
+func TestWithoutWindowTitle(t *testing.T) {
+	m := NewModel(DefaultConfig()).WithoutWindowTitle()
+	m.screen = ScreenGameOver
+	if cmd := m.syncTerminal(); cmd != nil {
+		t.Error("Expected no command with window titles off")
+	}
+	if m.terminalTitle != appTitle {
+		t.Errorf("Title = %q, want it left at %q", m.terminalTitle, appTitle)
+	}
+}
+
+func TestSyncTerminalWritesProgressToOutput(t *testing.T) {
+	file, err := os.Create(filepath.Join(t.TempDir(), "terminal"))
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	defer file.Close()
+	cfg := DefaultConfig()
+	cfg.TerminalProgress = true
+	m := NewModel(cfg).WithOutput(NewTerminalOutput(file))
+	m.screen = ScreenBvBGamePlay
+	m.bvbManager = bvb.NewSessionManager(0, 0, "Easy Bot", "Easy Bot", 4, 1)
+
+	// Setting the title is left to Bubble Tea, so only the progress is written
+	msg := m.syncTerminal()()
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		for _, cmd := range batch {
+			cmd()
+		}
+	}
+	data, err := os.ReadFile(file.Name())
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(data) != "\x1b]9;4;1;0\x07" {
+		t.Errorf("Output = %q, want the progress sequence", data)
+	}
+}
+
+func TestRestoreTerminal(t *testing.T) {
+	var buf bytes.Buffer
+	m := NewModel(DefaultConfig())
+	RestoreTerminal(&buf, m)
+	if buf.String() != restoreTitleSeq {
+		t.Errorf("RestoreTerminal() wrote %q, want only the title restored", buf.String())
+	}
+
+	buf.Reset()
+	m.terminalProgress = "\x1b]9;4;1;50\x07"
+	RestoreTerminal(&buf, m)
+	if buf.String() != clearProgressSeq+restoreTitleSeq {
+		t.Errorf("RestoreTerminal() wrote %q, want the progress cleared too", buf.String())
+	}
+}
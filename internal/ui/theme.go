@@ -61,6 +61,8 @@ type Theme struct {
 	// Selection colors (for future use)
 	SelectedHighlight  lipgloss.Color
 	ValidMoveHighlight lipgloss.Color
+	MoveFlashHighlight lipgloss.Color // Squares of a bot's move, flashed as it is played
+	CheckHighlight     lipgloss.Color // King in check, and the check banner
 
 	// UI colors
 	BoardBorder  lipgloss.Color
@@ -96,6 +98,8 @@ var themes = map[ThemeName]Theme{
 		// Selection colors (for future use)
 		SelectedHighlight:  lipgloss.Color("#7D56F4"), // Purple - matches cursor
 		ValidMoveHighlight: lipgloss.Color("#50FA7B"), // Green - matches status
+		MoveFlashHighlight: lipgloss.Color("#F1FA8C"), // Yellow
+		CheckHighlight:     lipgloss.Color("#FF5555"), // Red
 
 		// UI colors - matching original hardcoded values
 		BoardBorder:  lipgloss.Color("#FAFAFA"), // White - matches title
@@ -127,6 +131,8 @@ var themes = map[ThemeName]Theme{
 		// Selection colors
 		SelectedHighlight:  lipgloss.Color("#00A0B0"), // Teal
 		ValidMoveHighlight: lipgloss.Color("#4ECDC4"), // Light teal
+		MoveFlashHighlight: lipgloss.Color("#FFD166"), // Amber
+		CheckHighlight:     lipgloss.Color("#EF476F"), // Coral red
 
 		// UI colors - clean modern look with blues and teals
 		BoardBorder:  lipgloss.Color("#B8C5D0"), // Light steel
@@ -158,6 +164,8 @@ var themes = map[ThemeName]Theme{
 		// Selection colors - subtle accents
 		SelectedHighlight:  lipgloss.Color("#A0A0A0"), // Gray
 		ValidMoveHighlight: lipgloss.Color("#B8B8B8"), // Light gray
+		MoveFlashHighlight: lipgloss.Color("#E8E8E8"), // Near white
+		CheckHighlight:     lipgloss.Color("#707070"), // Dark gray
 
 		// UI colors - muted grayscale palette
 		BoardBorder:  lipgloss.Color("#A0A0A0"), // Gray
@@ -539,12 +539,12 @@ func TestTurnStyleUsesCorrectColor(t *testing.T) {
 	_ = style.Render("test")
 
 	// With a board set to white's turn
-	m.board = &engine.Board{ActiveColor: 0} // White
+	m.game = engine.NewGameFrom(&engine.Board{ActiveColor: 0}) // White
 	style = m.turnStyle()
 	_ = style.Render("White to move")
 
 	// With a board set to black's turn
-	m.board = &engine.Board{ActiveColor: 1} // Black
+	m.game = engine.NewGameFrom(&engine.Board{ActiveColor: 1}) // Black
 	style = m.turnStyle()
 	_ = style.Render("Black to move")
 }
@@ -603,33 +603,6 @@ func TestMenuHierarchyStyleMethods(t *testing.T) {
 	// If we get here without panics, the test passes
 }
 
-// TestIsPrimaryAction tests the isPrimaryAction helper function.
-func TestIsPrimaryAction(t *testing.T) {
-	tests := []struct {
-		option   string
-		expected bool
-	}{
-		{"New Game", true},
-		{"Resume Game", true},
-		{"Start", true},
-		{"Play Again", true},
-		{"New Session", true},
-		{"Settings", false},
-		{"Exit", false},
-		{"Load Game", false},
-		{"Return to Menu", false},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.option, func(t *testing.T) {
-			got := isPrimaryAction(tt.option)
-			if got != tt.expected {
-				t.Errorf("isPrimaryAction(%q) = %v, want %v", tt.option, got, tt.expected)
-			}
-		})
-	}
-}
-
 // TestMainMenuRendersSeparator tests that the main menu renders a separator.
 func TestMainMenuRendersSeparator(t *testing.T) {
 	config := Config{
@@ -810,7 +783,7 @@ func TestKeyboardAccessibility_FocusIndicatorsPresent(t *testing.T) {
 // TestMouseAndKeyboardParallelUsage tests that mouse and keyboard can be used together.
 func TestMouseAndKeyboardParallelUsage(t *testing.T) {
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenGamePlay
 	m.gameType = GameTypePvP
 	m.termWidth = 80
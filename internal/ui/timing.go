@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/bvb"
+	"github.com/Mgrdich/TermChess/internal/engine"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// playMove plays move in the game along with the time its player, or the bot,
+// spent on it, and starts timing the next move.
+func (m *Model) playMove(move engine.Move) error {
+	if err := m.game.MakeTimedMove(move, time.Since(m.turnStartedAt)); err != nil {
+		return err
+	}
+	m.turnStartedAt = time.Now()
+	return nil
+}
+
+// averageThinkTimes returns the average time White and Black spent per timed move.
+// A color without timed moves has an average of 0.
+func (m Model) averageThinkTimes() (white, black time.Duration) {
+	// The first move was made by the side to move in the starting position
+	first := m.game.Start().ActiveColor
+
+	var totals [2]time.Duration
+	var counts [2]int
+	for i, d := range m.game.MoveTimes() {
+		if d <= 0 {
+			continue
+		}
+		color := first
+		if i%2 == 1 {
+			color = opponentColor(first)
+		}
+		totals[color] += d
+		counts[color]++
+	}
+
+	if counts[engine.White] > 0 {
+		white = totals[engine.White] / time.Duration(counts[engine.White])
+	}
+	if counts[engine.Black] > 0 {
+		black = totals[engine.Black] / time.Duration(counts[engine.Black])
+	}
+	return white, black
+}
+
+// formatThinkTime formats a move time compactly, e.g. "0.4s", "12.3s" or "2m05s".
+func formatThinkTime(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	}
+	return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+}
+
+// renderThinkTimes renders the average think time of each side for the game over screen,
+// or an empty string if no moves were timed.
+func (m Model) renderThinkTimes() string {
+	white, black := m.averageThinkTimes()
+	if white == 0 && black == 0 {
+		return ""
+	}
+
+	whiteLabel, blackLabel := "White", "Black"
+	if m.gameType == GameTypePvBot {
+		if m.userColor == engine.White {
+			whiteLabel, blackLabel = "White (you)", "Black (bot)"
+		} else {
+			whiteLabel, blackLabel = "White (bot)", "Black (you)"
+		}
+	}
+
+	parts := make([]string, 0, 2)
+	if white > 0 {
+		parts = append(parts, fmt.Sprintf("%s %s", whiteLabel, formatThinkTime(white)))
+	}
+	if black > 0 {
+		parts = append(parts, fmt.Sprintf("%s %s", blackLabel, formatThinkTime(black)))
+	}
+	return "Average think time: " + strings.Join(parts, " | ")
+}
+
+// moveTimeSuffix returns the time spent on the i-th move formatted for the move
+// history, e.g. " (2.1s)", or an empty string if the move was not timed.
+func (m Model) moveTimeSuffix(i int) string {
+	times := m.game.MoveTimes()
+	if i >= len(times) || times[i] <= 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%s)", formatThinkTime(times[i]))
+}
+
+// bvbThinkTimeLine renders the average think time of both bots for the BvB stats screen,
+// or an empty string if no moves were timed.
+func bvbThinkTimeLine(stats *bvb.AggregateStats) string {
+	if stats.WhiteAvgThinkTime == 0 && stats.BlackAvgThinkTime == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Avg think time: %s %s | %s %s",
+		stats.WhiteBotName, formatThinkTime(stats.WhiteAvgThinkTime),
+		stats.BlackBotName, formatThinkTime(stats.BlackAvgThinkTime))
+}
+
+// bvbThinkBarWidth is the width of the bar that fills while a bot thinks.
+const bvbThinkBarWidth = 10
+
+// renderBvBThinking renders a line per bot of a BvB game with the time it took
+// for its last move and on average, and a bar beside the bot that is thinking,
+// filling towards its deadline, so slow bots show up as more than a pause.
+func (m Model) renderBvBThinking(session *bvb.GameSession) string {
+	board := session.CurrentBoard()
+	times := session.CurrentMoveTimes()
+	whiteName, blackName := session.Players()
+	names := [2]string{whiteName, blackName}
+
+	// The last move was made by the side not to move, and so on backwards
+	var last, totals [2]time.Duration
+	var counts [2]int
+	color := opponentColor(board.ActiveColor)
+	for i := len(times) - 1; i >= 0; i-- {
+		if counts[color] == 0 {
+			last[color] = times[i]
+		}
+		totals[color] += times[i]
+		counts[color]++
+		color = opponentColor(color)
+	}
+
+	thinking, elapsed, limit, ok := session.Thinking()
+	width := max(lipgloss.Width(whiteName), lipgloss.Width(blackName))
+	lines := make([]string, 0, 2)
+	for _, c := range []engine.Color{engine.White, engine.Black} {
+		label := "White"
+		if c == engine.Black {
+			label = "Black"
+		}
+		line := fmt.Sprintf("%s: %-*s", label, width, names[c])
+		if counts[c] > 0 {
+			line += fmt.Sprintf("  last %s  avg %s", formatThinkTime(last[c]), formatThinkTime(totals[c]/time.Duration(counts[c])))
+		}
+		if ok && thinking == c {
+			filled := min(int(float64(bvbThinkBarWidth)*elapsed.Seconds()/limit.Seconds()), bvbThinkBarWidth)
+			bar := strings.Repeat("█", filled) + strings.Repeat("░", bvbThinkBarWidth-filled)
+			line += fmt.Sprintf("  [%s] thinking %s", bar, formatThinkTime(elapsed))
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,169 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/bvb"
+	"github.com/Mgrdich/TermChess/internal/engine"
+)
+
+// timedGame plays moves from fen, each taking the matching entry of times.
+func timedGame(t *testing.T, fen string, moves []string, times []time.Duration) *engine.Game {
+	t.Helper()
+	board, err := engine.FromFEN(fen)
+	if err != nil {
+		t.Fatalf("FromFEN(%q) error = %v", fen, err)
+	}
+	game := engine.NewGameFrom(board)
+	for i, s := range moves {
+		move, _ := engine.ParseMove(s)
+		if err := game.MakeTimedMove(move, times[i]); err != nil {
+			t.Fatalf("MakeTimedMove(%s) error = %v", s, err)
+		}
+	}
+	return game
+}
+
+func TestPlayMoveTimesEachMove(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.game = engine.NewGame()
+	m.beginGameRecord()
+
+	m.turnStartedAt = time.Now().Add(-3 * time.Second)
+	if err := m.playMove(engine.Move{From: engine.NewSquare(4, 1), To: engine.NewSquare(4, 3)}); err != nil {
+		t.Fatalf("playMove() error = %v", err)
+	}
+	if times := m.game.MoveTimes(); len(times) != 1 || times[0] < 3*time.Second {
+		t.Fatalf("Expected the first move to take at least 3s, got %v", times)
+	}
+	if time.Since(m.turnStartedAt) > time.Second {
+		t.Error("Expected the clock to restart for the next move")
+	}
+
+	// Moves without timings stay aligned with the history
+	_ = m.game.MakeMove(engine.Move{From: engine.NewSquare(4, 6), To: engine.NewSquare(4, 4)})
+	if err := m.playMove(engine.Move{From: engine.NewSquare(6, 0), To: engine.NewSquare(5, 2)}); err != nil {
+		t.Fatalf("playMove() error = %v", err)
+	}
+	if times := m.game.MoveTimes(); len(times) != 3 || times[1] != 0 {
+		t.Errorf("Expected an untimed second move, got %v", times)
+	}
+}
+
+func TestAverageThinkTimes(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	times := []time.Duration{2 * time.Second, time.Second, 4 * time.Second, 0}
+	m.game = timedGame(t, engine.NewBoard().ToFEN(), []string{"e2e4", "e7e5", "g1f3", "b8c6"}, times)
+
+	white, black := m.averageThinkTimes()
+	if white != 3*time.Second || black != time.Second {
+		t.Errorf("averageThinkTimes() = %v, %v, want 3s, 1s", white, black)
+	}
+
+	// Black moves first from a position with Black to move
+	m.game = timedGame(t, "4k3/8/8/8/8/8/4P3/4K3 b - - 0 1", []string{"e8e7", "e1d2", "e7e6", "d2d3"}, times)
+	white, black = m.averageThinkTimes()
+	if white != time.Second || black != 3*time.Second {
+		t.Errorf("averageThinkTimes() = %v, %v, want 1s, 3s", white, black)
+	}
+}
+
+func TestFormatThinkTime(t *testing.T) {
+	tests := map[time.Duration]string{
+		400 * time.Millisecond:        "0.4s",
+		12300 * time.Millisecond:      "12.3s",
+		2*time.Minute + 5*time.Second: "2m05s",
+	}
+	for d, want := range tests {
+		if got := formatThinkTime(d); got != want {
+			t.Errorf("formatThinkTime(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestMoveHistoryShowsMoveTimes(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.game = timedGame(t, engine.NewBoard().ToFEN(), []string{"e2e4", "e7e5"}, []time.Duration{2100 * time.Millisecond, 0})
+
+	if got, want := m.formatMoveHistory(), "Move History: 1. e4 (2.1s) e5"; got != want {
+		t.Errorf("formatMoveHistory() = %q, want %q", got, want)
+	}
+}
+
+func TestGameOverShowsThinkTimes(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.game = timedGame(t, engine.NewBoard().ToFEN(), []string{"e2e4", "e7e5"}, []time.Duration{5 * time.Second, 500 * time.Millisecond})
+	m.gameType = GameTypePvBot
+	m.userColor = engine.White
+	m.screen = ScreenGameOver
+
+	view := m.View()
+	if !strings.Contains(view, "Average think time: White (you) 5.0s | Black (bot) 0.5s") {
+		t.Errorf("Expected the average think times on the game over screen, got:\n%s", view)
+	}
+}
+
+func TestBvBThinkTimeLine(t *testing.T) {
+	stats := &bvb.AggregateStats{WhiteBotName: "Easy Bot", BlackBotName: "Hard Bot"}
+	if got := bvbThinkTimeLine(stats); got != "" {
+		t.Errorf("Expected no line without timings, got %q", got)
+	}
+	stats.WhiteAvgThinkTime = 10 * time.Millisecond
+	stats.BlackAvgThinkTime = 1500 * time.Millisecond
+	if got, want := bvbThinkTimeLine(stats), "Avg think time: Easy Bot 0.0s | Hard Bot 1.5s"; got != want {
+		t.Errorf("bvbThinkTimeLine() = %q, want %q", got, want)
+	}
+}
+
+// stallingEngine is a bot that never finds a move before its game is aborted.
+type stallingEngine struct{}
+
+func (stallingEngine) SelectMove(ctx context.Context, board *engine.Board) (engine.Move, error) {
+	<-ctx.Done()
+	return engine.Move{}, ctx.Err()
+}
+func (stallingEngine) Name() string { return "Stalling" }
+func (stallingEngine) Close() error { return nil }
+
+func TestRenderBvBThinking(t *testing.T) {
+	manager := bvb.NewSessionManager(0, 0, "Easy Bot", "Easy Bot", 1, 1)
+	manager.SetSeed(5)
+	if err := manager.RunHeadless(context.Background(), nil); err != nil {
+		t.Fatalf("RunHeadless() error: %v", err)
+	}
+	m := NewModel(DefaultConfig())
+	finished := m.renderBvBThinking(manager.Sessions()[0])
+	if !strings.Contains(finished, "White: Easy Bot  last ") || !strings.Contains(finished, "Black: Easy Bot  last ") {
+		t.Errorf("Expected the last and average think times of both bots, got:\n%s", finished)
+	}
+	if strings.Contains(finished, "thinking") {
+		t.Errorf("Expected no bot thinking in a finished game, got:\n%s", finished)
+	}
+
+	speed := bvb.SpeedInstant
+	session := bvb.NewGameSession(1, stallingEngine{}, stallingEngine{}, "Slow Bot", "Other Bot", &speed)
+	done := make(chan struct{})
+	go func() {
+		session.Run()
+		close(done)
+	}()
+	defer func() {
+		session.Abort()
+		<-done
+	}()
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(time.Millisecond) {
+		if _, _, _, ok := session.Thinking(); ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("White never started thinking")
+		}
+	}
+	lines := strings.Split(m.renderBvBThinking(session), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], "] thinking ") || strings.Contains(lines[1], "thinking") {
+		t.Errorf("Expected a think bar beside White only, got:\n%s", strings.Join(lines, "\n"))
+	}
+}
@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Messages for the user are shown as toasts: they stack at the bottom of every
+// screen and dismiss themselves after toastDuration.
+
+const (
+	// toastDuration is how long a toast stays on screen
+	toastDuration = 5 * time.Second
+	// maxToasts is the number of toasts shown at once; older ones are dropped
+	maxToasts = 3
+)
+
+// Severity sets how a toast is styled.
+type Severity int
+
+const (
+	// SeverityInfo reports the outcome of an action, such as a saved game
+	SeverityInfo Severity = iota
+	// SeverityWarning asks the user to double-check something
+	SeverityWarning
+	// SeverityError reports an action that failed
+	SeverityError
+)
+
+// toast is a message shown at the bottom of the screen until it expires.
+type toast struct {
+	id       int
+	text     string
+	severity Severity
+}
+
+// toastExpiredMsg is sent when the toast with the given ID has been shown for
+// toastDuration.
+type toastExpiredMsg struct {
+	id int
+}
+
+// notify shows text as a toast of the given severity. A toast repeating one
+// already shown replaces it, so it starts its time on screen over.
+func (m *Model) notify(severity Severity, text string) {
+	m.nextToastID++
+	toasts := make([]toast, 0, len(m.toasts)+1)
+	for _, t := range m.toasts {
+		if t.text != text || t.severity != severity {
+			toasts = append(toasts, t)
+		}
+	}
+	toasts = append(toasts, toast{id: m.nextToastID, text: text, severity: severity})
+	if len(toasts) > maxToasts {
+		toasts = toasts[len(toasts)-maxToasts:]
+	}
+	m.toasts = toasts
+}
+
+// dismissToasts removes the toasts of the given severities, or all toasts if
+// none are given.
+func (m *Model) dismissToasts(severities ...Severity) {
+	if len(m.toasts) == 0 {
+		return
+	}
+	var kept []toast
+	for _, t := range m.toasts {
+		dismiss := len(severities) == 0
+		for _, s := range severities {
+			if t.severity == s {
+				dismiss = true
+				break
+			}
+		}
+		if !dismiss {
+			kept = append(kept, t)
+		}
+	}
+	m.toasts = kept
+}
+
+// expireToast removes the toast with the given ID, if it is still shown.
+func (m *Model) expireToast(id int) {
+	for i, t := range m.toasts {
+		if t.id == id {
+			m.toasts = append(m.toasts[:i:i], m.toasts[i+1:]...)
+			return
+		}
+	}
+}
+
+// scheduleToasts returns a command expiring each toast added since the last
+// call, or nil if there are none.
+func (m *Model) scheduleToasts() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, t := range m.toasts {
+		if t.id <= m.scheduledToastID {
+			continue
+		}
+		id := t.id
+		cmds = append(cmds, tea.Tick(toastDuration, func(time.Time) tea.Msg {
+			return toastExpiredMsg{id: id}
+		}))
+	}
+	m.scheduledToastID = m.nextToastID
+	return tea.Batch(cmds...)
+}
+
+// renderToasts renders the toasts, oldest first, one per line.
+func (m Model) renderToasts() string {
+	if len(m.toasts) == 0 {
+		return ""
+	}
+	lines := make([]string, len(m.toasts))
+	for i, t := range m.toasts {
+		switch t.severity {
+		case SeverityError:
+			lines[i] = m.errorStyle().UnsetPadding().Render("Error: " + t.text)
+		case SeverityWarning:
+			lines[i] = m.statusStyle().UnsetPadding().Bold(true).Render(t.text)
+		default:
+			lines[i] = m.statusStyle().UnsetPadding().Render(t.text)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// withToasts renders the view drawn by render with the toasts below it. Screens
+// fitting themselves to the terminal height are given the height left over.
+func (m Model) withToasts(render func(Model) string) string {
+	toasts := m.renderToasts()
+	if toasts == "" {
+		return render(m)
+	}
+	if m.termHeight > 0 {
+		m.termHeight = max(m.termHeight-lipgloss.Height(toasts)-1, 1)
+	}
+	return strings.TrimRight(render(m), "\n") + "\n\n" + toasts
+}
@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// lastToast returns the text of the newest toast of the given severities, or
+// "" if there is none.
+func lastToast(m Model, severities ...Severity) string {
+	for i := len(m.toasts) - 1; i >= 0; i-- {
+		for _, s := range severities {
+			if m.toasts[i].severity == s {
+				return m.toasts[i].text
+			}
+		}
+	}
+	return ""
+}
+
+// toastError returns the newest error toast of m.
+func toastError(m Model) string {
+	return lastToast(m, SeverityError)
+}
+
+// toastStatus returns the newest info or warning toast of m.
+func toastStatus(m Model) string {
+	return lastToast(m, SeverityInfo, SeverityWarning)
+}
+
+func TestNotifyStacksToasts(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.notify(SeverityInfo, "saved")
+	m.notify(SeverityError, "failed")
+	m.notify(SeverityInfo, "saved")
+
+	if len(m.toasts) != 2 {
+		t.Fatalf("toasts = %v, want the repeated toast replaced", m.toasts)
+	}
+	if m.toasts[0].text != "failed" || m.toasts[1].text != "saved" {
+		t.Errorf("toasts = %v, want the repeated toast moved to the end", m.toasts)
+	}
+
+	for i := 0; i < maxToasts+2; i++ {
+		m.notify(SeverityInfo, fmt.Sprintf("message %d", i))
+	}
+	if len(m.toasts) != maxToasts || m.toasts[maxToasts-1].text != fmt.Sprintf("message %d", maxToasts+1) {
+		t.Errorf("toasts = %v, want the newest %d", m.toasts, maxToasts)
+	}
+}
+
+func TestDismissToastsBySeverity(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.notify(SeverityInfo, "info")
+	m.notify(SeverityWarning, "warning")
+	m.notify(SeverityError, "error")
+
+	m.dismissToasts(SeverityError)
+	if toastError(m) != "" || toastStatus(m) != "warning" {
+		t.Errorf("toasts = %v, want only the error dismissed", m.toasts)
+	}
+	m.dismissToasts()
+	if len(m.toasts) != 0 {
+		t.Errorf("toasts = %v, want all dismissed", m.toasts)
+	}
+}
+
+func TestToastsExpire(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenSettings
+	m.notify(SeverityInfo, "first")
+
+	result, cmd := m.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	m = result.(Model)
+	if cmd == nil {
+		t.Fatal("Update() returned no command to expire the toast")
+	}
+	if _, cmd = m.Update(tea.WindowSizeMsg{Width: 100, Height: 40}); cmd != nil {
+		t.Error("Update() scheduled the same toast twice")
+	}
+
+	m.notify(SeverityInfo, "second")
+	result, _ = m.Update(toastExpiredMsg{id: m.toasts[0].id})
+	m = result.(Model)
+	if len(m.toasts) != 1 || m.toasts[0].text != "second" {
+		t.Errorf("toasts = %v, want only the expired toast removed", m.toasts)
+	}
+}
+
+func TestToastsRenderBelowEveryScreen(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.notify(SeverityInfo, "Game saved")
+	m.notify(SeverityError, "disk full")
+
+	for _, screen := range []Screen{ScreenMainMenu, ScreenSettings, ScreenGameTypeSelect} {
+		m.screen = screen
+		view := ansi.Strip(m.View())
+		lines := strings.Split(view, "\n")
+		if len(lines) < 2 || lines[len(lines)-2] != "Game saved" || lines[len(lines)-1] != "Error: disk full" {
+			t.Errorf("screen %v ends with %q, want the toasts, oldest first", screen, lines[max(len(lines)-2, 0):])
+		}
+	}
+}
+
+func TestToastsLeaveRoomOnFittedScreens(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.termWidth = 100
+	m.termHeight = 30
+	m.showShortcutsOverlay = true
+	m.notify(SeverityInfo, "Game saved")
+
+	view := ansi.Strip(m.View())
+	if lines := strings.Count(view, "\n") + 1; lines > m.termHeight {
+		t.Errorf("view has %d lines, want at most %d", lines, m.termHeight)
+	}
+	if !strings.HasSuffix(view, "Game saved") {
+		t.Errorf("view = %q, want the toast at the bottom", view)
+	}
+}
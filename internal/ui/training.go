@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/bot"
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// pieceNames maps piece types to the names used in training messages.
+var pieceNames = map[engine.PieceType]string{
+	engine.Pawn:   "pawn",
+	engine.Knight: "knight",
+	engine.Bishop: "bishop",
+	engine.Rook:   "rook",
+	engine.Queen:  "queen",
+	engine.King:   "king",
+}
+
+// trainingSquare returns the square typed at the move prompt if it holds one of
+// the user's pieces, so training mode can show that piece's legal moves.
+func (m Model) trainingSquare() (engine.Square, bool) {
+	sq, err := engine.ParseSquare(strings.TrimSpace(strings.ToLower(m.input)))
+	if err != nil {
+		return 0, false
+	}
+	piece := m.game.Board().PieceAt(sq)
+	if piece.IsEmpty() || piece.Color() != m.game.Board().ActiveColor {
+		return 0, false
+	}
+	return sq, true
+}
+
+// showLegalMoves selects the piece on sq, highlighting its legal moves on the
+// board the same way a mouse click does, and lists them in the chosen notation.
+func (m Model) showLegalMoves(sq engine.Square) (tea.Model, tea.Cmd) {
+	m.input = ""
+	m.dismissToasts(SeverityError)
+	m.selectedSquare = &sq
+	m.computeValidMoves()
+	m.blinkOn = true
+
+	var moves []string
+	for _, move := range m.game.Board().LegalMoves() {
+		if move.From == sq {
+			moves = append(moves, m.formatMove(m.game.Board(), move))
+		}
+	}
+
+	name := pieceNames[m.game.Board().PieceAt(sq).Type()]
+	if len(moves) == 0 {
+		m.notify(SeverityInfo, fmt.Sprintf("The %s on %s has no legal moves", name, sq))
+	} else {
+		m.notify(SeverityInfo, fmt.Sprintf("Legal moves for the %s on %s: %s", name, sq, strings.Join(moves, ", ")))
+	}
+	return m, blinkTickCmd()
+}
+
+// holdHangingMove reports whether move should be held back because it hangs a
+// piece. The first time the user tries such a move in training mode a warning is
+// shown instead; trying the same move again plays it.
+func (m *Model) holdHangingMove(move engine.Move) bool {
+	if !m.trainingMode {
+		return false
+	}
+	if m.trainingWarned != nil && *m.trainingWarned == move {
+		m.trainingWarned = nil
+		return false
+	}
+
+	sq, hangs := bot.HangsPiece(m.game.Board(), move)
+	if !hangs {
+		m.trainingWarned = nil
+		return false
+	}
+
+	after := m.game.Board().Copy()
+	_ = after.MakeMove(move)
+	m.trainingWarned = &move
+	m.dismissToasts(SeverityError)
+	m.notify(SeverityWarning, fmt.Sprintf("Careful: %s leaves your %s on %s unprotected - play it again to confirm",
+		m.formatMove(m.game.Board(), move), pieceNames[after.PieceAt(sq).Type()], sq))
+	return true
+}
+
+// handleTakebackCommand handles the "takeback" command in training mode.
+// It undoes the user's last move together with the bot's reply, or only the
+// user's move if the bot is still thinking about it.
+func (m Model) handleTakebackCommand() (tea.Model, tea.Cmd) {
+	m.input = ""
+
+	if !m.trainingMode {
+		m.notify(SeverityError, "Takebacks are only available in training mode")
+		return m, nil
+	}
+
+	plies := 2
+	if m.game.Board().ActiveColor != m.userColor {
+		plies = 1
+	}
+	keep := m.game.MoveCount() - plies
+	if keep < 0 {
+		m.notify(SeverityError, "No move to take back")
+		return m, nil
+	}
+
+	// The bot's pondering was based on the abandoned line, so start it afresh
+	m.stopBotThinking()
+	if m.botEngine != nil {
+		_ = m.botEngine.Close()
+		m.botEngine = nil
+	}
+
+	positions := m.game.Positions()
+	san := m.formatMove(positions[keep], m.game.Moves()[keep])
+	for m.game.MoveCount() > keep {
+		m.game.Undo()
+	}
+	m.turnStartedAt = time.Now()
+	m.premove = nil
+	m.stopCoach()
+	m.trainingWarned = nil
+	m.selectedSquare = nil
+	m.validMoves = nil
+	m.blinkOn = false
+	m.dismissToasts(SeverityError)
+	m.notify(SeverityInfo, fmt.Sprintf("Took back %s", san))
+	return m, nil
+}
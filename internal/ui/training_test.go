@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// trainingGame starts a PvBot game as White with training mode ticked on the
+// color selection screen.
+func trainingGame(t *testing.T) Model {
+	t.Helper()
+	m := NewModel(DefaultConfig())
+	m.gameType = GameTypePvBot
+	m.botDifficulty = BotEasy
+	m.screen = ScreenColorSelect
+	m.menuOptions = []string{"Play as White", "Play as Black"}
+
+	result, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeySpace})
+	m = result.(Model)
+	if !strings.Contains(m.View(), "[x] Training mode") {
+		t.Error("Expected the training mode option to be ticked")
+	}
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if !m.trainingMode {
+		t.Fatal("Expected the game to start in training mode")
+	}
+	return m
+}
+
+// stopTrainingBot stops a bot left thinking at the end of a test.
+func stopTrainingBot(m *Model) {
+	m.stopBotThinking()
+	if m.botEngine != nil {
+		_ = m.botEngine.Close()
+	}
+}
+
+func TestTrainingModeIsPerGame(t *testing.T) {
+	m := trainingGame(t)
+
+	// Any other game start turns training mode off
+	m.beginGameRecord()
+	if m.trainingMode {
+		t.Error("Expected a new game to start without training mode")
+	}
+	if !m.trainingOption {
+		t.Error("Expected the color selection option to be remembered")
+	}
+}
+
+func TestTrainingShowsLegalMoves(t *testing.T) {
+	m := trainingGame(t)
+	defer stopTrainingBot(&m)
+	fenBefore := m.game.Board().ToFEN()
+
+	m, cmd := submitMove(m, "g1")
+	if m.game.Board().ToFEN() != fenBefore {
+		t.Fatal("Expected typing a square not to move anything")
+	}
+	if m.selectedSquare == nil || m.selectedSquare.String() != "g1" || len(m.validMoves) != 2 {
+		t.Errorf("Expected the knight on g1 selected with 2 moves, got %v %v", m.selectedSquare, m.validMoves)
+	}
+	if !strings.Contains(toastStatus(m), "Nf3") || !strings.Contains(toastStatus(m), "Nh3") {
+		t.Errorf("Expected Nf3 and Nh3 to be listed, got %q", toastStatus(m))
+	}
+	if cmd == nil {
+		t.Error("Expected the selection to blink")
+	}
+
+	// Playing a move clears the selection
+	m, _ = submitMove(m, "Nf3")
+	if m.selectedSquare != nil || m.validMoves != nil {
+		t.Error("Expected the selection to be cleared after moving")
+	}
+}
+
+func TestTrainingSquareNeedsTrainingMode(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.game = engine.NewGame()
+	m.screen = ScreenGamePlay
+
+	m, _ = submitMove(m, "g1")
+	if m.selectedSquare != nil || !strings.Contains(toastError(m), "Invalid move") {
+		t.Errorf("Expected a square to be an invalid move outside training mode, got %q", toastError(m))
+	}
+}
+
+func TestTrainingWarnsAboutHangingPiece(t *testing.T) {
+	m := trainingGame(t)
+	defer stopTrainingBot(&m)
+	board, err := engine.FromFEN("rnbqkbnr/pppp1ppp/8/4p3/4P3/8/PPPP1PPP/RNBQKBNR w KQkq - 0 2")
+	if err != nil {
+		t.Fatalf("FromFEN() error = %v", err)
+	}
+	m.game = engine.NewGameFrom(board)
+
+	m, _ = submitMove(m, "Ba6")
+	if m.game.MoveCount() != 0 {
+		t.Fatal("Expected the hanging move to be held back")
+	}
+	if !strings.Contains(toastStatus(m), "bishop on a6") {
+		t.Errorf("Expected a warning about the bishop, got %q", toastStatus(m))
+	}
+	if m.input != "Ba6" {
+		t.Errorf("Expected the move to stay at the prompt, got %q", m.input)
+	}
+
+	// Submitting the same move again plays it anyway
+	m, _ = submitMove(m, m.input)
+	if m.game.MoveCount() != 1 || m.game.Moves()[0].String() != "f1a6" {
+		t.Errorf("Expected Ba6 to be played after confirming, got %v", m.game.Moves())
+	}
+}
+
+func TestTrainingTakeback(t *testing.T) {
+	m := trainingGame(t)
+	defer stopTrainingBot(&m)
+	start := m.game.Board().ToFEN()
+
+	// While the bot is thinking only the user's move is taken back
+	m, _ = submitMove(m, "e4")
+	m, _ = submitMove(m, "takeback")
+	if m.botThinking {
+		t.Error("Expected the bot to stop thinking")
+	}
+	if m.game.MoveCount() != 0 || m.game.Board().ToFEN() != start {
+		t.Fatalf("Expected the starting position, got %q", m.game.Board().ToFEN())
+	}
+	if toastStatus(m) != "Took back e4" {
+		t.Errorf("Unexpected status %q", toastStatus(m))
+	}
+
+	// The interrupted search's move arrives late and is ignored
+	m, _ = botReplies(t, m, "e7e5")
+	if m.game.MoveCount() != 0 {
+		t.Errorf("Expected the stale bot move to be ignored, got %v", m.game.Moves())
+	}
+
+	// After the bot has replied, both moves are taken back
+	m, _ = submitMove(m, "d4")
+	m, _ = botReplies(t, m, "d7d5")
+	m, _ = submitMove(m, "takeback")
+	if m.game.MoveCount() != 0 || m.game.Board().ToFEN() != start {
+		t.Errorf("Expected the starting position, got %q", m.game.Board().ToFEN())
+	}
+
+	m, _ = submitMove(m, "takeback")
+	if toastError(m) != "No move to take back" {
+		t.Errorf("Unexpected error %q", toastError(m))
+	}
+}
+
+func TestTakebackNeedsTrainingMode(t *testing.T) {
+	m := botToMove(t, false)
+	m, _ = submitMove(m, "takeback")
+	if !strings.Contains(toastError(m), "training mode") {
+		t.Errorf("Expected takebacks to be refused, got %q", toastError(m))
+	}
+	if m.game.MoveCount() != 1 {
+		t.Error("Expected the game to be unchanged")
+	}
+}
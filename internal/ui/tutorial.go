@@ -0,0 +1,245 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// tutorialStep is a step of the tutorial game: a move or a command the player
+// is asked for, with why.
+type tutorialStep struct {
+	// expect is the move, in SAN, or the command the step waits for
+	expect string
+	// command is set when expect is a command rather than a move
+	command bool
+	// explain tells the player what to type and why
+	explain string
+	// reply is the opponent's answer to the move, in SAN, or "" for none
+	reply string
+	// done is what the tutor says once the step is done
+	done string
+}
+
+// tutorialSteps is the scripted tutorial game, the Scholar's Mate, with the
+// game commands introduced along the way.
+var tutorialSteps = []tutorialStep{
+	{
+		expect: "e4",
+		explain: "Pawns move straight ahead: one square, or two from their starting square. " +
+			"Start by taking the center: type e4 to move the pawn in front of your king two squares.",
+		reply: "e5",
+		done:  "Black answers e5, taking its share of the center.",
+	},
+	{
+		expect: "Bc4",
+		explain: "Bishops move any distance diagonally. Type Bc4 to bring your bishop out, " +
+			"aiming at f7: next to Black's king, only the king defends it.",
+		reply: "Nc6",
+		done:  "Black brings out a knight with Nc6, defending the pawn on e5.",
+	},
+	{
+		expect:  "showfen",
+		command: true,
+		explain: "Besides moves, you can type commands. showfen shows the position as FEN, a single line " +
+			"you can share or load again with Load Game. Type showfen.",
+		done: "That line is the whole position: the pieces rank by rank, the side to move, castling rights and more.",
+	},
+	{
+		expect: "Qh5",
+		explain: "The queen moves like a rook and a bishop combined. Type Qh5: your queen attacks f7 too, " +
+			"and the bishop supports it.",
+		reply: "Nf6",
+		done:  "Black attacks your queen with Nf6, but misses the threat on f7!",
+	},
+	{
+		expect:  "offerdraw",
+		command: true,
+		explain: "During a game you can offer a draw with offerdraw, and your opponent accepts or declines. Try it.",
+		done:    "Black declines. With the attack you have, you shouldn't settle for a draw anyway.",
+	},
+	{
+		expect: "Qxf7#",
+		explain: "Capture on f7 with your queen: x marks a capture and # checkmate. The bishop protects the queen, " +
+			"so the king can't take it, and it has nowhere to go. Type Qxf7#.",
+		done: "Checkmate! This four-move win is known as the Scholar's Mate.",
+	},
+	{
+		expect:  "resign",
+		command: true,
+		explain: "When a game is lost, you can give up with resign instead of playing on. " +
+			"Type resign to finish the tutorial: in a real game it ends the game as a loss.",
+		done: "Tutorial complete! Play the Easy bot from New Game next, and see Learn on the main menu for the rules and notation.",
+	},
+}
+
+// tutorialSession is the progress through the tutorial game.
+type tutorialSession struct {
+	board *engine.Board
+	// step is the index in tutorialSteps of the step waiting for the player
+	step int
+	// feedback is what the tutor said about the last step done
+	feedback string
+}
+
+// finished reports whether every step of the tutorial is done.
+func (s tutorialSession) finished() bool {
+	return s.step >= len(tutorialSteps)
+}
+
+// expectedMove returns the move the current step waits for, or false if it
+// waits for a command.
+func (s tutorialSession) expectedMove() (engine.Move, bool) {
+	if s.finished() || tutorialSteps[s.step].command {
+		return engine.Move{}, false
+	}
+	move, err := engine.ParseSAN(s.board, tutorialSteps[s.step].expect)
+	return move, err == nil
+}
+
+// startTutorial starts the tutorial game from the starting position.
+func (m Model) startTutorial() (tea.Model, tea.Cmd) {
+	m.tutorial = tutorialSession{board: engine.NewBoard()}
+	m.input = ""
+	m.dismissToasts()
+	m.pushScreen(ScreenTutorial)
+	return m, nil
+}
+
+// handleTutorialKeys handles keyboard input for the tutorial. Typed moves and
+// commands are checked against the step, Enter submits them and ESC leaves.
+func (m Model) handleTutorialKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "esc" {
+		m.input = ""
+		m.dismissToasts()
+		m.popScreen()
+		return m, nil
+	}
+
+	switch msg.Type {
+	case tea.KeyBackspace:
+		if len(m.input) > 0 {
+			m.input = m.input[:len(m.input)-1]
+		}
+		m.dismissToasts(SeverityError)
+	case tea.KeyEnter:
+		if m.tutorial.finished() {
+			m.popScreen()
+			return m, nil
+		}
+		if strings.TrimSpace(m.input) != "" {
+			return m.submitTutorialStep(), nil
+		}
+	case tea.KeyRunes:
+		m.dismissToasts(SeverityError)
+		m.input += string(msg.Runes)
+	}
+	return m, nil
+}
+
+// submitTutorialStep checks the typed input against the current step. The
+// expected input does the step: a move is played along with the opponent's
+// reply. Anything else is explained and the step waits.
+func (m Model) submitTutorialStep() Model {
+	step := tutorialSteps[m.tutorial.step]
+	input := strings.TrimSpace(m.input)
+	m.input = ""
+
+	if step.command {
+		if !strings.EqualFold(input, step.expect) {
+			m.notify(SeverityError, fmt.Sprintf("Type %s to continue", step.expect))
+			return m
+		}
+		m.tutorial.feedback = step.done
+		if step.expect == "showfen" {
+			m.tutorial.feedback = fmt.Sprintf("FEN: %s\n%s", m.tutorial.board.ToFEN(), step.done)
+		}
+		m.tutorial.step++
+		return m
+	}
+
+	board := m.tutorial.board
+	move, err := ParseMoveForgiving(board, input, m.config.Language)
+	if err != nil || !board.IsLegalMove(move) {
+		m.notify(SeverityError, fmt.Sprintf("%s isn't a legal move here - type %s", input, step.expect))
+		return m
+	}
+	if expected, _ := m.tutorial.expectedMove(); move != expected {
+		m.notify(SeverityError, fmt.Sprintf("%s is legal, but this lesson plays %s - try it", engine.FormatSAN(board, move), step.expect))
+		return m
+	}
+
+	_ = board.MakeMove(move)
+	if step.reply != "" {
+		if reply, err := engine.ParseSAN(board, step.reply); err == nil {
+			_ = board.MakeMove(reply)
+		}
+	}
+	m.tutorial.feedback = step.done
+	m.tutorial.step++
+	return m
+}
+
+// renderTutorial renders the tutorial: the board with the piece to move and its
+// destination highlighted, what the tutor said about the last step and what to
+// do next.
+func (m Model) renderTutorial() string {
+	var b strings.Builder
+	s := m.tutorial
+
+	b.WriteString(m.titleStyle().Render("TermChess"))
+	b.WriteString("\n")
+	b.WriteString(m.renderBreadcrumb())
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(m.theme.TitleText).
+		Padding(0, 0, 1, 0)
+	header := "Tutorial - complete"
+	if !s.finished() {
+		header = fmt.Sprintf("Tutorial - step %d of %d", s.step+1, len(tutorialSteps))
+	}
+	b.WriteString(headerStyle.Render(header))
+	b.WriteString("\n")
+
+	renderer := NewBoardRendererWithTheme(m.config, m.theme)
+	renderer.ShowCheck()
+	if move, ok := s.expectedMove(); ok {
+		b.WriteString(renderer.RenderWithSelection(s.board, &move.From, []engine.Square{move.To}, true))
+	} else {
+		b.WriteString(renderer.Render(s.board))
+	}
+	b.WriteString("\n\n")
+
+	width := learnTextWidth
+	if m.termWidth > 0 {
+		width = min(width, m.termWidth-2)
+	}
+	if s.feedback != "" {
+		b.WriteString(lipgloss.NewStyle().Foreground(m.theme.MenuSelected).Width(width).Render(s.feedback))
+		b.WriteString("\n\n")
+	}
+
+	help := "Enter/ESC: back"
+	if !s.finished() {
+		step := tutorialSteps[s.step]
+		b.WriteString(lipgloss.NewStyle().Foreground(m.theme.MenuNormal).Width(width).Render(step.explain))
+		b.WriteString("\n\n")
+		prompt := "Your move: "
+		if step.command {
+			prompt = "Command: "
+		}
+		b.WriteString(lipgloss.NewStyle().Foreground(m.theme.StatusText).Render(prompt) + m.input)
+		help = "type the move or command + Enter | ESC: leave the tutorial"
+	}
+
+	if helpText := m.renderHelpText(help); helpText != "" {
+		b.WriteString("\n\n")
+		b.WriteString(helpText)
+	}
+
+	return b.String()
+}
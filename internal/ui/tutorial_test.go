@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestTutorialScriptIsPlayable(t *testing.T) {
+	board := engine.NewBoard()
+	for i, step := range tutorialSteps {
+		if step.command {
+			continue
+		}
+		move, err := engine.ParseSAN(board, step.expect)
+		if err != nil {
+			t.Fatalf("step %d: %s is not legal: %v", i+1, step.expect, err)
+		}
+		_ = board.MakeMove(move)
+		if step.reply == "" {
+			continue
+		}
+		reply, err := engine.ParseSAN(board, step.reply)
+		if err != nil {
+			t.Fatalf("step %d: reply %s is not legal: %v", i+1, step.reply, err)
+		}
+		_ = board.MakeMove(reply)
+	}
+	if board.Status() != engine.Checkmate {
+		t.Errorf("Expected the tutorial game to end in checkmate, got %s", board.Status())
+	}
+}
+
+func TestTutorialFromGameTypeMenu(t *testing.T) {
+	m := snapshotModel(t)
+	m = pressKeys(m, "n", "up", "enter")
+	if m.screen != ScreenTutorial {
+		t.Fatalf("Expected Tutorial to start the tutorial, got screen %v", m.screen)
+	}
+	if !m.isInTextInputMode() {
+		t.Error("Expected the tutorial to take typed input")
+	}
+
+	m = pressKeys(m, "esc")
+	if m.screen != ScreenGameTypeSelect {
+		t.Errorf("Expected ESC to leave the tutorial, got screen %v", m.screen)
+	}
+}
+
+func TestTutorialValidatesMoves(t *testing.T) {
+	m := snapshotModel(t)
+	m = pressKeys(m, "n", "up", "enter")
+
+	m = pressKeys(m, "d4", "enter")
+	if m.tutorial.step != 0 || !strings.Contains(toastError(m), "lesson plays e4") {
+		t.Errorf("Expected a legal but unexpected move refused, got step %d, %q", m.tutorial.step, toastError(m))
+	}
+	m = pressKeys(m, "e5", "enter")
+	if m.tutorial.step != 0 || !strings.Contains(toastError(m), "isn't a legal move") {
+		t.Errorf("Expected an illegal move refused, got step %d, %q", m.tutorial.step, toastError(m))
+	}
+
+	m = pressKeys(m, "e2e4", "enter")
+	if m.tutorial.step != 1 {
+		t.Fatalf("Expected e4 in any notation accepted, got step %d", m.tutorial.step)
+	}
+	if got := m.tutorial.board.ActiveColor; got != engine.White {
+		t.Errorf("Expected Black's reply played, got %v to move", got)
+	}
+	if toastError(m) != "" {
+		t.Errorf("Expected the error dismissed, got %q", toastError(m))
+	}
+}
+
+func TestTutorialWalkthrough(t *testing.T) {
+	m := snapshotModel(t)
+	m = pressKeys(m, "n", "up", "enter")
+
+	m = pressKeys(m, "e4", "enter", "Bc4", "enter")
+	m = pressKeys(m, "resign", "enter")
+	if m.tutorial.step != 2 || !strings.Contains(toastError(m), "Type showfen") {
+		t.Errorf("Expected the wrong command refused, got step %d, %q", m.tutorial.step, toastError(m))
+	}
+	m = pressKeys(m, "showfen", "enter")
+	if !strings.Contains(m.tutorial.feedback, "FEN: "+m.tutorial.board.ToFEN()) {
+		t.Errorf("Expected showfen to show the FEN, got %q", m.tutorial.feedback)
+	}
+
+	m = pressKeys(m, "Qh5", "enter", "offerdraw", "enter", "Qxf7#", "enter")
+	if m.tutorial.board.Status() != engine.Checkmate {
+		t.Errorf("Expected checkmate, got %s", m.tutorial.board.Status())
+	}
+	m = pressKeys(m, "resign", "enter")
+	if !m.tutorial.finished() {
+		t.Fatalf("Expected the tutorial finished, got step %d", m.tutorial.step)
+	}
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "Tutorial complete!") {
+		t.Errorf("Expected the tutorial complete, got\n%s", view)
+	}
+
+	m = pressKeys(m, "enter")
+	if m.screen != ScreenGameTypeSelect {
+		t.Errorf("Expected Enter to leave the finished tutorial, got screen %v", m.screen)
+	}
+}
@@ -2,8 +2,13 @@ package ui
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,12 +19,17 @@ import (
 	"github.com/Mgrdich/TermChess/internal/updater"
 	"github.com/Mgrdich/TermChess/internal/util"
 	"github.com/Mgrdich/TermChess/internal/version"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 // BvBTickMsg triggers a UI re-render for Bot vs Bot gameplay.
 type BvBTickMsg struct{}
 
+// bvbFrameInterval is the time between re-renders of Bot vs Bot gameplay,
+// 10 frames per second.
+const bvbFrameInterval = 100 * time.Millisecond
+
 // BlinkTickMsg triggers the blink state toggle for selected square highlighting.
 // The blink effect runs at 500ms intervals while a piece is selected.
 type BlinkTickMsg time.Time
@@ -27,11 +37,15 @@ type BlinkTickMsg time.Time
 // BotMoveMsg is sent when the bot has selected a move.
 type BotMoveMsg struct {
 	move engine.Move
+	// game is the simul board the move was chosen for
+	game int
 }
 
 // BotMoveErrorMsg is sent when the bot encounters an error during move selection.
 type BotMoveErrorMsg struct {
 	err error
+	// game is the simul board the move was searched for
+	game int
 }
 
 // UpdateAvailableMsg is sent when a newer version of TermChess is available.
@@ -40,6 +54,10 @@ type UpdateAvailableMsg struct {
 	Version string
 }
 
+// ReloadConfigMsg asks the UI to reload the config file, e.g. on SIGHUP after a
+// dotfile manager updated it. The current game carries on with the new settings.
+type ReloadConfigMsg struct{}
+
 // blinkTickCmd returns a command that sends a BlinkTickMsg after 500ms.
 // Used to create the blinking highlight effect for selected squares.
 func blinkTickCmd() tea.Cmd {
@@ -48,33 +66,66 @@ func blinkTickCmd() tea.Cmd {
 	})
 }
 
-// checkForUpdateCmd returns a command that checks for available updates asynchronously.
-// It queries the GitHub API for the latest release and compares it with the current version.
-// If a newer version is available, it sends an UpdateAvailableMsg.
-// On any error (network, timeout, parsing), it silently returns nil (no message).
-func checkForUpdateCmd() tea.Cmd {
-	return func() tea.Msg {
-		// Don't check for updates in dev builds
-		currentVersion := version.Version
-		if currentVersion == "dev" {
-			return nil
-		}
+// updateCheckTimeout bounds how long an update check waits for GitHub, so it
+// gives up quickly when the machine is offline.
+const updateCheckTimeout = 5 * time.Second
 
-		// Create context with 5 second timeout
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+// updateCheckResultMsg carries the result of the Check for Updates action.
+type updateCheckResultMsg struct {
+	latest string
+	err    error
+}
 
-		// Check for latest version
-		client := updater.NewClient()
-		latestVersion, err := client.CheckLatestVersion(ctx)
-		if err != nil {
-			// Silent failure - don't show any error to user
-			return nil
+// WithOffline returns the model in offline mode, which makes no network
+// requests: updates are not checked for, not even when asked to.
+func (m Model) WithOffline() Model {
+	m.offline = true
+	return m
+}
+
+// fetchLatestVersion queries the GitHub API for the latest release and records
+// the check, so the next startup check can be spaced out.
+func fetchLatestVersion() (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), updateCheckTimeout)
+	defer cancel()
+
+	latestVersion, err := updater.NewClient().CheckLatestVersion(ctx)
+	if err != nil {
+		return "", err
+	}
+	_ = config.SaveUpdateCheck(config.UpdateCheckRecord{CheckedAt: time.Now(), LatestVersion: latestVersion})
+	return latestVersion, nil
+}
+
+// checkForUpdateCmd returns a command that checks for available updates asynchronously
+// on startup, or nil if update checks are turned off, in offline mode and in dev builds,
+// so no network request is made.
+// The latest release is only queried when a check is due under the Update Checks
+// setting; otherwise the one found by the last check is used. If a newer version is
+// available, it sends an UpdateAvailableMsg.
+// On any error (network, timeout, parsing), it silently returns nil (no message).
+func (m Model) checkForUpdateCmd() tea.Cmd {
+	// Don't check for updates in dev builds
+	currentVersion := version.Version
+	if m.offline || m.config.UpdateCheck == config.UpdateCheckOff || currentVersion == "dev" {
+		return nil
+	}
+	frequency := m.config.UpdateCheck
+
+	return func() tea.Msg {
+		record, _ := config.LoadUpdateCheck()
+		latestVersion := record.LatestVersion
+		if config.UpdateCheckDue(frequency, record.CheckedAt, time.Now()) {
+			var err error
+			if latestVersion, err = fetchLatestVersion(); err != nil {
+				// Silent failure - don't show any error to user
+				return nil
+			}
 		}
 
 		// Compare versions using proper semver comparison
 		// Returns 1 if latestVersion > currentVersion
-		if updater.CompareVersions(latestVersion, currentVersion) > 0 {
+		if latestVersion != "" && updater.CompareVersions(latestVersion, currentVersion) > 0 {
 			return UpdateAvailableMsg{Version: latestVersion}
 		}
 
@@ -82,10 +133,62 @@ func checkForUpdateCmd() tea.Cmd {
 	}
 }
 
+// checkForUpdatesNow starts the Check for Updates action, which looks up the
+// latest release whatever the Update Checks setting is. The result is reported
+// by handleUpdateCheckResult.
+func (m *Model) checkForUpdatesNow() tea.Cmd {
+	if m.offline {
+		m.notify(SeverityWarning, "Offline mode, not checking for updates")
+		return nil
+	}
+	if version.Version == "dev" {
+		m.notify(SeverityWarning, "Development builds are not checked for updates")
+		return nil
+	}
+	m.notify(SeverityInfo, "Checking for updates...")
+	return func() tea.Msg {
+		latest, err := fetchLatestVersion()
+		return updateCheckResultMsg{latest: latest, err: err}
+	}
+}
+
+// handleUpdateCheckResult reports the result of the Check for Updates action.
+func (m Model) handleUpdateCheckResult(msg updateCheckResultMsg) (tea.Model, tea.Cmd) {
+	m.dismissToasts(SeverityInfo)
+	switch {
+	case msg.err != nil:
+		m.notify(SeverityError, fmt.Sprintf("Failed to check for updates: %v", msg.err))
+	case updater.CompareVersions(msg.latest, version.Version) > 0:
+		m.updateAvailable = msg.latest
+		m.notify(SeverityInfo, fmt.Sprintf("Update available: %s (current: %s)", msg.latest, version.Version))
+	default:
+		m.updateAvailable = ""
+		m.notify(SeverityInfo, fmt.Sprintf("TermChess %s is up to date", version.Version))
+	}
+	return m, nil
+}
+
 // Init initializes the model. Called once at program start.
-// Returns a command to check for updates asynchronously.
+// Returns a command to check for updates asynchronously and set the window
+// title, and lets the bot move first if the program was launched into a bot game
+// on the bot's turn.
 func (m Model) Init() tea.Cmd {
-	return checkForUpdateCmd()
+	return m.guardCmd(m.initCmd())
+}
+
+// initCmd returns the commands Init starts with.
+func (m Model) initCmd() tea.Cmd {
+	if m.screen == ScreenWatch {
+		return m.nextWatchSnapshotCmd()
+	}
+	var title tea.Cmd
+	if !m.noWindowTitle {
+		title = tea.SetWindowTitle(m.terminalTitle)
+	}
+	if m.isBotTurn() {
+		return tea.Batch(m.checkForUpdateCmd(), title, func() tea.Msg { return botTurnMsg{} })
+	}
+	return tea.Batch(m.checkForUpdateCmd(), title)
 }
 
 // Update handles incoming messages and updates the model state.
@@ -93,23 +196,89 @@ func (m Model) Init() tea.Cmd {
 // It takes a message (user input, events, etc.) and returns an updated model
 // and optionally a command to execute.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	defer m.recoverCrash()
+	logged := msg
+	if _, ok := msg.(tea.KeyMsg); ok && m.EnteringPassphrase() {
+		// Keep the passphrase out of crash reports and the debug panel
+		logged = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("*")}
+	}
+	m.crash.record(logged)
+	if m.debug != nil {
+		m.debug.record(logged, time.Now())
+	}
+	next, cmd := m.update(msg)
+	model, ok := next.(Model)
+	if !ok {
+		return next, cmd
+	}
+	// Spinner frames change nothing spectators can see
+	if _, ok := msg.(spinner.TickMsg); !ok {
+		model.publishSpectator()
+	}
+	if expire := model.scheduleToasts(); expire != nil {
+		cmd = tea.Batch(cmd, expire)
+	}
+	if reveal := model.scheduleMateReveal(); reveal != nil {
+		cmd = tea.Batch(cmd, reveal)
+	}
+	if terminal := model.syncTerminal(); terminal != nil {
+		cmd = tea.Batch(cmd, terminal)
+	}
+	return model, model.guardCmd(cmd)
+}
+
+// update routes a message to its handler.
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		return m.handleKeyPress(msg)
+	case toastExpiredMsg:
+		m.expireToast(msg.id)
+		return m, nil
 	case tea.WindowSizeMsg:
 		m.termWidth = msg.Width
 		m.termHeight = msg.Height
-		// Adjust BvB grid for new terminal width if in BvB gameplay
+		// Adjust BvB grid for new terminal size if in BvB gameplay
 		if m.screen == ScreenBvBGamePlay && m.bvbViewMode == BvBGridView {
-			m.adjustBvBGridForWidth()
+			if m.bvbGridAuto {
+				m.layoutBvBGridAuto()
+			} else {
+				m.adjustBvBGridForWidth()
+			}
 		}
 		return m, nil
+	case ReloadConfigMsg:
+		m.reloadConfig()
+		return m, nil
 	case BvBTickMsg:
 		return m.handleBvBTick()
 	case BotMoveMsg:
 		return m.handleBotMove(msg)
 	case BotMoveErrorMsg:
 		return m.handleBotMoveError(msg)
+	case botTurnMsg:
+		if !m.isBotTurn() {
+			return m, nil
+		}
+		return m.makeBotMove()
+	case AnalysisDoneMsg:
+		return m.handleAnalysisDone(msg)
+	case AnalysisErrorMsg:
+		return m.handleAnalysisError(msg)
+	case GuessScoredMsg:
+		return m.handleGuessScored(msg)
+	case GuessScoreErrorMsg:
+		return m.handleGuessScoreError(msg)
+	case CoachCommentMsg:
+		return m.handleCoachComment(msg)
+	case spinner.TickMsg:
+		// Only keep the spinner animating while the bot is thinking or the engine is evaluating
+		if !m.botThinking && !m.analyzing && !m.guess.evaluating {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.botSpinner, cmd = m.botSpinner.Update(msg)
+		return m, cmd
 	case tea.MouseMsg:
 		// Only handle mouse in interactive game modes (not Bot vs Bot)
 		if m.screen == ScreenGamePlay && m.gameType != GameTypeBvB {
@@ -124,10 +293,25 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.blinkOn = false
 		return m, nil
+	case MoveFlashTickMsg:
+		return m.handleMoveFlashTick(msg)
+	case mateRevealDoneMsg:
+		m.finishMateReveal()
+		return m, nil
+	case watchSnapshotMsg:
+		return m.handleWatchSnapshot(msg)
+	case watchEndedMsg:
+		return m.handleWatchEnded(msg)
 	case UpdateAvailableMsg:
 		// Store the available update version for display in main menu
 		m.updateAvailable = msg.Version
 		return m, nil
+	case updateCheckResultMsg:
+		return m.handleUpdateCheckResult(msg)
+	case upgradeProgressMsg:
+		return m.handleUpgradeProgress(msg)
+	case upgradeDoneMsg:
+		return m.handleUpgradeDone(msg)
 	}
 
 	return m, nil
@@ -137,73 +321,118 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 // Global keys like quit are handled first, then screen-specific keys are delegated
 // to the current screen's handler.
 func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	// If shortcuts overlay is showing, any key dismisses it
+	// Pasted text only goes to text inputs, as one piece of text rather than keys
+	if msg.Paste {
+		var ok bool
+		if msg, ok = m.handlePaste(msg); !ok {
+			return m, nil
+		}
+	}
+
+	// The debug panel can be toggled whatever else is going on
+	if msg.String() == debugKey {
+		m.toggleDebug()
+		return m, nil
+	}
+
+	// Any key skips the rest of a checkmate reveal
+	if m.mateReveal && msg.String() != "ctrl+c" {
+		m.finishMateReveal()
+		return m, nil
+	}
+
+	// While the command palette is open, it receives every key
+	if m.showCommandPalette {
+		return m.handleCommandPaletteKeys(msg)
+	}
+
+	// If shortcuts overlay is showing, the scroll keys scroll it and any other key dismisses it
 	if m.showShortcutsOverlay {
-		m.showShortcutsOverlay = false
+		body, footer := m.shortcutsOverlayParts()
+		if !m.scrollContent(msg, body, m.shortcutsScrollHeight(footer), true) {
+			m.showShortcutsOverlay = false
+		}
+		return m, nil
+	}
+
+	// Likewise for the Bot vs Bot session log
+	if m.showBvBLog {
+		body, footer := m.bvbLogParts()
+		if !m.scrollContent(msg, body, m.shortcutsScrollHeight(footer), true) {
+			m.showBvBLog = false
+		}
 		return m, nil
 	}
 
-	// Handle '?' key to toggle shortcuts overlay (only when not in text input mode)
-	if msg.String() == "?" && !m.isInTextInputMode() {
+	// While capturing a new key binding, every key goes to the Key Bindings screen
+	if m.screen == ScreenKeyBindings && m.keyBindingCapture {
+		return m.handleKeyBindingsKeys(msg)
+	}
+
+	// The pass-the-keyboard screen only waits for the next player to be ready
+	if m.screen == ScreenHandoff && msg.String() != "ctrl+c" {
+		return m.handleHandoffKeys(msg)
+	}
+
+	// Watching another instance is read-only, so no global action applies
+	if m.screen == ScreenWatch {
+		return m.handleWatchKeys(msg)
+	}
+
+	// The upgrade can only be cancelled or left once it's done
+	if m.screen == ScreenUpgrade && msg.String() != "ctrl+c" {
+		return m.handleUpgradeKeys(msg)
+	}
+
+	// The crashed game is restored or discarded before anything else
+	if m.screen == ScreenCrashRecovery && msg.String() != "ctrl+c" {
+		return m.handleCrashRecoveryKeys(msg)
+	}
+
+	// Every key but ctrl+c is part of the passphrase
+	if m.screen == ScreenPassphrase && msg.String() != "ctrl+c" {
+		return m.handlePassphraseKeys(msg)
+	}
+
+	// Open the command palette (printable keys only outside text input mode)
+	if m.keys.Matches(msg, ActionCommandPalette) && (msg.Type != tea.KeyRunes || !m.isInTextInputMode()) {
+		return m.openCommandPalette(), nil
+	}
+
+	// Handle help key to toggle shortcuts overlay (only when not in text input mode)
+	if m.keys.Matches(msg, ActionHelp) && !m.isInTextInputMode() {
 		m.showShortcutsOverlay = true
+		m.scrollOffset = 0
 		return m, nil
 	}
 
-	// Handle 'n' key globally for new game (only when not in text input mode)
-	if msg.String() == "n" && !m.isInTextInputMode() {
-		// Don't trigger if already on game type select, in active game, or game over
-		if m.screen != ScreenGameTypeSelect && m.screen != ScreenGamePlay && m.screen != ScreenGameOver &&
-			m.screen != ScreenBvBGamePlay && m.screen != ScreenBvBStats {
-			m.pushScreen(ScreenGameTypeSelect)
-			m.menuOptions = []string{"Player vs Player", "Player vs Bot", "Bot vs Bot"}
-			m.menuSelection = 0
-			m.statusMsg = ""
-			m.errorMsg = ""
-			return m, nil
-		}
+	// Handle new game key globally (only when not in text input mode)
+	if m.keys.Matches(msg, ActionNewGame) && !m.isInTextInputMode() && m.canStartNewGame() {
+		m.openNewGame()
+		return m, nil
 	}
 
-	// Handle 's' key globally for settings (only when not in text input mode)
-	// Exception: On BvB stats screen, 's' is used for export instead of settings
-	if msg.String() == "s" && !m.isInTextInputMode() {
+	// Handle settings key globally (only when not in text input mode)
+	// Exception: On BvB stats screen, the export key is used instead of settings
+	if m.keys.Matches(msg, ActionSettings) && !m.isInTextInputMode() {
 		// Don't trigger if already on settings or on BvB stats screen (where 's' means export)
-		if m.screen != ScreenSettings && m.screen != ScreenBvBStats {
-			m.pushScreen(ScreenSettings)
-			m.settingsSelection = 0
-			m.statusMsg = ""
-			m.errorMsg = ""
+		if m.screen != ScreenSettings && m.screen != ScreenKeyBindings && m.screen != ScreenBvBStats {
+			m.openSettings()
 			return m, nil
 		}
 	}
 
 	// Handle global quit keys (work from any screen except GamePlay where 'q' shows save prompt)
-	switch msg.String() {
-	case "ctrl+c":
-		// Clean up bot engine if it exists
-		if m.botEngine != nil {
-			_ = m.botEngine.Close()
-		}
-		// Clean up BvB manager if running
-		if m.bvbManager != nil {
-			m.bvbManager.Abort()
-			m.bvbManager = nil
-		}
-		return m, tea.Quit
-	case "q":
-		// Only quit directly if not in GamePlay screen
-		if m.screen != ScreenGamePlay {
-			// Clean up bot engine if it exists
-			if m.botEngine != nil {
-				_ = m.botEngine.Close()
-			}
-			// Clean up BvB manager if running
-			if m.bvbManager != nil {
-				m.bvbManager.Abort()
-				m.bvbManager = nil
-			}
-			return m, tea.Quit
+	switch {
+	case msg.String() == "ctrl+c":
+		return m.quit()
+	case m.keys.Matches(msg, ActionQuit):
+		// Only quit directly if not in GamePlay screen or typing an opponent's name or a guess
+		if m.screen != ScreenGamePlay && m.screen != ScreenCorrespondenceNew && m.screen != ScreenGuessMove && m.screen != ScreenTutorial &&
+			m.screen != ScreenGameHistory {
+			return m.quit()
 		}
-		// Otherwise, let the GamePlay handler deal with it
+		// Otherwise, let the screen handler deal with it
 	}
 
 	// Handle screen-specific keys based on current screen
@@ -214,6 +443,12 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleGameTypeSelectKeys(msg)
 	case ScreenBotSelect:
 		return m.handleBotSelectKeys(msg)
+	case ScreenHandicapSelect:
+		return m.handleHandicapSelectKeys(msg)
+	case ScreenCorrespondenceList:
+		return m.handleCorrespondenceListKeys(msg)
+	case ScreenCorrespondenceNew:
+		return m.handleCorrespondenceNewKeys(msg)
 	case ScreenColorSelect:
 		return m.handleColorSelectKeys(msg)
 	case ScreenFENInput:
@@ -222,6 +457,20 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleGamePlayKeys(msg)
 	case ScreenGameOver:
 		return m.handleGameOverKeys(msg)
+	case ScreenMatchSummary:
+		return m.handleMatchSummaryKeys(msg)
+	case ScreenSimulSetup:
+		return m.handleSimulSetupKeys(msg)
+	case ScreenGuessSelect:
+		return m.handleGuessSelectKeys(msg)
+	case ScreenGuessMove:
+		return m.handleGuessMoveKeys(msg)
+	case ScreenLearn:
+		return m.handleLearnKeys(msg)
+	case ScreenTutorial:
+		return m.handleTutorialKeys(msg)
+	case ScreenGameHistory:
+		return m.handleGameHistoryKeys(msg)
 	case ScreenSettings:
 		return m.handleSettingsKeys(msg)
 	case ScreenSavePrompt:
@@ -242,111 +491,168 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleBvBViewModeSelectKeys(msg)
 	case ScreenBvBConcurrencySelect:
 		return m.handleBvBConcurrencySelectKeys(msg)
+	case ScreenAnalysis:
+		return m.handleAnalysisKeys(msg)
+	case ScreenKeyBindings:
+		return m.handleKeyBindingsKeys(msg)
+	case ScreenProfileSelect:
+		return m.handleProfileSelectKeys(msg)
 	default:
 		// Other screens will be implemented in future tasks
 		return m, nil
 	}
 }
 
+// canStartNewGame reports whether the new game shortcut is available on the current screen.
+// It is disabled on game type select itself, during games, on game over and
+// result screens and on My Games, which have their own actions.
+func (m Model) canStartNewGame() bool {
+	switch m.screen {
+	case ScreenGameTypeSelect, ScreenGamePlay, ScreenGameOver, ScreenBvBGamePlay, ScreenBvBStats, ScreenAnalysis, ScreenCorrespondenceList, ScreenGuessMove, ScreenTutorial, ScreenGameHistory:
+		return false
+	default:
+		return true
+	}
+}
+
+// openNewGame navigates to the game type selection screen.
+func (m *Model) openNewGame() {
+	m.pushScreen(ScreenGameTypeSelect)
+	m.menuOptions = gameTypeOptions()
+	m.menuSelection = 0
+	m.dismissToasts()
+}
+
+// openSettings navigates to the settings screen.
+func (m *Model) openSettings() {
+	m.pushScreen(ScreenSettings)
+	m.settingsSelection = 0
+	m.dismissToasts()
+}
+
+// quit closes any running bot engine and Bot vs Bot session and exits the application.
+func (m Model) quit() (tea.Model, tea.Cmd) {
+	// Clean up bot engine if it exists
+	if m.botEngine != nil {
+		_ = m.botEngine.Close()
+	}
+	// Clean up BvB manager if running
+	if m.bvbManager != nil {
+		m.bvbManager.Abort()
+		m.bvbManager = nil
+	}
+	return m, tea.Quit
+}
+
 // handleMainMenuKeys handles keyboard input for the main menu screen.
 // Supports arrow keys and vi-style navigation (j/k), Enter to select,
 // and wraps around at top and bottom of the menu.
 func (m Model) handleMainMenuKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Clear any previous error or status messages when user takes action
-	m.errorMsg = ""
-	m.statusMsg = ""
-
-	switch msg.String() {
-	case "up", "k":
-		// Move selection up
-		if m.menuSelection > 0 {
-			m.menuSelection--
-		} else {
-			// Wrap to bottom of menu
-			m.menuSelection = len(m.menuOptions) - 1
-		}
+	m.dismissToasts()
 
-	case "down", "j":
-		// Move selection down
-		if m.menuSelection < len(m.menuOptions)-1 {
-			m.menuSelection++
-		} else {
-			// Wrap to top of menu
-			m.menuSelection = 0
-		}
-
-	case "enter":
-		return m.handleMainMenuSelection()
+	// Install the update the main menu announces
+	if msg.String() == "u" && m.updateAvailable != "" {
+		return m.startUpgrade()
 	}
 
-	return m, nil
+	return m.updateMenu(msg)
 }
 
-// handleMainMenuSelection executes the action for the currently selected menu option.
-// Handles all main menu options including the dynamic "Resume Game" option.
-func (m Model) handleMainMenuSelection() (tea.Model, tea.Cmd) {
-	selected := m.menuOptions[m.menuSelection]
-
-	switch selected {
-	case "Resume Game":
-		// Load the saved game and start gameplay
-		board, err := config.LoadGame()
-		if err != nil {
-			// Failed to load - show error and stay on main menu
-			m.errorMsg = fmt.Sprintf("Failed to load saved game: %v", err)
-			return m, nil
+// resumeSavedGame loads the saved game and starts gameplay from it. A Player vs
+// Bot game is resumed against the same bot, which moves right away if it is its turn.
+func (m Model) resumeSavedGame() (tea.Model, tea.Cmd) {
+	board, err := config.LoadGame()
+	if err == nil {
+		var info config.SavedGameInfo
+		if info, err = config.LoadGameInfo(); err == nil {
+			m.restoreGameInfo(info)
 		}
+	}
+	if errors.Is(err, config.ErrPassphraseRequired) {
+		// Encrypted since TermChess started, e.g. synced from another machine
+		m.openPassphrasePrompt(false)
+		return m, nil
+	}
+	if err != nil {
+		// Failed to load - show error and stay on main menu
+		m.notify(SeverityError, fmt.Sprintf("Failed to load saved game: %v", err))
+		return m, nil
+	}
+	return m.resumeGame(board, "Game resumed")
+}
 
-		// Successfully loaded - start gameplay with loaded board
-		m.board = board
-		m.moveHistory = []engine.Move{}
-		m.clearNavStack() // Clear nav stack when starting game
-		m.screen = ScreenGamePlay
-		m.input = ""
-		m.errorMsg = ""
-		m.statusMsg = "Game resumed"
-		m.resignedBy = -1
-		// Reset draw offer state
-		m.drawOfferedBy = -1
-		m.drawOfferedByWhite = false
-		m.drawOfferedByBlack = false
-		m.drawByAgreement = false
-
-	case "Exit":
-		return m, tea.Quit
+// resumeGame starts gameplay from board, set up by restoreGameInfo, announcing
+// it with status. The bot moves right away if it is its turn.
+func (m Model) resumeGame(board *engine.Board, status string) (tea.Model, tea.Cmd) {
+	m.game = engine.NewGameFrom(board)
+	m.beginGameRecord()
+	m.loadSavedNotes()
+	m.clearNavStack() // Clear nav stack when starting game
+	m.screen = ScreenGamePlay
+	m.input = ""
+	m.dismissToasts(SeverityError)
+	m.notify(SeverityInfo, status)
+	// Reset draw offer state
+	m.drawOfferedBy = -1
+	m.drawOfferedByWhite = false
+	m.drawOfferedByBlack = false
 
-	case "New Game":
-		// Transition to game type selection screen using navigation stack
-		m.pushScreen(ScreenGameTypeSelect)
-		// Set up menu options for game type selection
-		m.menuOptions = []string{"Player vs Player", "Player vs Bot", "Bot vs Bot"}
-		m.menuSelection = 0
-		// Clear any previous status messages
-		m.statusMsg = ""
-		m.errorMsg = ""
-		// Clear any previous input
-		m.input = ""
+	if m.isBotTurn() {
+		return m.makeBotMove()
+	}
+	return m, nil
+}
 
-	case "Load Game":
-		// Transition to FEN input screen using navigation stack
-		m.pushScreen(ScreenFENInput)
-		// Reset and focus the text input
-		m.fenInput.SetValue("")
-		m.fenInput.Focus()
-		// Clear any previous status messages
-		m.statusMsg = ""
-		m.errorMsg = ""
+// savedGameInfo describes the current game for resuming it later.
+func (m Model) savedGameInfo() config.SavedGameInfo {
+	if m.gameType != GameTypePvBot {
+		return config.SavedGameInfo{GameType: "pvp"}
+	}
+	info := config.SavedGameInfo{
+		GameType:      "pvbot",
+		UserColor:     "white",
+		BotDifficulty: strings.ToLower(m.botDifficulty.String()),
+		BotName:       m.botName,
+	}
+	if m.userColor == engine.Black {
+		info.UserColor = "black"
+	}
+	return info
+}
 
-	case "Settings":
-		// Transition to settings screen using navigation stack
-		m.pushScreen(ScreenSettings)
-		m.settingsSelection = 0
-		// Clear any previous status messages
-		m.statusMsg = ""
-		m.errorMsg = ""
+// restoreGameInfo sets up the game type, the user's color and the bot of a
+// saved game. A registered bot that is no longer available is replaced by the
+// built-in bot of its difficulty.
+func (m *Model) restoreGameInfo(info config.SavedGameInfo) {
+	// Discard any engine left over from a previous game; the next bot move creates the saved one
+	if m.botEngine != nil {
+		_ = m.botEngine.Close()
+		m.botEngine = nil
 	}
+	m.botHopelessTurns = 0
+	m.handicap = engine.NoHandicap
+	m.trainingMode = false
+	m.match = matchScore{}
 
-	return m, nil
+	if info.GameType != "pvbot" {
+		m.gameType = GameTypePvP
+		return
+	}
+	m.gameType = GameTypePvBot
+	m.userColor = engine.White
+	if strings.EqualFold(info.UserColor, "black") {
+		m.userColor = engine.Black
+	}
+	m.botDifficulty, _ = bot.ParseDifficulty(info.BotDifficulty)
+	m.botName = ""
+	if info.BotName != "" {
+		if slices.Contains(bot.Registered(), info.BotName) {
+			m.botName = info.BotName
+		} else {
+			m.notify(SeverityWarning, fmt.Sprintf("Bot %q is not available, playing the %s Bot instead", info.BotName, m.botDifficulty))
+		}
+	}
 }
 
 // handleGameTypeSelectKeys handles keyboard input for the game type selection screen.
@@ -354,91 +660,46 @@ func (m Model) handleMainMenuSelection() (tea.Model, tea.Cmd) {
 // ESC to return to main menu, and wraps around at top and bottom of the menu.
 func (m Model) handleGameTypeSelectKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Clear any previous error or status messages when user takes action
-	m.errorMsg = ""
-	m.statusMsg = ""
-
-	switch msg.String() {
-	case "up", "k":
-		// Move selection up
-		if m.menuSelection > 0 {
-			m.menuSelection--
-		} else {
-			// Wrap to bottom of menu
-			m.menuSelection = len(m.menuOptions) - 1
-		}
+	m.dismissToasts()
 
-	case "down", "j":
-		// Move selection down
-		if m.menuSelection < len(m.menuOptions)-1 {
-			m.menuSelection++
-		} else {
-			// Wrap to top of menu
-			m.menuSelection = 0
-		}
-
-	case "enter":
-		return m.handleGameTypeSelection()
-
-	case "esc":
+	if m.keys.Matches(msg, ActionBack) {
 		// Return to previous screen using navigation stack
 		// popScreen() handles menu state restoration
 		m.popScreen()
-		m.statusMsg = ""
+		m.dismissToasts(SeverityInfo, SeverityWarning)
+		return m, nil
 	}
 
-	return m, nil
+	return m.updateMenu(msg)
 }
 
-// handleGameTypeSelection executes the action for the currently selected game type option.
-// "Player vs Player" starts a new PvP game.
-// "Player vs Bot" shows a "coming soon" message and returns to main menu.
-func (m Model) handleGameTypeSelection() (tea.Model, tea.Cmd) {
-	selected := m.menuOptions[m.menuSelection]
+// startPvPGame starts a new Player vs Player game from the standard starting position.
+func (m Model) startPvPGame() (tea.Model, tea.Cmd) {
+	return m.startPvPGameVariant(nil)
+}
 
-	switch selected {
-	case "Player vs Player":
-		// Set game type to PvP
-		m.gameType = GameTypePvP
-		// Create a new board with the standard starting position
-		m.board = engine.NewBoard()
-		// Clear nav stack when starting game
-		m.clearNavStack()
-		// Switch to the GamePlay screen
-		m.screen = ScreenGamePlay
-		// Clear any previous status messages
-		m.statusMsg = ""
-		m.errorMsg = ""
-		// Clear any previous input
-		m.input = ""
-		// Reset resignation tracking
-		m.resignedBy = -1
-		// Reset draw offer state
-		m.drawOfferedBy = -1
-		m.drawOfferedByWhite = false
-		m.drawOfferedByBlack = false
-		m.drawByAgreement = false
-
-	case "Player vs Bot":
-		// Set game type to PvBot
-		m.gameType = GameTypePvBot
-		// Transition to bot difficulty selection screen using navigation stack
-		m.pushScreen(ScreenBotSelect)
-		m.menuOptions = []string{"Easy", "Medium", "Hard"}
-		m.menuSelection = 0
-		m.statusMsg = ""
-		m.errorMsg = ""
-
-	case "Bot vs Bot":
-		// Set game type to BvB
-		m.gameType = GameTypeBvB
-		// Start with selecting White bot difficulty
-		m.bvbSelectingWhite = true
-		m.pushScreen(ScreenBvBBotSelect)
-		m.menuOptions = []string{"Easy", "Medium", "Hard"}
-		m.menuSelection = 0
-		m.statusMsg = ""
-		m.errorMsg = ""
-	}
+// startPvPGameVariant starts a new Player vs Player game of the given variant,
+// nil for standard chess.
+func (m Model) startPvPGameVariant(variant engine.Variant) (tea.Model, tea.Cmd) {
+	// Set game type to PvP
+	m.gameType = GameTypePvP
+	// Create a new board with the standard starting position
+	board := engine.NewBoard()
+	board.Variant = variant
+	m.game = engine.NewGameFrom(board)
+	m.beginGameRecord()
+	// Clear nav stack when starting game
+	m.clearNavStack()
+	// Switch to the GamePlay screen
+	m.screen = ScreenGamePlay
+	// Clear any previous status messages
+	m.dismissToasts()
+	// Clear any previous input
+	m.input = ""
+	// Reset draw offer state
+	m.drawOfferedBy = -1
+	m.drawOfferedByWhite = false
+	m.drawOfferedByBlack = false
 
 	return m, nil
 }
@@ -447,14 +708,23 @@ func (m Model) handleGameTypeSelection() (tea.Model, tea.Cmd) {
 // Supports text input for entering chess moves in coordinate notation (e.g., "e2e4").
 // Regular characters are appended to input, backspace deletes, and enter submits.
 func (m Model) handleGamePlayKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	// Check for 'q' key to show save prompt
-	if msg.String() == "q" || msg.String() == "Q" {
+	// Correspondence games are saved after every move, so there is nothing to prompt for
+	if m.gameType == GameTypeCorrespondence {
+		switch {
+		case m.keys.Matches(msg, ActionQuit):
+			return m, tea.Quit
+		case m.keys.Matches(msg, ActionLeaveGame):
+			return m.leaveCorrespondenceGame()
+		}
+	}
+
+	// Check for 'q' key to show save prompt, unless it is part of a note
+	if (msg.String() == "q" || msg.String() == "Q") && !m.typingNote() {
 		// Show save prompt
 		m.screen = ScreenSavePrompt
 		m.savePromptSelection = 0
 		m.savePromptAction = "exit"
-		m.errorMsg = ""
-		m.statusMsg = ""
+		m.dismissToasts()
 		return m, nil
 	}
 
@@ -464,81 +734,122 @@ func (m Model) handleGamePlayKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.screen = ScreenSavePrompt
 		m.savePromptSelection = 0
 		m.savePromptAction = "menu"
-		m.errorMsg = ""
-		m.statusMsg = ""
+		m.dismissToasts()
 		return m, nil
 	}
 
 	switch msg.Type {
+	case tea.KeyTab:
+		// Tab fills in the highlighted completion of the typed move
+		m = m.acceptCompletion()
+
+	case tea.KeyLeft:
+		m = m.cycleCompletion(-1)
+
+	case tea.KeyRight:
+		m = m.cycleCompletion(1)
+
+	case tea.KeyUp:
+		// Recall earlier entries, like a shell
+		m.recallInput(-1)
+
+	case tea.KeyDown:
+		m.recallInput(1)
+
 	case tea.KeyBackspace:
+		m.completionIndex = 0
+		m.stopBrowsingInput()
 		// Remove the last character from input
 		if len(m.input) > 0 {
 			m.input = m.input[:len(m.input)-1]
+		} else if m.premove != nil {
+			// Backspace on an empty prompt cancels the queued pre-move
+			m.premove = nil
+			m.notify(SeverityInfo, "Pre-move cancelled")
 		}
 		// Clear error messages when user modifies input
-		m.errorMsg = ""
+		m.dismissToasts(SeverityError)
 
 	case tea.KeyEnter:
 		// Parse and execute the move or command if input is not empty
 		if m.input != "" {
+			m.rememberInput(m.input)
 			return m.handleGamePlayInput()
 		}
+		// Enter on an empty prompt tells a thinking bot to move now
+		return m.interruptBotMove(), nil
 
 	case tea.KeyRunes:
+		// A number key on an empty prompt switches simul boards
+		if i, ok := m.simulBoardKey(msg); ok && m.input == "" {
+			m.switchSimulGame(i)
+			return m, nil
+		}
 		// Clear error messages when user starts typing a new move
-		m.errorMsg = ""
+		m.dismissToasts(SeverityError)
 		// Append the typed character(s) to the input
 		// Only allow alphanumeric characters and basic symbols
 		m.input += string(msg.Runes)
+		m.completionIndex = 0
+		m.stopBrowsingInput()
+
+	case tea.KeySpace:
+		// Spaces separate the words of a note; moves and other commands have none
+		if m.typingNote() {
+			m.input += " "
+			m.stopBrowsingInput()
+		}
 	}
 
 	return m, nil
 }
 
 // handleGameOverKeys handles keyboard input for the GameOver screen.
-// Supports 'n' for new game, 'm' for main menu, 'esc' for main menu, and 'q' for quit.
+// Supports the game over menu, plus 'n' for new game, 'm' or 'esc' for main menu,
+// 'a' to analyze, 'r' to rematch, 'q' to quit and 1-9 to switch simul boards.
 func (m Model) handleGameOverKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "n", "N":
+	// Look through the finished boards of a simul
+	if i, ok := m.simulBoardKey(msg); ok {
+		m.switchSimulGame(i)
+		return m, nil
+	}
+
+	switch {
+	case m.keys.Matches(msg, ActionNewGame):
 		// Clean up bot engine if it exists
 		if m.botEngine != nil {
 			_ = m.botEngine.Close()
 			m.botEngine = nil
 		}
+		m.endSimul()
 		// Start a new game - go through game type selection
-		m.board = nil
-		m.moveHistory = []engine.Move{}
+		m.game = nil
 		m.screen = ScreenGameTypeSelect
 		m.input = ""
-		m.errorMsg = ""
-		m.statusMsg = ""
+		m.dismissToasts()
 		// Set up menu options for game type selection
-		m.menuOptions = []string{"Player vs Player", "Player vs Bot", "Bot vs Bot"}
+		m.menuOptions = gameTypeOptions()
 		m.menuSelection = 0
 		// Reset draw offer state
 		m.drawOfferedBy = -1
 		m.drawOfferedByWhite = false
 		m.drawOfferedByBlack = false
-		m.drawByAgreement = false
 
-	case "m", "M", "esc":
-		// Clean up bot engine if it exists
-		if m.botEngine != nil {
-			_ = m.botEngine.Close()
-			m.botEngine = nil
-		}
-		// Return to main menu
-		m.screen = ScreenMainMenu
-		m.board = nil
-		m.moveHistory = []engine.Move{}
-		m.input = ""
-		m.errorMsg = ""
-		m.statusMsg = ""
-		// Reset menu options to main menu
-		m.menuOptions = []string{"New Game", "Load Game", "Settings", "Exit"}
-		m.menuSelection = 0
+	case m.keys.Matches(msg, ActionMainMenu, ActionBack):
+		return m.leaveGameOver()
+
+	case m.keys.Matches(msg, ActionAnalyze):
+		// Walk through the game with engine evaluations
+		return m.startAnalysis()
+
+	case m.keys.Matches(msg, ActionRematch) && m.matchOver():
+		return m.openMatchSummary()
 
-	case "q", "Q":
+	case m.keys.Matches(msg, ActionRematch) && m.canRematch():
+		// Play again with colors swapped, keeping the match score
+		return m.rematch()
+
+	case m.keys.Matches(msg, ActionQuit):
 		// Clean up bot engine if it exists
 		if m.botEngine != nil {
 			_ = m.botEngine.Close()
@@ -547,22 +858,22 @@ func (m Model) handleGameOverKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, tea.Quit
 	}
 
-	return m, nil
+	return m.updateMenu(msg)
 }
 
 // handleSettingsKeys handles keyboard input for the Settings screen.
 // Supports arrow keys and vi-style navigation (j/k), Space or Enter to toggle/cycle,
-// ESC to return to main menu, and wraps around at top and bottom of the settings.
+// ESC to return to main menu, and 'r' to reload the config file. Wraps around at
+// top and bottom of the settings.
 func (m Model) handleSettingsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Clear any previous error or status messages when user takes action
-	m.errorMsg = ""
-	m.statusMsg = ""
+	m.dismissToasts()
 
-	// Number of settings options (5 toggles + 1 theme selector)
-	numSettings := 6 // UseUnicode, ShowCoords, UseColors, ShowMoveHistory, ShowHelpText, Theme
+	// Number of settings options (7 toggles + 7 value options + key bindings + update checks + encryption)
+	numSettings := 18 // UseUnicode, ShowCoords, UseColors, ShowMoveHistory, ShowHelpText, TurnNotifications, HotSeatPrivacy, Theme, CoordinateStyle, Notation, FigurineNotation, Language, BotMoveTime, Coach, Key Bindings, UpdateCheck, Check for Updates, EncryptSaves
 
-	switch msg.String() {
-	case "up", "k":
+	switch {
+	case m.keys.Matches(msg, ActionUp):
 		// Move selection up
 		if m.settingsSelection > 0 {
 			m.settingsSelection--
@@ -571,7 +882,7 @@ func (m Model) handleSettingsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.settingsSelection = numSettings - 1
 		}
 
-	case "down", "j":
+	case m.keys.Matches(msg, ActionDown):
 		// Move selection down
 		if m.settingsSelection < numSettings-1 {
 			m.settingsSelection++
@@ -580,23 +891,48 @@ func (m Model) handleSettingsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.settingsSelection = 0
 		}
 
-	case "enter", " ":
+	case m.keys.Matches(msg, ActionSelect, ActionToggle):
 		// Toggle the selected setting
 		return m.toggleSelectedSetting()
 
-	case "esc", "q", "b", "backspace":
+	case m.keys.Matches(msg, ActionBack, ActionQuit):
 		// Return to previous screen using navigation stack
 		// popScreen() handles menu state restoration
 		m.popScreen()
-		m.statusMsg = ""
+		m.dismissToasts(SeverityInfo, SeverityWarning)
+
+	case m.keys.Matches(msg, ActionRefresh):
+		// Pick up changes made to the config file outside TermChess
+		m.reloadConfig()
 	}
 
 	return m, nil
 }
 
+// reloadConfig reloads the config file and its environment overrides and
+// applies them without leaving the current screen or game.
+func (m *Model) reloadConfig() {
+	if err := m.applyConfig(LoadConfig()); err != nil {
+		m.notify(SeverityError, fmt.Sprintf("Invalid key bindings in config, using defaults: %v", err))
+		return
+	}
+	m.notify(SeverityInfo, "Config reloaded")
+}
+
+// applyConfig makes cfg the configuration in use, including its theme and key
+// bindings. If the key bindings are invalid, the defaults are used and the
+// error is returned.
+func (m *Model) applyConfig(cfg Config) error {
+	keys, err := NewKeyMap(cfg.KeyBindings)
+	m.config = cfg
+	m.theme = GetTheme(ParseThemeName(cfg.Theme))
+	m.keys = keys
+	return err
+}
+
 // toggleSelectedSetting toggles the currently selected setting and saves the config.
 // For boolean settings, it toggles between true/false.
-// For the theme setting, it cycles through: Classic -> Modern -> Minimalist -> Classic.
+// The theme, coordinate style, notation, language, bot move time, coach and update check settings cycle through their values.
 func (m Model) toggleSelectedSetting() (tea.Model, tea.Cmd) {
 	// Toggle or cycle the selected setting based on settingsSelection index
 	switch m.settingsSelection {
@@ -610,19 +946,63 @@ func (m Model) toggleSelectedSetting() (tea.Model, tea.Cmd) {
 		m.config.ShowMoveHistory = !m.config.ShowMoveHistory
 	case 4: // Show Help Text
 		m.config.ShowHelpText = !m.config.ShowHelpText
-	case 5: // Theme
+	case 5: // Turn Notifications
+		m.config.TurnNotifications = !m.config.TurnNotifications
+	case 6: // Hot-Seat Privacy Screen
+		m.config.HotSeatPrivacy = !m.config.HotSeatPrivacy
+	case 7: // Theme
 		// Cycle through themes: Classic -> Modern -> Minimalist -> Classic
 		m.config.Theme = cycleTheme(m.config.Theme)
 		// Update the theme in the model immediately for visual feedback
 		m.theme = GetTheme(ParseThemeName(m.config.Theme))
+	case 8: // Coordinates
+		// Cycle through coordinate styles: Outside -> Inside -> Outside
+		m.config.CoordinateStyle = cycleCoordinateStyle(m.config.CoordinateStyle)
+	case 9: // Notation
+		// Cycle through notations: SAN -> Long Algebraic -> Coordinate -> SAN
+		m.config.Notation = cycleNotation(m.config.Notation)
+	case 10: // Figurine Notation
+		m.config.FigurineNotation = !m.config.FigurineNotation
+	case 11: // Language
+		// Cycle through piece letters: English -> German -> French -> Spanish -> English
+		m.config.Language = cycleLanguage(m.config.Language)
+	case 12: // Bot Move Time
+		// Cycle through per-move budgets: Per Difficulty -> 100ms -> ... -> 10s -> Per Difficulty
+		m.config.BotMoveTime = cycleBotMoveTime(m.config.BotMoveTime)
+	case 13: // Coach
+		// Cycle through verbosities: Off -> Mistakes Only -> Every Move -> Off
+		m.config.Coach = cycleCoach(m.config.Coach)
+	case 14: // Key Bindings
+		// Open the key bindings screen; changes there are saved individually
+		m.pushScreen(ScreenKeyBindings)
+		m.keyBindingSelection = 0
+		m.keyBindingCapture = false
+		return m, nil
+	case 15: // Update Checks
+		// Cycle through frequencies: On Startup -> Daily -> Weekly -> Off -> On Startup
+		m.config.UpdateCheck = cycleUpdateCheck(m.config.UpdateCheck)
+	case 16: // Check for Updates
+		// Look up the latest release now; nothing is saved
+		return m, m.checkForUpdatesNow()
+	case 17: // Encrypt Saves
+		// Turning encryption on asks for the passphrase first, which saves the setting
+		if !m.config.EncryptSaves {
+			m.openPassphrasePrompt(true)
+			return m, nil
+		}
+		var err error
+		if m, err = m.turnOffEncryption(); err != nil {
+			m.notify(SeverityError, fmt.Sprintf("Failed to decrypt saves: %v", err))
+			return m, nil
+		}
 	}
 
 	// Save the configuration immediately
 	err := config.SaveConfig(m.config)
 	if err != nil {
-		m.errorMsg = fmt.Sprintf("Failed to save settings: %v", err)
+		m.notify(SeverityError, fmt.Sprintf("Failed to save settings: %v", err))
 	} else {
-		m.statusMsg = "Setting saved successfully"
+		m.notify(SeverityInfo, "Setting saved successfully")
 	}
 
 	return m, nil
@@ -643,67 +1023,32 @@ func cycleTheme(current string) string {
 	}
 }
 
-// handleSavePromptKeys handles keyboard input for the Save Prompt screen.
-// Supports arrow keys to navigate between Yes/No, Enter to confirm, direct 'y'/'n' keys, and ESC to cancel.
+// handleSavePromptKeys handles keyboard input for the Save Prompt dialog.
+// Supports arrow keys to navigate between the options, Enter to confirm, direct 'y'/'n' keys, and ESC to cancel.
 func (m Model) handleSavePromptKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Clear any previous error or status messages when user takes action
-	m.errorMsg = ""
-	m.statusMsg = ""
-
-	switch msg.String() {
-	case "up", "k":
-		// Move selection up (toggle between Yes and No)
-		if m.savePromptSelection > 0 {
-			m.savePromptSelection--
-		} else {
-			// Wrap to bottom (only 2 options)
-			m.savePromptSelection = 1
-		}
-
-	case "down", "j":
-		// Move selection down (toggle between Yes and No)
-		if m.savePromptSelection < 1 {
-			m.savePromptSelection++
-		} else {
-			// Wrap to top
-			m.savePromptSelection = 0
-		}
-
-	case "y", "Y":
-		// Direct "Yes" - save the game and exit to main menu
-		err := config.SaveGame(m.board)
-		if err != nil {
-			m.errorMsg = fmt.Sprintf("Failed to save game: %v", err)
-			return m, nil
-		}
-		m.statusMsg = "Game saved!"
-		// Both Yes and No go to Main Menu
-		m.cleanupGame()
-		m.screen = ScreenMainMenu
-		m.menuOptions = buildMainMenuOptions()
-		m.menuSelection = 0
-		m.navStack = nil // Clear navigation stack
-		return m, nil
-
-	case "n", "N":
-		// Direct "No" - exit to main menu without saving
-		m.cleanupGame()
-		m.screen = ScreenMainMenu
-		m.menuOptions = buildMainMenuOptions()
-		m.menuSelection = 0
-		m.navStack = nil // Clear navigation stack
-		return m, nil
+	m.dismissToasts()
 
-	case "enter":
-		// Execute the selected action
-		if m.savePromptSelection == 0 { // "Save & Exit"
-			// Save the game
-			err := config.SaveGame(m.board)
-			if err != nil {
-				m.errorMsg = fmt.Sprintf("Failed to save: %v", err)
+	switch m.savePrompt().update(m.keys, msg, &m.savePromptSelection, nil) {
+	case modalChosen:
+		if m.inSimul() && m.savePromptSelection == 1 { // "Keep Playing"
+			m.screen = ScreenGamePlay
+			return m, nil
+		}
+		if m.savePromptSelection == 0 && !m.inSimul() { // "Save & Exit"
+			if err := config.SaveGame(m.game.Board()); err != nil {
+				m.notify(SeverityError, fmt.Sprintf("Failed to save game: %v", err))
+				return m, nil
+			}
+			if err := config.SaveGameInfo(m.savedGameInfo()); err != nil {
+				m.notify(SeverityError, fmt.Sprintf("Failed to save game: %v", err))
 				return m, nil
 			}
-			m.statusMsg = "Game saved!"
+			if err := config.SaveGameNotes(m.noteTexts()); err != nil {
+				m.notify(SeverityError, fmt.Sprintf("Failed to save notes: %v", err))
+				return m, nil
+			}
+			m.notify(SeverityInfo, "Game saved!")
 		}
 		// Both Save & Exit and Exit without saving go to Main Menu
 		m.cleanupGame()
@@ -711,12 +1056,10 @@ func (m Model) handleSavePromptKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.menuOptions = buildMainMenuOptions()
 		m.menuSelection = 0
 		m.navStack = nil // Clear navigation stack
-		return m, nil
 
-	case "esc":
+	case modalCancelled:
 		// Cancel - return to gameplay
 		m.screen = ScreenGamePlay
-		m.errorMsg = ""
 	}
 
 	return m, nil
@@ -725,18 +1068,18 @@ func (m Model) handleSavePromptKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 // cleanupGame cleans up game state when exiting a game.
 // This includes clearing the board, move history, input state, and bot engine.
 func (m *Model) cleanupGame() {
-	m.board = nil
-	m.moveHistory = nil
+	m.game = nil
 	m.selectedSquare = nil
 	m.validMoves = nil
 	m.input = ""
-	m.errorMsg = ""
+	m.dismissToasts(SeverityError)
 	m.blinkOn = false
 	// Clean up bot engine if it exists
 	if m.botEngine != nil {
 		_ = m.botEngine.Close()
 		m.botEngine = nil
 	}
+	m.endSimul()
 }
 
 // handleFENInputKeys handles keyboard input for the FEN Input screen.
@@ -750,64 +1093,144 @@ func (m Model) handleFENInputKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Return to previous screen using navigation stack
 		// popScreen() handles menu state restoration
 		m.popScreen()
-		m.statusMsg = ""
+		m.dismissToasts(SeverityInfo, SeverityWarning)
 		m.fenInput.SetValue("")
 		return m, nil
 
+	case "up", "down":
+		// Choose one of the recent FENs, filling it into the input
+		if len(m.fenHistory) == 0 {
+			return m, nil
+		}
+		if msg.String() == "up" {
+			m.fenHistorySelection = max(m.fenHistorySelection-1, 0)
+		} else {
+			m.fenHistorySelection = min(m.fenHistorySelection+1, len(m.fenHistory)-1)
+		}
+		m.fenInput.SetValue(m.fenHistory[m.fenHistorySelection])
+		m.fenInput.CursorEnd()
+		m.dismissToasts(SeverityError)
+		return m, nil
+
 	case "enter":
 		// Try to parse and load the FEN string
 		fenString := m.fenInput.Value()
 		if fenString == "" {
-			m.errorMsg = "Please enter a FEN string"
+			m.notify(SeverityError, "Please enter a FEN string")
 			return m, nil
 		}
 
 		// Parse the FEN string using the engine
-		board, err := engine.FromFEN(fenString)
+		board, err := engine.LoadFEN(fenString)
 		if err != nil {
 			// Show parsing error to user
-			m.errorMsg = fmt.Sprintf("Invalid FEN: %v", err)
+			m.notify(SeverityError, fmt.Sprintf("Invalid FEN: %v", err))
 			return m, nil
 		}
 
-		// Successfully loaded - start gameplay with loaded board
-		m.board = board
-		m.moveHistory = []engine.Move{}
+		// Successfully loaded - remember the position and start gameplay with loaded board
+		// The history is a convenience, so failing to record it doesn't stop the game
+		_ = config.AddFENHistory(fenString)
+		m.game = engine.NewGameFrom(board)
+		m.beginGameRecord()
 		// Clear nav stack when starting game
 		m.clearNavStack()
 		m.screen = ScreenGamePlay
 		m.gameType = GameTypePvP
 		m.input = ""
-		m.errorMsg = ""
-		m.statusMsg = ""
+		m.dismissToasts()
 		m.fenInput.SetValue("")
-		m.resignedBy = -1
 		// Reset draw offer state
 		m.drawOfferedBy = -1
 		m.drawOfferedByWhite = false
 		m.drawOfferedByBlack = false
-		m.drawByAgreement = false
 		return m, nil
 
+	case "tab":
+		return m.handFENToBots()
+
 	default:
 		// Delegate to the text input component for regular typing
 		m.fenInput, cmd = m.fenInput.Update(msg)
-		// Clear error message when user starts typing
+		// Clear error message and the chosen recent FEN when user starts typing
 		if msg.Type == tea.KeyRunes || msg.Type == tea.KeyBackspace {
-			m.errorMsg = ""
+			m.dismissToasts(SeverityError)
+			m.fenHistorySelection = -1
 		}
 	}
 
 	return m, cmd
 }
 
+// handFENToBots hands the position typed on the FEN input screen to two bots:
+// once their difficulties are chosen, a Bot vs Bot game starts from it.
+func (m Model) handFENToBots() (tea.Model, tea.Cmd) {
+	fenString := m.fenInput.Value()
+	if fenString == "" {
+		m.notify(SeverityError, "Please enter a FEN string")
+		return m, nil
+	}
+	board, err := engine.LoadFEN(fenString)
+	if err != nil {
+		m.notify(SeverityError, fmt.Sprintf("Invalid FEN: %v", err))
+		return m, nil
+	}
+	if board.IsGameOver() {
+		m.notify(SeverityError, fmt.Sprintf("The game is already over: %s", board.Status()))
+		return m, nil
+	}
+
+	_ = config.AddFENHistory(fenString)
+	m.bvbStartFEN = board.ToFEN()
+	m.gameType = GameTypeBvB
+	m.bvbSelectingWhite = true
+	m.fenInput.SetValue("")
+	m.openMenu(ScreenBvBBotSelect)
+	return m, nil
+}
+
 // handleGamePlayInput processes user input during gameplay.
 // It first checks if the input is a special command (resign, showfen, menu),
 // and if not, attempts to parse and execute it as a chess move.
 func (m Model) handleGamePlayInput() (tea.Model, tea.Cmd) {
+	// A finished simul game stays on screen until the user switches boards
+	if m.inSimul() && m.shownSimulGame().finished() {
+		if _, ok := parseNoteCommand(m.input); !ok {
+			m.notify(SeverityError, fmt.Sprintf("Board %d is over - press 1-%d to switch boards", m.simulActive+1, len(m.simulGames)))
+			m.input = ""
+			return m, nil
+		}
+	}
+
+	// Notes keep the case they were typed in
+	if text, ok := parseNoteCommand(m.input); ok {
+		if m.gameType == GameTypeCorrespondence {
+			m.notify(SeverityError, "'note' is not available in correspondence games")
+			m.input = ""
+			return m, nil
+		}
+		return m.handleNoteCommand(text)
+	}
+
 	// Get the trimmed and lowercased input for command matching
 	input := strings.TrimSpace(strings.ToLower(m.input))
 
+	// Correspondence games record resignations in the mailbox and have no draw offers or takebacks
+	if m.gameType == GameTypeCorrespondence {
+		switch input {
+		case "resign":
+			return m.resignCorrespondenceGame()
+		case "offerdraw", "claimdraw", "takeback":
+			m.notify(SeverityError, fmt.Sprintf("'%s' is not available in correspondence games", input))
+			m.input = ""
+			return m, nil
+		}
+	}
+
+	if coords, ok := parseDiagramCommand(input); ok {
+		return m.handleDiagramCommand(coords)
+	}
+
 	// Check for special commands first
 	switch input {
 	case "resign":
@@ -818,6 +1241,10 @@ func (m Model) handleGamePlayInput() (tea.Model, tea.Cmd) {
 		return m.handleMenuCommand()
 	case "offerdraw":
 		return m.handleOfferDrawCommand()
+	case "claimdraw":
+		return m.handleClaimDrawCommand()
+	case "takeback":
+		return m.handleTakebackCommand()
 	default:
 		// Not a command, try to parse as a move
 		return m.handleMoveInput()
@@ -828,15 +1255,24 @@ func (m Model) handleGamePlayInput() (tea.Model, tea.Cmd) {
 // The current player resigns, and the game transitions to GameOver screen.
 func (m Model) handleResignCommand() (tea.Model, tea.Cmd) {
 	// Mark which player resigned
-	m.resignedBy = int8(m.board.ActiveColor)
+	resigning := m.game.Board().ActiveColor
+	if m.gameType == GameTypePvBot {
+		// Against a bot only the user can type commands, even while the bot is thinking
+		resigning = m.userColor
+		m.stopBotThinking()
+		if m.botEngine != nil {
+			_ = m.botEngine.Close()
+			m.botEngine = nil
+		}
+	}
+	m.game.Resign(resigning)
 
 	// Transition to game over screen
-	m.screen = ScreenGameOver
+	m.showGameOver()
 
 	// Clear input
 	m.input = ""
-	m.errorMsg = ""
-	m.statusMsg = ""
+	m.dismissToasts()
 
 	// Delete the save game file since the game is over
 	_ = config.DeleteSaveGame()
@@ -848,21 +1284,22 @@ func (m Model) handleResignCommand() (tea.Model, tea.Cmd) {
 // It displays the current FEN string and copies it to clipboard if possible.
 func (m Model) handleShowFenCommand() (tea.Model, tea.Cmd) {
 	// Get the FEN string from the current board
-	fen := m.board.ToFEN()
+	fen := m.game.Board().ToFEN()
+	_ = config.AddFENHistory(fen)
 
 	// Try to copy to clipboard
 	err := util.CopyToClipboard(fen)
 	if err != nil {
 		// Show FEN with clipboard error message
-		m.statusMsg = fmt.Sprintf("FEN: %s (Failed to copy to clipboard: %v)", fen, err)
+		m.notify(SeverityInfo, fmt.Sprintf("FEN: %s (Failed to copy to clipboard: %v)", fen, err))
 	} else {
 		// Show FEN with success message
-		m.statusMsg = fmt.Sprintf("FEN: %s (Copied to clipboard)", fen)
+		m.notify(SeverityInfo, fmt.Sprintf("FEN: %s (Copied to clipboard)", fen))
 	}
 
 	// Clear input and error messages
 	m.input = ""
-	m.errorMsg = ""
+	m.dismissToasts(SeverityError)
 
 	return m, nil
 }
@@ -877,46 +1314,67 @@ func (m Model) handleMenuCommand() (tea.Model, tea.Cmd) {
 
 	// Clear input and messages
 	m.input = ""
-	m.errorMsg = ""
-	m.statusMsg = ""
+	m.dismissToasts()
 
 	return m, nil
 }
 
 // handleMoveInput parses and executes a chess move.
-// It tries SAN notation first, then falls back to coordinate notation.
+// It accepts SAN and coordinate notation, forgiving common slips such as "nf3".
+// While the bot is thinking, the move is queued as a pre-move instead.
+// In training mode, typing the square of one of your pieces shows its legal moves.
 func (m Model) handleMoveInput() (tea.Model, tea.Cmd) {
-	// Try SAN parsing first
-	move, err := ParseSAN(m.board, m.input)
-	if err != nil {
-		// Fall back to coordinate notation
-		move, err = engine.ParseMove(m.input)
-		if err != nil {
-			// Show parsing error to user
-			m.errorMsg = fmt.Sprintf("Invalid move: %v", err)
-			return m, nil
+	if m.gameType == GameTypePvBot && m.game.Board().ActiveColor != m.userColor {
+		return m.queuePremove()
+	}
+	if m.trainingMode {
+		if sq, ok := m.trainingSquare(); ok {
+			return m.showLegalMoves(sq)
 		}
 	}
 
+	// Parse SAN or coordinate notation, forgiving common slips
+	move, err := ParseMoveForgiving(m.game.Board(), m.input, m.config.Language)
+	if err != nil {
+		// Show parsing error to user
+		m.notify(SeverityError, fmt.Sprintf("Invalid move: %v", err))
+		return m, nil
+	}
+
+	// Keep the input so pressing Enter again confirms a move training mode warned about
+	if m.holdHangingMove(move) {
+		return m, nil
+	}
+
+	return m.playUserMove(move)
+}
+
+// playUserMove plays the user's move, ends the game if it is over and
+// otherwise lets the bot reply in Player vs Bot games.
+func (m Model) playUserMove(move engine.Move) (tea.Model, tea.Cmd) {
+	if m.gameType == GameTypeCorrespondence {
+		return m.playCorrespondenceMove(move)
+	}
+
 	// Try to make the move on the board
-	err = m.board.MakeMove(move)
+	position := m.game.Board().Copy()
+	err := m.playMove(move)
 	if err != nil {
 		// Show move execution error to user
-		m.errorMsg = err.Error()
+		m.notify(SeverityError, err.Error())
 		return m, nil
 	}
 
-	// Move was successful - clear input and error messages
+	// Move was successful - clear input, error messages and any selected piece
 	m.input = ""
-	m.errorMsg = ""
-	m.statusMsg = ""
-
-	// Add move to history
-	m.moveHistory = append(m.moveHistory, move)
+	m.dismissToasts()
+	m.selectedSquare = nil
+	m.validMoves = nil
+	m.blinkOn = false
 
 	// Check if the game is over after this move
-	if m.board.IsGameOver() {
-		m.screen = ScreenGameOver
+	if m.game.Board().IsGameOver() {
+		m.showGameOver()
 		// Delete the save game file since the game is over
 		_ = config.DeleteSaveGame()
 		// Clean up bot engine if it exists
@@ -927,11 +1385,34 @@ func (m Model) handleMoveInput() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	// If this is a bot game and game is not over, trigger bot move
+	// If this is a bot game and game is not over, trigger bot move while the coach checks the user's move
 	if m.gameType == GameTypePvBot {
-		return m.makeBotMove()
+		coachCmd := m.coachMove(position, move)
+		m, botCmd := m.makeBotMove()
+		return m, tea.Batch(botCmd, coachCmd)
+	}
+
+	m.passKeyboard()
+	return m, nil
+}
+
+// passKeyboard hides the board behind the pass-the-keyboard screen after a move in a
+// hot-seat game, if the privacy screen is enabled.
+func (m *Model) passKeyboard() {
+	if m.gameType == GameTypePvP && m.config.HotSeatPrivacy {
+		m.screen = ScreenHandoff
 	}
+}
 
+// handleHandoffKeys handles keyboard input for the pass-the-keyboard screen.
+// Enter or Space reveals the board to the next player; other keys are ignored so
+// a stray key press by the previous player doesn't give anything away.
+func (m Model) handleHandoffKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.keys.Matches(msg, ActionSelect, ActionToggle) {
+		m.screen = ScreenGamePlay
+		// The next player's think time starts once they can see the board
+		m.turnStartedAt = time.Now()
+	}
 	return m, nil
 }
 
@@ -939,16 +1420,21 @@ func (m Model) handleMoveInput() (tea.Model, tea.Cmd) {
 // A player offers a draw to their opponent, which can be accepted or declined.
 func (m Model) handleOfferDrawCommand() (tea.Model, tea.Cmd) {
 	// Check if this player already offered a draw
-	if (m.board.ActiveColor == engine.White && m.drawOfferedByWhite) ||
-		(m.board.ActiveColor == engine.Black && m.drawOfferedByBlack) {
-		m.errorMsg = "You have already offered a draw this game"
+	if (m.game.Board().ActiveColor == engine.White && m.drawOfferedByWhite) ||
+		(m.game.Board().ActiveColor == engine.Black && m.drawOfferedByBlack) {
+		m.notify(SeverityError, "You have already offered a draw this game")
 		m.input = ""
 		return m, nil
 	}
 
+	// The bot answers draw offers itself instead of showing the prompt
+	if m.gameType == GameTypePvBot {
+		return m.handleBotDrawOffer()
+	}
+
 	// Mark who offered the draw
-	m.drawOfferedBy = int8(m.board.ActiveColor)
-	if m.board.ActiveColor == engine.White {
+	m.drawOfferedBy = int8(m.game.Board().ActiveColor)
+	if m.game.Board().ActiveColor == engine.White {
 		m.drawOfferedByWhite = true
 	} else {
 		m.drawOfferedByBlack = true
@@ -958,65 +1444,108 @@ func (m Model) handleOfferDrawCommand() (tea.Model, tea.Cmd) {
 	m.screen = ScreenDrawPrompt
 	m.drawPromptSelection = 0
 	m.input = ""
-	m.errorMsg = ""
-	m.statusMsg = ""
+	m.dismissToasts()
+
+	return m, nil
+}
+
+// handleClaimDrawCommand handles the "claimdraw" command.
+// A draw can be claimed by threefold repetition or the fifty-move rule;
+// the fivefold and seventy-five-move variants end the game automatically.
+func (m Model) handleClaimDrawCommand() (tea.Model, tea.Cmd) {
+	m.input = ""
+
+	if !m.game.Board().CanClaimDraw() {
+		m.notify(SeverityError, "No draw is available to claim")
+		return m, nil
+	}
+
+	return m.endGameByDrawClaim(), nil
+}
+
+// endGameByDrawClaim ends the game with a claimed draw.
+// The result message is derived from the board's claimable draw status.
+func (m Model) endGameByDrawClaim() Model {
+	m.showGameOver()
+	m.dismissToasts()
+	m.stopBotThinking()
+	// Delete the save game file since the game is over
+	_ = config.DeleteSaveGame()
+	if m.botEngine != nil {
+		_ = m.botEngine.Close()
+		m.botEngine = nil
+	}
+	return m
+}
+
+// handleBotDrawOffer lets the bot accept or decline the user's draw offer based on
+// its evaluation of the position. The user may offer a draw once per game.
+func (m Model) handleBotDrawOffer() (tea.Model, tea.Cmd) {
+	if m.game.Board().ActiveColor != m.userColor {
+		m.notify(SeverityError, "You can only offer a draw on your turn")
+		m.input = ""
+		return m, nil
+	}
+
+	if m.userColor == engine.White {
+		m.drawOfferedByWhite = true
+	} else {
+		m.drawOfferedByBlack = true
+	}
+	m.input = ""
+	m.dismissToasts(SeverityError)
+
+	botColor := engine.Black
+	if m.userColor == engine.Black {
+		botColor = engine.White
+	}
 
+	if !bot.AcceptsDraw(m.botEngine, m.game.Board(), m.botDifficulty, botColor) {
+		m.notify(SeverityInfo, "Bot declined the draw offer")
+		return m, nil
+	}
+
+	m.game.AgreeDraw()
+	m.showGameOver()
+	m.dismissToasts(SeverityInfo, SeverityWarning)
+	// Delete the save game file since the game is over
+	_ = config.DeleteSaveGame()
+	if m.botEngine != nil {
+		_ = m.botEngine.Close()
+		m.botEngine = nil
+	}
 	return m, nil
 }
 
-// handleDrawPromptKeys handles keyboard input for the Draw Prompt screen.
+// handleDrawPromptKeys handles keyboard input for the Draw Prompt dialog.
 // Supports arrow keys to navigate between Accept/Decline, Enter to confirm, and ESC to cancel.
 func (m Model) handleDrawPromptKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Clear any previous error or status messages when user takes action
-	m.errorMsg = ""
-	m.statusMsg = ""
-
-	switch msg.String() {
-	case "up", "k":
-		// Move selection up (toggle between Accept and Decline)
-		if m.drawPromptSelection > 0 {
-			m.drawPromptSelection--
-		} else {
-			// Wrap to bottom (only 2 options)
-			m.drawPromptSelection = 1
-		}
-
-	case "down", "j":
-		// Move selection down (toggle between Accept and Decline)
-		if m.drawPromptSelection < 1 {
-			m.drawPromptSelection++
-		} else {
-			// Wrap to top
-			m.drawPromptSelection = 0
-		}
+	m.dismissToasts()
 
-	case "enter":
-		// Execute the selected action
+	switch m.drawPrompt().update(m.keys, msg, &m.drawPromptSelection, nil) {
+	case modalChosen:
 		if m.drawPromptSelection == 0 {
 			// User selected "Accept" - end game in draw
-			m.drawByAgreement = true
-			m.screen = ScreenGameOver
+			m.game.AgreeDraw()
+			m.showGameOver()
 			m.input = ""
-			m.errorMsg = ""
-			m.statusMsg = ""
 			// Delete the save game file since the game is over
 			_ = config.DeleteSaveGame()
 		} else {
 			// User selected "Decline" - return to game
 			m.screen = ScreenGamePlay
-			m.statusMsg = "Draw offer declined"
+			m.notify(SeverityInfo, "Draw offer declined")
 			m.input = ""
-			m.errorMsg = ""
 			// Reset draw offered by so another offer can be made
 			m.drawOfferedBy = -1
 		}
 
-	case "esc":
+	case modalCancelled:
 		// Cancel and return to game
 		m.screen = ScreenGamePlay
-		m.statusMsg = "Draw offer cancelled"
+		m.notify(SeverityInfo, "Draw offer cancelled")
 		m.input = ""
-		m.errorMsg = ""
 		// Reset draw offered by and the flag for the player who offered
 		if m.drawOfferedBy == int8(engine.White) {
 			m.drawOfferedByWhite = false
@@ -1034,39 +1563,17 @@ func (m Model) handleDrawPromptKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 // ESC to return to game type selection, and wraps around at top and bottom of the menu.
 func (m Model) handleBotSelectKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Clear any previous error or status messages when user takes action
-	m.errorMsg = ""
-	m.statusMsg = ""
-
-	switch msg.String() {
-	case "up", "k":
-		// Move selection up
-		if m.menuSelection > 0 {
-			m.menuSelection--
-		} else {
-			// Wrap to bottom of menu
-			m.menuSelection = len(m.menuOptions) - 1
-		}
-
-	case "down", "j":
-		// Move selection down
-		if m.menuSelection < len(m.menuOptions)-1 {
-			m.menuSelection++
-		} else {
-			// Wrap to top of menu
-			m.menuSelection = 0
-		}
+	m.dismissToasts()
 
-	case "enter":
-		return m.handleBotDifficultySelection()
-
-	case "esc":
+	if m.keys.Matches(msg, ActionBack) {
 		// Return to previous screen using navigation stack
 		// popScreen() handles menu state restoration
 		m.popScreen()
-		m.statusMsg = ""
+		m.dismissToasts(SeverityInfo, SeverityWarning)
+		return m, nil
 	}
 
-	return m, nil
+	return m.updateMenu(msg)
 }
 
 // handleBvBBotSelectKeys handles keyboard input for the BvB bot difficulty selection screen.
@@ -1075,28 +1582,9 @@ func (m Model) handleBotSelectKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 // ESC to go back, and wraps around at top and bottom of the menu.
 func (m Model) handleBvBBotSelectKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Clear any previous error or status messages when user takes action
-	m.errorMsg = ""
-	m.statusMsg = ""
-
-	switch msg.String() {
-	case "up", "k":
-		if m.menuSelection > 0 {
-			m.menuSelection--
-		} else {
-			m.menuSelection = len(m.menuOptions) - 1
-		}
-
-	case "down", "j":
-		if m.menuSelection < len(m.menuOptions)-1 {
-			m.menuSelection++
-		} else {
-			m.menuSelection = 0
-		}
-
-	case "enter":
-		return m.handleBvBBotDifficultySelection()
+	m.dismissToasts()
 
-	case "esc":
+	if m.keys.Matches(msg, ActionBack) {
 		if m.bvbSelectingWhite {
 			// At White selection - go back to previous screen
 			m.popScreen()
@@ -1105,44 +1593,34 @@ func (m Model) handleBvBBotSelectKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.bvbSelectingWhite = true
 			m.menuSelection = 0
 		}
+		return m, nil
 	}
 
-	return m, nil
+	return m.updateMenu(msg)
 }
 
-// handleBvBBotDifficultySelection executes the action for the currently selected BvB bot difficulty.
-// If selecting White, stores the difficulty and moves to Black selection.
-// If selecting Black, stores the difficulty and transitions to game mode selection.
-func (m Model) handleBvBBotDifficultySelection() (tea.Model, tea.Cmd) {
-	selected := m.menuOptions[m.menuSelection]
-
-	var diff BotDifficulty
-	switch selected {
-	case "Easy":
-		diff = BotEasy
-	case "Medium":
-		diff = BotMedium
-	case "Hard":
-		diff = BotHard
-	}
-
+// chooseBvBBot stores the engine picked for the side being selected. After White's
+// engine it moves on to Black's, after Black's to game mode selection. name is
+// set for a registered custom bot.
+func (m Model) chooseBvBBot(diff BotDifficulty, name string) (tea.Model, tea.Cmd) {
 	if m.bvbSelectingWhite {
 		// Store White difficulty and move to Black selection
 		m.bvbWhiteDiff = diff
+		m.bvbWhiteBot = name
 		m.bvbSelectingWhite = false
 		m.menuSelection = 0
-		m.statusMsg = ""
-		m.errorMsg = ""
+		m.dismissToasts()
 	} else {
 		// Store Black difficulty and transition to game mode selection
 		m.bvbBlackDiff = diff
-		m.pushScreen(ScreenBvBGameMode)
-		m.menuOptions = []string{"Single Game", "Multi-Game"}
-		m.menuSelection = 0
+		m.bvbBlackBot = name
 		m.bvbInputtingCount = false
 		m.bvbCountInput = ""
-		m.statusMsg = ""
-		m.errorMsg = ""
+		// A position handed over from the FEN input screen is played out at once
+		if m.bvbStartFEN != "" {
+			return m.startSingleBvBGame()
+		}
+		m.openMenu(ScreenBvBGameMode)
 	}
 
 	return m, nil
@@ -1151,63 +1629,201 @@ func (m Model) handleBvBBotDifficultySelection() (tea.Model, tea.Cmd) {
 // handleBvBGameModeKeys handles keyboard input for the BvB game mode selection screen.
 // Supports menu navigation for Single/Multi-Game selection, and text input for game count.
 func (m Model) handleBvBGameModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	m.errorMsg = ""
+	m.dismissToasts(SeverityError)
 
 	if m.bvbInputtingCount {
 		return m.handleBvBCountInput(msg)
 	}
+	if m.bvbInputtingSeed {
+		return m.handleBvBSeedInput(msg)
+	}
+	if m.bvbInputtingOpenings {
+		return m.handleBvBOpeningsInput(msg)
+	}
 
-	switch msg.String() {
-	case "up", "k":
-		if m.menuSelection > 0 {
-			m.menuSelection--
-		} else {
-			m.menuSelection = len(m.menuOptions) - 1
-		}
+	if m.keys.Matches(msg, ActionBack) {
+		// Go back to BvB bot select (Black selection)
+		m.popScreen()
+		m.bvbSelectingWhite = false
+		return m, nil
+	}
 
-	case "down", "j":
-		if m.menuSelection < len(m.menuOptions)-1 {
-			m.menuSelection++
-		} else {
-			m.menuSelection = 0
+	return m.updateMenu(msg)
+}
+
+// startSingleBvBGame starts a Bot vs Bot session of one game, shown on a single board.
+func (m Model) startSingleBvBGame() (tea.Model, tea.Cmd) {
+	m.bvbSPRT = false
+	m.bvbGameCount = 1
+	m.bvbGridRows = 1
+	m.bvbGridCols = 1
+	m.bvbGridAuto = false
+	// For single game, go directly to gameplay with single view (no view mode selection needed)
+	m.bvbViewMode = BvBSingleView
+	return m.startBvBSession()
+}
+
+// askBvBGameCount switches to text input for the number of games, which is the
+// maximum in an SPRT test.
+func (m Model) askBvBGameCount(sprt bool) (tea.Model, tea.Cmd) {
+	m.bvbSPRT = sprt
+	m.bvbInputtingCount = true
+	m.bvbCountInput = ""
+	m.dismissToasts()
+	return m, nil
+}
+
+// askBvBSeed switches to text input for the seed, starting from the current one.
+func (m Model) askBvBSeed() (tea.Model, tea.Cmd) {
+	m.bvbInputtingSeed = true
+	m.bvbSeedInput = ""
+	if m.bvbSeedSet {
+		m.bvbSeedInput = strconv.FormatInt(m.bvbSeed, 10)
+	}
+	m.dismissToasts()
+	return m, nil
+}
+
+// handleBvBSeedInput handles text input for the BvB seed. An empty seed makes
+// the next sessions random again.
+func (m Model) handleBvBSeedInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.bvbInputtingSeed = false
+		m.bvbSeedInput = ""
+		m.dismissToasts(SeverityError)
+
+	case tea.KeyBackspace:
+		if len(m.bvbSeedInput) > 0 {
+			m.bvbSeedInput = m.bvbSeedInput[:len(m.bvbSeedInput)-1]
 		}
 
-	case "enter":
-		return m.handleBvBGameModeSelection()
+	case tea.KeyEnter:
+		if m.bvbSeedInput == "" {
+			m.bvbSeedSet = false
+			m.bvbInputtingSeed = false
+			m.notify(SeverityInfo, "Seed cleared: games will be random")
+			return m, nil
+		}
+		seed, err := strconv.ParseInt(m.bvbSeedInput, 10, 64)
+		if err != nil {
+			m.notify(SeverityError, "Seed must be a whole number")
+			return m, nil
+		}
+		m.bvbSeed = seed
+		m.bvbSeedSet = true
+		m.bvbInputtingSeed = false
+		m.notify(SeverityInfo, fmt.Sprintf("Seed set to %d", seed))
 
-	case "esc":
-		// Go back to BvB bot select (Black selection)
-		m.popScreen()
-		m.bvbSelectingWhite = false
+	case tea.KeyRunes:
+		for _, r := range msg.Runes {
+			if (r >= '0' && r <= '9') || (r == '-' && m.bvbSeedInput == "") {
+				m.bvbSeedInput += string(r)
+			}
+		}
 	}
 
 	return m, nil
 }
 
-// handleBvBGameModeSelection executes the action for the selected game mode.
-func (m Model) handleBvBGameModeSelection() (tea.Model, tea.Cmd) {
-	selected := m.menuOptions[m.menuSelection]
+// askBvBOpenings switches to text input for the openings the games start from.
+func (m Model) askBvBOpenings() (tea.Model, tea.Cmd) {
+	m.bvbInputtingOpenings = true
+	m.bvbOpeningsInput = ""
+	m.dismissToasts()
+	return m, nil
+}
 
-	switch selected {
-	case "Single Game":
-		m.bvbGameCount = 1
-		m.bvbGridRows = 1
-		m.bvbGridCols = 1
-		// For single game, go directly to gameplay with single view (no view mode selection needed)
-		m.bvbViewMode = BvBSingleView
-		return m.startBvBSession()
+// handleBvBOpeningsInput handles text input for the BvB openings: a FEN to start
+// every game from, or the path of an opening suite in FEN or EPD, one position
+// per line. Empty input goes back to the standard starting position.
+func (m Model) handleBvBOpeningsInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.bvbInputtingOpenings = false
+		m.bvbOpeningsInput = ""
+		m.dismissToasts(SeverityError)
 
-	case "Multi-Game":
-		// Switch to text input mode for game count
-		m.bvbInputtingCount = true
-		m.bvbCountInput = ""
-		m.statusMsg = ""
-		m.errorMsg = ""
+	case tea.KeyBackspace:
+		if len(m.bvbOpeningsInput) > 0 {
+			m.bvbOpeningsInput = m.bvbOpeningsInput[:len(m.bvbOpeningsInput)-1]
+		}
+
+	case tea.KeyEnter:
+		input := strings.TrimSpace(m.bvbOpeningsInput)
+		if input == "" {
+			m.bvbOpenings = nil
+			m.bvbOpeningsName = ""
+			m.bvbInputtingOpenings = false
+			m.notify(SeverityInfo, "Openings cleared: games start from the standard position")
+			return m, nil
+		}
+		openings, name, err := loadBvBOpenings(input)
+		if err != nil {
+			m.notify(SeverityError, "Invalid openings: "+err.Error())
+			return m, nil
+		}
+		m.bvbOpenings = openings
+		m.bvbOpeningsName = name
+		m.bvbInputtingOpenings = false
+		m.notify(SeverityInfo, "Openings set: "+m.bvbOpeningsInfo())
+
+	case tea.KeySpace:
+		m.bvbOpeningsInput += " "
+
+	case tea.KeyRunes:
+		m.bvbOpeningsInput += string(msg.Runes)
 	}
 
 	return m, nil
 }
 
+// loadBvBOpenings reads the openings typed in: a single FEN, or else the path of
+// an opening suite. It returns the positions and a short name for them.
+func loadBvBOpenings(input string) ([]*engine.Board, string, error) {
+	if board, err := engine.LoadFEN(input); err == nil {
+		if board.IsGameOver() {
+			return nil, "", fmt.Errorf("the game is already over: %s", board.Status())
+		}
+		return []*engine.Board{board}, board.ToFEN(), nil
+	}
+	openings, err := bvb.LoadOpenings(input)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, "", fmt.Errorf("%s is neither a FEN nor an opening suite file", input)
+		}
+		return nil, "", err
+	}
+	return openings, filepath.Base(input), nil
+}
+
+// bvbOpeningsInfo describes the openings the next BvB session starts from.
+func (m Model) bvbOpeningsInfo() string {
+	info := "standard"
+	switch {
+	case len(m.bvbOpenings) == 1:
+		info = m.bvbOpeningsName
+	case len(m.bvbOpenings) > 1:
+		info = fmt.Sprintf("%s (%d positions)", m.bvbOpeningsName, len(m.bvbOpenings))
+	}
+	if m.bvbPairedOpenings {
+		info += ", paired"
+	}
+	return info
+}
+
+// togglePairedGames switches between playing each opening once and playing it
+// in a pair of games with the bots swapping colors.
+func (m Model) togglePairedGames() (tea.Model, tea.Cmd) {
+	m.bvbPairedOpenings = !m.bvbPairedOpenings
+	if m.bvbPairedOpenings {
+		m.notify(SeverityInfo, "Paired games: each opening is played with both bots as White")
+	} else {
+		m.notify(SeverityInfo, "Paired games off")
+	}
+	return m, nil
+}
+
 // handleBvBCountInput handles text input for the multi-game count.
 func (m Model) handleBvBCountInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.Type {
@@ -1215,7 +1831,7 @@ func (m Model) handleBvBCountInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Cancel count input, go back to menu mode
 		m.bvbInputtingCount = false
 		m.bvbCountInput = ""
-		m.errorMsg = ""
+		m.dismissToasts(SeverityError)
 
 	case tea.KeyBackspace:
 		if len(m.bvbCountInput) > 0 {
@@ -1226,18 +1842,14 @@ func (m Model) handleBvBCountInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Validate and submit count
 		count, err := parsePositiveInt(m.bvbCountInput)
 		if err != nil {
-			m.errorMsg = "Please enter a positive integer"
+			m.notify(SeverityError, "Please enter a positive integer")
 			return m, nil
 		}
 		m.bvbGameCount = count
 		m.bvbInputtingCount = false
-		m.pushScreen(ScreenBvBGridConfig)
-		m.menuOptions = []string{"1x1", "2x2", "2x3", "2x4", "Custom"}
-		m.menuSelection = 0
 		m.bvbInputtingGrid = false
 		m.bvbCustomGridInput = ""
-		m.statusMsg = ""
-		m.errorMsg = ""
+		m.openMenu(ScreenBvBGridConfig)
 
 	case tea.KeyRunes:
 		// Only allow digits
@@ -1269,63 +1881,23 @@ func parsePositiveInt(s string) (int, error) {
 	return n, nil
 }
 
-// handleBvBGridConfigKeys handles keyboard input for the BvB grid configuration screen.
-// Supports preset selection (1x1, 2x2, 2x3, 2x4) or custom grid input.
-func (m Model) handleBvBGridConfigKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	m.errorMsg = ""
-
-	if m.bvbInputtingGrid {
-		return m.handleBvBGridInput(msg)
-	}
-
-	switch msg.String() {
-	case "up", "k":
-		if m.menuSelection > 0 {
-			m.menuSelection--
-		} else {
-			m.menuSelection = len(m.menuOptions) - 1
-		}
-
-	case "down", "j":
-		if m.menuSelection < len(m.menuOptions)-1 {
-			m.menuSelection++
-		} else {
-			m.menuSelection = 0
-		}
+// handleBvBGridConfigKeys handles keyboard input for the BvB grid configuration screen.
+// Supports preset selection (1x1, 2x2, 2x3, 2x4) or custom grid input.
+func (m Model) handleBvBGridConfigKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.dismissToasts(SeverityError)
 
-	case "enter":
-		return m.handleBvBGridSelection()
+	if m.bvbInputtingGrid {
+		return m.handleBvBGridInput(msg)
+	}
 
-	case "esc":
+	if m.keys.Matches(msg, ActionBack) {
 		// Go back to game mode selection
 		m.popScreen()
 		m.bvbInputtingGrid = false
-	}
-
-	return m, nil
-}
-
-// handleBvBGridSelection handles the selection of a grid preset or custom option.
-func (m Model) handleBvBGridSelection() (tea.Model, tea.Cmd) {
-	selected := m.menuOptions[m.menuSelection]
-
-	switch selected {
-	case "1x1":
-		m.bvbGridRows, m.bvbGridCols = 1, 1
-	case "2x2":
-		m.bvbGridRows, m.bvbGridCols = 2, 2
-	case "2x3":
-		m.bvbGridRows, m.bvbGridCols = 2, 3
-	case "2x4":
-		m.bvbGridRows, m.bvbGridCols = 2, 4
-	case "Custom":
-		m.bvbInputtingGrid = true
-		m.bvbCustomGridInput = ""
 		return m, nil
 	}
 
-	// Navigate to concurrency selection screen
-	return m.navigateToConcurrencySelect()
+	return m.updateMenu(msg)
 }
 
 // handleBvBGridInput handles text input for custom grid dimensions.
@@ -1335,7 +1907,7 @@ func (m Model) handleBvBGridInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case tea.KeyEsc:
 		m.bvbInputtingGrid = false
 		m.bvbCustomGridInput = ""
-		m.errorMsg = ""
+		m.dismissToasts(SeverityError)
 
 	case tea.KeyBackspace:
 		if len(m.bvbCustomGridInput) > 0 {
@@ -1345,11 +1917,12 @@ func (m Model) handleBvBGridInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case tea.KeyEnter:
 		rows, cols, err := parseGridDimensions(m.bvbCustomGridInput)
 		if err != nil {
-			m.errorMsg = err.Error()
+			m.notify(SeverityError, err.Error())
 			return m, nil
 		}
 		m.bvbGridRows = rows
 		m.bvbGridCols = cols
+		m.bvbGridAuto = false
 		m.bvbInputtingGrid = false
 		// Navigate to concurrency selection screen
 		return m.navigateToConcurrencySelect()
@@ -1372,8 +1945,7 @@ func (m Model) navigateToConcurrencySelect() (tea.Model, tea.Cmd) {
 	m.bvbConcurrencySelection = 0 // Default to Recommended
 	m.bvbInputtingConcurrency = false
 	m.bvbCustomConcurrency = ""
-	m.statusMsg = ""
-	m.errorMsg = ""
+	m.dismissToasts()
 	return m, nil
 }
 
@@ -1382,34 +1954,33 @@ func (m Model) navigateToConcurrencySelect() (tea.Model, tea.Cmd) {
 func (m Model) navigateToViewModeSelect() (tea.Model, tea.Cmd) {
 	m.pushScreen(ScreenBvBViewModeSelect)
 	m.bvbViewModeSelection = 0 // Default to Grid View
-	m.statusMsg = ""
-	m.errorMsg = ""
+	m.dismissToasts()
 	return m, nil
 }
 
 // handleBvBViewModeSelectKeys handles keyboard input for the BvB view mode selection screen.
 // Supports arrow keys for navigation, Enter to select, and Esc to go back.
 func (m Model) handleBvBViewModeSelectKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	m.errorMsg = ""
+	m.dismissToasts(SeverityError)
 
 	numOptions := 3 // Grid View, Single Board, Stats Only
 
-	switch msg.String() {
-	case "up", "k":
+	switch {
+	case m.keys.Matches(msg, ActionUp):
 		if m.bvbViewModeSelection > 0 {
 			m.bvbViewModeSelection--
 		} else {
 			m.bvbViewModeSelection = numOptions - 1
 		}
 
-	case "down", "j":
+	case m.keys.Matches(msg, ActionDown):
 		if m.bvbViewModeSelection < numOptions-1 {
 			m.bvbViewModeSelection++
 		} else {
 			m.bvbViewModeSelection = 0
 		}
 
-	case "enter":
+	case m.keys.Matches(msg, ActionSelect):
 		// Set view mode based on selection
 		switch m.bvbViewModeSelection {
 		case 0:
@@ -1422,14 +1993,14 @@ func (m Model) handleBvBViewModeSelectKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 		// Start the session with the selected view mode
 		return m.startBvBSession()
 
-	case "esc":
+	case m.keys.Matches(msg, ActionBack):
 		// Go back to concurrency select
 		// popScreen() handles menu state restoration
 		m.popScreen()
 		m.bvbConcurrencySelection = 0
 		m.bvbInputtingConcurrency = false
 		m.bvbCustomConcurrency = ""
-		m.statusMsg = ""
+		m.dismissToasts(SeverityInfo, SeverityWarning)
 	}
 
 	return m, nil
@@ -1438,30 +2009,36 @@ func (m Model) handleBvBViewModeSelectKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 // handleBvBConcurrencySelectKeys handles keyboard input for the BvB concurrency selection screen.
 // Supports arrow keys for navigation, Enter to select, and Esc to go back.
 func (m Model) handleBvBConcurrencySelectKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	m.errorMsg = ""
+	m.dismissToasts(SeverityError)
 
 	if m.bvbInputtingConcurrency {
 		return m.handleBvBConcurrencyInput(msg)
 	}
 
-	numOptions := 2 // Recommended, Custom
+	numOptions := 3 // Recommended, Custom, CPU Limit
 
-	switch msg.String() {
-	case "up", "k":
+	switch {
+	case m.bvbConcurrencySelection == 2 && m.keys.Matches(msg, ActionLeft):
+		m.bvbCPULimit = stepCPULimit(m.bvbCPULimit, -1, getCPUCount())
+
+	case m.bvbConcurrencySelection == 2 && m.keys.Matches(msg, ActionRight):
+		m.bvbCPULimit = stepCPULimit(m.bvbCPULimit, 1, getCPUCount())
+
+	case m.keys.Matches(msg, ActionUp):
 		if m.bvbConcurrencySelection > 0 {
 			m.bvbConcurrencySelection--
 		} else {
 			m.bvbConcurrencySelection = numOptions - 1
 		}
 
-	case "down", "j":
+	case m.keys.Matches(msg, ActionDown):
 		if m.bvbConcurrencySelection < numOptions-1 {
 			m.bvbConcurrencySelection++
 		} else {
 			m.bvbConcurrencySelection = 0
 		}
 
-	case "enter":
+	case m.keys.Matches(msg, ActionSelect):
 		switch m.bvbConcurrencySelection {
 		case 0: // Recommended
 			m.bvbConcurrency = bvb.CalculateDefaultConcurrency()
@@ -1469,14 +2046,16 @@ func (m Model) handleBvBConcurrencySelectKeys(msg tea.KeyMsg) (tea.Model, tea.Cm
 		case 1: // Custom
 			m.bvbInputtingConcurrency = true
 			m.bvbCustomConcurrency = ""
+		case 2: // CPU Limit
+			m.bvbCPULimit = stepCPULimit(m.bvbCPULimit, 1, getCPUCount())
 		}
 
-	case "esc":
+	case m.keys.Matches(msg, ActionBack):
 		// Go back to grid config
 		// popScreen() handles menu state restoration
 		m.popScreen()
 		m.bvbInputtingGrid = false
-		m.statusMsg = ""
+		m.dismissToasts(SeverityInfo, SeverityWarning)
 	}
 
 	return m, nil
@@ -1488,7 +2067,7 @@ func (m Model) handleBvBConcurrencyInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case tea.KeyEsc:
 		m.bvbInputtingConcurrency = false
 		m.bvbCustomConcurrency = ""
-		m.errorMsg = ""
+		m.dismissToasts(SeverityError)
 
 	case tea.KeyBackspace:
 		if len(m.bvbCustomConcurrency) > 0 {
@@ -1498,7 +2077,7 @@ func (m Model) handleBvBConcurrencyInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case tea.KeyEnter:
 		concurrency, err := parsePositiveInt(m.bvbCustomConcurrency)
 		if err != nil {
-			m.errorMsg = "Must be a positive integer (minimum 1)"
+			m.notify(SeverityError, "Must be a positive integer (minimum 1)")
 			return m, nil
 		}
 		m.bvbConcurrency = concurrency
@@ -1516,6 +2095,28 @@ func (m Model) handleBvBConcurrencyInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// stepCPULimit moves the BvB CPU limit one step in direction (-1 or +1) through
+// 1..numCPU-1 and 0 (all cores), wrapping around at either end.
+func stepCPULimit(limit, direction, numCPU int) int {
+	if numCPU <= 1 {
+		return 0
+	}
+	// Treat "all cores" as numCPU so the limits form one cycle
+	if limit <= 0 || limit >= numCPU {
+		limit = numCPU
+	}
+	limit += direction
+	if limit < 1 {
+		limit = numCPU
+	} else if limit > numCPU {
+		limit = 1
+	}
+	if limit == numCPU {
+		return 0
+	}
+	return limit
+}
+
 // parseGridDimensions parses a grid string like "2x3" into rows and cols.
 // Validates that total boards (rows*cols) does not exceed 8.
 func parseGridDimensions(s string) (int, int, error) {
@@ -1549,51 +2150,70 @@ func parseGridDimensions(s string) (int, int, error) {
 
 // startBvBSession creates a SessionManager with the configured settings and starts it.
 func (m Model) startBvBSession() (tea.Model, tea.Cmd) {
-	// Map UI bot difficulty to bvb bot difficulty
-	whiteDiff := uiBotDiffToBvB(m.bvbWhiteDiff)
-	blackDiff := uiBotDiffToBvB(m.bvbBlackDiff)
-	whiteName := botDifficultyName(m.bvbWhiteDiff) + " Bot"
-	blackName := botDifficultyName(m.bvbBlackDiff) + " Bot"
-
 	// Use the concurrency value selected by the user
 	// For single-game mode, bvbConcurrency will be 0 (auto-detect)
 	// For multi-game mode, it's set by the concurrency selection screen
 	concurrency := m.bvbConcurrency
 
-	manager := bvb.NewSessionManager(whiteDiff, blackDiff, whiteName, blackName, m.bvbGameCount, concurrency)
+	manager := bvb.NewSessionManagerFor(m.bvbWhiteConfig(), m.bvbBlackConfig(), m.bvbGameCount, concurrency)
+	manager.SetCPULimit(m.bvbCPULimit)
+	if m.bvbSeedSet {
+		manager.SetSeed(m.bvbSeed)
+	}
+	if m.bvbSPRT {
+		manager.EnableSPRT(m.sprtConfig())
+	}
+	var start *engine.Board
+	if m.bvbStartFEN != "" {
+		var err error
+		if start, err = engine.FromFEN(m.bvbStartFEN); err != nil {
+			m.notify(SeverityError, fmt.Sprintf("Invalid FEN: %v", err))
+			return m, nil
+		}
+		manager.SetStartPosition(start)
+	} else if len(m.bvbOpenings) > 0 || m.bvbPairedOpenings {
+		manager.SetOpenings(m.bvbOpenings, m.bvbPairedOpenings)
+	}
+	var stream *GameStream
+	if m.config.BvBStreamFile != "" {
+		var err error
+		stream, err = OpenGameStream(m.config.BvBStreamFile)
+		if err != nil {
+			m.notify(SeverityError, "Failed to open stream file: "+err.Error())
+			m.screen = ScreenBvBGameMode
+			m.bvbInputtingCount = false
+			return m, nil
+		}
+		// The manager closes the stream once the games are over
+		manager.SetObserver(stream)
+	}
 	if err := manager.Start(); err != nil {
+		if stream != nil {
+			_ = stream.Close()
+		}
 		// Engine creation failed - stay on game mode screen and show error
-		m.errorMsg = "Failed to start bot session: " + err.Error()
+		m.notify(SeverityError, "Failed to start bot session: "+err.Error())
 		m.screen = ScreenBvBGameMode
 		m.bvbInputtingCount = false
 		return m, nil
 	}
 
 	m.bvbManager = manager
+	m.bvbCells = newBvBCellCache()
 	m.bvbSpeed = bvb.SpeedNormal
 	m.bvbSelectedGame = 0
+	m.bvbPageIndex = 0
+	m.bvbGridFilter = BvBFilterAll
+	m.bvbResultSort = BvBSortGameNumber
+	m.bvbLastNotable = 0
+	m.layoutBvBGridAuto()
 	// Note: bvbViewMode is set by the view mode selection screen or single game handler
 	// Don't override it here
 	m.bvbPaused = false
 	m.bvbRecentCompletions = nil // Reset recent completions for new session
 	m.screen = ScreenBvBGamePlay
-	m.statusMsg = ""
-	m.errorMsg = ""
-	return m, bvbTickCmd(m.bvbSpeed)
-}
-
-// uiBotDiffToBvB maps the UI BotDifficulty to the bot package Difficulty.
-func uiBotDiffToBvB(d BotDifficulty) bot.Difficulty {
-	switch d {
-	case BotEasy:
-		return bot.Easy
-	case BotMedium:
-		return bot.Medium
-	case BotHard:
-		return bot.Hard
-	default:
-		return bot.Easy
-	}
+	m.dismissToasts()
+	return m, bvbTickCmd()
 }
 
 // handleBvBGamePlayKeys handles keyboard input during BvB game viewing.
@@ -1609,17 +2229,17 @@ func (m Model) handleBvBGamePlayKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleBvBJumpInput(msg)
 	}
 
-	switch msg.String() {
-	case "g", "G":
+	switch {
+	case m.keys.Matches(msg, ActionJumpToGame):
 		// Show jump prompt (only if multi-game mode)
 		if m.bvbManager != nil && m.bvbGameCount > 1 {
 			m.bvbShowJumpPrompt = true
 			m.bvbJumpInput = ""
-			m.errorMsg = ""
+			m.dismissToasts(SeverityError)
 		}
 		return m, nil
 
-	case "esc":
+	case m.keys.Matches(msg, ActionBack):
 		// Check if session is still running (has active games)
 		if m.bvbManager != nil && !m.bvbManager.AllFinished() {
 			// Show abort confirmation
@@ -1631,45 +2251,22 @@ func (m Model) handleBvBGamePlayKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.screen = ScreenBvBStats
 		m.bvbStatsSelection = 0
 		m.bvbStatsResultsPage = 0
-		m.menuOptions = []string{"New Session", "Return to Menu"}
+		m.scrollOffset = 0
+		m.menuOptions = menuLabels(bvbStatsMenu())
 		return m, nil
 
-	case " ":
-		// Toggle pause/resume
-		if m.bvbManager != nil {
-			if m.bvbPaused {
-				m.bvbManager.Resume()
-				m.bvbPaused = false
-			} else {
-				m.bvbManager.Pause()
-				m.bvbPaused = true
-			}
-		}
+	case m.keys.Matches(msg, ActionToggle):
+		m.toggleBvBPause()
 
-	case "t", "T":
-		// Toggle between Normal and Instant speed
-		if m.bvbSpeed == bvb.SpeedNormal {
-			m.bvbSpeed = bvb.SpeedInstant
-		} else {
-			m.bvbSpeed = bvb.SpeedNormal
-		}
-		if m.bvbManager != nil {
-			m.bvbManager.SetSpeed(m.bvbSpeed)
-		}
+	case m.keys.Matches(msg, ActionToggleSpeed):
+		m.toggleBvBSpeed()
 
-	case "tab", "v", "V":
-		// Cycle view mode: Grid -> Single -> StatsOnly -> Grid
-		switch m.bvbViewMode {
-		case BvBGridView:
-			m.bvbViewMode = BvBSingleView
-		case BvBSingleView:
-			m.bvbViewMode = BvBStatsOnlyView
-		case BvBStatsOnlyView:
-			m.bvbViewMode = BvBGridView
-		}
+	case m.keys.Matches(msg, ActionToggleView):
+		m.cycleBvBViewMode()
 
-	case "left", "h":
+	case m.keys.Matches(msg, ActionLeft):
 		if m.bvbManager != nil {
+			m.bvbFollow = false // Paging by hand ends follow mode
 			if m.bvbViewMode == BvBSingleView {
 				// Previous game in single view
 				sessions := m.bvbManager.Sessions()
@@ -1686,8 +2283,9 @@ func (m Model) handleBvBGamePlayKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-	case "right", "l":
+	case m.keys.Matches(msg, ActionRight):
 		if m.bvbManager != nil {
+			m.bvbFollow = false
 			if m.bvbViewMode == BvBSingleView {
 				// Next game in single view
 				sessions := m.bvbManager.Sessions()
@@ -1697,8 +2295,8 @@ func (m Model) handleBvBGamePlayKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 					m.bvbSelectedGame = 0
 				}
 			} else {
-				// Next page in grid view
-				sessions := m.bvbManager.Sessions()
+				// Next page in grid view, among the games the filter shows
+				sessions := m.bvbGridSessions()
 				boardsPerPage := m.bvbGridRows * m.bvbGridCols
 				totalPages := (len(sessions) + boardsPerPage - 1) / boardsPerPage
 				if m.bvbPageIndex < totalPages-1 {
@@ -1707,70 +2305,126 @@ func (m Model) handleBvBGamePlayKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-	case "f":
-		// Export FEN of the focused game
+	case m.keys.Matches(msg, ActionCopyFEN):
+		m.copyBvBFEN()
+
+	case m.keys.Matches(msg, ActionFilterGames):
+		if m.bvbManager != nil && m.bvbViewMode == BvBGridView {
+			m.cycleBvBGridFilter()
+		}
+
+	case m.keys.Matches(msg, ActionFollowGames):
 		if m.bvbManager != nil {
-			sessions := m.bvbManager.Sessions()
-			var targetSession *bvb.GameSession
-			if m.bvbViewMode == BvBSingleView {
-				if m.bvbSelectedGame < len(sessions) {
-					targetSession = sessions[m.bvbSelectedGame]
-				}
-			} else {
-				// In grid view, use first visible game on current page
-				boardsPerPage := m.bvbGridRows * m.bvbGridCols
-				startIdx := m.bvbPageIndex * boardsPerPage
-				if startIdx < len(sessions) {
-					targetSession = sessions[startIdx]
-				}
-			}
-			if targetSession != nil {
-				board := targetSession.CurrentBoard()
-				if board != nil {
-					fen := board.ToFEN()
-					err := util.CopyToClipboard(fen)
-					if err != nil {
-						m.statusMsg = fmt.Sprintf("FEN: %s (Failed to copy: %v)", fen, err)
-					} else {
-						m.statusMsg = fmt.Sprintf("FEN copied to clipboard")
-					}
-				}
-			}
+			m.toggleBvBFollow()
 		}
+
+	case m.keys.Matches(msg, ActionBookmark):
+		m.toggleBvBBookmark()
+
+	case m.keys.Matches(msg, ActionNextNotable):
+		if s := m.focusedBvBSession(); s != nil {
+			m.openNextNotableBvBGame(s.GameNumber())
+		}
+
+	case m.keys.Matches(msg, ActionShowLog):
+		m.openBvBLog()
 	}
 
 	return m, nil
 }
 
+// toggleBvBPause pauses or resumes the running Bot vs Bot session.
+func (m *Model) toggleBvBPause() {
+	if m.bvbManager != nil {
+		if m.bvbPaused {
+			m.bvbManager.Resume()
+			m.bvbPaused = false
+		} else {
+			m.bvbManager.Pause()
+			m.bvbPaused = true
+		}
+	}
+}
+
+// toggleBvBSpeed switches Bot vs Bot playback between Normal and Instant speed.
+func (m *Model) toggleBvBSpeed() {
+	if m.bvbSpeed == bvb.SpeedNormal {
+		m.bvbSpeed = bvb.SpeedInstant
+	} else {
+		m.bvbSpeed = bvb.SpeedNormal
+	}
+	if m.bvbManager != nil {
+		m.bvbManager.SetSpeed(m.bvbSpeed)
+	}
+}
+
+// cycleBvBViewMode cycles the Bot vs Bot view mode: Grid -> Single -> StatsOnly -> Grid.
+func (m *Model) cycleBvBViewMode() {
+	switch m.bvbViewMode {
+	case BvBGridView:
+		m.bvbViewMode = BvBSingleView
+	case BvBSingleView:
+		m.bvbViewMode = BvBStatsOnlyView
+	case BvBStatsOnlyView:
+		m.bvbViewMode = BvBGridView
+	}
+}
+
+// focusedBvBSession returns the Bot vs Bot game the keys act on: the selected
+// game in single view, or the first game on the current page otherwise.
+// It returns nil if there is no such game.
+func (m Model) focusedBvBSession() *bvb.GameSession {
+	if m.bvbManager == nil {
+		return nil
+	}
+	if m.bvbViewMode == BvBSingleView {
+		sessions := m.bvbManager.Sessions()
+		if m.bvbSelectedGame < len(sessions) {
+			return sessions[m.bvbSelectedGame]
+		}
+		return nil
+	}
+	// In grid view, use first visible game on current page
+	sessions := m.bvbGridSessions()
+	boardsPerPage := m.bvbGridRows * m.bvbGridCols
+	startIdx := m.bvbPageIndex * boardsPerPage
+	if startIdx < len(sessions) {
+		return sessions[startIdx]
+	}
+	return nil
+}
+
+// copyBvBFEN copies the FEN of the focused Bot vs Bot game to the clipboard.
+// In grid view the first game on the current page is used.
+func (m *Model) copyBvBFEN() {
+	if targetSession := m.focusedBvBSession(); targetSession != nil {
+		board := targetSession.CurrentBoard()
+		if board != nil {
+			fen := board.ToFEN()
+			_ = config.AddFENHistory(fen)
+			err := util.CopyToClipboard(fen)
+			if err != nil {
+				m.notify(SeverityInfo, fmt.Sprintf("FEN: %s (Failed to copy: %v)", fen, err))
+			} else {
+				m.notify(SeverityInfo, fmt.Sprintf("FEN copied to clipboard"))
+			}
+		}
+	}
+}
+
 // handleBvBJumpInput handles text input for the game jump prompt.
 // Allows the user to type a game number and press Enter to jump to it.
 func (m Model) handleBvBJumpInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.Type {
-	case tea.KeyEsc:
-		// Cancel jump prompt
+	switch m.bvbJumpPrompt().update(m.keys, msg, nil, &m.bvbJumpInput) {
+	case modalCancelled:
 		m.bvbShowJumpPrompt = false
 		m.bvbJumpInput = ""
-		m.errorMsg = ""
-
-	case tea.KeyBackspace:
-		// Remove last character
-		if len(m.bvbJumpInput) > 0 {
-			m.bvbJumpInput = m.bvbJumpInput[:len(m.bvbJumpInput)-1]
-		}
-		m.errorMsg = ""
-
-	case tea.KeyEnter:
+		m.dismissToasts(SeverityError)
+	case modalChanged:
+		m.dismissToasts(SeverityError)
+	case modalChosen:
 		// Validate and submit the game number
 		m.handleBvBJumpSubmit()
-
-	case tea.KeyRunes:
-		// Only allow digits
-		for _, r := range msg.Runes {
-			if r >= '0' && r <= '9' {
-				m.bvbJumpInput += string(r)
-			}
-		}
-		m.errorMsg = ""
 	}
 
 	return m, nil
@@ -1779,26 +2433,29 @@ func (m Model) handleBvBJumpInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 // handleBvBJumpSubmit validates the jump input and navigates to the specified game.
 func (m *Model) handleBvBJumpSubmit() {
 	if m.bvbJumpInput == "" {
-		m.errorMsg = fmt.Sprintf("Enter a game number (1-%d)", m.bvbGameCount)
+		m.notify(SeverityError, fmt.Sprintf("Enter a game number (1-%d)", m.bvbGameCount))
 		return
 	}
 
 	gameNum, err := parsePositiveInt(m.bvbJumpInput)
 	if err != nil || gameNum < 1 || gameNum > m.bvbGameCount {
-		m.errorMsg = fmt.Sprintf("Invalid game number. Enter 1-%d", m.bvbGameCount)
+		m.notify(SeverityError, fmt.Sprintf("Invalid game number. Enter 1-%d", m.bvbGameCount))
 		m.bvbShowJumpPrompt = false
 		m.bvbJumpInput = ""
 		return
 	}
 
-	// Navigate to the specified game (convert to 0-indexed)
+	// Navigate to the specified game (convert to 0-indexed), which ends follow mode
 	m.bvbSelectedGame = gameNum - 1
+	m.bvbFollow = false
 	m.bvbShowJumpPrompt = false
 	m.bvbJumpInput = ""
-	m.errorMsg = ""
+	m.dismissToasts(SeverityError)
 
-	// If in grid view, also update the page index to show the selected game
+	// If in grid view, also update the page index to show the selected game,
+	// showing all games again since the filter may hide it
 	if m.bvbViewMode == BvBGridView {
+		m.bvbGridFilter = BvBFilterAll
 		boardsPerPage := m.bvbGridRows * m.bvbGridCols
 		m.bvbPageIndex = m.bvbSelectedGame / boardsPerPage
 	}
@@ -1807,40 +2464,31 @@ func (m *Model) handleBvBJumpSubmit() {
 // handleBvBAbortConfirmKeys handles keyboard input for the BvB abort confirmation dialog.
 // Supports navigation between Cancel/Abort options, Enter to confirm, and ESC to cancel.
 func (m Model) handleBvBAbortConfirmKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "up", "down", "k", "j":
-		// Toggle between Cancel (0) and Abort (1)
-		m.bvbAbortSelection = 1 - m.bvbAbortSelection
-	case "enter":
+	switch m.bvbAbortPrompt().update(m.keys, msg, &m.bvbAbortSelection, nil) {
+	case modalChosen:
+		m.bvbShowAbortConfirm = false
 		if m.bvbAbortSelection == 1 { // "Abort Session"
 			if m.bvbManager != nil {
 				m.bvbManager.Stop()
 				m.bvbManager = nil
 			}
-			m.bvbShowAbortConfirm = false
 			m.screen = ScreenMainMenu
 			m.menuOptions = buildMainMenuOptions()
 			m.menuSelection = 0
 			m.navStack = nil
-		} else { // "Cancel"
-			m.bvbShowAbortConfirm = false
 		}
-		return m, nil
-	case "esc":
-		// ESC on dialog = Cancel
+	case modalCancelled:
 		m.bvbShowAbortConfirm = false
 	}
 	return m, nil
 }
 
-// bvbTickCmd returns a command that sends a BvBTickMsg after a delay based on speed.
-func bvbTickCmd(speed bvb.PlaybackSpeed) tea.Cmd {
-	delay := speed.Duration()
-	if delay == 0 {
-		// For instant speed, use a short tick interval for rendering
-		delay = 100 * time.Millisecond
-	}
-	return tea.Tick(delay, func(time.Time) tea.Msg {
+// bvbTickCmd returns a command that sends a BvBTickMsg after a frame. The frame
+// rate doesn't follow the playback speed: the games play in their own goroutines,
+// and the UI only samples them, so many games at Instant speed don't flood it
+// with renders and the think bars still move at Normal speed.
+func bvbTickCmd() tea.Cmd {
+	return tea.Tick(bvbFrameInterval, func(time.Time) tea.Msg {
 		return BvBTickMsg{}
 	})
 }
@@ -1853,17 +2501,21 @@ func (m Model) handleBvBTick() (tea.Model, tea.Cmd) {
 
 	// Update recent completions for stats-only view
 	m.updateRecentCompletions()
+	// The live statistics above the grid grow as games finish
+	m.layoutBvBGridAuto()
+	m.followBvBRunningGames()
 
 	if m.bvbManager.AllFinished() {
 		m.screen = ScreenBvBStats
 		m.bvbStatsSelection = 0
 		m.bvbStatsResultsPage = 0
-		m.menuOptions = []string{"New Session", "Return to Menu"}
+		m.scrollOffset = 0
+		m.menuOptions = menuLabels(bvbStatsMenu())
 		return m, nil
 	}
 
 	// Schedule next tick
-	return m, bvbTickCmd(m.bvbSpeed)
+	return m, bvbTickCmd()
 }
 
 // updateRecentCompletions updates the list of recent game completions for stats-only view.
@@ -1902,21 +2554,22 @@ func (m *Model) updateRecentCompletions() {
 
 // handleBvBStatsKeys handles keyboard input on the BvB statistics screen.
 func (m Model) handleBvBStatsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "up", "k":
+	switch {
+	case m.keys.Matches(msg, ActionUp):
 		if m.bvbStatsSelection > 0 {
 			m.bvbStatsSelection--
 		}
-	case "down", "j":
+	case m.keys.Matches(msg, ActionDown):
 		if m.bvbStatsSelection < len(m.menuOptions)-1 {
 			m.bvbStatsSelection++
 		}
-	case "left", "h":
+	case m.keys.Matches(msg, ActionLeft):
 		// Previous page of individual results
 		if m.bvbStatsResultsPage > 0 {
 			m.bvbStatsResultsPage--
+			m.scrollOffset = 0
 		}
-	case "right", "l":
+	case m.keys.Matches(msg, ActionRight):
 		// Next page of individual results
 		if m.bvbManager != nil {
 			stats := m.bvbManager.Stats()
@@ -1924,33 +2577,26 @@ func (m Model) handleBvBStatsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				totalPages := (len(stats.IndividualResults) + 14) / 15 // resultsPerPage = 15
 				if m.bvbStatsResultsPage < totalPages-1 {
 					m.bvbStatsResultsPage++
+					m.scrollOffset = 0
 				}
 			}
 		}
-	case "s", "S":
+	case m.keys.Matches(msg, ActionPageUp), m.keys.Matches(msg, ActionPageDown):
+		// Scroll results that don't fit the terminal
+		body, footer := m.bvbStatsParts()
+		m.scrollContent(msg, body, m.bvbStatsScrollHeight(footer), false)
+	case m.keys.Matches(msg, ActionExportStats):
 		// Export statistics to JSON file
 		return m.handleBvBStatsExport()
-	case "enter":
-		return m.handleBvBStatsSelection()
-	case "esc":
-		m.screen = ScreenMainMenu
-		m.menuOptions = buildMainMenuOptions()
-		m.menuSelection = 0
-		m.bvbManager = nil
-	}
-	return m, nil
-}
-
-// handleBvBStatsSelection handles the selected action on the stats screen.
-func (m Model) handleBvBStatsSelection() (tea.Model, tea.Cmd) {
-	switch m.bvbStatsSelection {
-	case 0: // New Session
-		m.screen = ScreenBvBBotSelect
-		m.menuOptions = []string{"Easy", "Medium", "Hard"}
-		m.menuSelection = 0
-		m.bvbSelectingWhite = true
-		m.bvbManager = nil
-	case 1: // Return to Menu
+	case m.keys.Matches(msg, ActionSortResults):
+		m.cycleBvBResultSort()
+	case m.keys.Matches(msg, ActionNextNotable):
+		m.openNextNotableBvBGame(m.bvbLastNotable)
+	case m.keys.Matches(msg, ActionShowLog):
+		m.openBvBLog()
+	case m.keys.Matches(msg, ActionSelect):
+		return m.runMenuItem(m.bvbStatsSelection)
+	case m.keys.Matches(msg, ActionBack):
 		m.screen = ScreenMainMenu
 		m.menuOptions = buildMainMenuOptions()
 		m.menuSelection = 0
@@ -1962,13 +2608,19 @@ func (m Model) handleBvBStatsSelection() (tea.Model, tea.Cmd) {
 // handleBvBStatsExport exports the session statistics to a JSON file.
 func (m Model) handleBvBStatsExport() (tea.Model, tea.Cmd) {
 	if m.bvbManager == nil {
-		m.errorMsg = "No session data to export"
+		m.notify(SeverityError, "No session data to export")
 		return m, nil
 	}
 
-	// Get bot difficulty names for the export
+	// Get bot difficulty names (or custom bot names) for the export
 	whiteBotName := botDifficultyName(m.bvbWhiteDiff)
+	if m.bvbWhiteBot != "" {
+		whiteBotName = m.bvbWhiteBot
+	}
 	blackBotName := botDifficultyName(m.bvbBlackDiff)
+	if m.bvbBlackBot != "" {
+		blackBotName = m.bvbBlackBot
+	}
 
 	// Generate export data
 	export := m.bvbManager.ExportStats(whiteBotName, blackBotName)
@@ -1976,13 +2628,12 @@ func (m Model) handleBvBStatsExport() (tea.Model, tea.Cmd) {
 	// Save to file (empty string uses default directory)
 	filepath, err := bvb.SaveSessionExport(export, "")
 	if err != nil {
-		m.errorMsg = fmt.Sprintf("Failed to export: %v", err)
-		m.statusMsg = ""
+		m.notify(SeverityError, fmt.Sprintf("Failed to export: %v", err))
 		return m, nil
 	}
 
-	m.statusMsg = fmt.Sprintf("Stats exported to: %s", filepath)
-	m.errorMsg = ""
+	m.notify(SeverityInfo, fmt.Sprintf("Stats exported to: %s", filepath))
+	m.dismissToasts(SeverityError)
 	return m, nil
 }
 
@@ -1991,72 +2642,74 @@ func (m Model) handleBvBStatsExport() (tea.Model, tea.Cmd) {
 // ESC to return to bot difficulty selection, and wraps around at top and bottom of the menu.
 func (m Model) handleColorSelectKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Clear any previous error or status messages when user takes action
-	m.errorMsg = ""
-	m.statusMsg = ""
+	m.dismissToasts()
 
-	switch msg.String() {
-	case "up", "k":
-		// Move selection up
-		if m.menuSelection > 0 {
-			m.menuSelection--
-		} else {
-			// Wrap to bottom of menu
-			m.menuSelection = len(m.menuOptions) - 1
-		}
+	switch {
+	case m.keys.Matches(msg, ActionToggle):
+		m.trainingOption = !m.trainingOption
+		return m, nil
 
-	case "down", "j":
-		// Move selection down
-		if m.menuSelection < len(m.menuOptions)-1 {
-			m.menuSelection++
-		} else {
-			// Wrap to top of menu
-			m.menuSelection = 0
-		}
+	case m.keys.Matches(msg, ActionLeft):
+		m.cycleMatchLength(-1)
+		return m, nil
 
-	case "enter":
-		return m.handleColorSelection()
+	case m.keys.Matches(msg, ActionRight):
+		m.cycleMatchLength(1)
+		return m, nil
 
-	case "esc":
+	case m.keys.Matches(msg, ActionBack):
 		// Return to previous screen using navigation stack
 		// popScreen() handles menu state restoration
 		m.popScreen()
-		m.statusMsg = ""
+		m.dismissToasts(SeverityInfo, SeverityWarning)
+		return m, nil
 	}
 
-	return m, nil
+	return m.updateMenu(msg)
+}
+
+// startBotGame starts a game of standard chess against the bot with the user
+// playing color, beginning a new match of the chosen length. If user plays Black,
+// triggers bot's opening move.
+func (m Model) startBotGame(color engine.Color) (tea.Model, tea.Cmd) {
+	m.match = matchScore{length: m.matchLengthOption}
+	return m.startBotGameVariant(color, nil)
 }
 
-// handleColorSelection executes the action for the currently selected color.
-// Sets the user's color and starts a new game.
-// If user plays Black, triggers bot's opening move.
-func (m Model) handleColorSelection() (tea.Model, tea.Cmd) {
-	selected := m.menuOptions[m.menuSelection]
+// startBotGameVariant starts a game of the given variant, nil for standard chess,
+// against the bot with the user playing color.
+func (m Model) startBotGameVariant(color engine.Color, variant engine.Variant) (tea.Model, tea.Cmd) {
+	m.userColor = color
 
-	switch selected {
-	case "Play as White":
-		m.userColor = engine.White
-	case "Play as Black":
-		m.userColor = engine.Black
+	// Discard any engine left over from a previous game so the new difficulty applies
+	if m.botEngine != nil {
+		_ = m.botEngine.Close()
+		m.botEngine = nil
 	}
+	m.botHopelessTurns = 0
 
-	// Create a new board with the standard starting position
-	m.board = engine.NewBoard()
+	// Create a new board with the bot's handicap material removed
+	botColor := engine.Black
+	if m.userColor == engine.Black {
+		botColor = engine.White
+	}
+	board := engine.NewHandicapBoard(m.handicap, botColor)
+	board.Variant = variant
+	m.game = engine.NewGameFrom(board)
+	m.beginGameRecord()
+	m.trainingMode = m.trainingOption
 	// Clear nav stack when starting game
 	m.clearNavStack()
 	// Switch to the GamePlay screen
 	m.screen = ScreenGamePlay
 	// Clear any previous status messages
-	m.statusMsg = ""
-	m.errorMsg = ""
+	m.dismissToasts()
 	// Clear any previous input
 	m.input = ""
-	// Reset resignation tracking
-	m.resignedBy = -1
 	// Reset draw offer state
 	m.drawOfferedBy = -1
 	m.drawOfferedByWhite = false
 	m.drawOfferedByBlack = false
-	m.drawByAgreement = false
 
 	// If user plays Black, bot should make the opening move
 	if m.userColor == engine.Black {
@@ -2066,27 +2719,40 @@ func (m Model) handleColorSelection() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// handleBotDifficultySelection executes the action for the currently selected bot difficulty.
-// Sets the bot difficulty and transitions to color selection.
-func (m Model) handleBotDifficultySelection() (tea.Model, tea.Cmd) {
-	selected := m.menuOptions[m.menuSelection]
+// chooseBot sets the bot difficulty, or the custom bot name, and transitions to handicap selection.
+func (m Model) chooseBot(diff BotDifficulty, name string) (tea.Model, tea.Cmd) {
+	m.botDifficulty = diff
+	m.botName = name
 
-	switch selected {
-	case "Easy":
-		m.botDifficulty = BotEasy
-	case "Medium":
-		m.botDifficulty = BotMedium
-	case "Hard":
-		m.botDifficulty = BotHard
+	// A simul is played without handicap, as White on every board
+	if m.simulSetup {
+		m.openMenu(ScreenSimulSetup)
+		return m, nil
 	}
 
-	// Transition to color selection screen using navigation stack
-	m.pushScreen(ScreenColorSelect)
-	m.menuOptions = []string{"Play as White", "Play as Black"}
-	m.menuSelection = 0
-	m.statusMsg = ""
-	m.errorMsg = ""
+	// Transition to handicap selection screen using navigation stack
+	m.openMenu(ScreenHandicapSelect)
+
+	return m, nil
+}
+
+// handleHandicapSelectKeys handles keyboard input for the HandicapSelect screen.
+// Supports arrow keys for navigation, Enter to select, and ESC to go back to difficulty selection.
+func (m Model) handleHandicapSelectKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.dismissToasts()
 
+	if m.keys.Matches(msg, ActionBack) {
+		m.popScreen()
+		return m, nil
+	}
+
+	return m.updateMenu(msg)
+}
+
+// chooseHandicap sets the odds the bot gives and transitions to color selection.
+func (m Model) chooseHandicap(h engine.Handicap) (tea.Model, tea.Cmd) {
+	m.handicap = h
+	m.openMenu(ScreenColorSelect)
 	return m, nil
 }
 
@@ -2094,52 +2760,124 @@ func (m Model) handleBotDifficultySelection() (tea.Model, tea.Cmd) {
 // It displays a thinking message, creates the appropriate bot engine based on difficulty,
 // and returns a command that will execute the move selection in a goroutine.
 func (m Model) makeBotMove() (Model, tea.Cmd) {
-	// Display thinking message
-	m.statusMsg = getRandomThinkingMessage()
+	// Resign instead of playing on when the position has been hopeless for two turns in a row,
+	// so a capture in the middle of an exchange doesn't trigger resignation
+	if bot.ShouldResign(m.botEngine, m.game.Board(), m.game.Board().ActiveColor, m.config.BotResignThreshold) {
+		m.botHopelessTurns++
+	} else {
+		m.botHopelessTurns = 0
+	}
+	if m.botHopelessTurns >= 2 {
+		return m.botResigns(), nil
+	}
 
-	// Create bot engine based on difficulty
-	var botEngine bot.Engine
-	var err error
-	switch m.botDifficulty {
-	case BotEasy:
-		botEngine, err = bot.NewRandomEngine()
-	case BotMedium:
-		botEngine, err = bot.NewMinimaxEngine(bot.Medium)
-	case BotHard:
-		botEngine, err = bot.NewMinimaxEngine(bot.Hard)
+	// Claim an available draw unless the bot is ahead and playing for a win
+	if m.game.Board().CanClaimDraw() && bot.AcceptsDraw(m.botEngine, m.game.Board(), m.botDifficulty, m.game.Board().ActiveColor) {
+		return m.endGameByDrawClaim(), nil
 	}
 
-	if err != nil {
-		return m, func() tea.Msg {
-			return BotMoveErrorMsg{err: err}
+	// Display thinking message
+	m.thinkingMsg = getRandomThinkingMessage()
+
+	// Reuse the engine for the whole game so it can ponder on the user's time
+	botEngine := m.botEngine
+	if botEngine == nil {
+		var err error
+		botEngine, err = m.botConfig().NewEngine()
+		if err != nil {
+			return m, func() tea.Msg {
+				return BotMoveErrorMsg{err: err, game: m.simulActive}
+			}
 		}
+
+		botEngine = bot.NewPonderer(botEngine)
 	}
 
 	// Store engine for cleanup
 	m.botEngine = botEngine
 
-	// Execute bot move asynchronously
-	return m, func() tea.Msg {
+	// The user can cancel this context to make the bot move immediately
+	ctx, cancel := context.WithCancel(context.Background())
+	m.botThinking = true
+	m.botCancel = cancel
+
+	// Built-in bots must move within their budget; a registered bot keeps its own time
+	moveTimeout := m.botConfig().MoveTimeout()
+
+	// Execute bot move asynchronously, on a copy of the board since the
+	// search makes and takes back moves while the UI keeps rendering
+	board := m.game.Board().Copy()
+	game := m.simulActive
+	botMoveCmd := func() tea.Msg {
 		// Track start time for minimum delay enforcement
 		startTime := time.Now()
 
 		// Determine minimum delay based on difficulty
 		minDelay := getMinimumBotDelay(m.botDifficulty)
 
-		ctx := context.Background()
-		move, err := botEngine.SelectMove(ctx, m.board)
+		searchCtx := ctx
+		if moveTimeout > 0 {
+			var cancelSearch context.CancelFunc
+			searchCtx, cancelSearch = context.WithTimeout(ctx, moveTimeout)
+			defer cancelSearch()
+		}
+		move, err := botEngine.SelectMove(searchCtx, board)
 		if err != nil {
-			return BotMoveErrorMsg{err: err}
+			return BotMoveErrorMsg{err: err, game: game}
+		}
+
+		// The user played the predicted move, so answer right away
+		if p, ok := botEngine.(*bot.Ponderer); ok && p.PonderHit() {
+			return BotMoveMsg{move: move, game: game}
 		}
 
-		// Enforce minimum delay for natural feel
+		// Enforce minimum delay for natural feel, unless the user asked the bot to move now
 		elapsed := time.Since(startTime)
 		if elapsed < minDelay {
-			time.Sleep(minDelay - elapsed)
+			select {
+			case <-time.After(minDelay - elapsed):
+			case <-ctx.Done():
+			}
 		}
 
-		return BotMoveMsg{move: move}
+		return BotMoveMsg{move: move, game: game}
+	}
+
+	return m, tea.Batch(botMoveCmd, m.botSpinner.Tick)
+}
+
+// botResigns ends the game with the bot resigning.
+func (m Model) botResigns() Model {
+	m.game.Resign(m.game.Board().ActiveColor)
+	m.showGameOver()
+	m.notify(SeverityInfo, "The bot resigned")
+	m.botHopelessTurns = 0
+	// Delete the save game file since the game is over
+	_ = config.DeleteSaveGame()
+	if m.botEngine != nil {
+		_ = m.botEngine.Close()
+		m.botEngine = nil
+	}
+	return m
+}
+
+// interruptBotMove asks a thinking bot to stop searching and play the best move found so far.
+func (m Model) interruptBotMove() Model {
+	if !m.botThinking || m.botCancel == nil {
+		return m
+	}
+	m.botCancel()
+	m.thinkingMsg = "Bot is moving now..."
+	return m
+}
+
+// stopBotThinking clears the thinking indicator once the bot's search has finished.
+func (m *Model) stopBotThinking() {
+	if m.botCancel != nil {
+		m.botCancel()
+		m.botCancel = nil
 	}
+	m.botThinking = false
 }
 
 // getMinimumBotDelay returns the minimum delay for bot moves based on difficulty.
@@ -2156,12 +2894,12 @@ func getMinimumBotDelay(difficulty BotDifficulty) time.Duration {
 		return time.Duration(minSeconds * float64(time.Second))
 	case BotMedium:
 		// Medium: 1-2 seconds minimum
-		// Minimax usually takes 2-4 seconds naturally, so this is a safety net
+		// Minimax takes up to its 1 second budget, so this is a safety net
 		minSeconds := 1.0 + rng.Float64() // 1.0 to 2.0 seconds
 		return time.Duration(minSeconds * float64(time.Second))
 	case BotHard:
 		// Hard: 1 second minimum
-		// Minimax usually takes 4-8 seconds naturally, so delay rarely needed
+		// Minimax usually uses most of its 2 second budget, so delay rarely needed
 		return 1 * time.Second
 	default:
 		// Fallback to 1 second
@@ -2169,29 +2907,112 @@ func getMinimumBotDelay(difficulty BotDifficulty) time.Duration {
 	}
 }
 
+// botConfig describes the bot of a PvBot game, with the Bot Move Time setting
+// applied to a built-in bot.
+func (m Model) botConfig() bot.Config {
+	return bot.Config{Difficulty: m.botDifficulty, Backend: m.botName, MoveTime: m.config.BotMoveTime}
+}
+
+// botMoveTimes are the values the Bot Move Time setting cycles through; 0 keeps
+// the budget of each difficulty.
+var botMoveTimes = []time.Duration{
+	0,
+	100 * time.Millisecond,
+	500 * time.Millisecond,
+	1 * time.Second,
+	2 * time.Second,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// cycleBotMoveTime returns the Bot Move Time after current. A value set in the
+// config file that isn't one of botMoveTimes goes back to the first one.
+func cycleBotMoveTime(current time.Duration) time.Duration {
+	for i, d := range botMoveTimes {
+		if d == current {
+			return botMoveTimes[(i+1)%len(botMoveTimes)]
+		}
+	}
+	return botMoveTimes[0]
+}
+
+// getBotMoveTimeDisplayName returns a display-friendly name for a Bot Move Time.
+func getBotMoveTimeDisplayName(d time.Duration) string {
+	if d <= 0 {
+		return "Per Difficulty"
+	}
+	return d.String()
+}
+
+// updateChecks are the values the Update Checks setting cycles through.
+var updateChecks = []string{
+	config.UpdateCheckStartup,
+	config.UpdateCheckDaily,
+	config.UpdateCheckWeekly,
+	config.UpdateCheckOff,
+}
+
+// cycleUpdateCheck returns the update check frequency after current. A value set
+// in the config file that isn't one of updateChecks counts as the default.
+func cycleUpdateCheck(current string) string {
+	if !slices.Contains(updateChecks, current) {
+		current = config.DefaultUpdateCheck
+	}
+	i := slices.Index(updateChecks, current)
+	return updateChecks[(i+1)%len(updateChecks)]
+}
+
+// getUpdateCheckDisplayName returns a display-friendly name for an update check frequency.
+func getUpdateCheckDisplayName(frequency string) string {
+	switch frequency {
+	case config.UpdateCheckStartup:
+		return "On Startup"
+	case config.UpdateCheckWeekly:
+		return "Weekly"
+	case config.UpdateCheckOff:
+		return "Off"
+	default:
+		return "Daily"
+	}
+}
+
 // handleBotMove processes a successful bot move.
 // It applies the move to the board, clears the status message, adds the move to history,
 // and checks if the game is over.
 func (m Model) handleBotMove(msg BotMoveMsg) (tea.Model, tea.Cmd) {
+	// A simul board that isn't shown keeps playing in the background
+	if m.inSimul() && msg.game != m.simulActive {
+		return m.handleSimulBotMove(msg.game, func(m Model) (tea.Model, tea.Cmd) {
+			return m.handleBotMove(msg)
+		})
+	}
+
+	m.stopBotThinking()
+
+	// Ignore moves that arrive after the game already ended (e.g. the user resigned)
+	// or after the user's move was taken back
+	if m.game == nil || m.game.Over() ||
+		(m.gameType == GameTypePvBot && m.game.Board().ActiveColor == m.userColor) {
+		return m, nil
+	}
+
+	// Format the move before it is played, for the turn notification
+	san := LocalizePieces(engine.FormatSAN(m.game.Board(), msg.move), m.config.Language)
+
 	// Try to make the move on the board
-	err := m.board.MakeMove(msg.move)
+	err := m.playMove(msg.move)
 	if err != nil {
 		// Invalid move from bot - show error
-		m.errorMsg = fmt.Sprintf("Bot generated invalid move: %v", err)
-		m.statusMsg = ""
+		m.notify(SeverityError, fmt.Sprintf("Bot generated invalid move: %v", err))
 		return m, nil
 	}
 
 	// Move was successful - clear status message
-	m.statusMsg = ""
-	m.errorMsg = ""
-
-	// Add move to history
-	m.moveHistory = append(m.moveHistory, msg.move)
+	m.dismissToasts()
 
 	// Check if the game is over after this move
-	if m.board.IsGameOver() {
-		m.screen = ScreenGameOver
+	if m.game.Board().IsGameOver() {
+		m.showGameOver()
 		// Delete the save game file since the game is over
 		_ = config.DeleteSaveGame()
 		// Clean up bot engine
@@ -2199,16 +3020,36 @@ func (m Model) handleBotMove(msg BotMoveMsg) (tea.Model, tea.Cmd) {
 			_ = m.botEngine.Close()
 			m.botEngine = nil
 		}
+		return m, nil
 	}
 
-	return m, nil
+	// Flash the squares of the move, so it doesn't just appear on the board
+	flash := m.startMoveFlash(msg.move)
+
+	// Play the queued pre-move if it is still legal
+	if m.premove != nil {
+		result, cmd := m.playPremove(san)
+		return result, tea.Batch(cmd, flash)
+	}
+
+	// Think about the reply to the user's expected move while they decide
+	if p, ok := m.botEngine.(*bot.Ponderer); ok {
+		p.StartPondering(m.game.Board())
+	}
+
+	return m, tea.Batch(m.turnNotificationCmd(san), flash)
 }
 
 // handleBotMoveError processes a bot move error.
 // It displays the error message to the user and clears the thinking status.
 func (m Model) handleBotMoveError(msg BotMoveErrorMsg) (tea.Model, tea.Cmd) {
-	m.errorMsg = fmt.Sprintf("Bot error: %v", msg.err)
-	m.statusMsg = ""
+	if m.inSimul() && msg.game != m.simulActive {
+		return m.handleSimulBotMove(msg.game, func(m Model) (tea.Model, tea.Cmd) {
+			return m.handleBotMoveError(msg)
+		})
+	}
+	m.stopBotThinking()
+	m.notify(SeverityError, fmt.Sprintf("Bot error: %v", msg.err))
 	return m, nil
 }
 
@@ -2225,8 +3066,23 @@ func (m Model) isInTextInputMode() bool {
 		return true
 	}
 
-	// BvB game count input mode
-	if m.screen == ScreenBvBGameMode && m.bvbInputtingCount {
+	// Guess the Move screen uses text input for guesses
+	if m.screen == ScreenGuessMove {
+		return true
+	}
+
+	// The tutorial asks for moves and commands
+	if m.screen == ScreenTutorial {
+		return true
+	}
+
+	// Game History has a search field and the tags being edited
+	if m.screen == ScreenGameHistory {
+		return true
+	}
+
+	// BvB game count and openings input modes
+	if m.screen == ScreenBvBGameMode && (m.bvbInputtingCount || m.bvbInputtingOpenings) {
 		return true
 	}
 
@@ -2245,6 +3101,11 @@ func (m Model) isInTextInputMode() bool {
 		return true
 	}
 
+	// New correspondence game form with the opponent name field
+	if m.screen == ScreenCorrespondenceNew {
+		return true
+	}
+
 	return false
 }
 
@@ -10,7 +10,7 @@ import (
 func TestHandleGamePlayKeys_ValidMove(t *testing.T) {
 	// Create a model with a new board
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenGamePlay
 
 	// Simulate typing "e2e4"
@@ -36,38 +36,38 @@ func TestHandleGamePlayKeys_ValidMove(t *testing.T) {
 		t.Errorf("Expected input to be cleared after valid move, got '%s'", m.input)
 	}
 
-	if m.errorMsg != "" {
-		t.Errorf("Expected no error message after valid move, got '%s'", m.errorMsg)
+	if toastError(m) != "" {
+		t.Errorf("Expected no error message after valid move, got '%s'", toastError(m))
 	}
 
 	// Verify the board was updated - pawn should have moved from e2 to e4
 	e2 := engine.NewSquare(4, 1) // e2
 	e4 := engine.NewSquare(4, 3) // e4
 
-	if !m.board.PieceAt(e2).IsEmpty() {
+	if !m.game.Board().PieceAt(e2).IsEmpty() {
 		t.Error("Expected e2 to be empty after move")
 	}
 
-	pieceAtE4 := m.board.PieceAt(e4)
+	pieceAtE4 := m.game.Board().PieceAt(e4)
 	if pieceAtE4.Type() != engine.Pawn || pieceAtE4.Color() != engine.White {
 		t.Error("Expected white pawn at e4 after move")
 	}
 
 	// Verify turn changed to Black
-	if m.board.ActiveColor != engine.Black {
+	if m.game.Board().ActiveColor != engine.Black {
 		t.Error("Expected active color to be Black after White's move")
 	}
 
 	// Verify move was added to history
-	if len(m.moveHistory) != 1 {
-		t.Errorf("Expected 1 move in history, got %d", len(m.moveHistory))
+	if m.game.MoveCount() != 1 {
+		t.Errorf("Expected 1 move in history, got %d", m.game.MoveCount())
 	}
 }
 
 func TestHandleGamePlayKeys_InvalidMoveFormat(t *testing.T) {
 	// Create a model with a new board
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenGamePlay
 
 	// Simulate typing an invalid move format
@@ -80,12 +80,12 @@ func TestHandleGamePlayKeys_InvalidMoveFormat(t *testing.T) {
 		m = result.(Model)
 
 		// Verify error message was set
-		if m.errorMsg == "" {
+		if toastError(m) == "" {
 			t.Errorf("Expected error message for invalid input '%s', got none", input)
 		}
 
 		// Verify board was not modified (still White to move)
-		if m.board.ActiveColor != engine.White {
+		if m.game.Board().ActiveColor != engine.White {
 			t.Errorf("Expected active color to still be White after invalid move '%s'", input)
 		}
 	}
@@ -94,7 +94,7 @@ func TestHandleGamePlayKeys_InvalidMoveFormat(t *testing.T) {
 func TestHandleGamePlayKeys_IllegalMove(t *testing.T) {
 	// Create a model with a new board
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenGamePlay
 
 	// Try an illegal move: e2e5 (pawn can't move 3 squares)
@@ -104,7 +104,7 @@ func TestHandleGamePlayKeys_IllegalMove(t *testing.T) {
 	m = result.(Model)
 
 	// Verify error message was set
-	if m.errorMsg == "" {
+	if toastError(m) == "" {
 		t.Error("Expected error message for illegal move e2e5")
 	}
 
@@ -112,17 +112,17 @@ func TestHandleGamePlayKeys_IllegalMove(t *testing.T) {
 	e2 := engine.NewSquare(4, 1) // e2
 	e5 := engine.NewSquare(4, 4) // e5
 
-	pieceAtE2 := m.board.PieceAt(e2)
+	pieceAtE2 := m.game.Board().PieceAt(e2)
 	if pieceAtE2.Type() != engine.Pawn || pieceAtE2.Color() != engine.White {
 		t.Error("Expected white pawn to still be at e2 after illegal move")
 	}
 
-	if !m.board.PieceAt(e5).IsEmpty() {
+	if !m.game.Board().PieceAt(e5).IsEmpty() {
 		t.Error("Expected e5 to be empty after illegal move")
 	}
 
 	// Verify still White to move
-	if m.board.ActiveColor != engine.White {
+	if m.game.Board().ActiveColor != engine.White {
 		t.Error("Expected active color to still be White after illegal move")
 	}
 }
@@ -130,9 +130,9 @@ func TestHandleGamePlayKeys_IllegalMove(t *testing.T) {
 func TestHandleGamePlayKeys_ErrorClearingOnNewInput(t *testing.T) {
 	// Create a model with a new board and an error message
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenGamePlay
-	m.errorMsg = "Previous error"
+	m.notify(SeverityError, "Previous error")
 
 	// Simulate typing a character
 	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}}
@@ -140,8 +140,8 @@ func TestHandleGamePlayKeys_ErrorClearingOnNewInput(t *testing.T) {
 	m = result.(Model)
 
 	// Verify error message was cleared
-	if m.errorMsg != "" {
-		t.Errorf("Expected error message to be cleared when typing, got '%s'", m.errorMsg)
+	if toastError(m) != "" {
+		t.Errorf("Expected error message to be cleared when typing, got '%s'", toastError(m))
 	}
 
 	// Verify input was updated
@@ -153,10 +153,10 @@ func TestHandleGamePlayKeys_ErrorClearingOnNewInput(t *testing.T) {
 func TestHandleGamePlayKeys_BackspaceHandling(t *testing.T) {
 	// Create a model with some input
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenGamePlay
 	m.input = "e2e4"
-	m.errorMsg = "Some error"
+	m.notify(SeverityError, "Some error")
 
 	// Simulate pressing Backspace
 	msg := tea.KeyMsg{Type: tea.KeyBackspace}
@@ -169,8 +169,8 @@ func TestHandleGamePlayKeys_BackspaceHandling(t *testing.T) {
 	}
 
 	// Verify error message was cleared
-	if m.errorMsg != "" {
-		t.Errorf("Expected error message to be cleared on backspace, got '%s'", m.errorMsg)
+	if toastError(m) != "" {
+		t.Errorf("Expected error message to be cleared on backspace, got '%s'", toastError(m))
 	}
 
 	// Backspace on empty input should not cause issues
@@ -187,7 +187,7 @@ func TestHandleGamePlayKeys_BackspaceHandling(t *testing.T) {
 func TestHandleGamePlayKeys_SequenceOfMoves(t *testing.T) {
 	// Create a model with a new board
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenGamePlay
 
 	// Execute a sequence of valid moves
@@ -200,8 +200,8 @@ func TestHandleGamePlayKeys_SequenceOfMoves(t *testing.T) {
 		m = result.(Model)
 
 		// Verify no error
-		if m.errorMsg != "" {
-			t.Errorf("Move %d (%s) failed with error: %s", i+1, moveStr, m.errorMsg)
+		if toastError(m) != "" {
+			t.Errorf("Move %d (%s) failed with error: %s", i+1, moveStr, toastError(m))
 		}
 
 		// Verify input was cleared
@@ -211,12 +211,12 @@ func TestHandleGamePlayKeys_SequenceOfMoves(t *testing.T) {
 	}
 
 	// Verify move history has all moves
-	if len(m.moveHistory) != 4 {
-		t.Errorf("Expected 4 moves in history, got %d", len(m.moveHistory))
+	if m.game.MoveCount() != 4 {
+		t.Errorf("Expected 4 moves in history, got %d", m.game.MoveCount())
 	}
 
 	// Verify it's White's turn again
-	if m.board.ActiveColor != engine.White {
+	if m.game.Board().ActiveColor != engine.White {
 		t.Error("Expected active color to be White after 4 moves")
 	}
 }
@@ -224,7 +224,7 @@ func TestHandleGamePlayKeys_SequenceOfMoves(t *testing.T) {
 func TestHandleGamePlayKeys_EmptyInputEnter(t *testing.T) {
 	// Create a model with a new board
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenGamePlay
 	m.input = ""
 
@@ -234,23 +234,23 @@ func TestHandleGamePlayKeys_EmptyInputEnter(t *testing.T) {
 	m = result.(Model)
 
 	// Verify nothing happened (no error, board unchanged)
-	if m.errorMsg != "" {
-		t.Errorf("Expected no error for empty input, got '%s'", m.errorMsg)
+	if toastError(m) != "" {
+		t.Errorf("Expected no error for empty input, got '%s'", toastError(m))
 	}
 
-	if m.board.ActiveColor != engine.White {
+	if m.game.Board().ActiveColor != engine.White {
 		t.Error("Expected active color to still be White")
 	}
 
-	if len(m.moveHistory) != 0 {
-		t.Errorf("Expected empty move history, got %d moves", len(m.moveHistory))
+	if m.game.MoveCount() != 0 {
+		t.Errorf("Expected empty move history, got %d moves", m.game.MoveCount())
 	}
 }
 
 func TestHandleGamePlayKeys_PromotionMove(t *testing.T) {
 	// Create a custom board position where a pawn can promote
 	m := NewModel(DefaultConfig())
-	m.board = engine.NewBoard()
+	m.game = engine.NewGame()
 	m.screen = ScreenGamePlay
 
 	// Set up a position: White pawn on e7, ready to promote
@@ -258,10 +258,10 @@ func TestHandleGamePlayKeys_PromotionMove(t *testing.T) {
 	e8 := engine.NewSquare(4, 7) // e8
 
 	// Clear e2 pawn and place it on e7
-	m.board.Squares[engine.NewSquare(4, 1)] = engine.Piece(engine.Empty)
-	m.board.Squares[e7] = engine.NewPiece(engine.White, engine.Pawn)
+	m.game.Board().Squares[engine.NewSquare(4, 1)] = engine.Piece(engine.Empty)
+	m.game.Board().Squares[e7] = engine.NewPiece(engine.White, engine.Pawn)
 	// Clear black pieces on rank 8 that might block
-	m.board.Squares[e8] = engine.Piece(engine.Empty)
+	m.game.Board().Squares[e8] = engine.Piece(engine.Empty)
 
 	// Try to move without promotion piece (should fail)
 	m.input = "e7e8"
@@ -269,23 +269,23 @@ func TestHandleGamePlayKeys_PromotionMove(t *testing.T) {
 	result, _ := m.handleGamePlayKeys(msg)
 	m = result.(Model)
 
-	if m.errorMsg == "" {
+	if toastError(m) == "" {
 		t.Error("Expected error for promotion move without promotion piece")
 	}
 
 	// Try with promotion piece (should succeed)
 	m.input = "e7e8q"
-	m.errorMsg = "" // Clear previous error
+	m.dismissToasts(SeverityError) // Clear previous error
 	msg = tea.KeyMsg{Type: tea.KeyEnter}
 	result, _ = m.handleGamePlayKeys(msg)
 	m = result.(Model)
 
-	if m.errorMsg != "" {
-		t.Errorf("Expected no error for promotion move with piece, got '%s'", m.errorMsg)
+	if toastError(m) != "" {
+		t.Errorf("Expected no error for promotion move with piece, got '%s'", toastError(m))
 	}
 
 	// Verify queen was placed at e8
-	pieceAtE8 := m.board.PieceAt(e8)
+	pieceAtE8 := m.game.Board().PieceAt(e8)
 	if pieceAtE8.Type() != engine.Queen || pieceAtE8.Color() != engine.White {
 		t.Error("Expected white queen at e8 after promotion")
 	}
@@ -299,7 +299,7 @@ func TestHandleMainMenuSelection_Settings(t *testing.T) {
 	m.menuSelection = 2 // Select "Settings"
 
 	// Simulate pressing Enter
-	result, _ := m.handleMainMenuSelection()
+	result, _ := m.selectMenuItem()
 	m = result.(Model)
 
 	// Verify transitioned to Settings screen
@@ -313,12 +313,12 @@ func TestHandleMainMenuSelection_Settings(t *testing.T) {
 	}
 
 	// Verify messages are cleared
-	if m.errorMsg != "" {
-		t.Errorf("Expected errorMsg to be cleared, got '%s'", m.errorMsg)
+	if toastError(m) != "" {
+		t.Errorf("Expected errorMsg to be cleared, got '%s'", toastError(m))
 	}
 
-	if m.statusMsg != "" {
-		t.Errorf("Expected statusMsg to be cleared, got '%s'", m.statusMsg)
+	if toastStatus(m) != "" {
+		t.Errorf("Expected statusMsg to be cleared, got '%s'", toastStatus(m))
 	}
 }
 
@@ -355,9 +355,9 @@ func TestHandleSettingsKeys_Navigation(t *testing.T) {
 		t.Errorf("Expected settingsSelection to be 1 after up, got %d", m.settingsSelection)
 	}
 
-	// Test wrapping at bottom (move to index 5, then down should wrap to 0)
-	// Note: 6 settings total (5 toggles + 1 theme selector)
-	m.settingsSelection = 5
+	// Test wrapping at bottom (move to index 17, then down should wrap to 0)
+	// Note: 18 settings total (7 toggles + 7 value options + key bindings + 2 update options + encryption)
+	m.settingsSelection = 17
 	msg = tea.KeyMsg{Type: tea.KeyDown}
 	result, _ = m.handleSettingsKeys(msg)
 	m = result.(Model)
@@ -366,13 +366,13 @@ func TestHandleSettingsKeys_Navigation(t *testing.T) {
 		t.Errorf("Expected settingsSelection to wrap to 0, got %d", m.settingsSelection)
 	}
 
-	// Test wrapping at top (at index 0, up should wrap to 5)
+	// Test wrapping at top (at index 0, up should wrap to 17)
 	msg = tea.KeyMsg{Type: tea.KeyUp}
 	result, _ = m.handleSettingsKeys(msg)
 	m = result.(Model)
 
-	if m.settingsSelection != 5 {
-		t.Errorf("Expected settingsSelection to wrap to 5, got %d", m.settingsSelection)
+	if m.settingsSelection != 17 {
+		t.Errorf("Expected settingsSelection to wrap to 17, got %d", m.settingsSelection)
 	}
 }
 
@@ -460,17 +460,17 @@ func TestHandleSettingsKeys_ESC(t *testing.T) {
 		t.Errorf("Expected menuSelection to be reset to 0, got %d", m.menuSelection)
 	}
 
-	expectedOptions := []string{"New Game", "Load Game", "Settings", "Exit"}
+	expectedOptions := []string{"New Game", "Load Game", "Game History", "Settings", "Learn", "Exit"}
 	if len(m.menuOptions) != len(expectedOptions) {
 		t.Errorf("Expected %d menu options, got %d", len(expectedOptions), len(m.menuOptions))
 	}
 
 	// Verify messages are cleared
-	if m.errorMsg != "" {
-		t.Errorf("Expected errorMsg to be cleared, got '%s'", m.errorMsg)
+	if toastError(m) != "" {
+		t.Errorf("Expected errorMsg to be cleared, got '%s'", toastError(m))
 	}
 
-	if m.statusMsg != "" {
-		t.Errorf("Expected statusMsg to be cleared, got '%s'", m.statusMsg)
+	if toastStatus(m) != "" {
+		t.Errorf("Expected statusMsg to be cleared, got '%s'", toastStatus(m))
 	}
 }
@@ -0,0 +1,261 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Mgrdich/TermChess/internal/updater"
+	"github.com/Mgrdich/TermChess/internal/version"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// upgradeTimeout bounds how long the upgrade may take, downloads included.
+const upgradeTimeout = 2 * time.Minute
+
+// upgradeBarWidth is the width of the download progress bar in characters.
+const upgradeBarWidth = 40
+
+// upgradeState is the upgrade shown on the Upgrade screen.
+type upgradeState struct {
+	// target is the version being installed, e.g. "v0.3.0".
+	target string
+	// progress is the last progress reported by the updater.
+	progress updater.Progress
+	// updates delivers the progress and result messages of the upgrade.
+	updates chan tea.Msg
+	// cancel stops the upgrade while it downloads.
+	cancel context.CancelFunc
+	// done is true once the upgrade succeeded, failed or was cancelled.
+	done bool
+	// result is the completed upgrade, if it succeeded.
+	result *updater.UpgradeResult
+	// err is why the upgrade failed, if it did.
+	err error
+}
+
+// upgradeProgressMsg reports progress of the upgrade.
+type upgradeProgressMsg struct {
+	progress updater.Progress
+}
+
+// upgradeDoneMsg reports the end of the upgrade.
+type upgradeDoneMsg struct {
+	result *updater.UpgradeResult
+	err    error
+}
+
+// startUpgrade opens the Upgrade screen and starts installing the available
+// update in the background. Installs made with go install are pointed to it instead.
+func (m Model) startUpgrade() (tea.Model, tea.Cmd) {
+	if m.offline {
+		m.notify(SeverityWarning, "Offline mode, not upgrading")
+		return m, nil
+	}
+	if updater.DetectInstallMethod() == updater.InstallMethodGoInstall {
+		m.notify(SeverityWarning, "Installed with go install: run 'go install github.com/Mgrdich/TermChess/cmd/termchess@latest' to upgrade")
+		return m, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), upgradeTimeout)
+	upgrade := &upgradeState{
+		target:  m.updateAvailable,
+		updates: make(chan tea.Msg),
+		cancel:  cancel,
+	}
+	// send delivers a message unless the upgrade was cancelled and nobody listens anymore
+	send := func(msg tea.Msg) {
+		select {
+		case upgrade.updates <- msg:
+		case <-ctx.Done():
+		}
+	}
+	go func() {
+		defer cancel()
+		percent := -1
+		result, err := updater.NewClient().UpgradeWithProgress(ctx, version.Version, upgrade.target, nil, func(p updater.Progress) {
+			// Only whole percents are worth a redraw
+			if p.Stage == updater.StageDownloading && p.Total > 0 {
+				if next := int(p.Downloaded * 100 / p.Total); next != percent {
+					percent = next
+				} else {
+					return
+				}
+			}
+			send(upgradeProgressMsg{progress: p})
+		})
+		if ctx.Err() == context.Canceled {
+			// Let a waitForUpgradeCmd still waiting return
+			close(upgrade.updates)
+			return
+		}
+		upgrade.updates <- upgradeDoneMsg{result: result, err: err}
+	}()
+
+	m.upgrade = upgrade
+	m.pushScreen(ScreenUpgrade)
+	m.dismissToasts()
+	return m, m.waitForUpgradeCmd()
+}
+
+// waitForUpgradeCmd waits for the next progress or result of the upgrade.
+func (m Model) waitForUpgradeCmd() tea.Cmd {
+	updates := m.upgrade.updates
+	return func() tea.Msg {
+		return <-updates
+	}
+}
+
+// handleUpgradeProgress shows the progress of the upgrade and waits for more.
+func (m Model) handleUpgradeProgress(msg upgradeProgressMsg) (tea.Model, tea.Cmd) {
+	if m.upgrade == nil || m.upgrade.done {
+		return m, nil
+	}
+	m.upgrade.progress = msg.progress
+	return m, m.waitForUpgradeCmd()
+}
+
+// handleUpgradeDone shows how the upgrade ended. After a successful upgrade the
+// update notification is gone, as the new version runs from the next start.
+func (m Model) handleUpgradeDone(msg upgradeDoneMsg) (tea.Model, tea.Cmd) {
+	if m.upgrade == nil || m.upgrade.done {
+		return m, nil
+	}
+	m.upgrade.done = true
+	m.upgrade.result = msg.result
+	m.upgrade.err = msg.err
+	if msg.err == nil {
+		m.updateAvailable = ""
+	}
+	return m, nil
+}
+
+// handleUpgradeKeys handles keyboard input on the Upgrade screen. ESC cancels a
+// running upgrade; once it is done ESC returns to the main menu and q quits, so
+// the new version can be started.
+func (m Model) handleUpgradeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case m.keys.Matches(msg, ActionBack):
+		if !m.upgrade.done {
+			// Installing is a pair of renames, too quick to be worth interrupting
+			if m.upgrade.progress.Stage == updater.StageInstalling {
+				return m, nil
+			}
+			m.upgrade.cancel()
+			m.upgrade.done = true
+			m.upgrade.err = context.Canceled
+			m.notify(SeverityInfo, "Upgrade cancelled, nothing was changed")
+		}
+		m.popScreen()
+		return m, nil
+	case m.keys.Matches(msg, ActionQuit) && m.upgrade.done:
+		return m.quit()
+	}
+	return m, nil
+}
+
+// renderUpgrade renders the Upgrade screen: each step of the upgrade with the
+// download's progress bar, then how it ended.
+func (m Model) renderUpgrade() string {
+	var b strings.Builder
+
+	// Render the application title
+	b.WriteString(m.titleStyle().Render("TermChess"))
+	b.WriteString("\n")
+	b.WriteString(m.renderBreadcrumb())
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(m.theme.TitleText).
+		Padding(0, 0, 1, 0)
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Upgrade to %s", m.upgrade.target)))
+	b.WriteString("\n")
+
+	verify := "Verify checksum"
+	if updater.SigningKey != "" {
+		verify = "Verify checksum and signature"
+	}
+	progress := m.upgrade.progress
+	steps := []struct {
+		stage updater.Stage
+		label string
+	}{
+		{updater.StageDownloading, "Download"},
+		{updater.StageVerifying, verify},
+		{updater.StageInstalling, "Install"},
+	}
+	for _, step := range steps {
+		mark := "  "
+		switch {
+		case progress.Stage > step.stage || m.upgrade.done && m.upgrade.err == nil:
+			mark = "✓ "
+		case progress.Stage == step.stage && m.upgrade.err != nil:
+			mark = "✗ "
+		case progress.Stage == step.stage:
+			mark = "> "
+		}
+		b.WriteString(m.menuPrimaryStyle().Render(mark + step.label))
+		b.WriteString("\n")
+		if step.stage == updater.StageDownloading && progress.Stage == updater.StageDownloading && progress.Total > 0 {
+			b.WriteString("  ")
+			b.WriteString(m.statusStyle().Render(renderDownloadBar(progress.Downloaded, progress.Total, upgradeBarWidth)))
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\n")
+
+	help := "ESC: cancel"
+	if m.upgrade.done {
+		b.WriteString(m.renderUpgradeOutcome())
+		help = "ESC: main menu | q: quit"
+	}
+
+	if helpText := m.renderHelpText(help); helpText != "" {
+		b.WriteString("\n\n")
+		b.WriteString(helpText)
+	}
+
+	return b.String()
+}
+
+// renderUpgradeOutcome describes how the finished upgrade ended and, after a
+// failure, what state the installed binary is in.
+func (m Model) renderUpgradeOutcome() string {
+	result, err := m.upgrade.result, m.upgrade.err
+	var rollbackErr *updater.RollbackError
+	switch {
+	case err == nil:
+		signed := "checksum verified"
+		if result.Signed {
+			signed = "checksum and signature verified"
+		}
+		return m.statusStyle().Render(fmt.Sprintf("TermChess upgraded from %s to %s (%s). Quit and start TermChess again to use it.\nThe previous version is kept at %s; run 'termchess --rollback' to go back to it.",
+			result.PreviousVersion, result.NewVersion, signed, result.BackupPath))
+	case errors.Is(err, context.Canceled):
+		return m.statusStyle().Render("Upgrade cancelled, nothing was changed.")
+	case errors.As(err, &rollbackErr):
+		return m.errorStyle().Render(fmt.Sprintf("Error: %v", err))
+	case errors.Is(err, updater.ErrChecksumMismatch):
+		return m.errorStyle().Render("Error: the download doesn't match its checksum and was discarded. Nothing was changed.")
+	case errors.Is(err, updater.ErrSignatureInvalid):
+		return m.errorStyle().Render(fmt.Sprintf("Error: %v. The release may have been tampered with and was discarded. Nothing was changed.", err))
+	case errors.Is(err, updater.ErrPermissionDenied):
+		return m.errorStyle().Render("Error: permission denied, nothing was changed. Run 'sudo termchess --upgrade' to upgrade.")
+	case errors.Is(err, updater.ErrAlreadyUpToDate):
+		return m.statusStyle().Render(fmt.Sprintf("TermChess %s is already up to date.", version.Version))
+	default:
+		return m.errorStyle().Render(fmt.Sprintf("Error: %v. Nothing was changed.", err))
+	}
+}
+
+// renderDownloadBar renders a progress bar of a download, e.g.
+// "[████░░░░] 50% (3.1 of 6.2 MB)".
+func renderDownloadBar(downloaded, total int64, width int) string {
+	filled := min(int(downloaded*int64(width)/total), width)
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+	return fmt.Sprintf("[%s] %d%% (%.1f of %.1f MB)", bar, downloaded*100/total,
+		float64(downloaded)/(1<<20), float64(total)/(1<<20))
+}
@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/updater"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// upgradingModel returns a model on the Upgrade screen with an upgrade that
+// never reports anything by itself.
+func upgradingModel() (Model, *bool) {
+	cancelled := false
+	m := NewModel(DefaultConfig())
+	m.updateAvailable = "v9.0.0"
+	m.upgrade = &upgradeState{
+		target:  "v9.0.0",
+		updates: make(chan tea.Msg),
+		cancel:  func() { cancelled = true },
+	}
+	m.pushScreen(ScreenUpgrade)
+	return m, &cancelled
+}
+
+func TestUpgradeProgress(t *testing.T) {
+	m, _ := upgradingModel()
+
+	model, cmd := m.Update(upgradeProgressMsg{progress: updater.Progress{Stage: updater.StageDownloading, Downloaded: 1 << 20, Total: 4 << 20}})
+	m = model.(Model)
+	if cmd == nil {
+		t.Error("Expected to keep waiting for the upgrade")
+	}
+	view := m.View()
+	if !strings.Contains(view, "Upgrade to v9.0.0") || !strings.Contains(view, "25% (1.0 of 4.0 MB)") {
+		t.Errorf("Expected the download's progress, got:\n%s", view)
+	}
+
+	model, _ = m.Update(upgradeDoneMsg{result: &updater.UpgradeResult{
+		PreviousVersion: "v1.0.0",
+		NewVersion:      "v9.0.0",
+		BackupPath:      "/usr/local/bin/termchess.old",
+	}})
+	m = model.(Model)
+	if m.updateAvailable != "" {
+		t.Error("Expected the update notice to go once the update is installed")
+	}
+	view = m.View()
+	if !strings.Contains(view, "upgraded from v1.0.0 to v9.0.0") || !strings.Contains(view, "termchess --rollback") {
+		t.Errorf("Expected the upgrade and the way back to be reported, got:\n%s", view)
+	}
+}
+
+func TestUpgradeFailure(t *testing.T) {
+	m, _ := upgradingModel()
+	model, _ := m.Update(upgradeProgressMsg{progress: updater.Progress{Stage: updater.StageVerifying}})
+	m = model.(Model)
+	model, _ = m.Update(upgradeDoneMsg{err: updater.ErrChecksumMismatch})
+	m = model.(Model)
+
+	view := m.View()
+	if !strings.Contains(view, "✗ Verify checksum") || !strings.Contains(view, "Nothing was changed") {
+		t.Errorf("Expected the failed step and the untouched install, got:\n%s", view)
+	}
+	if m.updateAvailable != "v9.0.0" {
+		t.Error("Expected the update to still be available")
+	}
+
+	rollback := &updater.RollbackError{Path: "/bin/termchess", Backup: "/bin/termchess.old", Err: context.DeadlineExceeded}
+	m.upgrade.done = false
+	model, _ = m.Update(upgradeDoneMsg{err: rollback})
+	m = model.(Model)
+	if view := m.View(); !strings.Contains(view, "move /bin/termchess.old back to /bin/termchess") {
+		t.Errorf("Expected directions to restore the previous binary, got:\n%s", view)
+	}
+}
+
+func TestUpgradeCancel(t *testing.T) {
+	m, cancelled := upgradingModel()
+
+	// Other keys do nothing while the upgrade runs
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	m = model.(Model)
+	if m.screen != ScreenUpgrade {
+		t.Fatalf("Expected to stay on the Upgrade screen, got %v", m.screen)
+	}
+
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = model.(Model)
+	if !*cancelled || m.screen != ScreenMainMenu {
+		t.Errorf("Expected ESC to cancel the upgrade and return to the main menu, cancelled %v on %v", *cancelled, m.screen)
+	}
+	if m.updateAvailable != "v9.0.0" {
+		t.Error("Expected the update to still be available after cancelling")
+	}
+}
+
+func TestStartUpgradeOffline(t *testing.T) {
+	m := NewModel(DefaultConfig()).WithOffline()
+	m.updateAvailable = "v9.0.0"
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	m = model.(Model)
+	if m.screen != ScreenMainMenu || m.upgrade != nil {
+		t.Error("Expected no upgrade in offline mode")
+	}
+	if !strings.Contains(lastToast(m, SeverityWarning), "Offline") {
+		t.Errorf("Expected an offline warning, got %q", lastToast(m, SeverityWarning))
+	}
+}
@@ -6,7 +6,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Mgrdich/TermChess/internal/bot"
 	"github.com/Mgrdich/TermChess/internal/bvb"
+	"github.com/Mgrdich/TermChess/internal/config"
 	"github.com/Mgrdich/TermChess/internal/engine"
 	"github.com/Mgrdich/TermChess/internal/updater"
 	"github.com/Mgrdich/TermChess/internal/version"
@@ -103,7 +105,7 @@ func (m Model) blackTurnStyle() lipgloss.Style {
 
 // turnStyle returns the appropriate style for the current turn.
 func (m Model) turnStyle() lipgloss.Style {
-	if m.board != nil && m.board.ActiveColor == 1 { // Black
+	if m.game != nil && m.game.Board().ActiveColor == 1 { // Black
 		return m.blackTurnStyle()
 	}
 	return m.whiteTurnStyle()
@@ -158,17 +160,6 @@ func (m Model) renderMenuSeparator() string {
 	return m.menuSeparatorStyle().Render(separator)
 }
 
-// isPrimaryAction returns true if the menu option is a primary action.
-// Primary actions: New Game, Resume Game, Start, Play Again, New Session
-func isPrimaryAction(option string) bool {
-	switch option {
-	case "New Game", "Resume Game", "Start", "Play Again", "New Session":
-		return true
-	default:
-		return false
-	}
-}
-
 // renderBreadcrumb renders the navigation breadcrumb if present.
 // Returns an empty string if there's no breadcrumb to display.
 func (m Model) renderBreadcrumb() string {
@@ -215,6 +206,11 @@ func (m Model) renderMinSizeWarning() string {
 // This function is called by Bubbletea on every update to generate
 // the string that will be displayed in the terminal.
 func (m Model) View() string {
+	defer m.recoverCrash()
+	if m.debug != nil {
+		defer m.debug.timeFrame(time.Now())
+	}
+
 	// Check if terminal is too small to render properly
 	if m.termWidth > 0 && m.termHeight > 0 {
 		if m.termWidth < minTerminalWidth || m.termHeight < minTerminalHeight {
@@ -222,11 +218,29 @@ func (m Model) View() string {
 		}
 	}
 
+	return m.withDebug(func(m Model) string { return m.withToasts(Model.renderView) })
+}
+
+// renderView renders the current screen with any open overlay drawn over it.
+func (m Model) renderView() string {
+	// If the command palette is open, render it over the current view
+	if m.showCommandPalette {
+		return m.renderCommandPalette()
+	}
+
 	// If the shortcuts overlay is active, render it over the current view
 	if m.showShortcutsOverlay {
-		return m.renderShortcutsOverlay()
+		return m.renderOverlay(m.renderScreen(), m.renderShortcutsOverlay())
+	}
+	if m.showBvBLog {
+		return m.renderOverlay(m.renderScreen(), m.renderBvBLog())
 	}
 
+	return m.renderScreen()
+}
+
+// renderScreen renders the current screen.
+func (m Model) renderScreen() string {
 	switch m.screen {
 	case ScreenMainMenu:
 		return m.renderMainMenu()
@@ -234,6 +248,16 @@ func (m Model) View() string {
 		return m.renderGameTypeSelect()
 	case ScreenBotSelect:
 		return m.renderBotSelect()
+	case ScreenHandicapSelect:
+		return m.renderHandicapSelect()
+	case ScreenCorrespondenceList:
+		return m.renderCorrespondenceList()
+	case ScreenCorrespondenceNew:
+		return m.renderCorrespondenceNew()
+	case ScreenHandoff:
+		return m.renderHandoff()
+	case ScreenWatch:
+		return m.renderWatch()
 	case ScreenColorSelect:
 		return m.renderColorSelect()
 	case ScreenFENInput:
@@ -262,6 +286,32 @@ func (m Model) View() string {
 		return m.renderBvBViewModeSelect()
 	case ScreenBvBConcurrencySelect:
 		return m.renderBvBConcurrencySelect()
+	case ScreenAnalysis:
+		return m.renderAnalysis()
+	case ScreenKeyBindings:
+		return m.renderKeyBindings()
+	case ScreenProfileSelect:
+		return m.renderProfileSelect()
+	case ScreenMatchSummary:
+		return m.renderMatchSummary()
+	case ScreenSimulSetup:
+		return m.renderSimulSetup()
+	case ScreenGuessSelect:
+		return m.renderGuessSelect()
+	case ScreenGuessMove:
+		return m.renderGuessMove()
+	case ScreenUpgrade:
+		return m.renderUpgrade()
+	case ScreenCrashRecovery:
+		return m.renderCrashRecovery()
+	case ScreenLearn:
+		return m.renderLearn()
+	case ScreenGameHistory:
+		return m.renderGameHistory()
+	case ScreenPassphrase:
+		return m.renderPassphrase()
+	case ScreenTutorial:
+		return m.renderTutorial()
 	default:
 		return "Unknown screen"
 	}
@@ -280,63 +330,7 @@ func (m Model) renderMainMenu() string {
 	b.WriteString(title)
 	b.WriteString("\n\n")
 
-	// Track when separator has been inserted
-	// Main menu structure: [Resume Game], New Game, Load Game | Settings, Exit
-	// Primary: Resume Game, New Game
-	// Secondary: Load Game, Settings, Exit
-	separatorInserted := false
-
-	// Render menu options with cursor indicator for selected item
-	for i, option := range m.menuOptions {
-		// Check if we need to insert a separator before this item
-		// Insert separator before "Settings" to separate game actions from app actions
-		if option == "Settings" && !separatorInserted {
-			b.WriteString(m.renderMenuSeparator())
-			b.WriteString("\n")
-			separatorInserted = true
-		}
-
-		cursor := "  " // Two spaces for non-selected items
-		optionText := option
-
-		// Check if this is the "Resume Game" option
-		isResumeGame := option == "Resume Game"
-		isPrimary := isPrimaryAction(option)
-
-		if i == m.menuSelection {
-			// Highlight the selected item with focus indicator
-			if isResumeGame {
-				// Special styling for selected Resume Game option
-				cursor = m.cursorStyle().Render(">> ")
-				resumeStyle := lipgloss.NewStyle().
-					Foreground(m.theme.StatusText).
-					Bold(true).
-					Padding(0, 2)
-				optionText = resumeStyle.Render(option)
-			} else if isPrimary {
-				cursor = m.cursorStyle().Render(">> ")
-				optionText = m.selectedPrimaryStyle().Render(option)
-			} else {
-				cursor = m.cursorStyle().Render(" > ")
-				optionText = m.selectedSecondaryStyle().Render(option)
-			}
-		} else {
-			// Regular menu item styling
-			if isResumeGame {
-				// Special styling for unselected Resume Game option
-				resumeStyle := lipgloss.NewStyle().
-					Foreground(m.theme.StatusText).
-					Padding(0, 2)
-				optionText = resumeStyle.Render(option)
-			} else if isPrimary {
-				optionText = m.menuPrimaryStyle().Render(option)
-			} else {
-				optionText = m.menuSecondaryStyle().Render(option)
-			}
-		}
-
-		b.WriteString(fmt.Sprintf("%s%s\n", cursor, optionText))
-	}
+	b.WriteString(m.renderMenu(m.menuSelection))
 
 	// Render help text
 	helpText := m.renderHelpText("arrows/jk: navigate | enter: select | q: quit")
@@ -345,20 +339,6 @@ func (m Model) renderMainMenu() string {
 		b.WriteString(helpText)
 	}
 
-	// Render error message if present
-	if m.errorMsg != "" {
-		b.WriteString("\n\n")
-		errorText := m.errorStyle().Render(fmt.Sprintf("Error: %s", m.errorMsg))
-		b.WriteString(errorText)
-	}
-
-	// Render status message if present
-	if m.statusMsg != "" {
-		b.WriteString("\n\n")
-		statusText := m.statusStyle().Render(m.statusMsg)
-		b.WriteString(statusText)
-	}
-
 	// Render update notification if available
 	if m.updateAvailable != "" {
 		b.WriteString("\n\n")
@@ -372,7 +352,7 @@ func (m Model) renderMainMenu() string {
 			updateText = fmt.Sprintf("Update available: %s (current: %s). Run 'go install github.com/Mgrdich/TermChess/cmd/termchess@latest' to update.",
 				m.updateAvailable, version.Version)
 		} else {
-			updateText = fmt.Sprintf("Update available: %s (current: %s). Run 'termchess --upgrade' to update.",
+			updateText = fmt.Sprintf("Update available: %s (current: %s). Press u to upgrade now, or run 'termchess --upgrade'.",
 				m.updateAvailable, version.Version)
 		}
 		b.WriteString(updateStyle.Render(updateText))
@@ -404,23 +384,7 @@ func (m Model) renderGameTypeSelect() string {
 	b.WriteString(header)
 	b.WriteString("\n")
 
-	// Render menu options with cursor indicator for selected item
-	// All game type options are primary actions
-	for i, option := range m.menuOptions {
-		cursor := "  " // Two spaces for non-selected items
-		optionText := option
-
-		if i == m.menuSelection {
-			// Highlight the selected item with prominent focus indicator
-			cursor = m.cursorStyle().Render(">> ")
-			optionText = m.selectedPrimaryStyle().Render(option)
-		} else {
-			// Primary styling for all game type options
-			optionText = m.menuPrimaryStyle().Render(option)
-		}
-
-		b.WriteString(fmt.Sprintf("%s%s\n", cursor, optionText))
-	}
+	b.WriteString(m.renderMenu(m.menuSelection))
 
 	// Render help text
 	helpText := m.renderHelpText("ESC: back to menu | arrows/jk: navigate | enter: select")
@@ -429,20 +393,6 @@ func (m Model) renderGameTypeSelect() string {
 		b.WriteString(helpText)
 	}
 
-	// Render error message if present
-	if m.errorMsg != "" {
-		b.WriteString("\n\n")
-		errorText := m.errorStyle().Render(fmt.Sprintf("Error: %s", m.errorMsg))
-		b.WriteString(errorText)
-	}
-
-	// Render status message if present
-	if m.statusMsg != "" {
-		b.WriteString("\n\n")
-		statusText := m.statusStyle().Render(m.statusMsg)
-		b.WriteString(statusText)
-	}
-
 	return b.String()
 }
 
@@ -468,22 +418,7 @@ func (m Model) renderBotSelect() string {
 	b.WriteString(header)
 	b.WriteString("\n")
 
-	// Render menu options with cursor indicator for selected item
-	for i, option := range m.menuOptions {
-		cursor := "  " // Two spaces for non-selected items
-		optionText := option
-
-		if i == m.menuSelection {
-			// Highlight the selected item with focus indicator
-			cursor = m.cursorStyle().Render(">> ")
-			optionText = m.selectedPrimaryStyle().Render(option)
-		} else {
-			// Primary styling for difficulty options
-			optionText = m.menuPrimaryStyle().Render(option)
-		}
-
-		b.WriteString(fmt.Sprintf("%s%s\n", cursor, optionText))
-	}
+	b.WriteString(m.renderMenu(m.menuSelection))
 
 	// Render help text
 	helpText := m.renderHelpText("ESC: back to game type | arrows/jk: navigate | enter: select")
@@ -492,20 +427,6 @@ func (m Model) renderBotSelect() string {
 		b.WriteString(helpText)
 	}
 
-	// Render error message if present
-	if m.errorMsg != "" {
-		b.WriteString("\n\n")
-		errorText := m.errorStyle().Render(fmt.Sprintf("Error: %s", m.errorMsg))
-		b.WriteString(errorText)
-	}
-
-	// Render status message if present
-	if m.statusMsg != "" {
-		b.WriteString("\n\n")
-		statusText := m.statusStyle().Render(m.statusMsg)
-		b.WriteString(statusText)
-	}
-
 	return b.String()
 }
 
@@ -531,47 +452,111 @@ func (m Model) renderColorSelect() string {
 	b.WriteString(header)
 	b.WriteString("\n")
 
-	// Render menu options with cursor indicator for selected item
-	for i, option := range m.menuOptions {
-		cursor := "  " // Two spaces for non-selected items
-		optionText := option
+	b.WriteString(m.renderMenu(m.menuSelection))
 
-		if i == m.menuSelection {
-			// Highlight the selected item with focus indicator
-			cursor = m.cursorStyle().Render(">> ")
-			optionText = m.selectedPrimaryStyle().Render(option)
-		} else {
-			// Primary styling for color options
-			optionText = m.menuPrimaryStyle().Render(option)
-		}
+	// Render the per-game training mode option
+	checkbox := "[ ]"
+	if m.trainingOption {
+		checkbox = "[x]"
+	}
+	b.WriteString("\n")
+	b.WriteString(m.menuPrimaryStyle().Render(checkbox + " Training mode: move hints, blunder warnings, takebacks"))
+	b.WriteString("\n")
 
-		b.WriteString(fmt.Sprintf("%s%s\n", cursor, optionText))
+	// Render the match length option
+	match := "Match: < " + matchLengthName(m.matchLengthOption) + " >"
+	if m.matchLengthOption > 0 {
+		match += " - colors alternate each game"
 	}
+	b.WriteString(m.menuPrimaryStyle().Render(match))
+	b.WriteString("\n")
 
 	// Render help text
-	helpText := m.renderHelpText("ESC: back to difficulty | arrows/jk: navigate | enter: select")
+	helpText := m.renderHelpText("ESC: back to handicap | arrows/jk: navigate | enter: select | space: toggle training mode | left/right: match length")
 	if helpText != "" {
 		b.WriteString("\n")
 		b.WriteString(helpText)
 	}
 
-	// Render error message if present
-	if m.errorMsg != "" {
-		b.WriteString("\n\n")
-		errorText := m.errorStyle().Render(fmt.Sprintf("Error: %s", m.errorMsg))
-		b.WriteString(errorText)
-	}
+	return b.String()
+}
 
-	// Render status message if present
-	if m.statusMsg != "" {
-		b.WriteString("\n\n")
-		statusText := m.statusStyle().Render(m.statusMsg)
-		b.WriteString(statusText)
+// renderHandicapSelect renders the HandicapSelect screen where the user chooses
+// the material odds the bot gives.
+func (m Model) renderHandicapSelect() string {
+	var b strings.Builder
+
+	// Render the application title
+	title := m.titleStyle().Render("TermChess")
+	b.WriteString(title)
+	b.WriteString("\n")
+
+	// Render breadcrumb navigation
+	b.WriteString(m.renderBreadcrumb())
+
+	// Render screen header
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(m.theme.TitleText).
+		Padding(0, 0, 1, 0)
+	header := headerStyle.Render("Select Bot Handicap:")
+	b.WriteString(header)
+	b.WriteString("\n")
+
+	b.WriteString(m.renderMenu(m.menuSelection))
+
+	// Render help text
+	helpText := m.renderHelpText("ESC: back to difficulty | arrows/jk: navigate | enter: select | the bot plays without the removed material")
+	if helpText != "" {
+		b.WriteString("\n")
+		b.WriteString(helpText)
 	}
 
 	return b.String()
 }
 
+// positionInfo describes the position on board in one line: the number of
+// legal moves, whether the side to move is in check, the half-move clock
+// against the fifty-move rule and the phase of the game.
+func positionInfo(board *engine.Board) string {
+	check := "no"
+	if board.InCheck() {
+		check = "yes"
+	}
+	return fmt.Sprintf("Legal moves: %d | Check: %s | Fifty-move rule: %d/100 | Phase: %s",
+		len(board.LegalMoves()), check, board.HalfMoveClock, bot.GamePhase(board))
+}
+
+// gameHeader describes the game being played in one line: the game type, the
+// bot and its difficulty, the user's color, the time control and the move
+// number, e.g. "vs Medium Bot | You: White | Untimed | Move 12".
+func (m Model) gameHeader() string {
+	var parts []string
+	timeControl := "Untimed"
+	switch m.gameType {
+	case GameTypePvBot:
+		color := "White"
+		if m.userColor == engine.Black {
+			color = "Black"
+		}
+		parts = append(parts, "vs "+m.matchBotName(), "You: "+color)
+	case GameTypeCorrespondence:
+		parts = append(parts, "Correspondence")
+		if m.correspondence != nil {
+			color := "White"
+			if me := m.playerName(); m.correspondence.Black == me && m.correspondence.White != me {
+				color = "Black"
+			}
+			parts = append(parts, "You: "+color)
+			timeControl = correspondenceDaysLabel(m.correspondence.DaysPerMove)
+		}
+	default:
+		parts = append(parts, "Player vs Player")
+	}
+	parts = append(parts, timeControl, fmt.Sprintf("Move %d", m.game.Board().FullMoveNum))
+	return strings.Join(parts, " | ")
+}
+
 // renderGamePlay renders the GamePlay screen showing the chess board.
 // Displays the title, board, turn indicator, input prompt, help text, and messages.
 func (m Model) renderGamePlay() string {
@@ -580,15 +565,34 @@ func (m Model) renderGamePlay() string {
 	// Render the application title
 	title := m.titleStyle().Render("TermChess")
 	b.WriteString(title)
+	b.WriteString("\n")
+
+	// Keep what is being played in view, whatever moves around below
+	b.WriteString(m.statusStyle().UnsetPadding().Render(m.gameHeader()))
 	b.WriteString("\n\n")
 
 	// Render the chess board with selection highlighting
 	renderer := NewBoardRendererWithTheme(m.config, m.theme)
-	boardStr := renderer.RenderWithSelection(m.board, m.selectedSquare, m.validMoves, m.blinkOn)
-	b.WriteString(boardStr)
+	renderer.UseCache(m.boardCache)
+	renderer.FlashMove(m.flashedMove())
+	renderer.ShowCheck()
+	boardStr := renderer.RenderWithSelection(m.game.Board(), m.selectedSquare, m.validMoves, m.blinkOn)
+	showHistory := m.config.ShowMoveHistory && m.game.MoveCount() > 0
+
+	// On a wide terminal the move history goes beside the board
+	sideWidth := 0
+	if showHistory {
+		sideWidth = m.sidePanelWidth(boardStr)
+	}
+	if sideWidth > 0 {
+		history := m.renderMoveHistoryPanel(m.moveHistoryText(), lipgloss.Height(boardStr))
+		b.WriteString(besideBoard(boardStr, sideWidth, history))
+	} else {
+		b.WriteString(boardStr)
+	}
 
 	// Render move history if enabled
-	if m.config.ShowMoveHistory && len(m.moveHistory) > 0 {
+	if showHistory && sideWidth == 0 {
 		b.WriteString("\n\n")
 		moveHistoryText := m.formatMoveHistory()
 		moveHistoryStyle := lipgloss.NewStyle().
@@ -601,11 +605,52 @@ func (m Model) renderGamePlay() string {
 	b.WriteString("\n\n")
 	turnText := "White to move"
 	turnStyle := m.whiteTurnStyle()
-	if m.board.ActiveColor == 1 { // Black
+	if m.game.Board().ActiveColor == 1 { // Black
 		turnText = "Black to move"
 		turnStyle = m.blackTurnStyle()
 	}
+	if _, standard := m.game.Board().Variant.(engine.Standard); m.game.Board().Variant != nil && !standard {
+		turnText += fmt.Sprintf(" (%s chess)", m.game.Board().Variant.Name())
+	}
 	b.WriteString(turnStyle.Render(turnText))
+	if banner := m.checkBanner(); banner != "" {
+		b.WriteString("  ")
+		b.WriteString(banner)
+	}
+	b.WriteString("\n")
+	b.WriteString(m.statusStyle().UnsetPadding().Render(positionInfo(m.game.Board())))
+	if match := m.matchStatus(); match != "" {
+		b.WriteString("\n")
+		b.WriteString(m.statusStyle().UnsetPadding().Render(match))
+	}
+	if m.inSimul() {
+		b.WriteString("\n")
+		b.WriteString(m.renderSimulBoards())
+	}
+	if m.gameType == GameTypeCorrespondence && m.correspondence != nil {
+		b.WriteString("\n")
+		b.WriteString(m.renderCorrespondenceInfo())
+	}
+	if n := len(m.notes); n > 0 {
+		b.WriteString("\n")
+		b.WriteString(m.statusStyle().UnsetPadding().Render(fmt.Sprintf("Notes (%d): %s", n, m.notes[n-1].text)))
+	}
+	if m.coachComment != "" {
+		b.WriteString("\n")
+		b.WriteString(m.statusStyle().UnsetPadding().Render("Coach: " + m.coachComment))
+	}
+
+	// Let the player know a draw can be claimed
+	if reason := claimableDrawReason(m.game.Board()); reason != "" {
+		b.WriteString("\n\n")
+		b.WriteString(m.statusStyle().UnsetPadding().Render(
+			fmt.Sprintf("Draw available by %s - type 'claimdraw' to claim it", reason)))
+	} else if m.game.Board().RepetitionCount() == 2 {
+		// Warn before the position comes up a third time
+		b.WriteString("\n\n")
+		b.WriteString(m.statusStyle().UnsetPadding().Render(
+			"Position repeated twice - one more repetition is a draw"))
+	}
 
 	// Render input prompt with turn-based color for the input text
 	b.WriteString("\n\n")
@@ -615,29 +660,56 @@ func (m Model) renderGamePlay() string {
 	inputText := turnStyle.Render(m.input)
 	b.WriteString(inputPrompt + inputText)
 
+	// Offer the legal moves that complete what has been typed
+	if completions := m.renderMoveCompletions(); completions != "" {
+		b.WriteString("\n")
+		b.WriteString(completions)
+		if hint := m.renderHelpText("Tab: complete | ←/→: choose"); hint != "" {
+			b.WriteString(" ")
+			b.WriteString(hint)
+		}
+	}
+
+	// Show the queued pre-move while the bot is thinking
+	if m.premove != nil {
+		b.WriteString("\n")
+		b.WriteString(m.statusStyle().UnsetPadding().Render(fmt.Sprintf("Pre-move: %s", m.premove)))
+		if hint := m.renderHelpText("Backspace: cancel pre-move"); hint != "" {
+			b.WriteString(" ")
+			b.WriteString(hint)
+		}
+	}
+
 	// Add help text
-	helpText := m.renderHelpText("ESC: menu (with save) | type move (e.g. e4, Nf3) | Commands: resign, offerdraw, showfen, menu")
+	helpStr := "ESC: menu (with save) | type move (e.g. e4, Nf3) | Commands: resign, offerdraw, showfen, note <text>, menu"
+	if m.gameType == GameTypeCorrespondence {
+		helpStr = "ESC: My Games | type move (e.g. e4, Nf3) | Commands: resign, showfen | moves are saved to the mailbox"
+	}
+	if m.inSimul() {
+		helpStr = fmt.Sprintf("ESC: leave simul | 1-%d: switch board | type move (e.g. e4, Nf3) | Commands: resign, offerdraw, showfen, note <text>", len(m.simulGames))
+	}
+	if m.trainingMode {
+		helpStr += ", takeback | type a square (e.g. g1) to see its moves"
+	}
+	helpText := m.renderHelpText(helpStr)
 	if helpText != "" {
 		b.WriteString("\n\n")
 		b.WriteString(helpText)
 	}
 
-	// Render error message if present
-	if m.errorMsg != "" {
+	// Render a spinner while the bot is thinking
+	if m.botThinking {
 		b.WriteString("\n\n")
-		errorText := m.errorStyle().Render(fmt.Sprintf("Error: %s", m.errorMsg))
-		b.WriteString(errorText)
-	}
-
-	// Render status message if present
-	if m.statusMsg != "" {
-		b.WriteString("\n\n")
-		statusText := m.statusStyle().Render(m.statusMsg)
+		statusText := m.statusStyle().Render(m.botSpinner.View() + " " + m.thinkingMsg)
 		b.WriteString(statusText)
+		if hint := m.renderHelpText("Enter: move now"); hint != "" {
+			b.WriteString("\n")
+			b.WriteString(hint)
+		}
 	}
 
-	// Render move history if enabled
-	if m.config.ShowMoveHistory && len(m.moveHistory) > 0 {
+	// Render move history if enabled and not already beside the board
+	if showHistory && sideWidth == 0 {
 		b.WriteString("\n\n")
 
 		// Move history header
@@ -649,7 +721,7 @@ func (m Model) renderGamePlay() string {
 		b.WriteString("\n")
 
 		// Format and display move history
-		historyText := FormatMoveHistory(m.moveHistory)
+		historyText := FormatMoveHistory(m.game.Moves())
 		historyStyle := lipgloss.NewStyle().
 			Foreground(m.theme.MenuSelected)
 		history := historyStyle.Render(historyText)
@@ -660,25 +732,74 @@ func (m Model) renderGamePlay() string {
 	return b.String()
 }
 
+// renderHandoff renders the pass-the-keyboard screen shown between moves of a
+// hot-seat game. The board is hidden until the next player is ready.
+func (m Model) renderHandoff() string {
+	var b strings.Builder
+
+	// Render the application title
+	title := m.titleStyle().Render("TermChess")
+	b.WriteString(title)
+	b.WriteString("\n\n")
+
+	moved, next := "Black", "White"
+	nextStyle := m.whiteTurnStyle()
+	if m.game.Board().ActiveColor == engine.Black {
+		moved, next = "White", "Black"
+		nextStyle = m.blackTurnStyle()
+	}
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(m.theme.TitleText).
+		Padding(0, 0, 1, 0)
+	b.WriteString(headerStyle.Render("Pass the Keyboard"))
+	b.WriteString("\n")
+	b.WriteString(m.menuPrimaryStyle().Render(fmt.Sprintf("%s has moved.", moved)))
+	b.WriteString("\n")
+	b.WriteString(nextStyle.Render(fmt.Sprintf("%s: press Enter when you're ready to see the board.", next)))
+
+	helpText := m.renderHelpText("enter/space: show the board | other keys are ignored")
+	if helpText != "" {
+		b.WriteString("\n\n")
+		b.WriteString(helpText)
+	}
+
+	return b.String()
+}
+
+// claimableDrawReason describes why a draw can currently be claimed,
+// or returns an empty string if no draw is claimable.
+func claimableDrawReason(board *engine.Board) string {
+	switch board.Status() {
+	case engine.DrawThreefoldRepetition:
+		return "threefold repetition"
+	case engine.DrawFiftyMoveRule:
+		return "the fifty-move rule"
+	default:
+		return ""
+	}
+}
+
 // getGameResultMessage returns a human-readable message describing the game result.
-// It analyzes the game status and winner to generate an appropriate message.
-// If resignedBy is not -1, it indicates which player resigned.
-// If drawByAgreement is true, the game ended by mutual agreement.
-func getGameResultMessage(board *engine.Board, resignedBy int8, drawByAgreement bool) string {
+// It analyzes the game status and winner to generate an appropriate message,
+// after a draw by agreement or a resignation recorded in the game.
+func getGameResultMessage(game *engine.Game) string {
 	// Check for draw by agreement first
-	if drawByAgreement {
+	if game.DrawnByAgreement() {
 		return "Draw by agreement"
 	}
 
 	// Check for resignation
-	if resignedBy != -1 {
-		if resignedBy == int8(engine.White) {
+	if resigned, ok := game.ResignedBy(); ok {
+		if resigned == engine.White {
 			return "White resigned - Black wins"
 		}
 		return "Black resigned - White wins"
 	}
 
 	// Otherwise, check the board status
+	board := game.Board()
 	status := board.Status()
 
 	switch status {
@@ -689,6 +810,13 @@ func getGameResultMessage(board *engine.Board, resignedBy int8, drawByAgreement
 		}
 		return "Checkmate! Black wins"
 
+	case engine.KingExploded:
+		winner, _ := board.Winner()
+		if winner == engine.White {
+			return "King exploded! White wins"
+		}
+		return "King exploded! Black wins"
+
 	case engine.Stalemate:
 		return "Stalemate - Draw"
 
@@ -720,7 +848,7 @@ func (m Model) renderGameOver() string {
 	b.WriteString("\n\n")
 
 	// Render game result message
-	resultMsg := getGameResultMessage(m.board, m.resignedBy, m.drawByAgreement)
+	resultMsg := getGameResultMessage(m.game)
 	resultStyle := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(lipgloss.Color("#FFD700")).
@@ -731,27 +859,51 @@ func (m Model) renderGameOver() string {
 
 	// Render the final board position
 	renderer := NewBoardRenderer(m.config)
-	boardStr := renderer.Render(m.board)
+	renderer.UseCache(m.boardCache)
+	renderer.ShowCheck()
+	boardStr := renderer.Render(m.game.Board())
 	b.WriteString(boardStr)
 
 	// Render move count
 	b.WriteString("\n\n")
-	moveCountMsg := fmt.Sprintf("Game ended after %d moves", m.board.FullMoveNum)
+	moveCountMsg := fmt.Sprintf("Game ended after %d moves", m.game.Board().FullMoveNum)
 	moveCountStyle := lipgloss.NewStyle().
 		Foreground(m.theme.MenuNormal).
 		Align(lipgloss.Center)
 	b.WriteString(moveCountStyle.Render(moveCountMsg))
 
-	// Render options
+	// Render average think times
+	if thinkTimes := m.renderThinkTimes(); thinkTimes != "" {
+		b.WriteString("\n")
+		b.WriteString(moveCountStyle.Render(thinkTimes))
+	}
+
+	// Render the running match score
+	if match := m.matchStatus(); match != "" {
+		b.WriteString("\n")
+		b.WriteString(moveCountStyle.Render(match))
+	}
+
+	// Render the result of every simul board
+	if m.inSimul() {
+		b.WriteString("\n")
+		b.WriteString(moveCountStyle.Render(m.simulStatus()))
+		b.WriteString("\n")
+		b.WriteString(moveCountStyle.Render(m.renderSimulBoards()))
+	}
+
+	// Render the evaluation graph once the game has been evaluated
+	if graph := m.renderEvalGraph(-1); graph != "" {
+		b.WriteString("\n\n")
+		b.WriteString(graph)
+	}
+
+	// Render menu
 	b.WriteString("\n\n")
-	optionsText := "Press 'n' for New Game  |  Press 'm' for Main Menu  |  Press 'q' to Quit"
-	optionsStyle := lipgloss.NewStyle().
-		Foreground(m.theme.MenuSelected).
-		Align(lipgloss.Center)
-	b.WriteString(optionsStyle.Render(optionsText))
+	b.WriteString(m.renderMenu(m.menuSelection))
 
 	// Render help text
-	helpText := m.renderHelpText("ESC/m: menu | n: new game | q: quit")
+	helpText := m.renderHelpText("arrows/jk: navigate | enter: select | r: rematch | a: analyze | n: new game | ESC/m: menu | q: quit")
 	if helpText != "" {
 		b.WriteString("\n\n")
 		b.WriteString(helpText)
@@ -779,24 +931,31 @@ func (m Model) renderSettings() string {
 		Bold(true).
 		Foreground(m.theme.TitleText).
 		Padding(0, 0, 1, 0)
-	header := headerStyle.Render("Settings")
+	headerText := "Settings"
+	if profile := config.ActiveProfile(); profile != "" {
+		headerText = fmt.Sprintf("Settings (profile: %s)", profile)
+	}
+	header := headerStyle.Render(headerText)
 	b.WriteString(header)
 	b.WriteString("\n")
 
 	// Define toggle settings options with their current values
-	// The order here determines the settingsSelection index (0-4 for toggles)
+	// The order here determines the settingsSelection index (0-6 for toggles)
 	// Group 1 (Display): Use Unicode, Show Coordinates, Use Colors
 	// Group 2 (Info): Show Move History, Show Help Text
+	// Group 3 (Game): Turn Notifications, Hot-Seat Privacy Screen
 	toggleOptions := []struct {
 		label   string
 		enabled bool
-		group   int // 1 = display, 2 = info
+		group   int // 1 = display, 2 = info, 3 = game
 	}{
 		{"Use Unicode Pieces", m.config.UseUnicode, 1},
 		{"Show Coordinates", m.config.ShowCoords, 1},
 		{"Use Colors", m.config.UseColors, 1},
 		{"Show Move History", m.config.ShowMoveHistory, 2},
 		{"Show Help Text", m.config.ShowHelpText, 2},
+		{"Turn Notifications", m.config.TurnNotifications, 3},
+		{"Hot-Seat Privacy Screen", m.config.HotSeatPrivacy, 3},
 	}
 
 	currentGroup := 0
@@ -837,98 +996,67 @@ func (m Model) renderSettings() string {
 	b.WriteString(m.renderMenuSeparator())
 	b.WriteString("\n")
 
-	// Render the Theme option (index 5)
-	// Get theme display name with proper capitalization
-	themeDisplayName := getThemeDisplayName(m.config.Theme)
-	themeCursor := "  "
-	themeText := fmt.Sprintf("Theme: %s", themeDisplayName)
-
-	if m.settingsSelection == 5 {
-		themeCursor = m.cursorStyle().Render(">> ")
-		themeText = m.selectedItemStyle().Render(themeText)
-	} else {
-		themeText = m.menuItemStyle().Render(themeText)
+	// Render the options cycling through values (indices 7-13), the Key
+	// Bindings option (index 14), the update options (indices 15-16) and
+	// saves encryption (index 17)
+	figurine := "[ ]"
+	if m.config.FigurineNotation {
+		figurine = "[X]"
+	}
+	encrypt := "[ ]"
+	if m.config.EncryptSaves {
+		encrypt = "[X]"
+	}
+	valueOptions := []string{
+		fmt.Sprintf("Theme: %s", getThemeDisplayName(m.config.Theme)),
+		fmt.Sprintf("Coordinates: %s", getCoordinateStyleDisplayName(m.config.CoordinateStyle)),
+		fmt.Sprintf("Notation: %s", getNotationDisplayName(m.config.Notation)),
+		fmt.Sprintf("Figurine Notation %s", figurine),
+		fmt.Sprintf("Language: %s", getLanguageDisplayName(m.config.Language)),
+		fmt.Sprintf("Bot Move Time: %s", getBotMoveTimeDisplayName(m.config.BotMoveTime)),
+		fmt.Sprintf("Coach: %s", getCoachDisplayName(m.config.Coach)),
+		"Key Bindings...",
+		fmt.Sprintf("Update Checks: %s", getUpdateCheckDisplayName(m.config.UpdateCheck)),
+		"Check for Updates Now",
+		fmt.Sprintf("Encrypt Saves %s", encrypt),
+	}
+	for i, optionText := range valueOptions {
+		cursor := "  "
+		if len(toggleOptions)+i == m.settingsSelection {
+			cursor = m.cursorStyle().Render(">> ")
+			optionText = m.selectedItemStyle().Render(optionText)
+		} else {
+			optionText = m.menuItemStyle().Render(optionText)
+		}
+		b.WriteString(fmt.Sprintf("%s%s\n", cursor, optionText))
 	}
-	b.WriteString(fmt.Sprintf("%s%s\n", themeCursor, themeText))
 
 	// Render help text
-	helpText := m.renderHelpText("ESC: back | arrows/jk: navigate | enter/space: toggle/cycle")
+	helpText := m.renderHelpText("ESC: back | arrows/jk: navigate | enter/space: toggle/cycle | r: reload config file")
 	if helpText != "" {
 		b.WriteString("\n")
 		b.WriteString(helpText)
 	}
 
-	// Render error message if present
-	if m.errorMsg != "" {
-		b.WriteString("\n\n")
-		errorText := m.errorStyle().Render(fmt.Sprintf("Error: %s", m.errorMsg))
-		b.WriteString(errorText)
-	}
-
-	// Render status message if present
-	if m.statusMsg != "" {
-		b.WriteString("\n\n")
-		statusText := m.statusStyle().Render(m.statusMsg)
-		b.WriteString(statusText)
-	}
-
 	return b.String()
 }
 
-// renderSavePrompt renders the save prompt screen when the user tries to exit during an active game.
-// It shows the current board position and asks if they want to save before exiting.
+// renderSavePrompt renders the save prompt shown when the user tries to exit during an active game.
+// It asks, over the current game, if they want to save before exiting.
 func (m Model) renderSavePrompt() string {
-	var b strings.Builder
-
-	// Render the application title
-	title := m.titleStyle().Render("TermChess")
-	b.WriteString(title)
-	b.WriteString("\n\n")
-
-	// Render the current board position
-	if m.board != nil {
-		renderer := NewBoardRenderer(m.config)
-		boardStr := renderer.Render(m.board)
-		b.WriteString(boardStr)
-		b.WriteString("\n\n")
-	}
-
-	// Render the save prompt message
-	promptStyle := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("#FFD700")).
-		Align(lipgloss.Center).
-		Padding(1, 0)
-	promptMsg := "Save current game before exiting?"
-	b.WriteString(promptStyle.Render(promptMsg))
-	b.WriteString("\n\n")
-
-	// Render options
-	optionsStyle := lipgloss.NewStyle().
-		Foreground(m.theme.MenuSelected).
-		Align(lipgloss.Center)
-	optionsText := "y: Save & Exit  |  n: Exit without saving  |  ESC: Cancel"
-	b.WriteString(optionsStyle.Render(optionsText))
-
-	// Render help text
-	helpText := m.renderHelpText("y: save & exit | n: exit without saving | ESC: cancel")
-	if helpText != "" {
-		b.WriteString("\n\n")
-		b.WriteString(helpText)
-	}
+	return m.renderModalOver(Model.renderPromptBackground, m.savePrompt(), m.savePromptSelection, "")
+}
 
-	// Render error message if present
-	if m.errorMsg != "" {
-		b.WriteString("\n\n")
-		errorText := m.errorStyle().Render(fmt.Sprintf("Error: %s", m.errorMsg))
-		b.WriteString(errorText)
+// renderPromptBackground renders the game a prompt is shown over, or just the title without one.
+func (m Model) renderPromptBackground() string {
+	if m.game == nil {
+		return m.titleStyle().Render("TermChess")
 	}
-
-	return b.String()
+	return m.renderGamePlay()
 }
 
 // renderFENInput renders the FEN input screen where users can load a chess position from FEN notation.
-// Displays input field, instructions, example FEN, help text, and any error messages.
+// Displays input field, live validation with a preview board, instructions, example FEN and help text.
 func (m Model) renderFENInput() string {
 	var b strings.Builder
 
@@ -956,7 +1084,20 @@ func (m Model) renderFENInput() string {
 	// Input field with cursor
 	// Render the text input component
 	b.WriteString(m.fenInput.View())
-	b.WriteString("\n\n")
+	b.WriteString("\n")
+
+	// Live validation of what has been typed so far
+	if check := m.renderFENCheck(); check != "" {
+		b.WriteString(check)
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	// Recently loaded and exported positions
+	if recent := m.renderFENHistory(); recent != "" {
+		b.WriteString(recent)
+		b.WriteString("\n\n")
+	}
 
 	// Example
 	exampleStyle := lipgloss.NewStyle().
@@ -966,97 +1107,57 @@ func (m Model) renderFENInput() string {
 	b.WriteString("\n\n")
 
 	// Help text
-	helpText := m.renderHelpText("ESC: back to menu | enter: load position")
+	help := "ESC: back to menu | enter: load position | tab: bot vs bot from here"
+	if len(m.fenHistory) > 0 {
+		help += " | up/down: recent positions"
+	}
+	helpText := m.renderHelpText(help)
 	if helpText != "" {
 		b.WriteString(helpText)
 	}
 
-	// Error message if present
-	if m.errorMsg != "" {
-		b.WriteString("\n\n")
-		errorText := m.errorStyle().Render(fmt.Sprintf("Error: %s", m.errorMsg))
-		b.WriteString(errorText)
-	}
-
 	return b.String()
 }
 
-// renderDrawPrompt renders the Draw Prompt screen asking the opponent to accept or decline a draw offer.
-// Displays a title, message indicating which player offered the draw, two options (Accept/Decline), and help text.
-func (m Model) renderDrawPrompt() string {
-	var b strings.Builder
-
-	// Render the application title
-	title := m.titleStyle().Render("TermChess")
-	b.WriteString(title)
-	b.WriteString("\n\n")
-
-	// Render prompt title
-	promptTitle := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("#FFD700")).
-		Align(lipgloss.Center).
-		Padding(1, 0).
-		Render("Draw Offer")
-	b.WriteString(promptTitle)
-	b.WriteString("\n\n")
-
-	// Render prompt message based on who offered the draw
-	offerMessage := "White offers a draw. Accept?"
-	if m.drawOfferedBy == int8(engine.Black) {
-		offerMessage = "Black offers a draw. Accept?"
+// renderFENHistory renders the recently loaded and exported FENs, most recent
+// first, with the one chosen with the arrow keys highlighted.
+func (m Model) renderFENHistory() string {
+	if len(m.fenHistory) == 0 {
+		return ""
 	}
-	promptMessage := lipgloss.NewStyle().
-		Foreground(m.theme.MenuNormal).
-		Padding(0, 2).
-		Render(offerMessage)
-	b.WriteString(promptMessage)
-	b.WriteString("\n\n")
-
-	// Define the draw prompt options
-	options := []string{"Accept", "Decline"}
-
-	// Render each option with cursor indicator
-	for i, option := range options {
-		cursor := "  " // Two spaces for non-selected items
-		optionText := option
 
-		if i == m.drawPromptSelection {
-			// Highlight the selected item with focus indicator
-			cursor = m.cursorStyle().Render(">> ")
-			optionText = m.selectedPrimaryStyle().Render(option)
+	var b strings.Builder
+	b.WriteString("Recent positions:\n")
+	for i, fen := range m.fenHistory {
+		if i == m.fenHistorySelection {
+			b.WriteString(lipgloss.NewStyle().Foreground(m.theme.MenuSelected).Bold(true).Render("> " + fen))
 		} else {
-			// Primary styling for options
-			optionText = m.menuPrimaryStyle().Render(option)
+			b.WriteString(lipgloss.NewStyle().Foreground(m.theme.MenuNormal).Render("  " + fen))
+		}
+		if i < len(m.fenHistory)-1 {
+			b.WriteString("\n")
 		}
-
-		b.WriteString(fmt.Sprintf("%s%s\n", cursor, optionText))
 	}
+	return b.String()
+}
 
-	// Render help text
-	b.WriteString("\n")
-	helpText := m.helpStyle().Render("Use arrow keys to select, Enter to confirm, ESC to cancel")
-	b.WriteString(helpText)
-
-	// Render error message if present
-	if m.errorMsg != "" {
-		b.WriteString("\n\n")
-		errorText := m.errorStyle().Render(fmt.Sprintf("Error: %s", m.errorMsg))
-		b.WriteString(errorText)
-	}
+// renderDrawPrompt renders the Draw Prompt asking the opponent to accept or decline a draw offer,
+// shown over the current game.
+func (m Model) renderDrawPrompt() string {
+	return m.renderModalOver(Model.renderPromptBackground, m.drawPrompt(), m.drawPromptSelection, "")
+}
 
-	// Render status message if present
-	if m.statusMsg != "" {
-		b.WriteString("\n\n")
-		statusText := m.statusStyle().Render(m.statusMsg)
-		b.WriteString(statusText)
+// engineBackend names the backend that plays the bot menu option: "built-in" for
+// the difficulties, otherwise the kind of the registered bot, e.g. "UCI".
+func engineBackend(option string) string {
+	if kind, ok := bot.KindOf(option); ok {
+		return string(kind)
 	}
-
-	return b.String()
+	return "built-in"
 }
 
-// renderBvBBotSelect renders the Bot vs Bot bot selection screen.
-// This screen is shown twice: once for White bot difficulty, once for Black.
+// renderBvBBotSelect renders the Bot vs Bot engine picker.
+// This screen is shown twice: once for the White engine, once for Black.
 func (m Model) renderBvBBotSelect() string {
 	var b strings.Builder
 
@@ -1071,37 +1172,32 @@ func (m Model) renderBvBBotSelect() string {
 		Foreground(m.theme.TitleText).
 		Padding(0, 0, 1, 0)
 
-	headerText := "Select White Bot Difficulty:"
+	headerText := "Select White Engine:"
 	if !m.bvbSelectingWhite {
-		headerText = "Select Black Bot Difficulty:"
+		headerText = "Select Black Engine:"
 	}
 	header := headerStyle.Render(headerText)
 	b.WriteString(header)
 	b.WriteString("\n")
 
-	// Render menu options with cursor indicator for selected item
-	for i, option := range m.menuOptions {
-		cursor := "  "
-		optionText := option
-
-		if i == m.menuSelection {
-			cursor = m.cursorStyle().Render(">> ")
-			optionText = m.selectedPrimaryStyle().Render(option)
-		} else {
-			optionText = m.menuPrimaryStyle().Render(option)
-		}
-
-		b.WriteString(fmt.Sprintf("%s%s\n", cursor, optionText))
+	// Show the position handed over from the FEN input screen
+	if m.bvbStartFEN != "" {
+		fenStyle := lipgloss.NewStyle().
+			Foreground(m.theme.HelpText).
+			Padding(0, 0, 1, 0)
+		b.WriteString(fenStyle.Render("From position: " + m.bvbStartFEN))
+		b.WriteString("\n")
 	}
 
-	// Show the already-selected White difficulty when selecting Black
+	b.WriteString(m.renderMenu(m.menuSelection))
+
+	// Show the already-selected White engine when selecting Black
 	if !m.bvbSelectingWhite {
 		b.WriteString("\n")
 		infoStyle := lipgloss.NewStyle().
 			Foreground(m.theme.StatusText).
 			Padding(0, 2)
-		diffName := botDifficultyName(m.bvbWhiteDiff)
-		b.WriteString(infoStyle.Render(fmt.Sprintf("White: %s Bot", diffName)))
+		b.WriteString(infoStyle.Render(fmt.Sprintf("White: %s", m.bvbWhiteName())))
 		b.WriteString("\n")
 	}
 
@@ -1112,25 +1208,11 @@ func (m Model) renderBvBBotSelect() string {
 		b.WriteString(helpText)
 	}
 
-	// Render error message if present
-	if m.errorMsg != "" {
-		b.WriteString("\n\n")
-		errorText := m.errorStyle().Render(fmt.Sprintf("Error: %s", m.errorMsg))
-		b.WriteString(errorText)
-	}
-
-	// Render status message if present
-	if m.statusMsg != "" {
-		b.WriteString("\n\n")
-		statusText := m.statusStyle().Render(m.statusMsg)
-		b.WriteString(statusText)
-	}
-
 	return b.String()
 }
 
 // renderBvBGameMode renders the Bot vs Bot game mode selection screen.
-// Shows Single Game / Multi-Game options, or a text input for game count.
+// Shows Single Game / Multi-Game / SPRT Test options, or a text input for game count.
 func (m Model) renderBvBGameMode() string {
 	var b strings.Builder
 
@@ -1152,17 +1234,75 @@ func (m Model) renderBvBGameMode() string {
 	infoStyle := lipgloss.NewStyle().
 		Foreground(m.theme.StatusText).
 		Padding(0, 2)
-	matchup := fmt.Sprintf("%s Bot (White) vs %s Bot (Black)",
-		botDifficultyName(m.bvbWhiteDiff), botDifficultyName(m.bvbBlackDiff))
+	matchup := fmt.Sprintf("%s (White) vs %s (Black)",
+		m.bvbWhiteName(), m.bvbBlackName())
 	b.WriteString(infoStyle.Render(matchup))
+	b.WriteString("\n")
+	seedInfo := "Seed: random"
+	if m.bvbSeedSet {
+		seedInfo = fmt.Sprintf("Seed: %d", m.bvbSeed)
+	}
+	b.WriteString(infoStyle.Render(seedInfo))
+	b.WriteString("\n")
+	b.WriteString(infoStyle.Render("Openings: " + m.bvbOpeningsInfo()))
 	b.WriteString("\n\n")
 
-	if m.bvbInputtingCount {
+	if m.bvbInputtingOpenings {
+		// Show text input for the openings
+		promptStyle := lipgloss.NewStyle().
+			Foreground(m.theme.MenuNormal).
+			Padding(0, 2)
+		b.WriteString(promptStyle.Render("FEN, or path of an EPD/FEN suite (empty for the standard position):"))
+		b.WriteString("\n\n")
+
+		inputStyle := lipgloss.NewStyle().
+			Foreground(m.theme.MenuSelected).
+			Padding(0, 2)
+		inputDisplay := m.bvbOpeningsInput
+		if inputDisplay == "" {
+			inputDisplay = "_"
+		}
+		b.WriteString(inputStyle.Render(">> " + inputDisplay))
+		b.WriteString("\n")
+
+		helpText := m.renderHelpText("ESC: back | enter: confirm")
+		if helpText != "" {
+			b.WriteString("\n")
+			b.WriteString(helpText)
+		}
+	} else if m.bvbInputtingSeed {
+		// Show text input for the seed
+		promptStyle := lipgloss.NewStyle().
+			Foreground(m.theme.MenuNormal).
+			Padding(0, 2)
+		b.WriteString(promptStyle.Render("Seed (empty for random):"))
+		b.WriteString("\n\n")
+
+		inputStyle := lipgloss.NewStyle().
+			Foreground(m.theme.MenuSelected).
+			Padding(0, 2)
+		inputDisplay := m.bvbSeedInput
+		if inputDisplay == "" {
+			inputDisplay = "_"
+		}
+		b.WriteString(inputStyle.Render(">> " + inputDisplay))
+		b.WriteString("\n")
+
+		helpText := m.renderHelpText("ESC: back | enter: confirm | type number")
+		if helpText != "" {
+			b.WriteString("\n")
+			b.WriteString(helpText)
+		}
+	} else if m.bvbInputtingCount {
 		// Show text input for game count
 		promptStyle := lipgloss.NewStyle().
 			Foreground(m.theme.MenuNormal).
 			Padding(0, 2)
-		b.WriteString(promptStyle.Render("Number of games:"))
+		prompt := "Number of games:"
+		if m.bvbSPRT {
+			prompt = fmt.Sprintf("Maximum number of games for %s:", m.sprtConfig().Describe())
+		}
+		b.WriteString(promptStyle.Render(prompt))
 		b.WriteString("\n\n")
 
 		inputStyle := lipgloss.NewStyle().
@@ -1181,20 +1321,7 @@ func (m Model) renderBvBGameMode() string {
 			b.WriteString(helpText)
 		}
 	} else {
-		// Show menu options
-		for i, option := range m.menuOptions {
-			cursor := "  "
-			optionText := option
-
-			if i == m.menuSelection {
-				cursor = m.cursorStyle().Render(">> ")
-				optionText = m.selectedPrimaryStyle().Render(option)
-			} else {
-				optionText = m.menuPrimaryStyle().Render(option)
-			}
-
-			b.WriteString(fmt.Sprintf("%s%s\n", cursor, optionText))
-		}
+		b.WriteString(m.renderMenu(m.menuSelection))
 
 		helpText := m.renderHelpText("ESC: back | arrows/jk: navigate | enter: select")
 		if helpText != "" {
@@ -1203,20 +1330,6 @@ func (m Model) renderBvBGameMode() string {
 		}
 	}
 
-	// Render error message if present
-	if m.errorMsg != "" {
-		b.WriteString("\n\n")
-		errorText := m.errorStyle().Render(fmt.Sprintf("Error: %s", m.errorMsg))
-		b.WriteString(errorText)
-	}
-
-	// Render status message if present
-	if m.statusMsg != "" {
-		b.WriteString("\n\n")
-		statusText := m.statusStyle().Render(m.statusMsg)
-		b.WriteString(statusText)
-	}
-
 	return b.String()
 }
 
@@ -1231,7 +1344,8 @@ func (m Model) renderBvBGridView() string {
 	// Check terminal size - each cell needs ~14 width and ~11 height
 	minWidth := m.bvbGridCols * 14
 	minHeight := m.bvbGridRows*11 + 8 // 8 lines for header/footer
-	if m.termWidth > 0 && m.termHeight > 0 && (m.termWidth < minWidth || m.termHeight < minHeight) {
+	// The Auto layout shrinks to fit instead
+	if !m.bvbGridAuto && m.termWidth > 0 && m.termHeight > 0 && (m.termWidth < minWidth || m.termHeight < minHeight) {
 		warnStyle := lipgloss.NewStyle().
 			Foreground(m.theme.ErrorText).
 			Padding(0, 2)
@@ -1249,18 +1363,14 @@ func (m Model) renderBvBGridView() string {
 		return b.String()
 	}
 
-	// Calculate pagination
+	// Calculate pagination over the games the filter shows; games move in and
+	// out of a filter as they finish, so the page is clamped
+	shown := m.bvbGridSessions()
 	boardsPerPage := m.bvbGridRows * m.bvbGridCols
-	totalPages := (len(sessions) + boardsPerPage - 1) / boardsPerPage
-	pageIdx := m.bvbPageIndex
-	if pageIdx >= totalPages {
-		pageIdx = totalPages - 1
-	}
-	startIdx := pageIdx * boardsPerPage
-	endIdx := startIdx + boardsPerPage
-	if endIdx > len(sessions) {
-		endIdx = len(sessions)
-	}
+	totalPages := max((len(shown)+boardsPerPage-1)/boardsPerPage, 1)
+	pageIdx := min(m.bvbPageIndex, totalPages-1)
+	startIdx := min(pageIdx*boardsPerPage, len(shown))
+	endIdx := min(startIdx+boardsPerPage, len(shown))
 
 	// Show matchup and progress info
 	infoStyle := lipgloss.NewStyle().
@@ -1276,11 +1386,16 @@ func (m Model) renderBvBGridView() string {
 	running := m.bvbManager.RunningCount()
 	queued := m.bvbManager.QueuedCount()
 	concurrency := m.bvbManager.Concurrency()
-	matchup := fmt.Sprintf("%s Bot (White) vs %s Bot (Black) | Completed: %d/%d | Running: %d | Queued: %d | Concurrency: %d",
-		botDifficultyName(m.bvbWhiteDiff), botDifficultyName(m.bvbBlackDiff),
+	matchup := fmt.Sprintf("%s (White) vs %s (Black) | Completed: %d/%d | Running: %d | Queued: %d | Concurrency: %d",
+		m.bvbWhiteName(), m.bvbBlackName(),
 		finished, len(sessions), running, queued, concurrency)
 	b.WriteString(infoStyle.Render(matchup))
-	b.WriteString("\n\n")
+	b.WriteString("\n")
+	if m.bvbGridFilter != BvBFilterAll {
+		b.WriteString(infoStyle.Render(fmt.Sprintf("Showing: %s games (%d of %d)", m.bvbGridFilter, len(shown), len(sessions))))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
 
 	// Render live statistics panel
 	liveStats := m.renderBvBLiveStats()
@@ -1290,7 +1405,11 @@ func (m Model) renderBvBGridView() string {
 	}
 
 	// Render the grid
-	pageSessions := sessions[startIdx:endIdx]
+	pageSessions := shown[startIdx:endIdx]
+	if len(pageSessions) == 0 {
+		b.WriteString(infoStyle.Render(fmt.Sprintf("No %s games yet.", strings.ToLower(m.bvbGridFilter.String()))))
+		b.WriteString("\n")
+	}
 	gridStr := m.renderBoardGrid(pageSessions, m.bvbGridCols)
 	b.WriteString(gridStr)
 	b.WriteString("\n")
@@ -1315,44 +1434,17 @@ func (m Model) renderBvBGridView() string {
 	if m.bvbPaused {
 		controlStatus += " | PAUSED"
 	}
+	if m.bvbFollow {
+		controlStatus += " | FOLLOWING"
+	}
 	controlStyle := lipgloss.NewStyle().
 		Foreground(m.theme.MenuNormal).
 		Padding(0, 2)
 	b.WriteString(controlStyle.Render(controlStatus))
 	b.WriteString("\n")
 
-	// Jump prompt (if showing)
-	if m.bvbShowJumpPrompt {
-		b.WriteString("\n")
-		jumpPromptStyle := lipgloss.NewStyle().
-			Bold(true).
-			Foreground(m.theme.MenuSelected).
-			Padding(0, 2)
-		inputDisplay := m.bvbJumpInput
-		if inputDisplay == "" {
-			inputDisplay = "_"
-		}
-		jumpPrompt := fmt.Sprintf("Jump to game (1-%d): %s", m.bvbGameCount, inputDisplay)
-		b.WriteString(jumpPromptStyle.Render(jumpPrompt))
-		b.WriteString("\n")
-
-		jumpHintStyle := lipgloss.NewStyle().
-			Foreground(m.theme.HelpText).
-			Italic(true).
-			Padding(0, 2)
-		b.WriteString(jumpHintStyle.Render("Enter: jump | Esc: cancel"))
-		b.WriteString("\n")
-	}
-
-	// Error message if present
-	if m.errorMsg != "" {
-		b.WriteString("\n")
-		errorText := m.errorStyle().Render(fmt.Sprintf("Error: %s", m.errorMsg))
-		b.WriteString(errorText)
-	}
-
 	// Help text
-	helpText := m.renderHelpText("Space: pause/resume | t: toggle speed | ←/→: pages | g: jump to game | Tab: single view | f: FEN | ESC: abort")
+	helpText := m.renderHelpText("Space: pause/resume | t: toggle speed | ←/→: pages | g: jump to game | F: filter | L: follow | *: bookmark | N: next notable | Tab: single view | f: FEN | ESC: abort")
 	if helpText != "" {
 		b.WriteString("\n")
 		b.WriteString(helpText)
@@ -1371,7 +1463,7 @@ func (m Model) renderBoardGrid(sessions []*bvb.GameSession, cols int) string {
 	// Render each session as a fixed-dimension compact board cell
 	cells := make([]string, len(sessions))
 	for i, session := range sessions {
-		cells[i] = m.renderCompactBoardCell(session)
+		cells[i] = m.cachedCompactBoardCell(session)
 	}
 
 	// Arrange cells into rows with consistent alignment
@@ -1406,7 +1498,7 @@ func (m Model) renderBvBGridCell(gameIndex int) string {
 }
 
 // renderCompactBoardCell renders a single game session as a compact board cell for the grid.
-// Shows: game number, compact board, move count, and status.
+// Shows: game number, compact board, move count, and the result or material trend.
 // The cell has fixed dimensions (bvbCellHeight x bvbCellWidth) to prevent layout shifts.
 func (m Model) renderCompactBoardCell(session *bvb.GameSession) string {
 	board := session.CurrentBoard()
@@ -1418,7 +1510,11 @@ func (m Model) renderCompactBoardCell(session *bvb.GameSession) string {
 	var lines []string
 
 	// Line 1: Game header
-	lines = append(lines, fmt.Sprintf("Game %d", gameNum))
+	header := fmt.Sprintf("Game %d", gameNum)
+	if m.bvbManager != nil && m.bvbManager.IsBookmarked(gameNum) {
+		header += " *"
+	}
+	lines = append(lines, header)
 
 	// Lines 2-9: Board (8 lines)
 	compactConfig := Config{
@@ -1434,7 +1530,7 @@ func (m Model) renderCompactBoardCell(session *bvb.GameSession) string {
 	// Line 10: Status line (always shows move count)
 	lines = append(lines, fmt.Sprintf("Moves: %d", moveCount))
 
-	// Line 11: Result line (empty for in-progress, result for finished)
+	// Line 11: Result line (material sparkline for in-progress, result for finished)
 	if isFinished {
 		result := session.Result()
 		if result != nil {
@@ -1443,7 +1539,8 @@ func (m Model) renderCompactBoardCell(session *bvb.GameSession) string {
 			lines = append(lines, "") // Empty placeholder
 		}
 	} else {
-		lines = append(lines, "") // Empty placeholder for in-progress games
+		// Material trend of in-progress games, empty before the first move
+		lines = append(lines, materialSparkline(session.MaterialHistory(), bvbCellWidth))
 	}
 
 	// Line 12: Spacing (empty line)
@@ -1520,8 +1617,8 @@ func (m Model) renderBvBGridConfig() string {
 	infoStyle := lipgloss.NewStyle().
 		Foreground(m.theme.StatusText).
 		Padding(0, 2)
-	gameInfo := fmt.Sprintf("%d game(s) | %s Bot (White) vs %s Bot (Black)",
-		m.bvbGameCount, botDifficultyName(m.bvbWhiteDiff), botDifficultyName(m.bvbBlackDiff))
+	gameInfo := fmt.Sprintf("%d game(s) | %s (White) vs %s (Black)",
+		m.bvbGameCount, m.bvbWhiteName(), m.bvbBlackName())
 	b.WriteString(infoStyle.Render(gameInfo))
 	b.WriteString("\n\n")
 
@@ -1548,19 +1645,7 @@ func (m Model) renderBvBGridConfig() string {
 			b.WriteString(helpText)
 		}
 	} else {
-		for i, option := range m.menuOptions {
-			cursor := "  "
-			optionText := option
-
-			if i == m.menuSelection {
-				cursor = m.cursorStyle().Render(">> ")
-				optionText = m.selectedPrimaryStyle().Render(option)
-			} else {
-				optionText = m.menuPrimaryStyle().Render(option)
-			}
-
-			b.WriteString(fmt.Sprintf("%s%s\n", cursor, optionText))
-		}
+		b.WriteString(m.renderMenu(m.menuSelection))
 
 		helpText := m.renderHelpText("ESC: back | arrows/jk: navigate | enter: select")
 		if helpText != "" {
@@ -1569,12 +1654,6 @@ func (m Model) renderBvBGridConfig() string {
 		}
 	}
 
-	if m.errorMsg != "" {
-		b.WriteString("\n\n")
-		errorText := m.errorStyle().Render(fmt.Sprintf("Error: %s", m.errorMsg))
-		b.WriteString(errorText)
-	}
-
 	return b.String()
 }
 
@@ -1585,11 +1664,19 @@ func (m Model) renderBvBGamePlay() string {
 		return "No session running.\n"
 	}
 
-	// Render the abort confirmation dialog as an overlay if showing
-	if m.bvbShowAbortConfirm {
-		return m.renderBvBAbortConfirm()
+	// Render the abort confirmation or jump prompt over the games if showing
+	switch {
+	case m.bvbShowAbortConfirm:
+		return m.renderModalOver(Model.renderBvBView, m.bvbAbortPrompt(), m.bvbAbortSelection, "")
+	case m.bvbShowJumpPrompt:
+		return m.renderModalOver(Model.renderBvBView, m.bvbJumpPrompt(), 0, m.bvbJumpInput)
 	}
 
+	return m.renderBvBView()
+}
+
+// renderBvBView renders the running games in the selected view mode.
+func (m Model) renderBvBView() string {
 	switch m.bvbViewMode {
 	case BvBSingleView:
 		return m.renderBvBSingleView()
@@ -1600,54 +1687,22 @@ func (m Model) renderBvBGamePlay() string {
 	}
 }
 
-// renderBvBAbortConfirm renders the abort confirmation dialog for BvB sessions.
-func (m Model) renderBvBAbortConfirm() string {
-	var b strings.Builder
-
-	// Title
-	title := m.titleStyle().Render("TermChess - Bot vs Bot")
-	b.WriteString(title)
-	b.WriteString("\n\n")
-
-	// Dialog box
-	dialogStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(m.theme.MenuSelected).
-		Padding(1, 2)
-
-	var dialogContent strings.Builder
-	dialogTitleStyle := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(m.theme.TitleText)
-	dialogContent.WriteString(dialogTitleStyle.Render("  Abort Session?  "))
-	dialogContent.WriteString("\n\n")
-	dialogContent.WriteString("  Games in progress will be lost.\n\n")
-
-	// Options
-	normalStyle := m.menuItemStyle()
-	selectedStyle := m.selectedItemStyle()
-
-	if m.bvbAbortSelection == 0 {
-		dialogContent.WriteString(selectedStyle.Render("  > Cancel"))
-	} else {
-		dialogContent.WriteString(normalStyle.Render("    Cancel"))
-	}
-	dialogContent.WriteString("\n")
-	if m.bvbAbortSelection == 1 {
-		dialogContent.WriteString(selectedStyle.Render("  > Abort Session"))
-	} else {
-		dialogContent.WriteString(normalStyle.Render("    Abort Session"))
-	}
-	dialogContent.WriteString("\n\n")
-	dialogContent.WriteString(m.helpStyle().Render("  esc: cancel | enter: select"))
-
-	b.WriteString(dialogStyle.Render(dialogContent.String()))
+// renderBvBStats renders the Bot vs Bot statistics screen after all games finish.
+// The results scroll when they don't fit the terminal, while the menu stays in view.
+func (m Model) renderBvBStats() string {
+	body, footer := m.bvbStatsParts()
+	return m.renderScrolled(body, m.bvbStatsScrollHeight(footer)) + footer
+}
 
-	return b.String()
+// bvbStatsScrollHeight returns the number of lines the results of the statistics
+// screen may take above footer.
+func (m Model) bvbStatsScrollHeight(footer string) int {
+	return m.termHeight - lipgloss.Height(footer)
 }
 
-// renderBvBStats renders the Bot vs Bot statistics screen after all games finish.
-func (m Model) renderBvBStats() string {
+// bvbStatsParts renders the statistics screen in two parts: the results in body
+// and the menu, messages and help text in footer.
+func (m Model) bvbStatsParts() (body, footer string) {
 	var b strings.Builder
 
 	title := m.titleStyle().Render("TermChess - Bot vs Bot Results")
@@ -1656,13 +1711,13 @@ func (m Model) renderBvBStats() string {
 
 	if m.bvbManager == nil {
 		b.WriteString("No session data available.\n")
-		return b.String()
+		return b.String(), ""
 	}
 
 	stats := m.bvbManager.Stats()
 	if stats == nil || stats.TotalGames == 0 {
 		b.WriteString("No games completed.\n")
-		return b.String()
+		return b.String(), ""
 	}
 
 	infoStyle := lipgloss.NewStyle().
@@ -1692,22 +1747,66 @@ func (m Model) renderBvBStats() string {
 		b.WriteString("\n")
 		b.WriteString(statStyle.Render(fmt.Sprintf("Duration: %s", r.Duration.Round(time.Millisecond))))
 		b.WriteString("\n")
+		if line := bvbThinkTimeLine(stats); line != "" {
+			b.WriteString(statStyle.Render(line))
+			b.WriteString("\n")
+		}
+		b.WriteString(statStyle.Render(fmt.Sprintf("Seed: %d", m.bvbManager.Seed())))
+		b.WriteString("\n")
+		if notable := m.bvbNotableLabel(r.GameNumber); notable != "" {
+			b.WriteString(statStyle.Render("Notable: " + notable))
+			b.WriteString("\n")
+		}
 	} else {
 		// Multi-game stats
-		b.WriteString(infoStyle.Render(fmt.Sprintf("%s (White) vs %s (Black) — %d games", stats.WhiteBotName, stats.BlackBotName, stats.TotalGames)))
+		if stats.Pairs != nil {
+			b.WriteString(infoStyle.Render(fmt.Sprintf("%s vs %s — %d games, colors swapped every other game", stats.WhiteBotName, stats.BlackBotName, stats.TotalGames)))
+		} else {
+			b.WriteString(infoStyle.Render(fmt.Sprintf("%s (White) vs %s (Black) — %d games", stats.WhiteBotName, stats.BlackBotName, stats.TotalGames)))
+		}
 		b.WriteString("\n\n")
 
 		// Win/loss/draw summary
-		b.WriteString(statStyle.Render(fmt.Sprintf("%s wins: %d (%.1f%%)", stats.WhiteBotName, stats.WhiteWins, stats.WhiteWinPct)))
+		b.WriteString(statStyle.Render(fmt.Sprintf("%s wins: %d (%.1f%%, %s)", stats.WhiteBotName, stats.WhiteWins, stats.WhiteWinPct, formatBvBInterval(stats.WhiteWinCI))))
 		b.WriteString("\n")
-		b.WriteString(statStyle.Render(fmt.Sprintf("%s wins: %d (%.1f%%)", stats.BlackBotName, stats.BlackWins, stats.BlackWinPct)))
+		b.WriteString(statStyle.Render(fmt.Sprintf("%s wins: %d (%.1f%%, %s)", stats.BlackBotName, stats.BlackWins, stats.BlackWinPct, formatBvBInterval(stats.BlackWinCI))))
+		b.WriteString("\n")
+		b.WriteString(statStyle.Render(fmt.Sprintf("Draws: %d (%.1f%%, %s) | Decisive: %.1f%%", stats.Draws, stats.DrawPct, formatBvBInterval(stats.DrawCI), stats.DecisivePct)))
+		b.WriteString("\n")
+		b.WriteString(statStyle.Render(bvbEndingsLine(stats)))
+		b.WriteString("\n")
+		if line := bvbRepetitionLine(stats); line != "" {
+			b.WriteString(statStyle.Render(line))
+			b.WriteString("\n")
+		}
+		if stats.Pairs != nil {
+			for _, line := range bvbPairLines(stats) {
+				b.WriteString(statStyle.Render(line))
+				b.WriteString("\n")
+			}
+		}
 		b.WriteString("\n")
-		b.WriteString(statStyle.Render(fmt.Sprintf("Draws: %d", stats.Draws)))
-		b.WriteString("\n\n")
 
 		// Averages
 		b.WriteString(statStyle.Render(fmt.Sprintf("Avg moves: %.1f | Avg duration: %s", stats.AvgMoveCount, stats.AvgDuration.Round(time.Millisecond))))
 		b.WriteString("\n")
+		b.WriteString(statStyle.Render(fmt.Sprintf("Duration: median %s | p10 %s | p90 %s",
+			stats.DurationP50.Round(time.Millisecond), stats.DurationP10.Round(time.Millisecond), stats.DurationP90.Round(time.Millisecond))))
+		b.WriteString("\n")
+		if line := bvbThinkTimeLine(stats); line != "" {
+			b.WriteString(statStyle.Render(line))
+			b.WriteString("\n")
+		}
+
+		// Estimated strength difference between the bots
+		b.WriteString(statStyle.Render(bvbEloLine(stats)))
+		b.WriteString("\n")
+		b.WriteString(statStyle.Render(fmt.Sprintf("Seed: %d", m.bvbManager.Seed())))
+		b.WriteString("\n")
+		if sprtLine := m.renderSPRTLine(); sprtLine != "" {
+			b.WriteString(statStyle.Render(sprtLine))
+			b.WriteString("\n")
+		}
 
 		// Shortest/longest
 		b.WriteString(statStyle.Render(fmt.Sprintf("Shortest game: #%d (%d moves) | Longest game: #%d (%d moves)",
@@ -1715,7 +1814,25 @@ func (m Model) renderBvBStats() string {
 			stats.LongestGame.GameNumber, stats.LongestGame.MoveCount)))
 		b.WriteString("\n\n")
 
-		// Individual results (paginated, 15 per page)
+		// Bookmarked and flagged games, the first few of them
+		if notable := m.bvbManager.NotableGames(); len(notable) > 0 {
+			const maxNotableShown = 5
+			b.WriteString(dimStyle.Render(fmt.Sprintf("Notable Games (%d, %s: open next):", len(notable), m.keys.Label(ActionNextNotable))))
+			b.WriteString("\n")
+			for i, n := range notable {
+				if i == maxNotableShown {
+					b.WriteString(dimStyle.Render(fmt.Sprintf("  ... and %d more", len(notable)-maxNotableShown)))
+					b.WriteString("\n")
+					break
+				}
+				b.WriteString(dimStyle.Render(fmt.Sprintf("  Game %d: %s", n, m.bvbNotableLabel(n))))
+				b.WriteString("\n")
+			}
+			b.WriteString("\n")
+		}
+
+		// Individual results (paginated, 15 per page) in the chosen order
+		results := sortBvBResults(stats.IndividualResults, m.bvbResultSort)
 		resultsPerPage := 15
 		totalResults := len(stats.IndividualResults)
 		totalPages := (totalResults + resultsPerPage - 1) / resultsPerPage
@@ -1733,68 +1850,38 @@ func (m Model) renderBvBStats() string {
 			endIdx = totalResults
 		}
 
-		b.WriteString(dimStyle.Render(fmt.Sprintf("Individual Results (Page %d/%d):", currentPage+1, totalPages)))
+		b.WriteString(dimStyle.Render(fmt.Sprintf("Individual Results (Page %d/%d, by %s):", currentPage+1, totalPages, m.bvbResultSort)))
 		b.WriteString("\n")
-		for _, r := range stats.IndividualResults[startIdx:endIdx] {
+		for _, r := range results[startIdx:endIdx] {
 			var resultText string
 			if r.Winner == "Draw" {
-				resultText = fmt.Sprintf("  Game %d: Draw (%s) — %d moves", r.GameNumber, r.EndReason, r.MoveCount)
+				resultText = fmt.Sprintf("  Game %d: Draw (%s) — %d moves, %s", r.GameNumber, r.EndReason, r.MoveCount, r.Duration.Round(time.Millisecond))
 			} else {
-				resultText = fmt.Sprintf("  Game %d: %s wins (%s) — %d moves", r.GameNumber, r.Winner, r.EndReason, r.MoveCount)
+				resultText = fmt.Sprintf("  Game %d: %s wins (%s) — %d moves, %s", r.GameNumber, r.Winner, r.EndReason, r.MoveCount, r.Duration.Round(time.Millisecond))
+			}
+			if m.bvbManager.IsBookmarked(r.GameNumber) {
+				resultText += " *"
 			}
 			b.WriteString(dimStyle.Render(resultText))
 			b.WriteString("\n")
 		}
 	}
 
+	// Everything above the menu may scroll
+	body = b.String()
+	b.Reset()
 	b.WriteString("\n")
 
 	// Menu options with visual hierarchy
-	for i, opt := range m.menuOptions {
-		cursor := "  "
-		var optionText string
-		isPrimary := isPrimaryAction(opt)
-
-		if i == m.bvbStatsSelection {
-			cursor = m.cursorStyle().Render(">> ")
-			if isPrimary {
-				optionText = m.selectedPrimaryStyle().Render(opt)
-			} else {
-				optionText = m.selectedSecondaryStyle().Render(opt)
-			}
-		} else {
-			if isPrimary {
-				optionText = m.menuPrimaryStyle().Render(opt)
-			} else {
-				optionText = m.menuSecondaryStyle().Render(opt)
-			}
-		}
-		b.WriteString(cursor + optionText)
-		b.WriteString("\n")
-	}
-
-	// Show status or error messages
-	if m.statusMsg != "" {
-		statusStyle := lipgloss.NewStyle().
-			Foreground(m.theme.StatusText).
-			Padding(0, 2)
-		b.WriteString("\n")
-		b.WriteString(statusStyle.Render(m.statusMsg))
-	}
-	if m.errorMsg != "" {
-		errorStyle := lipgloss.NewStyle().
-			Foreground(m.theme.ErrorText).
-			Padding(0, 2)
-		b.WriteString("\n")
-		b.WriteString(errorStyle.Render(m.errorMsg))
-	}
+	b.WriteString(m.renderMenu(m.bvbStatsSelection))
 
 	// Build help text, including pagination controls if multiple pages
 	helpStr := "up/down: navigate | s: export | Enter: select | ESC: menu"
 	if stats.TotalGames > 1 {
+		helpStr = "up/down: navigate | o: sort | s: export | Enter: select | ESC: menu"
 		totalPages := (len(stats.IndividualResults) + 14) / 15 // resultsPerPage = 15
 		if totalPages > 1 {
-			helpStr = "up/down: navigate | left/right: page | s: export | Enter: select | ESC: menu"
+			helpStr = "up/down: navigate | left/right: page | o: sort | s: export | Enter: select | ESC: menu"
 		}
 	}
 	helpText := m.renderHelpText(helpStr)
@@ -1803,7 +1890,7 @@ func (m Model) renderBvBStats() string {
 		b.WriteString(helpText)
 	}
 
-	return b.String()
+	return body, b.String()
 }
 
 // renderBvBSingleView renders a single game with full detail.
@@ -1832,8 +1919,8 @@ func (m Model) renderBvBSingleView() string {
 		Foreground(m.theme.StatusText).
 		Padding(0, 2)
 
-	matchup := fmt.Sprintf("%s Bot (White) vs %s Bot (Black)",
-		botDifficultyName(m.bvbWhiteDiff), botDifficultyName(m.bvbBlackDiff))
+	whiteName, blackName := session.Players()
+	matchup := fmt.Sprintf("%s (White) vs %s (Black)", whiteName, blackName)
 	b.WriteString(infoStyle.Render(matchup))
 	b.WriteString("\n")
 
@@ -1868,23 +1955,34 @@ func (m Model) renderBvBSingleView() string {
 	b.WriteString(infoStyle.Render(gameInfo))
 	b.WriteString("\n\n")
 
-	// Render live statistics panel
-	liveStats := m.renderBvBLiveStats()
-	if liveStats != "" {
-		b.WriteString(liveStats)
-		b.WriteString("\n\n")
-	}
-
-	// Render the chess board
+	// Render the chess board, with the live statistics and move history beside
+	// it on a wide terminal or above and below it otherwise
 	board := session.CurrentBoard()
 	renderer := NewBoardRenderer(m.config)
+	renderer.UseCache(m.boardCache)
 	boardStr := renderer.Render(board)
-	b.WriteString(boardStr)
+	liveStats := m.renderBvBLiveStats()
+	moves := session.CurrentMoveHistory()
+	moveCount := len(moves)
+	showHistory := m.config.ShowMoveHistory && moveCount > 0
+
+	sideWidth := m.sidePanelWidth(boardStr)
+	if sideWidth > 0 {
+		history := ""
+		if showHistory {
+			history = m.renderMoveHistoryPanel(coordinateMoves(moves), lipgloss.Height(boardStr)-lipgloss.Height(liveStats)-1)
+		}
+		b.WriteString(besideBoard(boardStr, sideWidth, liveStats, history))
+	} else {
+		if liveStats != "" {
+			b.WriteString(liveStats)
+			b.WriteString("\n\n")
+		}
+		b.WriteString(boardStr)
+	}
 	b.WriteString("\n\n")
 
 	// Move count and status
-	moves := session.CurrentMoveHistory()
-	moveCount := len(moves)
 
 	statusLine := fmt.Sprintf("Moves: %d", moveCount)
 	if session.IsFinished() {
@@ -1906,6 +2004,16 @@ func (m Model) renderBvBSingleView() string {
 	b.WriteString(statusLineStyle.Render(statusLine))
 	b.WriteString("\n")
 
+	// Think times of the bots, and which one is computing
+	b.WriteString(infoStyle.Render(m.renderBvBThinking(session)))
+	b.WriteString("\n")
+
+	// Bookmark and notable events of the game
+	if notable := m.bvbNotableLabel(session.GameNumber()); notable != "" {
+		b.WriteString(infoStyle.Render("Notable: " + notable))
+		b.WriteString("\n")
+	}
+
 	// Show pause/speed status
 	speedNames := map[bvb.PlaybackSpeed]string{
 		bvb.SpeedInstant: "Instant",
@@ -1915,14 +2023,17 @@ func (m Model) renderBvBSingleView() string {
 	if m.bvbPaused {
 		controlStatus += " | PAUSED"
 	}
+	if m.bvbFollow {
+		controlStatus += " | FOLLOWING"
+	}
 	controlStyle := lipgloss.NewStyle().
 		Foreground(m.theme.MenuNormal).
 		Padding(0, 2)
 	b.WriteString(controlStyle.Render(controlStatus))
 	b.WriteString("\n")
 
-	// Move history (if enabled and there are moves)
-	if m.config.ShowMoveHistory && moveCount > 0 {
+	// Move history (if enabled, there are moves and it isn't beside the board)
+	if showHistory && sideWidth == 0 {
 		b.WriteString("\n")
 		historyHeader := lipgloss.NewStyle().
 			Bold(true).
@@ -1938,42 +2049,12 @@ func (m Model) renderBvBSingleView() string {
 		b.WriteString("\n")
 	}
 
-	// Jump prompt (if showing)
-	if m.bvbShowJumpPrompt {
-		b.WriteString("\n")
-		jumpPromptStyle := lipgloss.NewStyle().
-			Bold(true).
-			Foreground(m.theme.MenuSelected).
-			Padding(0, 2)
-		inputDisplay := m.bvbJumpInput
-		if inputDisplay == "" {
-			inputDisplay = "_"
-		}
-		jumpPrompt := fmt.Sprintf("Jump to game (1-%d): %s", m.bvbGameCount, inputDisplay)
-		b.WriteString(jumpPromptStyle.Render(jumpPrompt))
-		b.WriteString("\n")
-
-		jumpHintStyle := lipgloss.NewStyle().
-			Foreground(m.theme.HelpText).
-			Italic(true).
-			Padding(0, 2)
-		b.WriteString(jumpHintStyle.Render("Enter: jump | Esc: cancel"))
-		b.WriteString("\n")
-	}
-
-	// Error message if present
-	if m.errorMsg != "" {
-		b.WriteString("\n")
-		errorText := m.errorStyle().Render(fmt.Sprintf("Error: %s", m.errorMsg))
-		b.WriteString(errorText)
-	}
-
 	// Help text
 	helpStr := "Space: pause/resume | t: toggle speed | "
 	if m.bvbGameCount > 1 {
-		helpStr += "left/right: games | g: jump to game | "
+		helpStr += "left/right: games | g: jump to game | L: follow | "
 	}
-	helpStr += "Tab: view | f: FEN | ESC: abort"
+	helpStr += "*: bookmark | N: next notable | Tab: view | f: FEN | ESC: abort"
 	helpText := m.renderHelpText(helpStr)
 	if helpText != "" {
 		b.WriteString("\n")
@@ -2036,6 +2117,10 @@ func (m Model) renderBvBLiveStats() string {
 	progressLine := fmt.Sprintf("Progress: %d / %d games", completed, totalGames)
 	sb.WriteString(progressStyle.Render(progressLine))
 	sb.WriteString("\n")
+	if sprtLine := m.renderSPRTLine(); sprtLine != "" {
+		sb.WriteString(progressStyle.Render(sprtLine))
+		sb.WriteString("\n")
+	}
 
 	// Detailed stats (only show if we have completed games)
 	detailStyle := lipgloss.NewStyle().
@@ -2115,6 +2200,51 @@ func (m Model) getCurrentBvBSession() *bvb.GameSession {
 	return nil
 }
 
+// bvbEloLine renders the estimated Elo difference of the white bot over the black bot.
+// Unless the games were paired, colors are fixed in a BvB session, so the estimate
+// includes White's first-move advantage.
+func bvbEloLine(stats *bvb.AggregateStats) string {
+	if stats.Elo == nil {
+		return fmt.Sprintf("Elo difference: not enough information (%d-%d-%d)", stats.WhiteWins, stats.Draws, stats.BlackWins)
+	}
+	return fmt.Sprintf("Elo difference: %s %s", stats.WhiteBotName, stats.Elo)
+}
+
+// formatBvBInterval formats a 95% confidence interval of a percentage.
+func formatBvBInterval(ci bvb.Interval) string {
+	return fmt.Sprintf("95%% CI %.1f–%.1f%%", ci.Lower, ci.Upper)
+}
+
+// bvbEndingsLine renders how the games ended, most common ending first.
+func bvbEndingsLine(stats *bvb.AggregateStats) string {
+	parts := make([]string, len(stats.EndReasons))
+	for i, e := range stats.EndReasons {
+		parts[i] = fmt.Sprintf("%s %d (%.0f%%)", e.Reason, e.Games, e.Pct)
+	}
+	return "Endings: " + strings.Join(parts, " | ")
+}
+
+// bvbRepetitionLine renders how often the games came one repetition away from
+// a draw, or "" if none did.
+func bvbRepetitionLine(stats *bvb.AggregateStats) string {
+	if stats.RepetitionWarnings == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Repetition warnings: %d in %d games, %d then drawn by repetition",
+		stats.RepetitionWarnings, stats.RepetitionWarningGames, stats.RepetitionDraws)
+}
+
+// bvbPairLines renders the results of the game pairs of a session whose bots
+// swapped colors, and how the games went by color.
+func bvbPairLines(stats *bvb.AggregateStats) []string {
+	p := stats.Pairs
+	return []string{
+		fmt.Sprintf("Pairs: %s won %d | %s won %d | Split %d (%d complete)",
+			stats.WhiteBotName, p.WhiteWins, stats.BlackBotName, p.BlackWins, p.Draws, p.Complete),
+		fmt.Sprintf("Games won with White: %d | with Black: %d", p.WinsAsWhite, p.WinsAsBlack),
+	}
+}
+
 // formatBvBDuration formats a duration as MM:SS for display.
 func formatBvBDuration(d time.Duration) string {
 	totalSeconds := int(d.Seconds())
@@ -2243,9 +2373,12 @@ func (m Model) renderBvBViewModeSelect() string {
 	infoStyle := lipgloss.NewStyle().
 		Foreground(m.theme.StatusText).
 		Padding(0, 2)
-	sessionInfo := fmt.Sprintf("%d game(s) | %s Bot (White) vs %s Bot (Black) | Grid: %dx%d",
-		m.bvbGameCount, botDifficultyName(m.bvbWhiteDiff), botDifficultyName(m.bvbBlackDiff),
-		m.bvbGridRows, m.bvbGridCols)
+	grid := fmt.Sprintf("%dx%d", m.bvbGridRows, m.bvbGridCols)
+	if m.bvbGridAuto {
+		grid = "Auto"
+	}
+	sessionInfo := fmt.Sprintf("%d game(s) | %s (White) vs %s (Black) | Grid: %s",
+		m.bvbGameCount, m.bvbWhiteName(), m.bvbBlackName(), grid)
 	b.WriteString(infoStyle.Render(sessionInfo))
 	b.WriteString("\n\n")
 
@@ -2298,12 +2431,6 @@ func (m Model) renderBvBViewModeSelect() string {
 		b.WriteString(helpText)
 	}
 
-	if m.errorMsg != "" {
-		b.WriteString("\n\n")
-		errorText := m.errorStyle().Render(fmt.Sprintf("Error: %s", m.errorMsg))
-		b.WriteString(errorText)
-	}
-
 	return b.String()
 }
 
@@ -2369,8 +2496,8 @@ func (m Model) renderBvBStatsOnly() string {
 		Padding(0, 2)
 
 	// Matchup header
-	matchup := fmt.Sprintf("%s Bot (White) vs %s Bot (Black)",
-		botDifficultyName(m.bvbWhiteDiff), botDifficultyName(m.bvbBlackDiff))
+	matchup := fmt.Sprintf("%s (White) vs %s (Black)",
+		m.bvbWhiteName(), m.bvbBlackName())
 	b.WriteString(infoStyle.Render(matchup))
 	b.WriteString("\n\n")
 
@@ -2408,6 +2535,10 @@ func (m Model) renderBvBStatsOnly() string {
 	avgLine := fmt.Sprintf("Average moves per game: %.1f", avgMoves)
 	b.WriteString(statStyle.Render(avgLine))
 	b.WriteString("\n")
+	if sprtLine := m.renderSPRTLine(); sprtLine != "" {
+		b.WriteString(statStyle.Render(sprtLine))
+		b.WriteString("\n")
+	}
 
 	// In-progress indicator
 	inProgressLine := fmt.Sprintf("%d game(s) in progress", inProgress)
@@ -2458,19 +2589,15 @@ func (m Model) renderBvBStatsOnly() string {
 	if m.bvbPaused {
 		controlStatus += " | PAUSED"
 	}
+	if m.bvbFollow {
+		controlStatus += " | FOLLOWING"
+	}
 	controlStyle := lipgloss.NewStyle().
 		Foreground(m.theme.MenuNormal).
 		Padding(0, 2)
 	b.WriteString(controlStyle.Render(controlStatus))
 	b.WriteString("\n")
 
-	// Error message if present
-	if m.errorMsg != "" {
-		b.WriteString("\n")
-		errorText := m.errorStyle().Render(fmt.Sprintf("Error: %s", m.errorMsg))
-		b.WriteString(errorText)
-	}
-
 	// Help text
 	helpText := m.renderHelpText("[Space] Pause/Resume | [v] Change view | [t] Speed | [q/ESC] Quit")
 	if helpText != "" {
@@ -2496,58 +2623,55 @@ func renderProgressBar(completed, total, width int) string {
 	return fmt.Sprintf("[%s] %d%% (%d/%d)", bar, int(percent*100), completed, total)
 }
 
+// bvbWhiteName returns the display name of the white bot in BvB mode, e.g. "Hard Bot".
+func (m Model) bvbWhiteName() string {
+	return m.bvbWhiteConfig().DisplayName()
+}
+
+// bvbBlackName returns the display name of the black bot in BvB mode.
+func (m Model) bvbBlackName() string {
+	return m.bvbBlackConfig().DisplayName()
+}
+
+// bvbWhiteConfig describes the white bot in BvB mode, with the Bot Move Time
+// setting applied.
+func (m Model) bvbWhiteConfig() bot.Config {
+	return bot.Config{Difficulty: m.bvbWhiteDiff, Backend: m.bvbWhiteBot, MoveTime: m.config.BotMoveTime}
+}
+
+// bvbBlackConfig describes the black bot in BvB mode, with the Bot Move Time
+// setting applied.
+func (m Model) bvbBlackConfig() bot.Config {
+	return bot.Config{Difficulty: m.bvbBlackDiff, Backend: m.bvbBlackBot, MoveTime: m.config.BotMoveTime}
+}
+
 // botDifficultyName returns the display name for a bot difficulty.
 func botDifficultyName(d BotDifficulty) string {
-	switch d {
-	case BotEasy:
-		return "Easy"
-	case BotMedium:
-		return "Medium"
-	case BotHard:
-		return "Hard"
-	default:
-		return "Unknown"
-	}
+	return d.String()
 }
 
 // formatMoveHistory formats the move history for display with a header.
 // Returns an empty string if there are no moves to display.
-// Format: "Move History: 1. e4 e5 2. Nf3 Nc6"
+// Format: "Move History: 1. e4 (2.1s) e5 (0.8s) 2. Nf3 (4.0s) Nc6 (1.2s)"
+// Moves without a recorded time are shown without one.
 func (m Model) formatMoveHistory() string {
-	if len(m.moveHistory) == 0 {
+	if m.game.MoveCount() == 0 {
 		return ""
 	}
+	return "Move History: " + strings.Join(moveHistoryRows(m.moveHistoryText()), " ")
+}
 
-	var b strings.Builder
-	b.WriteString("Move History: ")
-
-	// We need to replay moves on a board to format them as SAN
+// moveHistoryText returns the moves of the game in the notation chosen in the
+// settings, each followed by the time spent on it when move timing is shown.
+func (m Model) moveHistoryText() []string {
+	// We need to replay moves on a board to format them
 	board := engine.NewBoard()
-
-	for i := 0; i < len(m.moveHistory); i += 2 {
-		moveNum := (i / 2) + 1
-
-		// Format white's move
-		whiteSAN := FormatSAN(board, m.moveHistory[i])
-		board.MakeMove(m.moveHistory[i])
-
-		// Format black's move (if exists)
-		if i+1 < len(m.moveHistory) {
-			blackSAN := FormatSAN(board, m.moveHistory[i+1])
-			board.MakeMove(m.moveHistory[i+1])
-			b.WriteString(fmt.Sprintf("%d. %s %s", moveNum, whiteSAN, blackSAN))
-
-			// Add space only if there are more moves to come
-			if i+2 < len(m.moveHistory) {
-				b.WriteString(" ")
-			}
-		} else {
-			// Only white's move (game in progress)
-			b.WriteString(fmt.Sprintf("%d. %s", moveNum, whiteSAN))
-		}
+	moves := make([]string, m.game.MoveCount())
+	for i, move := range m.game.Moves() {
+		moves[i] = m.formatMove(board, move) + m.moveTimeSuffix(i)
+		board.MakeMove(move)
 	}
-
-	return b.String()
+	return moves
 }
 
 // getThemeDisplayName returns a display-friendly name for a theme.
@@ -2565,17 +2689,32 @@ func getThemeDisplayName(themeName string) string {
 	}
 }
 
-// renderShortcutsOverlay renders a full-screen modal overlay displaying all keyboard shortcuts.
-// The overlay is organized by context (Global, Menu, Settings, Gameplay, Bot vs Bot).
+// renderShortcutsOverlay renders the content of the modal overlay displaying all keyboard
+// shortcuts, shown over the current screen. It is organized by context (Global, Menu,
+// Settings, Gameplay, Bot vs Bot). The shortcuts scroll when the terminal is too short
+// to show them all.
 func (m Model) renderShortcutsOverlay() string {
+	body, footer := m.shortcutsOverlayParts()
+	return m.renderScrolled(body, m.shortcutsScrollHeight(footer)) + footer
+}
+
+// shortcutsScrollHeight returns the number of lines the shortcuts may take in the
+// overlay above footer, leaving room for the overlay's border and padding.
+func (m Model) shortcutsScrollHeight(footer string) int {
+	chrome := m.overlayBoxStyle().GetVerticalFrameSize()
+	return m.termHeight - chrome - lipgloss.Height(footer)
+}
+
+// shortcutsOverlayParts renders the shortcuts overlay in two parts: the list of
+// shortcuts in body and the closing hint in footer.
+func (m Model) shortcutsOverlayParts() (body, footer string) {
 	var b strings.Builder
 
 	// Title style for the overlay
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(m.theme.TitleText).
-		Align(lipgloss.Center).
-		Padding(1, 0)
+		Align(lipgloss.Center)
 
 	// Section header style
 	sectionStyle := lipgloss.NewStyle().
@@ -2597,7 +2736,7 @@ func (m Model) renderShortcutsOverlay() string {
 	hintStyle := lipgloss.NewStyle().
 		Foreground(m.theme.HelpText).
 		Italic(true).
-		Padding(2, 0, 0, 0)
+		Padding(1, 0, 0, 0)
 
 	// Render title
 	b.WriteString(titleStyle.Render("Keyboard Shortcuts"))
@@ -2613,52 +2752,76 @@ func (m Model) renderShortcutsOverlay() string {
 	// Global shortcuts
 	b.WriteString(sectionStyle.Render("Global"))
 	b.WriteString("\n")
-	renderShortcut("?", "Show this help overlay")
-	renderShortcut("n", "Start new game")
-	renderShortcut("s", "Open settings")
+	renderShortcut(m.keys.Label(ActionHelp), "Show this help overlay")
+	renderShortcut(m.keys.Label(ActionNewGame), "Start new game")
+	renderShortcut(m.keys.Label(ActionSettings), "Open settings")
+	renderShortcut(m.keys.Label(ActionCommandPalette), "Open command palette")
 	renderShortcut("Ctrl+C", "Quit application")
-	renderShortcut("q", "Quit (or show save prompt in game)")
-	renderShortcut("Esc", "Go back / Cancel")
+	renderShortcut(m.keys.Label(ActionQuit), "Quit (or show save prompt in game)")
+	renderShortcut(m.keys.Label(ActionBack), "Go back / Cancel")
 
 	// Menu navigation
 	b.WriteString(sectionStyle.Render("Menu Navigation"))
 	b.WriteString("\n")
-	renderShortcut("Up / k", "Move selection up")
-	renderShortcut("Down / j", "Move selection down")
-	renderShortcut("Enter", "Select / Confirm")
+	renderShortcut(m.keys.Label(ActionUp), "Move selection up")
+	renderShortcut(m.keys.Label(ActionDown), "Move selection down")
+	renderShortcut(m.keys.Label(ActionSelect), "Select / Confirm")
+	renderShortcut(m.keys.Label(ActionPageUp)+" / "+m.keys.Label(ActionPageDown), "Scroll long screens")
 
 	// Settings
 	b.WriteString(sectionStyle.Render("Settings"))
 	b.WriteString("\n")
-	renderShortcut("Up / k", "Previous setting")
-	renderShortcut("Down / j", "Next setting")
-	renderShortcut("Enter/Space", "Toggle / Cycle setting")
+	renderShortcut(m.keys.Label(ActionUp), "Previous setting")
+	renderShortcut(m.keys.Label(ActionDown), "Next setting")
+	renderShortcut(m.keys.Label(ActionSelect)+" / "+m.keys.Label(ActionToggle), "Toggle / Cycle setting")
+	renderShortcut("r", "Reload config file")
 
 	// Gameplay
 	b.WriteString(sectionStyle.Render("Gameplay"))
 	b.WriteString("\n")
 	renderShortcut("Type move", "Enter move (e.g., e4, Nf3, O-O)")
 	renderShortcut("Enter", "Submit move")
+	renderShortcut("Enter (empty)", "Make a thinking bot move now")
+	renderShortcut("Tab / ←/→", "Complete the typed move / choose a completion")
+	renderShortcut("↑/↓", "Recall earlier moves and commands")
 	renderShortcut("resign", "Resign the game")
 	renderShortcut("offerdraw", "Offer a draw")
+	renderShortcut("claimdraw", "Claim a draw (repetition / 50 moves)")
+	renderShortcut("takeback", "Take back your last move (training mode)")
 	renderShortcut("showfen", "Show/copy FEN position")
+	renderShortcut("diagram [plain]", "Copy a text diagram of the position")
+	renderShortcut("note <text>", "Add a note, exported as a PGN comment")
 	renderShortcut("menu", "Return to menu (with save)")
 
+	// Game Analysis
+	b.WriteString(sectionStyle.Render("Game Analysis"))
+	b.WriteString("\n")
+	renderShortcut(m.keys.Label(ActionAnalyze), "Analyze finished game (game over screen)")
+	renderShortcut(m.keys.Label(ActionRematch), "Rematch with colors swapped (game over screen)")
+	renderShortcut(m.keys.Label(ActionLeft)+" / "+m.keys.Label(ActionRight), "Step through moves")
+	renderShortcut("[ / ]", "Previous / next flagged move")
+
 	// Bot vs Bot
 	b.WriteString(sectionStyle.Render("Bot vs Bot"))
 	b.WriteString("\n")
-	renderShortcut("Space", "Pause / Resume")
-	renderShortcut("Left / h", "Previous game / page")
-	renderShortcut("Right / l", "Next game / page")
-	renderShortcut("g", "Jump to game (enter game number)")
-	renderShortcut("Tab", "Toggle grid / single view")
-	renderShortcut("t", "Toggle speed (Normal / Instant)")
-	renderShortcut("f", "Copy FEN of current game")
+	renderShortcut(m.keys.Label(ActionToggle), "Pause / Resume")
+	renderShortcut(m.keys.Label(ActionLeft), "Previous game / page")
+	renderShortcut(m.keys.Label(ActionRight), "Next game / page")
+	renderShortcut(m.keys.Label(ActionJumpToGame), "Jump to game (enter game number)")
+	renderShortcut(m.keys.Label(ActionToggleView), "Toggle grid / single view")
+	renderShortcut(m.keys.Label(ActionToggleSpeed), "Toggle speed (Normal / Instant)")
+	renderShortcut(m.keys.Label(ActionCopyFEN), "Copy FEN of current game")
+	renderShortcut(m.keys.Label(ActionFilterGames), "Filter grid (All / Running / Decisive / Draws)")
+	renderShortcut(m.keys.Label(ActionFollowGames), "Follow running games")
+	renderShortcut(m.keys.Label(ActionBookmark), "Bookmark / unbookmark current game")
+	renderShortcut(m.keys.Label(ActionNextNotable), "Next bookmarked or flagged game")
+	renderShortcut(m.keys.Label(ActionShowLog), "Session log of errors and warnings")
+	renderShortcut(m.keys.Label(ActionSortResults), "Sort results (stats screen)")
 
 	// Footer hint
-	b.WriteString(hintStyle.Render("Press any key to close"))
+	footer = hintStyle.Render("Press any key to close | Rebind keys in Settings > Key Bindings")
 
-	return b.String()
+	return b.String(), footer
 }
 
 // renderBvBConcurrencySelect renders the Bot vs Bot concurrency selection screen.
@@ -2682,8 +2845,8 @@ func (m Model) renderBvBConcurrencySelect() string {
 	infoStyle := lipgloss.NewStyle().
 		Foreground(m.theme.StatusText).
 		Padding(0, 2)
-	sessionInfo := fmt.Sprintf("%d game(s) | %s Bot (White) vs %s Bot (Black)",
-		m.bvbGameCount, botDifficultyName(m.bvbWhiteDiff), botDifficultyName(m.bvbBlackDiff))
+	sessionInfo := fmt.Sprintf("%d game(s) | %s (White) vs %s (Black)",
+		m.bvbGameCount, m.bvbWhiteName(), m.bvbBlackName())
 	b.WriteString(infoStyle.Render(sessionInfo))
 	b.WriteString("\n\n")
 
@@ -2705,6 +2868,10 @@ func (m Model) renderBvBConcurrencySelect() string {
 			name:        "Custom",
 			description: "Enter your own value (may cause lag)",
 		},
+		{
+			name:        "CPU Limit: " + cpuLimitLabel(m.bvbCPULimit, numCPU),
+			description: "left/right: change | caps the cores the games may use",
+		},
 	}
 
 	descStyle := lipgloss.NewStyle().
@@ -2769,15 +2936,17 @@ func (m Model) renderBvBConcurrencySelect() string {
 		}
 	}
 
-	if m.errorMsg != "" {
-		b.WriteString("\n\n")
-		errorText := m.errorStyle().Render(fmt.Sprintf("Error: %s", m.errorMsg))
-		b.WriteString(errorText)
-	}
-
 	return b.String()
 }
 
+// cpuLimitLabel describes the BvB CPU limit, e.g. "2 of 8 cores" or "All 8 cores".
+func cpuLimitLabel(limit, numCPU int) string {
+	if limit <= 0 || limit >= numCPU {
+		return fmt.Sprintf("All %d cores", numCPU)
+	}
+	return fmt.Sprintf("%d of %d cores", limit, numCPU)
+}
+
 // parseConcurrencyValue parses a string into an integer, returning 0 if invalid.
 func parseConcurrencyValue(s string) int {
 	if s == "" {
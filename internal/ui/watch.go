@@ -0,0 +1,162 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Mgrdich/TermChess/internal/engine"
+	"github.com/Mgrdich/TermChess/internal/spectate"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// watchSnapshotMsg carries a snapshot received from the watched instance.
+type watchSnapshotMsg struct {
+	snapshot spectate.Snapshot
+}
+
+// watchEndedMsg reports that the connection to the watched instance ended.
+type watchEndedMsg struct {
+	err error
+}
+
+// Watch connects to the instance sharing its games on the unix socket at path and
+// returns the model showing them on the read-only Watch screen.
+func (m Model) Watch(path string) (Model, error) {
+	watcher, err := spectate.Watch(path)
+	if err != nil {
+		return m, err
+	}
+	m.watcher = watcher
+	m.watchSnapshot = spectate.Snapshot{Mode: "idle"}
+	m.watchGame = 0
+	m.watchErr = nil
+	m.screen = ScreenWatch
+	m.navStack = []Screen{}
+	return m, nil
+}
+
+// nextWatchSnapshotCmd waits for the next snapshot from the watched instance.
+func (m Model) nextWatchSnapshotCmd() tea.Cmd {
+	watcher := m.watcher
+	return func() tea.Msg {
+		snapshot, err := watcher.Next()
+		if err != nil {
+			return watchEndedMsg{err: err}
+		}
+		return watchSnapshotMsg{snapshot: snapshot}
+	}
+}
+
+// handleWatchSnapshot shows a new snapshot and waits for the next one.
+func (m Model) handleWatchSnapshot(msg watchSnapshotMsg) (tea.Model, tea.Cmd) {
+	m.watchSnapshot = msg.snapshot
+	if m.watchGame >= len(m.watchSnapshot.Games) {
+		m.watchGame = 0
+	}
+	return m, m.nextWatchSnapshotCmd()
+}
+
+// handleWatchEnded keeps the last position on screen once the watched instance is gone.
+func (m Model) handleWatchEnded(msg watchEndedMsg) (tea.Model, tea.Cmd) {
+	m.watchErr = msg.err
+	return m, nil
+}
+
+// handleWatchKeys handles keyboard input on the Watch screen. Nothing can be
+// played from here: the arrows switch between Bot vs Bot games and every quit
+// key disconnects.
+func (m Model) handleWatchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	count := len(m.watchSnapshot.Games)
+	switch {
+	case msg.String() == "ctrl+c", m.keys.Matches(msg, ActionBack), m.keys.Matches(msg, ActionQuit):
+		if m.watcher != nil {
+			_ = m.watcher.Close()
+		}
+		return m, tea.Quit
+	case m.keys.Matches(msg, ActionRight) && count > 0:
+		m.watchGame = (m.watchGame + 1) % count
+	case m.keys.Matches(msg, ActionLeft) && count > 0:
+		m.watchGame = (m.watchGame - 1 + count) % count
+	}
+	return m, nil
+}
+
+// renderWatch renders the Watch screen: the shared game's board, players and status.
+func (m Model) renderWatch() string {
+	var b strings.Builder
+
+	// Render the application title
+	title := m.titleStyle().Render("TermChess")
+	b.WriteString(title)
+	b.WriteString("\n\n")
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(m.theme.TitleText).
+		Padding(0, 0, 1, 0)
+
+	games := m.watchSnapshot.Games
+	if len(games) == 0 {
+		b.WriteString(headerStyle.Render("Watching (read-only)"))
+		b.WriteString("\n")
+		b.WriteString(m.menuPrimaryStyle().Render("Waiting for a game to start..."))
+	} else {
+		game := games[m.watchGame]
+		header := fmt.Sprintf("%s vs %s", game.White, game.Black)
+		if m.watchSnapshot.Mode == "bvb" {
+			header = fmt.Sprintf("Game %d of %d: %s", game.Number, len(games), header)
+		}
+		b.WriteString(headerStyle.Render(header))
+		b.WriteString("\n")
+
+		board, err := engine.FromFEN(game.FEN)
+		if err != nil {
+			b.WriteString(m.errorStyle().Render(fmt.Sprintf("Error: invalid position: %v", err)))
+		} else {
+			renderer := NewBoardRendererWithTheme(m.config, m.theme)
+			b.WriteString(renderer.Render(board))
+			b.WriteString("\n\n")
+			b.WriteString(m.renderWatchStatus(board, game))
+		}
+	}
+
+	// Render help text
+	help := "q/ESC: stop watching"
+	if len(games) > 1 {
+		help = "←/→: switch game | " + help
+	}
+	if helpText := m.renderHelpText(help); helpText != "" {
+		b.WriteString("\n\n")
+		b.WriteString(helpText)
+	}
+
+	// Let the watcher know the game they see is no longer live
+	if m.watchErr != nil {
+		b.WriteString("\n\n")
+		if errors.Is(m.watchErr, io.EOF) {
+			b.WriteString(m.statusStyle().Render("The shared game has closed."))
+		} else {
+			b.WriteString(m.errorStyle().Render(fmt.Sprintf("Error: lost connection: %v", m.watchErr)))
+		}
+	}
+
+	return b.String()
+}
+
+// renderWatchStatus renders the move count and either the side to move or the result.
+func (m Model) renderWatchStatus(board *engine.Board, game spectate.Game) string {
+	moves := fmt.Sprintf("%d moves", len(game.Moves))
+	if game.Result != "" {
+		return m.statusStyle().Render(fmt.Sprintf("%s (%s), %s", game.Status, game.Result, moves))
+	}
+	if game.Status != engine.Ongoing.String() {
+		return m.menuPrimaryStyle().Render(fmt.Sprintf("%s, %s", game.Status, moves))
+	}
+	if board.ActiveColor == engine.Black {
+		return m.blackTurnStyle().Render(fmt.Sprintf("Black to move, %s", moves))
+	}
+	return m.whiteTurnStyle().Render(fmt.Sprintf("White to move, %s", moves))
+}
@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Mgrdich/TermChess/internal/spectate"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// shareGames shares the host's games on a unix socket and returns a model watching them.
+func shareGames(t *testing.T, host Model) (Model, Model) {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "tc")
+	if err != nil {
+		t.Fatalf("MkdirTemp() error = %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	path := filepath.Join(dir, "game.sock")
+
+	broadcaster, err := spectate.ListenUnix(path)
+	if err != nil {
+		t.Fatalf("ListenUnix() error = %v", err)
+	}
+	t.Cleanup(func() { _ = broadcaster.Close() })
+	host = host.WithSpectator(broadcaster)
+
+	watch, err := NewModel(DefaultConfig()).Watch(path)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	t.Cleanup(func() { _ = watch.watcher.Close() })
+	return host, watch
+}
+
+// receive waits for the next message from the watched instance and applies it.
+func receive(t *testing.T, m Model, cmd tea.Cmd) (Model, tea.Cmd) {
+	t.Helper()
+	result, next := m.Update(cmd())
+	return result.(Model), next
+}
+
+func TestWatchFollowsHostGame(t *testing.T) {
+	host, err := NewModel(DefaultConfig()).StartGame(StartOptions{})
+	if err != nil {
+		t.Fatalf("StartGame() error = %v", err)
+	}
+	host, watch := shareGames(t, host)
+	if watch.screen != ScreenWatch {
+		t.Fatalf("screen = %v, want ScreenWatch", watch.screen)
+	}
+
+	watch, cmd := receive(t, watch, watch.Init())
+	if !strings.Contains(watch.View(), "White to move, 0 moves") {
+		t.Errorf("Expected the starting position, got:\n%s", watch.View())
+	}
+
+	host.input = "e2e4"
+	result, _ := host.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	host = result.(Model)
+
+	watch, _ = receive(t, watch, cmd)
+	if got := watch.watchSnapshot.Games[0].FEN; got != host.game.Board().ToFEN() {
+		t.Errorf("watched FEN = %q, want %q", got, host.game.Board().ToFEN())
+	}
+	if !strings.Contains(watch.View(), "Black to move, 1 moves") {
+		t.Errorf("Expected the position after e2e4, got:\n%s", watch.View())
+	}
+}
+
+func TestWatchKeys(t *testing.T) {
+	m := NewModel(DefaultConfig())
+	m.screen = ScreenWatch
+	m.watchSnapshot = spectate.Snapshot{Mode: "bvb", Games: []spectate.Game{
+		{Number: 1, White: "Easy Bot", Black: "Hard Bot", FEN: "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", Status: "ongoing"},
+		{Number: 2, White: "Easy Bot", Black: "Hard Bot", FEN: "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", Status: "queued"},
+	}}
+
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	m = result.(Model)
+	if m.watchGame != 1 {
+		t.Errorf("watchGame after left = %d, want to wrap to 1", m.watchGame)
+	}
+	if !strings.Contains(m.View(), "Game 2 of 2") {
+		t.Errorf("Expected the second game, got:\n%s", m.View())
+	}
+
+	// Gameplay keys do nothing while watching
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if result.(Model).screen != ScreenWatch {
+		t.Error("Expected to stay on the Watch screen")
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd == nil {
+		t.Fatal("Expected ESC to stop watching")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("Expected ESC to quit")
+	}
+}
+
+func TestWatchShowsClosedHost(t *testing.T) {
+	_, watch := shareGames(t, NewModel(DefaultConfig()))
+	watch, _ = receive(t, watch, watch.Init())
+	if !strings.Contains(watch.View(), "Waiting for a game to start") {
+		t.Errorf("Expected to wait for the host's game, got:\n%s", watch.View())
+	}
+
+	result, _ := watch.Update(watchEndedMsg{err: io.ErrUnexpectedEOF})
+	if !strings.Contains(result.(Model).View(), "lost connection") {
+		t.Errorf("Expected the lost connection to be shown, got:\n%s", result.(Model).View())
+	}
+}
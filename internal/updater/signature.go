@@ -0,0 +1,47 @@
+package updater
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SigningKey is the base64-encoded Ed25519 public key the checksums.txt of
+// releases are signed with. It is set at build time by the release build:
+//
+//	-ldflags "-X github.com/Mgrdich/TermChess/internal/updater.SigningKey=..."
+//
+// Builds without a key only verify the checksum of the downloaded binary.
+var SigningKey = ""
+
+// ErrSignatureInvalid is returned when the checksums of a release are not
+// signed with SigningKey.
+var ErrSignatureInvalid = errors.New("signature verification failed")
+
+// GetSignatureURL constructs the download URL for the signature of the checksums file.
+func GetSignatureURL(version string) string {
+	return GetChecksumsURL(version) + ".sig"
+}
+
+// VerifySignature checks that signature is the Ed25519 signature of data by
+// publicKey, the base64-encoded public key. The signature may be raw, as written
+// by "openssl pkeyutl -sign -rawin", or base64-encoded.
+func VerifySignature(data, signature []byte, publicKey string) error {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(publicKey))
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return fmt.Errorf("%w: invalid public key", ErrSignatureInvalid)
+	}
+	if len(signature) != ed25519.SignatureSize {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+		if err != nil {
+			return fmt.Errorf("%w: malformed signature", ErrSignatureInvalid)
+		}
+		signature = decoded
+	}
+	if len(signature) != ed25519.SignatureSize || !ed25519.Verify(key, data, signature) {
+		return ErrSignatureInvalid
+	}
+	return nil
+}
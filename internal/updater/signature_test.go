@@ -0,0 +1,153 @@
+package updater
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestVerifySignature(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	key := base64.StdEncoding.EncodeToString(publicKey)
+	data := []byte("abc123  termchess-v0.1.0-linux-amd64\n")
+	signature := ed25519.Sign(privateKey, data)
+
+	if err := VerifySignature(data, signature, key); err != nil {
+		t.Errorf("VerifySignature() with a raw signature = %v, want nil", err)
+	}
+	encoded := []byte(base64.StdEncoding.EncodeToString(signature) + "\n")
+	if err := VerifySignature(data, encoded, key); err != nil {
+		t.Errorf("VerifySignature() with a base64 signature = %v, want nil", err)
+	}
+	if err := VerifySignature([]byte("tampered"), signature, key); !errors.Is(err, ErrSignatureInvalid) {
+		t.Errorf("VerifySignature() of other data = %v, want ErrSignatureInvalid", err)
+	}
+	if err := VerifySignature(data, signature, "not a key"); !errors.Is(err, ErrSignatureInvalid) {
+		t.Errorf("VerifySignature() with a bad key = %v, want ErrSignatureInvalid", err)
+	}
+}
+
+// releaseServer serves a fake release of version with the given binary, the
+// checksum of listed and, if privateKey is not nil, the signature of the checksums.
+// The tests keep binary and listed different, so nothing is ever installed over
+// the test executable.
+func releaseServer(t *testing.T, version string, binary, listed []byte, privateKey ed25519.PrivateKey) *Client {
+	t.Helper()
+	sum := sha256.Sum256(listed)
+	checksums := fmt.Sprintf("%s  %s\n", hex.EncodeToString(sum[:]), GetBinaryFilename(version, runtime.GOOS, runtime.GOARCH))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/checksums.txt.sig") && privateKey != nil:
+			w.Write(ed25519.Sign(privateKey, []byte(checksums)))
+		case strings.HasSuffix(r.URL.Path, "/checksums.txt"):
+			w.Write([]byte(checksums))
+		case strings.Contains(r.URL.Path, "/termchess-"):
+			w.Header().Set("Content-Length", fmt.Sprint(len(binary)))
+			w.Write(binary)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return NewClientWithHTTPClient(&http.Client{Transport: &testTransport{server: server}}, server.URL)
+}
+
+func TestUpgradeWithProgressRejectsBadSignature(t *testing.T) {
+	publicKey, _, _ := ed25519.GenerateKey(nil)
+	_, otherKey, _ := ed25519.GenerateKey(nil)
+	defer func(key string) { SigningKey = key }(SigningKey)
+	SigningKey = base64.StdEncoding.EncodeToString(publicKey)
+
+	// Signed with a key other than the one built in
+	client := releaseServer(t, "v2.0.0", []byte("new binary"), []byte("other binary"), otherKey)
+	var stages []Stage
+	_, err := client.UpgradeWithProgress(context.Background(), "v1.0.0", "v2.0.0", nil, func(p Progress) {
+		if len(stages) == 0 || stages[len(stages)-1] != p.Stage {
+			stages = append(stages, p.Stage)
+		}
+	})
+	if !errors.Is(err, ErrSignatureInvalid) {
+		t.Fatalf("UpgradeWithProgress() error = %v, want ErrSignatureInvalid", err)
+	}
+	if fmt.Sprint(stages) != fmt.Sprint([]Stage{StageDownloading, StageVerifying}) {
+		t.Errorf("Reported stages %v, want downloading then verifying", stages)
+	}
+
+	// Not signed at all
+	client = releaseServer(t, "v2.0.0", []byte("new binary"), []byte("other binary"), nil)
+	if _, err := client.UpgradeWithProgress(context.Background(), "v1.0.0", "v2.0.0", nil, nil); !errors.Is(err, ErrSignatureInvalid) {
+		t.Errorf("UpgradeWithProgress() of an unsigned release = %v, want ErrSignatureInvalid", err)
+	}
+}
+
+func TestUpgradeWithProgressReportsDownload(t *testing.T) {
+	defer func(key string) { SigningKey = key }(SigningKey)
+	SigningKey = ""
+
+	// A binary that doesn't match its checksum stops the upgrade before installing
+	binary := []byte(strings.Repeat("x", 100000))
+	client := releaseServer(t, "v2.0.0", binary, []byte("other binary"), nil)
+	var last Progress
+	_, err := client.UpgradeWithProgress(context.Background(), "v1.0.0", "v2.0.0", nil, func(p Progress) {
+		if p.Stage == StageDownloading && p.Downloaded > 0 {
+			last = p
+		}
+	})
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("UpgradeWithProgress() error = %v, want ErrChecksumMismatch", err)
+	}
+	if last.Downloaded != int64(len(binary)) || last.Total != int64(len(binary)) {
+		t.Errorf("Last download progress %+v, want all %d bytes", last, len(binary))
+	}
+}
+
+func TestReplaceBinaryAtAndRollback(t *testing.T) {
+	dir := t.TempDir()
+	binaryPath := filepath.Join(dir, "termchess")
+	if err := os.WriteFile(binaryPath, []byte("old binary"), 0755); err != nil {
+		t.Fatalf("failed to write binary: %v", err)
+	}
+
+	if _, err := rollbackAt(binaryPath); !errors.Is(err, ErrNoBackup) {
+		t.Errorf("rollbackAt() before an upgrade = %v, want ErrNoBackup", err)
+	}
+
+	backup, err := replaceBinaryAt(binaryPath, []byte("new binary"))
+	if err != nil {
+		t.Fatalf("replaceBinaryAt() error = %v", err)
+	}
+	if data, _ := os.ReadFile(binaryPath); string(data) != "new binary" {
+		t.Errorf("Installed binary = %q, want the new one", data)
+	}
+	if data, _ := os.ReadFile(backup); string(data) != "old binary" {
+		t.Errorf("Backup = %q, want the old binary", data)
+	}
+	if info, err := os.Stat(binaryPath); err != nil || info.Mode().Perm() != 0755 {
+		t.Errorf("Installed binary mode = %v, %v; want 0755", info.Mode().Perm(), err)
+	}
+
+	if _, err := rollbackAt(binaryPath); err != nil {
+		t.Fatalf("rollbackAt() error = %v", err)
+	}
+	if data, _ := os.ReadFile(binaryPath); string(data) != "old binary" {
+		t.Errorf("Binary after rollback = %q, want the old one", data)
+	}
+	if _, err := os.Stat(backup); !os.IsNotExist(err) {
+		t.Error("Expected the backup to be used up by the rollback")
+	}
+}
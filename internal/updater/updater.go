@@ -168,6 +168,53 @@ type UpgradeResult struct {
 	PreviousVersion string
 	NewVersion      string
 	IsDowngrade     bool
+	// Signed is true if the release's checksums were signed and the signature verified.
+	Signed bool
+	// BackupPath is where the previous binary is kept, for Rollback.
+	BackupPath string
+}
+
+// RollbackError is returned when installing the new binary failed and the
+// previous one could not be put back either. The previous binary is left at Backup.
+type RollbackError struct {
+	// Path is where the binary is installed.
+	Path string
+	// Backup is where the previous binary was left.
+	Backup string
+	// Err is why the new binary could not be installed.
+	Err error
+}
+
+func (e *RollbackError) Error() string {
+	return fmt.Sprintf("installing new binary: %v; restoring the previous binary also failed, move %s back to %s", e.Err, e.Backup, e.Path)
+}
+
+func (e *RollbackError) Unwrap() error {
+	return e.Err
+}
+
+// Stage is a step of an upgrade, as reported to the progress callback of
+// UpgradeWithProgress.
+type Stage int
+
+const (
+	// StageChecking looks up the latest release.
+	StageChecking Stage = iota
+	// StageDownloading downloads the checksums and the binary.
+	StageDownloading
+	// StageVerifying checks the binary against its checksum and the checksums against their signature.
+	StageVerifying
+	// StageInstalling replaces the binary.
+	StageInstalling
+)
+
+// Progress reports how far an upgrade has got.
+type Progress struct {
+	Stage Stage
+	// Downloaded is the number of bytes of the binary downloaded so far.
+	Downloaded int64
+	// Total is the size of the binary in bytes, or 0 if the server didn't say.
+	Total int64
 }
 
 // GetChecksumsURL constructs the download URL for the checksums file.
@@ -228,6 +275,27 @@ func GetExpectedChecksum(checksums map[string]string, version, goos, goarch stri
 
 // downloadFile performs an HTTP GET request and returns the response body.
 func (c *Client) downloadFile(ctx context.Context, url string) ([]byte, error) {
+	return c.downloadFileWithProgress(ctx, url, nil)
+}
+
+// progressReader reports the bytes read through it to a callback.
+type progressReader struct {
+	r        io.Reader
+	read     int64
+	total    int64
+	progress func(read, total int64)
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.read += int64(n)
+	p.progress(p.read, p.total)
+	return n, err
+}
+
+// downloadFileWithProgress performs an HTTP GET request and returns the response
+// body, calling progress, if not nil, as it is read.
+func (c *Client) downloadFileWithProgress(ctx context.Context, url string, progress func(read, total int64)) ([]byte, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
@@ -245,7 +313,11 @@ func (c *Client) downloadFile(ctx context.Context, url string) ([]byte, error) {
 		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
-	data, err := io.ReadAll(resp.Body)
+	var body io.Reader = resp.Body
+	if progress != nil {
+		body = &progressReader{r: resp.Body, total: max(resp.ContentLength, 0), progress: progress}
+	}
+	data, err := io.ReadAll(body)
 	if err != nil {
 		return nil, fmt.Errorf("reading response: %w", err)
 	}
@@ -365,8 +437,24 @@ func parseUint(s string) (int, error) {
 // Upgrade performs the upgrade to the specified version.
 // If targetVersion is empty, it upgrades to the latest version.
 func (c *Client) Upgrade(ctx context.Context, currentVersion, targetVersion string, confirmDowngrade func() bool) (*UpgradeResult, error) {
+	return c.UpgradeWithProgress(ctx, currentVersion, targetVersion, confirmDowngrade, nil)
+}
+
+// UpgradeWithProgress performs the upgrade like Upgrade, calling progress, if
+// not nil, as each stage starts and while the binary downloads.
+// The downloaded binary must match its checksum and, in builds with a
+// SigningKey, the checksums must be signed with it. Nothing is installed
+// otherwise. The previous binary is kept at UpgradeResult.BackupPath.
+func (c *Client) UpgradeWithProgress(ctx context.Context, currentVersion, targetVersion string, confirmDowngrade func() bool, progress func(Progress)) (*UpgradeResult, error) {
+	report := func(p Progress) {
+		if progress != nil {
+			progress(p)
+		}
+	}
+
 	// If no target version specified, get the latest
 	if targetVersion == "" {
+		report(Progress{Stage: StageChecking})
 		latest, err := c.CheckLatestVersion(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("checking latest version: %w", err)
@@ -394,31 +482,48 @@ func (c *Client) Upgrade(ctx context.Context, currentVersion, targetVersion stri
 		return nil, fmt.Errorf("downgrade cancelled by user")
 	}
 
-	// Download checksums first
-	checksums, err := c.DownloadChecksums(ctx, targetVersion)
+	// Download checksums first, and their signature
+	report(Progress{Stage: StageDownloading})
+	checksumsData, err := c.downloadFile(ctx, GetChecksumsURL(targetVersion))
 	if err != nil {
-		return nil, err // DownloadChecksums already wraps the error
+		return nil, fmt.Errorf("downloading checksums: %w", err)
+	}
+	var signature []byte
+	if SigningKey != "" {
+		if signature, err = c.downloadFile(ctx, GetSignatureURL(targetVersion)); err != nil {
+			return nil, fmt.Errorf("%w: downloading signature: %v", ErrSignatureInvalid, err)
+		}
 	}
 
 	// Get expected checksum
-	expectedChecksum, err := GetExpectedChecksum(checksums, targetVersion, runtime.GOOS, runtime.GOARCH)
+	expectedChecksum, err := GetExpectedChecksum(ParseChecksums(string(checksumsData)), targetVersion, runtime.GOOS, runtime.GOARCH)
 	if err != nil {
 		return nil, err
 	}
 
 	// Download the binary
-	binaryData, err := c.DownloadBinary(ctx, targetVersion)
+	binaryData, err := c.downloadFileWithProgress(ctx, GetAssetURL(targetVersion, runtime.GOOS, runtime.GOARCH), func(read, total int64) {
+		report(Progress{Stage: StageDownloading, Downloaded: read, Total: total})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("downloading binary: %w", err)
 	}
 
-	// Verify checksum
+	// Verify the signature of the checksums, then the checksum of the binary
+	report(Progress{Stage: StageVerifying})
+	if SigningKey != "" {
+		if err := VerifySignature(checksumsData, signature, SigningKey); err != nil {
+			return nil, err
+		}
+	}
 	if !VerifyChecksum(binaryData, expectedChecksum) {
 		return nil, ErrChecksumMismatch
 	}
 
 	// Replace the binary
-	if err := ReplaceBinary(binaryData); err != nil {
+	report(Progress{Stage: StageInstalling})
+	backupPath, err := replaceExecutable(binaryData)
+	if err != nil {
 		return nil, err
 	}
 
@@ -426,6 +531,8 @@ func (c *Client) Upgrade(ctx context.Context, currentVersion, targetVersion stri
 		PreviousVersion: currentVersion,
 		NewVersion:      targetVersion,
 		IsDowngrade:     isDowngrade,
+		Signed:          SigningKey != "",
+		BackupPath:      backupPath,
 	}, nil
 }
 
@@ -435,22 +542,46 @@ func normalizeVersion(v string) string {
 }
 
 // ReplaceBinary atomically replaces the current executable with new binary data.
-// It preserves the original file's permissions.
+// It preserves the original file's permissions. The previous binary is kept
+// next to it with an .old suffix, so Rollback can restore it.
 func ReplaceBinary(newBinaryData []byte) error {
+	_, err := replaceExecutable(newBinaryData)
+	return err
+}
+
+// executablePath returns the path of the running executable, with symlinks resolved.
+func executablePath() (string, error) {
 	execPath, err := os.Executable()
 	if err != nil {
-		return fmt.Errorf("getting executable path: %w", err)
+		return "", fmt.Errorf("getting executable path: %w", err)
 	}
 
 	realPath, err := filepath.EvalSymlinks(execPath)
 	if err != nil {
 		realPath = execPath
 	}
+	return realPath, nil
+}
+
+// replaceExecutable replaces the current executable and returns where the
+// previous one is kept.
+func replaceExecutable(newBinaryData []byte) (string, error) {
+	realPath, err := executablePath()
+	if err != nil {
+		return "", err
+	}
+	return replaceBinaryAt(realPath, newBinaryData)
+}
 
+// replaceBinaryAt replaces the binary at realPath with new binary data and
+// returns the path the previous binary was moved to. If the new binary can't be
+// put in place, the previous one is moved back; a *RollbackError is returned if
+// that fails too.
+func replaceBinaryAt(realPath string, newBinaryData []byte) (string, error) {
 	// Get the current binary's permissions to preserve them
 	fileInfo, err := os.Stat(realPath)
 	if err != nil {
-		return fmt.Errorf("getting file info: %w", err)
+		return "", fmt.Errorf("getting file info: %w", err)
 	}
 	fileMode := fileInfo.Mode()
 
@@ -458,37 +589,76 @@ func ReplaceBinary(newBinaryData []byte) error {
 	tmpPath := realPath + ".new"
 	if err := os.WriteFile(tmpPath, newBinaryData, fileMode); err != nil {
 		if os.IsPermission(err) {
-			return ErrPermissionDenied
+			return "", ErrPermissionDenied
 		}
-		return fmt.Errorf("writing new binary: %w", err)
+		return "", fmt.Errorf("writing new binary: %w", err)
 	}
 
-	// 2. Rename current to .old
+	// 2. Rename current to .old, replacing the backup of an earlier upgrade
 	oldPath := realPath + ".old"
 	if err := os.Rename(realPath, oldPath); err != nil {
 		// Clean up temp file
 		os.Remove(tmpPath)
 		if os.IsPermission(err) {
-			return ErrPermissionDenied
+			return "", ErrPermissionDenied
 		}
-		return fmt.Errorf("backing up current binary: %w", err)
+		return "", fmt.Errorf("backing up current binary: %w", err)
 	}
 
 	// 3. Rename new to current
 	if err := os.Rename(tmpPath, realPath); err != nil {
-		// Try to restore old binary and clean up temp file
-		os.Rename(oldPath, realPath)
 		os.Remove(tmpPath) // Clean up temp file on rollback
+		// Try to restore old binary
+		if restoreErr := os.Rename(oldPath, realPath); restoreErr != nil {
+			return "", &RollbackError{Path: realPath, Backup: oldPath, Err: err}
+		}
 		if os.IsPermission(err) {
-			return ErrPermissionDenied
+			return "", ErrPermissionDenied
 		}
-		return fmt.Errorf("installing new binary: %w", err)
+		return "", fmt.Errorf("installing new binary: %w", err)
 	}
 
-	// 4. Delete old (best effort, don't fail if this doesn't work)
-	os.Remove(oldPath)
+	// 4. Keep old for Rollback
+	return oldPath, nil
+}
 
-	return nil
+// ErrNoBackup is returned by Rollback when there is no previous binary to restore.
+var ErrNoBackup = errors.New("no previous version to roll back to")
+
+// Rollback restores the binary that the last upgrade replaced, which is kept
+// next to the executable with an .old suffix. It returns the restored path.
+func Rollback() (string, error) {
+	realPath, err := executablePath()
+	if err != nil {
+		return "", err
+	}
+	return rollbackAt(realPath)
+}
+
+// rollbackAt restores the binary at realPath from realPath + ".old".
+func rollbackAt(realPath string) (string, error) {
+	oldPath := realPath + ".old"
+	if _, err := os.Stat(oldPath); os.IsNotExist(err) {
+		return "", ErrNoBackup
+	}
+
+	// Move the current binary aside first, so it can be put back if the restore fails
+	asidePath := realPath + ".rollback"
+	if err := os.Rename(realPath, asidePath); err != nil {
+		if os.IsPermission(err) {
+			return "", ErrPermissionDenied
+		}
+		return "", fmt.Errorf("moving current binary aside: %w", err)
+	}
+	if err := os.Rename(oldPath, realPath); err != nil {
+		os.Rename(asidePath, realPath)
+		if os.IsPermission(err) {
+			return "", ErrPermissionDenied
+		}
+		return "", fmt.Errorf("restoring previous binary: %w", err)
+	}
+	os.Remove(asidePath)
+	return realPath, nil
 }
 
 // GetGoInstallMessage returns the message to show users who installed via go install.
@@ -507,7 +677,7 @@ func GetBinaryFilename(version, goos, goarch string) string {
 	return fmt.Sprintf("termchess-%s-%s-%s", version, goos, goarch)
 }
 
-// Uninstall removes the TermChess binary and configuration directory.
+// Uninstall removes the TermChess binary and its configuration and data directories.
 // It returns an error if any removal operation fails.
 func Uninstall() error {
 	// Get executable path
@@ -522,11 +692,15 @@ func Uninstall() error {
 		realPath = execPath
 	}
 
-	// Get config directory
+	// Get config and data directories, which may be the same
 	configDir, err := config.GetConfigDir()
 	if err != nil {
 		return fmt.Errorf("getting config directory: %w", err)
 	}
+	dataDir, err := config.GetDataDir()
+	if err != nil {
+		return fmt.Errorf("getting data directory: %w", err)
+	}
 
 	// Remove the binary
 	if err := os.Remove(realPath); err != nil {
@@ -535,14 +709,22 @@ func Uninstall() error {
 		}
 		return fmt.Errorf("removing binary: %w", err)
 	}
+	// The binary kept by the last upgrade goes too
+	os.Remove(realPath + ".old")
 
-	// Remove config directory recursively
+	// Remove config and data directories recursively
 	if err := os.RemoveAll(configDir); err != nil {
 		if os.IsPermission(err) {
 			return ErrPermissionDenied
 		}
 		return fmt.Errorf("removing config directory: %w", err)
 	}
+	if err := os.RemoveAll(dataDir); err != nil {
+		if os.IsPermission(err) {
+			return ErrPermissionDenied
+		}
+		return fmt.Errorf("removing data directory: %w", err)
+	}
 
 	return nil
 }
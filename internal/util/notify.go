@@ -0,0 +1,51 @@
+package util
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+// lookPath and runCommand are variables so tests can stub out terminal-notifier.
+var (
+	lookPath   = exec.LookPath
+	runCommand = func(name string, args ...string) error {
+		return exec.Command(name, args...).Run()
+	}
+)
+
+// Notify shows a desktop notification with the given title and message.
+//
+// If terminal-notifier is installed (macOS), it is used to post the notification.
+// Otherwise the OSC 777 escape sequence is written to w (normally the terminal),
+// which terminals such as iTerm2, Kitty, WezTerm, foot and urxvt turn into a
+// desktop notification. Terminals without support ignore the sequence.
+//
+// Returns an error if terminal-notifier fails or the sequence cannot be written.
+func Notify(w io.Writer, title, message string) error {
+	if path, err := lookPath("terminal-notifier"); err == nil {
+		if err := runCommand(path, "-title", title, "-message", message); err != nil {
+			return fmt.Errorf("terminal-notifier failed: %w", err)
+		}
+		return nil
+	}
+
+	_, err := fmt.Fprintf(w, "\x1b]777;notify;%s;%s\x07", oscSafe(title), oscSafe(message))
+	return err
+}
+
+// oscSafe removes characters that would end or split an OSC 777 sequence:
+// control characters are dropped and ';' (the field separator) becomes ','.
+func oscSafe(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == ';':
+			return ','
+		case r < 0x20 || r == 0x7f:
+			return -1
+		default:
+			return r
+		}
+	}, s)
+}
@@ -0,0 +1,72 @@
+package util
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// stubNotifier replaces terminal-notifier lookups for the duration of a test.
+func stubNotifier(t *testing.T, installed bool, run func(name string, args ...string) error) {
+	t.Helper()
+	origLookPath, origRun := lookPath, runCommand
+	t.Cleanup(func() {
+		lookPath, runCommand = origLookPath, origRun
+	})
+
+	lookPath = func(file string) (string, error) {
+		if installed {
+			return "/usr/local/bin/" + file, nil
+		}
+		return "", errors.New("not found")
+	}
+	runCommand = run
+}
+
+func TestNotifyWritesOSC777(t *testing.T) {
+	stubNotifier(t, false, nil)
+
+	var buf bytes.Buffer
+	if err := Notify(&buf, "TermChess", "Bot played Nf3; your move\x07"); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	want := "\x1b]777;notify;TermChess;Bot played Nf3, your move\x07"
+	if buf.String() != want {
+		t.Errorf("Notify() wrote %q, want %q", buf.String(), want)
+	}
+}
+
+func TestNotifyUsesTerminalNotifier(t *testing.T) {
+	var gotName string
+	var gotArgs []string
+	stubNotifier(t, true, func(name string, args ...string) error {
+		gotName, gotArgs = name, args
+		return nil
+	})
+
+	var buf bytes.Buffer
+	if err := Notify(&buf, "TermChess", "Your move"); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	if gotName != "/usr/local/bin/terminal-notifier" {
+		t.Errorf("ran %q, want terminal-notifier", gotName)
+	}
+	if len(gotArgs) != 4 || gotArgs[1] != "TermChess" || gotArgs[3] != "Your move" {
+		t.Errorf("unexpected arguments %v", gotArgs)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no escape sequence when terminal-notifier is used, got %q", buf.String())
+	}
+}
+
+func TestNotifyTerminalNotifierError(t *testing.T) {
+	stubNotifier(t, true, func(name string, args ...string) error {
+		return errors.New("exit status 1")
+	})
+
+	if err := Notify(&bytes.Buffer{}, "TermChess", "Your move"); err == nil {
+		t.Error("expected an error when terminal-notifier fails")
+	}
+}